@@ -27,12 +27,28 @@ type AggLayerClientRecoveryQuerier interface {
 	GetLatestPendingCertificateHeader(ctx context.Context, networkID uint32) (*types.CertificateHeader, error)
 }
 
+// AggLayerClientLatestCertificateQuerier gets the latest settled and known (pending) certificates of a network,
+// cached for a short time, for the loops that check them periodically
+type AggLayerClientLatestCertificateQuerier interface {
+	GetLatestSettledCertificate(ctx context.Context, networkID uint32) (*types.CertificateHeader, error)
+	GetLatestKnownCertificate(ctx context.Context, networkID uint32) (*types.CertificateHeader, error)
+}
+
+// AggLayerClientCertificateHeightQuerier is implemented by the agglayer clients that can get the certificate
+// header of a network by its height. It's optional: with the agglayer versions that don't support it, the
+// headers are only queried by certificate ID
+type AggLayerClientCertificateHeightQuerier interface {
+	GetCertificateHeaderByHeight(ctx context.Context, networkID uint32,
+		height uint64) (*types.CertificateHeader, error)
+}
+
 // AgglayerClientInterface is the interface that defines the methods that the AggLayerClient will implement
 type AgglayerClientInterface interface {
 	SendCertificate(ctx context.Context, certificate *types.Certificate) (common.Hash, error)
 	GetCertificateHeader(ctx context.Context, certificateHash common.Hash) (*types.CertificateHeader, error)
 	AggLayerClientGetEpochConfiguration
 	AggLayerClientRecoveryQuerier
+	AggLayerClientLatestCertificateQuerier
 }
 
 // AggLayerClient is the client that will be used to interact with the AggLayer
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	node "buf.build/gen/go/agglayer/agglayer/grpc/go/agglayer/node/v1/nodev1grpc"
 	v1nodetypes "buf.build/gen/go/agglayer/agglayer/protocolbuffers/go/agglayer/node/types/v1"
@@ -26,6 +27,8 @@ type AgglayerGRPCClient struct {
 	networkStateService node.NodeStateServiceClient
 	cfgService          node.ConfigurationServiceClient
 	submissionService   node.CertificateSubmissionServiceClient
+	// latestCertificates is nil if the cache is disabled
+	latestCertificates *latestCertificateCache
 }
 
 // NewAggchainProofClient initializes a new AggchainProof instance
@@ -40,9 +43,20 @@ func NewAgglayerGRPCClient(cfg *aggkitgrpc.ClientConfig) (*AgglayerGRPCClient, e
 		networkStateService: node.NewNodeStateServiceClient(grpcClient.Conn()),
 		cfgService:          node.NewConfigurationServiceClient(grpcClient.Conn()),
 		submissionService:   node.NewCertificateSubmissionServiceClient(grpcClient.Conn()),
+		latestCertificates:  newLatestCertificateCache(DefaultLatestCertificateCacheTTL),
 	}, nil
 }
 
+// SetLatestCertificateCacheTTL sets how long GetLatestSettledCertificate and GetLatestKnownCertificate
+// cache the certificates (0 = disabled)
+func (a *AgglayerGRPCClient) SetLatestCertificateCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		a.latestCertificates = nil
+		return
+	}
+	a.latestCertificates = newLatestCertificateCache(ttl)
+}
+
 // GetEpochConfiguration returns the epoch configuration from the AggLayer
 func (a *AgglayerGRPCClient) GetEpochConfiguration(ctx context.Context) (*types.ClockConfiguration, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.cfg.RequestTimeout.Duration)
@@ -110,11 +124,13 @@ func (a *AgglayerGRPCClient) SendCertificate(ctx context.Context,
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to submit certificate: %w", aggkitgrpc.RepackGRPCErrorWithDetails(err))
 	}
+	// the latest known certificate of the network is the new one
+	a.latestCertificates.invalidate(certificate.NetworkID)
 
 	return common.BytesToHash(response.CertificateId.Value.Value), nil
 }
 
-// GetLatestPendingCertificateHeader returns the latest pending certificate header from the AggLayer
+// GetLatestSettledCertificateHeader returns the latest settled certificate header from the AggLayer
 func (a *AgglayerGRPCClient) GetLatestSettledCertificateHeader(
 	ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
 	ctx, cancel := context.WithTimeout(ctx, a.cfg.RequestTimeout.Duration)
@@ -156,6 +172,22 @@ func (a *AgglayerGRPCClient) GetLatestPendingCertificateHeader(
 	return convertProtoCertificateHeader(response.CertificateHeader), nil
 }
 
+// GetLatestSettledCertificate returns the latest settled certificate header of the network, cached for a
+// short TTL (see SetLatestCertificateCacheTTL)
+func (a *AgglayerGRPCClient) GetLatestSettledCertificate(
+	ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
+	return a.latestCertificates.get(ctx, latestCertificateKey{networkID: networkID, settled: true},
+		a.GetLatestSettledCertificateHeader)
+}
+
+// GetLatestKnownCertificate returns the latest certificate header of the network known by the AggLayer
+// (the latest pending one), cached for a short TTL (see SetLatestCertificateCacheTTL)
+func (a *AgglayerGRPCClient) GetLatestKnownCertificate(
+	ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
+	return a.latestCertificates.get(ctx, latestCertificateKey{networkID: networkID, settled: false},
+		a.GetLatestPendingCertificateHeader)
+}
+
 // GetCertificateHeader returns the certificate header from the AggLayer for the given certificate ID
 func (a *AgglayerGRPCClient) GetCertificateHeader(
 	ctx context.Context, certificateID common.Hash) (*types.CertificateHeader, error) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	v1nodetypes "buf.build/gen/go/agglayer/agglayer/protocolbuffers/go/agglayer/node/types/v1"
 	node "buf.build/gen/go/agglayer/agglayer/protocolbuffers/go/agglayer/node/v1"
@@ -459,6 +460,133 @@ func TestSendCertificate(t *testing.T) {
 	})
 }
 
+func TestGetLatestCertificateCached(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	networkID := uint32(1)
+	response := func(height uint64) *node.GetLatestCertificateHeaderResponse {
+		return &node.GetLatestCertificateHeaderResponse{
+			CertificateHeader: &v1nodetypes.CertificateHeader{
+				NetworkId: networkID,
+				Height:    height,
+				CertificateId: &v1nodetypes.CertificateId{
+					Value: &v1types.FixedBytes32{Value: common.HexToHash("0x01").Bytes()},
+				},
+				NewLocalExitRoot: &v1types.FixedBytes32{Value: common.HexToHash("0x02").Bytes()},
+				Metadata:         &v1types.FixedBytes32{Value: common.HexToHash("0x03").Bytes()},
+			},
+		}
+	}
+	settledRequest := &node.GetLatestCertificateHeaderRequest{
+		NetworkId: networkID,
+		Type:      node.LatestCertificateRequestType_LATEST_CERTIFICATE_REQUEST_TYPE_SETTLED,
+	}
+	pendingRequest := &node.GetLatestCertificateHeaderRequest{
+		NetworkId: networkID,
+		Type:      node.LatestCertificateRequestType_LATEST_CERTIFICATE_REQUEST_TYPE_PENDING,
+	}
+	newClient := func(t *testing.T) (*AgglayerGRPCClient, *mocks.NodeStateServiceClient, *time.Time) {
+		t.Helper()
+		networkStateServiceMock := mocks.NewNodeStateServiceClient(t)
+		client := &AgglayerGRPCClient{
+			networkStateService: networkStateServiceMock,
+			cfg:                 aggkitgrpc.DefaultConfig(),
+		}
+		client.SetLatestCertificateCacheTTL(DefaultLatestCertificateCacheTTL)
+		now := time.Now()
+		client.latestCertificates.now = func() time.Time { return now }
+		return client, networkStateServiceMock, &now
+	}
+
+	t.Run("the certificates are cached until the TTL expires", func(t *testing.T) {
+		t.Parallel()
+
+		client, networkStateServiceMock, now := newClient(t)
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, settledRequest).
+			Return(response(10), nil).Once()
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, pendingRequest).
+			Return(response(11), nil).Once()
+
+		for range 2 {
+			settled, err := client.GetLatestSettledCertificate(ctx, networkID)
+			require.NoError(t, err)
+			require.Equal(t, uint64(10), settled.Height)
+			known, err := client.GetLatestKnownCertificate(ctx, networkID)
+			require.NoError(t, err)
+			require.Equal(t, uint64(11), known.Height)
+		}
+
+		*now = now.Add(DefaultLatestCertificateCacheTTL)
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, settledRequest).
+			Return(response(11), nil).Once()
+		settled, err := client.GetLatestSettledCertificate(ctx, networkID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), settled.Height)
+	})
+
+	t.Run("the errors are not cached", func(t *testing.T) {
+		t.Parallel()
+
+		client, networkStateServiceMock, _ := newClient(t)
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, pendingRequest).
+			Return(nil, errors.New("test error")).Once()
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, pendingRequest).
+			Return(&node.GetLatestCertificateHeaderResponse{}, nil).Once()
+
+		_, err := client.GetLatestKnownCertificate(ctx, networkID)
+		require.ErrorContains(t, err, "test error")
+		for range 2 {
+			known, err := client.GetLatestKnownCertificate(ctx, networkID)
+			require.NoError(t, err)
+			require.Nil(t, known)
+		}
+	})
+
+	t.Run("sending a certificate clears the cache of the network", func(t *testing.T) {
+		t.Parallel()
+
+		client, networkStateServiceMock, _ := newClient(t)
+		submissionServiceMock := mocks.NewCertificateSubmissionServiceClient(t)
+		client.submissionService = submissionServiceMock
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, pendingRequest).
+			Return(response(10), nil).Once()
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, pendingRequest).
+			Return(response(11), nil).Once()
+		submissionServiceMock.EXPECT().SubmitCertificate(mock.Anything, mock.Anything).Return(
+			&node.SubmitCertificateResponse{CertificateId: &v1nodetypes.CertificateId{
+				Value: &v1types.FixedBytes32{Value: common.HexToHash("0x04").Bytes()},
+			}}, nil).Once()
+
+		known, err := client.GetLatestKnownCertificate(ctx, networkID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), known.Height)
+		_, err = client.SendCertificate(ctx, &types.Certificate{
+			NetworkID:    networkID,
+			Height:       11,
+			AggchainData: &types.AggchainDataSignature{Signature: []byte{0x01}},
+		})
+		require.NoError(t, err)
+		known, err = client.GetLatestKnownCertificate(ctx, networkID)
+		require.NoError(t, err)
+		require.Equal(t, uint64(11), known.Height)
+	})
+
+	t.Run("cache disabled", func(t *testing.T) {
+		t.Parallel()
+
+		client, networkStateServiceMock, _ := newClient(t)
+		client.SetLatestCertificateCacheTTL(0)
+		networkStateServiceMock.EXPECT().GetLatestCertificateHeader(mock.Anything, settledRequest).
+			Return(response(10), nil).Twice()
+
+		for range 2 {
+			_, err := client.GetLatestSettledCertificate(ctx, networkID)
+			require.NoError(t, err)
+		}
+	})
+}
+
 func TestLeafTypeToProto(t *testing.T) {
 	t.Parallel()
 
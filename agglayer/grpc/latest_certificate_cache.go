@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/agglayer/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultLatestCertificateCacheTTL is how long the latest settled and known certificates of a network are cached
+const DefaultLatestCertificateCacheTTL = 2 * time.Second
+
+// latestCertificateKey identifies a cached latest certificate: settled or known (pending) of a network
+type latestCertificateKey struct {
+	networkID uint32
+	settled   bool
+}
+
+type cachedLatestCertificate struct {
+	header    *types.CertificateHeader
+	expiresAt time.Time
+}
+
+// latestCertificateCache caches the latest settled and known certificates of each network for a short TTL,
+// so the recovery, the audits and the health checks that query them in their loops don't send the same
+// request again and again. The concurrent requests of the same certificate are also sent only once
+type latestCertificateCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	entries  map[latestCertificateKey]cachedLatestCertificate
+	inFlight singleflight.Group
+	now      func() time.Time
+}
+
+func newLatestCertificateCache(ttl time.Duration) *latestCertificateCache {
+	return &latestCertificateCache{
+		ttl:     ttl,
+		entries: make(map[latestCertificateKey]cachedLatestCertificate),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached certificate if it has not expired, otherwise it's requested and cached.
+// A nil cache or a TTL of 0 always requests it
+func (c *latestCertificateCache) get(ctx context.Context, key latestCertificateKey,
+	request func(ctx context.Context, networkID uint32) (*types.CertificateHeader, error),
+) (*types.CertificateHeader, error) {
+	if c == nil || c.ttl <= 0 {
+		return request(ctx, key.networkID)
+	}
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(cached.expiresAt) {
+		return copyCertificateHeader(cached.header), nil
+	}
+
+	result, err, _ := c.inFlight.Do(fmt.Sprintf("%d/%t", key.networkID, key.settled), func() (any, error) {
+		header, err := request(ctx, key.networkID)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.entries[key] = cachedLatestCertificate{header: header, expiresAt: c.now().Add(c.ttl)}
+		c.mu.Unlock()
+		return header, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	header, ok := result.(*types.CertificateHeader)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of the cached certificate header", result)
+	}
+	return copyCertificateHeader(header), nil
+}
+
+// invalidate removes the cached certificates of the network, e.g. after sending a new one
+func (c *latestCertificateCache) invalidate(networkID uint32) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, latestCertificateKey{networkID: networkID, settled: true})
+	delete(c.entries, latestCertificateKey{networkID: networkID, settled: false})
+}
+
+// copyCertificateHeader returns a shallow copy of the header, so the callers can't modify the cached one
+func copyCertificateHeader(header *types.CertificateHeader) *types.CertificateHeader {
+	if header == nil {
+		return nil
+	}
+	headerCopy := *header
+	return &headerCopy
+}
@@ -0,0 +1,43 @@
+package agglayer
+
+import (
+	"errors"
+	"strings"
+
+	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// maintenanceSignal is the text of the messages and the error details (the reason of the ErrorInfo)
+// with which the agglayer reports an explicit maintenance
+const maintenanceSignal = "maintenance"
+
+// ErrAgglayerMaintenance is returned when the agglayer is in maintenance or temporarily unavailable
+var ErrAgglayerMaintenance = errors.New("agglayer in maintenance")
+
+// IsMaintenanceError returns true if the error is a signal of the agglayer that it's in maintenance or
+// temporarily unavailable: a gRPC Unavailable error, or an error with maintenance in its message or
+// details. The request can be retried later, so it's not a failure of the request itself
+func IsMaintenanceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrAgglayerMaintenance) {
+		return true
+	}
+	var grpcErr aggkitgrpc.GRPCError
+	if errors.As(err, &grpcErr) {
+		if grpcErr.Code == codes.Unavailable {
+			return true
+		}
+		if strings.Contains(strings.ToLower(grpcErr.Message), maintenanceSignal) {
+			return true
+		}
+		for _, detail := range grpcErr.Details {
+			if strings.Contains(strings.ToLower(detail), maintenanceSignal) {
+				return true
+			}
+		}
+	}
+	return false
+}
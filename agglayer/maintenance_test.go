@@ -0,0 +1,36 @@
+package agglayer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestIsMaintenanceError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil", err: nil, expected: false},
+		{name: "sentinel", err: fmt.Errorf("wrapped: %w", ErrAgglayerMaintenance), expected: true},
+		{name: "unavailable", err: fmt.Errorf("failed to submit certificate: %w",
+			aggkitgrpc.GRPCError{Code: codes.Unavailable, Message: "connection refused"}), expected: true},
+		{name: "maintenance message", err: aggkitgrpc.GRPCError{Code: codes.FailedPrecondition,
+			Message: "the agglayer is under Maintenance"}, expected: true},
+		{name: "maintenance reason", err: aggkitgrpc.GRPCError{Code: codes.Internal, Message: "internal",
+			Details: []string{"Reason: MAINTENANCE, Domain: agglayer. "}}, expected: true},
+		{name: "other grpc error", err: aggkitgrpc.GRPCError{Code: codes.InvalidArgument,
+			Message: "invalid certificate"}, expected: false},
+		{name: "other error", err: errors.New("maintenance"), expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, IsMaintenanceError(tt.err))
+		})
+	}
+}
@@ -142,6 +142,65 @@ func (_c *AgglayerClientMock_GetEpochConfiguration_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// GetLatestKnownCertificate provides a mock function with given fields: ctx, networkID
+func (_m *AgglayerClientMock) GetLatestKnownCertificate(ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
+	ret := _m.Called(ctx, networkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestKnownCertificate")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*types.CertificateHeader, error)); ok {
+		return rf(ctx, networkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) *types.CertificateHeader); ok {
+		r0 = rf(ctx, networkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = rf(ctx, networkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AgglayerClientMock_GetLatestKnownCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestKnownCertificate'
+type AgglayerClientMock_GetLatestKnownCertificate_Call struct {
+	*mock.Call
+}
+
+// GetLatestKnownCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - networkID uint32
+func (_e *AgglayerClientMock_Expecter) GetLatestKnownCertificate(ctx interface{}, networkID interface{}) *AgglayerClientMock_GetLatestKnownCertificate_Call {
+	return &AgglayerClientMock_GetLatestKnownCertificate_Call{Call: _e.mock.On("GetLatestKnownCertificate", ctx, networkID)}
+}
+
+func (_c *AgglayerClientMock_GetLatestKnownCertificate_Call) Run(run func(ctx context.Context, networkID uint32)) *AgglayerClientMock_GetLatestKnownCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32))
+	})
+	return _c
+}
+
+func (_c *AgglayerClientMock_GetLatestKnownCertificate_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AgglayerClientMock_GetLatestKnownCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AgglayerClientMock_GetLatestKnownCertificate_Call) RunAndReturn(run func(context.Context, uint32) (*types.CertificateHeader, error)) *AgglayerClientMock_GetLatestKnownCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLatestPendingCertificateHeader provides a mock function with given fields: ctx, networkID
 func (_m *AgglayerClientMock) GetLatestPendingCertificateHeader(ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
 	ret := _m.Called(ctx, networkID)
@@ -201,6 +260,65 @@ func (_c *AgglayerClientMock_GetLatestPendingCertificateHeader_Call) RunAndRetur
 	return _c
 }
 
+// GetLatestSettledCertificate provides a mock function with given fields: ctx, networkID
+func (_m *AgglayerClientMock) GetLatestSettledCertificate(ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
+	ret := _m.Called(ctx, networkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestSettledCertificate")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) (*types.CertificateHeader, error)); ok {
+		return rf(ctx, networkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32) *types.CertificateHeader); ok {
+		r0 = rf(ctx, networkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = rf(ctx, networkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AgglayerClientMock_GetLatestSettledCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestSettledCertificate'
+type AgglayerClientMock_GetLatestSettledCertificate_Call struct {
+	*mock.Call
+}
+
+// GetLatestSettledCertificate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - networkID uint32
+func (_e *AgglayerClientMock_Expecter) GetLatestSettledCertificate(ctx interface{}, networkID interface{}) *AgglayerClientMock_GetLatestSettledCertificate_Call {
+	return &AgglayerClientMock_GetLatestSettledCertificate_Call{Call: _e.mock.On("GetLatestSettledCertificate", ctx, networkID)}
+}
+
+func (_c *AgglayerClientMock_GetLatestSettledCertificate_Call) Run(run func(ctx context.Context, networkID uint32)) *AgglayerClientMock_GetLatestSettledCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32))
+	})
+	return _c
+}
+
+func (_c *AgglayerClientMock_GetLatestSettledCertificate_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AgglayerClientMock_GetLatestSettledCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AgglayerClientMock_GetLatestSettledCertificate_Call) RunAndReturn(run func(context.Context, uint32) (*types.CertificateHeader, error)) *AgglayerClientMock_GetLatestSettledCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLatestSettledCertificateHeader provides a mock function with given fields: ctx, networkID
 func (_m *AgglayerClientMock) GetLatestSettledCertificateHeader(ctx context.Context, networkID uint32) (*types.CertificateHeader, error) {
 	ret := _m.Called(ctx, networkID)
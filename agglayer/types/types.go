@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/agglayer/aggkit/bridgesync"
@@ -1020,6 +1021,10 @@ func (c *CertificateHeader) UnmarshalJSON(data []byte) error {
 		if err := c.Status.UnmarshalJSON([]byte(status)); err != nil {
 			return err
 		}
+	case float64: // numeric status, as encoded by CertificateHeader
+		if err := c.Status.UnmarshalJSON([]byte(strconv.FormatFloat(status, 'f', -1, 64))); err != nil {
+			return err
+		}
 	case map[string]interface{}: // certificate has errors
 		inErrMap, err := convertMapValue[map[string]interface{}](status, "InError")
 		if err != nil {
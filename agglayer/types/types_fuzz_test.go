@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// The fuzz targets check that the JSON encoding of the types sent to and received from the agglayer is
+// stable: a decoded value encodes to a JSON that decodes to the same value, and its hashes don't change.
+// Run them with: make test-fuzz
+
+func FuzzCertificateJSON(f *testing.F) {
+	for _, seed := range []string{fullCertificateJSON, expectedSignedCertificateEmptyMetadataJSON,
+		expectedSignedCertificateMetadataJSON, `{}`, `{"bridge_exits":[{"amount":"-1"}]}`} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var cert Certificate
+		if err := json.Unmarshal(data, &cert); err != nil {
+			return
+		}
+		encoded, err := json.Marshal(&cert)
+		require.NoError(t, err)
+
+		var decoded Certificate
+		require.NoError(t, json.Unmarshal(encoded, &decoded), "re-decoding %s", encoded)
+		reencoded, err := json.Marshal(&decoded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(encoded), string(reencoded))
+
+		requireSameHash(t, cert.Hash, decoded.Hash)
+		requireSameHash(t, cert.PPHashToSign, decoded.PPHashToSign)
+		requireSameHash(t, cert.FEPHashToSign, decoded.FEPHashToSign)
+	})
+}
+
+func FuzzImportedBridgeExitJSON(f *testing.F) {
+	var cert Certificate
+	require.NoError(f, json.Unmarshal([]byte(fullCertificateJSON), &cert))
+	for _, importedBridgeExit := range cert.ImportedBridgeExits {
+		seed, err := json.Marshal(importedBridgeExit)
+		require.NoError(f, err)
+		f.Add(seed)
+	}
+	f.Add([]byte(`{"bridge_exit":null,"claim_data":null,"global_index":null}`))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var importedBridgeExit ImportedBridgeExit
+		if err := json.Unmarshal(data, &importedBridgeExit); err != nil {
+			return
+		}
+		encoded, err := json.Marshal(&importedBridgeExit)
+		require.NoError(t, err)
+
+		var decoded ImportedBridgeExit
+		require.NoError(t, json.Unmarshal(encoded, &decoded), "re-decoding %s", encoded)
+		reencoded, err := json.Marshal(&decoded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(encoded), string(reencoded))
+
+		requireSameHash(t, importedBridgeExit.Hash, decoded.Hash)
+	})
+}
+
+func FuzzCertificateHeaderJSON(f *testing.F) {
+	for _, seed := range []string{
+		`{"network_id":1,"height":2,"epoch_number":null,"certificate_index":null,"certificate_id":"0x0000000000000000000000000000000000000000000000000000000000000001","new_local_exit_root":"0x0000000000000000000000000000000000000000000000000000000000000002","status":"Pending","metadata":"0x0000000000000000000000000000000000000000000000000000000000000000"}`,
+		`{"network_id":1,"height":2,"epoch_number":3,"certificate_index":4,"status":"Settled","settlement_tx_hash":"0x0000000000000000000000000000000000000000000000000000000000000003"}`,
+		`{"network_id":1,"height":2,"status":{"InError":{"error":{"ProofVerificationFailed":{"Plonk":"the proof verification failed"}}}}}`,
+		`{"status":{"InError":{}}}`,
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var header CertificateHeader
+		if err := json.Unmarshal(data, &header); err != nil {
+			return
+		}
+		// the agglayer errors are not encoded, so only the re-encoding must be stable
+		encoded, err := json.Marshal(&header)
+		require.NoError(t, err)
+
+		var decoded CertificateHeader
+		require.NoError(t, json.Unmarshal(encoded, &decoded), "re-decoding %s", encoded)
+		reencoded, err := json.Marshal(&decoded)
+		require.NoError(t, err)
+		require.JSONEq(t, string(encoded), string(reencoded))
+		require.Equal(t, header.ID(), decoded.ID())
+	})
+}
+
+// requireSameHash checks that the hash of the decoded and the re-decoded values is the same. The values
+// without the fields required to compute the hash (rejected by the agglayer) panic, and they must panic
+// in both cases
+func requireSameHash(t *testing.T, hashFn, decodedHashFn func() common.Hash) {
+	t.Helper()
+
+	hash, ok := tryHash(hashFn)
+	decodedHash, decodedOk := tryHash(decodedHashFn)
+	require.Equal(t, ok, decodedOk)
+	require.Equal(t, hash, decodedHash)
+}
+
+func tryHash(hashFn func() common.Hash) (hash common.Hash, ok bool) {
+	defer func() {
+		if recover() != nil {
+			hash, ok = common.Hash{}, false
+		}
+	}()
+	return hashFn(), true
+}
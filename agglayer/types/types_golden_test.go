@@ -0,0 +1,89 @@
+package types
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	goldenVectorsFile = "testdata/golden_vectors.json"
+	// goldenVectorsEnv is the env var with the path of additional golden vectors, e.g. the vectors exported
+	// by the Rust agglayer for a new release, to check them without adding them to the repo
+	goldenVectorsEnv = "AGGLAYER_GOLDEN_VECTORS"
+)
+
+// goldenVector is a certificate and its hashes computed by the Rust agglayer. The empty hashes are not
+// checked, so a vector can include only some of them
+type goldenVector struct {
+	Name                     string          `json:"name"`
+	Certificate              json.RawMessage `json:"certificate"`
+	Hash                     *common.Hash    `json:"hash,omitempty"`
+	PPHashToSign             *common.Hash    `json:"pp_hash_to_sign,omitempty"`
+	FEPHashToSign            *common.Hash    `json:"fep_hash_to_sign,omitempty"`
+	BridgeExitHashes         []common.Hash   `json:"bridge_exit_hashes,omitempty"`
+	ImportedBridgeExitHashes []common.Hash   `json:"imported_bridge_exit_hashes,omitempty"`
+}
+
+func loadGoldenVectors(t *testing.T, path string) []goldenVector {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	require.NoError(t, err)
+	var file struct {
+		Vectors []goldenVector `json:"vectors"`
+	}
+	require.NoError(t, json.Unmarshal(data, &file), "decoding %s", path)
+	require.NotEmpty(t, file.Vectors, "no golden vectors in %s", path)
+	return file.Vectors
+}
+
+// TestGoldenVectors compares the hashes of the certificates with the ones computed by the Rust agglayer,
+// so a divergence in the serialization or the hashing is caught before it's deployed
+func TestGoldenVectors(t *testing.T) {
+	vectors := loadGoldenVectors(t, goldenVectorsFile)
+	if path := os.Getenv(goldenVectorsEnv); path != "" {
+		vectors = append(vectors, loadGoldenVectors(t, path)...)
+	}
+
+	for _, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			var cert Certificate
+			require.NoError(t, json.Unmarshal(vector.Certificate, &cert))
+
+			if vector.Hash != nil {
+				require.Equal(t, *vector.Hash, cert.Hash(), "Hash")
+			}
+			if vector.PPHashToSign != nil {
+				require.Equal(t, *vector.PPHashToSign, cert.PPHashToSign(), "PPHashToSign")
+			}
+			if vector.FEPHashToSign != nil {
+				require.Equal(t, *vector.FEPHashToSign, cert.FEPHashToSign(), "FEPHashToSign")
+			}
+			if len(vector.BridgeExitHashes) > 0 {
+				require.Len(t, cert.BridgeExits, len(vector.BridgeExitHashes))
+				for i, bridgeExit := range cert.BridgeExits {
+					require.Equal(t, vector.BridgeExitHashes[i], bridgeExit.Hash(), "bridge exit %d", i)
+				}
+			}
+			if len(vector.ImportedBridgeExitHashes) > 0 {
+				require.Len(t, cert.ImportedBridgeExits, len(vector.ImportedBridgeExitHashes))
+				for i, importedBridgeExit := range cert.ImportedBridgeExits {
+					require.Equal(t, vector.ImportedBridgeExitHashes[i], importedBridgeExit.Hash(),
+						"imported bridge exit %d", i)
+				}
+			}
+
+			// the hashes don't change after a JSON round-trip
+			encoded, err := json.Marshal(&cert)
+			require.NoError(t, err)
+			var decoded Certificate
+			require.NoError(t, json.Unmarshal(encoded, &decoded))
+			require.Equal(t, cert.Hash(), decoded.Hash())
+		})
+	}
+}
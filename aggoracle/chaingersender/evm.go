@@ -2,6 +2,7 @@ package chaingersender
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/0xPolygon/zkevm-ethtx-manager/ethtxmanager"
 	ethtxtypes "github.com/0xPolygon/zkevm-ethtx-manager/types"
 	"github.com/agglayer/aggkit/aggoracle/types"
+	"github.com/agglayer/aggkit/balancemonitor"
 	cfgtypes "github.com/agglayer/aggkit/config/types"
 	"github.com/agglayer/aggkit/log"
 	aggkittypes "github.com/agglayer/aggkit/types"
@@ -24,6 +26,10 @@ type EVMConfig struct {
 	GasOffset            uint64              `mapstructure:"GasOffset"`
 	WaitPeriodMonitorTx  cfgtypes.Duration   `mapstructure:"WaitPeriodMonitorTx"`
 	EthTxManager         ethtxmanager.Config `mapstructure:"EthTxManager"`
+	// Relay injects the GERs through a relay service instead of the EthTxManager
+	Relay RelayConfig `mapstructure:"Relay"`
+	// BalanceMonitor alerts when the balance of the sender account of the EthTxManager is low
+	BalanceMonitor balancemonitor.Config `mapstructure:"BalanceMonitor"`
 }
 
 type EVMChainGERSender struct {
@@ -33,9 +39,12 @@ type EVMChainGERSender struct {
 	l2GERManagerAddr common.Address
 	l2GERManagerAbi  *abi.ABI
 
+	// ethTxMan is nil if the GERs are only injected through the relay
 	ethTxMan            types.EthTxManager
 	gasOffset           uint64
 	waitPeriodMonitorTx time.Duration
+	// relay is nil if the relay is disabled
+	relay *relayClient
 }
 
 func NewEVMChainGERSender(
@@ -45,15 +54,39 @@ func NewEVMChainGERSender(
 	ethTxMan types.EthTxManager,
 	gasOffset uint64,
 	waitPeriodMonitorTx time.Duration,
+	relayCfg RelayConfig,
 ) (*EVMChainGERSender, error) {
+	if err := relayCfg.Validate(); err != nil {
+		return nil, err
+	}
+	if ethTxMan == nil && (!relayCfg.Enabled || relayCfg.FallbackToDirect) {
+		return nil, errors.New("an EthTxManager is required unless the GERs are only injected through the relay")
+	}
+
 	l2GERManager, err := globalexitrootmanagerl2sovereignchain.NewGlobalexitrootmanagerl2sovereignchain(
 		l2GERManagerAddr, l2Client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create binding for GER L2 manager (SC address: %s): %w", l2GERManagerAddr, err)
 	}
 
-	if err := validateGERSender(ethTxMan.From(), l2GERManager); err != nil {
-		return nil, err
+	if ethTxMan != nil {
+		if err := validateGERSender(ethTxMan.From(), l2GERManager); err != nil {
+			return nil, err
+		}
+	}
+
+	var relay *relayClient
+	if relayCfg.Enabled {
+		l2RPC, ok := l2Client.(aggkittypes.RPCClienter)
+		if !ok {
+			return nil, errRelayNoReceiptClient
+		}
+		if relayCfg.RelayerAddr != (common.Address{}) {
+			if err := validateGERSender(relayCfg.RelayerAddr, l2GERManager); err != nil {
+				return nil, fmt.Errorf("relay: %w", err)
+			}
+		}
+		relay = newRelayClient(logger, relayCfg, l2RPC)
 	}
 
 	l2GERAbi, err := globalexitrootmanagerl2sovereignchain.Globalexitrootmanagerl2sovereignchainMetaData.GetAbi()
@@ -69,6 +102,7 @@ func NewEVMChainGERSender(
 		ethTxMan:            ethTxMan,
 		gasOffset:           gasOffset,
 		waitPeriodMonitorTx: waitPeriodMonitorTx,
+		relay:               relay,
 	}, nil
 }
 
@@ -97,15 +131,59 @@ func (c *EVMChainGERSender) IsGERInjected(ger common.Hash) (bool, error) {
 	return gerIndex.Cmp(common.Big0) == 1, nil
 }
 
+// InjectGER injects the GER through the relay (if enabled) or with the EthTxManager. If the relay
+// fails and FallbackToDirect is enabled, the GER is injected with the EthTxManager
 func (c *EVMChainGERSender) InjectGER(ctx context.Context, ger common.Hash) error {
-	ticker := time.NewTicker(c.waitPeriodMonitorTx)
-	defer ticker.Stop()
-
 	updateGERTxInput, err := c.l2GERManagerAbi.Pack(insertGERFuncName, ger)
 	if err != nil {
 		return err
 	}
 
+	if c.relay == nil {
+		return c.injectGERDirect(ctx, updateGERTxInput)
+	}
+	err = c.injectGERByRelay(ctx, ger, updateGERTxInput)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		c.logger.Infof("context cancelled")
+		return nil
+	}
+	if c.ethTxMan == nil {
+		return err
+	}
+	// the relayed tx could be mined after the timeout, so it's only sent again if the GER is not injected
+	injected, injectedErr := c.IsGERInjected(ger)
+	if injectedErr == nil && injected {
+		c.logger.Infof("GER %s injected by the relayed tx", ger.Hex())
+		return nil
+	}
+	c.logger.Warnf("failed to inject GER %s through the relay, falling back to direct submission: %v", ger.Hex(), err)
+	return c.injectGERDirect(ctx, updateGERTxInput)
+}
+
+// injectGERByRelay sends the GER injection tx through the relay and waits for its receipt
+func (c *EVMChainGERSender) injectGERByRelay(ctx context.Context, ger common.Hash, txInput []byte) error {
+	txHash, err := c.relay.sendTx(ctx, c.l2GERManagerAddr, txInput)
+	if err != nil {
+		return fmt.Errorf("failed to relay inject GER %s tx: %w", ger.Hex(), err)
+	}
+	c.logger.Debugf("inject GER %s tx relayed: %s", ger.Hex(), txHash.Hex())
+
+	receipt, err := c.relay.waitReceipt(ctx, txHash, c.waitPeriodMonitorTx)
+	if err != nil {
+		return fmt.Errorf("relayed inject GER %s tx: %w", ger.Hex(), err)
+	}
+	c.logger.Debugf("relayed inject GER tx %s was successfully mined at block %d", txHash.Hex(), receipt.BlockNumber)
+	return nil
+}
+
+// injectGERDirect sends the GER injection tx with the EthTxManager and waits for it to be mined
+func (c *EVMChainGERSender) injectGERDirect(ctx context.Context, updateGERTxInput []byte) error {
+	ticker := time.NewTicker(c.waitPeriodMonitorTx)
+	defer ticker.Stop()
+
 	id, err := c.ethTxMan.Add(ctx, &c.l2GERManagerAddr, common.Big0, updateGERTxInput, c.gasOffset, nil)
 	if err != nil {
 		return err
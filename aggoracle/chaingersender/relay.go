@@ -0,0 +1,166 @@
+package chaingersender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	cfgtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultRelayMethod     = "relay_sendTransaction"
+	receiptRPCMethod       = "eth_getTransactionReceipt"
+	defaultRelayReqTimeout = 10 * time.Second
+)
+
+var (
+	errRelayTxReverted      = errors.New("relayed tx reverted")
+	errRelayReceiptTimeout  = errors.New("timeout waiting for the receipt of the relayed tx")
+	errRelayNoReceiptClient = errors.New("the L2 client doesn't support RPC calls to track the receipts")
+)
+
+// RelayConfig is the configuration to inject the GERs through a relay service (e.g. a custodial
+// relayer API or a private RPC) that signs and submits the transactions, instead of a local key
+type RelayConfig struct {
+	// Enabled sends the GER injection transactions through the relay service
+	Enabled bool `mapstructure:"Enabled"`
+	// URL is the JSON-RPC endpoint of the relay service
+	URL string `mapstructure:"URL"`
+	// Method is the JSON-RPC method that relays a transaction. Its only param is an object with
+	// the fields "to" and "data", and it returns the hash of the submitted transaction
+	Method string `mapstructure:"Method"`
+	// HTTPHeaders are added to the requests to the relay service (e.g. an API key)
+	HTTPHeaders map[string]string `mapstructure:"HTTPHeaders"`
+	// RelayerAddr is the address that sends the transactions relayed, used to check that it's
+	// allowed to update the GERs. If it's empty it's not checked
+	RelayerAddr common.Address `mapstructure:"RelayerAddr"`
+	// RequestTimeout is the timeout of the requests to the relay service
+	RequestTimeout cfgtypes.Duration `mapstructure:"RequestTimeout"`
+	// ReceiptTimeout is the time to wait for the receipt of a relayed transaction
+	ReceiptTimeout cfgtypes.Duration `mapstructure:"ReceiptTimeout"`
+	// FallbackToDirect injects the GER with the EthTxManager (local key) if the relay fails
+	FallbackToDirect bool `mapstructure:"FallbackToDirect"`
+}
+
+// Validate checks the relay config
+func (c RelayConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.URL == "" {
+		return errors.New("relay: URL is required")
+	}
+	if c.ReceiptTimeout.Duration <= 0 {
+		return errors.New("relay: ReceiptTimeout must be greater than 0")
+	}
+	return nil
+}
+
+// relayTxRequest is the param of the relay method
+type relayTxRequest struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// relayClient submits transactions through a relay service and tracks their receipts on L2
+type relayClient struct {
+	logger     *log.Logger
+	cfg        RelayConfig
+	httpClient *http.Client
+	// l2RPC is used to query the receipts of the relayed transactions
+	l2RPC aggkittypes.RPCClienter
+}
+
+func newRelayClient(logger *log.Logger, cfg RelayConfig, l2RPC aggkittypes.RPCClienter) *relayClient {
+	if cfg.Method == "" {
+		cfg.Method = defaultRelayMethod
+	}
+	timeout := cfg.RequestTimeout.Duration
+	if timeout <= 0 {
+		timeout = defaultRelayReqTimeout
+	}
+	return &relayClient{
+		logger:     logger,
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: timeout},
+		l2RPC:      l2RPC,
+	}
+}
+
+// sendTx relays the transaction and returns its hash
+func (r *relayClient) sendTx(ctx context.Context, to common.Address, data []byte) (common.Hash, error) {
+	httpReq, err := rpc.BuildJsonHTTPRequest(ctx, r.cfg.URL, r.cfg.Method, relayTxRequest{To: to, Data: data})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("relay: failed to build the request: %w", err)
+	}
+	for key, value := range r.cfg.HTTPHeaders {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpRes, err := r.httpClient.Do(httpReq)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("relay: request failed: %w", err)
+	}
+	defer httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK {
+		return common.Hash{}, fmt.Errorf("relay: invalid status code, expected: %d, found: %d",
+			http.StatusOK, httpRes.StatusCode)
+	}
+
+	var res rpc.Response
+	if err := json.NewDecoder(httpRes.Body).Decode(&res); err != nil {
+		return common.Hash{}, fmt.Errorf("relay: failed to decode the response: %w", err)
+	}
+	if res.Error != nil {
+		return common.Hash{}, fmt.Errorf("relay: %s returned error code %d: %s",
+			r.cfg.Method, res.Error.Code, res.Error.Message)
+	}
+	var txHash common.Hash
+	if err := json.Unmarshal(res.Result, &txHash); err != nil {
+		return common.Hash{}, fmt.Errorf("relay: failed to decode the tx hash %s: %w", string(res.Result), err)
+	}
+	return txHash, nil
+}
+
+// waitReceipt polls the receipt of the relayed transaction every pollInterval until it's mined or
+// ReceiptTimeout expires. It returns an error if the transaction reverted
+func (r *relayClient) waitReceipt(ctx context.Context, txHash common.Hash,
+	pollInterval time.Duration) (*types.Receipt, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	timeout := time.NewTimer(r.cfg.ReceiptTimeout.Duration)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, fmt.Errorf("%w %s (%s)", errRelayReceiptTimeout, txHash.Hex(), r.cfg.ReceiptTimeout)
+		case <-ticker.C:
+			var receipt *types.Receipt
+			if err := r.l2RPC.Call(&receipt, receiptRPCMethod, txHash); err != nil {
+				r.logger.Warnf("relay: failed to get the receipt of the tx %s: %v", txHash.Hex(), err)
+				continue
+			}
+			if receipt == nil {
+				r.logger.Debugf("relay: waiting for tx %s to be mined", txHash.Hex())
+				continue
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return receipt, fmt.Errorf("%w: %s at block %d", errRelayTxReverted, txHash.Hex(), receipt.BlockNumber)
+			}
+			return receipt, nil
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package chaingersender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ethtxtypes "github.com/0xPolygon/zkevm-ethtx-manager/types"
+	"github.com/agglayer/aggkit/aggoracle/mocks"
+	cfgtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newRelayServer returns a relay service that answers the relay requests with the given tx hash
+// (or JSON-RPC error if rpcErr is not empty), and checks the API key header
+func newRelayServer(t *testing.T, txHash common.Hash, rpcErr string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req struct {
+			Method string           `json:"method"`
+			Params []relayTxRequest `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.Equal(t, defaultRelayMethod, req.Method)
+		require.Len(t, req.Params, 1)
+		require.NotEmpty(t, req.Params[0].Data)
+
+		if rpcErr != "" {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"` + rpcErr + `"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + txHash.Hex() + `"}`))
+	}))
+}
+
+func TestEVMChainGERSender_InjectGERByRelay(t *testing.T) {
+	l2GERManagerAddr := common.HexToAddress("0x123")
+	l2GERManagerAbi, err := abi.JSON(strings.NewReader(`[{
+		"inputs": [{"internalType": "bytes32", "name": "_newRoot", "type": "bytes32"}],
+		"name": "insertGlobalExitRoot",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}]`))
+	require.NoError(t, err)
+
+	ger := common.HexToHash("0x456")
+	relayedTxHash := common.HexToHash("0xabc")
+	directTxID := common.HexToHash("0x789")
+	statusSuccessful := types.ReceiptStatusSuccessful
+	statusFailed := types.ReceiptStatusFailed
+
+	tests := []struct {
+		name             string
+		rpcErr           string
+		apiKey           string
+		receiptStatus    *uint64
+		fallbackToDirect bool
+		gerInjected      bool
+		expectedErr      string
+		expectedDirect   bool
+	}{
+		{
+			name:          "relayed tx mined",
+			apiKey:        "secret",
+			receiptStatus: &statusSuccessful,
+		},
+		{
+			name:          "relayed tx reverted without fallback",
+			apiKey:        "secret",
+			receiptStatus: &statusFailed,
+			expectedErr:   errRelayTxReverted.Error(),
+		},
+		{
+			name:        "relay error without fallback",
+			apiKey:      "secret",
+			rpcErr:      "insufficient relayer balance",
+			expectedErr: "insufficient relayer balance",
+		},
+		{
+			name:        "relay rejects the request",
+			apiKey:      "wrong",
+			expectedErr: "invalid status code",
+		},
+		{
+			name:             "relayed tx not mined in time, fallback to direct submission",
+			apiKey:           "secret",
+			fallbackToDirect: true,
+			expectedDirect:   true,
+		},
+		{
+			name:             "relay error, fallback to direct submission",
+			apiKey:           "secret",
+			rpcErr:           "relayer unavailable",
+			fallbackToDirect: true,
+			expectedDirect:   true,
+		},
+		{
+			name:             "relayed tx not mined in time but GER injected, no fallback",
+			apiKey:           "secret",
+			fallbackToDirect: true,
+			gerInjected:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRelayServer(t, relayedTxHash, tt.rpcErr)
+			defer server.Close()
+
+			l2RPC := aggkittypesmocks.NewRPCClienter(t)
+			if tt.rpcErr == "" && tt.apiKey == "secret" {
+				l2RPC.EXPECT().Call(mock.Anything, receiptRPCMethod, relayedTxHash).
+					RunAndReturn(func(result any, _ string, _ ...any) error {
+						if tt.receiptStatus == nil {
+							// not mined yet
+							return nil
+						}
+						receipt, ok := result.(**types.Receipt)
+						require.True(t, ok)
+						*receipt = &types.Receipt{Status: *tt.receiptStatus, BlockNumber: big.NewInt(10)}
+						return nil
+					})
+			}
+
+			var ethTxMan *mocks.EthTxManager
+			sender := &EVMChainGERSender{
+				logger:              log.GetDefaultLogger(),
+				l2GERManagerAddr:    l2GERManagerAddr,
+				l2GERManagerAbi:     &l2GERManagerAbi,
+				waitPeriodMonitorTx: time.Millisecond * 10,
+				relay: newRelayClient(log.GetDefaultLogger(), RelayConfig{
+					Enabled:          true,
+					URL:              server.URL,
+					HTTPHeaders:      map[string]string{"X-Api-Key": tt.apiKey},
+					ReceiptTimeout:   cfgtypes.NewDuration(time.Millisecond * 100),
+					FallbackToDirect: tt.fallbackToDirect,
+				}, l2RPC),
+			}
+			if tt.fallbackToDirect {
+				ethTxMan = mocks.NewEthTxManager(t)
+				sender.ethTxMan = ethTxMan
+				l2GERManager := mocks.NewL2GERManagerContract(t)
+				gerIndex := big.NewInt(0)
+				if tt.gerInjected {
+					gerIndex = big.NewInt(1)
+				}
+				l2GERManager.EXPECT().GlobalExitRootMap(mock.Anything, [common.HashLength]byte(ger)).Return(gerIndex, nil)
+				sender.l2GERManager = l2GERManager
+			}
+			if tt.expectedDirect {
+				ethTxMan.EXPECT().Add(mock.Anything, &l2GERManagerAddr, common.Big0, mock.Anything, mock.Anything, mock.Anything).
+					Return(directTxID, nil).Once()
+				ethTxMan.EXPECT().Result(mock.Anything, directTxID).
+					Return(ethtxtypes.MonitoredTxResult{Status: ethtxtypes.MonitoredTxStatusMined, MinedAtBlockNumber: big.NewInt(11)}, nil).Once()
+			}
+
+			err := sender.InjectGER(context.Background(), ger)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRelayConfigValidate(t *testing.T) {
+	require.NoError(t, RelayConfig{}.Validate())
+	require.ErrorContains(t, RelayConfig{Enabled: true}.Validate(), "URL is required")
+	require.ErrorContains(t, RelayConfig{Enabled: true, URL: "http://localhost"}.Validate(), "ReceiptTimeout")
+	require.NoError(t, RelayConfig{
+		Enabled:        true,
+		URL:            "http://localhost",
+		ReceiptTimeout: cfgtypes.NewDuration(time.Minute),
+	}.Validate())
+}
+
+func TestNewEVMChainGERSenderRelay(t *testing.T) {
+	relayCfg := RelayConfig{
+		Enabled:        true,
+		URL:            "http://localhost",
+		ReceiptTimeout: cfgtypes.NewDuration(time.Minute),
+	}
+
+	t.Run("EthTxManager required for the fallback", func(t *testing.T) {
+		relayCfg := relayCfg
+		relayCfg.FallbackToDirect = true
+		_, err := NewEVMChainGERSender(log.GetDefaultLogger(), common.HexToAddress("0x123"),
+			aggkittypesmocks.NewEthClienter(t), nil, 0, time.Second, relayCfg)
+		require.ErrorContains(t, err, "an EthTxManager is required")
+	})
+
+	t.Run("L2 client without RPC calls", func(t *testing.T) {
+		l2Client := aggkittypesmocks.NewBaseEthereumClienter(t)
+		_, err := NewEVMChainGERSender(log.GetDefaultLogger(), common.HexToAddress("0x123"),
+			l2Client, nil, 0, time.Second, relayCfg)
+		require.True(t, errors.Is(err, errRelayNoReceiptClient))
+	})
+}
@@ -0,0 +1,122 @@
+package aggsender
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+)
+
+// agglayerMaintenanceTracker pauses the submission of certificates while the agglayer reports that it's
+// in maintenance (or temporarily unavailable), instead of recording each attempt as a failure. The
+// submissions are retried with an exponential backoff (from initialBackoff up to maxBackoff) until the
+// agglayer accepts one. A nil tracker is disabled
+type agglayerMaintenanceTracker struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	mu          sync.Mutex
+	since       *time.Time
+	attempts    int
+	nextAttempt time.Time
+	lastError   error
+}
+
+// newAgglayerMaintenanceTracker returns the tracker, or nil if initialBackoff is 0
+func newAgglayerMaintenanceTracker(initialBackoff, maxBackoff time.Duration) *agglayerMaintenanceTracker {
+	if initialBackoff <= 0 {
+		return nil
+	}
+	return &agglayerMaintenanceTracker{
+		initialBackoff: initialBackoff,
+		maxBackoff:     max(maxBackoff, initialBackoff),
+	}
+}
+
+// maintenance records that a submission was rejected by the maintenance of the agglayer and returns the
+// time to wait before the next attempt. The start of the maintenance is logged once
+func (t *agglayerMaintenanceTracker) maintenance(logger aggkitcommon.Logger, err error, now time.Time) time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.since == nil {
+		t.since = &now
+		logger.Warnf("agglayer in maintenance, pausing the submission of certificates: %v", err)
+	}
+	backoff := t.initialBackoff
+	for i := 0; i < t.attempts && backoff < t.maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, t.maxBackoff)
+	t.attempts++
+	t.nextAttempt = now.Add(backoff)
+	t.lastError = err
+	logger.Infof("agglayer in maintenance for %s, next certificate submission attempt in %s",
+		now.Sub(*t.since).Round(time.Second), backoff)
+	metrics.AgglayerMaintenanceDeferred()
+	metrics.AgglayerMaintenance(true, now.Sub(*t.since).Seconds())
+	return backoff
+}
+
+// available records that the agglayer accepted a submission, resuming the normal operation
+func (t *agglayerMaintenanceTracker) available(logger aggkitcommon.Logger, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.since == nil {
+		return
+	}
+	logger.Infof("agglayer available again after %s in maintenance (%d submissions deferred)",
+		now.Sub(*t.since).Round(time.Second), t.attempts)
+	t.since = nil
+	t.attempts = 0
+	t.nextAttempt = time.Time{}
+	t.lastError = nil
+	metrics.AgglayerMaintenance(false, 0)
+}
+
+// waiting returns the time left until the next attempt, and false if the agglayer is not in maintenance
+// or it's time to try again
+func (t *agglayerMaintenanceTracker) waiting(now time.Time) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.since == nil || !now.Before(t.nextAttempt) {
+		return 0, false
+	}
+	metrics.AgglayerMaintenance(true, now.Sub(*t.since).Seconds())
+	return t.nextAttempt.Sub(now), true
+}
+
+// status returns the maintenance of the agglayer, or nil if it's not in maintenance
+func (t *agglayerMaintenanceTracker) status() *types.AgglayerMaintenanceStatus {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.since == nil {
+		return nil
+	}
+	status := &types.AgglayerMaintenanceStatus{
+		Since:       *t.since,
+		Attempts:    t.attempts,
+		NextAttempt: t.nextAttempt,
+	}
+	if t.lastError != nil {
+		status.LastError = t.lastError.Error()
+	}
+	return status
+}
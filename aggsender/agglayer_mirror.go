@@ -0,0 +1,187 @@
+package aggsender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agglayer/aggkit/agglayer"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	aggkitdb "github.com/agglayer/aggkit/db"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// certificateMirror copies periodically the certificate headers of the network from the agglayer into the
+// local storage, so there is a complete view of the certificates even if the storage was created after the
+// first ones were sent. Every run mirrors the latest pending and settled headers, and then the heights
+// below them that are missing or not settled yet: by height if the agglayer client supports it, or
+// otherwise by the certificate ID known by the mirror or by the local storage
+type certificateMirror struct {
+	log           aggkitcommon.Logger
+	storage       db.AggSenderStorage
+	client        agglayer.AgglayerClientInterface
+	heightQuerier agglayer.AggLayerClientCertificateHeightQuerier
+	networkID     uint32
+	interval      time.Duration
+	// maxHeadersPerRun is the maximum number of headers queried by height or ID in a run (0 = no limit)
+	maxHeadersPerRun uint32
+	timeNowFn        func() time.Time
+}
+
+func newCertificateMirror(logger aggkitcommon.Logger, storage db.AggSenderStorage,
+	client agglayer.AgglayerClientInterface, networkID uint32, interval time.Duration,
+	maxHeadersPerRun uint32) *certificateMirror {
+	if interval == 0 {
+		return nil
+	}
+	heightQuerier, ok := client.(agglayer.AggLayerClientCertificateHeightQuerier)
+	if !ok {
+		logger.Infof("the agglayer client can't query the certificates by height, only the certificates " +
+			"known by this instance are mirrored")
+	}
+	return &certificateMirror{
+		log:              logger,
+		storage:          storage,
+		client:           client,
+		heightQuerier:    heightQuerier,
+		networkID:        networkID,
+		interval:         interval,
+		maxHeadersPerRun: maxHeadersPerRun,
+		timeNowFn:        time.Now,
+	}
+}
+
+// start mirrors the certificate headers every interval until the context is done
+func (m *certificateMirror) start(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if err := m.mirror(ctx); err != nil {
+			m.log.Warnf("error mirroring the certificate headers from the agglayer: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// mirror runs a mirroring pass. The headers queried before an error are saved anyway
+func (m *certificateMirror) mirror(ctx context.Context) error {
+	pending, err := m.client.GetLatestKnownCertificate(ctx, m.networkID)
+	if err != nil {
+		return fmt.Errorf("error getting the latest pending certificate from agglayer: %w", err)
+	}
+	settled, err := m.client.GetLatestSettledCertificate(ctx, m.networkID)
+	if err != nil {
+		return fmt.Errorf("error getting the latest settled certificate from agglayer: %w", err)
+	}
+	if pending == nil && settled == nil {
+		return nil
+	}
+
+	// the settled header is the most recent one if both have the same height
+	if pending != nil && settled != nil && pending.Height == settled.Height {
+		pending = nil
+	}
+	latest := make(map[uint64]*agglayertypes.CertificateHeader, 2) //nolint:mnd
+	topHeight := uint64(0)
+	for _, header := range []*agglayertypes.CertificateHeader{settled, pending} {
+		if header != nil {
+			latest[header.Height] = header
+			topHeight = max(topHeight, header.Height)
+		}
+	}
+
+	mirrored, err := m.storage.GetMirroredCertificateHeaders(0, topHeight)
+	if err != nil {
+		return err
+	}
+	mirroredByHeight := make(map[uint64]*types.MirroredCertificateHeader, len(mirrored))
+	for _, header := range mirrored {
+		mirroredByHeight[header.Height] = header
+	}
+
+	now := uint32(m.timeNowFn().UTC().Unix())
+	toSave := make([]*types.MirroredCertificateHeader, 0, len(latest))
+	for _, header := range []*agglayertypes.CertificateHeader{settled, pending} {
+		if header != nil {
+			toSave = append(toSave, types.NewMirroredCertificateHeader(header, now))
+		}
+	}
+	queried := uint32(0)
+	for height := uint64(0); height <= topHeight; height++ {
+		if m.maxHeadersPerRun > 0 && queried >= m.maxHeadersPerRun {
+			break
+		}
+		if _, ok := latest[height]; ok {
+			continue
+		}
+		if current := mirroredByHeight[height]; current != nil && current.Status.IsSettled() {
+			continue
+		}
+		header, ok, err := m.queryHeader(ctx, height, mirroredByHeight[height])
+		if ok {
+			queried++
+		}
+		if err != nil {
+			saveErr := m.storage.SaveMirroredCertificateHeaders(ctx, toSave)
+			if saveErr != nil {
+				m.log.Errorf("error saving the mirrored certificate headers: %v", saveErr)
+			}
+			return err
+		}
+		if header != nil {
+			toSave = append(toSave, types.NewMirroredCertificateHeader(header, now))
+		}
+	}
+
+	if err := m.storage.SaveMirroredCertificateHeaders(ctx, toSave); err != nil {
+		return err
+	}
+	m.log.Debugf("mirrored %d certificate headers from the agglayer (latest height: %d)", len(toSave), topHeight)
+	return nil
+}
+
+// queryHeader queries the agglayer header of the height, by height or by the certificate ID of the mirror
+// or of the local storage. It returns false if the height can't be queried (the certificate ID is unknown)
+func (m *certificateMirror) queryHeader(ctx context.Context, height uint64,
+	current *types.MirroredCertificateHeader) (*agglayertypes.CertificateHeader, bool, error) {
+	if m.heightQuerier != nil {
+		header, err := m.heightQuerier.GetCertificateHeaderByHeight(ctx, m.networkID, height)
+		if err != nil {
+			return nil, true, fmt.Errorf("error getting the certificate of height %d from agglayer: %w", height, err)
+		}
+		return header, true, nil
+	}
+
+	// the local certificate is the last one sent by this instance for the height
+	local, err := m.storage.GetCertificateHeaderByHeight(height)
+	if err != nil && !errors.Is(err, aggkitdb.ErrNotFound) {
+		return nil, false, fmt.Errorf("error getting the local certificate of height %d: %w", height, err)
+	}
+	var certificateID common.Hash
+	switch {
+	case local != nil && local.CertificateID != (common.Hash{}):
+		certificateID = local.CertificateID
+	case current != nil:
+		certificateID = current.CertificateID
+	default:
+		return nil, false, nil
+	}
+	if current != nil && current.CertificateID == certificateID && current.Status.IsClosed() {
+		// the status of this certificate is not going to change
+		return nil, false, nil
+	}
+	header, err := m.client.GetCertificateHeader(ctx, certificateID)
+	if err != nil {
+		return nil, true, fmt.Errorf("error getting the certificate %s (height %d) from agglayer: %w",
+			certificateID.Hex(), height, err)
+	}
+	return header, true, nil
+}
@@ -11,8 +11,11 @@ import (
 	zkevm "github.com/agglayer/aggkit"
 	"github.com/agglayer/aggkit/agglayer"
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/archiver"
+	"github.com/agglayer/aggkit/aggsender/certvalidation"
 	"github.com/agglayer/aggkit/aggsender/config"
 	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/eventbus"
 	"github.com/agglayer/aggkit/aggsender/flows"
 	"github.com/agglayer/aggkit/aggsender/metrics"
 	aggsenderrpc "github.com/agglayer/aggkit/aggsender/rpc"
@@ -39,8 +42,11 @@ type AggSender struct {
 
 	storage                      db.AggSenderStorage
 	aggLayerClient               agglayer.AgglayerClientInterface
+	l2Syncer                     types.L2BridgeSyncer
 	compatibilityStoragedChecker compatibility.CompatibilityChecker
 	certStatusChecker            types.CertificateStatusChecker
+	archiver                     types.CertificateArchiver
+	eventPublisher               types.CertificateEventPublisher
 
 	cfg config.Config
 
@@ -49,6 +55,22 @@ type AggSender struct {
 	flow        types.AggsenderFlow
 
 	l2OriginNetwork uint32
+	// instanceLease is nil if InstanceLeaseTTL is 0
+	instanceLease *instanceLease
+	// feeBudget is nil if the fee budget is disabled
+	feeBudget *feeBudget
+	// proverSLO is nil if the tracking of the prover SLOs is disabled
+	proverSLO *proverSLO
+	// approvalHook is nil if the approval hook is disabled
+	approvalHook *approvalHook
+	// certificateMirror is nil if AgglayerMirrorInterval is 0
+	certificateMirror *certificateMirror
+	// epochRollover detects that the last certificate is still pending in the epoch after its submission
+	epochRollover epochRolloverTracker
+	// agglayerMaintenance is nil if AgglayerMaintenanceBackoff is 0
+	agglayerMaintenance *agglayerMaintenanceTracker
+	// maintenanceWindows is nil if there are no scheduled MaintenanceWindows
+	maintenanceWindows *maintenanceWindows
 }
 
 // New returns a new AggSender instance
@@ -66,6 +88,7 @@ func New(
 	storageConfig := db.AggSenderSQLStorageConfig{
 		DBPath:                  cfg.StoragePath,
 		KeepCertificatesHistory: cfg.KeepCertificatesHistory,
+		Tuning:                  cfg.StorageTuning,
 	}
 	storage, err := db.NewAggSenderSQLStorage(logger, storageConfig)
 	if err != nil {
@@ -74,6 +97,7 @@ func New(
 
 	rateLimit := aggkitcommon.NewRateLimit(cfg.MaxSubmitCertificateRate)
 
+	certFeeBudget := newFeeBudget(logger, cfg.FeeBudget, storage, epochNotifier)
 	flowManager, err := flows.NewFlow(
 		ctx,
 		cfg,
@@ -84,6 +108,7 @@ func New(
 		l1InfoTreeSyncer,
 		l2Syncer,
 		rollupDataQuerier,
+		certFeeBudget,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating flow manager: %w", err)
@@ -100,18 +125,61 @@ func New(
 		compatibility.NewKeyValueToCompatibilityStorage[db.RuntimeData](storage, aggkitcommon.AGGSENDER),
 	)
 
+	var certArchiver types.CertificateArchiver
+	if cfg.ArchiverConfig.Enabled {
+		certArchiver, err = archiver.New(logger, cfg.ArchiverConfig, l2OriginNetwork)
+		if err != nil {
+			return nil, fmt.Errorf("error creating certificate archiver: %w", err)
+		}
+	}
+
+	var eventPublisher types.CertificateEventPublisher
+	if cfg.EventBusConfig.Enabled {
+		eventPublisher, err = eventbus.New(logger, cfg.EventBusConfig, l2OriginNetwork)
+		if err != nil {
+			return nil, fmt.Errorf("error creating certificate event publisher: %w", err)
+		}
+	}
+
+	windows, err := newMaintenanceWindows(cfg.MaintenanceWindows)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the maintenance windows: %w", err)
+	}
+	windows.registerHealth()
+
+	var lease *instanceLease
+	if cfg.InstanceLeaseTTL.Duration > 0 {
+		lease, err = newInstanceLease(logger, storage, cfg.InstanceLeaseTTL.Duration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &AggSender{
 		cfg:                          cfg,
 		log:                          logger,
 		storage:                      storage,
 		aggLayerClient:               aggLayerClient,
+		l2Syncer:                     l2Syncer,
 		epochNotifier:                epochNotifier,
 		status:                       &types.AggsenderStatus{Status: types.StatusNone},
 		flow:                         flowManager,
 		rateLimiter:                  rateLimit,
 		compatibilityStoragedChecker: compatibilityStoragedChecker,
 		l2OriginNetwork:              l2OriginNetwork,
-		certStatusChecker:            statuschecker.NewCertStatusChecker(logger, storage, aggLayerClient, l2OriginNetwork),
+		archiver:                     certArchiver,
+		eventPublisher:               eventPublisher,
+		instanceLease:                lease,
+		feeBudget:                    certFeeBudget,
+		proverSLO:                    newProverSLO(logger, cfg.ProverSLO, storage, l2OriginNetwork),
+		approvalHook:                 newApprovalHook(logger, cfg.ApprovalHook, l2OriginNetwork),
+		certificateMirror: newCertificateMirror(logger, storage, aggLayerClient, l2OriginNetwork,
+			cfg.AgglayerMirrorInterval.Duration, cfg.AgglayerMirrorMaxHeadersPerRun),
+		agglayerMaintenance: newAgglayerMaintenanceTracker(
+			cfg.AgglayerMaintenanceBackoff.Duration, cfg.AgglayerMaintenanceMaxBackoff.Duration),
+		maintenanceWindows: windows,
+		certStatusChecker: statuschecker.NewCertStatusChecker(
+			logger, storage, aggLayerClient, l2OriginNetwork, certArchiver, eventPublisher, epochNotifier),
 	}, nil
 }
 
@@ -121,6 +189,9 @@ func (a *AggSender) Info() types.AggsenderInfo {
 		Version:                  zkevm.GetVersion(),
 		EpochNotifierDescription: a.epochNotifier.String(),
 		NetworkID:                a.l2OriginNetwork,
+		L2Chain:                  a.flow.L2ChainStatus(),
+		AgglayerMaintenance:      a.agglayerMaintenance.status(),
+		MaintenanceWindow:        a.maintenanceWindows.status(time.Now()),
 	}
 	return res
 }
@@ -134,8 +205,9 @@ func (a *AggSender) GetRPCServices() []jRPC.Service {
 	logger := log.WithFields("aggsender-rpc", aggkitcommon.BRIDGE)
 	return []jRPC.Service{
 		{
-			Name:    "aggsender",
-			Service: aggsenderrpc.NewAggsenderRPC(logger, a.storage, a),
+			Name: "aggsender",
+			Service: aggsenderrpc.NewAggsenderRPC(logger, a.storage, a, a.aggLayerClient, a.l2Syncer,
+				certvalidation.NewValidator(a.l2OriginNetwork, a.storage, a.flow)),
 		},
 	}
 }
@@ -146,14 +218,39 @@ func (a *AggSender) Start(ctx context.Context) {
 	metrics.Register()
 	a.status.Start(time.Now().UTC())
 
+	a.acquireInstanceLease(ctx)
 	a.checkDBCompatibility(ctx)
+	if a.archiver != nil {
+		go a.archiver.Start(ctx)
+	}
+	if a.eventPublisher != nil {
+		go a.eventPublisher.Start(ctx)
+	}
 	a.certStatusChecker.CheckInitialStatus(ctx, a.cfg.DelayBetweenRetries.Duration, a.status)
+	if a.certificateMirror != nil {
+		go a.certificateMirror.start(ctx)
+	}
 	if err := a.flow.CheckInitialStatus(ctx); err != nil {
 		a.log.Panicf("error checking flow Initial Status: %v", err)
 	}
 	a.sendCertificates(ctx, 0)
 }
 
+// acquireInstanceLease refuses to start if another instance holds the lease of the storage,
+// otherwise it keeps the lease renewed while the aggsender is running
+func (a *AggSender) acquireInstanceLease(ctx context.Context) {
+	if a.instanceLease == nil {
+		a.log.Warnf("instance lease is disabled (InstanceLeaseTTL is 0), another instance could use the same storage")
+		return
+	}
+	if err := a.instanceLease.acquire(ctx); err != nil {
+		a.log.Panicf("%v. Another aggsender instance seems to be running with the same storage (%s): stop it, "+
+			"or wait %s for its lease to expire if it has crashed", err, a.cfg.StoragePath, a.cfg.InstanceLeaseTTL)
+	}
+	a.log.Infof("instance lease %s acquired", a.instanceLease.ownerID)
+	go a.instanceLease.start(ctx)
+}
+
 func (a *AggSender) checkDBCompatibility(ctx context.Context) {
 	if a.compatibilityStoragedChecker == nil {
 		a.log.Warnf("compatibilityStoragedChecker is nil, so we are not going to check the compatibility")
@@ -163,6 +260,7 @@ func (a *AggSender) checkDBCompatibility(ctx context.Context) {
 		a.log.Panicf("error checking compatibility data in DB, you can bypass this check using config file. Err: %w", err)
 	}
 }
+
 func (a *AggSender) checkSendCertificateStopCondition(err error) {
 	if errors.Is(err, flows.ErrComplete) {
 		a.log.Infof("AggSender reached the end of the certificates to send")
@@ -187,6 +285,54 @@ func (a *AggSender) sendCertificates(ctx context.Context, returnAfterNIterations
 	}
 
 	chEpoch := a.epochNotifier.Subscribe("aggsender")
+	// rolloverRecheck is only armed while the certificate of the previous epoch is pending (epoch rollover)
+	var rolloverRecheck *time.Timer
+	var rolloverRecheckChannel <-chan time.Time
+	stopRolloverRecheck := func() {
+		if rolloverRecheck != nil {
+			rolloverRecheck.Stop()
+		}
+		rolloverRecheck, rolloverRecheckChannel = nil, nil
+	}
+	armRolloverRecheck := func() {
+		stopRolloverRecheck()
+		if a.cfg.EpochRolloverRecheckInterval.Duration > 0 {
+			rolloverRecheck = time.NewTimer(a.cfg.EpochRolloverRecheckInterval.Duration)
+			rolloverRecheckChannel = rolloverRecheck.C
+		}
+	}
+	defer stopRolloverRecheck()
+	// maintenanceRetry is only armed while the agglayer is in maintenance or during a maintenance window,
+	// to resume the submissions after the backoff (or the end of the window) instead of waiting for the
+	// next epoch
+	var maintenanceRetry *time.Timer
+	var maintenanceRetryChannel <-chan time.Time
+	defer func() {
+		if maintenanceRetry != nil {
+			maintenanceRetry.Stop()
+		}
+	}()
+	trySendCertificate := func() {
+		_, err := a.sendCertificate(ctx)
+		a.status.SetLastError(err)
+		if err != nil {
+			a.log.Error(err)
+		}
+		a.checkSendCertificateStopCondition(err)
+
+		if maintenanceRetry != nil {
+			maintenanceRetry.Stop()
+		}
+		maintenanceRetry, maintenanceRetryChannel = nil, nil
+		if wait, ok := a.agglayerMaintenance.waiting(time.Now()); ok {
+			maintenanceRetry = time.NewTimer(wait)
+			maintenanceRetryChannel = maintenanceRetry.C
+		} else if window, ok := a.maintenanceWindows.active(time.Now()); ok {
+			maintenanceRetry = time.NewTimer(time.Until(window.End))
+			maintenanceRetryChannel = maintenanceRetry.C
+		}
+	}
+
 	a.status.Status = types.StatusCertificateStage
 	iteration := 0
 	for {
@@ -197,15 +343,10 @@ func (a *AggSender) sendCertificates(ctx context.Context, returnAfterNIterations
 				a.cfg.CheckCertConfigBriefString())
 			checkResult := a.certStatusChecker.CheckPendingCertificatesStatus(ctx)
 			if !checkResult.ExistPendingCerts && checkResult.ExistNewInErrorCert {
-				if a.cfg.RetryCertAfterInError {
+				if a.cfg.RetryCertAfterInError && a.checkInErrorRetryPolicy() {
 					a.log.Infof("An InError cert exists. Sending a new one (%s)", a.cfg.CheckCertConfigBriefString())
-					_, err := a.sendCertificate(ctx)
-					a.status.SetLastError(err)
-					if err != nil {
-						a.log.Error(err)
-					}
-					a.checkSendCertificateStopCondition(err)
-				} else {
+					trySendCertificate()
+				} else if !a.cfg.RetryCertAfterInError {
 					a.log.Infof("An InError cert exists but skipping send cert because RetryCertAfterInError is false")
 				}
 			}
@@ -216,17 +357,51 @@ func (a *AggSender) sendCertificates(ctx context.Context, returnAfterNIterations
 		case epoch := <-chEpoch:
 			iteration++
 			a.log.Infof("Epoch received: %s", epoch.String())
+			stopRolloverRecheck()
 			checkResult := a.certStatusChecker.CheckPendingCertificatesStatus(ctx)
-			if !checkResult.ExistPendingCerts {
-				_, err := a.sendCertificate(ctx)
-				a.status.SetLastError(err)
-				if err != nil {
-					a.log.Error(err)
+			if checkResult.ExistPendingCerts {
+				if a.epochRollover.isRollover(a.log, epoch.Epoch) {
+					// it's expected to be processed in this epoch, so its status is checked again
+					// instead of waiting for the next epoch to send a new certificate
+					a.log.Infof("Waiting for the certificate of the previous epoch in epoch %s (recheck interval: %s)",
+						epoch.String(), a.cfg.EpochRolloverRecheckInterval.String())
+					armRolloverRecheck()
+				} else {
+					log.Infof("Skipping epoch %s because there are pending certificates",
+						epoch.String())
 				}
-				a.checkSendCertificateStopCondition(err)
+			} else if a.checkInErrorRetryPolicy() {
+				trySendCertificate()
+			}
+
+			if returnAfterNIterations > 0 && iteration >= returnAfterNIterations {
+				a.log.Warnf("reached number of iterations, so we are going to return")
+				return
+			}
+		case <-rolloverRecheckChannel:
+			iteration++
+			checkResult := a.certStatusChecker.CheckPendingCertificatesStatus(ctx)
+			if checkResult.ExistPendingCerts {
+				armRolloverRecheck()
 			} else {
-				log.Infof("Skipping epoch %s because there are pending certificates",
-					epoch.String())
+				stopRolloverRecheck()
+				a.log.Infof("The certificate of the previous epoch is not pending anymore, sending a new one")
+				if a.checkInErrorRetryPolicy() {
+					trySendCertificate()
+				}
+			}
+
+			if returnAfterNIterations > 0 && iteration >= returnAfterNIterations {
+				a.log.Warnf("reached number of iterations, so we are going to return")
+				return
+			}
+		case <-maintenanceRetryChannel:
+			iteration++
+			maintenanceRetry, maintenanceRetryChannel = nil, nil
+			a.log.Infof("Trying again to send a certificate after the maintenance")
+			checkResult := a.certStatusChecker.CheckPendingCertificatesStatus(ctx)
+			if !checkResult.ExistPendingCerts && a.checkInErrorRetryPolicy() {
+				trySendCertificate()
 			}
 
 			if returnAfterNIterations > 0 && iteration >= returnAfterNIterations {
@@ -240,8 +415,56 @@ func (a *AggSender) sendCertificates(ctx context.Context, returnAfterNIterations
 	}
 }
 
+// checkInErrorRetryPolicy returns true if a new certificate can be sent. If the last sent certificate
+// is InError, it depends on the retry policy of its root cause:
+//   - rebuild: a new certificate is sent immediately
+//   - wait: a new certificate is sent after InErrorWaitRetryDelay
+//   - manual: no new certificate is sent unless RetryInErrorRequiringIntervention is enabled
+func (a *AggSender) checkInErrorRetryPolicy() bool {
+	lastCert, err := a.storage.GetLastSentCertificateHeader()
+	if err != nil {
+		// sendCertificate is going to fail too, so the error is reported there
+		a.log.Errorf("error getting last sent certificate to check the InError retry policy: %v", err)
+		return true
+	}
+	if lastCert == nil || !lastCert.Status.IsInError() {
+		return true
+	}
+
+	switch lastCert.ErrorCategory.RetryPolicy() {
+	case types.RetryPolicyWait:
+		elapsed := time.Since(time.Unix(int64(lastCert.UpdatedAt), 0))
+		if elapsed < a.cfg.InErrorWaitRetryDelay.Duration {
+			a.log.Infof("certificate %s is InError (%s), waiting %s before sending a new one",
+				lastCert.ID(), lastCert.ErrorCategory, a.cfg.InErrorWaitRetryDelay.Duration-elapsed)
+			return false
+		}
+	case types.RetryPolicyManual:
+		if !a.cfg.RetryInErrorRequiringIntervention {
+			err := fmt.Errorf("certificate %s is InError (%s) and requires manual intervention, "+
+				"not sending new certificates (see RetryInErrorRequiringIntervention)",
+				lastCert.ID(), lastCert.ErrorCategory)
+			a.log.Error(err)
+			a.status.SetLastError(err)
+			return false
+		}
+	case types.RetryPolicyRebuild:
+	}
+	return true
+}
+
 // sendCertificate sends certificate for a network
 func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certificate, error) {
+	if wait, ok := a.agglayerMaintenance.waiting(time.Now()); ok {
+		a.log.Infof("agglayer in maintenance, not sending a certificate until the next attempt in %s", wait)
+		return nil, nil
+	}
+	if window, ok := a.maintenanceWindows.active(time.Now()); ok {
+		a.log.Infof("scheduled maintenance window (%s) until %s, not sending a certificate",
+			window.Schedule, window.End.Format(time.RFC3339))
+		return nil, nil
+	}
+
 	startEpochStatus := a.epochNotifier.GetEpochStatus()
 	a.log.Infof("trying to send a new certificate... %s", startEpochStatus.String())
 
@@ -255,10 +478,36 @@ func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certifi
 	if certificateParams == nil {
 		return nil, nil
 	}
+	// the decisions taken for the certificate are stored in its audit trail, whether it's sent or not
+	defer a.saveAuditTrail(ctx, nextCertificateHeight(certificateParams.LastSentCertificate), certificateParams)
+
+	// the certificate is approved before signing it, so a rejected one is never signed. Its bridges are
+	// going to be included in the next certificate
+	if a.approvalHook != nil {
+		if err := a.approvalHook.approve(ctx, certificateParams); err != nil {
+			return nil, notSent(certificateParams, fmt.Errorf("not sending certificate: %w", err))
+		}
+	}
 
 	certificate, err := a.flow.BuildCertificate(ctx, certificateParams)
 	if err != nil {
-		return nil, fmt.Errorf("error building certificate: %w", err)
+		return nil, notSent(certificateParams, fmt.Errorf("error building certificate: %w", err))
+	}
+	certificateParams.AddAuditEvent(types.CertificateAuditSigned, map[string]any{
+		"new_local_exit_root":   certificate.NewLocalExitRoot.Hex(),
+		"bridge_exits":          len(certificate.BridgeExits),
+		"imported_bridge_exits": len(certificate.ImportedBridgeExits),
+		"l1_info_tree_root":     certificateParams.L1InfoTreeRootFromWhichToProve.Hex(),
+	})
+
+	if a.proverSLO != nil {
+		a.proverSLO.record(ctx, certificate.Height, certificateParams)
+	}
+
+	if a.cfg.CheckLocalExitRootAgainstContract {
+		if err := a.checkLocalExitRootAgainstContract(ctx, certificate, certificateParams); err != nil {
+			return nil, notSent(certificateParams, fmt.Errorf("not sending certificate %s: %w", certificate.Brief(), err))
+		}
 	}
 
 	if rateLimitSleepTime := a.rateLimiter.Call("sendCertificate", false); rateLimitSleepTime != nil {
@@ -267,24 +516,20 @@ func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certifi
 			rateLimitSleepTime.String(), a.rateLimiter.String())
 		time.Sleep(*rateLimitSleepTime)
 	}
+	sendEpochStatus := a.epochNotifier.GetEpochStatus()
 	a.log.Infof("certificate ready to be sent to AggLayer: %s start: %s , end: %s",
-		certificate.Brief(), startEpochStatus.String(), a.epochNotifier.GetEpochStatus().String())
+		certificate.Brief(), startEpochStatus.String(), sendEpochStatus.String())
 	metrics.CertificateBuildTime(time.Since(start).Seconds())
 
 	if a.cfg.DryRun {
 		a.log.Warn("dry run mode enabled, skipping sending certificate")
 		return certificate, nil
 	}
-	certificateHash, err := a.aggLayerClient.SendCertificate(ctx, certificate)
-	if err != nil {
-		a.saveNonAcceptedCert(ctx, certificate, certificateParams.CreatedAt, err)
 
-		return nil, fmt.Errorf("error sending certificate: %w", err)
+	if err := a.checkSingleInstance(ctx); err != nil {
+		return nil, notSent(certificateParams, fmt.Errorf("not sending certificate %s: %w", certificate.Brief(), err))
 	}
 
-	metrics.CertificateSent()
-	a.log.Debugf("certificate send: Height: %d cert: %s", certificate.Height, certificate.Brief())
-
 	raw, err := json.Marshal(certificate)
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling signed certificate. Cert:%s. Err: %w", certificate.Brief(), err)
@@ -297,7 +542,6 @@ func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certifi
 		Header: &types.CertificateHeader{
 			Height:                  certificate.Height,
 			RetryCount:              certificateParams.RetryCount,
-			CertificateID:           certificateHash,
 			NewLocalExitRoot:        certificate.NewLocalExitRoot,
 			PreviousLocalExitRoot:   &prevLER,
 			FromBlock:               certificateParams.FromBlock,
@@ -308,11 +552,60 @@ func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certifi
 			L1InfoTreeLeafCount:     certificateParams.L1InfoTreeLeafCount,
 			CertType:                certificateParams.CertificateType,
 			CertSource:              types.CertificateSourceLocal,
+			Tags:                    a.cfg.CertificateTags,
+			BuildEpoch:              &startEpochStatus.Epoch,
 		},
 		SignedCertificate: &jsonCert,
 		AggchainProof:     certificateParams.AggchainProof,
 		ExtraData:         certificateParams.ExtraData,
 	}
+	a.publishCertificateEvent(types.CertificateEventBuilt, certInfo.Header, nil)
+
+	// The inputs of the certificate are saved before sending it, so if it ends InError
+	// the retry is built from the same inputs. The PP flow doesn't reuse them: its retry
+	// covers the new blocks too
+	if certificateParams.CertificateType != types.CertificateTypePP {
+		buildParamsSnapshot, err := db.NewCertificateBuildParamsSnapshot(
+			certificate.Height, certificateParams.RetryCount, certificateParams)
+		if err != nil {
+			return nil, fmt.Errorf("error creating build params snapshot. Cert:%s. Err: %w", certificate.Brief(), err)
+		}
+		if err := a.storage.SaveCertificateBuildParams(ctx, buildParamsSnapshot); err != nil {
+			return nil, fmt.Errorf("error saving build params snapshot. Cert:%s. Err: %w", certificate.Brief(), err)
+		}
+	}
+
+	// Pre-commit: the certificate is journaled before sending it, so if the aggsender stops
+	// before storing it, on startup it's possible to check if agglayer received it
+	journalEntry, err := db.NewCertificateJournalEntry(certInfo, certificate.Metadata, certificateParams.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error creating journal entry. Cert:%s. Err: %w", certificate.Brief(), err)
+	}
+	if err := a.storage.SaveCertificateJournalEntry(ctx, journalEntry); err != nil {
+		return nil, fmt.Errorf("error saving journal entry. Cert:%s. Err: %w", certificate.Brief(), err)
+	}
+
+	certificateHash, err := a.aggLayerClient.SendCertificate(ctx, certificate)
+	addSubmissionAttemptAuditEvent(certificateParams, certificateHash, err)
+	if err != nil && a.agglayerMaintenance != nil && agglayer.IsMaintenanceError(err) {
+		// it's not a failure of the certificate: it's sent again once the agglayer is available
+		a.updateJournalEntryState(ctx, certificate.Height, db.CertificateJournalStateDiscarded, nil)
+		a.agglayerMaintenance.maintenance(a.log, err, time.Now())
+		return nil, nil
+	}
+	if err != nil {
+		a.saveNonAcceptedCert(ctx, certificate, certificateParams.CreatedAt, err)
+		a.updateJournalEntryState(ctx, certificate.Height, db.CertificateJournalStateDiscarded, nil)
+		a.publishCertificateEvent(types.CertificateEventError, certInfo.Header, err)
+
+		return nil, fmt.Errorf("error sending certificate: %w", err)
+	}
+
+	a.agglayerMaintenance.available(a.log, time.Now())
+	metrics.CertificateSent()
+	a.log.Debugf("certificate send: Height: %d cert: %s", certificate.Height, certificate.Brief())
+
+	certInfo.Header.CertificateID = certificateHash
 	// TODO: Improve this case, if a cert is not save in the storage, we are going to settle a unknown certificate
 	err = a.saveCertificateToStorage(ctx, certInfo, a.cfg.MaxRetriesStoreCertificate)
 	if err != nil {
@@ -320,19 +613,108 @@ func (a *AggSender) sendCertificate(ctx context.Context) (*agglayertypes.Certifi
 		return nil, fmt.Errorf("error saving last sent certificate %s in db: %w", certInfo.String(), err)
 	}
 
+	if a.feeBudget != nil {
+		a.feeBudget.record(ctx, certInfo.Header, certificate, startEpochStatus.Epoch, time.Now())
+	}
+	a.epochRollover.certificateSubmitted(certificateHash, sendEpochStatus.Epoch)
+	a.saveCertificateAnalytics(ctx, certInfo.Header, certificateParams)
+	a.publishCertificateEvent(types.CertificateEventSubmitted, certInfo.Header, nil)
+
 	a.log.Infof("certificate: %s sent successfully for range of l2 blocks (from block: %d, to block: %d) cert:%s",
 		certInfo.Header.ID(), certificateParams.FromBlock, certificateParams.ToBlock, certificate.Brief())
 
+	if a.archiver != nil {
+		if err := a.archiver.ArchiveCertificate(&certInfo); err != nil {
+			a.log.Errorf("error archiving certificate %s: %v", certInfo.Header.ID(), err)
+		}
+	}
+
 	return certificate, nil
 }
 
-// saveCertificateToStorage saves the certificate to the storage
-// it retries if it fails. if param retries == 0 it retries indefinitely
+// notSent records in the audit trail that the certificate is not sent, and returns the error
+func notSent(certificateParams *types.CertificateBuildParams, err error) error {
+	certificateParams.AddAuditEvent(types.CertificateAuditNotSent, map[string]any{"reason": err.Error()})
+	return err
+}
+
+// addSubmissionAttemptAuditEvent records in the audit trail the submission of the certificate to the agglayer
+func addSubmissionAttemptAuditEvent(certificateParams *types.CertificateBuildParams,
+	certificateID common.Hash, err error) {
+	if err != nil {
+		certificateParams.AddAuditEvent(types.CertificateAuditSubmissionAttempt, map[string]any{"error": err.Error()})
+		return
+	}
+	certificateParams.AuditTrail = append(certificateParams.AuditTrail, types.NewCertificateAuditEvent(
+		0, certificateParams.RetryCount, &certificateID, types.CertificateAuditSubmissionAttempt, map[string]any{}))
+}
+
+// saveAuditTrail stores the audit trail of the certificate with the given height. It's not stored in
+// DryRun mode, and an error is logged but the certificate is not affected
+func (a *AggSender) saveAuditTrail(ctx context.Context, height uint64,
+	certificateParams *types.CertificateBuildParams) {
+	if a.cfg.DryRun || len(certificateParams.AuditTrail) == 0 {
+		return
+	}
+	for _, event := range certificateParams.AuditTrail {
+		event.Height = height
+	}
+	if err := a.storage.SaveCertificateAuditEvents(ctx, certificateParams.AuditTrail); err != nil {
+		a.log.Errorf("error saving the audit trail of the certificate of height %d: %v", height, err)
+	}
+}
+
+// publishCertificateEvent publishes the lifecycle event of the certificate, if the event bus is enabled
+func (a *AggSender) publishCertificateEvent(eventType types.CertificateEventType,
+	header *types.CertificateHeader, err error) {
+	if a.eventPublisher == nil {
+		return
+	}
+	event := types.NewCertificateEvent(eventType, header, time.Now())
+	if err != nil {
+		event.Error = err.Error()
+	}
+	a.eventPublisher.Publish(event)
+}
+
+// saveCertificateAnalytics stores the aggregated figures of the bridges and claims of the certificate
+// sent. They are only used for analytics, so an error is logged but the certificate is not affected
+func (a *AggSender) saveCertificateAnalytics(ctx context.Context, header *types.CertificateHeader,
+	certificateParams *types.CertificateBuildParams) {
+	analytics := certificateParams.Analytics()
+	analytics.Height = header.Height
+	analytics.RetryCount = header.RetryCount
+	analytics.CertificateID = header.CertificateID
+	if err := a.storage.SaveCertificateAnalytics(ctx, analytics); err != nil {
+		a.log.Errorf("error saving analytics of certificate %s: %v", header.ID(), err)
+	}
+}
+
+// checkSingleInstance checks, just before sending a certificate, that this instance still holds the
+// instance lease and (if CheckAgglayerHeightBeforeSend) that no other instance has sent certificates
+func (a *AggSender) checkSingleInstance(ctx context.Context) error {
+	if a.instanceLease != nil {
+		if err := a.instanceLease.check(); err != nil {
+			return err
+		}
+	}
+	if a.cfg.CheckAgglayerHeightBeforeSend {
+		return a.checkAgglayerLastCertificate(ctx)
+	}
+	return nil
+}
+
+// saveCertificateToStorage saves the certificate to the storage and acknowledges its journal entry
+// in the same transaction. It retries if it fails. if param retries == 0 it retries indefinitely
 func (a *AggSender) saveCertificateToStorage(ctx context.Context, cert types.Certificate, maxRetries int) error {
+	batch := db.CertificateWriteBatch{
+		Certificate:  cert,
+		JournalState: db.CertificateJournalStateAcknowledged,
+	}
 	retries := 1
 	err := fmt.Errorf("initial_error")
 	for err != nil {
-		if err = a.storage.SaveLastSentCertificate(ctx, cert); err != nil {
+		if err = a.storage.SaveCertificateBatch(ctx, batch); err != nil {
 			// If this happens we can't work as normal, because local DB is outdated, we have to retry
 			a.log.Errorf("error saving last sent certificate %s in db: %w", cert.String(), err)
 			if retries == maxRetries {
@@ -346,6 +728,18 @@ func (a *AggSender) saveCertificateToStorage(ctx context.Context, cert types.Cer
 	return nil
 }
 
+// updateJournalEntryState updates the state of the journal entry of the certificate.
+// An error is only logged because the entry is going to be reconciled on the next startup
+func (a *AggSender) updateJournalEntryState(
+	ctx context.Context,
+	height uint64,
+	state db.CertificateJournalState,
+	certificateID *common.Hash) {
+	if err := a.storage.UpdateCertificateJournalEntryState(ctx, height, state, certificateID); err != nil {
+		a.log.Errorf("error updating journal entry for height %d to state %s: %v", height, state, err)
+	}
+}
+
 // saveNonAcceptedCert saves a certificate that was not accepted by the aggLayer in db
 func (a *AggSender) saveNonAcceptedCert(
 	ctx context.Context,
@@ -8,8 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +27,7 @@ import (
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/config/types"
+	aggkitdb "github.com/agglayer/aggkit/db"
 	mocksdb "github.com/agglayer/aggkit/db/compatibility/mocks"
 	aggkitgrpc "github.com/agglayer/aggkit/grpc"
 	"github.com/agglayer/aggkit/log"
@@ -33,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -77,11 +83,11 @@ func TestAggSenderStart(t *testing.T) {
 	rollupQuerierMock := mocks.NewRollupDataQuerier(t)
 	ch := make(chan aggsendertypes.EpochEvent)
 	epochNotifierMock.EXPECT().Subscribe("aggsender").Return(ch)
-	epochNotifierMock.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{}).Once()
+	epochNotifierMock.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{})
 	bridgeL2SyncerMock.EXPECT().OriginNetwork().Return(uint32(1))
 	bridgeL2SyncerMock.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(0), nil)
-	aggLayerMock.EXPECT().GetLatestPendingCertificateHeader(mock.Anything, mock.Anything).Return(nil, nil)
-	aggLayerMock.EXPECT().GetLatestSettledCertificateHeader(mock.Anything, mock.Anything).Return(nil, nil)
+	aggLayerMock.EXPECT().GetLatestKnownCertificate(mock.Anything, mock.Anything).Return(nil, nil)
+	aggLayerMock.EXPECT().GetLatestSettledCertificate(mock.Anything, mock.Anything).Return(nil, nil)
 
 	ctx := t.Context()
 	aggSender, err := New(
@@ -214,7 +220,7 @@ func TestSendCertificate_NoClaims(t *testing.T) {
 		flow: flows.NewPPFlow(logger,
 			flows.NewBaseFlow(logger, mockL2BridgeQuerier, mockStorage,
 				mockL1Querier, mockLERQuerier, flows.NewBaseFlowConfigDefault()),
-			mockStorage, mockL1Querier, mockL2BridgeQuerier, signer, true, 0),
+			mockStorage, mockL1Querier, mockL2BridgeQuerier, signer, true, 0, 0, nil),
 		rateLimiter: aggkitcommon.NewRateLimit(aggkitcommon.RateLimitConfig{}),
 	}
 
@@ -225,7 +231,9 @@ func TestSendCertificate_NoClaims(t *testing.T) {
 		ToBlock:          10,
 		Status:           agglayertypes.Settled,
 	}, nil).Once()
-	mockStorage.EXPECT().SaveLastSentCertificate(mock.Anything, mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.MatchedBy(func(batch db.CertificateWriteBatch) bool {
+		return batch.JournalState == db.CertificateJournalStateAcknowledged
+	})).Return(nil).Once()
 	mockL2BridgeQuerier.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(50), nil)
 	mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(mock.Anything, uint64(11), uint64(50)).Return([]bridgesync.Bridge{
 		{
@@ -244,7 +252,24 @@ func TestSendCertificate_NoClaims(t *testing.T) {
 	mockL1Querier.EXPECT().GetLatestFinalizedL1InfoRoot(ctx).Return(&treetypes.Root{}, nil, nil).Once()
 	mockL2BridgeQuerier.EXPECT().GetExitRootByIndex(mock.Anything, uint32(1)).Return(common.Hash{}, nil).Once()
 	mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1)).Once()
+	mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
 	mockAggLayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.Hash{}, nil).Once()
+	mockStorage.EXPECT().SaveCertificateAnalytics(mock.Anything, mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().SaveCertificateAuditEvents(mock.Anything, mock.MatchedBy(
+		func(events []*aggsendertypes.CertificateAuditEvent) bool {
+			eventTypes := make([]aggsendertypes.CertificateAuditEventType, 0, len(events))
+			for _, event := range events {
+				if event.Height != 2 {
+					return false
+				}
+				eventTypes = append(eventTypes, event.Event)
+			}
+			return reflect.DeepEqual([]aggsendertypes.CertificateAuditEventType{
+				aggsendertypes.CertificateAuditRangeSelected,
+				aggsendertypes.CertificateAuditSigned,
+				aggsendertypes.CertificateAuditSubmissionAttempt,
+			}, eventTypes)
+		})).Return(nil).Once()
 	mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{})
 	signedCertificate, err := aggSender.sendCertificate(ctx)
 	require.NoError(t, err)
@@ -307,9 +332,11 @@ func TestSendCertificate(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name          string
-		mockFn        func(*mocks.AggSenderStorage, *mocks.AggsenderFlow, *agglayer.AgglayerClientMock)
-		expectedError string
+		name                string
+		checkAgglayerHeight bool
+		feeBudget           aggsendertypes.FeeBudgetConfig
+		mockFn              func(*mocks.AggSenderStorage, *mocks.AggsenderFlow, *agglayer.AgglayerClientMock)
+		expectedError       string
 	}{
 		{
 			name: "error getting certificate build params",
@@ -354,8 +381,12 @@ func TestSendCertificate(t *testing.T) {
 					NewLocalExitRoot: common.HexToHash("0x1"),
 					BridgeExits:      []*agglayertypes.BridgeExit{{}},
 				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
 				mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.Hash{}, errors.New("some error")).Once()
 				mockStorage.EXPECT().SaveNonAcceptedCertificate(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().UpdateCertificateJournalEntryState(mock.Anything, uint64(0),
+					db.CertificateJournalStateDiscarded, (*common.Hash)(nil)).Return(nil).Once()
 			},
 			expectedError: "error sending certificate",
 		},
@@ -373,11 +404,100 @@ func TestSendCertificate(t *testing.T) {
 					NewLocalExitRoot: common.HexToHash("0x11"),
 					BridgeExits:      []*agglayertypes.BridgeExit{{}},
 				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
 				mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.HexToHash("0x22"), nil).Once()
-				mockStorage.EXPECT().SaveLastSentCertificate(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
 			},
 			expectedError: "error saving last sent certificate",
 		},
+		{
+			name: "error saving journal entry",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockFlow *mocks.AggsenderFlow,
+				mockAgglayerClient *agglayer.AgglayerClientMock) {
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+					Bridges: []bridgesync.Bridge{{}},
+				}, nil).Once()
+				mockFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+					NetworkID:        11,
+					Height:           0,
+					NewLocalExitRoot: common.HexToHash("0x11"),
+					BridgeExits:      []*agglayertypes.BridgeExit{{}},
+				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
+			},
+			expectedError: "error saving journal entry",
+		},
+		{
+			name: "error saving build params snapshot",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockFlow *mocks.AggsenderFlow,
+				mockAgglayerClient *agglayer.AgglayerClientMock) {
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+					Bridges: []bridgesync.Bridge{{}},
+				}, nil).Once()
+				mockFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+					NetworkID:        11,
+					Height:           0,
+					NewLocalExitRoot: common.HexToHash("0x11"),
+					BridgeExits:      []*agglayertypes.BridgeExit{{}},
+				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
+			},
+			expectedError: "error saving build params snapshot",
+		},
+		{
+			name:                "certificate sent by another instance",
+			checkAgglayerHeight: true,
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockFlow *mocks.AggsenderFlow,
+				mockAgglayerClient *agglayer.AgglayerClientMock) {
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+					Bridges: []bridgesync.Bridge{{}},
+				}, nil).Once()
+				mockFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+					NetworkID:        11,
+					Height:           1,
+					NewLocalExitRoot: common.HexToHash("0x11"),
+					BridgeExits:      []*agglayertypes.BridgeExit{{}},
+				}, nil).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(
+					&aggsendertypes.CertificateHeader{Height: 0, CertificateID: common.HexToHash("0x22")}, nil).Once()
+				mockAgglayerClient.EXPECT().GetLatestKnownCertificate(mock.Anything, uint32(0)).Return(
+					&agglayertypes.CertificateHeader{Height: 1, CertificateID: common.HexToHash("0x33")}, nil).Once()
+				mockAgglayerClient.EXPECT().GetLatestSettledCertificate(mock.Anything, uint32(0)).Return(
+					&agglayertypes.CertificateHeader{Height: 0, CertificateID: common.HexToHash("0x22")}, nil).Once()
+			},
+			expectedError: ErrAnotherInstanceDetected.Error(),
+		},
+		{
+			name:      "successful sending of a certificate, its fee is recorded",
+			feeBudget: aggsendertypes.FeeBudgetConfig{Enabled: true, BaseFeePerCertificate: 10, FeePerExit: 1, MaxFeePerDay: 100},
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockFlow *mocks.AggsenderFlow,
+				mockAgglayerClient *agglayer.AgglayerClientMock) {
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+					Bridges: []bridgesync.Bridge{{}},
+				}, nil).Once()
+				mockFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+					NetworkID:        11,
+					Height:           0,
+					NewLocalExitRoot: common.HexToHash("0x11"),
+					BridgeExits:      []*agglayertypes.BridgeExit{{}},
+				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
+				mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.HexToHash("0x22"), nil).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateFee(mock.Anything, mock.MatchedBy(func(fee *db.CertificateFee) bool {
+					return fee.CertificateID == common.HexToHash("0x22") && fee.Fee == 11 &&
+						fee.FeeSource == aggsendertypes.CertificateFeeSourceConfig
+				})).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateAnalytics(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
 		{
 			name: "successful sending and saving of a certificate",
 			mockFn: func(mockStorage *mocks.AggSenderStorage,
@@ -392,8 +512,38 @@ func TestSendCertificate(t *testing.T) {
 					NewLocalExitRoot: common.HexToHash("0x11"),
 					BridgeExits:      []*agglayertypes.BridgeExit{{}},
 				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
 				mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.HexToHash("0x22"), nil).Once()
-				mockStorage.EXPECT().SaveLastSentCertificate(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.MatchedBy(func(batch db.CertificateWriteBatch) bool {
+					return batch.Certificate.Header.CertificateID == common.HexToHash("0x22") &&
+						batch.JournalState == db.CertificateJournalStateAcknowledged
+				})).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateAnalytics(mock.Anything, mock.MatchedBy(
+					func(analytics *aggsendertypes.CertificateAnalytics) bool {
+						return analytics.CertificateID == common.HexToHash("0x22") && analytics.AssetExits == 1
+					})).Return(nil).Once()
+			},
+		},
+		{
+			name: "error saving the analytics doesn't fail the certificate",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockFlow *mocks.AggsenderFlow,
+				mockAgglayerClient *agglayer.AgglayerClientMock) {
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+					Bridges: []bridgesync.Bridge{{}},
+				}, nil).Once()
+				mockFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+					NetworkID:        11,
+					Height:           0,
+					NewLocalExitRoot: common.HexToHash("0x11"),
+					BridgeExits:      []*agglayertypes.BridgeExit{{}},
+				}, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil).Once()
+				mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.HexToHash("0x22"), nil).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.Anything).Return(nil).Once()
+				mockStorage.EXPECT().SaveCertificateAnalytics(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
 			},
 		},
 	}
@@ -409,6 +559,7 @@ func TestSendCertificate(t *testing.T) {
 			mockAgglayerClient := agglayer.NewAgglayerClientMock(t)
 			mockEpochNotifier := mocks.NewEpochNotifier(t)
 			tt.mockFn(mockStorage, mockAggsenderFlow, mockAgglayerClient)
+			mockStorage.EXPECT().SaveCertificateAuditEvents(mock.Anything, mock.Anything).Return(nil).Maybe()
 
 			logger := log.WithFields("aggsender-test", "sendCertificate")
 
@@ -420,8 +571,10 @@ func TestSendCertificate(t *testing.T) {
 				aggLayerClient: mockAgglayerClient,
 				rateLimiter:    aggkitcommon.NewRateLimit(aggkitcommon.RateLimitConfig{}),
 				cfg: config.Config{
-					MaxRetriesStoreCertificate: 1,
+					MaxRetriesStoreCertificate:    1,
+					CheckAgglayerHeightBeforeSend: tt.checkAgglayerHeight,
 				},
+				feeBudget: newFeeBudget(logger, tt.feeBudget, mockStorage, mockEpochNotifier),
 			}
 			mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{})
 			_, err := aggsender.sendCertificate(context.Background())
@@ -487,6 +640,8 @@ func TestSendCertificates(t *testing.T) {
 		mockFn                  func(*mocks.CertificateStatusChecker, *mocks.EpochNotifier, *mocks.AggSenderStorage, *mocks.AggsenderFlow)
 		returnAfterNIterations  int
 		certStatusCheckInterval time.Duration
+		rolloverRecheckInterval time.Duration
+		epochRollover           epochRolloverTracker
 	}{
 		{
 			name: "context canceled",
@@ -503,12 +658,49 @@ func TestSendCertificates(t *testing.T) {
 					ExistNewInErrorCert: true,
 				}).Once()
 				mockEpochNotifier.EXPECT().Subscribe("aggsender").Return(make(chan aggsendertypes.EpochEvent)).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&aggsendertypes.CertificateHeader{
+					Status:        agglayertypes.InError,
+					ErrorCategory: aggsendertypes.CertificateErrorProofVerification,
+				}, nil).Once()
 				mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{}).Once()
 				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(nil, nil).Once()
 			},
 			returnAfterNIterations:  1,
 			certStatusCheckInterval: 100 * time.Millisecond,
 		},
+		{
+			name: "in-error certificate with wait retry policy",
+			mockFn: func(mockCertStatusChecker *mocks.CertificateStatusChecker, mockEpochNotifier *mocks.EpochNotifier, mockStorage *mocks.AggSenderStorage, mockFlow *mocks.AggsenderFlow) {
+				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
+					ExistPendingCerts:   false,
+					ExistNewInErrorCert: true,
+				}).Once()
+				mockEpochNotifier.EXPECT().Subscribe("aggsender").Return(make(chan aggsendertypes.EpochEvent)).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&aggsendertypes.CertificateHeader{
+					Status:        agglayertypes.InError,
+					ErrorCategory: aggsendertypes.CertificateErrorAgglayerInternal,
+					UpdatedAt:     uint32(time.Now().UTC().Unix()),
+				}, nil).Once()
+			},
+			returnAfterNIterations:  1,
+			certStatusCheckInterval: 100 * time.Millisecond,
+		},
+		{
+			name: "epoch received with in-error certificate requiring manual intervention",
+			mockFn: func(mockCertStatusChecker *mocks.CertificateStatusChecker, mockEpochNotifier *mocks.EpochNotifier, mockStorage *mocks.AggSenderStorage, mockFlow *mocks.AggsenderFlow) {
+				chEpoch := make(chan aggsendertypes.EpochEvent, 1)
+				chEpoch <- aggsendertypes.EpochEvent{Epoch: 1}
+				mockEpochNotifier.EXPECT().Subscribe("aggsender").Return(chEpoch).Once()
+				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
+					ExistPendingCerts: false,
+				}).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&aggsendertypes.CertificateHeader{
+					Status:        agglayertypes.InError,
+					ErrorCategory: aggsendertypes.CertificateErrorLERMismatch,
+				}, nil).Once()
+			},
+			returnAfterNIterations: 1,
+		},
 		{
 			name: "epoch received with no pending certificates",
 			mockFn: func(mockCertStatusChecker *mocks.CertificateStatusChecker, mockEpochNotifier *mocks.EpochNotifier, mockStorage *mocks.AggSenderStorage, mockFlow *mocks.AggsenderFlow) {
@@ -519,6 +711,7 @@ func TestSendCertificates(t *testing.T) {
 				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
 					ExistPendingCerts: false,
 				}).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(nil, nil).Once()
 				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(nil, nil).Once()
 			},
 			returnAfterNIterations: 1,
@@ -535,6 +728,39 @@ func TestSendCertificates(t *testing.T) {
 			},
 			returnAfterNIterations: 1,
 		},
+		{
+			name: "epoch rollover: the pending certificate of the previous epoch is checked again",
+			mockFn: func(mockCertStatusChecker *mocks.CertificateStatusChecker, mockEpochNotifier *mocks.EpochNotifier, mockStorage *mocks.AggSenderStorage, mockFlow *mocks.AggsenderFlow) {
+				chEpoch := make(chan aggsendertypes.EpochEvent, 1)
+				chEpoch <- aggsendertypes.EpochEvent{Epoch: 2}
+				mockEpochNotifier.EXPECT().Subscribe("aggsender").Return(chEpoch).Once()
+				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
+					ExistPendingCerts: true,
+				}).Once()
+				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
+					ExistPendingCerts: false,
+				}).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(nil, nil).Once()
+				mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{Epoch: 2}).Once()
+				mockFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(nil, nil).Once()
+			},
+			returnAfterNIterations:  2,
+			rolloverRecheckInterval: 10 * time.Millisecond,
+			epochRollover:           epochRolloverTracker{tracking: true, submittedEpoch: 1},
+		},
+		{
+			name: "epoch rollover without recheck interval waits for the next epoch",
+			mockFn: func(mockCertStatusChecker *mocks.CertificateStatusChecker, mockEpochNotifier *mocks.EpochNotifier, mockStorage *mocks.AggSenderStorage, mockFlow *mocks.AggsenderFlow) {
+				chEpoch := make(chan aggsendertypes.EpochEvent, 1)
+				chEpoch <- aggsendertypes.EpochEvent{Epoch: 2}
+				mockEpochNotifier.EXPECT().Subscribe("aggsender").Return(chEpoch).Once()
+				mockCertStatusChecker.EXPECT().CheckPendingCertificatesStatus(mock.Anything).Return(aggsendertypes.CertStatus{
+					ExistPendingCerts: true,
+				}).Once()
+			},
+			returnAfterNIterations: 2,
+			epochRollover:          epochRolloverTracker{tracking: true, submittedEpoch: 1},
+		},
 	}
 
 	for _, tt := range tests {
@@ -560,8 +786,11 @@ func TestSendCertificates(t *testing.T) {
 				cfg: config.Config{
 					RetryCertAfterInError:          true,
 					CheckStatusCertificateInterval: types.NewDuration(tt.certStatusCheckInterval),
+					InErrorWaitRetryDelay:          types.NewDuration(time.Hour),
+					EpochRolloverRecheckInterval:   types.NewDuration(tt.rolloverRecheckInterval),
 				},
-				status: &aggsendertypes.AggsenderStatus{},
+				status:        &aggsendertypes.AggsenderStatus{},
+				epochRollover: tt.epochRollover,
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -680,7 +909,7 @@ func newAggsenderTestData(t *testing.T, creationFlags testDataFlags) *aggsenderT
 		flow: flows.NewPPFlow(logger,
 			flows.NewBaseFlow(logger, l2BridgeQuerier, storage,
 				l1InfoTreeQuerierMock, lerQuerier, flows.NewBaseFlowConfigDefault()),
-			storage, l1InfoTreeQuerierMock, l2BridgeQuerier, signer, true, 0),
+			storage, l1InfoTreeQuerierMock, l2BridgeQuerier, signer, true, 0, 0, nil),
 	}
 	var flowMock *mocks.AggsenderFlow
 	if creationFlags&testDataFlagMockFlow != 0 {
@@ -713,3 +942,819 @@ func newAggsenderTestData(t *testing.T, creationFlags testDataFlags) *aggsenderT
 		sut:                     sut,
 	}
 }
+
+func TestInstanceLease(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := log.WithFields("aggsender-test", "TestInstanceLease")
+	dbPath := path.Join(t.TempDir(), "TestInstanceLease.sqlite")
+	newStorage := func() db.AggSenderStorage {
+		storage, err := db.NewAggSenderSQLStorage(logger, db.AggSenderSQLStorageConfig{DBPath: dbPath})
+		require.NoError(t, err)
+		return storage
+	}
+
+	first, err := newInstanceLease(logger, newStorage(), time.Minute)
+	require.NoError(t, err)
+	second, err := newInstanceLease(logger, newStorage(), time.Minute)
+	require.NoError(t, err)
+	require.NotEqual(t, first.ownerID, second.ownerID)
+
+	require.Error(t, first.check(), "the lease is not acquired yet")
+	require.NoError(t, first.acquire(ctx))
+	require.NoError(t, first.check())
+
+	// the second instance can't acquire the lease while the first one holds it
+	err = second.acquire(ctx)
+	require.ErrorIs(t, err, db.ErrInstanceLeaseHeld)
+	require.ErrorIs(t, second.check(), ErrAnotherInstanceDetected)
+
+	// the lease is released when the first instance stops
+	done := make(chan struct{})
+	go func() {
+		first.start(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+	require.NoError(t, second.acquire(context.Background()))
+	require.NoError(t, second.check())
+}
+
+func TestAcquireInstanceLease(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		testData := newAggsenderTestData(t, testDataFlagMockStorage)
+		testData.sut.acquireInstanceLease(testData.ctx)
+	})
+
+	t.Run("held by another instance panics", func(t *testing.T) {
+		testData := newAggsenderTestData(t, testDataFlagMockStorage)
+		lease, err := newInstanceLease(testData.sut.log, testData.storageMock, time.Minute)
+		require.NoError(t, err)
+		testData.sut.instanceLease = lease
+		testData.storageMock.EXPECT().AcquireInstanceLease(mock.Anything, lease.ownerID, mock.Anything, time.Minute).
+			Return(nil, fmt.Errorf("%w: lease{owner:other}", db.ErrInstanceLeaseHeld)).Once()
+		require.Panics(t, func() {
+			testData.sut.acquireInstanceLease(testData.ctx)
+		})
+	})
+}
+
+func TestCheckAgglayerLastCertificate(t *testing.T) {
+	lastSentID := common.HexToHash("0x1")
+	otherID := common.HexToHash("0x2")
+
+	tests := []struct {
+		name        string
+		lastSent    *aggsendertypes.CertificateHeader
+		pending     *agglayertypes.CertificateHeader
+		settled     *agglayertypes.CertificateHeader
+		expectedErr error
+	}{
+		{
+			name: "no certificates on agglayer",
+		},
+		{
+			name:     "last pending certificate sent by this instance",
+			lastSent: &aggsendertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+			pending:  &agglayertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+			settled:  &agglayertypes.CertificateHeader{Height: 4, CertificateID: otherID},
+		},
+		{
+			name:     "last settled certificate sent by this instance",
+			lastSent: &aggsendertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+			settled:  &agglayertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+		},
+		{
+			name:        "agglayer has a certificate but this instance has sent none",
+			settled:     &agglayertypes.CertificateHeader{Height: 0, CertificateID: otherID},
+			expectedErr: ErrAnotherInstanceDetected,
+		},
+		{
+			name:        "certificate of the same height sent by another instance",
+			lastSent:    &aggsendertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+			pending:     &agglayertypes.CertificateHeader{Height: 5, CertificateID: otherID},
+			expectedErr: ErrAnotherInstanceDetected,
+		},
+		{
+			name:        "newer certificate sent by another instance",
+			lastSent:    &aggsendertypes.CertificateHeader{Height: 5, CertificateID: lastSentID},
+			settled:     &agglayertypes.CertificateHeader{Height: 6, CertificateID: otherID},
+			expectedErr: ErrAnotherInstanceDetected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testData := newAggsenderTestData(t, testDataFlagMockStorage)
+			testData.storageMock.EXPECT().GetLastSentCertificateHeader().Return(tt.lastSent, nil).Once()
+			testData.agglayerClientMock.EXPECT().GetLatestKnownCertificate(mock.Anything, networkIDTest).
+				Return(tt.pending, nil).Once()
+			testData.agglayerClientMock.EXPECT().GetLatestSettledCertificate(mock.Anything, networkIDTest).
+				Return(tt.settled, nil).Once()
+
+			err := testData.sut.checkAgglayerLastCertificate(testData.ctx)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCheckLocalExitRootAgainstContract(t *testing.T) {
+	certLER := common.HexToHash("0x1")
+	otherLER := common.HexToHash("0x2")
+	params := &aggsendertypes.CertificateBuildParams{
+		FromBlock: 10,
+		ToBlock:   20,
+		Bridges:   []bridgesync.Bridge{{DepositCount: 4}},
+	}
+
+	tests := []struct {
+		name          string
+		contractRoot  common.Hash
+		depositCount  uint32
+		contractErr   error
+		syncerErr     error
+		expectedErr   error
+		expectedError string
+	}{
+		{
+			name:         "local exit root matches",
+			contractRoot: certLER,
+			depositCount: 5,
+		},
+		{
+			name:         "no deposits on the contract",
+			contractRoot: otherLER,
+		},
+		{
+			name:          "error reading the contract",
+			contractErr:   errors.New("missing trie node"),
+			expectedError: "error getting the local exit root of the bridge contract at block 20: missing trie node",
+		},
+		{
+			name:          "local exit root mismatch",
+			contractRoot:  otherLER,
+			depositCount:  6,
+			expectedErr:   ErrLocalExitRootMismatch,
+			expectedError: "(5 deposits), but the bridge contract has the local exit root " + otherLER.Hex() + " and 6 deposits",
+		},
+		{
+			name:          "local exit root mismatch and the syncer misses the deposits",
+			contractRoot:  otherLER,
+			depositCount:  6,
+			syncerErr:     errors.New("not found"),
+			expectedErr:   ErrLocalExitRootMismatch,
+			expectedError: "The root of the syncer for 6 deposits is error: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l2Syncer := mocks.NewL2BridgeSyncer(t)
+			l2Syncer.EXPECT().GetContractLocalExitRoot(mock.Anything, params.ToBlock).
+				Return(tt.contractRoot, tt.depositCount, tt.contractErr).Once()
+			if tt.expectedErr != nil {
+				l2Syncer.EXPECT().GetExitRootByIndex(mock.Anything, tt.depositCount-1).
+					Return(treetypes.Root{Hash: certLER}, tt.syncerErr).Once()
+			}
+			sut := &AggSender{log: log.WithFields("aggsender-test", "ler"), l2Syncer: l2Syncer}
+
+			err := sut.checkLocalExitRootAgainstContract(context.Background(),
+				&agglayertypes.Certificate{Height: 3, NewLocalExitRoot: certLER}, params)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			}
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+// heightQuerierAgglayerClientMock is an agglayer client that can query the certificates by height
+type heightQuerierAgglayerClientMock struct {
+	*agglayer.AgglayerClientMock
+	headers map[uint64]*agglayertypes.CertificateHeader
+	queried []uint64
+}
+
+func (m *heightQuerierAgglayerClientMock) GetCertificateHeaderByHeight(_ context.Context, _ uint32,
+	height uint64) (*agglayertypes.CertificateHeader, error) {
+	m.queried = append(m.queried, height)
+	header, ok := m.headers[height]
+	if !ok {
+		return nil, errors.New("agglayer unreachable")
+	}
+	return header, nil
+}
+
+func TestCertificateMirror(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("aggsender-test", "mirror")
+	certID := func(height uint64) common.Hash { return common.BigToHash(new(big.Int).SetUint64(height + 1)) }
+	header := func(height uint64, status agglayertypes.CertificateStatus) *agglayertypes.CertificateHeader {
+		return &agglayertypes.CertificateHeader{
+			NetworkID:     networkIDTest,
+			Height:        height,
+			CertificateID: certID(height),
+			Status:        status,
+		}
+	}
+	savedHeights := func(heights ...uint64) interface{} {
+		return mock.MatchedBy(func(headers []*aggsendertypes.MirroredCertificateHeader) bool {
+			if len(headers) != len(heights) {
+				return false
+			}
+			for i, h := range headers {
+				if h.Height != heights[i] || h.MirroredAt != 1000 {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	newMirror := func(client agglayer.AgglayerClientInterface, storage db.AggSenderStorage,
+		maxHeadersPerRun uint32) *certificateMirror {
+		m := newCertificateMirror(logger, storage, client, networkIDTest, time.Minute, maxHeadersPerRun)
+		m.timeNowFn = func() time.Time { return time.Unix(1000, 0) }
+		return m
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		require.Nil(t, newCertificateMirror(logger, nil, nil, networkIDTest, 0, 0))
+	})
+
+	t.Run("nothing on agglayer", func(t *testing.T) {
+		client := agglayer.NewAgglayerClientMock(t)
+		client.EXPECT().GetLatestKnownCertificate(ctx, networkIDTest).Return(nil, nil).Once()
+		client.EXPECT().GetLatestSettledCertificate(ctx, networkIDTest).Return(nil, nil).Once()
+
+		require.NoError(t, newMirror(client, mocks.NewAggSenderStorage(t), 0).mirror(ctx))
+	})
+
+	t.Run("by height, the settled headers are not queried again", func(t *testing.T) {
+		client := &heightQuerierAgglayerClientMock{
+			AgglayerClientMock: agglayer.NewAgglayerClientMock(t),
+			headers:            map[uint64]*agglayertypes.CertificateHeader{1: header(1, agglayertypes.Settled)},
+		}
+		client.EXPECT().GetLatestKnownCertificate(ctx, networkIDTest).
+			Return(header(3, agglayertypes.Pending), nil).Once()
+		client.EXPECT().GetLatestSettledCertificate(ctx, networkIDTest).
+			Return(header(2, agglayertypes.Settled), nil).Once()
+		storage := mocks.NewAggSenderStorage(t)
+		storage.EXPECT().GetMirroredCertificateHeaders(uint64(0), uint64(3)).Return(
+			[]*aggsendertypes.MirroredCertificateHeader{{Height: 0, Status: agglayertypes.Settled}}, nil).Once()
+		storage.EXPECT().SaveMirroredCertificateHeaders(ctx, savedHeights(2, 3, 1)).Return(nil).Once()
+
+		require.NoError(t, newMirror(client, storage, 0).mirror(ctx))
+		require.Equal(t, []uint64{1}, client.queried)
+	})
+
+	t.Run("by height, limited per run and saved on error", func(t *testing.T) {
+		client := &heightQuerierAgglayerClientMock{
+			AgglayerClientMock: agglayer.NewAgglayerClientMock(t),
+			headers:            map[uint64]*agglayertypes.CertificateHeader{0: header(0, agglayertypes.Settled)},
+		}
+		client.EXPECT().GetLatestKnownCertificate(ctx, networkIDTest).Return(nil, nil).Twice()
+		client.EXPECT().GetLatestSettledCertificate(ctx, networkIDTest).
+			Return(header(5, agglayertypes.Settled), nil).Twice()
+		storage := mocks.NewAggSenderStorage(t)
+		storage.EXPECT().GetMirroredCertificateHeaders(uint64(0), uint64(5)).Return(nil, nil).Twice()
+		storage.EXPECT().SaveMirroredCertificateHeaders(ctx, savedHeights(5, 0)).Return(nil).Twice()
+
+		// the limit stops after the height 0
+		require.NoError(t, newMirror(client, storage, 1).mirror(ctx))
+		// the height 1 can't be queried
+		err := newMirror(client, storage, 0).mirror(ctx)
+		require.ErrorContains(t, err, "error getting the certificate of height 1 from agglayer: agglayer unreachable")
+		require.Equal(t, []uint64{0, 0, 1}, client.queried)
+	})
+
+	t.Run("by certificate ID of the local storage and of the mirror", func(t *testing.T) {
+		client := agglayer.NewAgglayerClientMock(t)
+		client.EXPECT().GetLatestKnownCertificate(ctx, networkIDTest).Return(nil, nil).Once()
+		client.EXPECT().GetLatestSettledCertificate(ctx, networkIDTest).
+			Return(header(4, agglayertypes.Settled), nil).Once()
+		storage := mocks.NewAggSenderStorage(t)
+		storage.EXPECT().GetMirroredCertificateHeaders(uint64(0), uint64(4)).Return(
+			[]*aggsendertypes.MirroredCertificateHeader{
+				// the status of the certificate in error of the height 2 is not going to change
+				{Height: 2, CertificateID: certID(2), Status: agglayertypes.InError},
+				{Height: 3, CertificateID: certID(3), Status: agglayertypes.Pending},
+			}, nil).Once()
+		// the height 0 is unknown, the height 1 was sent by this instance
+		storage.EXPECT().GetCertificateHeaderByHeight(uint64(0)).Return(nil, aggkitdb.ErrNotFound).Once()
+		storage.EXPECT().GetCertificateHeaderByHeight(uint64(1)).
+			Return(&aggsendertypes.CertificateHeader{Height: 1, CertificateID: certID(1)}, nil).Once()
+		storage.EXPECT().GetCertificateHeaderByHeight(uint64(2)).Return(nil, aggkitdb.ErrNotFound).Once()
+		storage.EXPECT().GetCertificateHeaderByHeight(uint64(3)).Return(nil, aggkitdb.ErrNotFound).Once()
+		client.EXPECT().GetCertificateHeader(ctx, certID(1)).
+			Return(header(1, agglayertypes.Settled), nil).Once()
+		client.EXPECT().GetCertificateHeader(ctx, certID(3)).
+			Return(header(3, agglayertypes.Settled), nil).Once()
+		storage.EXPECT().SaveMirroredCertificateHeaders(ctx, savedHeights(4, 1, 3)).Return(nil).Once()
+
+		require.NoError(t, newMirror(client, storage, 0).mirror(ctx))
+	})
+}
+
+func TestFeeBudget(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("aggsender-test", "TestFeeBudget")
+	dbPath := path.Join(t.TempDir(), "TestFeeBudget.sqlite")
+	storage, err := db.NewAggSenderSQLStorage(logger, db.AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	cfg := aggsendertypes.FeeBudgetConfig{
+		Enabled:               true,
+		BaseFeePerCertificate: 100,
+		FeePerExit:            25,
+		MaxFeePerEpoch:        300,
+		MaxFeePerDay:          400,
+	}
+	epochNotifier := mocks.NewEpochNotifier(t)
+	require.Nil(t, newFeeBudget(logger, aggsendertypes.FeeBudgetConfig{}, storage, epochNotifier))
+	require.NoError(t, (*feeBudget)(nil).CheckFeeBudget(ctx, &aggsendertypes.CertificateBuildParams{}))
+
+	budget := newFeeBudget(logger, cfg, storage, epochNotifier)
+	// 2 exits (100 + 2 * 25)
+	cert := &agglayertypes.Certificate{Height: 1, BridgeExits: []*agglayertypes.BridgeExit{{}},
+		ImportedBridgeExits: []*agglayertypes.ImportedBridgeExit{{}}}
+	now := time.Now()
+
+	// the fee of the build params (a bridge and a claim) is checked in the current epoch
+	epochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{Epoch: 1}).Once()
+	require.NoError(t, budget.CheckFeeBudget(ctx, &aggsendertypes.CertificateBuildParams{
+		Bridges: []bridgesync.Bridge{{}}, Claims: []bridgesync.Claim{{}},
+	}))
+	budget.record(ctx, &aggsendertypes.CertificateHeader{Height: 1}, cert, 1, now)
+
+	// the budget of the epoch is exhausted (150 + 175 > 300), but not the one of the next epoch
+	require.ErrorIs(t, budget.check(3, "cert", 1, now), ErrFeeBudgetExhausted)
+	require.ErrorContains(t, budget.check(3, "cert", 1, now), "MaxFeePerEpoch")
+	require.NoError(t, budget.check(3, "cert", 2, now))
+	budget.record(ctx, &aggsendertypes.CertificateHeader{Height: 2}, cert, 2, now)
+
+	// the budget of the day is exhausted (150 + 150 + 150 > 400)
+	err = budget.check(2, "cert", 3, now)
+	require.ErrorIs(t, err, ErrFeeBudgetExhausted)
+	require.ErrorContains(t, err, "MaxFeePerDay")
+
+	// the fees older than a day are not counted
+	require.NoError(t, budget.check(2, "cert", 3, now.Add(25*time.Hour)))
+}
+
+func TestProverSLO(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("aggsender-test", "TestProverSLO")
+	dbPath := path.Join(t.TempDir(), "TestProverSLO.sqlite")
+	storage, err := db.NewAggSenderSQLStorage(logger, db.AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	var alerts []proverSLOAlert
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var alert proverSLOAlert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		alerts = append(alerts, alert)
+	}))
+	defer webhook.Close()
+
+	cfg := aggsendertypes.ProverSLOConfig{
+		Enabled:        true,
+		WindowSize:     3,
+		Percentile:     50,
+		MaxProvingTime: types.NewDuration(10 * time.Second),
+		MaxProofSize:   1000,
+		WebhookURL:     webhook.URL,
+		WebhookTimeout: types.NewDuration(time.Second),
+	}
+	require.Nil(t, newProverSLO(logger, aggsendertypes.ProverSLOConfig{}, storage, networkIDTest))
+	slo := newProverSLO(logger, cfg, storage, networkIDTest)
+	now := time.Unix(1000, 0)
+	slo.timeNowFn = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	recordProof := func(height uint64, provingTime time.Duration, proofSize int) {
+		proof := &aggsendertypes.SP1StarkProof{Proof: make([]byte, proofSize)}
+		slo.record(ctx, height, &aggsendertypes.CertificateBuildParams{
+			FromBlock:     height * 10,
+			ToBlock:       height*10 + 9,
+			AggchainProof: &aggsendertypes.AggchainProof{SP1StarkProof: proof},
+			ProvingTime:   provingTime,
+		})
+	}
+
+	// the certificates without a proof of the prover are not tracked
+	slo.record(ctx, 0, &aggsendertypes.CertificateBuildParams{})
+	slo.record(ctx, 0, &aggsendertypes.CertificateBuildParams{
+		AggchainProof:   &aggsendertypes.AggchainProof{SP1StarkProof: &aggsendertypes.SP1StarkProof{}},
+		ProvingTime:     time.Second,
+		CertificateType: aggsendertypes.CertificateTypeOptimistic,
+	})
+	stats, err := storage.GetLastProofStats(10)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+
+	// the median of [5s, 20s] is 5s
+	recordProof(1, 5*time.Second, 500)
+	recordProof(2, 20*time.Second, 500)
+	require.Empty(t, alerts)
+
+	// the median of [5s, 20s, 30s] is 20s, so the SLO is breached and alerted once
+	recordProof(3, 30*time.Second, 500)
+	recordProof(4, 25*time.Second, 2000)
+	require.Len(t, alerts, 1)
+	require.Equal(t, proverSLOStatusBreached, alerts[0].Status)
+	require.Equal(t, networkIDTest, alerts[0].NetworkID)
+	require.Equal(t, uint64(3), alerts[0].Height)
+	require.Equal(t, 3, alerts[0].Samples)
+	require.InDelta(t, 20.0, alerts[0].ProvingTimeSeconds, 0.001)
+	require.Len(t, alerts[0].Violations, 1)
+	require.Contains(t, alerts[0].Violations[0], "exceeds MaxProvingTime")
+
+	// the slow proofs leave the window
+	recordProof(5, time.Second, 500)
+	recordProof(6, time.Second, 500)
+	require.Len(t, alerts, 2)
+	require.Equal(t, proverSLOStatusRecovered, alerts[1].Status)
+	require.Equal(t, uint64(6), alerts[1].Height)
+	require.Equal(t, uint64(500), alerts[1].ProofSize)
+	require.Empty(t, alerts[1].Violations)
+
+	stats, err = storage.GetLastProofStats(10)
+	require.NoError(t, err)
+	require.Len(t, stats, 6)
+	require.Equal(t, uint64(6), stats[0].Height)
+}
+
+func TestPercentile(t *testing.T) {
+	values := []uint64{50, 10, 40, 20, 30}
+	require.Equal(t, uint64(0), percentile(nil, 95))
+	require.Equal(t, uint64(10), percentile(values, 1))
+	require.Equal(t, uint64(30), percentile(values, 50))
+	require.Equal(t, uint64(50), percentile(values, 95))
+	require.Equal(t, uint64(50), percentile(values, 100))
+	// the values are not modified
+	require.Equal(t, []uint64{50, 10, 40, 20, 30}, values)
+}
+
+func TestEpochRolloverTracker(t *testing.T) {
+	logger := log.WithFields("aggsender-test", "epoch-rollover")
+	var tracker epochRolloverTracker
+	require.False(t, tracker.isRollover(logger, 1), "no certificate submitted")
+
+	tracker.certificateSubmitted(common.HexToHash("0x1"), 5)
+	require.False(t, tracker.isRollover(logger, 5), "same epoch of the submission")
+	require.True(t, tracker.isRollover(logger, 6))
+	require.Equal(t, uint64(6), tracker.reportedEpoch)
+	require.True(t, tracker.isRollover(logger, 6), "reported once per epoch but still a rollover")
+
+	tracker.certificateSubmitted(common.HexToHash("0x2"), 6)
+	require.False(t, tracker.isRollover(logger, 6))
+	require.Zero(t, tracker.reportedEpoch)
+}
+
+func TestApprovalHook(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("aggsender-test", "TestApprovalHook")
+	params := &aggsendertypes.CertificateBuildParams{
+		FromBlock:           10,
+		ToBlock:             20,
+		LastSentCertificate: &aggsendertypes.CertificateHeader{Height: 4, Status: agglayertypes.Settled},
+		Bridges: []bridgesync.Bridge{
+			{LeafType: agglayertypes.LeafTypeAsset.Uint8(), Amount: big.NewInt(100)},
+			{LeafType: agglayertypes.LeafTypeAsset.Uint8(), Amount: big.NewInt(50)},
+			{LeafType: agglayertypes.LeafTypeMessage.Uint8()},
+		},
+		Claims:          []bridgesync.Claim{{OriginNetwork: 2}},
+		CertificateType: aggsendertypes.CertificateTypePP,
+	}
+
+	var requests []certificateApprovalRequest
+	response := `{"approved": true}`
+	status := http.StatusOK
+	hookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		var request certificateApprovalRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+		w.WriteHeader(status)
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	defer hookServer.Close()
+
+	cfg := aggsendertypes.ApprovalHookConfig{Enabled: true, URL: hookServer.URL, Timeout: types.NewDuration(time.Second)}
+	require.Nil(t, newApprovalHook(logger, aggsendertypes.ApprovalHookConfig{}, networkIDTest))
+	hook := newApprovalHook(logger, cfg, networkIDTest)
+
+	t.Run("approved", func(t *testing.T) {
+		require.NoError(t, hook.approve(ctx, params))
+		require.Len(t, requests, 1)
+		require.Equal(t, networkIDTest, requests[0].NetworkID)
+		require.Equal(t, uint64(5), requests[0].Height)
+		require.Equal(t, "pp", requests[0].CertificateType)
+		require.Equal(t, 3, requests[0].BridgeExits)
+		require.Equal(t, 1, requests[0].ImportedBridgeExits)
+		require.Equal(t, 1, requests[0].MessageExits)
+		require.Equal(t, map[uint32]int{2: 1}, requests[0].ClaimsPerOriginNetwork)
+		key := aggsendertypes.NewTokenKey(0, common.Address{})
+		require.Equal(t, big.NewInt(150), requests[0].BridgedValuePerToken[key])
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		response = `{"approved": false, "reason": "too much value"}`
+		err := hook.approve(ctx, params)
+		require.ErrorIs(t, err, ErrCertificateNotApproved)
+		require.ErrorContains(t, err, "too much value")
+
+		// the rejected certificate is not built (nor signed), and the rejection is in its audit trail
+		mockAggsenderFlow := mocks.NewAggsenderFlow(t)
+		mockEpochNotifier := mocks.NewEpochNotifier(t)
+		mockStorage := mocks.NewAggSenderStorage(t)
+		aggsender := &AggSender{
+			log:           logger,
+			epochNotifier: mockEpochNotifier,
+			flow:          mockAggsenderFlow,
+			storage:       mockStorage,
+			approvalHook:  hook,
+		}
+		mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{})
+		mockAggsenderFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(params, nil)
+		mockStorage.EXPECT().SaveCertificateAuditEvents(mock.Anything, mock.MatchedBy(
+			func(events []*aggsendertypes.CertificateAuditEvent) bool {
+				return len(events) == 1 && events[0].Height == 5 &&
+					events[0].Event == aggsendertypes.CertificateAuditNotSent &&
+					strings.Contains(events[0].Details["reason"].(string), "too much value")
+			})).Return(nil).Once()
+		cert, err := aggsender.sendCertificate(ctx)
+		require.ErrorIs(t, err, ErrCertificateNotApproved)
+		require.Nil(t, cert)
+	})
+
+	t.Run("the hook fails", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		response = "foo"
+		err := hook.approve(ctx, params)
+		require.ErrorIs(t, err, ErrCertificateNotApproved)
+		require.ErrorContains(t, err, "status 500")
+
+		cfg.FailurePolicy = aggsendertypes.ApprovalHookFailOpen
+		require.NoError(t, newApprovalHook(logger, cfg, networkIDTest).approve(ctx, params))
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		status = http.StatusOK
+		response = "foo"
+		cfg.FailurePolicy = aggsendertypes.ApprovalHookFailClosed
+		err := newApprovalHook(logger, cfg, networkIDTest).approve(ctx, params)
+		require.ErrorIs(t, err, ErrCertificateNotApproved)
+		require.ErrorContains(t, err, "error decoding the response")
+	})
+}
+
+func TestNextCertificateHeight(t *testing.T) {
+	require.Equal(t, uint64(0), nextCertificateHeight(nil))
+	require.Equal(t, uint64(3),
+		nextCertificateHeight(&aggsendertypes.CertificateHeader{Height: 3, Status: agglayertypes.InError}))
+	require.Equal(t, uint64(4),
+		nextCertificateHeight(&aggsendertypes.CertificateHeader{Height: 3, Status: agglayertypes.Settled}))
+}
+
+func TestAgglayerMaintenanceTracker(t *testing.T) {
+	logger := log.WithFields("aggsender-test", "agglayer-maintenance")
+	start := time.Now()
+
+	var disabled *agglayerMaintenanceTracker
+	require.Nil(t, newAgglayerMaintenanceTracker(0, time.Minute))
+	require.Zero(t, disabled.maintenance(logger, agglayer.ErrAgglayerMaintenance, start))
+	_, waiting := disabled.waiting(start)
+	require.False(t, waiting)
+	require.Nil(t, disabled.status())
+
+	tracker := newAgglayerMaintenanceTracker(10*time.Second, 30*time.Second)
+	require.Nil(t, tracker.status())
+	require.Equal(t, 10*time.Second, tracker.maintenance(logger, agglayer.ErrAgglayerMaintenance, start))
+	wait, waiting := tracker.waiting(start.Add(time.Second))
+	require.True(t, waiting)
+	require.Equal(t, 9*time.Second, wait)
+	_, waiting = tracker.waiting(start.Add(10 * time.Second))
+	require.False(t, waiting, "time to try again")
+
+	require.Equal(t, 20*time.Second, tracker.maintenance(logger, agglayer.ErrAgglayerMaintenance, start.Add(10*time.Second)))
+	require.Equal(t, 30*time.Second, tracker.maintenance(logger, agglayer.ErrAgglayerMaintenance, start.Add(30*time.Second)))
+	require.Equal(t, 30*time.Second, tracker.maintenance(logger, agglayer.ErrAgglayerMaintenance, start.Add(60*time.Second)))
+	require.Equal(t, &aggsendertypes.AgglayerMaintenanceStatus{
+		Since:       start,
+		Attempts:    4,
+		NextAttempt: start.Add(90 * time.Second),
+		LastError:   agglayer.ErrAgglayerMaintenance.Error(),
+	}, tracker.status())
+
+	tracker.available(logger, start.Add(90*time.Second))
+	require.Nil(t, tracker.status())
+	_, waiting = tracker.waiting(start.Add(90 * time.Second))
+	require.False(t, waiting)
+	require.Equal(t, 10*time.Second, tracker.maintenance(logger, agglayer.ErrAgglayerMaintenance, start))
+}
+
+func TestMaintenanceWindows(t *testing.T) {
+	var disabled *maintenanceWindows
+	windows, err := newMaintenanceWindows(nil)
+	require.NoError(t, err)
+	require.Nil(t, windows)
+	require.Nil(t, disabled.status(time.Now()))
+	_, active := disabled.active(time.Now())
+	require.False(t, active)
+
+	_, err = newMaintenanceWindows(aggsendertypes.MaintenanceWindowsConfig{
+		{Schedule: "0 3 * *", Duration: types.Duration{Duration: time.Hour}},
+	})
+	require.ErrorIs(t, err, aggkitcommon.ErrInvalidCronSchedule)
+
+	// every Tuesday at 03:00 for 1h, and every day at 03:30 for 2h
+	windows, err = newMaintenanceWindows(aggsendertypes.MaintenanceWindowsConfig{
+		{Schedule: "0 3 * * 2", Duration: types.Duration{Duration: time.Hour}},
+		{Schedule: "30 3 * * *", Duration: types.Duration{Duration: 2 * time.Hour}},
+	})
+	require.NoError(t, err)
+	// Tuesday
+	day := time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC)
+
+	// before the windows: the next one
+	require.Equal(t, &aggsendertypes.MaintenanceWindowStatus{
+		Schedule: "0 3 * * 2", Start: day.Add(3 * time.Hour), End: day.Add(4 * time.Hour),
+	}, windows.status(day.Add(time.Hour)))
+	_, active = windows.active(day.Add(time.Hour))
+	require.False(t, active)
+
+	// inside the first window
+	status, active := windows.active(day.Add(3*time.Hour + 10*time.Minute))
+	require.True(t, active)
+	require.Equal(t, &aggsendertypes.MaintenanceWindowStatus{
+		Schedule: "0 3 * * 2", Active: true, Start: day.Add(3 * time.Hour), End: day.Add(4 * time.Hour),
+	}, status)
+
+	// both windows are active: the one that ends last
+	status, active = windows.active(day.Add(3*time.Hour + 45*time.Minute))
+	require.True(t, active)
+	require.Equal(t, &aggsendertypes.MaintenanceWindowStatus{
+		Schedule: "30 3 * * *", Active: true, Start: day.Add(3*time.Hour + 30*time.Minute),
+		End: day.Add(5*time.Hour + 30*time.Minute),
+	}, status)
+
+	// the end of a window is not part of it
+	require.Equal(t, &aggsendertypes.MaintenanceWindowStatus{
+		Schedule: "30 3 * * *", Start: day.Add(27*time.Hour + 30*time.Minute),
+		End: day.Add(29*time.Hour + 30*time.Minute),
+	}, windows.status(day.Add(5*time.Hour+30*time.Minute)))
+}
+
+func TestSendCertificateMaintenanceWindow(t *testing.T) {
+	mockAggsenderFlow := mocks.NewAggsenderFlow(t)
+	windows, err := newMaintenanceWindows(aggsendertypes.MaintenanceWindowsConfig{
+		{Schedule: "* * * * *", Duration: types.Duration{Duration: time.Hour}},
+	})
+	require.NoError(t, err)
+	aggsender := &AggSender{
+		log:                log.WithFields("aggsender-test", "maintenance-window"),
+		flow:               mockAggsenderFlow,
+		rateLimiter:        aggkitcommon.NewRateLimit(aggkitcommon.RateLimitConfig{}),
+		maintenanceWindows: windows,
+	}
+
+	// nothing is built during the window
+	cert, err := aggsender.sendCertificate(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	mockAggsenderFlow.AssertNotCalled(t, "GetCertificateBuildParams", mock.Anything)
+}
+
+func TestSendCertificateAgglayerMaintenance(t *testing.T) {
+	ctx := context.Background()
+	mockStorage := mocks.NewAggSenderStorage(t)
+	mockAggsenderFlow := mocks.NewAggsenderFlow(t)
+	mockAgglayerClient := agglayer.NewAgglayerClientMock(t)
+	mockEpochNotifier := mocks.NewEpochNotifier(t)
+	logger := log.WithFields("aggsender-test", "agglayer-maintenance")
+	aggsender := &AggSender{
+		log:                 logger,
+		storage:             mockStorage,
+		epochNotifier:       mockEpochNotifier,
+		flow:                mockAggsenderFlow,
+		aggLayerClient:      mockAgglayerClient,
+		rateLimiter:         aggkitcommon.NewRateLimit(aggkitcommon.RateLimitConfig{}),
+		cfg:                 config.Config{MaxRetriesStoreCertificate: 1},
+		agglayerMaintenance: newAgglayerMaintenanceTracker(time.Hour, time.Hour),
+	}
+	mockEpochNotifier.EXPECT().GetEpochStatus().Return(aggsendertypes.EpochStatus{})
+	mockAggsenderFlow.EXPECT().GetCertificateBuildParams(mock.Anything).Return(&aggsendertypes.CertificateBuildParams{
+		Bridges: []bridgesync.Bridge{{}},
+	}, nil)
+	mockAggsenderFlow.EXPECT().BuildCertificate(mock.Anything, mock.Anything).Return(&agglayertypes.Certificate{
+		NetworkID:        1,
+		NewLocalExitRoot: common.HexToHash("0x1"),
+		BridgeExits:      []*agglayertypes.BridgeExit{{}},
+	}, nil)
+	mockStorage.EXPECT().SaveCertificateBuildParams(mock.Anything, mock.Anything).Return(nil)
+	mockStorage.EXPECT().SaveCertificateJournalEntry(mock.Anything, mock.Anything).Return(nil)
+	mockStorage.EXPECT().SaveCertificateAuditEvents(mock.Anything, mock.Anything).Return(nil)
+	unavailable := fmt.Errorf("failed to submit certificate: %w",
+		aggkitgrpc.GRPCError{Code: codes.Unavailable, Message: "under maintenance"})
+	mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.Hash{}, unavailable).Once()
+	mockStorage.EXPECT().UpdateCertificateJournalEntryState(mock.Anything, uint64(0),
+		db.CertificateJournalStateDiscarded, (*common.Hash)(nil)).Return(nil).Once()
+
+	// the rejection is not a failure: the certificate is not saved as non accepted
+	cert, err := aggsender.sendCertificate(ctx)
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	status := aggsender.agglayerMaintenance.status()
+	require.NotNil(t, status)
+	require.Equal(t, 1, status.Attempts)
+	require.Contains(t, status.LastError, "under maintenance")
+
+	// paused until the next attempt: nothing is built
+	cert, err = aggsender.sendCertificate(ctx)
+	require.NoError(t, err)
+	require.Nil(t, cert)
+	mockAggsenderFlow.AssertNumberOfCalls(t, "BuildCertificate", 1)
+
+	// the next attempt is accepted and the submissions are resumed
+	aggsender.agglayerMaintenance.nextAttempt = time.Now()
+	mockAgglayerClient.EXPECT().SendCertificate(mock.Anything, mock.Anything).Return(common.HexToHash("0x22"), nil).Once()
+	mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().SaveCertificateAnalytics(mock.Anything, mock.Anything).Return(nil).Once()
+	cert, err = aggsender.sendCertificate(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+	require.Nil(t, aggsender.agglayerMaintenance.status())
+}
+
+func TestFastSyncL2Bridge(t *testing.T) {
+	settledLER := common.HexToHash("0x1")
+	settledCert := &agglayertypes.CertificateHeader{
+		Height:           7,
+		NewLocalExitRoot: settledLER,
+		Metadata:         aggsendertypes.NewCertificateMetadata(100, 50, 0, 0).ToHash(),
+	}
+
+	tests := []struct {
+		name          string
+		settledCert   *agglayertypes.CertificateHeader
+		agglayerErr   error
+		initErr       error
+		expectedBlock uint64
+		expectedError string
+	}{
+		{
+			name:          "the syncer starts at the last block of the settled certificate",
+			settledCert:   settledCert,
+			expectedBlock: 150,
+		},
+		{
+			name: "no settled certificate",
+		},
+		{
+			name:          "error getting the settled certificate",
+			agglayerErr:   errors.New("unavailable"),
+			expectedError: "error getting the last settled certificate of the network 1: unavailable",
+		},
+		{
+			name:          "error starting the syncer",
+			settledCert:   settledCert,
+			initErr:       bridgesync.ErrLocalExitRootMismatch,
+			expectedError: "error starting the L2 bridge syncer at the block 150 of the settled certificate (height 7)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agglayerClient := agglayer.NewAgglayerClientMock(t)
+			agglayerClient.EXPECT().GetLatestSettledCertificateHeader(mock.Anything, uint32(1)).
+				Return(tt.settledCert, tt.agglayerErr).Once()
+			l2Syncer := mocks.NewL2BridgeFastSyncer(t)
+			l2Syncer.EXPECT().OriginNetwork().Return(1)
+			if tt.settledCert != nil {
+				l2Syncer.EXPECT().InitFromLocalExitRoot(mock.Anything, uint64(150), settledLER).
+					Return(tt.initErr).Once()
+			}
+
+			blockNum, err := FastSyncL2Bridge(context.Background(), log.WithFields("aggsender-test", "fast-sync"),
+				agglayerClient, l2Syncer)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedBlock, blockNum)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package aggsender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+)
+
+const (
+	approvalResultApproved = "approved"
+	approvalResultRejected = "rejected"
+	approvalResultFailed   = "failed"
+)
+
+// ErrCertificateNotApproved is returned when the approval hook rejects a certificate or, with the
+// FailClosed policy, when the hook fails
+var ErrCertificateNotApproved = errors.New("certificate not approved")
+
+// certificateApprovalRequest is the JSON body posted to the approval hook: the summary of the certificate
+type certificateApprovalRequest struct {
+	NetworkID uint32 `json:"network_id"`
+	// Height is the height the certificate is going to be sent with
+	Height          uint64 `json:"height"`
+	RetryCount      int    `json:"retry_count"`
+	CertificateType string `json:"certificate_type"`
+	FromBlock       uint64 `json:"from_block"`
+	ToBlock         uint64 `json:"to_block"`
+	// BridgeExits and ImportedBridgeExits are the number of bridges and claims of the certificate
+	BridgeExits         int `json:"bridge_exits"`
+	ImportedBridgeExits int `json:"imported_bridge_exits"`
+	AssetExits          int `json:"asset_exits"`
+	MessageExits        int `json:"message_exits"`
+	// BridgedValuePerToken is the total amount bridged by token ("originNetwork:originAddress")
+	BridgedValuePerToken   map[types.TokenKey]*big.Int `json:"bridged_value_per_token"`
+	ClaimsPerOriginNetwork map[uint32]int              `json:"claims_per_origin_network"`
+	L1InfoTreeLeafCount    uint32                      `json:"l1_info_tree_leaf_count"`
+	CreatedAt              uint32                      `json:"created_at"`
+}
+
+// certificateApprovalResponse is the JSON answer of the approval hook
+type certificateApprovalResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// approvalHook asks an external hook to approve each certificate before it's signed and sent, so the
+// organizations can add a human or a policy approval step. When the hook fails, the certificate is sent
+// or not depending on the failure policy
+type approvalHook struct {
+	log        aggkitcommon.Logger
+	cfg        types.ApprovalHookConfig
+	networkID  uint32
+	httpClient *http.Client
+}
+
+// newApprovalHook returns nil if the approval hook is disabled
+func newApprovalHook(logger aggkitcommon.Logger, cfg types.ApprovalHookConfig, networkID uint32) *approvalHook {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &approvalHook{
+		log:        logger,
+		cfg:        cfg,
+		networkID:  networkID,
+		httpClient: &http.Client{Timeout: cfg.Timeout.Duration},
+	}
+}
+
+// approve returns an ErrCertificateNotApproved error if the hook rejects the certificate, or if it fails
+// with the FailClosed policy
+func (h *approvalHook) approve(ctx context.Context, params *types.CertificateBuildParams) error {
+	request := h.newRequest(params)
+	response, err := h.request(ctx, request)
+	if err != nil {
+		metrics.CertificateApproval(approvalResultFailed)
+		if h.cfg.FailOpen() {
+			h.log.Warnf("error requesting the approval of the certificate of height %d (blocks %d-%d), "+
+				"sending it because the failure policy is %s: %v", request.Height, request.FromBlock, request.ToBlock,
+				types.ApprovalHookFailOpen, err)
+			return nil
+		}
+		return fmt.Errorf("%w: error requesting the approval of the certificate of height %d (blocks %d-%d): %w",
+			ErrCertificateNotApproved, request.Height, request.FromBlock, request.ToBlock, err)
+	}
+	if !response.Approved {
+		metrics.CertificateApproval(approvalResultRejected)
+		return fmt.Errorf("%w: the certificate of height %d (blocks %d-%d) was rejected by the approval hook: %s. "+
+			"Its bridges are going to be included in the next certificate",
+			ErrCertificateNotApproved, request.Height, request.FromBlock, request.ToBlock, response.Reason)
+	}
+	metrics.CertificateApproval(approvalResultApproved)
+	h.log.Infof("certificate of height %d (blocks %d-%d) approved by the approval hook",
+		request.Height, request.FromBlock, request.ToBlock)
+	return nil
+}
+
+// newRequest returns the summary of the certificate sent to the hook
+func (h *approvalHook) newRequest(params *types.CertificateBuildParams) certificateApprovalRequest {
+	analytics := params.Analytics()
+	return certificateApprovalRequest{
+		NetworkID:              h.networkID,
+		Height:                 nextCertificateHeight(params.LastSentCertificate),
+		RetryCount:             params.RetryCount,
+		CertificateType:        params.CertificateType.String(),
+		FromBlock:              params.FromBlock,
+		ToBlock:                params.ToBlock,
+		BridgeExits:            params.NumberOfBridges(),
+		ImportedBridgeExits:    params.NumberOfClaims(),
+		AssetExits:             analytics.AssetExits,
+		MessageExits:           analytics.MessageExits,
+		BridgedValuePerToken:   analytics.BridgedValuePerToken,
+		ClaimsPerOriginNetwork: analytics.ClaimsPerOriginNetwork,
+		L1InfoTreeLeafCount:    params.L1InfoTreeLeafCount,
+		CreatedAt:              params.CreatedAt,
+	}
+}
+
+// request posts the summary of the certificate to the hook and decodes its answer
+func (h *approvalHook) request(ctx context.Context,
+	request certificateApprovalRequest) (*certificateApprovalResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the approval request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating the approval request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending the approval request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+		return nil, fmt.Errorf("the approval hook answered with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	var response certificateApprovalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error decoding the response of the approval hook: %w", err)
+	}
+	return &response, nil
+}
+
+// nextCertificateHeight returns the height of the next certificate: the height of the last one if it's
+// a retry of a certificate InError, or the next one otherwise
+func nextCertificateHeight(lastSentCertificate *types.CertificateHeader) uint64 {
+	if lastSentCertificate == nil {
+		return 0
+	}
+	if lastSentCertificate.Status.IsInError() {
+		return lastSentCertificate.Height
+	}
+	return lastSentCertificate.Height + 1
+}
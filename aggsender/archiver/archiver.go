@@ -0,0 +1,215 @@
+package archiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const defaultRetryInterval = 5 * time.Second
+
+var _ types.CertificateArchiver = (*CertificateArchiver)(nil)
+
+// ArchivedCertificate is the document stored in the object storage for each certificate
+type ArchivedCertificate struct {
+	NetworkID         uint32          `json:"network_id"`
+	Height            uint64          `json:"height"`
+	CertificateID     common.Hash     `json:"certificate_id"`
+	RetryCount        int             `json:"retry_count"`
+	Status            string          `json:"status"`
+	CertType          string          `json:"cert_type"`
+	FromBlock         uint64          `json:"from_block"`
+	ToBlock           uint64          `json:"to_block"`
+	CreatedAt         uint32          `json:"created_at"`
+	UpdatedAt         uint32          `json:"updated_at"`
+	ArchivedAt        uint32          `json:"archived_at"`
+	SignedCertificate json.RawMessage `json:"signed_certificate,omitempty"`
+}
+
+// archiveJob is a certificate queued to be uploaded
+type archiveJob struct {
+	certID string
+	status string
+	key    string
+	data   []byte
+}
+
+// CertificateArchiver uploads the certificates sent to agglayer (and their final status)
+// to an object storage using the layout: <prefix><network>/<height>.json. The certificates are
+// queued and uploaded, in order, by a background goroutine, so the object storage never delays
+// the submission of the certificates
+type CertificateArchiver struct {
+	log       types.Logger
+	store     ObjectStorer
+	cfg       Config
+	networkID uint32
+	queue     chan archiveJob
+}
+
+// New creates a new CertificateArchiver that uploads the certificates to a S3-compatible storage
+func New(log types.Logger, cfg Config, networkID uint32) (*CertificateArchiver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	store, err := NewS3Client(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithStore(log, cfg, networkID, store), nil
+}
+
+// NewWithStore creates a new CertificateArchiver using the given object storage
+func NewWithStore(log types.Logger, cfg Config, networkID uint32, store ObjectStorer) *CertificateArchiver {
+	return &CertificateArchiver{
+		log:       log,
+		store:     store,
+		cfg:       cfg,
+		networkID: networkID,
+		queue:     make(chan archiveJob, max(cfg.BufferSize, 1)),
+	}
+}
+
+// ObjectKey returns the key of the object for the given height
+func (a *CertificateArchiver) ObjectKey(height uint64) string {
+	return fmt.Sprintf("%s%d.json", a.networkPrefix(), height)
+}
+
+func (a *CertificateArchiver) networkPrefix() string {
+	return fmt.Sprintf("%s%d/", a.cfg.Prefix, a.networkID)
+}
+
+// ArchiveCertificate queues the certificate (with its current status) to be uploaded to the object
+// storage. If the certificate at this height was already archived (e.g. a retry or a status update)
+// the object is overwritten. If the queue is full the certificate is not archived and an error is returned
+func (a *CertificateArchiver) ArchiveCertificate(cert *types.Certificate) error {
+	if cert == nil || cert.Header == nil {
+		return fmt.Errorf("archiver: certificate is nil")
+	}
+	doc := ArchivedCertificate{
+		NetworkID:     a.networkID,
+		Height:        cert.Header.Height,
+		CertificateID: cert.Header.CertificateID,
+		RetryCount:    cert.Header.RetryCount,
+		Status:        cert.Header.Status.String(),
+		CertType:      cert.Header.CertType.String(),
+		FromBlock:     cert.Header.FromBlock,
+		ToBlock:       cert.Header.ToBlock,
+		CreatedAt:     cert.Header.CreatedAt,
+		UpdatedAt:     cert.Header.UpdatedAt,
+		ArchivedAt:    uint32(time.Now().UTC().Unix()),
+	}
+	if cert.SignedCertificate != nil && json.Valid([]byte(*cert.SignedCertificate)) {
+		doc.SignedCertificate = json.RawMessage(*cert.SignedCertificate)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("archiver: error marshalling certificate %s: %w", cert.Header.ID(), err)
+	}
+	job := archiveJob{
+		certID: cert.Header.ID(),
+		status: doc.Status,
+		key:    a.ObjectKey(cert.Header.Height),
+		data:   data,
+	}
+	select {
+	case a.queue <- job:
+		return nil
+	default:
+		return fmt.Errorf("archiver: queue full (%d certificates), certificate %s (status: %s) not archived",
+			cap(a.queue), job.certID, job.status)
+	}
+}
+
+// upload uploads the queued certificate, retrying up to MaxRetries times
+func (a *CertificateArchiver) upload(ctx context.Context, job archiveJob) {
+	retryInterval := a.cfg.RetryInterval.Duration
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+	for attempt := 0; ; attempt++ {
+		err := a.store.PutObject(ctx, job.key, job.data)
+		if err == nil {
+			a.log.Debugf("archiver: certificate %s (status: %s) archived at %s", job.certID, job.status, job.key)
+			return
+		}
+		if attempt >= a.cfg.MaxRetries {
+			a.log.Errorf("archiver: error archiving certificate %s (status: %s) after %d attempts: %v",
+				job.certID, job.status, attempt+1, err)
+			return
+		}
+		a.log.Warnf("archiver: error archiving certificate %s (status: %s), retrying in %s: %v",
+			job.certID, job.status, retryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// PruneExpired deletes the archived certificates older than the retention period
+func (a *CertificateArchiver) PruneExpired(ctx context.Context) (int, error) {
+	if a.cfg.RetentionPeriod.Duration <= 0 {
+		return 0, nil
+	}
+	objects, err := a.store.ListObjects(ctx, a.networkPrefix())
+	if err != nil {
+		return 0, err
+	}
+	expiration := time.Now().UTC().Add(-a.cfg.RetentionPeriod.Duration)
+	deleted := 0
+	for _, obj := range objects {
+		if !strings.HasSuffix(obj.Key, ".json") || !obj.LastModified.Before(expiration) {
+			continue
+		}
+		if err := a.store.DeleteObject(ctx, obj.Key); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// Start uploads the queued certificates, and runs the retention loop, until the context is done
+func (a *CertificateArchiver) Start(ctx context.Context) {
+	if a.cfg.RetentionPeriod.Duration <= 0 {
+		a.log.Infof("archiver: RetentionPeriod is 0, archived certificates are kept forever")
+	} else {
+		go a.pruneLoop(ctx)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-a.queue:
+			a.upload(ctx, job)
+		}
+	}
+}
+
+// pruneLoop deletes the expired certificates every PruneInterval until the context is done
+func (a *CertificateArchiver) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.PruneInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.PruneExpired(ctx)
+			if err != nil {
+				a.log.Errorf("archiver: error pruning expired certificates: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				a.log.Infof("archiver: pruned %d expired certificates", deleted)
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package archiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	modified map[string]time.Time
+	errPut   error
+	// putFailures is the number of PutObject calls that fail before the next one succeeds
+	putFailures int
+	puts        int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{objects: map[string][]byte{}, modified: map[string]time.Time{}}
+}
+
+func (m *memoryStore) PutObject(_ context.Context, key string, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.puts++
+	if m.errPut != nil {
+		return m.errPut
+	}
+	if m.putFailures > 0 {
+		m.putFailures--
+		return errors.New("temporary error")
+	}
+	m.objects[key] = body
+	m.modified[key] = time.Now().UTC()
+	return nil
+}
+
+func (m *memoryStore) object(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	body, ok := m.objects[key]
+	return body, ok
+}
+
+func (m *memoryStore) putCalls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.puts
+}
+
+func (m *memoryStore) DeleteObject(_ context.Context, key string) error {
+	delete(m.objects, key)
+	delete(m.modified, key)
+	return nil
+}
+
+func (m *memoryStore) ListObjects(_ context.Context, _ string) ([]ObjectInfo, error) {
+	res := make([]ObjectInfo, 0, len(m.objects))
+	for k := range m.objects {
+		res = append(res, ObjectInfo{Key: k, LastModified: m.modified[k]})
+	}
+	return res, nil
+}
+
+func TestArchiveCertificate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := newMemoryStore()
+	store.putFailures = 1
+	cfg := Config{Prefix: "audit/", BufferSize: 1, MaxRetries: 1, RetryInterval: configtypes.NewDuration(time.Millisecond)}
+	sut := NewWithStore(log.WithFields("test", "archiver"), cfg, 7, store)
+	signed := `{"network_id":7,"height":3}`
+	cert := &types.Certificate{
+		Header: &types.CertificateHeader{
+			Height:        3,
+			CertificateID: common.HexToHash("0x1234"),
+			Status:        agglayertypes.Settled,
+			CertType:      types.CertificateTypePP,
+			FromBlock:     10,
+			ToBlock:       20,
+		},
+		SignedCertificate: &signed,
+	}
+
+	require.Error(t, sut.ArchiveCertificate(nil))
+	require.NoError(t, sut.ArchiveCertificate(cert))
+	// the queue is full until the archiver is started
+	require.ErrorContains(t, sut.ArchiveCertificate(cert), "queue full")
+	require.Equal(t, 0, store.putCalls())
+
+	// the first upload fails and it's retried
+	go sut.Start(ctx)
+	require.Eventually(t, func() bool {
+		_, ok := store.object("audit/7/3.json")
+		return ok
+	}, time.Second, time.Millisecond)
+	require.Equal(t, 2, store.putCalls())
+	raw, _ := store.object("audit/7/3.json")
+	var doc ArchivedCertificate
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Equal(t, uint32(7), doc.NetworkID)
+	require.Equal(t, uint64(3), doc.Height)
+	require.Equal(t, "Settled", doc.Status)
+	require.Equal(t, "pp", doc.CertType)
+	require.JSONEq(t, signed, string(doc.SignedCertificate))
+}
+
+func TestUploadMaxRetries(t *testing.T) {
+	store := newMemoryStore()
+	store.errPut = errors.New("boom")
+	cfg := Config{MaxRetries: 2, RetryInterval: configtypes.NewDuration(time.Millisecond)}
+	sut := NewWithStore(log.WithFields("test", "archiver"), cfg, 1, store)
+
+	// the upload is dropped after the retries
+	sut.upload(context.Background(), archiveJob{certID: "1", key: "1/1.json", data: []byte("{}")})
+	require.Equal(t, 3, store.putCalls())
+	_, ok := store.object("1/1.json")
+	require.False(t, ok)
+}
+
+func TestPruneExpired(t *testing.T) {
+	store := newMemoryStore()
+	cfg := Config{RetentionPeriod: configtypes.NewDuration(time.Hour)}
+	sut := NewWithStore(log.WithFields("test", "archiver"), cfg, 1, store)
+
+	store.objects["1/1.json"] = []byte("{}")
+	store.modified["1/1.json"] = time.Now().Add(-2 * time.Hour)
+	store.objects["1/2.json"] = []byte("{}")
+	store.modified["1/2.json"] = time.Now()
+
+	deleted, err := sut.PruneExpired(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	require.Contains(t, store.objects, "1/2.json")
+	require.NotContains(t, store.objects, "1/1.json")
+
+	sut.cfg.RetentionPeriod = configtypes.NewDuration(0)
+	deleted, err = sut.PruneExpired(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, deleted)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.ErrorContains(t, Config{Enabled: true}.Validate(), "URL is required")
+	require.ErrorContains(t, Config{Enabled: true, URL: "http://s3"}.Validate(), "Bucket is required")
+	require.ErrorContains(t, Config{Enabled: true, URL: "http://s3", Bucket: "b"}.Validate(), "Region is required")
+	require.ErrorContains(t, Config{Enabled: true, URL: "http://s3", Bucket: "b", Region: "r", BufferSize: 1,
+		RetentionPeriod: configtypes.NewDuration(time.Hour)}.Validate(), "PruneInterval")
+	require.ErrorContains(t, Config{Enabled: true, URL: "http://s3", Bucket: "b", Region: "r"}.Validate(), "BufferSize")
+	require.ErrorContains(t, Config{Enabled: true, URL: "http://s3", Bucket: "b", Region: "r", BufferSize: 1,
+		MaxRetries: -1}.Validate(), "MaxRetries")
+	require.NoError(t, Config{Enabled: true, URL: "http://s3", Bucket: "b", Region: "r", BufferSize: 1}.Validate())
+}
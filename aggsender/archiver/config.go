@@ -0,0 +1,74 @@
+package archiver
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// Config holds the configuration for the certificates archiver
+type Config struct {
+	// Enabled is a flag to enable the archival of the submitted certificates
+	Enabled bool `mapstructure:"Enabled"`
+	// URL is the S3-compatible endpoint (e.g. https://s3.us-east-1.amazonaws.com
+	// or https://storage.googleapis.com using GCS HMAC keys)
+	URL string `mapstructure:"URL"`
+	// Bucket is the name of the bucket where the certificates are stored
+	Bucket string `mapstructure:"Bucket"`
+	// Region is the region of the bucket, used to sign the requests
+	Region string `mapstructure:"Region"`
+	// Prefix is an optional prefix prepended to the object keys (e.g. "mainnet/")
+	Prefix string `mapstructure:"Prefix"`
+	// AccessKeyID is the access key used to sign the requests
+	AccessKeyID string `mapstructure:"AccessKeyID"`
+	// SecretAccessKey is the secret key used to sign the requests
+	SecretAccessKey string `mapstructure:"SecretAccessKey"`
+	// RequestTimeout is the timeout for each request to the object storage
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
+	// BufferSize is the number of certificates queued to be uploaded. When the queue is full
+	// the new certificates are not archived
+	BufferSize int `mapstructure:"BufferSize"`
+	// MaxRetries is the number of times a failed upload is retried before the certificate is dropped
+	MaxRetries int `mapstructure:"MaxRetries"`
+	// RetryInterval is the delay before retrying a failed upload
+	RetryInterval types.Duration `mapstructure:"RetryInterval"`
+	// RetentionPeriod is the time an archived certificate is kept in the object storage.
+	// 0 means the certificates are kept forever
+	RetentionPeriod types.Duration `mapstructure:"RetentionPeriod"`
+	// PruneInterval is the interval at which expired certificates are deleted
+	PruneInterval types.Duration `mapstructure:"PruneInterval"`
+}
+
+// Validate checks that the configuration is correct
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.URL == "" {
+		return errors.New("archiver: URL is required")
+	}
+	if c.Bucket == "" {
+		return errors.New("archiver: Bucket is required")
+	}
+	if c.Region == "" {
+		return errors.New("archiver: Region is required")
+	}
+	if c.BufferSize <= 0 {
+		return errors.New("archiver: BufferSize must be greater than 0")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("archiver: MaxRetries can't be negative")
+	}
+	if c.RetentionPeriod.Duration > 0 && c.PruneInterval.Duration <= 0 {
+		return fmt.Errorf("archiver: PruneInterval must be greater than 0 if RetentionPeriod is set (%s)",
+			c.RetentionPeriod.String())
+	}
+	return nil
+}
+
+// String returns a string representation of the configuration (without secrets)
+func (c Config) String() string {
+	return fmt.Sprintf("Enabled: %t, URL: %s, Bucket: %s, Region: %s, Prefix: %s, RetentionPeriod: %s",
+		c.Enabled, c.URL, c.Bucket, c.Region, c.Prefix, c.RetentionPeriod.String())
+}
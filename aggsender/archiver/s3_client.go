@@ -0,0 +1,305 @@
+package archiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4Service    = "s3"
+	amzDateFormat   = "20060102T150405Z"
+	amzDayFormat    = "20060102"
+	maxErrorBodyLen = 1024
+)
+
+// ObjectInfo is the information of an object stored in the object storage
+type ObjectInfo struct {
+	Key          string
+	LastModified time.Time
+}
+
+// ObjectStorer is the interface to interact with an object storage
+type ObjectStorer interface {
+	PutObject(ctx context.Context, key string, body []byte) error
+	DeleteObject(ctx context.Context, key string) error
+	ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+var _ ObjectStorer = (*S3Client)(nil)
+
+// S3Client is a minimal client of the S3 API (path-style) using AWS Signature Version 4.
+// It works with any S3-compatible storage (AWS S3, GCS interoperability API, MinIO, ...)
+type S3Client struct {
+	httpClient      *http.Client
+	endpoint        *url.URL
+	bucket          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	now             func() time.Time
+}
+
+// NewS3Client creates a new S3Client based on the archiver configuration
+func NewS3Client(cfg Config) (*S3Client, error) {
+	endpoint, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("archiver: invalid URL %s: %w", cfg.URL, err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("archiver: invalid URL %s: scheme and host are required", cfg.URL)
+	}
+
+	return &S3Client{
+		httpClient:      &http.Client{Timeout: cfg.RequestTimeout.Duration},
+		endpoint:        endpoint,
+		bucket:          cfg.Bucket,
+		region:          cfg.Region,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		now:             time.Now,
+	}, nil
+}
+
+// PutObject uploads (or overwrites) an object
+func (s *S3Client) PutObject(ctx context.Context, key string, body []byte) error {
+	_, err := s.do(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return fmt.Errorf("archiver: error putting object %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteObject deletes an object
+func (s *S3Client) DeleteObject(ctx context.Context, key string) error {
+	_, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return fmt.Errorf("archiver: error deleting object %s: %w", key, err)
+	}
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects returns all the objects with the given prefix (ListObjectsV2)
+func (s *S3Client) ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var (
+		objects           []ObjectInfo
+		continuationToken string
+	)
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		body, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("archiver: error listing objects with prefix %s: %w", prefix, err)
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("archiver: error decoding list objects response: %w", err)
+		}
+		for _, c := range result.Contents {
+			objects = append(objects, ObjectInfo{Key: c.Key, LastModified: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// do executes a signed request against the bucket and returns the response body
+func (s *S3Client) do(ctx context.Context, method, key string, query url.Values, body []byte) ([]byte, error) {
+	reqURL := *s.endpoint
+	reqURL.Path = strings.TrimSuffix(reqURL.Path, "/") + "/" + s.bucket
+	if key != "" {
+		reqURL.Path += "/" + key
+	}
+	reqURL.RawPath = awsURIEncode(reqURL.Path, false)
+	reqURL.RawQuery = canonicalQueryString(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPut {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	s.sign(req, body)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if len(respBody) > maxErrorBodyLen {
+			respBody = respBody[:maxErrorBodyLen]
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+// If no credentials are configured the request is sent unsigned
+func (s *S3Client) sign(req *http.Request, body []byte) {
+	SignS3Request(req, body, S3Credentials{
+		Region:          s.region,
+		AccessKeyID:     s.accessKeyID,
+		SecretAccessKey: s.secretAccessKey,
+	}, s.now())
+}
+
+// S3Credentials are the credentials used to sign the requests to a S3-compatible storage
+type S3Credentials struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is the token of the temporary credentials (e.g. AWS STS), if any
+	SessionToken string
+}
+
+// SignS3Request adds the AWS Signature Version 4 headers to the request to a S3-compatible storage.
+// If there is no access key the request is left unsigned
+func SignS3Request(req *http.Request, body []byte, creds S3Credentials, now time.Time) {
+	if creds.AccessKeyID == "" {
+		return
+	}
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+	day := now.Format(amzDayFormat)
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if ct := req.Header.Get("Content-Type"); ct != "" {
+		headers["content-type"] = ct
+	}
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+		headers["x-amz-security-token"] = creds.SessionToken
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{day, creds.Region, sigV4Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), day)
+	signingKey = hmacSHA256(signingKey, creds.Region)
+	signingKey = hmacSHA256(signingKey, sigV4Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQueryString returns the query string sorted by key and encoded as required by SigV4
+func canonicalQueryString(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsURIEncode(k, true)+"="+awsURIEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsURIEncode encodes a string following the SigV4 rules (RFC 3986 unreserved characters are kept)
+func awsURIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
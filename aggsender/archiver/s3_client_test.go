@@ -0,0 +1,114 @@
+package archiver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestS3Client(t *testing.T, serverURL string) *S3Client {
+	t.Helper()
+
+	client, err := NewS3Client(Config{
+		URL:             serverURL,
+		Bucket:          "certs",
+		Region:          "us-east-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	})
+	require.NoError(t, err)
+	client.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return client
+}
+
+func TestS3ClientPutObject(t *testing.T) {
+	var (
+		gotPath, gotAuth, gotDate string
+		gotBody                   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotDate = r.Header.Get("X-Amz-Date")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestS3Client(t, server.URL)
+	err := client.PutObject(context.Background(), "1/10.json", []byte(`{"height":10}`))
+	require.NoError(t, err)
+	require.Equal(t, "/certs/1/10.json", gotPath)
+	require.Equal(t, `{"height":10}`, string(gotBody))
+	require.Equal(t, "20250102T030405Z", gotDate)
+	require.True(t, strings.HasPrefix(gotAuth,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250102/us-east-1/s3/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="), gotAuth)
+}
+
+func TestS3ClientUnsignedAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("AccessDenied"))
+	}))
+	defer server.Close()
+
+	client, err := NewS3Client(Config{URL: server.URL, Bucket: "certs", Region: "us-east-1"})
+	require.NoError(t, err)
+	err = client.DeleteObject(context.Background(), "1/10.json")
+	require.ErrorContains(t, err, "unexpected status code 403: AccessDenied")
+
+	_, err = NewS3Client(Config{URL: "localhost"})
+	require.ErrorContains(t, err, "scheme and host are required")
+}
+
+func TestS3ClientListObjectsPagination(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/certs", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("list-type"))
+		require.Equal(t, "1/", r.URL.Query().Get("prefix"))
+		calls++
+		if r.URL.Query().Get("continuation-token") == "" {
+			fmt.Fprint(w, `<ListBucketResult><IsTruncated>true</IsTruncated>`+
+				`<NextContinuationToken>tok</NextContinuationToken>`+
+				`<Contents><Key>1/1.json</Key><LastModified>2025-01-01T00:00:00.000Z</LastModified></Contents>`+
+				`</ListBucketResult>`)
+			return
+		}
+		require.Equal(t, "tok", r.URL.Query().Get("continuation-token"))
+		fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated>`+
+			`<Contents><Key>1/2.json</Key><LastModified>2025-01-02T00:00:00.000Z</LastModified></Contents>`+
+			`</ListBucketResult>`)
+	}))
+	defer server.Close()
+
+	client := newTestS3Client(t, server.URL)
+	objects, err := client.ListObjects(context.Background(), "1/")
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.Len(t, objects, 2)
+	require.Equal(t, "1/1.json", objects[0].Key)
+	require.Equal(t, "1/2.json", objects[1].Key)
+	require.Equal(t, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), objects[1].LastModified)
+}
+
+func TestCanonicalQueryString(t *testing.T) {
+	query := url.Values{}
+	query.Set("prefix", "a b/c")
+	query.Set("list-type", "2")
+	require.Equal(t, "list-type=2&prefix=a%20b%2Fc", canonicalQueryString(query))
+	require.Equal(t, "", canonicalQueryString(nil))
+	require.Equal(t, "/bucket/a%20b/c~d", awsURIEncode("/bucket/a b/c~d", false))
+}
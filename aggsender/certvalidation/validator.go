@@ -0,0 +1,261 @@
+package certvalidation
+
+import (
+	"fmt"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RuleStatus is the result of a validation rule
+type RuleStatus string
+
+const (
+	// RulePassed means the certificate complies with the rule
+	RulePassed RuleStatus = "passed"
+	// RuleFailed means the certificate would be rejected by the agglayer because of the rule
+	RuleFailed RuleStatus = "failed"
+	// RuleSkipped means the rule can't be checked locally (e.g. there is no previous certificate stored)
+	RuleSkipped RuleStatus = "skipped"
+)
+
+// Names of the validation rules
+const (
+	RuleNetworkID        = "network_id"
+	RuleHeightContinuity = "height_continuity"
+	RuleLocalExitRoot    = "local_exit_root_chain"
+	RuleSignature        = "signature"
+	RuleProofFormat      = "proof_format"
+
+	// signatureLength is the length of an ECDSA signature (r, s, v)
+	signatureLength = 65
+)
+
+// emptyLER is the local exit root of an empty exit tree, the previous LER of the first certificate
+var emptyLER = common.HexToHash("0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757")
+
+// RuleResult is the verdict of a validation rule
+type RuleResult struct {
+	Rule    string     `json:"rule"`
+	Status  RuleStatus `json:"status"`
+	Message string     `json:"message"`
+}
+
+// Verdict is the result of the validation of a certificate, rule by rule. The certificate is valid
+// if no rule failed
+type Verdict struct {
+	CertificateID common.Hash  `json:"certificate_id"`
+	Height        uint64       `json:"height"`
+	Valid         bool         `json:"valid"`
+	Rules         []RuleResult `json:"rules"`
+}
+
+// CertificateStorer returns the certificates stored by the aggsender
+type CertificateStorer interface {
+	GetCertificateByHeight(height uint64) (*types.Certificate, error)
+}
+
+// SignerAddresser returns the address of the signer of the certificates
+type SignerAddresser interface {
+	SignerAddress() common.Address
+}
+
+// Validator replicates locally the key acceptance rules of the agglayer (height continuity,
+// signature, local exit root chain and proof format), so a certificate can be checked before
+// submitting it
+type Validator struct {
+	networkID uint32
+	storage   CertificateStorer
+	signer    SignerAddresser
+}
+
+// NewValidator returns a Validator for the certificates of the network. The signer is optional,
+// if it's nil the signature is only recovered and not compared with the expected signer
+func NewValidator(networkID uint32, storage CertificateStorer, signer SignerAddresser) *Validator {
+	return &Validator{
+		networkID: networkID,
+		storage:   storage,
+		signer:    signer,
+	}
+}
+
+// Validate checks the certificate against all the rules. It only returns an error if the
+// stored certificates can't be read
+func (v *Validator) Validate(cert *agglayertypes.Certificate) (*Verdict, error) {
+	if cert == nil {
+		return nil, fmt.Errorf("certificate is nil")
+	}
+	var previous, current *types.Certificate
+	if cert.Height > 0 {
+		var err error
+		if previous, err = v.storage.GetCertificateByHeight(cert.Height - 1); err != nil {
+			return nil, fmt.Errorf("error getting the certificate of height %d: %w", cert.Height-1, err)
+		}
+	}
+	current, err := v.storage.GetCertificateByHeight(cert.Height)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the certificate of height %d: %w", cert.Height, err)
+	}
+
+	verdict := &Verdict{
+		CertificateID: cert.Hash(),
+		Height:        cert.Height,
+		Rules: []RuleResult{
+			v.checkNetworkID(cert),
+			checkHeightContinuity(cert, previous, current),
+			checkLocalExitRootChain(cert, previous),
+			v.checkSignature(cert),
+			checkProofFormat(cert),
+		},
+	}
+	verdict.Valid = true
+	for _, rule := range verdict.Rules {
+		if rule.Status == RuleFailed {
+			verdict.Valid = false
+		}
+	}
+	return verdict, nil
+}
+
+func (v *Validator) checkNetworkID(cert *agglayertypes.Certificate) RuleResult {
+	if cert.NetworkID != v.networkID {
+		return failed(RuleNetworkID, "network ID %d doesn't match the network of the aggsender (%d)",
+			cert.NetworkID, v.networkID)
+	}
+	return passed(RuleNetworkID, "network ID %d", cert.NetworkID)
+}
+
+// checkHeightContinuity checks that the previous height is settled and the height is not taken by another
+// certificate that is not in error
+func checkHeightContinuity(cert *agglayertypes.Certificate, previous, current *types.Certificate) RuleResult {
+	certID := cert.Hash()
+	if current != nil && current.Header != nil && current.Header.CertificateID != certID &&
+		!current.Header.Status.IsInError() {
+		return failed(RuleHeightContinuity, "height %d is taken by certificate %s (%s)",
+			cert.Height, current.Header.CertificateID.Hex(), current.Header.Status.String())
+	}
+	if cert.Height == 0 {
+		return passed(RuleHeightContinuity, "first certificate of the network")
+	}
+	if previous == nil || previous.Header == nil {
+		return failed(RuleHeightContinuity, "there is no certificate of the previous height %d", cert.Height-1)
+	}
+	if !previous.Header.Status.IsSettled() {
+		return failed(RuleHeightContinuity, "the certificate of the previous height %d is %s, it must be settled",
+			cert.Height-1, previous.Header.Status.String())
+	}
+	return passed(RuleHeightContinuity, "the certificate of the previous height %d is settled", cert.Height-1)
+}
+
+// checkLocalExitRootChain checks that the previous LER is the new LER of the previous certificate, and
+// that the LER doesn't change without bridge exits
+func checkLocalExitRootChain(cert *agglayertypes.Certificate, previous *types.Certificate) RuleResult {
+	if len(cert.BridgeExits) == 0 && cert.NewLocalExitRoot != cert.PrevLocalExitRoot {
+		return failed(RuleLocalExitRoot, "new local exit root %s differs from the previous one %s without bridge exits",
+			cert.NewLocalExitRoot.Hex(), cert.PrevLocalExitRoot.Hex())
+	}
+	if cert.Height == 0 {
+		if cert.PrevLocalExitRoot == emptyLER {
+			return passed(RuleLocalExitRoot, "previous local exit root is the empty one")
+		}
+		return skipped(RuleLocalExitRoot, "previous local exit root %s of the first certificate is not the empty one, "+
+			"it must match the one of the rollup contract", cert.PrevLocalExitRoot.Hex())
+	}
+	if previous == nil || previous.Header == nil {
+		return skipped(RuleLocalExitRoot, "there is no certificate of the previous height %d", cert.Height-1)
+	}
+	if cert.PrevLocalExitRoot != previous.Header.NewLocalExitRoot {
+		return failed(RuleLocalExitRoot, "previous local exit root %s doesn't match the new local exit root %s "+
+			"of the certificate of height %d", cert.PrevLocalExitRoot.Hex(),
+			previous.Header.NewLocalExitRoot.Hex(), cert.Height-1)
+	}
+	return passed(RuleLocalExitRoot, "previous local exit root matches the certificate of height %d", cert.Height-1)
+}
+
+// checkSignature recovers the signer of the certificate and compares it with the expected one
+func (v *Validator) checkSignature(cert *agglayertypes.Certificate) RuleResult {
+	var (
+		signature []byte
+		hash      common.Hash
+	)
+	switch data := cert.AggchainData.(type) {
+	case *agglayertypes.AggchainDataSignature:
+		signature, hash = data.Signature, cert.PPHashToSign()
+	case *agglayertypes.AggchainDataProof:
+		signature, hash = data.Signature, cert.FEPHashToSign()
+	default:
+		return skipped(RuleSignature, "certificate has no aggchain data")
+	}
+
+	signer, err := recoverSigner(hash, signature)
+	if err != nil {
+		return failed(RuleSignature, "%v", err)
+	}
+	if v.signer == nil {
+		return passed(RuleSignature, "signed by %s (the expected signer is unknown)", signer.Hex())
+	}
+	if expected := v.signer.SignerAddress(); signer != expected {
+		return failed(RuleSignature, "signed by %s but the expected signer is %s", signer.Hex(), expected.Hex())
+	}
+	return passed(RuleSignature, "signed by %s", signer.Hex())
+}
+
+// checkProofFormat checks that the aggchain data has the fields required by its type
+func checkProofFormat(cert *agglayertypes.Certificate) RuleResult {
+	switch data := cert.AggchainData.(type) {
+	case *agglayertypes.AggchainDataSignature:
+		if len(data.Signature) == 0 {
+			return failed(RuleProofFormat, "aggchain data has no signature")
+		}
+		return passed(RuleProofFormat, "aggchain data is a signature")
+	case *agglayertypes.AggchainDataProof:
+		missing := []string{}
+		if len(data.Proof) == 0 {
+			missing = append(missing, "proof")
+		}
+		if len(data.Vkey) == 0 {
+			missing = append(missing, "vkey")
+		}
+		if data.Version == "" {
+			missing = append(missing, "version")
+		}
+		if len(missing) > 0 {
+			return failed(RuleProofFormat, "aggchain proof has no %v", missing)
+		}
+		return passed(RuleProofFormat, "aggchain data is an aggchain proof (version %s)", data.Version)
+	default:
+		return failed(RuleProofFormat, "certificate has no aggchain data")
+	}
+}
+
+// recoverSigner returns the address that signed the hash
+func recoverSigner(hash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != signatureLength {
+		return common.Address{}, fmt.Errorf("invalid signature length %d, expected %d", len(signature), signatureLength)
+	}
+	sig := make([]byte, signatureLength)
+	copy(sig, signature)
+	// the recovery ID can be in the legacy format (27/28)
+	if sig[crypto.RecoveryIDOffset] >= 27 { //nolint:mnd
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error recovering the signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+func passed(rule, format string, args ...interface{}) RuleResult {
+	return RuleResult{Rule: rule, Status: RulePassed, Message: fmt.Sprintf(format, args...)}
+}
+
+func failed(rule, format string, args ...interface{}) RuleResult {
+	return RuleResult{Rule: rule, Status: RuleFailed, Message: fmt.Sprintf(format, args...)}
+}
+
+func skipped(rule, format string, args ...interface{}) RuleResult {
+	return RuleResult{Rule: rule, Status: RuleSkipped, Message: fmt.Sprintf(format, args...)}
+}
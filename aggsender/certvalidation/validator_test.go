@@ -0,0 +1,196 @@
+package certvalidation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// storageStub returns the certificates by height, and err if it's set
+type storageStub struct {
+	certs map[uint64]*types.Certificate
+	err   error
+}
+
+func (s *storageStub) GetCertificateByHeight(height uint64) (*types.Certificate, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.certs[height], nil
+}
+
+type signerStub common.Address
+
+func (s signerStub) SignerAddress() common.Address {
+	return common.Address(s)
+}
+
+func storedCert(height uint64, status agglayertypes.CertificateStatus, newLER common.Hash) *types.Certificate {
+	return &types.Certificate{Header: &types.CertificateHeader{
+		Height:           height,
+		CertificateID:    common.BigToHash(big.NewInt(int64(height) + 100)),
+		Status:           status,
+		NewLocalExitRoot: newLER,
+	}}
+}
+
+func signPP(t *testing.T, key *ecdsa.PrivateKey, cert *agglayertypes.Certificate) *agglayertypes.Certificate {
+	t.Helper()
+	sig, err := crypto.Sign(cert.PPHashToSign().Bytes(), key)
+	require.NoError(t, err)
+	cert.AggchainData = &agglayertypes.AggchainDataSignature{Signature: sig}
+	return cert
+}
+
+func ruleStatuses(verdict *Verdict) map[string]RuleStatus {
+	statuses := map[string]RuleStatus{}
+	for _, rule := range verdict.Rules {
+		statuses[rule.Rule] = rule.Status
+	}
+	return statuses
+}
+
+func TestValidator_Validate(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := signerStub(crypto.PubkeyToAddress(key.PublicKey))
+	ler1 := common.HexToHash("0x01")
+	ler2 := common.HexToHash("0x02")
+	bridgeExit := &agglayertypes.BridgeExit{TokenInfo: &agglayertypes.TokenInfo{}, Amount: big.NewInt(1)}
+
+	storage := &storageStub{certs: map[uint64]*types.Certificate{
+		0: storedCert(0, agglayertypes.Settled, ler1),
+		1: storedCert(1, agglayertypes.Settled, ler2),
+		2: storedCert(2, agglayertypes.InError, ler2),
+		4: storedCert(4, agglayertypes.Pending, ler2),
+	}}
+	emptyStorage := &storageStub{}
+
+	tests := []struct {
+		name          string
+		storage       CertificateStorer
+		cert          *agglayertypes.Certificate
+		signer        SignerAddresser
+		expectedValid bool
+		expected      map[string]RuleStatus
+	}{
+		{
+			name:    "valid first certificate",
+			storage: emptyStorage,
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 1, Height: 0,
+				PrevLocalExitRoot: emptyLER, NewLocalExitRoot: emptyLER}),
+			signer:        signer,
+			expectedValid: true,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RulePassed,
+				RuleLocalExitRoot: RulePassed, RuleSignature: RulePassed, RuleProofFormat: RulePassed},
+		},
+		{
+			name: "valid retry of a certificate in error",
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 1, Height: 2,
+				PrevLocalExitRoot: ler2, NewLocalExitRoot: ler2}),
+			signer:        signer,
+			expectedValid: true,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RulePassed,
+				RuleLocalExitRoot: RulePassed, RuleSignature: RulePassed, RuleProofFormat: RulePassed},
+		},
+		{
+			name: "height taken by another certificate",
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 1, Height: 1,
+				PrevLocalExitRoot: ler1, NewLocalExitRoot: ler2, BridgeExits: []*agglayertypes.BridgeExit{bridgeExit}}),
+			signer: signer,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RuleFailed,
+				RuleLocalExitRoot: RulePassed, RuleSignature: RulePassed, RuleProofFormat: RulePassed},
+		},
+		{
+			name: "previous height not settled",
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 1, Height: 5,
+				PrevLocalExitRoot: ler2, NewLocalExitRoot: ler2}),
+			signer: signer,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RuleFailed,
+				RuleLocalExitRoot: RulePassed, RuleSignature: RulePassed, RuleProofFormat: RulePassed},
+		},
+		{
+			name: "wrong network, LER chain and signer",
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 2, Height: 2,
+				PrevLocalExitRoot: ler1, NewLocalExitRoot: ler1}),
+			signer: signerStub(common.HexToAddress("0x1234")),
+			expected: map[string]RuleStatus{RuleNetworkID: RuleFailed, RuleHeightContinuity: RulePassed,
+				RuleLocalExitRoot: RuleFailed, RuleSignature: RuleFailed, RuleProofFormat: RulePassed},
+		},
+		{
+			name: "unknown signer and no previous certificate",
+			cert: signPP(t, key, &agglayertypes.Certificate{NetworkID: 1, Height: 7,
+				PrevLocalExitRoot: ler2, NewLocalExitRoot: ler2}),
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RuleFailed,
+				RuleLocalExitRoot: RuleSkipped, RuleSignature: RulePassed, RuleProofFormat: RulePassed},
+		},
+		{
+			name:    "aggchain proof without vkey and invalid signature",
+			storage: emptyStorage,
+			cert: &agglayertypes.Certificate{NetworkID: 1, Height: 0, PrevLocalExitRoot: ler1, NewLocalExitRoot: ler1,
+				AggchainData: &agglayertypes.AggchainDataProof{Proof: []byte{1}, Version: "v1", Signature: []byte{1}}},
+			signer: signer,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RulePassed,
+				RuleLocalExitRoot: RuleSkipped, RuleSignature: RuleFailed, RuleProofFormat: RuleFailed},
+		},
+		{
+			name:    "no aggchain data",
+			storage: emptyStorage,
+			cert:    &agglayertypes.Certificate{NetworkID: 1, Height: 0, PrevLocalExitRoot: emptyLER},
+			signer:  signer,
+			expected: map[string]RuleStatus{RuleNetworkID: RulePassed, RuleHeightContinuity: RulePassed,
+				RuleLocalExitRoot: RuleFailed, RuleSignature: RuleSkipped, RuleProofFormat: RuleFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certStorage := tt.storage
+			if certStorage == nil {
+				certStorage = storage
+			}
+			verdict, err := NewValidator(1, certStorage, tt.signer).Validate(tt.cert)
+			require.NoError(t, err)
+			require.Equal(t, tt.cert.Hash(), verdict.CertificateID)
+			require.Equal(t, tt.cert.Height, verdict.Height)
+			require.Equal(t, tt.expectedValid, verdict.Valid)
+			require.Equal(t, tt.expected, ruleStatuses(verdict))
+		})
+	}
+}
+
+func TestValidator_ValidateStorageError(t *testing.T) {
+	storage := &storageStub{err: errors.New("db closed")}
+	_, err := NewValidator(1, storage, nil).Validate(&agglayertypes.Certificate{Height: 1})
+	require.ErrorContains(t, err, "db closed")
+
+	_, err = NewValidator(1, storage, nil).Validate(nil)
+	require.ErrorContains(t, err, "certificate is nil")
+}
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hash := common.HexToHash("0xabcd")
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+
+	signer, err := recoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	// legacy recovery ID
+	sig[crypto.RecoveryIDOffset] += 27
+	signer, err = recoverSigner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	_, err = recoverSigner(hash, sig[:64])
+	require.ErrorContains(t, err, "invalid signature length 64")
+}
@@ -1,11 +1,17 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/agglayer/aggkit/aggsender/archiver"
+	"github.com/agglayer/aggkit/aggsender/eventbus"
 	"github.com/agglayer/aggkit/aggsender/optimistic"
+	"github.com/agglayer/aggkit/aggsender/signerreload"
+	aggsendertypes "github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/config/types"
+	aggkitdb "github.com/agglayer/aggkit/db"
 	aggkitgrpc "github.com/agglayer/aggkit/grpc"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	ethCommon "github.com/ethereum/go-ethereum/common"
@@ -15,10 +21,15 @@ import (
 type Config struct {
 	// StoragePath is the path of the sqlite db on which the AggSender will store the data
 	StoragePath string `mapstructure:"StoragePath"`
+	// StorageTuning tunes the sqlite connections of the storage (busy timeout, synchronous mode, cache)
+	StorageTuning aggkitdb.SQLiteConfig `mapstructure:"StorageTuning"`
 	// AgglayerClient is the Agglayer gRPC client configuration
 	AgglayerClient *aggkitgrpc.ClientConfig `mapstructure:"AgglayerClient"`
 	// AggsenderPrivateKey is the private key which is used to sign certificates
 	AggsenderPrivateKey signertypes.SignerConfig `mapstructure:"AggsenderPrivateKey"`
+	// SignerReload is the configuration of the live reload of the AggsenderPrivateKey credentials when they
+	// are rotated (the optimistic signer has its own, OptimisticModeConfig.SignerReload)
+	SignerReload signerreload.Config `mapstructure:"SignerReload"`
 	// URLRPCL2 is the URL of the L2 RPC node
 	URLRPCL2 string `mapstructure:"URLRPCL2"`
 	// BlockFinality indicates which finality the AggLayer follows
@@ -39,6 +50,19 @@ type Config struct {
 	// MaxCertSize is the maximum size of the certificate (the emitted certificate cannot be bigger that this size)
 	// 0 is infinite
 	MaxCertSize uint `mapstructure:"MaxCertSize"`
+	// MaxConcurrentClaimProofs is the maximum number of claims whose merkle proofs are generated
+	// concurrently while building a certificate. 0 or 1 generates them sequentially
+	MaxConcurrentClaimProofs uint `mapstructure:"MaxConcurrentClaimProofs"`
+	// MaxBlocksPerBridgeQuery is the maximum number of L2 blocks of each query of bridges and claims to the
+	// bridge syncer. Wider ranges are queried in chunks, so the first certificate after a long time without
+	// certificates doesn't lock the syncer DB with a single giant query. 0 means no limit
+	MaxBlocksPerBridgeQuery uint64 `mapstructure:"MaxBlocksPerBridgeQuery"`
+	// L2IdleThreshold is the time without new L2 blocks beyond the last certified block after which the
+	// L2 chain is reported as idle (and the "no new blocks" messages are not logged). 0 means disabled
+	L2IdleThreshold types.Duration `mapstructure:"L2IdleThreshold"`
+	// L2HaltAlertThreshold is the time without new L2 blocks after which the L2 chain is reported as
+	// halted and an error is logged. 0 means no alert. It requires L2IdleThreshold
+	L2HaltAlertThreshold types.Duration `mapstructure:"L2HaltAlertThreshold"`
 	// DryRun is a flag to enable the dry run mode
 	// in this mode the AggSender will not send the certificates to Agglayer
 	DryRun bool `mapstructure:"DryRun"`
@@ -53,6 +77,24 @@ type Config struct {
 	// RetryCertAfterInError when a cert pass to 'InError'
 	// state the AggSender will try to resend it immediately
 	RetryCertAfterInError bool `mapstructure:"RetryCertAfterInError"`
+	// InErrorWaitRetryDelay is the delay before sending a new certificate when the last one is InError
+	// by a transient error (e.g. an agglayer internal error or a height conflict)
+	InErrorWaitRetryDelay types.Duration `mapstructure:"InErrorWaitRetryDelay"`
+	// EpochRolloverRecheckInterval is the interval to check again the status of a certificate submitted in
+	// the previous epoch that is still pending when a new epoch starts (epoch rollover), to send the next
+	// certificate in the same epoch once it's processed. 0 waits for the next epoch
+	EpochRolloverRecheckInterval types.Duration `mapstructure:"EpochRolloverRecheckInterval"`
+	// AgglayerMaintenanceBackoff is the initial delay to try again a submission rejected because the
+	// agglayer is in maintenance (or unavailable), doubled on each attempt. 0 handles it as any other error
+	AgglayerMaintenanceBackoff types.Duration `mapstructure:"AgglayerMaintenanceBackoff"`
+	// AgglayerMaintenanceMaxBackoff is the max delay between the submissions while the agglayer is in maintenance
+	AgglayerMaintenanceMaxBackoff types.Duration `mapstructure:"AgglayerMaintenanceMaxBackoff"`
+	// MaintenanceWindows are the recurring windows (cron schedule and duration) during which no new
+	// certificates are submitted. The status of the sent certificates is still checked
+	MaintenanceWindows aggsendertypes.MaintenanceWindowsConfig `mapstructure:"MaintenanceWindows"`
+	// RetryInErrorRequiringIntervention allows to send a new certificate when the last one is InError
+	// by an error that requires human intervention (e.g. a local exit root mismatch or a size limit)
+	RetryInErrorRequiringIntervention bool `mapstructure:"RetryInErrorRequiringIntervention"`
 	// MaxSubmitCertificateRate is the maximum rate of certificate submission allowed
 	MaxSubmitCertificateRate common.RateLimitConfig `mapstructure:"MaxSubmitCertificateRate"`
 	// GlobalExitRootL2Addr is the address of the GlobalExitRootManager contract on l2 sovereign chain
@@ -71,6 +113,9 @@ type Config struct {
 	// RequireOneBridgeInPPCertificate is a flag to force the AggSender to have at least one bridge exit
 	// for the Pessimistic Proof certificates
 	RequireOneBridgeInPPCertificate bool `mapstructure:"RequireOneBridgeInPPCertificate"`
+	// HeartbeatCertificateInterval is the interval to send an empty certificate (with no bridge exits)
+	// for the Pessimistic Proof mode when there is no bridge activity. 0 means disabled
+	HeartbeatCertificateInterval types.Duration `mapstructure:"HeartbeatCertificateInterval"`
 	// RollupManagerAddr is the address of the RollupManager contract on L1
 	RollupManagerAddr ethCommon.Address `mapstructure:"RollupManagerAddr"`
 	// RollupCreationBlockL1 is the block number when the rollup was created on L1
@@ -81,6 +126,152 @@ type Config struct {
 	// StopOnFinishedSendingAllCertificates is a flag to stop the AggSender when it finishes sending all certificates
 	// up to MaxL2BlockNumber
 	StopOnFinishedSendingAllCertificates bool `mapstructure:"StopOnFinishedSendingAllCertificates"`
+	// HardForks is the list of L2 hard forks (sorted by BlockNumber). A certificate never includes
+	// blocks of two forks and it's tagged with the fork name in the metadata
+	HardForks aggsendertypes.HardForks `mapstructure:"HardForks"`
+	// CertificateCustomFields are key/value entries (e.g. operator name, environment) added to the
+	// context of the certificates (only AggchainProof mode). The keys must be lower case
+	CertificateCustomFields aggsendertypes.CertificateCustomFields `mapstructure:"CertificateCustomFields"`
+	// CertificateTags identify the deployment (e.g. environment, region) of the certificates. They are
+	// stored with the certificates, to filter them in the admin API, and in the AggchainProof mode they
+	// are also added to the context of the certificates with the prefix aggkit.tag.
+	CertificateTags aggsendertypes.CertificateTags `mapstructure:"CertificateTags"`
+	// InstanceLeaseTTL is the duration of the lease on the storage that the running instance renews
+	// periodically, so a second instance using the same storage refuses to start. 0 means disabled
+	InstanceLeaseTTL types.Duration `mapstructure:"InstanceLeaseTTL"`
+	// CheckAgglayerHeightBeforeSend checks before sending a certificate that the last certificate known by
+	// the agglayer for the network is the last one sent by this instance, to detect other instances
+	// (with a different storage) sending certificates for the same network
+	CheckAgglayerHeightBeforeSend bool `mapstructure:"CheckAgglayerHeightBeforeSend"`
+	// CheckLocalExitRootAgainstContract checks before sending a certificate that its new local exit root
+	// matches the local exit root of the L2 bridge contract at the last block of the certificate.
+	// It requires an archive node for the L2 RPC
+	CheckLocalExitRootAgainstContract bool `mapstructure:"CheckLocalExitRootAgainstContract"`
+	// FastSyncFromAgglayer overrides the initial block of the L2 bridge syncer on a fresh start (no storage of
+	// the AggSender nor of the syncer): it starts at the last block of the last certificate settled in the
+	// agglayer, with the local exit tree read from the L2 bridge contract at that block. It's not applied if
+	// the bridge service runs (it needs all the deposits) and it requires the state of that block in the L2 RPC
+	FastSyncFromAgglayer bool `mapstructure:"FastSyncFromAgglayer"`
+	// AgglayerMirrorInterval is how often the certificate headers of the network are copied from the agglayer
+	// into the local storage (0 = disabled)
+	AgglayerMirrorInterval types.Duration `mapstructure:"AgglayerMirrorInterval"`
+	// AgglayerMirrorMaxHeadersPerRun is the maximum number of past certificate headers queried to the agglayer
+	// on each mirroring run, so a long history is mirrored in several runs (0 = no limit)
+	AgglayerMirrorMaxHeadersPerRun uint32 `mapstructure:"AgglayerMirrorMaxHeadersPerRun"`
+	// AgglayerLatestCertificateCacheTTL is how long the latest settled and known certificates of the network
+	// are cached by the agglayer client for the recovery, the audits and the health checks (0 = disabled)
+	AgglayerLatestCertificateCacheTTL types.Duration `mapstructure:"AgglayerLatestCertificateCacheTTL"`
+	// ArchiverConfig is the configuration to archive the submitted certificates to an object storage
+	ArchiverConfig archiver.Config `mapstructure:"ArchiverConfig"`
+	// EventBusConfig is the configuration to publish the lifecycle events of the certificates
+	// to a message bus (NATS or Redis Streams)
+	EventBusConfig eventbus.Config `mapstructure:"EventBusConfig"`
+	// FeeBudget estimates the fee of each certificate and limits the fees spent per epoch and per day.
+	// When the budget is exhausted the certificate is not sent and its bridges go in the next one
+	FeeBudget aggsendertypes.FeeBudgetConfig `mapstructure:"FeeBudget"`
+	// ProverSLO tracks the proving time and the proof size of the aggchain proofs, and alerts when the
+	// percentiles of the last proofs exceed the limits
+	ProverSLO aggsendertypes.ProverSLOConfig `mapstructure:"ProverSLO"`
+	// TokenPolicy excludes from the certificates the bridge exits and imported bridge exits of the
+	// denied (or not allowed) tokens and origin networks
+	TokenPolicy aggsendertypes.TokenPolicyConfig `mapstructure:"TokenPolicy"`
+	// ApprovalHook submits the summary of each certificate to an external hook that approves it before
+	// it's signed and sent
+	ApprovalHook aggsendertypes.ApprovalHookConfig `mapstructure:"ApprovalHook"`
+	// DataAvailability verifies the data availability attestations of the blocks of each certificate
+	// against an external DA endpoint before including them (AggchainProof mode)
+	DataAvailability aggsendertypes.DataAvailabilityConfig `mapstructure:"DataAvailability"`
+}
+
+// ErrInvalidModeConfig is returned when the config sets a knob that is not used by the mode (flow) of the
+// AggSender, or misses one that the mode requires
+var ErrInvalidModeConfig = errors.New("invalid config for the AggSender mode")
+
+// Validate cross-checks the knobs of the config against the mode of the AggSender, so the ones that a
+// flow would silently ignore are reported at startup:
+//   - PessimisticProof: RequireOneBridgeInPPCertificate and HeartbeatCertificateInterval
+//   - AggchainProof (FEP): AggkitProverClient, GlobalExitRootL2Addr, RequireNoFEPBlockGap,
+//     CertificateCustomFields, OptimisticModeConfig (the optimistic signer), ProverSLO and DataAvailability
+//
+// The rest of the knobs are common to both modes
+func (c Config) Validate() error {
+	if c.StopOnFinishedSendingAllCertificates && c.MaxL2BlockNumber == 0 {
+		return fmt.Errorf("%w: StopOnFinishedSendingAllCertificates requires MaxL2BlockNumber", ErrInvalidModeConfig)
+	}
+	if err := c.TokenPolicy.Validate(); err != nil {
+		return err
+	}
+	if err := c.ApprovalHook.Validate(); err != nil {
+		return err
+	}
+	if err := c.MaintenanceWindows.Validate(); err != nil {
+		return err
+	}
+	switch aggsendertypes.AggsenderMode(c.Mode) {
+	case aggsendertypes.PessimisticProofMode:
+		return c.validatePessimisticProof()
+	case aggsendertypes.AggchainProofMode:
+		return c.validateAggchainProof()
+	default:
+		return fmt.Errorf("unsupported Aggsender mode: %s", c.Mode)
+	}
+}
+
+func (c Config) validatePessimisticProof() error {
+	if c.HeartbeatCertificateInterval.Duration > 0 && c.RequireOneBridgeInPPCertificate {
+		return fmt.Errorf("%w: HeartbeatCertificateInterval can't be used with RequireOneBridgeInPPCertificate "+
+			"because the heartbeat certificates have no bridge exits", ErrInvalidModeConfig)
+	}
+	if c.RequireNoFEPBlockGap {
+		return c.onlyAggchainProofError("RequireNoFEPBlockGap")
+	}
+	if c.ProverSLO.Enabled {
+		return c.onlyAggchainProofError("ProverSLO")
+	}
+	if c.DataAvailability.Enabled {
+		return c.onlyAggchainProofError("DataAvailability")
+	}
+	if len(c.CertificateCustomFields) > 0 {
+		return fmt.Errorf("%w: CertificateCustomFields are only used in %s mode, the %s certificates have no context",
+			ErrInvalidModeConfig, aggsendertypes.AggchainProofMode, aggsendertypes.PessimisticProofMode)
+	}
+	return nil
+}
+
+func (c Config) validateAggchainProof() error {
+	if c.RequireOneBridgeInPPCertificate {
+		return c.onlyPessimisticProofError("RequireOneBridgeInPPCertificate")
+	}
+	if c.HeartbeatCertificateInterval.Duration > 0 {
+		return c.onlyPessimisticProofError("HeartbeatCertificateInterval")
+	}
+	if err := c.AggkitProverClient.Validate(); err != nil {
+		return fmt.Errorf("invalid aggkit prover client config: %w", err)
+	}
+	if err := c.ProverSLO.Validate(); err != nil {
+		return fmt.Errorf("invalid prover SLO config: %w", err)
+	}
+	if err := c.DataAvailability.Validate(); err != nil {
+		return fmt.Errorf("invalid data availability config: %w", err)
+	}
+	if c.GlobalExitRootL2Addr == (ethCommon.Address{}) {
+		return fmt.Errorf("%w: GlobalExitRootL2 is required in %s mode", ErrInvalidModeConfig, c.Mode)
+	}
+	if c.OptimisticModeConfig.TrustedSequencerKey.Method == "" {
+		return fmt.Errorf("%w: OptimisticModeConfig.TrustedSequencerKey is required in %s mode to sign "+
+			"the optimistic proofs", ErrInvalidModeConfig, c.Mode)
+	}
+	return nil
+}
+
+func (c Config) onlyPessimisticProofError(field string) error {
+	return fmt.Errorf("%w: %s is only used in %s mode, remove it from the %s config",
+		ErrInvalidModeConfig, field, aggsendertypes.PessimisticProofMode, c.Mode)
+}
+
+func (c Config) onlyAggchainProofError(field string) error {
+	return fmt.Errorf("%w: %s is only used in %s mode, remove it from the %s config",
+		ErrInvalidModeConfig, field, aggsendertypes.AggchainProofMode, c.Mode)
 }
 
 func (c Config) CheckCertConfigBriefString() string {
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/optimistic"
+	aggsendertypes "github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/config/types"
+	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	fepConfig := func() Config {
+		return Config{
+			Mode:                 string(aggsendertypes.AggchainProofMode),
+			AggkitProverClient:   aggkitgrpc.DefaultConfig(),
+			GlobalExitRootL2Addr: ethCommon.HexToAddress("0x1"),
+			OptimisticModeConfig: optimistic.Config{
+				TrustedSequencerKey: signertypes.SignerConfig{Method: signertypes.MethodLocal},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		cfg    func() Config
+		errMsg string
+	}{
+		{
+			name: "PessimisticProof mode",
+			cfg: func() Config {
+				return Config{
+					Mode:                            string(aggsendertypes.PessimisticProofMode),
+					RequireOneBridgeInPPCertificate: true,
+					MaxL2BlockNumber:                100,
+				}
+			},
+		},
+		{
+			name: "PessimisticProof mode ignores the optimistic and prover configs",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.Mode = string(aggsendertypes.PessimisticProofMode)
+				return cfg
+			},
+		},
+		{
+			name: "PessimisticProof mode with heartbeat and RequireOneBridgeInPPCertificate",
+			cfg: func() Config {
+				return Config{
+					Mode:                            string(aggsendertypes.PessimisticProofMode),
+					RequireOneBridgeInPPCertificate: true,
+					HeartbeatCertificateInterval:    types.NewDuration(time.Hour),
+				}
+			},
+			errMsg: "HeartbeatCertificateInterval can't be used with RequireOneBridgeInPPCertificate",
+		},
+		{
+			name: "PessimisticProof mode with RequireNoFEPBlockGap",
+			cfg: func() Config {
+				return Config{Mode: string(aggsendertypes.PessimisticProofMode), RequireNoFEPBlockGap: true}
+			},
+			errMsg: "RequireNoFEPBlockGap is only used in AggchainProof mode",
+		},
+		{
+			name: "PessimisticProof mode with CertificateCustomFields",
+			cfg: func() Config {
+				return Config{
+					Mode:                    string(aggsendertypes.PessimisticProofMode),
+					CertificateCustomFields: aggsendertypes.CertificateCustomFields{"operator": "acme"},
+				}
+			},
+			errMsg: "CertificateCustomFields are only used in AggchainProof mode",
+		},
+		{
+			name: "PessimisticProof mode with ProverSLO",
+			cfg: func() Config {
+				return Config{
+					Mode:      string(aggsendertypes.PessimisticProofMode),
+					ProverSLO: aggsendertypes.ProverSLOConfig{Enabled: true, WindowSize: 10, Percentile: 95},
+				}
+			},
+			errMsg: "ProverSLO is only used in AggchainProof mode",
+		},
+		{
+			name: "PessimisticProof mode with DataAvailability",
+			cfg: func() Config {
+				return Config{
+					Mode:             string(aggsendertypes.PessimisticProofMode),
+					DataAvailability: aggsendertypes.DataAvailabilityConfig{Enabled: true, URL: "http://da"},
+				}
+			},
+			errMsg: "DataAvailability is only used in AggchainProof mode",
+		},
+		{
+			name: "AggchainProof mode",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.RequireNoFEPBlockGap = true
+				cfg.CertificateCustomFields = aggsendertypes.CertificateCustomFields{"operator": "acme"}
+				return cfg
+			},
+		},
+		{
+			name: "AggchainProof mode with RequireOneBridgeInPPCertificate",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.RequireOneBridgeInPPCertificate = true
+				return cfg
+			},
+			errMsg: "RequireOneBridgeInPPCertificate is only used in PessimisticProof mode",
+		},
+		{
+			name: "AggchainProof mode with HeartbeatCertificateInterval",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.HeartbeatCertificateInterval = types.NewDuration(time.Hour)
+				return cfg
+			},
+			errMsg: "HeartbeatCertificateInterval is only used in PessimisticProof mode",
+		},
+		{
+			name: "AggchainProof mode without AggkitProverClient",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.AggkitProverClient = nil
+				return cfg
+			},
+			errMsg: "invalid aggkit prover client config",
+		},
+		{
+			name: "AggchainProof mode without GlobalExitRootL2",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.GlobalExitRootL2Addr = ethCommon.Address{}
+				return cfg
+			},
+			errMsg: "GlobalExitRootL2 is required in AggchainProof mode",
+		},
+		{
+			name: "AggchainProof mode without optimistic signer",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.OptimisticModeConfig = optimistic.Config{}
+				return cfg
+			},
+			errMsg: "OptimisticModeConfig.TrustedSequencerKey is required in AggchainProof mode",
+		},
+		{
+			name: "AggchainProof mode with ProverSLO",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.ProverSLO = aggsendertypes.ProverSLOConfig{Enabled: true, WindowSize: 10, Percentile: 95}
+				return cfg
+			},
+		},
+		{
+			name: "AggchainProof mode with an invalid ProverSLO percentile",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.ProverSLO = aggsendertypes.ProverSLOConfig{Enabled: true, WindowSize: 10, Percentile: 101}
+				return cfg
+			},
+			errMsg: "ProverSLO.Percentile must be in the range (0, 100]",
+		},
+		{
+			name: "AggchainProof mode without ProverSLO window",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.ProverSLO = aggsendertypes.ProverSLOConfig{Enabled: true, Percentile: 95}
+				return cfg
+			},
+			errMsg: "ProverSLO.WindowSize must be greater than 0",
+		},
+		{
+			name: "AggchainProof mode with DataAvailability",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.DataAvailability = aggsendertypes.DataAvailabilityConfig{Enabled: true, URL: "http://da"}
+				return cfg
+			},
+		},
+		{
+			name: "AggchainProof mode with DataAvailability without URL",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.DataAvailability = aggsendertypes.DataAvailabilityConfig{Enabled: true}
+				return cfg
+			},
+			errMsg: "DataAvailability.URL is required",
+		},
+		{
+			name: "token allowed and denied",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.TokenPolicy = aggsendertypes.TokenPolicyConfig{
+					DeniedTokens:  []ethCommon.Address{ethCommon.HexToAddress("0x1")},
+					AllowedTokens: []ethCommon.Address{ethCommon.HexToAddress("0x1")},
+				}
+				return cfg
+			},
+			errMsg: "is both allowed and denied",
+		},
+		{
+			name: "ApprovalHook without URL",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.ApprovalHook = aggsendertypes.ApprovalHookConfig{Enabled: true}
+				return cfg
+			},
+			errMsg: "ApprovalHook.URL is required",
+		},
+		{
+			name: "ApprovalHook with an invalid failure policy",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.ApprovalHook = aggsendertypes.ApprovalHookConfig{Enabled: true, URL: "http://hook", FailurePolicy: "Foo"}
+				return cfg
+			},
+			errMsg: "invalid ApprovalHook.FailurePolicy",
+		},
+		{
+			name: "MaintenanceWindows with an invalid schedule",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.MaintenanceWindows = aggsendertypes.MaintenanceWindowsConfig{
+					{Schedule: "0 3 * * 9", Duration: types.Duration{Duration: time.Hour}},
+				}
+				return cfg
+			},
+			errMsg: "invalid MaintenanceWindows[0].Schedule",
+		},
+		{
+			name: "MaintenanceWindows without duration",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.MaintenanceWindows = aggsendertypes.MaintenanceWindowsConfig{{Schedule: "0 3 * * 2"}}
+				return cfg
+			},
+			errMsg: "MaintenanceWindows[0].Duration must be greater than 0",
+		},
+		{
+			name: "PessimisticProof mode with TokenPolicy",
+			cfg: func() Config {
+				return Config{
+					Mode:        string(aggsendertypes.PessimisticProofMode),
+					TokenPolicy: aggsendertypes.TokenPolicyConfig{DeniedOriginNetworks: []uint32{7}},
+				}
+			},
+		},
+		{
+			name: "StopOnFinishedSendingAllCertificates without MaxL2BlockNumber",
+			cfg: func() Config {
+				cfg := fepConfig()
+				cfg.StopOnFinishedSendingAllCertificates = true
+				return cfg
+			},
+			errMsg: "StopOnFinishedSendingAllCertificates requires MaxL2BlockNumber",
+		},
+		{
+			name:   "unsupported mode",
+			cfg:    func() Config { return Config{Mode: "FEP"} },
+			errMsg: "unsupported Aggsender mode: FEP",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg().Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+
+	err := Config{Mode: string(aggsendertypes.PessimisticProofMode), RequireNoFEPBlockGap: true}.Validate()
+	require.ErrorIs(t, err, ErrInvalidModeConfig)
+}
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/db/migrations"
+	"github.com/agglayer/aggkit/aggsender/metrics"
 	"github.com/agglayer/aggkit/aggsender/types"
 	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/db"
@@ -54,16 +56,23 @@ type AggSenderStorage interface {
 	DeleteCertificate(ctx context.Context, certificateID common.Hash) error
 	// GetCertificateHeadersByStatus returns a list of certificate headers by their status
 	GetCertificateHeadersByStatus(status []agglayertypes.CertificateStatus) ([]*types.CertificateHeader, error)
-	// UpdateCertificateStatus updates certificate status in db
+	// UpdateCertificateStatus updates certificate status (and the root cause if it's InError) in db
 	UpdateCertificateStatus(
 		ctx context.Context,
 		certificateID common.Hash,
 		newStatus agglayertypes.CertificateStatus,
+		errorCategory types.CertificateErrorCategory,
 		updatedAt uint32) error
 	// GetLastSentCertificateHeader returns the last certificate header sent to the aggLayer
 	GetLastSentCertificateHeader() (*types.CertificateHeader, error)
 	// GetCertificateHeaderByHeight returns a certificate header by its height
 	GetCertificateHeaderByHeight(height uint64) (*types.CertificateHeader, error)
+	// GetCertificateHeadersInHeightRange returns the certificate headers with fromHeight <= height <= toHeight
+	GetCertificateHeadersInHeightRange(fromHeight, toHeight uint64) ([]*types.CertificateHeader, error)
+	// GetCertificateHeadersByTags returns the last certificate headers (newest first, up to limit) that have
+	// all the tags, and one of the statuses if any is given
+	GetCertificateHeadersByTags(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus,
+		limit uint64) ([]*types.CertificateHeader, error)
 	// GetLastSentCertificateHeaderWithProofIfInError returns the last certificate header sent to the aggLayer
 	// and the aggchain proof if the certificate is in error
 	GetLastSentCertificateHeaderWithProofIfInError(
@@ -72,6 +81,64 @@ type AggSenderStorage interface {
 	SaveNonAcceptedCertificate(ctx context.Context, nonAcceptedCert *NonAcceptedCertificate) error
 	// GetNonAcceptedCertificate returns the last non-accepted certificate
 	GetNonAcceptedCertificate() (*NonAcceptedCertificate, error)
+	// SaveCertificateJournalEntry saves (or replaces) the journal entry of a certificate before sending it
+	SaveCertificateJournalEntry(ctx context.Context, entry *CertificateJournalEntry) error
+	// UpdateCertificateJournalEntryState updates the state of the journal entry for the given height
+	UpdateCertificateJournalEntryState(
+		ctx context.Context,
+		height uint64,
+		state CertificateJournalState,
+		certificateID *common.Hash) error
+	// GetPendingCertificateJournalEntries returns the journal entries that are still in pre-commit state
+	GetPendingCertificateJournalEntries() ([]*CertificateJournalEntry, error)
+	// SaveCertificateBatch saves the certificate (with its proof and status) and updates the state of
+	// its journal entry in a single transaction
+	SaveCertificateBatch(ctx context.Context, batch CertificateWriteBatch) error
+	// SaveCertificateBuildParams saves (or replaces) the snapshot of the build params of a certificate
+	// before sending it
+	SaveCertificateBuildParams(ctx context.Context, snapshot *CertificateBuildParamsSnapshot) error
+	// GetCertificateBuildParams returns the snapshot of the build params of the certificate with the
+	// given height, or nil if there is none
+	GetCertificateBuildParams(height uint64) (*CertificateBuildParamsSnapshot, error)
+	// SaveCertificateFee saves the estimated fee of a certificate submitted to the agglayer
+	SaveCertificateFee(ctx context.Context, fee *CertificateFee) error
+	// GetCertificateFeesSpent returns the sum of the fees of the certificates sent since sinceTime
+	// and the sum of the fees of the certificates sent in the given epoch
+	GetCertificateFeesSpent(sinceTime uint32, epoch uint64) (sinceTimeFees uint64, epochFees uint64, err error)
+	// SaveCertificateAnalytics saves the aggregated figures of a certificate submitted to the agglayer
+	SaveCertificateAnalytics(ctx context.Context, analytics *types.CertificateAnalytics) error
+	// GetCertificateAnalytics returns the analytics of the last submission of the certificate with the
+	// given height, or nil if there are none
+	GetCertificateAnalytics(height uint64) (*types.CertificateAnalytics, error)
+	// SaveProofStats saves the proving time and the proof size of the aggchain proof of a certificate
+	SaveProofStats(ctx context.Context, stats *types.ProofStats) error
+	// GetLastProofStats returns the stats of the last proofs generated, the most recent first
+	GetLastProofStats(limit uint32) ([]*types.ProofStats, error)
+	// SaveMirroredCertificateHeaders saves (or replaces) the certificate headers of the agglayer, by height
+	SaveMirroredCertificateHeaders(ctx context.Context, headers []*types.MirroredCertificateHeader) error
+	// GetMirroredCertificateHeaders returns the mirrored headers with fromHeight <= height <= toHeight
+	GetMirroredCertificateHeaders(fromHeight, toHeight uint64) ([]*types.MirroredCertificateHeader, error)
+	// GetLastMirroredCertificateHeader returns the mirrored header with the highest height, or nil if there are none
+	GetLastMirroredCertificateHeader() (*types.MirroredCertificateHeader, error)
+	// AcquireInstanceLease acquires the instance lease (or renews it if it's already held by ownerID)
+	// until now + ttl. It fails with ErrInstanceLeaseHeld if another instance holds a not expired lease
+	AcquireInstanceLease(ctx context.Context, ownerID string, now time.Time, ttl time.Duration) (*InstanceLease, error)
+	// ReleaseInstanceLease releases the instance lease if it's held by ownerID
+	ReleaseInstanceLease(ctx context.Context, ownerID string) error
+	// SaveCertificateAuditEvents appends the events to the audit trail of the certificates
+	SaveCertificateAuditEvents(ctx context.Context, events []*types.CertificateAuditEvent) error
+	// GetCertificateAuditEvents returns the audit trail of the certificate with the given height, the oldest
+	// event first
+	GetCertificateAuditEvents(height uint64) ([]*types.CertificateAuditEvent, error)
+}
+
+// CertificateWriteBatch groups the writes of a certificate that are persisted in one transaction
+type CertificateWriteBatch struct {
+	// Certificate is the certificate to save, including its aggchain proof and status
+	Certificate types.Certificate
+	// JournalState is the new state of the journal entry of the certificate height.
+	// If it's empty the journal is not updated
+	JournalState CertificateJournalState
 }
 
 var _ AggSenderStorage = (*AggSenderSQLStorage)(nil)
@@ -80,34 +147,95 @@ var _ AggSenderStorage = (*AggSenderSQLStorage)(nil)
 type AggSenderSQLStorageConfig struct {
 	DBPath                  string
 	KeepCertificatesHistory bool
+	// Tuning tunes the sqlite connections
+	Tuning db.SQLiteConfig
 }
 
-// AggSenderSQLStorage is the struct that implements the AggSenderStorage interface
+// AggSenderSQLStorage is the struct that implements the AggSenderStorage interface.
+// The writes go through a single connection (so they are queued instead of competing for the
+// sqlite lock) and the reads use a separate pool, so in WAL mode they are not blocked by
+// long write transactions (e.g. persisting a big aggchain proof)
 type AggSenderSQLStorage struct {
 	dbtypes.KeyValueStorager
 	logger *log.Logger
 	db     *sql.DB
+	readDB *sql.DB
 	cfg    AggSenderSQLStorageConfig
 }
 
 // NewAggSenderSQLStorage creates a new AggSenderSQLStorage
 func NewAggSenderSQLStorage(logger *log.Logger, cfg AggSenderSQLStorageConfig) (*AggSenderSQLStorage, error) {
-	database, err := db.NewSQLiteDB(cfg.DBPath)
+	if err := cfg.Tuning.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage tuning config: %w", err)
+	}
+	database, err := db.NewSQLiteWriterDB(cfg.DBPath, cfg.Tuning)
 	if err != nil {
 		return nil, err
 	}
 	if err := migrations.RunMigrations(logger, database); err != nil {
 		return nil, err
 	}
+	// the reader is opened after the migrations, so the db file already exists in WAL mode
+	readDB, err := db.NewSQLiteReaderDB(cfg.DBPath, cfg.Tuning)
+	if err != nil {
+		return nil, err
+	}
 
 	return &AggSenderSQLStorage{
 		db:               database,
+		readDB:           readDB,
 		logger:           logger,
 		cfg:              cfg,
 		KeyValueStorager: db.NewKeyValueStorage(database),
 	}, nil
 }
 
+// executeWriteTx runs fn in a write transaction that is committed if fn succeeds,
+// and records the duration of the transaction (or the failure) in the storage metrics
+func (a *AggSenderSQLStorage) executeWriteTx(ctx context.Context, operation string,
+	fn func(tx dbtypes.Txer) error) error {
+	start := time.Now()
+	if err := a.executeTx(ctx, fn); err != nil {
+		metrics.StorageError(operation)
+		return err
+	}
+	metrics.StorageWrite(operation, time.Since(start))
+	return nil
+}
+
+func (a *AggSenderSQLStorage) executeTx(ctx context.Context, fn func(tx dbtypes.Txer) error) error {
+	tx, err := newTxer(ctx, a.db)
+	if err != nil {
+		return fmt.Errorf("failed to create db transaction: %w", err)
+	}
+	shouldRollback := true
+	defer func() {
+		if shouldRollback {
+			if errRllbck := tx.Rollback(); errRllbck != nil {
+				a.logger.Errorf(errWhileRollbackFormat, errRllbck)
+			}
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db transaction: %w", err)
+	}
+	shouldRollback = false
+	return nil
+}
+
+// observeRead records the duration of a read (or the failure) in the storage metrics
+func observeRead(operation string, start time.Time, err error) {
+	if err != nil {
+		metrics.StorageError(operation)
+		return
+	}
+	metrics.StorageRead(operation, time.Since(start))
+}
+
 // GetCertificateHeadersByStatus returns a list of certificate headers by their status
 func (a *AggSenderSQLStorage) GetCertificateHeadersByStatus(
 	statuses []agglayertypes.CertificateStatus) ([]*types.CertificateHeader, error) {
@@ -130,8 +258,11 @@ func (a *AggSenderSQLStorage) GetCertificateHeadersByStatus(
 	// Add ordering by creation date (oldest first)
 	query += " ORDER BY height ASC"
 
+	start := time.Now()
 	var certificates []*types.CertificateHeader
-	if err := meddler.QueryAll(a.db, &certificates, query, args...); err != nil {
+	err := meddler.QueryAll(a.readDB, &certificates, query, args...)
+	observeRead("GetCertificateHeadersByStatus", start, err)
+	if err != nil {
 		return nil, err
 	}
 
@@ -140,7 +271,7 @@ func (a *AggSenderSQLStorage) GetCertificateHeadersByStatus(
 
 // GetCertificateByHeight returns a certificate by its height
 func (a *AggSenderSQLStorage) GetCertificateByHeight(height uint64) (*types.Certificate, error) {
-	certInfo, err := getCertificateByHeight(a.db, height)
+	certInfo, err := getCertificateByHeight(a.readDB, height)
 	if err != nil {
 		return nil, err
 	}
@@ -155,13 +286,72 @@ func (a *AggSenderSQLStorage) GetCertificateByHeight(height uint64) (*types.Cert
 // GetCertificateHeaderByHeight returns a certificate by its height
 func (a *AggSenderSQLStorage) GetCertificateHeaderByHeight(height uint64) (*types.CertificateHeader, error) {
 	var certificateHeader types.CertificateHeader
-	if err := meddler.QueryRow(a.db, &certificateHeader,
+	if err := meddler.QueryRow(a.readDB, &certificateHeader,
 		fmt.Sprintf("%s WHERE height = $1;", selectQueryCertificateHeader), height); err != nil {
 		return nil, getSelectQueryError(height, err)
 	}
 	return &certificateHeader, nil
 }
 
+// GetCertificateHeadersInHeightRange returns the certificate headers with fromHeight <= height <= toHeight,
+// ordered by height
+func (a *AggSenderSQLStorage) GetCertificateHeadersInHeightRange(
+	fromHeight, toHeight uint64) ([]*types.CertificateHeader, error) {
+	start := time.Now()
+	var certificates []*types.CertificateHeader
+	err := meddler.QueryAll(a.readDB, &certificates,
+		fmt.Sprintf("%s WHERE height >= $1 AND height <= $2 ORDER BY height ASC;", selectQueryCertificateHeader),
+		fromHeight, toHeight)
+	observeRead("GetCertificateHeadersInHeightRange", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+// GetCertificateHeadersByTags returns the last certificate headers (newest first, up to limit) that have
+// all the tags, and one of the statuses if any is given
+func (a *AggSenderSQLStorage) GetCertificateHeadersByTags(tags types.CertificateTags,
+	statuses []agglayertypes.CertificateStatus, limit uint64) ([]*types.CertificateHeader, error) {
+	conditions := make([]string, 0, len(tags)+1)
+	args := make([]any, 0, len(tags)*2+len(statuses)+1) //nolint:mnd
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions,
+			fmt.Sprintf("json_extract(tags, $%d) = $%d", len(args)+1, len(args)+2)) //nolint:mnd
+		args = append(args, fmt.Sprintf("$.%q", key), tags[key])
+	}
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, status := range statuses {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	query := selectQueryCertificateHeader
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY height DESC LIMIT $%d;", len(args))
+
+	start := time.Now()
+	var certificates []*types.CertificateHeader
+	err := meddler.QueryAll(a.readDB, &certificates, query, args...)
+	observeRead("GetCertificateHeadersByTags", start, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
 // getCertificateByHeight returns a certificate by its height using the provided db
 func getCertificateByHeight(db dbtypes.Querier,
 	height uint64) (*certificateInfo, error) {
@@ -177,7 +367,7 @@ func getCertificateByHeight(db dbtypes.Querier,
 // GetLastSentCertificate returns the last certificate sent to the aggLayer
 func (a *AggSenderSQLStorage) GetLastSentCertificate() (*types.Certificate, error) {
 	var certificateInfo certificateInfo
-	if err := meddler.QueryRow(a.db, &certificateInfo,
+	if err := meddler.QueryRow(a.readDB, &certificateInfo,
 		"SELECT * FROM certificate_info ORDER BY height DESC LIMIT 1;"); err != nil {
 		return nil, getSelectQueryError(0, err)
 	}
@@ -188,7 +378,7 @@ func (a *AggSenderSQLStorage) GetLastSentCertificate() (*types.Certificate, erro
 // GetLastSentCertificateHeader returns the last certificate header sent to the aggLayer
 func (a *AggSenderSQLStorage) GetLastSentCertificateHeader() (*types.CertificateHeader, error) {
 	var certificateHeader types.CertificateHeader
-	if err := meddler.QueryRow(a.db, &certificateHeader,
+	if err := meddler.QueryRow(a.readDB, &certificateHeader,
 		fmt.Sprintf("%s ORDER BY height DESC LIMIT 1;", selectQueryCertificateHeader)); err != nil {
 		return nil, getSelectQueryError(0, err)
 	}
@@ -197,19 +387,37 @@ func (a *AggSenderSQLStorage) GetLastSentCertificateHeader() (*types.Certificate
 
 // SaveLastSentCertificate saves the last certificate sent to the aggLayer
 func (a *AggSenderSQLStorage) SaveLastSentCertificate(ctx context.Context, certificate types.Certificate) error {
-	tx, err := db.NewTx(ctx, a.db)
-	if err != nil {
-		return fmt.Errorf("saveLastSentCertificate NewTx. Err: %w", err)
+	return a.executeWriteTx(ctx, "SaveLastSentCertificate", func(tx dbtypes.Txer) error {
+		return a.saveLastSentCertificate(tx, certificate)
+	})
+}
+
+// SaveCertificateBatch saves the certificate (with its proof and status) and updates the state of
+// its journal entry in a single transaction, so there is only one commit (and one fsync) and the
+// readers never see the certificate stored without the journal updated
+func (a *AggSenderSQLStorage) SaveCertificateBatch(ctx context.Context, batch CertificateWriteBatch) error {
+	if batch.Certificate.Header == nil {
+		return errors.New("saveCertificateBatch: missing certificate header")
 	}
-	shouldRollback := true
-	defer func() {
-		if shouldRollback {
-			if errRllbck := tx.Rollback(); errRllbck != nil {
-				a.logger.Errorf(errWhileRollbackFormat, errRllbck)
-			}
+	return a.executeWriteTx(ctx, "SaveCertificateBatch", func(tx dbtypes.Txer) error {
+		if err := a.saveLastSentCertificate(tx, batch.Certificate); err != nil {
+			return err
 		}
-	}()
+		if batch.JournalState == "" {
+			return nil
+		}
+		header := batch.Certificate.Header
+		err := updateCertificateJournalEntryState(tx, header.Height, batch.JournalState, &header.CertificateID)
+		if errors.Is(err, db.ErrNotFound) {
+			// the journal is only used to reconcile on startup, the certificate must be stored anyway
+			a.logger.Warnf("saveCertificateBatch: no journal entry for height %d", header.Height)
+			return nil
+		}
+		return err
+	})
+}
 
+func (a *AggSenderSQLStorage) saveLastSentCertificate(tx dbtypes.Txer, certificate types.Certificate) error {
 	certInfo, err := convertCertificateToCertificateInfo(&certificate)
 	if err != nil {
 		return fmt.Errorf("error converting certificate to certificate info: %w", err)
@@ -232,14 +440,10 @@ func (a *AggSenderSQLStorage) SaveLastSentCertificate(ctx context.Context, certi
 		return fmt.Errorf("error inserting certificate info: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("saveLastSentCertificate commit. Err: %w", err)
-	}
-	shouldRollback = false
-
-	a.logger.Debugf("inserted certificate - Height: %d. Hash: %s",
-		certInfo.Height, certInfo.CertificateID)
-
+	tx.AddCommitCallback(func() {
+		a.logger.Debugf("inserted certificate - Height: %d. Hash: %s",
+			certInfo.Height, certInfo.CertificateID)
+	})
 	return nil
 }
 
@@ -262,27 +466,11 @@ func (a *AggSenderSQLStorage) moveCertificateToHistoryOrDelete(tx dbtypes.Querie
 
 // DeleteCertificate deletes a certificate from the storage
 func (a *AggSenderSQLStorage) DeleteCertificate(ctx context.Context, certificateID common.Hash) error {
-	tx, err := db.NewTx(ctx, a.db)
-	if err != nil {
+	if err := a.executeWriteTx(ctx, "DeleteCertificate", func(tx dbtypes.Txer) error {
+		return deleteCertificate(tx, certificateID)
+	}); err != nil {
 		return err
 	}
-	shouldRollback := true
-	defer func() {
-		if shouldRollback {
-			if errRllbck := tx.Rollback(); errRllbck != nil {
-				a.logger.Errorf(errWhileRollbackFormat, errRllbck)
-			}
-		}
-	}()
-
-	if err = deleteCertificate(tx, certificateID); err != nil {
-		return err
-	}
-
-	if err = tx.Commit(); err != nil {
-		return err
-	}
-	shouldRollback = false
 
 	a.logger.Debugf("deleted certificate - CertificateID: %s", certificateID)
 	return nil
@@ -297,33 +485,43 @@ func deleteCertificate(tx dbtypes.Querier, certificateID common.Hash) error {
 	return nil
 }
 
-// UpdateCertificateStatus updates a certificate status in the storage
+// UpdateCertificateStatus updates a certificate status and its error category in the storage
 func (a *AggSenderSQLStorage) UpdateCertificateStatus(
 	ctx context.Context,
 	certificateID common.Hash,
 	newStatus agglayertypes.CertificateStatus,
+	errorCategory types.CertificateErrorCategory,
 	updatedAt uint32) error {
-	tx, err := db.NewTx(ctx, a.db)
-	if err != nil {
-		return err
-	}
-	shouldRollback := true
-	defer func() {
-		if shouldRollback {
-			if errRllbck := tx.Rollback(); errRllbck != nil {
-				a.logger.Errorf(errWhileRollbackFormat, errRllbck)
-			}
+	if err := a.executeWriteTx(ctx, "UpdateCertificateStatus", func(tx dbtypes.Txer) error {
+		var header certificateStatusHeader
+		err := meddler.QueryRow(tx, &header,
+			`SELECT height, retry_count, status FROM certificate_info WHERE certificate_id = $1;`, certificateID.String())
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("error getting certificate info: %w", err)
 		}
-	}()
-
-	if _, err = tx.Exec(`UPDATE certificate_info SET status = $1, updated_at = $2 WHERE certificate_id = $3;`,
-		newStatus, updatedAt, certificateID.String()); err != nil {
-		return fmt.Errorf("error updating certificate info: %w", err)
-	}
-	if err = tx.Commit(); err != nil {
+		if _, err := tx.Exec(`UPDATE certificate_info SET status = $1, error_category = $2, updated_at = $3
+		WHERE certificate_id = $4;`,
+			newStatus, errorCategory, updatedAt, certificateID.String()); err != nil {
+			return fmt.Errorf("error updating certificate info: %w", err)
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		// the status changes are recorded in the same transaction, so the audit trail never misses one
+		details := map[string]any{
+			"previous_status": header.Status.String(),
+			"status":          newStatus.String(),
+		}
+		if errorCategory != types.CertificateErrorNone {
+			details["error_category"] = errorCategory.String()
+		}
+		event := types.NewCertificateAuditEvent(header.Height, header.RetryCount, &certificateID,
+			types.CertificateAuditStatusChanged, details)
+		event.CreatedAt = updatedAt
+		return insertCertificateAuditEvents(tx, []*types.CertificateAuditEvent{event})
+	}); err != nil {
 		return err
 	}
-	shouldRollback = false
 
 	a.logger.Debugf("updated certificate status - CertificateID: %s", certificateID)
 
@@ -334,7 +532,8 @@ func (a *AggSenderSQLStorage) UpdateCertificateStatus(
 // and the aggchain proof if the certificate is in error
 func (a *AggSenderSQLStorage) GetLastSentCertificateHeaderWithProofIfInError(
 	ctx context.Context) (*types.CertificateHeader, *types.AggchainProof, error) {
-	tx, err := db.NewTx(context.Background(), a.db)
+	// read transaction on the reader pool, so both queries see the same snapshot
+	tx, err := db.NewTx(ctx, a.readDB)
 	if err != nil {
 		return nil, nil, fmt.Errorf("GetLastSentCertificateHeaderWithProofIfInError NewTx. Err: %w", err)
 	}
@@ -346,7 +545,7 @@ func (a *AggSenderSQLStorage) GetLastSentCertificateHeaderWithProofIfInError(
 	}()
 
 	var certificateHeader types.CertificateHeader
-	if err := meddler.QueryRow(a.db, &certificateHeader,
+	if err := meddler.QueryRow(tx, &certificateHeader,
 		fmt.Sprintf("%s ORDER BY height DESC LIMIT 1;", selectQueryCertificateHeader)); err != nil {
 		return nil, nil, getSelectQueryError(0, err)
 	}
@@ -373,37 +572,22 @@ func (a *AggSenderSQLStorage) GetLastSentCertificateHeaderWithProofIfInError(
 // and to allow for debugging and analysis of why they were not accepted.
 func (a *AggSenderSQLStorage) SaveNonAcceptedCertificate(
 	ctx context.Context, nonAcceptedCert *NonAcceptedCertificate) error {
-	tx, err := newTxer(ctx, a.db)
-	if err != nil {
-		return fmt.Errorf("failed to create db transaction for non-accepted certificate persistence: %w", err)
-	}
-
-	shouldRollback := true
-	defer func() {
-		if shouldRollback {
-			if errRllbck := tx.Rollback(); errRllbck != nil {
-				a.logger.Errorf(errWhileRollbackFormat, errRllbck)
-			}
-		}
-	}()
-
 	raw, err := json.Marshal(nonAcceptedCert)
 	if err != nil {
 		return fmt.Errorf("failed to marshal non-accepted certificate struct: %w", err)
 	}
 
-	// if the value already exists, the db will update it, if not, it will insert it
-	// it is all handled in the UpdateValue function
-	if err := a.UpdateValue(tx, aggkitcommon.AGGSENDER, nonAcceptedCertKey, string(raw)); err != nil {
-		return fmt.Errorf("failed to update non-accepted certificate value: %w", err)
-	}
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit db transaction for non-accepted certificate: %w", err)
+	if err := a.executeWriteTx(ctx, "SaveNonAcceptedCertificate", func(tx dbtypes.Txer) error {
+		// if the value already exists, the db will update it, if not, it will insert it
+		// it is all handled in the UpdateValue function
+		if err := a.UpdateValue(tx, aggkitcommon.AGGSENDER, nonAcceptedCertKey, string(raw)); err != nil {
+			return fmt.Errorf("failed to update non-accepted certificate value: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to persist non-accepted certificate: %w", err)
 	}
 
-	shouldRollback = false
-
 	a.logger.Debugf("inserted non-accepted certificate - Height: %d. CreatedAt: %s",
 		nonAcceptedCert.Height, time.Unix(int64(nonAcceptedCert.CreatedAt), 0))
 
@@ -412,7 +596,7 @@ func (a *AggSenderSQLStorage) SaveNonAcceptedCertificate(
 
 // GetNonAcceptedCertificates returns a list of non-accepted certificates
 func (a *AggSenderSQLStorage) GetNonAcceptedCertificate() (*NonAcceptedCertificate, error) {
-	val, err := a.GetValue(a.db, aggkitcommon.AGGSENDER, nonAcceptedCertKey)
+	val, err := a.GetValue(a.readDB, aggkitcommon.AGGSENDER, nonAcceptedCertKey)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
 			return nil, nil // no non-accepted certificate found
@@ -428,6 +612,317 @@ func (a *AggSenderSQLStorage) GetNonAcceptedCertificate() (*NonAcceptedCertifica
 	return &nonAcceptedCert, nil
 }
 
+// SaveCertificateJournalEntry saves the journal entry of a certificate before sending it to agglayer.
+// If there is already an entry for the same height it is replaced, and the resolved entries
+// of previous heights are removed because they are not needed anymore
+func (a *AggSenderSQLStorage) SaveCertificateJournalEntry(ctx context.Context, entry *CertificateJournalEntry) error {
+	if err := a.executeWriteTx(ctx, "SaveCertificateJournalEntry", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec(`DELETE FROM certificate_journal WHERE height = $1 OR (height < $1 AND state != $2);`,
+			entry.Height, CertificateJournalStatePreCommit); err != nil {
+			return fmt.Errorf("error deleting previous certificate journal entries: %w", err)
+		}
+		if err := meddler.Insert(tx, "certificate_journal", entry); err != nil {
+			return fmt.Errorf("error inserting certificate journal entry: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveCertificateJournalEntry. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted certificate journal entry - %s", entry.ID())
+	return nil
+}
+
+// UpdateCertificateJournalEntryState updates the state (and the certificateID if it's known)
+// of the journal entry for the given height
+func (a *AggSenderSQLStorage) UpdateCertificateJournalEntryState(
+	ctx context.Context,
+	height uint64,
+	state CertificateJournalState,
+	certificateID *common.Hash) error {
+	if err := a.executeWriteTx(ctx, "UpdateCertificateJournalEntryState", func(tx dbtypes.Txer) error {
+		return updateCertificateJournalEntryState(tx, height, state, certificateID)
+	}); err != nil {
+		return err
+	}
+
+	a.logger.Debugf("updated certificate journal entry - Height: %d. State: %s", height, state)
+	return nil
+}
+
+// updateCertificateJournalEntryState updates the journal entry using the provided db
+func updateCertificateJournalEntryState(tx dbtypes.Querier, height uint64,
+	state CertificateJournalState, certificateID *common.Hash) error {
+	var certID *string
+	if certificateID != nil {
+		id := certificateID.String()
+		certID = &id
+	}
+	res, err := tx.Exec(`UPDATE certificate_journal SET state = $1, certificate_id = COALESCE($2, certificate_id),
+		updated_at = $3 WHERE height = $4;`,
+		state, certID, uint32(time.Now().UTC().Unix()), height)
+	if err != nil {
+		return fmt.Errorf("error updating certificate journal entry: %w", err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error getting rows affected updating certificate journal entry: %w", err)
+	}
+	if rowsAffected == 0 {
+		return db.ErrNotFound
+	}
+	return nil
+}
+
+// GetPendingCertificateJournalEntries returns the journal entries in pre-commit state ordered by height
+func (a *AggSenderSQLStorage) GetPendingCertificateJournalEntries() ([]*CertificateJournalEntry, error) {
+	var entries []*CertificateJournalEntry
+	if err := meddler.QueryAll(a.readDB, &entries,
+		"SELECT * FROM certificate_journal WHERE state = $1 ORDER BY height ASC;",
+		CertificateJournalStatePreCommit); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// SaveCertificateBuildParams saves the snapshot of the build params of a certificate before sending it.
+// If there is already a snapshot for the same height it is replaced, and the snapshots of previous
+// heights are removed because only the last certificate can be retried
+func (a *AggSenderSQLStorage) SaveCertificateBuildParams(ctx context.Context,
+	snapshot *CertificateBuildParamsSnapshot) error {
+	if err := a.executeWriteTx(ctx, "SaveCertificateBuildParams", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec(`DELETE FROM certificate_build_params WHERE height <= $1;`, snapshot.Height); err != nil {
+			return fmt.Errorf("error deleting previous certificate build params: %w", err)
+		}
+		if err := meddler.Insert(tx, "certificate_build_params", snapshot); err != nil {
+			return fmt.Errorf("error inserting certificate build params: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveCertificateBuildParams. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted certificate build params - %s", snapshot.ID())
+	return nil
+}
+
+// GetCertificateBuildParams returns the snapshot of the build params of the certificate with the
+// given height, or nil if there is none (e.g. it was sent by a previous version)
+func (a *AggSenderSQLStorage) GetCertificateBuildParams(height uint64) (*CertificateBuildParamsSnapshot, error) {
+	var snapshot CertificateBuildParamsSnapshot
+	if err := meddler.QueryRow(a.readDB, &snapshot,
+		"SELECT * FROM certificate_build_params WHERE height = $1;", height); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting certificate build params of height %d: %w", height, err)
+	}
+	return &snapshot, nil
+}
+
+// SaveCertificateFee saves the estimated fee of a certificate submitted to the agglayer.
+// A fee already saved for the same height and retry is replaced
+func (a *AggSenderSQLStorage) SaveCertificateFee(ctx context.Context, fee *CertificateFee) error {
+	if err := a.executeWriteTx(ctx, "SaveCertificateFee", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec(`DELETE FROM certificate_fee WHERE height = $1 AND retry_count = $2;`,
+			fee.Height, fee.RetryCount); err != nil {
+			return fmt.Errorf("error deleting previous certificate fee: %w", err)
+		}
+		if err := meddler.Insert(tx, "certificate_fee", fee); err != nil {
+			return fmt.Errorf("error inserting certificate fee: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveCertificateFee. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted certificate fee - %s", fee.ID())
+	return nil
+}
+
+// GetCertificateFeesSpent returns the sum of the fees of the certificates sent since sinceTime
+// and the sum of the fees of the certificates sent in the given epoch
+func (a *AggSenderSQLStorage) GetCertificateFeesSpent(sinceTime uint32,
+	epoch uint64) (uint64, uint64, error) {
+	var sinceTimeFees, epochFees uint64
+	if err := a.readDB.QueryRow(`SELECT
+			COALESCE(SUM(CASE WHEN sent_at >= $1 THEN fee ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN epoch = $2 THEN fee ELSE 0 END), 0)
+		FROM certificate_fee WHERE sent_at >= $1 OR epoch = $2;`,
+		sinceTime, epoch).Scan(&sinceTimeFees, &epochFees); err != nil {
+		return 0, 0, fmt.Errorf("error getting the certificate fees spent since %d and in epoch %d: %w",
+			sinceTime, epoch, err)
+	}
+	return sinceTimeFees, epochFees, nil
+}
+
+// SaveCertificateAnalytics saves the aggregated figures of a certificate submitted to the agglayer.
+// The analytics already saved for the same height and retry are replaced
+func (a *AggSenderSQLStorage) SaveCertificateAnalytics(ctx context.Context,
+	analytics *types.CertificateAnalytics) error {
+	if err := a.executeWriteTx(ctx, "SaveCertificateAnalytics", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec(`DELETE FROM certificate_analytics WHERE height = $1 AND retry_count = $2;`,
+			analytics.Height, analytics.RetryCount); err != nil {
+			return fmt.Errorf("error deleting previous certificate analytics: %w", err)
+		}
+		if err := meddler.Insert(tx, "certificate_analytics", analytics); err != nil {
+			return fmt.Errorf("error inserting certificate analytics: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveCertificateAnalytics. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted certificate analytics - %s", analytics.String())
+	return nil
+}
+
+// GetCertificateAnalytics returns the analytics of the last submission (highest retry) of the
+// certificate with the given height, or nil if there are none (e.g. it was sent by a previous version)
+func (a *AggSenderSQLStorage) GetCertificateAnalytics(height uint64) (*types.CertificateAnalytics, error) {
+	var analytics types.CertificateAnalytics
+	if err := meddler.QueryRow(a.readDB, &analytics,
+		"SELECT * FROM certificate_analytics WHERE height = $1 ORDER BY retry_count DESC LIMIT 1;",
+		height); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting certificate analytics of height %d: %w", height, err)
+	}
+	return &analytics, nil
+}
+
+// SaveProofStats saves the proving time and the proof size of the aggchain proof of a certificate.
+// The stats already saved for the same height and retry are replaced
+func (a *AggSenderSQLStorage) SaveProofStats(ctx context.Context, stats *types.ProofStats) error {
+	if err := a.executeWriteTx(ctx, "SaveProofStats", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec(`DELETE FROM proof_stats WHERE height = $1 AND retry_count = $2;`,
+			stats.Height, stats.RetryCount); err != nil {
+			return fmt.Errorf("error deleting previous proof stats: %w", err)
+		}
+		if err := meddler.Insert(tx, "proof_stats", stats); err != nil {
+			return fmt.Errorf("error inserting proof stats: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveProofStats. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted proof stats - %s", stats.String())
+	return nil
+}
+
+// GetLastProofStats returns the stats of the last limit proofs generated, the most recent first
+func (a *AggSenderSQLStorage) GetLastProofStats(limit uint32) ([]*types.ProofStats, error) {
+	var stats []*types.ProofStats
+	if err := meddler.QueryAll(a.readDB, &stats,
+		"SELECT * FROM proof_stats ORDER BY created_at DESC, height DESC, retry_count DESC LIMIT $1;",
+		limit); err != nil {
+		return nil, fmt.Errorf("error getting the last %d proof stats: %w", limit, err)
+	}
+	return stats, nil
+}
+
+// SaveMirroredCertificateHeaders saves the certificate headers of the agglayer in a single transaction.
+// The header of a height replaces the previous one (e.g. a new certificate after one InError)
+func (a *AggSenderSQLStorage) SaveMirroredCertificateHeaders(ctx context.Context,
+	headers []*types.MirroredCertificateHeader) error {
+	if len(headers) == 0 {
+		return nil
+	}
+	if err := a.executeWriteTx(ctx, "SaveMirroredCertificateHeaders", func(tx dbtypes.Txer) error {
+		for _, header := range headers {
+			if _, err := tx.Exec(`DELETE FROM agglayer_certificate_header WHERE height = $1;`,
+				header.Height); err != nil {
+				return fmt.Errorf("error deleting previous mirrored header of height %d: %w", header.Height, err)
+			}
+			if err := meddler.Insert(tx, "agglayer_certificate_header", header); err != nil {
+				return fmt.Errorf("error inserting mirrored header %s: %w", header.String(), err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("saveMirroredCertificateHeaders. Err: %w", err)
+	}
+
+	a.logger.Debugf("saved %d mirrored certificate headers", len(headers))
+	return nil
+}
+
+// GetMirroredCertificateHeaders returns the mirrored headers with fromHeight <= height <= toHeight,
+// ordered by height. The heights not mirrored are missing
+func (a *AggSenderSQLStorage) GetMirroredCertificateHeaders(
+	fromHeight, toHeight uint64) ([]*types.MirroredCertificateHeader, error) {
+	var headers []*types.MirroredCertificateHeader
+	if err := meddler.QueryAll(a.readDB, &headers,
+		"SELECT * FROM agglayer_certificate_header WHERE height >= $1 AND height <= $2 ORDER BY height ASC;",
+		fromHeight, toHeight); err != nil {
+		return nil, fmt.Errorf("error getting mirrored headers of heights %d-%d: %w", fromHeight, toHeight, err)
+	}
+	return headers, nil
+}
+
+// GetLastMirroredCertificateHeader returns the mirrored header with the highest height, or nil if there are none
+func (a *AggSenderSQLStorage) GetLastMirroredCertificateHeader() (*types.MirroredCertificateHeader, error) {
+	var header types.MirroredCertificateHeader
+	if err := meddler.QueryRow(a.readDB, &header,
+		"SELECT * FROM agglayer_certificate_header ORDER BY height DESC LIMIT 1;"); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting the last mirrored header: %w", err)
+	}
+	return &header, nil
+}
+
+// AcquireInstanceLease acquires the instance lease for ownerID, or renews it if ownerID already holds it.
+// The lease is checked and written in the same (write) transaction, so two instances can't acquire it
+func (a *AggSenderSQLStorage) AcquireInstanceLease(ctx context.Context, ownerID string,
+	now time.Time, ttl time.Duration) (*InstanceLease, error) {
+	nowUnix := uint32(now.UTC().Unix())
+	lease := &InstanceLease{
+		OwnerID:    ownerID,
+		AcquiredAt: nowUnix,
+		RenewedAt:  nowUnix,
+		ExpiresAt:  uint32(now.Add(ttl).UTC().Unix()),
+	}
+	if err := a.executeWriteTx(ctx, "AcquireInstanceLease", func(tx dbtypes.Txer) error {
+		var current InstanceLease
+		err := meddler.QueryRow(tx, &current,
+			"SELECT owner_id, acquired_at, renewed_at, expires_at FROM instance_lease WHERE id = 1;")
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+		case err != nil:
+			return fmt.Errorf("error getting the instance lease: %w", err)
+		case current.OwnerID == ownerID:
+			lease.AcquiredAt = current.AcquiredAt
+		case current.ExpiresAt > nowUnix:
+			return fmt.Errorf("%w: %s", ErrInstanceLeaseHeld, current.String())
+		}
+		if _, err := tx.Exec(`INSERT INTO instance_lease (id, owner_id, acquired_at, renewed_at, expires_at)
+			VALUES (1, $1, $2, $3, $4) ON CONFLICT(id) DO UPDATE SET owner_id = excluded.owner_id,
+			acquired_at = excluded.acquired_at, renewed_at = excluded.renewed_at, expires_at = excluded.expires_at;`,
+			lease.OwnerID, lease.AcquiredAt, lease.RenewedAt, lease.ExpiresAt); err != nil {
+			return fmt.Errorf("error saving the instance lease: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// ReleaseInstanceLease releases the instance lease if it's held by ownerID, so another instance
+// can start without waiting for the lease to expire
+func (a *AggSenderSQLStorage) ReleaseInstanceLease(ctx context.Context, ownerID string) error {
+	return a.executeWriteTx(ctx, "ReleaseInstanceLease", func(tx dbtypes.Txer) error {
+		if _, err := tx.Exec("DELETE FROM instance_lease WHERE id = 1 AND owner_id = $1;", ownerID); err != nil {
+			return fmt.Errorf("error releasing the instance lease: %w", err)
+		}
+		return nil
+	})
+}
+
 func getSelectQueryError(height uint64, err error) error {
 	errToReturn := err
 	if errors.Is(err, sql.ErrNoRows) {
@@ -442,3 +937,47 @@ func getSelectQueryError(height uint64, err error) error {
 
 	return errToReturn
 }
+
+// certificateStatusHeader are the fields of a certificate needed to record a change of its status
+type certificateStatusHeader struct {
+	Height     uint64                          `meddler:"height"`
+	RetryCount int                             `meddler:"retry_count"`
+	Status     agglayertypes.CertificateStatus `meddler:"status"`
+}
+
+// SaveCertificateAuditEvents appends the events to the audit trail of the certificates in a single transaction.
+// The trail is append-only, the events are never modified or deleted
+func (a *AggSenderSQLStorage) SaveCertificateAuditEvents(ctx context.Context,
+	events []*types.CertificateAuditEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := a.executeWriteTx(ctx, "SaveCertificateAuditEvents", func(tx dbtypes.Txer) error {
+		return insertCertificateAuditEvents(tx, events)
+	}); err != nil {
+		return fmt.Errorf("saveCertificateAuditEvents. Err: %w", err)
+	}
+
+	a.logger.Debugf("inserted %d certificate audit events", len(events))
+	return nil
+}
+
+func insertCertificateAuditEvents(tx dbtypes.Querier, events []*types.CertificateAuditEvent) error {
+	for _, event := range events {
+		if err := meddler.Insert(tx, "certificate_audit_event", event); err != nil {
+			return fmt.Errorf("error inserting certificate audit event %s: %w", event.String(), err)
+		}
+	}
+	return nil
+}
+
+// GetCertificateAuditEvents returns the audit trail of the certificate with the given height (of all its
+// retries), in the order the events were recorded
+func (a *AggSenderSQLStorage) GetCertificateAuditEvents(height uint64) ([]*types.CertificateAuditEvent, error) {
+	var events []*types.CertificateAuditEvent
+	if err := meddler.QueryAll(a.readDB, &events,
+		"SELECT * FROM certificate_audit_event WHERE height = $1 ORDER BY id;", height); err != nil {
+		return nil, fmt.Errorf("error getting the audit events of the certificate of height %d: %w", height, err)
+	}
+	return events, nil
+}
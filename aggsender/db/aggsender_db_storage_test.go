@@ -12,6 +12,7 @@ import (
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/db"
 	dbmocks "github.com/agglayer/aggkit/db/mocks"
@@ -335,14 +336,17 @@ func Test_Storage(t *testing.T) {
 		require.NoError(t, storage.SaveLastSentCertificate(ctx, certificate))
 
 		// Update the status of the certificate
-		certificate.Header.Status = agglayertypes.Settled
+		certificate.Header.Status = agglayertypes.InError
+		certificate.Header.ErrorCategory = types.CertificateErrorProofVerification
 		certificate.Header.UpdatedAt = updateTime + 1
-		require.NoError(t, storage.UpdateCertificateStatus(ctx, certificate.Header.CertificateID, certificate.Header.Status, certificate.Header.UpdatedAt))
+		require.NoError(t, storage.UpdateCertificateStatus(ctx, certificate.Header.CertificateID, certificate.Header.Status,
+			certificate.Header.ErrorCategory, certificate.Header.UpdatedAt))
 
 		// Fetch the certificate and verify the status has been updated
 		certificateFromDB, err := storage.GetCertificateByHeight(certificate.Header.Height)
 		require.NoError(t, err)
 		require.Equal(t, certificate.Header.Status, certificateFromDB.Header.Status, "equal status")
+		require.Equal(t, certificate.Header.ErrorCategory, certificateFromDB.Header.ErrorCategory, "equal error category")
 		require.Equal(t, certificate.Header.UpdatedAt, certificateFromDB.Header.UpdatedAt, "equal updated at")
 
 		require.NoError(t, storage.clean())
@@ -992,3 +996,628 @@ func Test_GetNonAcceptedCert(t *testing.T) {
 	}
 	require.Equal(t, *certificate, certificateFromDB, "retrieved certificate should match the saved certificate")
 }
+
+func Test_CertificateJournal(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_CertificateJournal.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	entries, err := storage.GetPendingCertificateJournalEntries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	newCert := func(height uint64, retryCount int) types.Certificate {
+		signedCert := "{}"
+		return types.Certificate{
+			Header: &types.CertificateHeader{
+				Height:           height,
+				RetryCount:       retryCount,
+				NewLocalExitRoot: common.HexToHash("0x2"),
+				FromBlock:        10,
+				ToBlock:          20,
+				CertType:         types.CertificateTypeFEP,
+				CertSource:       types.CertificateSourceLocal,
+			},
+			SignedCertificate: &signedCert,
+			AggchainProof:     &types.AggchainProof{LastProvenBlock: 9, EndBlock: 20},
+		}
+	}
+
+	cert1 := newCert(1, 0)
+	entry1, err := NewCertificateJournalEntry(cert1, common.HexToHash("0x3"), 100)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateJournalEntry(ctx, entry1))
+
+	entries, err = storage.GetPendingCertificateJournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entry1, entries[0])
+	certFromJournal, err := entries[0].ToCertificate()
+	require.NoError(t, err)
+	require.Equal(t, cert1, *certFromJournal)
+
+	// a retry at the same height replaces the entry
+	entry1Retry, err := NewCertificateJournalEntry(newCert(1, 1), common.HexToHash("0x4"), 101)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateJournalEntry(ctx, entry1Retry))
+	entries, err = storage.GetPendingCertificateJournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, 1, entries[0].RetryCount)
+
+	certID := common.HexToHash("0x5")
+	require.NoError(t, storage.UpdateCertificateJournalEntryState(ctx, 1,
+		CertificateJournalStateAcknowledged, &certID))
+	entries, err = storage.GetPendingCertificateJournalEntries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.ErrorIs(t, storage.UpdateCertificateJournalEntryState(ctx, 2,
+		CertificateJournalStateDiscarded, nil), db.ErrNotFound)
+
+	// a new height removes the resolved entries of previous heights
+	entry2, err := NewCertificateJournalEntry(newCert(2, 0), common.HexToHash("0x6"), 102)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateJournalEntry(ctx, entry2))
+	var count int
+	require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM certificate_journal;").Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func Test_CertificateJournalEntryToCertificateInvalid(t *testing.T) {
+	entry := &CertificateJournalEntry{Height: 1, Certificate: "not a json"}
+	_, err := entry.ToCertificate()
+	require.ErrorContains(t, err, "failed to unmarshal certificate")
+
+	entry.Certificate = "{}"
+	_, err = entry.ToCertificate()
+	require.ErrorContains(t, err, "has no header")
+
+	_, err = NewCertificateJournalEntry(types.Certificate{}, common.Hash{}, 0)
+	require.ErrorContains(t, err, "certificate header is nil")
+}
+
+func Test_SaveCertificateBatch(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_SaveCertificateBatch.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	signedCert := "{}"
+	cert := types.Certificate{
+		Header: &types.CertificateHeader{
+			Height:           1,
+			CertificateID:    common.HexToHash("0x1"),
+			NewLocalExitRoot: common.HexToHash("0x2"),
+			Status:           agglayertypes.Pending,
+			CertType:         types.CertificateTypeFEP,
+			CertSource:       types.CertificateSourceLocal,
+		},
+		SignedCertificate: &signedCert,
+		AggchainProof:     &types.AggchainProof{LastProvenBlock: 9, EndBlock: 20},
+	}
+
+	t.Run("missing header", func(t *testing.T) {
+		err := storage.SaveCertificateBatch(ctx, CertificateWriteBatch{})
+		require.ErrorContains(t, err, "missing certificate header")
+	})
+
+	t.Run("without journal entry", func(t *testing.T) {
+		require.NoError(t, storage.SaveCertificateBatch(ctx, CertificateWriteBatch{
+			Certificate:  cert,
+			JournalState: CertificateJournalStateAcknowledged,
+		}))
+		certFromDB, err := storage.GetCertificateByHeight(1)
+		require.NoError(t, err)
+		require.Equal(t, cert.Header.CertificateID, certFromDB.Header.CertificateID)
+		require.Equal(t, cert.AggchainProof, certFromDB.AggchainProof)
+	})
+
+	t.Run("certificate and journal entry", func(t *testing.T) {
+		cert := cert
+		header := *cert.Header
+		header.Height = 2
+		header.CertificateID = common.HexToHash("0x3")
+		cert.Header = &header
+		entry, err := NewCertificateJournalEntry(cert, common.HexToHash("0x4"), 100)
+		require.NoError(t, err)
+		require.NoError(t, storage.SaveCertificateJournalEntry(ctx, entry))
+
+		require.NoError(t, storage.SaveCertificateBatch(ctx, CertificateWriteBatch{
+			Certificate:  cert,
+			JournalState: CertificateJournalStateAcknowledged,
+		}))
+
+		entries, err := storage.GetPendingCertificateJournalEntries()
+		require.NoError(t, err)
+		require.Empty(t, entries)
+		var certID string
+		require.NoError(t, storage.readDB.QueryRow(
+			"SELECT certificate_id FROM certificate_journal WHERE height = 2;").Scan(&certID))
+		require.Equal(t, header.CertificateID.String(), certID)
+		lastCert, err := storage.GetLastSentCertificateHeader()
+		require.NoError(t, err)
+		require.Equal(t, header.CertificateID, lastCert.CertificateID)
+	})
+}
+
+func Test_StorageReadsDuringWriteTx(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_StorageReadsDuringWriteTx.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{
+		DBPath: dbPath,
+		Tuning: db.SQLiteConfig{MaxReadConns: 2},
+	})
+	require.NoError(t, err)
+
+	signedCert := "{}"
+	require.NoError(t, storage.SaveLastSentCertificate(ctx, types.Certificate{
+		Header: &types.CertificateHeader{
+			Height:        1,
+			CertificateID: common.HexToHash("0x1"),
+			Status:        agglayertypes.Pending,
+		},
+		SignedCertificate: &signedCert,
+	}))
+
+	// the write transaction is kept open (e.g. persisting a big proof) while reading
+	writeTxStarted := make(chan struct{})
+	releaseWriteTx := make(chan struct{})
+	writeDone := make(chan error)
+	go func() {
+		writeDone <- storage.executeWriteTx(ctx, "test", func(tx dbtypes.Txer) error {
+			if _, err := tx.Exec(`UPDATE certificate_info SET status = $1 WHERE height = 1;`,
+				agglayertypes.Settled); err != nil {
+				return err
+			}
+			close(writeTxStarted)
+			<-releaseWriteTx
+			return nil
+		})
+	}()
+	<-writeTxStarted
+
+	// the readers are not blocked and see the last committed data
+	header, err := storage.GetLastSentCertificateHeader()
+	require.NoError(t, err)
+	require.Equal(t, agglayertypes.Pending, header.Status)
+	headers, err := storage.GetCertificateHeadersByStatus([]agglayertypes.CertificateStatus{agglayertypes.Pending})
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+
+	close(releaseWriteTx)
+	require.NoError(t, <-writeDone)
+	header, err = storage.GetLastSentCertificateHeader()
+	require.NoError(t, err)
+	require.Equal(t, agglayertypes.Settled, header.Status)
+}
+
+func Test_NewAggSenderSQLStorageInvalidTuning(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "Test_NewAggSenderSQLStorageInvalidTuning.sqlite")
+	_, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{
+		DBPath: dbPath,
+		Tuning: db.SQLiteConfig{Synchronous: "SOMETIMES"},
+	})
+	require.ErrorContains(t, err, "invalid storage tuning config")
+}
+
+func Test_InstanceLease(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_InstanceLease.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+	// a second instance using the same DB
+	otherStorage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	ttl := 30 * time.Second
+
+	lease, err := storage.AcquireInstanceLease(ctx, "instance-a", now, ttl)
+	require.NoError(t, err)
+	require.Equal(t, &InstanceLease{OwnerID: "instance-a", AcquiredAt: 1000, RenewedAt: 1000, ExpiresAt: 1030}, lease)
+
+	// the lease is held by instance-a
+	_, err = otherStorage.AcquireInstanceLease(ctx, "instance-b", now.Add(10*time.Second), ttl)
+	require.ErrorIs(t, err, ErrInstanceLeaseHeld)
+	require.ErrorContains(t, err, "instance-a")
+
+	// instance-a renews it
+	lease, err = storage.AcquireInstanceLease(ctx, "instance-a", now.Add(20*time.Second), ttl)
+	require.NoError(t, err)
+	require.Equal(t, &InstanceLease{OwnerID: "instance-a", AcquiredAt: 1000, RenewedAt: 1020, ExpiresAt: 1050}, lease)
+
+	_, err = otherStorage.AcquireInstanceLease(ctx, "instance-b", now.Add(40*time.Second), ttl)
+	require.ErrorIs(t, err, ErrInstanceLeaseHeld)
+
+	// once expired, instance-b takes it
+	lease, err = otherStorage.AcquireInstanceLease(ctx, "instance-b", now.Add(50*time.Second), ttl)
+	require.NoError(t, err)
+	require.Equal(t, "instance-b", lease.OwnerID)
+	require.Equal(t, uint32(1050), lease.AcquiredAt)
+
+	// instance-a can't renew it anymore
+	_, err = storage.AcquireInstanceLease(ctx, "instance-a", now.Add(55*time.Second), ttl)
+	require.ErrorIs(t, err, ErrInstanceLeaseHeld)
+
+	// releasing a lease held by another instance does nothing
+	require.NoError(t, storage.ReleaseInstanceLease(ctx, "instance-a"))
+	_, err = storage.AcquireInstanceLease(ctx, "instance-a", now.Add(55*time.Second), ttl)
+	require.ErrorIs(t, err, ErrInstanceLeaseHeld)
+
+	require.NoError(t, otherStorage.ReleaseInstanceLease(ctx, "instance-b"))
+	_, err = storage.AcquireInstanceLease(ctx, "instance-a", now.Add(55*time.Second), ttl)
+	require.NoError(t, err)
+}
+
+func Test_CertificateBuildParams(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_CertificateBuildParams.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	snapshot, err := storage.GetCertificateBuildParams(1)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+
+	params := &types.CertificateBuildParams{
+		FromBlock: 10,
+		ToBlock:   20,
+		Bridges: []bridgesync.Bridge{{
+			BlockNum:     11,
+			DepositCount: 3,
+			Amount:       big.NewInt(100),
+		}},
+		Claims: []bridgesync.Claim{{
+			BlockNum:    12,
+			GlobalIndex: big.NewInt(5),
+			Amount:      big.NewInt(50),
+		}},
+		L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+		L1InfoTreeLeafCount:            4,
+		CertificateType:                types.CertificateTypeFEP,
+		CreatedAt:                      1000,
+		// not stored in the snapshot
+		RetryCount: 2,
+	}
+	snapshot, err = NewCertificateBuildParamsSnapshot(1, 2, params)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateBuildParams(ctx, snapshot))
+
+	stored, err := storage.GetCertificateBuildParams(1)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, stored)
+	require.True(t, stored.Matches(&types.CertificateHeader{
+		Height: 1, FromBlock: 10, ToBlock: 20, CertType: types.CertificateTypeFEP}))
+	require.False(t, stored.Matches(&types.CertificateHeader{
+		Height: 1, FromBlock: 10, ToBlock: 21, CertType: types.CertificateTypeFEP}))
+
+	decoded, err := stored.ToBuildParams()
+	require.NoError(t, err)
+	expected := *params
+	expected.RetryCount = 0
+	require.Equal(t, &expected, decoded)
+
+	// the snapshot of a retry replaces the previous one
+	params.Bridges = nil
+	snapshot, err = NewCertificateBuildParamsSnapshot(1, 3, params)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateBuildParams(ctx, snapshot))
+	stored, err = storage.GetCertificateBuildParams(1)
+	require.NoError(t, err)
+	require.Equal(t, 3, stored.RetryCount)
+
+	// the snapshots of previous heights are removed
+	snapshot, err = NewCertificateBuildParamsSnapshot(2, 0, params)
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveCertificateBuildParams(ctx, snapshot))
+	stored, err = storage.GetCertificateBuildParams(1)
+	require.NoError(t, err)
+	require.Nil(t, stored)
+	stored, err = storage.GetCertificateBuildParams(2)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+}
+
+func Test_CertificateFees(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_CertificateFees.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	dayFees, epochFees, err := storage.GetCertificateFeesSpent(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), dayFees)
+	require.Equal(t, uint64(0), epochFees)
+
+	fees := []*CertificateFee{
+		{Height: 1, Epoch: 1, Fee: 100, FeeSource: types.CertificateFeeSourceConfig, SentAt: 1000},
+		{Height: 2, Epoch: 2, Fee: 200, FeeSource: types.CertificateFeeSourceConfig, SentAt: 2000},
+		{Height: 2, RetryCount: 1, Epoch: 3, Fee: 300, FeeSource: types.CertificateFeeSourceConfig, SentAt: 3000},
+	}
+	for _, fee := range fees {
+		require.NoError(t, storage.SaveCertificateFee(ctx, fee))
+	}
+	// the fee of the same height and retry is replaced
+	fees[2].Fee = 400
+	require.NoError(t, storage.SaveCertificateFee(ctx, fees[2]))
+
+	dayFees, epochFees, err = storage.GetCertificateFeesSpent(2000, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(600), dayFees)
+	require.Equal(t, uint64(100), epochFees)
+
+	dayFees, epochFees, err = storage.GetCertificateFeesSpent(4000, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), dayFees)
+	require.Equal(t, uint64(400), epochFees)
+}
+
+func Test_CertificateAnalytics(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_CertificateAnalytics.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	analytics, err := storage.GetCertificateAnalytics(1)
+	require.NoError(t, err)
+	require.Nil(t, analytics)
+
+	token := types.NewTokenKey(0, common.HexToAddress("0x1"))
+	first := &types.CertificateAnalytics{
+		Height:                 1,
+		CertificateID:          common.HexToHash("0x1"),
+		ClaimsPerOriginNetwork: map[uint32]int{0: 2, 5: 1},
+		BridgedValuePerToken:   map[types.TokenKey]*big.Int{token: big.NewInt(100)},
+		AssetExits:             2,
+		MessageExits:           1,
+		CreatedAt:              1000,
+	}
+	retry := *first
+	retry.RetryCount = 1
+	retry.CertificateID = common.HexToHash("0x2")
+	retry.BridgedValuePerToken = map[types.TokenKey]*big.Int{token: big.NewInt(300)}
+	require.NoError(t, storage.SaveCertificateAnalytics(ctx, first))
+	require.NoError(t, storage.SaveCertificateAnalytics(ctx, &retry))
+	// the analytics of the same height and retry are replaced
+	require.NoError(t, storage.SaveCertificateAnalytics(ctx, &retry))
+
+	analytics, err = storage.GetCertificateAnalytics(1)
+	require.NoError(t, err)
+	require.Equal(t, &retry, analytics)
+}
+
+func Test_ProofStats(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_ProofStats.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	stats, err := storage.GetLastProofStats(10)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(t, storage.SaveProofStats(ctx, &types.ProofStats{
+			Height:        height,
+			FromBlock:     height * 10,
+			ToBlock:       height*10 + 9,
+			ProvingTimeMs: height * 1000,
+			ProofSize:     height * 100,
+			CreatedAt:     uint32(height * 100),
+		}))
+	}
+	// the stats of the same height and retry are replaced
+	replaced := &types.ProofStats{Height: 3, FromBlock: 30, ToBlock: 39, ProvingTimeMs: 5000, ProofSize: 500,
+		CreatedAt: 400}
+	require.NoError(t, storage.SaveProofStats(ctx, replaced))
+
+	stats, err = storage.GetLastProofStats(2)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, replaced, stats[0])
+	require.Equal(t, uint64(2), stats[1].Height)
+	require.Equal(t, 2*time.Second, stats[1].ProvingTime())
+}
+
+func Test_CertificateAuditEvents(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_CertificateAuditEvents.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	events, err := storage.GetCertificateAuditEvents(1)
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.NoError(t, storage.SaveCertificateAuditEvents(ctx, nil))
+
+	certificateID := common.HexToHash("0x1")
+	require.NoError(t, storage.SaveCertificateAuditEvents(ctx, []*types.CertificateAuditEvent{
+		types.NewCertificateAuditEvent(1, 0, nil, types.CertificateAuditRangeSelected,
+			map[string]any{"from_block": 10, "to_block": 20, "reason": types.CertificateAuditReasonNewBlocks}),
+		types.NewCertificateAuditEvent(1, 0, &certificateID, types.CertificateAuditSubmissionAttempt, nil),
+		types.NewCertificateAuditEvent(2, 0, nil, types.CertificateAuditRangeSelected, nil),
+	}))
+	require.NoError(t, storage.SaveLastSentCertificate(ctx, types.Certificate{
+		Header: &types.CertificateHeader{
+			Height:        1,
+			CertificateID: certificateID,
+			Status:        agglayertypes.Pending,
+		},
+	}))
+	// the status changes are recorded in the audit trail
+	require.NoError(t, storage.UpdateCertificateStatus(ctx, certificateID, agglayertypes.InError,
+		types.CertificateErrorAgglayerInternal, 300))
+
+	events, err = storage.GetCertificateAuditEvents(1)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, types.CertificateAuditRangeSelected, events[0].Event)
+	require.Nil(t, events[0].CertificateID)
+	require.Equal(t, map[string]any{"from_block": float64(10), "to_block": float64(20), "reason": "new_blocks"},
+		events[0].Details)
+	require.Equal(t, types.CertificateAuditSubmissionAttempt, events[1].Event)
+	require.Equal(t, &certificateID, events[1].CertificateID)
+	require.Equal(t, &types.CertificateAuditEvent{
+		ID:            4,
+		Height:        1,
+		CertificateID: &certificateID,
+		Event:         types.CertificateAuditStatusChanged,
+		Details: map[string]any{
+			"previous_status": agglayertypes.Pending.String(),
+			"status":          agglayertypes.InError.String(),
+			"error_category":  types.CertificateErrorAgglayerInternal.String(),
+		},
+		CreatedAt: 300,
+	}, events[2])
+}
+
+func Test_GetCertificateHeadersInHeightRange(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_GetCertificateHeadersInHeightRange.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	headers, err := storage.GetCertificateHeadersInHeightRange(0, 10)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	for height := uint64(0); height < 5; height++ {
+		require.NoError(t, storage.SaveLastSentCertificate(ctx, types.Certificate{
+			Header: &types.CertificateHeader{
+				Height:        height,
+				CertificateID: common.BigToHash(new(big.Int).SetUint64(height + 1)),
+				FromBlock:     height * 10,
+				ToBlock:       height*10 + 9,
+				Status:        agglayertypes.Settled,
+			},
+		}))
+	}
+
+	headers, err = storage.GetCertificateHeadersInHeightRange(1, 3)
+	require.NoError(t, err)
+	require.Len(t, headers, 3)
+	for i, header := range headers {
+		require.Equal(t, uint64(i+1), header.Height)
+	}
+
+	headers, err = storage.GetCertificateHeadersInHeightRange(4, 10)
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	require.Equal(t, uint64(4), headers[0].Height)
+}
+
+func Test_GetCertificateHeadersByTags(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_GetCertificateHeadersByTags.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	prodEU := types.CertificateTags{types.CertificateTagEnvironment: "prod", types.CertificateTagRegion: "eu"}
+	prodUS := types.CertificateTags{types.CertificateTagEnvironment: "prod", types.CertificateTagRegion: "us"}
+	certs := []struct {
+		tags   types.CertificateTags
+		status agglayertypes.CertificateStatus
+	}{
+		{tags: nil, status: agglayertypes.Settled},
+		{tags: prodEU, status: agglayertypes.Settled},
+		{tags: prodUS, status: agglayertypes.InError},
+		{tags: prodEU, status: agglayertypes.InError},
+		{tags: prodEU, status: agglayertypes.Pending},
+	}
+	for height, cert := range certs {
+		require.NoError(t, storage.SaveLastSentCertificate(ctx, types.Certificate{
+			Header: &types.CertificateHeader{
+				Height:        uint64(height),
+				CertificateID: common.BigToHash(big.NewInt(int64(height + 1))),
+				Status:        cert.status,
+				Tags:          cert.tags,
+			},
+		}))
+	}
+
+	heights := func(headers []*types.CertificateHeader) []uint64 {
+		result := make([]uint64, 0, len(headers))
+		for _, header := range headers {
+			result = append(result, header.Height)
+		}
+		return result
+	}
+
+	headers, err := storage.GetCertificateHeadersByTags(prodEU, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 3, 1}, heights(headers))
+	require.Equal(t, prodEU, headers[0].Tags)
+
+	headers, err = storage.GetCertificateHeadersByTags(
+		types.CertificateTags{types.CertificateTagEnvironment: "prod"}, []agglayertypes.CertificateStatus{agglayertypes.InError}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 2}, heights(headers))
+
+	headers, err = storage.GetCertificateHeadersByTags(prodEU, nil, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4}, heights(headers))
+
+	headers, err = storage.GetCertificateHeadersByTags(types.CertificateTags{types.CertificateTagEnvironment: "staging"}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+}
+
+func Test_MirroredCertificateHeaders(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "Test_MirroredCertificateHeaders.sqlite")
+	storage, err := NewAggSenderSQLStorage(log.WithFields("aggsender-db"), AggSenderSQLStorageConfig{DBPath: dbPath})
+	require.NoError(t, err)
+
+	last, err := storage.GetLastMirroredCertificateHeader()
+	require.NoError(t, err)
+	require.Nil(t, last)
+	require.NoError(t, storage.SaveMirroredCertificateHeaders(ctx, nil))
+
+	epoch := uint64(3)
+	previousLER := common.HexToHash("0xa")
+	settlementTx := common.HexToHash("0xb")
+	settled := &types.MirroredCertificateHeader{
+		Height:                0,
+		CertificateID:         common.HexToHash("0x1"),
+		EpochNumber:           &epoch,
+		CertificateIndex:      &epoch,
+		PreviousLocalExitRoot: &previousLER,
+		NewLocalExitRoot:      common.HexToHash("0xc"),
+		Status:                agglayertypes.Settled,
+		Metadata:              common.HexToHash("0xd"),
+		SettlementTxHash:      &settlementTx,
+		MirroredAt:            1000,
+	}
+	inError := &types.MirroredCertificateHeader{
+		Height:           2,
+		CertificateID:    common.HexToHash("0x2"),
+		NewLocalExitRoot: common.HexToHash("0xe"),
+		Status:           agglayertypes.InError,
+		Error:            "invalid signature",
+		MirroredAt:       1000,
+	}
+	require.NoError(t, storage.SaveMirroredCertificateHeaders(ctx, []*types.MirroredCertificateHeader{settled, inError}))
+
+	// a new certificate for the same height replaces the one in error
+	replacement := &types.MirroredCertificateHeader{
+		Height:        2,
+		CertificateID: common.HexToHash("0x3"),
+		Status:        agglayertypes.Pending,
+		MirroredAt:    2000,
+	}
+	require.NoError(t, storage.SaveMirroredCertificateHeaders(ctx, []*types.MirroredCertificateHeader{replacement}))
+
+	headers, err := storage.GetMirroredCertificateHeaders(0, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*types.MirroredCertificateHeader{settled, replacement}, headers)
+
+	headers, err = storage.GetMirroredCertificateHeaders(1, 1)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	last, err = storage.GetLastMirroredCertificateHeader()
+	require.NoError(t, err)
+	require.Equal(t, replacement, last)
+}
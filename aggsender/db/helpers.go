@@ -82,5 +82,8 @@ func convertCertificateToCertificateInfo(c *types.Certificate) (*certificateInfo
 		SignedCertificate:       c.SignedCertificate,
 		AggchainProof:           c.AggchainProof,
 		ExtraData:               c.ExtraData,
+		ErrorCategory:           c.Header.ErrorCategory,
+		Tags:                    c.Header.Tags,
+		BuildEpoch:              c.Header.BuildEpoch,
 	}, nil
 }
@@ -12,6 +12,7 @@ func init() {
 	// this is done like this to make sure that init() function in db package is called
 	// before this init() function
 	db.RegisterMeddler("aggchainproof", &AggchainProofMeddler{})
+	db.RegisterMeddler("certificatetags", &CertificateTagsMeddler{})
 }
 
 // AggchainProofMeddler is a meddler.Meddler implementation for the AggchainProof type.
@@ -54,3 +55,40 @@ func (m *AggchainProofMeddler) PreWrite(field interface{}) (saveValue interface{
 
 	return json.Marshal(proof)
 }
+
+// CertificateTagsMeddler is a meddler.Meddler implementation for the CertificateTags type, the
+// certificates without tags are stored as NULL
+type CertificateTagsMeddler struct{}
+
+// PreRead prepares the field for reading from the database.
+func (m *CertificateTagsMeddler) PreRead(fieldAddr interface{}) (scanTarget interface{}, err error) {
+	return &[]byte{}, nil
+}
+
+// PostRead decodes the data from the database into the field.
+func (m *CertificateTagsMeddler) PostRead(fieldAddr interface{}, scanTarget interface{}) error {
+	tagsPtr, ok := fieldAddr.(*types.CertificateTags)
+	if !ok {
+		return errors.New("invalid type for CertificateTags")
+	}
+
+	data, ok := scanTarget.(*[]byte)
+	if !ok || data == nil || len(*data) == 0 {
+		return nil // No data to decode
+	}
+
+	return json.Unmarshal(*data, tagsPtr)
+}
+
+// PreWrite prepares the field for writing to the database.
+func (m *CertificateTagsMeddler) PreWrite(field interface{}) (saveValue interface{}, err error) {
+	tags, ok := field.(types.CertificateTags)
+	if !ok {
+		return nil, errors.New("invalid type for CertificateTags")
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(tags)
+}
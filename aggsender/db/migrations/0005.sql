@@ -0,0 +1,18 @@
+-- +migrate Down
+DROP TABLE IF EXISTS certificate_journal;
+
+-- +migrate Up
+-- certificate_journal is a write-ahead journal of the certificates submitted to agglayer.
+-- An entry is written in state 'pre_commit' before sending the certificate and is moved
+-- to 'acknowledged' once agglayer has accepted it (or to 'discarded' if it was not)
+CREATE TABLE certificate_journal (
+    height              INTEGER NOT NULL PRIMARY KEY,
+    retry_count         INTEGER NOT NULL DEFAULT 0,
+    state               VARCHAR NOT NULL,
+    certificate_id      VARCHAR,
+    new_local_exit_root VARCHAR NOT NULL,
+    metadata            VARCHAR NOT NULL,
+    certificate         TEXT NOT NULL,
+    created_at          INTEGER NOT NULL,
+    updated_at          INTEGER NOT NULL
+);
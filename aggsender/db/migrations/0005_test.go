@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester005 struct{}
+
+func (m *migrationTester005) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester005) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester005) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO certificate_journal (
+			height,
+			retry_count,
+			state,
+			new_local_exit_root,
+			metadata,
+			certificate,
+			created_at,
+			updated_at
+		) VALUES (1, 0, 'pre_commit', '0x123456', '0x654321', '{}', 0, 0);
+	`)
+	require.NoError(t, err)
+
+	var state string
+	require.NoError(t, db.QueryRow("SELECT state FROM certificate_journal WHERE height = $1;", 1).Scan(&state))
+	require.Equal(t, "pre_commit", state)
+}
+
+func (m *migrationTester005) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT * FROM certificate_journal;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration005(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 5, &migrationTester005{})
+}
@@ -0,0 +1,7 @@
+-- +migrate Down
+ALTER TABLE certificate_info DROP COLUMN error_category;
+ALTER TABLE certificate_info_history DROP COLUMN error_category;
+
+-- +migrate Up
+ALTER TABLE certificate_info ADD COLUMN error_category VARCHAR DEFAULT "";
+ALTER TABLE certificate_info_history ADD COLUMN error_category VARCHAR DEFAULT "";
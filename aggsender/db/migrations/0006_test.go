@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester006 struct{}
+
+func (m *migrationTester006) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester006) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO certificate_info (
+			height,
+			retry_count,
+			certificate_id,
+			status,
+			new_local_exit_root,
+			from_block,
+			to_block,
+			created_at,
+			updated_at
+		) VALUES (10, 0, '0x789abc', 4, '0x23456', 1000, 2000, 0, 0);
+	`)
+	require.NoError(t, err)
+}
+
+func (m *migrationTester006) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	var errorCategory string
+	require.NoError(t, db.QueryRow("SELECT error_category FROM certificate_info WHERE height = $1;", 10).
+		Scan(&errorCategory))
+	require.Equal(t, "", errorCategory)
+
+	_, err := db.Exec(`UPDATE certificate_info SET error_category = 'proof_verification' WHERE height = 10;
+		INSERT INTO certificate_info_history SELECT * FROM certificate_info;`)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT error_category FROM certificate_info_history WHERE height = $1;", 10).
+		Scan(&errorCategory))
+	require.Equal(t, "proof_verification", errorCategory)
+}
+
+func (m *migrationTester006) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT error_category FROM certificate_info;")
+	require.ErrorContains(t, err, "no such column")
+}
+
+func TestMigration006(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 6, &migrationTester006{})
+}
@@ -0,0 +1,13 @@
+-- +migrate Down
+DROP TABLE IF EXISTS instance_lease;
+
+-- +migrate Up
+-- instance_lease is held by the running aggsender instance, which renews it periodically.
+-- A second instance using the same DB can't start until the lease expires
+CREATE TABLE instance_lease (
+    id          INTEGER NOT NULL PRIMARY KEY CHECK (id = 1),
+    owner_id    VARCHAR NOT NULL,
+    acquired_at INTEGER NOT NULL,
+    renewed_at  INTEGER NOT NULL,
+    expires_at  INTEGER NOT NULL
+);
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester007 struct{}
+
+func (m *migrationTester007) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester007) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester007) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO instance_lease (id, owner_id, acquired_at, renewed_at, expires_at)
+		VALUES (1, 'instance-a', 100, 100, 130);`)
+	require.NoError(t, err)
+
+	// there is only one lease
+	_, err = db.Exec(`INSERT INTO instance_lease (id, owner_id, acquired_at, renewed_at, expires_at)
+		VALUES (2, 'instance-b', 100, 100, 130);`)
+	require.ErrorContains(t, err, "CHECK constraint failed")
+
+	var ownerID string
+	require.NoError(t, db.QueryRow("SELECT owner_id FROM instance_lease WHERE id = 1;").Scan(&ownerID))
+	require.Equal(t, "instance-a", ownerID)
+}
+
+func (m *migrationTester007) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT owner_id FROM instance_lease;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration007(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 7, &migrationTester007{})
+}
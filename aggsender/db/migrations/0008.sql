@@ -0,0 +1,16 @@
+-- +migrate Down
+DROP TABLE IF EXISTS certificate_build_params;
+
+-- +migrate Up
+-- certificate_build_params keeps the inputs (bridges, claims, L1 info tree root...) used to build
+-- the last certificate submitted for each height, so a retry of an InError certificate is built
+-- from exactly the same inputs instead of querying the syncers again
+CREATE TABLE certificate_build_params (
+    height      INTEGER NOT NULL PRIMARY KEY,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    cert_type   VARCHAR NOT NULL,
+    from_block  INTEGER NOT NULL,
+    to_block    INTEGER NOT NULL,
+    params      TEXT NOT NULL,
+    created_at  INTEGER NOT NULL
+);
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester008 struct{}
+
+func (m *migrationTester008) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester008) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester008) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO certificate_build_params
+		(height, retry_count, cert_type, from_block, to_block, params, created_at)
+		VALUES (1, 0, 'pp', 10, 20, '{}', 100);`)
+	require.NoError(t, err)
+
+	// there is only one snapshot per height
+	_, err = db.Exec(`INSERT INTO certificate_build_params
+		(height, retry_count, cert_type, from_block, to_block, params, created_at)
+		VALUES (1, 1, 'pp', 10, 20, '{}', 100);`)
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+
+	var toBlock uint64
+	require.NoError(t, db.QueryRow("SELECT to_block FROM certificate_build_params WHERE height = 1;").Scan(&toBlock))
+	require.Equal(t, uint64(20), toBlock)
+}
+
+func (m *migrationTester008) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM certificate_build_params;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration008(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 8, &migrationTester008{})
+}
@@ -0,0 +1,18 @@
+-- +migrate Down
+DROP TABLE IF EXISTS certificate_fee;
+
+-- +migrate Up
+-- certificate_fee keeps the estimated fee of each certificate submitted to the agglayer, so the
+-- fee budget (per epoch and per day) is enforced across restarts
+CREATE TABLE certificate_fee (
+    height         INTEGER NOT NULL,
+    retry_count    INTEGER NOT NULL DEFAULT 0,
+    certificate_id VARCHAR NOT NULL,
+    epoch          INTEGER NOT NULL,
+    fee            INTEGER NOT NULL,
+    fee_source     VARCHAR NOT NULL,
+    sent_at        INTEGER NOT NULL,
+    PRIMARY KEY (height, retry_count)
+);
+
+CREATE INDEX idx_certificate_fee_sent_at ON certificate_fee (sent_at);
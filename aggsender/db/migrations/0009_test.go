@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester009 struct{}
+
+func (m *migrationTester009) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester009) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester009) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO certificate_fee
+		(height, retry_count, certificate_id, epoch, fee, fee_source, sent_at)
+		VALUES (1, 0, '0x1', 5, 1000, 'agglayer', 100);`)
+	require.NoError(t, err)
+
+	// a retry of the same height is a different submission
+	_, err = db.Exec(`INSERT INTO certificate_fee
+		(height, retry_count, certificate_id, epoch, fee, fee_source, sent_at)
+		VALUES (1, 1, '0x2', 6, 2000, 'config', 200);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO certificate_fee
+		(height, retry_count, certificate_id, epoch, fee, fee_source, sent_at)
+		VALUES (1, 1, '0x3', 6, 2000, 'config', 200);`)
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+
+	var total uint64
+	require.NoError(t, db.QueryRow("SELECT SUM(fee) FROM certificate_fee;").Scan(&total))
+	require.Equal(t, uint64(3000), total)
+}
+
+func (m *migrationTester009) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM certificate_fee;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration009(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 9, &migrationTester009{})
+}
@@ -0,0 +1,17 @@
+-- +migrate Down
+DROP TABLE IF EXISTS certificate_analytics;
+
+-- +migrate Up
+-- certificate_analytics keeps the aggregated figures of the bridges and claims of each certificate
+-- submitted to the agglayer (claims per origin network, bridged value per token, exits per leaf type)
+CREATE TABLE certificate_analytics (
+    height                    INTEGER NOT NULL,
+    retry_count               INTEGER NOT NULL DEFAULT 0,
+    certificate_id            VARCHAR NOT NULL,
+    claims_per_origin_network VARCHAR NOT NULL,
+    bridged_value_per_token   VARCHAR NOT NULL,
+    asset_exits               INTEGER NOT NULL,
+    message_exits             INTEGER NOT NULL,
+    created_at                INTEGER NOT NULL,
+    PRIMARY KEY (height, retry_count)
+);
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester010 struct{}
+
+func (m *migrationTester010) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester010) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester010) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO certificate_analytics
+		(height, retry_count, certificate_id, claims_per_origin_network, bridged_value_per_token,
+		 asset_exits, message_exits, created_at)
+		VALUES (1, 0, '0x1', '{"0":2}', '{"0:0x0":"10"}', 3, 1, 100);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO certificate_analytics
+		(height, retry_count, certificate_id, claims_per_origin_network, bridged_value_per_token,
+		 asset_exits, message_exits, created_at)
+		VALUES (1, 0, '0x2', '{}', '{}', 0, 0, 200);`)
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+}
+
+func (m *migrationTester010) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM certificate_analytics;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration010(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 10, &migrationTester010{})
+}
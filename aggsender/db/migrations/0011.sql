@@ -0,0 +1,19 @@
+-- +migrate Down
+DROP TABLE IF EXISTS agglayer_certificate_header;
+
+-- +migrate Up
+-- agglayer_certificate_header is the mirror of the certificate headers of the network known by the
+-- agglayer, one per height (the last certificate of the height)
+CREATE TABLE agglayer_certificate_header (
+    height                   INTEGER PRIMARY KEY,
+    certificate_id           VARCHAR NOT NULL,
+    epoch_number             INTEGER,
+    certificate_index        INTEGER,
+    previous_local_exit_root VARCHAR,
+    new_local_exit_root      VARCHAR NOT NULL,
+    status                   INTEGER NOT NULL,
+    metadata                 VARCHAR NOT NULL,
+    settlement_tx_hash       VARCHAR,
+    error                    VARCHAR NOT NULL DEFAULT '',
+    mirrored_at              INTEGER NOT NULL
+);
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester011 struct{}
+
+func (m *migrationTester011) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester011) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester011) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO agglayer_certificate_header
+		(height, certificate_id, epoch_number, new_local_exit_root, status, metadata, mirrored_at)
+		VALUES (1, '0x1', 7, '0x2', 4, '0x3', 100);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO agglayer_certificate_header
+		(height, certificate_id, new_local_exit_root, status, metadata, mirrored_at)
+		VALUES (1, '0x4', '0x2', 0, '0x3', 200);`)
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+}
+
+func (m *migrationTester011) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM agglayer_certificate_header;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration011(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 11, &migrationTester011{})
+}
@@ -0,0 +1,17 @@
+-- +migrate Down
+DROP TABLE IF EXISTS proof_stats;
+
+-- +migrate Up
+-- proof_stats keeps the proving time and the proof size of the aggchain proof of each certificate
+-- built, to track the percentiles of the prover against the SLOs
+CREATE TABLE proof_stats (
+    height          INTEGER NOT NULL,
+    retry_count     INTEGER NOT NULL DEFAULT 0,
+    from_block      INTEGER NOT NULL,
+    to_block        INTEGER NOT NULL,
+    proving_time_ms INTEGER NOT NULL,
+    proof_size      INTEGER NOT NULL,
+    created_at      INTEGER NOT NULL,
+    PRIMARY KEY (height, retry_count)
+);
+CREATE INDEX idx_proof_stats_created_at ON proof_stats (created_at);
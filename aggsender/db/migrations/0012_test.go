@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester012 struct{}
+
+func (m *migrationTester012) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester012) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester012) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO proof_stats
+		(height, retry_count, from_block, to_block, proving_time_ms, proof_size, created_at)
+		VALUES (1, 0, 10, 20, 60000, 1024, 100);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO proof_stats
+		(height, retry_count, from_block, to_block, proving_time_ms, proof_size, created_at)
+		VALUES (1, 0, 10, 20, 30000, 512, 200);`)
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+}
+
+func (m *migrationTester012) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM proof_stats;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration012(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 12, &migrationTester012{})
+}
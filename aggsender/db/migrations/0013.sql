@@ -0,0 +1,8 @@
+-- +migrate Down
+ALTER TABLE certificate_info DROP COLUMN tags;
+ALTER TABLE certificate_info_history DROP COLUMN tags;
+
+-- +migrate Up
+-- tags is the JSON object of the tags of the deployment that sent the certificate, NULL if it has no tags
+ALTER TABLE certificate_info ADD COLUMN tags VARCHAR;
+ALTER TABLE certificate_info_history ADD COLUMN tags VARCHAR;
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester013 struct{}
+
+func (m *migrationTester013) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester013) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO certificate_info (
+			height,
+			retry_count,
+			certificate_id,
+			status,
+			new_local_exit_root,
+			from_block,
+			to_block,
+			created_at,
+			updated_at
+		) VALUES (10, 0, '0x789abc', 4, '0x23456', 1000, 2000, 0, 0);
+	`)
+	require.NoError(t, err)
+}
+
+func (m *migrationTester013) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	var tags sql.NullString
+	require.NoError(t, db.QueryRow("SELECT tags FROM certificate_info WHERE height = $1;", 10).Scan(&tags))
+	require.False(t, tags.Valid)
+
+	_, err := db.Exec(`UPDATE certificate_info SET tags = '{"environment":"prod"}' WHERE height = 10;
+		INSERT INTO certificate_info_history SELECT * FROM certificate_info;`)
+	require.NoError(t, err)
+	var environment string
+	require.NoError(t, db.QueryRow(`SELECT json_extract(tags, '$.environment') FROM certificate_info_history
+		WHERE height = $1;`, 10).Scan(&environment))
+	require.Equal(t, "prod", environment)
+}
+
+func (m *migrationTester013) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT tags FROM certificate_info;")
+	require.ErrorContains(t, err, "no such column")
+}
+
+func TestMigration013(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 13, &migrationTester013{})
+}
@@ -0,0 +1,32 @@
+-- +migrate Down
+DROP TRIGGER IF EXISTS certificate_audit_event_no_update;
+DROP TRIGGER IF EXISTS certificate_audit_event_no_delete;
+DROP TABLE IF EXISTS certificate_audit_event;
+
+-- +migrate Up
+-- certificate_audit_event is the append-only audit trail of the decisions taken for each certificate
+-- (range chosen, truncations, prover attempts, signature, submissions and status changes)
+CREATE TABLE certificate_audit_event (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    height         INTEGER NOT NULL,
+    retry_count    INTEGER NOT NULL DEFAULT 0,
+    certificate_id VARCHAR,
+    event          VARCHAR NOT NULL,
+    details        VARCHAR NOT NULL,
+    created_at     INTEGER NOT NULL
+);
+CREATE INDEX idx_certificate_audit_event_height ON certificate_audit_event (height);
+
+-- +migrate StatementBegin
+CREATE TRIGGER certificate_audit_event_no_update BEFORE UPDATE ON certificate_audit_event
+BEGIN
+    SELECT RAISE(ABORT, 'certificate_audit_event is append-only');
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER certificate_audit_event_no_delete BEFORE DELETE ON certificate_audit_event
+BEGIN
+    SELECT RAISE(ABORT, 'certificate_audit_event is append-only');
+END;
+-- +migrate StatementEnd
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester014 struct{}
+
+func (m *migrationTester014) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester014) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+}
+
+func (m *migrationTester014) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`INSERT INTO certificate_audit_event (height, retry_count, event, details, created_at)
+		VALUES (1, 0, 'range_selected', '{"from_block":10}', 100);`)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`UPDATE certificate_audit_event SET event = 'signed' WHERE height = 1;`)
+	require.ErrorContains(t, err, "append-only")
+	_, err = db.Exec(`DELETE FROM certificate_audit_event WHERE height = 1;`)
+	require.ErrorContains(t, err, "append-only")
+}
+
+func (m *migrationTester014) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT height FROM certificate_audit_event;")
+	require.ErrorContains(t, err, "no such table")
+}
+
+func TestMigration014(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 14, &migrationTester014{})
+}
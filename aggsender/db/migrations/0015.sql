@@ -0,0 +1,9 @@
+-- +migrate Down
+ALTER TABLE certificate_info DROP COLUMN build_epoch;
+ALTER TABLE certificate_info_history DROP COLUMN build_epoch;
+
+-- +migrate Up
+-- build_epoch is the epoch in which the build of the certificate started, NULL if it's unknown (e.g. the
+-- certificates received from the agglayer or stored before it was tracked)
+ALTER TABLE certificate_info ADD COLUMN build_epoch INTEGER;
+ALTER TABLE certificate_info_history ADD COLUMN build_epoch INTEGER;
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"database/sql"
+	"testing"
+
+	dbmigrations "github.com/agglayer/aggkit/db/migrations/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type migrationTester015 struct{}
+
+func (m *migrationTester015) FilenameTemplateDatabase(t *testing.T) string {
+	t.Helper()
+	return ""
+}
+
+func (m *migrationTester015) InsertDataBeforeMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec(`
+		INSERT INTO certificate_info (
+			height,
+			retry_count,
+			certificate_id,
+			status,
+			new_local_exit_root,
+			from_block,
+			to_block,
+			created_at,
+			updated_at
+		) VALUES (10, 0, '0x789abc', 4, '0x23456', 1000, 2000, 0, 0);
+	`)
+	require.NoError(t, err)
+}
+
+func (m *migrationTester015) RunAssertsAfterMigrationUp(t *testing.T, db *sql.DB) {
+	t.Helper()
+	var buildEpoch sql.NullInt64
+	require.NoError(t, db.QueryRow("SELECT build_epoch FROM certificate_info WHERE height = $1;", 10).
+		Scan(&buildEpoch))
+	require.False(t, buildEpoch.Valid)
+
+	_, err := db.Exec(`UPDATE certificate_info SET build_epoch = 7 WHERE height = 10;
+		INSERT INTO certificate_info_history SELECT * FROM certificate_info;`)
+	require.NoError(t, err)
+	require.NoError(t, db.QueryRow("SELECT build_epoch FROM certificate_info_history WHERE height = $1;", 10).
+		Scan(&buildEpoch))
+	require.Equal(t, int64(7), buildEpoch.Int64)
+}
+
+func (m *migrationTester015) RunAssertsAfterMigrationDown(t *testing.T, db *sql.DB) {
+	t.Helper()
+	_, err := db.Exec("SELECT build_epoch FROM certificate_info;")
+	require.ErrorContains(t, err, "no such column")
+}
+
+func TestMigration015(t *testing.T) {
+	dbmigrations.TestMigration(t, "aggsender", Migrations, 15, &migrationTester015{})
+}
@@ -21,6 +21,39 @@ var mig003 string
 //go:embed 0004.sql
 var mig004 string
 
+//go:embed 0005.sql
+var mig005 string
+
+//go:embed 0006.sql
+var mig006 string
+
+//go:embed 0007.sql
+var mig007 string
+
+//go:embed 0008.sql
+var mig008 string
+
+//go:embed 0009.sql
+var mig009 string
+
+//go:embed 0010.sql
+var mig010 string
+
+//go:embed 0011.sql
+var mig011 string
+
+//go:embed 0012.sql
+var mig012 string
+
+//go:embed 0013.sql
+var mig013 string
+
+//go:embed 0014.sql
+var mig014 string
+
+//go:embed 0015.sql
+var mig015 string
+
 var Migrations = []types.Migration{
 	{
 		ID:  "0001",
@@ -38,6 +71,50 @@ var Migrations = []types.Migration{
 		ID:  "0004",
 		SQL: mig004,
 	},
+	{
+		ID:  "0005",
+		SQL: mig005,
+	},
+	{
+		ID:  "0006",
+		SQL: mig006,
+	},
+	{
+		ID:  "0007",
+		SQL: mig007,
+	},
+	{
+		ID:  "0008",
+		SQL: mig008,
+	},
+	{
+		ID:  "0009",
+		SQL: mig009,
+	},
+	{
+		ID:  "0010",
+		SQL: mig010,
+	},
+	{
+		ID:  "0011",
+		SQL: mig011,
+	},
+	{
+		ID:  "0012",
+		SQL: mig012,
+	},
+	{
+		ID:  "0013",
+		SQL: mig013,
+	},
+	{
+		ID:  "0014",
+		SQL: mig014,
+	},
+	{
+		ID:  "0015",
+		SQL: mig015,
+	},
 }
 
 func RunMigrations(logger *log.Logger, database *sql.DB) error {
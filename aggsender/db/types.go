@@ -2,10 +2,12 @@ package db
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -31,6 +33,9 @@ type certificateInfo struct {
 	CertType                types.CertificateType           `meddler:"cert_type"`
 	CertSource              types.CertificateSource         `meddler:"cert_source"`
 	ExtraData               string                          `meddler:"extra_data"`
+	ErrorCategory           types.CertificateErrorCategory  `meddler:"error_category"`
+	Tags                    types.CertificateTags           `meddler:"tags,certificatetags"`
+	BuildEpoch              *uint64                         `meddler:"build_epoch"`
 }
 
 // toCertificate converts the certificateInfo struct to a Certificate struct
@@ -51,6 +56,9 @@ func (c *certificateInfo) toCertificate() *types.Certificate {
 			L1InfoTreeLeafCount:     c.L1InfoTreeLeafCount,
 			CertType:                c.CertType,
 			CertSource:              c.CertSource,
+			ErrorCategory:           c.ErrorCategory,
+			Tags:                    c.Tags,
+			BuildEpoch:              c.BuildEpoch,
 		},
 		SignedCertificate: c.SignedCertificate,
 		AggchainProof:     c.AggchainProof,
@@ -90,3 +98,213 @@ func NewNonAcceptedCertificate(
 		Error:             certError,
 	}, nil
 }
+
+// CertificateJournalState is the state of an entry of the certificate submission journal
+type CertificateJournalState string
+
+const (
+	// CertificateJournalStatePreCommit means that the certificate is built and signed and it's
+	// going to be sent to agglayer, but there is no acknowledgement yet
+	CertificateJournalStatePreCommit CertificateJournalState = "pre_commit"
+	// CertificateJournalStateAcknowledged means that agglayer has accepted the certificate
+	CertificateJournalStateAcknowledged CertificateJournalState = "acknowledged"
+	// CertificateJournalStateDiscarded means that agglayer has not received or rejected the certificate
+	CertificateJournalStateDiscarded CertificateJournalState = "discarded"
+)
+
+// CertificateJournalEntry is an entry of the certificate submission journal. It keeps the full
+// certificate before submitting it, so after a crash it's possible to know what was sent to agglayer
+type CertificateJournalEntry struct {
+	Height           uint64                  `meddler:"height"`
+	RetryCount       int                     `meddler:"retry_count"`
+	State            CertificateJournalState `meddler:"state"`
+	CertificateID    *common.Hash            `meddler:"certificate_id,hash"`
+	NewLocalExitRoot common.Hash             `meddler:"new_local_exit_root,hash"`
+	// Metadata is the metadata field of the signed certificate, it's used to identify the exact
+	// certificate (retry) on agglayer
+	Metadata common.Hash `meddler:"metadata,hash"`
+	// Certificate is the JSON representation of the certificate to be stored once acknowledged
+	Certificate string `meddler:"certificate"`
+	CreatedAt   uint32 `meddler:"created_at"`
+	UpdatedAt   uint32 `meddler:"updated_at"`
+}
+
+// NewCertificateJournalEntry creates a pre-commit entry of the journal for the given certificate
+func NewCertificateJournalEntry(
+	cert types.Certificate,
+	metadata common.Hash,
+	createdAt uint32) (*CertificateJournalEntry, error) {
+	if cert.Header == nil {
+		return nil, fmt.Errorf("certificate journal: certificate header is nil")
+	}
+	raw, err := json.Marshal(cert)
+	if err != nil {
+		return nil, fmt.Errorf("certificate journal: failed to marshal certificate %s: %w", cert.Header.ID(), err)
+	}
+
+	return &CertificateJournalEntry{
+		Height:           cert.Header.Height,
+		RetryCount:       cert.Header.RetryCount,
+		State:            CertificateJournalStatePreCommit,
+		NewLocalExitRoot: cert.Header.NewLocalExitRoot,
+		Metadata:         metadata,
+		Certificate:      string(raw),
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt,
+	}, nil
+}
+
+// ToCertificate decodes the certificate stored in the journal entry
+func (c *CertificateJournalEntry) ToCertificate() (*types.Certificate, error) {
+	var cert types.Certificate
+	if err := json.Unmarshal([]byte(c.Certificate), &cert); err != nil {
+		return nil, fmt.Errorf("certificate journal: failed to unmarshal certificate at height %d: %w", c.Height, err)
+	}
+	if cert.Header == nil {
+		return nil, fmt.Errorf("certificate journal: certificate at height %d has no header", c.Height)
+	}
+	return &cert, nil
+}
+
+// ID returns a string with the identifier of the journal entry
+func (c *CertificateJournalEntry) ID() string {
+	if c == nil {
+		return types.NilStr
+	}
+	return fmt.Sprintf("journal{height:%d, retry:%d, state:%s}", c.Height, c.RetryCount, c.State)
+}
+
+// CertificateFee is the estimated fee of a certificate submitted to the agglayer, used to enforce
+// the fee budget. SentAt is a unix timestamp in seconds
+type CertificateFee struct {
+	Height        uint64                     `meddler:"height"`
+	RetryCount    int                        `meddler:"retry_count"`
+	CertificateID common.Hash                `meddler:"certificate_id,hash"`
+	Epoch         uint64                     `meddler:"epoch"`
+	Fee           uint64                     `meddler:"fee"`
+	FeeSource     types.CertificateFeeSource `meddler:"fee_source"`
+	SentAt        uint32                     `meddler:"sent_at"`
+}
+
+// ID returns a string with the identifier of the certificate fee
+func (c *CertificateFee) ID() string {
+	if c == nil {
+		return types.NilStr
+	}
+	return fmt.Sprintf("fee{height:%d, retry:%d, epoch:%d, fee:%d, source:%s}",
+		c.Height, c.RetryCount, c.Epoch, c.Fee, c.FeeSource)
+}
+
+// ErrInstanceLeaseHeld is returned when the instance lease is held by another aggsender instance
+var ErrInstanceLeaseHeld = errors.New("the aggsender instance lease is held by another instance")
+
+// InstanceLease is the lease of the aggsender instance that is allowed to send certificates.
+// The times are unix timestamps in seconds
+type InstanceLease struct {
+	OwnerID    string `meddler:"owner_id"`
+	AcquiredAt uint32 `meddler:"acquired_at"`
+	RenewedAt  uint32 `meddler:"renewed_at"`
+	ExpiresAt  uint32 `meddler:"expires_at"`
+}
+
+// String returns a string representation of the lease
+func (l *InstanceLease) String() string {
+	if l == nil {
+		return types.NilStr
+	}
+	return fmt.Sprintf("lease{owner:%s, acquiredAt:%d, renewedAt:%d, expiresAt:%d}",
+		l.OwnerID, l.AcquiredAt, l.RenewedAt, l.ExpiresAt)
+}
+
+// CertificateBuildParamsSnapshot is the snapshot of the inputs used to build the last certificate
+// submitted for a height. A retry of the certificate (if it's InError) is built from these inputs,
+// so it's the same certificate even if the syncers have advanced or reorged meanwhile
+type CertificateBuildParamsSnapshot struct {
+	Height     uint64                `meddler:"height"`
+	RetryCount int                   `meddler:"retry_count"`
+	CertType   types.CertificateType `meddler:"cert_type"`
+	FromBlock  uint64                `meddler:"from_block"`
+	ToBlock    uint64                `meddler:"to_block"`
+	// Params is the JSON representation of the inputs (certificateBuildParamsInputs)
+	Params    string `meddler:"params"`
+	CreatedAt uint32 `meddler:"created_at"`
+}
+
+// certificateBuildParamsInputs are the fields of the CertificateBuildParams stored in the snapshot
+type certificateBuildParamsInputs struct {
+	Bridges                        []bridgesync.Bridge `json:"bridges"`
+	Claims                         []bridgesync.Claim  `json:"claims"`
+	L1InfoTreeRootFromWhichToProve common.Hash         `json:"l1_info_tree_root_from_which_to_prove"`
+	L1InfoTreeLeafCount            uint32              `json:"l1_info_tree_leaf_count"`
+	ForkName                       string              `json:"fork_name,omitempty"`
+}
+
+// NewCertificateBuildParamsSnapshot creates the snapshot of the build params of the certificate
+// with the given height and retry count
+func NewCertificateBuildParamsSnapshot(height uint64, retryCount int,
+	params *types.CertificateBuildParams) (*CertificateBuildParamsSnapshot, error) {
+	if params == nil {
+		return nil, fmt.Errorf("certificate build params snapshot: build params are nil")
+	}
+	raw, err := json.Marshal(certificateBuildParamsInputs{
+		Bridges:                        params.Bridges,
+		Claims:                         params.Claims,
+		L1InfoTreeRootFromWhichToProve: params.L1InfoTreeRootFromWhichToProve,
+		L1InfoTreeLeafCount:            params.L1InfoTreeLeafCount,
+		ForkName:                       params.ForkName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("certificate build params snapshot: failed to marshal build params of height %d: %w",
+			height, err)
+	}
+
+	return &CertificateBuildParamsSnapshot{
+		Height:     height,
+		RetryCount: retryCount,
+		CertType:   params.CertificateType,
+		FromBlock:  params.FromBlock,
+		ToBlock:    params.ToBlock,
+		Params:     string(raw),
+		CreatedAt:  params.CreatedAt,
+	}, nil
+}
+
+// ToBuildParams decodes the snapshot into build params for a retry of the certificate. The fields
+// that depend on the retry (RetryCount, LastSentCertificate, AggchainProof...) are not set
+func (c *CertificateBuildParamsSnapshot) ToBuildParams() (*types.CertificateBuildParams, error) {
+	var inputs certificateBuildParamsInputs
+	if err := json.Unmarshal([]byte(c.Params), &inputs); err != nil {
+		return nil, fmt.Errorf("certificate build params snapshot: failed to unmarshal build params of height %d: %w",
+			c.Height, err)
+	}
+
+	return &types.CertificateBuildParams{
+		FromBlock:                      c.FromBlock,
+		ToBlock:                        c.ToBlock,
+		Bridges:                        inputs.Bridges,
+		Claims:                         inputs.Claims,
+		CreatedAt:                      c.CreatedAt,
+		L1InfoTreeRootFromWhichToProve: inputs.L1InfoTreeRootFromWhichToProve,
+		L1InfoTreeLeafCount:            inputs.L1InfoTreeLeafCount,
+		CertificateType:                c.CertType,
+		ForkName:                       inputs.ForkName,
+	}, nil
+}
+
+// Matches returns true if the snapshot was taken for the given certificate (same height,
+// block range and type)
+func (c *CertificateBuildParamsSnapshot) Matches(header *types.CertificateHeader) bool {
+	return c != nil && header != nil &&
+		c.Height == header.Height &&
+		c.FromBlock == header.FromBlock &&
+		c.ToBlock == header.ToBlock &&
+		c.CertType == header.CertType
+}
+
+// ID returns a string with the identifier of the snapshot
+func (c *CertificateBuildParamsSnapshot) ID() string {
+	if c == nil {
+		return types.NilStr
+	}
+	return fmt.Sprintf("buildParams{height:%d, retry:%d, blocks:%d-%d}", c.Height, c.RetryCount, c.FromBlock, c.ToBlock)
+}
@@ -0,0 +1,44 @@
+package aggsender
+
+import (
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// epochRolloverTracker keeps the epoch in which the last certificate was submitted, to detect that
+// it's still pending in a later epoch because it was submitted close to the epoch boundary and the
+// agglayer processes it in the next one (submitted in epoch N, acknowledged in N+1).
+// It's not persisted, so a certificate submitted before a restart is never detected as a rollover.
+// The zero value doesn't track any certificate
+type epochRolloverTracker struct {
+	certificateID  common.Hash
+	submittedEpoch uint64
+	tracking       bool
+	// reportedEpoch is the last epoch in which the rollover was reported, so it's counted once
+	reportedEpoch uint64
+}
+
+// certificateSubmitted starts tracking the certificate submitted in the given epoch
+func (t *epochRolloverTracker) certificateSubmitted(certificateID common.Hash, epoch uint64) {
+	*t = epochRolloverTracker{
+		certificateID:  certificateID,
+		submittedEpoch: epoch,
+		tracking:       true,
+	}
+}
+
+// isRollover returns true if the pending certificate was submitted in an epoch previous to the given
+// one. The first time it's detected in an epoch it's logged and counted in the metrics
+func (t *epochRolloverTracker) isRollover(logger aggkitcommon.Logger, epoch uint64) bool {
+	if !t.tracking || epoch <= t.submittedEpoch {
+		return false
+	}
+	if t.reportedEpoch != epoch {
+		t.reportedEpoch = epoch
+		metrics.EpochRollover()
+		logger.Infof("certificate %s submitted in epoch %d is still pending in epoch %d: it's processed by "+
+			"the agglayer in a later epoch (epoch rollover)", t.certificateID.Hex(), t.submittedEpoch, epoch)
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeServer accepts a single connection and runs the handler with it
+func startFakeServer(t *testing.T, handler func(conn net.Conn, reader *bufio.Reader)) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handler(conn, bufio.NewReader(conn))
+	}()
+	return listener.Addr().String()
+}
+
+func readLine(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	return strings.TrimRight(line, "\r\n")
+}
+
+// readRESPCommand reads a RESP array of bulk strings
+func readRESPCommand(t *testing.T, reader *bufio.Reader) []string {
+	t.Helper()
+	header := readLine(t, reader)
+	require.True(t, strings.HasPrefix(header, "*"))
+	n, err := strconv.Atoi(header[1:])
+	require.NoError(t, err)
+	args := make([]string, 0, n)
+	for range n {
+		size, err := strconv.Atoi(strings.TrimPrefix(readLine(t, reader), "$"))
+		require.NoError(t, err)
+		buf := make([]byte, size+2)
+		_, err = io.ReadFull(reader, buf)
+		require.NoError(t, err)
+		args = append(args, string(buf[:size]))
+	}
+	return args
+}
+
+func newClientTestConfig(url string) Config {
+	return Config{URL: url, RequestTimeout: configtypes.NewDuration(5 * time.Second)}
+}
+
+func TestNATSClientPublish(t *testing.T) {
+	received := make(chan []string, 1)
+	addr := startFakeServer(t, func(conn net.Conn, reader *bufio.Reader) {
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		connect := readLine(t, reader)
+		require.Equal(t, "PING", readLine(t, reader))
+		fmt.Fprint(conn, "PONG\r\n")
+		pub := readLine(t, reader)
+		payload := readLine(t, reader)
+		require.Equal(t, "PING", readLine(t, reader))
+		// the client must answer the PINGs of the server while waiting for its PONG
+		fmt.Fprint(conn, "PING\r\n")
+		require.Equal(t, "PONG", readLine(t, reader))
+		fmt.Fprint(conn, "PONG\r\n")
+		received <- []string{connect, pub, payload}
+	})
+
+	sut := NewNATSClient(newClientTestConfig("nats://user:secret@" + addr))
+	defer sut.Close()
+	require.NoError(t, sut.Publish(context.Background(), "aggsender.certificates", []byte(`{"height":1}`)))
+
+	got := <-received
+	require.Contains(t, got[0], `"user":"user"`)
+	require.Contains(t, got[0], `"pass":"secret"`)
+	require.Equal(t, "PUB aggsender.certificates 12", got[1])
+	require.Equal(t, `{"height":1}`, got[2])
+}
+
+func TestNATSClientPublishError(t *testing.T) {
+	addr := startFakeServer(t, func(conn net.Conn, reader *bufio.Reader) {
+		fmt.Fprint(conn, "INFO {}\r\n")
+		connect := readLine(t, reader)
+		require.Contains(t, connect, `"auth_token":"token"`)
+		readLine(t, reader)
+		fmt.Fprint(conn, "-ERR 'Authorization Violation'\r\n")
+	})
+
+	sut := NewNATSClient(newClientTestConfig("nats://token@" + addr))
+	err := sut.Publish(context.Background(), "subject", []byte("{}"))
+	require.ErrorContains(t, err, "Authorization Violation")
+	// the connection is closed, so the next publication connects again
+	require.Nil(t, sut.conn)
+}
+
+func TestRedisStreamsClientPublish(t *testing.T) {
+	received := make(chan [][]string, 1)
+	addr := startFakeServer(t, func(conn net.Conn, reader *bufio.Reader) {
+		var commands [][]string
+		for range 3 {
+			cmd := readRESPCommand(t, reader)
+			commands = append(commands, cmd)
+			if cmd[0] == "XADD" {
+				fmt.Fprint(conn, "$15\r\n1700000000000-0\r\n")
+			} else {
+				fmt.Fprint(conn, "+OK\r\n")
+			}
+		}
+		received <- commands
+	})
+
+	cfg := newClientTestConfig("redis://default:secret@" + addr + "/2")
+	cfg.MaxLen = 1000
+	sut := NewRedisStreamsClient(cfg)
+	defer sut.Close()
+	require.NoError(t, sut.Publish(context.Background(), "aggsender:certificates", []byte(`{"height":1}`)))
+
+	got := <-received
+	require.Equal(t, []string{"AUTH", "default", "secret"}, got[0])
+	require.Equal(t, []string{"SELECT", "2"}, got[1])
+	require.Equal(t, []string{"XADD", "aggsender:certificates", "MAXLEN", "~", "1000", "*",
+		"event", `{"height":1}`}, got[2])
+}
+
+func TestRedisStreamsClientPublishError(t *testing.T) {
+	addr := startFakeServer(t, func(conn net.Conn, reader *bufio.Reader) {
+		require.Equal(t, "XADD", readRESPCommand(t, reader)[0])
+		fmt.Fprint(conn, "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n")
+	})
+
+	sut := NewRedisStreamsClient(newClientTestConfig("redis://" + addr))
+	err := sut.Publish(context.Background(), "stream", []byte("{}"))
+	require.ErrorContains(t, err, "WRONGTYPE")
+	require.Nil(t, sut.conn)
+}
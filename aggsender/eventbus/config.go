@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// Backend is the message bus where the certificate events are published
+type Backend string
+
+const (
+	// BackendNATS publishes the events to a NATS subject
+	BackendNATS Backend = "NATS"
+	// BackendRedisStreams appends the events to a Redis stream (XADD)
+	BackendRedisStreams Backend = "RedisStreams"
+)
+
+// Config holds the configuration of the publisher of the certificate events
+type Config struct {
+	// Enabled is a flag to enable the publication of the certificate lifecycle events
+	Enabled bool `mapstructure:"Enabled"`
+	// Backend is the message bus: NATS or RedisStreams
+	Backend Backend `jsonschema:"enum=NATS, enum=RedisStreams" mapstructure:"Backend"`
+	// URL is the address of the server, with the credentials if any:
+	// nats://[user:password@|token@]host:4222 (tls:// for TLS) or
+	// redis://[user:password@]host:6379[/db] (rediss:// for TLS)
+	URL string `mapstructure:"URL"`
+	// Subject is the NATS subject or the key of the Redis stream where the events are published
+	Subject string `mapstructure:"Subject"`
+	// MaxLen trims the Redis stream to approximately this number of events. 0 means no trim
+	MaxLen uint64 `mapstructure:"MaxLen"`
+	// BufferSize is the number of events queued while the message bus is unreachable.
+	// When the queue is full the new events are dropped
+	BufferSize int `mapstructure:"BufferSize"`
+	// RequestTimeout is the timeout to connect and to publish each event
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
+	// RetryInterval is the delay before trying again to publish an event after an error
+	RetryInterval types.Duration `mapstructure:"RetryInterval"`
+}
+
+// Validate checks that the configuration is correct
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("eventbus: invalid URL %s", redactURL(c.URL))
+	}
+	scheme := strings.ToLower(u.Scheme)
+	switch c.Backend {
+	case BackendNATS:
+		if scheme != "nats" && scheme != "tls" {
+			return fmt.Errorf("eventbus: the URL of the %s backend must be nats:// or tls://", c.Backend)
+		}
+	case BackendRedisStreams:
+		if scheme != "redis" && scheme != "rediss" {
+			return fmt.Errorf("eventbus: the URL of the %s backend must be redis:// or rediss://", c.Backend)
+		}
+	default:
+		return fmt.Errorf("eventbus: unsupported Backend %q, expected %s or %s",
+			c.Backend, BackendNATS, BackendRedisStreams)
+	}
+	if c.Subject == "" {
+		return errors.New("eventbus: Subject is required")
+	}
+	if c.BufferSize <= 0 {
+		return errors.New("eventbus: BufferSize must be greater than 0")
+	}
+	if c.RequestTimeout.Duration <= 0 {
+		return errors.New("eventbus: RequestTimeout must be greater than 0")
+	}
+	return nil
+}
+
+// String returns a string representation of the configuration (without credentials)
+func (c Config) String() string {
+	return fmt.Sprintf("Enabled: %t, Backend: %s, URL: %s, Subject: %s, BufferSize: %d",
+		c.Enabled, c.Backend, redactURL(c.URL), c.Subject, c.BufferSize)
+}
+
+// redactURL removes the credentials from the URL to log it
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "<invalid URL>"
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,35 @@
+package eventbus
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"time"
+)
+
+// dial opens a TCP (or TLS) connection to the host of the URL, with the default port if it has none
+func dial(ctx context.Context, u *url.URL, defaultPort string, useTLS bool) (net.Conn, error) {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	dialer := &net.Dialer{}
+	if !useTLS {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{
+		ServerName: u.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}}
+	return tlsDialer.DialContext(ctx, "tcp", address)
+}
+
+// setDeadline sets the deadline of the context (if any) to the reads and writes of the connection
+func setDeadline(ctx context.Context, conn net.Conn) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Time{}
+	}
+	_ = conn.SetDeadline(deadline)
+}
@@ -0,0 +1,156 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	natsDefaultPort = "4222"
+	natsClientName  = "aggkit-aggsender"
+)
+
+// NATSClient publishes to a NATS server using the core NATS text protocol. Each publication is
+// followed by a PING, so it only succeeds once the server has processed it
+type NATSClient struct {
+	cfg Config
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNATSClient creates a new NATSClient. The connection is opened on the first publication
+func NewNATSClient(cfg Config) *NATSClient {
+	return &NATSClient{cfg: cfg}
+}
+
+// Publish publishes the payload to the subject
+func (n *NATSClient) Publish(ctx context.Context, subject string, payload []byte) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return fmt.Errorf("error connecting to NATS %s: %w", redactURL(n.cfg.URL), err)
+		}
+	}
+	setDeadline(ctx, n.conn)
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload)
+	if _, err := n.conn.Write([]byte(msg)); err != nil {
+		n.closeConn()
+		return fmt.Errorf("error publishing to NATS: %w", err)
+	}
+	if err := n.waitPong(); err != nil {
+		n.closeConn()
+		return fmt.Errorf("error publishing to NATS: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (n *NATSClient) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.closeConn()
+	return nil
+}
+
+// connect opens the connection: the server sends its INFO and the client answers with its CONNECT
+// options (with the credentials of the URL) and a PING to check that they are accepted
+func (n *NATSClient) connect(ctx context.Context) error {
+	u, err := url.Parse(n.cfg.URL)
+	if err != nil {
+		return err
+	}
+	conn, err := dial(ctx, u, natsDefaultPort, strings.EqualFold(u.Scheme, "tls"))
+	if err != nil {
+		return err
+	}
+	n.conn = conn
+	n.reader = bufio.NewReader(conn)
+	setDeadline(ctx, conn)
+
+	line, err := n.readLine()
+	if err != nil {
+		n.closeConn()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		n.closeConn()
+		return fmt.Errorf("unexpected NATS greeting: %s", line)
+	}
+	connectOpts := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     natsClientName,
+		"lang":     "go",
+		"protocol": 1,
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			connectOpts["user"] = u.User.Username()
+			connectOpts["pass"] = password
+		} else {
+			connectOpts["auth_token"] = u.User.Username()
+		}
+	}
+	opts, err := json.Marshal(connectOpts)
+	if err != nil {
+		n.closeConn()
+		return err
+	}
+	if _, err := fmt.Fprintf(n.conn, "CONNECT %s\r\nPING\r\n", opts); err != nil {
+		n.closeConn()
+		return err
+	}
+	if err := n.waitPong(); err != nil {
+		n.closeConn()
+		return err
+	}
+	return nil
+}
+
+// waitPong reads the messages of the server until the PONG, answering its PINGs
+func (n *NATSClient) waitPong() error {
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (n *NATSClient) readLine() (string, error) {
+	line, err := n.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// closeConn closes the connection after an error, so the next publication opens a new one
+func (n *NATSClient) closeConn() {
+	if n.conn == nil {
+		return
+	}
+	_ = n.conn.Close()
+	n.conn = nil
+	n.reader = nil
+}
@@ -0,0 +1,117 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+const defaultRetryInterval = 5 * time.Second
+
+var _ types.CertificateEventPublisher = (*Publisher)(nil)
+
+// MessageBus is the client of the message bus where the events are published
+type MessageBus interface {
+	// Publish publishes the payload to the subject, connecting to the server if needed
+	Publish(ctx context.Context, subject string, payload []byte) error
+	// Close closes the connection to the server
+	Close() error
+}
+
+// Publisher queues the lifecycle events of the certificates and publishes them, in order, to a
+// message bus (NATS or Redis Streams), so the downstream automation doesn't have to poll the
+// aggsender. A failed publication is retried until it succeeds, so the events are delivered at
+// least once while they fit in the queue
+type Publisher struct {
+	log       types.Logger
+	bus       MessageBus
+	cfg       Config
+	networkID uint32
+	queue     chan types.CertificateEvent
+}
+
+// New creates a new Publisher for the backend of the config
+func New(log types.Logger, cfg Config, networkID uint32) (*Publisher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var bus MessageBus
+	switch cfg.Backend {
+	case BackendNATS:
+		bus = NewNATSClient(cfg)
+	case BackendRedisStreams:
+		bus = NewRedisStreamsClient(cfg)
+	}
+	return NewWithBus(log, cfg, networkID, bus), nil
+}
+
+// NewWithBus creates a new Publisher using the given message bus
+func NewWithBus(log types.Logger, cfg Config, networkID uint32, bus MessageBus) *Publisher {
+	return &Publisher{
+		log:       log,
+		bus:       bus,
+		cfg:       cfg,
+		networkID: networkID,
+		queue:     make(chan types.CertificateEvent, max(cfg.BufferSize, 1)),
+	}
+}
+
+// Publish queues the event of the network of the aggsender. If the queue is full the event is dropped
+// (and logged), so a message bus that is down never blocks the submission of the certificates
+func (p *Publisher) Publish(event types.CertificateEvent) {
+	event.NetworkID = p.networkID
+	select {
+	case p.queue <- event:
+	default:
+		p.log.Warnf("eventbus: queue full, dropping the %s event of certificate %d", event.Type, event.Height)
+	}
+}
+
+// Start publishes the queued events until the context is done
+func (p *Publisher) Start(ctx context.Context) {
+	p.log.Infof("eventbus: publishing the certificate events. %s", p.cfg.String())
+	defer func() {
+		if err := p.bus.Close(); err != nil {
+			p.log.Warnf("eventbus: error closing the connection: %v", err)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-p.queue:
+			p.deliver(ctx, event)
+		}
+	}
+}
+
+// deliver publishes the event, retrying until it succeeds or the context is done
+func (p *Publisher) deliver(ctx context.Context, event types.CertificateEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.log.Errorf("eventbus: error marshalling the %s event of certificate %d: %v", event.Type, event.Height, err)
+		return
+	}
+	retryInterval := p.cfg.RetryInterval.Duration
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+	for {
+		publishCtx, cancel := context.WithTimeout(ctx, p.cfg.RequestTimeout.Duration)
+		err := p.bus.Publish(publishCtx, p.cfg.Subject, payload)
+		cancel()
+		if err == nil {
+			p.log.Debugf("eventbus: published the %s event of certificate %d", event.Type, event.Height)
+			return
+		}
+		p.log.Warnf("eventbus: error publishing the %s event of certificate %d, retrying in %s: %v",
+			event.Type, event.Height, retryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
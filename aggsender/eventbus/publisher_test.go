@@ -0,0 +1,142 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryBus struct {
+	mu        sync.Mutex
+	published [][]byte
+	subjects  []string
+	failures  int
+	closed    bool
+}
+
+func (m *memoryBus) Publish(_ context.Context, subject string, payload []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("bus unreachable")
+	}
+	m.published = append(m.published, payload)
+	m.subjects = append(m.subjects, subject)
+	return nil
+}
+
+func (m *memoryBus) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func (m *memoryBus) events(t *testing.T) []types.CertificateEvent {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]types.CertificateEvent, 0, len(m.published))
+	for _, payload := range m.published {
+		var event types.CertificateEvent
+		require.NoError(t, json.Unmarshal(payload, &event))
+		res = append(res, event)
+	}
+	return res
+}
+
+func newTestConfig() Config {
+	return Config{
+		Enabled:        true,
+		Backend:        BackendNATS,
+		URL:            "nats://localhost:4222",
+		Subject:        "aggsender.certificates",
+		BufferSize:     10,
+		RequestTimeout: configtypes.NewDuration(time.Second),
+		RetryInterval:  configtypes.NewDuration(time.Millisecond),
+	}
+}
+
+func TestPublisherPublishesInOrder(t *testing.T) {
+	bus := &memoryBus{failures: 2}
+	sut := NewWithBus(log.WithFields("test", "eventbus"), newTestConfig(), 7, bus)
+	header := &types.CertificateHeader{Height: 3, FromBlock: 10, ToBlock: 20}
+
+	sut.Publish(types.NewCertificateEvent(types.CertificateEventBuilt, header, time.Now()))
+	sut.Publish(types.NewCertificateEvent(types.CertificateEventSubmitted, header, time.Now()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		sut.Start(ctx)
+		close(done)
+	}()
+	// the failed publications are retried
+	require.Eventually(t, func() bool { return len(bus.events(t)) == 2 }, 5*time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	events := bus.events(t)
+	require.Equal(t, types.CertificateEventBuilt, events[0].Type)
+	require.Equal(t, types.CertificateEventSubmitted, events[1].Type)
+	require.Equal(t, uint32(7), events[0].NetworkID)
+	require.Equal(t, uint64(3), events[0].Height)
+	require.Equal(t, types.CertificateEventSchemaVersion, events[0].SchemaVersion)
+	require.Equal(t, "aggsender.certificates", bus.subjects[0])
+	require.True(t, bus.closed)
+}
+
+func TestPublisherDropsEventsWhenQueueIsFull(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.BufferSize = 1
+	bus := &memoryBus{}
+	sut := NewWithBus(log.WithFields("test", "eventbus"), cfg, 7, bus)
+
+	sut.Publish(types.CertificateEvent{Type: types.CertificateEventBuilt, Height: 1})
+	// the queue is full, so it doesn't block
+	sut.Publish(types.CertificateEvent{Type: types.CertificateEventBuilt, Height: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sut.Start(ctx)
+	require.Eventually(t, func() bool { return len(bus.events(t)) == 1 }, 5*time.Second, time.Millisecond)
+	require.Equal(t, uint64(1), bus.events(t)[0].Height)
+}
+
+func TestNewPublisher(t *testing.T) {
+	logger := log.WithFields("test", "eventbus")
+
+	sut, err := New(logger, newTestConfig(), 1)
+	require.NoError(t, err)
+	require.IsType(t, &NATSClient{}, sut.bus)
+
+	cfg := newTestConfig()
+	cfg.Backend = BackendRedisStreams
+	cfg.URL = "redis://localhost:6379/2"
+	sut, err = New(logger, cfg, 1)
+	require.NoError(t, err)
+	require.IsType(t, &RedisStreamsClient{}, sut.bus)
+
+	cfg.URL = "nats://localhost:4222"
+	_, err = New(logger, cfg, 1)
+	require.ErrorContains(t, err, "must be redis:// or rediss://")
+
+	cfg = newTestConfig()
+	cfg.Backend = "Kafka"
+	_, err = New(logger, cfg, 1)
+	require.ErrorContains(t, err, "unsupported Backend")
+
+	cfg = newTestConfig()
+	cfg.Subject = ""
+	_, err = New(logger, cfg, 1)
+	require.ErrorContains(t, err, "Subject is required")
+}
@@ -0,0 +1,156 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	redisDefaultPort = "6379"
+	// redisEventField is the field of the stream entries with the JSON of the event
+	redisEventField = "event"
+)
+
+// RedisStreamsClient appends the events to a Redis stream (XADD) using the RESP protocol
+type RedisStreamsClient struct {
+	cfg Config
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStreamsClient creates a new RedisStreamsClient. The connection is opened on the first publication
+func NewRedisStreamsClient(cfg Config) *RedisStreamsClient {
+	return &RedisStreamsClient{cfg: cfg}
+}
+
+// Publish appends the payload to the stream (the subject) in the field event, trimming the stream
+// to approximately MaxLen entries if it's set
+func (r *RedisStreamsClient) Publish(ctx context.Context, subject string, payload []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conn == nil {
+		if err := r.connect(ctx); err != nil {
+			return fmt.Errorf("error connecting to Redis %s: %w", redactURL(r.cfg.URL), err)
+		}
+	}
+	setDeadline(ctx, r.conn)
+	args := []string{"XADD", subject}
+	if r.cfg.MaxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.FormatUint(r.cfg.MaxLen, 10))
+	}
+	args = append(args, "*", redisEventField, string(payload))
+	if _, err := r.command(args...); err != nil {
+		r.closeConn()
+		return fmt.Errorf("error adding the event to the Redis stream %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (r *RedisStreamsClient) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeConn()
+	return nil
+}
+
+// connect opens the connection, authenticating with the credentials of the URL and selecting
+// the database of its path, if any
+func (r *RedisStreamsClient) connect(ctx context.Context) error {
+	u, err := url.Parse(r.cfg.URL)
+	if err != nil {
+		return err
+	}
+	conn, err := dial(ctx, u, redisDefaultPort, strings.EqualFold(u.Scheme, "rediss"))
+	if err != nil {
+		return err
+	}
+	r.conn = conn
+	r.reader = bufio.NewReader(conn)
+	setDeadline(ctx, conn)
+
+	if u.User != nil {
+		args := []string{"AUTH"}
+		if user := u.User.Username(); user != "" {
+			args = append(args, user)
+		}
+		password, _ := u.User.Password()
+		if _, err := r.command(append(args, password)...); err != nil {
+			r.closeConn()
+			return fmt.Errorf("error authenticating: %w", err)
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if _, err := r.command("SELECT", db); err != nil {
+			r.closeConn()
+			return fmt.Errorf("error selecting the database %s: %w", db, err)
+		}
+	}
+	return nil
+}
+
+// command sends the command as a RESP array of bulk strings and returns its reply
+func (r *RedisStreamsClient) command(args ...string) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := r.conn.Write([]byte(b.String())); err != nil {
+		return "", err
+	}
+	return r.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (r *RedisStreamsClient) readReply() (string, error) {
+	line, err := r.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("empty Redis reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", fmt.Errorf("redis error: %s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid Redis bulk string size %s", line[1:])
+		}
+		if size < 0 {
+			return "", nil
+		}
+		buf := make([]byte, size+len("\r\n"))
+		if _, err := io.ReadFull(r.reader, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:size]), nil
+	default:
+		return "", fmt.Errorf("unexpected Redis reply: %s", line)
+	}
+}
+
+// closeConn closes the connection after an error, so the next publication opens a new one
+func (r *RedisStreamsClient) closeConn() {
+	if r.conn == nil {
+		return
+	}
+	_ = r.conn.Close()
+	r.conn = nil
+	r.reader = nil
+}
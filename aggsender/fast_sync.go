@@ -0,0 +1,42 @@
+package aggsender
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agglayer/aggkit/agglayer"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+)
+
+// FastSyncL2Bridge starts the L2 bridge syncer of a fresh node at the last block of the last certificate
+// settled in the agglayer for the network, instead of syncing all the bridges since its initial block. The
+// next certificate starts right after that block, so the bridges until it are not needed by the AggSender.
+// It returns the block the syncer starts at, 0 if there is no settled certificate (it syncs from its initial
+// block)
+func FastSyncL2Bridge(ctx context.Context, logger *log.Logger, agglayerClient agglayer.AgglayerClientInterface,
+	l2BridgeSync types.L2BridgeFastSyncer) (uint64, error) {
+	networkID := l2BridgeSync.OriginNetwork()
+	settledCert, err := agglayerClient.GetLatestSettledCertificateHeader(ctx, networkID)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the last settled certificate of the network %d: %w", networkID, err)
+	}
+	if settledCert == nil {
+		logger.Infof("no settled certificate in the agglayer for the network %d, the L2 bridge syncer "+
+			"starts at its initial block", networkID)
+		return 0, nil
+	}
+	meta, err := types.NewCertificateMetadataFromHash(settledCert.Metadata)
+	if err != nil {
+		return 0, fmt.Errorf("error decoding the metadata of the settled certificate %s: %w",
+			settledCert.CertificateID.Hex(), err)
+	}
+	toBlock := meta.GetToBlock()
+	if err := l2BridgeSync.InitFromLocalExitRoot(ctx, toBlock, settledCert.NewLocalExitRoot); err != nil {
+		return 0, fmt.Errorf("error starting the L2 bridge syncer at the block %d of the settled certificate "+
+			"(height %d): %w", toBlock, settledCert.Height, err)
+	}
+	logger.Infof("the L2 bridge syncer starts at the block %d of the last settled certificate (height %d, "+
+		"local exit root %s)", toBlock, settledCert.Height, settledCert.NewLocalExitRoot.Hex())
+	return toBlock, nil
+}
@@ -0,0 +1,106 @@
+package aggsender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+)
+
+const (
+	feeBudgetPeriodEpoch = "epoch"
+	feeBudgetPeriodDay   = "day"
+	feeBudgetDay         = 24 * time.Hour
+)
+
+// ErrFeeBudgetExhausted is returned when sending a certificate would exceed the fee budget
+var ErrFeeBudgetExhausted = errors.New("certificate fee budget exhausted")
+
+// feeBudget estimates the fee of each certificate from the config and keeps the fees spent under the
+// budget per epoch and per day. The fees of the certificates sent are stored, so the budget is kept
+// across restarts
+type feeBudget struct {
+	log           aggkitcommon.Logger
+	cfg           types.FeeBudgetConfig
+	storage       db.AggSenderStorage
+	epochNotifier types.EpochNotifier
+}
+
+// newFeeBudget returns nil if the fee budget is disabled
+func newFeeBudget(logger aggkitcommon.Logger, cfg types.FeeBudgetConfig, storage db.AggSenderStorage,
+	epochNotifier types.EpochNotifier) *feeBudget {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &feeBudget{
+		log:           logger,
+		cfg:           cfg,
+		storage:       storage,
+		epochNotifier: epochNotifier,
+	}
+}
+
+// CheckFeeBudget checks the fee of the certificate of the build params against the budget of the current
+// epoch. It's called by the flows before proving and signing the certificate
+func (b *feeBudget) CheckFeeBudget(ctx context.Context, buildParams *types.CertificateBuildParams) error {
+	if b == nil {
+		return nil
+	}
+	return b.check(buildParams.NumberOfBridges()+buildParams.NumberOfClaims(), buildParams.String(),
+		b.epochNotifier.GetEpochStatus().Epoch, time.Now())
+}
+
+// check estimates the fee of a certificate with the given exits and returns an ErrFeeBudgetExhausted
+// error if sending it in the given epoch would exceed the budget of the epoch or of the last 24 hours
+func (b *feeBudget) check(exits int, cert string, epoch uint64, now time.Time) error {
+	fee := b.cfg.EstimateFee(exits)
+	metrics.CertificateEstimatedFee(fee)
+
+	dayStart := uint32(now.Add(-feeBudgetDay).UTC().Unix())
+	dayFees, epochFees, err := b.storage.GetCertificateFeesSpent(dayStart, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting the fees spent: %w", err)
+	}
+	metrics.FeesSpent(epochFees, dayFees)
+	b.log.Infof("certificate %s estimated fee: %d. Spent in epoch %d: %d/%d, in the last day: %d/%d",
+		cert, fee, epoch, epochFees, b.cfg.MaxFeePerEpoch, dayFees, b.cfg.MaxFeePerDay)
+
+	if b.cfg.MaxFeePerEpoch > 0 && epochFees+fee > b.cfg.MaxFeePerEpoch {
+		metrics.FeeBudgetExhausted(feeBudgetPeriodEpoch)
+		return fmt.Errorf("%w: the certificate %s (fee %d) exceeds the budget of the epoch %d "+
+			"(spent %d, MaxFeePerEpoch %d). Its bridges are going to be included in the next certificate",
+			ErrFeeBudgetExhausted, cert, fee, epoch, epochFees, b.cfg.MaxFeePerEpoch)
+	}
+	if b.cfg.MaxFeePerDay > 0 && dayFees+fee > b.cfg.MaxFeePerDay {
+		metrics.FeeBudgetExhausted(feeBudgetPeriodDay)
+		return fmt.Errorf("%w: the certificate %s (fee %d) exceeds the budget of the last day "+
+			"(spent %d, MaxFeePerDay %d). Its bridges are going to be included in the next certificate",
+			ErrFeeBudgetExhausted, cert, fee, dayFees, b.cfg.MaxFeePerDay)
+	}
+	return nil
+}
+
+// record saves the fee of a certificate sent to the agglayer, estimated from its exits. An error is
+// only logged, because the certificate is already sent
+func (b *feeBudget) record(ctx context.Context, header *types.CertificateHeader,
+	cert *agglayertypes.Certificate, epoch uint64, now time.Time) {
+	fee := &db.CertificateFee{
+		Height:        header.Height,
+		RetryCount:    header.RetryCount,
+		CertificateID: header.CertificateID,
+		Epoch:         epoch,
+		Fee:           b.cfg.EstimateFee(len(cert.BridgeExits) + len(cert.ImportedBridgeExits)),
+		FeeSource:     types.CertificateFeeSourceConfig,
+		SentAt:        uint32(now.UTC().Unix()),
+	}
+	if err := b.storage.SaveCertificateFee(ctx, fee); err != nil {
+		b.log.Errorf("error saving the fee of the certificate %s, it's not counted in the budget: %v",
+			header.ID(), err)
+	}
+}
@@ -0,0 +1,142 @@
+package flows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/healthcheck"
+)
+
+const (
+	dataAvailabilityResultAvailable   = "available"
+	dataAvailabilityResultPartial     = "partial"
+	dataAvailabilityResultUnavailable = "unavailable"
+	dataAvailabilityResultFailed      = "failed"
+
+	// maxDataAvailabilityResponseBody is the maximum number of bytes of an error answer of the DA endpoint logged
+	maxDataAvailabilityResponseBody = 512
+)
+
+// dataAvailabilityRequest is the JSON body posted to the DA endpoint
+type dataAvailabilityRequest struct {
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+}
+
+// dataAvailabilityResponse is the JSON answer of the DA endpoint. AttestedToBlock is the last block of the
+// requested range whose data is attested (nil if the data of from_block is not attested yet)
+type dataAvailabilityResponse struct {
+	AttestedToBlock *uint64  `json:"attested_to_block"`
+	References      []string `json:"references"`
+}
+
+// httpDataAvailabilityVerifier verifies the data availability of the block ranges against an HTTP endpoint,
+// e.g. a DA committee or a bridge to an availability layer
+type httpDataAvailabilityVerifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+var _ types.DataAvailabilityVerifier = (*httpDataAvailabilityVerifier)(nil)
+
+// newDataAvailabilityVerifier returns nil if the data availability check is disabled
+func newDataAvailabilityVerifier(cfg types.DataAvailabilityConfig) types.DataAvailabilityVerifier {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &httpDataAvailabilityVerifier{
+		url:        cfg.URL,
+		httpClient: &http.Client{Timeout: cfg.Timeout.Duration},
+	}
+}
+
+// VerifyDataAvailability asks the DA endpoint for the attestations of the block range
+func (v *httpDataAvailabilityVerifier) VerifyDataAvailability(ctx context.Context,
+	fromBlock, toBlock uint64) (*types.DataAvailabilityAttestation, error) {
+	response, err := v.request(ctx, dataAvailabilityRequest{FromBlock: fromBlock, ToBlock: toBlock})
+	healthcheck.RecordResult("data_availability", err)
+	if err != nil {
+		return nil, err
+	}
+	if response.AttestedToBlock == nil || *response.AttestedToBlock < fromBlock {
+		return nil, nil
+	}
+	if len(response.References) == 0 {
+		return nil, fmt.Errorf("%w: the DA endpoint attested the blocks %d-%d without references",
+			types.ErrInvalidDataAvailabilityAttestation, fromBlock, *response.AttestedToBlock)
+	}
+	return &types.DataAvailabilityAttestation{
+		FromBlock:  fromBlock,
+		ToBlock:    min(*response.AttestedToBlock, toBlock),
+		References: response.References,
+	}, nil
+}
+
+// request posts the block range to the DA endpoint and decodes its answer
+func (v *httpDataAvailabilityVerifier) request(ctx context.Context,
+	request dataAvailabilityRequest) (*dataAvailabilityResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the data availability request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating the data availability request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending the data availability request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxDataAvailabilityResponseBody))
+		return nil, fmt.Errorf("the DA endpoint answered with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	var response dataAvailabilityResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("error decoding the response of the DA endpoint: %w", err)
+	}
+	return &response, nil
+}
+
+// verifyDataAvailability checks the data availability of the blocks to prove (from lastProvenBlock + 1) and
+// reduces the range of the certificate to the attested blocks. It returns nil build params if the data of
+// the first block is not attested yet
+func (a *AggchainProverFlow) verifyDataAvailability(ctx context.Context, lastProvenBlock uint64,
+	buildParams *types.CertificateBuildParams) (*types.CertificateBuildParams, *types.DataAvailabilityAttestation,
+	error) {
+	attestation, err := a.dataAvailabilityVerifier.VerifyDataAvailability(ctx, lastProvenBlock+1, buildParams.ToBlock)
+	if err != nil {
+		metrics.DataAvailabilityCheck(dataAvailabilityResultFailed)
+		return nil, nil, fmt.Errorf("aggchainProverFlow - error verifying the data availability of the blocks %d-%d: %w",
+			lastProvenBlock+1, buildParams.ToBlock, err)
+	}
+	if attestation == nil || attestation.ToBlock < buildParams.FromBlock {
+		metrics.DataAvailabilityCheck(dataAvailabilityResultUnavailable)
+		a.log.Infof("aggchainProverFlow - the data of the blocks %d-%d is not available yet, waiting for the "+
+			"attestations", lastProvenBlock+1, buildParams.ToBlock)
+		return nil, nil, nil
+	}
+	if attestation.ToBlock < buildParams.ToBlock {
+		metrics.DataAvailabilityCheck(dataAvailabilityResultPartial)
+		a.log.Infof("aggchainProverFlow - the data is only attested up to block %d, the certificate is reduced "+
+			"from blocks %d-%d", attestation.ToBlock, buildParams.FromBlock, buildParams.ToBlock)
+		previousToBlock := buildParams.ToBlock
+		buildParams, err = buildParams.Range(buildParams.FromBlock, attestation.ToBlock)
+		if err != nil {
+			return nil, nil, fmt.Errorf("aggchainProverFlow - error reducing the certificate to the attested blocks: %w",
+				err)
+		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonDataAvailability)
+		return buildParams, attestation, nil
+	}
+	metrics.DataAvailabilityCheck(dataAvailabilityResultAvailable)
+	return buildParams, attestation, nil
+}
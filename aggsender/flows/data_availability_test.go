@@ -0,0 +1,199 @@
+package flows
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataAvailabilityVerifier(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newDataAvailabilityVerifier(types.DataAvailabilityConfig{}))
+	require.NotNil(t, newDataAvailabilityVerifier(types.DataAvailabilityConfig{Enabled: true, URL: "http://da"}))
+}
+
+func TestHTTPDataAvailabilityVerifier(t *testing.T) {
+	t.Parallel()
+
+	attestedTo := func(block uint64) *uint64 { return &block }
+
+	tests := []struct {
+		name                string
+		status              int
+		response            any
+		expectedAttestation *types.DataAvailabilityAttestation
+		expectedErr         string
+	}{
+		{
+			name:     "whole range attested",
+			status:   http.StatusOK,
+			response: dataAvailabilityResponse{AttestedToBlock: attestedTo(20), References: []string{"0x01", "0x02"}},
+			expectedAttestation: &types.DataAvailabilityAttestation{
+				FromBlock: 10, ToBlock: 20, References: []string{"0x01", "0x02"},
+			},
+		},
+		{
+			name:     "part of the range attested",
+			status:   http.StatusOK,
+			response: dataAvailabilityResponse{AttestedToBlock: attestedTo(15), References: []string{"0x01"}},
+			expectedAttestation: &types.DataAvailabilityAttestation{
+				FromBlock: 10, ToBlock: 15, References: []string{"0x01"},
+			},
+		},
+		{
+			name:     "attested beyond the range",
+			status:   http.StatusOK,
+			response: dataAvailabilityResponse{AttestedToBlock: attestedTo(30), References: []string{"0x01"}},
+			expectedAttestation: &types.DataAvailabilityAttestation{
+				FromBlock: 10, ToBlock: 20, References: []string{"0x01"},
+			},
+		},
+		{
+			name:     "nothing attested",
+			status:   http.StatusOK,
+			response: dataAvailabilityResponse{},
+		},
+		{
+			name:     "attested before the range",
+			status:   http.StatusOK,
+			response: dataAvailabilityResponse{AttestedToBlock: attestedTo(9), References: []string{"0x01"}},
+		},
+		{
+			name:        "attested without references",
+			status:      http.StatusOK,
+			response:    dataAvailabilityResponse{AttestedToBlock: attestedTo(20)},
+			expectedErr: "without references",
+		},
+		{
+			name:        "error status",
+			status:      http.StatusInternalServerError,
+			response:    "committee unavailable",
+			expectedErr: "answered with status 500",
+		},
+		{
+			name:        "invalid body",
+			status:      http.StatusOK,
+			response:    "not an object",
+			expectedErr: "error decoding the response of the DA endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request dataAvailabilityRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+				require.Equal(t, dataAvailabilityRequest{FromBlock: 10, ToBlock: 20}, request)
+				w.WriteHeader(tt.status)
+				require.NoError(t, json.NewEncoder(w).Encode(tt.response))
+			}))
+			defer server.Close()
+
+			verifier := newDataAvailabilityVerifier(types.DataAvailabilityConfig{
+				Enabled: true,
+				URL:     server.URL,
+				Timeout: configtypes.NewDuration(time.Second),
+			})
+			attestation, err := verifier.VerifyDataAvailability(context.Background(), 10, 20)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAttestation, attestation)
+		})
+	}
+}
+
+func Test_AggchainProverFlow_verifyDataAvailability(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newBuildParams := func() *types.CertificateBuildParams {
+		return &types.CertificateBuildParams{
+			FromBlock: 11,
+			ToBlock:   20,
+			Bridges:   []bridgesync.Bridge{{BlockNum: 12}, {BlockNum: 18}},
+			Claims:    []bridgesync.Claim{{BlockNum: 19}},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		attestation         *types.DataAvailabilityAttestation
+		verifyErr           error
+		expectedParams      *types.CertificateBuildParams
+		expectedAttestation *types.DataAvailabilityAttestation
+		expectedTruncation  bool
+		expectedErr         string
+	}{
+		{
+			name:                "whole range attested",
+			attestation:         &types.DataAvailabilityAttestation{FromBlock: 11, ToBlock: 20, References: []string{"0x01"}},
+			expectedParams:      newBuildParams(),
+			expectedAttestation: &types.DataAvailabilityAttestation{FromBlock: 11, ToBlock: 20, References: []string{"0x01"}},
+		},
+		{
+			name:        "range reduced to the attested blocks",
+			attestation: &types.DataAvailabilityAttestation{FromBlock: 11, ToBlock: 15, References: []string{"0x01"}},
+			expectedParams: &types.CertificateBuildParams{
+				FromBlock: 11,
+				ToBlock:   15,
+				Bridges:   []bridgesync.Bridge{{BlockNum: 12}},
+				Claims:    []bridgesync.Claim{},
+			},
+			expectedAttestation: &types.DataAvailabilityAttestation{FromBlock: 11, ToBlock: 15, References: []string{"0x01"}},
+			expectedTruncation:  true,
+		},
+		{
+			name: "data not available yet",
+		},
+		{
+			name:        "error verifying the data availability",
+			verifyErr:   errors.New("committee unavailable"),
+			expectedErr: "error verifying the data availability of the blocks 11-20: committee unavailable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockVerifier := mocks.NewDataAvailabilityVerifier(t)
+			mockVerifier.EXPECT().VerifyDataAvailability(ctx, uint64(11), uint64(20)).Return(tt.attestation, tt.verifyErr)
+			flow := &AggchainProverFlow{
+				log:                      log.WithFields("test", t.Name()),
+				dataAvailabilityVerifier: mockVerifier,
+			}
+
+			params, attestation, err := flow.verifyDataAvailability(ctx, 10, newBuildParams())
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedTruncation {
+				require.Len(t, params.AuditTrail, 1)
+				require.Equal(t, types.CertificateAuditRangeTruncated, params.AuditTrail[0].Event)
+				require.Equal(t, types.CertificateAuditReasonDataAvailability, params.AuditTrail[0].Details["reason"])
+				params.AuditTrail = nil
+			}
+			require.Equal(t, tt.expectedParams, params)
+			require.Equal(t, tt.expectedAttestation, attestation)
+		})
+	}
+}
@@ -4,16 +4,17 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/aggchainfep"
 	"github.com/agglayer/aggkit/aggsender/aggchainproofclient"
 	"github.com/agglayer/aggkit/aggsender/config"
 	"github.com/agglayer/aggkit/aggsender/db"
 	"github.com/agglayer/aggkit/aggsender/optimistic"
 	"github.com/agglayer/aggkit/aggsender/query"
+	"github.com/agglayer/aggkit/aggsender/signerreload"
 	"github.com/agglayer/aggkit/aggsender/types"
 	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/log"
 	aggkittypes "github.com/agglayer/aggkit/types"
-	"github.com/agglayer/go_signer/signer"
 	signerTypes "github.com/agglayer/go_signer/signer/types"
 )
 
@@ -31,10 +32,30 @@ func NewFlow(
 	l1InfoTreeSyncer types.L1InfoTreeSyncer,
 	l2Syncer types.L2BridgeSyncer,
 	rollupDataQuerier types.RollupDataQuerier,
+	feeBudget types.CertificateFeeBudget,
 ) (types.AggsenderFlow, error) {
+	if err := cfg.HardForks.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid HardForks config: %w", err)
+	}
+	if err := cfg.CertificateCustomFields.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid CertificateCustomFields config: %w", err)
+	}
+	if err := cfg.CertificateTags.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid CertificateTags config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	l2ChainHalt := L2ChainHaltConfig{
+		IdleThreshold:      cfg.L2IdleThreshold.Duration,
+		HaltAlertThreshold: cfg.L2HaltAlertThreshold.Duration,
+	}
+	if err := l2ChainHalt.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid L2 chain halt config: %w", err)
+	}
 	switch types.AggsenderMode(cfg.Mode) {
 	case types.PessimisticProofMode:
-		signer, err := initializeSigner(ctx, cfg.AggsenderPrivateKey, logger)
+		signer, err := initializeSigner(ctx, cfg, l1Client, logger, true)
 		if err != nil {
 			return nil, err
 		}
@@ -46,13 +67,15 @@ func NewFlow(
 			return nil, fmt.Errorf("error creating LER data querier: %w", err)
 		}
 
-		l2BridgeQuerier := query.NewBridgeDataQuerier(logger, l2Syncer, cfg.DelayBetweenRetries.Duration)
+		l2BridgeQuerier := newTokenPolicyBridgeQuerier(logger, query.NewBridgeDataQuerier(logger, l2Syncer,
+			cfg.DelayBetweenRetries.Duration, cfg.MaxBlocksPerBridgeQuery), cfg.TokenPolicy)
 		l1InfoTreeQuerier := query.NewL1InfoTreeDataQuerier(l1Client, l1InfoTreeSyncer)
 		logger.Infof("Aggsender signer address: %s", signer.PublicAddress().Hex())
 		baseFlow := NewBaseFlow(
 			logger, l2BridgeQuerier, storage, l1InfoTreeQuerier, lerQuerier,
-			NewBaseFlowConfig(cfg.MaxCertSize, 0, false),
+			NewBaseFlowConfig(cfg.MaxCertSize, 0, false, cfg.MaxConcurrentClaimProofs, l2ChainHalt),
 		)
+		baseFlow.feeBudget = feeBudget
 		return NewPPFlow(
 			logger,
 			baseFlow,
@@ -61,14 +84,12 @@ func NewFlow(
 			l2BridgeQuerier,
 			signer,
 			cfg.RequireOneBridgeInPPCertificate,
+			cfg.HeartbeatCertificateInterval.Duration,
 			cfg.MaxL2BlockNumber,
+			cfg.HardForks,
 		), nil
 	case types.AggchainProofMode:
-		if err := cfg.AggkitProverClient.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid aggkit prover client config: %w", err)
-		}
-
-		signer, err := initializeSigner(ctx, cfg.AggsenderPrivateKey, logger)
+		signer, err := initializeSigner(ctx, cfg, l1Client, logger, false)
 		if err != nil {
 			return nil, err
 		}
@@ -102,15 +123,19 @@ func NewFlow(
 			return nil, fmt.Errorf("error creating LER data querier: %w", err)
 		}
 
-		l2BridgeQuerier := query.NewBridgeDataQuerier(logger, l2Syncer, cfg.DelayBetweenRetries.Duration)
+		l2BridgeQuerier := newTokenPolicyBridgeQuerier(logger, query.NewBridgeDataQuerier(logger, l2Syncer,
+			cfg.DelayBetweenRetries.Duration, cfg.MaxBlocksPerBridgeQuery), cfg.TokenPolicy)
 		baseFlow := NewBaseFlow(
 			logger, l2BridgeQuerier, storage, l1InfoTreeQuerier, lerQuerier,
-			NewBaseFlowConfig(cfg.MaxCertSize, startL2Block, cfg.RequireNoFEPBlockGap),
+			NewBaseFlowConfig(cfg.MaxCertSize, startL2Block, cfg.RequireNoFEPBlockGap,
+				cfg.MaxConcurrentClaimProofs, l2ChainHalt),
 		)
+		baseFlow.feeBudget = feeBudget
 
 		return NewAggchainProverFlow(
 			logger,
-			NewAggchainProverFlowConfig(cfg.MaxL2BlockNumber),
+			NewAggchainProverFlowConfig(cfg.MaxL2BlockNumber, cfg.HardForks, cfg.CertificateCustomFields,
+				cfg.CertificateTags, cfg.DataAvailability),
 			baseFlow,
 			aggchainProofClient,
 			storage,
@@ -128,19 +153,34 @@ func NewFlow(
 	}
 }
 
+// initializeSigner creates the signer of the certificates. In PessimisticProof mode the certificates are
+// signed by the trustedSequencer of the rollup contract, so a reloaded key can be validated against it. In
+// AggchainProof mode the trustedSequencer is the optimistic signer (validated with its own SignerReload), so
+// the reloaded key must keep the address of the active key
 func initializeSigner(
 	ctx context.Context,
-	signerCfg signerTypes.SignerConfig,
+	cfg config.Config,
+	l1Client aggkittypes.BaseEthereumClienter,
 	logger *log.Logger,
+	signsAsTrustedSequencer bool,
 ) (signerTypes.Signer, error) {
-	signer, err := signer.NewSigner(ctx, 0, signerCfg, aggkitcommon.AGGSENDER, logger)
-	if err != nil {
-		return nil, fmt.Errorf("error NewSigner. Err: %w", err)
+	var trustedSequencer signerreload.TrustedSequencerQuerier
+	if signsAsTrustedSequencer {
+		rollupContract, err := aggchainfep.NewAggchainfep(cfg.SovereignRollupAddr, l1Client)
+		if err != nil {
+			return nil, fmt.Errorf("error creating the rollup contract %s: %w", cfg.SovereignRollupAddr, err)
+		}
+		trustedSequencer = rollupContract
+	} else if cfg.SignerReload.RequireKeyMatchTrustedSequencer {
+		return nil, fmt.Errorf("SignerReload.RequireKeyMatchTrustedSequencer is not supported in %s mode, "+
+			"the trustedSequencer is the optimistic signer (see OptimisticModeConfig.SignerReload)", cfg.Mode)
 	}
-
-	if err := signer.Initialize(ctx); err != nil {
-		return nil, fmt.Errorf("error signer.Initialize. Err: %w", err)
+	signer, err := signerreload.NewReloadableSigner(ctx, aggkitcommon.AGGSENDER, logger,
+		cfg.AggsenderPrivateKey, cfg.SignerReload, trustedSequencer)
+	if err != nil {
+		return nil, err
 	}
+	go signer.Start(ctx)
 
 	return signer, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/agglayer/aggkit/aggsender/config"
 	"github.com/agglayer/aggkit/aggsender/mocks"
 	"github.com/agglayer/aggkit/aggsender/optimistic"
+	"github.com/agglayer/aggkit/aggsender/signerreload"
 	"github.com/agglayer/aggkit/aggsender/types"
 	cfgtypes "github.com/agglayer/aggkit/config/types"
 	aggkitgrpc "github.com/agglayer/aggkit/grpc"
@@ -58,7 +59,9 @@ func TestNewFlow(t *testing.T) {
 				AggsenderPrivateKey: signertypes.SignerConfig{
 					Method: signertypes.MethodLocal,
 				},
-				AggkitProverClient: aggkitgrpc.DefaultConfig(),
+				AggkitProverClient:   aggkitgrpc.DefaultConfig(),
+				GlobalExitRootL2Addr: common.HexToAddress("0x1"),
+				OptimisticModeConfig: optimistic.Config{TrustedSequencerKey: keyConfig},
 			},
 			expectedError: "error signer.Initialize",
 		},
@@ -70,6 +73,56 @@ func TestNewFlow(t *testing.T) {
 			},
 			expectedError: "invalid aggkit prover client config: gRPC client configuration cannot be nil",
 		},
+		{
+			name: "error invalid HardForks",
+			cfg: config.Config{
+				Mode:      string(types.PessimisticProofMode),
+				HardForks: types.HardForks{{Name: "fork1", BlockNumber: 200}, {Name: "fork2", BlockNumber: 100}},
+			},
+			expectedError: "invalid HardForks config",
+		},
+		{
+			name: "error invalid CertificateCustomFields",
+			cfg: config.Config{
+				Mode:                    string(types.AggchainProofMode),
+				CertificateCustomFields: types.CertificateCustomFields{"Operator": "acme"},
+			},
+			expectedError: "invalid CertificateCustomFields config",
+		},
+		{
+			name: "error invalid CertificateTags",
+			cfg: config.Config{
+				Mode:            string(types.PessimisticProofMode),
+				CertificateTags: types.CertificateTags{types.CertificateTagEnvironment: ""},
+			},
+			expectedError: "invalid CertificateTags config",
+		},
+		{
+			name: "error heartbeat certificates with RequireOneBridgeInPPCertificate",
+			cfg: config.Config{
+				Mode:                            string(types.PessimisticProofMode),
+				RequireOneBridgeInPPCertificate: true,
+				HeartbeatCertificateInterval:    cfgtypes.NewDuration(time.Hour),
+			},
+			expectedError: "HeartbeatCertificateInterval can't be used with RequireOneBridgeInPPCertificate",
+		},
+		{
+			name: "error FEP knob in PessimisticProofMode",
+			cfg: config.Config{
+				Mode:                 string(types.PessimisticProofMode),
+				RequireNoFEPBlockGap: true,
+			},
+			expectedError: "RequireNoFEPBlockGap is only used in AggchainProof mode",
+		},
+		{
+			name: "error missing optimistic signer in AggchainProofMode",
+			cfg: config.Config{
+				Mode:                 string(types.AggchainProofMode),
+				AggkitProverClient:   aggkitgrpc.DefaultConfig(),
+				GlobalExitRootL2Addr: common.HexToAddress("0x1"),
+			},
+			expectedError: "OptimisticModeConfig.TrustedSequencerKey is required in AggchainProof mode",
+		},
 		{
 			name: "unsupported Aggsender mode",
 			cfg: config.Config{
@@ -86,6 +139,7 @@ func TestNewFlow(t *testing.T) {
 					URL:               "http://127.0.0.1",
 					MinConnectTimeout: cfgtypes.Duration{Duration: 1 * time.Millisecond},
 				},
+				GlobalExitRootL2Addr: common.HexToAddress("0x1"),
 				OptimisticModeConfig: optimistic.Config{
 					TrustedSequencerKey:             keyConfig,
 					RequireKeyMatchTrustedSequencer: true,
@@ -93,6 +147,20 @@ func TestNewFlow(t *testing.T) {
 			},
 			expectedError: "error aggchainFEPContract",
 		},
+		{
+			name: "the aggsender signer can't be validated against the trustedSequencer in AggchainProofMode",
+			cfg: config.Config{
+				Mode:                 string(types.AggchainProofMode),
+				AggsenderPrivateKey:  keyConfig,
+				SignerReload:         signerreload.Config{RequireKeyMatchTrustedSequencer: true},
+				AggkitProverClient:   aggkitgrpc.DefaultConfig(),
+				GlobalExitRootL2Addr: common.HexToAddress("0x1"),
+				OptimisticModeConfig: optimistic.Config{
+					TrustedSequencerKey: keyConfig,
+				},
+			},
+			expectedError: "SignerReload.RequireKeyMatchTrustedSequencer is not supported in AggchainProof mode",
+		},
 	}
 	funcNewEVMChainGERReader = func(_ common.Address, _ aggkittypes.BaseEthereumClienter) (*chaingerreader.EVMChainGERReader, error) {
 		return &chaingerreader.EVMChainGERReader{}, nil
@@ -129,6 +197,7 @@ func TestNewFlow(t *testing.T) {
 				mockL1InfoTreeSyncer,
 				mockL2BridgeSyncer,
 				mockRollupDataQuerier,
+				nil,
 			)
 
 			if tc.expectedError != "" {
@@ -0,0 +1,71 @@
+package flows
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+// ErrPPSettledBeyondFEPStart is returned when the PessimisticProof certificates settled
+// blocks beyond the FEP starting block, so the FEP proofs would re-prove an already settled range
+var ErrPPSettledBeyondFEPStart = errors.New("PP certificates settled beyond the FEP starting block")
+
+// fepMigrationBoundary describes the boundary between the last range settled using
+// PessimisticProof certificates and the first FEP certificate of a chain that upgraded mid-history
+type fepMigrationBoundary struct {
+	// LastPPSettledBlock is the last L2 block settled by a PP certificate
+	LastPPSettledBlock uint64
+	// StartL2Block is the FEP starting block (read from the aggchainFEP contract)
+	StartL2Block uint64
+}
+
+// newFEPMigrationBoundary returns the migration boundary if the last sent certificate
+// belongs to the PP history of the chain, otherwise it returns nil (no migration in progress)
+func newFEPMigrationBoundary(lastSentCert *types.CertificateHeader, startL2Block uint64) *fepMigrationBoundary {
+	if lastSentCert == nil || lastSentCert.CertType != types.CertificateTypePP {
+		return nil
+	}
+
+	switch {
+	case lastSentCert.Status.IsSettled():
+		return &fepMigrationBoundary{
+			LastPPSettledBlock: lastSentCert.ToBlock,
+			StartL2Block:       startL2Block,
+		}
+	case lastSentCert.Status.IsInError() && lastSentCert.FromBlock > 0:
+		// the InError PP certificate is going to be replaced by a FEP one,
+		// so the last settled PP block is the one before its range
+		return &fepMigrationBoundary{
+			LastPPSettledBlock: lastSentCert.FromBlock - 1,
+			StartL2Block:       startL2Block,
+		}
+	default:
+		// no PP certificate settled yet or the last one is still open
+		return nil
+	}
+}
+
+// Validate checks that the FEP starting block is not inside the range already settled by PP certificates
+func (b *fepMigrationBoundary) Validate() error {
+	if b.LastPPSettledBlock > b.StartL2Block {
+		return fmt.Errorf("%w: last PP settled block: %d, FEP startL2Block: %d",
+			ErrPPSettledBeyondFEPStart, b.LastPPSettledBlock, b.StartL2Block)
+	}
+
+	return nil
+}
+
+// LastProvenBlock returns the last proven block for the first FEP certificate
+func (b *fepMigrationBoundary) LastProvenBlock() uint64 {
+	return b.StartL2Block
+}
+
+// String returns a string representation of the migration boundary
+func (b *fepMigrationBoundary) String() string {
+	if b == nil {
+		return types.NilStr
+	}
+	return fmt.Sprintf("fepMigrationBoundary{LastPPSettledBlock: %d, StartL2Block: %d}",
+		b.LastPPSettledBlock, b.StartL2Block)
+}
@@ -0,0 +1,124 @@
+package flows
+
+import (
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFEPMigrationBoundary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		lastSentCert     *types.CertificateHeader
+		startL2Block     uint64
+		expectedBoundary *fepMigrationBoundary
+		expectedError    error
+	}{
+		{
+			name:             "no last sent certificate",
+			lastSentCert:     nil,
+			startL2Block:     100,
+			expectedBoundary: nil,
+		},
+		{
+			name: "last sent certificate is FEP",
+			lastSentCert: &types.CertificateHeader{
+				CertType: types.CertificateTypeFEP,
+				Status:   agglayertypes.Settled,
+				ToBlock:  150,
+			},
+			startL2Block:     100,
+			expectedBoundary: nil,
+		},
+		{
+			name: "last sent PP certificate is pending",
+			lastSentCert: &types.CertificateHeader{
+				CertType: types.CertificateTypePP,
+				Status:   agglayertypes.Pending,
+				ToBlock:  90,
+			},
+			startL2Block:     100,
+			expectedBoundary: nil,
+		},
+		{
+			name: "last sent PP certificate is settled before startL2Block",
+			lastSentCert: &types.CertificateHeader{
+				CertType:  types.CertificateTypePP,
+				Status:    agglayertypes.Settled,
+				FromBlock: 80,
+				ToBlock:   90,
+			},
+			startL2Block:     100,
+			expectedBoundary: &fepMigrationBoundary{LastPPSettledBlock: 90, StartL2Block: 100},
+		},
+		{
+			name: "last sent PP certificate is settled on startL2Block",
+			lastSentCert: &types.CertificateHeader{
+				CertType:  types.CertificateTypePP,
+				Status:    agglayertypes.Settled,
+				FromBlock: 80,
+				ToBlock:   100,
+			},
+			startL2Block:     100,
+			expectedBoundary: &fepMigrationBoundary{LastPPSettledBlock: 100, StartL2Block: 100},
+		},
+		{
+			name: "last sent PP certificate is settled beyond startL2Block",
+			lastSentCert: &types.CertificateHeader{
+				CertType:  types.CertificateTypePP,
+				Status:    agglayertypes.Settled,
+				FromBlock: 80,
+				ToBlock:   110,
+			},
+			startL2Block:     100,
+			expectedBoundary: &fepMigrationBoundary{LastPPSettledBlock: 110, StartL2Block: 100},
+			expectedError:    ErrPPSettledBeyondFEPStart,
+		},
+		{
+			name: "last sent PP certificate is InError",
+			lastSentCert: &types.CertificateHeader{
+				CertType:  types.CertificateTypePP,
+				Status:    agglayertypes.InError,
+				FromBlock: 95,
+				ToBlock:   120,
+			},
+			startL2Block:     100,
+			expectedBoundary: &fepMigrationBoundary{LastPPSettledBlock: 94, StartL2Block: 100},
+		},
+		{
+			name: "first PP certificate is InError",
+			lastSentCert: &types.CertificateHeader{
+				CertType:  types.CertificateTypePP,
+				Status:    agglayertypes.InError,
+				FromBlock: 0,
+				ToBlock:   120,
+			},
+			startL2Block:     100,
+			expectedBoundary: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			boundary := newFEPMigrationBoundary(tt.lastSentCert, tt.startL2Block)
+			require.Equal(t, tt.expectedBoundary, boundary)
+			if boundary == nil {
+				return
+			}
+
+			require.Equal(t, tt.startL2Block, boundary.LastProvenBlock())
+			err := boundary.Validate()
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
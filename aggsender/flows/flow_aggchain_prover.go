@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/aggchainfep"
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggoracle/chaingerreader"
 	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/query"
 	"github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	"github.com/agglayer/aggkit/healthcheck"
 	treetypes "github.com/agglayer/aggkit/tree/types"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	signertypes "github.com/agglayer/go_signer/signer/types"
@@ -24,6 +28,10 @@ var errNoProofBuiltYet = &aggkitgrpc.GRPCError{
 	Message: "Proposer service has not built any proof yet",
 }
 
+// maxStaleProofRebuilds is the number of times an aggchain proof is generated again in the same round if the
+// L1 Info tree diverges from its root. If it's still stale, the certificate is retried in the next round
+const maxStaleProofRebuilds = 1
+
 // AggchainProverFlow is a struct that holds the logic for the AggchainProver prover type flow
 type AggchainProverFlow struct {
 	baseFlow types.AggsenderFlowBaser
@@ -40,6 +48,9 @@ type AggchainProverFlow struct {
 	optimisticSigner      types.OptimisticSigner
 	config                AggchainProverFlowConfig
 	featureMaxL2Block     types.MaxL2BlockNumberLimiterInterface
+	featureHardForks      types.HardForkLimiterInterface
+	// dataAvailabilityVerifier is nil if the data availability check is disabled
+	dataAvailabilityVerifier types.DataAvailabilityVerifier
 }
 
 func getL2StartBlock(sovereignRollupAddr common.Address, l1Client aggkittypes.BaseEthereumClienter) (uint64, error) {
@@ -63,6 +74,10 @@ var funcNewEVMChainGERReader = chaingerreader.NewEVMChainGERReader
 // AggchainProverFlowConfig holds the configuration for the AggchainProverFlow
 type AggchainProverFlowConfig struct {
 	maxL2BlockNumber uint64
+	hardForks        types.HardForks
+	customFields     types.CertificateCustomFields
+	tags             types.CertificateTags
+	dataAvailability types.DataAvailabilityConfig
 }
 
 // NewAggchainProverFlowConfigDefault returns a default configuration for the AggchainProverFlow
@@ -74,9 +89,17 @@ func NewAggchainProverFlowConfigDefault() AggchainProverFlowConfig {
 
 // NewAggchainProverFlowConfig creates a new AggchainProverFlowConfig with the given base flow config
 func NewAggchainProverFlowConfig(
-	maxL2BlockNumber uint64) AggchainProverFlowConfig {
+	maxL2BlockNumber uint64,
+	hardForks types.HardForks,
+	customFields types.CertificateCustomFields,
+	tags types.CertificateTags,
+	dataAvailability types.DataAvailabilityConfig) AggchainProverFlowConfig {
 	return AggchainProverFlowConfig{
 		maxL2BlockNumber: maxL2BlockNumber,
+		hardForks:        hardForks,
+		customFields:     customFields,
+		tags:             tags,
+		dataAvailability: dataAvailability,
 	}
 }
 
@@ -115,9 +138,25 @@ func NewAggchainProverFlow(
 		optimisticSigner:      optimisticSigner,
 		baseFlow:              baseFlow,
 		featureMaxL2Block:     feature,
+		featureHardForks: NewHardForkLimiter(
+			aggChainProverConfig.hardForks,
+			log,
+			false, // AggchainProverFlow doesn't allow to resize retry certs
+		),
+		dataAvailabilityVerifier: newDataAvailabilityVerifier(aggChainProverConfig.dataAvailability),
 	}
 }
 
+// SignerAddress returns the address of the signer of the certificates
+func (a *AggchainProverFlow) SignerAddress() common.Address {
+	return a.certificateSigner.PublicAddress()
+}
+
+// L2ChainStatus returns whether the L2 chain is active, idle or halted
+func (a *AggchainProverFlow) L2ChainStatus() *types.L2ChainStatus {
+	return a.baseFlow.L2ChainStatus()
+}
+
 // CheckInitialStatus checks that initial status is correct.
 // For AggchainProverFlow checks that starting block and last certificate match
 func (a *AggchainProverFlow) CheckInitialStatus(ctx context.Context) error {
@@ -130,6 +169,15 @@ func (a *AggchainProverFlow) CheckInitialStatus(ctx context.Context) error {
 	// if there are gaps with bridge transactions, we can not allow the start of aggsender
 	startL2Block := a.baseFlow.StartL2Block()
 
+	// if the chain upgraded from PP to FEP, the FEP starting block can't be inside
+	// the range already settled by PP certificates
+	if boundary := newFEPMigrationBoundary(lastSentCertificate, startL2Block); boundary != nil {
+		a.log.Infof("aggchainProverFlow - PP to FEP migration detected: %s", boundary.String())
+		if err := boundary.Validate(); err != nil {
+			return fmt.Errorf("aggchainProverFlow - invalid PP to FEP migration boundary: %w", err)
+		}
+	}
+
 	// we need to wait for the syncer to catch up to the start L2 block (start FEP block)
 	// in order to check if there are any bridge transactions in the gap
 	if err := a.l2BridgeQuerier.WaitForSyncerToCatchUp(ctx, startL2Block); err != nil {
@@ -175,55 +223,56 @@ func (a *AggchainProverFlow) GetCertificateBuildParams(ctx context.Context) (*ty
 
 	if lastSentCert != nil && lastSentCert.Status.IsInError() && lastSentCert.CertType == typeCert {
 		a.log.Infof("resending the same InError certificate: %s", lastSentCert.String())
-		fromBlock := lastSentCert.FromBlock
-		toBlock := lastSentCert.ToBlock
-
-		lastProvenBlock := a.getLastProvenBlock(fromBlock, lastSentCert)
+		lastProvenBlock := a.getLastProvenBlock(lastSentCert.FromBlock, lastSentCert)
 		if lastSentCert.FromBlock != lastProvenBlock+1 {
 			a.log.Warnf("aggchainProverFlow - last sent certificate is InError and its fromBlock: %d doesn't match "+
 				"lastProvenBlock: %d + 1. Check update process 😅", lastSentCert.FromBlock, lastProvenBlock)
 		}
 
-		bridges, claims, err := a.l2BridgeQuerier.GetBridgesAndClaims(
-			ctx, fromBlock,
-			toBlock,
-		)
+		buildParams, err := a.getRetryCertificateBuildParams(ctx, lastSentCert, typeCert)
 		if err != nil {
-			return nil, fmt.Errorf("aggchainProverFlow - error getting bridges and claims: %w", err)
+			return nil, err
 		}
 
-		buildParams := &types.CertificateBuildParams{
-			FromBlock:           fromBlock,
-			ToBlock:             toBlock,
-			RetryCount:          lastSentCert.RetryCount + 1,
-			Bridges:             bridges,
-			Claims:              claims,
-			LastSentCertificate: lastSentCert,
-			CreatedAt:           lastSentCert.CreatedAt,
-			CertificateType:     typeCert,
-		}
-		if a.featureMaxL2Block != nil {
-			// If the feature is enabled, we need to adapt the build params
-			buildParams, err = a.featureMaxL2Block.AdaptCertificate(buildParams)
+		if proof != nil && lastSentCert.FinalizedL1InfoTreeRoot != nil && lastSentCert.L1InfoTreeLeafCount > 0 {
+			err := a.l1InfoTreeDataQuerier.CheckL1InfoTreeRoot(ctx,
+				*lastSentCert.FinalizedL1InfoTreeRoot, lastSentCert.L1InfoTreeLeafCount-1)
+			if err != nil && !errors.Is(err, query.ErrStaleL1InfoTreeRoot) {
+				return nil, fmt.Errorf("aggchainProverFlow - error checking the L1 Info tree root of the aggchain proof: %w",
+					err)
+			}
 			if err != nil {
-				return nil, fmt.Errorf("aggchainProverFlow - error adapting certificate to MaxL2Block.Err: %w", err)
+				// resending it would end InError again, so the proof is generated again against the current root
+				a.log.Warnf("aggchainProverFlow - discarding the aggchain proof of the InError certificate %s: %v",
+					lastSentCert.ID(), err)
+				proof = nil
 			}
 		}
 
 		if proof == nil {
 			// this can happen if the aggsender db was deleted, so the aggsender
 			// got the last sent certificate from agglayer, but in that data we do not have
-			// the aggchain proof that was generated before, so we need to call the prover again
+			// the aggchain proof that was generated before, so we need to call the prover again.
+			// It also happens if the proof was generated against a root that is no longer in the L1 Info tree
 
 			return a.verifyBuildParamsAndGenerateProof(ctx, buildParams)
 		}
 
+		// the proof is reused, but resending the certificate is charged again
+		if err := a.baseFlow.CheckFeeBudget(ctx, buildParams); err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error resending the InError certificate: %w", err)
+		}
+
 		// if we have the aggchain proof, we need to set it in the build params
 		// and set the root from which to prove the imported bridge exits
 		// no need to call the prover again
 		buildParams.AggchainProof = proof
 		buildParams.L1InfoTreeRootFromWhichToProve = *lastSentCert.FinalizedL1InfoTreeRoot
 		buildParams.L1InfoTreeLeafCount = lastSentCert.L1InfoTreeLeafCount
+		buildParams.AddAuditEvent(types.CertificateAuditProofReused, map[string]any{
+			"last_proven_block": proof.LastProvenBlock,
+			"end_block":         proof.EndBlock,
+		})
 
 		return buildParams, nil
 	}
@@ -245,10 +294,27 @@ func (a *AggchainProverFlow) GetCertificateBuildParams(ctx context.Context) (*ty
 	}
 	if a.featureMaxL2Block != nil {
 		// If the feature is enabled, we need to adapt the build params
+		previousToBlock := buildParams.ToBlock
 		buildParams, err = a.featureMaxL2Block.AdaptCertificate(buildParams)
 		if err != nil {
 			return nil, fmt.Errorf("aggchainProverFlow - error adapting certificate to MaxL2Block. Err: %w", err)
 		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonMaxL2BlockNumber)
+	}
+	if a.featureHardForks != nil {
+		previousToBlock := buildParams.ToBlock
+		buildParams, err = a.featureHardForks.AdaptCertificate(buildParams)
+		if err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error adapting certificate to hard forks. Err: %w", err)
+		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonHardFork)
+	}
+
+	if boundary := newFEPMigrationBoundary(lastSentCert, a.baseFlow.StartL2Block()); boundary != nil {
+		// this is the first FEP certificate, so we must not prove any block already settled by PP
+		if err := boundary.Validate(); err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error building first FEP certificate: %w", err)
+		}
 	}
 
 	lastProvenBlock := a.getLastProvenBlock(buildParams.FromBlock, lastSentCert)
@@ -261,6 +327,68 @@ func (a *AggchainProverFlow) GetCertificateBuildParams(ctx context.Context) (*ty
 	return a.verifyBuildParamsAndGenerateProof(ctx, buildParams)
 }
 
+// getRetryCertificateBuildParams returns the build params to resend the InError certificate. They
+// are taken from the snapshot saved when it was sent, so the retry is exactly the same certificate.
+// If there is no snapshot (e.g. it was sent by a previous version), the bridges and claims are queried again
+func (a *AggchainProverFlow) getRetryCertificateBuildParams(ctx context.Context,
+	lastSentCert *types.CertificateHeader, typeCert types.CertificateType) (*types.CertificateBuildParams, error) {
+	snapshot, err := a.storage.GetCertificateBuildParams(lastSentCert.Height)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error getting build params snapshot: %w", err)
+	}
+	if snapshot.Matches(lastSentCert) {
+		buildParams, err := snapshot.ToBuildParams()
+		if err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error decoding build params snapshot: %w", err)
+		}
+		a.log.Infof("aggchainProverFlow - reusing the build params of %s to resend the certificate", snapshot.ID())
+		buildParams.RetryCount = lastSentCert.RetryCount + 1
+		buildParams.LastSentCertificate = lastSentCert
+		buildParams.CreatedAt = lastSentCert.CreatedAt
+		addRetryRangeSelectedAuditEvent(buildParams, true)
+		return buildParams, nil
+	}
+	if snapshot != nil {
+		a.log.Warnf("aggchainProverFlow - the build params snapshot %s doesn't match the last sent certificate %s, "+
+			"querying the bridges and claims again", snapshot.ID(), lastSentCert.ID())
+	}
+
+	bridges, claims, err := a.l2BridgeQuerier.GetBridgesAndClaims(ctx, lastSentCert.FromBlock, lastSentCert.ToBlock)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error getting bridges and claims: %w", err)
+	}
+
+	buildParams := &types.CertificateBuildParams{
+		FromBlock:           lastSentCert.FromBlock,
+		ToBlock:             lastSentCert.ToBlock,
+		RetryCount:          lastSentCert.RetryCount + 1,
+		Bridges:             bridges,
+		Claims:              claims,
+		LastSentCertificate: lastSentCert,
+		CreatedAt:           lastSentCert.CreatedAt,
+		CertificateType:     typeCert,
+	}
+	addRetryRangeSelectedAuditEvent(buildParams, false)
+	if a.featureMaxL2Block != nil {
+		// If the feature is enabled, we need to adapt the build params
+		previousToBlock := buildParams.ToBlock
+		buildParams, err = a.featureMaxL2Block.AdaptCertificate(buildParams)
+		if err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error adapting certificate to MaxL2Block.Err: %w", err)
+		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonMaxL2BlockNumber)
+	}
+	if a.featureHardForks != nil {
+		previousToBlock := buildParams.ToBlock
+		buildParams, err = a.featureHardForks.AdaptCertificate(buildParams)
+		if err != nil {
+			return nil, fmt.Errorf("aggchainProverFlow - error adapting certificate to hard forks. Err: %w", err)
+		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonHardFork)
+	}
+	return buildParams, nil
+}
+
 // verifyBuildParams verifies the certificate build params and returns an error if they are not valid
 // it also calls the prover to get the aggchain proof
 func (a *AggchainProverFlow) verifyBuildParamsAndGenerateProof(
@@ -271,29 +399,111 @@ func (a *AggchainProverFlow) verifyBuildParamsAndGenerateProof(
 
 	lastProvenBlock := a.getLastProvenBlock(buildParams.FromBlock, buildParams.LastSentCertificate)
 
-	aggchainProof, rootFromWhichToProveClaims, err := a.GenerateAggchainProof(
-		ctx, lastProvenBlock, buildParams.ToBlock, buildParams)
-	if err != nil {
-		if errors.Is(err, errNoProofBuiltYet) {
-			a.log.Infof("aggchainProverFlow - no proof built yet for lastProvenBlock: %d, maxEndBlock: %d",
-				lastProvenBlock, buildParams.ToBlock)
-			return nil, nil
+	var attestation *types.DataAvailabilityAttestation
+	if a.dataAvailabilityVerifier != nil {
+		var err error
+		buildParams, attestation, err = a.verifyDataAvailability(ctx, lastProvenBlock, buildParams)
+		if err != nil || buildParams == nil {
+			return nil, err
 		}
-		errNew := fmt.Errorf("aggchainProverFlow - error generating aggchain proof: %w", err)
-		return nil, errNew
+	}
+
+	var (
+		aggchainProof              *types.AggchainProof
+		rootFromWhichToProveClaims *treetypes.Root
+		provingTime                time.Duration
+	)
+	for rebuilds := 0; ; rebuilds++ {
+		var err error
+		start := time.Now()
+		aggchainProof, rootFromWhichToProveClaims, err = a.GenerateAggchainProof(
+			ctx, lastProvenBlock, buildParams.ToBlock, buildParams)
+		provingTime = time.Since(start)
+		if err != nil {
+			if errors.Is(err, errNoProofBuiltYet) {
+				a.log.Infof("aggchainProverFlow - no proof built yet for lastProvenBlock: %d, maxEndBlock: %d",
+					lastProvenBlock, buildParams.ToBlock)
+				return nil, nil
+			}
+			errNew := fmt.Errorf("aggchainProverFlow - error generating aggchain proof: %w", err)
+			return nil, errNew
+		}
+
+		// the proof generation can take long, so the L1 Info tree may have diverged from the root of the proof
+		// meanwhile (a reorg of finalized L1 blocks). Such a certificate would end InError, so it's rebuilt
+		err = a.l1InfoTreeDataQuerier.CheckL1InfoTreeRoot(ctx,
+			rootFromWhichToProveClaims.Hash, rootFromWhichToProveClaims.Index)
+		addProverAttemptAuditEvent(buildParams, lastProvenBlock, aggchainProof, provingTime, err)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, query.ErrStaleL1InfoTreeRoot) || rebuilds >= maxStaleProofRebuilds {
+			return nil, fmt.Errorf("aggchainProverFlow - error checking the L1 Info tree root of the aggchain proof: %w",
+				err)
+		}
+		a.log.Warnf("aggchainProverFlow - the aggchain proof for lastProvenBlock: %d, maxEndBlock: %d is stale, "+
+			"rebuilding it: %v", lastProvenBlock, buildParams.ToBlock, err)
 	}
 
 	a.log.Infof("aggchainProverFlow - fetched auth proof for lastProvenBlock: %d, maxEndBlock: %d "+
 		"from aggchain prover. End block gotten from the prover: %d. Proof length: %d",
 		lastProvenBlock, buildParams.ToBlock, aggchainProof.EndBlock, len(aggchainProof.SP1StarkProof.Proof))
 
+	// the attestations are kept in the context of the proof, so a resent certificate has the same ones
+	certContext, err := attestation.ApplyToContext(aggchainProof.Context)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error adding the data availability attestation to the "+
+			"certificate context: %w", err)
+	}
+	aggchainProof.Context = certContext
+
 	// set the root from which to generate merkle proofs for each claim
 	// this is crucial since Aggchain Prover will use this root to generate the proofs as well
 	buildParams.L1InfoTreeRootFromWhichToProve = rootFromWhichToProveClaims.Hash
 	buildParams.AggchainProof = aggchainProof
 	buildParams.L1InfoTreeLeafCount = rootFromWhichToProveClaims.Index + 1
+	buildParams.ProvingTime = provingTime
+	metrics.ProverTime(provingTime.Seconds())
+
+	previousToBlock := buildParams.ToBlock
+	buildParams, err = adjustBlockRange(buildParams, buildParams.ToBlock, aggchainProof.EndBlock)
+	if err != nil {
+		return nil, err
+	}
+	buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonAggchainProver)
+	return buildParams, nil
+}
 
-	return adjustBlockRange(buildParams, buildParams.ToBlock, aggchainProof.EndBlock)
+// addRetryRangeSelectedAuditEvent records in the audit trail that the range is the one of the InError
+// certificate resent, and if its inputs are the ones of the build params snapshot
+func addRetryRangeSelectedAuditEvent(buildParams *types.CertificateBuildParams, fromSnapshot bool) {
+	buildParams.AddAuditEvent(types.CertificateAuditRangeSelected, map[string]any{
+		"from_block":       buildParams.FromBlock,
+		"to_block":         buildParams.ToBlock,
+		"reason":           types.CertificateAuditReasonRetryInError,
+		"from_snapshot":    fromSnapshot,
+		"certificate_type": buildParams.CertificateType.String(),
+		"bridges":          buildParams.NumberOfBridges(),
+		"claims":           buildParams.NumberOfClaims(),
+	})
+}
+
+// addProverAttemptAuditEvent records in the audit trail a request of the aggchain proof with its result
+func addProverAttemptAuditEvent(buildParams *types.CertificateBuildParams, lastProvenBlock uint64,
+	proof *types.AggchainProof, provingTime time.Duration, err error) {
+	details := map[string]any{
+		"last_proven_block":   lastProvenBlock,
+		"requested_end_block": buildParams.ToBlock,
+		"duration_ms":         provingTime.Milliseconds(),
+		"optimistic":          buildParams.CertificateType == types.CertificateTypeOptimistic,
+	}
+	if proof != nil {
+		details["end_block"] = proof.EndBlock
+	}
+	if err != nil {
+		details["error"] = err.Error()
+	}
+	buildParams.AddAuditEvent(types.CertificateAuditProverAttempt, details)
 }
 
 // BuildCertificate builds a certificate based on the buildParams
@@ -305,12 +515,21 @@ func (a *AggchainProverFlow) BuildCertificate(ctx context.Context,
 		return nil, fmt.Errorf("aggchainProverFlow - error building certificate: %w", err)
 	}
 
+	certContext, err := a.config.customFields.ApplyToContext(buildParams.AggchainProof.Context)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error adding custom fields to certificate context: %w", err)
+	}
+	certContext, err = a.config.tags.ApplyToContext(certContext)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error adding tags to certificate context: %w", err)
+	}
+
 	cert.AggchainData = &agglayertypes.AggchainDataProof{
 		Proof:          buildParams.AggchainProof.SP1StarkProof.Proof,
 		Version:        buildParams.AggchainProof.SP1StarkProof.Version,
 		Vkey:           buildParams.AggchainProof.SP1StarkProof.Vkey,
 		AggchainParams: buildParams.AggchainProof.AggchainParams,
-		Context:        buildParams.AggchainProof.Context,
+		Context:        certContext,
 	}
 
 	cert.CustomChainData = buildParams.AggchainProof.CustomChainData
@@ -411,6 +630,7 @@ func (a *AggchainProverFlow) GenerateAggchainProof(
 	} else {
 		aggchainProof, err = a.generateOptimisticAggchainProof(ctx, certBuildParams, request)
 	}
+	healthcheck.RecordResult("aggchain_prover", err)
 	if err != nil {
 		err := fmt.Errorf("aggchainProverFlow - error fetching aggchain proof (optimisticMode: %t) for lastProvenBlock: %d, "+
 			"maxEndBlock: %d. Err: %w. Message sent: %s", optimisticMode, lastProvenBlock, toBlock, err, request.String(),
@@ -457,6 +677,11 @@ func (a *AggchainProverFlow) getLastProvenBlock(fromBlock uint64, lastCertificat
 			a.baseFlow.StartL2Block())
 		return a.baseFlow.StartL2Block()
 	}
+	if boundary := newFEPMigrationBoundary(lastCertificate, a.baseFlow.StartL2Block()); boundary != nil {
+		// the first FEP certificate must be proven from the FEP starting block
+		a.log.Infof("aggchainProverFlow - getLastProvenBlock. PP to FEP migration: %s", boundary.String())
+		return boundary.LastProvenBlock()
+	}
 	if lastCertificate != nil && lastCertificate.ToBlock < a.baseFlow.StartL2Block() {
 		// if the last certificate is settled on PP, the last proven block is the starting L2 block
 		a.log.Infof("aggchainProverFlow - getLastProvenBlock. Last certificate block: %d < startL2Block: %d",
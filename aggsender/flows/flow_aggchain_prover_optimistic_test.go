@@ -99,6 +99,7 @@ func Test_AggchainProverFlow_PreviousCertNotSameTypeItRecalculateCertificate(t *
 		Return(nil).Once()
 	data.mockGERQuerier.EXPECT().GetInjectedGERsProofs(data.ctx, mock.Anything, nextCert.FromBlock, nextCert.ToBlock).
 		Return(nil, nil)
+	data.mockL1InfoTreeQuerier.EXPECT().CheckL1InfoTreeRoot(data.ctx, common.Hash{}, uint32(0)).Return(nil).Once()
 	// Now calls to aggkit-prover service:
 	data.mockAggchainProofClient.EXPECT().GenerateAggchainProof(data.ctx, mock.Anything).Return(&types.AggchainProof{
 		EndBlock: 60,
@@ -11,7 +11,9 @@ import (
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/aggchainfep"
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/db"
 	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/query"
 	"github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/agglayer/aggkit/l1infotreesync"
@@ -32,6 +34,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 	ctx := context.Background()
 
 	finalizedL1Root := common.HexToHash("0x1")
+	staleL1Root := common.HexToHash("0x99")
 
 	ibe1 := &agglayertypes.ImportedBridgeExit{
 		BridgeExit: &agglayertypes.BridgeExit{
@@ -63,6 +66,8 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 		)
 		expectedParams *types.CertificateBuildParams
 		expectedError  string
+		// expectedAuditEvents are the events of the audit trail, only checked if set
+		expectedAuditEvents []types.CertificateAuditEventType
 	}{
 		{
 			name: "error getting last sent certificate",
@@ -77,6 +82,10 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 		},
 		{
 			name: "resend InError certificate - have aggchain proof in db",
+			expectedAuditEvents: []types.CertificateAuditEventType{
+				types.CertificateAuditRangeSelected,
+				types.CertificateAuditProofReused,
+			},
 			mockFn: func(mockStorage *mocks.AggSenderStorage,
 				mockL2BridgeQuerier *mocks.BridgeQuerier,
 				mockProverClient *mocks.AggchainProofClientInterface,
@@ -99,6 +108,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 						LastProvenBlock: 1,
 						EndBlock:        10,
 					}, nil).Once()
+				mockStorage.EXPECT().GetCertificateBuildParams(uint64(0)).Return(nil, nil).Once()
 				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return([]bridgesync.Bridge{{}}, []bridgesync.Claim{
 					{
 						GlobalIndex:     big.NewInt(1),
@@ -135,6 +145,73 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 				CertificateType: types.CertificateTypeFEP,
 			},
 		},
+		{
+			name: "resend InError certificate with build params snapshot",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockProverClient *mocks.AggchainProofClientInterface,
+				mockL1InfoDataQuery *mocks.L1InfoTreeDataQuerier,
+				mockGERQuerier *mocks.GERQuerier) {
+				mockStorage.EXPECT().GetLastSentCertificateHeaderWithProofIfInError(ctx).Return(&types.CertificateHeader{
+					Height:                  3,
+					FromBlock:               1,
+					ToBlock:                 10,
+					Status:                  agglayertypes.InError,
+					FinalizedL1InfoTreeRoot: &finalizedL1Root,
+					L1InfoTreeLeafCount:     5,
+					CertificateID:           common.HexToHash("0x1"),
+					CertType:                types.CertificateTypeFEP,
+					RetryCount:              1,
+					CreatedAt:               100,
+				},
+					&types.AggchainProof{
+						SP1StarkProof:   &types.SP1StarkProof{Proof: []byte("some-proof")},
+						LastProvenBlock: 1,
+						EndBlock:        10,
+					}, nil).Once()
+				// the bridges and claims are not queried again, the ones of the snapshot are used
+				snapshot, err := db.NewCertificateBuildParamsSnapshot(3, 1, &types.CertificateBuildParams{
+					FromBlock:                      1,
+					ToBlock:                        10,
+					Bridges:                        []bridgesync.Bridge{{DepositCount: 7}},
+					L1InfoTreeRootFromWhichToProve: finalizedL1Root,
+					L1InfoTreeLeafCount:            5,
+					CertificateType:                types.CertificateTypeFEP,
+					CreatedAt:                      100,
+				})
+				require.NoError(t, err)
+				mockStorage.EXPECT().GetCertificateBuildParams(uint64(3)).Return(snapshot, nil).Once()
+				// the root of the aggchain proof is still part of the L1 Info tree, so it's reused
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, finalizedL1Root, uint32(4)).Return(nil).Once()
+			},
+			expectedParams: &types.CertificateBuildParams{
+				FromBlock:                      1,
+				ToBlock:                        10,
+				RetryCount:                     2,
+				Bridges:                        []bridgesync.Bridge{{DepositCount: 7}},
+				CreatedAt:                      100,
+				L1InfoTreeRootFromWhichToProve: finalizedL1Root,
+				L1InfoTreeLeafCount:            5,
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof:   &types.SP1StarkProof{Proof: []byte("some-proof")},
+					LastProvenBlock: 1,
+					EndBlock:        10,
+				},
+				LastSentCertificate: &types.CertificateHeader{
+					Height:                  3,
+					FromBlock:               1,
+					ToBlock:                 10,
+					Status:                  agglayertypes.InError,
+					FinalizedL1InfoTreeRoot: &finalizedL1Root,
+					L1InfoTreeLeafCount:     5,
+					CertificateID:           common.HexToHash("0x1"),
+					CertType:                types.CertificateTypeFEP,
+					RetryCount:              1,
+					CreatedAt:               100,
+				},
+				CertificateType: types.CertificateTypeFEP,
+			},
+		},
 		{
 			name: "resend InError certificate - no aggchain proof in db",
 			mockFn: func(mockStorage *mocks.AggSenderStorage,
@@ -154,6 +231,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 					CertificateID: common.HexToHash("0x1"),
 					CertType:      types.CertificateTypeFEP,
 				}, nil, nil).Once()
+				mockStorage.EXPECT().GetCertificateBuildParams(uint64(0)).Return(nil, nil).Once()
 				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return([]bridgesync.Bridge{{}}, []bridgesync.Claim{
 					{
 						GlobalIndex:     big.NewInt(1),
@@ -174,6 +252,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 					nil,
 				)
 				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).Return(nil)
 				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
 					Hash:  common.HexToHash("0x1"),
 					Index: 10,
@@ -218,6 +297,101 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "resend InError certificate - aggchain proof in db with a stale L1 Info tree root",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockProverClient *mocks.AggchainProofClientInterface,
+				mockL1InfoDataQuery *mocks.L1InfoTreeDataQuerier,
+				mockGERQuerier *mocks.GERQuerier) {
+				rer := common.HexToHash("0x1")
+				mer := common.HexToHash("0x2")
+				ger := calculateGER(mer, rer)
+				l1Header := &gethtypes.Header{Number: big.NewInt(10)}
+				mockStorage.EXPECT().GetLastSentCertificateHeaderWithProofIfInError(ctx).Return(&types.CertificateHeader{
+					FromBlock:               1,
+					ToBlock:                 10,
+					Status:                  agglayertypes.InError,
+					FinalizedL1InfoTreeRoot: &staleL1Root,
+					L1InfoTreeLeafCount:     5,
+					CertificateID:           common.HexToHash("0x1"),
+					CertType:                types.CertificateTypeFEP,
+				}, &types.AggchainProof{
+					SP1StarkProof:   &types.SP1StarkProof{Proof: []byte("stale-proof")},
+					LastProvenBlock: 0,
+					EndBlock:        10,
+				}, nil).Once()
+				mockStorage.EXPECT().GetCertificateBuildParams(uint64(0)).Return(nil, nil).Once()
+				// the proof in db was generated against a root that is no longer in the L1 Info tree
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, staleL1Root, uint32(4)).
+					Return(fmt.Errorf("root %s: %w", staleL1Root, query.ErrStaleL1InfoTreeRoot)).Once()
+				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return([]bridgesync.Bridge{{}}, []bridgesync.Claim{
+					{
+						GlobalIndex:     big.NewInt(1),
+						GlobalExitRoot:  ger,
+						MainnetExitRoot: mer,
+						RollupExitRoot:  rer,
+					}}, nil)
+				mockL1InfoDataQuery.EXPECT().GetFinalizedL1InfoTreeData(ctx).Return(
+					treetypes.Proof{},
+					&l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					&treetypes.Root{
+						Hash:  common.HexToHash("0x1"),
+						Index: 10,
+					},
+					nil,
+				)
+				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).Return(nil).Once()
+				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
+					Hash:  common.HexToHash("0x1"),
+					Index: 10,
+				}, uint64(1), uint64(10)).Return(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber{}, nil)
+				mockProverClient.EXPECT().GenerateAggchainProof(context.Background(), types.NewAggchainProofRequest(uint64(0), uint64(10),
+					common.HexToHash("0x1"), l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					agglayertypes.MerkleProof{
+						Root:  common.HexToHash("0x1"),
+						Proof: treetypes.Proof{},
+					}, make(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber, 0),
+					[]*agglayertypes.ImportedBridgeExitWithBlockNumber{{ImportedBridgeExit: ibe1}})).Return(&types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{Proof: []byte("some-proof")}, LastProvenBlock: 0, EndBlock: 10}, nil).Once()
+			},
+			expectedParams: &types.CertificateBuildParams{
+				CertificateType: types.CertificateTypeFEP,
+				FromBlock:       1,
+				ToBlock:         10,
+				RetryCount:      1,
+				LastSentCertificate: &types.CertificateHeader{
+					FromBlock:               1,
+					ToBlock:                 10,
+					Status:                  agglayertypes.InError,
+					FinalizedL1InfoTreeRoot: &staleL1Root,
+					L1InfoTreeLeafCount:     5,
+					CertificateID:           common.HexToHash("0x1"),
+					CertType:                types.CertificateTypeFEP,
+				},
+				Bridges:             []bridgesync.Bridge{{}},
+				L1InfoTreeLeafCount: 11,
+				Claims: []bridgesync.Claim{{
+					GlobalIndex:     big.NewInt(1),
+					RollupExitRoot:  common.HexToHash("0x1"),
+					MainnetExitRoot: common.HexToHash("0x2"),
+					GlobalExitRoot:  calculateGER(common.HexToHash("0x2"), common.HexToHash("0x1")),
+				}},
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof:   &types.SP1StarkProof{Proof: []byte("some-proof")},
+					LastProvenBlock: 0,
+					EndBlock:        10,
+				},
+			},
+		},
 		{
 			name: "error fetching aggchain proof for new certificate",
 			mockFn: func(mockStorage *mocks.AggSenderStorage,
@@ -348,6 +522,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 					nil,
 				)
 				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).Return(nil)
 				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
 					Hash:  common.HexToHash("0x1"),
 					Index: 10,
@@ -389,8 +564,149 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 				CertificateType: types.CertificateTypeFEP,
 			},
 		},
+		{
+			name: "success fetching aggchain proof for new certificate - L1 Info tree diverges, the proof is rebuilt",
+			expectedAuditEvents: []types.CertificateAuditEventType{
+				types.CertificateAuditRangeSelected,
+				types.CertificateAuditProverAttempt,
+				types.CertificateAuditProverAttempt,
+			},
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockProverClient *mocks.AggchainProofClientInterface,
+				mockL1InfoDataQuery *mocks.L1InfoTreeDataQuerier,
+				mockGERQuerier *mocks.GERQuerier) {
+				rer := common.HexToHash("0x1")
+				mer := common.HexToHash("0x2")
+				ger := calculateGER(mer, rer)
+				l1Header := &gethtypes.Header{Number: big.NewInt(10)}
+				mockStorage.EXPECT().GetLastSentCertificateHeaderWithProofIfInError(ctx).Return(&types.CertificateHeader{ToBlock: 5, Status: agglayertypes.Settled}, nil, nil).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&types.CertificateHeader{ToBlock: 5}, nil).Once()
+				mockL2BridgeQuerier.On("GetLastProcessedBlock", ctx).Return(uint64(10), nil)
+				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(6), uint64(10)).Return([]bridgesync.Bridge{{}}, []bridgesync.Claim{{
+					GlobalIndex:     big.NewInt(1),
+					GlobalExitRoot:  ger,
+					MainnetExitRoot: mer,
+					RollupExitRoot:  rer,
+				}}, nil)
+				mockL1InfoDataQuery.EXPECT().GetFinalizedL1InfoTreeData(ctx).Return(
+					treetypes.Proof{},
+					&l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					&treetypes.Root{
+						Hash:  common.HexToHash("0x1"),
+						Index: 10,
+					},
+					nil,
+				)
+				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				// the L1 Info tree diverges from the root while the first proof is generated
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).
+					Return(fmt.Errorf("root 0x1: %w", query.ErrStaleL1InfoTreeRoot)).Once()
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).Return(nil).Once()
+				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
+					Hash:  common.HexToHash("0x1"),
+					Index: 10,
+				}, uint64(6), uint64(10)).Return(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber{}, nil)
+				mockProverClient.EXPECT().GenerateAggchainProof(context.Background(), types.NewAggchainProofRequest(uint64(5), uint64(10),
+					common.HexToHash("0x1"), l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					agglayertypes.MerkleProof{
+						Root:  common.HexToHash("0x1"),
+						Proof: treetypes.Proof{},
+					}, make(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber, 0),
+					[]*agglayertypes.ImportedBridgeExitWithBlockNumber{{ImportedBridgeExit: ibe1}})).Return(&types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{Proof: []byte("some-proof")}, LastProvenBlock: 6, EndBlock: 10}, nil).Twice()
+			},
+			expectedParams: &types.CertificateBuildParams{
+				FromBlock:  6,
+				ToBlock:    10,
+				RetryCount: 0,
+				LastSentCertificate: &types.CertificateHeader{
+					ToBlock: 5,
+				},
+				Bridges:             []bridgesync.Bridge{{}},
+				L1InfoTreeLeafCount: 11,
+				Claims: []bridgesync.Claim{{
+					GlobalIndex:     big.NewInt(1),
+					RollupExitRoot:  common.HexToHash("0x1"),
+					MainnetExitRoot: common.HexToHash("0x2"),
+					GlobalExitRoot:  calculateGER(common.HexToHash("0x2"), common.HexToHash("0x1")),
+				}},
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof:   &types.SP1StarkProof{Proof: []byte("some-proof")},
+					LastProvenBlock: 6,
+					EndBlock:        10,
+				},
+				CreatedAt:       uint32(time.Now().UTC().Unix()),
+				CertificateType: types.CertificateTypeFEP,
+			},
+		},
+		{
+			name: "error fetching aggchain proof for new certificate - L1 Info tree still diverges after rebuilding",
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockProverClient *mocks.AggchainProofClientInterface,
+				mockL1InfoDataQuery *mocks.L1InfoTreeDataQuerier,
+				mockGERQuerier *mocks.GERQuerier) {
+				rer := common.HexToHash("0x1")
+				mer := common.HexToHash("0x2")
+				ger := calculateGER(mer, rer)
+				l1Header := &gethtypes.Header{Number: big.NewInt(10)}
+				mockStorage.EXPECT().GetLastSentCertificateHeaderWithProofIfInError(ctx).Return(&types.CertificateHeader{ToBlock: 5, Status: agglayertypes.Settled}, nil, nil).Once()
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&types.CertificateHeader{ToBlock: 5}, nil).Once()
+				mockL2BridgeQuerier.On("GetLastProcessedBlock", ctx).Return(uint64(10), nil)
+				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(6), uint64(10)).Return([]bridgesync.Bridge{{}}, []bridgesync.Claim{{
+					GlobalIndex:     big.NewInt(1),
+					GlobalExitRoot:  ger,
+					MainnetExitRoot: mer,
+					RollupExitRoot:  rer,
+				}}, nil)
+				mockL1InfoDataQuery.EXPECT().GetFinalizedL1InfoTreeData(ctx).Return(
+					treetypes.Proof{},
+					&l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					&treetypes.Root{
+						Hash:  common.HexToHash("0x1"),
+						Index: 10,
+					},
+					nil,
+				)
+				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).
+					Return(fmt.Errorf("root 0x1: %w", query.ErrStaleL1InfoTreeRoot)).Twice()
+				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
+					Hash:  common.HexToHash("0x1"),
+					Index: 10,
+				}, uint64(6), uint64(10)).Return(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber{}, nil)
+				mockProverClient.EXPECT().GenerateAggchainProof(context.Background(), types.NewAggchainProofRequest(uint64(5), uint64(10),
+					common.HexToHash("0x1"), l1infotreesync.L1InfoTreeLeaf{
+						BlockNumber: l1Header.Number.Uint64(),
+						Hash:        common.HexToHash("0x2"),
+					},
+					agglayertypes.MerkleProof{
+						Root:  common.HexToHash("0x1"),
+						Proof: treetypes.Proof{},
+					}, make(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber, 0),
+					[]*agglayertypes.ImportedBridgeExitWithBlockNumber{{ImportedBridgeExit: ibe1}})).Return(&types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{Proof: []byte("some-proof")}, LastProvenBlock: 6, EndBlock: 10}, nil).Twice()
+			},
+			expectedError: "error checking the L1 Info tree root of the aggchain proof",
+		},
 		{
 			name: "success fetching aggchain proof for new certificate - aggchain prover returns smaller range",
+			expectedAuditEvents: []types.CertificateAuditEventType{
+				types.CertificateAuditRangeSelected,
+				types.CertificateAuditProverAttempt,
+				types.CertificateAuditRangeTruncated,
+			},
 			mockFn: func(mockStorage *mocks.AggSenderStorage,
 				mockL2BridgeQuerier *mocks.BridgeQuerier,
 				mockProverClient *mocks.AggchainProofClientInterface,
@@ -422,6 +738,7 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 					nil,
 				)
 				mockL1InfoDataQuery.EXPECT().CheckIfClaimsArePartOfFinalizedL1InfoTree(mock.Anything, mock.Anything).Return(nil)
+				mockL1InfoDataQuery.EXPECT().CheckL1InfoTreeRoot(ctx, common.HexToHash("0x1"), uint32(10)).Return(nil)
 				mockGERQuerier.EXPECT().GetInjectedGERsProofs(ctx, &treetypes.Root{
 					Hash:  common.HexToHash("0x1"),
 					Index: 10,
@@ -513,6 +830,19 @@ func Test_AggchainProverFlow_GetCertificateBuildParams(t *testing.T) {
 				require.ErrorContains(t, err, tc.expectedError)
 			} else {
 				require.NoError(t, err)
+				if params != nil {
+					// the proving time is measured (0 if the proof is reused), so it's not part of the expected params
+					params.ProvingTime = 0
+					// the audit trail has timestamps, so only the types of the events are checked
+					if tc.expectedAuditEvents != nil {
+						events := make([]types.CertificateAuditEventType, 0, len(params.AuditTrail))
+						for _, event := range params.AuditTrail {
+							events = append(events, event.Event)
+						}
+						require.Equal(t, tc.expectedAuditEvents, events)
+					}
+					params.AuditTrail = nil
+				}
 				require.Equal(t, tc.expectedParams, params)
 			}
 
@@ -680,6 +1010,18 @@ func Test_AggchainProverFlow_getLastProvenBlock(t *testing.T) {
 			},
 			expectedResult: 50,
 		},
+		{
+			name:         "PP to FEP migration, proves from startL2Block",
+			fromBlock:    41,
+			startL2Block: 50,
+			lastSentCertificate: &types.CertificateHeader{
+				FromBlock: 10,
+				ToBlock:   40,
+				Status:    agglayertypes.Settled,
+				CertType:  types.CertificateTypePP,
+			},
+			expectedResult: 50,
+		},
 		{
 			name:                "lastSentCertificate settled on PP on the fence. Case 2",
 			fromBlock:           50,
@@ -715,7 +1057,7 @@ func Test_AggchainProverFlow_getLastProvenBlock(t *testing.T) {
 				nil, // sotrage
 				nil, // l1InfoTreeDataQuerier,
 				nil, // lerQuerier
-				NewBaseFlowConfig(0, tc.startL2Block, false),
+				NewBaseFlowConfig(0, tc.startL2Block, false, 0, L2ChainHaltConfig{}),
 			)
 			flow := NewAggchainProverFlow(
 				logger,
@@ -748,6 +1090,8 @@ func Test_AggchainProverFlow_BuildCertificate(t *testing.T) {
 		name           string
 		mockFn         func(*mocks.BridgeQuerier, *mocks.LERQuerier, *mocks.Signer)
 		buildParams    *types.CertificateBuildParams
+		customFields   types.CertificateCustomFields
+		tags           types.CertificateTags
 		expectedError  string
 		expectedResult *agglayertypes.Certificate
 	}{
@@ -820,6 +1164,159 @@ func Test_AggchainProverFlow_BuildCertificate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success building certificate with custom fields",
+			mockFn: func(mockL2BridgeQuerier *mocks.BridgeQuerier, mockLERQuerier *mocks.LERQuerier, mockSigner *mocks.Signer) {
+				mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+				mockSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x123"))
+				mockSigner.EXPECT().SignHash(mock.Anything, mock.Anything).Return([]byte("signature"), nil)
+				mockLERQuerier.EXPECT().GetLastLocalExitRoot().Return(emptyLER, nil)
+			},
+			buildParams: &types.CertificateBuildParams{
+				FromBlock:                      1,
+				ToBlock:                        10,
+				Bridges:                        []bridgesync.Bridge{},
+				Claims:                         []bridgesync.Claim{},
+				CreatedAt:                      uint32(createdAt.Unix()),
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				CertificateType:                types.CertificateTypeFEP,
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{
+						Proof:   []byte("some-proof"),
+						Version: "0.1",
+						Vkey:    []byte("some-vkey"),
+					},
+					LastProvenBlock: 1,
+					EndBlock:        10,
+					AggchainParams:  common.HexToHash("0x2"),
+					Context: map[string][]byte{
+						"key1": []byte("value1"),
+					},
+				},
+			},
+			customFields: types.CertificateCustomFields{"operator": "acme"},
+			expectedResult: &agglayertypes.Certificate{
+				NetworkID:           1,
+				Height:              0,
+				NewLocalExitRoot:    emptyLER,
+				Metadata:            types.NewCertificateMetadata(1, 9, uint32(createdAt.Unix()), types.CertificateTypeFEP.ToInt()).ToHash(),
+				BridgeExits:         []*agglayertypes.BridgeExit{},
+				ImportedBridgeExits: []*agglayertypes.ImportedBridgeExit{},
+				PrevLocalExitRoot:   emptyLER,
+				L1InfoTreeLeafCount: 0,
+				AggchainData: &agglayertypes.AggchainDataProof{
+					Proof:          []byte("some-proof"),
+					Version:        "0.1",
+					Vkey:           []byte("some-vkey"),
+					AggchainParams: common.HexToHash("0x2"),
+					Context: map[string][]byte{
+						"key1":                                  []byte("value1"),
+						types.CertificateCustomFieldsVersionKey: []byte(types.CertificateCustomFieldsVersion),
+						"aggkit.custom.operator":                []byte("acme"),
+					},
+					Signature: []byte("signature"),
+				},
+			},
+		},
+		{
+			name: "success building certificate with custom fields and tags",
+			mockFn: func(mockL2BridgeQuerier *mocks.BridgeQuerier, mockLERQuerier *mocks.LERQuerier, mockSigner *mocks.Signer) {
+				mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+				mockSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x123"))
+				mockSigner.EXPECT().SignHash(mock.Anything, mock.Anything).Return([]byte("signature"), nil)
+				mockLERQuerier.EXPECT().GetLastLocalExitRoot().Return(emptyLER, nil)
+			},
+			buildParams: &types.CertificateBuildParams{
+				FromBlock:                      1,
+				ToBlock:                        10,
+				Bridges:                        []bridgesync.Bridge{},
+				Claims:                         []bridgesync.Claim{},
+				CreatedAt:                      uint32(createdAt.Unix()),
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				CertificateType:                types.CertificateTypeFEP,
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{
+						Proof:   []byte("some-proof"),
+						Version: "0.1",
+						Vkey:    []byte("some-vkey"),
+					},
+					LastProvenBlock: 1,
+					EndBlock:        10,
+					AggchainParams:  common.HexToHash("0x2"),
+				},
+			},
+			customFields: types.CertificateCustomFields{"operator": "acme"},
+			tags:         types.CertificateTags{"environment": "staging", "region": "eu-west-1"},
+			expectedResult: &agglayertypes.Certificate{
+				NetworkID:           1,
+				Height:              0,
+				NewLocalExitRoot:    emptyLER,
+				Metadata:            types.NewCertificateMetadata(1, 9, uint32(createdAt.Unix()), types.CertificateTypeFEP.ToInt()).ToHash(),
+				BridgeExits:         []*agglayertypes.BridgeExit{},
+				ImportedBridgeExits: []*agglayertypes.ImportedBridgeExit{},
+				PrevLocalExitRoot:   emptyLER,
+				L1InfoTreeLeafCount: 0,
+				AggchainData: &agglayertypes.AggchainDataProof{
+					Proof:          []byte("some-proof"),
+					Version:        "0.1",
+					Vkey:           []byte("some-vkey"),
+					AggchainParams: common.HexToHash("0x2"),
+					Context: map[string][]byte{
+						types.CertificateCustomFieldsVersionKey: []byte(types.CertificateCustomFieldsVersion),
+						"aggkit.custom.operator":                []byte("acme"),
+						"aggkit.tag.environment":                []byte("staging"),
+						"aggkit.tag.region":                     []byte("eu-west-1"),
+					},
+					Signature: []byte("signature"),
+				},
+			},
+		},
+		{
+			name: "tag already set by the prover",
+			mockFn: func(mockL2BridgeQuerier *mocks.BridgeQuerier, mockLERQuerier *mocks.LERQuerier, mockSigner *mocks.Signer) {
+				mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+				mockLERQuerier.EXPECT().GetLastLocalExitRoot().Return(emptyLER, nil)
+			},
+			buildParams: &types.CertificateBuildParams{
+				FromBlock:                      1,
+				ToBlock:                        10,
+				Bridges:                        []bridgesync.Bridge{},
+				Claims:                         []bridgesync.Claim{},
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				CertificateType:                types.CertificateTypeFEP,
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{},
+					Context: map[string][]byte{
+						"aggkit.tag.environment": []byte("prover"),
+					},
+				},
+			},
+			tags:          types.CertificateTags{"environment": "staging"},
+			expectedError: "error adding tags to certificate context",
+		},
+		{
+			name: "custom field already set by the prover",
+			mockFn: func(mockL2BridgeQuerier *mocks.BridgeQuerier, mockLERQuerier *mocks.LERQuerier, mockSigner *mocks.Signer) {
+				mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+				mockLERQuerier.EXPECT().GetLastLocalExitRoot().Return(emptyLER, nil)
+			},
+			buildParams: &types.CertificateBuildParams{
+				FromBlock:                      1,
+				ToBlock:                        10,
+				Bridges:                        []bridgesync.Bridge{},
+				Claims:                         []bridgesync.Claim{},
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x1"),
+				CertificateType:                types.CertificateTypeFEP,
+				AggchainProof: &types.AggchainProof{
+					SP1StarkProof: &types.SP1StarkProof{},
+					Context: map[string][]byte{
+						"aggkit.custom.operator": []byte("prover"),
+					},
+				},
+			},
+			customFields:  types.CertificateCustomFields{"operator": "acme"},
+			expectedError: "error adding custom fields to certificate context",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -843,7 +1340,7 @@ func Test_AggchainProverFlow_BuildCertificate(t *testing.T) {
 			)
 			aggchainFlow := NewAggchainProverFlow(
 				logger,
-				NewAggchainProverFlowConfigDefault(),
+				NewAggchainProverFlowConfig(0, nil, tc.customFields, tc.tags, types.DataAvailabilityConfig{}),
 				flowBase,
 				nil, // mockAggchainProofClient
 				nil, // mockStorage
@@ -992,6 +1489,23 @@ func Test_AggchainProverFlow_CheckInitialStatus(t *testing.T) {
 			},
 			expectedError: "aggchainProverFlow - error verifying block range gaps on startup",
 		},
+		{
+			name: "error PP certificates settled beyond FEP starting block",
+			mockFn: func(
+				mockStorage *mocks.AggSenderStorage,
+				mockBaseFlow *mocks.AggsenderFlowBaser,
+				mockL2BridgeSyncer *mocks.BridgeQuerier,
+			) {
+				lastCert := &types.CertificateHeader{
+					ToBlock:  20,
+					CertType: types.CertificateTypePP,
+					Status:   agglayertypes.Settled,
+				}
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(lastCert, nil).Once()
+				mockBaseFlow.EXPECT().StartL2Block().Return(uint64(15)).Once()
+			},
+			expectedError: "aggchainProverFlow - invalid PP to FEP migration boundary",
+		},
 		{
 			name:                 "success ",
 			requireNoFEPBlockGap: true,
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -35,23 +36,32 @@ type BaseFlowConfig struct {
 	// RequireNoFEPBlockGap indicates whether the flow requires no gap between the
 	// first FEP block and last settled certificate.
 	RequireNoFEPBlockGap bool
+	// MaxConcurrentClaimProofs is the maximum number of imported bridge exits whose merkle proofs are
+	// generated concurrently while building a certificate. 0 or 1 generates them sequentially
+	MaxConcurrentClaimProofs uint
+	// L2ChainHalt are the thresholds to detect that the L2 has no new blocks to certify
+	L2ChainHalt L2ChainHaltConfig
 }
 
 // NewBaseFlowConfigDefault returns a BaseFlowConfig with default values
 func NewBaseFlowConfigDefault() BaseFlowConfig {
 	return BaseFlowConfig{
-		MaxCertSize:          0,     // 0 means no limit
-		StartL2Block:         0,     // 0 means start from the first block
-		RequireNoFEPBlockGap: false, // default is false, can be set to true if needed
+		MaxCertSize:              0,     // 0 means no limit
+		StartL2Block:             0,     // 0 means start from the first block
+		RequireNoFEPBlockGap:     false, // default is false, can be set to true if needed
+		MaxConcurrentClaimProofs: 0,     // 0 means the proofs are generated sequentially
 	}
 }
 
 // NewBaseFlowConfig returns a BaseFlowConfig with the specified maxCertSize and startL2Block
-func NewBaseFlowConfig(maxCertSize uint, startL2Block uint64, requireNoFEPBlockGap bool) BaseFlowConfig {
+func NewBaseFlowConfig(maxCertSize uint, startL2Block uint64, requireNoFEPBlockGap bool,
+	maxConcurrentClaimProofs uint, l2ChainHalt L2ChainHaltConfig) BaseFlowConfig {
 	return BaseFlowConfig{
-		MaxCertSize:          maxCertSize,
-		StartL2Block:         startL2Block,
-		RequireNoFEPBlockGap: requireNoFEPBlockGap,
+		MaxCertSize:              maxCertSize,
+		StartL2Block:             startL2Block,
+		RequireNoFEPBlockGap:     requireNoFEPBlockGap,
+		MaxConcurrentClaimProofs: maxConcurrentClaimProofs,
+		L2ChainHalt:              l2ChainHalt,
 	}
 }
 
@@ -63,6 +73,10 @@ type baseFlow struct {
 	lerQuerier            types.LERQuerier
 	cfg                   BaseFlowConfig
 	log                   types.Logger
+	// l2ChainHalt is nil (disabled) if the flow is not created with NewBaseFlow
+	l2ChainHalt *l2ChainHaltTracker
+	// feeBudget is nil if the fee budget is disabled
+	feeBudget types.CertificateFeeBudget
 }
 
 // NewBaseFlow creates a new instance of the base flow
@@ -81,6 +95,7 @@ func NewBaseFlow(
 		l1InfoTreeDataQuerier: l1InfoTreeDataQuerier,
 		lerQuerier:            lerQuerier,
 		cfg:                   cfg,
+		l2ChainHalt:           newL2ChainHaltTracker(cfg.L2ChainHalt),
 	}
 }
 
@@ -89,6 +104,11 @@ func (f *baseFlow) StartL2Block() uint64 {
 	return f.cfg.StartL2Block
 }
 
+// L2ChainStatus returns whether the L2 chain is active, idle or halted, or nil if the detection is disabled
+func (f *baseFlow) L2ChainStatus() *types.L2ChainStatus {
+	return f.l2ChainHalt.chainStatus()
+}
+
 // GetCertificateBuildParamsInternal returns the parameters to build a certificate
 func (f *baseFlow) GetCertificateBuildParamsInternal(
 	ctx context.Context, certType types.CertificateType) (*types.CertificateBuildParams, error) {
@@ -105,10 +125,14 @@ func (f *baseFlow) GetCertificateBuildParamsInternal(
 	previousToBlock, retryCount := f.getLastSentBlockAndRetryCount(lastSentCertificate)
 
 	if previousToBlock >= lastL2BlockSynced {
-		f.log.Warnf("no new blocks to send a certificate, last certificate block: %d, last L2 block: %d",
-			previousToBlock, lastL2BlockSynced)
+		// once the L2 chain is idle, its status is reported instead of logging on each epoch
+		if f.l2ChainHalt.noNewBlocks(f.log, lastL2BlockSynced, previousToBlock, time.Now()) {
+			f.log.Warnf("no new blocks to send a certificate, last certificate block: %d, last L2 block: %d",
+				previousToBlock, lastL2BlockSynced)
+		}
 		return nil, errNoNewBlocks
 	}
+	f.l2ChainHalt.newBlocks(f.log, lastL2BlockSynced)
 
 	fromBlock := previousToBlock + 1
 	toBlock := lastL2BlockSynced
@@ -128,11 +152,21 @@ func (f *baseFlow) GetCertificateBuildParamsInternal(
 		CreatedAt:           uint32(time.Now().UTC().Unix()),
 		CertificateType:     certType,
 	}
+	buildParams.AddAuditEvent(types.CertificateAuditRangeSelected, map[string]any{
+		"from_block":           fromBlock,
+		"to_block":             toBlock,
+		"reason":               types.CertificateAuditReasonNewBlocks,
+		"last_l2_block_synced": lastL2BlockSynced,
+		"certificate_type":     certType.String(),
+		"bridges":              len(bridges),
+		"claims":               len(claims),
+	})
 
 	buildParams, err = f.limitCertSize(buildParams)
 	if err != nil {
 		return nil, fmt.Errorf("error limitCertSize: %w", err)
 	}
+	buildParams.AddRangeTruncatedAuditEvent(toBlock, types.CertificateAuditReasonMaxCertSize)
 
 	return buildParams, nil
 }
@@ -147,7 +181,16 @@ func (f *baseFlow) VerifyBuildParams(ctx context.Context, fullCert *types.Certif
 		return err
 	}
 
-	return nil
+	return f.CheckFeeBudget(ctx, fullCert)
+}
+
+// CheckFeeBudget returns an error if the certificate exceeds the fee budget, so it's not proven nor signed.
+// Its bridges are going to be included in the next certificate
+func (f *baseFlow) CheckFeeBudget(ctx context.Context, buildParams *types.CertificateBuildParams) error {
+	if f.feeBudget == nil {
+		return nil
+	}
+	return f.feeBudget.CheckFeeBudget(ctx, buildParams)
 }
 
 // limitCertSize limits certificate size based on the max size configuration parameter
@@ -219,11 +262,12 @@ func (f *baseFlow) BuildCertificate(ctx context.Context,
 		return nil, fmt.Errorf("error getting new local exit root: %w", err)
 	}
 
-	meta := types.NewCertificateMetadata(
+	meta := types.NewCertificateMetadataWithForkName(
 		certParams.FromBlock,
 		uint32(certParams.ToBlock-certParams.FromBlock),
 		certParams.CreatedAt,
 		certParams.CertificateType.ToInt(),
+		certParams.ForkName,
 	)
 
 	return &agglayertypes.Certificate{
@@ -339,79 +383,101 @@ func (f *baseFlow) getImportedBridgeExits(
 		return []*agglayertypes.ImportedBridgeExit{}, nil
 	}
 
-	importedBridgeExits := make([]*agglayertypes.ImportedBridgeExit, 0, len(claims))
-
+	// the proofs are generated by a bounded pool of workers, each one storing its imported bridge exit
+	// at the index of the claim, so the output keeps the order of the claims
+	importedBridgeExits := make([]*agglayertypes.ImportedBridgeExit, len(claims))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(int(max(f.cfg.MaxConcurrentClaimProofs, 1)))
 	for i, claim := range claims {
-		f.log.Debugf("claim[%d]: destAddr: %s GER: %s Block: %d Pos: %d GlobalIndex: 0x%x",
-			i, claim.DestinationAddress.String(), claim.GlobalExitRoot.String(),
-			claim.BlockNum, claim.BlockPos, claim.GlobalIndex)
-		ibe, err := f.ConvertClaimToImportedBridgeExit(claim)
-		if err != nil {
-			return nil, fmt.Errorf("error converting claim to imported bridge exit: %w", err)
-		}
+		group.Go(func() error {
+			ibe, err := f.getImportedBridgeExit(groupCtx, i, claim, rootFromWhichToProve)
+			if err != nil {
+				return err
+			}
+			importedBridgeExits[i] = ibe
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
 
-		importedBridgeExits = append(importedBridgeExits, ibe)
+	return importedBridgeExits, nil
+}
 
-		l1Info, gerToL1Proof, err := f.l1InfoTreeDataQuerier.GetProofForGER(ctx,
-			claim.GlobalExitRoot, rootFromWhichToProve)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"error getting L1 Info tree merkle proof for GER: %s and root: %s. Error: %w",
-				claim.GlobalExitRoot, rootFromWhichToProve, err,
-			)
-		}
+// getImportedBridgeExit converts the claim (the i-th of the certificate) to an imported bridge exit
+// with the claim data and the merkle proof of its GER to the given L1 info tree root
+func (f *baseFlow) getImportedBridgeExit(
+	ctx context.Context, i int, claim bridgesync.Claim,
+	rootFromWhichToProve common.Hash,
+) (*agglayertypes.ImportedBridgeExit, error) {
+	f.log.Debugf("claim[%d]: destAddr: %s GER: %s Block: %d Pos: %d GlobalIndex: 0x%x",
+		i, claim.DestinationAddress.String(), claim.GlobalExitRoot.String(),
+		claim.BlockNum, claim.BlockPos, claim.GlobalIndex)
+	ibe, err := f.ConvertClaimToImportedBridgeExit(claim)
+	if err != nil {
+		return nil, fmt.Errorf("error converting claim to imported bridge exit: %w", err)
+	}
 
-		if ibe.GlobalIndex.MainnetFlag {
-			ibe.ClaimData = &agglayertypes.ClaimFromMainnnet{
-				L1Leaf: &agglayertypes.L1InfoTreeLeaf{
-					L1InfoTreeIndex: l1Info.L1InfoTreeIndex,
-					RollupExitRoot:  claim.RollupExitRoot,
-					MainnetExitRoot: claim.MainnetExitRoot,
-					Inner: &agglayertypes.L1InfoTreeLeafInner{
-						GlobalExitRoot: l1Info.GlobalExitRoot,
-						Timestamp:      l1Info.Timestamp,
-						BlockHash:      l1Info.PreviousBlockHash,
-					},
-				},
-				ProofLeafMER: &agglayertypes.MerkleProof{
-					Root:  claim.MainnetExitRoot,
-					Proof: claim.ProofLocalExitRoot,
-				},
-				ProofGERToL1Root: &agglayertypes.MerkleProof{
-					Root:  rootFromWhichToProve,
-					Proof: gerToL1Proof,
-				},
-			}
-		} else {
-			ibe.ClaimData = &agglayertypes.ClaimFromRollup{
-				L1Leaf: &agglayertypes.L1InfoTreeLeaf{
-					L1InfoTreeIndex: l1Info.L1InfoTreeIndex,
-					RollupExitRoot:  claim.RollupExitRoot,
-					MainnetExitRoot: claim.MainnetExitRoot,
-					Inner: &agglayertypes.L1InfoTreeLeafInner{
-						GlobalExitRoot: l1Info.GlobalExitRoot,
-						Timestamp:      l1Info.Timestamp,
-						BlockHash:      l1Info.PreviousBlockHash,
-					},
-				},
-				ProofLeafLER: &agglayertypes.MerkleProof{
-					Root: tree.CalculateRoot(ibe.BridgeExit.Hash(),
-						claim.ProofLocalExitRoot, ibe.GlobalIndex.LeafIndex),
-					Proof: claim.ProofLocalExitRoot,
-				},
-				ProofLERToRER: &agglayertypes.MerkleProof{
-					Root:  claim.RollupExitRoot,
-					Proof: claim.ProofRollupExitRoot,
+	l1Info, gerToL1Proof, err := f.l1InfoTreeDataQuerier.GetProofForGER(ctx,
+		claim.GlobalExitRoot, rootFromWhichToProve)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error getting L1 Info tree merkle proof for GER: %s and root: %s. Error: %w",
+			claim.GlobalExitRoot, rootFromWhichToProve, err,
+		)
+	}
+
+	if ibe.GlobalIndex.MainnetFlag {
+		ibe.ClaimData = &agglayertypes.ClaimFromMainnnet{
+			L1Leaf: &agglayertypes.L1InfoTreeLeaf{
+				L1InfoTreeIndex: l1Info.L1InfoTreeIndex,
+				RollupExitRoot:  claim.RollupExitRoot,
+				MainnetExitRoot: claim.MainnetExitRoot,
+				Inner: &agglayertypes.L1InfoTreeLeafInner{
+					GlobalExitRoot: l1Info.GlobalExitRoot,
+					Timestamp:      l1Info.Timestamp,
+					BlockHash:      l1Info.PreviousBlockHash,
 				},
-				ProofGERToL1Root: &agglayertypes.MerkleProof{
-					Root:  rootFromWhichToProve,
-					Proof: gerToL1Proof,
+			},
+			ProofLeafMER: &agglayertypes.MerkleProof{
+				Root:  claim.MainnetExitRoot,
+				Proof: claim.ProofLocalExitRoot,
+			},
+			ProofGERToL1Root: &agglayertypes.MerkleProof{
+				Root:  rootFromWhichToProve,
+				Proof: gerToL1Proof,
+			},
+		}
+	} else {
+		ibe.ClaimData = &agglayertypes.ClaimFromRollup{
+			L1Leaf: &agglayertypes.L1InfoTreeLeaf{
+				L1InfoTreeIndex: l1Info.L1InfoTreeIndex,
+				RollupExitRoot:  claim.RollupExitRoot,
+				MainnetExitRoot: claim.MainnetExitRoot,
+				Inner: &agglayertypes.L1InfoTreeLeafInner{
+					GlobalExitRoot: l1Info.GlobalExitRoot,
+					Timestamp:      l1Info.Timestamp,
+					BlockHash:      l1Info.PreviousBlockHash,
 				},
-			}
+			},
+			ProofLeafLER: &agglayertypes.MerkleProof{
+				Root: tree.CalculateRoot(ibe.BridgeExit.Hash(),
+					claim.ProofLocalExitRoot, ibe.GlobalIndex.LeafIndex),
+				Proof: claim.ProofLocalExitRoot,
+			},
+			ProofLERToRER: &agglayertypes.MerkleProof{
+				Root:  claim.RollupExitRoot,
+				Proof: claim.ProofRollupExitRoot,
+			},
+			ProofGERToL1Root: &agglayertypes.MerkleProof{
+				Root:  rootFromWhichToProve,
+				Proof: gerToL1Proof,
+			},
 		}
 	}
 
-	return importedBridgeExits, nil
+	return ibe, nil
 }
 
 // getStartLER returns the last local exit root (LER) based on the configuration
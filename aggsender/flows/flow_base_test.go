@@ -3,7 +3,10 @@ package flows
 import (
 	"context"
 	"errors"
+	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/mocks"
@@ -11,7 +14,9 @@ import (
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/log"
+	treetypes "github.com/agglayer/aggkit/tree/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -123,7 +128,7 @@ func Test_baseFlow_limitCertSize(t *testing.T) {
 				nil,
 				nil,
 				nil,
-				NewBaseFlowConfig(tt.maxCertSize, 0, false))
+				NewBaseFlowConfig(tt.maxCertSize, 0, false, 0, L2ChainHaltConfig{}))
 
 			result, err := f.limitCertSize(tt.fullCert)
 
@@ -504,6 +509,7 @@ func Test_baseFlow_VerifyBuildParams(t *testing.T) {
 		name          string
 		buildParams   *types.CertificateBuildParams
 		mockFn        func(*mocks.BridgeQuerier)
+		feeBudgetErr  error
 		expectedError string
 	}{
 		{
@@ -532,6 +538,15 @@ func Test_baseFlow_VerifyBuildParams(t *testing.T) {
 			},
 			expectedError: "GER mismatch",
 		},
+		{
+			name: "fee budget exhausted",
+			buildParams: &types.CertificateBuildParams{
+				FromBlock: 1,
+				ToBlock:   10,
+			},
+			feeBudgetErr:  errors.New("certificate fee budget exhausted"),
+			expectedError: "certificate fee budget exhausted",
+		},
 		{
 			name: "success",
 			buildParams: &types.CertificateBuildParams{
@@ -556,6 +571,11 @@ func Test_baseFlow_VerifyBuildParams(t *testing.T) {
 				log:             log,
 				l2BridgeQuerier: mockL2BridgeQuerier,
 			}
+			if tc.feeBudgetErr != nil {
+				feeBudget := mocks.NewCertificateFeeBudget(t)
+				feeBudget.EXPECT().CheckFeeBudget(ctx, tc.buildParams).Return(tc.feeBudgetErr).Once()
+				f.feeBudget = feeBudget
+			}
 
 			err := f.VerifyBuildParams(ctx, tc.buildParams)
 			if tc.expectedError != "" {
@@ -743,3 +763,73 @@ func Test_baseFlow_VerifyBlockRangeGaps(t *testing.T) {
 		})
 	}
 }
+
+func Test_baseFlow_getImportedBridgeExitsConcurrent(t *testing.T) {
+	const (
+		numClaims     = 20
+		maxConcurrent = 4
+	)
+	root := common.HexToHash("0x7891")
+	claims := make([]bridgesync.Claim, numClaims)
+	for i := range claims {
+		claims[i] = bridgesync.Claim{
+			GlobalIndex:    big.NewInt(int64(i)),
+			GlobalExitRoot: common.BigToHash(big.NewInt(int64(i) + 1)),
+			Amount:         big.NewInt(1),
+		}
+	}
+
+	newFlow := func(t *testing.T, failingGER common.Hash) (*baseFlow, *atomic.Int32) {
+		t.Helper()
+		var inFlight, maxInFlight atomic.Int32
+		mockL1InfoTreeQuery := mocks.NewL1InfoTreeDataQuerier(t)
+		mockL1InfoTreeQuery.EXPECT().GetProofForGER(mock.Anything, mock.Anything, root).RunAndReturn(
+			func(ctx context.Context, ger, _ common.Hash) (*l1infotreesync.L1InfoTreeLeaf, treetypes.Proof, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					previous := maxInFlight.Load()
+					if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				// the first claims are the slowest, so they finish in a different order
+				time.Sleep(time.Duration(numClaims-ger.Big().Int64()) * time.Millisecond)
+				if ger == failingGER {
+					return nil, treetypes.Proof{}, errors.New("proof not found")
+				}
+				return &l1infotreesync.L1InfoTreeLeaf{
+					L1InfoTreeIndex: uint32(ger.Big().Uint64()),
+					GlobalExitRoot:  ger,
+				}, treetypes.Proof{}, nil
+			}).Maybe()
+
+		return &baseFlow{
+			l1InfoTreeDataQuerier: mockL1InfoTreeQuery,
+			log:                   log.WithFields("test", "unittest"),
+			cfg:                   NewBaseFlowConfig(0, 0, false, maxConcurrent, L2ChainHaltConfig{}),
+		}, &maxInFlight
+	}
+
+	t.Run("keeps the order of the claims", func(t *testing.T) {
+		flow, maxInFlight := newFlow(t, common.Hash{})
+		exits, err := flow.getImportedBridgeExits(context.Background(), claims, root)
+		require.NoError(t, err)
+		require.Len(t, exits, numClaims)
+		for i, exit := range exits {
+			require.Equal(t, uint32(i), exit.GlobalIndex.LeafIndex)
+			claimData, ok := exit.ClaimData.(*agglayertypes.ClaimFromRollup)
+			require.True(t, ok)
+			require.Equal(t, claims[i].GlobalExitRoot, claimData.L1Leaf.Inner.GlobalExitRoot)
+		}
+		require.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrent))
+		require.Greater(t, maxInFlight.Load(), int32(1))
+	})
+
+	t.Run("error in a claim", func(t *testing.T) {
+		flow, _ := newFlow(t, claims[5].GlobalExitRoot)
+		exits, err := flow.getImportedBridgeExits(context.Background(), claims, root)
+		require.ErrorContains(t, err, "proof not found")
+		require.Nil(t, exits)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/db"
@@ -20,7 +21,11 @@ type PPFlow struct {
 	l1InfoTreeDataQuerier types.L1InfoTreeDataQuerier
 
 	forceOneBridgeExit bool
-	maxL2BlockLimiter  types.MaxL2BlockNumberLimiterInterface
+	// heartbeatInterval is the time after which an empty certificate is sent if there is no
+	// bridge activity (0 = disabled)
+	heartbeatInterval time.Duration
+	maxL2BlockLimiter types.MaxL2BlockNumberLimiterInterface
+	hardForkLimiter   types.HardForkLimiterInterface
 }
 
 // NewPPFlow returns a new instance of the PPFlow
@@ -31,7 +36,9 @@ func NewPPFlow(log types.Logger,
 	l2BridgeQuerier types.BridgeQuerier,
 	signer signertypes.Signer,
 	forceOneBridgeExit bool,
-	maxL2BlockNumber uint64) *PPFlow {
+	heartbeatInterval time.Duration,
+	maxL2BlockNumber uint64,
+	hardForks types.HardForks) *PPFlow {
 	feature := NewMaxL2BlockNumberLimiter(
 		maxL2BlockNumber,
 		log,
@@ -44,10 +51,22 @@ func NewPPFlow(log types.Logger,
 		l1InfoTreeDataQuerier: l1InfoTreeQuerier,
 		baseFlow:              baseFlow,
 		forceOneBridgeExit:    forceOneBridgeExit,
+		heartbeatInterval:     heartbeatInterval,
 		maxL2BlockLimiter:     feature,
+		hardForkLimiter:       NewHardForkLimiter(hardForks, log, true),
 	}
 }
 
+// SignerAddress returns the address of the signer of the certificates
+func (p *PPFlow) SignerAddress() common.Address {
+	return p.signer.PublicAddress()
+}
+
+// L2ChainStatus returns whether the L2 chain is active, idle or halted
+func (p *PPFlow) L2ChainStatus() *types.L2ChainStatus {
+	return p.baseFlow.L2ChainStatus()
+}
+
 // CheckInitialStatus checks that initial status is correct.
 // For PPFlow  there are no special checks to do, so it just returns nil
 func (p *PPFlow) CheckInitialStatus(ctx context.Context) error {
@@ -77,16 +96,36 @@ func (p *PPFlow) GetCertificateBuildParams(ctx context.Context) (*types.Certific
 	}
 
 	if buildParams.IsEmpty() {
-		p.log.Infof("PPFlow - no bridges or claims found for range: %d - %d, so no certificate will be built",
-			buildParams.FromBlock, buildParams.ToBlock)
-		return nil, nil
+		if !p.isHeartbeatDue(buildParams.LastSentCertificate) {
+			p.log.Infof("PPFlow - no bridges or claims found for range: %d - %d, so no certificate will be built",
+				buildParams.FromBlock, buildParams.ToBlock)
+			return nil, nil
+		}
+		p.log.Infof("PPFlow - no bridges or claims found for range: %d - %d, building a heartbeat certificate "+
+			"(heartbeat interval: %s)", buildParams.FromBlock, buildParams.ToBlock, p.heartbeatInterval)
 	}
 	if p.maxL2BlockLimiter != nil {
 		// If the feature is enabled, we need to adapt the build params
+		previousToBlock := buildParams.ToBlock
 		buildParams, err = p.maxL2BlockLimiter.AdaptCertificate(buildParams)
 		if err != nil {
 			return nil, fmt.Errorf("ppFlow - error adapting  certificate to MaxL2Block. Err: %w", err)
 		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonMaxL2BlockNumber)
+	}
+	if p.hardForkLimiter != nil {
+		previousToBlock := buildParams.ToBlock
+		buildParams, err = p.hardForkLimiter.AdaptCertificate(buildParams)
+		if err != nil {
+			return nil, fmt.Errorf("ppFlow - error adapting certificate to hard forks. Err: %w", err)
+		}
+		buildParams.AddRangeTruncatedAuditEvent(previousToBlock, types.CertificateAuditReasonHardFork)
+		if buildParams.IsEmpty() {
+			// the blocks before the fork are closed by an empty certificate, so the next one starts
+			// at the fork block as in the AggchainProof flow
+			p.log.Infof("PPFlow - no bridges or claims before the hard fork, building an empty certificate "+
+				"for range: %d - %d", buildParams.FromBlock, buildParams.ToBlock)
+		}
 	}
 
 	if err := p.baseFlow.VerifyBuildParams(ctx, buildParams); err != nil {
@@ -108,7 +147,11 @@ func (p *PPFlow) GetCertificateBuildParams(ctx context.Context) (*types.Certific
 // this function is the implementation of the FlowManager interface
 func (p *PPFlow) BuildCertificate(ctx context.Context,
 	buildParams *types.CertificateBuildParams) (*agglayertypes.Certificate, error) {
-	certificate, err := p.baseFlow.BuildCertificate(ctx, buildParams, buildParams.LastSentCertificate, false)
+	// the empty certificates are only returned by GetCertificateBuildParams if they are heartbeats
+	// or if they end right before a hard fork
+	allowEmptyCert := p.heartbeatInterval > 0 ||
+		(p.hardForkLimiter != nil && p.hardForkLimiter.EndsAtFork(buildParams))
+	certificate, err := p.baseFlow.BuildCertificate(ctx, buildParams, buildParams.LastSentCertificate, allowEmptyCert)
 	if err != nil {
 		return nil, fmt.Errorf("ppFlow - error building certificate: %w", err)
 	}
@@ -121,6 +164,18 @@ func (p *PPFlow) BuildCertificate(ctx context.Context,
 	return signedCert, nil
 }
 
+// isHeartbeatDue returns true if the heartbeat certificates are enabled and the heartbeat interval
+// has elapsed since the last sent certificate was created (or no certificate has been sent yet)
+func (p *PPFlow) isHeartbeatDue(lastSentCertificate *types.CertificateHeader) bool {
+	if p.heartbeatInterval == 0 {
+		return false
+	}
+	if lastSentCertificate == nil {
+		return true
+	}
+	return lastSentCertificate.ElapsedTimeSinceCreation() >= p.heartbeatInterval
+}
+
 // signCertificate signs a certificate with the aggsender key
 func (p *PPFlow) signCertificate(ctx context.Context,
 	certificate *agglayertypes.Certificate) (*agglayertypes.Certificate, error) {
@@ -737,11 +737,13 @@ func Test_PPFlow_GetCertificateBuildParams(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	oldCreatedAt := uint32(time.Now().UTC().Add(-2 * time.Hour).Unix())
 
 	testCases := []struct {
 		name               string
 		mockFn             func(*mocks.AggSenderStorage, *mocks.BridgeQuerier, *mocks.L1InfoTreeDataQuerier)
 		forceOneBridgeExit bool
+		heartbeatInterval  time.Duration
 		expectedParams     *types.CertificateBuildParams
 		expectedError      string
 	}{
@@ -796,6 +798,44 @@ func Test_PPFlow_GetCertificateBuildParams(t *testing.T) {
 			},
 			expectedParams: nil,
 		},
+		{
+			name:              "no bridges and claims, heartbeat not due",
+			heartbeatInterval: 3 * time.Hour,
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockL1InfoTreeQuerier *mocks.L1InfoTreeDataQuerier) {
+				mockL2BridgeQuerier.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(10), nil)
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(
+					&types.CertificateHeader{ToBlock: 5, CreatedAt: oldCreatedAt}, nil)
+				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(6), uint64(10)).Return([]bridgesync.Bridge{}, []bridgesync.Claim{}, nil)
+			},
+			expectedParams: nil,
+		},
+		{
+			name:              "no bridges and claims, heartbeat due",
+			heartbeatInterval: time.Hour,
+			mockFn: func(mockStorage *mocks.AggSenderStorage,
+				mockL2BridgeQuerier *mocks.BridgeQuerier,
+				mockL1InfoTreeQuerier *mocks.L1InfoTreeDataQuerier) {
+				mockL2BridgeQuerier.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(10), nil)
+				mockStorage.EXPECT().GetLastSentCertificateHeader().Return(
+					&types.CertificateHeader{ToBlock: 5, CreatedAt: oldCreatedAt}, nil)
+				mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(6), uint64(10)).Return([]bridgesync.Bridge{}, []bridgesync.Claim{}, nil)
+				mockL1InfoTreeQuerier.EXPECT().GetLatestFinalizedL1InfoRoot(ctx).Return(
+					&treetypes.Root{Hash: common.HexToHash("0x123"), Index: 1}, nil, nil)
+			},
+			expectedParams: &types.CertificateBuildParams{
+				FromBlock:                      6,
+				ToBlock:                        10,
+				L1InfoTreeLeafCount:            2,
+				CertificateType:                types.CertificateTypePP,
+				LastSentCertificate:            &types.CertificateHeader{ToBlock: 5, CreatedAt: oldCreatedAt},
+				Bridges:                        []bridgesync.Bridge{},
+				Claims:                         []bridgesync.Claim{},
+				CreatedAt:                      uint32(time.Now().UTC().Unix()),
+				L1InfoTreeRootFromWhichToProve: common.HexToHash("0x123"),
+			},
+		},
 		{
 			name:               "no bridges when forceOneBridgeExit is true",
 			forceOneBridgeExit: true,
@@ -932,7 +972,7 @@ func Test_PPFlow_GetCertificateBuildParams(t *testing.T) {
 				logger,
 				NewBaseFlow(logger, mockL2BridgeQuerier,
 					mockStorage, mockL1InfoTreeQuerier, mockLERQuerier, NewBaseFlowConfigDefault()),
-				mockStorage, mockL1InfoTreeQuerier, mockL2BridgeQuerier, nil, tc.forceOneBridgeExit, 0)
+				mockStorage, mockL1InfoTreeQuerier, mockL2BridgeQuerier, nil, tc.forceOneBridgeExit, tc.heartbeatInterval, 0, nil)
 
 			tc.mockFn(mockStorage, mockL2BridgeQuerier, mockL1InfoTreeQuerier)
 
@@ -941,6 +981,10 @@ func Test_PPFlow_GetCertificateBuildParams(t *testing.T) {
 				require.ErrorContains(t, err, tc.expectedError)
 			} else {
 				require.NoError(t, err)
+				if params != nil {
+					// the audit trail has timestamps, it's checked in the tests of the flow that records it
+					params.AuditTrail = nil
+				}
 				require.Equal(t, tc.expectedParams, params)
 			}
 		})
@@ -1010,7 +1054,7 @@ func TestGetLastSentBlockAndRetryCount(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			baseFlow := &baseFlow{cfg: NewBaseFlowConfig(0, tt.startL2Block, false)}
+			baseFlow := &baseFlow{cfg: NewBaseFlowConfig(0, tt.startL2Block, false, 0, L2ChainHaltConfig{})}
 
 			block, retryCount := baseFlow.getLastSentBlockAndRetryCount(tt.lastSentCertificate)
 
@@ -1025,6 +1069,117 @@ func Test_PPFlow_CheckInitialStatus(t *testing.T) {
 	require.Nil(t, sut.CheckInitialStatus(context.TODO()))
 }
 
+func Test_PPFlow_BuildCertificateHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.WithFields("test", "Test_PPFlow_BuildCertificateHeartbeat")
+	buildParams := &types.CertificateBuildParams{
+		FromBlock:       6,
+		ToBlock:         10,
+		CertificateType: types.CertificateTypePP,
+		LastSentCertificate: &types.CertificateHeader{
+			NewLocalExitRoot: common.HexToHash("0x123"),
+			Height:           1,
+			Status:           agglayertypes.Settled,
+		},
+		Bridges: []bridgesync.Bridge{},
+		Claims:  []bridgesync.Claim{},
+	}
+
+	t.Run("heartbeat disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ppFlow := NewPPFlow(logger, NewBaseFlow(logger, nil, nil, nil, nil, NewBaseFlowConfigDefault()),
+			nil, nil, nil, nil, false, 0, 0, nil)
+
+		_, err := ppFlow.BuildCertificate(ctx, buildParams)
+		require.ErrorIs(t, err, errNoBridgesAndClaims)
+	})
+
+	t.Run("heartbeat enabled", func(t *testing.T) {
+		t.Parallel()
+
+		mockL2BridgeQuerier := mocks.NewBridgeQuerier(t)
+		mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+		mockSigner := mocks.NewSigner(t)
+		mockSigner.EXPECT().SignHash(ctx, mock.Anything).Return([]byte("mock_signature"), nil)
+		mockSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x123"))
+		ppFlow := NewPPFlow(logger,
+			NewBaseFlow(logger, mockL2BridgeQuerier, nil, nil, nil, NewBaseFlowConfigDefault()),
+			nil, nil, mockL2BridgeQuerier, mockSigner, false, time.Hour, 0, nil)
+
+		cert, err := ppFlow.BuildCertificate(ctx, buildParams)
+		require.NoError(t, err)
+		require.Empty(t, cert.BridgeExits)
+		require.Empty(t, cert.ImportedBridgeExits)
+		require.Equal(t, uint64(2), cert.Height)
+		require.Equal(t, common.HexToHash("0x123"), cert.PrevLocalExitRoot)
+		require.Equal(t, common.HexToHash("0x123"), cert.NewLocalExitRoot)
+		require.Equal(t, &agglayertypes.AggchainDataSignature{Signature: []byte("mock_signature")}, cert.AggchainData)
+	})
+}
+
+func Test_PPFlow_CrossingHardFork(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := log.WithFields("test", "Test_PPFlow_CrossingHardFork")
+	hardForks := types.HardForks{{Name: "fork1", BlockNumber: 11}}
+	mockStorage := mocks.NewAggSenderStorage(t)
+	mockL2BridgeQuerier := mocks.NewBridgeQuerier(t)
+	mockL1InfoTreeQuerier := mocks.NewL1InfoTreeDataQuerier(t)
+	mockSigner := mocks.NewSigner(t)
+	ppFlow := NewPPFlow(logger,
+		NewBaseFlow(logger, mockL2BridgeQuerier, mockStorage, mockL1InfoTreeQuerier, nil, NewBaseFlowConfigDefault()),
+		mockStorage, mockL1InfoTreeQuerier, mockL2BridgeQuerier, mockSigner, false, 0, 0, hardForks)
+
+	lastSentCert := &types.CertificateHeader{
+		Height:           1,
+		ToBlock:          5,
+		NewLocalExitRoot: common.HexToHash("0x123"),
+		Status:           agglayertypes.Settled,
+	}
+	bridges := []bridgesync.Bridge{{BlockNum: 15}}
+	mockL2BridgeQuerier.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(20), nil)
+	mockL1InfoTreeQuerier.EXPECT().GetLatestFinalizedL1InfoRoot(ctx).Return(
+		&treetypes.Root{Hash: common.HexToHash("0x456"), Index: 1}, nil, nil)
+
+	// the blocks before the fork have no bridges, but they are closed by an empty certificate
+	mockStorage.EXPECT().GetLastSentCertificateHeader().Return(lastSentCert, nil).Once()
+	mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(6), uint64(20)).Return(bridges, nil, nil).Once()
+	params, err := ppFlow.GetCertificateBuildParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), params.FromBlock)
+	require.Equal(t, uint64(10), params.ToBlock)
+	require.Empty(t, params.ForkName)
+	require.True(t, params.IsEmpty())
+
+	mockL2BridgeQuerier.EXPECT().OriginNetwork().Return(uint32(1))
+	mockSigner.EXPECT().SignHash(ctx, mock.Anything).Return([]byte("mock_signature"), nil)
+	mockSigner.EXPECT().PublicAddress().Return(common.HexToAddress("0x123"))
+	cert, err := ppFlow.BuildCertificate(ctx, params)
+	require.NoError(t, err)
+	require.Empty(t, cert.BridgeExits)
+	require.Equal(t, uint64(2), cert.Height)
+
+	// the next certificate starts at the fork block
+	mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&types.CertificateHeader{
+		Height:           2,
+		FromBlock:        6,
+		ToBlock:          10,
+		NewLocalExitRoot: common.HexToHash("0x123"),
+		Status:           agglayertypes.Settled,
+	}, nil).Once()
+	mockL2BridgeQuerier.EXPECT().GetBridgesAndClaims(ctx, uint64(11), uint64(20)).Return(bridges, nil, nil).Once()
+	params, err = ppFlow.GetCertificateBuildParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), params.FromBlock)
+	require.Equal(t, uint64(20), params.ToBlock)
+	require.Equal(t, "fork1", params.ForkName)
+	require.Equal(t, bridges, params.Bridges)
+}
+
 func Test_PPFlow_SignCertificate(t *testing.T) {
 	t.Parallel()
 
@@ -1092,7 +1247,9 @@ func Test_PPFlow_SignCertificate(t *testing.T) {
 				nil, // l2BridgeQuerier
 				mockSigner,
 				false, // forceOneBridgeExit
+				0,     // heartbeatInterval
 				0,     // maxL2BlockNumber
+				nil,   // hardForks
 			)
 
 			signedCert, err := ppFlow.signCertificate(ctx, tt.certificate)
@@ -0,0 +1,79 @@
+package flows
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+var ErrHardForkInARetryCert = errors.New("hardForkLimiter. " +
+	"Retry certificate straddles a hard fork and can't be resized")
+
+// HardForkLimiter adapts the certificate ranges so a certificate never contains blocks
+// of two L2 hard forks (one cert ends at fork-1 and the next starts at fork), and tags
+// the certificate with the name of its fork
+type HardForkLimiter struct {
+	hardForks              types.HardForks
+	log                    types.Logger
+	allowToResizeRetryCert bool
+}
+
+// NewHardForkLimiter returns a new HardForkLimiter
+func NewHardForkLimiter(
+	hardForks types.HardForks,
+	log types.Logger,
+	allowToResizeRetryCert bool,
+) *HardForkLimiter {
+	return &HardForkLimiter{
+		hardForks:              hardForks,
+		log:                    log,
+		allowToResizeRetryCert: allowToResizeRetryCert,
+	}
+}
+
+// IsEnabled returns true if there are hard forks configured
+func (f *HardForkLimiter) IsEnabled() bool {
+	return len(f.hardForks) > 0
+}
+
+// AdaptCertificate adjusts the range of the certificate build parameters to not straddle a hard fork.
+// The certificate ends at fork-1 even if it has no bridges or claims, so the next one starts at the fork
+func (f *HardForkLimiter) AdaptCertificate(
+	buildParams *types.CertificateBuildParams) (*types.CertificateBuildParams, error) {
+	if !f.IsEnabled() {
+		return buildParams, nil
+	}
+	if buildParams == nil {
+		return nil, ErrBuildParamsIsNil
+	}
+
+	// the forks are sorted, so the range before the first one doesn't straddle any other
+	if fork := f.hardForks.FirstForkInRange(buildParams.FromBlock, buildParams.ToBlock); fork != nil {
+		if buildParams.IsARetry() && !f.allowToResizeRetryCert {
+			return nil, fmt.Errorf("hardForkLimiter can't adapt the retry certificate [%d to %d] "+
+				"to the fork %s (block %d). Err: %w",
+				buildParams.FromBlock, buildParams.ToBlock, fork.Name, fork.BlockNumber, ErrHardForkInARetryCert)
+		}
+
+		preForkParams, err := buildParams.Range(buildParams.FromBlock, fork.BlockNumber-1)
+		if err != nil {
+			return nil, fmt.Errorf("hardForkLimiter error adjusting the ToBlock of the certificate %d -> %d: %w",
+				buildParams.ToBlock, fork.BlockNumber-1, err)
+		}
+		f.log.Infof("hardForkLimiter. Adjusting the certificate ToBlock: %d to %d, before the fork %s",
+			buildParams.ToBlock, preForkParams.ToBlock, fork.Name)
+		buildParams = preForkParams
+	}
+
+	buildParams.ForkName = f.hardForks.ForkAt(buildParams.FromBlock)
+	return buildParams, nil
+}
+
+// EndsAtFork returns true if the next block after the certificate is the first block of a hard fork
+func (f *HardForkLimiter) EndsAtFork(buildParams *types.CertificateBuildParams) bool {
+	if buildParams == nil {
+		return false
+	}
+	return f.hardForks.FirstForkInRange(buildParams.ToBlock, buildParams.ToBlock+1) != nil
+}
@@ -0,0 +1,114 @@
+package flows
+
+import (
+	"testing"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardForkLimiterAdaptCertificate(t *testing.T) {
+	hardForks := types.HardForks{{Name: "fork1", BlockNumber: 100}, {Name: "fork2", BlockNumber: 200}}
+
+	tests := []struct {
+		name                   string
+		hardForks              types.HardForks
+		allowToResizeRetryCert bool
+		buildParams            *types.CertificateBuildParams
+		expectedBuildParams    *types.CertificateBuildParams
+		expectedError          error
+	}{
+		{
+			name:                "Feature disabled",
+			hardForks:           nil,
+			buildParams:         &types.CertificateBuildParams{FromBlock: 50, ToBlock: 150},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 50, ToBlock: 150},
+		},
+		{
+			name:          "BuildParams is nil",
+			hardForks:     hardForks,
+			buildParams:   nil,
+			expectedError: ErrBuildParamsIsNil,
+		},
+		{
+			name:                "Range before the first fork",
+			hardForks:           hardForks,
+			buildParams:         &types.CertificateBuildParams{FromBlock: 10, ToBlock: 99},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 10, ToBlock: 99},
+		},
+		{
+			name:                "Range inside a fork is tagged",
+			hardForks:           hardForks,
+			buildParams:         &types.CertificateBuildParams{FromBlock: 100, ToBlock: 199},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 100, ToBlock: 199, ForkName: "fork1"},
+		},
+		{
+			name:      "Range straddling a fork ends at fork-1",
+			hardForks: hardForks,
+			buildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 250,
+				Bridges: []bridgesync.Bridge{{BlockNum: 160}, {BlockNum: 210}}},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 199, ForkName: "fork1",
+				Bridges: []bridgesync.Bridge{{BlockNum: 160}}, Claims: []bridgesync.Claim{}},
+		},
+		{
+			name:      "Empty range before the fork is allowed",
+			hardForks: hardForks,
+			buildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 250,
+				Bridges: []bridgesync.Bridge{{BlockNum: 210}}},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 199, ForkName: "fork1",
+				Bridges: []bridgesync.Bridge{}, Claims: []bridgesync.Claim{}},
+		},
+		{
+			name:      "Range straddling two forks ends before the first one",
+			hardForks: hardForks,
+			buildParams: &types.CertificateBuildParams{FromBlock: 50, ToBlock: 250,
+				Bridges: []bridgesync.Bridge{{BlockNum: 210}}},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 50, ToBlock: 99,
+				Bridges: []bridgesync.Bridge{}, Claims: []bridgesync.Claim{}},
+		},
+		{
+			name:                   "Retry certificate not allowed to resize",
+			hardForks:              hardForks,
+			allowToResizeRetryCert: false,
+			buildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 250, RetryCount: 1,
+				LastSentCertificate: &types.CertificateHeader{}},
+			expectedError: ErrHardForkInARetryCert,
+		},
+		{
+			name:                   "Retry certificate allowed to resize",
+			hardForks:              hardForks,
+			allowToResizeRetryCert: true,
+			buildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 250, RetryCount: 1,
+				LastSentCertificate: &types.CertificateHeader{}},
+			expectedBuildParams: &types.CertificateBuildParams{FromBlock: 150, ToBlock: 199, RetryCount: 1,
+				LastSentCertificate: &types.CertificateHeader{}, ForkName: "fork1",
+				Bridges: []bridgesync.Bridge{}, Claims: []bridgesync.Claim{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewHardForkLimiter(tt.hardForks, log.WithFields("test", tt.name),
+				tt.allowToResizeRetryCert)
+			result, err := limiter.AdaptCertificate(tt.buildParams)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedBuildParams, result)
+			}
+		})
+	}
+}
+
+func TestHardForkLimiterEndsAtFork(t *testing.T) {
+	limiter := NewHardForkLimiter(types.HardForks{{Name: "fork1", BlockNumber: 100}}, log.WithFields("test", t.Name()), true)
+
+	require.False(t, limiter.EndsAtFork(nil))
+	require.True(t, limiter.EndsAtFork(&types.CertificateBuildParams{FromBlock: 50, ToBlock: 99}))
+	require.False(t, limiter.EndsAtFork(&types.CertificateBuildParams{FromBlock: 50, ToBlock: 98}))
+	require.False(t, limiter.EndsAtFork(&types.CertificateBuildParams{FromBlock: 100, ToBlock: 150}))
+}
@@ -0,0 +1,131 @@
+package flows
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+// L2ChainHaltConfig are the thresholds to detect that the L2 has produced no new blocks beyond the
+// last certified block
+type L2ChainHaltConfig struct {
+	// IdleThreshold is the time without new L2 blocks after which the chain is idle: the
+	// "no new blocks" messages are not logged anymore. 0 disables the detection
+	IdleThreshold time.Duration
+	// HaltAlertThreshold is the time without new L2 blocks after which the chain is halted and an
+	// error is logged. 0 disables the alert
+	HaltAlertThreshold time.Duration
+}
+
+// Validate checks that the halt alert is not set without the idle detection, and that it's not
+// raised before the chain is idle
+func (c L2ChainHaltConfig) Validate() error {
+	if c.HaltAlertThreshold > 0 && c.IdleThreshold <= 0 {
+		return errors.New("L2HaltAlertThreshold requires L2IdleThreshold")
+	}
+	if c.HaltAlertThreshold > 0 && c.HaltAlertThreshold < c.IdleThreshold {
+		return fmt.Errorf("L2HaltAlertThreshold (%s) must be greater than L2IdleThreshold (%s)",
+			c.HaltAlertThreshold, c.IdleThreshold)
+	}
+	return nil
+}
+
+// l2ChainHaltTracker keeps the time since the L2 has no new blocks to certify, moving the chain from
+// active to idle and halted when the thresholds are exceeded. The time is measured from the first
+// check without new blocks, so it's restarted on restarts. The zero value is disabled
+type l2ChainHaltTracker struct {
+	cfg L2ChainHaltConfig
+
+	mu        sync.Mutex
+	status    types.L2ChainStatusType
+	lastBlock uint64
+	idleSince *time.Time
+}
+
+func newL2ChainHaltTracker(cfg L2ChainHaltConfig) *l2ChainHaltTracker {
+	return &l2ChainHaltTracker{cfg: cfg, status: types.L2ChainActive}
+}
+
+// enabled returns true if the chain halts are detected
+func (t *l2ChainHaltTracker) enabled() bool {
+	return t != nil && t.cfg.IdleThreshold > 0
+}
+
+// newBlocks records that there are new L2 blocks to certify up to lastBlock
+func (t *l2ChainHaltTracker) newBlocks(log types.Logger, lastBlock uint64) {
+	if !t.enabled() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.status != types.L2ChainActive {
+		log.Infof("L2 chain is active again at block %d after %s without new blocks (it was %s)",
+			lastBlock, time.Since(*t.idleSince).Round(time.Second), t.status)
+	}
+	t.status = types.L2ChainActive
+	t.lastBlock = lastBlock
+	t.idleSince = nil
+	metrics.L2ChainStatus(0, 0)
+}
+
+// noNewBlocks records that there are no new L2 blocks to certify beyond lastCertifiedBlock and returns
+// true if the "no new blocks" message has to be logged, that is while the chain isn't idle yet
+func (t *l2ChainHaltTracker) noNewBlocks(log types.Logger, lastBlock, lastCertifiedBlock uint64,
+	now time.Time) bool {
+	if !t.enabled() {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.idleSince == nil || lastBlock != t.lastBlock {
+		t.idleSince = &now
+		t.lastBlock = lastBlock
+	}
+	idleTime := now.Sub(*t.idleSince)
+
+	switch {
+	case t.cfg.HaltAlertThreshold > 0 && idleTime >= t.cfg.HaltAlertThreshold:
+		if t.status != types.L2ChainHalted {
+			log.Errorf("L2 chain is halted: no new blocks for %s (alert threshold: %s), last L2 block: %d, "+
+				"last certified block: %d", idleTime.Round(time.Second), t.cfg.HaltAlertThreshold,
+				lastBlock, lastCertifiedBlock)
+		}
+		t.status = types.L2ChainHalted
+	case idleTime >= t.cfg.IdleThreshold:
+		if t.status != types.L2ChainIdle {
+			log.Warnf("L2 chain is idle: no new blocks for %s (idle threshold: %s), last L2 block: %d, "+
+				"last certified block: %d", idleTime.Round(time.Second), t.cfg.IdleThreshold,
+				lastBlock, lastCertifiedBlock)
+		}
+		t.status = types.L2ChainIdle
+	default:
+		t.status = types.L2ChainActive
+	}
+	metrics.L2ChainStatus(t.status.Level(), idleTime.Seconds())
+	return t.status == types.L2ChainActive
+}
+
+// chainStatus returns the status of the L2 chain, or nil if the detection is disabled
+func (t *l2ChainHaltTracker) chainStatus() *types.L2ChainStatus {
+	if !t.enabled() {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := &types.L2ChainStatus{
+		Status:    t.status,
+		LastBlock: t.lastBlock,
+	}
+	if t.idleSince != nil {
+		idleSince := *t.idleSince
+		status.IdleSince = &idleSince
+	}
+	return status
+}
@@ -0,0 +1,86 @@
+package flows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2ChainHaltConfig_Validate(t *testing.T) {
+	require.NoError(t, L2ChainHaltConfig{}.Validate())
+	require.NoError(t, L2ChainHaltConfig{IdleThreshold: time.Minute}.Validate())
+	require.NoError(t, L2ChainHaltConfig{IdleThreshold: time.Minute, HaltAlertThreshold: time.Hour}.Validate())
+	require.ErrorContains(t, L2ChainHaltConfig{HaltAlertThreshold: time.Hour}.Validate(),
+		"requires L2IdleThreshold")
+	require.ErrorContains(t, L2ChainHaltConfig{IdleThreshold: time.Hour, HaltAlertThreshold: time.Minute}.Validate(),
+		"must be greater than L2IdleThreshold")
+}
+
+func TestL2ChainHaltTracker(t *testing.T) {
+	logger := log.WithFields("test", t.Name())
+	start := time.Now()
+
+	t.Run("disabled", func(t *testing.T) {
+		var nilTracker *l2ChainHaltTracker
+		require.True(t, nilTracker.noNewBlocks(logger, 10, 10, start))
+		nilTracker.newBlocks(logger, 11)
+		require.Nil(t, nilTracker.chainStatus())
+
+		sut := newL2ChainHaltTracker(L2ChainHaltConfig{})
+		require.True(t, sut.noNewBlocks(logger, 10, 10, start.Add(time.Hour)))
+		require.Nil(t, sut.chainStatus())
+	})
+
+	t.Run("idle, halted and active again", func(t *testing.T) {
+		sut := newL2ChainHaltTracker(L2ChainHaltConfig{IdleThreshold: time.Minute, HaltAlertThreshold: time.Hour})
+		require.Equal(t, &types.L2ChainStatus{Status: types.L2ChainActive}, sut.chainStatus())
+
+		require.True(t, sut.noNewBlocks(logger, 10, 10, start))
+		require.True(t, sut.noNewBlocks(logger, 10, 10, start.Add(30*time.Second)))
+		require.Equal(t, &types.L2ChainStatus{Status: types.L2ChainActive, LastBlock: 10, IdleSince: &start},
+			sut.chainStatus())
+
+		require.False(t, sut.noNewBlocks(logger, 10, 10, start.Add(time.Minute)))
+		require.Equal(t, types.L2ChainIdle, sut.chainStatus().Status)
+
+		require.False(t, sut.noNewBlocks(logger, 10, 10, start.Add(2*time.Hour)))
+		require.Equal(t, &types.L2ChainStatus{Status: types.L2ChainHalted, LastBlock: 10, IdleSince: &start},
+			sut.chainStatus())
+
+		sut.newBlocks(logger, 12)
+		require.Equal(t, &types.L2ChainStatus{Status: types.L2ChainActive, LastBlock: 12}, sut.chainStatus())
+	})
+
+	t.Run("the idle time restarts if the last block changes", func(t *testing.T) {
+		sut := newL2ChainHaltTracker(L2ChainHaltConfig{IdleThreshold: time.Minute})
+		require.True(t, sut.noNewBlocks(logger, 10, 12, start))
+		require.True(t, sut.noNewBlocks(logger, 11, 12, start.Add(time.Minute)))
+		require.False(t, sut.noNewBlocks(logger, 11, 12, start.Add(2*time.Minute)))
+		// without HaltAlertThreshold it's never halted
+		require.False(t, sut.noNewBlocks(logger, 11, 12, start.Add(48*time.Hour)))
+		require.Equal(t, types.L2ChainIdle, sut.chainStatus().Status)
+	})
+}
+
+func Test_baseFlow_GetCertificateBuildParamsInternalNoNewBlocks(t *testing.T) {
+	ctx := context.Background()
+	mockL2BridgeQuerier := mocks.NewBridgeQuerier(t)
+	mockStorage := mocks.NewAggSenderStorage(t)
+	sut := NewBaseFlow(log.WithFields("test", t.Name()), mockL2BridgeQuerier, mockStorage, nil, nil,
+		NewBaseFlowConfig(0, 0, false, 0, L2ChainHaltConfig{IdleThreshold: time.Nanosecond}))
+	mockL2BridgeQuerier.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(10), nil)
+	mockStorage.EXPECT().GetLastSentCertificateHeader().Return(&types.CertificateHeader{ToBlock: 10}, nil)
+
+	_, err := sut.GetCertificateBuildParamsInternal(ctx, types.CertificateTypePP)
+	require.ErrorIs(t, err, errNoNewBlocks)
+	time.Sleep(time.Millisecond)
+	_, err = sut.GetCertificateBuildParamsInternal(ctx, types.CertificateTypePP)
+	require.ErrorIs(t, err, errNoNewBlocks)
+	require.Equal(t, types.L2ChainIdle, sut.L2ChainStatus().Status)
+	require.Equal(t, uint64(10), sut.L2ChainStatus().LastBlock)
+}
@@ -0,0 +1,90 @@
+package flows
+
+import (
+	"context"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// tokenPolicyBridgeQuerier applies the token policy to the bridges and claims queried by the flows, so
+// the bridge exits and imported bridge exits of the excluded assets never reach the certificates
+type tokenPolicyBridgeQuerier struct {
+	types.BridgeQuerier
+	log    types.Logger
+	policy types.TokenPolicyConfig
+}
+
+// newTokenPolicyBridgeQuerier returns the querier as is if the token policy is disabled
+func newTokenPolicyBridgeQuerier(log types.Logger, querier types.BridgeQuerier,
+	policy types.TokenPolicyConfig) types.BridgeQuerier {
+	if !policy.IsEnabled() {
+		return querier
+	}
+	log.Infof("token policy for the certificate exits: %s", policy.String())
+	return &tokenPolicyBridgeQuerier{
+		BridgeQuerier: querier,
+		log:           log,
+		policy:        policy,
+	}
+}
+
+// GetBridgesAndClaims returns the bridges and claims of the block range not excluded by the token policy
+func (q *tokenPolicyBridgeQuerier) GetBridgesAndClaims(
+	ctx context.Context,
+	fromBlock, toBlock uint64,
+) ([]bridgesync.Bridge, []bridgesync.Claim, error) {
+	bridges, claims, err := q.BridgeQuerier.GetBridgesAndClaims(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(bridges) == 0 && len(claims) == 0 {
+		return bridges, claims, nil
+	}
+
+	includedBridges := make([]bridgesync.Bridge, 0, len(bridges))
+	for _, bridge := range bridges {
+		isMessage := bridge.LeafType == uint8(agglayertypes.LeafTypeMessage)
+		if reason := q.excludes(isMessage, bridge.OriginNetwork, bridge.OriginAddress); reason != "" {
+			q.log.Warnf("token policy: bridge exit excluded (%s): block %d, deposit count %d, "+
+				"origin network %d, token %s, amount %s", reason, bridge.BlockNum, bridge.DepositCount,
+				bridge.OriginNetwork, bridge.OriginAddress.Hex(), bridge.Amount)
+			continue
+		}
+		includedBridges = append(includedBridges, bridge)
+	}
+
+	includedClaims := make([]bridgesync.Claim, 0, len(claims))
+	for _, claim := range claims {
+		if reason := q.excludes(claim.IsMessage, claim.OriginNetwork, claim.OriginAddress); reason != "" {
+			q.log.Warnf("token policy: imported bridge exit excluded (%s): block %d, global index %s, "+
+				"origin network %d, token %s, amount %s", reason, claim.BlockNum, claim.GlobalIndex,
+				claim.OriginNetwork, claim.OriginAddress.Hex(), claim.Amount)
+			continue
+		}
+		includedClaims = append(includedClaims, claim)
+	}
+
+	if excluded := len(bridges) - len(includedBridges); excluded > 0 {
+		metrics.TokenPolicyExcluded(metrics.BridgeExitLabel, excluded)
+	}
+	if excluded := len(claims) - len(includedClaims); excluded > 0 {
+		metrics.TokenPolicyExcluded(metrics.ImportedBridgeExitLabel, excluded)
+	}
+
+	return includedBridges, includedClaims, nil
+}
+
+// excludes returns the reason why an exit is excluded, or an empty string if it's included.
+// The messages are always included
+func (q *tokenPolicyBridgeQuerier) excludes(isMessage bool, originNetwork uint32,
+	originToken common.Address) string {
+	if isMessage {
+		return ""
+	}
+	return q.policy.Excludes(originNetwork, originToken)
+}
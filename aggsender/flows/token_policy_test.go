@@ -0,0 +1,81 @@
+package flows
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenPolicyBridgeQuerier(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("module", "test")
+	deniedToken := common.HexToAddress("0xdead")
+	allowedToken := common.HexToAddress("0x1")
+	policy := types.TokenPolicyConfig{
+		DeniedTokens:         []common.Address{deniedToken},
+		DeniedOriginNetworks: []uint32{7},
+	}
+
+	t.Run("disabled policy returns the querier", func(t *testing.T) {
+		querier := mocks.NewBridgeQuerier(t)
+		require.Equal(t, querier, newTokenPolicyBridgeQuerier(logger, querier, types.TokenPolicyConfig{}))
+	})
+
+	t.Run("excludes the denied assets", func(t *testing.T) {
+		querier := mocks.NewBridgeQuerier(t)
+		bridges := []bridgesync.Bridge{
+			{BlockNum: 1, OriginAddress: allowedToken, Amount: big.NewInt(1)},
+			{BlockNum: 2, OriginAddress: deniedToken, Amount: big.NewInt(2)},
+			{BlockNum: 3, OriginNetwork: 7, OriginAddress: allowedToken, Amount: big.NewInt(3)},
+			// the messages are never excluded
+			{BlockNum: 4, LeafType: uint8(agglayertypes.LeafTypeMessage), OriginAddress: deniedToken},
+		}
+		claims := []bridgesync.Claim{
+			{BlockNum: 5, OriginAddress: deniedToken, GlobalIndex: big.NewInt(1)},
+			{BlockNum: 6, OriginAddress: allowedToken, GlobalIndex: big.NewInt(2)},
+			{BlockNum: 7, IsMessage: true, OriginNetwork: 7, GlobalIndex: big.NewInt(3)},
+		}
+		querier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return(bridges, claims, nil).Once()
+
+		policyQuerier := newTokenPolicyBridgeQuerier(logger, querier, policy)
+		actualBridges, actualClaims, err := policyQuerier.GetBridgesAndClaims(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Equal(t, []bridgesync.Bridge{bridges[0], bridges[3]}, actualBridges)
+		require.Equal(t, []bridgesync.Claim{claims[1], claims[2]}, actualClaims)
+	})
+
+	t.Run("no bridges and claims", func(t *testing.T) {
+		querier := mocks.NewBridgeQuerier(t)
+		querier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return(nil, nil, nil).Once()
+
+		actualBridges, actualClaims, err := newTokenPolicyBridgeQuerier(logger, querier, policy).
+			GetBridgesAndClaims(ctx, 1, 10)
+		require.NoError(t, err)
+		require.Nil(t, actualBridges)
+		require.Nil(t, actualClaims)
+	})
+
+	t.Run("error querying", func(t *testing.T) {
+		querier := mocks.NewBridgeQuerier(t)
+		querier.EXPECT().GetBridgesAndClaims(ctx, uint64(1), uint64(10)).Return(nil, nil, errors.New("some error")).Once()
+
+		_, _, err := newTokenPolicyBridgeQuerier(logger, querier, policy).GetBridgesAndClaims(ctx, 1, 10)
+		require.ErrorContains(t, err, "some error")
+	})
+
+	t.Run("the other queries are delegated", func(t *testing.T) {
+		querier := mocks.NewBridgeQuerier(t)
+		querier.EXPECT().OriginNetwork().Return(uint32(3)).Once()
+
+		require.Equal(t, uint32(3), newTokenPolicyBridgeQuerier(logger, querier, policy).OriginNetwork())
+	})
+}
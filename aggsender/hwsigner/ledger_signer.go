@@ -0,0 +1,271 @@
+package hwsigner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MethodLedger is the signer method of the Ledger hardware wallets, connected via USB/HID
+const MethodLedger signertypes.SignMethod = "ledger"
+
+const (
+	// FieldAddress is the address of the account of the Ledger, verified when it's derived
+	FieldAddress = "Address"
+	// FieldDerivationPath is the derivation path of the account (default m/44'/60'/0'/0/0)
+	FieldDerivationPath = "DerivationPath"
+	// FieldConfirmationTimeout is how long a signature waits for the confirmation on the device
+	FieldConfirmationTimeout = "ConfirmationTimeout"
+
+	// DefaultConfirmationTimeout is the default time to confirm a signature on the device
+	DefaultConfirmationTimeout = 2 * time.Minute
+
+	legacyVOffset = 27
+)
+
+var (
+	// ErrNotSupported is returned when signing something else than EIP-712 typed data, which is the only
+	// arbitrary data that the Ledger signs without the blind signing of raw hashes
+	ErrNotSupported = errors.New("the hardware wallet signer only signs EIP-712 typed data")
+	// ErrConfirmationTimeout is returned when the signature is not confirmed on the device in time
+	ErrConfirmationTimeout = errors.New("timeout waiting for the confirmation of the signature on the hardware wallet")
+	// ErrNotInitialized is returned when signing before Initialize
+	ErrNotInitialized = errors.New("the hardware wallet signer is not initialized")
+)
+
+var _ signertypes.Signer = (*LedgerSigner)(nil)
+
+// TypedDataSigner signs EIP-712 typed data given its domain separator and the hash of its struct. The
+// hardware wallets only sign the typed data, and show both hashes to the user on the device
+type TypedDataSigner interface {
+	SignTypedData(ctx context.Context, domainSeparator, structHash common.Hash) ([]byte, error)
+}
+
+// Wallet is the subset of accounts.Wallet used by the LedgerSigner
+type Wallet interface {
+	URL() accounts.URL
+	Open(passphrase string) error
+	Close() error
+	Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error)
+	SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error)
+}
+
+// LedgerConfig is the config of the LedgerSigner, from the fields of the SignerConfig
+type LedgerConfig struct {
+	Address             common.Address
+	DerivationPath      accounts.DerivationPath
+	ConfirmationTimeout time.Duration
+}
+
+// NewLedgerConfig parses the config of the LedgerSigner. The Address is required, so a wrong device or
+// derivation path is never used to sign
+func NewLedgerConfig(cfg signertypes.SignerConfig) (LedgerConfig, error) {
+	if cfg.Method != MethodLedger {
+		return LedgerConfig{}, fmt.Errorf("invalid signer method %s (expected %s)", cfg.Method, MethodLedger)
+	}
+	address, err := cfg.Get(FieldAddress)
+	if err != nil {
+		return LedgerConfig{}, err
+	}
+	if !common.IsHexAddress(address) {
+		return LedgerConfig{}, fmt.Errorf("invalid %s %q of the Ledger signer", FieldAddress, address)
+	}
+	result := LedgerConfig{
+		Address:             common.HexToAddress(address),
+		DerivationPath:      accounts.DefaultBaseDerivationPath,
+		ConfirmationTimeout: DefaultConfirmationTimeout,
+	}
+	if path, err := cfg.Get(FieldDerivationPath); err == nil && path != "" {
+		result.DerivationPath, err = accounts.ParseDerivationPath(path)
+		if err != nil {
+			return LedgerConfig{}, fmt.Errorf("invalid %s %q of the Ledger signer: %w", FieldDerivationPath, path, err)
+		}
+	}
+	if timeout, err := cfg.Get(FieldConfirmationTimeout); err == nil && timeout != "" {
+		result.ConfirmationTimeout, err = time.ParseDuration(timeout)
+		if err != nil {
+			return LedgerConfig{}, fmt.Errorf("invalid %s %q of the Ledger signer: %w",
+				FieldConfirmationTimeout, timeout, err)
+		}
+		if result.ConfirmationTimeout <= 0 {
+			return LedgerConfig{}, fmt.Errorf("%s of the Ledger signer must be greater than 0", FieldConfirmationTimeout)
+		}
+	}
+	return result, nil
+}
+
+// LedgerSigner signs the EIP-712 typed data with an account of a Ledger connected via USB/HID. Each
+// signature must be confirmed by the user on the device, so it's meant for the low-frequency signatures
+// (e.g. the optimistic mode attestations) of the operators that require hardware-held keys
+type LedgerSigner struct {
+	name        string
+	logger      *log.Logger
+	cfg         LedgerConfig
+	findWallets func() ([]Wallet, error)
+
+	wallet  Wallet
+	account accounts.Account
+}
+
+// NewLedgerSigner creates a LedgerSigner from the signer config. The device is opened by Initialize
+func NewLedgerSigner(name string, logger *log.Logger, signerCfg signertypes.SignerConfig) (*LedgerSigner, error) {
+	cfg, err := NewLedgerConfig(signerCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &LedgerSigner{
+		name:        name,
+		logger:      logger,
+		cfg:         cfg,
+		findWallets: findLedgerWallets,
+	}, nil
+}
+
+// Initialize opens the connected Ledgers and selects the one whose account at the derivation path is
+// the configured Address. The Ledger must be unlocked with the Ethereum app open
+func (s *LedgerSigner) Initialize(context.Context) error {
+	wallets, err := s.findWallets()
+	if err != nil {
+		return err
+	}
+	if len(wallets) == 0 {
+		return fmt.Errorf("signer %s: no Ledger connected (USB/HID)", s.name)
+	}
+	var errs []error
+	for _, wallet := range wallets {
+		account, err := openAccount(wallet, s.cfg.DerivationPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", wallet.URL(), err))
+			continue
+		}
+		if account.Address != s.cfg.Address {
+			errs = append(errs, fmt.Errorf("%s: the account at %s is %s", wallet.URL(),
+				s.cfg.DerivationPath.String(), account.Address.Hex()))
+			_ = wallet.Close()
+			continue
+		}
+		s.wallet = wallet
+		s.account = account
+		s.logger.Infof("signer %s: using the account %s at %s of the Ledger %s",
+			s.name, account.Address.Hex(), s.cfg.DerivationPath.String(), wallet.URL())
+		return nil
+	}
+	return fmt.Errorf("signer %s: no Ledger with the account %s at %s (is it unlocked with the Ethereum app open?): %w",
+		s.name, s.cfg.Address.Hex(), s.cfg.DerivationPath.String(), errors.Join(errs...))
+}
+
+// PublicAddress returns the configured address, verified on Initialize
+func (s *LedgerSigner) PublicAddress() common.Address {
+	return s.cfg.Address
+}
+
+// String returns a string representation of the signer
+func (s *LedgerSigner) String() string {
+	return fmt.Sprintf("ledger(address=%s, path=%s, confirmationTimeout=%s)",
+		s.cfg.Address.Hex(), s.cfg.DerivationPath.String(), s.cfg.ConfirmationTimeout)
+}
+
+// SignHash is not supported, the raw hashes can't be signed by the Ledger (use the EIP-712 typed data)
+func (s *LedgerSigner) SignHash(context.Context, common.Hash) ([]byte, error) {
+	return nil, ErrNotSupported
+}
+
+// SignTx is not supported, the hardware wallet signer is only used for the typed data signatures
+func (s *LedgerSigner) SignTx(context.Context, *types.Transaction) (*types.Transaction, error) {
+	return nil, ErrNotSupported
+}
+
+// SignTypedData asks the Ledger to sign the EIP-712 typed data, and waits until the user confirms it
+// on the device, up to the ConfirmationTimeout. The signature is checked against the address and
+// returned as [R || S || V] with the 0/1 recovery id, as the rest of the signers
+func (s *LedgerSigner) SignTypedData(ctx context.Context, domainSeparator, structHash common.Hash) ([]byte, error) {
+	if s.wallet == nil {
+		return nil, ErrNotInitialized
+	}
+	typedData := make([]byte, 0, 2+2*common.HashLength) //nolint:mnd
+	typedData = append(typedData, 0x19, 0x01)           //nolint:mnd
+	typedData = append(typedData, domainSeparator.Bytes()...)
+	typedData = append(typedData, structHash.Bytes()...)
+
+	s.logger.Infof("signer %s: confirm the signature on the Ledger %s within %s (domain hash: %s, message hash: %s)",
+		s.name, s.wallet.URL(), s.cfg.ConfirmationTimeout, domainSeparator.Hex(), structHash.Hex())
+	ctx, cancel := context.WithTimeoutCause(ctx, s.cfg.ConfirmationTimeout, ErrConfirmationTimeout)
+	defer cancel()
+	type signResult struct {
+		signature []byte
+		err       error
+	}
+	// the device doesn't support cancellation, so the request keeps waiting for the user in the background
+	done := make(chan signResult, 1)
+	go func() {
+		signature, err := s.wallet.SignData(s.account, accounts.MimetypeTypedData, typedData)
+		done <- signResult{signature: signature, err: err}
+	}()
+
+	var result signResult
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("signer %s: %w", s.name, context.Cause(ctx))
+	case result = <-done:
+	}
+	if result.err != nil {
+		return nil, fmt.Errorf("signer %s: the Ledger failed to sign (rejected by the user?): %w", s.name, result.err)
+	}
+	return s.checkSignature(crypto.Keccak256Hash(typedData), result.signature)
+}
+
+// checkSignature normalizes the V of the signature to the recovery id, and checks that it's from the address
+func (s *LedgerSigner) checkSignature(hash common.Hash, signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("signer %s: invalid signature length %d (expected %d)",
+			s.name, len(signature), crypto.SignatureLength)
+	}
+	signature = common.CopyBytes(signature)
+	if signature[crypto.RecoveryIDOffset] >= legacyVOffset {
+		signature[crypto.RecoveryIDOffset] -= legacyVOffset
+	}
+	pubKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return nil, fmt.Errorf("signer %s: invalid signature: %w", s.name, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != s.cfg.Address {
+		return nil, fmt.Errorf("signer %s: the signature is from %s instead of %s", s.name, signer.Hex(), s.cfg.Address.Hex())
+	}
+	return signature, nil
+}
+
+// openAccount opens the wallet and derives the account at the path
+func openAccount(wallet Wallet, path accounts.DerivationPath) (accounts.Account, error) {
+	if err := wallet.Open(""); err != nil && !errors.Is(err, accounts.ErrWalletAlreadyOpen) {
+		return accounts.Account{}, fmt.Errorf("error opening the wallet: %w", err)
+	}
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		_ = wallet.Close()
+		return accounts.Account{}, fmt.Errorf("error deriving the account at %s: %w", path.String(), err)
+	}
+	return account, nil
+}
+
+// findLedgerWallets returns the Ledgers connected via USB/HID
+func findLedgerWallets() ([]Wallet, error) {
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("error creating the Ledger hub (USB/HID): %w", err)
+	}
+	wallets := hub.Wallets()
+	result := make([]Wallet, 0, len(wallets))
+	for _, wallet := range wallets {
+		result = append(result, wallet)
+	}
+	return result, nil
+}
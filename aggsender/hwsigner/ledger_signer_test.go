@@ -0,0 +1,238 @@
+package hwsigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLedger signs the typed data as the Ledger does: keccak256(data) with V as 27/28
+type fakeLedger struct {
+	key     *ecdsa.PrivateKey
+	openErr error
+	signErr error
+	// confirm blocks the signature until it's closed, as a user that doesn't confirm it
+	confirm chan struct{}
+	closed  bool
+}
+
+func newFakeLedger(t *testing.T) *fakeLedger {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return &fakeLedger{key: key}
+}
+
+func (f *fakeLedger) address() common.Address {
+	return crypto.PubkeyToAddress(f.key.PublicKey)
+}
+
+func (f *fakeLedger) URL() accounts.URL {
+	return accounts.URL{Scheme: usbwallet.LedgerScheme, Path: f.address().Hex()}
+}
+
+func (f *fakeLedger) Open(string) error {
+	return f.openErr
+}
+
+func (f *fakeLedger) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeLedger) Derive(accounts.DerivationPath, bool) (accounts.Account, error) {
+	return accounts.Account{Address: f.address()}, nil
+}
+
+func (f *fakeLedger) SignData(_ accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	if f.confirm != nil {
+		<-f.confirm
+	}
+	if f.signErr != nil {
+		return nil, f.signErr
+	}
+	if mimeType != accounts.MimetypeTypedData {
+		return nil, accounts.ErrNotSupported
+	}
+	signature, err := crypto.Sign(crypto.Keccak256(data), f.key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += legacyVOffset
+	return signature, nil
+}
+
+func newTestLedgerSigner(t *testing.T, address common.Address, wallets ...Wallet) *LedgerSigner {
+	t.Helper()
+	signer, err := NewLedgerSigner("test", log.WithFields("test", "ledger"), signertypes.SignerConfig{
+		Method: MethodLedger,
+		Config: map[string]any{FieldAddress: address.Hex(), FieldConfirmationTimeout: "100ms"},
+	})
+	require.NoError(t, err)
+	signer.findWallets = func() ([]Wallet, error) { return wallets, nil }
+	return signer
+}
+
+func TestNewLedgerConfig(t *testing.T) {
+	address := common.HexToAddress("0x1234")
+	tests := []struct {
+		name     string
+		cfg      signertypes.SignerConfig
+		expected LedgerConfig
+		errMsg   string
+	}{
+		{
+			name: "defaults",
+			cfg:  signertypes.SignerConfig{Method: MethodLedger, Config: map[string]any{"address": address.Hex()}},
+			expected: LedgerConfig{
+				Address:             address,
+				DerivationPath:      accounts.DefaultBaseDerivationPath,
+				ConfirmationTimeout: DefaultConfirmationTimeout,
+			},
+		},
+		{
+			name: "all the fields",
+			cfg: signertypes.SignerConfig{Method: MethodLedger, Config: map[string]any{
+				FieldAddress: address.Hex(), FieldDerivationPath: "m/44'/60'/1'/0/2", FieldConfirmationTimeout: "5m",
+			}},
+			expected: LedgerConfig{
+				Address:             address,
+				DerivationPath:      accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 1, 0, 2},
+				ConfirmationTimeout: 5 * time.Minute,
+			},
+		},
+		{
+			name:   "other method",
+			cfg:    signertypes.SignerConfig{Method: signertypes.MethodLocal},
+			errMsg: "invalid signer method local",
+		},
+		{
+			name:   "no address",
+			cfg:    signertypes.SignerConfig{Method: MethodLedger},
+			errMsg: "key Address not found",
+		},
+		{
+			name:   "invalid address",
+			cfg:    signertypes.SignerConfig{Method: MethodLedger, Config: map[string]any{FieldAddress: "0xzz"}},
+			errMsg: "invalid Address",
+		},
+		{
+			name: "invalid derivation path",
+			cfg: signertypes.SignerConfig{Method: MethodLedger, Config: map[string]any{
+				FieldAddress: address.Hex(), FieldDerivationPath: "m/x",
+			}},
+			errMsg: "invalid DerivationPath",
+		},
+		{
+			name: "invalid timeout",
+			cfg: signertypes.SignerConfig{Method: MethodLedger, Config: map[string]any{
+				FieldAddress: address.Hex(), FieldConfirmationTimeout: "0s",
+			}},
+			errMsg: "ConfirmationTimeout of the Ledger signer must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLedgerConfig(tt.cfg)
+			if tt.errMsg != "" {
+				require.ErrorContains(t, err, tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestLedgerSignerInitialize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no Ledger connected", func(t *testing.T) {
+		signer := newTestLedgerSigner(t, common.HexToAddress("0x1234"))
+		require.ErrorContains(t, signer.Initialize(ctx), "no Ledger connected")
+	})
+
+	t.Run("the Ledger with the address is selected", func(t *testing.T) {
+		other := newFakeLedger(t)
+		locked := newFakeLedger(t)
+		locked.openErr = errors.New("ledger: Ethereum app offline")
+		ledger := newFakeLedger(t)
+		signer := newTestLedgerSigner(t, ledger.address(), other, locked, ledger)
+		require.NoError(t, signer.Initialize(ctx))
+		require.Equal(t, ledger.address(), signer.PublicAddress())
+		require.True(t, other.closed)
+		require.False(t, ledger.closed)
+	})
+
+	t.Run("no Ledger with the address", func(t *testing.T) {
+		other := newFakeLedger(t)
+		signer := newTestLedgerSigner(t, common.HexToAddress("0x1234"), other)
+		err := signer.Initialize(ctx)
+		require.ErrorContains(t, err, "no Ledger with the account "+common.HexToAddress("0x1234").Hex())
+		require.ErrorContains(t, err, "the account at m/44'/60'/0'/0/0 is "+other.address().Hex())
+	})
+}
+
+func TestLedgerSignerSignTypedData(t *testing.T) {
+	ctx := context.Background()
+	domainSeparator := common.HexToHash("0xd0")
+	structHash := common.HexToHash("0x5e")
+	typedDataHash := crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes())
+
+	ledger := newFakeLedger(t)
+	signer := newTestLedgerSigner(t, ledger.address(), ledger)
+	_, err := signer.SignTypedData(ctx, domainSeparator, structHash)
+	require.ErrorIs(t, err, ErrNotInitialized)
+	require.NoError(t, signer.Initialize(ctx))
+
+	t.Run("signed", func(t *testing.T) {
+		signature, err := signer.SignTypedData(ctx, domainSeparator, structHash)
+		require.NoError(t, err)
+		require.Less(t, signature[crypto.RecoveryIDOffset], byte(legacyVOffset))
+		pubKey, err := crypto.SigToPub(typedDataHash, signature)
+		require.NoError(t, err)
+		require.Equal(t, ledger.address(), crypto.PubkeyToAddress(*pubKey))
+	})
+
+	t.Run("rejected by the user", func(t *testing.T) {
+		ledger.signErr = errors.New("ledger: denied by user")
+		defer func() { ledger.signErr = nil }()
+		_, err := signer.SignTypedData(ctx, domainSeparator, structHash)
+		require.ErrorContains(t, err, "denied by user")
+	})
+
+	t.Run("not confirmed in time", func(t *testing.T) {
+		ledger.confirm = make(chan struct{})
+		defer func() {
+			close(ledger.confirm)
+			ledger.confirm = nil
+		}()
+		_, err := signer.SignTypedData(ctx, domainSeparator, structHash)
+		require.ErrorIs(t, err, ErrConfirmationTimeout)
+	})
+
+	t.Run("signed by another key", func(t *testing.T) {
+		other := newFakeLedger(t)
+		signer.wallet = other
+		defer func() { signer.wallet = ledger }()
+		_, err := signer.SignTypedData(ctx, domainSeparator, structHash)
+		require.ErrorContains(t, err, "the signature is from "+other.address().Hex())
+	})
+
+	t.Run("hashes and txs are not signed", func(t *testing.T) {
+		_, err := signer.SignHash(ctx, common.BytesToHash(typedDataHash))
+		require.ErrorIs(t, err, ErrNotSupported)
+		_, err = signer.SignTx(ctx, nil)
+		require.ErrorIs(t, err, ErrNotSupported)
+	})
+}
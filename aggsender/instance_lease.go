@@ -0,0 +1,163 @@
+package aggsender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/db"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+)
+
+// ErrAnotherInstanceDetected is returned when another aggsender instance is sending certificates
+// for the same network
+var ErrAnotherInstanceDetected = errors.New("another aggsender instance is sending certificates for this network")
+
+// instanceLease keeps the lease of the storage for this aggsender instance, so two instances
+// configured with the same storage can't send certificates at the same time. The lease is renewed
+// every third of its TTL, and it's released when the context is done
+type instanceLease struct {
+	log     aggkitcommon.Logger
+	storage db.AggSenderStorage
+	ownerID string
+	ttl     time.Duration
+
+	mu sync.RWMutex
+	// expiresAt is the expiration of the lease held by this instance
+	expiresAt time.Time
+	// lostErr is set if another instance took the lease
+	lostErr error
+}
+
+func newInstanceLease(logger aggkitcommon.Logger, storage db.AggSenderStorage,
+	ttl time.Duration) (*instanceLease, error) {
+	ownerID, err := newInstanceOwnerID()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the instance lease owner ID: %w", err)
+	}
+	return &instanceLease{
+		log:     logger,
+		storage: storage,
+		ownerID: ownerID,
+		ttl:     ttl,
+	}, nil
+}
+
+// newInstanceOwnerID returns an ID that identifies this process: hostname, pid and a random suffix
+// (the pid is not enough inside containers)
+func newInstanceOwnerID() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	suffix := make([]byte, 4) //nolint:mnd
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix)), nil
+}
+
+// acquire acquires (or renews) the lease. It returns an error if another instance holds it
+func (l *instanceLease) acquire(ctx context.Context) error {
+	now := time.Now()
+	lease, err := l.storage.AcquireInstanceLease(ctx, l.ownerID, now, l.ttl)
+	if err != nil {
+		if errors.Is(err, db.ErrInstanceLeaseHeld) {
+			l.mu.Lock()
+			l.lostErr = err
+			l.mu.Unlock()
+		}
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expiresAt = time.Unix(int64(lease.ExpiresAt), 0)
+	l.lostErr = nil
+	return nil
+}
+
+// start renews the lease periodically until the context is done, then it releases the lease
+func (l *instanceLease) start(ctx context.Context) {
+	ticker := time.NewTicker(l.ttl / 3) //nolint:mnd
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.release()
+			return
+		case <-ticker.C:
+			if err := l.acquire(ctx); err != nil {
+				if errors.Is(err, db.ErrInstanceLeaseHeld) {
+					l.log.Errorf("instance lease lost, no new certificates are going to be sent: %v", err)
+				} else {
+					l.log.Warnf("error renewing the instance lease: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// check returns an error if this instance doesn't hold a valid lease
+func (l *instanceLease) check() error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.lostErr != nil {
+		return fmt.Errorf("%w: %w", ErrAnotherInstanceDetected, l.lostErr)
+	}
+	if time.Now().After(l.expiresAt) {
+		return fmt.Errorf("the instance lease (%s) expired at %s and couldn't be renewed",
+			l.ownerID, l.expiresAt.UTC())
+	}
+	return nil
+}
+
+func (l *instanceLease) release() {
+	// the context is done, so the lease is released with a new one
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5) //nolint:mnd
+	defer cancel()
+	if err := l.storage.ReleaseInstanceLease(ctx, l.ownerID); err != nil {
+		l.log.Warnf("error releasing the instance lease: %v", err)
+		return
+	}
+	l.log.Infof("instance lease %s released", l.ownerID)
+}
+
+// checkAgglayerLastCertificate returns an error if the last certificate known by the agglayer for
+// the network was not sent by this instance (it's more recent than the last one sent)
+func (a *AggSender) checkAgglayerLastCertificate(ctx context.Context) error {
+	lastSent, err := a.storage.GetLastSentCertificateHeader()
+	if err != nil {
+		return fmt.Errorf("error getting the last sent certificate: %w", err)
+	}
+	pending, err := a.aggLayerClient.GetLatestKnownCertificate(ctx, a.l2OriginNetwork)
+	if err != nil {
+		return fmt.Errorf("error getting the latest pending certificate from agglayer: %w", err)
+	}
+	settled, err := a.aggLayerClient.GetLatestSettledCertificate(ctx, a.l2OriginNetwork)
+	if err != nil {
+		return fmt.Errorf("error getting the latest settled certificate from agglayer: %w", err)
+	}
+
+	agglayerLast := pending
+	if agglayerLast == nil || (settled != nil && settled.Height > agglayerLast.Height) {
+		agglayerLast = settled
+	}
+	if agglayerLast == nil {
+		return nil
+	}
+	if lastSent == nil {
+		return fmt.Errorf("%w: the agglayer has the certificate %s (height %d) but this instance has sent none",
+			ErrAnotherInstanceDetected, agglayerLast.CertificateID.Hex(), agglayerLast.Height)
+	}
+	if agglayerLast.CertificateID != lastSent.CertificateID && agglayerLast.Height >= lastSent.Height {
+		return fmt.Errorf("%w: the agglayer has the certificate %s (height %d) that was not sent by "+
+			"this instance (last sent: %s)", ErrAnotherInstanceDetected, agglayerLast.CertificateID.Hex(),
+			agglayerLast.Height, lastSent.ID())
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package aggsender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+// ErrLocalExitRootMismatch is returned when the new local exit root of a certificate doesn't match the
+// local exit root of the L2 bridge contract at the last block of the certificate
+var ErrLocalExitRootMismatch = errors.New("the certificate local exit root doesn't match the bridge contract")
+
+// checkLocalExitRootAgainstContract compares the new local exit root of the certificate, computed from
+// the tree of the L2 bridge syncer, with the local exit root of the L2 bridge contract at the ToBlock of
+// the certificate. A mismatch means that the syncer is missing (or has extra) bridges, so the certificate
+// is not sent instead of letting the agglayer reject it
+func (a *AggSender) checkLocalExitRootAgainstContract(ctx context.Context,
+	certificate *agglayertypes.Certificate, params *types.CertificateBuildParams) error {
+	contractRoot, contractDepositCount, err := a.l2Syncer.GetContractLocalExitRoot(ctx, params.ToBlock)
+	if err != nil {
+		return fmt.Errorf("error getting the local exit root of the bridge contract at block %d: %w",
+			params.ToBlock, err)
+	}
+	if contractDepositCount == 0 {
+		// there are no bridges yet, the certificate has the empty local exit root
+		return nil
+	}
+	if contractRoot == certificate.NewLocalExitRoot {
+		return nil
+	}
+
+	certBridges := "no bridges"
+	if params.NumberOfBridges() > 0 {
+		certBridges = fmt.Sprintf("%d deposits", params.MaxDepositCount()+1)
+	}
+	var syncerRoot string
+	if root, err := a.l2Syncer.GetExitRootByIndex(ctx, contractDepositCount-1); err != nil {
+		syncerRoot = fmt.Sprintf("error: %v", err)
+	} else {
+		syncerRoot = root.Hash.Hex()
+	}
+	return fmt.Errorf("%w: certificate height %d (blocks %d-%d) has the local exit root %s (%s), "+
+		"but the bridge contract has the local exit root %s and %d deposits at block %d. "+
+		"The root of the syncer for %d deposits is %s",
+		ErrLocalExitRootMismatch, certificate.Height, params.FromBlock, params.ToBlock,
+		certificate.NewLocalExitRoot.Hex(), certBridges, contractRoot.Hex(), contractDepositCount,
+		params.ToBlock, contractDepositCount, syncerRoot)
+}
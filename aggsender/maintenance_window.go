@@ -0,0 +1,108 @@
+package aggsender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/healthcheck"
+)
+
+// scheduledMaintenanceWindow is a recurring window of the given duration starting on each time of the schedule
+type scheduledMaintenanceWindow struct {
+	schedule *aggkitcommon.CronSchedule
+	duration time.Duration
+}
+
+// maintenanceWindows pauses the submission of new certificates during the scheduled maintenance windows,
+// so the operators don't have to stop the aggsender around the L1 upgrades. The status of the sent
+// certificates is still checked, and the submissions are resumed at the end of the window. A nil value
+// is disabled
+type maintenanceWindows struct {
+	windows []scheduledMaintenanceWindow
+}
+
+// newMaintenanceWindows returns nil if there are no windows
+func newMaintenanceWindows(cfg types.MaintenanceWindowsConfig) (*maintenanceWindows, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	m := &maintenanceWindows{windows: make([]scheduledMaintenanceWindow, 0, len(cfg))}
+	for i, window := range cfg {
+		schedule, err := aggkitcommon.ParseCronSchedule(window.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MaintenanceWindows[%d].Schedule: %w", i, err)
+		}
+		m.windows = append(m.windows, scheduledMaintenanceWindow{schedule: schedule, duration: window.Duration.Duration})
+	}
+	return m, nil
+}
+
+// status returns the active window (the one that ends last if several are active), or the next one to start
+func (m *maintenanceWindows) status(now time.Time) *types.MaintenanceWindowStatus {
+	if m == nil {
+		return nil
+	}
+	var active, next *types.MaintenanceWindowStatus
+	for _, window := range m.windows {
+		// the last start of the window before now, if the window hasn't ended yet
+		var lastStart time.Time
+		for start := window.schedule.Next(now.Add(-window.duration)); !start.IsZero() && !start.After(now); {
+			lastStart = start
+			start = window.schedule.Next(start)
+		}
+		if !lastStart.IsZero() {
+			end := lastStart.Add(window.duration)
+			if active == nil || end.After(active.End) {
+				active = &types.MaintenanceWindowStatus{
+					Schedule: window.schedule.String(), Active: true, Start: lastStart, End: end,
+				}
+			}
+			continue
+		}
+		start := window.schedule.Next(now)
+		if !start.IsZero() && (next == nil || start.Before(next.Start)) {
+			next = &types.MaintenanceWindowStatus{
+				Schedule: window.schedule.String(), Start: start, End: start.Add(window.duration),
+			}
+		}
+	}
+	if active != nil {
+		return active
+	}
+	return next
+}
+
+// active returns the active window, and false if the submissions are not paused
+func (m *maintenanceWindows) active(now time.Time) (*types.MaintenanceWindowStatus, bool) {
+	status := m.status(now)
+	if status == nil || !status.Active {
+		if m != nil {
+			metrics.MaintenanceWindow(false)
+		}
+		return nil, false
+	}
+	metrics.MaintenanceWindow(true)
+	return status, true
+}
+
+// registerHealth reports the windows in the health endpoint
+func (m *maintenanceWindows) registerHealth() {
+	if m == nil {
+		return
+	}
+	healthcheck.RegisterMaintenanceWindows("aggsender", func(now time.Time) *healthcheck.MaintenanceWindow {
+		status := m.status(now)
+		if status == nil {
+			return nil
+		}
+		return &healthcheck.MaintenanceWindow{
+			Schedule: status.Schedule, Active: status.Active, Start: status.Start, End: status.End,
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/prometheus"
 	prometheusClient "github.com/prometheus/client_golang/prometheus"
@@ -14,6 +16,42 @@ const (
 	numberOfCertificatesSettled = prefix + "number_of_sending_settled"
 	certificateBuildTime        = prefix + "certificate_build_time"
 	proverTime                  = prefix + "prover_time"
+	storageReadDuration         = prefix + "storage_read_duration_seconds"
+	storageWriteDuration        = prefix + "storage_write_duration_seconds"
+	storageErrors               = prefix + "storage_errors_total"
+	certificateEstimatedFee     = prefix + "certificate_estimated_fee"
+	feeSpentEpoch               = prefix + "fee_spent_epoch"
+	feeSpentDay                 = prefix + "fee_spent_day"
+	feeBudgetExhausted          = prefix + "fee_budget_exhausted_total"
+	epochRollovers              = prefix + "epoch_rollovers_total"
+	l2ChainStatus               = prefix + "l2_chain_status"
+	l2ChainIdleTime             = prefix + "l2_chain_idle_seconds"
+	agglayerMaintenance         = prefix + "agglayer_maintenance"
+	agglayerMaintenanceTime     = prefix + "agglayer_maintenance_seconds"
+	agglayerMaintenanceDeferred = prefix + "agglayer_maintenance_deferred_submissions_total"
+	maintenanceWindow           = prefix + "maintenance_window"
+	proofSize                   = prefix + "proof_size_bytes"
+	proverTimePercentile        = prefix + "prover_time_percentile_seconds"
+	proofSizePercentile         = prefix + "proof_size_percentile_bytes"
+	proverSLOBreached           = prefix + "prover_slo_breached"
+	proverSLOAlerts             = prefix + "prover_slo_alerts_total"
+	tokenPolicyExcluded         = prefix + "token_policy_excluded_total"
+	certificateApprovals        = prefix + "certificate_approvals_total"
+	dataAvailabilityChecks      = prefix + "data_availability_checks_total"
+	settlementTime              = prefix + "certificate_settlement_seconds"
+	settlementEpochs            = prefix + "certificate_settlement_epochs"
+	settledCurrentEpoch         = prefix + "certificates_settled_current_epoch"
+	settledPreviousEpoch        = prefix + "certificates_settled_previous_epoch"
+
+	storageOperationLabel = "operation"
+	feeBudgetPeriodLabel  = "period"
+	exitTypeLabel         = "exit"
+	approvalResultLabel   = "result"
+	daResultLabel         = "result"
+
+	// BridgeExitLabel and ImportedBridgeExitLabel are the values of the exit label
+	BridgeExitLabel         = "bridge_exit"
+	ImportedBridgeExitLabel = "imported_bridge_exit"
 )
 
 // Register the metrics for the aggsender package
@@ -43,8 +81,138 @@ func Register() {
 			Name: proverTime,
 			Help: "[AGGSENDER] prover time",
 		},
+		{
+			Name: certificateEstimatedFee,
+			Help: "[AGGSENDER] estimated fee (wei) of the last certificate to send",
+		},
+		{
+			Name: feeSpentEpoch,
+			Help: "[AGGSENDER] estimated fees (wei) of the certificates sent in the current epoch",
+		},
+		{
+			Name: feeSpentDay,
+			Help: "[AGGSENDER] estimated fees (wei) of the certificates sent in the last 24 hours",
+		},
+		{
+			Name: l2ChainStatus,
+			Help: "[AGGSENDER] status of the L2 chain (0 = active, 1 = idle, 2 = halted)",
+		},
+		{
+			Name: l2ChainIdleTime,
+			Help: "[AGGSENDER] seconds without new L2 blocks beyond the last certified block",
+		},
+		{
+			Name: agglayerMaintenance,
+			Help: "[AGGSENDER] 1 if the agglayer is in maintenance and the submissions are paused, 0 otherwise",
+		},
+		{
+			Name: agglayerMaintenanceTime,
+			Help: "[AGGSENDER] seconds since the agglayer entered in maintenance",
+		},
+		{
+			Name: maintenanceWindow,
+			Help: "[AGGSENDER] 1 during a scheduled maintenance window (no new submissions), 0 otherwise",
+		},
+		{
+			Name: proofSize,
+			Help: "[AGGSENDER] size in bytes of the last aggchain proof",
+		},
+		{
+			Name: proverTimePercentile,
+			Help: "[AGGSENDER] configured percentile of the proving time of the last proofs, in seconds",
+		},
+		{
+			Name: proofSizePercentile,
+			Help: "[AGGSENDER] configured percentile of the size of the last proofs, in bytes",
+		},
+		{
+			Name: proverSLOBreached,
+			Help: "[AGGSENDER] 1 if the percentiles of the prover exceed the SLOs, 0 otherwise",
+		},
+		{
+			Name: settledCurrentEpoch,
+			Help: "[AGGSENDER] number of certificates settled in the current epoch",
+		},
+		{
+			Name: settledPreviousEpoch,
+			Help: "[AGGSENDER] number of certificates settled in the previous epoch",
+		},
 	}
 	prometheus.RegisterGauges(gauges...)
+	prometheus.RegisterHistograms(prometheusClient.HistogramOpts{
+		Name:    settlementTime,
+		Help:    "[AGGSENDER] time from the start of the build of the certificates to their settlement",
+		Buckets: prometheusClient.ExponentialBuckets(60, 2, 10), //nolint:mnd
+	}, prometheusClient.HistogramOpts{
+		Name:    settlementEpochs,
+		Help:    "[AGGSENDER] number of epochs elapsed from the start of the build of the certificates to their settlement",
+		Buckets: prometheusClient.LinearBuckets(0, 1, 6), //nolint:mnd
+	})
+	prometheus.RegisterHistogramVecs(
+		prometheus.HistogramVecOpts{
+			HistogramOpts: prometheusClient.HistogramOpts{
+				Name:    storageReadDuration,
+				Help:    "[AGGSENDER] duration of the storage reads",
+				Buckets: prometheusClient.ExponentialBuckets(0.001, 4, 8), //nolint:mnd
+			},
+			Labels: []string{storageOperationLabel},
+		},
+		prometheus.HistogramVecOpts{
+			HistogramOpts: prometheusClient.HistogramOpts{
+				Name:    storageWriteDuration,
+				Help:    "[AGGSENDER] duration of the storage write transactions (including the commit)",
+				Buckets: prometheusClient.ExponentialBuckets(0.001, 4, 8), //nolint:mnd
+			},
+			Labels: []string{storageOperationLabel},
+		},
+	)
+	prometheus.RegisterCounters(prometheusClient.CounterOpts{
+		Name: epochRollovers,
+		Help: "[AGGSENDER] number of epochs in which a certificate submitted in a previous epoch was still pending",
+	}, prometheusClient.CounterOpts{
+		Name: agglayerMaintenanceDeferred,
+		Help: "[AGGSENDER] number of certificate submissions deferred because the agglayer was in maintenance",
+	}, prometheusClient.CounterOpts{
+		Name: proverSLOAlerts,
+		Help: "[AGGSENDER] number of times the percentiles of the prover have exceeded the SLOs",
+	})
+	prometheus.RegisterCounterVecs(
+		prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: storageErrors,
+				Help: "[AGGSENDER] number of failed storage operations",
+			},
+			Labels: []string{storageOperationLabel},
+		},
+		prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: feeBudgetExhausted,
+				Help: "[AGGSENDER] number of certificates not sent because the fee budget was exhausted",
+			},
+			Labels: []string{feeBudgetPeriodLabel},
+		},
+		prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: tokenPolicyExcluded,
+				Help: "[AGGSENDER] number of bridge exits and imported bridge exits excluded by the token policy",
+			},
+			Labels: []string{exitTypeLabel},
+		},
+		prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: certificateApprovals,
+				Help: "[AGGSENDER] number of certificates submitted to the approval hook, by result",
+			},
+			Labels: []string{approvalResultLabel},
+		},
+		prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: dataAvailabilityChecks,
+				Help: "[AGGSENDER] number of data availability checks of the block ranges, by result",
+			},
+			Labels: []string{daResultLabel},
+		},
+	)
 	log.Info("Registered prometheus aggsender metrics")
 }
 
@@ -77,3 +245,130 @@ func CertificateBuildTime(value float64) {
 func ProverTime(value float64) {
 	prometheus.GaugeSet(proverTime, value)
 }
+
+// StorageRead observes the duration of a storage read
+func StorageRead(operation string, duration time.Duration) {
+	prometheus.HistogramVecObserve(storageReadDuration, operation, duration.Seconds())
+}
+
+// StorageWrite observes the duration of a storage write transaction
+func StorageWrite(operation string, duration time.Duration) {
+	prometheus.HistogramVecObserve(storageWriteDuration, operation, duration.Seconds())
+}
+
+// StorageError increments the counter of failed storage operations
+func StorageError(operation string) {
+	prometheus.CounterVecInc(storageErrors, operation)
+}
+
+// CertificateEstimatedFee sets the gauge for the estimated fee of the last certificate to send
+func CertificateEstimatedFee(fee uint64) {
+	prometheus.GaugeSet(certificateEstimatedFee, float64(fee))
+}
+
+// FeesSpent sets the gauges for the fees spent in the current epoch and in the last 24 hours
+func FeesSpent(epochFees, dayFees uint64) {
+	prometheus.GaugeSet(feeSpentEpoch, float64(epochFees))
+	prometheus.GaugeSet(feeSpentDay, float64(dayFees))
+}
+
+// FeeBudgetExhausted increments the counter of certificates not sent because the budget of
+// the period (epoch or day) was exhausted
+func FeeBudgetExhausted(period string) {
+	prometheus.CounterVecInc(feeBudgetExhausted, period)
+}
+
+// EpochRollover increments the counter of epochs in which a certificate submitted in a previous
+// epoch was still pending
+func EpochRollover() {
+	prometheus.CounterInc(epochRollovers)
+}
+
+// L2ChainStatus sets the gauges for the status of the L2 chain and the seconds without new blocks
+func L2ChainStatus(status, idleSeconds float64) {
+	prometheus.GaugeSet(l2ChainStatus, status)
+	prometheus.GaugeSet(l2ChainIdleTime, idleSeconds)
+}
+
+// AgglayerMaintenance sets the gauges for the maintenance of the agglayer and the seconds since it started
+func AgglayerMaintenance(inMaintenance bool, seconds float64) {
+	value := 0.0
+	if inMaintenance {
+		value = 1
+	}
+	prometheus.GaugeSet(agglayerMaintenance, value)
+	prometheus.GaugeSet(agglayerMaintenanceTime, seconds)
+}
+
+// AgglayerMaintenanceDeferred increments the counter of submissions deferred by the agglayer maintenance
+func AgglayerMaintenanceDeferred() {
+	prometheus.CounterInc(agglayerMaintenanceDeferred)
+}
+
+// MaintenanceWindow sets the gauge for the scheduled maintenance windows of the aggsender
+func MaintenanceWindow(active bool) {
+	value := 0.0
+	if active {
+		value = 1
+	}
+	prometheus.GaugeSet(maintenanceWindow, value)
+}
+
+// ProofSize sets the gauge for the size of the last aggchain proof
+func ProofSize(size uint64) {
+	prometheus.GaugeSet(proofSize, float64(size))
+}
+
+// ProverPercentiles sets the gauges for the percentiles of the proving time and the proof size
+func ProverPercentiles(provingTimeSeconds float64, size uint64) {
+	prometheus.GaugeSet(proverTimePercentile, provingTimeSeconds)
+	prometheus.GaugeSet(proofSizePercentile, float64(size))
+}
+
+// ProverSLOBreached sets the gauge for the breach of the prover SLOs, and increments the counter of
+// alerts if it's a new breach
+func ProverSLOBreached(breached, newBreach bool) {
+	value := 0.0
+	if breached {
+		value = 1
+	}
+	prometheus.GaugeSet(proverSLOBreached, value)
+	if newBreach {
+		prometheus.CounterInc(proverSLOAlerts)
+	}
+}
+
+// TokenPolicyExcluded adds the exits (bridge exits or imported bridge exits) excluded by the token policy
+func TokenPolicyExcluded(exitType string, count int) {
+	prometheus.CounterVecAdd(tokenPolicyExcluded, exitType, float64(count))
+}
+
+// CertificateApproval increments the counter of certificates submitted to the approval hook with the
+// result (approved, rejected or failed)
+func CertificateApproval(result string) {
+	prometheus.CounterVecInc(certificateApprovals, result)
+}
+
+// DataAvailabilityCheck increments the counter of data availability checks with the result
+// (available, partial, unavailable or failed)
+func DataAvailabilityCheck(result string) {
+	prometheus.CounterVecInc(dataAvailabilityChecks, result)
+}
+
+// CertificateSettled observes the time from the start of the build of a certificate to its settlement
+func CertificateSettled(elapsed time.Duration) {
+	prometheus.HistogramObserve(settlementTime, elapsed.Seconds())
+}
+
+// CertificateSettledEpochs observes the number of epochs elapsed from the start of the build of a
+// certificate to its settlement
+func CertificateSettledEpochs(epochs uint64) {
+	prometheus.HistogramObserve(settlementEpochs, float64(epochs))
+}
+
+// CertificatesSettledPerEpoch sets the gauges for the number of certificates settled in the current epoch
+// and in the previous one
+func CertificatesSettledPerEpoch(current, previous int) {
+	prometheus.GaugeSet(settledCurrentEpoch, float64(current))
+	prometheus.GaugeSet(settledPreviousEpoch, float64(previous))
+}
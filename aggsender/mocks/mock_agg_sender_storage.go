@@ -12,6 +12,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/agglayer/aggkit/aggsender/types"
 )
 
@@ -28,6 +30,67 @@ func (_m *AggSenderStorage) EXPECT() *AggSenderStorage_Expecter {
 	return &AggSenderStorage_Expecter{mock: &_m.Mock}
 }
 
+// AcquireInstanceLease provides a mock function with given fields: ctx, ownerID, now, ttl
+func (_m *AggSenderStorage) AcquireInstanceLease(ctx context.Context, ownerID string, now time.Time, ttl time.Duration) (*db.InstanceLease, error) {
+	ret := _m.Called(ctx, ownerID, now, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireInstanceLease")
+	}
+
+	var r0 *db.InstanceLease
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (*db.InstanceLease, error)); ok {
+		return rf(ctx, ownerID, now, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) *db.InstanceLease); ok {
+		r0 = rf(ctx, ownerID, now, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.InstanceLease)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, ownerID, now, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_AcquireInstanceLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireInstanceLease'
+type AggSenderStorage_AcquireInstanceLease_Call struct {
+	*mock.Call
+}
+
+// AcquireInstanceLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+//   - now time.Time
+//   - ttl time.Duration
+func (_e *AggSenderStorage_Expecter) AcquireInstanceLease(ctx interface{}, ownerID interface{}, now interface{}, ttl interface{}) *AggSenderStorage_AcquireInstanceLease_Call {
+	return &AggSenderStorage_AcquireInstanceLease_Call{Call: _e.mock.On("AcquireInstanceLease", ctx, ownerID, now, ttl)}
+}
+
+func (_c *AggSenderStorage_AcquireInstanceLease_Call) Run(run func(ctx context.Context, ownerID string, now time.Time, ttl time.Duration)) *AggSenderStorage_AcquireInstanceLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_AcquireInstanceLease_Call) Return(_a0 *db.InstanceLease, _a1 error) *AggSenderStorage_AcquireInstanceLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_AcquireInstanceLease_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Duration) (*db.InstanceLease, error)) *AggSenderStorage_AcquireInstanceLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteCertificate provides a mock function with given fields: ctx, certificateID
 func (_m *AggSenderStorage) DeleteCertificate(ctx context.Context, certificateID common.Hash) error {
 	ret := _m.Called(ctx, certificateID)
@@ -75,6 +138,180 @@ func (_c *AggSenderStorage_DeleteCertificate_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// GetCertificateAnalytics provides a mock function with given fields: height
+func (_m *AggSenderStorage) GetCertificateAnalytics(height uint64) (*types.CertificateAnalytics, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAnalytics")
+	}
+
+	var r0 *types.CertificateAnalytics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*types.CertificateAnalytics, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *types.CertificateAnalytics); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateAnalytics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetCertificateAnalytics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateAnalytics'
+type AggSenderStorage_GetCertificateAnalytics_Call struct {
+	*mock.Call
+}
+
+// GetCertificateAnalytics is a helper method to define mock.On call
+//   - height uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateAnalytics(height interface{}) *AggSenderStorage_GetCertificateAnalytics_Call {
+	return &AggSenderStorage_GetCertificateAnalytics_Call{Call: _e.mock.On("GetCertificateAnalytics", height)}
+}
+
+func (_c *AggSenderStorage_GetCertificateAnalytics_Call) Run(run func(height uint64)) *AggSenderStorage_GetCertificateAnalytics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateAnalytics_Call) Return(_a0 *types.CertificateAnalytics, _a1 error) *AggSenderStorage_GetCertificateAnalytics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateAnalytics_Call) RunAndReturn(run func(uint64) (*types.CertificateAnalytics, error)) *AggSenderStorage_GetCertificateAnalytics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCertificateAuditEvents provides a mock function with given fields: height
+func (_m *AggSenderStorage) GetCertificateAuditEvents(height uint64) ([]*types.CertificateAuditEvent, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAuditEvents")
+	}
+
+	var r0 []*types.CertificateAuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*types.CertificateAuditEvent, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*types.CertificateAuditEvent); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.CertificateAuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetCertificateAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateAuditEvents'
+type AggSenderStorage_GetCertificateAuditEvents_Call struct {
+	*mock.Call
+}
+
+// GetCertificateAuditEvents is a helper method to define mock.On call
+//   - height uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateAuditEvents(height interface{}) *AggSenderStorage_GetCertificateAuditEvents_Call {
+	return &AggSenderStorage_GetCertificateAuditEvents_Call{Call: _e.mock.On("GetCertificateAuditEvents", height)}
+}
+
+func (_c *AggSenderStorage_GetCertificateAuditEvents_Call) Run(run func(height uint64)) *AggSenderStorage_GetCertificateAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateAuditEvents_Call) Return(_a0 []*types.CertificateAuditEvent, _a1 error) *AggSenderStorage_GetCertificateAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateAuditEvents_Call) RunAndReturn(run func(uint64) ([]*types.CertificateAuditEvent, error)) *AggSenderStorage_GetCertificateAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCertificateBuildParams provides a mock function with given fields: height
+func (_m *AggSenderStorage) GetCertificateBuildParams(height uint64) (*db.CertificateBuildParamsSnapshot, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateBuildParams")
+	}
+
+	var r0 *db.CertificateBuildParamsSnapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*db.CertificateBuildParamsSnapshot, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *db.CertificateBuildParamsSnapshot); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.CertificateBuildParamsSnapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetCertificateBuildParams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateBuildParams'
+type AggSenderStorage_GetCertificateBuildParams_Call struct {
+	*mock.Call
+}
+
+// GetCertificateBuildParams is a helper method to define mock.On call
+//   - height uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateBuildParams(height interface{}) *AggSenderStorage_GetCertificateBuildParams_Call {
+	return &AggSenderStorage_GetCertificateBuildParams_Call{Call: _e.mock.On("GetCertificateBuildParams", height)}
+}
+
+func (_c *AggSenderStorage_GetCertificateBuildParams_Call) Run(run func(height uint64)) *AggSenderStorage_GetCertificateBuildParams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateBuildParams_Call) Return(_a0 *db.CertificateBuildParamsSnapshot, _a1 error) *AggSenderStorage_GetCertificateBuildParams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateBuildParams_Call) RunAndReturn(run func(uint64) (*db.CertificateBuildParamsSnapshot, error)) *AggSenderStorage_GetCertificateBuildParams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCertificateByHeight provides a mock function with given fields: height
 func (_m *AggSenderStorage) GetCertificateByHeight(height uint64) (*types.Certificate, error) {
 	ret := _m.Called(height)
@@ -133,6 +370,70 @@ func (_c *AggSenderStorage_GetCertificateByHeight_Call) RunAndReturn(run func(ui
 	return _c
 }
 
+// GetCertificateFeesSpent provides a mock function with given fields: sinceTime, epoch
+func (_m *AggSenderStorage) GetCertificateFeesSpent(sinceTime uint32, epoch uint64) (uint64, uint64, error) {
+	ret := _m.Called(sinceTime, epoch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateFeesSpent")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint32, uint64) (uint64, uint64, error)); ok {
+		return rf(sinceTime, epoch)
+	}
+	if rf, ok := ret.Get(0).(func(uint32, uint64) uint64); ok {
+		r0 = rf(sinceTime, epoch)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32, uint64) uint64); ok {
+		r1 = rf(sinceTime, epoch)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint32, uint64) error); ok {
+		r2 = rf(sinceTime, epoch)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AggSenderStorage_GetCertificateFeesSpent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateFeesSpent'
+type AggSenderStorage_GetCertificateFeesSpent_Call struct {
+	*mock.Call
+}
+
+// GetCertificateFeesSpent is a helper method to define mock.On call
+//   - sinceTime uint32
+//   - epoch uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateFeesSpent(sinceTime interface{}, epoch interface{}) *AggSenderStorage_GetCertificateFeesSpent_Call {
+	return &AggSenderStorage_GetCertificateFeesSpent_Call{Call: _e.mock.On("GetCertificateFeesSpent", sinceTime, epoch)}
+}
+
+func (_c *AggSenderStorage_GetCertificateFeesSpent_Call) Run(run func(sinceTime uint32, epoch uint64)) *AggSenderStorage_GetCertificateFeesSpent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateFeesSpent_Call) Return(sinceTimeFees uint64, epochFees uint64, err error) *AggSenderStorage_GetCertificateFeesSpent_Call {
+	_c.Call.Return(sinceTimeFees, epochFees, err)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetCertificateFeesSpent_Call) RunAndReturn(run func(uint32, uint64) (uint64, uint64, error)) *AggSenderStorage_GetCertificateFeesSpent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCertificateHeaderByHeight provides a mock function with given fields: height
 func (_m *AggSenderStorage) GetCertificateHeaderByHeight(height uint64) (*types.CertificateHeader, error) {
 	ret := _m.Called(height)
@@ -249,29 +550,29 @@ func (_c *AggSenderStorage_GetCertificateHeadersByStatus_Call) RunAndReturn(run
 	return _c
 }
 
-// GetLastSentCertificate provides a mock function with no fields
-func (_m *AggSenderStorage) GetLastSentCertificate() (*types.Certificate, error) {
-	ret := _m.Called()
+// GetCertificateHeadersByTags provides a mock function with given fields: tags, statuses, limit
+func (_m *AggSenderStorage) GetCertificateHeadersByTags(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus, limit uint64) ([]*types.CertificateHeader, error) {
+	ret := _m.Called(tags, statuses, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastSentCertificate")
+		panic("no return value specified for GetCertificateHeadersByTags")
 	}
 
-	var r0 *types.Certificate
+	var r0 []*types.CertificateHeader
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*types.Certificate, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) ([]*types.CertificateHeader, error)); ok {
+		return rf(tags, statuses, limit)
 	}
-	if rf, ok := ret.Get(0).(func() *types.Certificate); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) []*types.CertificateHeader); ok {
+		r0 = rf(tags, statuses, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.Certificate)
+			r0 = ret.Get(0).([]*types.CertificateHeader)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) error); ok {
+		r1 = rf(tags, statuses, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -279,56 +580,59 @@ func (_m *AggSenderStorage) GetLastSentCertificate() (*types.Certificate, error)
 	return r0, r1
 }
 
-// AggSenderStorage_GetLastSentCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificate'
-type AggSenderStorage_GetLastSentCertificate_Call struct {
+// AggSenderStorage_GetCertificateHeadersByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateHeadersByTags'
+type AggSenderStorage_GetCertificateHeadersByTags_Call struct {
 	*mock.Call
 }
 
-// GetLastSentCertificate is a helper method to define mock.On call
-func (_e *AggSenderStorage_Expecter) GetLastSentCertificate() *AggSenderStorage_GetLastSentCertificate_Call {
-	return &AggSenderStorage_GetLastSentCertificate_Call{Call: _e.mock.On("GetLastSentCertificate")}
+// GetCertificateHeadersByTags is a helper method to define mock.On call
+//   - tags types.CertificateTags
+//   - statuses []agglayertypes.CertificateStatus
+//   - limit uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateHeadersByTags(tags interface{}, statuses interface{}, limit interface{}) *AggSenderStorage_GetCertificateHeadersByTags_Call {
+	return &AggSenderStorage_GetCertificateHeadersByTags_Call{Call: _e.mock.On("GetCertificateHeadersByTags", tags, statuses, limit)}
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificate_Call) Run(run func()) *AggSenderStorage_GetLastSentCertificate_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersByTags_Call) Run(run func(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus, limit uint64)) *AggSenderStorage_GetCertificateHeadersByTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(types.CertificateTags), args[1].([]agglayertypes.CertificateStatus), args[2].(uint64))
 	})
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificate_Call) Return(_a0 *types.Certificate, _a1 error) *AggSenderStorage_GetLastSentCertificate_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersByTags_Call) Return(_a0 []*types.CertificateHeader, _a1 error) *AggSenderStorage_GetCertificateHeadersByTags_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificate_Call) RunAndReturn(run func() (*types.Certificate, error)) *AggSenderStorage_GetLastSentCertificate_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersByTags_Call) RunAndReturn(run func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) ([]*types.CertificateHeader, error)) *AggSenderStorage_GetCertificateHeadersByTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastSentCertificateHeader provides a mock function with no fields
-func (_m *AggSenderStorage) GetLastSentCertificateHeader() (*types.CertificateHeader, error) {
-	ret := _m.Called()
+// GetCertificateHeadersInHeightRange provides a mock function with given fields: fromHeight, toHeight
+func (_m *AggSenderStorage) GetCertificateHeadersInHeightRange(fromHeight uint64, toHeight uint64) ([]*types.CertificateHeader, error) {
+	ret := _m.Called(fromHeight, toHeight)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastSentCertificateHeader")
+		panic("no return value specified for GetCertificateHeadersInHeightRange")
 	}
 
-	var r0 *types.CertificateHeader
+	var r0 []*types.CertificateHeader
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*types.CertificateHeader, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(uint64, uint64) ([]*types.CertificateHeader, error)); ok {
+		return rf(fromHeight, toHeight)
 	}
-	if rf, ok := ret.Get(0).(func() *types.CertificateHeader); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []*types.CertificateHeader); ok {
+		r0 = rf(fromHeight, toHeight)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.CertificateHeader)
+			r0 = ret.Get(0).([]*types.CertificateHeader)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(fromHeight, toHeight)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -336,153 +640,829 @@ func (_m *AggSenderStorage) GetLastSentCertificateHeader() (*types.CertificateHe
 	return r0, r1
 }
 
-// AggSenderStorage_GetLastSentCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificateHeader'
-type AggSenderStorage_GetLastSentCertificateHeader_Call struct {
+// AggSenderStorage_GetCertificateHeadersInHeightRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateHeadersInHeightRange'
+type AggSenderStorage_GetCertificateHeadersInHeightRange_Call struct {
 	*mock.Call
 }
 
-// GetLastSentCertificateHeader is a helper method to define mock.On call
-func (_e *AggSenderStorage_Expecter) GetLastSentCertificateHeader() *AggSenderStorage_GetLastSentCertificateHeader_Call {
-	return &AggSenderStorage_GetLastSentCertificateHeader_Call{Call: _e.mock.On("GetLastSentCertificateHeader")}
+// GetCertificateHeadersInHeightRange is a helper method to define mock.On call
+//   - fromHeight uint64
+//   - toHeight uint64
+func (_e *AggSenderStorage_Expecter) GetCertificateHeadersInHeightRange(fromHeight interface{}, toHeight interface{}) *AggSenderStorage_GetCertificateHeadersInHeightRange_Call {
+	return &AggSenderStorage_GetCertificateHeadersInHeightRange_Call{Call: _e.mock.On("GetCertificateHeadersInHeightRange", fromHeight, toHeight)}
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) Run(run func()) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersInHeightRange_Call) Run(run func(fromHeight uint64, toHeight uint64)) *AggSenderStorage_GetCertificateHeadersInHeightRange_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(uint64), args[1].(uint64))
 	})
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersInHeightRange_Call) Return(_a0 []*types.CertificateHeader, _a1 error) *AggSenderStorage_GetCertificateHeadersInHeightRange_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) RunAndReturn(run func() (*types.CertificateHeader, error)) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+func (_c *AggSenderStorage_GetCertificateHeadersInHeightRange_Call) RunAndReturn(run func(uint64, uint64) ([]*types.CertificateHeader, error)) *AggSenderStorage_GetCertificateHeadersInHeightRange_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLastSentCertificateHeaderWithProofIfInError provides a mock function with given fields: ctx
-func (_m *AggSenderStorage) GetLastSentCertificateHeaderWithProofIfInError(ctx context.Context) (*types.CertificateHeader, *types.AggchainProof, error) {
-	ret := _m.Called(ctx)
+// GetLastMirroredCertificateHeader provides a mock function with no fields
+func (_m *AggSenderStorage) GetLastMirroredCertificateHeader() (*types.MirroredCertificateHeader, error) {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLastSentCertificateHeaderWithProofIfInError")
+		panic("no return value specified for GetLastMirroredCertificateHeader")
 	}
 
-	var r0 *types.CertificateHeader
-	var r1 *types.AggchainProof
+	var r0 *types.MirroredCertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.MirroredCertificateHeader, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.MirroredCertificateHeader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MirroredCertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetLastMirroredCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastMirroredCertificateHeader'
+type AggSenderStorage_GetLastMirroredCertificateHeader_Call struct {
+	*mock.Call
+}
+
+// GetLastMirroredCertificateHeader is a helper method to define mock.On call
+func (_e *AggSenderStorage_Expecter) GetLastMirroredCertificateHeader() *AggSenderStorage_GetLastMirroredCertificateHeader_Call {
+	return &AggSenderStorage_GetLastMirroredCertificateHeader_Call{Call: _e.mock.On("GetLastMirroredCertificateHeader")}
+}
+
+func (_c *AggSenderStorage_GetLastMirroredCertificateHeader_Call) Run(run func()) *AggSenderStorage_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastMirroredCertificateHeader_Call) Return(_a0 *types.MirroredCertificateHeader, _a1 error) *AggSenderStorage_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastMirroredCertificateHeader_Call) RunAndReturn(run func() (*types.MirroredCertificateHeader, error)) *AggSenderStorage_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastProofStats provides a mock function with given fields: limit
+func (_m *AggSenderStorage) GetLastProofStats(limit uint32) ([]*types.ProofStats, error) {
+	ret := _m.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastProofStats")
+	}
+
+	var r0 []*types.ProofStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint32) ([]*types.ProofStats, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) []*types.ProofStats); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.ProofStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetLastProofStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastProofStats'
+type AggSenderStorage_GetLastProofStats_Call struct {
+	*mock.Call
+}
+
+// GetLastProofStats is a helper method to define mock.On call
+//   - limit uint32
+func (_e *AggSenderStorage_Expecter) GetLastProofStats(limit interface{}) *AggSenderStorage_GetLastProofStats_Call {
+	return &AggSenderStorage_GetLastProofStats_Call{Call: _e.mock.On("GetLastProofStats", limit)}
+}
+
+func (_c *AggSenderStorage_GetLastProofStats_Call) Run(run func(limit uint32)) *AggSenderStorage_GetLastProofStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastProofStats_Call) Return(_a0 []*types.ProofStats, _a1 error) *AggSenderStorage_GetLastProofStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastProofStats_Call) RunAndReturn(run func(uint32) ([]*types.ProofStats, error)) *AggSenderStorage_GetLastProofStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastSentCertificate provides a mock function with no fields
+func (_m *AggSenderStorage) GetLastSentCertificate() (*types.Certificate, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSentCertificate")
+	}
+
+	var r0 *types.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.Certificate, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.Certificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Certificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetLastSentCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificate'
+type AggSenderStorage_GetLastSentCertificate_Call struct {
+	*mock.Call
+}
+
+// GetLastSentCertificate is a helper method to define mock.On call
+func (_e *AggSenderStorage_Expecter) GetLastSentCertificate() *AggSenderStorage_GetLastSentCertificate_Call {
+	return &AggSenderStorage_GetLastSentCertificate_Call{Call: _e.mock.On("GetLastSentCertificate")}
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificate_Call) Run(run func()) *AggSenderStorage_GetLastSentCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificate_Call) Return(_a0 *types.Certificate, _a1 error) *AggSenderStorage_GetLastSentCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificate_Call) RunAndReturn(run func() (*types.Certificate, error)) *AggSenderStorage_GetLastSentCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastSentCertificateHeader provides a mock function with no fields
+func (_m *AggSenderStorage) GetLastSentCertificateHeader() (*types.CertificateHeader, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSentCertificateHeader")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.CertificateHeader, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.CertificateHeader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetLastSentCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificateHeader'
+type AggSenderStorage_GetLastSentCertificateHeader_Call struct {
+	*mock.Call
+}
+
+// GetLastSentCertificateHeader is a helper method to define mock.On call
+func (_e *AggSenderStorage_Expecter) GetLastSentCertificateHeader() *AggSenderStorage_GetLastSentCertificateHeader_Call {
+	return &AggSenderStorage_GetLastSentCertificateHeader_Call{Call: _e.mock.On("GetLastSentCertificateHeader")}
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) Run(run func()) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeader_Call) RunAndReturn(run func() (*types.CertificateHeader, error)) *AggSenderStorage_GetLastSentCertificateHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastSentCertificateHeaderWithProofIfInError provides a mock function with given fields: ctx
+func (_m *AggSenderStorage) GetLastSentCertificateHeaderWithProofIfInError(ctx context.Context) (*types.CertificateHeader, *types.AggchainProof, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSentCertificateHeaderWithProofIfInError")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 *types.AggchainProof
 	var r2 error
 	if rf, ok := ret.Get(0).(func(context.Context) (*types.CertificateHeader, *types.AggchainProof, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *types.CertificateHeader); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context) *types.CertificateHeader); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) *types.AggchainProof); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*types.AggchainProof)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificateHeaderWithProofIfInError'
+type AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call struct {
+	*mock.Call
+}
+
+// GetLastSentCertificateHeaderWithProofIfInError is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AggSenderStorage_Expecter) GetLastSentCertificateHeaderWithProofIfInError(ctx interface{}) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+	return &AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call{Call: _e.mock.On("GetLastSentCertificateHeaderWithProofIfInError", ctx)}
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) Run(run func(ctx context.Context)) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) Return(_a0 *types.CertificateHeader, _a1 *types.AggchainProof, _a2 error) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) RunAndReturn(run func(context.Context) (*types.CertificateHeader, *types.AggchainProof, error)) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMirroredCertificateHeaders provides a mock function with given fields: fromHeight, toHeight
+func (_m *AggSenderStorage) GetMirroredCertificateHeaders(fromHeight uint64, toHeight uint64) ([]*types.MirroredCertificateHeader, error) {
+	ret := _m.Called(fromHeight, toHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMirroredCertificateHeaders")
+	}
+
+	var r0 []*types.MirroredCertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) ([]*types.MirroredCertificateHeader, error)); ok {
+		return rf(fromHeight, toHeight)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []*types.MirroredCertificateHeader); ok {
+		r0 = rf(fromHeight, toHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.MirroredCertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(fromHeight, toHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetMirroredCertificateHeaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMirroredCertificateHeaders'
+type AggSenderStorage_GetMirroredCertificateHeaders_Call struct {
+	*mock.Call
+}
+
+// GetMirroredCertificateHeaders is a helper method to define mock.On call
+//   - fromHeight uint64
+//   - toHeight uint64
+func (_e *AggSenderStorage_Expecter) GetMirroredCertificateHeaders(fromHeight interface{}, toHeight interface{}) *AggSenderStorage_GetMirroredCertificateHeaders_Call {
+	return &AggSenderStorage_GetMirroredCertificateHeaders_Call{Call: _e.mock.On("GetMirroredCertificateHeaders", fromHeight, toHeight)}
+}
+
+func (_c *AggSenderStorage_GetMirroredCertificateHeaders_Call) Run(run func(fromHeight uint64, toHeight uint64)) *AggSenderStorage_GetMirroredCertificateHeaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetMirroredCertificateHeaders_Call) Return(_a0 []*types.MirroredCertificateHeader, _a1 error) *AggSenderStorage_GetMirroredCertificateHeaders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetMirroredCertificateHeaders_Call) RunAndReturn(run func(uint64, uint64) ([]*types.MirroredCertificateHeader, error)) *AggSenderStorage_GetMirroredCertificateHeaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNonAcceptedCertificate provides a mock function with no fields
+func (_m *AggSenderStorage) GetNonAcceptedCertificate() (*db.NonAcceptedCertificate, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNonAcceptedCertificate")
+	}
+
+	var r0 *db.NonAcceptedCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*db.NonAcceptedCertificate, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *db.NonAcceptedCertificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.NonAcceptedCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetNonAcceptedCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNonAcceptedCertificate'
+type AggSenderStorage_GetNonAcceptedCertificate_Call struct {
+	*mock.Call
+}
+
+// GetNonAcceptedCertificate is a helper method to define mock.On call
+func (_e *AggSenderStorage_Expecter) GetNonAcceptedCertificate() *AggSenderStorage_GetNonAcceptedCertificate_Call {
+	return &AggSenderStorage_GetNonAcceptedCertificate_Call{Call: _e.mock.On("GetNonAcceptedCertificate")}
+}
+
+func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) Run(run func()) *AggSenderStorage_GetNonAcceptedCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) Return(_a0 *db.NonAcceptedCertificate, _a1 error) *AggSenderStorage_GetNonAcceptedCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) RunAndReturn(run func() (*db.NonAcceptedCertificate, error)) *AggSenderStorage_GetNonAcceptedCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingCertificateJournalEntries provides a mock function with no fields
+func (_m *AggSenderStorage) GetPendingCertificateJournalEntries() ([]*db.CertificateJournalEntry, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingCertificateJournalEntries")
+	}
+
+	var r0 []*db.CertificateJournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*db.CertificateJournalEntry, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*db.CertificateJournalEntry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*db.CertificateJournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggSenderStorage_GetPendingCertificateJournalEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingCertificateJournalEntries'
+type AggSenderStorage_GetPendingCertificateJournalEntries_Call struct {
+	*mock.Call
+}
+
+// GetPendingCertificateJournalEntries is a helper method to define mock.On call
+func (_e *AggSenderStorage_Expecter) GetPendingCertificateJournalEntries() *AggSenderStorage_GetPendingCertificateJournalEntries_Call {
+	return &AggSenderStorage_GetPendingCertificateJournalEntries_Call{Call: _e.mock.On("GetPendingCertificateJournalEntries")}
+}
+
+func (_c *AggSenderStorage_GetPendingCertificateJournalEntries_Call) Run(run func()) *AggSenderStorage_GetPendingCertificateJournalEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_GetPendingCertificateJournalEntries_Call) Return(_a0 []*db.CertificateJournalEntry, _a1 error) *AggSenderStorage_GetPendingCertificateJournalEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggSenderStorage_GetPendingCertificateJournalEntries_Call) RunAndReturn(run func() ([]*db.CertificateJournalEntry, error)) *AggSenderStorage_GetPendingCertificateJournalEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseInstanceLease provides a mock function with given fields: ctx, ownerID
+func (_m *AggSenderStorage) ReleaseInstanceLease(ctx context.Context, ownerID string) error {
+	ret := _m.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseInstanceLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, ownerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggSenderStorage_ReleaseInstanceLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseInstanceLease'
+type AggSenderStorage_ReleaseInstanceLease_Call struct {
+	*mock.Call
+}
+
+// ReleaseInstanceLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ownerID string
+func (_e *AggSenderStorage_Expecter) ReleaseInstanceLease(ctx interface{}, ownerID interface{}) *AggSenderStorage_ReleaseInstanceLease_Call {
+	return &AggSenderStorage_ReleaseInstanceLease_Call{Call: _e.mock.On("ReleaseInstanceLease", ctx, ownerID)}
+}
+
+func (_c *AggSenderStorage_ReleaseInstanceLease_Call) Run(run func(ctx context.Context, ownerID string)) *AggSenderStorage_ReleaseInstanceLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_ReleaseInstanceLease_Call) Return(_a0 error) *AggSenderStorage_ReleaseInstanceLease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_ReleaseInstanceLease_Call) RunAndReturn(run func(context.Context, string) error) *AggSenderStorage_ReleaseInstanceLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCertificateAnalytics provides a mock function with given fields: ctx, analytics
+func (_m *AggSenderStorage) SaveCertificateAnalytics(ctx context.Context, analytics *types.CertificateAnalytics) error {
+	ret := _m.Called(ctx, analytics)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCertificateAnalytics")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CertificateAnalytics) error); ok {
+		r0 = rf(ctx, analytics)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggSenderStorage_SaveCertificateAnalytics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateAnalytics'
+type AggSenderStorage_SaveCertificateAnalytics_Call struct {
+	*mock.Call
+}
+
+// SaveCertificateAnalytics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - analytics *types.CertificateAnalytics
+func (_e *AggSenderStorage_Expecter) SaveCertificateAnalytics(ctx interface{}, analytics interface{}) *AggSenderStorage_SaveCertificateAnalytics_Call {
+	return &AggSenderStorage_SaveCertificateAnalytics_Call{Call: _e.mock.On("SaveCertificateAnalytics", ctx, analytics)}
+}
+
+func (_c *AggSenderStorage_SaveCertificateAnalytics_Call) Run(run func(ctx context.Context, analytics *types.CertificateAnalytics)) *AggSenderStorage_SaveCertificateAnalytics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CertificateAnalytics))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateAnalytics_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateAnalytics_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateAnalytics_Call) RunAndReturn(run func(context.Context, *types.CertificateAnalytics) error) *AggSenderStorage_SaveCertificateAnalytics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCertificateAuditEvents provides a mock function with given fields: ctx, events
+func (_m *AggSenderStorage) SaveCertificateAuditEvents(ctx context.Context, events []*types.CertificateAuditEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCertificateAuditEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*types.CertificateAuditEvent) error); ok {
+		r0 = rf(ctx, events)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.CertificateHeader)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) *types.AggchainProof); ok {
-		r1 = rf(ctx)
-	} else {
-		if ret.Get(1) != nil {
-			r1 = ret.Get(1).(*types.AggchainProof)
-		}
+	return r0
+}
+
+// AggSenderStorage_SaveCertificateAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateAuditEvents'
+type AggSenderStorage_SaveCertificateAuditEvents_Call struct {
+	*mock.Call
+}
+
+// SaveCertificateAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []*types.CertificateAuditEvent
+func (_e *AggSenderStorage_Expecter) SaveCertificateAuditEvents(ctx interface{}, events interface{}) *AggSenderStorage_SaveCertificateAuditEvents_Call {
+	return &AggSenderStorage_SaveCertificateAuditEvents_Call{Call: _e.mock.On("SaveCertificateAuditEvents", ctx, events)}
+}
+
+func (_c *AggSenderStorage_SaveCertificateAuditEvents_Call) Run(run func(ctx context.Context, events []*types.CertificateAuditEvent)) *AggSenderStorage_SaveCertificateAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*types.CertificateAuditEvent))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateAuditEvents_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateAuditEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateAuditEvents_Call) RunAndReturn(run func(context.Context, []*types.CertificateAuditEvent) error) *AggSenderStorage_SaveCertificateAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCertificateBatch provides a mock function with given fields: ctx, batch
+func (_m *AggSenderStorage) SaveCertificateBatch(ctx context.Context, batch db.CertificateWriteBatch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCertificateBatch")
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
-		r2 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, db.CertificateWriteBatch) error); ok {
+		r0 = rf(ctx, batch)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificateHeaderWithProofIfInError'
-type AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call struct {
+// AggSenderStorage_SaveCertificateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateBatch'
+type AggSenderStorage_SaveCertificateBatch_Call struct {
 	*mock.Call
 }
 
-// GetLastSentCertificateHeaderWithProofIfInError is a helper method to define mock.On call
+// SaveCertificateBatch is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *AggSenderStorage_Expecter) GetLastSentCertificateHeaderWithProofIfInError(ctx interface{}) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
-	return &AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call{Call: _e.mock.On("GetLastSentCertificateHeaderWithProofIfInError", ctx)}
+//   - batch db.CertificateWriteBatch
+func (_e *AggSenderStorage_Expecter) SaveCertificateBatch(ctx interface{}, batch interface{}) *AggSenderStorage_SaveCertificateBatch_Call {
+	return &AggSenderStorage_SaveCertificateBatch_Call{Call: _e.mock.On("SaveCertificateBatch", ctx, batch)}
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) Run(run func(ctx context.Context)) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+func (_c *AggSenderStorage_SaveCertificateBatch_Call) Run(run func(ctx context.Context, batch db.CertificateWriteBatch)) *AggSenderStorage_SaveCertificateBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(db.CertificateWriteBatch))
 	})
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) Return(_a0 *types.CertificateHeader, _a1 *types.AggchainProof, _a2 error) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *AggSenderStorage_SaveCertificateBatch_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateBatch_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call) RunAndReturn(run func(context.Context) (*types.CertificateHeader, *types.AggchainProof, error)) *AggSenderStorage_GetLastSentCertificateHeaderWithProofIfInError_Call {
+func (_c *AggSenderStorage_SaveCertificateBatch_Call) RunAndReturn(run func(context.Context, db.CertificateWriteBatch) error) *AggSenderStorage_SaveCertificateBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNonAcceptedCertificate provides a mock function with no fields
-func (_m *AggSenderStorage) GetNonAcceptedCertificate() (*db.NonAcceptedCertificate, error) {
-	ret := _m.Called()
+// SaveCertificateBuildParams provides a mock function with given fields: ctx, snapshot
+func (_m *AggSenderStorage) SaveCertificateBuildParams(ctx context.Context, snapshot *db.CertificateBuildParamsSnapshot) error {
+	ret := _m.Called(ctx, snapshot)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNonAcceptedCertificate")
+		panic("no return value specified for SaveCertificateBuildParams")
 	}
 
-	var r0 *db.NonAcceptedCertificate
-	var r1 error
-	if rf, ok := ret.Get(0).(func() (*db.NonAcceptedCertificate, error)); ok {
-		return rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.CertificateBuildParamsSnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
 	}
-	if rf, ok := ret.Get(0).(func() *db.NonAcceptedCertificate); ok {
-		r0 = rf()
+
+	return r0
+}
+
+// AggSenderStorage_SaveCertificateBuildParams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateBuildParams'
+type AggSenderStorage_SaveCertificateBuildParams_Call struct {
+	*mock.Call
+}
+
+// SaveCertificateBuildParams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshot *db.CertificateBuildParamsSnapshot
+func (_e *AggSenderStorage_Expecter) SaveCertificateBuildParams(ctx interface{}, snapshot interface{}) *AggSenderStorage_SaveCertificateBuildParams_Call {
+	return &AggSenderStorage_SaveCertificateBuildParams_Call{Call: _e.mock.On("SaveCertificateBuildParams", ctx, snapshot)}
+}
+
+func (_c *AggSenderStorage_SaveCertificateBuildParams_Call) Run(run func(ctx context.Context, snapshot *db.CertificateBuildParamsSnapshot)) *AggSenderStorage_SaveCertificateBuildParams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.CertificateBuildParamsSnapshot))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateBuildParams_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateBuildParams_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateBuildParams_Call) RunAndReturn(run func(context.Context, *db.CertificateBuildParamsSnapshot) error) *AggSenderStorage_SaveCertificateBuildParams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCertificateFee provides a mock function with given fields: ctx, fee
+func (_m *AggSenderStorage) SaveCertificateFee(ctx context.Context, fee *db.CertificateFee) error {
+	ret := _m.Called(ctx, fee)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCertificateFee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.CertificateFee) error); ok {
+		r0 = rf(ctx, fee)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*db.NonAcceptedCertificate)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	return r0
+}
+
+// AggSenderStorage_SaveCertificateFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateFee'
+type AggSenderStorage_SaveCertificateFee_Call struct {
+	*mock.Call
+}
+
+// SaveCertificateFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fee *db.CertificateFee
+func (_e *AggSenderStorage_Expecter) SaveCertificateFee(ctx interface{}, fee interface{}) *AggSenderStorage_SaveCertificateFee_Call {
+	return &AggSenderStorage_SaveCertificateFee_Call{Call: _e.mock.On("SaveCertificateFee", ctx, fee)}
+}
+
+func (_c *AggSenderStorage_SaveCertificateFee_Call) Run(run func(ctx context.Context, fee *db.CertificateFee)) *AggSenderStorage_SaveCertificateFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*db.CertificateFee))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateFee_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateFee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveCertificateFee_Call) RunAndReturn(run func(context.Context, *db.CertificateFee) error) *AggSenderStorage_SaveCertificateFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCertificateJournalEntry provides a mock function with given fields: ctx, entry
+func (_m *AggSenderStorage) SaveCertificateJournalEntry(ctx context.Context, entry *db.CertificateJournalEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCertificateJournalEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *db.CertificateJournalEntry) error); ok {
+		r0 = rf(ctx, entry)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// AggSenderStorage_GetNonAcceptedCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNonAcceptedCertificate'
-type AggSenderStorage_GetNonAcceptedCertificate_Call struct {
+// AggSenderStorage_SaveCertificateJournalEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCertificateJournalEntry'
+type AggSenderStorage_SaveCertificateJournalEntry_Call struct {
 	*mock.Call
 }
 
-// GetNonAcceptedCertificate is a helper method to define mock.On call
-func (_e *AggSenderStorage_Expecter) GetNonAcceptedCertificate() *AggSenderStorage_GetNonAcceptedCertificate_Call {
-	return &AggSenderStorage_GetNonAcceptedCertificate_Call{Call: _e.mock.On("GetNonAcceptedCertificate")}
+// SaveCertificateJournalEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry *db.CertificateJournalEntry
+func (_e *AggSenderStorage_Expecter) SaveCertificateJournalEntry(ctx interface{}, entry interface{}) *AggSenderStorage_SaveCertificateJournalEntry_Call {
+	return &AggSenderStorage_SaveCertificateJournalEntry_Call{Call: _e.mock.On("SaveCertificateJournalEntry", ctx, entry)}
 }
 
-func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) Run(run func()) *AggSenderStorage_GetNonAcceptedCertificate_Call {
+func (_c *AggSenderStorage_SaveCertificateJournalEntry_Call) Run(run func(ctx context.Context, entry *db.CertificateJournalEntry)) *AggSenderStorage_SaveCertificateJournalEntry_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(*db.CertificateJournalEntry))
 	})
 	return _c
 }
 
-func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) Return(_a0 *db.NonAcceptedCertificate, _a1 error) *AggSenderStorage_GetNonAcceptedCertificate_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *AggSenderStorage_SaveCertificateJournalEntry_Call) Return(_a0 error) *AggSenderStorage_SaveCertificateJournalEntry_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *AggSenderStorage_GetNonAcceptedCertificate_Call) RunAndReturn(run func() (*db.NonAcceptedCertificate, error)) *AggSenderStorage_GetNonAcceptedCertificate_Call {
+func (_c *AggSenderStorage_SaveCertificateJournalEntry_Call) RunAndReturn(run func(context.Context, *db.CertificateJournalEntry) error) *AggSenderStorage_SaveCertificateJournalEntry_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -534,6 +1514,53 @@ func (_c *AggSenderStorage_SaveLastSentCertificate_Call) RunAndReturn(run func(c
 	return _c
 }
 
+// SaveMirroredCertificateHeaders provides a mock function with given fields: ctx, headers
+func (_m *AggSenderStorage) SaveMirroredCertificateHeaders(ctx context.Context, headers []*types.MirroredCertificateHeader) error {
+	ret := _m.Called(ctx, headers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMirroredCertificateHeaders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*types.MirroredCertificateHeader) error); ok {
+		r0 = rf(ctx, headers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggSenderStorage_SaveMirroredCertificateHeaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMirroredCertificateHeaders'
+type AggSenderStorage_SaveMirroredCertificateHeaders_Call struct {
+	*mock.Call
+}
+
+// SaveMirroredCertificateHeaders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - headers []*types.MirroredCertificateHeader
+func (_e *AggSenderStorage_Expecter) SaveMirroredCertificateHeaders(ctx interface{}, headers interface{}) *AggSenderStorage_SaveMirroredCertificateHeaders_Call {
+	return &AggSenderStorage_SaveMirroredCertificateHeaders_Call{Call: _e.mock.On("SaveMirroredCertificateHeaders", ctx, headers)}
+}
+
+func (_c *AggSenderStorage_SaveMirroredCertificateHeaders_Call) Run(run func(ctx context.Context, headers []*types.MirroredCertificateHeader)) *AggSenderStorage_SaveMirroredCertificateHeaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*types.MirroredCertificateHeader))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveMirroredCertificateHeaders_Call) Return(_a0 error) *AggSenderStorage_SaveMirroredCertificateHeaders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveMirroredCertificateHeaders_Call) RunAndReturn(run func(context.Context, []*types.MirroredCertificateHeader) error) *AggSenderStorage_SaveMirroredCertificateHeaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveNonAcceptedCertificate provides a mock function with given fields: ctx, nonAcceptedCert
 func (_m *AggSenderStorage) SaveNonAcceptedCertificate(ctx context.Context, nonAcceptedCert *db.NonAcceptedCertificate) error {
 	ret := _m.Called(ctx, nonAcceptedCert)
@@ -581,17 +1608,113 @@ func (_c *AggSenderStorage_SaveNonAcceptedCertificate_Call) RunAndReturn(run fun
 	return _c
 }
 
-// UpdateCertificateStatus provides a mock function with given fields: ctx, certificateID, newStatus, updatedAt
-func (_m *AggSenderStorage) UpdateCertificateStatus(ctx context.Context, certificateID common.Hash, newStatus agglayertypes.CertificateStatus, updatedAt uint32) error {
-	ret := _m.Called(ctx, certificateID, newStatus, updatedAt)
+// SaveProofStats provides a mock function with given fields: ctx, stats
+func (_m *AggSenderStorage) SaveProofStats(ctx context.Context, stats *types.ProofStats) error {
+	ret := _m.Called(ctx, stats)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProofStats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.ProofStats) error); ok {
+		r0 = rf(ctx, stats)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggSenderStorage_SaveProofStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProofStats'
+type AggSenderStorage_SaveProofStats_Call struct {
+	*mock.Call
+}
+
+// SaveProofStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stats *types.ProofStats
+func (_e *AggSenderStorage_Expecter) SaveProofStats(ctx interface{}, stats interface{}) *AggSenderStorage_SaveProofStats_Call {
+	return &AggSenderStorage_SaveProofStats_Call{Call: _e.mock.On("SaveProofStats", ctx, stats)}
+}
+
+func (_c *AggSenderStorage_SaveProofStats_Call) Run(run func(ctx context.Context, stats *types.ProofStats)) *AggSenderStorage_SaveProofStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.ProofStats))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveProofStats_Call) Return(_a0 error) *AggSenderStorage_SaveProofStats_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_SaveProofStats_Call) RunAndReturn(run func(context.Context, *types.ProofStats) error) *AggSenderStorage_SaveProofStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCertificateJournalEntryState provides a mock function with given fields: ctx, height, state, certificateID
+func (_m *AggSenderStorage) UpdateCertificateJournalEntryState(ctx context.Context, height uint64, state db.CertificateJournalState, certificateID *common.Hash) error {
+	ret := _m.Called(ctx, height, state, certificateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCertificateJournalEntryState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, db.CertificateJournalState, *common.Hash) error); ok {
+		r0 = rf(ctx, height, state, certificateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggSenderStorage_UpdateCertificateJournalEntryState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCertificateJournalEntryState'
+type AggSenderStorage_UpdateCertificateJournalEntryState_Call struct {
+	*mock.Call
+}
+
+// UpdateCertificateJournalEntryState is a helper method to define mock.On call
+//   - ctx context.Context
+//   - height uint64
+//   - state db.CertificateJournalState
+//   - certificateID *common.Hash
+func (_e *AggSenderStorage_Expecter) UpdateCertificateJournalEntryState(ctx interface{}, height interface{}, state interface{}, certificateID interface{}) *AggSenderStorage_UpdateCertificateJournalEntryState_Call {
+	return &AggSenderStorage_UpdateCertificateJournalEntryState_Call{Call: _e.mock.On("UpdateCertificateJournalEntryState", ctx, height, state, certificateID)}
+}
+
+func (_c *AggSenderStorage_UpdateCertificateJournalEntryState_Call) Run(run func(ctx context.Context, height uint64, state db.CertificateJournalState, certificateID *common.Hash)) *AggSenderStorage_UpdateCertificateJournalEntryState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(db.CertificateJournalState), args[3].(*common.Hash))
+	})
+	return _c
+}
+
+func (_c *AggSenderStorage_UpdateCertificateJournalEntryState_Call) Return(_a0 error) *AggSenderStorage_UpdateCertificateJournalEntryState_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggSenderStorage_UpdateCertificateJournalEntryState_Call) RunAndReturn(run func(context.Context, uint64, db.CertificateJournalState, *common.Hash) error) *AggSenderStorage_UpdateCertificateJournalEntryState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCertificateStatus provides a mock function with given fields: ctx, certificateID, newStatus, errorCategory, updatedAt
+func (_m *AggSenderStorage) UpdateCertificateStatus(ctx context.Context, certificateID common.Hash, newStatus agglayertypes.CertificateStatus, errorCategory types.CertificateErrorCategory, updatedAt uint32) error {
+	ret := _m.Called(ctx, certificateID, newStatus, errorCategory, updatedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateCertificateStatus")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, agglayertypes.CertificateStatus, uint32) error); ok {
-		r0 = rf(ctx, certificateID, newStatus, updatedAt)
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, agglayertypes.CertificateStatus, types.CertificateErrorCategory, uint32) error); ok {
+		r0 = rf(ctx, certificateID, newStatus, errorCategory, updatedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -608,14 +1731,15 @@ type AggSenderStorage_UpdateCertificateStatus_Call struct {
 //   - ctx context.Context
 //   - certificateID common.Hash
 //   - newStatus agglayertypes.CertificateStatus
+//   - errorCategory types.CertificateErrorCategory
 //   - updatedAt uint32
-func (_e *AggSenderStorage_Expecter) UpdateCertificateStatus(ctx interface{}, certificateID interface{}, newStatus interface{}, updatedAt interface{}) *AggSenderStorage_UpdateCertificateStatus_Call {
-	return &AggSenderStorage_UpdateCertificateStatus_Call{Call: _e.mock.On("UpdateCertificateStatus", ctx, certificateID, newStatus, updatedAt)}
+func (_e *AggSenderStorage_Expecter) UpdateCertificateStatus(ctx interface{}, certificateID interface{}, newStatus interface{}, errorCategory interface{}, updatedAt interface{}) *AggSenderStorage_UpdateCertificateStatus_Call {
+	return &AggSenderStorage_UpdateCertificateStatus_Call{Call: _e.mock.On("UpdateCertificateStatus", ctx, certificateID, newStatus, errorCategory, updatedAt)}
 }
 
-func (_c *AggSenderStorage_UpdateCertificateStatus_Call) Run(run func(ctx context.Context, certificateID common.Hash, newStatus agglayertypes.CertificateStatus, updatedAt uint32)) *AggSenderStorage_UpdateCertificateStatus_Call {
+func (_c *AggSenderStorage_UpdateCertificateStatus_Call) Run(run func(ctx context.Context, certificateID common.Hash, newStatus agglayertypes.CertificateStatus, errorCategory types.CertificateErrorCategory, updatedAt uint32)) *AggSenderStorage_UpdateCertificateStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(common.Hash), args[2].(agglayertypes.CertificateStatus), args[3].(uint32))
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(agglayertypes.CertificateStatus), args[3].(types.CertificateErrorCategory), args[4].(uint32))
 	})
 	return _c
 }
@@ -625,7 +1749,7 @@ func (_c *AggSenderStorage_UpdateCertificateStatus_Call) Return(_a0 error) *AggS
 	return _c
 }
 
-func (_c *AggSenderStorage_UpdateCertificateStatus_Call) RunAndReturn(run func(context.Context, common.Hash, agglayertypes.CertificateStatus, uint32) error) *AggSenderStorage_UpdateCertificateStatus_Call {
+func (_c *AggSenderStorage_UpdateCertificateStatus_Call) RunAndReturn(run func(context.Context, common.Hash, agglayertypes.CertificateStatus, types.CertificateErrorCategory, uint32) error) *AggSenderStorage_UpdateCertificateStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,99 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/agglayer/aggkit/agglayer/types"
+)
+
+// AgglayerCertificateQuerier is an autogenerated mock type for the AgglayerCertificateQuerier type
+type AgglayerCertificateQuerier struct {
+	mock.Mock
+}
+
+type AgglayerCertificateQuerier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AgglayerCertificateQuerier) EXPECT() *AgglayerCertificateQuerier_Expecter {
+	return &AgglayerCertificateQuerier_Expecter{mock: &_m.Mock}
+}
+
+// GetCertificateHeader provides a mock function with given fields: ctx, certificateHash
+func (_m *AgglayerCertificateQuerier) GetCertificateHeader(ctx context.Context, certificateHash common.Hash) (*types.CertificateHeader, error) {
+	ret := _m.Called(ctx, certificateHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateHeader")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.CertificateHeader, error)); ok {
+		return rf(ctx, certificateHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) *types.CertificateHeader); ok {
+		r0 = rf(ctx, certificateHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, certificateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AgglayerCertificateQuerier_GetCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateHeader'
+type AgglayerCertificateQuerier_GetCertificateHeader_Call struct {
+	*mock.Call
+}
+
+// GetCertificateHeader is a helper method to define mock.On call
+//   - ctx context.Context
+//   - certificateHash common.Hash
+func (_e *AgglayerCertificateQuerier_Expecter) GetCertificateHeader(ctx interface{}, certificateHash interface{}) *AgglayerCertificateQuerier_GetCertificateHeader_Call {
+	return &AgglayerCertificateQuerier_GetCertificateHeader_Call{Call: _e.mock.On("GetCertificateHeader", ctx, certificateHash)}
+}
+
+func (_c *AgglayerCertificateQuerier_GetCertificateHeader_Call) Run(run func(ctx context.Context, certificateHash common.Hash)) *AgglayerCertificateQuerier_GetCertificateHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *AgglayerCertificateQuerier_GetCertificateHeader_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AgglayerCertificateQuerier_GetCertificateHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AgglayerCertificateQuerier_GetCertificateHeader_Call) RunAndReturn(run func(context.Context, common.Hash) (*types.CertificateHeader, error)) *AgglayerCertificateQuerier_GetCertificateHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAgglayerCertificateQuerier creates a new instance of AgglayerCertificateQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAgglayerCertificateQuerier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AgglayerCertificateQuerier {
+	mock := &AgglayerCertificateQuerier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
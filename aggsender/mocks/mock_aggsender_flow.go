@@ -3,9 +3,10 @@
 package mocks
 
 import (
-	context "context"
-
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	common "github.com/ethereum/go-ethereum/common"
+
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -188,6 +189,100 @@ func (_c *AggsenderFlow_GetCertificateBuildParams_Call) RunAndReturn(run func(co
 	return _c
 }
 
+// L2ChainStatus provides a mock function with no fields
+func (_m *AggsenderFlow) L2ChainStatus() *types.L2ChainStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for L2ChainStatus")
+	}
+
+	var r0 *types.L2ChainStatus
+	if rf, ok := ret.Get(0).(func() *types.L2ChainStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.L2ChainStatus)
+		}
+	}
+
+	return r0
+}
+
+// AggsenderFlow_L2ChainStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'L2ChainStatus'
+type AggsenderFlow_L2ChainStatus_Call struct {
+	*mock.Call
+}
+
+// L2ChainStatus is a helper method to define mock.On call
+func (_e *AggsenderFlow_Expecter) L2ChainStatus() *AggsenderFlow_L2ChainStatus_Call {
+	return &AggsenderFlow_L2ChainStatus_Call{Call: _e.mock.On("L2ChainStatus")}
+}
+
+func (_c *AggsenderFlow_L2ChainStatus_Call) Run(run func()) *AggsenderFlow_L2ChainStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggsenderFlow_L2ChainStatus_Call) Return(_a0 *types.L2ChainStatus) *AggsenderFlow_L2ChainStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggsenderFlow_L2ChainStatus_Call) RunAndReturn(run func() *types.L2ChainStatus) *AggsenderFlow_L2ChainStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignerAddress provides a mock function with no fields
+func (_m *AggsenderFlow) SignerAddress() common.Address {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignerAddress")
+	}
+
+	var r0 common.Address
+	if rf, ok := ret.Get(0).(func() common.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	return r0
+}
+
+// AggsenderFlow_SignerAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignerAddress'
+type AggsenderFlow_SignerAddress_Call struct {
+	*mock.Call
+}
+
+// SignerAddress is a helper method to define mock.On call
+func (_e *AggsenderFlow_Expecter) SignerAddress() *AggsenderFlow_SignerAddress_Call {
+	return &AggsenderFlow_SignerAddress_Call{Call: _e.mock.On("SignerAddress")}
+}
+
+func (_c *AggsenderFlow_SignerAddress_Call) Run(run func()) *AggsenderFlow_SignerAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggsenderFlow_SignerAddress_Call) Return(_a0 common.Address) *AggsenderFlow_SignerAddress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggsenderFlow_SignerAddress_Call) RunAndReturn(run func() common.Address) *AggsenderFlow_SignerAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAggsenderFlow creates a new instance of AggsenderFlow. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAggsenderFlow(t interface {
@@ -89,6 +89,53 @@ func (_c *AggsenderFlowBaser_BuildCertificate_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// CheckFeeBudget provides a mock function with given fields: ctx, buildParams
+func (_m *AggsenderFlowBaser) CheckFeeBudget(ctx context.Context, buildParams *types.CertificateBuildParams) error {
+	ret := _m.Called(ctx, buildParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckFeeBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CertificateBuildParams) error); ok {
+		r0 = rf(ctx, buildParams)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AggsenderFlowBaser_CheckFeeBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckFeeBudget'
+type AggsenderFlowBaser_CheckFeeBudget_Call struct {
+	*mock.Call
+}
+
+// CheckFeeBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - buildParams *types.CertificateBuildParams
+func (_e *AggsenderFlowBaser_Expecter) CheckFeeBudget(ctx interface{}, buildParams interface{}) *AggsenderFlowBaser_CheckFeeBudget_Call {
+	return &AggsenderFlowBaser_CheckFeeBudget_Call{Call: _e.mock.On("CheckFeeBudget", ctx, buildParams)}
+}
+
+func (_c *AggsenderFlowBaser_CheckFeeBudget_Call) Run(run func(ctx context.Context, buildParams *types.CertificateBuildParams)) *AggsenderFlowBaser_CheckFeeBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CertificateBuildParams))
+	})
+	return _c
+}
+
+func (_c *AggsenderFlowBaser_CheckFeeBudget_Call) Return(_a0 error) *AggsenderFlowBaser_CheckFeeBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggsenderFlowBaser_CheckFeeBudget_Call) RunAndReturn(run func(context.Context, *types.CertificateBuildParams) error) *AggsenderFlowBaser_CheckFeeBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ConvertClaimToImportedBridgeExit provides a mock function with given fields: claim
 func (_m *AggsenderFlowBaser) ConvertClaimToImportedBridgeExit(claim bridgesync.Claim) (*agglayertypes.ImportedBridgeExit, error) {
 	ret := _m.Called(claim)
@@ -265,6 +312,53 @@ func (_c *AggsenderFlowBaser_GetNewLocalExitRoot_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// L2ChainStatus provides a mock function with no fields
+func (_m *AggsenderFlowBaser) L2ChainStatus() *types.L2ChainStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for L2ChainStatus")
+	}
+
+	var r0 *types.L2ChainStatus
+	if rf, ok := ret.Get(0).(func() *types.L2ChainStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.L2ChainStatus)
+		}
+	}
+
+	return r0
+}
+
+// AggsenderFlowBaser_L2ChainStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'L2ChainStatus'
+type AggsenderFlowBaser_L2ChainStatus_Call struct {
+	*mock.Call
+}
+
+// L2ChainStatus is a helper method to define mock.On call
+func (_e *AggsenderFlowBaser_Expecter) L2ChainStatus() *AggsenderFlowBaser_L2ChainStatus_Call {
+	return &AggsenderFlowBaser_L2ChainStatus_Call{Call: _e.mock.On("L2ChainStatus")}
+}
+
+func (_c *AggsenderFlowBaser_L2ChainStatus_Call) Run(run func()) *AggsenderFlowBaser_L2ChainStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggsenderFlowBaser_L2ChainStatus_Call) Return(_a0 *types.L2ChainStatus) *AggsenderFlowBaser_L2ChainStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AggsenderFlowBaser_L2ChainStatus_Call) RunAndReturn(run func() *types.L2ChainStatus) *AggsenderFlowBaser_L2ChainStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartL2Block provides a mock function with no fields
 func (_m *AggsenderFlowBaser) StartL2Block() uint64 {
 	ret := _m.Called()
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	types "github.com/agglayer/aggkit/aggsender/types"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+
 	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
 )
 
 // AggsenderStorer is an autogenerated mock type for the AggsenderStorer type
@@ -20,6 +23,122 @@ func (_m *AggsenderStorer) EXPECT() *AggsenderStorer_Expecter {
 	return &AggsenderStorer_Expecter{mock: &_m.Mock}
 }
 
+// GetCertificateAnalytics provides a mock function with given fields: height
+func (_m *AggsenderStorer) GetCertificateAnalytics(height uint64) (*types.CertificateAnalytics, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAnalytics")
+	}
+
+	var r0 *types.CertificateAnalytics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*types.CertificateAnalytics, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *types.CertificateAnalytics); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateAnalytics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetCertificateAnalytics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateAnalytics'
+type AggsenderStorer_GetCertificateAnalytics_Call struct {
+	*mock.Call
+}
+
+// GetCertificateAnalytics is a helper method to define mock.On call
+//   - height uint64
+func (_e *AggsenderStorer_Expecter) GetCertificateAnalytics(height interface{}) *AggsenderStorer_GetCertificateAnalytics_Call {
+	return &AggsenderStorer_GetCertificateAnalytics_Call{Call: _e.mock.On("GetCertificateAnalytics", height)}
+}
+
+func (_c *AggsenderStorer_GetCertificateAnalytics_Call) Run(run func(height uint64)) *AggsenderStorer_GetCertificateAnalytics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateAnalytics_Call) Return(_a0 *types.CertificateAnalytics, _a1 error) *AggsenderStorer_GetCertificateAnalytics_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateAnalytics_Call) RunAndReturn(run func(uint64) (*types.CertificateAnalytics, error)) *AggsenderStorer_GetCertificateAnalytics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCertificateAuditEvents provides a mock function with given fields: height
+func (_m *AggsenderStorer) GetCertificateAuditEvents(height uint64) ([]*types.CertificateAuditEvent, error) {
+	ret := _m.Called(height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAuditEvents")
+	}
+
+	var r0 []*types.CertificateAuditEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*types.CertificateAuditEvent, error)); ok {
+		return rf(height)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*types.CertificateAuditEvent); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.CertificateAuditEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetCertificateAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateAuditEvents'
+type AggsenderStorer_GetCertificateAuditEvents_Call struct {
+	*mock.Call
+}
+
+// GetCertificateAuditEvents is a helper method to define mock.On call
+//   - height uint64
+func (_e *AggsenderStorer_Expecter) GetCertificateAuditEvents(height interface{}) *AggsenderStorer_GetCertificateAuditEvents_Call {
+	return &AggsenderStorer_GetCertificateAuditEvents_Call{Call: _e.mock.On("GetCertificateAuditEvents", height)}
+}
+
+func (_c *AggsenderStorer_GetCertificateAuditEvents_Call) Run(run func(height uint64)) *AggsenderStorer_GetCertificateAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateAuditEvents_Call) Return(_a0 []*types.CertificateAuditEvent, _a1 error) *AggsenderStorer_GetCertificateAuditEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateAuditEvents_Call) RunAndReturn(run func(uint64) ([]*types.CertificateAuditEvent, error)) *AggsenderStorer_GetCertificateAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCertificateByHeight provides a mock function with given fields: height
 func (_m *AggsenderStorer) GetCertificateByHeight(height uint64) (*types.Certificate, error) {
 	ret := _m.Called(height)
@@ -78,6 +197,182 @@ func (_c *AggsenderStorer_GetCertificateByHeight_Call) RunAndReturn(run func(uin
 	return _c
 }
 
+// GetCertificateHeadersByTags provides a mock function with given fields: tags, statuses, limit
+func (_m *AggsenderStorer) GetCertificateHeadersByTags(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus, limit uint64) ([]*types.CertificateHeader, error) {
+	ret := _m.Called(tags, statuses, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateHeadersByTags")
+	}
+
+	var r0 []*types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) ([]*types.CertificateHeader, error)); ok {
+		return rf(tags, statuses, limit)
+	}
+	if rf, ok := ret.Get(0).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) []*types.CertificateHeader); ok {
+		r0 = rf(tags, statuses, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) error); ok {
+		r1 = rf(tags, statuses, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetCertificateHeadersByTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateHeadersByTags'
+type AggsenderStorer_GetCertificateHeadersByTags_Call struct {
+	*mock.Call
+}
+
+// GetCertificateHeadersByTags is a helper method to define mock.On call
+//   - tags types.CertificateTags
+//   - statuses []agglayertypes.CertificateStatus
+//   - limit uint64
+func (_e *AggsenderStorer_Expecter) GetCertificateHeadersByTags(tags interface{}, statuses interface{}, limit interface{}) *AggsenderStorer_GetCertificateHeadersByTags_Call {
+	return &AggsenderStorer_GetCertificateHeadersByTags_Call{Call: _e.mock.On("GetCertificateHeadersByTags", tags, statuses, limit)}
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersByTags_Call) Run(run func(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus, limit uint64)) *AggsenderStorer_GetCertificateHeadersByTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.CertificateTags), args[1].([]agglayertypes.CertificateStatus), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersByTags_Call) Return(_a0 []*types.CertificateHeader, _a1 error) *AggsenderStorer_GetCertificateHeadersByTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersByTags_Call) RunAndReturn(run func(types.CertificateTags, []agglayertypes.CertificateStatus, uint64) ([]*types.CertificateHeader, error)) *AggsenderStorer_GetCertificateHeadersByTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCertificateHeadersInHeightRange provides a mock function with given fields: fromHeight, toHeight
+func (_m *AggsenderStorer) GetCertificateHeadersInHeightRange(fromHeight uint64, toHeight uint64) ([]*types.CertificateHeader, error) {
+	ret := _m.Called(fromHeight, toHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateHeadersInHeightRange")
+	}
+
+	var r0 []*types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) ([]*types.CertificateHeader, error)); ok {
+		return rf(fromHeight, toHeight)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []*types.CertificateHeader); ok {
+		r0 = rf(fromHeight, toHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(fromHeight, toHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetCertificateHeadersInHeightRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCertificateHeadersInHeightRange'
+type AggsenderStorer_GetCertificateHeadersInHeightRange_Call struct {
+	*mock.Call
+}
+
+// GetCertificateHeadersInHeightRange is a helper method to define mock.On call
+//   - fromHeight uint64
+//   - toHeight uint64
+func (_e *AggsenderStorer_Expecter) GetCertificateHeadersInHeightRange(fromHeight interface{}, toHeight interface{}) *AggsenderStorer_GetCertificateHeadersInHeightRange_Call {
+	return &AggsenderStorer_GetCertificateHeadersInHeightRange_Call{Call: _e.mock.On("GetCertificateHeadersInHeightRange", fromHeight, toHeight)}
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersInHeightRange_Call) Run(run func(fromHeight uint64, toHeight uint64)) *AggsenderStorer_GetCertificateHeadersInHeightRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersInHeightRange_Call) Return(_a0 []*types.CertificateHeader, _a1 error) *AggsenderStorer_GetCertificateHeadersInHeightRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetCertificateHeadersInHeightRange_Call) RunAndReturn(run func(uint64, uint64) ([]*types.CertificateHeader, error)) *AggsenderStorer_GetCertificateHeadersInHeightRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastMirroredCertificateHeader provides a mock function with no fields
+func (_m *AggsenderStorer) GetLastMirroredCertificateHeader() (*types.MirroredCertificateHeader, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastMirroredCertificateHeader")
+	}
+
+	var r0 *types.MirroredCertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.MirroredCertificateHeader, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.MirroredCertificateHeader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.MirroredCertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetLastMirroredCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastMirroredCertificateHeader'
+type AggsenderStorer_GetLastMirroredCertificateHeader_Call struct {
+	*mock.Call
+}
+
+// GetLastMirroredCertificateHeader is a helper method to define mock.On call
+func (_e *AggsenderStorer_Expecter) GetLastMirroredCertificateHeader() *AggsenderStorer_GetLastMirroredCertificateHeader_Call {
+	return &AggsenderStorer_GetLastMirroredCertificateHeader_Call{Call: _e.mock.On("GetLastMirroredCertificateHeader")}
+}
+
+func (_c *AggsenderStorer_GetLastMirroredCertificateHeader_Call) Run(run func()) *AggsenderStorer_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetLastMirroredCertificateHeader_Call) Return(_a0 *types.MirroredCertificateHeader, _a1 error) *AggsenderStorer_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetLastMirroredCertificateHeader_Call) RunAndReturn(run func() (*types.MirroredCertificateHeader, error)) *AggsenderStorer_GetLastMirroredCertificateHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastSentCertificate provides a mock function with no fields
 func (_m *AggsenderStorer) GetLastSentCertificate() (*types.Certificate, error) {
 	ret := _m.Called()
@@ -135,6 +430,122 @@ func (_c *AggsenderStorer_GetLastSentCertificate_Call) RunAndReturn(run func() (
 	return _c
 }
 
+// GetLastSentCertificateHeader provides a mock function with no fields
+func (_m *AggsenderStorer) GetLastSentCertificateHeader() (*types.CertificateHeader, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastSentCertificateHeader")
+	}
+
+	var r0 *types.CertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.CertificateHeader, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.CertificateHeader); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetLastSentCertificateHeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastSentCertificateHeader'
+type AggsenderStorer_GetLastSentCertificateHeader_Call struct {
+	*mock.Call
+}
+
+// GetLastSentCertificateHeader is a helper method to define mock.On call
+func (_e *AggsenderStorer_Expecter) GetLastSentCertificateHeader() *AggsenderStorer_GetLastSentCertificateHeader_Call {
+	return &AggsenderStorer_GetLastSentCertificateHeader_Call{Call: _e.mock.On("GetLastSentCertificateHeader")}
+}
+
+func (_c *AggsenderStorer_GetLastSentCertificateHeader_Call) Run(run func()) *AggsenderStorer_GetLastSentCertificateHeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetLastSentCertificateHeader_Call) Return(_a0 *types.CertificateHeader, _a1 error) *AggsenderStorer_GetLastSentCertificateHeader_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetLastSentCertificateHeader_Call) RunAndReturn(run func() (*types.CertificateHeader, error)) *AggsenderStorer_GetLastSentCertificateHeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMirroredCertificateHeaders provides a mock function with given fields: fromHeight, toHeight
+func (_m *AggsenderStorer) GetMirroredCertificateHeaders(fromHeight uint64, toHeight uint64) ([]*types.MirroredCertificateHeader, error) {
+	ret := _m.Called(fromHeight, toHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMirroredCertificateHeaders")
+	}
+
+	var r0 []*types.MirroredCertificateHeader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) ([]*types.MirroredCertificateHeader, error)); ok {
+		return rf(fromHeight, toHeight)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []*types.MirroredCertificateHeader); ok {
+		r0 = rf(fromHeight, toHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.MirroredCertificateHeader)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(fromHeight, toHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AggsenderStorer_GetMirroredCertificateHeaders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMirroredCertificateHeaders'
+type AggsenderStorer_GetMirroredCertificateHeaders_Call struct {
+	*mock.Call
+}
+
+// GetMirroredCertificateHeaders is a helper method to define mock.On call
+//   - fromHeight uint64
+//   - toHeight uint64
+func (_e *AggsenderStorer_Expecter) GetMirroredCertificateHeaders(fromHeight interface{}, toHeight interface{}) *AggsenderStorer_GetMirroredCertificateHeaders_Call {
+	return &AggsenderStorer_GetMirroredCertificateHeaders_Call{Call: _e.mock.On("GetMirroredCertificateHeaders", fromHeight, toHeight)}
+}
+
+func (_c *AggsenderStorer_GetMirroredCertificateHeaders_Call) Run(run func(fromHeight uint64, toHeight uint64)) *AggsenderStorer_GetMirroredCertificateHeaders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *AggsenderStorer_GetMirroredCertificateHeaders_Call) Return(_a0 []*types.MirroredCertificateHeader, _a1 error) *AggsenderStorer_GetMirroredCertificateHeaders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AggsenderStorer_GetMirroredCertificateHeaders_Call) RunAndReturn(run func(uint64, uint64) ([]*types.MirroredCertificateHeader, error)) *AggsenderStorer_GetMirroredCertificateHeaders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewAggsenderStorer creates a new instance of AggsenderStorer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAggsenderStorer(t interface {
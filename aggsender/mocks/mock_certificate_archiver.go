@@ -0,0 +1,116 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CertificateArchiver is an autogenerated mock type for the CertificateArchiver type
+type CertificateArchiver struct {
+	mock.Mock
+}
+
+type CertificateArchiver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CertificateArchiver) EXPECT() *CertificateArchiver_Expecter {
+	return &CertificateArchiver_Expecter{mock: &_m.Mock}
+}
+
+// ArchiveCertificate provides a mock function with given fields: cert
+func (_m *CertificateArchiver) ArchiveCertificate(cert *types.Certificate) error {
+	ret := _m.Called(cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveCertificate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.Certificate) error); ok {
+		r0 = rf(cert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateArchiver_ArchiveCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveCertificate'
+type CertificateArchiver_ArchiveCertificate_Call struct {
+	*mock.Call
+}
+
+// ArchiveCertificate is a helper method to define mock.On call
+//   - cert *types.Certificate
+func (_e *CertificateArchiver_Expecter) ArchiveCertificate(cert interface{}) *CertificateArchiver_ArchiveCertificate_Call {
+	return &CertificateArchiver_ArchiveCertificate_Call{Call: _e.mock.On("ArchiveCertificate", cert)}
+}
+
+func (_c *CertificateArchiver_ArchiveCertificate_Call) Run(run func(cert *types.Certificate)) *CertificateArchiver_ArchiveCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.Certificate))
+	})
+	return _c
+}
+
+func (_c *CertificateArchiver_ArchiveCertificate_Call) Return(_a0 error) *CertificateArchiver_ArchiveCertificate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CertificateArchiver_ArchiveCertificate_Call) RunAndReturn(run func(*types.Certificate) error) *CertificateArchiver_ArchiveCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *CertificateArchiver) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// CertificateArchiver_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type CertificateArchiver_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CertificateArchiver_Expecter) Start(ctx interface{}) *CertificateArchiver_Start_Call {
+	return &CertificateArchiver_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *CertificateArchiver_Start_Call) Run(run func(ctx context.Context)) *CertificateArchiver_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CertificateArchiver_Start_Call) Return() *CertificateArchiver_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *CertificateArchiver_Start_Call) RunAndReturn(run func(context.Context)) *CertificateArchiver_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewCertificateArchiver creates a new instance of CertificateArchiver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCertificateArchiver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CertificateArchiver {
+	mock := &CertificateArchiver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,158 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	bridgesync "github.com/agglayer/aggkit/bridgesync"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CertificateBridgeQuerier is an autogenerated mock type for the CertificateBridgeQuerier type
+type CertificateBridgeQuerier struct {
+	mock.Mock
+}
+
+type CertificateBridgeQuerier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CertificateBridgeQuerier) EXPECT() *CertificateBridgeQuerier_Expecter {
+	return &CertificateBridgeQuerier_Expecter{mock: &_m.Mock}
+}
+
+// GetBridges provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *CertificateBridgeQuerier) GetBridges(ctx context.Context, fromBlock uint64, toBlock uint64) ([]bridgesync.Bridge, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBridges")
+	}
+
+	var r0 []bridgesync.Bridge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]bridgesync.Bridge, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []bridgesync.Bridge); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridgesync.Bridge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateBridgeQuerier_GetBridges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridges'
+type CertificateBridgeQuerier_GetBridges_Call struct {
+	*mock.Call
+}
+
+// GetBridges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *CertificateBridgeQuerier_Expecter) GetBridges(ctx interface{}, fromBlock interface{}, toBlock interface{}) *CertificateBridgeQuerier_GetBridges_Call {
+	return &CertificateBridgeQuerier_GetBridges_Call{Call: _e.mock.On("GetBridges", ctx, fromBlock, toBlock)}
+}
+
+func (_c *CertificateBridgeQuerier_GetBridges_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *CertificateBridgeQuerier_GetBridges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *CertificateBridgeQuerier_GetBridges_Call) Return(_a0 []bridgesync.Bridge, _a1 error) *CertificateBridgeQuerier_GetBridges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CertificateBridgeQuerier_GetBridges_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]bridgesync.Bridge, error)) *CertificateBridgeQuerier_GetBridges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClaims provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *CertificateBridgeQuerier) GetClaims(ctx context.Context, fromBlock uint64, toBlock uint64) ([]bridgesync.Claim, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClaims")
+	}
+
+	var r0 []bridgesync.Claim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]bridgesync.Claim, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []bridgesync.Claim); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridgesync.Claim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateBridgeQuerier_GetClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClaims'
+type CertificateBridgeQuerier_GetClaims_Call struct {
+	*mock.Call
+}
+
+// GetClaims is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *CertificateBridgeQuerier_Expecter) GetClaims(ctx interface{}, fromBlock interface{}, toBlock interface{}) *CertificateBridgeQuerier_GetClaims_Call {
+	return &CertificateBridgeQuerier_GetClaims_Call{Call: _e.mock.On("GetClaims", ctx, fromBlock, toBlock)}
+}
+
+func (_c *CertificateBridgeQuerier_GetClaims_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *CertificateBridgeQuerier_GetClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *CertificateBridgeQuerier_GetClaims_Call) Return(_a0 []bridgesync.Claim, _a1 error) *CertificateBridgeQuerier_GetClaims_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CertificateBridgeQuerier_GetClaims_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]bridgesync.Claim, error)) *CertificateBridgeQuerier_GetClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCertificateBridgeQuerier creates a new instance of CertificateBridgeQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCertificateBridgeQuerier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CertificateBridgeQuerier {
+	mock := &CertificateBridgeQuerier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
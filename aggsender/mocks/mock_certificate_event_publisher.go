@@ -0,0 +1,103 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CertificateEventPublisher is an autogenerated mock type for the CertificateEventPublisher type
+type CertificateEventPublisher struct {
+	mock.Mock
+}
+
+type CertificateEventPublisher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CertificateEventPublisher) EXPECT() *CertificateEventPublisher_Expecter {
+	return &CertificateEventPublisher_Expecter{mock: &_m.Mock}
+}
+
+// Publish provides a mock function with given fields: event
+func (_m *CertificateEventPublisher) Publish(event types.CertificateEvent) {
+	_m.Called(event)
+}
+
+// CertificateEventPublisher_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type CertificateEventPublisher_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - event types.CertificateEvent
+func (_e *CertificateEventPublisher_Expecter) Publish(event interface{}) *CertificateEventPublisher_Publish_Call {
+	return &CertificateEventPublisher_Publish_Call{Call: _e.mock.On("Publish", event)}
+}
+
+func (_c *CertificateEventPublisher_Publish_Call) Run(run func(event types.CertificateEvent)) *CertificateEventPublisher_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.CertificateEvent))
+	})
+	return _c
+}
+
+func (_c *CertificateEventPublisher_Publish_Call) Return() *CertificateEventPublisher_Publish_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *CertificateEventPublisher_Publish_Call) RunAndReturn(run func(types.CertificateEvent)) *CertificateEventPublisher_Publish_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *CertificateEventPublisher) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// CertificateEventPublisher_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type CertificateEventPublisher_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *CertificateEventPublisher_Expecter) Start(ctx interface{}) *CertificateEventPublisher_Start_Call {
+	return &CertificateEventPublisher_Start_Call{Call: _e.mock.On("Start", ctx)}
+}
+
+func (_c *CertificateEventPublisher_Start_Call) Run(run func(ctx context.Context)) *CertificateEventPublisher_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *CertificateEventPublisher_Start_Call) Return() *CertificateEventPublisher_Start_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *CertificateEventPublisher_Start_Call) RunAndReturn(run func(context.Context)) *CertificateEventPublisher_Start_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewCertificateEventPublisher creates a new instance of CertificateEventPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCertificateEventPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CertificateEventPublisher {
+	mock := &CertificateEventPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
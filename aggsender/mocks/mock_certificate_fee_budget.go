@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CertificateFeeBudget is an autogenerated mock type for the CertificateFeeBudget type
+type CertificateFeeBudget struct {
+	mock.Mock
+}
+
+type CertificateFeeBudget_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CertificateFeeBudget) EXPECT() *CertificateFeeBudget_Expecter {
+	return &CertificateFeeBudget_Expecter{mock: &_m.Mock}
+}
+
+// CheckFeeBudget provides a mock function with given fields: ctx, buildParams
+func (_m *CertificateFeeBudget) CheckFeeBudget(ctx context.Context, buildParams *types.CertificateBuildParams) error {
+	ret := _m.Called(ctx, buildParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckFeeBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.CertificateBuildParams) error); ok {
+		r0 = rf(ctx, buildParams)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateFeeBudget_CheckFeeBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckFeeBudget'
+type CertificateFeeBudget_CheckFeeBudget_Call struct {
+	*mock.Call
+}
+
+// CheckFeeBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - buildParams *types.CertificateBuildParams
+func (_e *CertificateFeeBudget_Expecter) CheckFeeBudget(ctx interface{}, buildParams interface{}) *CertificateFeeBudget_CheckFeeBudget_Call {
+	return &CertificateFeeBudget_CheckFeeBudget_Call{Call: _e.mock.On("CheckFeeBudget", ctx, buildParams)}
+}
+
+func (_c *CertificateFeeBudget_CheckFeeBudget_Call) Run(run func(ctx context.Context, buildParams *types.CertificateBuildParams)) *CertificateFeeBudget_CheckFeeBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.CertificateBuildParams))
+	})
+	return _c
+}
+
+func (_c *CertificateFeeBudget_CheckFeeBudget_Call) Return(_a0 error) *CertificateFeeBudget_CheckFeeBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CertificateFeeBudget_CheckFeeBudget_Call) RunAndReturn(run func(context.Context, *types.CertificateBuildParams) error) *CertificateFeeBudget_CheckFeeBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCertificateFeeBudget creates a new instance of CertificateFeeBudget. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCertificateFeeBudget(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CertificateFeeBudget {
+	mock := &CertificateFeeBudget{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	certvalidation "github.com/agglayer/aggkit/aggsender/certvalidation"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/agglayer/aggkit/agglayer/types"
+)
+
+// CertificateValidator is an autogenerated mock type for the CertificateValidator type
+type CertificateValidator struct {
+	mock.Mock
+}
+
+type CertificateValidator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *CertificateValidator) EXPECT() *CertificateValidator_Expecter {
+	return &CertificateValidator_Expecter{mock: &_m.Mock}
+}
+
+// Validate provides a mock function with given fields: cert
+func (_m *CertificateValidator) Validate(cert *types.Certificate) (*certvalidation.Verdict, error) {
+	ret := _m.Called(cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Validate")
+	}
+
+	var r0 *certvalidation.Verdict
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*types.Certificate) (*certvalidation.Verdict, error)); ok {
+		return rf(cert)
+	}
+	if rf, ok := ret.Get(0).(func(*types.Certificate) *certvalidation.Verdict); ok {
+		r0 = rf(cert)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*certvalidation.Verdict)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*types.Certificate) error); ok {
+		r1 = rf(cert)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateValidator_Validate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Validate'
+type CertificateValidator_Validate_Call struct {
+	*mock.Call
+}
+
+// Validate is a helper method to define mock.On call
+//   - cert *types.Certificate
+func (_e *CertificateValidator_Expecter) Validate(cert interface{}) *CertificateValidator_Validate_Call {
+	return &CertificateValidator_Validate_Call{Call: _e.mock.On("Validate", cert)}
+}
+
+func (_c *CertificateValidator_Validate_Call) Run(run func(cert *types.Certificate)) *CertificateValidator_Validate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.Certificate))
+	})
+	return _c
+}
+
+func (_c *CertificateValidator_Validate_Call) Return(_a0 *certvalidation.Verdict, _a1 error) *CertificateValidator_Validate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *CertificateValidator_Validate_Call) RunAndReturn(run func(*types.Certificate) (*certvalidation.Verdict, error)) *CertificateValidator_Validate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewCertificateValidator creates a new instance of CertificateValidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCertificateValidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CertificateValidator {
+	mock := &CertificateValidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DataAvailabilityVerifier is an autogenerated mock type for the DataAvailabilityVerifier type
+type DataAvailabilityVerifier struct {
+	mock.Mock
+}
+
+type DataAvailabilityVerifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DataAvailabilityVerifier) EXPECT() *DataAvailabilityVerifier_Expecter {
+	return &DataAvailabilityVerifier_Expecter{mock: &_m.Mock}
+}
+
+// VerifyDataAvailability provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *DataAvailabilityVerifier) VerifyDataAvailability(ctx context.Context, fromBlock uint64, toBlock uint64) (*types.DataAvailabilityAttestation, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyDataAvailability")
+	}
+
+	var r0 *types.DataAvailabilityAttestation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*types.DataAvailabilityAttestation, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *types.DataAvailabilityAttestation); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DataAvailabilityAttestation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataAvailabilityVerifier_VerifyDataAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyDataAvailability'
+type DataAvailabilityVerifier_VerifyDataAvailability_Call struct {
+	*mock.Call
+}
+
+// VerifyDataAvailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *DataAvailabilityVerifier_Expecter) VerifyDataAvailability(ctx interface{}, fromBlock interface{}, toBlock interface{}) *DataAvailabilityVerifier_VerifyDataAvailability_Call {
+	return &DataAvailabilityVerifier_VerifyDataAvailability_Call{Call: _e.mock.On("VerifyDataAvailability", ctx, fromBlock, toBlock)}
+}
+
+func (_c *DataAvailabilityVerifier_VerifyDataAvailability_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *DataAvailabilityVerifier_VerifyDataAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *DataAvailabilityVerifier_VerifyDataAvailability_Call) Return(_a0 *types.DataAvailabilityAttestation, _a1 error) *DataAvailabilityVerifier_VerifyDataAvailability_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataAvailabilityVerifier_VerifyDataAvailability_Call) RunAndReturn(run func(context.Context, uint64, uint64) (*types.DataAvailabilityAttestation, error)) *DataAvailabilityVerifier_VerifyDataAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDataAvailabilityVerifier creates a new instance of DataAvailabilityVerifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDataAvailabilityVerifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DataAvailabilityVerifier {
+	mock := &DataAvailabilityVerifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
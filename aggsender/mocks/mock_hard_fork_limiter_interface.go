@@ -0,0 +1,139 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	types "github.com/agglayer/aggkit/aggsender/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HardForkLimiterInterface is an autogenerated mock type for the HardForkLimiterInterface type
+type HardForkLimiterInterface struct {
+	mock.Mock
+}
+
+type HardForkLimiterInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HardForkLimiterInterface) EXPECT() *HardForkLimiterInterface_Expecter {
+	return &HardForkLimiterInterface_Expecter{mock: &_m.Mock}
+}
+
+// AdaptCertificate provides a mock function with given fields: buildParams
+func (_m *HardForkLimiterInterface) AdaptCertificate(buildParams *types.CertificateBuildParams) (*types.CertificateBuildParams, error) {
+	ret := _m.Called(buildParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdaptCertificate")
+	}
+
+	var r0 *types.CertificateBuildParams
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*types.CertificateBuildParams) (*types.CertificateBuildParams, error)); ok {
+		return rf(buildParams)
+	}
+	if rf, ok := ret.Get(0).(func(*types.CertificateBuildParams) *types.CertificateBuildParams); ok {
+		r0 = rf(buildParams)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CertificateBuildParams)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*types.CertificateBuildParams) error); ok {
+		r1 = rf(buildParams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HardForkLimiterInterface_AdaptCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdaptCertificate'
+type HardForkLimiterInterface_AdaptCertificate_Call struct {
+	*mock.Call
+}
+
+// AdaptCertificate is a helper method to define mock.On call
+//   - buildParams *types.CertificateBuildParams
+func (_e *HardForkLimiterInterface_Expecter) AdaptCertificate(buildParams interface{}) *HardForkLimiterInterface_AdaptCertificate_Call {
+	return &HardForkLimiterInterface_AdaptCertificate_Call{Call: _e.mock.On("AdaptCertificate", buildParams)}
+}
+
+func (_c *HardForkLimiterInterface_AdaptCertificate_Call) Run(run func(buildParams *types.CertificateBuildParams)) *HardForkLimiterInterface_AdaptCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.CertificateBuildParams))
+	})
+	return _c
+}
+
+func (_c *HardForkLimiterInterface_AdaptCertificate_Call) Return(_a0 *types.CertificateBuildParams, _a1 error) *HardForkLimiterInterface_AdaptCertificate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *HardForkLimiterInterface_AdaptCertificate_Call) RunAndReturn(run func(*types.CertificateBuildParams) (*types.CertificateBuildParams, error)) *HardForkLimiterInterface_AdaptCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndsAtFork provides a mock function with given fields: buildParams
+func (_m *HardForkLimiterInterface) EndsAtFork(buildParams *types.CertificateBuildParams) bool {
+	ret := _m.Called(buildParams)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndsAtFork")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*types.CertificateBuildParams) bool); ok {
+		r0 = rf(buildParams)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// HardForkLimiterInterface_EndsAtFork_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndsAtFork'
+type HardForkLimiterInterface_EndsAtFork_Call struct {
+	*mock.Call
+}
+
+// EndsAtFork is a helper method to define mock.On call
+//   - buildParams *types.CertificateBuildParams
+func (_e *HardForkLimiterInterface_Expecter) EndsAtFork(buildParams interface{}) *HardForkLimiterInterface_EndsAtFork_Call {
+	return &HardForkLimiterInterface_EndsAtFork_Call{Call: _e.mock.On("EndsAtFork", buildParams)}
+}
+
+func (_c *HardForkLimiterInterface_EndsAtFork_Call) Run(run func(buildParams *types.CertificateBuildParams)) *HardForkLimiterInterface_EndsAtFork_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*types.CertificateBuildParams))
+	})
+	return _c
+}
+
+func (_c *HardForkLimiterInterface_EndsAtFork_Call) Return(_a0 bool) *HardForkLimiterInterface_EndsAtFork_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HardForkLimiterInterface_EndsAtFork_Call) RunAndReturn(run func(*types.CertificateBuildParams) bool) *HardForkLimiterInterface_EndsAtFork_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHardForkLimiterInterface creates a new instance of HardForkLimiterInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHardForkLimiterInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HardForkLimiterInterface {
+	mock := &HardForkLimiterInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -75,6 +75,54 @@ func (_c *L1InfoTreeDataQuerier_CheckIfClaimsArePartOfFinalizedL1InfoTree_Call)
 	return _c
 }
 
+// CheckL1InfoTreeRoot provides a mock function with given fields: ctx, root, index
+func (_m *L1InfoTreeDataQuerier) CheckL1InfoTreeRoot(ctx context.Context, root common.Hash, index uint32) error {
+	ret := _m.Called(ctx, root, index)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckL1InfoTreeRoot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash, uint32) error); ok {
+		r0 = rf(ctx, root, index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckL1InfoTreeRoot'
+type L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call struct {
+	*mock.Call
+}
+
+// CheckL1InfoTreeRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - root common.Hash
+//   - index uint32
+func (_e *L1InfoTreeDataQuerier_Expecter) CheckL1InfoTreeRoot(ctx interface{}, root interface{}, index interface{}) *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call {
+	return &L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call{Call: _e.mock.On("CheckL1InfoTreeRoot", ctx, root, index)}
+}
+
+func (_c *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call) Run(run func(ctx context.Context, root common.Hash, index uint32)) *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash), args[2].(uint32))
+	})
+	return _c
+}
+
+func (_c *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call) Return(_a0 error) *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call) RunAndReturn(run func(context.Context, common.Hash, uint32) error) *L1InfoTreeDataQuerier_CheckL1InfoTreeRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFinalizedL1InfoTreeData provides a mock function with given fields: ctx
 func (_m *L1InfoTreeDataQuerier) GetFinalizedL1InfoTreeData(ctx context.Context) (types.Proof, *l1infotreesync.L1InfoTreeLeaf, *types.Root, error) {
 	ret := _m.Called(ctx)
@@ -287,6 +335,75 @@ func (_c *L1InfoTreeDataQuerier_GetProofForGER_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// GetProofsForGERs provides a mock function with given fields: ctx, gers, rootFromWhichToProve
+func (_m *L1InfoTreeDataQuerier) GetProofsForGERs(ctx context.Context, gers []common.Hash, rootFromWhichToProve common.Hash) ([]*l1infotreesync.L1InfoTreeLeaf, []types.Proof, error) {
+	ret := _m.Called(ctx, gers, rootFromWhichToProve)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProofsForGERs")
+	}
+
+	var r0 []*l1infotreesync.L1InfoTreeLeaf
+	var r1 []types.Proof
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash, common.Hash) ([]*l1infotreesync.L1InfoTreeLeaf, []types.Proof, error)); ok {
+		return rf(ctx, gers, rootFromWhichToProve)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []common.Hash, common.Hash) []*l1infotreesync.L1InfoTreeLeaf); ok {
+		r0 = rf(ctx, gers, rootFromWhichToProve)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*l1infotreesync.L1InfoTreeLeaf)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []common.Hash, common.Hash) []types.Proof); ok {
+		r1 = rf(ctx, gers, rootFromWhichToProve)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]types.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []common.Hash, common.Hash) error); ok {
+		r2 = rf(ctx, gers, rootFromWhichToProve)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// L1InfoTreeDataQuerier_GetProofsForGERs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProofsForGERs'
+type L1InfoTreeDataQuerier_GetProofsForGERs_Call struct {
+	*mock.Call
+}
+
+// GetProofsForGERs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - gers []common.Hash
+//   - rootFromWhichToProve common.Hash
+func (_e *L1InfoTreeDataQuerier_Expecter) GetProofsForGERs(ctx interface{}, gers interface{}, rootFromWhichToProve interface{}) *L1InfoTreeDataQuerier_GetProofsForGERs_Call {
+	return &L1InfoTreeDataQuerier_GetProofsForGERs_Call{Call: _e.mock.On("GetProofsForGERs", ctx, gers, rootFromWhichToProve)}
+}
+
+func (_c *L1InfoTreeDataQuerier_GetProofsForGERs_Call) Run(run func(ctx context.Context, gers []common.Hash, rootFromWhichToProve common.Hash)) *L1InfoTreeDataQuerier_GetProofsForGERs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]common.Hash), args[2].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *L1InfoTreeDataQuerier_GetProofsForGERs_Call) Return(_a0 []*l1infotreesync.L1InfoTreeLeaf, _a1 []types.Proof, _a2 error) *L1InfoTreeDataQuerier_GetProofsForGERs_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *L1InfoTreeDataQuerier_GetProofsForGERs_Call) RunAndReturn(run func(context.Context, []common.Hash, common.Hash) ([]*l1infotreesync.L1InfoTreeLeaf, []types.Proof, error)) *L1InfoTreeDataQuerier_GetProofsForGERs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewL1InfoTreeDataQuerier creates a new instance of L1InfoTreeDataQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewL1InfoTreeDataQuerier(t interface {
@@ -204,6 +204,66 @@ func (_c *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofFromIndexToRoot_Call) RunAndR
 	return _c
 }
 
+// GetL1InfoTreeMerkleProofsFromIndexesToRoot provides a mock function with given fields: ctx, indexes, root
+func (_m *L1InfoTreeSyncer) GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx context.Context, indexes []uint32, root common.Hash) ([]treetypes.Proof, error) {
+	ret := _m.Called(ctx, indexes, root)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetL1InfoTreeMerkleProofsFromIndexesToRoot")
+	}
+
+	var r0 []treetypes.Proof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32, common.Hash) ([]treetypes.Proof, error)); ok {
+		return rf(ctx, indexes, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32, common.Hash) []treetypes.Proof); ok {
+		r0 = rf(ctx, indexes, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]treetypes.Proof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint32, common.Hash) error); ok {
+		r1 = rf(ctx, indexes, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetL1InfoTreeMerkleProofsFromIndexesToRoot'
+type L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call struct {
+	*mock.Call
+}
+
+// GetL1InfoTreeMerkleProofsFromIndexesToRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - indexes []uint32
+//   - root common.Hash
+func (_e *L1InfoTreeSyncer_Expecter) GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx interface{}, indexes interface{}, root interface{}) *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call {
+	return &L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call{Call: _e.mock.On("GetL1InfoTreeMerkleProofsFromIndexesToRoot", ctx, indexes, root)}
+}
+
+func (_c *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call) Run(run func(ctx context.Context, indexes []uint32, root common.Hash)) *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uint32), args[2].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call) Return(_a0 []treetypes.Proof, _a1 error) *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call) RunAndReturn(run func(context.Context, []uint32, common.Hash) ([]treetypes.Proof, error)) *L1InfoTreeSyncer_GetL1InfoTreeMerkleProofsFromIndexesToRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetL1InfoTreeRootByIndex provides a mock function with given fields: ctx, index
 func (_m *L1InfoTreeSyncer) GetL1InfoTreeRootByIndex(ctx context.Context, index uint32) (treetypes.Root, error) {
 	ret := _m.Called(ctx, index)
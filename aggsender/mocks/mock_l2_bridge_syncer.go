@@ -250,6 +250,72 @@ func (_c *L2BridgeSyncer_GetClaims_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetContractLocalExitRoot provides a mock function with given fields: ctx, blockNumber
+func (_m *L2BridgeSyncer) GetContractLocalExitRoot(ctx context.Context, blockNumber uint64) (common.Hash, uint32, error) {
+	ret := _m.Called(ctx, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractLocalExitRoot")
+	}
+
+	var r0 common.Hash
+	var r1 uint32
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (common.Hash, uint32, error)); ok {
+		return rf(ctx, blockNumber)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) common.Hash); ok {
+		r0 = rf(ctx, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) uint32); ok {
+		r1 = rf(ctx, blockNumber)
+	} else {
+		r1 = ret.Get(1).(uint32)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64) error); ok {
+		r2 = rf(ctx, blockNumber)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// L2BridgeSyncer_GetContractLocalExitRoot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContractLocalExitRoot'
+type L2BridgeSyncer_GetContractLocalExitRoot_Call struct {
+	*mock.Call
+}
+
+// GetContractLocalExitRoot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockNumber uint64
+func (_e *L2BridgeSyncer_Expecter) GetContractLocalExitRoot(ctx interface{}, blockNumber interface{}) *L2BridgeSyncer_GetContractLocalExitRoot_Call {
+	return &L2BridgeSyncer_GetContractLocalExitRoot_Call{Call: _e.mock.On("GetContractLocalExitRoot", ctx, blockNumber)}
+}
+
+func (_c *L2BridgeSyncer_GetContractLocalExitRoot_Call) Run(run func(ctx context.Context, blockNumber uint64)) *L2BridgeSyncer_GetContractLocalExitRoot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *L2BridgeSyncer_GetContractLocalExitRoot_Call) Return(_a0 common.Hash, _a1 uint32, _a2 error) *L2BridgeSyncer_GetContractLocalExitRoot_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *L2BridgeSyncer_GetContractLocalExitRoot_Call) RunAndReturn(run func(context.Context, uint64) (common.Hash, uint32, error)) *L2BridgeSyncer_GetContractLocalExitRoot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetExitRootByIndex provides a mock function with given fields: ctx, index
 func (_m *L2BridgeSyncer) GetExitRootByIndex(ctx context.Context, index uint32) (treetypes.Root, error) {
 	ret := _m.Called(ctx, index)
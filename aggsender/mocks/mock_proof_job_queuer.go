@@ -0,0 +1,153 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/agglayer/aggkit/aggsender/types"
+)
+
+// ProofJobQueuer is an autogenerated mock type for the ProofJobQueuer type
+type ProofJobQueuer struct {
+	mock.Mock
+}
+
+type ProofJobQueuer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ProofJobQueuer) EXPECT() *ProofJobQueuer_Expecter {
+	return &ProofJobQueuer_Expecter{mock: &_m.Mock}
+}
+
+// Get provides a mock function with given fields: jobID
+func (_m *ProofJobQueuer) Get(jobID string) (*types.ProofJob, error) {
+	ret := _m.Called(jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *types.ProofJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*types.ProofJob, error)); ok {
+		return rf(jobID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *types.ProofJob); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ProofJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProofJobQueuer_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type ProofJobQueuer_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - jobID string
+func (_e *ProofJobQueuer_Expecter) Get(jobID interface{}) *ProofJobQueuer_Get_Call {
+	return &ProofJobQueuer_Get_Call{Call: _e.mock.On("Get", jobID)}
+}
+
+func (_c *ProofJobQueuer_Get_Call) Run(run func(jobID string)) *ProofJobQueuer_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ProofJobQueuer_Get_Call) Return(_a0 *types.ProofJob, _a1 error) *ProofJobQueuer_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProofJobQueuer_Get_Call) RunAndReturn(run func(string) (*types.ProofJob, error)) *ProofJobQueuer_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Submit provides a mock function with given fields: lastProvenBlock, maxEndBlock
+func (_m *ProofJobQueuer) Submit(lastProvenBlock uint64, maxEndBlock uint64) (*types.ProofJob, error) {
+	ret := _m.Called(lastProvenBlock, maxEndBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Submit")
+	}
+
+	var r0 *types.ProofJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (*types.ProofJob, error)); ok {
+		return rf(lastProvenBlock, maxEndBlock)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) *types.ProofJob); ok {
+		r0 = rf(lastProvenBlock, maxEndBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ProofJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(lastProvenBlock, maxEndBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProofJobQueuer_Submit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Submit'
+type ProofJobQueuer_Submit_Call struct {
+	*mock.Call
+}
+
+// Submit is a helper method to define mock.On call
+//   - lastProvenBlock uint64
+//   - maxEndBlock uint64
+func (_e *ProofJobQueuer_Expecter) Submit(lastProvenBlock interface{}, maxEndBlock interface{}) *ProofJobQueuer_Submit_Call {
+	return &ProofJobQueuer_Submit_Call{Call: _e.mock.On("Submit", lastProvenBlock, maxEndBlock)}
+}
+
+func (_c *ProofJobQueuer_Submit_Call) Run(run func(lastProvenBlock uint64, maxEndBlock uint64)) *ProofJobQueuer_Submit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint64), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *ProofJobQueuer_Submit_Call) Return(_a0 *types.ProofJob, _a1 error) *ProofJobQueuer_Submit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ProofJobQueuer_Submit_Call) RunAndReturn(run func(uint64, uint64) (*types.ProofJob, error)) *ProofJobQueuer_Submit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewProofJobQueuer creates a new instance of ProofJobQueuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProofJobQueuer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProofJobQueuer {
+	mock := &ProofJobQueuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,6 +1,7 @@
 package optimistic
 
 import (
+	"github.com/agglayer/aggkit/aggsender/signerreload"
 	signertypes "github.com/agglayer/go_signer/signer/types"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 )
@@ -17,4 +18,10 @@ type Config struct {
 	// the trusted sequencer address.
 	// This is useful to ensure that the signer is the trusted sequencer, and not a random signer.
 	RequireKeyMatchTrustedSequencer bool `mapstructure:"RequireKeyMatchTrustedSequencer"`
+	// SignerReload is the configuration of the live reload of the TrustedSequencerKey credentials. The
+	// reloaded key is validated against the trustedSequencer of the AggchainFEP contract
+	SignerReload signerreload.Config `mapstructure:"SignerReload"`
+	// Signature is the scheme used to sign the optimistic proofs, it depends on how the contracts
+	// of the network verify the signature (the default is the raw keccak hash)
+	Signature SignatureConfig `mapstructure:"Signature"`
 }
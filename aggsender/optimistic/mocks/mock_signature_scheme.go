@@ -0,0 +1,188 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	common "github.com/ethereum/go-ethereum/common"
+	mock "github.com/stretchr/testify/mock"
+
+	optimistichash "github.com/agglayer/aggkit/aggsender/optimistic/optimistichash"
+)
+
+// SignatureScheme is an autogenerated mock type for the SignatureScheme type
+type SignatureScheme struct {
+	mock.Mock
+}
+
+type SignatureScheme_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SignatureScheme) EXPECT() *SignatureScheme_Expecter {
+	return &SignatureScheme_Expecter{mock: &_m.Mock}
+}
+
+// EncodeSignature provides a mock function with given fields: signature
+func (_m *SignatureScheme) EncodeSignature(signature []byte) ([]byte, error) {
+	ret := _m.Called(signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EncodeSignature")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte) ([]byte, error)); ok {
+		return rf(signature)
+	}
+	if rf, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = rf(signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignatureScheme_EncodeSignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EncodeSignature'
+type SignatureScheme_EncodeSignature_Call struct {
+	*mock.Call
+}
+
+// EncodeSignature is a helper method to define mock.On call
+//   - signature []byte
+func (_e *SignatureScheme_Expecter) EncodeSignature(signature interface{}) *SignatureScheme_EncodeSignature_Call {
+	return &SignatureScheme_EncodeSignature_Call{Call: _e.mock.On("EncodeSignature", signature)}
+}
+
+func (_c *SignatureScheme_EncodeSignature_Call) Run(run func(signature []byte)) *SignatureScheme_EncodeSignature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]byte))
+	})
+	return _c
+}
+
+func (_c *SignatureScheme_EncodeSignature_Call) Return(_a0 []byte, _a1 error) *SignatureScheme_EncodeSignature_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SignatureScheme_EncodeSignature_Call) RunAndReturn(run func([]byte) ([]byte, error)) *SignatureScheme_EncodeSignature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HashToSign provides a mock function with given fields: data
+func (_m *SignatureScheme) HashToSign(data *optimistichash.OptimisticSignatureData) common.Hash {
+	ret := _m.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HashToSign")
+	}
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(*optimistichash.OptimisticSignatureData) common.Hash); ok {
+		r0 = rf(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	return r0
+}
+
+// SignatureScheme_HashToSign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HashToSign'
+type SignatureScheme_HashToSign_Call struct {
+	*mock.Call
+}
+
+// HashToSign is a helper method to define mock.On call
+//   - data *optimistichash.OptimisticSignatureData
+func (_e *SignatureScheme_Expecter) HashToSign(data interface{}) *SignatureScheme_HashToSign_Call {
+	return &SignatureScheme_HashToSign_Call{Call: _e.mock.On("HashToSign", data)}
+}
+
+func (_c *SignatureScheme_HashToSign_Call) Run(run func(data *optimistichash.OptimisticSignatureData)) *SignatureScheme_HashToSign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*optimistichash.OptimisticSignatureData))
+	})
+	return _c
+}
+
+func (_c *SignatureScheme_HashToSign_Call) Return(_a0 common.Hash) *SignatureScheme_HashToSign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SignatureScheme_HashToSign_Call) RunAndReturn(run func(*optimistichash.OptimisticSignatureData) common.Hash) *SignatureScheme_HashToSign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// String provides a mock function with no fields
+func (_m *SignatureScheme) String() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for String")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// SignatureScheme_String_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'String'
+type SignatureScheme_String_Call struct {
+	*mock.Call
+}
+
+// String is a helper method to define mock.On call
+func (_e *SignatureScheme_Expecter) String() *SignatureScheme_String_Call {
+	return &SignatureScheme_String_Call{Call: _e.mock.On("String")}
+}
+
+func (_c *SignatureScheme_String_Call) Run(run func()) *SignatureScheme_String_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *SignatureScheme_String_Call) Return(_a0 string) *SignatureScheme_String_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SignatureScheme_String_Call) RunAndReturn(run func() string) *SignatureScheme_String_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSignatureScheme creates a new instance of SignatureScheme. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSignatureScheme(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SignatureScheme {
+	mock := &SignatureScheme{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -21,7 +21,8 @@ type OptimisticAggregationProofPublicValuesQuery struct {
 	aggchainFEPContract FEPContractQuerier
 	aggchainFEPAddr     common.Address
 	opNodeClient        OpNodeClienter
-	proverAddress       common.Address
+	// proverAddress returns the address of the key that signs the optimistic proofs, that can be rotated
+	proverAddress func() common.Address
 }
 
 // NewOptimisticAggregationProofPublicValuesQuery creates a new instance of OptimisticAggregationProofPublicValuesQuery
@@ -29,7 +30,7 @@ func NewOptimisticAggregationProofPublicValuesQuery(
 	aggchainFEPContract FEPContractQuerier,
 	aggchainFEPAddr common.Address,
 	opNodeClient OpNodeClienter,
-	proverAddress common.Address,
+	proverAddress func() common.Address,
 ) *OptimisticAggregationProofPublicValuesQuery {
 	return &OptimisticAggregationProofPublicValuesQuery{
 		aggchainFEPContract: aggchainFEPContract,
@@ -72,6 +73,6 @@ func (o *OptimisticAggregationProofPublicValuesQuery) GetAggregationProofPublicV
 		L2BlockNumber:    requestedEndBlock,
 		RollupConfigHash: rollupConfigHash,
 		MultiBlockVKey:   multiBlockVKey,
-		ProverAddress:    o.proverAddress,
+		ProverAddress:    o.proverAddress(),
 	}, nil
 }
@@ -16,7 +16,8 @@ func TestGetAggregationProofPublicValuesData_Success(t *testing.T) {
 
 	contractAddr := common.HexToAddress("0x1234567890123456789012345678901234567890")
 	proverAddress := common.HexToAddress("0x0987654321098765432109876543210987654321")
-	sut := NewOptimisticAggregationProofPublicValuesQuery(mockFEPContract, contractAddr, mockOPNodeClient, proverAddress)
+	sut := NewOptimisticAggregationProofPublicValuesQuery(mockFEPContract, contractAddr, mockOPNodeClient,
+		func() common.Address { return proverAddress })
 
 	lastProvenBlock := uint64(1)
 	requestedEndBlock := uint64(2)
@@ -51,7 +52,8 @@ func TestGetAggregationProofPublicValuesData_Failure(t *testing.T) {
 
 	contractAddr := common.HexToAddress("0x1234567890123456789012345678901234567890")
 	proverAddress := common.HexToAddress("0x0987654321098765432109876543210987654321")
-	sut := NewOptimisticAggregationProofPublicValuesQuery(mockFEPContract, contractAddr, mockOPNodeClient, proverAddress)
+	sut := NewOptimisticAggregationProofPublicValuesQuery(mockFEPContract, contractAddr, mockOPNodeClient,
+		func() common.Address { return proverAddress })
 
 	lastProvenBlock := uint64(1)
 	requestedEndBlock := uint64(2)
@@ -5,14 +5,16 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/aggchainfep"
+	"github.com/agglayer/aggkit/aggsender/hwsigner"
 	optimistichash "github.com/agglayer/aggkit/aggsender/optimistic/optimistichash"
+	"github.com/agglayer/aggkit/aggsender/signerreload"
 	"github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/opnode"
 	aggkittypes "github.com/agglayer/aggkit/types"
-	"github.com/agglayer/go_signer/signer"
 	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -20,6 +22,7 @@ import (
 type OptimisticSignatureCalculatorImpl struct {
 	queryAggregationProofPublicValues OptimisticAggregationProofPublicValuesQuerier
 	signer                            signertypes.HashSigner
+	scheme                            SignatureScheme
 	logger                            *log.Logger
 }
 
@@ -34,16 +37,16 @@ func NewOptimisticSignatureCalculatorImpl(
 	if err != nil {
 		return nil, fmt.Errorf("newOptimisticSignatureCalculatorImpl.NewAggchainfep Err: %w", err)
 	}
-	signer, err := signer.NewSigner(ctx, 0, cfg.TrustedSequencerKey, "optimistic", logger)
+	scheme, err := NewSignatureScheme(ctx, cfg.Signature, cfg.SovereignRollupAddr, l1Client)
 	if err != nil {
-		return nil, fmt.Errorf("optimistic. error NewSigner. Err: %w", err)
+		return nil, fmt.Errorf("optimistic. error creating signature scheme. Err: %w", err)
 	}
-
-	if err := signer.Initialize(ctx); err != nil {
-		return nil, fmt.Errorf("optimistic. error signer.Initialize. Err: %w", err)
+	signer, err := newSigner(ctx, logger, cfg, aggchainFEPContract)
+	if err != nil {
+		return nil, fmt.Errorf("optimistic. error creating signer. Err: %w", err)
 	}
 	publicAddrSigner := signer.PublicAddress()
-	trustedSequencerAddr, err := aggchainFEPContract.TrustedSequencer(nil)
+	trustedSequencerAddr, err := aggchainFEPContract.TrustedSequencer(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		err = fmt.Errorf("optimistic. error aggchainFEPContract.TrustedSequencer. Err: %w", err)
 		if cfg.RequireKeyMatchTrustedSequencer {
@@ -60,22 +63,66 @@ func NewOptimisticSignatureCalculatorImpl(
 		logger.Warn(err.Error())
 	}
 
-	logger.Infof("OptimisticSignatureCalculatorImpl.signerPublicKey: %s, trustedSequencerAddr: %s",
+	logger.Infof("OptimisticSignatureCalculatorImpl.signerPublicKey: %s, trustedSequencerAddr: %s, scheme: %s",
 		signer.PublicAddress().Hex(),
-		trustedSequencerAddr.Hex())
+		trustedSequencerAddr.Hex(),
+		scheme.String())
 	query := NewOptimisticAggregationProofPublicValuesQuery(
 		aggchainFEPContract,
 		cfg.SovereignRollupAddr,
 		opnode.NewOpNodeClient(cfg.OpNodeURL),
-		signer.PublicAddress())
+		signer.PublicAddress)
 
 	return &OptimisticSignatureCalculatorImpl{
 		queryAggregationProofPublicValues: query,
 		signer:                            signer,
+		scheme:                            scheme,
 		logger:                            logger,
 	}, nil
 }
 
+// newSigner creates the signer of the optimistic signatures: a hardware wallet, that can only sign the
+// EIP-712 typed data, or a signer that reloads its credentials when they are rotated
+func newSigner(ctx context.Context,
+	logger *log.Logger,
+	cfg Config,
+	trustedSequencer signerreload.TrustedSequencerQuerier,
+) (signertypes.Signer, error) {
+	if cfg.TrustedSequencerKey.Method != hwsigner.MethodLedger {
+		signer, err := signerreload.NewReloadableSigner(ctx, "optimistic", logger,
+			cfg.TrustedSequencerKey, cfg.SignerReload, trustedSequencer)
+		if err != nil {
+			return nil, err
+		}
+		go signer.Start(ctx)
+		return signer, nil
+	}
+	if cfg.Signature.Scheme != SignatureSchemeEIP712 {
+		return nil, fmt.Errorf("the %s signer requires the %s signature scheme (Signature.Scheme is %q)",
+			hwsigner.MethodLedger, SignatureSchemeEIP712, cfg.Signature.Scheme)
+	}
+	signer, err := hwsigner.NewLedgerSigner("optimistic", logger, cfg.TrustedSequencerKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// sign signs the hash, or the EIP-712 domain separator and struct hash if the signer only signs typed
+// data (the hardware wallets), which results in the signature of the same hash
+func (o *OptimisticSignatureCalculatorImpl) sign(ctx context.Context,
+	data *optimistichash.OptimisticSignatureData, hashToSign common.Hash) ([]byte, error) {
+	typedDataSigner, isTypedDataSigner := o.signer.(hwsigner.TypedDataSigner)
+	eip712, isEIP712 := o.scheme.(*eip712Scheme)
+	if isTypedDataSigner && isEIP712 {
+		return typedDataSigner.SignTypedData(ctx, eip712.domain.Separator(), data.StructHash())
+	}
+	return o.signer.SignHash(ctx, hashToSign)
+}
+
 // Sign calculate hash and sign it.
 // It returns the signed hash, extra data for logging, and an error if any.
 func (o *OptimisticSignatureCalculatorImpl) Sign(ctx context.Context,
@@ -106,18 +153,24 @@ func (o *OptimisticSignatureCalculatorImpl) Sign(ctx context.Context,
 		CommitImportedBridgeExits:        importedBridgesHash,
 	}
 	o.logger.Infof("OptimisticSignatureCalculatorImpl.Sign %s", optimisticSignature.String())
-	hashToSign := optimisticSignature.Hash()
-	o.logger.Infof("OptimisticSignatureCalculatorImpl.Sign signed_commitment:%s", hashToSign.Hex())
-	signData, err := o.signer.SignHash(ctx, hashToSign)
+	hashToSign := o.scheme.HashToSign(&optimisticSignature)
+	o.logger.Infof("OptimisticSignatureCalculatorImpl.Sign signed_commitment:%s (scheme: %s)",
+		hashToSign.Hex(), o.scheme.String())
+	signData, err := o.sign(ctx, &optimisticSignature, hashToSign)
 	if err != nil {
 		return nil, "", fmt.Errorf("OptimisticSignatureData.Sign: Fails to sign. SignData:%s . Err: %w",
 			optimisticSignature.String(), err)
 	}
+	signData, err = o.scheme.EncodeSignature(signData)
+	if err != nil {
+		return nil, "", fmt.Errorf("OptimisticSignatureData.Sign: Fails to encode signature (scheme: %s). Err: %w",
+			o.scheme.String(), err)
+	}
 	extraData := fmt.Sprintf(
 		"aggregationProofPublicValues: %s. signData:%s (num_claims: %d) "+
-			"hashToSign: %s",
+			"hashToSign: %s (scheme: %s)",
 		aggregationProofPublicValues.String(), optimisticSignature.String(),
-		len(claims), hashToSign.Hex())
+		len(claims), hashToSign.Hex(), o.scheme.String())
 
 	return signData, extraData, nil
 }
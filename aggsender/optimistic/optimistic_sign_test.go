@@ -2,9 +2,11 @@ package optimistic
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"testing"
 
+	"github.com/agglayer/aggkit/aggsender/hwsigner"
 	optimisticmocks "github.com/agglayer/aggkit/aggsender/optimistic/mocks"
 	optimistichash "github.com/agglayer/aggkit/aggsender/optimistic/optimistichash"
 	"github.com/agglayer/aggkit/aggsender/types"
@@ -12,7 +14,9 @@ import (
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/go_signer/signer/mocks"
+	signertypes "github.com/agglayer/go_signer/signer/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -91,6 +95,7 @@ func TestOptimisticSignatureCalculatorImpl_Sign(t *testing.T) {
 			calculator := &OptimisticSignatureCalculatorImpl{
 				queryAggregationProofPublicValues: mockQuery,
 				signer:                            mockSigner,
+				scheme:                            &keccakScheme{},
 				logger:                            realLogger, // Use realLogger here
 			}
 
@@ -114,3 +119,57 @@ func TestOptimisticSignatureCalculatorImpl_Sign(t *testing.T) {
 		})
 	}
 }
+
+// typedDataSigner signs the EIP-712 typed data as the hardware wallets, and fails to sign the hashes
+type typedDataSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *typedDataSigner) SignHash(context.Context, common.Hash) ([]byte, error) {
+	return nil, hwsigner.ErrNotSupported
+}
+
+func (s *typedDataSigner) SignTypedData(_ context.Context, domainSeparator, structHash common.Hash) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator.Bytes(), structHash.Bytes()), s.key)
+}
+
+func TestOptimisticSignatureCalculatorImpl_SignTypedData(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	domain := optimistichash.EIP712Domain{
+		Name: "AggchainFEP", Version: "1", ChainID: 1, VerifyingContract: common.HexToAddress("0x1234"),
+	}
+	data := &optimistichash.OptimisticSignatureData{
+		AggregationProofPublicValuesHash: common.HexToHash("0x1"),
+		NewLocalExitRoot:                 common.HexToHash("0x2"),
+		CommitImportedBridgeExits:        common.HexToHash("0x3"),
+	}
+	calculator := &OptimisticSignatureCalculatorImpl{
+		signer: &typedDataSigner{key: key},
+		scheme: &eip712Scheme{domain: domain},
+		logger: log.WithFields("module", "test_logger"),
+	}
+	hashToSign := calculator.scheme.HashToSign(data)
+
+	signature, err := calculator.sign(context.Background(), data, hashToSign)
+	require.NoError(t, err)
+	pubKey, err := crypto.SigToPub(hashToSign.Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pubKey))
+
+	// the other schemes sign the hash
+	calculator.scheme = &keccakScheme{}
+	_, err = calculator.sign(context.Background(), data, calculator.scheme.HashToSign(data))
+	require.ErrorIs(t, err, hwsigner.ErrNotSupported)
+}
+
+func TestNewSignerLedgerRequiresEIP712(t *testing.T) {
+	_, err := newSigner(context.Background(), log.WithFields("module", "test_logger"), Config{
+		TrustedSequencerKey: signertypes.SignerConfig{
+			Method: hwsigner.MethodLedger,
+			Config: map[string]any{hwsigner.FieldAddress: common.HexToAddress("0x1234").Hex()},
+		},
+		Signature: SignatureConfig{Scheme: SignatureSchemeKeccak},
+	}, nil)
+	require.ErrorContains(t, err, "the ledger signer requires the EIP712 signature scheme")
+}
@@ -0,0 +1,59 @@
+package optimistichash
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// This file calculates the EIP-712 typed data hash of OptimisticSignatureData,
+// for the chains that verify the optimistic signature as typed data instead of a raw hash.
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash(
+		[]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	optimisticSignatureDataTypeHash = crypto.Keccak256Hash(
+		[]byte("OptimisticSignatureData(bytes32 aggregationProofPublicValuesHash," +
+			"bytes32 newLocalExitRoot,bytes32 commitImportedBridgeExits)"))
+)
+
+// EIP712Domain is the domain separator of the EIP-712 typed data
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract common.Address
+}
+
+// Separator calculates the hash of the domain (domainSeparator)
+func (d *EIP712Domain) Separator() common.Hash {
+	return crypto.Keccak256Hash(
+		eip712DomainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		math.U256Bytes(new(big.Int).SetUint64(d.ChainID)),
+		common.LeftPadBytes(d.VerifyingContract.Bytes(), common.HashLength),
+	)
+}
+
+// StructHash calculates the EIP-712 hashStruct of the OptimisticSignatureData
+func (o *OptimisticSignatureData) StructHash() common.Hash {
+	return crypto.Keccak256Hash(
+		optimisticSignatureDataTypeHash.Bytes(),
+		o.AggregationProofPublicValuesHash.Bytes(),
+		o.NewLocalExitRoot.Bytes(),
+		o.CommitImportedBridgeExits.Bytes(),
+	)
+}
+
+// TypedDataHash calculates the EIP-712 hash of the OptimisticSignatureData for the given domain:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(data))
+func (o *OptimisticSignatureData) TypedDataHash(domain EIP712Domain) common.Hash {
+	return crypto.Keccak256Hash(
+		[]byte{0x19, 0x01},
+		domain.Separator().Bytes(),
+		o.StructHash().Bytes(),
+	)
+}
@@ -0,0 +1,70 @@
+package optimistichash
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticSignatureData_TypedDataHash(t *testing.T) {
+	signData := &OptimisticSignatureData{
+		AggregationProofPublicValuesHash: common.HexToHash("0x502cbcfe9aa2a7c4fbd1fcf81ce71be6f1a79a904b31a2b1cf27e5179f970890"),
+		NewLocalExitRoot:                 common.HexToHash("0x81b8a2cf7a80538dee49ae721a87655b080523d37cdad80c6a002a33e91c96cb"),
+		CommitImportedBridgeExits:        common.HexToHash("0x1b2d35e62df05e64b5987fa70c318ccabb08ce181818c9c88851ac15da9d277a"),
+	}
+	domain := EIP712Domain{
+		Name:              "AggchainFEP",
+		Version:           "1",
+		ChainID:           11155111,
+		VerifyingContract: common.HexToAddress("0x4ce23a785114db45ac6351e02f0de440845351af"),
+	}
+
+	// the reference implementation of go-ethereum
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"OptimisticSignatureData": {
+				{Name: "aggregationProofPublicValuesHash", Type: "bytes32"},
+				{Name: "newLocalExitRoot", Type: "bytes32"},
+				{Name: "commitImportedBridgeExits", Type: "bytes32"},
+			},
+		},
+		PrimaryType: "OptimisticSignatureData",
+		Domain: apitypes.TypedDataDomain{
+			Name:              domain.Name,
+			Version:           domain.Version,
+			ChainId:           (*math.HexOrDecimal256)(new(big.Int).SetUint64(domain.ChainID)),
+			VerifyingContract: domain.VerifyingContract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"aggregationProofPublicValuesHash": signData.AggregationProofPublicValuesHash.Bytes(),
+			"newLocalExitRoot":                 signData.NewLocalExitRoot.Bytes(),
+			"commitImportedBridgeExits":        signData.CommitImportedBridgeExits.Bytes(),
+		},
+	}
+	expectedSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+	require.NoError(t, err)
+	expectedStructHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	require.NoError(t, err)
+	expectedHash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+
+	require.Equal(t, common.BytesToHash(expectedSeparator), domain.Separator())
+	require.Equal(t, common.BytesToHash(expectedStructHash), signData.StructHash())
+	require.Equal(t, common.BytesToHash(expectedHash), signData.TypedDataHash(domain))
+
+	t.Run("the chain ID is part of the domain", func(t *testing.T) {
+		otherChain := domain
+		otherChain.ChainID = 1
+		require.NotEqual(t, signData.TypedDataHash(domain), signData.TypedDataHash(otherChain))
+	})
+}
@@ -0,0 +1,177 @@
+package optimistic
+
+import (
+	"context"
+	"fmt"
+
+	optimistichash "github.com/agglayer/aggkit/aggsender/optimistic/optimistichash"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignatureSchemeType is how the OptimisticSignatureData is hashed before being signed.
+// It must match the verification of the optimistic signature on the contracts of the network
+type SignatureSchemeType string
+
+const (
+	// SignatureSchemeKeccak signs the raw keccak256 hash of the data (empty means Keccak)
+	SignatureSchemeKeccak SignatureSchemeType = "Keccak"
+	// SignatureSchemeEIP191 signs the keccak256 hash prefixed with "\x19Ethereum Signed Message:\n32"
+	SignatureSchemeEIP191 SignatureSchemeType = "EIP191"
+	// SignatureSchemeEIP712 signs the data as EIP-712 typed data, with the domain of the config
+	SignatureSchemeEIP712 SignatureSchemeType = "EIP712"
+)
+
+// SignatureConfig is the signature scheme of the optimistic signature
+type SignatureConfig struct {
+	// Scheme is the hash signed: Keccak (default), EIP191 or EIP712
+	Scheme SignatureSchemeType `jsonschema:"enum=Keccak, enum=EIP191, enum=EIP712" mapstructure:"Scheme"`
+	// DomainName and DomainVersion are the name and version of the EIP-712 domain
+	DomainName    string `mapstructure:"DomainName"`
+	DomainVersion string `mapstructure:"DomainVersion"`
+	// ChainID is the chain ID of the EIP-712 domain. If it's 0, the chain ID of L1 is used.
+	// It allows to set the chain ID expected by the contracts if it differs from the one of the RPC
+	ChainID uint64 `mapstructure:"ChainID"`
+	// VerifyingContract is the verifyingContract of the EIP-712 domain, if it's empty SovereignRollupAddr is used
+	VerifyingContract common.Address `mapstructure:"VerifyingContract"`
+	// LegacyV encodes the V of the signature as 27/28 (as pre-EIP-155 signatures, expected by ecrecover)
+	// instead of the 0/1 recovery id. If it's false the signature is sent as returned by the signer
+	LegacyV bool `mapstructure:"LegacyV"`
+}
+
+// Validate checks that the scheme is one of the supported ones
+func (c SignatureConfig) Validate() error {
+	switch c.Scheme {
+	case "", SignatureSchemeKeccak, SignatureSchemeEIP191, SignatureSchemeEIP712:
+		return nil
+	default:
+		return fmt.Errorf("invalid optimistic signature scheme %q (must be %s, %s or %s)",
+			c.Scheme, SignatureSchemeKeccak, SignatureSchemeEIP191, SignatureSchemeEIP712)
+	}
+}
+
+// SignatureScheme calculates the hash signed for an OptimisticSignatureData and the encoding
+// of the resulting signature
+type SignatureScheme interface {
+	fmt.Stringer
+	// HashToSign returns the hash that the signer signs
+	HashToSign(data *optimistichash.OptimisticSignatureData) common.Hash
+	// EncodeSignature adapts the signature returned by the signer to the format expected by the contracts
+	EncodeSignature(signature []byte) ([]byte, error)
+}
+
+// NewSignatureScheme creates the SignatureScheme of the config. The chain ID of L1 is only
+// queried for EIP712 if the config doesn't set it
+func NewSignatureScheme(ctx context.Context,
+	cfg SignatureConfig,
+	sovereignRollupAddr common.Address,
+	l1Client ethereum.ChainIDReader) (SignatureScheme, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	encoder := signatureEncoder{legacyV: cfg.LegacyV}
+	switch cfg.Scheme {
+	case SignatureSchemeEIP191:
+		return &eip191Scheme{signatureEncoder: encoder}, nil
+	case SignatureSchemeEIP712:
+		domain := optimistichash.EIP712Domain{
+			Name:              cfg.DomainName,
+			Version:           cfg.DomainVersion,
+			ChainID:           cfg.ChainID,
+			VerifyingContract: cfg.VerifyingContract,
+		}
+		if domain.VerifyingContract == (common.Address{}) {
+			domain.VerifyingContract = sovereignRollupAddr
+		}
+		if domain.ChainID == 0 {
+			chainID, err := l1Client.ChainID(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("optimistic signature scheme %s: error getting L1 chain ID: %w",
+					SignatureSchemeEIP712, err)
+			}
+			if !chainID.IsUint64() {
+				return nil, fmt.Errorf("optimistic signature scheme %s: L1 chain ID %s doesn't fit in uint64",
+					SignatureSchemeEIP712, chainID.String())
+			}
+			domain.ChainID = chainID.Uint64()
+		}
+		return &eip712Scheme{signatureEncoder: encoder, domain: domain}, nil
+	default:
+		return &keccakScheme{signatureEncoder: encoder}, nil
+	}
+}
+
+// signatureEncoder sets the V of the 65 bytes [R || S || V] signatures to the configured format
+type signatureEncoder struct {
+	legacyV bool
+}
+
+// legacyVOffset is added to the recovery id (0/1) of the signature to get the legacy V (27/28)
+const legacyVOffset = 27
+
+func (e signatureEncoder) EncodeSignature(signature []byte) ([]byte, error) {
+	if !e.legacyV {
+		return signature, nil
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d (expected %d)", len(signature), crypto.SignatureLength)
+	}
+	// some signers (e.g. remote ones) already return the legacy V
+	if signature[crypto.RecoveryIDOffset] >= legacyVOffset {
+		return signature, nil
+	}
+	encoded := common.CopyBytes(signature)
+	encoded[crypto.RecoveryIDOffset] += legacyVOffset
+	return encoded, nil
+}
+
+func (e signatureEncoder) vFormat() string {
+	if e.legacyV {
+		return "v=27/28"
+	}
+	return "v=signer"
+}
+
+// keccakScheme signs keccak256(aggregationProofPublicValuesHash || newLocalExitRoot || commitImportedBridgeExits)
+type keccakScheme struct {
+	signatureEncoder
+}
+
+func (s *keccakScheme) HashToSign(data *optimistichash.OptimisticSignatureData) common.Hash {
+	return data.Hash()
+}
+
+func (s *keccakScheme) String() string {
+	return fmt.Sprintf("%s(%s)", SignatureSchemeKeccak, s.vFormat())
+}
+
+// eip191Scheme signs the keccak hash as a personal message (eth_sign)
+type eip191Scheme struct {
+	signatureEncoder
+}
+
+func (s *eip191Scheme) HashToSign(data *optimistichash.OptimisticSignatureData) common.Hash {
+	return common.BytesToHash(accounts.TextHash(data.Hash().Bytes()))
+}
+
+func (s *eip191Scheme) String() string {
+	return fmt.Sprintf("%s(%s)", SignatureSchemeEIP191, s.vFormat())
+}
+
+// eip712Scheme signs the data as EIP-712 typed data
+type eip712Scheme struct {
+	signatureEncoder
+	domain optimistichash.EIP712Domain
+}
+
+func (s *eip712Scheme) HashToSign(data *optimistichash.OptimisticSignatureData) common.Hash {
+	return data.TypedDataHash(s.domain)
+}
+
+func (s *eip712Scheme) String() string {
+	return fmt.Sprintf("%s(name=%q, version=%q, chainID=%d, verifyingContract=%s, %s)",
+		SignatureSchemeEIP712, s.domain.Name, s.domain.Version, s.domain.ChainID,
+		s.domain.VerifyingContract.Hex(), s.vFormat())
+}
@@ -0,0 +1,123 @@
+package optimistic
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	optimistichash "github.com/agglayer/aggkit/aggsender/optimistic/optimistichash"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureConfig_Validate(t *testing.T) {
+	for _, scheme := range []SignatureSchemeType{"", SignatureSchemeKeccak, SignatureSchemeEIP191, SignatureSchemeEIP712} {
+		require.NoError(t, SignatureConfig{Scheme: scheme}.Validate(), "scheme %q", scheme)
+	}
+	err := SignatureConfig{Scheme: "EIP2612"}.Validate()
+	require.ErrorContains(t, err, `invalid optimistic signature scheme "EIP2612"`)
+}
+
+func TestNewSignatureScheme(t *testing.T) {
+	ctx := context.Background()
+	rollupAddr := common.HexToAddress("0x4ce23a785114db45ac6351e02f0de440845351af")
+	data := &optimistichash.OptimisticSignatureData{
+		AggregationProofPublicValuesHash: common.HexToHash("0x01"),
+		NewLocalExitRoot:                 common.HexToHash("0x02"),
+		CommitImportedBridgeExits:        common.HexToHash("0x03"),
+	}
+
+	t.Run("default scheme is the raw keccak hash", func(t *testing.T) {
+		scheme, err := NewSignatureScheme(ctx, SignatureConfig{}, rollupAddr, nil)
+		require.NoError(t, err)
+		require.Equal(t, data.Hash(), scheme.HashToSign(data))
+		require.Equal(t, "Keccak(v=signer)", scheme.String())
+	})
+
+	t.Run("EIP191 scheme", func(t *testing.T) {
+		scheme, err := NewSignatureScheme(ctx, SignatureConfig{Scheme: SignatureSchemeEIP191}, rollupAddr, nil)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(accounts.TextHash(data.Hash().Bytes())), scheme.HashToSign(data))
+	})
+
+	t.Run("EIP712 scheme with custom chain ID doesn't query L1", func(t *testing.T) {
+		cfg := SignatureConfig{
+			Scheme:        SignatureSchemeEIP712,
+			DomainName:    "AggchainFEP",
+			DomainVersion: "1",
+			ChainID:       1337,
+		}
+		scheme, err := NewSignatureScheme(ctx, cfg, rollupAddr, aggkittypesmocks.NewBaseEthereumClienter(t))
+		require.NoError(t, err)
+		expectedDomain := optimistichash.EIP712Domain{
+			Name: "AggchainFEP", Version: "1", ChainID: 1337, VerifyingContract: rollupAddr,
+		}
+		require.Equal(t, data.TypedDataHash(expectedDomain), scheme.HashToSign(data))
+	})
+
+	t.Run("EIP712 scheme takes the chain ID of L1", func(t *testing.T) {
+		l1Client := aggkittypesmocks.NewBaseEthereumClienter(t)
+		l1Client.EXPECT().ChainID(ctx).Return(big.NewInt(11155111), nil).Once()
+		verifyingContract := common.HexToAddress("0x1234")
+		cfg := SignatureConfig{Scheme: SignatureSchemeEIP712, VerifyingContract: verifyingContract}
+		scheme, err := NewSignatureScheme(ctx, cfg, rollupAddr, l1Client)
+		require.NoError(t, err)
+		expectedDomain := optimistichash.EIP712Domain{ChainID: 11155111, VerifyingContract: verifyingContract}
+		require.Equal(t, data.TypedDataHash(expectedDomain), scheme.HashToSign(data))
+	})
+
+	t.Run("EIP712 scheme fails if the chain ID of L1 can't be queried", func(t *testing.T) {
+		l1Client := aggkittypesmocks.NewBaseEthereumClienter(t)
+		l1Client.EXPECT().ChainID(ctx).Return(nil, errors.New("rpc down")).Once()
+		_, err := NewSignatureScheme(ctx, SignatureConfig{Scheme: SignatureSchemeEIP712}, rollupAddr, l1Client)
+		require.ErrorContains(t, err, "rpc down")
+	})
+
+	t.Run("invalid scheme", func(t *testing.T) {
+		_, err := NewSignatureScheme(ctx, SignatureConfig{Scheme: "unknown"}, rollupAddr, nil)
+		require.ErrorContains(t, err, "invalid optimistic signature scheme")
+	})
+}
+
+func TestSignatureEncoder_EncodeSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hash := crypto.Keccak256Hash([]byte("optimistic"))
+	signature, err := crypto.Sign(hash.Bytes(), key)
+	require.NoError(t, err)
+
+	t.Run("signature is not modified by default", func(t *testing.T) {
+		encoded, err := signatureEncoder{}.EncodeSignature([]byte("signed_data"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("signed_data"), encoded)
+	})
+
+	t.Run("legacy V", func(t *testing.T) {
+		encoded, err := signatureEncoder{legacyV: true}.EncodeSignature(signature)
+		require.NoError(t, err)
+		require.Equal(t, signature[crypto.RecoveryIDOffset]+legacyVOffset, encoded[crypto.RecoveryIDOffset])
+		require.Equal(t, signature[:crypto.RecoveryIDOffset], encoded[:crypto.RecoveryIDOffset])
+		require.Less(t, signature[crypto.RecoveryIDOffset], byte(legacyVOffset), "the input must not be modified")
+
+		// a signature that already has the legacy V is kept
+		again, err := signatureEncoder{legacyV: true}.EncodeSignature(encoded)
+		require.NoError(t, err)
+		require.Equal(t, encoded, again)
+
+		// the signature is recovered as the ecrecover precompile does
+		recoverable := common.CopyBytes(encoded)
+		recoverable[crypto.RecoveryIDOffset] -= legacyVOffset
+		pub, err := crypto.SigToPub(hash.Bytes(), recoverable)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pub))
+	})
+
+	t.Run("legacy V with invalid length", func(t *testing.T) {
+		_, err := signatureEncoder{legacyV: true}.EncodeSignature([]byte("signed_data"))
+		require.ErrorContains(t, err, "invalid signature length 11")
+	})
+}
@@ -0,0 +1,94 @@
+package prover
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/agglayer/aggkit/aggsender/prover/proto/v1"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AggchainProofGenerationGRPC implements the gRPC server for the AggchainProofGeneration service
+type AggchainProofGenerationGRPC struct {
+	// Embed the generated server interface to ensure forward compatibility
+	v1.UnimplementedAggchainProofGenerationServer
+
+	queue ProofJobQueuer
+}
+
+// NewAggchainProofGenerationGRPC creates a new AggchainProofGenerationGRPC
+func NewAggchainProofGenerationGRPC(queue ProofJobQueuer) *AggchainProofGenerationGRPC {
+	return &AggchainProofGenerationGRPC{
+		queue: queue,
+	}
+}
+
+// SubmitProofJob queues a proof generation job
+func (s *AggchainProofGenerationGRPC) SubmitProofJob(
+	_ context.Context, req *v1.SubmitProofJobRequest) (*v1.SubmitProofJobResponse, error) {
+	job, err := s.queue.Submit(req.LastProvenBlock, req.MaxEndBlock)
+	if err != nil {
+		return nil, proofJobErrorToGRPC(err)
+	}
+	return &v1.SubmitProofJobResponse{JobId: job.ID}, nil
+}
+
+// GetProofJob returns the status of a proof generation job
+func (s *AggchainProofGenerationGRPC) GetProofJob(
+	_ context.Context, req *v1.GetProofJobRequest) (*v1.ProofJob, error) {
+	job, err := s.queue.Get(req.JobId)
+	if err != nil {
+		return nil, proofJobErrorToGRPC(err)
+	}
+	return proofJobToProto(job), nil
+}
+
+func proofJobErrorToGRPC(err error) error {
+	switch {
+	case errors.Is(err, types.ErrInvalidProofJobRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrProofJobQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, types.ErrProofJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func proofJobToProto(job *types.ProofJob) *v1.ProofJob {
+	result := &v1.ProofJob{
+		JobId:           job.ID,
+		LastProvenBlock: job.LastProvenBlock,
+		MaxEndBlock:     job.MaxEndBlock,
+		Status:          proofJobStatusToProto(job.Status),
+		Error:           job.Error,
+		CreatedAt:       uint64(job.CreatedAt.Unix()),
+		UpdatedAt:       uint64(job.UpdatedAt.Unix()),
+	}
+	if job.Proof != nil {
+		result.Proof = &v1.SP1StarkProof{
+			Proof:   job.Proof.Proof,
+			Version: job.Proof.Version,
+			Vkey:    job.Proof.Vkey,
+		}
+	}
+	return result
+}
+
+func proofJobStatusToProto(s types.ProofJobStatus) v1.ProofJobStatus {
+	switch s {
+	case types.ProofJobQueued:
+		return v1.ProofJobStatus_PROOF_JOB_STATUS_QUEUED
+	case types.ProofJobRunning:
+		return v1.ProofJobStatus_PROOF_JOB_STATUS_RUNNING
+	case types.ProofJobSucceeded:
+		return v1.ProofJobStatus_PROOF_JOB_STATUS_SUCCEEDED
+	case types.ProofJobFailed:
+		return v1.ProofJobStatus_PROOF_JOB_STATUS_FAILED
+	default:
+		return v1.ProofJobStatus_PROOF_JOB_STATUS_UNSPECIFIED
+	}
+}
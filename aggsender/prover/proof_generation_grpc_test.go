@@ -0,0 +1,109 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	v1 "github.com/agglayer/aggkit/aggsender/prover/proto/v1"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAggchainProofGenerationGRPC_SubmitProofJob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		submitErr    error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "success",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "invalid range",
+			submitErr:    types.ErrInvalidProofJobRange,
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "queue full",
+			submitErr:    types.ErrProofJobQueueFull,
+			expectedCode: codes.ResourceExhausted,
+		},
+		{
+			name:         "unexpected error",
+			submitErr:    errors.New("unexpected"),
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := mocks.NewProofJobQueuer(t)
+			if tt.submitErr != nil {
+				queue.EXPECT().Submit(uint64(1), uint64(10)).Return(nil, tt.submitErr)
+			} else {
+				queue.EXPECT().Submit(uint64(1), uint64(10)).Return(&types.ProofJob{ID: "job-1"}, nil)
+			}
+
+			svc := NewAggchainProofGenerationGRPC(queue)
+			resp, err := svc.SubmitProofJob(context.Background(),
+				&v1.SubmitProofJobRequest{LastProvenBlock: 1, MaxEndBlock: 10})
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.submitErr == nil {
+				require.Equal(t, "job-1", resp.JobId)
+			}
+		})
+	}
+}
+
+func TestAggchainProofGenerationGRPC_GetProofJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		queue := mocks.NewProofJobQueuer(t)
+		queue.EXPECT().Get("job-1").Return(nil, types.ErrProofJobNotFound)
+
+		svc := NewAggchainProofGenerationGRPC(queue)
+		_, err := svc.GetProofJob(context.Background(), &v1.GetProofJobRequest{JobId: "job-1"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("succeeded job", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		queue := mocks.NewProofJobQueuer(t)
+		queue.EXPECT().Get("job-1").Return(&types.ProofJob{
+			ID:              "job-1",
+			LastProvenBlock: 1,
+			MaxEndBlock:     10,
+			Status:          types.ProofJobSucceeded,
+			Proof:           &types.SP1StarkProof{Proof: []byte("proof"), Version: "v1", Vkey: []byte("vkey")},
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}, nil)
+
+		svc := NewAggchainProofGenerationGRPC(queue)
+		job, err := svc.GetProofJob(context.Background(), &v1.GetProofJobRequest{JobId: "job-1"})
+		require.NoError(t, err)
+		require.Equal(t, "job-1", job.JobId)
+		require.Equal(t, uint64(1), job.LastProvenBlock)
+		require.Equal(t, uint64(10), job.MaxEndBlock)
+		require.Equal(t, v1.ProofJobStatus_PROOF_JOB_STATUS_SUCCEEDED, job.Status)
+		require.Equal(t, []byte("proof"), job.Proof.Proof)
+		require.Equal(t, "v1", job.Proof.Version)
+		require.Equal(t, []byte("vkey"), job.Proof.Vkey)
+		require.Equal(t, uint64(now.Unix()), job.CreatedAt)
+	})
+}
@@ -0,0 +1,87 @@
+package prover
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ProofGenV1Prefix is the prefix of the REST endpoints of the proof generation service
+const ProofGenV1Prefix = "/aggchain-proof-gen/v1"
+
+// SubmitProofJobRequest is the body of the request to queue a proof generation job
+type SubmitProofJobRequest struct {
+	LastProvenBlock uint64 `json:"last_proven_block"`
+	MaxEndBlock     uint64 `json:"max_end_block"`
+}
+
+// AggchainProofGenerationREST exposes the proof generation jobs through a REST API
+type AggchainProofGenerationREST struct {
+	queue  ProofJobQueuer
+	router *gin.Engine
+}
+
+// NewAggchainProofGenerationREST creates a new AggchainProofGenerationREST
+func NewAggchainProofGenerationREST(queue ProofJobQueuer) *AggchainProofGenerationREST {
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	r := &AggchainProofGenerationREST{
+		queue:  queue,
+		router: router,
+	}
+	group := router.Group(ProofGenV1Prefix)
+	{
+		group.POST("/jobs", r.SubmitProofJobHandler)
+		group.GET("/jobs/:id", r.GetProofJobHandler)
+	}
+	return r
+}
+
+// Handler returns the HTTP handler of the REST API
+func (r *AggchainProofGenerationREST) Handler() http.Handler {
+	return r.router
+}
+
+// SubmitProofJobHandler queues a proof generation job
+// curl -X POST http://localhost:5578/aggchain-proof-gen/v1/jobs -H "Content-Type: application/json" \
+// -d '{"last_proven_block": 100, "max_end_block": 200}'
+func (r *AggchainProofGenerationREST) SubmitProofJobHandler(c *gin.Context) {
+	var req SubmitProofJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	job, err := r.queue.Submit(req.LastProvenBlock, req.MaxEndBlock)
+	if err != nil {
+		c.JSON(proofJobErrorToHTTPStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetProofJobHandler returns the status of a proof generation job
+// curl http://localhost:5578/aggchain-proof-gen/v1/jobs/<job_id>
+func (r *AggchainProofGenerationREST) GetProofJobHandler(c *gin.Context) {
+	job, err := r.queue.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(proofJobErrorToHTTPStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func proofJobErrorToHTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrInvalidProofJobRange):
+		return http.StatusBadRequest
+	case errors.Is(err, types.ErrProofJobQueueFull):
+		return http.StatusTooManyRequests
+	case errors.Is(err, types.ErrProofJobNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,113 @@
+package prover
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggchainProofGenerationREST_SubmitProofJob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		body           string
+		mockFn         func(queue *mocks.ProofJobQueuer)
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			body: `{"last_proven_block": 1, "max_end_block": 10}`,
+			mockFn: func(queue *mocks.ProofJobQueuer) {
+				queue.EXPECT().Submit(uint64(1), uint64(10)).Return(&types.ProofJob{ID: "job-1", Status: types.ProofJobQueued}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "invalid body",
+			body:           `{"last_proven_block": "one"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid range",
+			body: `{"last_proven_block": 10, "max_end_block": 1}`,
+			mockFn: func(queue *mocks.ProofJobQueuer) {
+				queue.EXPECT().Submit(uint64(10), uint64(1)).Return(nil, types.ErrInvalidProofJobRange)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "queue full",
+			body: `{"last_proven_block": 1, "max_end_block": 10}`,
+			mockFn: func(queue *mocks.ProofJobQueuer) {
+				queue.EXPECT().Submit(uint64(1), uint64(10)).Return(nil, types.ErrProofJobQueueFull)
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := mocks.NewProofJobQueuer(t)
+			if tt.mockFn != nil {
+				tt.mockFn(queue)
+			}
+			api := NewAggchainProofGenerationREST(queue)
+
+			req := httptest.NewRequest(http.MethodPost, ProofGenV1Prefix+"/jobs", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			api.Handler().ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusAccepted {
+				var job types.ProofJob
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+				require.Equal(t, "job-1", job.ID)
+				require.Equal(t, types.ProofJobQueued, job.Status)
+			}
+		})
+	}
+}
+
+func TestAggchainProofGenerationREST_GetProofJob(t *testing.T) {
+	t.Parallel()
+
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+
+		queue := mocks.NewProofJobQueuer(t)
+		queue.EXPECT().Get("job-1").Return(&types.ProofJob{ID: "job-1", Status: types.ProofJobRunning}, nil)
+		api := NewAggchainProofGenerationREST(queue)
+
+		w := httptest.NewRecorder()
+		api.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, ProofGenV1Prefix+"/jobs/job-1", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var job types.ProofJob
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+		require.Equal(t, types.ProofJobRunning, job.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		queue := mocks.NewProofJobQueuer(t)
+		queue.EXPECT().Get("job-1").Return(nil, types.ErrProofJobNotFound)
+		api := NewAggchainProofGenerationREST(queue)
+
+		w := httptest.NewRecorder()
+		api.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, ProofGenV1Prefix+"/jobs/job-1", nil))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Contains(t, w.Body.String(), "proof job not found")
+	})
+}
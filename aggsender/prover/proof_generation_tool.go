@@ -2,15 +2,19 @@ package prover
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/0xPolygon/cdk-rpc/rpc"
 	"github.com/agglayer/aggkit/aggoracle/chaingerreader"
 	"github.com/agglayer/aggkit/aggsender/aggchainproofclient"
 	"github.com/agglayer/aggkit/aggsender/flows"
+	prooftypes "github.com/agglayer/aggkit/aggsender/prover/proto/v1"
 	"github.com/agglayer/aggkit/aggsender/query"
 	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
 	aggkitgrpc "github.com/agglayer/aggkit/grpc"
 	"github.com/agglayer/aggkit/log"
 	treetypes "github.com/agglayer/aggkit/tree/types"
@@ -43,6 +47,26 @@ type Config struct {
 
 	// SovereignRollupAddr is the address of the sovereign rollup contract on L1
 	SovereignRollupAddr common.Address `mapstructure:"SovereignRollupAddr"`
+
+	// Service is the configuration of the gRPC and REST endpoints to queue proof generation jobs
+	Service ServiceConfig `mapstructure:"Service"`
+}
+
+// ServiceConfig is the configuration of the proof generation service
+type ServiceConfig struct {
+	// GRPC is the gRPC server configuration. Port 0 disables the gRPC endpoint
+	GRPC aggkitgrpc.ServerConfig `mapstructure:"GRPC"`
+	// REST is the REST server configuration. Port 0 disables the REST endpoint
+	REST aggkitcommon.RESTConfig `mapstructure:"REST"`
+	// MaxQueuedJobs is the maximum number of jobs waiting to be processed
+	MaxQueuedJobs int `mapstructure:"MaxQueuedJobs"`
+	// MaxFinishedJobs is the number of finished jobs kept in memory to be polled
+	MaxFinishedJobs int `mapstructure:"MaxFinishedJobs"`
+}
+
+// IsEnabled returns true if any of the endpoints is enabled
+func (c ServiceConfig) IsEnabled() bool {
+	return c.GRPC.Port != 0 || c.REST.Port != 0
 }
 
 // AggchainProofGenerationTool is a tool to generate Aggchain proofs
@@ -86,7 +110,7 @@ func NewAggchainProofGenerationTool(
 	}
 
 	l1InfoTreeQuerier := query.NewL1InfoTreeDataQuerier(l1Client, l1InfoTreeSyncer)
-	l2BridgeQuerier := query.NewBridgeDataQuerier(logger, l2Syncer, time.Second)
+	l2BridgeQuerier := query.NewBridgeDataQuerier(logger, l2Syncer, time.Second, query.DefaultMaxBlocksPerQuery)
 
 	baseFlow := flows.NewBaseFlow(
 		logger,
@@ -130,6 +154,52 @@ func (a *AggchainProofGenerationTool) GetRPCServices() []rpc.Service {
 	}
 }
 
+// StartService starts the gRPC and REST endpoints (the enabled ones) to queue proof generation
+// jobs and poll their status. It returns when the servers are started; they are stopped
+// when the context is cancelled
+func (a *AggchainProofGenerationTool) StartService(ctx context.Context) error {
+	svcCfg := a.cfg.Service
+	if !svcCfg.IsEnabled() {
+		a.logger.Info("proof generation service is disabled")
+		return nil
+	}
+	queue := NewProofJobQueue(a.logger, a, svcCfg.MaxQueuedJobs, svcCfg.MaxFinishedJobs)
+	go queue.Start(ctx)
+
+	if svcCfg.GRPC.Port != 0 {
+		grpcServer, err := aggkitgrpc.NewServer(svcCfg.GRPC)
+		if err != nil {
+			return fmt.Errorf("failed to create proof generation gRPC server: %w", err)
+		}
+		prooftypes.RegisterAggchainProofGenerationServer(grpcServer.GRPC(), NewAggchainProofGenerationGRPC(queue))
+		a.logger.Infof("proof generation gRPC service listening on %s", grpcServer.Addr())
+		go grpcServer.Start(ctx)
+	}
+
+	if svcCfg.REST.Port != 0 {
+		srv := &http.Server{
+			Addr:              svcCfg.REST.Address(),
+			Handler:           NewAggchainProofGenerationREST(queue).Handler(),
+			ReadTimeout:       svcCfg.REST.ReadTimeout.Duration,
+			ReadHeaderTimeout: svcCfg.REST.ReadTimeout.Duration,
+			WriteTimeout:      svcCfg.REST.WriteTimeout.Duration,
+		}
+		go func() {
+			<-ctx.Done()
+			if err := srv.Shutdown(context.Background()); err != nil {
+				a.logger.Errorf("proof generation REST service shutdown error: %v", err)
+			}
+		}()
+		go func() {
+			a.logger.Infof("proof generation REST service listening on %s", srv.Addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				a.logger.Errorf("proof generation REST service error: %v", err)
+			}
+		}()
+	}
+	return nil
+}
+
 // GenerateAggchainProof generates an Aggchain proof
 func (a *AggchainProofGenerationTool) GenerateAggchainProof(
 	ctx context.Context,
@@ -0,0 +1,159 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaxQueuedProofJobs is the default number of jobs that can wait in the queue
+	DefaultMaxQueuedProofJobs = 10
+	// DefaultMaxFinishedProofJobs is the default number of finished jobs kept to be polled
+	DefaultMaxFinishedProofJobs = 100
+)
+
+// ProofJobQueuer queues proof generation jobs and returns their status
+type ProofJobQueuer interface {
+	Submit(lastProvenBlock, maxEndBlock uint64) (*types.ProofJob, error)
+	Get(jobID string) (*types.ProofJob, error)
+}
+
+// ProofJobQueue generates the aggchain proofs of the queued jobs, one at a time, because the
+// prover is a shared (and expensive) resource. The jobs are kept in memory: the finished jobs are
+// discarded (oldest first) once there are more than maxFinishedJobs
+type ProofJobQueue struct {
+	logger          *log.Logger
+	generator       AggchainProofGeneration
+	maxFinishedJobs int
+
+	mu           sync.RWMutex
+	jobs         map[string]*types.ProofJob
+	finishedJobs []string
+	pendingCh    chan string
+}
+
+// NewProofJobQueue creates a new ProofJobQueue
+func NewProofJobQueue(logger *log.Logger, generator AggchainProofGeneration,
+	maxQueuedJobs, maxFinishedJobs int) *ProofJobQueue {
+	if maxQueuedJobs <= 0 {
+		maxQueuedJobs = DefaultMaxQueuedProofJobs
+	}
+	if maxFinishedJobs <= 0 {
+		maxFinishedJobs = DefaultMaxFinishedProofJobs
+	}
+	return &ProofJobQueue{
+		logger:          logger,
+		generator:       generator,
+		maxFinishedJobs: maxFinishedJobs,
+		jobs:            make(map[string]*types.ProofJob),
+		pendingCh:       make(chan string, maxQueuedJobs),
+	}
+}
+
+// Start generates the proofs of the queued jobs until the context is cancelled
+func (q *ProofJobQueue) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			q.logger.Info("proof job queue stopped")
+			return
+		case jobID := <-q.pendingCh:
+			q.run(ctx, jobID)
+		}
+	}
+}
+
+// Submit queues a new job and returns it
+func (q *ProofJobQueue) Submit(lastProvenBlock, maxEndBlock uint64) (*types.ProofJob, error) {
+	if maxEndBlock <= lastProvenBlock {
+		return nil, fmt.Errorf("%w: max end block %d must be greater than last proven block %d",
+			types.ErrInvalidProofJobRange, maxEndBlock, lastProvenBlock)
+	}
+	now := time.Now().UTC()
+	job := &types.ProofJob{
+		ID:              uuid.NewString(),
+		LastProvenBlock: lastProvenBlock,
+		MaxEndBlock:     maxEndBlock,
+		Status:          types.ProofJobQueued,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	select {
+	case q.pendingCh <- job.ID:
+	default:
+		return nil, fmt.Errorf("%w: %d jobs waiting", types.ErrProofJobQueueFull, cap(q.pendingCh))
+	}
+	q.jobs[job.ID] = job
+	q.logger.Infof("proof job %s queued. Last proven block: %d. Max end block: %d",
+		job.ID, lastProvenBlock, maxEndBlock)
+	result := *job
+	return &result, nil
+}
+
+// Get returns a copy of the job
+func (q *ProofJobQueue) Get(jobID string) (*types.ProofJob, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	job, ok := q.jobs[jobID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrProofJobNotFound, jobID)
+	}
+	result := *job
+	return &result, nil
+}
+
+func (q *ProofJobQueue) run(ctx context.Context, jobID string) {
+	var job types.ProofJob
+	if !q.update(jobID, func(j *types.ProofJob) {
+		j.Status = types.ProofJobRunning
+		job = *j
+	}) {
+		return
+	}
+
+	start := time.Now()
+	proof, err := q.generator.GenerateAggchainProof(ctx, job.LastProvenBlock, job.MaxEndBlock)
+	q.update(jobID, func(j *types.ProofJob) {
+		if err != nil {
+			j.Status = types.ProofJobFailed
+			j.Error = err.Error()
+			return
+		}
+		j.Status = types.ProofJobSucceeded
+		j.Proof = proof
+	})
+	if err != nil {
+		q.logger.Errorf("proof job %s failed after %s: %v", jobID, time.Since(start), err)
+	} else {
+		q.logger.Infof("proof job %s succeeded after %s", jobID, time.Since(start))
+	}
+}
+
+// update applies fn to the job (if it exists), and discards the oldest finished jobs
+func (q *ProofJobQueue) update(jobID string, fn func(j *types.ProofJob)) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job, ok := q.jobs[jobID]
+	if !ok {
+		return false
+	}
+	fn(job)
+	job.UpdatedAt = time.Now().UTC()
+	if job.Status.IsFinished() {
+		q.finishedJobs = append(q.finishedJobs, jobID)
+		for len(q.finishedJobs) > q.maxFinishedJobs {
+			delete(q.jobs, q.finishedJobs[0])
+			q.finishedJobs = q.finishedJobs[1:]
+		}
+	}
+	return true
+}
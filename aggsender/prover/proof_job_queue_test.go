@@ -0,0 +1,116 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForProofJobStatus(t *testing.T, queue *ProofJobQueue, jobID string, status types.ProofJobStatus) *types.ProofJob {
+	t.Helper()
+
+	var job *types.ProofJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = queue.Get(jobID)
+		require.NoError(t, err)
+		return job.Status == status
+	}, time.Second, time.Millisecond*10)
+	return job
+}
+
+func TestProofJobQueue_Submit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		queue := NewProofJobQueue(log.WithFields("test", "queue"), mocks.NewAggchainProofGeneration(t), 0, 0)
+		_, err := queue.Submit(10, 10)
+		require.ErrorIs(t, err, types.ErrInvalidProofJobRange)
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		t.Parallel()
+
+		queue := NewProofJobQueue(log.WithFields("test", "queue"), mocks.NewAggchainProofGeneration(t), 1, 0)
+		job, err := queue.Submit(1, 10)
+		require.NoError(t, err)
+		require.Equal(t, types.ProofJobQueued, job.Status)
+		require.NotEmpty(t, job.ID)
+
+		_, err = queue.Submit(1, 10)
+		require.ErrorIs(t, err, types.ErrProofJobQueueFull)
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		t.Parallel()
+
+		queue := NewProofJobQueue(log.WithFields("test", "queue"), mocks.NewAggchainProofGeneration(t), 0, 0)
+		_, err := queue.Get("unknown")
+		require.ErrorIs(t, err, types.ErrProofJobNotFound)
+	})
+}
+
+func TestProofJobQueue_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success and failure", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		generator := mocks.NewAggchainProofGeneration(t)
+		expectedProof := &types.SP1StarkProof{Proof: []byte("proof"), Version: "v1", Vkey: []byte("vkey")}
+		generator.EXPECT().GenerateAggchainProof(mock.Anything, uint64(1), uint64(10)).Return(expectedProof, nil).Once()
+		generator.EXPECT().GenerateAggchainProof(mock.Anything, uint64(10), uint64(20)).
+			Return(nil, errors.New("prover error")).Once()
+
+		queue := NewProofJobQueue(log.WithFields("test", "queue"), generator, 0, 0)
+		okJob, err := queue.Submit(1, 10)
+		require.NoError(t, err)
+		failedJob, err := queue.Submit(10, 20)
+		require.NoError(t, err)
+
+		go queue.Start(ctx)
+
+		job := waitForProofJobStatus(t, queue, okJob.ID, types.ProofJobSucceeded)
+		require.Equal(t, expectedProof, job.Proof)
+		require.Empty(t, job.Error)
+
+		job = waitForProofJobStatus(t, queue, failedJob.ID, types.ProofJobFailed)
+		require.Nil(t, job.Proof)
+		require.Equal(t, "prover error", job.Error)
+	})
+
+	t.Run("discards the oldest finished jobs", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		generator := mocks.NewAggchainProofGeneration(t)
+		generator.EXPECT().GenerateAggchainProof(mock.Anything, mock.Anything, mock.Anything).
+			Return(&types.SP1StarkProof{}, nil).Twice()
+
+		queue := NewProofJobQueue(log.WithFields("test", "queue"), generator, 0, 1)
+		firstJob, err := queue.Submit(1, 10)
+		require.NoError(t, err)
+		secondJob, err := queue.Submit(10, 20)
+		require.NoError(t, err)
+
+		go queue.Start(ctx)
+
+		waitForProofJobStatus(t, queue, secondJob.ID, types.ProofJobSucceeded)
+		_, err = queue.Get(firstJob.ID)
+		require.ErrorIs(t, err, types.ErrProofJobNotFound)
+	})
+}
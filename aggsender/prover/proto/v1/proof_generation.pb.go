@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: aggsender/prover/proto/v1/proof_generation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Status of a proof generation job
+type ProofJobStatus int32
+
+const (
+	// Unknown status
+	ProofJobStatus_PROOF_JOB_STATUS_UNSPECIFIED ProofJobStatus = 0
+	// The job is waiting in the queue
+	ProofJobStatus_PROOF_JOB_STATUS_QUEUED ProofJobStatus = 1
+	// The proof is being generated
+	ProofJobStatus_PROOF_JOB_STATUS_RUNNING ProofJobStatus = 2
+	// The proof has been generated
+	ProofJobStatus_PROOF_JOB_STATUS_SUCCEEDED ProofJobStatus = 3
+	// The proof generation failed
+	ProofJobStatus_PROOF_JOB_STATUS_FAILED ProofJobStatus = 4
+)
+
+// Enum value maps for ProofJobStatus.
+var (
+	ProofJobStatus_name = map[int32]string{
+		0: "PROOF_JOB_STATUS_UNSPECIFIED",
+		1: "PROOF_JOB_STATUS_QUEUED",
+		2: "PROOF_JOB_STATUS_RUNNING",
+		3: "PROOF_JOB_STATUS_SUCCEEDED",
+		4: "PROOF_JOB_STATUS_FAILED",
+	}
+	ProofJobStatus_value = map[string]int32{
+		"PROOF_JOB_STATUS_UNSPECIFIED": 0,
+		"PROOF_JOB_STATUS_QUEUED":      1,
+		"PROOF_JOB_STATUS_RUNNING":     2,
+		"PROOF_JOB_STATUS_SUCCEEDED":   3,
+		"PROOF_JOB_STATUS_FAILED":      4,
+	}
+)
+
+func (x ProofJobStatus) Enum() *ProofJobStatus {
+	p := new(ProofJobStatus)
+	*p = x
+	return p
+}
+
+func (x ProofJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProofJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_enumTypes[0].Descriptor()
+}
+
+func (ProofJobStatus) Type() protoreflect.EnumType {
+	return &file_aggsender_prover_proto_v1_proof_generation_proto_enumTypes[0]
+}
+
+func (x ProofJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProofJobStatus.Descriptor instead.
+func (ProofJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{0}
+}
+
+// Request to queue a proof generation job
+type SubmitProofJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Last block already proven
+	LastProvenBlock uint64 `protobuf:"varint,1,opt,name=last_proven_block,json=lastProvenBlock,proto3" json:"last_proven_block,omitempty"`
+	// Last block that can be included in the proof
+	MaxEndBlock   uint64 `protobuf:"varint,2,opt,name=max_end_block,json=maxEndBlock,proto3" json:"max_end_block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitProofJobRequest) Reset() {
+	*x = SubmitProofJobRequest{}
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitProofJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitProofJobRequest) ProtoMessage() {}
+
+func (x *SubmitProofJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitProofJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitProofJobRequest) Descriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitProofJobRequest) GetLastProvenBlock() uint64 {
+	if x != nil {
+		return x.LastProvenBlock
+	}
+	return 0
+}
+
+func (x *SubmitProofJobRequest) GetMaxEndBlock() uint64 {
+	if x != nil {
+		return x.MaxEndBlock
+	}
+	return 0
+}
+
+// Response of a queued proof generation job
+type SubmitProofJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier of the job
+	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitProofJobResponse) Reset() {
+	*x = SubmitProofJobResponse{}
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitProofJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitProofJobResponse) ProtoMessage() {}
+
+func (x *SubmitProofJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitProofJobResponse.ProtoReflect.Descriptor instead.
+func (*SubmitProofJobResponse) Descriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitProofJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Request to get a proof generation job
+type GetProofJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier of the job
+	JobId         string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProofJobRequest) Reset() {
+	*x = GetProofJobRequest{}
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProofJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProofJobRequest) ProtoMessage() {}
+
+func (x *GetProofJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProofJobRequest.ProtoReflect.Descriptor instead.
+func (*GetProofJobRequest) Descriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetProofJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// SP1 stark proof generated by the aggkit prover
+type SP1StarkProof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Proof bytes
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Version of the prover
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Verification key
+	Vkey          []byte `protobuf:"bytes,3,opt,name=vkey,proto3" json:"vkey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SP1StarkProof) Reset() {
+	*x = SP1StarkProof{}
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SP1StarkProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SP1StarkProof) ProtoMessage() {}
+
+func (x *SP1StarkProof) ProtoReflect() protoreflect.Message {
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SP1StarkProof.ProtoReflect.Descriptor instead.
+func (*SP1StarkProof) Descriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SP1StarkProof) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *SP1StarkProof) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SP1StarkProof) GetVkey() []byte {
+	if x != nil {
+		return x.Vkey
+	}
+	return nil
+}
+
+// Proof generation job
+type ProofJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifier of the job
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Last block already proven
+	LastProvenBlock uint64 `protobuf:"varint,2,opt,name=last_proven_block,json=lastProvenBlock,proto3" json:"last_proven_block,omitempty"`
+	// Last block that can be included in the proof
+	MaxEndBlock uint64 `protobuf:"varint,3,opt,name=max_end_block,json=maxEndBlock,proto3" json:"max_end_block,omitempty"`
+	// Status of the job
+	Status ProofJobStatus `protobuf:"varint,4,opt,name=status,proto3,enum=aggkit.aggsender.prover.v1.ProofJobStatus" json:"status,omitempty"`
+	// Error of a failed job
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Generated proof, only set if the job succeeded
+	Proof *SP1StarkProof `protobuf:"bytes,6,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Creation time of the job (unix seconds)
+	CreatedAt uint64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Last update time of the job (unix seconds)
+	UpdatedAt     uint64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProofJob) Reset() {
+	*x = ProofJob{}
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofJob) ProtoMessage() {}
+
+func (x *ProofJob) ProtoReflect() protoreflect.Message {
+	mi := &file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofJob.ProtoReflect.Descriptor instead.
+func (*ProofJob) Descriptor() ([]byte, []int) {
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProofJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ProofJob) GetLastProvenBlock() uint64 {
+	if x != nil {
+		return x.LastProvenBlock
+	}
+	return 0
+}
+
+func (x *ProofJob) GetMaxEndBlock() uint64 {
+	if x != nil {
+		return x.MaxEndBlock
+	}
+	return 0
+}
+
+func (x *ProofJob) GetStatus() ProofJobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ProofJobStatus_PROOF_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *ProofJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProofJob) GetProof() *SP1StarkProof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *ProofJob) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ProofJob) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+var File_aggsender_prover_proto_v1_proof_generation_proto protoreflect.FileDescriptor
+
+const file_aggsender_prover_proto_v1_proof_generation_proto_rawDesc = "" +
+	"\n" +
+	"0aggsender/prover/proto/v1/proof_generation.proto\x12\x1aaggkit.aggsender.prover.v1\"g\n" +
+	"\x15SubmitProofJobRequest\x12*\n" +
+	"\x11last_proven_block\x18\x01 \x01(\x04R\x0flastProvenBlock\x12\"\n" +
+	"\rmax_end_block\x18\x02 \x01(\x04R\vmaxEndBlock\"/\n" +
+	"\x16SubmitProofJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"+\n" +
+	"\x12GetProofJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"S\n" +
+	"\rSP1StarkProof\x12\x14\n" +
+	"\x05proof\x18\x01 \x01(\fR\x05proof\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04vkey\x18\x03 \x01(\fR\x04vkey\"\xca\x02\n" +
+	"\bProofJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12*\n" +
+	"\x11last_proven_block\x18\x02 \x01(\x04R\x0flastProvenBlock\x12\"\n" +
+	"\rmax_end_block\x18\x03 \x01(\x04R\vmaxEndBlock\x12B\n" +
+	"\x06status\x18\x04 \x01(\x0e2*.aggkit.aggsender.prover.v1.ProofJobStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12?\n" +
+	"\x05proof\x18\x06 \x01(\v2).aggkit.aggsender.prover.v1.SP1StarkProofR\x05proof\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x04R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x04R\tupdatedAt*\xaa\x01\n" +
+	"\x0eProofJobStatus\x12 \n" +
+	"\x1cPROOF_JOB_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17PROOF_JOB_STATUS_QUEUED\x10\x01\x12\x1c\n" +
+	"\x18PROOF_JOB_STATUS_RUNNING\x10\x02\x12\x1e\n" +
+	"\x1aPROOF_JOB_STATUS_SUCCEEDED\x10\x03\x12\x1b\n" +
+	"\x17PROOF_JOB_STATUS_FAILED\x10\x042\xf7\x01\n" +
+	"\x17AggchainProofGeneration\x12w\n" +
+	"\x0eSubmitProofJob\x121.aggkit.aggsender.prover.v1.SubmitProofJobRequest\x1a2.aggkit.aggsender.prover.v1.SubmitProofJobResponse\x12c\n" +
+	"\vGetProofJob\x12..aggkit.aggsender.prover.v1.GetProofJobRequest\x1a$.aggkit.aggsender.prover.v1.ProofJobB6Z4github.com/agglayer/aggkit/aggsender/prover/proto/v1b\x06proto3"
+
+var (
+	file_aggsender_prover_proto_v1_proof_generation_proto_rawDescOnce sync.Once
+	file_aggsender_prover_proto_v1_proof_generation_proto_rawDescData []byte
+)
+
+func file_aggsender_prover_proto_v1_proof_generation_proto_rawDescGZIP() []byte {
+	file_aggsender_prover_proto_v1_proof_generation_proto_rawDescOnce.Do(func() {
+		file_aggsender_prover_proto_v1_proof_generation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_aggsender_prover_proto_v1_proof_generation_proto_rawDesc), len(file_aggsender_prover_proto_v1_proof_generation_proto_rawDesc)))
+	})
+	return file_aggsender_prover_proto_v1_proof_generation_proto_rawDescData
+}
+
+var file_aggsender_prover_proto_v1_proof_generation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_aggsender_prover_proto_v1_proof_generation_proto_goTypes = []any{
+	(ProofJobStatus)(0),            // 0: aggkit.aggsender.prover.v1.ProofJobStatus
+	(*SubmitProofJobRequest)(nil),  // 1: aggkit.aggsender.prover.v1.SubmitProofJobRequest
+	(*SubmitProofJobResponse)(nil), // 2: aggkit.aggsender.prover.v1.SubmitProofJobResponse
+	(*GetProofJobRequest)(nil),     // 3: aggkit.aggsender.prover.v1.GetProofJobRequest
+	(*SP1StarkProof)(nil),          // 4: aggkit.aggsender.prover.v1.SP1StarkProof
+	(*ProofJob)(nil),               // 5: aggkit.aggsender.prover.v1.ProofJob
+}
+var file_aggsender_prover_proto_v1_proof_generation_proto_depIdxs = []int32{
+	0, // 0: aggkit.aggsender.prover.v1.ProofJob.status:type_name -> aggkit.aggsender.prover.v1.ProofJobStatus
+	4, // 1: aggkit.aggsender.prover.v1.ProofJob.proof:type_name -> aggkit.aggsender.prover.v1.SP1StarkProof
+	1, // 2: aggkit.aggsender.prover.v1.AggchainProofGeneration.SubmitProofJob:input_type -> aggkit.aggsender.prover.v1.SubmitProofJobRequest
+	3, // 3: aggkit.aggsender.prover.v1.AggchainProofGeneration.GetProofJob:input_type -> aggkit.aggsender.prover.v1.GetProofJobRequest
+	2, // 4: aggkit.aggsender.prover.v1.AggchainProofGeneration.SubmitProofJob:output_type -> aggkit.aggsender.prover.v1.SubmitProofJobResponse
+	5, // 5: aggkit.aggsender.prover.v1.AggchainProofGeneration.GetProofJob:output_type -> aggkit.aggsender.prover.v1.ProofJob
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_aggsender_prover_proto_v1_proof_generation_proto_init() }
+func file_aggsender_prover_proto_v1_proof_generation_proto_init() {
+	if File_aggsender_prover_proto_v1_proof_generation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aggsender_prover_proto_v1_proof_generation_proto_rawDesc), len(file_aggsender_prover_proto_v1_proof_generation_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aggsender_prover_proto_v1_proof_generation_proto_goTypes,
+		DependencyIndexes: file_aggsender_prover_proto_v1_proof_generation_proto_depIdxs,
+		EnumInfos:         file_aggsender_prover_proto_v1_proof_generation_proto_enumTypes,
+		MessageInfos:      file_aggsender_prover_proto_v1_proof_generation_proto_msgTypes,
+	}.Build()
+	File_aggsender_prover_proto_v1_proof_generation_proto = out.File
+	file_aggsender_prover_proto_v1_proof_generation_proto_goTypes = nil
+	file_aggsender_prover_proto_v1_proof_generation_proto_depIdxs = nil
+}
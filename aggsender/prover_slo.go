@@ -0,0 +1,185 @@
+package aggsender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+)
+
+const (
+	proverSLOStatusBreached  = "breached"
+	proverSLOStatusRecovered = "recovered"
+	// maxWebhookResponseBody is the number of bytes of the webhook response body included in the errors
+	maxWebhookResponseBody = 512
+)
+
+// proverSLOAlert is the JSON body posted to the webhook when the prover SLOs are breached or recovered
+type proverSLOAlert struct {
+	Status    string `json:"status"`
+	NetworkID uint32 `json:"network_id"`
+	// Height is the height of the certificate whose proof changed the status
+	Height     uint64  `json:"height"`
+	Samples    int     `json:"samples"`
+	Percentile float64 `json:"percentile"`
+	// ProvingTimeSeconds and ProofSize are the percentiles of the window
+	ProvingTimeSeconds    float64  `json:"proving_time_seconds"`
+	MaxProvingTimeSeconds float64  `json:"max_proving_time_seconds"`
+	ProofSize             uint64   `json:"proof_size"`
+	MaxProofSize          uint64   `json:"max_proof_size"`
+	Violations            []string `json:"violations,omitempty"`
+	Timestamp             int64    `json:"timestamp"`
+}
+
+// proverSLO stores the proving time and the proof size of each aggchain proof, and compares the rolling
+// percentiles of the last proofs with the SLOs of the config. A breach (and its recovery) is alerted once,
+// with the metrics and the webhook, so the prover degradation is noticed before the certificates miss epochs
+type proverSLO struct {
+	log        aggkitcommon.Logger
+	cfg        types.ProverSLOConfig
+	storage    db.AggSenderStorage
+	networkID  uint32
+	httpClient *http.Client
+	timeNowFn  func() time.Time
+	// breached is the status of the last evaluation, the alerts are sent when it changes
+	breached bool
+}
+
+// newProverSLO returns nil if the tracking of the prover SLOs is disabled
+func newProverSLO(logger aggkitcommon.Logger, cfg types.ProverSLOConfig, storage db.AggSenderStorage,
+	networkID uint32) *proverSLO {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &proverSLO{
+		log:        logger,
+		cfg:        cfg,
+		storage:    storage,
+		networkID:  networkID,
+		httpClient: &http.Client{Timeout: cfg.WebhookTimeout.Duration},
+		timeNowFn:  time.Now,
+	}
+}
+
+// record saves the stats of the aggchain proof of the certificate and evaluates the SLOs. The errors are
+// only logged, so they never prevent sending the certificate
+func (s *proverSLO) record(ctx context.Context, height uint64, params *types.CertificateBuildParams) {
+	stats := params.ProofStats(height)
+	if stats == nil {
+		return
+	}
+	now := s.timeNowFn()
+	stats.CreatedAt = uint32(now.UTC().Unix())
+	metrics.ProofSize(stats.ProofSize)
+	if err := s.storage.SaveProofStats(ctx, stats); err != nil {
+		s.log.Errorf("error saving the proof stats %s: %v", stats.String(), err)
+		return
+	}
+	window, err := s.storage.GetLastProofStats(s.cfg.WindowSize)
+	if err != nil {
+		s.log.Errorf("error getting the last %d proof stats: %v", s.cfg.WindowSize, err)
+		return
+	}
+
+	alert := s.evaluate(window)
+	alert.Height = height
+	alert.Timestamp = now.UTC().Unix()
+	breached := len(alert.Violations) > 0
+	newBreach := breached && !s.breached
+	metrics.ProverPercentiles(alert.ProvingTimeSeconds, alert.ProofSize)
+	metrics.ProverSLOBreached(breached, newBreach)
+	s.log.Debugf("%s. p%v of the last %d proofs: proving time %.1fs, proof size %d bytes",
+		stats.String(), s.cfg.Percentile, alert.Samples, alert.ProvingTimeSeconds, alert.ProofSize)
+	if breached == s.breached {
+		return
+	}
+	s.breached = breached
+	if breached {
+		alert.Status = proverSLOStatusBreached
+		s.log.Warnf("prover SLOs breached (p%v of the last %d proofs): %v",
+			s.cfg.Percentile, alert.Samples, alert.Violations)
+	} else {
+		alert.Status = proverSLOStatusRecovered
+		s.log.Infof("prover SLOs recovered (p%v of the last %d proofs): proving time %.1fs, proof size %d bytes",
+			s.cfg.Percentile, alert.Samples, alert.ProvingTimeSeconds, alert.ProofSize)
+	}
+	if err := s.notify(ctx, alert); err != nil {
+		s.log.Errorf("error notifying the prover SLO alert (%s) to the webhook: %v", alert.Status, err)
+	}
+}
+
+// evaluate computes the percentiles of the window and compares them with the SLOs
+func (s *proverSLO) evaluate(window []*types.ProofStats) proverSLOAlert {
+	provingTimes := make([]uint64, len(window))
+	proofSizes := make([]uint64, len(window))
+	for i, stats := range window {
+		provingTimes[i] = stats.ProvingTimeMs
+		proofSizes[i] = stats.ProofSize
+	}
+	provingTime := time.Duration(percentile(provingTimes, s.cfg.Percentile)) * time.Millisecond
+	alert := proverSLOAlert{
+		NetworkID:             s.networkID,
+		Samples:               len(window),
+		Percentile:            s.cfg.Percentile,
+		ProvingTimeSeconds:    provingTime.Seconds(),
+		MaxProvingTimeSeconds: s.cfg.MaxProvingTime.Seconds(),
+		ProofSize:             percentile(proofSizes, s.cfg.Percentile),
+		MaxProofSize:          s.cfg.MaxProofSize,
+	}
+	if s.cfg.MaxProvingTime.Duration > 0 && provingTime > s.cfg.MaxProvingTime.Duration {
+		alert.Violations = append(alert.Violations, fmt.Sprintf("proving time %s exceeds MaxProvingTime %s",
+			provingTime, s.cfg.MaxProvingTime))
+	}
+	if s.cfg.MaxProofSize > 0 && alert.ProofSize > s.cfg.MaxProofSize {
+		alert.Violations = append(alert.Violations, fmt.Sprintf("proof size %d bytes exceeds MaxProofSize %d bytes",
+			alert.ProofSize, s.cfg.MaxProofSize))
+	}
+	return alert
+}
+
+// notify posts the alert to the webhook, if it's configured
+func (s *proverSLO) notify(ctx context.Context, alert proverSLOAlert) error {
+	if s.cfg.WebhookURL == "" {
+		return nil
+	}
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("error encoding the alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating the webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending the webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseBody))
+		return fmt.Errorf("the webhook answered with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// percentile returns the nearest-rank percentile p (0-100] of the values, or 0 if there are none
+func percentile(values []uint64, p float64) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted)))) //nolint:mnd
+	return sorted[max(rank, 1)-1]
+}
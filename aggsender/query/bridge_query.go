@@ -10,6 +10,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// DefaultMaxBlocksPerQuery is the default maximum number of blocks of each query to the bridge syncer
+const DefaultMaxBlocksPerQuery = 10000
+
 var _ types.BridgeQuerier = (*bridgeDataQuerier)(nil)
 
 // bridgeDataQuerier is a struct that holds the logic to query the bridge data
@@ -17,6 +20,9 @@ type bridgeDataQuerier struct {
 	log                 types.Logger
 	bridgeSyncer        types.L2BridgeSyncer
 	delayBetweenRetries time.Duration
+	// maxBlocksPerQuery is the maximum number of blocks of each query to the bridge syncer,
+	// wider ranges are queried in chunks (0 = no limit)
+	maxBlocksPerQuery uint64
 
 	originNetwork uint32
 }
@@ -26,16 +32,21 @@ func NewBridgeDataQuerier(
 	log types.Logger,
 	bridgeSyncer types.L2BridgeSyncer,
 	delayBetweenRetries time.Duration,
+	maxBlocksPerQuery uint64,
 ) *bridgeDataQuerier {
 	return &bridgeDataQuerier{
 		log:                 log,
 		bridgeSyncer:        bridgeSyncer,
 		delayBetweenRetries: delayBetweenRetries,
+		maxBlocksPerQuery:   maxBlocksPerQuery,
 		originNetwork:       bridgeSyncer.OriginNetwork(),
 	}
 }
 
 // GetBridgesAndClaims retrieves bridges and optionally claims within a specified block range.
+// If the range is wider than maxBlocksPerQuery, it's queried in chunks of maxBlocksPerQuery blocks,
+// so a very wide range (e.g. the first FEP certificate after a long time in PP mode) doesn't run
+// a single giant query that locks the bridge syncer DB.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -48,10 +59,60 @@ func NewBridgeDataQuerier(
 //   - error: An error if any occurs during the retrieval of bridges or claims.
 //
 // Errors:
-//   - Returns an error if there is an issue retrieving bridges or claims from the bridgeSyncer.
+//   - Returns an error if there is an issue retrieving bridges or claims from the bridgeSyncer,
+//     or if the context is canceled between two chunks.
 func (b *bridgeDataQuerier) GetBridgesAndClaims(
 	ctx context.Context,
 	fromBlock, toBlock uint64,
+) ([]bridgesync.Bridge, []bridgesync.Claim, error) {
+	if b.maxBlocksPerQuery == 0 || toBlock < fromBlock || toBlock-fromBlock < b.maxBlocksPerQuery {
+		return b.getBridgesAndClaims(ctx, fromBlock, toBlock)
+	}
+
+	totalChunks := (toBlock-fromBlock)/b.maxBlocksPerQuery + 1
+	b.log.Infof("bridgeDataQuerier - querying bridges and claims of blocks %d-%d in %d chunks of %d blocks",
+		fromBlock, toBlock, totalChunks, b.maxBlocksPerQuery)
+
+	var (
+		bridges []bridgesync.Bridge
+		claims  []bridgesync.Claim
+	)
+	for chunk, chunkFrom := uint64(1), fromBlock; ; chunk++ {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, fmt.Errorf("bridgeDataQuerier - query of blocks %d-%d canceled at block %d: %w",
+				fromBlock, toBlock, chunkFrom, err)
+		}
+		chunkTo := toBlock
+		if toBlock-chunkFrom >= b.maxBlocksPerQuery {
+			chunkTo = chunkFrom + b.maxBlocksPerQuery - 1
+		}
+
+		chunkBridges, chunkClaims, err := b.getBridgesAndClaims(ctx, chunkFrom, chunkTo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("blocks %d-%d: %w", chunkFrom, chunkTo, err)
+		}
+		// only the events are kept, the chunk slices are released on each iteration
+		bridges = append(bridges, chunkBridges...)
+		claims = append(claims, chunkClaims...)
+		b.log.Debugf("bridgeDataQuerier - queried blocks %d-%d (chunk %d/%d): %d bridges and %d claims so far",
+			chunkFrom, chunkTo, chunk, totalChunks, len(bridges), len(claims))
+
+		if chunkTo == toBlock {
+			break
+		}
+		chunkFrom = chunkTo + 1
+	}
+
+	b.log.Infof("bridgeDataQuerier - queried %d bridges and %d claims of blocks %d-%d",
+		len(bridges), len(claims), fromBlock, toBlock)
+
+	return bridges, claims, nil
+}
+
+// getBridgesAndClaims retrieves the bridges and claims of the block range with a single query for each one
+func (b *bridgeDataQuerier) getBridgesAndClaims(
+	ctx context.Context,
+	fromBlock, toBlock uint64,
 ) ([]bridgesync.Bridge, []bridgesync.Claim, error) {
 	bridges, err := b.bridgeSyncer.GetBridges(ctx, fromBlock, toBlock)
 	if err != nil {
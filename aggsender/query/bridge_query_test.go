@@ -19,13 +19,14 @@ func TestGetBridgesAndClaims(t *testing.T) {
 
 	ctx := context.Background()
 	testCases := []struct {
-		name            string
-		fromBlock       uint64
-		toBlock         uint64
-		mockFn          func(*mocks.L2BridgeSyncer)
-		expectedBridges []bridgesync.Bridge
-		expectedClaims  []bridgesync.Claim
-		expectedError   string
+		name              string
+		fromBlock         uint64
+		toBlock           uint64
+		maxBlocksPerQuery uint64
+		mockFn            func(*mocks.L2BridgeSyncer)
+		expectedBridges   []bridgesync.Bridge
+		expectedClaims    []bridgesync.Claim
+		expectedError     string
 	}{
 		{
 			name:      "success - valid bridges and claims",
@@ -80,6 +81,66 @@ func TestGetBridgesAndClaims(t *testing.T) {
 			expectedBridges: nil,
 			expectedClaims:  nil,
 		},
+		{
+			name:              "success - range equal to max blocks per query is not chunked",
+			fromBlock:         100,
+			toBlock:           199,
+			maxBlocksPerQuery: 100,
+			mockFn: func(mockSyncer *mocks.L2BridgeSyncer) {
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(100), uint64(199)).Return([]bridgesync.Bridge{
+					{BlockNum: 100, BlockPos: 1},
+				}, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(100), uint64(199)).Return(nil, nil).Once()
+			},
+			expectedBridges: []bridgesync.Bridge{
+				{BlockNum: 100, BlockPos: 1},
+			},
+		},
+		{
+			name:              "success - wide range queried in chunks",
+			fromBlock:         100,
+			toBlock:           349,
+			maxBlocksPerQuery: 100,
+			mockFn: func(mockSyncer *mocks.L2BridgeSyncer) {
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(100), uint64(199)).Return([]bridgesync.Bridge{
+					{BlockNum: 150, BlockPos: 1},
+				}, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(100), uint64(199)).Return(nil, nil).Once()
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(200), uint64(299)).Return(nil, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(200), uint64(299)).Return([]bridgesync.Claim{
+					{BlockNum: 210, BlockPos: 1},
+				}, nil).Once()
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(300), uint64(349)).Return([]bridgesync.Bridge{
+					{BlockNum: 300, BlockPos: 1},
+					{BlockNum: 349, BlockPos: 2},
+				}, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(300), uint64(349)).Return([]bridgesync.Claim{
+					{BlockNum: 320, BlockPos: 1},
+				}, nil).Once()
+			},
+			expectedBridges: []bridgesync.Bridge{
+				{BlockNum: 150, BlockPos: 1},
+				{BlockNum: 300, BlockPos: 1},
+				{BlockNum: 349, BlockPos: 2},
+			},
+			expectedClaims: []bridgesync.Claim{
+				{BlockNum: 210, BlockPos: 1},
+				{BlockNum: 320, BlockPos: 1},
+			},
+		},
+		{
+			name:              "error - failed to fetch claims of a chunk",
+			fromBlock:         100,
+			toBlock:           300,
+			maxBlocksPerQuery: 100,
+			mockFn: func(mockSyncer *mocks.L2BridgeSyncer) {
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(100), uint64(199)).Return(nil, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(100), uint64(199)).Return(nil, nil).Once()
+				mockSyncer.EXPECT().GetBridges(ctx, uint64(200), uint64(299)).Return(nil, nil).Once()
+				mockSyncer.EXPECT().GetClaims(ctx, uint64(200), uint64(299)).Return(nil, errors.New("some error")).Once()
+			},
+			expectedError: "blocks 200-299: error getting claims: some error",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -92,7 +153,7 @@ func TestGetBridgesAndClaims(t *testing.T) {
 			mockSyncer.EXPECT().OriginNetwork().Return(1).Once()
 			tc.mockFn(mockSyncer)
 
-			bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0)
+			bridgeQuerier := NewBridgeDataQuerier(log.WithFields("module", "test"), mockSyncer, 0, tc.maxBlocksPerQuery)
 
 			bridges, claims, err := bridgeQuerier.GetBridgesAndClaims(ctx, tc.fromBlock, tc.toBlock)
 			if tc.expectedError != "" {
@@ -109,6 +170,25 @@ func TestGetBridgesAndClaims(t *testing.T) {
 	}
 }
 
+func TestGetBridgesAndClaimsCanceledBetweenChunks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockSyncer := mocks.NewL2BridgeSyncer(t)
+	mockSyncer.EXPECT().OriginNetwork().Return(1).Once()
+	mockSyncer.EXPECT().GetBridges(ctx, uint64(0), uint64(9)).Return(nil, nil).Once()
+	mockSyncer.EXPECT().GetClaims(ctx, uint64(0), uint64(9)).RunAndReturn(
+		func(context.Context, uint64, uint64) ([]bridgesync.Claim, error) {
+			cancel()
+			return nil, nil
+		}).Once()
+
+	bridgeQuerier := NewBridgeDataQuerier(log.WithFields("module", "test"), mockSyncer, 0, 10)
+	_, _, err := bridgeQuerier.GetBridgesAndClaims(ctx, 0, 100)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "canceled at block 10")
+}
+
 func TestGetExitRootByIndex(t *testing.T) {
 	t.Parallel()
 
@@ -150,7 +230,7 @@ func TestGetExitRootByIndex(t *testing.T) {
 			mockSyncer.EXPECT().OriginNetwork().Return(1).Once()
 			tc.mockFn(mockSyncer)
 
-			bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0)
+			bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0, 0)
 
 			hash, err := bridgeQuerier.GetExitRootByIndex(ctx, tc.index)
 			if tc.expectedError != "" {
@@ -201,7 +281,7 @@ func TestGetLastProcessedBlock(t *testing.T) {
 			mockSyncer.EXPECT().OriginNetwork().Return(1).Once()
 			tc.mockFn(mockSyncer)
 
-			bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0)
+			bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0, 0)
 
 			block, err := bridgeQuerier.GetLastProcessedBlock(ctx)
 			if tc.expectedError != "" {
@@ -222,7 +302,7 @@ func TestOriginNetwork(t *testing.T) {
 	mockSyncer := new(mocks.L2BridgeSyncer)
 	mockSyncer.EXPECT().OriginNetwork().Return(uint32(1)).Once()
 
-	bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0)
+	bridgeQuerier := NewBridgeDataQuerier(nil, mockSyncer, 0, 0)
 
 	originNetwork := bridgeQuerier.OriginNetwork()
 	require.Equal(t, uint32(1), originNetwork)
@@ -29,7 +29,8 @@ func NewGERDataQuerier(
 }
 
 // GetInjectedGERsProofs retrieves proofs for injected GERs (Global Exit Roots) within a specified block range.
-// It queries the chain for injected GERs and generates proofs for each GER using the finalized L1 info tree root.
+// It queries the chain for injected GERs and generates the proofs of all of them in one batch using the finalized
+// L1 info tree root.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -56,12 +57,22 @@ func (g *gerDataQuerier) GetInjectedGERsProofs(
 	}
 
 	proofs := make(map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber, len(injectedGERs))
+	if len(injectedGERs) == 0 {
+		return proofs, nil
+	}
 
-	for ger, injectedGER := range injectedGERs {
-		info, proof, err := g.l1InfoTreeQuerier.GetProofForGER(ctx, ger, finalizedL1InfoTreeRoot.Hash)
-		if err != nil {
-			return nil, fmt.Errorf("aggchainProverFlow - error getting proof for GER: %s: %w", ger.String(), err)
-		}
+	gers := make([]common.Hash, 0, len(injectedGERs))
+	for ger := range injectedGERs {
+		gers = append(gers, ger)
+	}
+	infos, gerProofs, err := g.l1InfoTreeQuerier.GetProofsForGERs(ctx, gers, finalizedL1InfoTreeRoot.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("aggchainProverFlow - error getting proofs for GERs: %w", err)
+	}
+
+	for i, ger := range gers {
+		injectedGER := injectedGERs[ger]
+		info, proof := infos[i], gerProofs[i]
 
 		proofs[ger] = &agglayertypes.ProvenInsertedGERWithBlockNumber{
 			BlockNumber: injectedGER.BlockNumber,
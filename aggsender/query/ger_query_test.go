@@ -38,9 +38,16 @@ func Test_GetInjectedGERsProofs(t *testing.T) {
 				mockChainGERReader.EXPECT().GetInjectedGERsForRange(ctx, uint64(1), uint64(10)).Return(map[common.Hash]chaingerreader.InjectedGER{
 					common.HexToHash("0x1"): {GlobalExitRoot: common.HexToHash("0x1")},
 				}, nil)
-				mockL1InfoTreeQuery.EXPECT().GetProofForGER(ctx, common.HexToHash("0x1"), common.HexToHash("0x2")).Return(nil, treetypes.Proof{}, errors.New("some error"))
+				mockL1InfoTreeQuery.EXPECT().GetProofsForGERs(ctx, []common.Hash{common.HexToHash("0x1")}, common.HexToHash("0x2")).Return(nil, nil, errors.New("some error"))
 			},
-			expectedError: "error getting proof for GER: 0x0000000000000000000000000000000000000000000000000000000000000001: some error",
+			expectedError: "error getting proofs for GERs: some error",
+		},
+		{
+			name: "no injected GERs",
+			mockFn: func(mockChainGERReader *mocks.ChainGERReader, mockL1InfoTreeQuery *mocks.L1InfoTreeDataQuerier) {
+				mockChainGERReader.EXPECT().GetInjectedGERsForRange(ctx, uint64(1), uint64(10)).Return(map[common.Hash]chaingerreader.InjectedGER{}, nil)
+			},
+			expectedProofs: map[common.Hash]*agglayertypes.ProvenInsertedGERWithBlockNumber{},
 		},
 		{
 			name: "success",
@@ -48,8 +55,8 @@ func Test_GetInjectedGERsProofs(t *testing.T) {
 				mockChainGERReader.EXPECT().GetInjectedGERsForRange(ctx, uint64(1), uint64(10)).Return(map[common.Hash]chaingerreader.InjectedGER{
 					common.HexToHash("0x1"): {GlobalExitRoot: common.HexToHash("0x1"), BlockNumber: 111},
 				}, nil)
-				mockL1InfoTreeQuery.EXPECT().GetProofForGER(ctx, common.HexToHash("0x1"), common.HexToHash("0x2")).Return(
-					&l1infotreesync.L1InfoTreeLeaf{
+				mockL1InfoTreeQuery.EXPECT().GetProofsForGERs(ctx, []common.Hash{common.HexToHash("0x1")}, common.HexToHash("0x2")).Return(
+					[]*l1infotreesync.L1InfoTreeLeaf{{
 						L1InfoTreeIndex:   1,
 						BlockNumber:       111,
 						PreviousBlockHash: common.HexToHash("0x22"),
@@ -57,8 +64,8 @@ func Test_GetInjectedGERsProofs(t *testing.T) {
 						MainnetExitRoot:   common.HexToHash("0x11"),
 						RollupExitRoot:    common.HexToHash("0x33"),
 						GlobalExitRoot:    common.HexToHash("0x1"),
-					},
-					treetypes.Proof{},
+					}},
+					[]treetypes.Proof{{}},
 					nil,
 				)
 			},
@@ -2,18 +2,26 @@ package query
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	treetypes "github.com/agglayer/aggkit/tree/types"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var finalizedBlockBigInt = big.NewInt(int64(aggkittypes.Finalized))
+var (
+	finalizedBlockBigInt = big.NewInt(int64(aggkittypes.Finalized))
+
+	// ErrStaleL1InfoTreeRoot is returned when a root is no longer in the L1 Info tree (e.g. after a reorg of
+	// L1 blocks that were considered finalized), so the proofs generated against it are not valid anymore
+	ErrStaleL1InfoTreeRoot = errors.New("the L1 Info tree root is no longer part of the L1 Info tree")
+)
 
 var _ types.L1InfoTreeDataQuerier = (*L1InfoTreeDataQuerier)(nil)
 
@@ -101,6 +109,47 @@ func (l *L1InfoTreeDataQuerier) GetProofForGER(
 	return l1Info, gerToL1Proof, nil
 }
 
+// GetProofsForGERs returns the L1 Info tree leaves and the merkle proofs for the given GERs, in their order.
+// The proofs are computed in a single batch call to the L1 Info tree syncer
+func (l *L1InfoTreeDataQuerier) GetProofsForGERs(
+	ctx context.Context, gers []common.Hash, rootFromWhichToProve common.Hash) (
+	[]*l1infotreesync.L1InfoTreeLeaf, []treetypes.Proof, error) {
+	l1Infos := make([]*l1infotreesync.L1InfoTreeLeaf, 0, len(gers))
+	indexes := make([]uint32, 0, len(gers))
+	for _, ger := range gers {
+		l1Info, err := l.l1InfoTreeSyncer.GetInfoByGlobalExitRoot(ger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting info by global exit root %s: %w", ger.String(), err)
+		}
+		l1Infos = append(l1Infos, l1Info)
+		indexes = append(indexes, l1Info.L1InfoTreeIndex)
+	}
+
+	proofs, err := l.l1InfoTreeSyncer.GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, indexes, rootFromWhichToProve)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting L1 Info tree merkle proofs for GERs: %w", err)
+	}
+
+	return l1Infos, proofs, nil
+}
+
+// CheckL1InfoTreeRoot checks that the root is still the root of the L1 Info tree with the given leaf index.
+// It returns ErrStaleL1InfoTreeRoot if the L1 Info tree has diverged from it
+func (l *L1InfoTreeDataQuerier) CheckL1InfoTreeRoot(ctx context.Context, root common.Hash, index uint32) error {
+	currentRoot, err := l.l1InfoTreeSyncer.GetL1InfoTreeRootByIndex(ctx, index)
+	if errors.Is(err, db.ErrNotFound) {
+		return fmt.Errorf("%w: root %s, index %d not found", ErrStaleL1InfoTreeRoot, root.String(), index)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting L1 Info tree root by index %d: %w", index, err)
+	}
+	if currentRoot.Hash != root {
+		return fmt.Errorf("%w: root %s, the root of index %d is now %s",
+			ErrStaleL1InfoTreeRoot, root.String(), index, currentRoot.Hash.String())
+	}
+	return nil
+}
+
 // CheckIfClaimsArePartOfFinalizedL1InfoTree checks if the claims are part of the finalized L1 Info tree
 func (l *L1InfoTreeDataQuerier) CheckIfClaimsArePartOfFinalizedL1InfoTree(
 	finalizedL1InfoTreeRoot *treetypes.Root,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/agglayer/aggkit/aggsender/mocks"
 	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	treetypes "github.com/agglayer/aggkit/tree/types"
 	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
@@ -312,6 +313,143 @@ func Test_GetProofForGER(t *testing.T) {
 	}
 }
 
+func Test_GetProofsForGERs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gers := []common.Hash{common.HexToHash("0x1"), common.HexToHash("0x2")}
+	root := common.HexToHash("0x3")
+	leaves := []*l1infotreesync.L1InfoTreeLeaf{
+		{L1InfoTreeIndex: 7, GlobalExitRoot: common.HexToHash("0x1")},
+		{L1InfoTreeIndex: 2, GlobalExitRoot: common.HexToHash("0x2")},
+	}
+
+	testCases := []struct {
+		name           string
+		mockFn         func(*mocks.L1InfoTreeSyncer)
+		expectedLeaves []*l1infotreesync.L1InfoTreeLeaf
+		expectedProofs []treetypes.Proof
+		expectedError  string
+	}{
+		{
+			name: "error getting info by global exit root",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[0]).Return(leaves[0], nil)
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[1]).Return(nil, errors.New("some error"))
+			},
+			expectedError: "error getting info by global exit root 0x0000000000000000000000000000000000000000000000000000000000000002: some error",
+		},
+		{
+			name: "error getting L1 Info tree merkle proofs",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[0]).Return(leaves[0], nil)
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[1]).Return(leaves[1], nil)
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, []uint32{7, 2}, root).
+					Return(nil, errors.New("some error"))
+			},
+			expectedError: "error getting L1 Info tree merkle proofs for GERs: some error",
+		},
+		{
+			name: "success",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[0]).Return(leaves[0], nil)
+				mockL1InfoTreeSyncer.EXPECT().GetInfoByGlobalExitRoot(gers[1]).Return(leaves[1], nil)
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, []uint32{7, 2}, root).
+					Return([]treetypes.Proof{{common.HexToHash("0x7")}, {common.HexToHash("0x2")}}, nil)
+			},
+			expectedLeaves: leaves,
+			expectedProofs: []treetypes.Proof{{common.HexToHash("0x7")}, {common.HexToHash("0x2")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockL1InfoTreeSyncer := mocks.NewL1InfoTreeSyncer(t)
+			l1InfoTreeDataQuery := NewL1InfoTreeDataQuerier(nil, mockL1InfoTreeSyncer)
+
+			tc.mockFn(mockL1InfoTreeSyncer)
+
+			leaves, proofs, err := l1InfoTreeDataQuery.GetProofsForGERs(ctx, gers, root)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedLeaves, leaves)
+				require.Equal(t, tc.expectedProofs, proofs)
+			}
+		})
+	}
+}
+
+func Test_CheckL1InfoTreeRoot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	root := common.HexToHash("0x1")
+
+	testCases := []struct {
+		name          string
+		mockFn        func(*mocks.L1InfoTreeSyncer)
+		expectedStale bool
+		expectedError string
+	}{
+		{
+			name: "error getting L1 Info tree root by index",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeRootByIndex(ctx, uint32(5)).
+					Return(treetypes.Root{}, errors.New("some error"))
+			},
+			expectedError: "error getting L1 Info tree root by index 5: some error",
+		},
+		{
+			name: "root not found",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeRootByIndex(ctx, uint32(5)).
+					Return(treetypes.Root{}, db.ErrNotFound)
+			},
+			expectedStale: true,
+			expectedError: "index 5 not found",
+		},
+		{
+			name: "root diverged",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeRootByIndex(ctx, uint32(5)).
+					Return(treetypes.Root{Hash: common.HexToHash("0x2"), Index: 5}, nil)
+			},
+			expectedStale: true,
+			expectedError: "the root of index 5 is now 0x0000000000000000000000000000000000000000000000000000000000000002",
+		},
+		{
+			name: "success",
+			mockFn: func(mockL1InfoTreeSyncer *mocks.L1InfoTreeSyncer) {
+				mockL1InfoTreeSyncer.EXPECT().GetL1InfoTreeRootByIndex(ctx, uint32(5)).
+					Return(treetypes.Root{Hash: root, Index: 5}, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockL1InfoTreeSyncer := mocks.NewL1InfoTreeSyncer(t)
+			l1InfoTreeDataQuery := NewL1InfoTreeDataQuerier(nil, mockL1InfoTreeSyncer)
+
+			tc.mockFn(mockL1InfoTreeSyncer)
+
+			err := l1InfoTreeDataQuery.CheckL1InfoTreeRoot(ctx, root, 5)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				require.Equal(t, tc.expectedStale, errors.Is(err, ErrStaleL1InfoTreeRoot))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func Test_CheckIfClaimsArePartOfFinalizedL1InfoTree(t *testing.T) {
 	t.Parallel()
 
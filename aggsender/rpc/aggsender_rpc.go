@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/0xPolygon/cdk-rpc/rpc"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/aggsender/types"
 	"github.com/agglayer/aggkit/log"
 )
@@ -11,6 +12,14 @@ import (
 type AggsenderStorer interface {
 	GetCertificateByHeight(height uint64) (*types.Certificate, error)
 	GetLastSentCertificate() (*types.Certificate, error)
+	GetCertificateAnalytics(height uint64) (*types.CertificateAnalytics, error)
+	GetCertificateAuditEvents(height uint64) ([]*types.CertificateAuditEvent, error)
+	GetLastSentCertificateHeader() (*types.CertificateHeader, error)
+	GetCertificateHeadersInHeightRange(fromHeight, toHeight uint64) ([]*types.CertificateHeader, error)
+	GetCertificateHeadersByTags(tags types.CertificateTags, statuses []agglayertypes.CertificateStatus,
+		limit uint64) ([]*types.CertificateHeader, error)
+	GetMirroredCertificateHeaders(fromHeight, toHeight uint64) ([]*types.MirroredCertificateHeader, error)
+	GetLastMirroredCertificateHeader() (*types.MirroredCertificateHeader, error)
 }
 
 type AggsenderInterface interface {
@@ -22,17 +31,28 @@ type AggsenderRPC struct {
 	logger    *log.Logger
 	storage   AggsenderStorer
 	aggsender AggsenderInterface
+	// agglayer and bridges are optional, they are used by the certificate explorer
+	agglayer AgglayerCertificateQuerier
+	bridges  CertificateBridgeQuerier
+	// validator is optional, it's used by aggsender_validateCertificate
+	validator CertificateValidator
 }
 
 func NewAggsenderRPC(
 	logger *log.Logger,
 	storage AggsenderStorer,
 	aggsender AggsenderInterface,
+	agglayer AgglayerCertificateQuerier,
+	bridges CertificateBridgeQuerier,
+	validator CertificateValidator,
 ) *AggsenderRPC {
 	return &AggsenderRPC{
 		logger:    logger,
 		storage:   storage,
 		aggsender: aggsender,
+		agglayer:  agglayer,
+		bridges:   bridges,
+		validator: validator,
 	}
 }
 
@@ -74,3 +94,59 @@ func (b *AggsenderRPC) GetCertificateHeaderPerHeight(height *uint64) (interface{
 
 	return cert, nil
 }
+
+// GetCertificateAnalytics returns the aggregated figures (claims per origin network, bridged value per
+// token, asset and message exits) of the certificate for the given height.
+// if param is `nil` it returns the analytics of the last sent certificate
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_getCertificateAnalytics", "params":[$height], "id":1}'
+func (b *AggsenderRPC) GetCertificateAnalytics(height *uint64) (interface{}, rpc.Error) {
+	if height == nil {
+		cert, err := b.storage.GetLastSentCertificate()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting last sent certificate: %v", err))
+		}
+		if cert == nil || cert.Header == nil {
+			return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate not found")
+		}
+		height = &cert.Header.Height
+	}
+	analytics, err := b.storage.GetCertificateAnalytics(*height)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificate analytics: %v", err))
+	}
+	if analytics == nil {
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate analytics not found")
+	}
+
+	return analytics, nil
+}
+
+// GetCertificateAuditTrail returns the decisions taken for the certificate of the given height (range
+// selected and truncated, prover attempts, submissions, status changes...), in the order they were taken.
+// if param is `nil` it returns the audit trail of the last sent certificate
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_getCertificateAuditTrail", "params":[$height], "id":1}'
+func (b *AggsenderRPC) GetCertificateAuditTrail(height *uint64) (interface{}, rpc.Error) {
+	if height == nil {
+		cert, err := b.storage.GetLastSentCertificate()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting last sent certificate: %v", err))
+		}
+		if cert == nil || cert.Header == nil {
+			return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate not found")
+		}
+		height = &cert.Header.Height
+	}
+	events, err := b.storage.GetCertificateAuditEvents(*height)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificate audit trail: %v", err))
+	}
+	if len(events) == 0 {
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate audit trail not found")
+	}
+
+	return events, nil
+}
@@ -94,6 +94,89 @@ func newAggsenderData(t *testing.T) *aggsenderRPCTestData {
 	t.Helper()
 	mockStore := mocks.NewAggsenderStorer(t)
 	mockAggsender := mocks.NewAggsenderInterface(t)
-	sut := NewAggsenderRPC(nil, mockStore, mockAggsender)
+	sut := NewAggsenderRPC(nil, mockStore, mockAggsender, nil, nil, nil)
 	return &aggsenderRPCTestData{sut, mockStore, mockAggsender}
 }
+
+func TestAggsenderRPCGetCertificateAnalytics(t *testing.T) {
+	height := uint64(3)
+	analytics := &types.CertificateAnalytics{Height: height, AssetExits: 2}
+
+	t.Run("latest", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificate().Return(&types.Certificate{
+			Header: &types.CertificateHeader{Height: height},
+		}, nil).Once()
+		testData.mockStore.EXPECT().GetCertificateAnalytics(height).Return(analytics, nil).Once()
+		res, err := testData.sut.GetCertificateAnalytics(nil)
+		require.NoError(t, err)
+		require.Equal(t, analytics, res)
+	})
+
+	t.Run("latest, no cert", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificate().Return(nil, nil).Once()
+		res, err := testData.sut.GetCertificateAnalytics(nil)
+		require.ErrorContains(t, err, "certificate not found")
+		require.Nil(t, res)
+	})
+
+	t.Run("height, not found", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateAnalytics(height).Return(nil, nil).Once()
+		res, err := testData.sut.GetCertificateAnalytics(&height)
+		require.ErrorContains(t, err, "analytics not found")
+		require.Nil(t, res)
+	})
+
+	t.Run("height, error", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateAnalytics(height).Return(nil, fmt.Errorf("my_error")).Once()
+		res, err := testData.sut.GetCertificateAnalytics(&height)
+		require.ErrorContains(t, err, "my_error")
+		require.Nil(t, res)
+	})
+}
+
+func TestAggsenderRPCGetCertificateAuditTrail(t *testing.T) {
+	height := uint64(3)
+	events := []*types.CertificateAuditEvent{
+		{ID: 1, Height: height, Event: types.CertificateAuditRangeSelected},
+		{ID: 2, Height: height, Event: types.CertificateAuditSigned},
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificate().Return(&types.Certificate{
+			Header: &types.CertificateHeader{Height: height},
+		}, nil).Once()
+		testData.mockStore.EXPECT().GetCertificateAuditEvents(height).Return(events, nil).Once()
+		res, err := testData.sut.GetCertificateAuditTrail(nil)
+		require.NoError(t, err)
+		require.Equal(t, events, res)
+	})
+
+	t.Run("latest, no cert", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificate().Return(nil, nil).Once()
+		res, err := testData.sut.GetCertificateAuditTrail(nil)
+		require.ErrorContains(t, err, "certificate not found")
+		require.Nil(t, res)
+	})
+
+	t.Run("height, not found", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateAuditEvents(height).Return(nil, nil).Once()
+		res, err := testData.sut.GetCertificateAuditTrail(&height)
+		require.ErrorContains(t, err, "audit trail not found")
+		require.Nil(t, res)
+	})
+
+	t.Run("height, error", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateAuditEvents(height).Return(nil, fmt.Errorf("my_error")).Once()
+		res, err := testData.sut.GetCertificateAuditTrail(&height)
+		require.ErrorContains(t, err, "my_error")
+		require.Nil(t, res)
+	})
+}
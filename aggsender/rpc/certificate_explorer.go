@@ -0,0 +1,362 @@
+package aggsenderrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// defaultListCertificatesLimit and maxListCertificatesLimit are the number of certificates returned by
+	// aggsender_listCertificates if the limit is not set, and the maximum allowed
+	defaultListCertificatesLimit = 20
+	maxListCertificatesLimit     = 100
+	// explorerRequestTimeout is the timeout of the requests to the agglayer and the bridge syncer
+	explorerRequestTimeout = 10 * time.Second
+)
+
+// AgglayerCertificateQuerier returns the certificate headers known by the agglayer
+type AgglayerCertificateQuerier interface {
+	GetCertificateHeader(ctx context.Context, certificateHash common.Hash) (*agglayertypes.CertificateHeader, error)
+}
+
+// CertificateBridgeQuerier returns the bridges and claims of a block range of the L2
+type CertificateBridgeQuerier interface {
+	GetBridges(ctx context.Context, fromBlock, toBlock uint64) ([]bridgesync.Bridge, error)
+	GetClaims(ctx context.Context, fromBlock, toBlock uint64) ([]bridgesync.Claim, error)
+}
+
+// CertificateSummary is a stored certificate as listed by the certificate explorer
+type CertificateSummary struct {
+	Header  *types.CertificateHeader `json:"header"`
+	Timings CertificateTimings       `json:"timings"`
+}
+
+// CertificateTimings are the timestamps (unix seconds) of a certificate
+type CertificateTimings struct {
+	CreatedAt uint32 `json:"created_at"`
+	UpdatedAt uint32 `json:"updated_at"`
+	// SecondsToSettle is the time from the creation of the certificate to its settlement (only if settled).
+	// It's approximate, because UpdatedAt is the time the settlement was seen by the aggsender
+	SecondsToSettle *uint32 `json:"seconds_to_settle,omitempty"`
+}
+
+// CertificateProofSizes are the sizes in bytes of the signed certificate and its aggchain proof
+type CertificateProofSizes struct {
+	SignedCertificate int `json:"signed_certificate"`
+	SP1StarkProof     int `json:"sp1_stark_proof"`
+	SP1StarkProofVkey int `json:"sp1_stark_proof_vkey"`
+	CustomChainData   int `json:"custom_chain_data"`
+}
+
+// CertificateDetails is a stored certificate with the data needed to investigate it: the decoded metadata,
+// the header of the agglayer (with the settlement tx) and the bridges and claims of its block range
+type CertificateDetails struct {
+	CertificateSummary
+	Metadata               *types.CertificateMetadata       `json:"metadata,omitempty"`
+	NumBridgeExits         int                              `json:"num_bridge_exits"`
+	NumImportedBridgeExits int                              `json:"num_imported_bridge_exits"`
+	ProofSizes             CertificateProofSizes            `json:"proof_sizes"`
+	AgglayerHeader         *agglayertypes.CertificateHeader `json:"agglayer_header,omitempty"`
+	// MirroredHeader is the header of the local mirror of the agglayer, only if the agglayer can't be queried
+	MirroredHeader   *types.MirroredCertificateHeader `json:"mirrored_header,omitempty"`
+	SettlementTxHash *common.Hash                     `json:"settlement_tx_hash,omitempty"`
+	Bridges          []bridgesync.Bridge              `json:"bridges"`
+	Claims           []bridgesync.Claim               `json:"claims"`
+	// Errors are the data that couldn't be decoded or queried (e.g. the agglayer is not reachable),
+	// the rest of the fields are returned anyway
+	Errors []string `json:"errors,omitempty"`
+}
+
+// signedCertificateContent are the fields decoded from the stored signed certificate. The certificates
+// recovered from the agglayer store its certificate header, that also has the metadata
+type signedCertificateContent struct {
+	Metadata            common.Hash       `json:"metadata"`
+	BridgeExits         []json.RawMessage `json:"bridge_exits"`
+	ImportedBridgeExits []json.RawMessage `json:"imported_bridge_exits"`
+}
+
+func newCertificateSummary(header *types.CertificateHeader) CertificateSummary {
+	summary := CertificateSummary{
+		Header: header,
+		Timings: CertificateTimings{
+			CreatedAt: header.CreatedAt,
+			UpdatedAt: header.UpdatedAt,
+		},
+	}
+	if header.Status.IsSettled() && header.UpdatedAt >= header.CreatedAt {
+		secondsToSettle := header.UpdatedAt - header.CreatedAt
+		summary.Timings.SecondsToSettle = &secondsToSettle
+	}
+	return summary
+}
+
+// ListCertificates returns the summaries of the stored certificates, from the given height down
+// (newest first). If fromHeight is `nil` it starts from the last sent certificate, and if limit is `nil`
+// it returns 20 certificates (max 100)
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_listCertificates", "params":[$fromHeight, $limit], "id":1}'
+func (b *AggsenderRPC) ListCertificates(fromHeight *uint64, limit *uint64) (interface{}, rpc.Error) {
+	numCertificates := uint64(defaultListCertificatesLimit)
+	if limit != nil {
+		if *limit == 0 || *limit > maxListCertificatesLimit {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode,
+				fmt.Sprintf("limit must be between 1 and %d", maxListCertificatesLimit))
+		}
+		numCertificates = *limit
+	}
+
+	if fromHeight == nil {
+		header, err := b.storage.GetLastSentCertificateHeader()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting last sent certificate: %v", err))
+		}
+		if header == nil {
+			return []CertificateSummary{}, nil
+		}
+		fromHeight = &header.Height
+	}
+
+	toHeight := *fromHeight
+	lowestHeight := uint64(0)
+	if toHeight >= numCertificates {
+		lowestHeight = toHeight - numCertificates + 1
+	}
+	headers, err := b.storage.GetCertificateHeadersInHeightRange(lowestHeight, toHeight)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificates: %v", err))
+	}
+
+	summaries := make([]CertificateSummary, 0, len(headers))
+	for i := len(headers) - 1; i >= 0; i-- {
+		summaries = append(summaries, newCertificateSummary(headers[i]))
+	}
+	return summaries, nil
+}
+
+// ListCertificatesByTags returns the summaries of the last stored certificates (newest first) that have
+// all the given tags (e.g. {"environment": "prod"}), and one of the given statuses if any is set
+// (e.g. ["InError"] to alert on the failed certificates of an environment). If limit is `nil` it returns
+// 20 certificates (max 100)
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_listCertificatesByTags", "params":[{"environment":"prod"}, ["InError"], $limit], "id":1}'
+func (b *AggsenderRPC) ListCertificatesByTags(tags map[string]string, statuses []string,
+	limit *uint64) (interface{}, rpc.Error) {
+	numCertificates := uint64(defaultListCertificatesLimit)
+	if limit != nil {
+		if *limit == 0 || *limit > maxListCertificatesLimit {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode,
+				fmt.Sprintf("limit must be between 1 and %d", maxListCertificatesLimit))
+		}
+		numCertificates = *limit
+	}
+	if len(tags) == 0 {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, "at least one tag is required")
+	}
+	if err := types.CertificateTags(tags).Validate(); err != nil {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+	}
+	certStatuses := make([]agglayertypes.CertificateStatus, 0, len(statuses))
+	for _, status := range statuses {
+		certStatus, err := parseCertificateStatus(status)
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, err.Error())
+		}
+		certStatuses = append(certStatuses, certStatus)
+	}
+
+	headers, err := b.storage.GetCertificateHeadersByTags(tags, certStatuses, numCertificates)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificates: %v", err))
+	}
+
+	summaries := make([]CertificateSummary, 0, len(headers))
+	for _, header := range headers {
+		summaries = append(summaries, newCertificateSummary(header))
+	}
+	return summaries, nil
+}
+
+// parseCertificateStatus returns the certificate status of its name (e.g. InError)
+func parseCertificateStatus(status string) (agglayertypes.CertificateStatus, error) {
+	for _, certStatus := range []agglayertypes.CertificateStatus{agglayertypes.Pending, agglayertypes.Proven,
+		agglayertypes.Candidate, agglayertypes.InError, agglayertypes.Settled} {
+		if certStatus.String() == status {
+			return certStatus, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid certificate status: %s", status)
+}
+
+// ListAgglayerCertificateHeaders returns the certificate headers of the network mirrored from the agglayer,
+// from the given height down (newest first). It includes the certificates that are not in the local
+// storage (e.g. sent before it was created). If fromHeight is `nil` it starts from the last mirrored
+// header, and if limit is `nil` it returns 20 headers (max 100). The heights not mirrored yet are missing
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_listAgglayerCertificateHeaders", "params":[$fromHeight, $limit], "id":1}'
+func (b *AggsenderRPC) ListAgglayerCertificateHeaders(fromHeight *uint64, limit *uint64) (interface{}, rpc.Error) {
+	numHeaders := uint64(defaultListCertificatesLimit)
+	if limit != nil {
+		if *limit == 0 || *limit > maxListCertificatesLimit {
+			return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode,
+				fmt.Sprintf("limit must be between 1 and %d", maxListCertificatesLimit))
+		}
+		numHeaders = *limit
+	}
+
+	if fromHeight == nil {
+		header, err := b.storage.GetLastMirroredCertificateHeader()
+		if err != nil {
+			return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
+				fmt.Sprintf("error getting last mirrored certificate header: %v", err))
+		}
+		if header == nil {
+			return []*types.MirroredCertificateHeader{}, nil
+		}
+		fromHeight = &header.Height
+	}
+
+	toHeight := *fromHeight
+	lowestHeight := uint64(0)
+	if toHeight >= numHeaders {
+		lowestHeight = toHeight - numHeaders + 1
+	}
+	headers, err := b.storage.GetMirroredCertificateHeaders(lowestHeight, toHeight)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
+			fmt.Sprintf("error getting mirrored certificate headers: %v", err))
+	}
+
+	result := make([]*types.MirroredCertificateHeader, 0, len(headers))
+	for i := len(headers) - 1; i >= 0; i-- {
+		result = append(result, headers[i])
+	}
+	return result, nil
+}
+
+// GetCertificateDetails returns the stored certificate for the given height with its decoded metadata,
+// proof sizes, timings, the settlement tx (from the agglayer) and the bridges and claims of its blocks
+// (from the L2 bridge syncer). If param is `nil` it returns the last sent certificate
+//
+// curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+// -d '{"method":"aggsender_getCertificateDetails", "params":[$height], "id":1}'
+func (b *AggsenderRPC) GetCertificateDetails(height *uint64) (interface{}, rpc.Error) {
+	var (
+		cert *types.Certificate
+		err  error
+	)
+	if height == nil {
+		cert, err = b.storage.GetLastSentCertificate()
+	} else {
+		cert, err = b.storage.GetCertificateByHeight(*height)
+	}
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificate by height: %v", err))
+	}
+	if cert == nil || cert.Header == nil {
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate not found")
+	}
+
+	details := &CertificateDetails{
+		CertificateSummary: newCertificateSummary(cert.Header),
+		Bridges:            []bridgesync.Bridge{},
+		Claims:             []bridgesync.Claim{},
+	}
+	details.decodeSignedCertificate(cert.SignedCertificate)
+	if proof := cert.AggchainProof; proof != nil {
+		details.ProofSizes.CustomChainData = len(proof.CustomChainData)
+		if proof.SP1StarkProof != nil {
+			details.ProofSizes.SP1StarkProof = len(proof.SP1StarkProof.Proof)
+			details.ProofSizes.SP1StarkProofVkey = len(proof.SP1StarkProof.Vkey)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), explorerRequestTimeout)
+	defer cancel()
+	b.addAgglayerHeader(ctx, details)
+	b.addBridgesAndClaims(ctx, details)
+
+	return details, nil
+}
+
+func (d *CertificateDetails) decodeSignedCertificate(signedCertificate *string) {
+	if signedCertificate == nil || *signedCertificate == "" {
+		return
+	}
+	d.ProofSizes.SignedCertificate = len(*signedCertificate)
+
+	var content signedCertificateContent
+	if err := json.Unmarshal([]byte(*signedCertificate), &content); err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("error decoding signed certificate: %v", err))
+		return
+	}
+	d.NumBridgeExits = len(content.BridgeExits)
+	d.NumImportedBridgeExits = len(content.ImportedBridgeExits)
+
+	metadata, err := types.NewCertificateMetadataFromHash(content.Metadata)
+	if err != nil {
+		d.Errors = append(d.Errors, fmt.Sprintf("error decoding certificate metadata: %v", err))
+		return
+	}
+	d.Metadata = metadata
+}
+
+func (b *AggsenderRPC) addAgglayerHeader(ctx context.Context, details *CertificateDetails) {
+	if b.agglayer == nil || details.Header.CertificateID == (common.Hash{}) {
+		return
+	}
+	header, err := b.agglayer.GetCertificateHeader(ctx, details.Header.CertificateID)
+	if err != nil {
+		details.Errors = append(details.Errors, fmt.Sprintf("error getting certificate header from agglayer: %v", err))
+		b.addMirroredHeader(details)
+		return
+	}
+	details.AgglayerHeader = header
+	if header != nil {
+		details.SettlementTxHash = header.SettlementTxHash
+	}
+}
+
+// addMirroredHeader adds the header of the mirror of the agglayer if it's the same certificate
+func (b *AggsenderRPC) addMirroredHeader(details *CertificateDetails) {
+	height := details.Header.Height
+	mirrored, err := b.storage.GetMirroredCertificateHeaders(height, height)
+	if err != nil {
+		details.Errors = append(details.Errors, fmt.Sprintf("error getting the mirrored certificate header: %v", err))
+		return
+	}
+	if len(mirrored) == 0 || mirrored[0].CertificateID != details.Header.CertificateID {
+		return
+	}
+	details.MirroredHeader = mirrored[0]
+	details.SettlementTxHash = mirrored[0].SettlementTxHash
+}
+
+func (b *AggsenderRPC) addBridgesAndClaims(ctx context.Context, details *CertificateDetails) {
+	header := details.Header
+	if b.bridges == nil || header.FromBlock > header.ToBlock {
+		return
+	}
+	bridges, err := b.bridges.GetBridges(ctx, header.FromBlock, header.ToBlock)
+	if err != nil {
+		details.Errors = append(details.Errors, fmt.Sprintf("error getting bridges from the L2 bridge syncer: %v", err))
+	} else if bridges != nil {
+		details.Bridges = bridges
+	}
+	claims, err := b.bridges.GetClaims(ctx, header.FromBlock, header.ToBlock)
+	if err != nil {
+		details.Errors = append(details.Errors, fmt.Sprintf("error getting claims from the L2 bridge syncer: %v", err))
+	} else if claims != nil {
+		details.Claims = claims
+	}
+}
@@ -0,0 +1,357 @@
+package aggsenderrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggsenderRPCListCertificates(t *testing.T) {
+	headers := []*types.CertificateHeader{
+		{Height: 3, Status: agglayertypes.Settled, CreatedAt: 100, UpdatedAt: 160},
+		{Height: 4, Status: agglayertypes.Pending, CreatedAt: 200, UpdatedAt: 210},
+	}
+
+	t.Run("from the last sent certificate", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificateHeader().Return(headers[1], nil).Once()
+		testData.mockStore.EXPECT().GetCertificateHeadersInHeightRange(uint64(0), uint64(4)).
+			Return(headers, nil).Once()
+
+		res, err := testData.sut.ListCertificates(nil, nil)
+		require.Nil(t, err)
+		summaries, ok := res.([]CertificateSummary)
+		require.True(t, ok)
+		require.Len(t, summaries, 2)
+		// newest first
+		require.Equal(t, headers[1], summaries[0].Header)
+		require.Nil(t, summaries[0].Timings.SecondsToSettle)
+		require.Equal(t, headers[0], summaries[1].Header)
+		require.NotNil(t, summaries[1].Timings.SecondsToSettle)
+		require.Equal(t, uint32(60), *summaries[1].Timings.SecondsToSettle)
+	})
+
+	t.Run("from height with limit", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		fromHeight, limit := uint64(30), uint64(10)
+		testData.mockStore.EXPECT().GetCertificateHeadersInHeightRange(uint64(21), uint64(30)).
+			Return(nil, nil).Once()
+
+		res, err := testData.sut.ListCertificates(&fromHeight, &limit)
+		require.Nil(t, err)
+		require.Equal(t, []CertificateSummary{}, res)
+	})
+
+	t.Run("no certificates sent", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastSentCertificateHeader().Return(nil, nil).Once()
+
+		res, err := testData.sut.ListCertificates(nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, []CertificateSummary{}, res)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		for _, limit := range []uint64{0, maxListCertificatesLimit + 1} {
+			_, err := testData.sut.ListCertificates(nil, &limit)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "limit must be between")
+		}
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		fromHeight := uint64(5)
+		testData.mockStore.EXPECT().GetCertificateHeadersInHeightRange(mock.Anything, fromHeight).
+			Return(nil, errors.New("db error")).Once()
+
+		_, err := testData.sut.ListCertificates(&fromHeight, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestAggsenderRPCListCertificatesByTags(t *testing.T) {
+	tags := map[string]string{types.CertificateTagEnvironment: "prod"}
+
+	t.Run("filter by tags and status", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		headers := []*types.CertificateHeader{
+			{Height: 7, Status: agglayertypes.InError, Tags: tags},
+			{Height: 2, Status: agglayertypes.InError, Tags: tags},
+		}
+		limit := uint64(5)
+		testData.mockStore.EXPECT().GetCertificateHeadersByTags(types.CertificateTags(tags),
+			[]agglayertypes.CertificateStatus{agglayertypes.InError}, limit).Return(headers, nil).Once()
+
+		res, err := testData.sut.ListCertificatesByTags(tags, []string{"InError"}, &limit)
+		require.Nil(t, err)
+		summaries, ok := res.([]CertificateSummary)
+		require.True(t, ok)
+		require.Len(t, summaries, 2)
+		require.Equal(t, headers[0], summaries[0].Header)
+		require.Equal(t, headers[1], summaries[1].Header)
+	})
+
+	t.Run("default limit and no statuses", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateHeadersByTags(types.CertificateTags(tags),
+			[]agglayertypes.CertificateStatus{}, uint64(defaultListCertificatesLimit)).Return(nil, nil).Once()
+
+		res, err := testData.sut.ListCertificatesByTags(tags, nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, []CertificateSummary{}, res)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		testData := newAggsenderData(t)
+
+		_, err := testData.sut.ListCertificatesByTags(nil, nil, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "at least one tag is required")
+
+		_, err = testData.sut.ListCertificatesByTags(map[string]string{"Environment": "prod"}, nil, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "invalid certificate tags")
+
+		_, err = testData.sut.ListCertificatesByTags(tags, []string{"Failed"}, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "invalid certificate status: Failed")
+
+		limit := uint64(0)
+		_, err = testData.sut.ListCertificatesByTags(tags, nil, &limit)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "limit must be between")
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateHeadersByTags(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("db error")).Once()
+
+		_, err := testData.sut.ListCertificatesByTags(tags, nil, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestAggsenderRPCListAgglayerCertificateHeaders(t *testing.T) {
+	headers := []*types.MirroredCertificateHeader{
+		{Height: 3, CertificateID: common.HexToHash("0x3"), Status: agglayertypes.Settled},
+		{Height: 4, CertificateID: common.HexToHash("0x4"), Status: agglayertypes.Pending},
+	}
+
+	t.Run("from the last mirrored header", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastMirroredCertificateHeader().Return(headers[1], nil).Once()
+		testData.mockStore.EXPECT().GetMirroredCertificateHeaders(uint64(0), uint64(4)).Return(headers, nil).Once()
+
+		res, err := testData.sut.ListAgglayerCertificateHeaders(nil, nil)
+		require.Nil(t, err)
+		// newest first
+		require.Equal(t, []*types.MirroredCertificateHeader{headers[1], headers[0]}, res)
+	})
+
+	t.Run("from height with limit", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		fromHeight, limit := uint64(30), uint64(10)
+		testData.mockStore.EXPECT().GetMirroredCertificateHeaders(uint64(21), uint64(30)).Return(nil, nil).Once()
+
+		res, err := testData.sut.ListAgglayerCertificateHeaders(&fromHeight, &limit)
+		require.Nil(t, err)
+		require.Equal(t, []*types.MirroredCertificateHeader{}, res)
+	})
+
+	t.Run("nothing mirrored", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastMirroredCertificateHeader().Return(nil, nil).Once()
+
+		res, err := testData.sut.ListAgglayerCertificateHeaders(nil, nil)
+		require.Nil(t, err)
+		require.Equal(t, []*types.MirroredCertificateHeader{}, res)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		for _, limit := range []uint64{0, maxListCertificatesLimit + 1} {
+			_, err := testData.sut.ListAgglayerCertificateHeaders(nil, &limit)
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "limit must be between")
+		}
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetLastMirroredCertificateHeader().Return(nil, errors.New("db error")).Once()
+
+		_, err := testData.sut.ListAgglayerCertificateHeaders(nil, nil)
+		require.NotNil(t, err)
+		require.Contains(t, err.Error(), "db error")
+	})
+}
+
+func TestAggsenderRPCGetCertificateDetails(t *testing.T) {
+	height := uint64(7)
+	certificateID := common.HexToHash("0x7")
+	settlementTx := common.HexToHash("0xabc")
+	metadata := types.NewCertificateMetadata(100, 9, 1000, types.CertificateTypeFEP.ToInt())
+	signedCert, err := json.Marshal(&agglayertypes.Certificate{
+		Height:              height,
+		Metadata:            metadata.ToHash(),
+		BridgeExits:         []*agglayertypes.BridgeExit{{}, {}},
+		ImportedBridgeExits: []*agglayertypes.ImportedBridgeExit{},
+	})
+	require.NoError(t, err)
+	signedCertStr := string(signedCert)
+	cert := &types.Certificate{
+		Header: &types.CertificateHeader{
+			Height:        height,
+			CertificateID: certificateID,
+			FromBlock:     100,
+			ToBlock:       109,
+			Status:        agglayertypes.Settled,
+			CreatedAt:     1000,
+			UpdatedAt:     1090,
+		},
+		SignedCertificate: &signedCertStr,
+		AggchainProof: &types.AggchainProof{
+			SP1StarkProof: &types.SP1StarkProof{Proof: make([]byte, 1024), Vkey: make([]byte, 32)},
+		},
+	}
+	bridges := []bridgesync.Bridge{{BlockNum: 101, DepositCount: 1}}
+	claims := []bridgesync.Claim{{BlockNum: 105}}
+
+	newTestRPC := func(t *testing.T) (*AggsenderRPC, *mocks.AggsenderStorer, *mocks.AgglayerCertificateQuerier,
+		*mocks.CertificateBridgeQuerier) {
+		t.Helper()
+		storer := mocks.NewAggsenderStorer(t)
+		agglayer := mocks.NewAgglayerCertificateQuerier(t)
+		bridgeQuerier := mocks.NewCertificateBridgeQuerier(t)
+		return NewAggsenderRPC(nil, storer, mocks.NewAggsenderInterface(t), agglayer, bridgeQuerier, nil),
+			storer, agglayer, bridgeQuerier
+	}
+
+	t.Run("full details", func(t *testing.T) {
+		sut, storer, agglayer, bridgeQuerier := newTestRPC(t)
+		storer.EXPECT().GetCertificateByHeight(height).Return(cert, nil).Once()
+		agglayerHeader := &agglayertypes.CertificateHeader{
+			Height:           height,
+			CertificateID:    certificateID,
+			Status:           agglayertypes.Settled,
+			SettlementTxHash: &settlementTx,
+		}
+		agglayer.EXPECT().GetCertificateHeader(mock.Anything, certificateID).Return(agglayerHeader, nil).Once()
+		bridgeQuerier.EXPECT().GetBridges(mock.Anything, uint64(100), uint64(109)).Return(bridges, nil).Once()
+		bridgeQuerier.EXPECT().GetClaims(mock.Anything, uint64(100), uint64(109)).Return(claims, nil).Once()
+
+		res, rpcErr := sut.GetCertificateDetails(&height)
+		require.Nil(t, rpcErr)
+		details, ok := res.(*CertificateDetails)
+		require.True(t, ok)
+		require.Empty(t, details.Errors)
+		require.Equal(t, cert.Header, details.Header)
+		require.Equal(t, uint32(90), *details.Timings.SecondsToSettle)
+		require.Equal(t, metadata, details.Metadata)
+		require.Equal(t, 2, details.NumBridgeExits)
+		require.Equal(t, 0, details.NumImportedBridgeExits)
+		require.Equal(t, CertificateProofSizes{
+			SignedCertificate: len(signedCertStr),
+			SP1StarkProof:     1024,
+			SP1StarkProofVkey: 32,
+		}, details.ProofSizes)
+		require.Equal(t, agglayerHeader, details.AgglayerHeader)
+		require.Equal(t, &settlementTx, details.SettlementTxHash)
+		require.Equal(t, bridges, details.Bridges)
+		require.Equal(t, claims, details.Claims)
+	})
+
+	t.Run("agglayer and syncer errors are reported", func(t *testing.T) {
+		sut, storer, agglayer, bridgeQuerier := newTestRPC(t)
+		storer.EXPECT().GetLastSentCertificate().Return(cert, nil).Once()
+		agglayer.EXPECT().GetCertificateHeader(mock.Anything, certificateID).
+			Return(nil, errors.New("agglayer unreachable")).Once()
+		// the mirror has another certificate for the height
+		storer.EXPECT().GetMirroredCertificateHeaders(height, height).Return([]*types.MirroredCertificateHeader{
+			{Height: height, CertificateID: common.HexToHash("0x8"), SettlementTxHash: &settlementTx},
+		}, nil).Once()
+		bridgeQuerier.EXPECT().GetBridges(mock.Anything, uint64(100), uint64(109)).
+			Return(nil, errors.New("not synced")).Once()
+		bridgeQuerier.EXPECT().GetClaims(mock.Anything, uint64(100), uint64(109)).Return(nil, nil).Once()
+
+		res, rpcErr := sut.GetCertificateDetails(nil)
+		require.Nil(t, rpcErr)
+		details, ok := res.(*CertificateDetails)
+		require.True(t, ok)
+		require.Len(t, details.Errors, 2)
+		require.Contains(t, details.Errors[0], "agglayer unreachable")
+		require.Contains(t, details.Errors[1], "not synced")
+		require.Nil(t, details.SettlementTxHash)
+		require.Nil(t, details.MirroredHeader)
+		require.Empty(t, details.Bridges)
+		require.NotNil(t, details.Claims)
+		require.Equal(t, metadata, details.Metadata)
+	})
+
+	t.Run("mirrored header if the agglayer is not reachable", func(t *testing.T) {
+		sut, storer, agglayer, bridgeQuerier := newTestRPC(t)
+		mirrored := &types.MirroredCertificateHeader{
+			Height:           height,
+			CertificateID:    certificateID,
+			Status:           agglayertypes.Settled,
+			SettlementTxHash: &settlementTx,
+		}
+		storer.EXPECT().GetCertificateByHeight(height).Return(cert, nil).Once()
+		agglayer.EXPECT().GetCertificateHeader(mock.Anything, certificateID).
+			Return(nil, errors.New("agglayer unreachable")).Once()
+		storer.EXPECT().GetMirroredCertificateHeaders(height, height).
+			Return([]*types.MirroredCertificateHeader{mirrored}, nil).Once()
+		bridgeQuerier.EXPECT().GetBridges(mock.Anything, uint64(100), uint64(109)).Return(bridges, nil).Once()
+		bridgeQuerier.EXPECT().GetClaims(mock.Anything, uint64(100), uint64(109)).Return(claims, nil).Once()
+
+		res, rpcErr := sut.GetCertificateDetails(&height)
+		require.Nil(t, rpcErr)
+		details, ok := res.(*CertificateDetails)
+		require.True(t, ok)
+		require.Len(t, details.Errors, 1)
+		require.Contains(t, details.Errors[0], "agglayer unreachable")
+		require.Nil(t, details.AgglayerHeader)
+		require.Equal(t, mirrored, details.MirroredHeader)
+		require.Equal(t, &settlementTx, details.SettlementTxHash)
+	})
+
+	t.Run("without agglayer and bridge syncer", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		invalid := "not json"
+		testData.mockStore.EXPECT().GetCertificateByHeight(height).Return(&types.Certificate{
+			Header:            cert.Header,
+			SignedCertificate: &invalid,
+		}, nil).Once()
+
+		res, rpcErr := testData.sut.GetCertificateDetails(&height)
+		require.Nil(t, rpcErr)
+		details, ok := res.(*CertificateDetails)
+		require.True(t, ok)
+		require.Len(t, details.Errors, 1)
+		require.Contains(t, details.Errors[0], "error decoding signed certificate")
+		require.Nil(t, details.Metadata)
+		require.Nil(t, details.AgglayerHeader)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		testData := newAggsenderData(t)
+		testData.mockStore.EXPECT().GetCertificateByHeight(height).Return(nil, nil).Once()
+
+		_, rpcErr := testData.sut.GetCertificateDetails(&height)
+		require.NotNil(t, rpcErr)
+		require.Contains(t, rpcErr.Error(), "certificate not found")
+	})
+}
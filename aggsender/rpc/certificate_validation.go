@@ -0,0 +1,82 @@
+package aggsenderrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/certvalidation"
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+// CertificateValidator checks a certificate against the local replica of the acceptance rules of the agglayer
+type CertificateValidator interface {
+	Validate(cert *agglayertypes.Certificate) (*certvalidation.Verdict, error)
+}
+
+// ValidateCertificate simulates the submission of a certificate, returning the rule-by-rule verdict of the
+// local replica of the agglayer acceptance rules (height continuity, signature, local exit root chain and
+// proof format). The certificate can be a stored one, by height (the last sent one if all the params are
+// `nil`), or supplied as the second param, with the same JSON format that is sent to the agglayer.
+// stored:
+//
+//	curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+//	 -d '{"method":"aggsender_validateCertificate", "params":[$height], "id":1}'
+//
+// supplied:
+//
+//	curl -X POST http://localhost:5576/ -H "Content-Type: application/json" \
+//	 -d '{"method":"aggsender_validateCertificate", "params":[null, $certificate], "id":1}'
+func (b *AggsenderRPC) ValidateCertificate(
+	height *uint64, certificate *agglayertypes.Certificate) (interface{}, rpc.Error) {
+	if b.validator == nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, "certificate validation is not available")
+	}
+	if height != nil && certificate != nil {
+		return nil, rpc.NewRPCError(rpc.InvalidParamsErrorCode, "either a height or a certificate must be set, not both")
+	}
+	if certificate == nil {
+		var rpcErr rpc.Error
+		if certificate, rpcErr = b.getStoredSignedCertificate(height); rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	verdict, err := b.validator.Validate(certificate)
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error validating certificate: %v", err))
+	}
+	return verdict, nil
+}
+
+// getStoredSignedCertificate returns the certificate sent to the agglayer of the given height,
+// or of the last sent certificate if the height is nil
+func (b *AggsenderRPC) getStoredSignedCertificate(height *uint64) (*agglayertypes.Certificate, rpc.Error) {
+	var (
+		cert *types.Certificate
+		err  error
+	)
+	if height == nil {
+		cert, err = b.storage.GetLastSentCertificate()
+	} else {
+		cert, err = b.storage.GetCertificateByHeight(*height)
+	}
+	if err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode, fmt.Sprintf("error getting certificate: %v", err))
+	}
+	if cert == nil || cert.Header == nil {
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode, "certificate not found")
+	}
+	if cert.SignedCertificate == nil || *cert.SignedCertificate == "" {
+		return nil, rpc.NewRPCError(rpc.NotFoundErrorCode,
+			fmt.Sprintf("certificate %s has no signed certificate", cert.Header.ID()))
+	}
+
+	signedCert := &agglayertypes.Certificate{}
+	if err := json.Unmarshal([]byte(*cert.SignedCertificate), signedCert); err != nil {
+		return nil, rpc.NewRPCError(rpc.DefaultErrorCode,
+			fmt.Sprintf("error decoding signed certificate %s: %v", cert.Header.ID(), err))
+	}
+	return signedCert, nil
+}
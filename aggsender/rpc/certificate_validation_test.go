@@ -0,0 +1,103 @@
+package aggsenderrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/certvalidation"
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggsenderRPCValidateCertificate(t *testing.T) {
+	height := uint64(3)
+	cert := &agglayertypes.Certificate{
+		NetworkID:        1,
+		Height:           height,
+		NewLocalExitRoot: common.HexToHash("0x01"),
+		AggchainData:     &agglayertypes.AggchainDataSignature{Signature: []byte{1, 2, 3}},
+	}
+	raw, err := json.Marshal(cert)
+	require.NoError(t, err)
+	signedCert := string(raw)
+	verdict := &certvalidation.Verdict{CertificateID: cert.Hash(), Height: height, Valid: true}
+
+	newSut := func(t *testing.T) (*AggsenderRPC, *mocks.AggsenderStorer, *mocks.CertificateValidator) {
+		t.Helper()
+		mockStore := mocks.NewAggsenderStorer(t)
+		mockValidator := mocks.NewCertificateValidator(t)
+		return NewAggsenderRPC(nil, mockStore, mocks.NewAggsenderInterface(t), nil, nil, mockValidator),
+			mockStore, mockValidator
+	}
+
+	t.Run("stored certificate", func(t *testing.T) {
+		sut, mockStore, mockValidator := newSut(t)
+		mockStore.EXPECT().GetCertificateByHeight(height).Return(&types.Certificate{
+			Header:            &types.CertificateHeader{Height: height},
+			SignedCertificate: &signedCert,
+		}, nil).Once()
+		mockValidator.EXPECT().Validate(cert).Return(verdict, nil).Once()
+		res, rpcErr := sut.ValidateCertificate(&height, nil)
+		require.Nil(t, rpcErr)
+		require.Equal(t, verdict, res)
+	})
+
+	t.Run("last sent certificate", func(t *testing.T) {
+		sut, mockStore, mockValidator := newSut(t)
+		mockStore.EXPECT().GetLastSentCertificate().Return(&types.Certificate{
+			Header:            &types.CertificateHeader{Height: height},
+			SignedCertificate: &signedCert,
+		}, nil).Once()
+		mockValidator.EXPECT().Validate(cert).Return(verdict, nil).Once()
+		res, rpcErr := sut.ValidateCertificate(nil, nil)
+		require.Nil(t, rpcErr)
+		require.Equal(t, verdict, res)
+	})
+
+	t.Run("supplied certificate", func(t *testing.T) {
+		sut, _, mockValidator := newSut(t)
+		mockValidator.EXPECT().Validate(cert).Return(verdict, nil).Once()
+		res, rpcErr := sut.ValidateCertificate(nil, cert)
+		require.Nil(t, rpcErr)
+		require.Equal(t, verdict, res)
+	})
+
+	t.Run("height and certificate", func(t *testing.T) {
+		sut, _, _ := newSut(t)
+		_, rpcErr := sut.ValidateCertificate(&height, cert)
+		require.ErrorContains(t, rpcErr, "not both")
+	})
+
+	t.Run("certificate not found", func(t *testing.T) {
+		sut, mockStore, _ := newSut(t)
+		mockStore.EXPECT().GetCertificateByHeight(height).Return(nil, nil).Once()
+		_, rpcErr := sut.ValidateCertificate(&height, nil)
+		require.ErrorContains(t, rpcErr, "certificate not found")
+	})
+
+	t.Run("no signed certificate", func(t *testing.T) {
+		sut, mockStore, _ := newSut(t)
+		mockStore.EXPECT().GetCertificateByHeight(height).Return(&types.Certificate{
+			Header: &types.CertificateHeader{Height: height},
+		}, nil).Once()
+		_, rpcErr := sut.ValidateCertificate(&height, nil)
+		require.ErrorContains(t, rpcErr, "has no signed certificate")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		sut, _, mockValidator := newSut(t)
+		mockValidator.EXPECT().Validate(cert).Return(nil, errors.New("db closed")).Once()
+		_, rpcErr := sut.ValidateCertificate(nil, cert)
+		require.ErrorContains(t, rpcErr, "db closed")
+	})
+
+	t.Run("validation not available", func(t *testing.T) {
+		sut := NewAggsenderRPC(nil, mocks.NewAggsenderStorer(t), mocks.NewAggsenderInterface(t), nil, nil, nil)
+		_, rpcErr := sut.ValidateCertificate(nil, cert)
+		require.ErrorContains(t, rpcErr, "not available")
+	})
+}
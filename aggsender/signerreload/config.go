@@ -0,0 +1,43 @@
+package signerreload
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// Config holds the configuration of the live reload of the signers credentials
+type Config struct {
+	// CheckInterval is the interval at which the keystore file of a local signer is checked for
+	// changes (rotation). 0 disables the check
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+	// TTL is the time after which the signer is recreated from its config, even if nothing changed
+	// on disk (e.g. to pick up a rotated token of a remote signer). 0 disables it
+	TTL types.Duration `mapstructure:"TTL"`
+	// RequireKeyMatchTrustedSequencer requires the reloaded key to match the trustedSequencer of the
+	// rollup contract on L1 before activating it. If false, the reloaded key must keep the address of
+	// the active key, so the credentials can be rotated but not the key itself
+	RequireKeyMatchTrustedSequencer bool `mapstructure:"RequireKeyMatchTrustedSequencer"`
+}
+
+// Enabled returns true if the signer is going to be reloaded
+func (c Config) Enabled() bool {
+	return c.CheckInterval.Duration > 0 || c.TTL.Duration > 0
+}
+
+// Validate checks that the configuration is correct
+func (c Config) Validate() error {
+	if c.CheckInterval.Duration < 0 {
+		return errors.New("CheckInterval can't be negative")
+	}
+	if c.TTL.Duration < 0 {
+		return errors.New("TTL can't be negative")
+	}
+	return nil
+}
+
+func (c Config) String() string {
+	return fmt.Sprintf("CheckInterval: %s, TTL: %s, RequireKeyMatchTrustedSequencer: %t",
+		c.CheckInterval, c.TTL, c.RequireKeyMatchTrustedSequencer)
+}
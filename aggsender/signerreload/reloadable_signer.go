@@ -0,0 +1,226 @@
+package signerreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/go_signer/signer"
+	signertypes "github.com/agglayer/go_signer/signer/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var _ signertypes.Signer = (*ReloadableSigner)(nil)
+
+// TrustedSequencerQuerier queries the trustedSequencer of the rollup contract on L1
+type TrustedSequencerQuerier interface {
+	TrustedSequencer(opts *bind.CallOpts) (common.Address, error)
+}
+
+// keystoreFileState is the state of a keystore file used to detect that it changed on disk
+type keystoreFileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// ReloadableSigner is a signer that reloads the credentials (keystore file or remote signer config)
+// when they change on disk or when the TTL expires, without restarting the node. The reloaded key is
+// validated before activating it, and if the reload fails the active key keeps being used
+type ReloadableSigner struct {
+	name             string
+	logger           *log.Logger
+	signerCfg        signertypes.SignerConfig
+	cfg              Config
+	trustedSequencer TrustedSequencerQuerier
+
+	mu           sync.RWMutex
+	current      signertypes.Signer
+	keystore     keystoreFileState
+	lastReloadAt time.Time
+}
+
+// NewReloadableSigner creates and initializes the signer with the given config. The trustedSequencer
+// querier is only used if the config requires the key to match the trustedSequencer
+func NewReloadableSigner(
+	ctx context.Context,
+	name string,
+	logger *log.Logger,
+	signerCfg signertypes.SignerConfig,
+	cfg Config,
+	trustedSequencer TrustedSequencerQuerier,
+) (*ReloadableSigner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid signer reload config: %w", err)
+	}
+	if cfg.RequireKeyMatchTrustedSequencer && trustedSequencer == nil {
+		return nil, errors.New("RequireKeyMatchTrustedSequencer requires a trustedSequencer querier")
+	}
+	r := &ReloadableSigner{
+		name:             name,
+		logger:           logger,
+		signerCfg:        signerCfg,
+		cfg:              cfg,
+		trustedSequencer: trustedSequencer,
+	}
+	current, err := r.newSigner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.current = current
+	r.keystore = r.keystoreState()
+	r.lastReloadAt = time.Now()
+	return r, nil
+}
+
+// Start checks periodically if the signer has to be reloaded, until the context is done.
+// It does nothing if the reload is disabled
+func (r *ReloadableSigner) Start(ctx context.Context) {
+	if !r.cfg.Enabled() {
+		return
+	}
+	interval := r.cfg.CheckInterval.Duration
+	if interval <= 0 || (r.cfg.TTL.Duration > 0 && r.cfg.TTL.Duration < interval) {
+		interval = r.cfg.TTL.Duration
+	}
+	r.logger.Infof("signer %s: checking every %s whether the credentials have to be reloaded (%s)",
+		r.name, interval, r.cfg.String())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if reason := r.reloadReason(); reason != "" {
+				if err := r.Reload(ctx); err != nil {
+					r.logger.Errorf("signer %s: failed to reload the credentials (%s), keeping the active key %s: %v",
+						r.name, reason, r.PublicAddress().Hex(), err)
+				}
+			}
+		}
+	}
+}
+
+// reloadReason returns why the signer has to be reloaded, or empty if it doesn't
+func (r *ReloadableSigner) reloadReason() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cfg.TTL.Duration > 0 && time.Since(r.lastReloadAt) >= r.cfg.TTL.Duration {
+		return "TTL expired"
+	}
+	if r.cfg.CheckInterval.Duration > 0 && r.keystoreState() != r.keystore {
+		return "keystore file changed"
+	}
+	return ""
+}
+
+// Reload recreates the signer from its config, validates the new key and activates it. If it fails,
+// the keystore file is not reloaded again until it changes
+func (r *ReloadableSigner) Reload(ctx context.Context) error {
+	keystore := r.keystoreState()
+	defer func() {
+		r.mu.Lock()
+		r.keystore = keystore
+		r.mu.Unlock()
+	}()
+	newSigner, err := r.newSigner(ctx)
+	if err != nil {
+		return err
+	}
+	newAddr := newSigner.PublicAddress()
+	currentAddr := r.PublicAddress()
+	if err := r.validateAddress(ctx, newAddr, currentAddr); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.current = newSigner
+	r.lastReloadAt = time.Now()
+	r.mu.Unlock()
+	r.logger.Infof("signer %s: credentials reloaded, address %s -> %s", r.name, currentAddr.Hex(), newAddr.Hex())
+	return nil
+}
+
+// validateAddress checks the address of the reloaded key before activating it
+func (r *ReloadableSigner) validateAddress(ctx context.Context, newAddr, currentAddr common.Address) error {
+	if !r.cfg.RequireKeyMatchTrustedSequencer {
+		if newAddr != currentAddr {
+			return fmt.Errorf("the reloaded key %s doesn't match the active key %s "+
+				"(enable RequireKeyMatchTrustedSequencer to rotate the key)", newAddr.Hex(), currentAddr.Hex())
+		}
+		return nil
+	}
+	trustedSequencer, err := r.trustedSequencer.TrustedSequencer(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("error getting the trustedSequencer to validate the reloaded key: %w", err)
+	}
+	if newAddr != trustedSequencer {
+		return fmt.Errorf("the reloaded key %s doesn't match the trustedSequencer %s",
+			newAddr.Hex(), trustedSequencer.Hex())
+	}
+	return nil
+}
+
+func (r *ReloadableSigner) newSigner(ctx context.Context) (signertypes.Signer, error) {
+	s, err := signer.NewSigner(ctx, 0, r.signerCfg, r.name, r.logger)
+	if err != nil {
+		return nil, fmt.Errorf("error NewSigner. Err: %w", err)
+	}
+	if err := s.Initialize(ctx); err != nil {
+		return nil, fmt.Errorf("error signer.Initialize. Err: %w", err)
+	}
+	return s, nil
+}
+
+// keystoreState returns the state of the keystore file of a local signer (empty for the rest)
+func (r *ReloadableSigner) keystoreState() keystoreFileState {
+	if r.signerCfg.Method != signertypes.MethodLocal && r.signerCfg.Method != "" {
+		return keystoreFileState{}
+	}
+	path, err := r.signerCfg.Get(signer.FieldPath)
+	if err != nil {
+		return keystoreFileState{}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return keystoreFileState{}
+	}
+	return keystoreFileState{modTime: info.ModTime(), size: info.Size()}
+}
+
+func (r *ReloadableSigner) active() signertypes.Signer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Initialize does nothing, the signer is initialized when it's created or reloaded
+func (r *ReloadableSigner) Initialize(context.Context) error {
+	return nil
+}
+
+// PublicAddress returns the address of the active key
+func (r *ReloadableSigner) PublicAddress() common.Address {
+	return r.active().PublicAddress()
+}
+
+// String returns a string representation of the active signer (no secrets)
+func (r *ReloadableSigner) String() string {
+	return fmt.Sprintf("reloadable(%s)", r.active().String())
+}
+
+// SignHash signs the hash with the active key
+func (r *ReloadableSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return r.active().SignHash(ctx, hash)
+}
+
+// SignTx signs the tx with the active key
+func (r *ReloadableSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return r.active().SignTx(ctx, tx)
+}
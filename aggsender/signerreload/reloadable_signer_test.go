@@ -0,0 +1,162 @@
+package signerreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/go_signer/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassword = "test"
+
+type fakeTrustedSequencer struct {
+	mu   sync.Mutex
+	addr common.Address
+	err  error
+}
+
+func (f *fakeTrustedSequencer) TrustedSequencer(opts *bind.CallOpts) (common.Address, error) {
+	if opts == nil || opts.Context == nil {
+		return common.Address{}, errors.New("the call has no context")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addr, f.err
+}
+
+func (f *fakeTrustedSequencer) set(addr common.Address, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.addr = addr
+	f.err = err
+}
+
+// writeKeystore writes the key to the keystore file and returns its address
+func writeKeystore(t *testing.T, path string, key *ecdsa.PrivateKey) common.Address {
+	t.Helper()
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, testPassword)
+	require.NoError(t, err)
+	data, err := os.ReadFile(account.URL.Path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return account.Address
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func TestReloadableSignerReload(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("test", "signerreload")
+	path := filepath.Join(t.TempDir(), "signer.keystore")
+	key := newKey(t)
+	addr := writeKeystore(t, path, key)
+	signerCfg := signer.NewLocalSignerConfig(path, testPassword)
+
+	t.Run("the key must keep the address if it's not validated on-chain", func(t *testing.T) {
+		sut, err := NewReloadableSigner(ctx, "test", logger, signerCfg, Config{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, addr, sut.PublicAddress())
+
+		// same key with rotated credentials
+		writeKeystore(t, path, key)
+		require.NoError(t, sut.Reload(ctx))
+		require.Equal(t, addr, sut.PublicAddress())
+
+		writeKeystore(t, path, newKey(t))
+		require.ErrorContains(t, sut.Reload(ctx), "doesn't match the active key")
+		require.Equal(t, addr, sut.PublicAddress())
+		writeKeystore(t, path, key)
+	})
+
+	t.Run("the key must match the trustedSequencer", func(t *testing.T) {
+		trustedSequencer := &fakeTrustedSequencer{addr: addr}
+		sut, err := NewReloadableSigner(ctx, "test", logger, signerCfg,
+			Config{RequireKeyMatchTrustedSequencer: true}, trustedSequencer)
+		require.NoError(t, err)
+
+		newAddr := writeKeystore(t, path, newKey(t))
+		require.ErrorContains(t, sut.Reload(ctx), "doesn't match the trustedSequencer")
+		require.Equal(t, addr, sut.PublicAddress())
+
+		trustedSequencer.set(addr, errors.New("rpc error"))
+		require.ErrorContains(t, sut.Reload(ctx), "rpc error")
+
+		trustedSequencer.set(newAddr, nil)
+		require.NoError(t, sut.Reload(ctx))
+		require.Equal(t, newAddr, sut.PublicAddress())
+
+		signature, err := sut.SignHash(ctx, common.HexToHash("0x1"))
+		require.NoError(t, err)
+		pubKey, err := crypto.SigToPub(common.HexToHash("0x1").Bytes(), signature)
+		require.NoError(t, err)
+		require.Equal(t, newAddr, crypto.PubkeyToAddress(*pubKey))
+	})
+
+	t.Run("the key file can't be read", func(t *testing.T) {
+		writeKeystore(t, path, key)
+		sut, err := NewReloadableSigner(ctx, "test", logger, signerCfg, Config{}, nil)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+		require.Error(t, sut.Reload(ctx))
+		require.Equal(t, addr, sut.PublicAddress())
+	})
+
+	t.Run("RequireKeyMatchTrustedSequencer without querier", func(t *testing.T) {
+		_, err := NewReloadableSigner(ctx, "test", logger, signerCfg,
+			Config{RequireKeyMatchTrustedSequencer: true}, nil)
+		require.ErrorContains(t, err, "requires a trustedSequencer querier")
+	})
+}
+
+func TestReloadableSignerStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.WithFields("test", "signerreload")
+	path := filepath.Join(t.TempDir(), "signer.keystore")
+	addr := writeKeystore(t, path, newKey(t))
+	trustedSequencer := &fakeTrustedSequencer{addr: addr}
+
+	sut, err := NewReloadableSigner(ctx, "test", logger, signer.NewLocalSignerConfig(path, testPassword),
+		Config{
+			CheckInterval:                   types.NewDuration(10 * time.Millisecond),
+			RequireKeyMatchTrustedSequencer: true,
+		}, trustedSequencer)
+	require.NoError(t, err)
+	go sut.Start(ctx)
+
+	// the key is rotated on-chain and on disk
+	key := newKey(t)
+	newAddr := crypto.PubkeyToAddress(key.PublicKey)
+	trustedSequencer.set(newAddr, nil)
+	writeKeystore(t, path, key)
+	require.Eventually(t, func() bool {
+		return sut.PublicAddress() == newAddr
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.False(t, Config{}.Enabled())
+	require.True(t, Config{TTL: types.NewDuration(time.Hour)}.Enabled())
+	require.ErrorContains(t, Config{CheckInterval: types.NewDuration(-time.Second)}.Validate(), "CheckInterval")
+	require.ErrorContains(t, Config{TTL: types.NewDuration(-time.Second)}.Validate(), "TTL")
+}
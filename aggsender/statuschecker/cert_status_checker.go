@@ -25,6 +25,9 @@ type certStatusChecker struct {
 	log            *log.Logger
 	storage        db.AggSenderStorage
 	agglayerClient agglayer.AgglayerClientInterface
+	archiver       types.CertificateArchiver
+	eventPublisher types.CertificateEventPublisher
+	settlements    *settlementTracker
 
 	l2OriginNetwork uint32
 }
@@ -38,6 +41,9 @@ type certStatusChecker struct {
 //   - storage: Interface for accessing the AggSender storage.
 //   - agglayerClient: Client interface for interacting with the Agglayer.
 //   - l2OriginNetwork: Identifier for the L2 origin network.
+//   - archiver: Optional archiver that receives the certificates once they reach a final status (can be nil).
+//   - eventPublisher: Optional publisher of the status changes of the certificates (can be nil).
+//   - epochNotifier: Optional notifier of the epochs for the settlement metrics per epoch (can be nil).
 //
 // Returns:
 //
@@ -47,11 +53,17 @@ func NewCertStatusChecker(
 	storage db.AggSenderStorage,
 	agglayerClient agglayer.AgglayerClientInterface,
 	l2OriginNetwork uint32,
+	archiver types.CertificateArchiver,
+	eventPublisher types.CertificateEventPublisher,
+	epochNotifier types.EpochNotifier,
 ) types.CertificateStatusChecker {
 	return &certStatusChecker{
 		log:             log,
 		storage:         storage,
 		agglayerClient:  agglayerClient,
+		archiver:        archiver,
+		eventPublisher:  eventPublisher,
+		settlements:     newSettlementTracker(log, epochNotifier),
 		l2OriginNetwork: l2OriginNetwork,
 	}
 }
@@ -68,6 +80,8 @@ func NewCertStatusChecker(
 //     during the status check will be recorded.
 //
 // Behavior:
+//   - Reconciles the certificate submission journal with the aggregation layer, so a certificate
+//     sent just before a crash is stored in the local storage.
 //   - Continuously checks the status of pending certificates and the last certificate from the
 //     aggregation layer.
 //   - Logs errors and retries the operation if an error occurs.
@@ -80,8 +94,11 @@ func (c *certStatusChecker) CheckInitialStatus(
 	defer ticker.Stop()
 
 	for {
-		c.CheckPendingCertificatesStatus(ctx)
-		err := c.checkLastCertificateFromAgglayer(ctx)
+		err := c.reconcileCertificateJournal(ctx)
+		if err == nil {
+			c.CheckPendingCertificatesStatus(ctx)
+			err = c.checkLastCertificateFromAgglayer(ctx)
+		}
 		aggsenderStatus.SetLastError(err)
 		if err != nil {
 			c.log.Errorf("error checking initial status: %w, retrying in %s", err, delayBetweenRetries.String())
@@ -102,6 +119,7 @@ func (c *certStatusChecker) CheckInitialStatus(
 // It returns:
 // bool -> if there are pending certificates
 func (c *certStatusChecker) CheckPendingCertificatesStatus(ctx context.Context) types.CertStatus {
+	c.settlements.refresh()
 	pendingCertificates, err := c.storage.GetCertificateHeadersByStatus(agglayertypes.NonSettledStatuses)
 	if err != nil {
 		c.log.Errorf("error getting pending certificates: %w", err)
@@ -157,6 +175,7 @@ func (c *certStatusChecker) updateCertificateStatus(ctx context.Context,
 	switch agglayerCert.Status {
 	case agglayertypes.Settled:
 		metrics.Settled()
+		c.settlements.certificateSettled(localCert, time.Now())
 	case agglayertypes.InError:
 		metrics.InError()
 	}
@@ -167,19 +186,73 @@ func (c *certStatusChecker) updateCertificateStatus(ctx context.Context,
 			localCert.ID(), localCert.Status, agglayerCert.Status)
 	}
 
+	previousStatus := localCert.Status
 	localCert.Status = agglayerCert.Status
+	localCert.ErrorCategory = types.CertificateErrorNone
+	if agglayerCert.Status.IsInError() {
+		localCert.ErrorCategory = types.ClassifyCertificateError(agglayerCert.Error)
+		c.log.Warnf("certificate %s is InError, root cause: %s (retry policy: %s)",
+			localCert.ID(), localCert.ErrorCategory, localCert.ErrorCategory.RetryPolicy())
+	}
 	localCert.UpdatedAt = uint32(time.Now().UTC().Unix())
 	if err := c.storage.UpdateCertificateStatus(
 		ctx,
 		localCert.CertificateID,
 		localCert.Status,
+		localCert.ErrorCategory,
 		localCert.UpdatedAt); err != nil {
 		c.log.Errorf("error updating certificate %s status in storage: %w", agglayerCert.ID(), err)
 		return fmt.Errorf("error updating certificate. Err: %w", err)
 	}
+	c.publishStatusChange(localCert, previousStatus, agglayerCert.Error)
+	if localCert.Status.IsClosed() {
+		c.archiveCertificate(localCert)
+	}
 	return nil
 }
 
+// publishStatusChange publishes the new status of the certificate (if the event bus is enabled):
+// a settled or error event for these statuses and a status_changed event for the rest
+func (c *certStatusChecker) publishStatusChange(header *types.CertificateHeader,
+	previousStatus agglayertypes.CertificateStatus, agglayerErr error) {
+	if c.eventPublisher == nil {
+		return
+	}
+	eventType := types.CertificateEventStatusChanged
+	switch header.Status {
+	case agglayertypes.Settled:
+		eventType = types.CertificateEventSettled
+	case agglayertypes.InError:
+		eventType = types.CertificateEventError
+	}
+	event := types.NewCertificateEvent(eventType, header, time.Now())
+	event.PreviousStatus = previousStatus.String()
+	if agglayerErr != nil {
+		event.Error = agglayerErr.Error()
+	}
+	c.eventPublisher.Publish(event)
+}
+
+// archiveCertificate queues the certificate with its final status to be archived (if enabled).
+// Failing to archive is not critical, so the error is just logged
+func (c *certStatusChecker) archiveCertificate(header *types.CertificateHeader) {
+	if c.archiver == nil {
+		return
+	}
+	cert, err := c.storage.GetCertificateByHeight(header.Height)
+	if err != nil {
+		c.log.Errorf("archiver: error getting certificate %s from storage: %v", header.ID(), err)
+		return
+	}
+	if cert == nil || cert.Header == nil || cert.Header.CertificateID != header.CertificateID {
+		c.log.Warnf("archiver: certificate %s not found in storage, skipping archival", header.ID())
+		return
+	}
+	if err := c.archiver.ArchiveCertificate(cert); err != nil {
+		c.log.Errorf("archiver: error archiving certificate %s: %v", header.ID(), err)
+	}
+}
+
 // checkLastCertificateFromAgglayer checks the last certificate from agglayer
 func (c *certStatusChecker) checkLastCertificateFromAgglayer(ctx context.Context) error {
 	initialStatus, err := newInitialStatusFn(ctx, c.log, c.l2OriginNetwork, c.storage, c.agglayerClient)
@@ -187,6 +260,7 @@ func (c *certStatusChecker) checkLastCertificateFromAgglayer(ctx context.Context
 		return fmt.Errorf("recovery: error retrieving initial status: %w", err)
 	}
 	initialStatus.logData()
+	c.mirrorAgglayerHeaders(ctx, initialStatus.SettledCert, initialStatus.PendingCert)
 	action, err := initialStatus.process()
 	if err != nil {
 		return fmt.Errorf("recovery: error processing initial status: %w", err)
@@ -194,6 +268,21 @@ func (c *certStatusChecker) checkLastCertificateFromAgglayer(ctx context.Context
 	return c.executeInitialStatusAction(ctx, action, initialStatus.LocalCert)
 }
 
+// mirrorAgglayerHeaders saves the latest headers of the agglayer in the mirror of the certificate headers,
+// so the recovered certificates are in it before the first mirroring run. An error is only logged
+func (c *certStatusChecker) mirrorAgglayerHeaders(ctx context.Context, headers ...*agglayertypes.CertificateHeader) {
+	now := uint32(time.Now().UTC().Unix())
+	mirrored := make([]*types.MirroredCertificateHeader, 0, len(headers))
+	for _, header := range headers {
+		if header != nil {
+			mirrored = append(mirrored, types.NewMirroredCertificateHeader(header, now))
+		}
+	}
+	if err := c.storage.SaveMirroredCertificateHeaders(ctx, mirrored); err != nil {
+		c.log.Warnf("recovery: error saving the agglayer certificate headers in the mirror: %v", err)
+	}
+}
+
 func (c *certStatusChecker) executeInitialStatusAction(ctx context.Context,
 	action *initialStatusResult, localCert *types.CertificateHeader) error {
 	c.log.Infof("recovery: action: %s", action.String())
@@ -240,22 +329,19 @@ func newCertificateInfoFromAgglayerCertHeader(c *agglayertypes.CertificateHeader
 			" error creating certificate metadata from hash: %w", err)
 	}
 	var (
-		toBlock   uint64
+		toBlock   = meta.GetToBlock()
 		createdAt uint32
 		certType  types.CertificateType
 	)
 
 	switch meta.Version {
 	case types.CertificateMetadataV0:
-		toBlock = meta.ToBlock
 		createdAt = now
 		certType = types.CertificateTypeUnknown
 	case types.CertificateMetadataV1:
-		toBlock = meta.FromBlock + uint64(meta.Offset)
 		createdAt = meta.CreatedAt
 		certType = types.CertificateTypeUnknown
-	case types.CertificateMetadataV2:
-		toBlock = meta.FromBlock + uint64(meta.Offset)
+	case types.CertificateMetadataV2, types.CertificateMetadataV3:
 		createdAt = meta.CreatedAt
 		certType = types.NewCertificateTypeFromInt(meta.CertType)
 	default:
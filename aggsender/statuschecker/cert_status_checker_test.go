@@ -112,12 +112,12 @@ func TestCheckIfCertificatesAreSettled(t *testing.T) {
 				mockAggLayerClient.EXPECT().GetCertificateHeader(mock.Anything, certID).Return(header, tt.clientError)
 			}
 			if tt.updateDBError != nil {
-				mockStorage.EXPECT().UpdateCertificateStatus(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.updateDBError)
+				mockStorage.EXPECT().UpdateCertificateStatus(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.updateDBError)
 			} else if tt.clientError == nil && tt.getFromDBError == nil {
-				mockStorage.EXPECT().UpdateCertificateStatus(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockStorage.EXPECT().UpdateCertificateStatus(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			}
 
-			certStatusChecker := NewCertStatusChecker(mockLogger, mockStorage, mockAggLayerClient, 1)
+			certStatusChecker := NewCertStatusChecker(mockLogger, mockStorage, mockAggLayerClient, 1, nil, nil, nil)
 
 			ctx := context.TODO()
 			checkResult := certStatusChecker.CheckPendingCertificatesStatus(ctx)
@@ -336,7 +336,7 @@ func TestExecuteInitialStatusAction(t *testing.T) {
 			},
 			localCert: &types.CertificateHeader{CertificateID: common.HexToHash("0x1")},
 			mockFn: func(m *mocks.AggSenderStorage) {
-				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.InError, mock.Anything).Return(fmt.Errorf("update error"))
+				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.InError, mock.Anything, mock.Anything).Return(fmt.Errorf("update error"))
 			},
 			expectedError: "recovery: error updating local storage with agglayer certificate",
 		},
@@ -427,7 +427,11 @@ func TestCheckLastCertificateFromAgglayer(t *testing.T) {
 			localCert:    &types.CertificateHeader{CertificateID: common.HexToHash("0x1")},
 			agglayerCert: &agglayertypes.CertificateHeader{CertificateID: common.HexToHash("0x1"), Status: agglayertypes.Settled},
 			mockFn: func(m *mocks.AggSenderStorage) {
-				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.Settled, mock.Anything).Return(nil)
+				m.EXPECT().SaveMirroredCertificateHeaders(ctx, mock.MatchedBy(func(headers []*types.MirroredCertificateHeader) bool {
+					return len(headers) == 1 && headers[0].CertificateID == common.HexToHash("0x1") &&
+						headers[0].Status == agglayertypes.Settled
+				})).Return(nil)
+				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.Settled, mock.Anything, mock.Anything).Return(nil)
 			},
 		},
 		{
@@ -439,7 +443,9 @@ func TestCheckLastCertificateFromAgglayer(t *testing.T) {
 			localCert:    &types.CertificateHeader{CertificateID: common.HexToHash("0x1")},
 			agglayerCert: &agglayertypes.CertificateHeader{CertificateID: common.HexToHash("0x1"), Status: agglayertypes.InError},
 			mockFn: func(m *mocks.AggSenderStorage) {
-				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.InError, mock.Anything).Return(fmt.Errorf("update error"))
+				// an error saving the mirrored headers is only logged
+				m.EXPECT().SaveMirroredCertificateHeaders(ctx, mock.Anything).Return(fmt.Errorf("mirror error"))
+				m.EXPECT().UpdateCertificateStatus(ctx, common.HexToHash("0x1"), agglayertypes.InError, mock.Anything, mock.Anything).Return(fmt.Errorf("update error"))
 			},
 			expectedError: "recovery: error updating local storage with agglayer certificate",
 		},
@@ -464,7 +470,7 @@ func TestCheckLastCertificateFromAgglayer(t *testing.T) {
 			newInitialStatusFn = func(_ context.Context,
 				_ types.Logger, _ uint32,
 				_ db.AggSenderStorage,
-				_ agglayer.AggLayerClientRecoveryQuerier) (*initialStatus, error) {
+				_ agglayer.AggLayerClientLatestCertificateQuerier) (*initialStatus, error) {
 				return mockInitialStatus, tt.newInitialErr
 			}
 
@@ -487,3 +493,100 @@ func TestCheckLastCertificateFromAgglayer(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateCertificateStatusArchivesClosedCertificates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	certID := common.HexToHash("0x1")
+	mockStorage := mocks.NewAggSenderStorage(t)
+	mockArchiver := mocks.NewCertificateArchiver(t)
+	sut := &certStatusChecker{
+		log:      log.WithFields("test", "unittest"),
+		storage:  mockStorage,
+		archiver: mockArchiver,
+	}
+
+	storedCert := &types.Certificate{Header: &types.CertificateHeader{Height: 5, CertificateID: certID}}
+	mockStorage.EXPECT().UpdateCertificateStatus(ctx, certID, agglayertypes.Proven, types.CertificateErrorNone, mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().UpdateCertificateStatus(ctx, certID, agglayertypes.Settled, types.CertificateErrorNone, mock.Anything).Return(nil).Once()
+	mockStorage.EXPECT().GetCertificateByHeight(uint64(5)).Return(storedCert, nil).Once()
+	mockArchiver.EXPECT().ArchiveCertificate(storedCert).Return(fmt.Errorf("archiver error")).Once()
+
+	localCert := &types.CertificateHeader{Height: 5, CertificateID: certID, Status: agglayertypes.Pending}
+	// not closed yet, so it's not archived
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.Proven}))
+	// an archiver error doesn't fail the status update
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.Settled}))
+}
+
+func TestUpdateCertificateStatusStoresErrorCategory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	certID := common.HexToHash("0x1")
+	mockStorage := mocks.NewAggSenderStorage(t)
+	sut := &certStatusChecker{
+		log:     log.WithFields("test", "unittest"),
+		storage: mockStorage,
+	}
+
+	mockStorage.EXPECT().UpdateCertificateStatus(ctx, certID, agglayertypes.InError,
+		types.CertificateErrorLERMismatch, mock.Anything).Return(nil).Once()
+
+	localCert := &types.CertificateHeader{Height: 5, CertificateID: certID, Status: agglayertypes.Pending}
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert, &agglayertypes.CertificateHeader{
+		CertificateID: certID,
+		Status:        agglayertypes.InError,
+		Error: &agglayertypes.GenericError{
+			Key:   "ProofGenerationError",
+			Value: `{"generation_type":"Native","source":{"InvalidPreviousLocalExitRoot":{}}}`,
+		},
+	}))
+	require.Equal(t, types.CertificateErrorLERMismatch, localCert.ErrorCategory)
+}
+
+func TestUpdateCertificateStatusPublishesEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.TODO()
+	certID := common.HexToHash("0x1")
+	mockStorage := mocks.NewAggSenderStorage(t)
+	mockPublisher := mocks.NewCertificateEventPublisher(t)
+	sut := &certStatusChecker{
+		log:            log.WithFields("test", "unittest"),
+		storage:        mockStorage,
+		eventPublisher: mockPublisher,
+	}
+
+	mockStorage.EXPECT().UpdateCertificateStatus(ctx, certID, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	var events []types.CertificateEvent
+	mockPublisher.EXPECT().Publish(mock.Anything).Run(func(event types.CertificateEvent) {
+		events = append(events, event)
+	})
+
+	localCert := &types.CertificateHeader{Height: 5, CertificateID: certID, Status: agglayertypes.Pending}
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.Proven}))
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.InError,
+			Error: &agglayertypes.GenericError{Key: "SettlementError", Value: "timeout"}}))
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.Settled}))
+	// no status change, no event
+	require.NoError(t, sut.updateCertificateStatus(ctx, localCert,
+		&agglayertypes.CertificateHeader{CertificateID: certID, Status: agglayertypes.Settled}))
+
+	require.Len(t, events, 3)
+	require.Equal(t, types.CertificateEventStatusChanged, events[0].Type)
+	require.Equal(t, agglayertypes.Pending.String(), events[0].PreviousStatus)
+	require.Equal(t, agglayertypes.Proven.String(), events[0].Status)
+	require.Equal(t, types.CertificateEventError, events[1].Type)
+	require.Equal(t, agglayertypes.Proven.String(), events[1].PreviousStatus)
+	require.NotEmpty(t, events[1].Error)
+	require.Equal(t, types.CertificateEventSettled, events[2].Type)
+	require.Equal(t, certID.Hex(), events[2].CertificateID)
+	require.Empty(t, events[2].Error)
+}
@@ -60,17 +60,17 @@ func (i *initialStatusResult) String() string {
 func newInitialStatus(ctx context.Context,
 	log types.Logger, networkID uint32,
 	storage db.AggSenderStorage,
-	aggLayerClient agglayer.AggLayerClientRecoveryQuerier) (*initialStatus, error) {
+	aggLayerClient agglayer.AggLayerClientLatestCertificateQuerier) (*initialStatus, error) {
 	log.Infof("recovery: checking last settled certificate from AggLayer for network %d", networkID)
-	aggLayerLastSettledCert, err := aggLayerClient.GetLatestSettledCertificateHeader(ctx, networkID)
+	aggLayerLastSettledCert, err := aggLayerClient.GetLatestSettledCertificate(ctx, networkID)
 	if err != nil {
-		return nil, fmt.Errorf("recovery: error getting GetLatestSettledCertificateHeader from agglayer: %w", err)
+		return nil, fmt.Errorf("recovery: error getting GetLatestSettledCertificate from agglayer: %w", err)
 	}
 
 	log.Infof("recovery: checking last pending certificate from AggLayer for network %d", networkID)
-	aggLayerLastPendingCert, err := aggLayerClient.GetLatestPendingCertificateHeader(ctx, networkID)
+	aggLayerLastPendingCert, err := aggLayerClient.GetLatestKnownCertificate(ctx, networkID)
 	if err != nil {
-		return nil, fmt.Errorf("recovery: error getting GetLatestPendingCertificateHeader from agglayer: %w", err)
+		return nil, fmt.Errorf("recovery: error getting GetLatestKnownCertificate from agglayer: %w", err)
 	}
 
 	localLastCert, err := storage.GetLastSentCertificateHeader()
@@ -0,0 +1,101 @@
+package statuschecker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/db"
+	aggkitdb "github.com/agglayer/aggkit/db"
+)
+
+// reconcileCertificateJournal resolves the journal entries that are still in pre-commit state.
+// A pre-commit entry means that the aggsender stopped while submitting a certificate, so
+// we ask agglayer if it received it:
+//   - if agglayer has it, the certificate is stored in the local storage and the entry is acknowledged
+//   - if not, the certificate was never sent and the entry is discarded
+func (c *certStatusChecker) reconcileCertificateJournal(ctx context.Context) error {
+	entries, err := c.storage.GetPendingCertificateJournalEntries()
+	if err != nil {
+		return fmt.Errorf("journal: error getting pending journal entries: %w", err)
+	}
+	for _, entry := range entries {
+		if err := c.reconcileCertificateJournalEntry(ctx, entry); err != nil {
+			return fmt.Errorf("journal: error reconciling entry %s: %w", entry.ID(), err)
+		}
+	}
+	return nil
+}
+
+func (c *certStatusChecker) reconcileCertificateJournalEntry(ctx context.Context,
+	entry *db.CertificateJournalEntry) error {
+	cert, err := entry.ToCertificate()
+	if err != nil {
+		return err
+	}
+
+	// The certificate was stored after the agglayer acknowledgement, but the journal was not updated
+	localCert, err := c.storage.GetCertificateHeaderByHeight(entry.Height)
+	if err != nil && !errors.Is(err, aggkitdb.ErrNotFound) {
+		return fmt.Errorf("error getting local certificate at height %d: %w", entry.Height, err)
+	}
+	if localCert != nil && localCert.RetryCount == entry.RetryCount &&
+		localCert.NewLocalExitRoot == entry.NewLocalExitRoot {
+		c.log.Infof("journal: entry %s is already in local storage as %s", entry.ID(), localCert.ID())
+		return c.storage.UpdateCertificateJournalEntryState(ctx, entry.Height,
+			db.CertificateJournalStateAcknowledged, &localCert.CertificateID)
+	}
+
+	aggLayerCert, err := c.getAggLayerCertificateForJournalEntry(ctx, entry)
+	if err != nil {
+		return err
+	}
+	if aggLayerCert == nil {
+		c.log.Warnf("journal: entry %s was not received by agglayer, discarding it", entry.ID())
+		return c.storage.UpdateCertificateJournalEntryState(ctx, entry.Height,
+			db.CertificateJournalStateDiscarded, nil)
+	}
+
+	cert.Header.CertificateID = aggLayerCert.CertificateID
+	cert.Header.Status = aggLayerCert.Status
+	cert.Header.UpdatedAt = uint32(time.Now().UTC().Unix())
+	c.log.Infof("journal: entry %s was received by agglayer (%s), storing it", entry.ID(), aggLayerCert.ID())
+	if err := c.storage.SaveCertificateBatch(ctx, db.CertificateWriteBatch{
+		Certificate:  *cert,
+		JournalState: db.CertificateJournalStateAcknowledged,
+	}); err != nil {
+		return fmt.Errorf("error storing certificate %s: %w", cert.Header.ID(), err)
+	}
+	return nil
+}
+
+// getAggLayerCertificateForJournalEntry returns the agglayer certificate that matches the journal entry
+// (same height, local exit root and metadata) or nil if agglayer doesn't have it
+func (c *certStatusChecker) getAggLayerCertificateForJournalEntry(ctx context.Context,
+	entry *db.CertificateJournalEntry) (*agglayertypes.CertificateHeader, error) {
+	pendingCert, err := c.agglayerClient.GetLatestKnownCertificate(ctx, c.l2OriginNetwork)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest pending certificate from agglayer: %w", err)
+	}
+	if journalEntryMatchesAggLayerCert(entry, pendingCert) {
+		return pendingCert, nil
+	}
+	settledCert, err := c.agglayerClient.GetLatestSettledCertificate(ctx, c.l2OriginNetwork)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest settled certificate from agglayer: %w", err)
+	}
+	if journalEntryMatchesAggLayerCert(entry, settledCert) {
+		return settledCert, nil
+	}
+	return nil, nil
+}
+
+func journalEntryMatchesAggLayerCert(entry *db.CertificateJournalEntry,
+	aggLayerCert *agglayertypes.CertificateHeader) bool {
+	return aggLayerCert != nil &&
+		aggLayerCert.Height == entry.Height &&
+		aggLayerCert.NewLocalExitRoot == entry.NewLocalExitRoot &&
+		aggLayerCert.Metadata == entry.Metadata
+}
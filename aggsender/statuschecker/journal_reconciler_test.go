@@ -0,0 +1,168 @@
+package statuschecker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agglayer/aggkit/agglayer"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/db"
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	aggkitdb "github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileCertificateJournal(t *testing.T) {
+	t.Parallel()
+
+	const networkID = uint32(1)
+	ler := common.HexToHash("0x2")
+	metadata := common.HexToHash("0x3")
+	certID := common.HexToHash("0x4")
+
+	newEntry := func(t *testing.T) *db.CertificateJournalEntry {
+		t.Helper()
+		entry, err := db.NewCertificateJournalEntry(types.Certificate{
+			Header: &types.CertificateHeader{
+				Height:           5,
+				RetryCount:       1,
+				NewLocalExitRoot: ler,
+				FromBlock:        100,
+				ToBlock:          200,
+			},
+		}, metadata, 10)
+		require.NoError(t, err)
+		return entry
+	}
+	matchingAggLayerCert := &agglayertypes.CertificateHeader{
+		Height:           5,
+		CertificateID:    certID,
+		NewLocalExitRoot: ler,
+		Metadata:         metadata,
+		Status:           agglayertypes.Pending,
+	}
+
+	tests := []struct {
+		name          string
+		mockFn        func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock)
+		expectedError string
+	}{
+		{
+			name: "no pending entries",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "error getting pending entries",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(nil, errors.New("db error")).Once()
+			},
+			expectedError: "db error",
+		},
+		{
+			name: "certificate already in local storage",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(
+					[]*db.CertificateJournalEntry{newEntry(t)}, nil).Once()
+				mockStorage.EXPECT().GetCertificateHeaderByHeight(uint64(5)).Return(&types.CertificateHeader{
+					Height:           5,
+					RetryCount:       1,
+					CertificateID:    certID,
+					NewLocalExitRoot: ler,
+				}, nil).Once()
+				mockStorage.EXPECT().UpdateCertificateJournalEntryState(mock.Anything, uint64(5),
+					db.CertificateJournalStateAcknowledged, &certID).Return(nil).Once()
+			},
+		},
+		{
+			name: "certificate received by agglayer (pending)",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(
+					[]*db.CertificateJournalEntry{newEntry(t)}, nil).Once()
+				mockStorage.EXPECT().GetCertificateHeaderByHeight(uint64(5)).Return(nil, aggkitdb.ErrNotFound).Once()
+				mockAggLayerClient.EXPECT().GetLatestKnownCertificate(mock.Anything, networkID).
+					Return(matchingAggLayerCert, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.MatchedBy(func(batch db.CertificateWriteBatch) bool {
+					cert := batch.Certificate
+					return cert.Header.CertificateID == certID && cert.Header.Status == agglayertypes.Pending &&
+						cert.Header.FromBlock == 100 && cert.Header.ToBlock == 200 &&
+						batch.JournalState == db.CertificateJournalStateAcknowledged
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "certificate received by agglayer (settled)",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(
+					[]*db.CertificateJournalEntry{newEntry(t)}, nil).Once()
+				mockStorage.EXPECT().GetCertificateHeaderByHeight(uint64(5)).Return(nil, aggkitdb.ErrNotFound).Once()
+				mockAggLayerClient.EXPECT().GetLatestKnownCertificate(mock.Anything, networkID).
+					Return(nil, nil).Once()
+				mockAggLayerClient.EXPECT().GetLatestSettledCertificate(mock.Anything, networkID).
+					Return(matchingAggLayerCert, nil).Once()
+				mockStorage.EXPECT().SaveCertificateBatch(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name: "certificate not received by agglayer",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(
+					[]*db.CertificateJournalEntry{newEntry(t)}, nil).Once()
+				mockStorage.EXPECT().GetCertificateHeaderByHeight(uint64(5)).Return(nil, aggkitdb.ErrNotFound).Once()
+				// a previous retry of the same height is on agglayer
+				mockAggLayerClient.EXPECT().GetLatestKnownCertificate(mock.Anything, networkID).
+					Return(&agglayertypes.CertificateHeader{
+						Height:           5,
+						NewLocalExitRoot: ler,
+						Metadata:         common.HexToHash("0x99"),
+						Status:           agglayertypes.InError,
+					}, nil).Once()
+				mockAggLayerClient.EXPECT().GetLatestSettledCertificate(mock.Anything, networkID).
+					Return(&agglayertypes.CertificateHeader{Height: 4}, nil).Once()
+				mockStorage.EXPECT().UpdateCertificateJournalEntryState(mock.Anything, uint64(5),
+					db.CertificateJournalStateDiscarded, (*common.Hash)(nil)).Return(nil).Once()
+			},
+		},
+		{
+			name: "error querying agglayer",
+			mockFn: func(mockStorage *mocks.AggSenderStorage, mockAggLayerClient *agglayer.AgglayerClientMock) {
+				mockStorage.EXPECT().GetPendingCertificateJournalEntries().Return(
+					[]*db.CertificateJournalEntry{newEntry(t)}, nil).Once()
+				mockStorage.EXPECT().GetCertificateHeaderByHeight(uint64(5)).Return(nil, aggkitdb.ErrNotFound).Once()
+				mockAggLayerClient.EXPECT().GetLatestKnownCertificate(mock.Anything, networkID).
+					Return(nil, errors.New("agglayer error")).Once()
+			},
+			expectedError: "agglayer error",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockStorage := mocks.NewAggSenderStorage(t)
+			mockAggLayerClient := agglayer.NewAgglayerClientMock(t)
+			tt.mockFn(mockStorage, mockAggLayerClient)
+
+			sut := &certStatusChecker{
+				log:             log.WithFields("test", "unittest"),
+				storage:         mockStorage,
+				agglayerClient:  mockAggLayerClient,
+				l2OriginNetwork: networkID,
+			}
+			err := sut.reconcileCertificateJournal(context.TODO())
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
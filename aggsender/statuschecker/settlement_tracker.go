@@ -0,0 +1,78 @@
+package statuschecker
+
+import (
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/metrics"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+)
+
+// settlementTracker records the settlement latency of the certificates sent by this aggsender (the time and
+// the epochs elapsed from the start of their build) and counts the certificates settled per epoch.
+// The counts are not persisted, so they start from 0 after a restart. Without epoch notifier only the
+// time is recorded. A nil tracker doesn't record anything
+type settlementTracker struct {
+	log           *log.Logger
+	epochNotifier types.EpochNotifier
+
+	epoch    uint64
+	tracking bool
+	// settledInEpoch and settledInPreviousEpoch are the certificates settled in the epoch and the previous one
+	settledInEpoch         int
+	settledInPreviousEpoch int
+}
+
+func newSettlementTracker(logger *log.Logger, epochNotifier types.EpochNotifier) *settlementTracker {
+	return &settlementTracker{log: logger, epochNotifier: epochNotifier}
+}
+
+// refresh starts the count of the current epoch if it changed, so the epochs without settlements are
+// counted as 0
+func (t *settlementTracker) refresh() {
+	if t == nil || t.epochNotifier == nil {
+		return
+	}
+	t.setEpoch(t.epochNotifier.GetEpochStatus().Epoch)
+	metrics.CertificatesSettledPerEpoch(t.settledInEpoch, t.settledInPreviousEpoch)
+}
+
+// setEpoch moves the counts to the given epoch, if it's a later one
+func (t *settlementTracker) setEpoch(epoch uint64) {
+	switch {
+	case !t.tracking:
+		t.epoch, t.tracking = epoch, true
+	case epoch <= t.epoch:
+		return
+	case epoch == t.epoch+1:
+		t.settledInPreviousEpoch = t.settledInEpoch
+	default:
+		t.settledInPreviousEpoch = 0
+	}
+	t.epoch = epoch
+	t.settledInEpoch = 0
+}
+
+// certificateSettled records the settlement of the certificate, observed at the given time
+func (t *settlementTracker) certificateSettled(header *types.CertificateHeader, now time.Time) {
+	if t == nil || header.CertSource != types.CertificateSourceLocal || header.CreatedAt == 0 {
+		// the certificates received from the agglayer were not built by this aggsender
+		return
+	}
+	elapsed := now.Sub(time.Unix(int64(header.CreatedAt), 0))
+	metrics.CertificateSettled(elapsed)
+	if t.epochNotifier == nil {
+		return
+	}
+
+	t.setEpoch(t.epochNotifier.GetEpochStatus().Epoch)
+	t.settledInEpoch++
+	metrics.CertificatesSettledPerEpoch(t.settledInEpoch, t.settledInPreviousEpoch)
+	if header.BuildEpoch == nil || *header.BuildEpoch > t.epoch {
+		return
+	}
+	epochs := t.epoch - *header.BuildEpoch
+	metrics.CertificateSettledEpochs(epochs)
+	t.log.Infof("certificate %s settled in epoch %d, %s and %d epochs after the start of its build",
+		header.ID(), t.epoch, elapsed.Round(time.Second), epochs)
+}
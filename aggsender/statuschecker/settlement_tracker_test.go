@@ -0,0 +1,66 @@
+package statuschecker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/mocks"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettlementTracker(t *testing.T) {
+	now := time.Unix(10_000, 0)
+	buildEpoch := uint64(3)
+	header := &types.CertificateHeader{
+		Height: 1, CertSource: types.CertificateSourceLocal, CreatedAt: 9_000, BuildEpoch: &buildEpoch,
+	}
+
+	t.Run("counts the certificates settled per epoch", func(t *testing.T) {
+		epochNotifier := mocks.NewEpochNotifier(t)
+		tracker := newSettlementTracker(log.WithFields("test", "settlement"), epochNotifier)
+
+		epochNotifier.EXPECT().GetEpochStatus().Return(types.EpochStatus{Epoch: 4}).Times(3)
+		tracker.refresh()
+		tracker.certificateSettled(header, now)
+		tracker.certificateSettled(header, now)
+		require.Equal(t, uint64(4), tracker.epoch)
+		require.Equal(t, 2, tracker.settledInEpoch)
+		require.Equal(t, 0, tracker.settledInPreviousEpoch)
+
+		epochNotifier.EXPECT().GetEpochStatus().Return(types.EpochStatus{Epoch: 5}).Twice()
+		tracker.certificateSettled(header, now)
+		require.Equal(t, 1, tracker.settledInEpoch)
+		require.Equal(t, 2, tracker.settledInPreviousEpoch)
+		tracker.refresh()
+		require.Equal(t, 1, tracker.settledInEpoch)
+
+		// an epoch without settlements
+		epochNotifier.EXPECT().GetEpochStatus().Return(types.EpochStatus{Epoch: 7}).Once()
+		tracker.refresh()
+		require.Equal(t, uint64(7), tracker.epoch)
+		require.Equal(t, 0, tracker.settledInEpoch)
+		require.Equal(t, 0, tracker.settledInPreviousEpoch)
+	})
+
+	t.Run("the certificates not built by this aggsender are not recorded", func(t *testing.T) {
+		epochNotifier := mocks.NewEpochNotifier(t)
+		tracker := newSettlementTracker(log.WithFields("test", "settlement"), epochNotifier)
+		tracker.certificateSettled(&types.CertificateHeader{CertSource: types.CertificateSourceAggLayer,
+			CreatedAt: 9_000}, now)
+		tracker.certificateSettled(&types.CertificateHeader{CertSource: types.CertificateSourceLocal}, now)
+		require.Equal(t, 0, tracker.settledInEpoch)
+	})
+
+	t.Run("without epoch notifier", func(t *testing.T) {
+		tracker := newSettlementTracker(log.WithFields("test", "settlement"), nil)
+		tracker.refresh()
+		tracker.certificateSettled(header, now)
+		require.False(t, tracker.tracking)
+
+		var nilTracker *settlementTracker
+		nilTracker.refresh()
+		nilTracker.certificateSettled(header, now)
+	})
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// ApprovalHookFailurePolicy is what the aggsender does with a certificate when the approval hook fails
+// (it can't be reached, it times out or its response is invalid)
+type ApprovalHookFailurePolicy string
+
+const (
+	// ApprovalHookFailClosed doesn't send the certificate, it's submitted to the hook again on the next
+	// attempt (default)
+	ApprovalHookFailClosed ApprovalHookFailurePolicy = "FailClosed"
+	// ApprovalHookFailOpen sends the certificate as if it was approved
+	ApprovalHookFailOpen ApprovalHookFailurePolicy = "FailOpen"
+)
+
+// ApprovalHookConfig submits the summary of each certificate to an external approval hook (HTTP callback)
+// before it's signed and sent, so a human or a policy engine can approve the high-value certificates.
+// A rejected certificate is not sent, and its bridges are included in the next one (if it's approved)
+type ApprovalHookConfig struct {
+	// Enabled enables the approval of the certificates
+	Enabled bool `mapstructure:"Enabled"`
+	// URL is the URL of the hook, which receives a POST with the JSON summary of the certificate and
+	// answers with {"approved": bool, "reason": string}
+	URL string `mapstructure:"URL"`
+	// Timeout is the maximum time waiting for the answer of the hook
+	Timeout types.Duration `mapstructure:"Timeout"`
+	// FailurePolicy is FailClosed (the certificate is not sent) or FailOpen (it's sent) when the hook fails.
+	// Empty means FailClosed
+	FailurePolicy ApprovalHookFailurePolicy `jsonschema:"enum=FailClosed, enum=FailOpen" mapstructure:"FailurePolicy"` //nolint:lll
+}
+
+// Validate checks that the URL is set and the failure policy is known if the hook is enabled
+func (c ApprovalHookConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.URL == "" {
+		return errors.New("ApprovalHook.URL is required")
+	}
+	switch c.FailurePolicy {
+	case "", ApprovalHookFailClosed, ApprovalHookFailOpen:
+		return nil
+	default:
+		return fmt.Errorf("invalid ApprovalHook.FailurePolicy %q, valid values are: %s, %s",
+			c.FailurePolicy, ApprovalHookFailClosed, ApprovalHookFailOpen)
+	}
+}
+
+// FailOpen returns true if the certificates are sent when the hook fails
+func (c ApprovalHookConfig) FailOpen() bool {
+	return c.FailurePolicy == ApprovalHookFailOpen
+}
+
+// String returns a string representation of the config
+func (c ApprovalHookConfig) String() string {
+	if !c.Enabled {
+		return "ApprovalHook{disabled}"
+	}
+	return fmt.Sprintf("ApprovalHook{timeout:%s, failOpen:%t}", c.Timeout, c.FailOpen())
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CertificateAnalytics are the aggregated figures of the bridges and claims of a certificate, stored
+// for the chain economics dashboards so the certificates don't have to be parsed again
+type CertificateAnalytics struct {
+	Height        uint64      `meddler:"height" json:"height"`
+	RetryCount    int         `meddler:"retry_count" json:"retry_count"`
+	CertificateID common.Hash `meddler:"certificate_id,hash" json:"certificate_id"`
+	// ClaimsPerOriginNetwork is the number of claims (imported bridge exits) by origin network
+	ClaimsPerOriginNetwork map[uint32]int `meddler:"claims_per_origin_network,json" json:"claims_per_origin_network"`
+	// BridgedValuePerToken is the total amount bridged (asset bridge exits) by token
+	BridgedValuePerToken map[TokenKey]*big.Int `meddler:"bridged_value_per_token,json" json:"bridged_value_per_token"`
+	// AssetExits and MessageExits are the number of bridge exits of each leaf type
+	AssetExits   int    `meddler:"asset_exits" json:"asset_exits"`
+	MessageExits int    `meddler:"message_exits" json:"message_exits"`
+	CreatedAt    uint32 `meddler:"created_at" json:"created_at"`
+}
+
+// TokenKey identifies a token by its origin network and address, e.g. "0:0x000...000".
+// It's a string so it can be used as a JSON key
+type TokenKey string
+
+// NewTokenKey returns the key of the token with the given origin network and address
+func NewTokenKey(originNetwork uint32, originAddress common.Address) TokenKey {
+	return TokenKey(fmt.Sprintf("%d:%s", originNetwork, originAddress.Hex()))
+}
+
+// Analytics returns the aggregated figures of the bridges and claims of the certificate
+func (c *CertificateBuildParams) Analytics() *CertificateAnalytics {
+	analytics := &CertificateAnalytics{
+		ClaimsPerOriginNetwork: make(map[uint32]int),
+		BridgedValuePerToken:   make(map[TokenKey]*big.Int),
+		CreatedAt:              c.CreatedAt,
+	}
+	for _, claim := range c.Claims {
+		analytics.ClaimsPerOriginNetwork[claim.OriginNetwork]++
+	}
+	for _, bridge := range c.Bridges {
+		if bridge.LeafType == agglayertypes.LeafTypeMessage.Uint8() {
+			analytics.MessageExits++
+			continue
+		}
+		analytics.AssetExits++
+		if bridge.Amount == nil {
+			continue
+		}
+		key := NewTokenKey(bridge.OriginNetwork, bridge.OriginAddress)
+		total, ok := analytics.BridgedValuePerToken[key]
+		if !ok {
+			total = new(big.Int)
+			analytics.BridgedValuePerToken[key] = total
+		}
+		total.Add(total, bridge.Amount)
+	}
+	return analytics
+}
+
+func (a *CertificateAnalytics) String() string {
+	if a == nil {
+		return NilStr
+	}
+	return fmt.Sprintf("analytics{height:%d, retry:%d, claimOriginNetworks:%d, tokens:%d, assetExits:%d, messageExits:%d}",
+		a.Height, a.RetryCount, len(a.ClaimsPerOriginNetwork), len(a.BridgedValuePerToken),
+		a.AssetExits, a.MessageExits)
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CertificateAuditEventType is the kind of decision recorded in the audit trail of a certificate
+type CertificateAuditEventType string
+
+const (
+	// CertificateAuditRangeSelected is the block range chosen for the certificate and why
+	CertificateAuditRangeSelected CertificateAuditEventType = "range_selected"
+	// CertificateAuditRangeTruncated is a reduction of the block range (max size, max L2 block, hard fork...)
+	CertificateAuditRangeTruncated CertificateAuditEventType = "range_truncated"
+	// CertificateAuditProverAttempt is a request of the aggchain proof, with its duration and result
+	CertificateAuditProverAttempt CertificateAuditEventType = "prover_attempt"
+	// CertificateAuditProofReused is the reuse of the aggchain proof of the InError certificate resent
+	CertificateAuditProofReused CertificateAuditEventType = "proof_reused"
+	// CertificateAuditSigned is the certificate built and signed
+	CertificateAuditSigned CertificateAuditEventType = "signed"
+	// CertificateAuditNotSent is a certificate not sent (rejected by the approval hook, fee budget...)
+	CertificateAuditNotSent CertificateAuditEventType = "not_sent"
+	// CertificateAuditSubmissionAttempt is a submission of the certificate to the agglayer, with its result
+	CertificateAuditSubmissionAttempt CertificateAuditEventType = "submission_attempt"
+	// CertificateAuditStatusChanged is a change of the status of the certificate in the agglayer
+	CertificateAuditStatusChanged CertificateAuditEventType = "status_changed"
+)
+
+// CertificateAuditReason are the reasons of the decisions recorded in the audit trail
+const (
+	CertificateAuditReasonNewBlocks        = "new_blocks"
+	CertificateAuditReasonRetryInError     = "retry_in_error"
+	CertificateAuditReasonMaxCertSize      = "max_cert_size"
+	CertificateAuditReasonMaxL2BlockNumber = "max_l2_block_number"
+	CertificateAuditReasonHardFork         = "hard_fork"
+	CertificateAuditReasonDataAvailability = "data_availability"
+	CertificateAuditReasonAggchainProver   = "aggchain_prover"
+)
+
+// CertificateAuditEvent is an entry of the append-only audit trail of a certificate: a decision taken
+// while building, sending or tracking it. The events of a height are ordered by ID
+type CertificateAuditEvent struct {
+	ID         uint64 `meddler:"id,pk" json:"id"`
+	Height     uint64 `meddler:"height" json:"height"`
+	RetryCount int    `meddler:"retry_count" json:"retry_count"`
+	// CertificateID is nil until the certificate is accepted by the agglayer
+	CertificateID *common.Hash              `meddler:"certificate_id,hash" json:"certificate_id,omitempty"`
+	Event         CertificateAuditEventType `meddler:"event" json:"event"`
+	// Details are the specific fields of the event (e.g. the blocks and the reason of a truncation)
+	Details   map[string]any `meddler:"details,json" json:"details"`
+	CreatedAt uint32         `meddler:"created_at" json:"created_at"`
+}
+
+// NewCertificateAuditEvent creates an audit event of the certificate with the given height and retry count
+func NewCertificateAuditEvent(height uint64, retryCount int, certificateID *common.Hash,
+	event CertificateAuditEventType, details map[string]any) *CertificateAuditEvent {
+	return &CertificateAuditEvent{
+		Height:        height,
+		RetryCount:    retryCount,
+		CertificateID: certificateID,
+		Event:         event,
+		Details:       details,
+		CreatedAt:     uint32(time.Now().UTC().Unix()),
+	}
+}
+
+// String returns a string representation of the audit event
+func (c *CertificateAuditEvent) String() string {
+	if c == nil {
+		return NilStr
+	}
+	return fmt.Sprintf("CertificateAuditEvent{height:%d, retry:%d, event:%s, details:%v}",
+		c.Height, c.RetryCount, c.Event, c.Details)
+}
+
+// AddAuditEvent appends a decision to the audit trail of the certificate. The height is set when the
+// trail is stored, once the certificate is built
+func (c *CertificateBuildParams) AddAuditEvent(event CertificateAuditEventType, details map[string]any) {
+	if c == nil {
+		return
+	}
+	c.AuditTrail = append(c.AuditTrail, NewCertificateAuditEvent(0, c.RetryCount, nil, event, details))
+}
+
+// AddRangeTruncatedAuditEvent appends a truncation of the range to the audit trail if the last block of the
+// certificate is lower than previousToBlock
+func (c *CertificateBuildParams) AddRangeTruncatedAuditEvent(previousToBlock uint64, reason string) {
+	if c == nil || c.ToBlock >= previousToBlock {
+		return
+	}
+	c.AddAuditEvent(CertificateAuditRangeTruncated, map[string]any{
+		"from_block":        c.FromBlock,
+		"previous_to_block": previousToBlock,
+		"to_block":          c.ToBlock,
+		"reason":            reason,
+	})
+}
@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
 	"github.com/agglayer/aggkit/bridgesync"
@@ -25,6 +27,13 @@ type CertificateBuildParams struct {
 	AggchainProof                  *AggchainProof
 	CertificateType                CertificateType
 	ExtraData                      string
+	// ForkName is the name of the L2 hard fork of the blocks of the certificate (empty if there are no forks)
+	ForkName string
+	// ProvingTime is the time taken to generate the AggchainProof, including the queries of its inputs
+	// (0 if there is no proof)
+	ProvingTime time.Duration
+	// AuditTrail are the decisions taken while building the certificate, stored once it's built
+	AuditTrail []*CertificateAuditEvent
 }
 
 func (c *CertificateBuildParams) String() string {
@@ -64,6 +73,9 @@ func (c *CertificateBuildParams) Range(fromBlock, toBlock uint64) (*CertificateB
 		L1InfoTreeRootFromWhichToProve: c.L1InfoTreeRootFromWhichToProve,
 		L1InfoTreeLeafCount:            c.L1InfoTreeLeafCount,
 		CertificateType:                c.CertificateType,
+		ForkName:                       c.ForkName,
+		ProvingTime:                    c.ProvingTime,
+		AuditTrail:                     slices.Clone(c.AuditTrail),
 	}
 
 	for _, bridge := range c.Bridges {
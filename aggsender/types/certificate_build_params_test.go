@@ -1,8 +1,12 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,3 +27,33 @@ func TestInvalidRangeOutsideOriginalRange(t *testing.T) {
 	_, err := params.Range(99, 110)
 	require.Error(t, err, "should return an error for invalid range")
 }
+
+func TestCertificateBuildParamsAnalytics(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	params := &CertificateBuildParams{
+		Bridges: []bridgesync.Bridge{
+			{LeafType: agglayertypes.LeafTypeAsset.Uint8(), OriginNetwork: 0, OriginAddress: token, Amount: big.NewInt(10)},
+			{LeafType: agglayertypes.LeafTypeAsset.Uint8(), OriginNetwork: 0, OriginAddress: token, Amount: big.NewInt(5)},
+			{LeafType: agglayertypes.LeafTypeAsset.Uint8(), OriginNetwork: 2, OriginAddress: token, Amount: big.NewInt(1)},
+			{LeafType: agglayertypes.LeafTypeMessage.Uint8(), OriginNetwork: 0, Amount: big.NewInt(100)},
+		},
+		Claims: []bridgesync.Claim{
+			{OriginNetwork: 0}, {OriginNetwork: 0}, {OriginNetwork: 3},
+		},
+		CreatedAt: 1000,
+	}
+
+	analytics := params.Analytics()
+	require.Equal(t, map[uint32]int{0: 2, 3: 1}, analytics.ClaimsPerOriginNetwork)
+	require.Equal(t, map[TokenKey]*big.Int{
+		NewTokenKey(0, token): big.NewInt(15),
+		NewTokenKey(2, token): big.NewInt(1),
+	}, analytics.BridgedValuePerToken)
+	require.Equal(t, 3, analytics.AssetExits)
+	require.Equal(t, 1, analytics.MessageExits)
+	require.Equal(t, uint32(1000), analytics.CreatedAt)
+
+	empty := (&CertificateBuildParams{}).Analytics()
+	require.Empty(t, empty.ClaimsPerOriginNetwork)
+	require.Empty(t, empty.BridgedValuePerToken)
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// CertificateCustomFieldsVersion is the version of the custom fields format, it's stored in the
+	// certificate context under CertificateCustomFieldsVersionKey
+	CertificateCustomFieldsVersion = "1"
+	// CertificateCustomFieldsVersionKey is the context key that stores the custom fields format version
+	CertificateCustomFieldsVersionKey = "aggkit.custom.version"
+	// CertificateCustomFieldPrefix is the prefix of the context keys of the custom fields,
+	// e.g. the custom field `operator` is stored as `aggkit.custom.operator`
+	CertificateCustomFieldPrefix = "aggkit.custom."
+	// MaxCertificateCustomFields is the maximum number of custom fields
+	MaxCertificateCustomFields = 8
+	// MaxCertificateCustomFieldKeyLength is the maximum length of a custom field key
+	MaxCertificateCustomFieldKeyLength = 32
+	// MaxCertificateCustomFieldValueLength is the maximum length of a custom field value
+	MaxCertificateCustomFieldValueLength = 64
+)
+
+var (
+	ErrInvalidCertificateCustomFields = errors.New("invalid certificate custom fields")
+
+	// the keys are lower case because the config loader lower cases the keys of the maps
+	certificateCustomFieldKeyRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+)
+
+// CertificateCustomFields are the key/value entries set by the operator (e.g. operator name,
+// environment) that are added to the context of the certificates, so they can be attributed downstream
+type CertificateCustomFields map[string]string
+
+// Validate checks the number of fields and the format and length of the keys and values
+func (c CertificateCustomFields) Validate() error {
+	if len(c) > MaxCertificateCustomFields {
+		return fmt.Errorf("%w: %d fields exceeds the maximum of %d",
+			ErrInvalidCertificateCustomFields, len(c), MaxCertificateCustomFields)
+	}
+	for key, value := range c {
+		if len(key) > MaxCertificateCustomFieldKeyLength {
+			return fmt.Errorf("%w: key %s exceeds %d bytes",
+				ErrInvalidCertificateCustomFields, key, MaxCertificateCustomFieldKeyLength)
+		}
+		if !certificateCustomFieldKeyRegex.MatchString(key) {
+			return fmt.Errorf("%w: key %q must contain only lower case letters, digits, '_' or '-'",
+				ErrInvalidCertificateCustomFields, key)
+		}
+		if CertificateCustomFieldPrefix+key == CertificateCustomFieldsVersionKey {
+			return fmt.Errorf("%w: key %s is reserved", ErrInvalidCertificateCustomFields, key)
+		}
+		if len(value) > MaxCertificateCustomFieldValueLength {
+			return fmt.Errorf("%w: value of key %s exceeds %d bytes",
+				ErrInvalidCertificateCustomFields, key, MaxCertificateCustomFieldValueLength)
+		}
+	}
+	return nil
+}
+
+// ApplyToContext returns a copy of the certificate context with the custom fields (and the
+// format version) added. The original context is not modified. It fails if the context already
+// has any of the keys, because the entries set by the prover must not be overwritten
+func (c CertificateCustomFields) ApplyToContext(certContext map[string][]byte) (map[string][]byte, error) {
+	if len(c) == 0 {
+		return certContext, nil
+	}
+	result := make(map[string][]byte, len(certContext)+len(c)+1)
+	for key, value := range certContext {
+		result[key] = value
+	}
+	entries := map[string]string{CertificateCustomFieldsVersionKey: CertificateCustomFieldsVersion}
+	for key, value := range c {
+		entries[CertificateCustomFieldPrefix+key] = value
+	}
+	for key, value := range entries {
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("%w: key %s is already in the certificate context",
+				ErrInvalidCertificateCustomFields, key)
+		}
+		result[key] = []byte(value)
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateCustomFieldsValidate(t *testing.T) {
+	t.Parallel()
+
+	tooMany := CertificateCustomFields{}
+	for i := 0; i <= MaxCertificateCustomFields; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name         string
+		customFields CertificateCustomFields
+		expectedErr  string
+	}{
+		{
+			name:         "no fields",
+			customFields: nil,
+		},
+		{
+			name:         "valid fields",
+			customFields: CertificateCustomFields{"operator": "acme", "env": "mainnet", "region_1": "eu-west"},
+		},
+		{
+			name:         "too many fields",
+			customFields: tooMany,
+			expectedErr:  "exceeds the maximum",
+		},
+		{
+			name:         "key too long",
+			customFields: CertificateCustomFields{strings.Repeat("k", MaxCertificateCustomFieldKeyLength+1): "v"},
+			expectedErr:  "exceeds 32 bytes",
+		},
+		{
+			name:         "upper case key",
+			customFields: CertificateCustomFields{"Operator": "acme"},
+			expectedErr:  "must contain only lower case letters",
+		},
+		{
+			name:         "empty key",
+			customFields: CertificateCustomFields{"": "acme"},
+			expectedErr:  "must contain only lower case letters",
+		},
+		{
+			name:         "reserved key",
+			customFields: CertificateCustomFields{"version": "2"},
+			expectedErr:  "is reserved",
+		},
+		{
+			name:         "value too long",
+			customFields: CertificateCustomFields{"operator": strings.Repeat("v", MaxCertificateCustomFieldValueLength+1)},
+			expectedErr:  "exceeds 64 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.customFields.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidCertificateCustomFields)
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCertificateCustomFieldsApplyToContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no fields returns the same context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"prover": []byte("data")}
+		result, err := CertificateCustomFields(nil).ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, certContext, result)
+	})
+
+	t.Run("adds the fields and the version", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"prover": []byte("data")}
+		customFields := CertificateCustomFields{"operator": "acme", "env": "mainnet"}
+		result, err := customFields.ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			"prover":                          []byte("data"),
+			CertificateCustomFieldsVersionKey: []byte(CertificateCustomFieldsVersion),
+			"aggkit.custom.operator":          []byte("acme"),
+			"aggkit.custom.env":               []byte("mainnet"),
+		}, result)
+		// the original context is not modified
+		require.Len(t, certContext, 1)
+	})
+
+	t.Run("nil context", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := CertificateCustomFields{"operator": "acme"}.ApplyToContext(nil)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("key already in the context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"aggkit.custom.operator": []byte("prover")}
+		_, err := CertificateCustomFields{"operator": "acme"}.ApplyToContext(certContext)
+		require.ErrorIs(t, err, ErrInvalidCertificateCustomFields)
+		require.ErrorContains(t, err, "is already in the certificate context")
+	})
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"strings"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+)
+
+// CertificateErrorCategory is the root cause of a certificate that is InError on agglayer
+type CertificateErrorCategory string
+
+const (
+	// CertificateErrorNone means that the certificate is not InError (or it was stored before classifying errors)
+	CertificateErrorNone CertificateErrorCategory = ""
+	// CertificateErrorProofVerification is a failure verifying the proof of the certificate
+	CertificateErrorProofVerification CertificateErrorCategory = "proof_verification"
+	// CertificateErrorLERMismatch is a mismatch of the local exit root of the certificate
+	CertificateErrorLERMismatch CertificateErrorCategory = "ler_mismatch"
+	// CertificateErrorHeightConflict means that the height of the certificate is not the expected one
+	CertificateErrorHeightConflict CertificateErrorCategory = "height_conflict"
+	// CertificateErrorSizeLimit means that the certificate (or some of its fields) exceeds a limit
+	CertificateErrorSizeLimit CertificateErrorCategory = "size_limit"
+	// CertificateErrorAgglayerInternal is an internal (transient) error of agglayer
+	CertificateErrorAgglayerInternal CertificateErrorCategory = "agglayer_internal"
+	// CertificateErrorUnknown is an error that doesn't fit in any other category
+	CertificateErrorUnknown CertificateErrorCategory = "unknown"
+)
+
+// CertificateRetryPolicy is the action to take after a certificate is InError
+type CertificateRetryPolicy string
+
+const (
+	// RetryPolicyRebuild a new certificate can be built and sent immediately
+	RetryPolicyRebuild CertificateRetryPolicy = "rebuild"
+	// RetryPolicyWait a new certificate must be sent after waiting some time
+	RetryPolicyWait CertificateRetryPolicy = "wait"
+	// RetryPolicyManual the error can't be fixed sending a new certificate, it requires human intervention
+	RetryPolicyManual CertificateRetryPolicy = "manual"
+)
+
+// certificateErrorRules are evaluated in order, the first rule that matches (key or value of
+// the agglayer error contains any of the patterns) sets the category. The LER and height rules go
+// first because the proof generation errors wrap them (e.g. ProofGenerationError{InvalidPreviousLocalExitRoot}).
+// The height rule only matches the UnexpectedHeight variant, other errors can mention a (block) height
+var certificateErrorRules = []struct {
+	category CertificateErrorCategory
+	patterns []string
+}{
+	{CertificateErrorLERMismatch, []string{"LocalExitRoot"}},
+	{CertificateErrorHeightConflict, []string{"UnexpectedHeight"}},
+	{CertificateErrorSizeLimit, []string{"TooLarge", "TooMany", "SizeLimit", "ExceedsMaximum", "Overflow"}},
+	{CertificateErrorProofVerification, []string{"ProofVerificationFailed", "InvalidSignature", "InvalidProof"}},
+	{CertificateErrorAgglayerInternal, []string{"InternalError", "SettlementError", "PreCertificationError",
+		"CertificationError", "L1InfoRootNotFound", "L1RpcError", "Timeout", "Unavailable"}},
+}
+
+// String returns the string representation of the category
+func (c CertificateErrorCategory) String() string {
+	return string(c)
+}
+
+// RetryPolicy returns the action to take for a certificate InError with this category
+func (c CertificateErrorCategory) RetryPolicy() CertificateRetryPolicy {
+	switch c {
+	case CertificateErrorHeightConflict, CertificateErrorAgglayerInternal:
+		return RetryPolicyWait
+	case CertificateErrorLERMismatch, CertificateErrorSizeLimit:
+		return RetryPolicyManual
+	default:
+		return RetryPolicyRebuild
+	}
+}
+
+// ClassifyCertificateError returns the category of the error reported by agglayer for a certificate
+// InError. The agglayer errors are parsed as GenericError (key/value); any other error is classified
+// using its message
+func ClassifyCertificateError(err error) CertificateErrorCategory {
+	if err == nil {
+		return CertificateErrorUnknown
+	}
+	key, value := "", err.Error()
+	var genericErr *agglayertypes.GenericError
+	if errors.As(err, &genericErr) {
+		key, value = genericErr.Key, genericErr.Value
+	}
+	for _, rule := range certificateErrorRules {
+		for _, pattern := range rule.patterns {
+			if strings.Contains(key, pattern) || strings.Contains(value, pattern) {
+				return rule.category
+			}
+		}
+	}
+	return CertificateErrorUnknown
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyCertificateError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected CertificateErrorCategory
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: CertificateErrorUnknown,
+		},
+		{
+			name: "proof verification failed",
+			err: &agglayertypes.GenericError{
+				Key:   "ProofVerificationFailed",
+				Value: `{"Plonk":"the verifying key does not match the inner plonk bn254 proof's committed verifying key"}`,
+			},
+			expected: CertificateErrorProofVerification,
+		},
+		{
+			name: "invalid previous local exit root",
+			err: &agglayertypes.GenericError{
+				Key:   "ProofGenerationError",
+				Value: `{"generation_type":"Native","source":{"InvalidPreviousLocalExitRoot":{"computed":"0x1","declared":"0x2"}}}`,
+			},
+			expected: CertificateErrorLERMismatch,
+		},
+		{
+			name:     "unexpected height",
+			err:      &agglayertypes.GenericError{Key: "UnexpectedHeight", Value: `{"expected":1,"got":2}`},
+			expected: CertificateErrorHeightConflict,
+		},
+		{
+			name: "unexpected height wrapped in a certificate candidate error",
+			err: &agglayertypes.GenericError{
+				Key:   "CertificateCandidateError",
+				Value: `{"UnexpectedHeight":[1,3,2]}`,
+			},
+			expected: CertificateErrorHeightConflict,
+		},
+		{
+			name:     "settlement error mentioning a block height",
+			err:      &agglayertypes.GenericError{Key: "SettlementError", Value: `"reverted at BlockHeight 10"`},
+			expected: CertificateErrorAgglayerInternal,
+		},
+		{
+			name:     "too many bridge exits",
+			err:      &agglayertypes.GenericError{Key: "TypeConversionError", Value: `{"TooManyBridgeExits":{}}`},
+			expected: CertificateErrorSizeLimit,
+		},
+		{
+			name:     "settlement error",
+			err:      &agglayertypes.GenericError{Key: "SettlementError", Value: `"nonce too low"`},
+			expected: CertificateErrorAgglayerInternal,
+		},
+		{
+			name:     "unknown key",
+			err:      &agglayertypes.GenericError{Key: "SomethingElse", Value: `{}`},
+			expected: CertificateErrorUnknown,
+		},
+		{
+			name:     "not a generic error",
+			err:      errors.New("rpc error: InternalError"),
+			expected: CertificateErrorAgglayerInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expected, ClassifyCertificateError(tt.err))
+		})
+	}
+}
+
+func TestCertificateErrorCategoryRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, RetryPolicyRebuild, CertificateErrorNone.RetryPolicy())
+	require.Equal(t, RetryPolicyRebuild, CertificateErrorUnknown.RetryPolicy())
+	require.Equal(t, RetryPolicyRebuild, CertificateErrorProofVerification.RetryPolicy())
+	require.Equal(t, RetryPolicyWait, CertificateErrorHeightConflict.RetryPolicy())
+	require.Equal(t, RetryPolicyWait, CertificateErrorAgglayerInternal.RetryPolicy())
+	require.Equal(t, RetryPolicyManual, CertificateErrorLERMismatch.RetryPolicy())
+	require.Equal(t, RetryPolicyManual, CertificateErrorSizeLimit.RetryPolicy())
+}
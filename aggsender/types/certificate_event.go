@@ -0,0 +1,69 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CertificateEventSchemaVersion is the version of the schema of the certificate events. It's increased
+// only on breaking changes of the schema (new fields can be added without changing it)
+const CertificateEventSchemaVersion = 1
+
+// CertificateEventType is the type of a lifecycle event of a certificate
+type CertificateEventType string
+
+const (
+	// CertificateEventBuilt is emitted when a certificate is built, before sending it to the agglayer
+	CertificateEventBuilt CertificateEventType = "built"
+	// CertificateEventSubmitted is emitted when the agglayer accepts a certificate
+	CertificateEventSubmitted CertificateEventType = "submitted"
+	// CertificateEventStatusChanged is emitted when the agglayer changes the status of a certificate
+	// (except for Settled and InError, that have their own event)
+	CertificateEventStatusChanged CertificateEventType = "status_changed"
+	// CertificateEventSettled is emitted when a certificate is settled
+	CertificateEventSettled CertificateEventType = "settled"
+	// CertificateEventError is emitted when the agglayer rejects a certificate or it turns InError
+	CertificateEventError CertificateEventType = "error"
+)
+
+// CertificateEvent is a lifecycle event of a certificate, published to the message bus
+type CertificateEvent struct {
+	SchemaVersion    int                  `json:"schema_version"`
+	Type             CertificateEventType `json:"type"`
+	NetworkID        uint32               `json:"network_id"`
+	Height           uint64               `json:"height"`
+	RetryCount       int                  `json:"retry_count"`
+	CertificateID    string               `json:"certificate_id,omitempty"`
+	CertType         string               `json:"cert_type,omitempty"`
+	Status           string               `json:"status,omitempty"`
+	PreviousStatus   string               `json:"previous_status,omitempty"`
+	FromBlock        uint64               `json:"from_block"`
+	ToBlock          uint64               `json:"to_block"`
+	NewLocalExitRoot string               `json:"new_local_exit_root"`
+	ErrorCategory    string               `json:"error_category,omitempty"`
+	Error            string               `json:"error,omitempty"`
+	Timestamp        int64                `json:"timestamp"`
+}
+
+// NewCertificateEvent returns the event of the certificate with its current status. The certificate ID
+// is empty if the certificate is not submitted yet
+func NewCertificateEvent(eventType CertificateEventType, header *CertificateHeader, now time.Time) CertificateEvent {
+	event := CertificateEvent{
+		SchemaVersion:    CertificateEventSchemaVersion,
+		Type:             eventType,
+		Height:           header.Height,
+		RetryCount:       header.RetryCount,
+		CertType:         header.CertType.String(),
+		FromBlock:        header.FromBlock,
+		ToBlock:          header.ToBlock,
+		NewLocalExitRoot: header.NewLocalExitRoot.Hex(),
+		Timestamp:        now.UTC().Unix(),
+	}
+	if header.CertificateID != (common.Hash{}) {
+		event.CertificateID = header.CertificateID.Hex()
+		event.Status = header.Status.String()
+	}
+	event.ErrorCategory = string(header.ErrorCategory)
+	return event
+}
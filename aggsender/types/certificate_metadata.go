@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"math/big"
@@ -12,6 +13,7 @@ const (
 	CertificateMetadataV0 = uint8(0) // Pre v1 metadata, only ToBlock is stored
 	CertificateMetadataV1 = uint8(1) // Post v1 metadata, FromBlock, Offset, CreatedAt are stored
 	CertificateMetadataV2 = uint8(2) // Same V1 + CertType
+	CertificateMetadataV3 = uint8(3) // Same V2 + ForkName
 )
 
 type CertificateMetadata struct {
@@ -33,6 +35,9 @@ type CertificateMetadata struct {
 
 	// CertType is the type of certificate
 	CertType uint8 // version >= V2
+
+	// ForkName is the name of the L2 hard fork of the blocks of the certificate
+	ForkName string // version >= V3
 }
 
 // NewCertificateMetadata returns a new CertificateMetadata from the given hash
@@ -46,6 +51,18 @@ func NewCertificateMetadata(fromBlock uint64, offset uint32, createdAt uint32, c
 	}
 }
 
+// NewCertificateMetadataWithForkName returns a new CertificateMetadata tagged with the given fork name.
+// If forkName is empty it returns a V2 metadata
+func NewCertificateMetadataWithForkName(fromBlock uint64, offset uint32, createdAt uint32, certType uint8,
+	forkName string) *CertificateMetadata {
+	meta := NewCertificateMetadata(fromBlock, offset, createdAt, certType)
+	if forkName != "" {
+		meta.ForkName = forkName
+		meta.Version = CertificateMetadataV3
+	}
+	return meta
+}
+
 // NewCertificateMetadataFromHash returns a new CertificateMetadata from the given hash
 func NewCertificateMetadataFromHash(hash common.Hash) (*CertificateMetadata, error) {
 	b := hash.Bytes()
@@ -69,6 +86,15 @@ func NewCertificateMetadataFromHash(hash common.Hash) (*CertificateMetadata, err
 			CreatedAt: binary.BigEndian.Uint32(b[13:17]),
 			CertType:  b[17],
 		}, nil
+	} else if version == CertificateMetadataV3 {
+		return &CertificateMetadata{
+			Version:   version,
+			FromBlock: binary.BigEndian.Uint64(b[1:9]),
+			Offset:    binary.BigEndian.Uint32(b[9:13]),
+			CreatedAt: binary.BigEndian.Uint32(b[13:17]),
+			CertType:  b[17],
+			ForkName:  string(bytes.TrimRight(b[18:], "\x00")),
+		}, nil
 	} else {
 		// Unsupported version
 		return nil, fmt.Errorf("newCertificateMetadataFromHash. unsupported certificate metadata version: %d", version)
@@ -95,9 +121,22 @@ func (c *CertificateMetadata) ToHash() common.Hash {
 	// Encode createdAt
 	binary.BigEndian.PutUint32(b[13:17], c.CreatedAt)
 
-	if c.Version == CertificateMetadataV2 {
+	if c.Version >= CertificateMetadataV2 {
 		// Encode typeCert
 		b[17] = c.CertType
 	}
+
+	if c.Version >= CertificateMetadataV3 {
+		// Encode forkName (truncated to MaxHardForkNameLength bytes)
+		copy(b[18:], c.ForkName)
+	}
 	return common.BytesToHash(b)
 }
+
+// GetToBlock returns the last block of the certificate
+func (c *CertificateMetadata) GetToBlock() uint64 {
+	if c.Version == CertificateMetadataV0 {
+		return c.ToBlock
+	}
+	return c.FromBlock + uint64(c.Offset)
+}
@@ -14,6 +14,7 @@ func TestMetadataConversions_V0_toBlock_Only(t *testing.T) {
 	meta, err := NewCertificateMetadataFromHash(hash)
 	require.NoError(t, err)
 	require.Equal(t, toBlock, meta.ToBlock)
+	require.Equal(t, toBlock, meta.GetToBlock())
 	metabuild := meta.ToHash()
 	require.Equal(t, hash, metabuild)
 }
@@ -32,6 +33,7 @@ func TestMetadataConversions_V1(t *testing.T) {
 	require.Equal(t, meta.Offset, metabuild.Offset)
 	require.Equal(t, meta.CreatedAt, metabuild.CreatedAt)
 	require.Equal(t, meta.Version, metabuild.Version)
+	require.Equal(t, uint64(123568890), metabuild.GetToBlock())
 }
 
 func TestMetadataConversions_V2(t *testing.T) {
@@ -52,6 +54,18 @@ func TestMetadataConversions_V2(t *testing.T) {
 	require.Equal(t, meta.CertType, metabuild.CertType)
 }
 
+func TestMetadataConversions_V3(t *testing.T) {
+	meta := NewCertificateMetadataWithForkName(123567890, 1000, 123, 2, "pectra")
+	require.Equal(t, CertificateMetadataV3, meta.Version)
+	hash := meta.ToHash()
+	metabuild, err := NewCertificateMetadataFromHash(hash)
+	require.NoError(t, err)
+	require.Equal(t, meta, metabuild)
+
+	meta = NewCertificateMetadataWithForkName(123567890, 1000, 123, 2, "")
+	require.Equal(t, CertificateMetadataV2, meta.Version)
+}
+
 func TestMetadataConversions_UnknownMetadataVersion(t *testing.T) {
 	b := make([]byte, common.HashLength)
 	b[0] = 254 // Unknown version
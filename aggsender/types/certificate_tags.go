@@ -0,0 +1,73 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// CertificateTagPrefix is the prefix of the context keys of the tags, e.g. the tag `environment`
+	// is stored as `aggkit.tag.environment`
+	CertificateTagPrefix = "aggkit.tag."
+	// CertificateTagEnvironment is the standard tag of the environment of the deployment (e.g. staging, prod)
+	CertificateTagEnvironment = "environment"
+	// CertificateTagRegion is the standard tag of the region of the deployment (e.g. eu-west-1)
+	CertificateTagRegion = "region"
+	// CertificateTagDeployment is the standard tag of the name of the deployment
+	CertificateTagDeployment = "deployment"
+	// MaxCertificateTags is the maximum number of tags
+	MaxCertificateTags = 8
+)
+
+var ErrInvalidCertificateTags = errors.New("invalid certificate tags")
+
+// CertificateTags identify the deployment that sent the certificates (environment, region...), so the
+// certificates of a shared agglayer environment can be attributed to the right deployment. They are
+// stored with the certificates, and added to their context in the AggchainProof mode
+type CertificateTags map[string]string
+
+// Validate checks the number of tags and the format and length of the keys and values, that are the
+// same as the ones of the custom fields
+func (c CertificateTags) Validate() error {
+	if len(c) > MaxCertificateTags {
+		return fmt.Errorf("%w: %d tags exceeds the maximum of %d", ErrInvalidCertificateTags, len(c), MaxCertificateTags)
+	}
+	for key, value := range c {
+		if len(key) > MaxCertificateCustomFieldKeyLength {
+			return fmt.Errorf("%w: key %s exceeds %d bytes",
+				ErrInvalidCertificateTags, key, MaxCertificateCustomFieldKeyLength)
+		}
+		if !certificateCustomFieldKeyRegex.MatchString(key) {
+			return fmt.Errorf("%w: key %q must contain only lower case letters, digits, '_' or '-'",
+				ErrInvalidCertificateTags, key)
+		}
+		if value == "" {
+			return fmt.Errorf("%w: value of key %s is empty", ErrInvalidCertificateTags, key)
+		}
+		if len(value) > MaxCertificateCustomFieldValueLength {
+			return fmt.Errorf("%w: value of key %s exceeds %d bytes",
+				ErrInvalidCertificateTags, key, MaxCertificateCustomFieldValueLength)
+		}
+	}
+	return nil
+}
+
+// ApplyToContext returns a copy of the certificate context with the tags added. The original context
+// is not modified. It fails if the context already has any of the keys
+func (c CertificateTags) ApplyToContext(certContext map[string][]byte) (map[string][]byte, error) {
+	if len(c) == 0 {
+		return certContext, nil
+	}
+	result := make(map[string][]byte, len(certContext)+len(c))
+	for key, value := range certContext {
+		result[key] = value
+	}
+	for key, value := range c {
+		if _, exists := result[CertificateTagPrefix+key]; exists {
+			return nil, fmt.Errorf("%w: key %s is already in the certificate context",
+				ErrInvalidCertificateTags, CertificateTagPrefix+key)
+		}
+		result[CertificateTagPrefix+key] = []byte(value)
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateTagsValidate(t *testing.T) {
+	t.Parallel()
+
+	tooMany := CertificateTags{}
+	for i := 0; i <= MaxCertificateTags; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+
+	tests := []struct {
+		name        string
+		tags        CertificateTags
+		expectedErr string
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+		},
+		{
+			name: "valid tags",
+			tags: CertificateTags{CertificateTagEnvironment: "prod", CertificateTagRegion: "eu-west-1"},
+		},
+		{
+			name:        "too many tags",
+			tags:        tooMany,
+			expectedErr: "exceeds the maximum",
+		},
+		{
+			name:        "upper case key",
+			tags:        CertificateTags{"Environment": "prod"},
+			expectedErr: "must contain only lower case letters",
+		},
+		{
+			name:        "empty value",
+			tags:        CertificateTags{CertificateTagEnvironment: ""},
+			expectedErr: "is empty",
+		},
+		{
+			name:        "value too long",
+			tags:        CertificateTags{CertificateTagRegion: strings.Repeat("v", MaxCertificateCustomFieldValueLength+1)},
+			expectedErr: "exceeds 64 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.tags.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidCertificateTags)
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCertificateTagsApplyToContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no tags returns the same context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"key1": []byte("value1")}
+		result, err := CertificateTags(nil).ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, certContext, result)
+	})
+
+	t.Run("adds the prefixed tags without modifying the original context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"key1": []byte("value1")}
+		result, err := CertificateTags{CertificateTagEnvironment: "staging"}.ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			"key1":                   []byte("value1"),
+			"aggkit.tag.environment": []byte("staging"),
+		}, result)
+		require.Len(t, certContext, 1)
+	})
+
+	t.Run("key already in the context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"aggkit.tag.environment": []byte("prod")}
+		_, err := CertificateTags{CertificateTagEnvironment: "staging"}.ApplyToContext(certContext)
+		require.ErrorIs(t, err, ErrInvalidCertificateTags)
+		require.ErrorContains(t, err, "already in the certificate context")
+	})
+}
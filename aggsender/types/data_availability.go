@@ -0,0 +1,102 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+const (
+	// DataAvailabilityContextPrefix is the prefix of the context keys of the data availability attestation
+	DataAvailabilityContextPrefix = "aggkit.da."
+	// DataAvailabilityFromBlockKey is the context key of the first block of the attested range
+	DataAvailabilityFromBlockKey = DataAvailabilityContextPrefix + "from_block"
+	// DataAvailabilityToBlockKey is the context key of the last block of the attested range
+	DataAvailabilityToBlockKey = DataAvailabilityContextPrefix + "to_block"
+	// DataAvailabilityReferencesKey is the context key of the references of the attestations (JSON array)
+	DataAvailabilityReferencesKey = DataAvailabilityContextPrefix + "references"
+)
+
+var ErrInvalidDataAvailabilityAttestation = errors.New("invalid data availability attestation")
+
+// DataAvailabilityConfig verifies the data availability attestations of the blocks of each certificate
+// against an external DA endpoint (e.g. a DA committee or an availability layer), for the validium-style
+// aggchains. A block range is only included in a certificate once its data is attested (AggchainProof mode)
+type DataAvailabilityConfig struct {
+	// Enabled enables the data availability check of the block ranges
+	Enabled bool `mapstructure:"Enabled"`
+	// URL is the URL of the DA endpoint, which receives a POST with {"from_block": uint64, "to_block": uint64}
+	// and answers with {"attested_to_block": uint64, "references": []string}
+	URL string `mapstructure:"URL"`
+	// Timeout is the maximum time waiting for the answer of the DA endpoint
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// Validate checks that the URL is set if the data availability check is enabled
+func (c DataAvailabilityConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.URL == "" {
+		return errors.New("DataAvailability.URL is required")
+	}
+	return nil
+}
+
+// String returns a string representation of the config
+func (c DataAvailabilityConfig) String() string {
+	if !c.Enabled {
+		return "DataAvailability{disabled}"
+	}
+	return fmt.Sprintf("DataAvailability{timeout:%s}", c.Timeout)
+}
+
+// DataAvailabilityAttestation is the attestation that the data of a block range is available in the DA layer
+type DataAvailabilityAttestation struct {
+	FromBlock uint64
+	ToBlock   uint64
+	// References identify the attestations in the DA layer (e.g. the certificates of the DA committee or
+	// the commitments of the blobs)
+	References []string
+}
+
+// String returns a string representation of the attestation
+func (d *DataAvailabilityAttestation) String() string {
+	if d == nil {
+		return NilStr
+	}
+	return fmt.Sprintf("DataAvailabilityAttestation{blocks:%d-%d, references:%d}",
+		d.FromBlock, d.ToBlock, len(d.References))
+}
+
+// ApplyToContext returns a copy of the certificate context with the attested range and the references added.
+// The original context is not modified. It fails if the context already has any of the keys
+func (d *DataAvailabilityAttestation) ApplyToContext(certContext map[string][]byte) (map[string][]byte, error) {
+	if d == nil {
+		return certContext, nil
+	}
+	references, err := json.Marshal(d.References)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error encoding the references: %w", ErrInvalidDataAvailabilityAttestation, err)
+	}
+	entries := map[string][]byte{
+		DataAvailabilityFromBlockKey:  []byte(strconv.FormatUint(d.FromBlock, 10)),
+		DataAvailabilityToBlockKey:    []byte(strconv.FormatUint(d.ToBlock, 10)),
+		DataAvailabilityReferencesKey: references,
+	}
+	result := make(map[string][]byte, len(certContext)+len(entries))
+	for key, value := range certContext {
+		result[key] = value
+	}
+	for key, value := range entries {
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("%w: key %s is already in the certificate context",
+				ErrInvalidDataAvailabilityAttestation, key)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
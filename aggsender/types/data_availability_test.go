@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataAvailabilityConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, DataAvailabilityConfig{}.Validate())
+	require.NoError(t, DataAvailabilityConfig{Enabled: true, URL: "http://da"}.Validate())
+	require.ErrorContains(t, DataAvailabilityConfig{Enabled: true}.Validate(), "DataAvailability.URL is required")
+}
+
+func TestDataAvailabilityAttestationApplyToContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no attestation returns the same context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"key1": []byte("value1")}
+		var attestation *DataAvailabilityAttestation
+		result, err := attestation.ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, certContext, result)
+	})
+
+	t.Run("adds the attested range and references without modifying the original context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{"key1": []byte("value1")}
+		attestation := &DataAvailabilityAttestation{FromBlock: 10, ToBlock: 20, References: []string{"0x01", "0x02"}}
+		result, err := attestation.ApplyToContext(certContext)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			"key1":                 []byte("value1"),
+			"aggkit.da.from_block": []byte("10"),
+			"aggkit.da.to_block":   []byte("20"),
+			"aggkit.da.references": []byte(`["0x01","0x02"]`),
+		}, result)
+		require.Len(t, certContext, 1)
+	})
+
+	t.Run("key already in the context", func(t *testing.T) {
+		t.Parallel()
+
+		certContext := map[string][]byte{DataAvailabilityToBlockKey: []byte("5")}
+		attestation := &DataAvailabilityAttestation{FromBlock: 1, ToBlock: 2, References: []string{"0x01"}}
+		_, err := attestation.ApplyToContext(certContext)
+		require.ErrorIs(t, err, ErrInvalidDataAvailabilityAttestation)
+		require.ErrorContains(t, err, "already in the certificate context")
+	})
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"context"
+	"fmt"
+)
+
+// CertificateFeeSource is where the estimated fee of a certificate comes from
+type CertificateFeeSource string
+
+const (
+	// CertificateFeeSourceConfig is a fee estimated from the FeeBudget config
+	CertificateFeeSourceConfig CertificateFeeSource = "config"
+)
+
+// CertificateFeeBudget checks the estimated fee of a certificate against the fee budget. The flows
+// check it before proving and signing the certificate, so no proof is generated for a certificate that
+// is not going to be sent
+type CertificateFeeBudget interface {
+	// CheckFeeBudget returns an error if the certificate of the build params exceeds the fee budget
+	CheckFeeBudget(ctx context.Context, buildParams *CertificateBuildParams) error
+}
+
+// FeeBudgetConfig limits the fees spent submitting certificates to the agglayer. When the budget is
+// exhausted the certificate is not built, and its bridges are included in the next one (once the
+// budget allows it). The fees are in wei
+type FeeBudgetConfig struct {
+	// Enabled enables the estimation of the fees and the budget limits
+	Enabled bool `mapstructure:"Enabled"`
+	// BaseFeePerCertificate is the estimated fee of submitting a certificate
+	BaseFeePerCertificate uint64 `mapstructure:"BaseFeePerCertificate"`
+	// FeePerExit is the estimated fee of each bridge exit and imported bridge exit of a certificate,
+	// added to BaseFeePerCertificate
+	FeePerExit uint64 `mapstructure:"FeePerExit"`
+	// MaxFeePerEpoch is the maximum fee spent in an epoch. 0 means no limit
+	MaxFeePerEpoch uint64 `mapstructure:"MaxFeePerEpoch"`
+	// MaxFeePerDay is the maximum fee spent in the last 24 hours. 0 means no limit
+	MaxFeePerDay uint64 `mapstructure:"MaxFeePerDay"`
+}
+
+// EstimateFee returns the fee of a certificate with the given number of bridge exits and imported
+// bridge exits estimated from the config
+func (c FeeBudgetConfig) EstimateFee(exits int) uint64 {
+	return c.BaseFeePerCertificate + uint64(exits)*c.FeePerExit
+}
+
+// String returns a string representation of the config
+func (c FeeBudgetConfig) String() string {
+	if !c.Enabled {
+		return "FeeBudget{disabled}"
+	}
+	return fmt.Sprintf("FeeBudget{baseFee:%d, feePerExit:%d, maxPerEpoch:%d, maxPerDay:%d}",
+		c.BaseFeePerCertificate, c.FeePerExit, c.MaxFeePerEpoch, c.MaxFeePerDay)
+}
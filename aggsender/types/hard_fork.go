@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxHardForkNameLength is the maximum length of a hard fork name, it's limited by
+// the free space in the certificate metadata
+const MaxHardForkNameLength = 14
+
+var ErrInvalidHardForks = errors.New("invalid hard forks")
+
+// HardFork is an upcoming (or past) L2 hard fork. A certificate never contains blocks
+// of two different forks, because the provers usually need a different program per fork
+type HardFork struct {
+	// Name is the name of the fork, it's stored in the metadata of the certificates of this fork
+	Name string `mapstructure:"Name"`
+	// BlockNumber is the first L2 block of the fork
+	BlockNumber uint64 `mapstructure:"BlockNumber"`
+}
+
+// HardForks is a list of hard forks sorted by BlockNumber
+type HardForks []HardFork
+
+// Validate checks that the hard forks are sorted by block number, without duplicates
+// and that the names fit in the certificate metadata
+func (h HardForks) Validate() error {
+	for i, fork := range h {
+		if fork.Name == "" {
+			return fmt.Errorf("%w: fork at block %d has no name", ErrInvalidHardForks, fork.BlockNumber)
+		}
+		if len(fork.Name) > MaxHardForkNameLength {
+			return fmt.Errorf("%w: fork name %s exceeds %d bytes", ErrInvalidHardForks, fork.Name, MaxHardForkNameLength)
+		}
+		if fork.BlockNumber == 0 {
+			return fmt.Errorf("%w: fork %s must have a BlockNumber greater than 0", ErrInvalidHardForks, fork.Name)
+		}
+		if i > 0 && fork.BlockNumber <= h[i-1].BlockNumber {
+			return fmt.Errorf("%w: fork %s (block %d) must be after fork %s (block %d)", ErrInvalidHardForks,
+				fork.Name, fork.BlockNumber, h[i-1].Name, h[i-1].BlockNumber)
+		}
+	}
+	return nil
+}
+
+// ForkAt returns the name of the fork active at the given block or empty if there is no fork before it
+func (h HardForks) ForkAt(blockNumber uint64) string {
+	name := ""
+	for _, fork := range h {
+		if fork.BlockNumber > blockNumber {
+			break
+		}
+		name = fork.Name
+	}
+	return name
+}
+
+// FirstForkInRange returns the first fork that starts inside the range (fromBlock, toBlock], that
+// is, a fork that a certificate with this range would straddle
+func (h HardForks) FirstForkInRange(fromBlock, toBlock uint64) *HardFork {
+	for i := range h {
+		if h[i].BlockNumber > fromBlock && h[i].BlockNumber <= toBlock {
+			return &h[i]
+		}
+	}
+	return nil
+}
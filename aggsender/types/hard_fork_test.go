@@ -0,0 +1,88 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardForksValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hardForks   HardForks
+		expectedErr string
+	}{
+		{
+			name:      "no forks",
+			hardForks: nil,
+		},
+		{
+			name:      "valid forks",
+			hardForks: HardForks{{Name: "fork1", BlockNumber: 100}, {Name: "fork2", BlockNumber: 200}},
+		},
+		{
+			name:        "empty name",
+			hardForks:   HardForks{{Name: "", BlockNumber: 100}},
+			expectedErr: "has no name",
+		},
+		{
+			name:        "name too long",
+			hardForks:   HardForks{{Name: "fork-name-too-long", BlockNumber: 100}},
+			expectedErr: "exceeds 14 bytes",
+		},
+		{
+			name:        "block zero",
+			hardForks:   HardForks{{Name: "fork1", BlockNumber: 0}},
+			expectedErr: "greater than 0",
+		},
+		{
+			name:        "unsorted forks",
+			hardForks:   HardForks{{Name: "fork1", BlockNumber: 200}, {Name: "fork2", BlockNumber: 100}},
+			expectedErr: "must be after fork fork1",
+		},
+		{
+			name:        "duplicated block",
+			hardForks:   HardForks{{Name: "fork1", BlockNumber: 100}, {Name: "fork2", BlockNumber: 100}},
+			expectedErr: "must be after fork fork1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.hardForks.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidHardForks)
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestHardForksForkAt(t *testing.T) {
+	t.Parallel()
+
+	hardForks := HardForks{{Name: "fork1", BlockNumber: 100}, {Name: "fork2", BlockNumber: 200}}
+	require.Equal(t, "", hardForks.ForkAt(99))
+	require.Equal(t, "fork1", hardForks.ForkAt(100))
+	require.Equal(t, "fork1", hardForks.ForkAt(199))
+	require.Equal(t, "fork2", hardForks.ForkAt(200))
+	require.Equal(t, "", HardForks(nil).ForkAt(200))
+}
+
+func TestHardForksFirstForkInRange(t *testing.T) {
+	t.Parallel()
+
+	hardForks := HardForks{{Name: "fork1", BlockNumber: 100}, {Name: "fork2", BlockNumber: 200}}
+	require.Nil(t, hardForks.FirstForkInRange(10, 99))
+	// a certificate starting at the fork block doesn't straddle it
+	require.Nil(t, hardForks.FirstForkInRange(100, 199))
+	require.Equal(t, &hardForks[0], hardForks.FirstForkInRange(50, 100))
+	require.Equal(t, &hardForks[0], hardForks.FirstForkInRange(50, 250))
+	require.Equal(t, &hardForks[1], hardForks.FirstForkInRange(150, 250))
+}
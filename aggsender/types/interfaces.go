@@ -25,6 +25,10 @@ type AggsenderFlow interface {
 	// BuildCertificate builds a certificate based on the buildParams
 	BuildCertificate(ctx context.Context,
 		buildParams *CertificateBuildParams) (*agglayertypes.Certificate, error)
+	// SignerAddress returns the address of the signer of the certificates
+	SignerAddress() common.Address
+	// L2ChainStatus returns whether the L2 chain is active, idle or halted (nil if it's not tracked)
+	L2ChainStatus() *L2ChainStatus
 }
 
 type AggsenderFlowBaser interface {
@@ -37,12 +41,14 @@ type AggsenderFlowBaser interface {
 	GetNewLocalExitRoot(ctx context.Context,
 		certParams *CertificateBuildParams) (common.Hash, error)
 	VerifyBuildParams(ctx context.Context, fullCert *CertificateBuildParams) error
+	CheckFeeBudget(ctx context.Context, buildParams *CertificateBuildParams) error
 	VerifyBlockRangeGaps(
 		ctx context.Context,
 		lastSentCertificate *CertificateHeader,
 		newFromBlock, newToBlock uint64) error
 	ConvertClaimToImportedBridgeExit(claim bridgesync.Claim) (*agglayertypes.ImportedBridgeExit, error)
 	StartL2Block() uint64
+	L2ChainStatus() *L2ChainStatus
 }
 
 // L1InfoTreeSyncer is an interface defining functions that an L1InfoTreeSyncer should implement
@@ -51,6 +57,9 @@ type L1InfoTreeSyncer interface {
 	GetL1InfoTreeMerkleProofFromIndexToRoot(
 		ctx context.Context, index uint32, root common.Hash,
 	) (treetypes.Proof, error)
+	GetL1InfoTreeMerkleProofsFromIndexesToRoot(
+		ctx context.Context, indexes []uint32, root common.Hash,
+	) ([]treetypes.Proof, error)
 	GetL1InfoTreeRootByIndex(ctx context.Context, index uint32) (treetypes.Root, error)
 	GetProcessedBlockUntil(ctx context.Context, blockNumber uint64) (uint64, common.Hash, error)
 	GetInfoByIndex(ctx context.Context, index uint32) (*l1infotreesync.L1InfoTreeLeaf, error)
@@ -66,6 +75,14 @@ type L2BridgeSyncer interface {
 	OriginNetwork() uint32
 	BlockFinality() aggkittypes.BlockNumberFinality
 	GetLastProcessedBlock(ctx context.Context) (uint64, error)
+	GetContractLocalExitRoot(ctx context.Context, blockNumber uint64) (common.Hash, uint32, error)
+}
+
+// L2BridgeFastSyncer is the L2 bridge syncer of a fresh node, that can be started at the last block settled
+// in the agglayer
+type L2BridgeFastSyncer interface {
+	OriginNetwork() uint32
+	InitFromLocalExitRoot(ctx context.Context, blockNum uint64, localExitRoot common.Hash) error
 }
 
 // BridgeQuerier is an interface defining functions that an BridgeQuerier should implement
@@ -106,6 +123,14 @@ type L1InfoTreeDataQuerier interface {
 	GetProofForGER(ctx context.Context, ger, rootFromWhichToProve common.Hash) (
 		*l1infotreesync.L1InfoTreeLeaf, treetypes.Proof, error)
 
+	// GetProofsForGERs returns the L1 Info tree leaves and the merkle proofs for the given GERs, in their order,
+	// computing all the proofs to the same root in one batch
+	GetProofsForGERs(ctx context.Context, gers []common.Hash, rootFromWhichToProve common.Hash) (
+		[]*l1infotreesync.L1InfoTreeLeaf, []treetypes.Proof, error)
+
+	// CheckL1InfoTreeRoot checks that the root is still the root of the L1 Info tree with the given leaf index
+	CheckL1InfoTreeRoot(ctx context.Context, root common.Hash, index uint32) error
+
 	// CheckIfClaimsArePartOfFinalizedL1InfoTree checks if the claims are part of the finalized L1 Info tree
 	CheckIfClaimsArePartOfFinalizedL1InfoTree(
 		finalizedL1InfoTreeRoot *treetypes.Root, claims []bridgesync.Claim) error
@@ -159,3 +184,37 @@ type MaxL2BlockNumberLimiterInterface interface {
 	AdaptCertificate(
 		buildParams *CertificateBuildParams) (*CertificateBuildParams, error)
 }
+
+// HardForkLimiterInterface is an interface defining functions that a HardForkLimiter should implement
+type HardForkLimiterInterface interface {
+	// AdaptCertificate adjusts the certificate build parameters to not straddle a hard fork
+	//  and return it through a new buildParams
+	AdaptCertificate(
+		buildParams *CertificateBuildParams) (*CertificateBuildParams, error)
+	// EndsAtFork returns true if the next block after the certificate is the first block of a hard fork
+	EndsAtFork(buildParams *CertificateBuildParams) bool
+}
+
+// CertificateArchiver is an interface defining functions that a CertificateArchiver should implement
+type CertificateArchiver interface {
+	// Start uploads the queued certificates and runs the background tasks of the archiver (e.g. retention)
+	Start(ctx context.Context)
+	// ArchiveCertificate queues the certificate (and its current status) to be uploaded to the archive
+	ArchiveCertificate(cert *Certificate) error
+}
+
+// CertificateEventPublisher publishes the lifecycle events of the certificates to a message bus
+type CertificateEventPublisher interface {
+	// Start runs the delivery of the queued events to the message bus
+	Start(ctx context.Context)
+	// Publish queues the event to be published. It never blocks: if the queue is full the event is dropped
+	Publish(event CertificateEvent)
+}
+
+// DataAvailabilityVerifier verifies that the data of a block range of L2 is available in the external DA
+// layer (e.g. a DA committee) before the range is included in a certificate
+type DataAvailabilityVerifier interface {
+	// VerifyDataAvailability returns the attestation of the longest prefix of [fromBlock, toBlock] whose
+	// data is available, or nil if the data of fromBlock is not available yet
+	VerifyDataAvailability(ctx context.Context, fromBlock, toBlock uint64) (*DataAvailabilityAttestation, error)
+}
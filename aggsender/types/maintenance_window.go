@@ -0,0 +1,40 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// MaintenanceWindowConfig is a recurring window during which the aggsender doesn't submit new
+// certificates (e.g. around the L1 upgrades). The status of the sent certificates is still checked
+type MaintenanceWindowConfig struct {
+	// Schedule is the cron expression of the start of the window (minute hour day-of-month month
+	// day-of-week, in UTC), e.g. `0 3 * * 2` every Tuesday at 03:00
+	Schedule string `mapstructure:"Schedule"`
+	// Duration is the length of the window
+	Duration types.Duration `mapstructure:"Duration"`
+}
+
+// MaintenanceWindowsConfig are the scheduled maintenance windows of the aggsender
+type MaintenanceWindowsConfig []MaintenanceWindowConfig
+
+// Validate checks that the schedules are valid cron expressions that match some time, and that the
+// windows have a duration
+func (c MaintenanceWindowsConfig) Validate() error {
+	for i, window := range c {
+		schedule, err := aggkitcommon.ParseCronSchedule(window.Schedule)
+		if err != nil {
+			return fmt.Errorf("invalid MaintenanceWindows[%d].Schedule: %w", i, err)
+		}
+		if schedule.Next(time.Now()).IsZero() {
+			return fmt.Errorf("MaintenanceWindows[%d].Schedule %q never matches", i, window.Schedule)
+		}
+		if window.Duration.Duration <= 0 {
+			return fmt.Errorf("MaintenanceWindows[%d].Duration must be greater than 0", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MirroredCertificateHeader is a certificate header of the network as known by the agglayer, copied
+// into the local storage so there is a complete view of the certificates of the network, including the
+// ones sent before the local storage was created
+type MirroredCertificateHeader struct {
+	Height           uint64                          `meddler:"height" json:"height"`
+	CertificateID    common.Hash                     `meddler:"certificate_id,hash" json:"certificate_id"`
+	EpochNumber      *uint64                         `meddler:"epoch_number" json:"epoch_number,omitempty"`
+	CertificateIndex *uint64                         `meddler:"certificate_index" json:"certificate_index,omitempty"`
+	Status           agglayertypes.CertificateStatus `meddler:"status" json:"status"`
+	Metadata         common.Hash                     `meddler:"metadata,hash" json:"metadata"`
+	// PreviousLocalExitRoot and NewLocalExitRoot are the local exit roots before and after the certificate
+	PreviousLocalExitRoot *common.Hash `meddler:"previous_local_exit_root,hash" json:"prev_local_exit_root,omitempty"`
+	NewLocalExitRoot      common.Hash  `meddler:"new_local_exit_root,hash" json:"new_local_exit_root"`
+	// SettlementTxHash is the L1 transaction that settled the certificate
+	SettlementTxHash *common.Hash `meddler:"settlement_tx_hash,hash" json:"settlement_tx_hash,omitempty"`
+	// Error is the error reported by the agglayer if the certificate is InError
+	Error string `meddler:"error" json:"error,omitempty"`
+	// MirroredAt is the time (unix seconds) the header was copied from the agglayer
+	MirroredAt uint32 `meddler:"mirrored_at" json:"mirrored_at"`
+}
+
+// NewMirroredCertificateHeader returns the header of the agglayer to store in the mirror
+func NewMirroredCertificateHeader(header *agglayertypes.CertificateHeader,
+	mirroredAt uint32) *MirroredCertificateHeader {
+	mirrored := &MirroredCertificateHeader{
+		Height:                header.Height,
+		CertificateID:         header.CertificateID,
+		EpochNumber:           header.EpochNumber,
+		CertificateIndex:      header.CertificateIndex,
+		PreviousLocalExitRoot: header.PreviousLocalExitRoot,
+		NewLocalExitRoot:      header.NewLocalExitRoot,
+		Status:                header.Status,
+		Metadata:              header.Metadata,
+		SettlementTxHash:      header.SettlementTxHash,
+		MirroredAt:            mirroredAt,
+	}
+	if header.Error != nil {
+		mirrored.Error = header.Error.Error()
+	}
+	return mirrored
+}
+
+// String returns a string representation of the mirrored header
+func (m *MirroredCertificateHeader) String() string {
+	if m == nil {
+		return NilStr
+	}
+	return fmt.Sprintf("mirrored{height:%d, certificateID:%s, status:%s, mirroredAt:%d}",
+		m.Height, m.CertificateID.String(), m.Status.String(), m.MirroredAt)
+}
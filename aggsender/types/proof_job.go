@@ -0,0 +1,42 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProofJobNotFound is returned when the job doesn't exist (or it has been discarded)
+	ErrProofJobNotFound = errors.New("proof job not found")
+	// ErrProofJobQueueFull is returned when there are too many jobs waiting in the queue
+	ErrProofJobQueueFull = errors.New("proof job queue is full")
+	// ErrInvalidProofJobRange is returned when the requested block range is empty
+	ErrInvalidProofJobRange = errors.New("invalid proof job block range")
+)
+
+// ProofJobStatus is the status of a proof generation job
+type ProofJobStatus string
+
+const (
+	ProofJobQueued    ProofJobStatus = "queued"
+	ProofJobRunning   ProofJobStatus = "running"
+	ProofJobSucceeded ProofJobStatus = "succeeded"
+	ProofJobFailed    ProofJobStatus = "failed"
+)
+
+// IsFinished returns true if the job has finished (successfully or not)
+func (s ProofJobStatus) IsFinished() bool {
+	return s == ProofJobSucceeded || s == ProofJobFailed
+}
+
+// ProofJob is a request to generate an aggchain proof for the blocks (LastProvenBlock, MaxEndBlock]
+type ProofJob struct {
+	ID              string         `json:"job_id"`
+	LastProvenBlock uint64         `json:"last_proven_block"`
+	MaxEndBlock     uint64         `json:"max_end_block"`
+	Status          ProofJobStatus `json:"status"`
+	Error           string         `json:"error,omitempty"`
+	Proof           *SP1StarkProof `json:"proof,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+const maxPercentile = 100
+
+// ProverSLOConfig tracks the proving time and the proof size of the aggchain proofs, and alerts when the
+// rolling percentile of the last proofs exceeds the configured limits (SLOs)
+type ProverSLOConfig struct {
+	// Enabled enables the tracking of the proofs and the alerts
+	Enabled bool `mapstructure:"Enabled"`
+	// WindowSize is the number of the last proofs used to compute the percentiles
+	WindowSize uint32 `mapstructure:"WindowSize"`
+	// Percentile is the percentile (0-100] of the window compared with the limits, e.g. 95
+	Percentile float64 `mapstructure:"Percentile"`
+	// MaxProvingTime is the limit of the percentile of the proving time. 0 means no limit
+	MaxProvingTime types.Duration `mapstructure:"MaxProvingTime"`
+	// MaxProofSize is the limit of the percentile of the proof size in bytes. 0 means no limit
+	MaxProofSize uint64 `mapstructure:"MaxProofSize"`
+	// WebhookURL is the URL notified (POST with a JSON body) when the SLOs are breached and when they
+	// recover. Empty means that the alerts are only logged and exposed as metrics
+	WebhookURL string `mapstructure:"WebhookURL"`
+	// WebhookTimeout is the timeout of the webhook requests
+	WebhookTimeout types.Duration `mapstructure:"WebhookTimeout"`
+}
+
+// Validate checks that the window and the percentile are valid if the tracking is enabled
+func (c ProverSLOConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.WindowSize == 0 {
+		return errors.New("ProverSLO.WindowSize must be greater than 0")
+	}
+	if c.Percentile <= 0 || c.Percentile > maxPercentile {
+		return fmt.Errorf("ProverSLO.Percentile must be in the range (0, 100], got %v", c.Percentile)
+	}
+	return nil
+}
+
+// String returns a string representation of the config
+func (c ProverSLOConfig) String() string {
+	if !c.Enabled {
+		return "ProverSLO{disabled}"
+	}
+	return fmt.Sprintf("ProverSLO{window:%d, percentile:%v, maxProvingTime:%s, maxProofSize:%d, webhook:%t}",
+		c.WindowSize, c.Percentile, c.MaxProvingTime, c.MaxProofSize, c.WebhookURL != "")
+}
+
+// ProofStats are the proving time and the proof size of the aggchain proof of a certificate
+type ProofStats struct {
+	Height     uint64 `meddler:"height" json:"height"`
+	RetryCount int    `meddler:"retry_count" json:"retry_count"`
+	FromBlock  uint64 `meddler:"from_block" json:"from_block"`
+	ToBlock    uint64 `meddler:"to_block" json:"to_block"`
+	// ProvingTimeMs is the time the prover took to generate the proof, in milliseconds
+	ProvingTimeMs uint64 `meddler:"proving_time_ms" json:"proving_time_ms"`
+	// ProofSize is the size of the SP1 stark proof in bytes
+	ProofSize uint64 `meddler:"proof_size" json:"proof_size"`
+	// CreatedAt is the time the proof was generated
+	CreatedAt uint32 `meddler:"created_at" json:"created_at"`
+}
+
+// ProvingTime returns the proving time as a duration
+func (p *ProofStats) ProvingTime() time.Duration {
+	return time.Duration(p.ProvingTimeMs) * time.Millisecond
+}
+
+// ProofStats returns the stats (without CreatedAt) of the aggchain proof of the certificate, or nil if it
+// has no proof generated by the prover in this build (e.g. the pessimistic proof and the optimistic certificates)
+func (c *CertificateBuildParams) ProofStats(height uint64) *ProofStats {
+	if c.AggchainProof == nil || c.AggchainProof.SP1StarkProof == nil ||
+		c.CertificateType == CertificateTypeOptimistic || c.ProvingTime == 0 {
+		return nil
+	}
+	return &ProofStats{
+		Height:        height,
+		RetryCount:    c.RetryCount,
+		FromBlock:     c.FromBlock,
+		ToBlock:       c.ToBlock,
+		ProvingTimeMs: uint64(c.ProvingTime.Milliseconds()),
+		ProofSize:     uint64(len(c.AggchainProof.SP1StarkProof.Proof)),
+	}
+}
+
+func (p *ProofStats) String() string {
+	if p == nil {
+		return NilStr
+	}
+	return fmt.Sprintf("proofStats{height:%d, retry:%d, blocks:%d-%d, provingTime:%s, proofSize:%d}",
+		p.Height, p.RetryCount, p.FromBlock, p.ToBlock, p.ProvingTime(), p.ProofSize)
+}
@@ -14,6 +14,55 @@ const (
 	StatusCertificateStage     AggsenderStatusType = "certificate_stage"
 )
 
+// L2ChainStatusType is the activity of the L2 chain, based on the time without new blocks to certify
+type L2ChainStatusType string
+
+const (
+	L2ChainActive L2ChainStatusType = "active"
+	L2ChainIdle   L2ChainStatusType = "idle"
+	L2ChainHalted L2ChainStatusType = "halted"
+)
+
+// Level returns the value of the status for the metrics (0 = active, 1 = idle, 2 = halted)
+func (s L2ChainStatusType) Level() float64 {
+	switch s {
+	case L2ChainIdle:
+		return 1
+	case L2ChainHalted:
+		return 2 //nolint:mnd
+	default:
+		return 0
+	}
+}
+
+// L2ChainStatus is the activity of the L2 chain: it's idle or halted when it has produced no new blocks
+// beyond the last certified block for longer than the thresholds
+type L2ChainStatus struct {
+	Status    L2ChainStatusType `json:"status"`
+	LastBlock uint64            `json:"last_block"`
+	// IdleSince is the first time that there were no new blocks to certify (nil if there are)
+	IdleSince *time.Time `json:"idle_since,omitempty"`
+}
+
+// AgglayerMaintenanceStatus is reported while the agglayer is in maintenance (or temporarily unavailable)
+// and the submission of certificates is paused
+type AgglayerMaintenanceStatus struct {
+	Since time.Time `json:"since"`
+	// Attempts is the number of submissions deferred by the maintenance
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error"`
+}
+
+// MaintenanceWindowStatus is the active scheduled maintenance window of the aggsender, during which no new
+// certificate is submitted, or the next one if none is active
+type MaintenanceWindowStatus struct {
+	Schedule string    `json:"schedule"`
+	Active   bool      `json:"active"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
 type AggsenderStatus struct {
 	Running   bool                `json:"running"`
 	StartTime time.Time           `json:"start_time"`
@@ -26,6 +75,12 @@ type AggsenderInfo struct {
 	Version                  zkevm.FullVersion
 	EpochNotifierDescription string `json:"epoch_notifier_description"`
 	NetworkID                uint32 `json:"network_id"`
+	// L2Chain is nil if the detection of the L2 chain halts is disabled
+	L2Chain *L2ChainStatus `json:"l2_chain,omitempty"`
+	// AgglayerMaintenance is nil unless the agglayer is in maintenance
+	AgglayerMaintenance *AgglayerMaintenanceStatus `json:"agglayer_maintenance,omitempty"`
+	// MaintenanceWindow is nil if there are no scheduled maintenance windows
+	MaintenanceWindow *MaintenanceWindowStatus `json:"maintenance_window,omitempty"`
 }
 
 func (a *AggsenderStatus) Start(startTime time.Time) {
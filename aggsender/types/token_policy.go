@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenPolicyConfig excludes from the certificates the bridge exits and imported bridge exits of assets
+// with the configured origin token addresses or origin networks (e.g. sanctioned assets). The message
+// exits are never excluded
+type TokenPolicyConfig struct {
+	// DeniedTokens are the origin token addresses whose exits are excluded
+	DeniedTokens []common.Address `mapstructure:"DeniedTokens"`
+	// DeniedOriginNetworks are the origin networks whose tokens are excluded
+	DeniedOriginNetworks []uint32 `mapstructure:"DeniedOriginNetworks"`
+	// AllowedTokens, if not empty, are the only origin token addresses whose exits are included
+	AllowedTokens []common.Address `mapstructure:"AllowedTokens"`
+	// AllowedOriginNetworks, if not empty, are the only origin networks whose tokens are included
+	AllowedOriginNetworks []uint32 `mapstructure:"AllowedOriginNetworks"`
+}
+
+// IsEnabled returns true if any token or origin network is allowed or denied
+func (c TokenPolicyConfig) IsEnabled() bool {
+	return len(c.DeniedTokens) > 0 || len(c.DeniedOriginNetworks) > 0 ||
+		len(c.AllowedTokens) > 0 || len(c.AllowedOriginNetworks) > 0
+}
+
+// Validate checks that no token or origin network is both allowed and denied
+func (c TokenPolicyConfig) Validate() error {
+	for _, token := range c.DeniedTokens {
+		if slices.Contains(c.AllowedTokens, token) {
+			return fmt.Errorf("TokenPolicy: token %s is both allowed and denied", token.Hex())
+		}
+	}
+	for _, network := range c.DeniedOriginNetworks {
+		if slices.Contains(c.AllowedOriginNetworks, network) {
+			return fmt.Errorf("TokenPolicy: origin network %d is both allowed and denied", network)
+		}
+	}
+	return nil
+}
+
+// Excludes returns the reason why the exit of an asset is excluded, or an empty string if it's included
+func (c TokenPolicyConfig) Excludes(originNetwork uint32, originToken common.Address) string {
+	switch {
+	case slices.Contains(c.DeniedTokens, originToken):
+		return "denied token"
+	case slices.Contains(c.DeniedOriginNetworks, originNetwork):
+		return "denied origin network"
+	case len(c.AllowedTokens) > 0 && !slices.Contains(c.AllowedTokens, originToken):
+		return "token not allowed"
+	case len(c.AllowedOriginNetworks) > 0 && !slices.Contains(c.AllowedOriginNetworks, originNetwork):
+		return "origin network not allowed"
+	default:
+		return ""
+	}
+}
+
+// String returns a string representation of the config
+func (c TokenPolicyConfig) String() string {
+	if !c.IsEnabled() {
+		return "TokenPolicy{disabled}"
+	}
+	return fmt.Sprintf("TokenPolicy{deniedTokens:%d, deniedNetworks:%v, allowedTokens:%d, allowedNetworks:%v}",
+		len(c.DeniedTokens), c.DeniedOriginNetworks, len(c.AllowedTokens), c.AllowedOriginNetworks)
+}
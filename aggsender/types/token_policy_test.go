@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenPolicyExcludes(t *testing.T) {
+	t.Parallel()
+
+	token1 := common.HexToAddress("0x1")
+	token2 := common.HexToAddress("0x2")
+	tests := []struct {
+		name           string
+		policy         TokenPolicyConfig
+		originNetwork  uint32
+		originToken    common.Address
+		expectedReason string
+	}{
+		{name: "disabled", originToken: token1},
+		{
+			name:           "denied token",
+			policy:         TokenPolicyConfig{DeniedTokens: []common.Address{token1}},
+			originToken:    token1,
+			expectedReason: "denied token",
+		},
+		{
+			name:        "token not denied",
+			policy:      TokenPolicyConfig{DeniedTokens: []common.Address{token1}},
+			originToken: token2,
+		},
+		{
+			name:           "denied origin network",
+			policy:         TokenPolicyConfig{DeniedOriginNetworks: []uint32{7}},
+			originNetwork:  7,
+			originToken:    token1,
+			expectedReason: "denied origin network",
+		},
+		{
+			name:          "allowed token",
+			policy:        TokenPolicyConfig{AllowedTokens: []common.Address{token1}},
+			originNetwork: 7,
+			originToken:   token1,
+		},
+		{
+			name:           "token not allowed",
+			policy:         TokenPolicyConfig{AllowedTokens: []common.Address{token1}},
+			originToken:    token2,
+			expectedReason: "token not allowed",
+		},
+		{
+			name:           "origin network not allowed",
+			policy:         TokenPolicyConfig{AllowedOriginNetworks: []uint32{0}},
+			originNetwork:  7,
+			originToken:    token1,
+			expectedReason: "origin network not allowed",
+		},
+		{
+			name: "denied token of an allowed network",
+			policy: TokenPolicyConfig{
+				AllowedOriginNetworks: []uint32{0},
+				DeniedTokens:          []common.Address{token1},
+			},
+			originToken:    token1,
+			expectedReason: "denied token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.expectedReason, tt.policy.Excludes(tt.originNetwork, tt.originToken))
+		})
+	}
+}
+
+func TestTokenPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	token := common.HexToAddress("0x1")
+	require.NoError(t, TokenPolicyConfig{}.Validate())
+	require.NoError(t, TokenPolicyConfig{DeniedTokens: []common.Address{token}, AllowedOriginNetworks: []uint32{1}}.Validate())
+	require.ErrorContains(t, TokenPolicyConfig{
+		DeniedTokens:  []common.Address{token},
+		AllowedTokens: []common.Address{token},
+	}.Validate(), "token 0x0000000000000000000000000000000000000001 is both allowed and denied")
+	require.ErrorContains(t, TokenPolicyConfig{
+		DeniedOriginNetworks:  []uint32{1},
+		AllowedOriginNetworks: []uint32{1},
+	}.Validate(), "origin network 1 is both allowed and denied")
+	require.False(t, TokenPolicyConfig{}.IsEnabled())
+	require.Equal(t, "TokenPolicy{disabled}", TokenPolicyConfig{}.String())
+}
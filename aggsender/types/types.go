@@ -181,6 +181,12 @@ type CertificateHeader struct {
 	CertType CertificateType `meddler:"cert_type"`
 	// This is the origin of this data, it can be from the AggLayer or from the local sender
 	CertSource CertificateSource `meddler:"cert_source"`
+	// ErrorCategory is the root cause of the error if the certificate is InError
+	ErrorCategory CertificateErrorCategory `meddler:"error_category"`
+	// Tags identify the deployment that sent the certificate (only the certificates sent by this aggsender)
+	Tags CertificateTags `meddler:"tags,certificatetags"`
+	// BuildEpoch is the epoch in which the build of the certificate started, nil if it's unknown
+	BuildEpoch *uint64 `meddler:"build_epoch"`
 }
 
 func (c *CertificateHeader) String() string {
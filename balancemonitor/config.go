@@ -0,0 +1,50 @@
+package balancemonitor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// Config checks the balance of an account used for on-chain actions and alerts when it's lower than
+// MinBalance, so a lack of funds is noticed before the transactions fail with out-of-gas errors
+type Config struct {
+	// Enabled enables the balance checks
+	Enabled bool `mapstructure:"Enabled"`
+	// MinBalance is the balance in wei below which the account is alerted
+	MinBalance uint64 `mapstructure:"MinBalance"`
+	// CheckInterval is the interval of the balance checks (the balance is also checked at startup)
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+	// FaucetURL is the faucet API requested (POST with a JSON body with the address) to fund the account
+	// when its balance is low. It's intended for testnets, empty means that the low balance is only alerted
+	FaucetURL string `mapstructure:"FaucetURL"`
+	// FaucetTimeout is the timeout of the faucet requests
+	FaucetTimeout types.Duration `mapstructure:"FaucetTimeout"`
+	// FaucetCooldown is the minimum time between two faucet requests, so the faucet is not requested
+	// again before its funds arrive
+	FaucetCooldown types.Duration `mapstructure:"FaucetCooldown"`
+}
+
+// Validate checks that the threshold and the interval are set if the checks are enabled
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MinBalance == 0 {
+		return errors.New("BalanceMonitor.MinBalance must be greater than 0")
+	}
+	if c.CheckInterval.Duration <= 0 {
+		return errors.New("BalanceMonitor.CheckInterval must be greater than 0")
+	}
+	return nil
+}
+
+// String returns a string representation of the config
+func (c Config) String() string {
+	if !c.Enabled {
+		return "BalanceMonitor{disabled}"
+	}
+	return fmt.Sprintf("BalanceMonitor{minBalance:%d, interval:%s, faucet:%t}",
+		c.MinBalance, c.CheckInterval, c.FaucetURL != "")
+}
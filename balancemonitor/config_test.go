@@ -0,0 +1,17 @@
+package balancemonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	interval := types.NewDuration(time.Minute)
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{Enabled: true, MinBalance: 1, CheckInterval: interval}.Validate())
+	require.ErrorContains(t, Config{Enabled: true, CheckInterval: interval}.Validate(), "MinBalance")
+	require.ErrorContains(t, Config{Enabled: true, MinBalance: 1}.Validate(), "CheckInterval")
+}
@@ -0,0 +1,189 @@
+package balancemonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/prometheus"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// maxFaucetResponseBody is the number of bytes of the faucet response body included in the errors
+	maxFaucetResponseBody = 512
+
+	balanceMetricsAccountLabel = "account"
+	lowBalanceTotal            = "balance_monitor_low_balance_total"
+	faucetRequestsTotal        = "balance_monitor_faucet_requests_total"
+)
+
+var registerBalanceMetricsOnce sync.Once
+
+// faucetRequest is the JSON body posted to the faucet to fund an account
+type faucetRequest struct {
+	Address common.Address `json:"address"`
+}
+
+// BalanceMonitor checks the balance of an account at startup and periodically, and alerts (and requests
+// the faucet, if it's configured) when it's lower than the configured threshold
+type BalanceMonitor struct {
+	name       string
+	address    common.Address
+	client     aggkittypes.RPCClienter
+	cfg        Config
+	logger     aggkitcommon.Logger
+	httpClient *http.Client
+	timeNowFn  func() time.Time
+	// lastFaucetRequest is the time of the last faucet request, used to apply the cooldown
+	lastFaucetRequest time.Time
+}
+
+// NewBalanceMonitor creates a BalanceMonitor of the account with the given name (used as metrics label).
+// It returns nil if the balance checks are disabled
+func NewBalanceMonitor(logger aggkitcommon.Logger, name string, address common.Address,
+	client aggkittypes.RPCClienter, cfg Config) *BalanceMonitor {
+	if !cfg.Enabled {
+		return nil
+	}
+	registerBalanceMetrics(name)
+	return &BalanceMonitor{
+		name:       name,
+		address:    address,
+		client:     client,
+		cfg:        cfg,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: cfg.FaucetTimeout.Duration},
+		timeNowFn:  time.Now,
+	}
+}
+
+// Start checks the balance at startup and then periodically until the context is done
+func (m *BalanceMonitor) Start(ctx context.Context) {
+	m.check(ctx)
+	ticker := time.NewTicker(m.cfg.CheckInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check alerts if the balance of the account is lower than MinBalance, and requests the faucet. It
+// returns true if the balance is low. The errors are only logged, so they never stop the monitor
+func (m *BalanceMonitor) check(ctx context.Context) bool {
+	balance, err := m.balance()
+	if err != nil {
+		m.logger.Warnf("failed to get the balance of the %s account %s: %v", m.name, m.address.Hex(), err)
+		return false
+	}
+	prometheus.GaugeSet(balanceGaugeName(m.name), weiToEther(balance))
+	if balance.Cmp(new(big.Int).SetUint64(m.cfg.MinBalance)) >= 0 {
+		return false
+	}
+	prometheus.CounterVecInc(lowBalanceTotal, m.name)
+	m.logger.Warnf("the balance of the %s account %s is low: %s wei, minimum %d wei (MinBalance). "+
+		"Fund it before its transactions fail", m.name, m.address.Hex(), balance.String(), m.cfg.MinBalance)
+	if err := m.requestFaucet(ctx); err != nil {
+		m.logger.Errorf("error requesting the faucet to fund the %s account %s: %v", m.name, m.address.Hex(), err)
+	}
+	return true
+}
+
+// balance returns the balance of the account at the latest block
+func (m *BalanceMonitor) balance() (*big.Int, error) {
+	var balance hexutil.Big
+	if err := m.client.Call(&balance, "eth_getBalance", m.address, "latest"); err != nil {
+		return nil, err
+	}
+	return balance.ToInt(), nil
+}
+
+// requestFaucet posts the address of the account to the faucet, if it's configured and the cooldown
+// since the last request has elapsed
+func (m *BalanceMonitor) requestFaucet(ctx context.Context) error {
+	if m.cfg.FaucetURL == "" {
+		return nil
+	}
+	now := m.timeNowFn()
+	if !m.lastFaucetRequest.IsZero() && now.Sub(m.lastFaucetRequest) < m.cfg.FaucetCooldown.Duration {
+		m.logger.Debugf("skipping the faucet request of the %s account, last request at %s",
+			m.name, m.lastFaucetRequest.Format(time.RFC3339))
+		return nil
+	}
+	m.lastFaucetRequest = now
+	prometheus.CounterVecInc(faucetRequestsTotal, m.name)
+
+	body, err := json.Marshal(faucetRequest{Address: m.address})
+	if err != nil {
+		return fmt.Errorf("error encoding the faucet request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.cfg.FaucetURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating the faucet request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending the faucet request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxFaucetResponseBody))
+		return fmt.Errorf("the faucet answered with status %d: %s", resp.StatusCode, string(respBody))
+	}
+	m.logger.Infof("requested the faucet to fund the %s account %s", m.name, m.address.Hex())
+	return nil
+}
+
+// weiToEther converts the balance to ether, losing precision, to be exposed as a metric
+func weiToEther(wei *big.Int) float64 {
+	ether, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
+	return ether
+}
+
+func balanceGaugeName(name string) string {
+	return fmt.Sprintf("balance_monitor_%s_balance_ether", name)
+}
+
+// registerBalanceMetrics registers the gauge of the balance of the account and the counters shared by
+// all the accounts (registered only once and labelled by account name)
+func registerBalanceMetrics(name string) {
+	prometheus.RegisterGauges(prometheusClient.GaugeOpts{
+		Name: balanceGaugeName(name),
+		Help: fmt.Sprintf("[BalanceMonitor] balance in ether of the %s account", name),
+	})
+	registerBalanceMetricsOnce.Do(func() {
+		prometheus.RegisterCounterVecs(
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: lowBalanceTotal,
+					Help: "[BalanceMonitor] number of checks in which the balance of the account was lower than MinBalance",
+				},
+				Labels: []string{balanceMetricsAccountLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: faucetRequestsTotal,
+					Help: "[BalanceMonitor] number of requests to the faucet to fund the account",
+				},
+				Labels: []string{balanceMetricsAccountLabel},
+			},
+		)
+	})
+}
@@ -0,0 +1,99 @@
+package balancemonitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testAddress = common.HexToAddress("0x1234")
+
+func mockBalance(client *mocks.RPCClienter, balance int64) {
+	client.EXPECT().Call(mock.Anything, "eth_getBalance", testAddress, "latest").
+		Run(func(result any, method string, args ...any) {
+			*result.(*hexutil.Big) = hexutil.Big(*big.NewInt(balance))
+		}).Return(nil).Once()
+}
+
+func TestNewBalanceMonitorDisabled(t *testing.T) {
+	require.Nil(t, NewBalanceMonitor(log.WithFields("test", "balance-monitor"), "test", testAddress,
+		mocks.NewRPCClienter(t), Config{}))
+}
+
+func TestBalanceMonitorCheck(t *testing.T) {
+	client := mocks.NewRPCClienter(t)
+	sut := NewBalanceMonitor(log.WithFields("test", "balance-monitor"), "test", testAddress, client,
+		Config{Enabled: true, MinBalance: 100, CheckInterval: types.NewDuration(time.Minute)})
+	require.NotNil(t, sut)
+
+	mockBalance(client, 100)
+	require.False(t, sut.check(context.Background()))
+
+	mockBalance(client, 99)
+	require.True(t, sut.check(context.Background()))
+
+	// the errors getting the balance are only logged
+	client.EXPECT().Call(mock.Anything, "eth_getBalance", testAddress, "latest").
+		Return(errors.New("rpc error")).Once()
+	require.False(t, sut.check(context.Background()))
+}
+
+func TestBalanceMonitorFaucet(t *testing.T) {
+	var requests []faucetRequest
+	statusCode := http.StatusOK
+	faucet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req faucetRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		w.WriteHeader(statusCode)
+	}))
+	defer faucet.Close()
+
+	client := mocks.NewRPCClienter(t)
+	sut := NewBalanceMonitor(log.WithFields("test", "balance-monitor"), "test", testAddress, client, Config{
+		Enabled:        true,
+		MinBalance:     100,
+		CheckInterval:  types.NewDuration(time.Minute),
+		FaucetURL:      faucet.URL,
+		FaucetTimeout:  types.NewDuration(time.Second),
+		FaucetCooldown: types.NewDuration(time.Hour),
+	})
+	now := time.Now()
+	sut.timeNowFn = func() time.Time { return now }
+
+	// the faucet is not requested if the balance is enough
+	mockBalance(client, 100)
+	require.False(t, sut.check(context.Background()))
+	require.Empty(t, requests)
+
+	mockBalance(client, 10)
+	require.True(t, sut.check(context.Background()))
+	require.Equal(t, []faucetRequest{{Address: testAddress}}, requests)
+
+	// the faucet is not requested again during the cooldown
+	mockBalance(client, 10)
+	require.True(t, sut.check(context.Background()))
+	require.Len(t, requests, 1)
+
+	now = now.Add(time.Hour)
+	statusCode = http.StatusTooManyRequests
+	require.ErrorContains(t, sut.requestFaucet(context.Background()), "the faucet answered with status 429")
+	require.Len(t, requests, 2)
+}
+
+func TestWeiToEther(t *testing.T) {
+	require.InDelta(t, 1.5, weiToEther(big.NewInt(1_500_000_000_000_000_000)), 1e-9)
+}
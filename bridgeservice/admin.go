@@ -0,0 +1,39 @@
+package bridgeservice
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceConfig is the configuration of the jobs and of the admin endpoints of the bridge service
+type ServiceConfig struct {
+	// AdminToken is the token required by the /admin endpoints, sent as "Authorization: Bearer <token>".
+	// The admin endpoints are disabled if it's empty
+	AdminToken string `mapstructure:"AdminToken"`
+
+	// ClaimsReconciliationInterval is the interval at which the bridge service compares the L2 claims
+	// with the L1 bridges to detect duplicated and orphan claims (0 = disabled)
+	ClaimsReconciliationInterval types.Duration `mapstructure:"ClaimsReconciliationInterval"`
+}
+
+// adminOnly returns the handler guarded by the admin token. The guard wraps the handler, instead of being
+// a middleware of the admin group, so the HEAD routes registered from the GET ones are guarded too
+func (b *BridgeService) adminOnly(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if b.adminToken == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden,
+				gin.H{"error": "the admin endpoints are disabled: no admin token configured"})
+			return
+		}
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(b.adminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+		handler(c)
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"math"
 	"net/http"
 	"os"
-	"strconv"
 	"time"
 
 	"github.com/agglayer/aggkit"
@@ -27,9 +26,12 @@ import (
 	"github.com/agglayer/aggkit/bridgeservice/types"
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/networks"
 	tree "github.com/agglayer/aggkit/tree/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginswagger "github.com/swaggo/gin-swagger"
@@ -49,11 +51,18 @@ const (
 	depositCountParam = "deposit_count"
 	fromAddressParam  = "from_address"
 	leafIndexParam    = "leaf_index"
+	gerParam          = "global_exit_root"
 	globalIndexParam  = "global_index"
 	includeAllFields  = "include_all_fields"
+	includeOrigin     = "include_origin"
+	exportTypeParam   = "type"
+	exportFormatParam = "format"
+	fromBlockParam    = "from_block"
+	toBlockParam      = "to_block"
+	finalityParam     = "finality"
+	decodeMetadata    = "decode_metadata"
 
 	binarySearchDivider = 2
-	mainnetNetworkID    = 0
 
 	errNetworkID         = "unsupported network id: %v"
 	errSetupRequest      = "failed to setup request: %v"
@@ -70,6 +79,28 @@ type Config struct {
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
 	NetworkID    uint32
+	Networks     *networks.Registry
+	// NodeConfig are the contract addresses and finality settings used by the syncers, returned by /config
+	NodeConfig NodeConfig
+	// AdminToken is the bearer token of the /admin endpoints (empty = disabled)
+	AdminToken string
+	// ClaimsReconciliationInterval is the interval of the claims reconciliation job (0 = disabled)
+	ClaimsReconciliationInterval time.Duration
+	// ClaimProofPrecomputeDeposits is the number of most recent unclaimed deposits per network whose
+	// claim proofs are precomputed (0 = disabled)
+	ClaimProofPrecomputeDeposits uint32
+	// ClaimProofPrecomputeInterval is the interval at which the precomputation job checks for a new
+	// l1 info tree root
+	ClaimProofPrecomputeInterval time.Duration
+	// EndpointTimeouts overrides the ReadTimeout of the queries of the given endpoints
+	// (by route relative to the prefix, e.g. "claim-proof")
+	EndpointTimeouts map[string]time.Duration
+	// SlowRequestThreshold is the latency from which a request is logged as slow (0 = disabled)
+	SlowRequestThreshold time.Duration
+	// ReadinessMaxL1BlocksBehind and ReadinessMaxL2BlocksBehind are the maximum number of blocks that
+	// the L1 and L2 bridge syncers can be behind the chain for the service to be ready
+	ReadinessMaxL1BlocksBehind uint64
+	ReadinessMaxL2BlocksBehind uint64
 }
 
 // BridgeService contains implementations for the bridge service endpoints
@@ -80,11 +111,22 @@ type BridgeService struct {
 	readTimeout  time.Duration
 	writeTimeout time.Duration
 	networkID    uint32
+	networks     *networks.Registry
+	nodeConfig   NodeConfig
 	l1InfoTree   L1InfoTreer
 	injectedGERs LastGERer
 	bridgeL1     Bridger
 	bridgeL2     Bridger
 
+	// endpointTimeouts are the overrides of the readTimeout by endpoint
+	endpointTimeouts map[string]time.Duration
+	adminToken       string
+	claimsReconciler *claimsReconciler
+	claimProofCache  *claimProofCache
+	// maxL1BlocksBehind and maxL2BlocksBehind are the freshness thresholds of the readiness endpoint
+	maxL1BlocksBehind uint64
+	maxL2BlocksBehind uint64
+
 	router *gin.Engine
 }
 
@@ -114,7 +156,9 @@ func New(
 
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(RequestIDHandler())
 	router.Use(LoggerHandler(cfg.Logger))
+	router.Use(SlowRequestHandler(cfg.Logger, meter, cfg.SlowRequestThreshold))
 
 	b := &BridgeService{
 		logger:       cfg.Logger,
@@ -123,14 +167,30 @@ func New(
 		readTimeout:  cfg.ReadTimeout,
 		writeTimeout: cfg.WriteTimeout,
 		networkID:    cfg.NetworkID,
+		networks:     cfg.Networks,
+		nodeConfig:   cfg.NodeConfig,
 		l1InfoTree:   l1InfoTree,
 		injectedGERs: injectedGERs,
 		bridgeL1:     bridgeL1,
 		bridgeL2:     bridgeL2,
 		router:       router,
+
+		endpointTimeouts:  cfg.EndpointTimeouts,
+		adminToken:        cfg.AdminToken,
+		maxL1BlocksBehind: cfg.ReadinessMaxL1BlocksBehind,
+		maxL2BlocksBehind: cfg.ReadinessMaxL2BlocksBehind,
+	}
+
+	if cfg.ClaimsReconciliationInterval > 0 {
+		b.claimsReconciler = newClaimsReconciler(cfg.Logger, meter, bridgeL1, bridgeL2, cfg.ClaimsReconciliationInterval)
+	}
+	if cfg.ClaimProofPrecomputeDeposits > 0 && cfg.ClaimProofPrecomputeInterval > 0 {
+		b.claimProofCache = newClaimProofCache(cfg.Logger, meter, b,
+			cfg.ClaimProofPrecomputeDeposits, cfg.ClaimProofPrecomputeInterval)
 	}
 
 	b.registerRoutes()
+	b.checkEndpointTimeouts()
 	cfg.Logger.Info("bridge service initialized successfully")
 
 	return b
@@ -160,13 +220,14 @@ func LoggerHandler(logger aggkitcommon.Logger) gin.HandlerFunc {
 		}
 
 		logger.Debugf(
-			"[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+			"[GIN] %v | %3d | %13v | %15s | %-7s %#v | request id: %s\n%s",
 			start.Format("2006/01/02 - 15:04:05"),
 			statusCode,
 			latency,
 			clientIP,
 			method,
 			path,
+			requestID(c),
 			errorMessage,
 		)
 	}
@@ -176,11 +237,15 @@ func LoggerHandler(logger aggkitcommon.Logger) gin.HandlerFunc {
 func (b *BridgeService) registerRoutes() {
 	// Health check endpoint at root path
 	b.router.GET("/", b.HealthCheckHandler)
+	// Readiness endpoint, for the load balancers
+	b.router.GET("/ready", b.ReadinessHandler)
 
 	bridgeGroup := b.router.Group(BridgeV1Prefix)
 	{
 		bridgeGroup.GET("/bridges", b.GetBridgesHandler)
 		bridgeGroup.GET("/claims", b.GetClaimsHandler)
+		bridgeGroup.GET("/claims/:global_index", b.GetClaimByGlobalIndexHandler)
+		bridgeGroup.GET("/tx/:tx_hash", b.GetTxEventsHandler)
 		bridgeGroup.GET("/token-mappings", b.GetTokenMappingsHandler)
 		bridgeGroup.GET("/legacy-token-migrations", b.GetLegacyTokenMigrationsHandler)
 		bridgeGroup.GET("/l1-info-tree-index", b.L1InfoTreeIndexForBridgeHandler)
@@ -188,6 +253,15 @@ func (b *BridgeService) registerRoutes() {
 		bridgeGroup.GET("/claim-proof", b.ClaimProofHandler)
 		bridgeGroup.GET("/last-reorg-event", b.GetLastReorgEventHandler)
 		bridgeGroup.GET("/sync-status", b.GetSyncStatusHandler)
+		bridgeGroup.GET("/export", b.ExportHandler)
+		bridgeGroup.POST("/verify-claim-proof", b.VerifyClaimProofHandler)
+		bridgeGroup.GET("/networks", b.GetNetworksHandler)
+		bridgeGroup.GET("/config", b.GetConfigHandler)
+		bridgeGroup.GET("/usd-value-stats", b.GetUSDValueStatsHandler)
+		bridgeGroup.GET("/claim-gas-stats", b.GetClaimGasStatsHandler)
+
+		adminGroup := bridgeGroup.Group("/admin")
+		adminGroup.GET("/claims-reconciliation", b.adminOnly(b.GetClaimsReconciliationHandler))
 
 		// Swagger docs endpoint
 		bridgeGroup.GET("/swagger/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
@@ -197,6 +271,8 @@ func (b *BridgeService) registerRoutes() {
 			ctx.Redirect(http.StatusFound, BridgeV1Prefix+"/swagger/index.html")
 		})
 	}
+
+	b.registerHeadAndOptionsRoutes()
 }
 
 // Start starts the HTTP bridge service
@@ -205,11 +281,23 @@ func (b *BridgeService) Start(ctx context.Context) {
 		Addr:         b.address,
 		Handler:      b.router,
 		ReadTimeout:  b.readTimeout,
-		WriteTimeout: b.writeTimeout,
+		WriteTimeout: b.serverWriteTimeout(),
+	}
+
+	if b.claimsReconciler != nil {
+		go b.claimsReconciler.start(ctx)
+	}
+	if b.claimProofCache != nil {
+		go b.claimProofCache.start(ctx)
+	}
+
+	lis, err := aggkitcommon.Listen(b.address)
+	if err != nil {
+		b.logger.Panicf("failed to create the listener of the bridge service: %v", err)
 	}
 
 	b.logger.Infof("Bridge service listening on %s...", b.address)
-	err := srv.ListenAndServe()
+	err = srv.Serve(lis)
 	if err != nil && err != http.ErrServerClosed {
 		b.logger.Panicf("failed to start bridge service: %v", err)
 	}
@@ -257,6 +345,7 @@ func (b *BridgeService) HealthCheckHandler(c *gin.Context) {
 //
 // @Summary Get bridges
 // @Description Returns a paginated list of bridge events for the specified network.
+// @Description Each bridge is annotated with the finality of its block (pending, safe or finalized).
 // @Tags bridges
 // @Param network_id query uint32 true "Target network ID"
 // @Param page_number query uint32 false "Page number (default 1)"
@@ -264,6 +353,10 @@ func (b *BridgeService) HealthCheckHandler(c *gin.Context) {
 // @Param deposit_count query uint64 false "Filter by deposit count"
 // @Param from_address query string false "Filter by from address"
 // @Param network_ids query []uint32 false "Filter by one or more network IDs"
+// @Param finality query string false "Filter by finality of the block (pending, safe or finalized)"
+// @Param decode_metadata query bool false "Whether to decode the token metadata (default false)"
+// @Param amount_format query string false "Format of the amounts: wei (default) or decimal (adjusted to its decimals)"
+// @Param address_format query string false "Format of the addresses: checksum (EIP-55, default) or lowercase"
 // @Produce json
 // @Success 200 {object} types.BridgesResult
 // @Failure 400 {object} types.ErrorResponse "Bad Request"
@@ -292,12 +385,38 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 		depositCountPtr = &depositCount
 	}
 
-	fromAddress := c.Query(fromAddressParam)
+	fromAddress, err := parseAddressQuery(c, fromAddressParam)
+	if err != nil {
+		b.logger.Warnf("invalid from address parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	networkIDs, err := parseUint32SliceParam(c, networkIDsParam)
 	if err != nil {
 		b.logger.Warnf("invalid network IDs parameter: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid network_ids: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	finality, err := parseFinalityQuery(c)
+	if err != nil {
+		b.logger.Warnf("invalid finality parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	decodeMetadataFlag, err := parseBoolQuery(c, decodeMetadata, false)
+	if err != nil {
+		b.logger.Warnf("invalid decode_metadata parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := parseResponseFormat(c)
+	if err != nil {
+		b.logger.Warnf("invalid format parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -310,8 +429,15 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 	defer cancel()
 
 	b.logger.Debugf(
-		"fetching bridges (network id=%d, page=%d, size=%d, deposit_count=%v, network_ids=%v, from_address=%s)",
-		networkID, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress)
+		"fetching bridges (network id=%d, page=%d, size=%d, deposit_count=%v, network_ids=%v, from_address=%s, "+
+			"finality=%s)", networkID, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress, finality)
+
+	finalityBlocks, blockNumFilter, err := b.getFinalityFilter(ctx, networkID, finality)
+	if err != nil {
+		b.logger.Errorf("failed to get the finality of network %d: %v", networkID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	var (
 		bridges []*bridgesync.Bridge
@@ -319,8 +445,9 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 	)
 
 	switch {
-	case networkID == mainnetNetworkID:
-		bridges, count, err = b.bridgeL1.GetBridgesPaged(ctx, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress)
+	case b.networks.IsL1(networkID):
+		bridges, count, err = b.bridgeL1.GetBridgesPaged(ctx, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress,
+			blockNumFilter)
 		if err != nil {
 			b.logger.Errorf("failed to get bridges for L1 network: %v", err)
 			c.JSON(http.StatusInternalServerError,
@@ -328,7 +455,8 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 			return
 		}
 	case networkID == b.networkID:
-		bridges, count, err = b.bridgeL2.GetBridgesPaged(ctx, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress)
+		bridges, count, err = b.bridgeL2.GetBridgesPaged(ctx, pageNumber, pageSize, depositCountPtr, networkIDs, fromAddress,
+			blockNumFilter)
 		if err != nil {
 			b.logger.Errorf("failed to get bridges for L2 network (ID=%d): %v", networkID, err)
 			c.JSON(http.StatusInternalServerError,
@@ -342,12 +470,26 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 	}
 
 	b.logger.Debugf("successfully retrieved %d bridges for network %d", count, networkID)
-	bridgeResponses := aggkitcommon.MapSlice(bridges, NewBridgeResponse)
+	usdValues := b.getUSDValues(ctx, networkID, bridgesync.USDValueEventBridge,
+		aggkitcommon.MapSlice(bridges, func(bridge *bridgesync.Bridge) uint64 { return bridge.BlockNum }))
+	bridgeResponses := aggkitcommon.MapSlice(bridges, func(bridge *bridgesync.Bridge) *types.BridgeResponse {
+		response := NewBridgeResponse(bridge)
+		response.ValueUSD = usdValueOf(usdValues, bridge.BlockNum, bridge.BlockPos)
+		if finalityBlocks != nil {
+			response.Finality = string(finalityBlocks.Status(bridge.BlockNum))
+		}
+		if decodeMetadataFlag {
+			response.DecodedMetadata = b.decodeMetadataOrNil(bridge.Metadata)
+		}
+		format.applyToBridge(response, bridge)
+		return response
+	})
 
 	c.JSON(http.StatusOK,
 		types.BridgesResult{
-			Bridges: bridgeResponses,
-			Count:   count,
+			Bridges:            bridgeResponses,
+			Count:              count,
+			PaginationMetadata: types.NewPaginationMetadata(count, pageNumber, pageSize, requestID(c)),
 		})
 }
 
@@ -355,6 +497,9 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 //
 // @Summary Get claims
 // @Description Returns a paginated list of claims for the specified network.
+// @Description Each claim is annotated with the finality of its block (pending, safe or finalized).
+// @Description With include_origin, each claim includes the bridge of its origin network (decoded from the
+// @Description global index), if the origin network is indexed by this service.
 // @Tags claims
 // @Param network_id query uint32 true "Target network ID"
 // @Param page_number query uint32 false "Page number (default 1)"
@@ -362,6 +507,10 @@ func (b *BridgeService) GetBridgesHandler(c *gin.Context) {
 // @Param network_ids query []uint32 false "Filter by one or more network IDs"
 // @Param from_address query string false "Filter by from address"
 // @Param include_all_fields query bool false "Whether to include full response fields (default false)"
+// @Param include_origin query bool false "Whether to include the origin bridge of each claim (default false)"
+// @Param finality query string false "Filter by finality of the block (pending, safe or finalized)"
+// @Param amount_format query string false "Format of the amounts: wei (default) or decimal (adjusted to its decimals)"
+// @Param address_format query string false "Format of the addresses: checksum (EIP-55, default) or lowercase"
 // @Produce json
 // @Success 200 {object} types.ClaimsResult
 // @Failure 400 {object} types.ErrorResponse "Bad Request"
@@ -385,17 +534,40 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 		return
 	}
 
-	fromAddress := c.Query(fromAddressParam)
+	fromAddress, err := parseAddressQuery(c, fromAddressParam)
+	if err != nil {
+		b.logger.Warnf("invalid from address parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Parse include_all_fields parameter (default to false)
-	includeAllFieldsFlag := false
-	if includeAllFieldsStr := c.Query(includeAllFields); includeAllFieldsStr != "" {
-		includeAllFieldsFlag, err = strconv.ParseBool(includeAllFieldsStr)
-		if err != nil {
-			b.logger.Warnf("invalid include_all_fields parameter: %v", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_all_fields parameter"})
-			return
-		}
+	includeAllFieldsFlag, err := parseBoolQuery(c, includeAllFields, false)
+	if err != nil {
+		b.logger.Warnf("invalid include_all_fields parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	includeOriginFlag, err := parseBoolQuery(c, includeOrigin, false)
+	if err != nil {
+		b.logger.Warnf("invalid include_origin parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	finality, err := parseFinalityQuery(c)
+	if err != nil {
+		b.logger.Warnf("invalid finality parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := parseResponseFormat(c)
+	if err != nil {
+		b.logger.Warnf("invalid format parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	ctx, cancel, pageNumber, pageSize, err := b.setupRequest(c, "get_claims")
@@ -407,8 +579,16 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 	defer cancel()
 
 	b.logger.Debugf(
-		"fetching claims (network id=%d, page=%d, size=%d, network_ids=%v, from_address=%s, include_all_fields=%t)",
-		networkID, pageNumber, pageSize, networkIDs, fromAddress, includeAllFieldsFlag)
+		"fetching claims (network id=%d, page=%d, size=%d, network_ids=%v, from_address=%s, include_all_fields=%t, "+
+			"include_origin=%t, finality=%s)", networkID, pageNumber, pageSize, networkIDs, fromAddress,
+		includeAllFieldsFlag, includeOriginFlag, finality)
+
+	finalityBlocks, blockNumFilter, err := b.getFinalityFilter(ctx, networkID, finality)
+	if err != nil {
+		b.logger.Errorf("failed to get the finality of network %d: %v", networkID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	var (
 		claims []*bridgesync.Claim
@@ -416,8 +596,8 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 	)
 
 	switch {
-	case networkID == mainnetNetworkID:
-		claims, count, err = b.bridgeL1.GetClaimsPaged(ctx, pageNumber, pageSize, networkIDs, fromAddress)
+	case b.networks.IsL1(networkID):
+		claims, count, err = b.bridgeL1.GetClaimsPaged(ctx, pageNumber, pageSize, networkIDs, fromAddress, blockNumFilter)
 		if err != nil {
 			b.logger.Warnf("failed to get claims for L1 network: %v", err)
 			c.JSON(http.StatusInternalServerError,
@@ -425,7 +605,7 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 			return
 		}
 	case networkID == b.networkID:
-		claims, count, err = b.bridgeL2.GetClaimsPaged(ctx, pageNumber, pageSize, networkIDs, fromAddress)
+		claims, count, err = b.bridgeL2.GetClaimsPaged(ctx, pageNumber, pageSize, networkIDs, fromAddress, blockNumFilter)
 		if err != nil {
 			b.logger.Warnf("failed to get claims for L2 network (ID=%d): %v", networkID, err)
 			c.JSON(http.StatusInternalServerError,
@@ -438,16 +618,39 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 		return
 	}
 
+	var originBridges []*bridgesync.Bridge
+	if includeOriginFlag {
+		originBridges, err = b.getOriginBridges(ctx, claims)
+		if err != nil {
+			b.logger.Errorf("failed to get the origin bridges of the claims: %v", err)
+			c.JSON(http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to get the origin bridges of the claims, error: %s", err)})
+			return
+		}
+	}
+
+	usdValues := b.getUSDValues(ctx, networkID, bridgesync.USDValueEventClaim,
+		aggkitcommon.MapSlice(claims, func(claim *bridgesync.Claim) uint64 { return claim.BlockNum }))
 	// Use conditional function to create claim responses
 	claimResponses := make([]*types.ClaimResponse, len(claims))
 	for i, claim := range claims {
 		claimResponses[i] = NewClaimResponse(claim, includeAllFieldsFlag)
+		claimResponses[i].ValueUSD = usdValueOf(usdValues, claim.BlockNum, claim.BlockPos)
+		if finalityBlocks != nil {
+			claimResponses[i].Finality = string(finalityBlocks.Status(claim.BlockNum))
+		}
+		format.applyToClaim(claimResponses[i], claim)
+		if originBridges != nil && originBridges[i] != nil {
+			claimResponses[i].OriginBridge = NewBridgeResponse(originBridges[i])
+			format.applyToBridge(claimResponses[i].OriginBridge, originBridges[i])
+		}
 	}
 
 	c.JSON(http.StatusOK,
 		types.ClaimsResult{
-			Claims: claimResponses,
-			Count:  count,
+			Claims:             claimResponses,
+			Count:              count,
+			PaginationMetadata: types.NewPaginationMetadata(count, pageNumber, pageSize, requestID(c)),
 		})
 }
 
@@ -457,6 +660,7 @@ func (b *BridgeService) GetClaimsHandler(c *gin.Context) {
 // @Param network_id query int true "Network ID"
 // @Param page_number query int false "Page number"
 // @Param page_size query int false "Page size"
+// @Param decode_metadata query bool false "Whether to decode the token metadata (default false)"
 // @Produce json
 // @Success 200 {object} types.TokenMappingsResult
 // @Failure 400 {object} types.ErrorResponse "Bad Request"
@@ -475,6 +679,13 @@ func (b *BridgeService) GetTokenMappingsHandler(c *gin.Context) {
 		return
 	}
 
+	decodeMetadataFlag, err := parseBoolQuery(c, decodeMetadata, false)
+	if err != nil {
+		b.logger.Warnf("invalid decode_metadata parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	ctx, cancel, pageNumber, pageSize, err := b.setupRequest(c, "get_token_mappings")
 	if err != nil {
 		b.logger.Warnf(errSetupRequest, err)
@@ -489,7 +700,7 @@ func (b *BridgeService) GetTokenMappingsHandler(c *gin.Context) {
 	)
 
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		tokenMappings, tokenMappingsCount, err = b.bridgeL1.GetTokenMappings(ctx, pageNumber, pageSize)
 	case b.networkID == networkID:
 		tokenMappings, tokenMappingsCount, err = b.bridgeL2.GetTokenMappings(ctx, pageNumber, pageSize)
@@ -506,12 +717,20 @@ func (b *BridgeService) GetTokenMappingsHandler(c *gin.Context) {
 		return
 	}
 
-	tokenMappingResponses := aggkitcommon.MapSlice(tokenMappings, NewTokenMappingResponse)
+	tokenMappingResponses := aggkitcommon.MapSlice(tokenMappings,
+		func(tokenMapping *bridgesync.TokenMapping) *types.TokenMappingResponse {
+			response := NewTokenMappingResponse(tokenMapping)
+			if decodeMetadataFlag {
+				response.DecodedMetadata = b.decodeMetadataOrNil(tokenMapping.Metadata)
+			}
+			return response
+		})
 
 	c.JSON(http.StatusOK,
 		types.TokenMappingsResult{
-			TokenMappings: tokenMappingResponses,
-			Count:         tokenMappingsCount,
+			TokenMappings:      tokenMappingResponses,
+			Count:              tokenMappingsCount,
+			PaginationMetadata: types.NewPaginationMetadata(tokenMappingsCount, pageNumber, pageSize, requestID(c)),
 		})
 }
 
@@ -553,7 +772,7 @@ func (b *BridgeService) GetLegacyTokenMigrationsHandler(c *gin.Context) {
 	)
 
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		tokenMigrations, tokenMigrationsCount, err = b.bridgeL1.GetLegacyTokenMigrations(ctx, pageNumber, pageSize)
 	case b.networkID == networkID:
 		tokenMigrations, tokenMigrationsCount, err = b.bridgeL2.GetLegacyTokenMigrations(ctx, pageNumber, pageSize)
@@ -574,8 +793,9 @@ func (b *BridgeService) GetLegacyTokenMigrationsHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK,
 		types.LegacyTokenMigrationsResult{
-			TokenMigrations: tokenMigrationResponses,
-			Count:           tokenMigrationsCount,
+			TokenMigrations:    tokenMigrationResponses,
+			Count:              tokenMigrationsCount,
+			PaginationMetadata: types.NewPaginationMetadata(tokenMigrationsCount, pageNumber, pageSize, requestID(c)),
 		})
 }
 
@@ -607,7 +827,7 @@ func (b *BridgeService) L1InfoTreeIndexForBridgeHandler(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	defer cancel()
 
 	cnt, merr := b.meter.Int64Counter("l1_info_tree_index_for_bridge")
@@ -619,7 +839,7 @@ func (b *BridgeService) L1InfoTreeIndexForBridgeHandler(c *gin.Context) {
 	var l1InfoTreeIndex uint32
 
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		l1InfoTreeIndex, err = b.getFirstL1InfoTreeIndexForL1Bridge(ctx, depositCount)
 	case b.networkID == networkID:
 		l1InfoTreeIndex, err = b.getFirstL1InfoTreeIndexForL2Bridge(ctx, depositCount)
@@ -674,7 +894,7 @@ func (b *BridgeService) InjectedL1InfoLeafHandler(c *gin.Context) {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	defer cancel()
 
 	cnt, merr := b.meter.Int64Counter("injected_info_after_index")
@@ -686,7 +906,7 @@ func (b *BridgeService) InjectedL1InfoLeafHandler(c *gin.Context) {
 	var l1InfoLeaf *l1infotreesync.L1InfoTreeLeaf
 
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		l1InfoLeaf, err = b.l1InfoTree.GetInfoByIndex(ctx, l1InfoTreeIndex)
 	case b.networkID == networkID:
 		e, err := b.injectedGERs.GetFirstGERAfterL1InfoTreeIndex(ctx, l1InfoTreeIndex)
@@ -729,19 +949,23 @@ func (b *BridgeService) InjectedL1InfoLeafHandler(c *gin.Context) {
 // @Summary Get claim proof
 // @Description Returns the Merkle proofs (local and rollup exit root) and
 // @Description the corresponding L1 info tree leaf needed to verify a claim.
+// @Description The leaf is the one of leaf_index or, if global_exit_root is set, the one of the global exit root.
 // @Tags claims
 // @Param network_id query uint32 true "Target network ID"
-// @Param leaf_index query uint32 true "Index in the L1 info tree"
+// @Param leaf_index query uint32 false "Index in the L1 info tree, mandatory without global_exit_root"
+// @Param global_exit_root query string false "Global exit root to build the proofs against"
 // @Param deposit_count query uint32 true "Number of deposits in the bridge"
 // @Produce json
 // @Success 200 {object} types.ClaimProof "Merkle proofs and L1 info tree leaf"
 // @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 404 {object} types.ErrorResponse "Global exit root not found"
 // @Failure 500 {object} types.ErrorResponse "Internal Server Error"
 // @Router /claim-proof [get]
 func (b *BridgeService) ClaimProofHandler(c *gin.Context) {
-	b.logger.Debugf("ClaimProof request received (network id=%s, l1 info tree index=%s, deposit count=%s)",
-		c.Query(networkIDParam), c.Query(leafIndexParam), c.Query(depositCountParam))
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	b.logger.Debugf("ClaimProof request received (network id=%s, l1 info tree index=%s, global exit root=%s, "+
+		"deposit count=%s)", c.Query(networkIDParam), c.Query(leafIndexParam), c.Query(gerParam),
+		c.Query(depositCountParam))
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	defer cancel()
 
 	cnt, merr := b.meter.Int64Counter("claim_proof")
@@ -757,7 +981,15 @@ func (b *BridgeService) ClaimProofHandler(c *gin.Context) {
 		return
 	}
 
-	l1InfoTreeIndex, err := parseUintQuery(c, leafIndexParam, true, uint32(0))
+	ger, err := parseHash(c.Query(gerParam), gerParam, false)
+	if err != nil {
+		b.logger.Warnf("invalid global exit root parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	byGER := ger != (common.Hash{})
+
+	l1InfoTreeIndex, err := parseUintQuery(c, leafIndexParam, !byGER, uint32(0))
 	if err != nil {
 		b.logger.Warnf("invalid L1 info tree index parameter: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -771,66 +1003,147 @@ func (b *BridgeService) ClaimProofHandler(c *gin.Context) {
 		return
 	}
 
-	info, err := b.l1InfoTree.GetInfoByIndex(ctx, l1InfoTreeIndex)
+	var info *l1infotreesync.L1InfoTreeLeaf
+	if byGER {
+		info, err = b.l1InfoTree.GetInfoByGlobalExitRoot(ger)
+		if errors.Is(err, db.ErrNotFound) {
+			c.JSON(http.StatusNotFound,
+				gin.H{"error": fmt.Sprintf("global exit root %s not found in the l1 info tree", ger.Hex())})
+			return
+		}
+		if err != nil {
+			b.logger.Errorf("failed to get L1 info tree leaf for global exit root %s: %v", ger.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf(
+				"failed to get l1 info tree leaf for global exit root %s: %s", ger.Hex(), err)})
+			return
+		}
+		if c.Query(leafIndexParam) != "" && info.L1InfoTreeIndex != l1InfoTreeIndex {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(
+				"the global exit root %s is the l1 info tree leaf %d, not %d",
+				ger.Hex(), info.L1InfoTreeIndex, l1InfoTreeIndex)})
+			return
+		}
+		l1InfoTreeIndex = info.L1InfoTreeIndex
+	} else {
+		info, err = b.l1InfoTree.GetInfoByIndex(ctx, l1InfoTreeIndex)
+		if err != nil {
+			b.logger.Errorf("failed to get L1 info tree leaf for index %d: %v", l1InfoTreeIndex, err)
+			c.JSON(http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to get l1 info tree leaf for index %d: %s", l1InfoTreeIndex, err)})
+			return
+		}
+	}
+
+	if !b.networks.IsL1(networkID) && networkID != b.networkID {
+		b.logger.Warnf("unsupported network id for claim proof: %d", networkID)
+		c.JSON(http.StatusBadRequest,
+			gin.H{"error": fmt.Sprintf("failed to get claim proof, unsupported network %d", networkID)})
+		return
+	}
+
+	if proof := b.claimProofCache.get(networkID, l1InfoTreeIndex, depositCount, info); proof != nil {
+		c.JSON(http.StatusOK, proof)
+		return
+	}
+
+	if byGER {
+		// the clients choose the GER, so it can be one from before the deposit
+		included, err := b.isDepositIncluded(ctx, networkID, depositCount, info)
+		if err != nil {
+			b.logger.Errorf("failed to check the inclusion of the deposit %d of network %d in the global exit "+
+				"root %s: %v", depositCount, networkID, ger.Hex(), err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !included {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(
+				"the deposit %d of network %d is not included in the global exit root %s",
+				depositCount, networkID, ger.Hex())})
+			return
+		}
+	}
+
+	proof, err := b.computeClaimProof(ctx, networkID, l1InfoTreeIndex, depositCount, info)
 	if err != nil {
-		b.logger.Errorf("failed to get L1 info tree leaf for index %d: %v", l1InfoTreeIndex, err)
-		c.JSON(http.StatusInternalServerError,
-			gin.H{"error": fmt.Sprintf("failed to get l1 info tree leaf for index %d: %s", l1InfoTreeIndex, err)})
+		b.logger.Errorf("failed to compute the claim proof (network id=%d, leaf index=%d, deposit count=%d): %v",
+			networkID, l1InfoTreeIndex, depositCount, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	var proofLocalExitRoot tree.Proof
+	c.JSON(http.StatusOK, proof)
+}
+
+// isDepositIncluded returns if the deposit of the network (the L1 or the L2 of this service) is included
+// in the exit root of the network of the l1 info tree leaf
+func (b *BridgeService) isDepositIncluded(ctx context.Context, networkID, depositCount uint32,
+	info *l1infotreesync.L1InfoTreeLeaf) (bool, error) {
+	var (
+		root *tree.Root
+		err  error
+	)
+	if b.networks.IsL1(networkID) {
+		root, err = b.bridgeL1.GetRootByLER(ctx, info.MainnetExitRoot)
+	} else {
+		localExitRoot, lerErr := b.l1InfoTree.GetLocalExitRoot(ctx, networkID, info.RollupExitRoot)
+		if lerErr != nil {
+			if errors.Is(lerErr, db.ErrNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get local exit root from rollup exit tree, error: %w", lerErr)
+		}
+		root, err = b.bridgeL2.GetRootByLER(ctx, localExitRoot)
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		// the exit root of the network is from before its first deposit
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the root of the local exit root, error: %w", err)
+	}
+	return root.Index >= depositCount, nil
+}
+
+// computeClaimProof returns the claim proof of the deposit of the network (the L1 or the L2 of this service)
+// against the l1 info tree leaf of the given index
+func (b *BridgeService) computeClaimProof(ctx context.Context, networkID, l1InfoTreeIndex, depositCount uint32,
+	info *l1infotreesync.L1InfoTreeLeaf) (*types.ClaimProof, error) {
+	var (
+		proofLocalExitRoot tree.Proof
+		err                error
+	)
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		proofLocalExitRoot, err = b.bridgeL1.GetProof(ctx, depositCount, info.MainnetExitRoot)
 		if err != nil {
-			b.logger.Errorf("failed to get local exit proof for L1: %v", err)
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("failed to get local exit proof, error: %s", err)})
-			return
+			return nil, fmt.Errorf("failed to get local exit proof, error: %w", err)
 		}
 
 	case networkID == b.networkID:
 		localExitRoot, err := b.l1InfoTree.GetLocalExitRoot(ctx, networkID, info.RollupExitRoot)
 		if err != nil {
-			b.logger.Errorf("failed to get local exit root from rollup exit tree: %v", err)
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("failed to get local exit root from rollup exit tree, error: %s", err)})
-			return
+			return nil, fmt.Errorf("failed to get local exit root from rollup exit tree, error: %w", err)
 		}
 		proofLocalExitRoot, err = b.bridgeL2.GetProof(ctx, depositCount, localExitRoot)
 		if err != nil {
-			b.logger.Errorf("failed to get local exit proof for L2: %v", err)
-			c.JSON(http.StatusInternalServerError,
-				gin.H{"error": fmt.Sprintf("failed to get local exit proof, error: %s", err)})
-			return
+			return nil, fmt.Errorf("failed to get local exit proof, error: %w", err)
 		}
 
 	default:
-		b.logger.Warnf("unsupported network id for claim proof: %d", networkID)
-		c.JSON(http.StatusBadRequest,
-			gin.H{"error": fmt.Sprintf("failed to get claim proof, unsupported network %d", networkID)})
-		return
+		return nil, fmt.Errorf("failed to get claim proof, unsupported network %d", networkID)
 	}
 
 	proofRollupExitRoot, err := b.l1InfoTree.GetRollupExitTreeMerkleProof(ctx, networkID, info.RollupExitRoot)
 	if err != nil {
-		b.logger.Errorf("failed to get rollup exit proof (network id=%d, leaf index=%d, deposit count=%d): %v",
+		return nil, fmt.Errorf("failed to get rollup exit proof (network id=%d, leaf index=%d, deposit count=%d), error: %w",
 			networkID, l1InfoTreeIndex, depositCount, err)
-		c.JSON(http.StatusInternalServerError,
-			gin.H{
-				"error": fmt.Sprintf("failed to get rollup exit proof (network id=%d, leaf index=%d, deposit count=%d), error: %s",
-					networkID, l1InfoTreeIndex, depositCount, err)})
-		return
 	}
 
-	infoResponse := NewL1InfoTreeLeafResponse(info)
-
-	c.JSON(http.StatusOK, types.ClaimProof{
+	return &types.ClaimProof{
 		ProofLocalExitRoot:  types.ConvertToProofResponse(proofLocalExitRoot),
 		ProofRollupExitRoot: types.ConvertToProofResponse(proofRollupExitRoot),
-		L1InfoTreeLeaf:      *infoResponse,
-	})
+		L1InfoTreeLeaf:      *NewL1InfoTreeLeafResponse(info),
+	}, nil
 }
 
 // GetLastReorgEventHandler returns the most recent reorganization event for the specified network.
@@ -846,7 +1159,7 @@ func (b *BridgeService) ClaimProofHandler(c *gin.Context) {
 // @Router /last-reorg-event [get]
 func (b *BridgeService) GetLastReorgEventHandler(c *gin.Context) {
 	b.logger.Debugf("GetLastReorgEvent request received (network id=%s)", c.Query(networkIDParam))
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	defer cancel()
 
 	cnt, merr := b.meter.Int64Counter("last_reorg_event")
@@ -865,7 +1178,7 @@ func (b *BridgeService) GetLastReorgEventHandler(c *gin.Context) {
 	var reorgEvent *bridgesync.LastReorg
 
 	switch {
-	case networkID == mainnetNetworkID:
+	case b.networks.IsL1(networkID):
 		reorgEvent, err = b.bridgeL1.GetLastReorgEvent(ctx)
 		if err != nil {
 			b.logger.Errorf("failed to get last reorg event for L1 network: %v", err)
@@ -904,7 +1217,7 @@ func (b *BridgeService) GetLastReorgEventHandler(c *gin.Context) {
 func (b *BridgeService) GetSyncStatusHandler(c *gin.Context) {
 	b.logger.Debugf("GetSyncStatus request received")
 
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	defer cancel()
 
 	cnt, merr := b.meter.Int64Counter("get_sync_status")
@@ -926,7 +1239,7 @@ func (b *BridgeService) GetSyncStatusHandler(c *gin.Context) {
 	}
 
 	// Get the last bridge from L1 database
-	_, bridgesCount, err := b.bridgeL1.GetBridgesPaged(ctx, 1, 1, nil, nil, "")
+	_, bridgesCount, err := b.bridgeL1.GetBridgesPaged(ctx, 1, 1, nil, nil, "", nil)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			gin.H{"error": fmt.Sprintf("failed to get bridges from L1 database: %s", err)})
@@ -946,7 +1259,7 @@ func (b *BridgeService) GetSyncStatusHandler(c *gin.Context) {
 	}
 
 	// Get the last bridge from L2 database
-	_, bridgesCount, err = b.bridgeL2.GetBridgesPaged(ctx, 1, 1, nil, nil, "")
+	_, bridgesCount, err = b.bridgeL2.GetBridgesPaged(ctx, 1, 1, nil, nil, "", nil)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			gin.H{"error": fmt.Sprintf("failed to get bridges from L2 database: %s", err)})
@@ -1066,6 +1379,37 @@ func (b *BridgeService) getFirstL1InfoTreeIndexForL2Bridge(ctx context.Context,
 	return info.L1InfoTreeIndex, nil
 }
 
+// getFinalityFilter returns the finality blocks of the network and the block number filter of the
+// requested finality. The finality blocks are required only if the events are filtered by finality,
+// otherwise the events are returned without the finality annotation if they can't be retrieved
+func (b *BridgeService) getFinalityFilter(ctx context.Context, networkID uint32,
+	finality bridgesync.FinalityStatus) (*bridgesync.FinalityBlocks, *bridgesync.BlockNumFilter, error) {
+	var bridger Bridger
+	switch {
+	case b.networks.IsL1(networkID):
+		bridger = b.bridgeL1
+	case networkID == b.networkID:
+		bridger = b.bridgeL2
+	default:
+		// the unsupported networks are rejected by the caller
+		return nil, nil, nil
+	}
+
+	finalityBlocks, err := bridger.GetFinalityBlocks(ctx)
+	if err != nil {
+		if finality != "" {
+			return nil, nil, fmt.Errorf("failed to get the finality blocks: %w", err)
+		}
+		b.logger.Warnf("failed to get the finality blocks of network %d, events are not annotated: %v", networkID, err)
+		return nil, nil, nil
+	}
+
+	if finality == "" {
+		return &finalityBlocks, nil, nil
+	}
+	return &finalityBlocks, finalityBlocks.BlockNumFilter(finality), nil
+}
+
 // setupRequest parses the pagination parameters from the request context
 func (b *BridgeService) setupRequest(
 	c *gin.Context,
@@ -1085,7 +1429,7 @@ func (b *BridgeService) setupRequest(
 		return nil, nil, 0, 0, err
 	}
 
-	ctx, cancel := context.WithTimeout(c, b.readTimeout)
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
 	counter, merr := b.meter.Int64Counter(counterName)
 	if merr != nil {
 		b.logger.Warnf("failed to create %s counter: %s", counterName, merr)
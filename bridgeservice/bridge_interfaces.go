@@ -2,6 +2,7 @@ package bridgeservice
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/agglayer/aggkit/l1infotreesync"
@@ -14,14 +15,30 @@ type Bridger interface {
 	GetProof(ctx context.Context, depositCount uint32, localExitRoot common.Hash) (tree.Proof, error)
 	GetRootByLER(ctx context.Context, ler common.Hash) (*tree.Root, error)
 	GetBridgesPaged(ctx context.Context, pageNumber, pageSize uint32,
-		depositCount *uint64, networkIDs []uint32, fromAddress string) ([]*bridgesync.Bridge, int, error)
+		depositCount *uint64, networkIDs []uint32, fromAddress string,
+		blockNumFilter *bridgesync.BlockNumFilter) ([]*bridgesync.Bridge, int, error)
 	GetTokenMappings(ctx context.Context, pageNumber, pageSize uint32) ([]*bridgesync.TokenMapping, int, error)
 	GetLegacyTokenMigrations(ctx context.Context,
 		pageNumber, pageSize uint32) ([]*bridgesync.LegacyTokenMigration, int, error)
 	GetClaimsPaged(ctx context.Context, page, pageSize uint32,
-		networkIDs []uint32, fromAddress string) ([]*bridgesync.Claim, int, error)
+		networkIDs []uint32, fromAddress string,
+		blockNumFilter *bridgesync.BlockNumFilter) ([]*bridgesync.Claim, int, error)
 	GetLastReorgEvent(ctx context.Context) (*bridgesync.LastReorg, error)
 	GetContractDepositCount(ctx context.Context) (uint32, error)
+	GetBridges(ctx context.Context, fromBlock, toBlock uint64) ([]bridgesync.Bridge, error)
+	GetClaims(ctx context.Context, fromBlock, toBlock uint64) ([]bridgesync.Claim, error)
+	GetDuplicatedClaims(ctx context.Context, fromBlock, toBlock uint64, limit uint32) ([]*bridgesync.Claim, int, error)
+	GetClaimsByGlobalIndex(ctx context.Context, globalIndex *big.Int) ([]*bridgesync.Claim, error)
+	GetBridgesByDepositCount(ctx context.Context, fromDepositCount, toDepositCount uint32) ([]*bridgesync.Bridge, error)
+	GetBridgesByDepositCounts(ctx context.Context, depositCounts []uint32) ([]*bridgesync.Bridge, error)
+	GetBridgesAndClaimsByTxHash(ctx context.Context,
+		txHash common.Hash) ([]*bridgesync.Bridge, []*bridgesync.Claim, error)
+	GetLastProcessedBlock(ctx context.Context) (uint64, error)
+	GetSyncTargetBlock(ctx context.Context) (uint64, error)
+	GetFinalityBlocks(ctx context.Context) (bridgesync.FinalityBlocks, error)
+	GetUSDValues(ctx context.Context, eventType string, fromBlock, toBlock uint64) ([]*bridgesync.USDValue, error)
+	GetUSDValueStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*bridgesync.USDValueStats, error)
+	GetClaimGasStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*bridgesync.ClaimGasStats, error)
 }
 
 type LastGERer interface {
@@ -32,6 +49,8 @@ type LastGERer interface {
 
 type L1InfoTreer interface {
 	GetInfoByIndex(ctx context.Context, index uint32) (*l1infotreesync.L1InfoTreeLeaf, error)
+	GetInfoBeforeIndex(ctx context.Context, index uint32) (*l1infotreesync.L1InfoTreeLeaf, error)
+	GetInfoByGlobalExitRoot(ger common.Hash) (*l1infotreesync.L1InfoTreeLeaf, error)
 	GetRollupExitTreeMerkleProof(ctx context.Context, networkID uint32, root common.Hash) (tree.Proof, error)
 	GetLocalExitRoot(ctx context.Context, networkID uint32, rollupExitRoot common.Hash) (common.Hash, error)
 	GetLastInfo() (*l1infotreesync.L1InfoTreeLeaf, error)
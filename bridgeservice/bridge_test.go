@@ -3,6 +3,7 @@ package bridgeservice
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,25 +17,33 @@ import (
 	"testing"
 	"time"
 
+	"github.com/agglayer/aggkit"
+	aggsendermocks "github.com/agglayer/aggkit/aggsender/mocks"
 	mocks "github.com/agglayer/aggkit/bridgeservice/mocks"
 	bridgetypes "github.com/agglayer/aggkit/bridgeservice/types"
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/lastgersync"
 	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/networks"
+	aggkittree "github.com/agglayer/aggkit/tree"
 	tree "github.com/agglayer/aggkit/tree/types"
+	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
 )
 
 const (
-	fooErrMsg   = "foo"
-	barErrMsg   = "bar"
-	l2NetworkID = uint32(10)
+	fooErrMsg        = "foo"
+	barErrMsg        = "bar"
+	l2NetworkID      = uint32(10)
+	mainnetNetworkID = 0
 )
 
 type bridgeWithMocks struct {
@@ -54,12 +63,15 @@ func newBridgeWithMocks(t *testing.T, networkID uint32) bridgeWithMocks {
 		bridgeL2:     mocks.NewBridger(t),
 	}
 	logger := log.WithFields("module", "test bridge service")
+	registry, err := networks.NewDefaultRegistry(networkID)
+	require.NoError(t, err)
 	cfg := &Config{
 		Logger:       logger,
 		Address:      "localhost",
 		ReadTimeout:  0,
 		WriteTimeout: 0,
 		NetworkID:    networkID,
+		Networks:     registry,
 	}
 	b.bridge = New(cfg, b.l1InfoTree, b.injectedGERs, b.bridgeL1, b.bridgeL2)
 	return b
@@ -492,11 +504,20 @@ func TestGetBridgesHandler(t *testing.T) {
 				IsNativeToken:      true,
 			},
 		}
+		valueUSD := "2500.750000"
 		bridgesResp := aggkitcommon.MapSlice(expectedBridges, NewBridgeResponse)
+		for _, bridgeResp := range bridgesResp {
+			bridgeResp.Finality = string(bridgesync.FinalitySafe)
+			bridgeResp.ValueUSD = &valueUSD
+		}
 
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL1.EXPECT().
-			GetBridgesPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
+			GetBridgesPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, uint64(1), uint64(1)).
+			Return([]*bridgesync.USDValue{{BlockNum: 1, BlockPos: 1, ValueUSD: &valueUSD}}, nil)
 
 		queryParams := url.Values{}
 		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
@@ -513,11 +534,129 @@ func TestGetBridgesHandler(t *testing.T) {
 
 		require.Equal(t, bridgesResp, response.Bridges)
 		require.Equal(t, len(expectedBridges), response.Count)
+		require.Equal(t, uint32(1), response.TotalPages)
+		require.False(t, response.HasNext)
+		require.NotEmpty(t, response.RequestID)
+		require.Equal(t, w.Header().Get(RequestIDHeader), response.RequestID)
+		require.Equal(t, "1970-01-01T00:00:00Z", response.Bridges[0].BlockTimestampISO)
+	})
+
+	t.Run("GetBridges for L1 network with decoded metadata", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		tokenMetadata, err := tokenMetadataArgs.Pack("Wrapped Ether", "WETH", uint8(18))
+		require.NoError(t, err)
+		expectedBridges := []*bridgesync.Bridge{
+			{
+				BlockNum:      1,
+				OriginAddress: common.HexToAddress("0x1"),
+				Amount:        common.Big1,
+				Metadata:      tokenMetadata,
+			},
+			{
+				BlockNum: 2,
+				Amount:   common.Big1,
+				Metadata: []byte("message"),
+			},
+			{
+				BlockNum: 3,
+				Amount:   common.Big1,
+			},
+		}
+
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 3}, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+		queryParams.Set(decodeMetadata, "true")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.BridgesResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Bridges, 3)
+		require.Equal(t, &bridgetypes.TokenMetadata{Name: "Wrapped Ether", Symbol: "WETH", Decimals: 18},
+			response.Bridges[0].DecodedMetadata)
+		require.Nil(t, response.Bridges[1].DecodedMetadata)
+		require.Nil(t, response.Bridges[2].DecodedMetadata)
+	})
+
+	t.Run("GetBridges for L1 network with decimal amounts and lowercase addresses", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		expectedBridges := []*bridgesync.Bridge{
+			{
+				BlockNum:           1,
+				DestinationAddress: common.HexToAddress("0xAbC1234567890aBcDeF1234567890aBcDeF12345"),
+				Amount:             big.NewInt(1500000000000000000),
+			},
+		}
+
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+		queryParams.Set(amountFormatParam, amountFormatDecimal)
+		queryParams.Set(addressFormatParam, addressFormatLowercase)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.BridgesResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Bridges, 1)
+		require.Equal(t, bridgetypes.BigIntString("1.5"), response.Bridges[0].Amount)
+		require.Equal(t, uint8(18), *response.Bridges[0].AmountDecimals)
+		require.Equal(t, bridgetypes.Address("0xabc1234567890abcdef1234567890abcdef12345"),
+			response.Bridges[0].DestinationAddress)
+	})
+
+	t.Run("GetBridges with invalid amount_format", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+		queryParams.Set(amountFormatParam, "gwei")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), amountFormatParam)
+	})
+
+	t.Run("GetBridges with invalid decode_metadata", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+		queryParams.Set(decodeMetadata, "maybe")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), decodeMetadata)
 	})
 
 	t.Run("GetBridges for L1 network error", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
-		bridgeMocks.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, 0, fmt.Errorf("L1 network error"))
 
 		queryParams := url.Values{}
@@ -534,7 +673,9 @@ func TestGetBridgesHandler(t *testing.T) {
 	t.Run("GetBridges for L2 network error", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
 
-		bridgeMocks.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, 0, fmt.Errorf("L2 network error"))
 
 		queryParams := url.Values{}
@@ -569,12 +710,19 @@ func TestGetBridgesHandler(t *testing.T) {
 			},
 		}
 		bridgesResp := aggkitcommon.MapSlice(expectedBridges, NewBridgeResponse)
+		for _, bridgeResp := range bridgesResp {
+			bridgeResp.Finality = string(bridgesync.FinalitySafe)
+		}
 
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
 
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL2.EXPECT().
-			GetBridgesPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
+			GetBridgesPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL2.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
 
 		queryParams := url.Values{}
 		queryParams.Set(networkIDParam, strconv.Itoa(int(l2NetworkID)))
@@ -612,6 +760,81 @@ func TestGetBridgesHandler(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, w.Code)
 		require.Contains(t, w.Body.String(), fmt.Sprintf("invalid %s parameter", networkIDParam))
 	})
+
+	t.Run("GetBridges filtered by finality", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		expectedBridges := []*bridgesync.Bridge{{BlockNum: 5, Amount: common.Big0}}
+		finalized := uint64(5)
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 8, Finalized: finalized}, nil)
+		bridgeMocks.bridgeL2.EXPECT().
+			GetBridgesPaged(mock.Anything, uint32(1), uint32(20), mock.Anything, mock.Anything, mock.Anything,
+				&bridgesync.BlockNumFilter{ToBlock: &finalized}).
+			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL2.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(int(l2NetworkID)))
+		queryParams.Set(finalityParam, string(bridgesync.FinalityFinalized))
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.BridgesResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Bridges, 1)
+		require.Equal(t, string(bridgesync.FinalityFinalized), response.Bridges[0].Finality)
+	})
+
+	t.Run("GetBridges with invalid finality", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(int(l2NetworkID)))
+		queryParams.Set(finalityParam, "unsafe")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "unsafe is not supported")
+	})
+
+	t.Run("GetBridges without finality blocks", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		expectedBridges := []*bridgesync.Bridge{{BlockNum: 5, Amount: common.Big0}}
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{}, errors.New("rpc error"))
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				(*bridgesync.BlockNumFilter)(nil)).
+			Return(expectedBridges, len(expectedBridges), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventBridge, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+
+		// the bridges are returned without the finality annotation
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.BridgesResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Bridges, 1)
+		require.Empty(t, response.Bridges[0].Finality)
+
+		// but they can't be filtered by finality
+		queryParams.Set(finalityParam, string(bridgesync.FinalitySafe))
+		w = performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/bridges?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "failed to get the finality blocks: rpc error")
+	})
 }
 
 func TestGetClaimsHandler(t *testing.T) {
@@ -634,12 +857,19 @@ func TestGetClaimsHandler(t *testing.T) {
 			},
 		}
 		claimsResp := aggkitcommon.MapSlice(expectedClaims, func(claim *bridgesync.Claim) *bridgetypes.ClaimResponse {
-			return NewClaimResponse(claim, false)
+			response := NewClaimResponse(claim, false)
+			response.Finality = string(bridgesync.FinalitySafe)
+			return response
 		})
 
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL1.EXPECT().
-			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedClaims, len(expectedClaims), nil)
+		// the claims are returned without value if the USD values can't be retrieved
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, uint64(1), uint64(1)).
+			Return(nil, errors.New("db error"))
 
 		queryParams := url.Values{
 			networkIDParam:  []string{fmt.Sprintf("%d", mainnetNetworkID)},
@@ -676,13 +906,19 @@ func TestGetClaimsHandler(t *testing.T) {
 			},
 		}
 		claimsResp := aggkitcommon.MapSlice(expectedClaims, func(claim *bridgesync.Claim) *bridgetypes.ClaimResponse {
-			return NewClaimResponse(claim, false)
+			response := NewClaimResponse(claim, false)
+			response.Finality = string(bridgesync.FinalitySafe)
+			return response
 		})
 
 		bridgeMocks.bridge.networkID = 10
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL2.EXPECT().
-			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL2.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
 
 		query := url.Values{}
 		query.Set(networkIDParam, "10")
@@ -699,6 +935,79 @@ func TestGetClaimsHandler(t *testing.T) {
 		require.Equal(t, len(expectedClaims), response.Count)
 	})
 
+	t.Run("GetClaims for L2 network with include_origin=true", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridge.networkID = l2NetworkID
+
+		mainnetGlobalIndex := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(5))
+		rollupGlobalIndex := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(int64(l2NetworkID-1)), 32), big.NewInt(7))
+		otherRollupGlobalIndex := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(2), 32), big.NewInt(1))
+		expectedClaims := []*bridgesync.Claim{
+			{BlockNum: 1, BlockPos: 0, GlobalIndex: mainnetGlobalIndex, Amount: common.Big1},
+			{BlockNum: 1, BlockPos: 1, GlobalIndex: rollupGlobalIndex, Amount: common.Big1},
+			{BlockNum: 2, BlockPos: 0, GlobalIndex: otherRollupGlobalIndex, Amount: common.Big1},
+		}
+		originBridge := &bridgesync.Bridge{
+			BlockNum:           30,
+			TxHash:             common.HexToHash("0xabc"),
+			DestinationNetwork: l2NetworkID,
+			Amount:             common.Big1,
+			DepositCount:       5,
+		}
+
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 2}, nil)
+		bridgeMocks.bridgeL2.EXPECT().
+			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL2.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+		mainnetDepositCount := uint64(5)
+		bridgeMocks.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), &mainnetDepositCount,
+			[]uint32(nil), "", (*bridgesync.BlockNumFilter)(nil)).
+			Return([]*bridgesync.Bridge{originBridge}, 1, nil)
+		rollupDepositCount := uint64(7)
+		bridgeMocks.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), &rollupDepositCount,
+			[]uint32(nil), "", (*bridgesync.BlockNumFilter)(nil)).
+			Return([]*bridgesync.Bridge{}, 0, nil)
+
+		query := url.Values{}
+		query.Set(networkIDParam, fmt.Sprintf("%d", l2NetworkID))
+		query.Set(includeOrigin, "true")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, fmt.Sprintf("%s/claims?%s", BridgeV1Prefix, query.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.ClaimsResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Claims, 3)
+		require.Equal(t, NewBridgeResponse(originBridge), response.Claims[0].OriginBridge)
+		// the bridge of the L2 is not synced yet and the other rollup is not indexed
+		require.Nil(t, response.Claims[1].OriginBridge)
+		require.Nil(t, response.Claims[2].OriginBridge)
+	})
+
+	t.Run("GetClaims with include_origin=true failed", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]*bridgesync.Claim{{BlockNum: 1, GlobalIndex: new(big.Int).Lsh(big.NewInt(1), 64)}}, 1, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+				mock.Anything).
+			Return(nil, 0, errors.New(fooErrMsg))
+
+		query := url.Values{}
+		query.Set(networkIDParam, "0")
+		query.Set(includeOrigin, "true")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, fmt.Sprintf("%s/claims?%s", BridgeV1Prefix, query.Encode()), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "failed to get the origin bridges of the claims")
+	})
+
 	t.Run("GetClaims with unsupported network", func(t *testing.T) {
 		unsupportedNetworkID := 999
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
@@ -713,8 +1022,10 @@ func TestGetClaimsHandler(t *testing.T) {
 
 	t.Run("GetClaims for L1 network failed", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL1.EXPECT().
-			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, 0, errors.New(fooErrMsg))
 
 		query := url.Values{}
@@ -729,8 +1040,10 @@ func TestGetClaimsHandler(t *testing.T) {
 
 	t.Run("GetClaims for L2 network failed", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL2.EXPECT().
-			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, 0, errors.New(barErrMsg))
 
 		query := url.Values{}
@@ -788,12 +1101,18 @@ func TestGetClaimsHandler(t *testing.T) {
 			},
 		}
 		claimsResp := aggkitcommon.MapSlice(expectedClaims, func(claim *bridgesync.Claim) *bridgetypes.ClaimResponse {
-			return NewClaimResponse(claim, true)
+			response := NewClaimResponse(claim, true)
+			response.Finality = string(bridgesync.FinalitySafe)
+			return response
 		})
 
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL1.EXPECT().
-			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
 
 		queryParams := url.Values{
 			networkIDParam:   []string{fmt.Sprintf("%d", mainnetNetworkID)},
@@ -860,13 +1179,19 @@ func TestGetClaimsHandler(t *testing.T) {
 			},
 		}
 		claimsResp := aggkitcommon.MapSlice(expectedClaims, func(claim *bridgesync.Claim) *bridgetypes.ClaimResponse {
-			return NewClaimResponse(claim, true)
+			response := NewClaimResponse(claim, true)
+			response.Finality = string(bridgesync.FinalitySafe)
+			return response
 		})
 
 		bridgeMocks.bridge.networkID = 10
+		bridgeMocks.bridgeL2.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL2.EXPECT().
-			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL2.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
 
 		query := url.Values{}
 		query.Set(networkIDParam, "10")
@@ -932,12 +1257,18 @@ func TestGetClaimsHandler(t *testing.T) {
 			},
 		}
 		claimsResp := aggkitcommon.MapSlice(expectedClaims, func(claim *bridgesync.Claim) *bridgetypes.ClaimResponse {
-			return NewClaimResponse(claim, false)
+			response := NewClaimResponse(claim, false)
+			response.Finality = string(bridgesync.FinalitySafe)
+			return response
 		})
 
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 1}, nil)
 		bridgeMocks.bridgeL1.EXPECT().
-			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything).
+			GetClaimsPaged(mock.Anything, page, pageSize, mock.Anything, mock.Anything, mock.Anything).
 			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
 
 		queryParams := url.Values{
 			networkIDParam:   []string{fmt.Sprintf("%d", mainnetNetworkID)},
@@ -973,6 +1304,34 @@ func TestGetClaimsHandler(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, w.Code)
 		require.Contains(t, w.Body.String(), "invalid include_all_fields parameter")
 	})
+
+	t.Run("GetClaims filtered by finality", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		expectedClaims := []*bridgesync.Claim{{BlockNum: 9, GlobalIndex: big.NewInt(1), Amount: common.Big0}}
+		from := uint64(9)
+		bridgeMocks.bridgeL1.EXPECT().GetFinalityBlocks(mock.Anything).
+			Return(bridgesync.FinalityBlocks{Safe: 8, Finalized: 5}, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetClaimsPaged(mock.Anything, uint32(1), uint32(20), mock.Anything, mock.Anything,
+				&bridgesync.BlockNumFilter{FromBlock: &from}).
+			Return(expectedClaims, len(expectedClaims), nil)
+		bridgeMocks.bridgeL1.EXPECT().GetUSDValues(mock.Anything, bridgesync.USDValueEventClaim, mock.Anything, mock.Anything).
+			Return([]*bridgesync.USDValue{}, nil)
+
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, strconv.Itoa(mainnetNetworkID))
+		queryParams.Set(finalityParam, string(bridgesync.FinalityPending))
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/claims?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.ClaimsResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Claims, 1)
+		require.Equal(t, string(bridgesync.FinalityPending), response.Claims[0].Finality)
+	})
 }
 
 func TestGetTokenMappingsHandler(t *testing.T) {
@@ -1056,6 +1415,38 @@ func TestGetTokenMappingsHandler(t *testing.T) {
 		bridgeMocks.bridgeL2.AssertExpectations(t)
 	})
 
+	t.Run("GetTokenMappingsHandler with decoded metadata", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		tokenMetadata, err := tokenMetadataArgs.Pack("USD Coin", "USDC", uint8(6))
+		require.NoError(t, err)
+		tokenMappings := []*bridgesync.TokenMapping{
+			{
+				BlockNum:            1,
+				TxHash:              common.HexToHash("0x1"),
+				OriginTokenAddress:  common.HexToAddress("0x1"),
+				WrappedTokenAddress: common.HexToAddress("0x2"),
+				Metadata:            tokenMetadata,
+			},
+		}
+		tokenMappingsResp := aggkitcommon.MapSlice(tokenMappings, NewTokenMappingResponse)
+		tokenMappingsResp[0].DecodedMetadata = &bridgetypes.TokenMetadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6}
+
+		bridgeMocks.bridgeL2.EXPECT().GetTokenMappings(mock.Anything, mock.Anything, mock.Anything).
+			Return(tokenMappings, len(tokenMappings), nil)
+
+		query := url.Values{}
+		query.Set(networkIDParam, "10")
+		query.Set(decodeMetadata, "true")
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, fmt.Sprintf("%s/token-mappings?%s", BridgeV1Prefix, query.Encode()), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.TokenMappingsResult
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, tokenMappingsResp, response.TokenMappings)
+	})
+
 	t.Run("GetTokenMappingsHandler with unsupported network", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
 
@@ -1766,6 +2157,144 @@ func TestClaimProofHandler(t *testing.T) {
 		require.Equal(t, expectedClaimProof, result)
 	})
 
+	t.Run("Retrieve claim proof against a global exit root for L2 network", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		ger := common.HexToHash("0x4")
+		gerLeaf := &l1infotreesync.L1InfoTreeLeaf{
+			L1InfoTreeIndex: 5,
+			MainnetExitRoot: common.HexToHash("0x1"),
+			RollupExitRoot:  common.HexToHash("0x2"),
+			GlobalExitRoot:  ger,
+		}
+		localExitRoot := common.HexToHash("0x3")
+		localExitTreeProof := tree.Proof{common.HexToHash("0xf")}
+		rollupExitTreeProof := tree.Proof{common.HexToHash("0xe")}
+
+		bridgeMocks.l1InfoTree.EXPECT().GetInfoByGlobalExitRoot(ger).Return(gerLeaf, nil)
+		bridgeMocks.l1InfoTree.EXPECT().
+			GetLocalExitRoot(mock.Anything, l2NetworkID, gerLeaf.RollupExitRoot).
+			Return(localExitRoot, nil)
+		bridgeMocks.bridgeL2.EXPECT().
+			GetRootByLER(mock.Anything, localExitRoot).
+			Return(&tree.Root{Index: depositCount}, nil)
+		bridgeMocks.bridgeL2.EXPECT().
+			GetProof(mock.Anything, depositCount, localExitRoot).
+			Return(localExitTreeProof, nil)
+		bridgeMocks.l1InfoTree.EXPECT().
+			GetRollupExitTreeMerkleProof(mock.Anything, l2NetworkID, gerLeaf.RollupExitRoot).
+			Return(rollupExitTreeProof, nil)
+
+		// the leaf index is not needed with the global exit root
+		queryParams := url.Values{}
+		queryParams.Set(networkIDParam, fmt.Sprintf("%d", l2NetworkID))
+		queryParams.Set(gerParam, ger.Hex())
+		queryParams.Set(depositCountParam, fmt.Sprintf("%d", depositCount))
+
+		response := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, fmt.Sprintf("%s/claim-proof?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+		require.Equal(t, http.StatusOK, response.Code)
+		var result bridgetypes.ClaimProof
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+		require.Equal(t, bridgetypes.ClaimProof{
+			ProofLocalExitRoot:  bridgetypes.ConvertToProofResponse(localExitTreeProof),
+			ProofRollupExitRoot: bridgetypes.ConvertToProofResponse(rollupExitTreeProof),
+			L1InfoTreeLeaf:      *NewL1InfoTreeLeafResponse(gerLeaf),
+		}, result)
+	})
+
+	t.Run("Global exit root errors", func(t *testing.T) {
+		ger := common.HexToHash("0x4")
+		gerLeaf := &l1infotreesync.L1InfoTreeLeaf{
+			L1InfoTreeIndex: 5,
+			MainnetExitRoot: common.HexToHash("0x1"),
+			GlobalExitRoot:  ger,
+		}
+		tests := []struct {
+			name           string
+			leafIndex      string
+			ger            string
+			setupMocks     func(bridgeMocks bridgeWithMocks)
+			expectedStatus int
+			expectedErr    string
+		}{
+			{
+				name:           "neither leaf index nor global exit root",
+				expectedStatus: http.StatusBadRequest,
+				expectedErr:    fmt.Sprintf("%s is mandatory", leafIndexParam),
+			},
+			{
+				name:           "invalid global exit root",
+				ger:            "0x1234",
+				expectedStatus: http.StatusBadRequest,
+				expectedErr:    fmt.Sprintf("invalid %s parameter", gerParam),
+			},
+			{
+				name: "global exit root not found",
+				ger:  ger.Hex(),
+				setupMocks: func(bridgeMocks bridgeWithMocks) {
+					bridgeMocks.l1InfoTree.EXPECT().GetInfoByGlobalExitRoot(ger).Return(nil, db.ErrNotFound)
+				},
+				expectedStatus: http.StatusNotFound,
+				expectedErr:    fmt.Sprintf("global exit root %s not found in the l1 info tree", ger.Hex()),
+			},
+			{
+				name:      "leaf index of another global exit root",
+				leafIndex: "4",
+				ger:       ger.Hex(),
+				setupMocks: func(bridgeMocks bridgeWithMocks) {
+					bridgeMocks.l1InfoTree.EXPECT().GetInfoByGlobalExitRoot(ger).Return(gerLeaf, nil)
+				},
+				expectedStatus: http.StatusBadRequest,
+				expectedErr:    fmt.Sprintf("the global exit root %s is the l1 info tree leaf 5, not 4", ger.Hex()),
+			},
+			{
+				name: "deposit not included in the global exit root",
+				ger:  ger.Hex(),
+				setupMocks: func(bridgeMocks bridgeWithMocks) {
+					bridgeMocks.l1InfoTree.EXPECT().GetInfoByGlobalExitRoot(ger).Return(gerLeaf, nil)
+					bridgeMocks.bridgeL1.EXPECT().
+						GetRootByLER(mock.Anything, gerLeaf.MainnetExitRoot).
+						Return(&tree.Root{Index: depositCount - 1}, nil)
+				},
+				expectedStatus: http.StatusBadRequest,
+				expectedErr: fmt.Sprintf("the deposit %d of network %d is not included in the global exit root %s",
+					depositCount, mainnetNetworkID, ger.Hex()),
+			},
+			{
+				name: "global exit root from before the first deposit",
+				ger:  ger.Hex(),
+				setupMocks: func(bridgeMocks bridgeWithMocks) {
+					bridgeMocks.l1InfoTree.EXPECT().GetInfoByGlobalExitRoot(ger).Return(gerLeaf, nil)
+					bridgeMocks.bridgeL1.EXPECT().
+						GetRootByLER(mock.Anything, gerLeaf.MainnetExitRoot).
+						Return(nil, db.ErrNotFound)
+				},
+				expectedStatus: http.StatusBadRequest,
+				expectedErr:    "is not included in the global exit root",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+				if tt.setupMocks != nil {
+					tt.setupMocks(bridgeMocks)
+				}
+				queryParams := url.Values{}
+				queryParams.Set(networkIDParam, fmt.Sprintf("%d", mainnetNetworkID))
+				queryParams.Set(depositCountParam, fmt.Sprintf("%d", depositCount))
+				if tt.leafIndex != "" {
+					queryParams.Set(leafIndexParam, tt.leafIndex)
+				}
+				if tt.ger != "" {
+					queryParams.Set(gerParam, tt.ger)
+				}
+
+				response := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, fmt.Sprintf("%s/claim-proof?%s", BridgeV1Prefix, queryParams.Encode()), nil)
+				require.Equal(t, tt.expectedStatus, response.Code)
+				require.Contains(t, response.Body.String(), tt.expectedErr)
+			})
+		}
+	})
+
 	t.Run("Invalid network id param", func(t *testing.T) {
 		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
 
@@ -1901,18 +2430,189 @@ func TestGetLastReorgEventHandler(t *testing.T) {
 	})
 }
 
-// performRequest is a helper function to perform HTTP requests in tests.
-func performRequest(t *testing.T, router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
-	t.Helper()
+func TestExportHandler(t *testing.T) {
+	exportURL := func(query url.Values) string {
+		return fmt.Sprintf("%s/export?%s", BridgeV1Prefix, query.Encode())
+	}
+	bridges := []bridgesync.Bridge{
+		{
+			BlockNum:           5,
+			BlockPos:           1,
+			LeafType:           0,
+			OriginNetwork:      0,
+			OriginAddress:      common.HexToAddress("0x1"),
+			DestinationNetwork: l2NetworkID,
+			DestinationAddress: common.HexToAddress("0x2"),
+			Amount:             big.NewInt(100),
+			DepositCount:       3,
+			Metadata:           []byte("metadata"),
+		},
+		{
+			BlockNum:           7,
+			BlockPos:           0,
+			OriginAddress:      common.HexToAddress("0x3"),
+			DestinationNetwork: l2NetworkID,
+			DestinationAddress: common.HexToAddress("0x4"),
+			Amount:             big.NewInt(200),
+			DepositCount:       4,
+		},
+	}
+	claims := []bridgesync.Claim{
+		{
+			BlockNum:           8,
+			GlobalIndex:        big.NewInt(42),
+			OriginAddress:      common.HexToAddress("0x5"),
+			DestinationAddress: common.HexToAddress("0x6"),
+			DestinationNetwork: l2NetworkID,
+			Amount:             big.NewInt(300),
+		},
+	}
 
-	var bodyReader io.Reader
+	t.Run("missing type", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		query := url.Values{networkIDParam: []string{"0"}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "type is mandatory")
+	})
 
-	switch v := body.(type) {
-	case string:
-		// If the body is a raw string, use it directly.
-		bodyReader = strings.NewReader(v)
-	case []byte:
-		// If the body is a raw byte array, use it directly.
+	t.Run("invalid format", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges},
+			exportFormatParam: []string{"xml"}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "xml is not supported, allowed values: ndjson, csv")
+	})
+
+	t.Run("unsupported network", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		query := url.Values{networkIDParam: []string{"999"}, exportTypeParam: []string{exportTypeBridges}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "unsupported network id: 999")
+	})
+
+	t.Run("last processed block error", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(0, errors.New(fooErrMsg))
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "failed to get the last processed block")
+	})
+
+	t.Run("invalid block range", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(100, nil)
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges},
+			fromBlockParam: []string{"50"}, toBlockParam: []string{"10"}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "from_block 50 is greater than to_block 10")
+	})
+
+	t.Run("to_block not processed yet", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(100, nil)
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges},
+			toBlockParam: []string{"101"}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "to_block 101 is not processed yet")
+	})
+
+	t.Run("bridges as NDJSON in chunks", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		lastBlock := exportBlockChunkSize + 10
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(lastBlock, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridges(mock.Anything, uint64(0), exportBlockChunkSize-1).
+			Return(bridges[:1], nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridges(mock.Anything, exportBlockChunkSize, lastBlock).
+			Return(bridges[1:], nil)
+
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Header().Get("Content-Disposition"),
+			fmt.Sprintf("filename=bridges_0_0_%d.ndjson", lastBlock))
+		require.Empty(t, w.Header().Get(exportErrorTrailer))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, len(bridges))
+		for i, line := range lines {
+			var response bridgetypes.BridgeResponse
+			require.NoError(t, json.Unmarshal([]byte(line), &response))
+			require.Equal(t, NewBridgeResponse(&bridges[i]), &response)
+		}
+	})
+
+	t.Run("claims as CSV", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL2.EXPECT().GetLastProcessedBlock(mock.Anything).Return(100, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaims(mock.Anything, uint64(5), uint64(20)).Return(claims, nil)
+
+		query := url.Values{networkIDParam: []string{strconv.Itoa(int(l2NetworkID))},
+			exportTypeParam: []string{exportTypeClaims}, exportFormatParam: []string{exportFormatCSV},
+			fromBlockParam: []string{"5"}, toBlockParam: []string{"20"}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		require.Equal(t, claimsCSVHeader, records[0])
+		require.Equal(t, "8", records[1][0])
+		require.Equal(t, "42", records[1][3])
+		require.Equal(t, "300", records[1][8])
+	})
+
+	t.Run("empty CSV export has the header", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(10, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridges(mock.Anything, uint64(0), uint64(10)).Return(nil, nil)
+
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges},
+			exportFormatParam: []string{exportFormatCSV}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, [][]string{bridgesCSVHeader}, records)
+	})
+
+	t.Run("error after streaming started", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		lastBlock := exportBlockChunkSize + 10
+		bridgeMocks.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(lastBlock, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridges(mock.Anything, uint64(0), exportBlockChunkSize-1).
+			Return(bridges, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetBridges(mock.Anything, exportBlockChunkSize, lastBlock).
+			Return(nil, errors.New(fooErrMsg))
+
+		query := url.Values{networkIDParam: []string{"0"}, exportTypeParam: []string{exportTypeBridges}}
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, exportURL(query), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), len(bridges))
+		require.Contains(t, w.Header().Get(exportErrorTrailer), "failed to get bridges for blocks")
+	})
+}
+
+// performRequest is a helper function to perform HTTP requests in tests.
+func performRequest(t *testing.T, router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var bodyReader io.Reader
+
+	switch v := body.(type) {
+	case string:
+		// If the body is a raw string, use it directly.
+		bodyReader = strings.NewReader(v)
+	case []byte:
+		// If the body is a raw byte array, use it directly.
 		bodyReader = bytes.NewBuffer(v)
 	default:
 		if body != nil {
@@ -1985,13 +2685,15 @@ func TestGetSyncStatusHandler(t *testing.T) {
 			b.bridgeL1.EXPECT().GetContractDepositCount(mock.Anything).
 				Return(tc.l1ContractCount, nil).
 				Once()
-			b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+			b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+				(*bridgesync.BlockNumFilter)(nil)).
 				Return(nil, int(tc.l1BridgeCount), nil).
 				Once()
 			b.bridgeL2.EXPECT().GetContractDepositCount(mock.Anything).
 				Return(tc.l2ContractCount, nil).
 				Once()
-			b.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+			b.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+				(*bridgesync.BlockNumFilter)(nil)).
 				Return(nil, int(tc.l2BridgeCount), nil).
 				Once()
 
@@ -2037,7 +2739,8 @@ func TestGetSyncStatusHandler(t *testing.T) {
 				b.bridgeL1.EXPECT().GetContractDepositCount(mock.Anything).
 					Return(uint32(100), nil).
 					Once()
-				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+					(*bridgesync.BlockNumFilter)(nil)).
 					Return(nil, 0, errors.New("L1 database error")).
 					Once()
 			},
@@ -2050,7 +2753,8 @@ func TestGetSyncStatusHandler(t *testing.T) {
 				b.bridgeL1.EXPECT().GetContractDepositCount(mock.Anything).
 					Return(uint32(100), nil).
 					Once()
-				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+					(*bridgesync.BlockNumFilter)(nil)).
 					Return(nil, 100, nil).
 					Once()
 				b.bridgeL2.EXPECT().GetContractDepositCount(mock.Anything).
@@ -2066,13 +2770,15 @@ func TestGetSyncStatusHandler(t *testing.T) {
 				b.bridgeL1.EXPECT().GetContractDepositCount(mock.Anything).
 					Return(uint32(100), nil).
 					Once()
-				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+					(*bridgesync.BlockNumFilter)(nil)).
 					Return(nil, 100, nil).
 					Once()
 				b.bridgeL2.EXPECT().GetContractDepositCount(mock.Anything).
 					Return(uint32(200), nil).
 					Once()
-				b.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+				b.bridgeL2.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+					(*bridgesync.BlockNumFilter)(nil)).
 					Return(nil, 0, errors.New("L2 database error")).
 					Once()
 			},
@@ -2095,7 +2801,8 @@ func TestGetSyncStatusHandler(t *testing.T) {
 				b.bridgeL1.EXPECT().GetContractDepositCount(mock.Anything).
 					Return(uint32(100), nil).
 					Once()
-				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "").
+				b.bridgeL1.EXPECT().GetBridgesPaged(mock.Anything, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+					(*bridgesync.BlockNumFilter)(nil)).
 					Return(nil, 100, nil).
 					Once()
 				b.bridgeL2.EXPECT().GetContractDepositCount(mock.Anything).
@@ -2138,3 +2845,1254 @@ func TestHealthCheckHandler(t *testing.T) {
 	require.NotEmpty(t, response.Time)
 	require.NotEmpty(t, response.Version)
 }
+
+func TestReadinessHandler(t *testing.T) {
+	newBridge := func(t *testing.T) bridgeWithMocks {
+		t.Helper()
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridge.maxL1BlocksBehind = 10
+		b.bridge.maxL2BlocksBehind = 100
+		return b
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		b := newBridge(t)
+		b.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(95), nil)
+		b.bridgeL1.EXPECT().GetSyncTargetBlock(mock.Anything).Return(uint64(105), nil)
+		b.bridgeL2.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(2000), nil)
+		b.bridgeL2.EXPECT().GetSyncTargetBlock(mock.Anything).Return(uint64(1990), nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet, "/ready", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.ReadinessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Equal(t, bridgetypes.ReadinessResponse{
+			Ready: true,
+			L1: &bridgetypes.NetworkReadiness{NetworkID: mainnetNetworkID, LastProcessedBlock: 95,
+				TargetBlock: 105, BlocksBehind: 10, MaxBlocksBehind: 10, Ready: true},
+			L2: &bridgetypes.NetworkReadiness{NetworkID: l2NetworkID, LastProcessedBlock: 2000,
+				TargetBlock: 1990, MaxBlocksBehind: 100, Ready: true},
+		}, response)
+	})
+
+	t.Run("L2 syncer behind", func(t *testing.T) {
+		b := newBridge(t)
+		b.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(105), nil)
+		b.bridgeL1.EXPECT().GetSyncTargetBlock(mock.Anything).Return(uint64(105), nil)
+		b.bridgeL2.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(10), nil)
+		b.bridgeL2.EXPECT().GetSyncTargetBlock(mock.Anything).Return(uint64(5000), nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet, "/ready", nil)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		var response bridgetypes.ReadinessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.Ready)
+		require.True(t, response.L1.Ready)
+		require.False(t, response.L2.Ready)
+		require.Equal(t, uint64(4990), response.L2.BlocksBehind)
+	})
+
+	t.Run("L1 syncer error", func(t *testing.T) {
+		b := newBridge(t)
+		b.bridgeL1.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(0), errors.New(fooErrMsg))
+		b.bridgeL2.EXPECT().GetLastProcessedBlock(mock.Anything).Return(uint64(10), nil)
+		b.bridgeL2.EXPECT().GetSyncTargetBlock(mock.Anything).Return(uint64(10), nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet, "/ready", nil)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		var response bridgetypes.ReadinessResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.L1.Ready)
+		require.Contains(t, response.L1.Error, fooErrMsg)
+		require.True(t, response.L2.Ready)
+	})
+}
+
+func TestHeadAndOptionsRequests(t *testing.T) {
+	b := newBridgeWithMocks(t, l2NetworkID)
+
+	w := performRequest(t, b.bridge.router, http.MethodHead, "/", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(t, b.bridge.router, http.MethodOptions, "/", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "GET, HEAD, OPTIONS", w.Header().Get("Allow"))
+
+	w = performRequest(t, b.bridge.router, http.MethodOptions, BridgeV1Prefix+"/verify-claim-proof", nil)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "OPTIONS, POST", w.Header().Get("Allow"))
+
+	w = performRequest(t, b.bridge.router, http.MethodDelete, BridgeV1Prefix+"/bridges", nil)
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	require.Contains(t, w.Header().Get("Allow"), http.MethodGet)
+}
+
+func TestVerifyClaimProofHandler(t *testing.T) {
+	leafHash := common.HexToHash("0xaa")
+	depositCount := uint32(5)
+	rollupIndex := uint32(2)
+
+	var proofLER, proofRER [tree.DefaultHeight]common.Hash
+	for i := range proofLER {
+		proofLER[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+		proofRER[i] = common.BigToHash(big.NewInt(int64(i + 100)))
+	}
+
+	newLeaf := func(mainnetExitRoot, rollupExitRoot common.Hash) *l1infotreesync.L1InfoTreeLeaf {
+		leaf := &l1infotreesync.L1InfoTreeLeaf{
+			PreviousBlockHash: common.HexToHash("0xbb"),
+			Timestamp:         1684500000,
+			MainnetExitRoot:   mainnetExitRoot,
+			RollupExitRoot:    rollupExitRoot,
+		}
+		leaf.GlobalExitRoot = leaf.GetGlobalExitRoot()
+		leaf.Hash = leaf.GetHash()
+		return leaf
+	}
+	newRequest := func(mainnet bool, leaf *l1infotreesync.L1InfoTreeLeaf) bridgetypes.VerifyClaimProofRequest {
+		return bridgetypes.VerifyClaimProofRequest{
+			ClaimProof: bridgetypes.ClaimProof{
+				ProofLocalExitRoot:  bridgetypes.ConvertToProofResponse(proofLER),
+				ProofRollupExitRoot: bridgetypes.ConvertToProofResponse(proofRER),
+				L1InfoTreeLeaf:      *NewL1InfoTreeLeafResponse(leaf),
+			},
+			GlobalIndex: bridgetypes.BigIntString(
+				bridgesync.GenerateGlobalIndex(mainnet, rollupIndex, depositCount).String()),
+			LeafHash: bridgetypes.Hash(leafHash.Hex()),
+		}
+	}
+	verify := func(t *testing.T, req any) (*httptest.ResponseRecorder, bridgetypes.ClaimProofVerdict) {
+		t.Helper()
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		response := performRequest(t, bridgeMocks.bridge.router, http.MethodPost,
+			fmt.Sprintf("%s/verify-claim-proof", BridgeV1Prefix), req)
+		var verdict bridgetypes.ClaimProofVerdict
+		if response.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(response.Body.Bytes(), &verdict))
+		}
+		return response, verdict
+	}
+
+	mainnetExitRoot := aggkittree.CalculateRoot(leafHash, proofLER, depositCount)
+	localExitRoot := aggkittree.CalculateRoot(leafHash, proofLER, depositCount)
+	rollupExitRoot := aggkittree.CalculateRoot(localExitRoot, proofRER, rollupIndex)
+
+	t.Run("valid mainnet proof", func(t *testing.T) {
+		leaf := newLeaf(mainnetExitRoot, common.HexToHash("0x2"))
+		req := newRequest(true, leaf)
+		req.GlobalExitRoot = bridgetypes.Hash(leaf.GlobalExitRoot.Hex())
+
+		response, verdict := verify(t, req)
+		require.Equal(t, http.StatusOK, response.Code)
+		require.True(t, verdict.Valid)
+		require.True(t, verdict.MainnetFlag)
+		require.Equal(t, depositCount, verdict.DepositCount)
+		require.Len(t, verdict.Checks, 4)
+		require.Equal(t, claimProofCheckMainnetExitRoot, verdict.Checks[0].Name)
+	})
+
+	t.Run("valid rollup proof", func(t *testing.T) {
+		leaf := newLeaf(common.HexToHash("0x1"), rollupExitRoot)
+
+		response, verdict := verify(t, newRequest(false, leaf))
+		require.Equal(t, http.StatusOK, response.Code)
+		require.True(t, verdict.Valid)
+		require.False(t, verdict.MainnetFlag)
+		require.Equal(t, rollupIndex, verdict.RollupIndex)
+		require.Equal(t, claimProofCheckRollupExitRoot, verdict.Checks[0].Name)
+		require.Equal(t, bridgetypes.Hash(rollupExitRoot.Hex()), verdict.Checks[0].Computed)
+	})
+
+	t.Run("tampered local exit root proof", func(t *testing.T) {
+		leaf := newLeaf(common.HexToHash("0x1"), rollupExitRoot)
+		req := newRequest(false, leaf)
+		req.ClaimProof.ProofLocalExitRoot[3] = bridgetypes.Hash(common.HexToHash("0xdead").Hex())
+
+		response, verdict := verify(t, req)
+		require.Equal(t, http.StatusOK, response.Code)
+		require.False(t, verdict.Valid)
+		require.False(t, verdict.Checks[0].Valid)
+		require.Equal(t, bridgetypes.Hash(rollupExitRoot.Hex()), verdict.Checks[0].Expected)
+	})
+
+	t.Run("target global exit root mismatch", func(t *testing.T) {
+		req := newRequest(true, newLeaf(mainnetExitRoot, common.HexToHash("0x2")))
+		req.GlobalExitRoot = bridgetypes.Hash(common.HexToHash("0x3").Hex())
+
+		response, verdict := verify(t, req)
+		require.Equal(t, http.StatusOK, response.Code)
+		require.False(t, verdict.Valid)
+		for _, check := range verdict.Checks {
+			require.Equal(t, check.Name != claimProofCheckTargetGlobalExitRoot, check.Valid, check.Name)
+		}
+	})
+
+	t.Run("invalid leaf hash", func(t *testing.T) {
+		req := newRequest(true, newLeaf(mainnetExitRoot, common.HexToHash("0x2")))
+		req.LeafHash = "0x1234"
+
+		response, _ := verify(t, req)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "invalid leaf_hash parameter")
+	})
+
+	t.Run("invalid global index", func(t *testing.T) {
+		req := newRequest(true, newLeaf(mainnetExitRoot, common.HexToHash("0x2")))
+		req.GlobalIndex = "abc"
+
+		response, _ := verify(t, req)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "invalid global_index parameter")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		response, _ := verify(t, "not a json")
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "invalid request body")
+	})
+}
+
+func TestGetNetworksHandler(t *testing.T) {
+	b := newBridgeWithMocks(t, l2NetworkID)
+	registry, err := networks.NewRegistry([]networks.NetworkConfig{
+		{
+			ID:            l2NetworkID + 1,
+			Name:          "other",
+			Role:          networks.RoleL2,
+			BridgeAddr:    common.HexToAddress("0x3"),
+			GERAddr:       common.HexToAddress("0x4"),
+			BlockFinality: aggkittypes.LatestBlock,
+		},
+		{
+			ID:            l2NetworkID,
+			Name:          "katana",
+			Role:          networks.RoleL2,
+			BridgeAddr:    common.HexToAddress("0x1"),
+			GERAddr:       common.HexToAddress("0x2"),
+			BlockFinality: aggkittypes.LatestBlock,
+		},
+		{
+			ID:            networks.L1NetworkID,
+			Name:          "sepolia",
+			Role:          networks.RoleL1,
+			BridgeAddr:    common.HexToAddress("0x1"),
+			GERAddr:       common.HexToAddress("0x5"),
+			BlockFinality: aggkittypes.FinalizedBlock,
+		},
+	})
+	require.NoError(t, err)
+	b.bridge.networks = registry
+
+	w := performRequest(t, b.bridge.router, http.MethodGet, BridgeV1Prefix+"/networks", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response bridgetypes.NetworksResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, []bridgetypes.NetworkResponse{
+		{
+			NetworkID:     networks.L1NetworkID,
+			Name:          "sepolia",
+			Role:          "L1",
+			BridgeAddress: bridgetypes.Address(common.HexToAddress("0x1").Hex()),
+			GERAddress:    bridgetypes.Address(common.HexToAddress("0x5").Hex()),
+			BlockFinality: "FinalizedBlock",
+			Indexed:       true,
+		},
+		{
+			NetworkID:     l2NetworkID,
+			Name:          "katana",
+			Role:          "L2",
+			BridgeAddress: bridgetypes.Address(common.HexToAddress("0x1").Hex()),
+			GERAddress:    bridgetypes.Address(common.HexToAddress("0x2").Hex()),
+			BlockFinality: "LatestBlock",
+			Indexed:       true,
+		},
+		{
+			NetworkID:     l2NetworkID + 1,
+			Name:          "other",
+			Role:          "L2",
+			BridgeAddress: bridgetypes.Address(common.HexToAddress("0x3").Hex()),
+			GERAddress:    bridgetypes.Address(common.HexToAddress("0x4").Hex()),
+			BlockFinality: "LatestBlock",
+			Indexed:       false,
+		},
+	}, response.Networks)
+}
+
+func TestGetConfigHandler(t *testing.T) {
+	b := newBridgeWithMocks(t, l2NetworkID)
+	b.bridge.nodeConfig = NodeConfig{
+		RollupManagerAddr: common.HexToAddress("0x6"),
+		L1: SyncedNetworkConfig{
+			BridgeAddr:          common.HexToAddress("0x1"),
+			GERAddr:             common.HexToAddress("0x5"),
+			BridgeBlockFinality: "FinalizedBlock",
+			GERBlockFinality:    "SafeBlock",
+		},
+		L2: SyncedNetworkConfig{
+			BridgeAddr:          common.HexToAddress("0x1"),
+			GERAddr:             common.HexToAddress("0x2"),
+			BridgeBlockFinality: "LatestBlock",
+			GERBlockFinality:    "LatestBlock",
+		},
+	}
+
+	w := performRequest(t, b.bridge.router, http.MethodGet, BridgeV1Prefix+"/config", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response bridgetypes.ConfigResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, bridgetypes.ConfigResponse{
+		Version:              aggkit.GetVersion().Version,
+		RollupManagerAddress: bridgetypes.Address(common.HexToAddress("0x6").Hex()),
+		L1: bridgetypes.NetworkConfigResponse{
+			NetworkID:           networks.L1NetworkID,
+			BridgeAddress:       bridgetypes.Address(common.HexToAddress("0x1").Hex()),
+			GERAddress:          bridgetypes.Address(common.HexToAddress("0x5").Hex()),
+			BridgeBlockFinality: "FinalizedBlock",
+			GERBlockFinality:    "SafeBlock",
+		},
+		L2: bridgetypes.NetworkConfigResponse{
+			NetworkID:           l2NetworkID,
+			BridgeAddress:       bridgetypes.Address(common.HexToAddress("0x1").Hex()),
+			GERAddress:          bridgetypes.Address(common.HexToAddress("0x2").Hex()),
+			BridgeBlockFinality: "LatestBlock",
+			GERBlockFinality:    "LatestBlock",
+		},
+	}, response)
+}
+
+func TestClaimsReconciliation(t *testing.T) {
+	ctx := context.Background()
+	b := newBridgeWithMocks(t, l2NetworkID)
+	reconciler := newClaimsReconciler(b.bridge.logger, b.bridge.meter, b.bridgeL1, b.bridgeL2, time.Minute)
+
+	newBridge := func(depositCount uint32, amount int64) *bridgesync.Bridge {
+		return &bridgesync.Bridge{
+			DepositCount:       depositCount,
+			OriginNetwork:      0,
+			OriginAddress:      common.HexToAddress("0x1"),
+			DestinationNetwork: l2NetworkID,
+			DestinationAddress: common.HexToAddress("0x2"),
+			Amount:             big.NewInt(amount),
+		}
+	}
+	newClaim := func(blockNum uint64, globalIndex *big.Int) bridgesync.Claim {
+		return bridgesync.Claim{
+			BlockNum:           blockNum,
+			TxHash:             common.BigToHash(new(big.Int).SetUint64(blockNum)),
+			GlobalIndex:        globalIndex,
+			OriginNetwork:      0,
+			OriginAddress:      common.HexToAddress("0x1"),
+			DestinationNetwork: l2NetworkID,
+			DestinationAddress: common.HexToAddress("0x2"),
+			Amount:             big.NewInt(100),
+		}
+	}
+	matchingClaim := newClaim(10, bridgesync.GenerateGlobalIndex(true, 0, 1))
+	noBridgeClaim := newClaim(11, bridgesync.GenerateGlobalIndex(true, 0, 2))
+	mismatchClaim := newClaim(12, bridgesync.GenerateGlobalIndex(true, 0, 3))
+	pendingClaim := newClaim(13, bridgesync.GenerateGlobalIndex(true, 0, 10))
+	rollupClaim := newClaim(14, bridgesync.GenerateGlobalIndex(false, 1, 2))
+	duplicatedClaim := newClaim(15, bridgesync.GenerateGlobalIndex(true, 0, 1))
+	duplicatedNoBridgeClaim := newClaim(16, bridgesync.GenerateGlobalIndex(true, 0, 2))
+	duplicatedAgainClaim := newClaim(110, bridgesync.GenerateGlobalIndex(true, 0, 1))
+
+	// first run: the claims up to the finalized block are checked, with a single query of their L1 bridges
+	b.bridgeL2.EXPECT().GetFinalityBlocks(ctx).Return(bridgesync.FinalityBlocks{Safe: 110, Finalized: 100}, nil).Once()
+	b.bridgeL2.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(120), nil).Times(3)
+	b.bridgeL2.EXPECT().GetClaims(ctx, uint64(1), uint64(100)).Return([]bridgesync.Claim{
+		matchingClaim, noBridgeClaim, mismatchClaim, pendingClaim, rollupClaim, duplicatedClaim, duplicatedNoBridgeClaim,
+	}, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+		(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{newBridge(5, 100)}, 6, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesByDepositCounts(ctx, []uint32{1, 2, 3, 1, 2}).
+		Return([]*bridgesync.Bridge{newBridge(1, 100), newBridge(3, 99)}, nil).Once()
+	b.bridgeL2.EXPECT().GetDuplicatedClaims(ctx, uint64(1), uint64(100), uint32(maxDuplicatedClaims)).
+		Return([]*bridgesync.Claim{&matchingClaim, &duplicatedClaim}, 2, nil).Once()
+
+	require.NoError(t, reconciler.reconcile(ctx))
+	report := reconciler.getReport()
+	require.True(t, report.Enabled)
+	require.NotZero(t, report.LastRunAt)
+	require.Empty(t, report.LastError)
+	require.Equal(t, uint64(100), report.CheckedUpToBlock)
+	require.Equal(t, 1, report.PendingClaims)
+	// the orphan claims are reported once per global index
+	require.Equal(t, []bridgetypes.ClaimFinding{
+		newClaimFinding(noBridgeClaim, "no bridge with deposit count 2 on L1"),
+		newClaimFinding(mismatchClaim, "the amount of the L1 bridge with deposit count 3 doesn't match"),
+	}, report.OrphanClaims)
+	require.Zero(t, report.OmittedOrphanClaims)
+	require.Equal(t, []bridgetypes.ClaimFinding{
+		newClaimFinding(matchingClaim, "global index claimed more than once"),
+		newClaimFinding(duplicatedClaim, "global index claimed more than once"),
+	}, report.DuplicatedClaims)
+	require.Zero(t, report.OmittedDuplicatedClaims)
+
+	// second run: the new finalized blocks have no claims, the L1 syncer reaches the pending claim
+	// and the duplicated claims of the new blocks can't be loaded
+	b.bridgeL2.EXPECT().GetFinalityBlocks(ctx).Return(bridgesync.FinalityBlocks{Safe: 200, Finalized: 200}, nil).Twice()
+	b.bridgeL2.EXPECT().GetClaims(ctx, uint64(101), uint64(120)).Return(nil, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+		(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{newBridge(10, 100)}, 11, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesByDepositCounts(ctx, []uint32{10}).
+		Return([]*bridgesync.Bridge{newBridge(10, 100)}, nil).Once()
+	b.bridgeL2.EXPECT().GetDuplicatedClaims(ctx, uint64(101), uint64(120), uint32(maxDuplicatedClaims-2)).
+		Return(nil, 0, errors.New(fooErrMsg)).Once()
+
+	require.ErrorContains(t, reconciler.reconcile(ctx), fooErrMsg)
+	report = reconciler.getReport()
+	require.Equal(t, uint64(120), report.CheckedUpToBlock)
+	require.Equal(t, 0, report.PendingClaims)
+	require.Len(t, report.OrphanClaims, 2)
+	require.Len(t, report.DuplicatedClaims, 2)
+	require.Equal(t, "failed to get the L2 duplicated claims of blocks 101-120: "+fooErrMsg, report.LastError)
+
+	// third run: only the duplicated claims of the blocks not checked yet are loaded, and the claims
+	// already reported of a global index claimed again are not repeated
+	b.bridgeL2.EXPECT().GetDuplicatedClaims(ctx, uint64(101), uint64(120), uint32(maxDuplicatedClaims-2)).
+		Return([]*bridgesync.Claim{&matchingClaim, &duplicatedClaim, &duplicatedAgainClaim}, 3, nil).Once()
+
+	require.NoError(t, reconciler.reconcile(ctx))
+	report = reconciler.getReport()
+	require.Empty(t, report.LastError)
+	require.Equal(t, []bridgetypes.ClaimFinding{
+		newClaimFinding(matchingClaim, "global index claimed more than once"),
+		newClaimFinding(duplicatedClaim, "global index claimed more than once"),
+		newClaimFinding(duplicatedAgainClaim, "global index claimed more than once"),
+	}, report.DuplicatedClaims)
+	require.Zero(t, report.OmittedDuplicatedClaims)
+}
+
+func TestClaimsReconciliationBatches(t *testing.T) {
+	ctx := context.Background()
+	b := newBridgeWithMocks(t, l2NetworkID)
+	reconciler := newClaimsReconciler(b.bridge.logger, b.bridge.meter, b.bridgeL1, b.bridgeL2, time.Minute)
+
+	// one claim more than the limit of orphan claims, and a claim of a global index already reported
+	claims := make([]bridgesync.Claim, 0, maxOrphanClaims+2)
+	for depositCount := uint32(0); depositCount <= maxOrphanClaims; depositCount++ {
+		claims = append(claims, bridgesync.Claim{
+			BlockNum:    uint64(depositCount) + 1,
+			GlobalIndex: bridgesync.GenerateGlobalIndex(true, 0, depositCount),
+			Amount:      big.NewInt(1),
+		})
+	}
+	claims = append(claims, claims[0])
+
+	// the duplicated claims loaded reach the limit and two more are found
+	duplicatedClaims := make([]*bridgesync.Claim, 0, maxDuplicatedClaims)
+	for i := range maxDuplicatedClaims {
+		duplicatedClaims = append(duplicatedClaims, &bridgesync.Claim{
+			BlockNum:    uint64(i/2) + 1,
+			BlockPos:    uint64(i % 2),
+			GlobalIndex: bridgesync.GenerateGlobalIndex(false, 1, uint32(i/2)),
+		})
+	}
+
+	b.bridgeL2.EXPECT().GetFinalityBlocks(ctx).Return(bridgesync.FinalityBlocks{Finalized: 10}, nil).Once()
+	b.bridgeL2.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(10), nil).Once()
+	b.bridgeL2.EXPECT().GetClaims(ctx, uint64(1), uint64(10)).Return(claims, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+		(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{{DepositCount: maxOrphanClaims}}, 1, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesByDepositCounts(ctx, mock.Anything).Return([]*bridgesync.Bridge{}, nil).Times(3)
+	b.bridgeL2.EXPECT().GetDuplicatedClaims(ctx, uint64(1), uint64(10), uint32(maxDuplicatedClaims)).
+		Return(duplicatedClaims, maxDuplicatedClaims+2, nil).Once()
+
+	require.NoError(t, reconciler.reconcile(ctx))
+	report := reconciler.getReport()
+	require.Len(t, report.OrphanClaims, maxOrphanClaims)
+	require.Equal(t, 1, report.OmittedOrphanClaims)
+	require.Equal(t, uint64(1), report.OrphanClaims[0].BlockNum)
+	require.Zero(t, report.PendingClaims)
+	require.Len(t, report.DuplicatedClaims, maxDuplicatedClaims)
+	require.Equal(t, 2, report.OmittedDuplicatedClaims)
+
+	// once the limit is reached, the duplicated claims of the new blocks are only counted
+	b.bridgeL2.EXPECT().GetFinalityBlocks(ctx).Return(bridgesync.FinalityBlocks{Finalized: 20}, nil).Once()
+	b.bridgeL2.EXPECT().GetLastProcessedBlock(ctx).Return(uint64(20), nil).Once()
+	b.bridgeL2.EXPECT().GetClaims(ctx, uint64(11), uint64(20)).Return(nil, nil).Once()
+	b.bridgeL1.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(1), (*uint64)(nil), []uint32(nil), "",
+		(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{{DepositCount: maxOrphanClaims}}, 1, nil).Once()
+	b.bridgeL2.EXPECT().GetDuplicatedClaims(ctx, uint64(11), uint64(20), uint32(0)).
+		Return([]*bridgesync.Claim{}, 3, nil).Once()
+
+	require.NoError(t, reconciler.reconcile(ctx))
+	report = reconciler.getReport()
+	require.Len(t, report.DuplicatedClaims, maxDuplicatedClaims)
+	require.Equal(t, 5, report.OmittedDuplicatedClaims)
+}
+
+func TestGetClaimsReconciliationHandler(t *testing.T) {
+	b := newBridgeWithMocks(t, l2NetworkID)
+	const adminToken = "admin-token"
+	request := func(method, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, BridgeV1Prefix+"/admin/claims-reconciliation", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		b.bridge.router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("no admin token configured", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, request(http.MethodGet, "Bearer ").Code)
+	})
+
+	b.bridge.adminToken = adminToken
+
+	t.Run("invalid admin token", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "").Code)
+		require.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "Bearer foo").Code)
+		require.Equal(t, http.StatusUnauthorized, request(http.MethodGet, adminToken).Code)
+		require.Equal(t, http.StatusUnauthorized, request(http.MethodHead, "").Code)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w := request(http.MethodGet, "Bearer "+adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var report bridgetypes.ClaimsReconciliationReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		require.False(t, report.Enabled)
+		require.Empty(t, report.OrphanClaims)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		reconciler := newClaimsReconciler(b.bridge.logger, b.bridge.meter, b.bridgeL1, b.bridgeL2, time.Minute)
+		finding := bridgetypes.ClaimFinding{GlobalIndex: "1", BlockNum: 2, TxHash: "0x3", Reason: "no bridge"}
+		reconciler.report.OrphanClaims = []bridgetypes.ClaimFinding{finding}
+		reconciler.report.CheckedUpToBlock = 10
+		b.bridge.claimsReconciler = reconciler
+
+		w := request(http.MethodGet, "Bearer "+adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var report bridgetypes.ClaimsReconciliationReport
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		require.True(t, report.Enabled)
+		require.Equal(t, uint64(10), report.CheckedUpToBlock)
+		require.Equal(t, []bridgetypes.ClaimFinding{finding}, report.OrphanClaims)
+		require.Empty(t, report.DuplicatedClaims)
+	})
+}
+
+func TestRequestTimeout(t *testing.T) {
+	b := newBridgeWithMocks(t, 1)
+	b.bridge.readTimeout = 2 * time.Second
+	b.bridge.writeTimeout = 3 * time.Second
+	b.bridge.endpointTimeouts = map[string]time.Duration{"claim-proof": 10 * time.Second}
+
+	router := gin.New()
+	timeouts := map[string]time.Duration{}
+	handler := func(c *gin.Context) {
+		timeouts[endpointName(c)] = b.bridge.requestTimeout(c)
+	}
+	router.GET(BridgeV1Prefix+"/claim-proof", handler)
+	router.GET(BridgeV1Prefix+"/bridges", handler)
+
+	performRequest(t, router, http.MethodGet, BridgeV1Prefix+"/claim-proof?network_id=0", nil)
+	performRequest(t, router, http.MethodGet, BridgeV1Prefix+"/bridges", nil)
+	require.Equal(t, map[string]time.Duration{
+		"claim-proof": 10 * time.Second,
+		"bridges":     2 * time.Second,
+	}, timeouts)
+
+	// the server doesn't cut the responses of the endpoints with a longer timeout
+	require.Equal(t, 10*time.Second, b.bridge.serverWriteTimeout())
+	b.bridge.writeTimeout = 0
+	require.Equal(t, time.Duration(0), b.bridge.serverWriteTimeout())
+}
+
+func TestSlowRequestHandler(t *testing.T) {
+	logger := aggsendermocks.NewLogger(t)
+	router := gin.New()
+	router.Use(SlowRequestHandler(logger, otel.Meter(meterName), 20*time.Millisecond))
+	router.GET(BridgeV1Prefix+"/claim-proof", func(c *gin.Context) {
+		time.Sleep(30 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	router.GET(BridgeV1Prefix+"/bridges", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// fast requests are not logged
+	performRequest(t, router, http.MethodGet, BridgeV1Prefix+"/bridges", nil)
+
+	logger.EXPECT().Warnf(mock.MatchedBy(func(format string) bool {
+		return strings.HasPrefix(format, "slow request")
+	}), http.MethodGet, BridgeV1Prefix+"/claim-proof", mock.Anything, 20*time.Millisecond, http.StatusOK,
+		url.Values{"network_id": []string{"0"}, "leaf_index": []string{"5"}}).Once()
+	performRequest(t, router, http.MethodGet, BridgeV1Prefix+"/claim-proof?network_id=0&leaf_index=5", nil)
+
+	// disabled
+	disabledRouter := gin.New()
+	disabledRouter.Use(SlowRequestHandler(logger, otel.Meter(meterName), 0))
+	disabledRouter.GET(BridgeV1Prefix+"/claim-proof", func(c *gin.Context) {
+		time.Sleep(30 * time.Millisecond)
+	})
+	performRequest(t, disabledRouter, http.MethodGet, BridgeV1Prefix+"/claim-proof", nil)
+}
+
+func TestRequestIDHandler(t *testing.T) {
+	router := gin.New()
+	router.Use(RequestIDHandler())
+	router.GET(BridgeV1Prefix+"/bridges", func(c *gin.Context) {
+		c.String(http.StatusOK, requestID(c))
+	})
+
+	tests := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{name: "generated", generated: true},
+		{name: "sent by the client", header: "support-ticket-42"},
+		{name: "invalid characters", header: "id\twith\ttabs", generated: true},
+		{name: "too long", header: strings.Repeat("a", maxRequestIDLength+1), generated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, BridgeV1Prefix+"/bridges", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			require.Equal(t, id, w.Body.String())
+			if tt.generated {
+				require.Len(t, id, 2*requestIDBytes)
+				require.NotEqual(t, tt.header, id)
+			} else {
+				require.Equal(t, tt.header, id)
+			}
+		})
+	}
+}
+
+func TestNewPaginationMetadata(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		pageNumber uint32
+		pageSize   uint32
+		expected   bridgetypes.PaginationMetadata
+	}{
+		{name: "no results", count: 0, pageNumber: 1, pageSize: 10,
+			expected: bridgetypes.PaginationMetadata{RequestID: "id"}},
+		{name: "first of several pages", count: 25, pageNumber: 1, pageSize: 10,
+			expected: bridgetypes.PaginationMetadata{TotalPages: 3, HasNext: true, RequestID: "id"}},
+		{name: "last page", count: 25, pageNumber: 3, pageSize: 10,
+			expected: bridgetypes.PaginationMetadata{TotalPages: 3, HasNext: false, RequestID: "id"}},
+		{name: "exact pages", count: 20, pageNumber: 2, pageSize: 10,
+			expected: bridgetypes.PaginationMetadata{TotalPages: 2, HasNext: false, RequestID: "id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, bridgetypes.NewPaginationMetadata(tt.count, tt.pageNumber, tt.pageSize, "id"))
+		})
+	}
+}
+
+func TestGetClaimByGlobalIndexHandler(t *testing.T) {
+	claimURL := func(globalIndex *big.Int) string {
+		return fmt.Sprintf("%s/claims/%s", BridgeV1Prefix, globalIndex.String())
+	}
+	decodeResponse := func(t *testing.T, w *httptest.ResponseRecorder) bridgetypes.ClaimLookupResponse {
+		t.Helper()
+		var response bridgetypes.ClaimLookupResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	t.Run("invalid global index", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		for _, param := range []string{"foo", "-1"} {
+			w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+				fmt.Sprintf("%s/claims/%s", BridgeV1Prefix, param), nil)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), "invalid global_index parameter")
+		}
+	})
+
+	t.Run("claimed on L2", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		globalIndex := bridgesync.GenerateGlobalIndex(true, 0, 5)
+		claim := &bridgesync.Claim{
+			BlockNum:           10,
+			GlobalIndex:        globalIndex,
+			OriginNetwork:      mainnetNetworkID,
+			DestinationNetwork: l2NetworkID,
+			Amount:             big.NewInt(100),
+		}
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).
+			Return([]*bridgesync.Claim{claim}, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusClaimed, response.Status)
+		require.True(t, response.MainnetFlag)
+		require.Equal(t, uint32(5), response.DepositCount)
+		require.Equal(t, uint32(mainnetNetworkID), response.OriginNetwork)
+		require.NotNil(t, response.ClaimedOnNetwork)
+		require.Equal(t, l2NetworkID, *response.ClaimedOnNetwork)
+		require.Equal(t, NewClaimResponse(claim, false), response.Claim)
+		require.Nil(t, response.Deposit)
+	})
+
+	t.Run("L1 deposit claimable on L2", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		depositCount := uint32(5)
+		globalIndex := bridgesync.GenerateGlobalIndex(true, 0, depositCount)
+		bridge := bridgesync.Bridge{
+			BlockNum:           3,
+			OriginNetwork:      mainnetNetworkID,
+			DestinationNetwork: l2NetworkID,
+			Amount:             big.NewInt(100),
+			DepositCount:       depositCount,
+		}
+		info := &l1infotreesync.L1InfoTreeLeaf{
+			BlockNumber:     10,
+			L1InfoTreeIndex: 7,
+			MainnetExitRoot: common.HexToHash("0x1"),
+		}
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, uint32(1), uint32(1), mock.Anything, mock.Anything, "", mock.Anything).
+			Return([]*bridgesync.Bridge{&bridge}, 1, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetLastInfo().Return(info, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetFirstInfo().Return(info, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetFirstInfoAfterBlock(info.BlockNumber).Return(info, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetRootByLER(mock.Anything, info.MainnetExitRoot).
+			Return(&tree.Root{Index: depositCount}, nil)
+		bridgeMocks.injectedGERs.EXPECT().GetFirstGERAfterL1InfoTreeIndex(mock.Anything, info.L1InfoTreeIndex).
+			Return(lastgersync.GlobalExitRootInfo{L1InfoTreeIndex: info.L1InfoTreeIndex}, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusClaimable, response.Status)
+		require.Nil(t, response.Claim)
+		require.Equal(t, NewBridgeResponse(&bridge), response.Deposit)
+		require.NotNil(t, response.L1InfoTreeIndex)
+		require.Equal(t, info.L1InfoTreeIndex, *response.L1InfoTreeIndex)
+	})
+
+	t.Run("L1 deposit without injected GER is pending", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		depositCount := uint32(5)
+		globalIndex := bridgesync.GenerateGlobalIndex(true, 0, depositCount)
+		info := &l1infotreesync.L1InfoTreeLeaf{
+			BlockNumber:     10,
+			L1InfoTreeIndex: 7,
+			MainnetExitRoot: common.HexToHash("0x1"),
+		}
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, uint32(1), uint32(1), mock.Anything, mock.Anything, "", mock.Anything).
+			Return([]*bridgesync.Bridge{{DestinationNetwork: l2NetworkID, DepositCount: depositCount}}, 1, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetLastInfo().Return(info, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetFirstInfo().Return(info, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetFirstInfoAfterBlock(info.BlockNumber).Return(info, nil)
+		bridgeMocks.bridgeL1.EXPECT().GetRootByLER(mock.Anything, info.MainnetExitRoot).
+			Return(&tree.Root{Index: depositCount}, nil)
+		bridgeMocks.injectedGERs.EXPECT().GetFirstGERAfterL1InfoTreeIndex(mock.Anything, info.L1InfoTreeIndex).
+			Return(lastgersync.GlobalExitRootInfo{}, db.ErrNotFound)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusPending, response.Status)
+		require.Nil(t, response.L1InfoTreeIndex)
+		require.Contains(t, response.Reason, "no global exit root injected")
+	})
+
+	t.Run("L2 deposit not yet on the L1 info tree", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		depositCount := uint32(5)
+		globalIndex := bridgesync.GenerateGlobalIndex(false, l2NetworkID-1, depositCount)
+		lastVerified := &l1infotreesync.VerifyBatches{BlockNumber: 10, ExitRoot: common.HexToHash("0x1")}
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().
+			GetBridgesPaged(mock.Anything, uint32(1), uint32(1), mock.Anything, mock.Anything, "", mock.Anything).
+			Return([]*bridgesync.Bridge{{DestinationNetwork: mainnetNetworkID, DepositCount: depositCount}}, 1, nil)
+		bridgeMocks.l1InfoTree.EXPECT().GetLastVerifiedBatches(l2NetworkID).Return(lastVerified, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetRootByLER(mock.Anything, lastVerified.ExitRoot).
+			Return(&tree.Root{Index: depositCount - 1}, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusPending, response.Status)
+		require.False(t, response.MainnetFlag)
+		require.Equal(t, l2NetworkID, response.OriginNetwork)
+		require.NotNil(t, response.Deposit)
+		require.Equal(t, ErrNotOnL1Info.Error(), response.Reason)
+	})
+
+	t.Run("deposit not synced yet", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		globalIndex := bridgesync.GenerateGlobalIndex(true, 0, 5)
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL1.EXPECT().
+			GetBridgesPaged(mock.Anything, uint32(1), uint32(1), mock.Anything, mock.Anything, "", mock.Anything).
+			Return(nil, 0, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusUnknown, response.Status)
+		require.Nil(t, response.Deposit)
+	})
+
+	t.Run("deposit of a network not indexed", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		globalIndex := bridgesync.GenerateGlobalIndex(false, 2, 5)
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).Return(nil, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		response := decodeResponse(t, w)
+		require.Equal(t, claimStatusUnknown, response.Status)
+		require.Equal(t, uint32(3), response.OriginNetwork)
+		require.Contains(t, response.Reason, "not indexed")
+	})
+
+	t.Run("error getting the claims", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		globalIndex := bridgesync.GenerateGlobalIndex(true, 0, 5)
+
+		bridgeMocks.bridgeL1.EXPECT().GetClaimsByGlobalIndex(mock.Anything, globalIndex).
+			Return(nil, errors.New(fooErrMsg))
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, claimURL(globalIndex), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), fooErrMsg)
+	})
+}
+
+func TestGetTxEventsHandler(t *testing.T) {
+	txHash := common.HexToHash("0xabc")
+	txURL := fmt.Sprintf("%s/tx/%s", BridgeV1Prefix, txHash.Hex())
+
+	t.Run("invalid tx hash", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+
+		for _, param := range []string{"foo", "0x1234"} {
+			w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+				fmt.Sprintf("%s/tx/%s", BridgeV1Prefix, param), nil)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), "invalid tx_hash parameter")
+		}
+	})
+
+	t.Run("bridge on L2 and claim on L1", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridge := &bridgesync.Bridge{BlockNum: 3, TxHash: txHash, OriginNetwork: l2NetworkID,
+			DestinationNetwork: mainnetNetworkID, Amount: big.NewInt(100)}
+		claim := &bridgesync.Claim{BlockNum: 10, TxHash: txHash, GlobalIndex: big.NewInt(1), Amount: big.NewInt(100)}
+
+		bridgeMocks.bridgeL1.EXPECT().GetBridgesAndClaimsByTxHash(mock.Anything, txHash).
+			Return(nil, []*bridgesync.Claim{claim}, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetBridgesAndClaimsByTxHash(mock.Anything, txHash).
+			Return([]*bridgesync.Bridge{bridge}, nil, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, txURL, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.TxEventsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.True(t, response.Found)
+		require.Equal(t, bridgetypes.Hash(txHash.Hex()), response.TxHash)
+		require.Equal(t, []bridgetypes.TxNetworkEvents{
+			{
+				NetworkID: mainnetNetworkID,
+				Bridges:   []*bridgetypes.BridgeResponse{},
+				Claims:    []*bridgetypes.ClaimResponse{NewClaimResponse(claim, false)},
+			},
+			{
+				NetworkID: l2NetworkID,
+				Bridges:   []*bridgetypes.BridgeResponse{NewBridgeResponse(bridge)},
+				Claims:    []*bridgetypes.ClaimResponse{},
+			},
+		}, response.Networks)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetBridgesAndClaimsByTxHash(mock.Anything, txHash).Return(nil, nil, nil)
+		bridgeMocks.bridgeL2.EXPECT().GetBridgesAndClaimsByTxHash(mock.Anything, txHash).Return(nil, nil, nil)
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, txURL, nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response bridgetypes.TxEventsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.False(t, response.Found)
+		require.Len(t, response.Networks, 2)
+	})
+
+	t.Run("error getting the events", func(t *testing.T) {
+		bridgeMocks := newBridgeWithMocks(t, l2NetworkID)
+		bridgeMocks.bridgeL1.EXPECT().GetBridgesAndClaimsByTxHash(mock.Anything, txHash).
+			Return(nil, nil, errors.New(fooErrMsg))
+
+		w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet, txURL, nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), fooErrMsg)
+	})
+}
+
+func TestGetUSDValueStatsHandler(t *testing.T) {
+	t.Run("stats of the L2 network", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridgeL2.EXPECT().GetUSDValueStats(mock.Anything, uint64(100), uint64(200)).Return(&bridgesync.USDValueStats{
+			BridgedUSD:      "10.500000",
+			ClaimedUSD:      "2.000000",
+			PricedBridges:   2,
+			UnpricedBridges: 1,
+			PricedClaims:    1,
+			Tokens: []*bridgesync.TokenUSDValueStats{{
+				OriginNetwork: 0,
+				OriginAddress: common.HexToAddress("0x1"),
+				BridgedUSD:    "10.500000",
+				ClaimedUSD:    "2.000000",
+				Bridges:       3,
+				Claims:        1,
+			}},
+		}, nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet, fmt.Sprintf("%s/usd-value-stats?network_id=%d&from_timestamp=100&to_timestamp=200",
+			BridgeV1Prefix, l2NetworkID), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var stats bridgetypes.USDValueStats
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		require.Equal(t, bridgetypes.USDValueStats{
+			NetworkID:       l2NetworkID,
+			FromTimestamp:   100,
+			ToTimestamp:     200,
+			BridgedUSD:      "10.500000",
+			ClaimedUSD:      "2.000000",
+			PricedBridges:   2,
+			UnpricedBridges: 1,
+			PricedClaims:    1,
+			Tokens: []bridgetypes.TokenUSDValueStats{{
+				OriginNetwork: 0,
+				OriginAddress: bridgetypes.Address(common.HexToAddress("0x1").Hex()),
+				BridgedUSD:    "10.500000",
+				ClaimedUSD:    "2.000000",
+				Bridges:       3,
+				Claims:        1,
+			}},
+		}, stats)
+	})
+
+	t.Run("L1 error", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridgeL1.EXPECT().GetUSDValueStats(mock.Anything, uint64(0), mock.Anything).Return(nil, errors.New("db error"))
+
+		w := performRequest(t, b.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/usd-value-stats?network_id=%d", BridgeV1Prefix, mainnetNetworkID), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "db error")
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/usd-value-stats?network_id=%d&from_timestamp=200&to_timestamp=100", BridgeV1Prefix, l2NetworkID), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "from_timestamp (200) must be lower or equal than to_timestamp (100)")
+
+		w = performRequest(t, b.bridge.router, http.MethodGet, BridgeV1Prefix+"/usd-value-stats?network_id=99", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = performRequest(t, b.bridge.router, http.MethodGet, BridgeV1Prefix+"/usd-value-stats", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetClaimGasStatsHandler(t *testing.T) {
+	t.Run("stats of the L2 network", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridgeL2.EXPECT().GetClaimGasStats(mock.Anything, uint64(100), uint64(200)).Return(&bridgesync.ClaimGasStats{
+			Claims:                   3,
+			UntrackedClaims:          1,
+			Transactions:             1,
+			TotalGasUsed:             200_000,
+			TotalFee:                 big.NewInt(2_000_000),
+			AverageGasUsed:           200_000,
+			AverageEffectiveGasPrice: big.NewInt(10),
+			AverageFeePerClaim:       big.NewInt(1_000_000),
+		}, nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet, fmt.Sprintf("%s/claim-gas-stats?network_id=%d&from_timestamp=100&to_timestamp=200",
+			BridgeV1Prefix, l2NetworkID), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var stats bridgetypes.ClaimGasStats
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		averagePrice := bridgetypes.BigIntString("10")
+		averageFee := bridgetypes.BigIntString("1000000")
+		require.Equal(t, bridgetypes.ClaimGasStats{
+			NetworkID:                l2NetworkID,
+			FromTimestamp:            100,
+			ToTimestamp:              200,
+			Claims:                   3,
+			UntrackedClaims:          1,
+			Transactions:             1,
+			TotalGasUsed:             200_000,
+			TotalFee:                 "2000000",
+			AverageGasUsed:           200_000,
+			AverageEffectiveGasPrice: &averagePrice,
+			AverageFeePerClaim:       &averageFee,
+		}, stats)
+	})
+
+	t.Run("no fees", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridgeL1.EXPECT().GetClaimGasStats(mock.Anything, uint64(0), mock.Anything).Return(&bridgesync.ClaimGasStats{
+			Claims:          1,
+			UntrackedClaims: 1,
+			TotalFee:        big.NewInt(0),
+		}, nil)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/claim-gas-stats?network_id=%d", BridgeV1Prefix, mainnetNetworkID), nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotContains(t, w.Body.String(), "average_fee_per_claim")
+		require.Contains(t, w.Body.String(), `"total_fee":"0"`)
+	})
+
+	t.Run("L1 error", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+		b.bridgeL1.EXPECT().GetClaimGasStats(mock.Anything, uint64(0), mock.Anything).Return(nil, errors.New("db error"))
+
+		w := performRequest(t, b.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/claim-gas-stats?network_id=%d", BridgeV1Prefix, mainnetNetworkID), nil)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Contains(t, w.Body.String(), "db error")
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		b := newBridgeWithMocks(t, l2NetworkID)
+
+		w := performRequest(t, b.bridge.router, http.MethodGet,
+			fmt.Sprintf("%s/claim-gas-stats?network_id=%d&from_timestamp=200&to_timestamp=100", BridgeV1Prefix, l2NetworkID), nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "from_timestamp (200) must be lower or equal than to_timestamp (100)")
+
+		w = performRequest(t, b.bridge.router, http.MethodGet, BridgeV1Prefix+"/claim-gas-stats?network_id=99", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestNewClaimResponseGas(t *testing.T) {
+	claim := &bridgesync.Claim{GlobalIndex: big.NewInt(1), Amount: big.NewInt(1)}
+	response := NewClaimResponse(claim, false)
+	require.Nil(t, response.GasUsed)
+	require.Nil(t, response.EffectiveGasPrice)
+
+	gasUsed := uint64(120000)
+	claim.GasUsed = &gasUsed
+	claim.EffectiveGasPrice = big.NewInt(1000000000)
+	response = NewClaimResponse(claim, false)
+	require.Equal(t, &gasUsed, response.GasUsed)
+	require.NotNil(t, response.EffectiveGasPrice)
+	require.Equal(t, bridgetypes.BigIntString("1000000000"), *response.EffectiveGasPrice)
+}
+
+func TestClaimProofCache(t *testing.T) {
+	ctx := context.Background()
+	b := newBridgeWithMocks(t, l2NetworkID)
+	cache := newClaimProofCache(b.bridge.logger, b.bridge.meter, b.bridge, 2, time.Minute)
+	b.bridge.claimProofCache = cache
+
+	info := &l1infotreesync.L1InfoTreeLeaf{
+		BlockNumber:     10,
+		L1InfoTreeIndex: 5,
+		MainnetExitRoot: common.HexToHash("0x1"),
+		RollupExitRoot:  common.HexToHash("0x2"),
+		Hash:            common.HexToHash("0x5"),
+	}
+	newInfo := &l1infotreesync.L1InfoTreeLeaf{
+		BlockNumber:     11,
+		L1InfoTreeIndex: 6,
+		MainnetExitRoot: common.HexToHash("0x1"),
+		RollupExitRoot:  common.HexToHash("0x3"),
+		Hash:            common.HexToHash("0x6"),
+	}
+	localExitProof := tree.Proof{common.HexToHash("0xa")}
+	rollupExitProof := tree.Proof{common.HexToHash("0xb")}
+	noClaims := []*bridgesync.Claim{}
+
+	expectLastBridges := func() {
+		b.bridgeL1.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(2), (*uint64)(nil), []uint32(nil), "",
+			(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{{DepositCount: 3}, {DepositCount: 2}}, 4, nil).Once()
+		b.bridgeL2.EXPECT().GetBridgesPaged(ctx, uint32(1), uint32(2), (*uint64)(nil), []uint32(nil), "",
+			(*bridgesync.BlockNumFilter)(nil)).Return([]*bridgesync.Bridge{{DepositCount: 7}}, 8, nil).Once()
+		// the L1 deposit 2 is claimed on L2
+		b.bridgeL1.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(true, 0, 3)).Return(noClaims, nil).Once()
+		b.bridgeL2.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(true, 0, 3)).Return(noClaims, nil).Once()
+		b.bridgeL1.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(true, 0, 2)).Return(noClaims, nil).Once()
+		b.bridgeL2.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(true, 0, 2)).
+			Return([]*bridgesync.Claim{{}}, nil).Once()
+		b.bridgeL1.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(false, l2NetworkID-1, 7)).
+			Return(noClaims, nil).Once()
+		b.bridgeL2.EXPECT().GetClaimsByGlobalIndex(ctx, bridgesync.GenerateGlobalIndex(false, l2NetworkID-1, 7)).
+			Return(noClaims, nil).Once()
+		// the L2 deposit 7 is not verified on L1 yet
+		b.l1InfoTree.EXPECT().GetLastVerifiedBatches(l2NetworkID).Return(nil, db.ErrNotFound).Once()
+	}
+
+	// first refresh: the proof of the L1 deposit 3 is computed against the first leaf that includes it
+	b.l1InfoTree.EXPECT().GetLastInfo().Return(info, nil).Times(3)
+	expectLastBridges()
+	b.bridgeL1.EXPECT().GetRootByLER(ctx, info.MainnetExitRoot).Return(&tree.Root{Index: 3}, nil).Twice()
+	b.l1InfoTree.EXPECT().GetFirstInfo().Return(info, nil).Once()
+	b.l1InfoTree.EXPECT().GetFirstInfoAfterBlock(info.BlockNumber).Return(info, nil).Once()
+	b.l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(5)).Return(info, nil).Once()
+	b.bridgeL1.EXPECT().GetProof(ctx, uint32(3), info.MainnetExitRoot).Return(localExitProof, nil).Once()
+	b.l1InfoTree.EXPECT().GetRollupExitTreeMerkleProof(ctx, uint32(mainnetNetworkID), info.RollupExitRoot).
+		Return(rollupExitProof, nil).Once()
+
+	require.NoError(t, cache.refresh(ctx))
+	expectedProof := &bridgetypes.ClaimProof{
+		ProofLocalExitRoot:  bridgetypes.ConvertToProofResponse(localExitProof),
+		ProofRollupExitRoot: bridgetypes.ConvertToProofResponse(rollupExitProof),
+		L1InfoTreeLeaf:      *NewL1InfoTreeLeafResponse(info),
+	}
+	require.Equal(t, expectedProof, cache.get(mainnetNetworkID, 5, 3, info))
+	require.Nil(t, cache.get(mainnetNetworkID, 6, 3, newInfo))
+	require.Nil(t, cache.get(mainnetNetworkID, 5, 3, &l1infotreesync.L1InfoTreeLeaf{Hash: common.HexToHash("0xdead")}))
+	require.Nil(t, cache.get(mainnetNetworkID, 5, 2, info))
+
+	// the claim proof endpoint returns the cached proof without walking the trees
+	b.l1InfoTree.EXPECT().GetInfoByIndex(mock.Anything, uint32(5)).Return(info, nil).Once()
+	response := performRequest(t, b.bridge.router, http.MethodGet,
+		fmt.Sprintf("%s/claim-proof?%s=%d&%s=5&%s=3", BridgeV1Prefix, networkIDParam, mainnetNetworkID,
+			leafIndexParam, depositCountParam), nil)
+	require.Equal(t, http.StatusOK, response.Code)
+	var proof bridgetypes.ClaimProof
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &proof))
+	require.Equal(t, *expectedProof, proof)
+
+	// second refresh: there is no new l1 info tree root
+	require.NoError(t, cache.refresh(ctx))
+
+	// third refresh: a new root arrives and the proof of the deposit 3 is reused, its leaf hasn't changed
+	b.l1InfoTree.EXPECT().GetLastInfo().Return(newInfo, nil).Once()
+	expectLastBridges()
+	b.l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(5)).Return(info, nil).Once()
+
+	require.NoError(t, cache.refresh(ctx))
+	require.Equal(t, expectedProof, cache.get(mainnetNetworkID, 5, 3, info))
+}
+
+func TestUnclaimedDepositsGuard(t *testing.T) {
+	ctx := context.Background()
+	l1InfoTree := mocks.NewL1InfoTreer(t)
+	bridgeL1 := mocks.NewBridger(t)
+	bridgeL2 := mocks.NewBridger(t)
+	networksRegistry, err := networks.NewDefaultRegistry(l2NetworkID)
+	require.NoError(t, err)
+	guard := NewUnclaimedDepositsGuard(networksRegistry, l2NetworkID, l1InfoTree, bridgeL1, bridgeL2)
+
+	mer1, mer2, mer3 := common.HexToHash("0xa1"), common.HexToHash("0xa2"), common.HexToHash("0xa3")
+	rer1, rer2 := common.HexToHash("0xb1"), common.HexToHash("0xb2")
+	ler1, ler2 := common.HexToHash("0xc1"), common.HexToHash("0xc2")
+	leaf := func(index uint32, mer, rer common.Hash) *l1infotreesync.L1InfoTreeLeaf {
+		return &l1infotreesync.L1InfoTreeLeaf{
+			L1InfoTreeIndex: index, BlockNumber: uint64(index), MainnetExitRoot: mer, RollupExitRoot: rer,
+		}
+	}
+	l1GlobalIndex := func(depositCount uint32) *big.Int {
+		return bridgesync.GenerateGlobalIndex(true, 0, depositCount)
+	}
+	l2GlobalIndex := func(depositCount uint32) *big.Int {
+		return bridgesync.GenerateGlobalIndex(false, l2NetworkID-1, depositCount)
+	}
+
+	// the leaf before the range includes the first deposit of the L1 and of the L2
+	l1InfoTree.EXPECT().GetInfoBeforeIndex(ctx, uint32(3)).Return(leaf(2, mer1, rer1), nil)
+	bridgeL1.EXPECT().GetRootByLER(ctx, mer1).Return(&tree.Root{Index: 0}, nil)
+	l1InfoTree.EXPECT().GetLocalExitRoot(ctx, l2NetworkID, rer1).Return(ler1, nil)
+	bridgeL2.EXPECT().GetRootByLER(ctx, ler1).Return(&tree.Root{Index: 0}, nil)
+
+	// the leaf 3 includes the L1 deposits 1 (claimed) and 2 (to another network)
+	l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(3)).Return(leaf(3, mer2, rer1), nil)
+	bridgeL1.EXPECT().GetRootByLER(ctx, mer2).Return(&tree.Root{Index: 2}, nil)
+	bridgeL1.EXPECT().GetBridgesByDepositCount(ctx, uint32(1), uint32(2)).Return([]*bridgesync.Bridge{
+		{DepositCount: 1, DestinationNetwork: l2NetworkID},
+		{DepositCount: 2, DestinationNetwork: l2NetworkID + 1},
+	}, nil)
+	bridgeL2.EXPECT().GetClaimsByGlobalIndex(ctx, l1GlobalIndex(1)).Return([]*bridgesync.Claim{{}}, nil)
+
+	// the leaf 4 is pruned
+	l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(4)).Return(nil, db.ErrNotFound)
+
+	// the leaf 5 includes the L2 deposits 1 (claimed) and 2 (unclaimed)
+	l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(5)).Return(leaf(5, mer2, rer2), nil)
+	l1InfoTree.EXPECT().GetLocalExitRoot(ctx, l2NetworkID, rer2).Return(ler2, nil)
+	bridgeL2.EXPECT().GetRootByLER(ctx, ler2).Return(&tree.Root{Index: 2}, nil)
+	bridgeL2.EXPECT().GetBridgesByDepositCount(ctx, uint32(1), uint32(2)).Return([]*bridgesync.Bridge{
+		{DepositCount: 1, DestinationNetwork: mainnetNetworkID},
+		{DepositCount: 2, DestinationNetwork: mainnetNetworkID},
+	}, nil)
+	bridgeL1.EXPECT().GetClaimsByGlobalIndex(ctx, l2GlobalIndex(1)).Return([]*bridgesync.Claim{{}}, nil)
+	bridgeL1.EXPECT().GetClaimsByGlobalIndex(ctx, l2GlobalIndex(2)).Return(nil, nil)
+
+	// the leaf 6 includes the unclaimed L1 deposit 3
+	l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(6)).Return(leaf(6, mer3, rer2), nil)
+	bridgeL1.EXPECT().GetRootByLER(ctx, mer3).Return(&tree.Root{Index: 3}, nil)
+	bridgeL1.EXPECT().GetBridgesByDepositCount(ctx, uint32(3), uint32(3)).Return([]*bridgesync.Bridge{
+		{DepositCount: 3, DestinationNetwork: l2NetworkID},
+	}, nil)
+	bridgeL2.EXPECT().GetClaimsByGlobalIndex(ctx, l1GlobalIndex(3)).Return(nil, nil)
+
+	required, err := guard.RequiredL1InfoTreeIndexes(ctx, 3, 6)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]struct{}{5: {}, 6: {}}, required)
+
+	t.Run("the L1 bridge syncer is behind the leaf", func(t *testing.T) {
+		l1InfoTree := mocks.NewL1InfoTreer(t)
+		bridgeL1 := mocks.NewBridger(t)
+		guard := NewUnclaimedDepositsGuard(networksRegistry, l2NetworkID, l1InfoTree, bridgeL1, mocks.NewBridger(t))
+		l1InfoTree.EXPECT().GetInfoBeforeIndex(ctx, uint32(0)).Return(nil, db.ErrNotFound)
+		l1InfoTree.EXPECT().GetInfoByIndex(ctx, uint32(0)).Return(leaf(20, mer1, rer1), nil)
+		bridgeL1.EXPECT().GetRootByLER(ctx, mer1).Return(nil, db.ErrNotFound)
+		bridgeL1.EXPECT().GetLastProcessedBlock(ctx).Return(10, nil)
+
+		_, err := guard.RequiredL1InfoTreeIndexes(ctx, 0, 0)
+		require.ErrorContains(t, err, "the L1 bridge syncer (block 10) has not synced the l1 info tree leaf 20")
+	})
+}
@@ -0,0 +1,91 @@
+package bridgeservice
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/gin-gonic/gin"
+)
+
+// GetClaimGasStatsHandler returns the gas used and the fees paid by the claims of a network.
+//
+// @Summary Get claim gas stats
+// @Description Returns the gas used and the fees (gas used * effective gas price, in wei) of the claim
+// @Description transactions of the network with block timestamp in the given range, to analyze the cost of
+// @Description the claims. A transaction with several claims is counted once. The claims synced before the
+// @Description gas was tracked are counted as untracked.
+// @Tags stats
+// @Param network_id query uint32 true "Target network ID"
+// @Param from_timestamp query uint64 false "Start of the time range, unix timestamp (default 0)"
+// @Param to_timestamp query uint64 false "End of the time range, unix timestamp (default now)"
+// @Produce json
+// @Success 200 {object} types.ClaimGasStats
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 500 {object} types.ErrorResponse "Internal Server Error"
+// @Router /claim-gas-stats [get]
+func (b *BridgeService) GetClaimGasStatsHandler(c *gin.Context) {
+	b.logger.Debugf("GetClaimGasStats request received (network id=%s, from=%s, to=%s)",
+		c.Query(networkIDParam), c.Query(fromTimestampParam), c.Query(toTimestampParam))
+
+	networkID, err := parseUintQuery(c, networkIDParam, true, uint32(0))
+	if err != nil {
+		b.logger.Warnf(errNetworkID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	fromTimestamp, toTimestamp, err := parseTimestampRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bridger := b.bridgerOf(networkID)
+	if bridger == nil {
+		b.logger.Warnf(errNetworkID, networkID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(errNetworkID, networkID)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	cnt, merr := b.meter.Int64Counter("get_claim_gas_stats")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_claim_gas_stats counter: %s", merr)
+	}
+	cnt.Add(ctx, 1)
+
+	stats, err := bridger.GetClaimGasStats(ctx, fromTimestamp, toTimestamp)
+	if err != nil {
+		b.logger.Errorf("failed to get the claim gas stats of network %d: %v", networkID, err)
+		c.JSON(http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("failed to get the claim gas stats of network %d, error: %s", networkID, err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, types.ClaimGasStats{
+		NetworkID:                networkID,
+		FromTimestamp:            fromTimestamp,
+		ToTimestamp:              toTimestamp,
+		Claims:                   stats.Claims,
+		UntrackedClaims:          stats.UntrackedClaims,
+		Transactions:             stats.Transactions,
+		TotalGasUsed:             stats.TotalGasUsed,
+		TotalFee:                 types.BigIntString(stats.TotalFee.String()),
+		AverageGasUsed:           stats.AverageGasUsed,
+		AverageEffectiveGasPrice: bigIntStringOrNil(stats.AverageEffectiveGasPrice),
+		AverageFeePerClaim:       bigIntStringOrNil(stats.AverageFeePerClaim),
+	})
+}
+
+// bigIntStringOrNil returns the value as a BigIntString, or nil if it's nil
+func bigIntStringOrNil(value *big.Int) *types.BigIntString {
+	if value == nil {
+		return nil
+	}
+	result := types.BigIntString(value.String())
+	return &result
+}
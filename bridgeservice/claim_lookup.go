@@ -0,0 +1,180 @@
+package bridgeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/db"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	claimStatusClaimed   = "claimed"
+	claimStatusClaimable = "claimable"
+	claimStatusPending   = "pending"
+	claimStatusUnknown   = "unknown"
+)
+
+// GetClaimByGlobalIndexHandler looks up the claim of a global index in the L1 and L2 claims.
+//
+// @Summary Get claim by global index
+// @Description Returns the claim of the given global index, looked up in the claims of the L1 and the L2.
+// @Description If it's not claimed yet, returns whether it can be claimed and the bridge of the deposit
+// @Description (decoded from the global index: mainnet flag, rollup index and deposit count).
+// @Tags claims
+// @Param global_index path string true "Global index (decimal or 0x hex)"
+// @Param include_all_fields query bool false "Whether to include full response fields (default false)"
+// @Produce json
+// @Success 200 {object} types.ClaimLookupResponse
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 500 {object} types.ErrorResponse "Internal Server Error"
+// @Router /claims/{global_index} [get]
+func (b *BridgeService) GetClaimByGlobalIndexHandler(c *gin.Context) {
+	b.logger.Debugf("GetClaimByGlobalIndex request received (global index=%s)", c.Param(globalIndexParam))
+
+	globalIndex, ok := new(big.Int).SetString(c.Param(globalIndexParam), 0)
+	if !ok || globalIndex.Sign() < 0 {
+		err := newInvalidParamError(globalIndexParam,
+			fmt.Errorf("%s is not a valid number", c.Param(globalIndexParam)))
+		b.logger.Warnf("invalid global index parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	mainnetFlag, rollupIndex, depositCount, err := bridgesync.DecodeGlobalIndex(globalIndex)
+	if err != nil {
+		b.logger.Warnf("invalid global index parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": newInvalidParamError(globalIndexParam, err).Error()})
+		return
+	}
+
+	includeAllFieldsFlag, err := parseBoolQuery(c, includeAllFields, false)
+	if err != nil {
+		b.logger.Warnf("invalid include_all_fields parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	cnt, merr := b.meter.Int64Counter("get_claim_by_global_index")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_claim_by_global_index counter: %s", merr)
+	}
+	cnt.Add(ctx, 1)
+
+	result := &types.ClaimLookupResponse{
+		GlobalIndex:   types.BigIntString(globalIndex.String()),
+		MainnetFlag:   mainnetFlag,
+		RollupIndex:   rollupIndex,
+		DepositCount:  depositCount,
+		OriginNetwork: b.networks.L1().ID,
+	}
+	if !mainnetFlag {
+		result.OriginNetwork = rollupIndex + 1
+	}
+
+	stores := []struct {
+		networkID uint32
+		bridger   Bridger
+	}{
+		{networkID: b.networks.L1().ID, bridger: b.bridgeL1},
+		{networkID: b.networkID, bridger: b.bridgeL2},
+	}
+	for _, store := range stores {
+		claims, err := store.bridger.GetClaimsByGlobalIndex(ctx, globalIndex)
+		if err != nil {
+			b.logger.Errorf("failed to get the claims of global index %s (network id=%d): %v",
+				globalIndex, store.networkID, err)
+			c.JSON(http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to get the claims of global index %s (network id=%d), error: %s",
+					globalIndex, store.networkID, err)})
+			return
+		}
+		if len(claims) > 0 {
+			claimedOnNetwork := store.networkID
+			result.Status = claimStatusClaimed
+			result.ClaimedOnNetwork = &claimedOnNetwork
+			result.Claim = NewClaimResponse(claims[0], includeAllFieldsFlag)
+			c.JSON(http.StatusOK, result)
+			return
+		}
+	}
+
+	if err := b.lookupUnclaimedDeposit(ctx, result); err != nil {
+		b.logger.Errorf("failed to get the deposit of global index %s: %v", globalIndex, err)
+		c.JSON(http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("failed to get the deposit of global index %s, error: %s", globalIndex, err)})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// lookupUnclaimedDeposit fills the status and the deposit of a global index that is not claimed
+func (b *BridgeService) lookupUnclaimedDeposit(ctx context.Context, result *types.ClaimLookupResponse) error {
+	var bridger Bridger
+	switch {
+	case result.MainnetFlag:
+		bridger = b.bridgeL1
+	case result.OriginNetwork == b.networkID:
+		bridger = b.bridgeL2
+	default:
+		result.Status = claimStatusUnknown
+		result.Reason = fmt.Sprintf("the deposits of network %d are not indexed by this bridge service",
+			result.OriginNetwork)
+		return nil
+	}
+
+	depositCount := uint64(result.DepositCount)
+	bridges, _, err := bridger.GetBridgesPaged(ctx, 1, 1, &depositCount, nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the bridge with deposit count %d of network %d: %w",
+			depositCount, result.OriginNetwork, err)
+	}
+	if len(bridges) == 0 {
+		result.Status = claimStatusUnknown
+		result.Reason = fmt.Sprintf("no bridge with deposit count %d on network %d (it may not be synced yet)",
+			depositCount, result.OriginNetwork)
+		return nil
+	}
+	bridge := bridges[0]
+	result.Deposit = NewBridgeResponse(bridge)
+
+	var l1InfoTreeIndex uint32
+	if result.MainnetFlag {
+		l1InfoTreeIndex, err = b.getFirstL1InfoTreeIndexForL1Bridge(ctx, result.DepositCount)
+	} else {
+		l1InfoTreeIndex, err = b.getFirstL1InfoTreeIndexForL2Bridge(ctx, result.DepositCount)
+	}
+	if err != nil {
+		if errors.Is(err, ErrNotOnL1Info) || errors.Is(err, db.ErrNotFound) {
+			result.Status = claimStatusPending
+			result.Reason = ErrNotOnL1Info.Error()
+			return nil
+		}
+		return fmt.Errorf("failed to get the l1 info tree index of the deposit: %w", err)
+	}
+
+	// the deposits claimed on this L2 also require the GER to be injected
+	if bridge.DestinationNetwork == b.networkID && !b.networks.IsL1(bridge.DestinationNetwork) {
+		if _, err := b.injectedGERs.GetFirstGERAfterL1InfoTreeIndex(ctx, l1InfoTreeIndex); err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				result.Status = claimStatusPending
+				result.Reason = fmt.Sprintf("no global exit root injected on network %d after the l1 info tree index %d",
+					b.networkID, l1InfoTreeIndex)
+				return nil
+			}
+			return fmt.Errorf("failed to get the injected global exit root after the l1 info tree index %d: %w",
+				l1InfoTreeIndex, err)
+		}
+	}
+
+	result.Status = claimStatusClaimable
+	result.L1InfoTreeIndex = &l1InfoTreeIndex
+	return nil
+}
@@ -0,0 +1,52 @@
+package bridgeservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/agglayer/aggkit/bridgesync"
+)
+
+// getOriginBridges returns the bridge of the origin network of each claim (decoded from its global index:
+// mainnet flag, rollup index and deposit count), in the same order. The bridge is nil if the origin network
+// is not indexed by this bridge service or if the bridge is not synced yet
+func (b *BridgeService) getOriginBridges(ctx context.Context,
+	claims []*bridgesync.Claim) ([]*bridgesync.Bridge, error) {
+	type originKey struct {
+		mainnet      bool
+		depositCount uint32
+	}
+	cache := make(map[originKey]*bridgesync.Bridge)
+	bridges := make([]*bridgesync.Bridge, len(claims))
+	for i, claim := range claims {
+		mainnetFlag, rollupIndex, depositCount, err := bridgesync.DecodeGlobalIndex(claim.GlobalIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the global index %s: %w", claim.GlobalIndex, err)
+		}
+		var bridger Bridger
+		switch {
+		case mainnetFlag:
+			bridger = b.bridgeL1
+		case rollupIndex+1 == b.networkID:
+			bridger = b.bridgeL2
+		default:
+			continue
+		}
+
+		key := originKey{mainnet: mainnetFlag, depositCount: depositCount}
+		if bridge, ok := cache[key]; ok {
+			bridges[i] = bridge
+			continue
+		}
+		filter := uint64(depositCount)
+		found, _, err := bridger.GetBridgesPaged(ctx, 1, 1, &filter, nil, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the origin bridge of the global index %s: %w", claim.GlobalIndex, err)
+		}
+		if len(found) > 0 {
+			bridges[i] = found[0]
+		}
+		cache[key] = bridges[i]
+	}
+	return bridges, nil
+}
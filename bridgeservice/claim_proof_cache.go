@@ -0,0 +1,246 @@
+package bridgeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// depositKey identifies a deposit by its origin network and its deposit count
+type depositKey struct {
+	networkID    uint32
+	depositCount uint32
+}
+
+// cachedClaimProof is the claim proof of a deposit against the first l1 info tree leaf that includes it
+type cachedClaimProof struct {
+	l1InfoTreeIndex uint32
+	// leafHash is the hash of the l1 info tree leaf of the proof, so the proof is not served after an L1 reorg
+	leafHash common.Hash
+	proof    *types.ClaimProof
+}
+
+// claimProofCache precomputes the claim proofs of the most recent unclaimed deposits of the L1 and the L2,
+// against the first l1 info tree index that includes them (the one returned by /l1-info-tree-index), so
+// /claim-proof doesn't walk the trees for the fresh deposits. The proofs are refreshed when a new l1 info
+// tree root arrives: the claimed deposits are dropped and the newly included ones are computed
+type claimProofCache struct {
+	logger   aggkitcommon.Logger
+	service  *BridgeService
+	deposits uint32
+	interval time.Duration
+
+	entriesGauge metric.Int64Gauge
+
+	mu     sync.RWMutex
+	proofs map[depositKey]cachedClaimProof
+
+	// lastLeafHash is the hash of the last l1 info tree leaf of the last refresh, only accessed by the job
+	lastLeafHash common.Hash
+}
+
+func newClaimProofCache(logger aggkitcommon.Logger, meter metric.Meter, service *BridgeService,
+	deposits uint32, interval time.Duration) *claimProofCache {
+	entriesGauge, err := meter.Int64Gauge("claim_proof_cache_entries")
+	if err != nil {
+		logger.Warnf("failed to create claim_proof_cache_entries gauge: %s", err)
+	}
+	return &claimProofCache{
+		logger:       logger,
+		service:      service,
+		deposits:     deposits,
+		interval:     interval,
+		entriesGauge: entriesGauge,
+		proofs:       map[depositKey]cachedClaimProof{},
+	}
+}
+
+// start checks for a new l1 info tree root every interval and refreshes the proofs until the context is done
+func (c *claimProofCache) start(ctx context.Context) {
+	c.logger.Infof("starting claim proof precomputation job (deposits per network: %d, interval: %s)",
+		c.deposits, c.interval)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.refresh(ctx); err != nil {
+			c.logger.Errorf("claim proof precomputation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// get returns the precomputed claim proof of the deposit, or nil if it's not cached for the l1 info tree
+// index or the leaf has changed. It's safe to call on a nil cache (the precomputation is disabled)
+func (c *claimProofCache) get(networkID, l1InfoTreeIndex, depositCount uint32,
+	info *l1infotreesync.L1InfoTreeLeaf) *types.ClaimProof {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.proofs[depositKey{networkID: networkID, depositCount: depositCount}]
+	if !ok || entry.l1InfoTreeIndex != l1InfoTreeIndex || entry.leafHash != info.Hash {
+		return nil
+	}
+	return entry.proof
+}
+
+// refresh recomputes the cached proofs if there is a new l1 info tree root. The proofs of the deposits
+// still cached are reused if their leaf hasn't changed
+func (c *claimProofCache) refresh(ctx context.Context) error {
+	lastInfo, err := c.service.l1InfoTree.GetLastInfo()
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the last l1 info tree leaf: %w", err)
+	}
+	if lastInfo.Hash == c.lastLeafHash {
+		return nil
+	}
+
+	c.mu.RLock()
+	previous := c.proofs
+	c.mu.RUnlock()
+
+	proofs := make(map[depositKey]cachedClaimProof, len(previous))
+	stores := []struct {
+		networkID uint32
+		bridger   Bridger
+	}{
+		{networkID: c.service.networks.L1().ID, bridger: c.service.bridgeL1},
+		{networkID: c.service.networkID, bridger: c.service.bridgeL2},
+	}
+	for _, store := range stores {
+		if err := c.refreshNetwork(ctx, store.networkID, store.bridger, previous, proofs); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	c.proofs = proofs
+	c.mu.Unlock()
+	c.lastLeafHash = lastInfo.Hash
+	if c.entriesGauge != nil {
+		c.entriesGauge.Record(ctx, int64(len(proofs)))
+	}
+	c.logger.Debugf("claim proofs precomputed for %d deposits up to the l1 info tree index %d",
+		len(proofs), lastInfo.L1InfoTreeIndex)
+	return nil
+}
+
+// refreshNetwork adds to proofs the claim proofs of the most recent unclaimed deposits of the network
+func (c *claimProofCache) refreshNetwork(ctx context.Context, networkID uint32, bridger Bridger,
+	previous, proofs map[depositKey]cachedClaimProof) error {
+	bridges, _, err := bridger.GetBridgesPaged(ctx, 1, c.deposits, nil, nil, "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the last bridges of network %d: %w", networkID, err)
+	}
+	for _, bridge := range bridges {
+		claimed, err := c.isClaimed(ctx, networkID, bridge)
+		if err != nil {
+			return err
+		}
+		if claimed {
+			continue
+		}
+		key := depositKey{networkID: networkID, depositCount: bridge.DepositCount}
+		entry, ok, err := c.reuse(ctx, previous[key])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			entry, ok, err = c.compute(ctx, key)
+			if err != nil {
+				// the proof is computed at request time, and again on the next l1 info tree root
+				c.logger.Warnf("failed to precompute the claim proof: %v", err)
+				continue
+			}
+		}
+		if ok {
+			proofs[key] = entry
+		}
+	}
+	return nil
+}
+
+// isClaimed returns whether the deposit has been claimed on the L1 or the L2
+func (c *claimProofCache) isClaimed(ctx context.Context, networkID uint32, bridge *bridgesync.Bridge) (bool, error) {
+	mainnetFlag := c.service.networks.IsL1(networkID)
+	var rollupIndex uint32
+	if !mainnetFlag {
+		rollupIndex = networkID - 1
+	}
+	globalIndex := bridgesync.GenerateGlobalIndex(mainnetFlag, rollupIndex, bridge.DepositCount)
+	for _, bridger := range []Bridger{c.service.bridgeL1, c.service.bridgeL2} {
+		claims, err := bridger.GetClaimsByGlobalIndex(ctx, globalIndex)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the claims of global index %s: %w", globalIndex, err)
+		}
+		if len(claims) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reuse returns the previous proof of the deposit if its l1 info tree leaf hasn't changed
+func (c *claimProofCache) reuse(ctx context.Context, entry cachedClaimProof) (cachedClaimProof, bool, error) {
+	if entry.proof == nil {
+		return cachedClaimProof{}, false, nil
+	}
+	info, err := c.service.l1InfoTree.GetInfoByIndex(ctx, entry.l1InfoTreeIndex)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return cachedClaimProof{}, false, nil
+		}
+		return cachedClaimProof{}, false, fmt.Errorf("failed to get the l1 info tree leaf %d: %w",
+			entry.l1InfoTreeIndex, err)
+	}
+	return entry, info.Hash == entry.leafHash, nil
+}
+
+// compute computes the claim proof of the deposit against the first l1 info tree leaf that includes it.
+// It returns false if the deposit is not included in the l1 info tree yet
+func (c *claimProofCache) compute(ctx context.Context, key depositKey) (cachedClaimProof, bool, error) {
+	var (
+		l1InfoTreeIndex uint32
+		err             error
+	)
+	if c.service.networks.IsL1(key.networkID) {
+		l1InfoTreeIndex, err = c.service.getFirstL1InfoTreeIndexForL1Bridge(ctx, key.depositCount)
+	} else {
+		l1InfoTreeIndex, err = c.service.getFirstL1InfoTreeIndexForL2Bridge(ctx, key.depositCount)
+	}
+	if err != nil {
+		if errors.Is(err, ErrNotOnL1Info) || errors.Is(err, db.ErrNotFound) {
+			return cachedClaimProof{}, false, nil
+		}
+		return cachedClaimProof{}, false, fmt.Errorf("failed to get the l1 info tree index of the deposit %d "+
+			"of network %d: %w", key.depositCount, key.networkID, err)
+	}
+	info, err := c.service.l1InfoTree.GetInfoByIndex(ctx, l1InfoTreeIndex)
+	if err != nil {
+		return cachedClaimProof{}, false, fmt.Errorf("failed to get the l1 info tree leaf %d: %w", l1InfoTreeIndex, err)
+	}
+	proof, err := c.service.computeClaimProof(ctx, key.networkID, l1InfoTreeIndex, key.depositCount, info)
+	if err != nil {
+		return cachedClaimProof{}, false, fmt.Errorf("failed to compute the claim proof of the deposit %d "+
+			"of network %d: %w", key.depositCount, key.networkID, err)
+	}
+	return cachedClaimProof{l1InfoTreeIndex: l1InfoTreeIndex, leafHash: info.Hash, proof: proof}, true, nil
+}
@@ -48,9 +48,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/claims-reconciliation": {
+            "get": {
+                "description": "Returns the findings of the job that compares the L2 claims with the L1 bridges (by global index):\nclaims of a global index claimed more than once and claims without a matching bridge on L1.\nOnly the claims of finalized L2 blocks are checked.\nIt requires the admin token of the bridge service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get claims reconciliation report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003cadmin token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Claims reconciliation report",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimsReconciliationReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid admin token",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin endpoints disabled",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/bridges": {
             "get": {
-                "description": "Returns a paginated list of bridge events for the specified network.",
+                "description": "Returns a paginated list of bridge events for the specified network.\nEach bridge is annotated with the finality of its block (pending, safe or finalized).",
                 "produces": [
                     "application/json"
                 ],
@@ -99,6 +140,30 @@ const docTemplate = `{
                         "description": "Filter by one or more network IDs",
                         "name": "network_ids",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by finality of the block (pending, safe or finalized)",
+                        "name": "finality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to decode the token metadata (default false)",
+                        "name": "decode_metadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the amounts: wei (default) or decimal (adjusted to its decimals)",
+                        "name": "amount_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the addresses: checksum (EIP-55, default) or lowercase",
+                        "name": "address_format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -123,9 +188,62 @@ const docTemplate = `{
                 }
             }
         },
+        "/claim-gas-stats": {
+            "get": {
+                "description": "Returns the gas used and the fees (gas used * effective gas price, in wei) of the claim\ntransactions of the network with block timestamp in the given range, to analyze the cost of\nthe claims. A transaction with several claims is counted once. The claims synced before the\ngas was tracked are counted as untracked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get claim gas stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target network ID",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the time range, unix timestamp (default 0)",
+                        "name": "from_timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the time range, unix timestamp (default now)",
+                        "name": "to_timestamp",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimGasStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/claim-proof": {
             "get": {
-                "description": "Returns the Merkle proofs (local and rollup exit root) and\nthe corresponding L1 info tree leaf needed to verify a claim.",
+                "description": "Returns the Merkle proofs (local and rollup exit root) and\nthe corresponding L1 info tree leaf needed to verify a claim.\nThe leaf is the one of leaf_index or, if global_exit_root is set, the one of the global exit root.",
                 "produces": [
                     "application/json"
                 ],
@@ -143,10 +261,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Index in the L1 info tree",
+                        "description": "Index in the L1 info tree, mandatory without global_exit_root",
                         "name": "leaf_index",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Global exit root to build the proofs against",
+                        "name": "global_exit_root",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -169,6 +292,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/types.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Global exit root not found",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -180,7 +309,7 @@ const docTemplate = `{
         },
         "/claims": {
             "get": {
-                "description": "Returns a paginated list of claims for the specified network.",
+                "description": "Returns a paginated list of claims for the specified network.\nEach claim is annotated with the finality of its block (pending, safe or finalized).\nWith include_origin, each claim includes the bridge of its origin network (decoded from the\nglobal index), if the origin network is indexed by this service.",
                 "produces": [
                     "application/json"
                 ],
@@ -229,6 +358,30 @@ const docTemplate = `{
                         "description": "Whether to include full response fields (default false)",
                         "name": "include_all_fields",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include the origin bridge of each claim (default false)",
+                        "name": "include_origin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by finality of the block (pending, safe or finalized)",
+                        "name": "finality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the amounts: wei (default) or decimal (adjusted to its decimals)",
+                        "name": "amount_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the addresses: checksum (EIP-55, default) or lowercase",
+                        "name": "address_format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -253,6 +406,148 @@ const docTemplate = `{
                 }
             }
         },
+        "/claims/{global_index}": {
+            "get": {
+                "description": "Returns the claim of the given global index, looked up in the claims of the L1 and the L2.\nIf it's not claimed yet, returns whether it can be claimed and the bridge of the deposit\n(decoded from the global index: mainnet flag, rollup index and deposit count).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "claims"
+                ],
+                "summary": "Get claim by global index",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Global index (decimal or 0x hex)",
+                        "name": "global_index",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include full response fields (default false)",
+                        "name": "include_all_fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimLookupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config": {
+            "get": {
+                "description": "Returns the bridge contract addresses, the GER manager and rollup manager addresses, the\nnetwork IDs and the block finality settings used by the node, so the clients can\nauto-configure against this endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "Get node config",
+                "responses": {
+                    "200": {
+                        "description": "Configuration used by the node",
+                        "schema": {
+                            "$ref": "#/definitions/types.ConfigResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "description": "Streams all the bridges or claims of the specified network between from_block and to_block\n(both included) as NDJSON (one JSON document per line) or CSV. The response uses chunked\ntransfer encoding; if an error happens after the streaming started, it's reported\nin the X-Export-Error trailer.",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export bridges or claims",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target network ID",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "bridges",
+                            "claims"
+                        ],
+                        "type": "string",
+                        "description": "Kind of records to export",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First block of the range (default 0)",
+                        "name": "from_block",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last block of the range (default last processed block)",
+                        "name": "to_block",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ndjson",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Output format (default ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Streamed records",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/injected-l1-info-leaf": {
             "get": {
                 "description": "Returns the L1 info tree leaf either at the given index (for L1)\nor the first injected global exit root after the given index (for L2).",
@@ -443,6 +738,52 @@ const docTemplate = `{
                 }
             }
         },
+        "/networks": {
+            "get": {
+                "description": "Returns the networks supported by the bridge service (the L1 and the L2s), their roles\nand contract addresses, and whether their bridges and claims are indexed by this service.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "Get networks",
+                "responses": {
+                    "200": {
+                        "description": "Supported networks",
+                        "schema": {
+                            "$ref": "#/definitions/types.NetworksResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "Returns whether the bridge service is ready to serve traffic: both the L1 and L2 bridge syncers\nhave synced up to the configured number of blocks behind the chain (ReadinessMaxL1BlocksBehind\nand ReadinessMaxL2BlocksBehind). Unlike the health check, it returns 503 while a syncer is\nbehind, so the load balancers don't route traffic to replicas serving stale data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get readiness",
+                "responses": {
+                    "200": {
+                        "description": "The service is ready",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "The service is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/types.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/sync-status": {
             "get": {
                 "description": "Returns the sync status by comparing the deposit count\nfrom the bridge contract with the deposit count in the bridge sync database for both L1 and L2 networks.",
@@ -498,6 +839,12 @@ const docTemplate = `{
                         "description": "Page size",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to decode the token metadata (default false)",
+                        "name": "decode_metadata",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -521,6 +868,158 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/tx/{tx_hash}": {
+            "get": {
+                "description": "Returns the bridge and claim events emitted by the given transaction, searched in the\nevents indexed on the L1 and on the L2, so a bridge can be followed from its tx hash only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bridges"
+                ],
+                "summary": "Get the bridges and claims of a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash (0x hex)",
+                        "name": "tx_hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether to include full response fields (default false)",
+                        "name": "include_all_fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the amounts: wei (default) or decimal (adjusted to its decimals)",
+                        "name": "amount_format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the addresses: checksum (EIP-55, default) or lowercase",
+                        "name": "address_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.TxEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/usd-value-stats": {
+            "get": {
+                "description": "Returns the total value in USD of the bridges and claims of assets of the network with block\ntimestamp in the given range, using the price of each token at the time of the event as returned\nby the price oracle. Only available if the price oracle of the network is enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get USD value stats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target network ID",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the time range, unix timestamp (default 0)",
+                        "name": "from_timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the time range, unix timestamp (default now)",
+                        "name": "to_timestamp",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.USDValueStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-claim-proof": {
+            "post": {
+                "description": "Verifies the Merkle proofs of a claim (local and rollup exit root) against the exit roots\nof the L1 info tree leaf and the target global exit root, and returns a verdict with the\nresult of every check. An invalid proof is not an error: the verdict is returned with valid=false.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "claims"
+                ],
+                "summary": "Verify claim proof",
+                "parameters": [
+                    {
+                        "description": "Claim proof to verify",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/types.VerifyClaimProofRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification verdict",
+                        "schema": {
+                            "$ref": "#/definitions/types.ClaimProofVerdict"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -543,10 +1042,15 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "amount": {
-                    "description": "Amount of tokens being bridged",
+                    "description": "Amount of tokens being bridged, in the smallest unit of the token unless requested with amount_format=decimal",
                     "type": "string",
                     "example": "1000000000000000000"
                 },
+                "amount_decimals": {
+                    "description": "Decimals applied to the amount (only if requested with amount_format=decimal and the decimals are known)",
+                    "type": "integer",
+                    "example": 18
+                },
                 "block_num": {
                     "description": "Block number where the bridge event was recorded",
                     "type": "integer",
@@ -562,6 +1066,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1684500000
                 },
+                "block_timestamp_iso": {
+                    "description": "Timestamp of the block in ISO-8601 format (UTC)",
+                    "type": "string",
+                    "example": "2023-05-19T12:40:00Z"
+                },
                 "bridge_hash": {
                     "description": "Unique hash representing the bridge event, often used as an identifier",
                     "type": "string",
@@ -572,6 +1081,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "deadbeef"
                 },
+                "decoded_metadata": {
+                    "description": "Token metadata decoded from the metadata field (only if requested with decode_metadata)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.TokenMetadata"
+                        }
+                    ]
+                },
                 "deposit_count": {
                     "description": "Count of total deposits processed so far for the given token/address",
                     "type": "integer",
@@ -587,6 +1104,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 42161
                 },
+                "finality": {
+                    "description": "Finality of the block that contains the bridge: pending, safe or finalized",
+                    "type": "string",
+                    "example": "finalized"
+                },
                 "from_address": {
                     "description": "Address that initiated the bridge transaction",
                     "type": "string",
@@ -621,6 +1143,11 @@ const docTemplate = `{
                     "description": "Hash of the transaction that included the bridge event",
                     "type": "string",
                     "example": "0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+                },
+                "value_usd": {
+                    "description": "Value in USD of the amount at the time of the bridge (only if the price oracle is enabled and has a price)",
+                    "type": "string",
+                    "example": "2500.750000"
                 }
             }
         },
@@ -639,6 +1166,175 @@ const docTemplate = `{
                     "description": "Total number of bridge events",
                     "type": "integer",
                     "example": 42
+                },
+                "has_next": {
+                    "description": "Whether there are more results after the returned page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "request_id": {
+                    "description": "ID of the request (from the X-Request-ID header, or generated), to correlate it with the logs",
+                    "type": "string",
+                    "example": "6f1c2a3b4d5e6f708192a3b4c5d6e7f8"
+                },
+                "total_pages": {
+                    "description": "Total number of pages for the requested page size",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.ClaimFinding": {
+            "description": "Claim reported by the reconciliation job and the reason",
+            "type": "object",
+            "properties": {
+                "block_num": {
+                    "description": "Block number where the claim was processed",
+                    "type": "integer",
+                    "example": 1234
+                },
+                "global_index": {
+                    "description": "Global index of the claim",
+                    "type": "string",
+                    "example": "18446744073709551617"
+                },
+                "reason": {
+                    "description": "Why the claim is reported",
+                    "type": "string",
+                    "example": "no bridge with deposit count 1 on L1"
+                },
+                "tx_hash": {
+                    "description": "Transaction hash of the claim",
+                    "type": "string",
+                    "example": "0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+                }
+            }
+        },
+        "types.ClaimGasStats": {
+            "description": "Gas used and fees (gas used * effective gas price) of the claim transactions",
+            "type": "object",
+            "properties": {
+                "average_effective_gas_price": {
+                    "description": "Average effective gas price in wei, weighted by the gas used (only if there are fees)",
+                    "type": "string",
+                    "example": "1000000000"
+                },
+                "average_fee_per_claim": {
+                    "description": "Average fee in wei per claim (only if there are fees)",
+                    "type": "string",
+                    "example": "114000000000000"
+                },
+                "average_gas_used": {
+                    "description": "Average gas used per claim transaction",
+                    "type": "integer",
+                    "example": 120000
+                },
+                "claims": {
+                    "description": "Number of claims",
+                    "type": "integer",
+                    "example": 42
+                },
+                "from_timestamp": {
+                    "description": "Start of the time range (unix timestamp, included)",
+                    "type": "integer",
+                    "example": 1684500000
+                },
+                "network_id": {
+                    "description": "ID of the network of the claims",
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_timestamp": {
+                    "description": "End of the time range (unix timestamp, included)",
+                    "type": "integer",
+                    "example": 1687100000
+                },
+                "total_fee": {
+                    "description": "Total fee in wei of the claim transactions with effective gas price",
+                    "type": "string",
+                    "example": "4560000000000000"
+                },
+                "total_gas_used": {
+                    "description": "Total gas used by the claim transactions",
+                    "type": "integer",
+                    "example": 4560000
+                },
+                "transactions": {
+                    "description": "Number of claim transactions (a transaction with several claims is counted once)",
+                    "type": "integer",
+                    "example": 38
+                },
+                "untracked_claims": {
+                    "description": "Number of claims without gas data (synced before the gas was tracked)",
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "types.ClaimLookupResponse": {
+            "description": "Claim of a global index or, if it's not claimed yet, whether it's claimable and its deposit",
+            "type": "object",
+            "properties": {
+                "claim": {
+                    "description": "The claim (only if claimed)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ClaimResponse"
+                        }
+                    ]
+                },
+                "claimed_on_network": {
+                    "description": "Network ID where the claim was found (only if claimed)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "deposit": {
+                    "description": "The bridge event of the deposit (only if not claimed and it's indexed by this bridge service)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BridgeResponse"
+                        }
+                    ]
+                },
+                "deposit_count": {
+                    "description": "Deposit count of the bridge in its network (decoded from the global index)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "global_index": {
+                    "description": "Global index looked up",
+                    "type": "string",
+                    "example": "18446744073709551617"
+                },
+                "l1_info_tree_index": {
+                    "description": "First L1 info tree index that includes the deposit, to request the claim proof (only if claimable)",
+                    "type": "integer",
+                    "example": 10
+                },
+                "mainnet_flag": {
+                    "description": "Whether the deposit was made on L1 (decoded from the global index)",
+                    "type": "boolean",
+                    "example": true
+                },
+                "origin_network": {
+                    "description": "Network ID where the deposit was made",
+                    "type": "integer",
+                    "example": 0
+                },
+                "reason": {
+                    "description": "Why the deposit is pending or unknown",
+                    "type": "string",
+                    "example": "the deposit has not been included on the L1 info tree yet"
+                },
+                "rollup_index": {
+                    "description": "Rollup index of the network of the deposit (decoded from the global index, 0 if mainnet_flag)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "description": "Status of the claim: claimed, claimable (not claimed yet, but it can be), pending (the deposit\nis not ready to be claimed yet) or unknown (the deposit is not indexed by this bridge service)",
+                    "type": "string",
+                    "example": "claimed"
                 }
             }
         },
@@ -680,15 +1376,79 @@ const docTemplate = `{
                 }
             }
         },
+        "types.ClaimProofCheck": {
+            "description": "Result of a single claim proof verification check",
+            "type": "object",
+            "properties": {
+                "computed": {
+                    "description": "Value computed from the proof",
+                    "type": "string",
+                    "example": "0xdefc...789"
+                },
+                "expected": {
+                    "description": "Expected value (from the payload)",
+                    "type": "string",
+                    "example": "0xdefc...789"
+                },
+                "name": {
+                    "description": "Name of the check",
+                    "type": "string",
+                    "example": "mainnet_exit_root"
+                },
+                "valid": {
+                    "description": "Whether the check passed",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "types.ClaimProofVerdict": {
+            "description": "Verdict of a claim proof verification, including the result of every check",
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "description": "Results of the individual checks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ClaimProofCheck"
+                    }
+                },
+                "deposit_count": {
+                    "description": "Deposit count (leaf index in the local exit tree) decoded from the global index",
+                    "type": "integer",
+                    "example": 10
+                },
+                "mainnet_flag": {
+                    "description": "Whether the claimed leaf belongs to the mainnet exit tree (L1 bridge)",
+                    "type": "boolean",
+                    "example": false
+                },
+                "rollup_index": {
+                    "description": "Rollup index decoded from the global index (0 for mainnet)",
+                    "type": "integer",
+                    "example": 0
+                },
+                "valid": {
+                    "description": "Whether all the checks passed",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "types.ClaimResponse": {
             "description": "Detailed information about a claim event",
             "type": "object",
             "properties": {
                 "amount": {
-                    "description": "Amount claimed",
+                    "description": "Amount claimed, in the smallest unit of the token unless requested with amount_format=decimal",
                     "type": "string",
                     "example": "1000000000000000000"
                 },
+                "amount_decimals": {
+                    "description": "Decimals applied to the amount (only if requested with amount_format=decimal and the decimals are known)",
+                    "type": "integer",
+                    "example": 18
+                },
                 "block_num": {
                     "description": "Block number where the claim was processed",
                     "type": "integer",
@@ -699,6 +1459,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1684500000
                 },
+                "block_timestamp_iso": {
+                    "description": "Timestamp of the block in ISO-8601 format (UTC)",
+                    "type": "string",
+                    "example": "2023-05-19T12:40:00Z"
+                },
                 "destination_address": {
                     "description": "Address receiving the claim on the destination network",
                     "type": "string",
@@ -709,11 +1474,26 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 42161
                 },
+                "effective_gas_price": {
+                    "description": "Effective gas price of the claim transaction in wei (only if it's known)",
+                    "type": "string",
+                    "example": "1000000000"
+                },
+                "finality": {
+                    "description": "Finality of the block that contains the claim: pending, safe or finalized",
+                    "type": "string",
+                    "example": "finalized"
+                },
                 "from_address": {
                     "description": "Address from which the claim originated",
                     "type": "string",
                     "example": "0xabc1234567890abcdef1234567890abcdef1234"
                 },
+                "gas_used": {
+                    "description": "Gas used by the claim transaction, shared by all its claims (only if it's known)",
+                    "type": "integer",
+                    "example": 120000
+                },
                 "global_exit_root": {
                     "description": "Global exit root associated with the claim",
                     "type": "string",
@@ -739,6 +1519,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "0xabc1234567890abcdef1234567890abcdef1234"
                 },
+                "origin_bridge": {
+                    "description": "Bridge of the origin network claimed (only if requested with include_origin=true and the bridge is indexed)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BridgeResponse"
+                        }
+                    ]
+                },
                 "origin_network": {
                     "description": "Origin network ID where the claim was initiated",
                     "type": "integer",
@@ -768,15 +1556,75 @@ const docTemplate = `{
                         " 0x6...]"
                     ]
                 },
-                "rollup_exit_root": {
-                    "description": "Rollup exit root associated with the claim",
-                    "type": "string",
-                    "example": "0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757"
+                "rollup_exit_root": {
+                    "description": "Rollup exit root associated with the claim",
+                    "type": "string",
+                    "example": "0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757"
+                },
+                "tx_hash": {
+                    "description": "Transaction hash associated with the claim",
+                    "type": "string",
+                    "example": "0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+                },
+                "value_usd": {
+                    "description": "Value in USD of the amount at the time of the claim (only if the price oracle is enabled and has a price)",
+                    "type": "string",
+                    "example": "2500.750000"
+                }
+            }
+        },
+        "types.ClaimsReconciliationReport": {
+            "description": "Duplicated and orphan claims found comparing the L2 claims with the L1 bridges",
+            "type": "object",
+            "properties": {
+                "checked_up_to_block": {
+                    "description": "Last L2 block whose claims have been checked against the L1 bridges",
+                    "type": "integer",
+                    "example": 1234
+                },
+                "duplicated_claims": {
+                    "description": "Claims of a global index that is claimed more than once, at most 1000",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ClaimFinding"
+                    }
+                },
+                "enabled": {
+                    "description": "Whether the reconciliation job is enabled",
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_error": {
+                    "description": "Error of the last run, if it failed",
+                    "type": "string",
+                    "example": "failed to get the L2 claims"
+                },
+                "last_run_at": {
+                    "description": "Unix timestamp of the last run of the job (0 if it hasn't run yet)",
+                    "type": "integer",
+                    "example": 1684500000
+                },
+                "omitted_duplicated_claims": {
+                    "description": "Number of duplicated claims found after DuplicatedClaims reached its limit, that are not listed",
+                    "type": "integer",
+                    "example": 0
+                },
+                "omitted_orphan_claims": {
+                    "description": "Number of orphan claims found after OrphanClaims reached its limit, that are not listed",
+                    "type": "integer",
+                    "example": 0
+                },
+                "orphan_claims": {
+                    "description": "Claims without a matching bridge on L1, one per global index and at most 1000",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ClaimFinding"
+                    }
                 },
-                "tx_hash": {
-                    "description": "Transaction hash associated with the claim",
-                    "type": "string",
-                    "example": "0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+                "pending_claims": {
+                    "description": "Number of claims whose L1 bridge has not been synced yet, so they are checked again in the next run",
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
@@ -795,6 +1643,53 @@ const docTemplate = `{
                     "description": "Total number of matching claims",
                     "type": "integer",
                     "example": 42
+                },
+                "has_next": {
+                    "description": "Whether there are more results after the returned page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "request_id": {
+                    "description": "ID of the request (from the X-Request-ID header, or generated), to correlate it with the logs",
+                    "type": "string",
+                    "example": "6f1c2a3b4d5e6f708192a3b4c5d6e7f8"
+                },
+                "total_pages": {
+                    "description": "Total number of pages for the requested page size",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.ConfigResponse": {
+            "description": "Contract addresses, network IDs and block finality settings used by the node",
+            "type": "object",
+            "properties": {
+                "l1": {
+                    "description": "Configuration of the L1",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NetworkConfigResponse"
+                        }
+                    ]
+                },
+                "l2": {
+                    "description": "Configuration of the L2 run by this node",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.NetworkConfigResponse"
+                        }
+                    ]
+                },
+                "rollup_manager_address": {
+                    "description": "Address of the rollup manager contract on L1",
+                    "type": "string",
+                    "example": "0x32d33D5137a7cFFb54c5Bf8371172bcEc5f310ff"
+                },
+                "version": {
+                    "description": "Version of the aggkit",
+                    "type": "string",
+                    "example": "v0.5.0"
                 }
             }
         },
@@ -869,6 +1764,11 @@ const docTemplate = `{
                     "description": "Timestamp of the block in seconds since the Unix epoch",
                     "type": "integer",
                     "example": 1684500000
+                },
+                "timestamp_iso": {
+                    "description": "Timestamp of the block in ISO-8601 format (UTC)",
+                    "type": "string",
+                    "example": "2023-05-19T12:40:00Z"
                 }
             }
         },
@@ -896,6 +1796,11 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1684500000
                 },
+                "block_timestamp_iso": {
+                    "description": "Timestamp of the block in ISO-8601 format (UTC)",
+                    "type": "string",
+                    "example": "2023-05-19T12:40:00Z"
+                },
                 "calldata": {
                     "description": "Raw calldata included in the migration transaction",
                     "type": "string",
@@ -932,12 +1837,127 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 12
                 },
+                "has_next": {
+                    "description": "Whether there are more results after the returned page",
+                    "type": "boolean",
+                    "example": true
+                },
                 "legacy_token_migrations": {
                     "description": "List of legacy token migration events",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/types.LegacyTokenMigrationResponse"
                     }
+                },
+                "request_id": {
+                    "description": "ID of the request (from the X-Request-ID header, or generated), to correlate it with the logs",
+                    "type": "string",
+                    "example": "6f1c2a3b4d5e6f708192a3b4c5d6e7f8"
+                },
+                "total_pages": {
+                    "description": "Total number of pages for the requested page size",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.NetworkConfigResponse": {
+            "description": "Contract addresses of a network and block finality of their syncers",
+            "type": "object",
+            "properties": {
+                "bridge_address": {
+                    "description": "Address of the bridge contract",
+                    "type": "string",
+                    "example": "0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe"
+                },
+                "bridge_block_finality": {
+                    "description": "Block finality used to sync the bridge contract",
+                    "type": "string",
+                    "example": "LatestBlock"
+                },
+                "ger_address": {
+                    "description": "Address of the global exit root contract (the GER manager on L1)",
+                    "type": "string",
+                    "example": "0xa40d5f56745a118d0906a34e69aec8c0db1cb8fa"
+                },
+                "ger_block_finality": {
+                    "description": "Block finality used to sync the global exit roots (the L1 info tree on L1)",
+                    "type": "string",
+                    "example": "FinalizedBlock"
+                },
+                "network_id": {
+                    "description": "Network ID (0 for L1)",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "types.NetworkReadiness": {
+            "description": "Contains the last block processed by the bridge syncer, the last block of the chain",
+            "type": "object",
+            "properties": {
+                "blocks_behind": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "Error is set if the status of the syncer can't be retrieved (then it's not ready)",
+                    "type": "string"
+                },
+                "last_processed_block": {
+                    "type": "integer"
+                },
+                "max_blocks_behind": {
+                    "type": "integer"
+                },
+                "network_id": {
+                    "type": "integer"
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "target_block": {
+                    "type": "integer"
+                }
+            }
+        },
+        "types.NetworkResponse": {
+            "description": "Network of the registry, with its role and contract addresses",
+            "type": "object",
+            "properties": {
+                "block_finality": {
+                    "description": "Block finality used to sync the network",
+                    "type": "string",
+                    "example": "LatestBlock"
+                },
+                "bridge_address": {
+                    "description": "Address of the bridge contract",
+                    "type": "string",
+                    "example": "0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe"
+                },
+                "ger_address": {
+                    "description": "Address of the global exit root contract",
+                    "type": "string",
+                    "example": "0xa40d5f56745a118d0906a34e69aec8c0db1cb8fa"
+                },
+                "indexed": {
+                    "description": "Whether the bridges and claims of the network are indexed (and can be queried) by this bridge service",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "description": "Human readable name of the network",
+                    "type": "string",
+                    "example": "L2"
+                },
+                "network_id": {
+                    "description": "Network ID (0 for L1)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "role": {
+                    "description": "Role of the network (L1 or L2)",
+                    "type": "string",
+                    "example": "L2"
                 }
             }
         },
@@ -956,6 +1976,34 @@ const docTemplate = `{
                 }
             }
         },
+        "types.NetworksResponse": {
+            "description": "List of the supported networks and their roles",
+            "type": "object",
+            "properties": {
+                "networks": {
+                    "description": "Supported networks, sorted by network ID",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.NetworkResponse"
+                    }
+                }
+            }
+        },
+        "types.ReadinessResponse": {
+            "description": "Contains the readiness of the bridge service: it's ready to serve traffic once the L1 and L2",
+            "type": "object",
+            "properties": {
+                "l1": {
+                    "$ref": "#/definitions/types.NetworkReadiness"
+                },
+                "l2": {
+                    "$ref": "#/definitions/types.NetworkReadiness"
+                },
+                "ready": {
+                    "type": "boolean"
+                }
+            }
+        },
         "types.SyncStatus": {
             "description": "Contains synchronization information for both L1 and L2 networks",
             "type": "object",
@@ -987,11 +2035,24 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1684501234
                 },
+                "block_timestamp_iso": {
+                    "description": "Timestamp of the block in ISO-8601 format (UTC)",
+                    "type": "string",
+                    "example": "2023-05-19T13:00:34Z"
+                },
                 "calldata": {
                     "description": "Raw calldata submitted during the mapping",
                     "type": "string",
                     "example": "0xfeedface"
                 },
+                "decoded_metadata": {
+                    "description": "Token metadata decoded from the metadata field (only if requested with decode_metadata)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.TokenMetadata"
+                        }
+                    ]
+                },
                 "is_not_mintable": {
                     "description": "Indicates whether the wrapped token is not mintable (true = not mintable)",
                     "type": "boolean",
@@ -1038,12 +2099,218 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 27
                 },
+                "has_next": {
+                    "description": "Whether there are more results after the returned page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "request_id": {
+                    "description": "ID of the request (from the X-Request-ID header, or generated), to correlate it with the logs",
+                    "type": "string",
+                    "example": "6f1c2a3b4d5e6f708192a3b4c5d6e7f8"
+                },
                 "token_mappings": {
                     "description": "List of token mapping entries",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/types.TokenMappingResponse"
                     }
+                },
+                "total_pages": {
+                    "description": "Total number of pages for the requested page size",
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "types.TokenMetadata": {
+            "description": "Name, symbol and decimals of the token, ABI-encoded by the bridge contract in the metadata",
+            "type": "object",
+            "properties": {
+                "decimals": {
+                    "description": "Number of decimals of the token",
+                    "type": "integer",
+                    "example": 18
+                },
+                "name": {
+                    "description": "Name of the token",
+                    "type": "string",
+                    "example": "Wrapped Ether"
+                },
+                "symbol": {
+                    "description": "Symbol of the token",
+                    "type": "string",
+                    "example": "WETH"
+                }
+            }
+        },
+        "types.TokenUSDValueStats": {
+            "description": "Value in USD of the bridges and claims of a token",
+            "type": "object",
+            "properties": {
+                "bridged_usd": {
+                    "description": "Total value in USD of the priced bridges of the token",
+                    "type": "string",
+                    "example": "125000.500000"
+                },
+                "bridges": {
+                    "description": "Number of bridges of the token",
+                    "type": "integer",
+                    "example": 42
+                },
+                "claimed_usd": {
+                    "description": "Total value in USD of the priced claims of the token",
+                    "type": "string",
+                    "example": "98000.250000"
+                },
+                "claims": {
+                    "description": "Number of claims of the token",
+                    "type": "integer",
+                    "example": 40
+                },
+                "origin_address": {
+                    "description": "Address of the token on the origin network",
+                    "type": "string",
+                    "example": "0xabc1234567890abcdef1234567890abcdef1234"
+                },
+                "origin_network": {
+                    "description": "ID of the origin network of the token",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "types.TxEventsResponse": {
+            "description": "Bridges and claims emitted by a transaction, on each network indexed by the service",
+            "type": "object",
+            "properties": {
+                "found": {
+                    "description": "Whether any bridge or claim of the transaction was found",
+                    "type": "boolean",
+                    "example": true
+                },
+                "networks": {
+                    "description": "Events of the transaction on the L1 and on the L2",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TxNetworkEvents"
+                    }
+                },
+                "tx_hash": {
+                    "description": "Hash of the transaction searched",
+                    "type": "string",
+                    "example": "0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+                }
+            }
+        },
+        "types.TxNetworkEvents": {
+            "type": "object",
+            "properties": {
+                "bridges": {
+                    "description": "Bridge events of the transaction, sorted by position",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.BridgeResponse"
+                    }
+                },
+                "claims": {
+                    "description": "Claim events of the transaction, sorted by position",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ClaimResponse"
+                    }
+                },
+                "network_id": {
+                    "description": "ID of the network where the events were emitted",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "types.USDValueStats": {
+            "description": "Value in USD of the bridges and claims of assets at the time of each event",
+            "type": "object",
+            "properties": {
+                "bridged_usd": {
+                    "description": "Total value in USD of the priced bridges",
+                    "type": "string",
+                    "example": "125000.500000"
+                },
+                "claimed_usd": {
+                    "description": "Total value in USD of the priced claims",
+                    "type": "string",
+                    "example": "98000.250000"
+                },
+                "from_timestamp": {
+                    "description": "Start of the time range (unix timestamp, included)",
+                    "type": "integer",
+                    "example": 1684500000
+                },
+                "network_id": {
+                    "description": "ID of the network of the events",
+                    "type": "integer",
+                    "example": 1
+                },
+                "priced_bridges": {
+                    "description": "Number of bridges with value in USD",
+                    "type": "integer",
+                    "example": 42
+                },
+                "priced_claims": {
+                    "description": "Number of claims with value in USD",
+                    "type": "integer",
+                    "example": 40
+                },
+                "to_timestamp": {
+                    "description": "End of the time range (unix timestamp, included)",
+                    "type": "integer",
+                    "example": 1687100000
+                },
+                "tokens": {
+                    "description": "Totals by token",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.TokenUSDValueStats"
+                    }
+                },
+                "unpriced_bridges": {
+                    "description": "Number of bridges of tokens without price in the oracle",
+                    "type": "integer",
+                    "example": 1
+                },
+                "unpriced_claims": {
+                    "description": "Number of claims of tokens without price in the oracle",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "types.VerifyClaimProofRequest": {
+            "description": "Claim proof to verify, the bridge leaf it proves and (optionally) the target global exit root",
+            "type": "object",
+            "properties": {
+                "claim_proof": {
+                    "description": "Claim proof (as returned by the claim-proof endpoint) to verify",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ClaimProof"
+                        }
+                    ]
+                },
+                "global_exit_root": {
+                    "description": "Target global exit root. If empty, the global exit root of the L1 info tree leaf is used",
+                    "type": "string",
+                    "example": "0x4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef123"
+                },
+                "global_index": {
+                    "description": "Global index of the claim, it encodes the mainnet flag, the rollup index and the deposit count",
+                    "type": "string",
+                    "example": "18446744073709551617"
+                },
+                "leaf_hash": {
+                    "description": "Hash of the bridge leaf (the leaf of the local exit tree) being claimed",
+                    "type": "string",
+                    "example": "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
                 }
             }
         }
@@ -0,0 +1,302 @@
+package bridgeservice
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	exportTypeBridges = "bridges"
+	exportTypeClaims  = "claims"
+
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+
+	// exportBlockChunkSize is the number of blocks read from the database on each iteration,
+	// the rows of each chunk are flushed to the client before reading the next one
+	exportBlockChunkSize = uint64(10000)
+
+	// exportErrorTrailer is the HTTP trailer used to report an error after the streaming has started
+	exportErrorTrailer = "X-Export-Error"
+)
+
+var (
+	bridgesCSVHeader = []string{
+		"block_num", "block_pos", "from_address", "tx_hash", "calldata", "block_timestamp", "leaf_type",
+		"origin_network", "origin_address", "destination_network", "destination_address", "amount",
+		"metadata", "deposit_count", "is_native_token", "bridge_hash",
+	}
+	claimsCSVHeader = []string{
+		"block_num", "block_timestamp", "tx_hash", "global_index", "origin_address", "origin_network",
+		"destination_address", "destination_network", "amount", "from_address", "mainnet_exit_root",
+		"rollup_exit_root", "global_exit_root", "metadata",
+	}
+)
+
+// exportSink writes the exported rows in the requested format
+type exportSink interface {
+	WriteBridge(bridge *bridgesync.Bridge) error
+	WriteClaim(claim *bridgesync.Claim) error
+	Flush() error
+}
+
+// ndjsonExportSink writes a JSON document per line
+type ndjsonExportSink struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonExportSink) WriteBridge(bridge *bridgesync.Bridge) error {
+	return w.encoder.Encode(NewBridgeResponse(bridge))
+}
+
+func (w *ndjsonExportSink) WriteClaim(claim *bridgesync.Claim) error {
+	return w.encoder.Encode(NewClaimResponse(claim, false))
+}
+
+func (w *ndjsonExportSink) Flush() error {
+	return nil
+}
+
+// csvExportSink writes a CSV row (with a header row) per record
+type csvExportSink struct {
+	writer        *csv.Writer
+	header        []string
+	headerWritten bool
+}
+
+func (w *csvExportSink) write(record []string) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(w.header); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvExportSink) WriteBridge(bridge *bridgesync.Bridge) error {
+	r := NewBridgeResponse(bridge)
+	return w.write([]string{
+		strconv.FormatUint(r.BlockNum, 10), strconv.FormatUint(r.BlockPos, 10), string(r.FromAddress),
+		string(r.TxHash), r.Calldata, strconv.FormatUint(r.BlockTimestamp, 10),
+		strconv.FormatUint(uint64(r.LeafType), 10), strconv.FormatUint(uint64(r.OriginNetwork), 10),
+		string(r.OriginAddress), strconv.FormatUint(uint64(r.DestinationNetwork), 10),
+		string(r.DestinationAddress), string(r.Amount), r.Metadata,
+		strconv.FormatUint(uint64(r.DepositCount), 10), strconv.FormatBool(r.IsNativeToken), string(r.BridgeHash),
+	})
+}
+
+func (w *csvExportSink) WriteClaim(claim *bridgesync.Claim) error {
+	r := NewClaimResponse(claim, false)
+	return w.write([]string{
+		strconv.FormatUint(r.BlockNum, 10), strconv.FormatUint(r.BlockTimestamp, 10), string(r.TxHash),
+		string(r.GlobalIndex), string(r.OriginAddress), strconv.FormatUint(uint64(r.OriginNetwork), 10),
+		string(r.DestinationAddress), strconv.FormatUint(uint64(r.DestinationNetwork), 10), string(r.Amount),
+		string(r.FromAddress), string(r.MainnetExitRoot), string(r.RollupExitRoot), string(r.GlobalExitRoot),
+		r.Metadata,
+	})
+}
+
+func (w *csvExportSink) Flush() error {
+	if !w.headerWritten {
+		// an empty export still has the header row
+		if err := w.writer.Write(w.header); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func newExportSink(format, exportType string, out io.Writer) exportSink {
+	if format == exportFormatCSV {
+		header := bridgesCSVHeader
+		if exportType == exportTypeClaims {
+			header = claimsCSVHeader
+		}
+		return &csvExportSink{writer: csv.NewWriter(out), header: header}
+	}
+	return &ndjsonExportSink{encoder: json.NewEncoder(out)}
+}
+
+// ExportHandler streams the full history of bridges or claims in a block range.
+//
+// @Summary Export bridges or claims
+// @Description Streams all the bridges or claims of the specified network between from_block and to_block
+// @Description (both included) as NDJSON (one JSON document per line) or CSV. The response uses chunked
+// @Description transfer encoding; if an error happens after the streaming started, it's reported
+// @Description in the X-Export-Error trailer.
+// @Tags export
+// @Param network_id query uint32 true "Target network ID"
+// @Param type query string true "Kind of records to export" Enums(bridges, claims)
+// @Param from_block query uint64 false "First block of the range (default 0)"
+// @Param to_block query uint64 false "Last block of the range (default last processed block)"
+// @Param format query string false "Output format (default ndjson)" Enums(ndjson, csv)
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Success 200 {string} string "Streamed records"
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 500 {object} types.ErrorResponse "Internal Server Error"
+// @Router /export [get]
+func (b *BridgeService) ExportHandler(c *gin.Context) {
+	b.logger.Debugf("Export request received (network id=%s, type=%s, from_block=%s, to_block=%s, format=%s)",
+		c.Query(networkIDParam), c.Query(exportTypeParam), c.Query(fromBlockParam), c.Query(toBlockParam),
+		c.Query(exportFormatParam))
+
+	networkID, err := parseUintQuery(c, networkIDParam, true, uint32(0))
+	if err != nil {
+		b.logger.Warnf(errNetworkID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	exportType, err := parseEnumQuery(c, exportTypeParam, true, "", exportTypeBridges, exportTypeClaims)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := parseEnumQuery(c, exportFormatParam, false, exportFormatNDJSON,
+		exportFormatNDJSON, exportFormatCSV)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var bridger Bridger
+	switch {
+	case b.networks.IsL1(networkID):
+		bridger = b.bridgeL1
+	case networkID == b.networkID:
+		bridger = b.bridgeL2
+	default:
+		b.logger.Warnf(errNetworkID, networkID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(errNetworkID, networkID)})
+		return
+	}
+
+	fromBlock, err := parseUintQuery(c, fromBlockParam, false, uint64(0))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	lastProcessedBlock, err := bridger.GetLastProcessedBlock(ctx)
+	cancel()
+	if err != nil {
+		b.logger.Errorf("failed to get last processed block (network id=%d): %v", networkID, err)
+		c.JSON(http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("failed to get the last processed block, error: %s", err)})
+		return
+	}
+
+	toBlock, err := parseUintQuery(c, toBlockParam, false, lastProcessedBlock)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateBlockRange(fromBlock, toBlock, lastProcessedBlock); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	counter, merr := b.meter.Int64Counter("export")
+	if merr != nil {
+		b.logger.Warnf("failed to create export counter: %s", merr)
+	}
+	counter.Add(c, 1)
+
+	contentType := "application/x-ndjson"
+	if format == exportFormatCSV {
+		contentType = "text/csv"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%s_%d_%d_%d.%s", exportType, networkID, fromBlock, toBlock, format))
+	c.Header("Trailer", exportErrorTrailer)
+	c.Status(http.StatusOK)
+
+	rows, err := b.streamExport(c, bridger, newExportSink(format, exportType, c.Writer), exportType,
+		fromBlock, toBlock)
+	if err != nil {
+		b.logger.Errorf("export of %s (network id=%d, blocks %d..%d) interrupted after %d rows: %v",
+			exportType, networkID, fromBlock, toBlock, rows, err)
+		c.Writer.Header().Set(exportErrorTrailer, err.Error())
+		return
+	}
+	b.logger.Debugf("successfully exported %d %s for network %d (blocks %d..%d)",
+		rows, exportType, networkID, fromBlock, toBlock)
+}
+
+// streamExport reads the records by chunks of blocks and writes them to the client.
+// Each chunk is flushed before reading the next one, so a slow client stops the reads
+// (the writes block) instead of buffering the full history in memory
+func (b *BridgeService) streamExport(c *gin.Context, bridger Bridger, sink exportSink,
+	exportType string, fromBlock, toBlock uint64) (int, error) {
+	rows := 0
+	for chunkFrom := fromBlock; chunkFrom <= toBlock; chunkFrom += exportBlockChunkSize {
+		if err := c.Request.Context().Err(); err != nil {
+			return rows, fmt.Errorf("client disconnected: %w", err)
+		}
+		chunkTo := toBlock
+		if toBlock-chunkFrom >= exportBlockChunkSize {
+			chunkTo = chunkFrom + exportBlockChunkSize - 1
+		}
+
+		n, err := b.exportChunk(c, bridger, sink, exportType, chunkFrom, chunkTo)
+		rows += n
+		if err != nil {
+			return rows, err
+		}
+		if err := sink.Flush(); err != nil {
+			return rows, fmt.Errorf("failed to write the export: %w", err)
+		}
+		c.Writer.Flush()
+
+		if chunkTo == toBlock {
+			// avoid the overflow of chunkFrom if toBlock is close to MaxUint64
+			break
+		}
+	}
+	return rows, nil
+}
+
+func (b *BridgeService) exportChunk(c *gin.Context, bridger Bridger, sink exportSink,
+	exportType string, fromBlock, toBlock uint64) (int, error) {
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	if exportType == exportTypeClaims {
+		claims, err := bridger.GetClaims(ctx, fromBlock, toBlock)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get claims for blocks %d..%d: %w", fromBlock, toBlock, err)
+		}
+		for i := range claims {
+			if err := sink.WriteClaim(&claims[i]); err != nil {
+				return i, fmt.Errorf("failed to write the export: %w", err)
+			}
+		}
+		return len(claims), nil
+	}
+
+	bridges, err := bridger.GetBridges(ctx, fromBlock, toBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bridges for blocks %d..%d: %w", fromBlock, toBlock, err)
+	}
+	for i := range bridges {
+		if err := sink.WriteBridge(&bridges[i]); err != nil {
+			return i, fmt.Errorf("failed to write the export: %w", err)
+		}
+	}
+	return len(bridges), nil
+}
@@ -0,0 +1,120 @@
+package bridgeservice
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	amountFormatParam  = "amount_format"
+	addressFormatParam = "address_format"
+
+	// amountFormatWei returns the amounts in the smallest unit of the token (wei for ether)
+	amountFormatWei = "wei"
+	// amountFormatDecimal returns the amounts adjusted to the decimals of the token (e.g. "1.5")
+	amountFormatDecimal = "decimal"
+	// addressFormatChecksum returns the addresses with the EIP-55 checksum
+	addressFormatChecksum = "checksum"
+	// addressFormatLowercase returns the addresses in lowercase hex
+	addressFormatLowercase = "lowercase"
+
+	etherDecimals = 18
+	// etherOriginNetwork is the origin network of ether (the L1), whose origin address is the zero address
+	etherOriginNetwork = 0
+)
+
+// responseFormat is how the amounts and the addresses of the bridges and claims are returned
+type responseFormat struct {
+	decimalAmounts     bool
+	lowercaseAddresses bool
+}
+
+// parseResponseFormat parses the amount_format and address_format query parameters
+func parseResponseFormat(c *gin.Context) (responseFormat, error) {
+	amountFormat, err := parseEnumQuery(c, amountFormatParam, false, amountFormatWei,
+		amountFormatWei, amountFormatDecimal)
+	if err != nil {
+		return responseFormat{}, err
+	}
+	addressFormat, err := parseEnumQuery(c, addressFormatParam, false, addressFormatChecksum,
+		addressFormatChecksum, addressFormatLowercase)
+	if err != nil {
+		return responseFormat{}, err
+	}
+	return responseFormat{
+		decimalAmounts:     amountFormat == amountFormatDecimal,
+		lowercaseAddresses: addressFormat == addressFormatLowercase,
+	}, nil
+}
+
+// applyToBridge formats the amount and the addresses of the bridge response
+func (f responseFormat) applyToBridge(response *types.BridgeResponse, bridge *bridgesync.Bridge) {
+	if f.decimalAmounts {
+		response.Amount, response.AmountDecimals = formatAmount(
+			bridge.Amount, bridge.OriginNetwork, bridge.OriginAddress, bridge.Metadata)
+	}
+	if f.lowercaseAddresses {
+		lowercaseAddresses(&response.FromAddress, &response.OriginAddress, &response.DestinationAddress)
+	}
+}
+
+// applyToClaim formats the amount and the addresses of the claim response
+func (f responseFormat) applyToClaim(response *types.ClaimResponse, claim *bridgesync.Claim) {
+	if f.decimalAmounts {
+		response.Amount, response.AmountDecimals = formatAmount(
+			claim.Amount, claim.OriginNetwork, claim.OriginAddress, claim.Metadata)
+	}
+	if f.lowercaseAddresses {
+		lowercaseAddresses(&response.FromAddress, &response.OriginAddress, &response.DestinationAddress)
+	}
+}
+
+// formatAmount returns the amount adjusted to the decimals of the token and the decimals used. The
+// decimals are the ones of the token metadata of the event, or 18 for ether. If they are unknown, the
+// amount is returned in its smallest unit and the decimals are nil
+func formatAmount(amount *big.Int, originNetwork uint32, originAddress common.Address,
+	metadata []byte) (types.BigIntString, *uint8) {
+	if amount == nil {
+		return "", nil
+	}
+	decimals := uint8(etherDecimals)
+	if len(metadata) > 0 || originNetwork != etherOriginNetwork || originAddress != (common.Address{}) {
+		tokenMetadata, err := DecodeTokenMetadata(metadata)
+		if err != nil {
+			return types.BigIntString(amount.String()), nil
+		}
+		decimals = tokenMetadata.Decimals
+	}
+	return types.BigIntString(FormatTokenAmount(amount, decimals)), &decimals
+}
+
+// FormatTokenAmount returns the amount (in the smallest unit of the token) as a decimal string adjusted
+// to the decimals of the token, without trailing zeros: e.g. 1500000000000000000 with 18 decimals is "1.5"
+func FormatTokenAmount(amount *big.Int, decimals uint8) string {
+	if decimals == 0 {
+		return amount.String()
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil) //nolint:mnd
+	integer, fraction := new(big.Int).QuoRem(new(big.Int).Abs(amount), unit, new(big.Int))
+	result := integer.String()
+	if fraction.Sign() != 0 {
+		fractionStr := fraction.String()
+		fractionStr = strings.Repeat("0", int(decimals)-len(fractionStr)) + fractionStr
+		result += "." + strings.TrimRight(fractionStr, "0")
+	}
+	if amount.Sign() < 0 {
+		result = "-" + result
+	}
+	return result
+}
+
+func lowercaseAddresses(addresses ...*types.Address) {
+	for _, address := range addresses {
+		*address = types.Address(strings.ToLower(string(*address)))
+	}
+}
@@ -0,0 +1,95 @@
+package bridgeservice
+
+import (
+	"math/big"
+	"testing"
+
+	bridgetypes "github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTokenAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		amount   string
+		decimals uint8
+		expected string
+	}{
+		{amount: "0", decimals: 18, expected: "0"},
+		{amount: "1000000000000000000", decimals: 18, expected: "1"},
+		{amount: "1500000000000000000", decimals: 18, expected: "1.5"},
+		{amount: "1", decimals: 18, expected: "0.000000000000000001"},
+		{amount: "123456789", decimals: 6, expected: "123.456789"},
+		{amount: "123456789", decimals: 0, expected: "123456789"},
+		{amount: "-2500000", decimals: 6, expected: "-2.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			t.Parallel()
+			amount, ok := new(big.Int).SetString(tt.amount, 10)
+			require.True(t, ok)
+			require.Equal(t, tt.expected, FormatTokenAmount(amount, tt.decimals))
+		})
+	}
+}
+
+func TestResponseFormatApply(t *testing.T) {
+	t.Parallel()
+
+	usdcMetadata, err := tokenMetadataArgs.Pack("USD Coin", "USDC", uint8(6))
+	require.NoError(t, err)
+	from := common.HexToAddress("0xAbC1234567890aBcDeF1234567890aBcDeF12345")
+	format := responseFormat{decimalAmounts: true, lowercaseAddresses: true}
+
+	t.Run("ether", func(t *testing.T) {
+		t.Parallel()
+		bridge := &bridgesync.Bridge{FromAddress: from, Amount: big.NewInt(1500000000000000000)}
+		response := NewBridgeResponse(bridge)
+		format.applyToBridge(response, bridge)
+		require.Equal(t, bridgetypes.BigIntString("1.5"), response.Amount)
+		require.Equal(t, uint8(18), *response.AmountDecimals)
+		require.Equal(t, bridgetypes.Address("0xabc1234567890abcdef1234567890abcdef12345"), response.FromAddress)
+		require.Equal(t, bridgetypes.Address("0x0000000000000000000000000000000000000000"), response.OriginAddress)
+	})
+
+	t.Run("token with metadata", func(t *testing.T) {
+		t.Parallel()
+		claim := &bridgesync.Claim{
+			OriginNetwork: 0,
+			OriginAddress: common.HexToAddress("0x1"),
+			Amount:        big.NewInt(2500000),
+			Metadata:      usdcMetadata,
+			GlobalIndex:   big.NewInt(1),
+		}
+		response := NewClaimResponse(claim, false)
+		format.applyToClaim(response, claim)
+		require.Equal(t, bridgetypes.BigIntString("2.5"), response.Amount)
+		require.Equal(t, uint8(6), *response.AmountDecimals)
+	})
+
+	t.Run("unknown decimals", func(t *testing.T) {
+		t.Parallel()
+		bridge := &bridgesync.Bridge{
+			OriginNetwork: 1,
+			Amount:        big.NewInt(2500000),
+			Metadata:      []byte("message"),
+		}
+		response := NewBridgeResponse(bridge)
+		format.applyToBridge(response, bridge)
+		require.Equal(t, bridgetypes.BigIntString("2500000"), response.Amount)
+		require.Nil(t, response.AmountDecimals)
+	})
+
+	t.Run("default format", func(t *testing.T) {
+		t.Parallel()
+		bridge := &bridgesync.Bridge{FromAddress: from, Amount: big.NewInt(1500000000000000000)}
+		response := NewBridgeResponse(bridge)
+		responseFormat{}.applyToBridge(response, bridge)
+		require.Equal(t, bridgetypes.BigIntString("1500000000000000000"), response.Amount)
+		require.Nil(t, response.AmountDecimals)
+		require.Equal(t, bridgetypes.Address(from.Hex()), response.FromAddress)
+	})
+}
@@ -0,0 +1,72 @@
+package bridgeservice
+
+import (
+	"errors"
+	"fmt"
+
+	bridgetypes "github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	errEmptyMetadata = errors.New("empty metadata")
+
+	// tokenMetadataArgs are the arguments that the bridge contract ABI-encodes in the metadata of the
+	// bridged tokens: abi.encode(name, symbol, decimals)
+	tokenMetadataArgs = mustTokenMetadataArgs()
+)
+
+func mustTokenMetadataArgs() abi.Arguments {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	uint8Type, err := abi.NewType("uint8", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{
+		{Name: "name", Type: stringType},
+		{Name: "symbol", Type: stringType},
+		{Name: "decimals", Type: uint8Type},
+	}
+}
+
+// DecodeTokenMetadata decodes the standard token metadata (name, symbol and decimals) of a bridge or
+// a token mapping. It fails if the metadata is empty (e.g. bridges of the gas token or messages) or it's
+// not ABI-encoded as the bridge contract does
+func DecodeTokenMetadata(metadata []byte) (*bridgetypes.TokenMetadata, error) {
+	if len(metadata) == 0 {
+		return nil, errEmptyMetadata
+	}
+	values, err := tokenMetadataArgs.Unpack(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the token metadata: %w", err)
+	}
+
+	name, okName := values[0].(string)
+	symbol, okSymbol := values[1].(string)
+	decimals, okDecimals := values[2].(uint8)
+	if !okName || !okSymbol || !okDecimals {
+		return nil, fmt.Errorf("unexpected types of the token metadata values: %T, %T, %T",
+			values[0], values[1], values[2])
+	}
+	return &bridgetypes.TokenMetadata{
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}, nil
+}
+
+// decodeMetadataOrNil decodes the token metadata, returning nil if it's not standard token metadata
+func (b *BridgeService) decodeMetadataOrNil(metadata []byte) *bridgetypes.TokenMetadata {
+	if len(metadata) == 0 {
+		return nil
+	}
+	decoded, err := DecodeTokenMetadata(metadata)
+	if err != nil {
+		b.logger.Debugf("metadata 0x%x is not standard token metadata: %v", metadata, err)
+		return nil
+	}
+	return decoded
+}
@@ -0,0 +1,66 @@
+package bridgeservice
+
+import (
+	"testing"
+
+	bridgetypes "github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	wethMetadata, err := tokenMetadataArgs.Pack("Wrapped Ether", "WETH", uint8(18))
+	require.NoError(t, err)
+	emptyNameMetadata, err := tokenMetadataArgs.Pack("", "", uint8(0))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		metadata    []byte
+		expected    *bridgetypes.TokenMetadata
+		expectedErr string
+	}{
+		{
+			name:     "standard token metadata",
+			metadata: wethMetadata,
+			expected: &bridgetypes.TokenMetadata{Name: "Wrapped Ether", Symbol: "WETH", Decimals: 18},
+		},
+		{
+			name:     "empty name and symbol",
+			metadata: emptyNameMetadata,
+			expected: &bridgetypes.TokenMetadata{},
+		},
+		{
+			name:        "empty metadata",
+			metadata:    nil,
+			expectedErr: errEmptyMetadata.Error(),
+		},
+		{
+			name:        "message payload",
+			metadata:    []byte("hello world"),
+			expectedErr: "failed to decode the token metadata",
+		},
+		{
+			name:        "truncated metadata",
+			metadata:    wethMetadata[:len(wethMetadata)-common.HashLength],
+			expectedErr: "failed to decode the token metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoded, err := DecodeTokenMetadata(tt.metadata)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				require.Nil(t, decoded)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, decoded)
+		})
+	}
+}
@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	big "math/big"
+
 	bridgesync "github.com/agglayer/aggkit/bridgesync"
+
 	common "github.com/ethereum/go-ethereum/common"
 
 	context "context"
@@ -26,9 +29,256 @@ func (_m *Bridger) EXPECT() *Bridger_Expecter {
 	return &Bridger_Expecter{mock: &_m.Mock}
 }
 
-// GetBridgesPaged provides a mock function with given fields: ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress
-func (_m *Bridger) GetBridgesPaged(ctx context.Context, pageNumber uint32, pageSize uint32, depositCount *uint64, networkIDs []uint32, fromAddress string) ([]*bridgesync.Bridge, int, error) {
-	ret := _m.Called(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)
+// GetBridges provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *Bridger) GetBridges(ctx context.Context, fromBlock uint64, toBlock uint64) ([]bridgesync.Bridge, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBridges")
+	}
+
+	var r0 []bridgesync.Bridge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]bridgesync.Bridge, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []bridgesync.Bridge); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridgesync.Bridge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetBridges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridges'
+type Bridger_GetBridges_Call struct {
+	*mock.Call
+}
+
+// GetBridges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Bridger_Expecter) GetBridges(ctx interface{}, fromBlock interface{}, toBlock interface{}) *Bridger_GetBridges_Call {
+	return &Bridger_GetBridges_Call{Call: _e.mock.On("GetBridges", ctx, fromBlock, toBlock)}
+}
+
+func (_c *Bridger_GetBridges_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *Bridger_GetBridges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetBridges_Call) Return(_a0 []bridgesync.Bridge, _a1 error) *Bridger_GetBridges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetBridges_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]bridgesync.Bridge, error)) *Bridger_GetBridges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBridgesAndClaimsByTxHash provides a mock function with given fields: ctx, txHash
+func (_m *Bridger) GetBridgesAndClaimsByTxHash(ctx context.Context, txHash common.Hash) ([]*bridgesync.Bridge, []*bridgesync.Claim, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBridgesAndClaimsByTxHash")
+	}
+
+	var r0 []*bridgesync.Bridge
+	var r1 []*bridgesync.Claim
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]*bridgesync.Bridge, []*bridgesync.Claim, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []*bridgesync.Bridge); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.Bridge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) []*bridgesync.Claim); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*bridgesync.Claim)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, common.Hash) error); ok {
+		r2 = rf(ctx, txHash)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Bridger_GetBridgesAndClaimsByTxHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridgesAndClaimsByTxHash'
+type Bridger_GetBridgesAndClaimsByTxHash_Call struct {
+	*mock.Call
+}
+
+// GetBridgesAndClaimsByTxHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txHash common.Hash
+func (_e *Bridger_Expecter) GetBridgesAndClaimsByTxHash(ctx interface{}, txHash interface{}) *Bridger_GetBridgesAndClaimsByTxHash_Call {
+	return &Bridger_GetBridgesAndClaimsByTxHash_Call{Call: _e.mock.On("GetBridgesAndClaimsByTxHash", ctx, txHash)}
+}
+
+func (_c *Bridger_GetBridgesAndClaimsByTxHash_Call) Run(run func(ctx context.Context, txHash common.Hash)) *Bridger_GetBridgesAndClaimsByTxHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetBridgesAndClaimsByTxHash_Call) Return(_a0 []*bridgesync.Bridge, _a1 []*bridgesync.Claim, _a2 error) *Bridger_GetBridgesAndClaimsByTxHash_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Bridger_GetBridgesAndClaimsByTxHash_Call) RunAndReturn(run func(context.Context, common.Hash) ([]*bridgesync.Bridge, []*bridgesync.Claim, error)) *Bridger_GetBridgesAndClaimsByTxHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBridgesByDepositCount provides a mock function with given fields: ctx, fromDepositCount, toDepositCount
+func (_m *Bridger) GetBridgesByDepositCount(ctx context.Context, fromDepositCount uint32, toDepositCount uint32) ([]*bridgesync.Bridge, error) {
+	ret := _m.Called(ctx, fromDepositCount, toDepositCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBridgesByDepositCount")
+	}
+
+	var r0 []*bridgesync.Bridge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32) ([]*bridgesync.Bridge, error)); ok {
+		return rf(ctx, fromDepositCount, toDepositCount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32) []*bridgesync.Bridge); ok {
+		r0 = rf(ctx, fromDepositCount, toDepositCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.Bridge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, uint32) error); ok {
+		r1 = rf(ctx, fromDepositCount, toDepositCount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetBridgesByDepositCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridgesByDepositCount'
+type Bridger_GetBridgesByDepositCount_Call struct {
+	*mock.Call
+}
+
+// GetBridgesByDepositCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromDepositCount uint32
+//   - toDepositCount uint32
+func (_e *Bridger_Expecter) GetBridgesByDepositCount(ctx interface{}, fromDepositCount interface{}, toDepositCount interface{}) *Bridger_GetBridgesByDepositCount_Call {
+	return &Bridger_GetBridgesByDepositCount_Call{Call: _e.mock.On("GetBridgesByDepositCount", ctx, fromDepositCount, toDepositCount)}
+}
+
+func (_c *Bridger_GetBridgesByDepositCount_Call) Run(run func(ctx context.Context, fromDepositCount uint32, toDepositCount uint32)) *Bridger_GetBridgesByDepositCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32), args[2].(uint32))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetBridgesByDepositCount_Call) Return(_a0 []*bridgesync.Bridge, _a1 error) *Bridger_GetBridgesByDepositCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetBridgesByDepositCount_Call) RunAndReturn(run func(context.Context, uint32, uint32) ([]*bridgesync.Bridge, error)) *Bridger_GetBridgesByDepositCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBridgesByDepositCounts provides a mock function with given fields: ctx, depositCounts
+func (_m *Bridger) GetBridgesByDepositCounts(ctx context.Context, depositCounts []uint32) ([]*bridgesync.Bridge, error) {
+	ret := _m.Called(ctx, depositCounts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBridgesByDepositCounts")
+	}
+
+	var r0 []*bridgesync.Bridge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32) ([]*bridgesync.Bridge, error)); ok {
+		return rf(ctx, depositCounts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint32) []*bridgesync.Bridge); ok {
+		r0 = rf(ctx, depositCounts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.Bridge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint32) error); ok {
+		r1 = rf(ctx, depositCounts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetBridgesByDepositCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridgesByDepositCounts'
+type Bridger_GetBridgesByDepositCounts_Call struct {
+	*mock.Call
+}
+
+// GetBridgesByDepositCounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - depositCounts []uint32
+func (_e *Bridger_Expecter) GetBridgesByDepositCounts(ctx interface{}, depositCounts interface{}) *Bridger_GetBridgesByDepositCounts_Call {
+	return &Bridger_GetBridgesByDepositCounts_Call{Call: _e.mock.On("GetBridgesByDepositCounts", ctx, depositCounts)}
+}
+
+func (_c *Bridger_GetBridgesByDepositCounts_Call) Run(run func(ctx context.Context, depositCounts []uint32)) *Bridger_GetBridgesByDepositCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uint32))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetBridgesByDepositCounts_Call) Return(_a0 []*bridgesync.Bridge, _a1 error) *Bridger_GetBridgesByDepositCounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetBridgesByDepositCounts_Call) RunAndReturn(run func(context.Context, []uint32) ([]*bridgesync.Bridge, error)) *Bridger_GetBridgesByDepositCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBridgesPaged provides a mock function with given fields: ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter
+func (_m *Bridger) GetBridgesPaged(ctx context.Context, pageNumber uint32, pageSize uint32, depositCount *uint64, networkIDs []uint32, fromAddress string, blockNumFilter *bridgesync.BlockNumFilter) ([]*bridgesync.Bridge, int, error) {
+	ret := _m.Called(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBridgesPaged")
@@ -37,68 +287,248 @@ func (_m *Bridger) GetBridgesPaged(ctx context.Context, pageNumber uint32, pageS
 	var r0 []*bridgesync.Bridge
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, *uint64, []uint32, string) ([]*bridgesync.Bridge, int, error)); ok {
-		return rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, *uint64, []uint32, string, *bridgesync.BlockNumFilter) ([]*bridgesync.Bridge, int, error)); ok {
+		return rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, *uint64, []uint32, string) []*bridgesync.Bridge); ok {
-		r0 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, *uint64, []uint32, string, *bridgesync.BlockNumFilter) []*bridgesync.Bridge); ok {
+		r0 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*bridgesync.Bridge)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32, uint32, *uint64, []uint32, string) int); ok {
-		r1 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, uint32, *uint64, []uint32, string, *bridgesync.BlockNumFilter) int); ok {
+		r1 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uint32, uint32, *uint64, []uint32, string) error); ok {
-		r2 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)
+	if rf, ok := ret.Get(2).(func(context.Context, uint32, uint32, *uint64, []uint32, string, *bridgesync.BlockNumFilter) error); ok {
+		r2 = rf(ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Bridger_GetBridgesPaged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridgesPaged'
+type Bridger_GetBridgesPaged_Call struct {
+	*mock.Call
+}
+
+// GetBridgesPaged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pageNumber uint32
+//   - pageSize uint32
+//   - depositCount *uint64
+//   - networkIDs []uint32
+//   - fromAddress string
+//   - blockNumFilter *bridgesync.BlockNumFilter
+func (_e *Bridger_Expecter) GetBridgesPaged(ctx interface{}, pageNumber interface{}, pageSize interface{}, depositCount interface{}, networkIDs interface{}, fromAddress interface{}, blockNumFilter interface{}) *Bridger_GetBridgesPaged_Call {
+	return &Bridger_GetBridgesPaged_Call{Call: _e.mock.On("GetBridgesPaged", ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)}
+}
+
+func (_c *Bridger_GetBridgesPaged_Call) Run(run func(ctx context.Context, pageNumber uint32, pageSize uint32, depositCount *uint64, networkIDs []uint32, fromAddress string, blockNumFilter *bridgesync.BlockNumFilter)) *Bridger_GetBridgesPaged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32), args[2].(uint32), args[3].(*uint64), args[4].([]uint32), args[5].(string), args[6].(*bridgesync.BlockNumFilter))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetBridgesPaged_Call) Return(_a0 []*bridgesync.Bridge, _a1 int, _a2 error) *Bridger_GetBridgesPaged_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Bridger_GetBridgesPaged_Call) RunAndReturn(run func(context.Context, uint32, uint32, *uint64, []uint32, string, *bridgesync.BlockNumFilter) ([]*bridgesync.Bridge, int, error)) *Bridger_GetBridgesPaged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClaimGasStats provides a mock function with given fields: ctx, fromTimestamp, toTimestamp
+func (_m *Bridger) GetClaimGasStats(ctx context.Context, fromTimestamp uint64, toTimestamp uint64) (*bridgesync.ClaimGasStats, error) {
+	ret := _m.Called(ctx, fromTimestamp, toTimestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClaimGasStats")
+	}
+
+	var r0 *bridgesync.ClaimGasStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*bridgesync.ClaimGasStats, error)); ok {
+		return rf(ctx, fromTimestamp, toTimestamp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *bridgesync.ClaimGasStats); ok {
+		r0 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bridgesync.ClaimGasStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetClaimGasStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClaimGasStats'
+type Bridger_GetClaimGasStats_Call struct {
+	*mock.Call
+}
+
+// GetClaimGasStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromTimestamp uint64
+//   - toTimestamp uint64
+func (_e *Bridger_Expecter) GetClaimGasStats(ctx interface{}, fromTimestamp interface{}, toTimestamp interface{}) *Bridger_GetClaimGasStats_Call {
+	return &Bridger_GetClaimGasStats_Call{Call: _e.mock.On("GetClaimGasStats", ctx, fromTimestamp, toTimestamp)}
+}
+
+func (_c *Bridger_GetClaimGasStats_Call) Run(run func(ctx context.Context, fromTimestamp uint64, toTimestamp uint64)) *Bridger_GetClaimGasStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetClaimGasStats_Call) Return(_a0 *bridgesync.ClaimGasStats, _a1 error) *Bridger_GetClaimGasStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetClaimGasStats_Call) RunAndReturn(run func(context.Context, uint64, uint64) (*bridgesync.ClaimGasStats, error)) *Bridger_GetClaimGasStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClaims provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *Bridger) GetClaims(ctx context.Context, fromBlock uint64, toBlock uint64) ([]bridgesync.Claim, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClaims")
+	}
+
+	var r0 []bridgesync.Claim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]bridgesync.Claim, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []bridgesync.Claim); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bridgesync.Claim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClaims'
+type Bridger_GetClaims_Call struct {
+	*mock.Call
+}
+
+// GetClaims is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Bridger_Expecter) GetClaims(ctx interface{}, fromBlock interface{}, toBlock interface{}) *Bridger_GetClaims_Call {
+	return &Bridger_GetClaims_Call{Call: _e.mock.On("GetClaims", ctx, fromBlock, toBlock)}
+}
+
+func (_c *Bridger_GetClaims_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *Bridger_GetClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetClaims_Call) Return(_a0 []bridgesync.Claim, _a1 error) *Bridger_GetClaims_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetClaims_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]bridgesync.Claim, error)) *Bridger_GetClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClaimsByGlobalIndex provides a mock function with given fields: ctx, globalIndex
+func (_m *Bridger) GetClaimsByGlobalIndex(ctx context.Context, globalIndex *big.Int) ([]*bridgesync.Claim, error) {
+	ret := _m.Called(ctx, globalIndex)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClaimsByGlobalIndex")
+	}
+
+	var r0 []*bridgesync.Claim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) ([]*bridgesync.Claim, error)); ok {
+		return rf(ctx, globalIndex)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) []*bridgesync.Claim); ok {
+		r0 = rf(ctx, globalIndex)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.Claim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, globalIndex)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// Bridger_GetBridgesPaged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBridgesPaged'
-type Bridger_GetBridgesPaged_Call struct {
+// Bridger_GetClaimsByGlobalIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClaimsByGlobalIndex'
+type Bridger_GetClaimsByGlobalIndex_Call struct {
 	*mock.Call
 }
 
-// GetBridgesPaged is a helper method to define mock.On call
+// GetClaimsByGlobalIndex is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pageNumber uint32
-//   - pageSize uint32
-//   - depositCount *uint64
-//   - networkIDs []uint32
-//   - fromAddress string
-func (_e *Bridger_Expecter) GetBridgesPaged(ctx interface{}, pageNumber interface{}, pageSize interface{}, depositCount interface{}, networkIDs interface{}, fromAddress interface{}) *Bridger_GetBridgesPaged_Call {
-	return &Bridger_GetBridgesPaged_Call{Call: _e.mock.On("GetBridgesPaged", ctx, pageNumber, pageSize, depositCount, networkIDs, fromAddress)}
+//   - globalIndex *big.Int
+func (_e *Bridger_Expecter) GetClaimsByGlobalIndex(ctx interface{}, globalIndex interface{}) *Bridger_GetClaimsByGlobalIndex_Call {
+	return &Bridger_GetClaimsByGlobalIndex_Call{Call: _e.mock.On("GetClaimsByGlobalIndex", ctx, globalIndex)}
 }
 
-func (_c *Bridger_GetBridgesPaged_Call) Run(run func(ctx context.Context, pageNumber uint32, pageSize uint32, depositCount *uint64, networkIDs []uint32, fromAddress string)) *Bridger_GetBridgesPaged_Call {
+func (_c *Bridger_GetClaimsByGlobalIndex_Call) Run(run func(ctx context.Context, globalIndex *big.Int)) *Bridger_GetClaimsByGlobalIndex_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint32), args[2].(uint32), args[3].(*uint64), args[4].([]uint32), args[5].(string))
+		run(args[0].(context.Context), args[1].(*big.Int))
 	})
 	return _c
 }
 
-func (_c *Bridger_GetBridgesPaged_Call) Return(_a0 []*bridgesync.Bridge, _a1 int, _a2 error) *Bridger_GetBridgesPaged_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *Bridger_GetClaimsByGlobalIndex_Call) Return(_a0 []*bridgesync.Claim, _a1 error) *Bridger_GetClaimsByGlobalIndex_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Bridger_GetBridgesPaged_Call) RunAndReturn(run func(context.Context, uint32, uint32, *uint64, []uint32, string) ([]*bridgesync.Bridge, int, error)) *Bridger_GetBridgesPaged_Call {
+func (_c *Bridger_GetClaimsByGlobalIndex_Call) RunAndReturn(run func(context.Context, *big.Int) ([]*bridgesync.Claim, error)) *Bridger_GetClaimsByGlobalIndex_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetClaimsPaged provides a mock function with given fields: ctx, page, pageSize, networkIDs, fromAddress
-func (_m *Bridger) GetClaimsPaged(ctx context.Context, page uint32, pageSize uint32, networkIDs []uint32, fromAddress string) ([]*bridgesync.Claim, int, error) {
-	ret := _m.Called(ctx, page, pageSize, networkIDs, fromAddress)
+// GetClaimsPaged provides a mock function with given fields: ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter
+func (_m *Bridger) GetClaimsPaged(ctx context.Context, page uint32, pageSize uint32, networkIDs []uint32, fromAddress string, blockNumFilter *bridgesync.BlockNumFilter) ([]*bridgesync.Claim, int, error) {
+	ret := _m.Called(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetClaimsPaged")
@@ -107,25 +537,25 @@ func (_m *Bridger) GetClaimsPaged(ctx context.Context, page uint32, pageSize uin
 	var r0 []*bridgesync.Claim
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, []uint32, string) ([]*bridgesync.Claim, int, error)); ok {
-		return rf(ctx, page, pageSize, networkIDs, fromAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, []uint32, string, *bridgesync.BlockNumFilter) ([]*bridgesync.Claim, int, error)); ok {
+		return rf(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, []uint32, string) []*bridgesync.Claim); ok {
-		r0 = rf(ctx, page, pageSize, networkIDs, fromAddress)
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, uint32, []uint32, string, *bridgesync.BlockNumFilter) []*bridgesync.Claim); ok {
+		r0 = rf(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*bridgesync.Claim)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint32, uint32, []uint32, string) int); ok {
-		r1 = rf(ctx, page, pageSize, networkIDs, fromAddress)
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, uint32, []uint32, string, *bridgesync.BlockNumFilter) int); ok {
+		r1 = rf(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uint32, uint32, []uint32, string) error); ok {
-		r2 = rf(ctx, page, pageSize, networkIDs, fromAddress)
+	if rf, ok := ret.Get(2).(func(context.Context, uint32, uint32, []uint32, string, *bridgesync.BlockNumFilter) error); ok {
+		r2 = rf(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -144,13 +574,14 @@ type Bridger_GetClaimsPaged_Call struct {
 //   - pageSize uint32
 //   - networkIDs []uint32
 //   - fromAddress string
-func (_e *Bridger_Expecter) GetClaimsPaged(ctx interface{}, page interface{}, pageSize interface{}, networkIDs interface{}, fromAddress interface{}) *Bridger_GetClaimsPaged_Call {
-	return &Bridger_GetClaimsPaged_Call{Call: _e.mock.On("GetClaimsPaged", ctx, page, pageSize, networkIDs, fromAddress)}
+//   - blockNumFilter *bridgesync.BlockNumFilter
+func (_e *Bridger_Expecter) GetClaimsPaged(ctx interface{}, page interface{}, pageSize interface{}, networkIDs interface{}, fromAddress interface{}, blockNumFilter interface{}) *Bridger_GetClaimsPaged_Call {
+	return &Bridger_GetClaimsPaged_Call{Call: _e.mock.On("GetClaimsPaged", ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)}
 }
 
-func (_c *Bridger_GetClaimsPaged_Call) Run(run func(ctx context.Context, page uint32, pageSize uint32, networkIDs []uint32, fromAddress string)) *Bridger_GetClaimsPaged_Call {
+func (_c *Bridger_GetClaimsPaged_Call) Run(run func(ctx context.Context, page uint32, pageSize uint32, networkIDs []uint32, fromAddress string, blockNumFilter *bridgesync.BlockNumFilter)) *Bridger_GetClaimsPaged_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uint32), args[2].(uint32), args[3].([]uint32), args[4].(string))
+		run(args[0].(context.Context), args[1].(uint32), args[2].(uint32), args[3].([]uint32), args[4].(string), args[5].(*bridgesync.BlockNumFilter))
 	})
 	return _c
 }
@@ -160,7 +591,7 @@ func (_c *Bridger_GetClaimsPaged_Call) Return(_a0 []*bridgesync.Claim, _a1 int,
 	return _c
 }
 
-func (_c *Bridger_GetClaimsPaged_Call) RunAndReturn(run func(context.Context, uint32, uint32, []uint32, string) ([]*bridgesync.Claim, int, error)) *Bridger_GetClaimsPaged_Call {
+func (_c *Bridger_GetClaimsPaged_Call) RunAndReturn(run func(context.Context, uint32, uint32, []uint32, string, *bridgesync.BlockNumFilter) ([]*bridgesync.Claim, int, error)) *Bridger_GetClaimsPaged_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -221,6 +652,186 @@ func (_c *Bridger_GetContractDepositCount_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetDuplicatedClaims provides a mock function with given fields: ctx, fromBlock, toBlock, limit
+func (_m *Bridger) GetDuplicatedClaims(ctx context.Context, fromBlock uint64, toBlock uint64, limit uint32) ([]*bridgesync.Claim, int, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicatedClaims")
+	}
+
+	var r0 []*bridgesync.Claim
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint32) ([]*bridgesync.Claim, int, error)); ok {
+		return rf(ctx, fromBlock, toBlock, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64, uint32) []*bridgesync.Claim); ok {
+		r0 = rf(ctx, fromBlock, toBlock, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.Claim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64, uint32) int); ok {
+		r1 = rf(ctx, fromBlock, toBlock, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint64, uint64, uint32) error); ok {
+		r2 = rf(ctx, fromBlock, toBlock, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Bridger_GetDuplicatedClaims_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDuplicatedClaims'
+type Bridger_GetDuplicatedClaims_Call struct {
+	*mock.Call
+}
+
+// GetDuplicatedClaims is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+//   - limit uint32
+func (_e *Bridger_Expecter) GetDuplicatedClaims(ctx interface{}, fromBlock interface{}, toBlock interface{}, limit interface{}) *Bridger_GetDuplicatedClaims_Call {
+	return &Bridger_GetDuplicatedClaims_Call{Call: _e.mock.On("GetDuplicatedClaims", ctx, fromBlock, toBlock, limit)}
+}
+
+func (_c *Bridger_GetDuplicatedClaims_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64, limit uint32)) *Bridger_GetDuplicatedClaims_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64), args[3].(uint32))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetDuplicatedClaims_Call) Return(_a0 []*bridgesync.Claim, _a1 int, _a2 error) *Bridger_GetDuplicatedClaims_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Bridger_GetDuplicatedClaims_Call) RunAndReturn(run func(context.Context, uint64, uint64, uint32) ([]*bridgesync.Claim, int, error)) *Bridger_GetDuplicatedClaims_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFinalityBlocks provides a mock function with given fields: ctx
+func (_m *Bridger) GetFinalityBlocks(ctx context.Context) (bridgesync.FinalityBlocks, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinalityBlocks")
+	}
+
+	var r0 bridgesync.FinalityBlocks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bridgesync.FinalityBlocks, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bridgesync.FinalityBlocks); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bridgesync.FinalityBlocks)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetFinalityBlocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFinalityBlocks'
+type Bridger_GetFinalityBlocks_Call struct {
+	*mock.Call
+}
+
+// GetFinalityBlocks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Bridger_Expecter) GetFinalityBlocks(ctx interface{}) *Bridger_GetFinalityBlocks_Call {
+	return &Bridger_GetFinalityBlocks_Call{Call: _e.mock.On("GetFinalityBlocks", ctx)}
+}
+
+func (_c *Bridger_GetFinalityBlocks_Call) Run(run func(ctx context.Context)) *Bridger_GetFinalityBlocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetFinalityBlocks_Call) Return(_a0 bridgesync.FinalityBlocks, _a1 error) *Bridger_GetFinalityBlocks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetFinalityBlocks_Call) RunAndReturn(run func(context.Context) (bridgesync.FinalityBlocks, error)) *Bridger_GetFinalityBlocks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLastProcessedBlock provides a mock function with given fields: ctx
+func (_m *Bridger) GetLastProcessedBlock(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastProcessedBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetLastProcessedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastProcessedBlock'
+type Bridger_GetLastProcessedBlock_Call struct {
+	*mock.Call
+}
+
+// GetLastProcessedBlock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Bridger_Expecter) GetLastProcessedBlock(ctx interface{}) *Bridger_GetLastProcessedBlock_Call {
+	return &Bridger_GetLastProcessedBlock_Call{Call: _e.mock.On("GetLastProcessedBlock", ctx)}
+}
+
+func (_c *Bridger_GetLastProcessedBlock_Call) Run(run func(ctx context.Context)) *Bridger_GetLastProcessedBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetLastProcessedBlock_Call) Return(_a0 uint64, _a1 error) *Bridger_GetLastProcessedBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetLastProcessedBlock_Call) RunAndReturn(run func(context.Context) (uint64, error)) *Bridger_GetLastProcessedBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastReorgEvent provides a mock function with given fields: ctx
 func (_m *Bridger) GetLastReorgEvent(ctx context.Context) (*bridgesync.LastReorg, error) {
 	ret := _m.Called(ctx)
@@ -465,6 +1076,62 @@ func (_c *Bridger_GetRootByLER_Call) RunAndReturn(run func(context.Context, comm
 	return _c
 }
 
+// GetSyncTargetBlock provides a mock function with given fields: ctx
+func (_m *Bridger) GetSyncTargetBlock(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncTargetBlock")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetSyncTargetBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSyncTargetBlock'
+type Bridger_GetSyncTargetBlock_Call struct {
+	*mock.Call
+}
+
+// GetSyncTargetBlock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Bridger_Expecter) GetSyncTargetBlock(ctx interface{}) *Bridger_GetSyncTargetBlock_Call {
+	return &Bridger_GetSyncTargetBlock_Call{Call: _e.mock.On("GetSyncTargetBlock", ctx)}
+}
+
+func (_c *Bridger_GetSyncTargetBlock_Call) Run(run func(ctx context.Context)) *Bridger_GetSyncTargetBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetSyncTargetBlock_Call) Return(_a0 uint64, _a1 error) *Bridger_GetSyncTargetBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetSyncTargetBlock_Call) RunAndReturn(run func(context.Context) (uint64, error)) *Bridger_GetSyncTargetBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTokenMappings provides a mock function with given fields: ctx, pageNumber, pageSize
 func (_m *Bridger) GetTokenMappings(ctx context.Context, pageNumber uint32, pageSize uint32) ([]*bridgesync.TokenMapping, int, error) {
 	ret := _m.Called(ctx, pageNumber, pageSize)
@@ -532,6 +1199,127 @@ func (_c *Bridger_GetTokenMappings_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetUSDValueStats provides a mock function with given fields: ctx, fromTimestamp, toTimestamp
+func (_m *Bridger) GetUSDValueStats(ctx context.Context, fromTimestamp uint64, toTimestamp uint64) (*bridgesync.USDValueStats, error) {
+	ret := _m.Called(ctx, fromTimestamp, toTimestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUSDValueStats")
+	}
+
+	var r0 *bridgesync.USDValueStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*bridgesync.USDValueStats, error)); ok {
+		return rf(ctx, fromTimestamp, toTimestamp)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) *bridgesync.USDValueStats); ok {
+		r0 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bridgesync.USDValueStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromTimestamp, toTimestamp)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetUSDValueStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUSDValueStats'
+type Bridger_GetUSDValueStats_Call struct {
+	*mock.Call
+}
+
+// GetUSDValueStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromTimestamp uint64
+//   - toTimestamp uint64
+func (_e *Bridger_Expecter) GetUSDValueStats(ctx interface{}, fromTimestamp interface{}, toTimestamp interface{}) *Bridger_GetUSDValueStats_Call {
+	return &Bridger_GetUSDValueStats_Call{Call: _e.mock.On("GetUSDValueStats", ctx, fromTimestamp, toTimestamp)}
+}
+
+func (_c *Bridger_GetUSDValueStats_Call) Run(run func(ctx context.Context, fromTimestamp uint64, toTimestamp uint64)) *Bridger_GetUSDValueStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetUSDValueStats_Call) Return(_a0 *bridgesync.USDValueStats, _a1 error) *Bridger_GetUSDValueStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetUSDValueStats_Call) RunAndReturn(run func(context.Context, uint64, uint64) (*bridgesync.USDValueStats, error)) *Bridger_GetUSDValueStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUSDValues provides a mock function with given fields: ctx, eventType, fromBlock, toBlock
+func (_m *Bridger) GetUSDValues(ctx context.Context, eventType string, fromBlock uint64, toBlock uint64) ([]*bridgesync.USDValue, error) {
+	ret := _m.Called(ctx, eventType, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUSDValues")
+	}
+
+	var r0 []*bridgesync.USDValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]*bridgesync.USDValue, error)); ok {
+		return rf(ctx, eventType, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []*bridgesync.USDValue); ok {
+		r0 = rf(ctx, eventType, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*bridgesync.USDValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) error); ok {
+		r1 = rf(ctx, eventType, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Bridger_GetUSDValues_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUSDValues'
+type Bridger_GetUSDValues_Call struct {
+	*mock.Call
+}
+
+// GetUSDValues is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eventType string
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *Bridger_Expecter) GetUSDValues(ctx interface{}, eventType interface{}, fromBlock interface{}, toBlock interface{}) *Bridger_GetUSDValues_Call {
+	return &Bridger_GetUSDValues_Call{Call: _e.mock.On("GetUSDValues", ctx, eventType, fromBlock, toBlock)}
+}
+
+func (_c *Bridger_GetUSDValues_Call) Run(run func(ctx context.Context, eventType string, fromBlock uint64, toBlock uint64)) *Bridger_GetUSDValues_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(uint64))
+	})
+	return _c
+}
+
+func (_c *Bridger_GetUSDValues_Call) Return(_a0 []*bridgesync.USDValue, _a1 error) *Bridger_GetUSDValues_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Bridger_GetUSDValues_Call) RunAndReturn(run func(context.Context, string, uint64, uint64) ([]*bridgesync.USDValue, error)) *Bridger_GetUSDValues_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewBridger creates a new instance of Bridger. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewBridger(t interface {
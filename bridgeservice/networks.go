@@ -0,0 +1,48 @@
+package bridgeservice
+
+import (
+	"net/http"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/networks"
+	"github.com/gin-gonic/gin"
+)
+
+// GetNetworksHandler returns the networks of the registry and their roles.
+//
+// @Summary Get networks
+// @Description Returns the networks supported by the bridge service (the L1 and the L2s), their roles
+// @Description and contract addresses, and whether their bridges and claims are indexed by this service.
+// @Tags networks
+// @Produce json
+// @Success 200 {object} types.NetworksResponse "Supported networks"
+// @Router /networks [get]
+func (b *BridgeService) GetNetworksHandler(c *gin.Context) {
+	b.logger.Debugf("GetNetworks request received")
+
+	cnt, merr := b.meter.Int64Counter("get_networks")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_networks counter: %s", merr)
+	}
+	cnt.Add(c, 1)
+
+	registered := b.networks.List()
+	response := types.NetworksResponse{Networks: make([]types.NetworkResponse, 0, len(registered))}
+	for _, n := range registered {
+		response.Networks = append(response.Networks, b.newNetworkResponse(n))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (b *BridgeService) newNetworkResponse(n networks.NetworkConfig) types.NetworkResponse {
+	return types.NetworkResponse{
+		NetworkID:     n.ID,
+		Name:          n.Name,
+		Role:          string(n.Role),
+		BridgeAddress: types.Address(n.BridgeAddr.Hex()),
+		GERAddress:    types.Address(n.GERAddr.Hex()),
+		BlockFinality: n.BlockFinality.String(),
+		Indexed:       n.Role == networks.RoleL1 || n.ID == b.networkID,
+	}
+}
@@ -0,0 +1,69 @@
+package bridgeservice
+
+import (
+	"net/http"
+
+	"github.com/agglayer/aggkit"
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/networks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+// SyncedNetworkConfig are the contracts of a network synced by the node and the finality of their syncers
+type SyncedNetworkConfig struct {
+	// BridgeAddr is the address of the bridge contract synced by the bridge syncer
+	BridgeAddr common.Address
+	// GERAddr is the address of the global exit root contract (the GER manager on L1)
+	GERAddr common.Address
+	// BridgeBlockFinality is the block finality of the bridge syncer
+	BridgeBlockFinality string
+	// GERBlockFinality is the block finality of the GER syncer (the L1 info tree syncer on L1)
+	GERBlockFinality string
+}
+
+// NodeConfig is the configuration used by the node, returned by /config so the front-ends and SDKs
+// can auto-configure against this bridge service
+type NodeConfig struct {
+	// RollupManagerAddr is the address of the rollup manager contract on L1
+	RollupManagerAddr common.Address
+	L1                SyncedNetworkConfig
+	L2                SyncedNetworkConfig
+}
+
+// GetConfigHandler returns the contract addresses, network IDs and finality settings used by the node.
+//
+// @Summary Get node config
+// @Description Returns the bridge contract addresses, the GER manager and rollup manager addresses, the
+// @Description network IDs and the block finality settings used by the node, so the clients can
+// @Description auto-configure against this endpoint.
+// @Tags networks
+// @Produce json
+// @Success 200 {object} types.ConfigResponse "Configuration used by the node"
+// @Router /config [get]
+func (b *BridgeService) GetConfigHandler(c *gin.Context) {
+	b.logger.Debugf("GetConfig request received")
+
+	cnt, merr := b.meter.Int64Counter("get_config")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_config counter: %s", merr)
+	}
+	cnt.Add(c, 1)
+
+	c.JSON(http.StatusOK, types.ConfigResponse{
+		Version:              aggkit.GetVersion().Version,
+		RollupManagerAddress: types.Address(b.nodeConfig.RollupManagerAddr.Hex()),
+		L1:                   newNetworkConfigResponse(networks.L1NetworkID, b.nodeConfig.L1),
+		L2:                   newNetworkConfigResponse(b.networkID, b.nodeConfig.L2),
+	})
+}
+
+func newNetworkConfigResponse(networkID uint32, cfg SyncedNetworkConfig) types.NetworkConfigResponse {
+	return types.NetworkConfigResponse{
+		NetworkID:           networkID,
+		BridgeAddress:       types.Address(cfg.BridgeAddr.Hex()),
+		GERAddress:          types.Address(cfg.GERAddr.Hex()),
+		BridgeBlockFinality: cfg.BridgeBlockFinality,
+		GERBlockFinality:    cfg.GERBlockFinality,
+	}
+}
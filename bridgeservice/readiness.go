@@ -0,0 +1,95 @@
+package bridgeservice
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ReadinessHandler returns whether the bridge service is ready to serve traffic.
+//
+// @Summary Get readiness
+// @Description Returns whether the bridge service is ready to serve traffic: both the L1 and L2 bridge syncers
+// @Description have synced up to the configured number of blocks behind the chain (ReadinessMaxL1BlocksBehind
+// @Description and ReadinessMaxL2BlocksBehind). Unlike the health check, it returns 503 while a syncer is
+// @Description behind, so the load balancers don't route traffic to replicas serving stale data.
+// @Tags health
+// @Produce json
+// @Success 200 {object} types.ReadinessResponse "The service is ready"
+// @Failure 503 {object} types.ReadinessResponse "The service is not ready"
+// @Router /ready [get]
+func (b *BridgeService) ReadinessHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	response := types.ReadinessResponse{
+		L1: b.networkReadiness(ctx, b.bridgeL1, b.networks.L1().ID, b.maxL1BlocksBehind),
+		L2: b.networkReadiness(ctx, b.bridgeL2, b.networkID, b.maxL2BlocksBehind),
+	}
+	response.Ready = response.L1.Ready && response.L2.Ready
+
+	status := http.StatusOK
+	if !response.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
+}
+
+// networkReadiness returns the readiness of the bridge syncer, that is ready if it's at most
+// maxBlocksBehind blocks behind the last block of the chain that it syncs
+func (b *BridgeService) networkReadiness(ctx context.Context,
+	bridger Bridger, networkID uint32, maxBlocksBehind uint64) *types.NetworkReadiness {
+	readiness := &types.NetworkReadiness{
+		NetworkID:       networkID,
+		MaxBlocksBehind: maxBlocksBehind,
+	}
+	lastProcessedBlock, err := bridger.GetLastProcessedBlock(ctx)
+	if err != nil {
+		b.logger.Warnf("failed to get the last processed block of network %d: %v", networkID, err)
+		readiness.Error = "failed to get the last processed block: " + err.Error()
+		return readiness
+	}
+	targetBlock, err := bridger.GetSyncTargetBlock(ctx)
+	if err != nil {
+		b.logger.Warnf("failed to get the sync target block of network %d: %v", networkID, err)
+		readiness.Error = "failed to get the sync target block: " + err.Error()
+		return readiness
+	}
+
+	readiness.LastProcessedBlock = lastProcessedBlock
+	readiness.TargetBlock = targetBlock
+	if targetBlock > lastProcessedBlock {
+		readiness.BlocksBehind = targetBlock - lastProcessedBlock
+	}
+	readiness.Ready = readiness.BlocksBehind <= maxBlocksBehind
+	return readiness
+}
+
+// registerHeadAndOptionsRoutes answers the HEAD requests of the GET routes with the same handler
+// (the body is discarded by the HTTP server), the OPTIONS requests with the allowed methods of the
+// route, and the requests with other methods with 405 Method Not Allowed
+func (b *BridgeService) registerHeadAndOptionsRoutes() {
+	allowedMethods := map[string][]string{}
+	for _, route := range b.router.Routes() {
+		allowedMethods[route.Path] = append(allowedMethods[route.Path], route.Method)
+		if route.Method == http.MethodGet {
+			allowedMethods[route.Path] = append(allowedMethods[route.Path], http.MethodHead)
+			b.router.HEAD(route.Path, route.HandlerFunc)
+		}
+	}
+
+	for path, methods := range allowedMethods {
+		methods = append(methods, http.MethodOptions)
+		sort.Strings(methods)
+		allow := strings.Join(methods, ", ")
+		b.router.OPTIONS(path, func(c *gin.Context) {
+			c.Header("Allow", allow)
+			c.Status(http.StatusNoContent)
+		})
+	}
+	b.router.HandleMethodNotAllowed = true
+}
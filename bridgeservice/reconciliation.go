@@ -0,0 +1,393 @@
+package bridgeservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// reconciliationBlockRange is the max number of L2 blocks whose claims are loaded at once
+	reconciliationBlockRange = 10000
+	// reconciliationBatchSize is the max number of claims whose L1 bridges are loaded by a single query
+	reconciliationBatchSize = 500
+	// maxOrphanClaims is the max number of orphan claims kept in the report
+	maxOrphanClaims = 1000
+	// maxDuplicatedClaims is the max number of duplicated claims kept in the report
+	maxDuplicatedClaims = 1000
+)
+
+// claimsReconciler periodically compares the claims of the L2 with the bridges of the L1 (by global
+// index) to detect the claims of a global index claimed more than once and the claims without a
+// matching bridge. Only the claims of L1 bridges (mainnet flag) are checked against the bridges,
+// the bridges of other rollups are not indexed by this service
+type claimsReconciler struct {
+	logger   aggkitcommon.Logger
+	bridgeL1 Bridger
+	bridgeL2 Bridger
+	interval time.Duration
+
+	duplicatedGauge metric.Int64Gauge
+	orphanGauge     metric.Int64Gauge
+
+	mu     sync.RWMutex
+	report types.ClaimsReconciliationReport
+
+	// the fields below are only accessed by the job
+	checkedUpToBlock uint64
+	pending          []bridgesync.Claim
+	// orphans are the orphan claims by global index
+	orphans        map[string]types.ClaimFinding
+	omittedOrphans int
+	// duplicatedCheckedUpToBlock is the last L2 block checked for duplicated claims
+	duplicatedCheckedUpToBlock uint64
+	// duplicated are the duplicated claims by position
+	duplicated        map[string]types.ClaimFinding
+	omittedDuplicated int
+}
+
+func newClaimsReconciler(logger aggkitcommon.Logger, meter metric.Meter,
+	bridgeL1, bridgeL2 Bridger, interval time.Duration) *claimsReconciler {
+	duplicatedGauge, err := meter.Int64Gauge("claims_reconciliation_duplicated_claims")
+	if err != nil {
+		logger.Warnf("failed to create claims_reconciliation_duplicated_claims gauge: %s", err)
+	}
+	orphanGauge, err := meter.Int64Gauge("claims_reconciliation_orphan_claims")
+	if err != nil {
+		logger.Warnf("failed to create claims_reconciliation_orphan_claims gauge: %s", err)
+	}
+	return &claimsReconciler{
+		logger:          logger,
+		bridgeL1:        bridgeL1,
+		bridgeL2:        bridgeL2,
+		interval:        interval,
+		duplicatedGauge: duplicatedGauge,
+		orphanGauge:     orphanGauge,
+		orphans:         make(map[string]types.ClaimFinding),
+		duplicated:      make(map[string]types.ClaimFinding),
+		report: types.ClaimsReconciliationReport{
+			Enabled:          true,
+			DuplicatedClaims: []types.ClaimFinding{},
+			OrphanClaims:     []types.ClaimFinding{},
+		},
+	}
+}
+
+// start runs the reconciliation every interval until the context is done
+func (r *claimsReconciler) start(ctx context.Context) {
+	r.logger.Infof("starting claims reconciliation job (interval: %s)", r.interval)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.reconcile(ctx); err != nil {
+			r.logger.Errorf("claims reconciliation failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// getReport returns a copy of the last report
+func (r *claimsReconciler) getReport() types.ClaimsReconciliationReport {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	report := r.report
+	report.DuplicatedClaims = append([]types.ClaimFinding{}, r.report.DuplicatedClaims...)
+	report.OrphanClaims = append([]types.ClaimFinding{}, r.report.OrphanClaims...)
+	return report
+}
+
+// reconcile checks the claims of the finalized L2 blocks not checked yet (and the pending ones)
+// against the L1 bridges, looks for duplicated claims in the same blocks and updates the report
+func (r *claimsReconciler) reconcile(ctx context.Context) error {
+	toBlock, err := r.getLastFinalizedBlock(ctx)
+	if err == nil {
+		err = r.checkOrphanClaims(ctx, toBlock)
+	}
+	if err == nil {
+		err = r.checkDuplicatedClaims(ctx, toBlock)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.report.LastRunAt = uint64(time.Now().UTC().Unix())
+	r.report.CheckedUpToBlock = r.checkedUpToBlock
+	r.report.PendingClaims = len(r.pending)
+	r.report.OrphanClaims = sortedFindings(r.orphans)
+	r.report.OmittedOrphanClaims = r.omittedOrphans
+	r.report.DuplicatedClaims = sortedFindings(r.duplicated)
+	r.report.OmittedDuplicatedClaims = r.omittedDuplicated
+	if err != nil {
+		r.report.LastError = err.Error()
+		return err
+	}
+	r.report.LastError = ""
+
+	duplicated := len(r.duplicated) + r.omittedDuplicated
+	orphans := len(r.orphans) + r.omittedOrphans
+	if duplicated > 0 || orphans > 0 {
+		r.logger.Warnf("claims reconciliation found %d duplicated claims and %d orphan claims",
+			duplicated, orphans)
+	}
+	if r.duplicatedGauge != nil {
+		r.duplicatedGauge.Record(ctx, int64(duplicated))
+	}
+	if r.orphanGauge != nil {
+		r.orphanGauge.Record(ctx, int64(orphans))
+	}
+	return nil
+}
+
+// getLastFinalizedBlock returns the last finalized L2 block processed by the L2 syncer. Only the
+// claims up to this block are checked, so the findings are not affected by L2 reorgs
+func (r *claimsReconciler) getLastFinalizedBlock(ctx context.Context) (uint64, error) {
+	finality, err := r.bridgeL2.GetFinalityBlocks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the L2 finalized block: %w", err)
+	}
+	lastProcessedBlock, err := r.bridgeL2.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the L2 last processed block: %w", err)
+	}
+	return min(finality.Finalized, lastProcessedBlock), nil
+}
+
+// checkOrphanClaims checks the claims of L1 bridges of the L2 blocks up to toBlock. The claims whose
+// deposit count is greater than the last L1 bridge synced are kept as pending until the L1 syncer
+// reaches them
+func (r *claimsReconciler) checkOrphanClaims(ctx context.Context, toBlock uint64) error {
+	if len(r.pending) == 0 && r.checkedUpToBlock >= toBlock {
+		return nil
+	}
+	syncedDeposits, err := r.getSyncedL1Deposits(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the pending claims of the previous runs are checked first, then the claims of each chunk
+	// of blocks, so only the claims of a chunk are loaded at once
+	if err := r.checkPendingClaims(ctx, syncedDeposits); err != nil {
+		return err
+	}
+	for fromBlock := r.checkedUpToBlock + 1; fromBlock <= toBlock; fromBlock += reconciliationBlockRange {
+		chunkToBlock := min(fromBlock+reconciliationBlockRange-1, toBlock)
+		claims, err := r.bridgeL2.GetClaims(ctx, fromBlock, chunkToBlock)
+		if err != nil {
+			return fmt.Errorf("failed to get the L2 claims of blocks %d-%d: %w", fromBlock, chunkToBlock, err)
+		}
+		for _, claim := range claims {
+			mainnetFlag, _, _, err := bridgesync.DecodeGlobalIndex(claim.GlobalIndex)
+			if err != nil {
+				r.addOrphan(claim, fmt.Sprintf("invalid global index: %s", err))
+				continue
+			}
+			if mainnetFlag {
+				r.pending = append(r.pending, claim)
+			}
+		}
+		r.checkedUpToBlock = chunkToBlock
+		if err := r.checkPendingClaims(ctx, syncedDeposits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSyncedL1Deposits returns the number of deposits synced on L1 (the last deposit count + 1)
+func (r *claimsReconciler) getSyncedL1Deposits(ctx context.Context) (uint64, error) {
+	lastBridges, _, err := r.bridgeL1.GetBridgesPaged(ctx, 1, 1, nil, nil, "", nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last L1 bridge: %w", err)
+	}
+	if len(lastBridges) == 0 {
+		return 0, nil
+	}
+	return uint64(lastBridges[0].DepositCount) + 1, nil
+}
+
+// checkPendingClaims checks the pending claims whose L1 bridge is synced, in batches of
+// reconciliationBatchSize claims. The claims not checked are kept as pending
+func (r *claimsReconciler) checkPendingClaims(ctx context.Context, syncedDeposits uint64) error {
+	synced := make([]bridgesync.Claim, 0, len(r.pending))
+	notSynced := make([]bridgesync.Claim, 0, len(r.pending))
+	for _, claim := range r.pending {
+		if uint64(claimDepositCount(claim)) < syncedDeposits {
+			synced = append(synced, claim)
+		} else {
+			notSynced = append(notSynced, claim)
+		}
+	}
+	for start := 0; start < len(synced); start += reconciliationBatchSize {
+		batch := synced[start:min(start+reconciliationBatchSize, len(synced))]
+		if err := r.checkClaimsBatch(ctx, batch); err != nil {
+			// keep the claims not checked yet for the next run
+			r.pending = append(notSynced, synced[start:]...)
+			return err
+		}
+	}
+	r.pending = notSynced
+	return nil
+}
+
+// checkClaimsBatch compares the claims with their L1 bridges, loaded by a single query
+func (r *claimsReconciler) checkClaimsBatch(ctx context.Context, claims []bridgesync.Claim) error {
+	depositCounts := make([]uint32, 0, len(claims))
+	for _, claim := range claims {
+		depositCounts = append(depositCounts, claimDepositCount(claim))
+	}
+	bridges, err := r.bridgeL1.GetBridgesByDepositCounts(ctx, depositCounts)
+	if err != nil {
+		return fmt.Errorf("failed to get the L1 bridges of %d claims: %w", len(claims), err)
+	}
+	bridgesByDepositCount := make(map[uint32]*bridgesync.Bridge, len(bridges))
+	for _, bridge := range bridges {
+		bridgesByDepositCount[bridge.DepositCount] = bridge
+	}
+	for _, claim := range claims {
+		depositCount := claimDepositCount(claim)
+		if reason := findBridgeMismatch(claim, depositCount, bridgesByDepositCount[depositCount]); reason != "" {
+			r.addOrphan(claim, reason)
+		}
+	}
+	return nil
+}
+
+// addOrphan adds the claim to the orphan claims, once per global index and up to maxOrphanClaims
+func (r *claimsReconciler) addOrphan(claim bridgesync.Claim, reason string) {
+	key := claim.TxHash.Hex()
+	if claim.GlobalIndex != nil {
+		key = claim.GlobalIndex.String()
+	}
+	if _, found := r.orphans[key]; found {
+		return
+	}
+	if len(r.orphans) >= maxOrphanClaims {
+		r.omittedOrphans++
+		return
+	}
+	r.orphans[key] = newClaimFinding(claim, reason)
+}
+
+// sortedFindings returns the findings sorted by block
+func sortedFindings(findingsByKey map[string]types.ClaimFinding) []types.ClaimFinding {
+	findings := make([]types.ClaimFinding, 0, len(findingsByKey))
+	for _, finding := range findingsByKey {
+		findings = append(findings, finding)
+	}
+	slices.SortFunc(findings, func(a, b types.ClaimFinding) int {
+		return cmp.Or(cmp.Compare(a.BlockNum, b.BlockNum), strings.Compare(string(a.TxHash), string(b.TxHash)),
+			strings.Compare(string(a.GlobalIndex), string(b.GlobalIndex)))
+	})
+	return findings
+}
+
+// claimDepositCount returns the deposit count of the global index of a claim of an L1 bridge
+func claimDepositCount(claim bridgesync.Claim) uint32 {
+	_, _, depositCount, _ := bridgesync.DecodeGlobalIndex(claim.GlobalIndex)
+	return depositCount
+}
+
+// findBridgeMismatch returns why the claim doesn't match the L1 bridge with the given deposit count
+// (empty if it matches)
+func findBridgeMismatch(claim bridgesync.Claim, depositCount uint32, bridge *bridgesync.Bridge) string {
+	switch {
+	case bridge == nil:
+		return fmt.Sprintf("no bridge with deposit count %d on L1", depositCount)
+	case bridge.OriginNetwork != claim.OriginNetwork || bridge.OriginAddress != claim.OriginAddress:
+		return fmt.Sprintf("the origin of the L1 bridge with deposit count %d doesn't match", depositCount)
+	case bridge.DestinationNetwork != claim.DestinationNetwork || bridge.DestinationAddress != claim.DestinationAddress:
+		return fmt.Sprintf("the destination of the L1 bridge with deposit count %d doesn't match", depositCount)
+	case bridge.Amount == nil || claim.Amount == nil || bridge.Amount.Cmp(claim.Amount) != 0:
+		return fmt.Sprintf("the amount of the L1 bridge with deposit count %d doesn't match", depositCount)
+	default:
+		return ""
+	}
+}
+
+// checkDuplicatedClaims looks for the claims of a global index claimed more than once in the L2
+// blocks not checked yet up to toBlock, by chunks of blocks. The claims of a global index claimed
+// again are found with the new claim, so each chunk is only compared with the previous blocks.
+// Up to maxDuplicatedClaims claims are kept, the rest are only counted
+func (r *claimsReconciler) checkDuplicatedClaims(ctx context.Context, toBlock uint64) error {
+	for fromBlock := r.duplicatedCheckedUpToBlock + 1; fromBlock <= toBlock; fromBlock += reconciliationBlockRange {
+		chunkToBlock := min(fromBlock+reconciliationBlockRange-1, toBlock)
+		limit := uint32(maxDuplicatedClaims - len(r.duplicated)) //nolint:gosec
+		claims, total, err := r.bridgeL2.GetDuplicatedClaims(ctx, fromBlock, chunkToBlock, limit)
+		if err != nil {
+			return fmt.Errorf("failed to get the L2 duplicated claims of blocks %d-%d: %w",
+				fromBlock, chunkToBlock, err)
+		}
+		// the claims of a global index already reported are returned again with the new claims
+		// (they are sorted by position, so they are never the ones omitted by the limit)
+		for _, claim := range claims {
+			key := fmt.Sprintf("%d/%d", claim.BlockNum, claim.BlockPos)
+			if _, found := r.duplicated[key]; found {
+				continue
+			}
+			r.duplicated[key] = newClaimFinding(*claim, "global index claimed more than once")
+		}
+		r.omittedDuplicated += total - len(claims)
+		r.duplicatedCheckedUpToBlock = chunkToBlock
+	}
+	return nil
+}
+
+func newClaimFinding(claim bridgesync.Claim, reason string) types.ClaimFinding {
+	finding := types.ClaimFinding{
+		BlockNum: claim.BlockNum,
+		TxHash:   types.Hash(claim.TxHash.Hex()),
+		Reason:   reason,
+	}
+	if claim.GlobalIndex != nil {
+		finding.GlobalIndex = types.BigIntString(claim.GlobalIndex.String())
+	}
+	return finding
+}
+
+// GetClaimsReconciliationHandler returns the last report of the claims reconciliation job.
+//
+// @Summary Get claims reconciliation report
+// @Description Returns the findings of the job that compares the L2 claims with the L1 bridges (by global index):
+// @Description claims of a global index claimed more than once and claims without a matching bridge on L1.
+// @Description Only the claims of finalized L2 blocks are checked.
+// @Description It requires the admin token of the bridge service.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer <admin token>"
+// @Success 200 {object} types.ClaimsReconciliationReport "Claims reconciliation report"
+// @Failure 401 {object} types.ErrorResponse "Invalid admin token"
+// @Failure 403 {object} types.ErrorResponse "Admin endpoints disabled"
+// @Router /admin/claims-reconciliation [get]
+func (b *BridgeService) GetClaimsReconciliationHandler(c *gin.Context) {
+	b.logger.Debugf("GetClaimsReconciliation request received")
+
+	cnt, merr := b.meter.Int64Counter("get_claims_reconciliation")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_claims_reconciliation counter: %s", merr)
+	}
+	cnt.Add(c, 1)
+
+	if b.claimsReconciler == nil {
+		c.JSON(http.StatusOK, types.ClaimsReconciliationReport{
+			DuplicatedClaims: []types.ClaimFinding{},
+			OrphanClaims:     []types.ClaimFinding{},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, b.claimsReconciler.getReport())
+}
@@ -0,0 +1,56 @@
+package bridgeservice
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader is the header with the ID of the request, sent by the client or generated by the service
+	RequestIDHeader = "X-Request-ID"
+	requestIDKey    = "request_id"
+	// maxRequestIDLength is the maximum length of the request IDs sent by the clients
+	maxRequestIDLength = 128
+	requestIDBytes     = 16
+)
+
+// RequestIDHandler returns a Gin middleware that assigns an ID to each request, so the responses (the header
+// and the pagination metadata) and the logs can be correlated. The ID sent by the client in the X-Request-ID
+// header is kept if it's valid, otherwise a random one is generated
+func RequestIDHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// requestID returns the ID assigned to the request by RequestIDHandler
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// isValidRequestID checks that the request ID is not empty, not too long, and only has printable ASCII characters
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, requestIDBytes)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,181 @@
+package bridgeservice
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/agglayer/aggkit/networks"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UnclaimedDepositsGuard is the retention guard of the L1 info tree that keeps, for each unclaimed deposit
+// of the L1 to the L2 and of the L2, the first leaf that includes it: the one returned by /l1-info-tree-index
+// and used by /claim-proof. The L1 deposits to other networks are claimed with the proofs of their own
+// nodes. The L2 deposits are claimed on the L1 or on other networks, whose claims are not synced, so the
+// ones not claimed on the L1 are kept
+type UnclaimedDepositsGuard struct {
+	networks   *networks.Registry
+	networkID  uint32
+	l1InfoTree L1InfoTreer
+	bridgeL1   Bridger
+	bridgeL2   Bridger
+}
+
+// NewUnclaimedDepositsGuard returns the retention guard of the unclaimed deposits of the bridge syncers
+func NewUnclaimedDepositsGuard(networksRegistry *networks.Registry, networkID uint32, l1InfoTree L1InfoTreer,
+	bridgeL1, bridgeL2 Bridger) *UnclaimedDepositsGuard {
+	return &UnclaimedDepositsGuard{
+		networks:   networksRegistry,
+		networkID:  networkID,
+		l1InfoTree: l1InfoTree,
+		bridgeL1:   bridgeL1,
+		bridgeL2:   bridgeL2,
+	}
+}
+
+// RequiredL1InfoTreeIndexes returns the L1 info tree indexes, between fromIndex and toIndex, of the
+// first leaves that include an unclaimed deposit
+func (g *UnclaimedDepositsGuard) RequiredL1InfoTreeIndexes(
+	ctx context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error) {
+	// the deposits included by the leaf before the range have their first leaf before it
+	var includedL1, includedL2 uint32
+	previous, err := g.l1InfoTree.GetInfoBeforeIndex(ctx, fromIndex)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get the l1 info tree leaf before %d: %w", fromIndex, err)
+	}
+	if err == nil {
+		if includedL1, includedL2, err = g.includedDeposits(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	required := make(map[uint32]struct{})
+	for index := fromIndex; index <= toIndex; index++ {
+		leaf, err := g.l1InfoTree.GetInfoByIndex(ctx, index)
+		if errors.Is(err, db.ErrNotFound) || errors.Is(err, sql.ErrNoRows) {
+			// already pruned
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the l1 info tree leaf %d: %w", index, err)
+		}
+		leafL1, leafL2, err := g.includedDeposits(ctx, leaf)
+		if err != nil {
+			return nil, err
+		}
+		unclaimed, err := g.hasUnclaimedDeposit(ctx, g.networks.L1().ID, includedL1, leafL1)
+		if err != nil {
+			return nil, err
+		}
+		if !unclaimed {
+			unclaimed, err = g.hasUnclaimedDeposit(ctx, g.networkID, includedL2, leafL2)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if unclaimed {
+			required[index] = struct{}{}
+		}
+		includedL1, includedL2 = max(includedL1, leafL1), max(includedL2, leafL2)
+	}
+	return required, nil
+}
+
+// includedDeposits returns the number of deposits of the L1 and of the L2 included in the leaf
+func (g *UnclaimedDepositsGuard) includedDeposits(ctx context.Context,
+	leaf *l1infotreesync.L1InfoTreeLeaf) (uint32, uint32, error) {
+	l1Deposits, err := g.includedL1Deposits(ctx, leaf)
+	if err != nil {
+		return 0, 0, err
+	}
+	l2Deposits, err := g.includedL2Deposits(ctx, leaf)
+	if err != nil {
+		return 0, 0, err
+	}
+	return l1Deposits, l2Deposits, nil
+}
+
+// includedL1Deposits returns the number of deposits of the L1 included in the mainnet exit root of the leaf
+func (g *UnclaimedDepositsGuard) includedL1Deposits(ctx context.Context,
+	leaf *l1infotreesync.L1InfoTreeLeaf) (uint32, error) {
+	root, err := g.bridgeL1.GetRootByLER(ctx, leaf.MainnetExitRoot)
+	if err == nil {
+		return root.Index + 1, nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return 0, fmt.Errorf("failed to get the L1 exit root %s of the l1 info tree leaf %d: %w",
+			leaf.MainnetExitRoot.Hex(), leaf.L1InfoTreeIndex, err)
+	}
+	// the mainnet exit root is not in the L1 exit tree if it has no deposits, or if the syncer is behind
+	lastBlock, err := g.bridgeL1.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last block of the L1 bridge syncer: %w", err)
+	}
+	if lastBlock < leaf.BlockNumber {
+		return 0, fmt.Errorf("the L1 bridge syncer (block %d) has not synced the l1 info tree leaf %d (block %d)",
+			lastBlock, leaf.L1InfoTreeIndex, leaf.BlockNumber)
+	}
+	return 0, nil
+}
+
+// includedL2Deposits returns the number of deposits of the L2 included in the rollup exit root of the leaf
+func (g *UnclaimedDepositsGuard) includedL2Deposits(ctx context.Context,
+	leaf *l1infotreesync.L1InfoTreeLeaf) (uint32, error) {
+	ler, err := g.l1InfoTree.GetLocalExitRoot(ctx, g.networkID, leaf.RollupExitRoot)
+	if errors.Is(err, db.ErrNotFound) || (err == nil && ler == (common.Hash{})) {
+		// the L2 has no verified local exit root yet
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the local exit root of the l1 info tree leaf %d: %w",
+			leaf.L1InfoTreeIndex, err)
+	}
+	root, err := g.bridgeL2.GetRootByLER(ctx, ler)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the L2 exit root %s of the l1 info tree leaf %d: %w",
+			ler.Hex(), leaf.L1InfoTreeIndex, err)
+	}
+	return root.Index + 1, nil
+}
+
+// hasUnclaimedDeposit returns whether any deposit of the network, from the deposit count fromDeposit
+// (included) to toDeposit (excluded), is unclaimed
+func (g *UnclaimedDepositsGuard) hasUnclaimedDeposit(ctx context.Context, networkID uint32,
+	fromDeposit, toDeposit uint32) (bool, error) {
+	if fromDeposit >= toDeposit {
+		return false, nil
+	}
+	mainnetFlag := g.networks.IsL1(networkID)
+	bridger, claimer := g.bridgeL2, g.bridgeL1
+	if mainnetFlag {
+		bridger, claimer = g.bridgeL1, g.bridgeL2
+	}
+	bridges, err := bridger.GetBridgesByDepositCount(ctx, fromDeposit, toDeposit-1)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the deposits %d-%d of network %d: %w",
+			fromDeposit, toDeposit-1, networkID, err)
+	}
+	for _, bridge := range bridges {
+		if mainnetFlag && bridge.DestinationNetwork != g.networkID {
+			continue
+		}
+		var rollupIndex uint32
+		if !mainnetFlag {
+			rollupIndex = networkID - 1
+		}
+		globalIndex := bridgesync.GenerateGlobalIndex(mainnetFlag, rollupIndex, bridge.DepositCount)
+		claims, err := claimer.GetClaimsByGlobalIndex(ctx, globalIndex)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the claims of global index %s: %w", globalIndex, err)
+		}
+		if len(claims) == 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,81 @@
+package bridgeservice
+
+import (
+	"strings"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// endpointName returns the name of the endpoint of the request: its route relative to the
+// bridge service prefix (e.g. "claim-proof"). It's empty if the request didn't match any route
+func endpointName(c *gin.Context) string {
+	return strings.TrimPrefix(strings.TrimPrefix(c.FullPath(), BridgeV1Prefix), "/")
+}
+
+// requestTimeout returns the timeout of the queries of the request: the override of its endpoint
+// (EndpointTimeouts) or the ReadTimeout
+func (b *BridgeService) requestTimeout(c *gin.Context) time.Duration {
+	if timeout, ok := b.endpointTimeouts[endpointName(c)]; ok {
+		return timeout
+	}
+	return b.readTimeout
+}
+
+// serverWriteTimeout returns the write timeout of the HTTP server, that must not cut the responses
+// of the endpoints whose timeout is longer than the WriteTimeout
+func (b *BridgeService) serverWriteTimeout() time.Duration {
+	if b.writeTimeout == 0 {
+		return 0
+	}
+	writeTimeout := b.writeTimeout
+	for _, timeout := range b.endpointTimeouts {
+		writeTimeout = max(writeTimeout, timeout)
+	}
+	return writeTimeout
+}
+
+// checkEndpointTimeouts warns about the timeout overrides that don't match any endpoint
+func (b *BridgeService) checkEndpointTimeouts() {
+	endpoints := make(map[string]struct{})
+	for _, route := range b.router.Routes() {
+		endpoints[strings.TrimPrefix(strings.TrimPrefix(route.Path, BridgeV1Prefix), "/")] = struct{}{}
+	}
+	for endpoint, timeout := range b.endpointTimeouts {
+		if _, ok := endpoints[endpoint]; !ok {
+			b.logger.Warnf("EndpointTimeouts: ignoring the timeout %s of the unknown endpoint %q", timeout, endpoint)
+		}
+	}
+}
+
+// SlowRequestHandler returns a Gin middleware that logs at WARN level (and counts in the slow_requests
+// metric, by endpoint) the requests that take longer than threshold, with their parameters.
+// It does nothing if threshold is 0
+func SlowRequestHandler(logger aggkitcommon.Logger, meter metric.Meter, threshold time.Duration) gin.HandlerFunc {
+	if threshold <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	counter, err := meter.Int64Counter("slow_requests")
+	if err != nil {
+		logger.Warnf("failed to create slow_requests counter: %s", err)
+	}
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		latency := time.Since(start)
+		if latency < threshold {
+			return
+		}
+		endpoint := endpointName(c)
+		if counter != nil {
+			counter.Add(c, 1, metric.WithAttributes(attribute.String("endpoint", endpoint)))
+		}
+		logger.Warnf("slow request: %s %s took %s (threshold %s) | status: %d | params: %v",
+			c.Request.Method, c.Request.URL.Path, latency, threshold, c.Writer.Status(), c.Request.URL.Query())
+	}
+}
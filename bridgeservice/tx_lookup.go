@@ -0,0 +1,104 @@
+package bridgeservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/gin-gonic/gin"
+)
+
+const txHashParam = "tx_hash"
+
+// GetTxEventsHandler searches the bridges and claims of a transaction hash on the L1 and the L2.
+//
+// @Summary Get the bridges and claims of a transaction
+// @Description Returns the bridge and claim events emitted by the given transaction, searched in the
+// @Description events indexed on the L1 and on the L2, so a bridge can be followed from its tx hash only
+// @Tags bridges
+// @Param tx_hash path string true "Transaction hash (0x hex)"
+// @Param include_all_fields query bool false "Whether to include full response fields (default false)"
+// @Param amount_format query string false "Format of the amounts: wei (default) or decimal (adjusted to its decimals)"
+// @Param address_format query string false "Format of the addresses: checksum (EIP-55, default) or lowercase"
+// @Produce json
+// @Success 200 {object} types.TxEventsResponse
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 500 {object} types.ErrorResponse "Internal Server Error"
+// @Router /tx/{tx_hash} [get]
+func (b *BridgeService) GetTxEventsHandler(c *gin.Context) {
+	b.logger.Debugf("GetTxEvents request received (tx hash=%s)", c.Param(txHashParam))
+
+	txHash, err := parseHash(c.Param(txHashParam), txHashParam, true)
+	if err != nil {
+		b.logger.Warnf("invalid tx hash parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	includeAllFieldsFlag, err := parseBoolQuery(c, includeAllFields, false)
+	if err != nil {
+		b.logger.Warnf("invalid include_all_fields parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := parseResponseFormat(c)
+	if err != nil {
+		b.logger.Warnf("invalid format parameter: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	cnt, merr := b.meter.Int64Counter("get_tx_events")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_tx_events counter: %s", merr)
+	}
+	cnt.Add(ctx, 1)
+
+	stores := []struct {
+		networkID uint32
+		bridger   Bridger
+	}{
+		{networkID: b.networks.L1().ID, bridger: b.bridgeL1},
+		{networkID: b.networkID, bridger: b.bridgeL2},
+	}
+	result := &types.TxEventsResponse{
+		TxHash:   types.Hash(txHash.Hex()),
+		Networks: make([]types.TxNetworkEvents, 0, len(stores)),
+	}
+	for _, store := range stores {
+		bridges, claims, err := store.bridger.GetBridgesAndClaimsByTxHash(ctx, txHash)
+		if err != nil {
+			b.logger.Errorf("failed to get the bridges and claims of tx %s (network id=%d): %v",
+				txHash.Hex(), store.networkID, err)
+			c.JSON(http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to get the bridges and claims of tx %s (network id=%d), error: %s",
+					txHash.Hex(), store.networkID, err)})
+			return
+		}
+
+		events := types.TxNetworkEvents{
+			NetworkID: store.networkID,
+			Bridges:   make([]*types.BridgeResponse, 0, len(bridges)),
+			Claims:    make([]*types.ClaimResponse, 0, len(claims)),
+		}
+		for _, bridge := range bridges {
+			response := NewBridgeResponse(bridge)
+			format.applyToBridge(response, bridge)
+			events.Bridges = append(events.Bridges, response)
+		}
+		for _, claim := range claims {
+			response := NewClaimResponse(claim, includeAllFieldsFlag)
+			format.applyToClaim(response, claim)
+			events.Claims = append(events.Claims, response)
+		}
+		result.Found = result.Found || len(bridges) > 0 || len(claims) > 0
+		result.Networks = append(result.Networks, events)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
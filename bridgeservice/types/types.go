@@ -80,6 +80,36 @@ type ClaimProof struct {
 	L1InfoTreeLeaf L1InfoTreeLeafResponse `json:"l1_info_tree_leaf"`
 }
 
+// PaginationMetadata is the pagination information included in the paginated results
+// @Description Pagination metadata of a paginated response
+type PaginationMetadata struct {
+	// Total number of pages for the requested page size
+	TotalPages uint32 `json:"total_pages" example:"5"`
+
+	// Whether there are more results after the returned page
+	HasNext bool `json:"has_next" example:"true"`
+
+	// ID of the request (from the X-Request-ID header, or generated), to correlate it with the logs
+	RequestID string `json:"request_id" example:"6f1c2a3b4d5e6f708192a3b4c5d6e7f8"`
+}
+
+// NewPaginationMetadata returns the pagination metadata of a page of the results
+func NewPaginationMetadata(count int, pageNumber, pageSize uint32, requestID string) PaginationMetadata {
+	metadata := PaginationMetadata{RequestID: requestID}
+	if count <= 0 || pageSize == 0 {
+		return metadata
+	}
+	totalPages := (uint64(count) + uint64(pageSize) - 1) / uint64(pageSize)
+	metadata.TotalPages = uint32(totalPages) //nolint:gosec
+	metadata.HasNext = uint64(pageNumber) < totalPages
+	return metadata
+}
+
+// ISO8601Time formats the unix timestamp (in seconds) as an ISO-8601 UTC time
+func ISO8601Time(timestamp uint64) string {
+	return time.Unix(int64(timestamp), 0).UTC().Format(time.RFC3339) //nolint:gosec
+}
+
 // BridgesResult contains the bridges and the total count of bridges
 // @Description Paginated response of bridge events
 type BridgesResult struct {
@@ -88,6 +118,8 @@ type BridgesResult struct {
 
 	// Total number of bridge events
 	Count int `json:"count" example:"42"`
+
+	PaginationMetadata
 }
 
 // BridgeResponse represents a bridge event response
@@ -111,6 +143,9 @@ type BridgeResponse struct {
 	// Timestamp of the block containing the bridge event
 	BlockTimestamp uint64 `json:"block_timestamp" example:"1684500000"`
 
+	// Timestamp of the block in ISO-8601 format (UTC)
+	BlockTimestampISO string `json:"block_timestamp_iso" example:"2023-05-19T12:40:00Z"`
+
 	// Type of leaf (bridge event type) used in the tree structure
 	LeafType uint8 `json:"leaf_type" example:"1"`
 
@@ -126,9 +161,12 @@ type BridgeResponse struct {
 	// Address of the token receiver on the destination network
 	DestinationAddress Address `json:"destination_address" example:"0xdef4567890abcdef1234567890abcdef12345678"`
 
-	// Amount of tokens being bridged
+	// Amount of tokens being bridged, in the smallest unit of the token unless requested with amount_format=decimal
 	Amount BigIntString `json:"amount" example:"1000000000000000000"`
 
+	// Decimals applied to the amount (only if requested with amount_format=decimal and the decimals are known)
+	AmountDecimals *uint8 `json:"amount_decimals,omitempty" example:"18"`
+
 	// Optional metadata attached to the bridge event
 	Metadata string `json:"metadata" example:"0xdeadbeef"`
 
@@ -140,6 +178,28 @@ type BridgeResponse struct {
 
 	// Unique hash representing the bridge event, often used as an identifier
 	BridgeHash Hash `json:"bridge_hash" example:"0xabc1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcd"`
+
+	// Finality of the block that contains the bridge: pending, safe or finalized
+	Finality string `json:"finality,omitempty" example:"finalized"`
+
+	// Token metadata decoded from the metadata field (only if requested with decode_metadata)
+	DecodedMetadata *TokenMetadata `json:"decoded_metadata,omitempty"`
+
+	// Value in USD of the amount at the time of the bridge (only if the price oracle is enabled and has a price)
+	ValueUSD *string `json:"value_usd,omitempty" example:"2500.750000"`
+}
+
+// TokenMetadata represents the metadata of a bridged token
+// @Description Name, symbol and decimals of the token, ABI-encoded by the bridge contract in the metadata
+type TokenMetadata struct {
+	// Name of the token
+	Name string `json:"name" example:"Wrapped Ether"`
+
+	// Symbol of the token
+	Symbol string `json:"symbol" example:"WETH"`
+
+	// Number of decimals of the token
+	Decimals uint8 `json:"decimals" example:"18"`
 }
 
 // ClaimsResult contains the list of claim records and the total count
@@ -150,6 +210,8 @@ type ClaimsResult struct {
 
 	// Total number of matching claims
 	Count int `json:"count" example:"42"`
+
+	PaginationMetadata
 }
 
 // ClaimResponse represents a claim event response
@@ -161,6 +223,9 @@ type ClaimResponse struct {
 	// Timestamp of the block containing the claim
 	BlockTimestamp uint64 `json:"block_timestamp" example:"1684500000"`
 
+	// Timestamp of the block in ISO-8601 format (UTC)
+	BlockTimestampISO string `json:"block_timestamp_iso" example:"2023-05-19T12:40:00Z"`
+
 	// Transaction hash associated with the claim
 	TxHash Hash `json:"tx_hash" example:"0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"`
 
@@ -179,9 +244,12 @@ type ClaimResponse struct {
 	// Destination network ID where the claim was processed
 	DestinationNetwork uint32 `json:"destination_network" example:"42161"`
 
-	// Amount claimed
+	// Amount claimed, in the smallest unit of the token unless requested with amount_format=decimal
 	Amount BigIntString `json:"amount" example:"1000000000000000000"`
 
+	// Decimals applied to the amount (only if requested with amount_format=decimal and the decimals are known)
+	AmountDecimals *uint8 `json:"amount_decimals,omitempty" example:"18"`
+
 	// Address from which the claim originated
 	FromAddress Address `json:"from_address" example:"0xabc1234567890abcdef1234567890abcdef1234"`
 
@@ -202,6 +270,21 @@ type ClaimResponse struct {
 
 	// Metadata associated with the claim
 	Metadata string `json:"metadata" example:"0xdeadbeef"`
+
+	// Finality of the block that contains the claim: pending, safe or finalized
+	Finality string `json:"finality,omitempty" example:"finalized"`
+
+	// Value in USD of the amount at the time of the claim (only if the price oracle is enabled and has a price)
+	ValueUSD *string `json:"value_usd,omitempty" example:"2500.750000"`
+
+	// Bridge of the origin network claimed (only if requested with include_origin=true and the bridge is indexed)
+	OriginBridge *BridgeResponse `json:"origin_bridge,omitempty"`
+
+	// Gas used by the claim transaction, shared by all its claims (only if it's known)
+	GasUsed *uint64 `json:"gas_used,omitempty" example:"120000"`
+
+	// Effective gas price of the claim transaction in wei (only if it's known)
+	EffectiveGasPrice *BigIntString `json:"effective_gas_price,omitempty" example:"1000000000"`
 }
 
 // TokenMappingsResult contains the token mappings and the total count of token mappings
@@ -212,6 +295,8 @@ type TokenMappingsResult struct {
 
 	// Total number of token mapping records
 	Count int `json:"count" example:"27"`
+
+	PaginationMetadata
 }
 
 // TokenMappingResponse represents a token mapping event
@@ -226,6 +311,9 @@ type TokenMappingResponse struct {
 	// Timestamp of the block containing the mapping event
 	BlockTimestamp uint64 `json:"block_timestamp" example:"1684501234"`
 
+	// Timestamp of the block in ISO-8601 format (UTC)
+	BlockTimestampISO string `json:"block_timestamp_iso" example:"2023-05-19T13:00:34Z"`
+
 	// Transaction hash associated with the mapping event
 	TxHash Hash `json:"tx_hash" example:"0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd"`
 
@@ -249,6 +337,9 @@ type TokenMappingResponse struct {
 
 	// Type of the token mapping: 0 = WrappedToken, 1 = SovereignToken
 	Type TokenMappingType `json:"token_type" example:"0"`
+
+	// Token metadata decoded from the metadata field (only if requested with decode_metadata)
+	DecodedMetadata *TokenMetadata `json:"decoded_metadata,omitempty"`
 }
 
 // LegacyTokenMigrationsResult contains the legacy token migrations and the total count of such migrations
@@ -259,6 +350,8 @@ type LegacyTokenMigrationsResult struct {
 
 	// Total number of legacy token migration events
 	Count int `json:"count" example:"12"`
+
+	PaginationMetadata
 }
 
 // LegacyTokenMigrationResponse represents a MigrateLegacyToken event emitted by the sovereign chain bridge contract
@@ -273,6 +366,9 @@ type LegacyTokenMigrationResponse struct {
 	// Timestamp of the block
 	BlockTimestamp uint64 `json:"block_timestamp" example:"1684500000"`
 
+	// Timestamp of the block in ISO-8601 format (UTC)
+	BlockTimestampISO string `json:"block_timestamp_iso" example:"2023-05-19T12:40:00Z"`
+
 	// Transaction hash of the migration event
 	TxHash Hash `json:"tx_hash" example:"0xabc123..."`
 
@@ -311,6 +407,9 @@ type L1InfoTreeLeafResponse struct {
 	// Timestamp of the block in seconds since the Unix epoch
 	Timestamp uint64 `json:"timestamp" example:"1684500000"`
 
+	// Timestamp of the block in ISO-8601 format (UTC)
+	TimestampISO string `json:"timestamp_iso" example:"2023-05-19T12:40:00Z"`
+
 	// Mainnet exit root at this leaf
 	MainnetExitRoot Hash `json:"mainnet_exit_root" example:"0xdefc...789"`
 
@@ -354,3 +453,353 @@ type HealthCheckResponse struct {
 	Time    time.Time `json:"time"`
 	Version string    `json:"version"`
 }
+
+// ReadinessResponse represents the JSON returned by ReadinessHandler.
+// @Description Contains the readiness of the bridge service: it's ready to serve traffic once the L1 and L2
+// bridge syncers are close enough to the chain
+// @example {"ready":false,"l1":{"network_id":0,"last_processed_block":100,"target_block":105,
+// "blocks_behind":5,"max_blocks_behind":10,"ready":true},"l2":{"network_id":1,"last_processed_block":10,
+// "target_block":5000,"blocks_behind":4990,"max_blocks_behind":100,"ready":false}}
+type ReadinessResponse struct {
+	Ready bool              `json:"ready"`
+	L1    *NetworkReadiness `json:"l1"`
+	L2    *NetworkReadiness `json:"l2"`
+}
+
+// NetworkReadiness represents the readiness of the bridge syncer of a network (L1 or L2)
+// @Description Contains the last block processed by the bridge syncer, the last block of the chain
+// that it syncs up to and the number of blocks it is behind
+// @example {"network_id":0,"last_processed_block":100,"target_block":105,"blocks_behind":5,
+// "max_blocks_behind":10,"ready":true}
+type NetworkReadiness struct {
+	NetworkID          uint32 `json:"network_id"`
+	LastProcessedBlock uint64 `json:"last_processed_block"`
+	TargetBlock        uint64 `json:"target_block"`
+	BlocksBehind       uint64 `json:"blocks_behind"`
+	MaxBlocksBehind    uint64 `json:"max_blocks_behind"`
+	Ready              bool   `json:"ready"`
+	// Error is set if the status of the syncer can't be retrieved (then it's not ready)
+	Error string `json:"error,omitempty"`
+}
+
+// VerifyClaimProofRequest is the payload to verify a claim proof server-side
+// @Description Claim proof to verify, the bridge leaf it proves and (optionally) the target global exit root
+type VerifyClaimProofRequest struct {
+	// Claim proof (as returned by the claim-proof endpoint) to verify
+	ClaimProof ClaimProof `json:"claim_proof"`
+
+	// Global index of the claim, it encodes the mainnet flag, the rollup index and the deposit count
+	GlobalIndex BigIntString `json:"global_index" example:"18446744073709551617"`
+
+	// Hash of the bridge leaf (the leaf of the local exit tree) being claimed
+	LeafHash Hash `json:"leaf_hash" example:"0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"`
+
+	// Target global exit root. If empty, the global exit root of the L1 info tree leaf is used
+	GlobalExitRoot Hash `json:"global_exit_root,omitempty" example:"0x4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef123"` //nolint:lll
+}
+
+// ClaimProofCheck is the result of one of the checks done to verify a claim proof
+// @Description Result of a single claim proof verification check
+type ClaimProofCheck struct {
+	// Name of the check
+	Name string `json:"name" example:"mainnet_exit_root"`
+
+	// Whether the check passed
+	Valid bool `json:"valid" example:"true"`
+
+	// Expected value (from the payload)
+	Expected Hash `json:"expected" example:"0xdefc...789"`
+
+	// Value computed from the proof
+	Computed Hash `json:"computed" example:"0xdefc...789"`
+}
+
+// ClaimProofVerdict is the result of the server-side verification of a claim proof
+// @Description Verdict of a claim proof verification, including the result of every check
+type ClaimProofVerdict struct {
+	// Whether all the checks passed
+	Valid bool `json:"valid" example:"true"`
+
+	// Whether the claimed leaf belongs to the mainnet exit tree (L1 bridge)
+	MainnetFlag bool `json:"mainnet_flag" example:"false"`
+
+	// Rollup index decoded from the global index (0 for mainnet)
+	RollupIndex uint32 `json:"rollup_index" example:"0"`
+
+	// Deposit count (leaf index in the local exit tree) decoded from the global index
+	DepositCount uint32 `json:"deposit_count" example:"10"`
+
+	// Results of the individual checks
+	Checks []ClaimProofCheck `json:"checks"`
+}
+
+// NetworksResponse contains the networks supported by the bridge service
+// @Description List of the supported networks and their roles
+type NetworksResponse struct {
+	// Supported networks, sorted by network ID
+	Networks []NetworkResponse `json:"networks"`
+}
+
+// NetworkResponse describes a network of the registry
+// @Description Network of the registry, with its role and contract addresses
+type NetworkResponse struct {
+	// Network ID (0 for L1)
+	NetworkID uint32 `json:"network_id" example:"1"`
+
+	// Human readable name of the network
+	Name string `json:"name" example:"L2"`
+
+	// Role of the network (L1 or L2)
+	Role string `json:"role" example:"L2"`
+
+	// Address of the bridge contract
+	BridgeAddress Address `json:"bridge_address" example:"0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe"`
+
+	// Address of the global exit root contract
+	GERAddress Address `json:"ger_address" example:"0xa40d5f56745a118d0906a34e69aec8c0db1cb8fa"`
+
+	// Block finality used to sync the network
+	BlockFinality string `json:"block_finality,omitempty" example:"LatestBlock"`
+
+	// Whether the bridges and claims of the network are indexed (and can be queried) by this bridge service
+	Indexed bool `json:"indexed" example:"true"`
+}
+
+// ConfigResponse contains the configuration used by the node
+// @Description Contract addresses, network IDs and block finality settings used by the node
+type ConfigResponse struct {
+	// Version of the aggkit
+	Version string `json:"version" example:"v0.5.0"`
+
+	// Address of the rollup manager contract on L1
+	RollupManagerAddress Address `json:"rollup_manager_address" example:"0x32d33D5137a7cFFb54c5Bf8371172bcEc5f310ff"`
+
+	// Configuration of the L1
+	L1 NetworkConfigResponse `json:"l1"`
+
+	// Configuration of the L2 run by this node
+	L2 NetworkConfigResponse `json:"l2"`
+}
+
+// NetworkConfigResponse contains the contracts of a network synced by the node
+// @Description Contract addresses of a network and block finality of their syncers
+type NetworkConfigResponse struct {
+	// Network ID (0 for L1)
+	NetworkID uint32 `json:"network_id" example:"1"`
+
+	// Address of the bridge contract
+	BridgeAddress Address `json:"bridge_address" example:"0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe"`
+
+	// Address of the global exit root contract (the GER manager on L1)
+	GERAddress Address `json:"ger_address" example:"0xa40d5f56745a118d0906a34e69aec8c0db1cb8fa"`
+
+	// Block finality used to sync the bridge contract
+	BridgeBlockFinality string `json:"bridge_block_finality" example:"LatestBlock"`
+
+	// Block finality used to sync the global exit roots (the L1 info tree on L1)
+	GERBlockFinality string `json:"ger_block_finality" example:"FinalizedBlock"`
+}
+
+// ClaimsReconciliationReport contains the findings of the claims reconciliation job
+// @Description Duplicated and orphan claims found comparing the L2 claims with the L1 bridges
+type ClaimsReconciliationReport struct {
+	// Whether the reconciliation job is enabled
+	Enabled bool `json:"enabled" example:"true"`
+
+	// Unix timestamp of the last run of the job (0 if it hasn't run yet)
+	LastRunAt uint64 `json:"last_run_at" example:"1684500000"`
+
+	// Last L2 block whose claims have been checked against the L1 bridges
+	CheckedUpToBlock uint64 `json:"checked_up_to_block" example:"1234"`
+
+	// Number of claims whose L1 bridge has not been synced yet, so they are checked again in the next run
+	PendingClaims int `json:"pending_claims" example:"0"`
+
+	// Claims of a global index that is claimed more than once, at most 1000
+	DuplicatedClaims []ClaimFinding `json:"duplicated_claims"`
+
+	// Number of duplicated claims found after DuplicatedClaims reached its limit, that are not listed
+	OmittedDuplicatedClaims int `json:"omitted_duplicated_claims" example:"0"`
+
+	// Claims without a matching bridge on L1, one per global index and at most 1000
+	OrphanClaims []ClaimFinding `json:"orphan_claims"`
+
+	// Number of orphan claims found after OrphanClaims reached its limit, that are not listed
+	OmittedOrphanClaims int `json:"omitted_orphan_claims" example:"0"`
+
+	// Error of the last run, if it failed
+	LastError string `json:"last_error,omitempty" example:"failed to get the L2 claims"`
+}
+
+// ClaimFinding is a claim reported by the claims reconciliation job
+// @Description Claim reported by the reconciliation job and the reason
+type ClaimFinding struct {
+	// Global index of the claim
+	GlobalIndex BigIntString `json:"global_index" example:"18446744073709551617"`
+
+	// Block number where the claim was processed
+	BlockNum uint64 `json:"block_num" example:"1234"`
+
+	// Transaction hash of the claim
+	TxHash Hash `json:"tx_hash" example:"0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"`
+
+	// Why the claim is reported
+	Reason string `json:"reason" example:"no bridge with deposit count 1 on L1"`
+}
+
+// ClaimLookupResponse is the result of the lookup of a claim by its global index
+// @Description Claim of a global index or, if it's not claimed yet, whether it's claimable and its deposit
+type ClaimLookupResponse struct {
+	// Global index looked up
+	GlobalIndex BigIntString `json:"global_index" example:"18446744073709551617"`
+
+	// Whether the deposit was made on L1 (decoded from the global index)
+	MainnetFlag bool `json:"mainnet_flag" example:"true"`
+
+	// Rollup index of the network of the deposit (decoded from the global index, 0 if mainnet_flag)
+	RollupIndex uint32 `json:"rollup_index" example:"0"`
+
+	// Deposit count of the bridge in its network (decoded from the global index)
+	DepositCount uint32 `json:"deposit_count" example:"1"`
+
+	// Network ID where the deposit was made
+	OriginNetwork uint32 `json:"origin_network" example:"0"`
+
+	// Status of the claim: claimed, claimable (not claimed yet, but it can be), pending (the deposit
+	// is not ready to be claimed yet) or unknown (the deposit is not indexed by this bridge service)
+	Status string `json:"status" example:"claimed"`
+
+	// Network ID where the claim was found (only if claimed)
+	ClaimedOnNetwork *uint32 `json:"claimed_on_network,omitempty" example:"1"`
+
+	// The claim (only if claimed)
+	Claim *ClaimResponse `json:"claim,omitempty"`
+
+	// The bridge event of the deposit (only if not claimed and it's indexed by this bridge service)
+	Deposit *BridgeResponse `json:"deposit,omitempty"`
+
+	// First L1 info tree index that includes the deposit, to request the claim proof (only if claimable)
+	L1InfoTreeIndex *uint32 `json:"l1_info_tree_index,omitempty" example:"10"`
+
+	// Why the deposit is pending or unknown
+	Reason string `json:"reason,omitempty" example:"the deposit has not been included on the L1 info tree yet"`
+}
+
+// TxNetworkEvents contains the bridges and claims of a transaction indexed on a network
+type TxNetworkEvents struct {
+	// ID of the network where the events were emitted
+	NetworkID uint32 `json:"network_id" example:"0"`
+
+	// Bridge events of the transaction, sorted by position
+	Bridges []*BridgeResponse `json:"bridges"`
+
+	// Claim events of the transaction, sorted by position
+	Claims []*ClaimResponse `json:"claims"`
+}
+
+// TxEventsResponse is the result of the search of a transaction in the bridges and claims of the
+// L1 and the L2
+// @Description Bridges and claims emitted by a transaction, on each network indexed by the service
+type TxEventsResponse struct {
+	// Hash of the transaction searched
+	TxHash Hash `json:"tx_hash" example:"0xdef4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"`
+
+	// Whether any bridge or claim of the transaction was found
+	Found bool `json:"found" example:"true"`
+
+	// Events of the transaction on the L1 and on the L2
+	Networks []TxNetworkEvents `json:"networks"`
+}
+
+// USDValueStats contains the totals in USD of the bridges and claims of a network in a time range
+// @Description Value in USD of the bridges and claims of assets at the time of each event
+type USDValueStats struct {
+	// ID of the network of the events
+	NetworkID uint32 `json:"network_id" example:"1"`
+
+	// Start of the time range (unix timestamp, included)
+	FromTimestamp uint64 `json:"from_timestamp" example:"1684500000"`
+
+	// End of the time range (unix timestamp, included)
+	ToTimestamp uint64 `json:"to_timestamp" example:"1687100000"`
+
+	// Total value in USD of the priced bridges
+	BridgedUSD string `json:"bridged_usd" example:"125000.500000"`
+
+	// Total value in USD of the priced claims
+	ClaimedUSD string `json:"claimed_usd" example:"98000.250000"`
+
+	// Number of bridges with value in USD
+	PricedBridges int `json:"priced_bridges" example:"42"`
+
+	// Number of bridges of tokens without price in the oracle
+	UnpricedBridges int `json:"unpriced_bridges" example:"1"`
+
+	// Number of claims with value in USD
+	PricedClaims int `json:"priced_claims" example:"40"`
+
+	// Number of claims of tokens without price in the oracle
+	UnpricedClaims int `json:"unpriced_claims" example:"0"`
+
+	// Totals by token
+	Tokens []TokenUSDValueStats `json:"tokens"`
+}
+
+// ClaimGasStats contains the gas used and the fees of the claims of a network in a time range
+// @Description Gas used and fees (gas used * effective gas price) of the claim transactions
+type ClaimGasStats struct {
+	// ID of the network of the claims
+	NetworkID uint32 `json:"network_id" example:"1"`
+
+	// Start of the time range (unix timestamp, included)
+	FromTimestamp uint64 `json:"from_timestamp" example:"1684500000"`
+
+	// End of the time range (unix timestamp, included)
+	ToTimestamp uint64 `json:"to_timestamp" example:"1687100000"`
+
+	// Number of claims
+	Claims int `json:"claims" example:"42"`
+
+	// Number of claims without gas data (synced before the gas was tracked)
+	UntrackedClaims int `json:"untracked_claims" example:"2"`
+
+	// Number of claim transactions (a transaction with several claims is counted once)
+	Transactions int `json:"transactions" example:"38"`
+
+	// Total gas used by the claim transactions
+	TotalGasUsed uint64 `json:"total_gas_used" example:"4560000"`
+
+	// Total fee in wei of the claim transactions with effective gas price
+	TotalFee BigIntString `json:"total_fee" example:"4560000000000000"`
+
+	// Average gas used per claim transaction
+	AverageGasUsed uint64 `json:"average_gas_used" example:"120000"`
+
+	// Average effective gas price in wei, weighted by the gas used (only if there are fees)
+	AverageEffectiveGasPrice *BigIntString `json:"average_effective_gas_price,omitempty" example:"1000000000"`
+
+	// Average fee in wei per claim (only if there are fees)
+	AverageFeePerClaim *BigIntString `json:"average_fee_per_claim,omitempty" example:"114000000000000"`
+}
+
+// TokenUSDValueStats contains the totals in USD of the bridges and claims of a token
+// @Description Value in USD of the bridges and claims of a token
+type TokenUSDValueStats struct {
+	// ID of the origin network of the token
+	OriginNetwork uint32 `json:"origin_network" example:"0"`
+
+	// Address of the token on the origin network
+	OriginAddress Address `json:"origin_address" example:"0xabc1234567890abcdef1234567890abcdef1234"`
+
+	// Total value in USD of the priced bridges of the token
+	BridgedUSD string `json:"bridged_usd" example:"125000.500000"`
+
+	// Total value in USD of the priced claims of the token
+	ClaimedUSD string `json:"claimed_usd" example:"98000.250000"`
+
+	// Number of bridges of the token
+	Bridges int `json:"bridges" example:"42"`
+
+	// Number of claims of the token
+	Claims int `json:"claims" example:"40"`
+}
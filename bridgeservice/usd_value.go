@@ -0,0 +1,169 @@
+package bridgeservice
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	fromTimestampParam = "from_timestamp"
+	toTimestampParam   = "to_timestamp"
+)
+
+// eventPosition identifies a bridge or a claim by its position on the chain
+type eventPosition struct {
+	blockNum uint64
+	blockPos uint64
+}
+
+// bridgerOf returns the bridge syncer of the network, or nil if it's not supported
+func (b *BridgeService) bridgerOf(networkID uint32) Bridger {
+	switch {
+	case b.networks.IsL1(networkID):
+		return b.bridgeL1
+	case networkID == b.networkID:
+		return b.bridgeL2
+	default:
+		return nil
+	}
+}
+
+// getUSDValues returns the values in USD of the events of the type in the given blocks, by position.
+// The events are returned without value if they can't be retrieved
+func (b *BridgeService) getUSDValues(ctx context.Context, networkID uint32,
+	eventType string, blockNums []uint64) map[eventPosition]string {
+	bridger := b.bridgerOf(networkID)
+	if bridger == nil || len(blockNums) == 0 {
+		return nil
+	}
+	fromBlock, toBlock := uint64(math.MaxUint64), uint64(0)
+	for _, blockNum := range blockNums {
+		fromBlock = min(fromBlock, blockNum)
+		toBlock = max(toBlock, blockNum)
+	}
+
+	values, err := bridger.GetUSDValues(ctx, eventType, fromBlock, toBlock)
+	if err != nil {
+		b.logger.Warnf("failed to get the USD values of network %d, events are not annotated: %v", networkID, err)
+		return nil
+	}
+	byPosition := make(map[eventPosition]string, len(values))
+	for _, value := range values {
+		if value.ValueUSD != nil {
+			byPosition[eventPosition{blockNum: value.BlockNum, blockPos: value.BlockPos}] = *value.ValueUSD
+		}
+	}
+	return byPosition
+}
+
+// usdValueOf returns the value of the event at the position, or nil if it has no value
+func usdValueOf(values map[eventPosition]string, blockNum, blockPos uint64) *string {
+	value, ok := values[eventPosition{blockNum: blockNum, blockPos: blockPos}]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// GetUSDValueStatsHandler returns the totals in USD of the bridges and claims of a network.
+//
+// @Summary Get USD value stats
+// @Description Returns the total value in USD of the bridges and claims of assets of the network with block
+// @Description timestamp in the given range, using the price of each token at the time of the event as returned
+// @Description by the price oracle. Only available if the price oracle of the network is enabled.
+// @Tags stats
+// @Param network_id query uint32 true "Target network ID"
+// @Param from_timestamp query uint64 false "Start of the time range, unix timestamp (default 0)"
+// @Param to_timestamp query uint64 false "End of the time range, unix timestamp (default now)"
+// @Produce json
+// @Success 200 {object} types.USDValueStats
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Failure 500 {object} types.ErrorResponse "Internal Server Error"
+// @Router /usd-value-stats [get]
+func (b *BridgeService) GetUSDValueStatsHandler(c *gin.Context) {
+	b.logger.Debugf("GetUSDValueStats request received (network id=%s, from=%s, to=%s)",
+		c.Query(networkIDParam), c.Query(fromTimestampParam), c.Query(toTimestampParam))
+
+	networkID, err := parseUintQuery(c, networkIDParam, true, uint32(0))
+	if err != nil {
+		b.logger.Warnf(errNetworkID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	fromTimestamp, toTimestamp, err := parseTimestampRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bridger := b.bridgerOf(networkID)
+	if bridger == nil {
+		b.logger.Warnf(errNetworkID, networkID)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(errNetworkID, networkID)})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c, b.requestTimeout(c))
+	defer cancel()
+
+	cnt, merr := b.meter.Int64Counter("get_usd_value_stats")
+	if merr != nil {
+		b.logger.Warnf("failed to create get_usd_value_stats counter: %s", merr)
+	}
+	cnt.Add(ctx, 1)
+
+	stats, err := bridger.GetUSDValueStats(ctx, fromTimestamp, toTimestamp)
+	if err != nil {
+		b.logger.Errorf("failed to get the USD value stats of network %d: %v", networkID, err)
+		c.JSON(http.StatusInternalServerError,
+			gin.H{"error": fmt.Sprintf("failed to get the USD value stats of network %d, error: %s", networkID, err)})
+		return
+	}
+
+	response := types.USDValueStats{
+		NetworkID:       networkID,
+		FromTimestamp:   fromTimestamp,
+		ToTimestamp:     toTimestamp,
+		BridgedUSD:      stats.BridgedUSD,
+		ClaimedUSD:      stats.ClaimedUSD,
+		PricedBridges:   stats.PricedBridges,
+		UnpricedBridges: stats.UnpricedBridges,
+		PricedClaims:    stats.PricedClaims,
+		UnpricedClaims:  stats.UnpricedClaims,
+		Tokens:          make([]types.TokenUSDValueStats, 0, len(stats.Tokens)),
+	}
+	for _, token := range stats.Tokens {
+		response.Tokens = append(response.Tokens, types.TokenUSDValueStats{
+			OriginNetwork: token.OriginNetwork,
+			OriginAddress: types.Address(token.OriginAddress.Hex()),
+			BridgedUSD:    token.BridgedUSD,
+			ClaimedUSD:    token.ClaimedUSD,
+			Bridges:       token.Bridges,
+			Claims:        token.Claims,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// parseTimestampRange parses the time range of the stats endpoints, from the beginning until now by default
+func parseTimestampRange(c *gin.Context) (uint64, uint64, error) {
+	fromTimestamp, err := parseUintQuery(c, fromTimestampParam, false, uint64(0))
+	if err != nil {
+		return 0, 0, err
+	}
+	toTimestamp, err := parseUintQuery(c, toTimestampParam, false, uint64(time.Now().Unix()))
+	if err != nil {
+		return 0, 0, err
+	}
+	if fromTimestamp > toTimestamp {
+		return 0, 0, fmt.Errorf("%s (%d) must be lower or equal than %s (%d)",
+			fromTimestampParam, fromTimestamp, toTimestampParam, toTimestamp)
+	}
+	return fromTimestamp, toTimestamp, nil
+}
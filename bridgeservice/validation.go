@@ -0,0 +1,233 @@
+package bridgeservice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPageSize is the default number of records to be fetched
+	DefaultPageSize = uint32(20)
+	// MaxPageSize is the maximum number of records to be fetched
+	MaxPageSize = 200
+	// DefaultPage is the default page number to be used when fetching records
+	DefaultPage = uint32(1)
+	// MaxNetworkIDsFilter is the maximum number of network IDs that can be used to filter
+	MaxNetworkIDsFilter = 64
+)
+
+var (
+	// ErrMandatoryParam indicates that a mandatory query parameter is missing
+	ErrMandatoryParam = errors.New("parameter is mandatory")
+	// ErrOutOfRangeParam indicates that a numeric query parameter is out of the allowed bounds
+	ErrOutOfRangeParam = errors.New("value out of range")
+	// ErrInvalidAddressChecksum indicates that a mixed-case address doesn't match its EIP-55 checksum
+	ErrInvalidAddressChecksum = errors.New("address checksum is not valid")
+)
+
+// InvalidParamError is the error returned when a query parameter doesn't pass the validation.
+// The handlers answer it with a http.StatusBadRequest
+type InvalidParamError struct {
+	Param string
+	Err   error
+}
+
+func newInvalidParamError(param string, err error) *InvalidParamError {
+	return &InvalidParamError{Param: param, Err: err}
+}
+
+func (e *InvalidParamError) Error() string {
+	if errors.Is(e.Err, ErrMandatoryParam) {
+		return fmt.Sprintf("%s is mandatory", e.Param)
+	}
+	return fmt.Sprintf("invalid %s parameter: %v", e.Param, e.Err)
+}
+
+func (e *InvalidParamError) Unwrap() error {
+	return e.Err
+}
+
+// validatePaginationParams validates the page number and page size
+func validatePaginationParams(pageNumber, pageSize uint32) error {
+	if pageNumber == 0 {
+		return newInvalidParamError(pageNumberParam, bridgesync.ErrInvalidPageNumber)
+	}
+
+	if pageSize == 0 {
+		return newInvalidParamError(pageSizeParam, bridgesync.ErrInvalidPageSize)
+	}
+
+	if pageSize > MaxPageSize {
+		return newInvalidParamError(pageSizeParam,
+			fmt.Errorf("page size must be less than or equal to %d", MaxPageSize))
+	}
+
+	// the offset of the query must fit in an uint32
+	if uint64(pageNumber-1)*uint64(pageSize) > math.MaxUint32 {
+		return newInvalidParamError(pageNumberParam, ErrOutOfRangeParam)
+	}
+
+	return nil
+}
+
+type UintParam interface {
+	~uint32 | ~uint64
+}
+
+// parseUintQuery parses a uint32 or uint64 query parameter from the request context.
+// If the parameter is mandatory and not present, it returns an error.
+// If the parameter is optional, it returns the default value if not provided.
+// A present but invalid value is always an error.
+func parseUintQuery[T UintParam](c *gin.Context, key string, mandatory bool, defaultVal T) (T, error) {
+	return parseUint(c.Query(key), key, mandatory, defaultVal)
+}
+
+func parseUint[T UintParam](paramStr, key string, mandatory bool, defaultVal T) (T, error) {
+	if paramStr == "" {
+		if mandatory {
+			return 0, newInvalidParamError(key, ErrMandatoryParam)
+		}
+		return defaultVal, nil
+	}
+
+	var result T
+	bitSize := 64
+	if _, ok := any(result).(uint32); ok {
+		bitSize = 32
+	}
+
+	value, err := strconv.ParseUint(paramStr, 10, bitSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, newInvalidParamError(key, ErrOutOfRangeParam)
+		}
+		return 0, newInvalidParamError(key, err)
+	}
+
+	return T(value), nil
+}
+
+// parseUint32SliceParam parses a slice of uint32 parameters from the request context
+func parseUint32SliceParam(c *gin.Context, key string) ([]uint32, error) {
+	return parseUint32Slice(c.QueryArray(key), key)
+}
+
+func parseUint32Slice(vals []string, key string) ([]uint32, error) {
+	if len(vals) > MaxNetworkIDsFilter {
+		return nil, newInvalidParamError(key,
+			fmt.Errorf("too many values (%d), the maximum is %d", len(vals), MaxNetworkIDsFilter))
+	}
+	result := make([]uint32, 0, len(vals))
+	for _, v := range vals {
+		n, err := parseUint(v, key, true, uint32(0))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+// parseAddressQuery parses an optional address query parameter. An empty string is returned
+// if the parameter is not present, otherwise the checksummed address
+func parseAddressQuery(c *gin.Context, key string) (string, error) {
+	return parseAddress(c.Query(key), key)
+}
+
+func parseAddress(addressStr, key string) (string, error) {
+	if addressStr == "" {
+		return "", nil
+	}
+	if !common.IsHexAddress(addressStr) {
+		return "", newInvalidParamError(key, fmt.Errorf("%s is not a hex address", addressStr))
+	}
+	address := common.HexToAddress(addressStr)
+	// an all lower-case or all upper-case address has no checksum information
+	hexPart := strings.TrimPrefix(strings.TrimPrefix(addressStr, "0x"), "0X")
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) &&
+		hexPart != address.Hex()[2:] {
+		return "", newInvalidParamError(key, ErrInvalidAddressChecksum)
+	}
+	return address.Hex(), nil
+}
+
+// parseHash parses a 32-byte hex hash. If it's not mandatory, an empty string returns the zero hash
+func parseHash(hashStr, key string, mandatory bool) (common.Hash, error) {
+	if hashStr == "" {
+		if mandatory {
+			return common.Hash{}, newInvalidParamError(key, ErrMandatoryParam)
+		}
+		return common.Hash{}, nil
+	}
+	b, err := hexutil.Decode(hashStr)
+	if err != nil {
+		return common.Hash{}, newInvalidParamError(key, err)
+	}
+	if len(b) != common.HashLength {
+		return common.Hash{}, newInvalidParamError(key,
+			fmt.Errorf("hash must be %d bytes long, got %d", common.HashLength, len(b)))
+	}
+	return common.BytesToHash(b), nil
+}
+
+// parseBoolQuery parses an optional boolean query parameter
+func parseBoolQuery(c *gin.Context, key string, defaultVal bool) (bool, error) {
+	paramStr := c.Query(key)
+	if paramStr == "" {
+		return defaultVal, nil
+	}
+	value, err := strconv.ParseBool(paramStr)
+	if err != nil {
+		return false, newInvalidParamError(key, err)
+	}
+	return value, nil
+}
+
+// parseEnumQuery parses a query parameter that must be one of the allowed values
+func parseEnumQuery(c *gin.Context, key string, mandatory bool, defaultVal string, allowed ...string) (string, error) {
+	return parseEnum(c.Query(key), key, mandatory, defaultVal, allowed...)
+}
+
+func parseEnum(paramStr, key string, mandatory bool, defaultVal string, allowed ...string) (string, error) {
+	if paramStr == "" {
+		if mandatory {
+			return "", newInvalidParamError(key, ErrMandatoryParam)
+		}
+		return defaultVal, nil
+	}
+	for _, v := range allowed {
+		if paramStr == v {
+			return paramStr, nil
+		}
+	}
+	return "", newInvalidParamError(key,
+		fmt.Errorf("%s is not supported, allowed values: %s", paramStr, strings.Join(allowed, ", ")))
+}
+
+// parseFinalityQuery parses the optional finality filter (empty if not provided)
+func parseFinalityQuery(c *gin.Context) (bridgesync.FinalityStatus, error) {
+	finality, err := parseEnumQuery(c, finalityParam, false, "", string(bridgesync.FinalityPending),
+		string(bridgesync.FinalitySafe), string(bridgesync.FinalityFinalized))
+	return bridgesync.FinalityStatus(finality), err
+}
+
+// validateBlockRange validates that [fromBlock, toBlock] is a valid range of already processed blocks
+func validateBlockRange(fromBlock, toBlock, lastProcessedBlock uint64) error {
+	if fromBlock > toBlock {
+		return newInvalidParamError(fromBlockParam,
+			fmt.Errorf("from_block %d is greater than to_block %d", fromBlock, toBlock))
+	}
+	if toBlock > lastProcessedBlock {
+		return newInvalidParamError(toBlockParam,
+			fmt.Errorf("to_block %d is not processed yet, the last processed block is %d", toBlock, lastProcessedBlock))
+	}
+	return nil
+}
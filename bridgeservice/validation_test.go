@@ -0,0 +1,286 @@
+package bridgeservice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePaginationParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		pageNumber  uint32
+		pageSize    uint32
+		expectedErr error
+	}{
+		{name: "valid", pageNumber: 1, pageSize: MaxPageSize},
+		{name: "page number 0", pageNumber: 0, pageSize: 10, expectedErr: bridgesync.ErrInvalidPageNumber},
+		{name: "page size 0", pageNumber: 1, pageSize: 0, expectedErr: bridgesync.ErrInvalidPageSize},
+		{name: "offset overflow", pageNumber: math.MaxUint32, pageSize: MaxPageSize, expectedErr: ErrOutOfRangeParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePaginationParams(tt.pageNumber, tt.pageSize)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+			var paramErr *InvalidParamError
+			require.ErrorAs(t, err, &paramErr)
+		})
+	}
+
+	err := validatePaginationParams(1, MaxPageSize+1)
+	require.ErrorContains(t, err, fmt.Sprintf("page size must be less than or equal to %d", MaxPageSize))
+}
+
+func TestParseUint(t *testing.T) {
+	_, err := parseUint("", "key", true, uint32(0))
+	require.ErrorIs(t, err, ErrMandatoryParam)
+	require.EqualError(t, err, "key is mandatory")
+
+	v32, err := parseUint("", "key", false, uint32(7))
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), v32)
+
+	_, err = parseUint("abc", "key", false, uint32(7))
+	require.ErrorContains(t, err, "invalid key parameter")
+
+	_, err = parseUint("4294967296", "key", false, uint32(0))
+	require.ErrorIs(t, err, ErrOutOfRangeParam)
+
+	_, err = parseUint("-1", "key", false, uint64(0))
+	require.ErrorContains(t, err, "invalid key parameter")
+
+	v64, err := parseUint("4294967296", "key", true, uint64(0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(4294967296), v64)
+}
+
+func TestParseUint32Slice(t *testing.T) {
+	values, err := parseUint32Slice([]string{"1", "2"}, networkIDsParam)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 2}, values)
+
+	_, err = parseUint32Slice([]string{"1", "x"}, networkIDsParam)
+	require.ErrorContains(t, err, "invalid network_ids parameter")
+
+	tooMany := make([]string, MaxNetworkIDsFilter+1)
+	for i := range tooMany {
+		tooMany[i] = "1"
+	}
+	_, err = parseUint32Slice(tooMany, networkIDsParam)
+	require.ErrorContains(t, err, "too many values")
+}
+
+func TestParseAddress(t *testing.T) {
+	checksummed := "0xE34aaF64b29273B7D567FCFc40544c014EEe9970"
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr string
+	}{
+		{name: "empty", input: "", expected: ""},
+		{name: "checksummed", input: checksummed, expected: checksummed},
+		{name: "lower case", input: strings.ToLower(checksummed), expected: checksummed},
+		{name: "upper case", input: "0x" + strings.ToUpper(checksummed[2:]), expected: checksummed},
+		{name: "without prefix", input: checksummed[2:], expected: checksummed},
+		{name: "wrong checksum", input: "0xe34aaF64b29273B7D567FCFc40544c014EEe9970", expectedErr: "checksum is not valid"},
+		{name: "not hex", input: "0xZZ4aaF64b29273B7D567FCFc40544c014EEe9970", expectedErr: "is not a hex address"},
+		{name: "too short", input: "0x1234", expectedErr: "is not a hex address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := parseAddress(tt.input, fromAddressParam)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, address)
+		})
+	}
+}
+
+func TestHandlersRejectInvalidParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		query         url.Values
+		expectedError string
+	}{
+		{
+			name:          "oversized page size",
+			path:          "/bridges",
+			query:         url.Values{networkIDParam: {"0"}, pageSizeParam: {"100000"}},
+			expectedError: "page size must be less than or equal to",
+		},
+		{
+			name:          "invalid optional page number",
+			path:          "/claims",
+			query:         url.Values{networkIDParam: {"0"}, pageNumberParam: {"abc"}},
+			expectedError: "invalid page_number parameter",
+		},
+		{
+			name:          "wrong address checksum",
+			path:          "/bridges",
+			query:         url.Values{networkIDParam: {"0"}, fromAddressParam: {"0xe34aaF64b29273B7D567FCFc40544c014EEe9970"}},
+			expectedError: "checksum is not valid",
+		},
+		{
+			name:          "invalid address",
+			path:          "/claims",
+			query:         url.Values{networkIDParam: {"0"}, fromAddressParam: {"foo"}},
+			expectedError: "invalid from_address parameter",
+		},
+		{
+			name:          "too many network ids",
+			path:          "/bridges",
+			query:         url.Values{networkIDParam: {"0"}, networkIDsParam: make([]string, MaxNetworkIDsFilter+1)},
+			expectedError: "too many values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bridgeMocks := newBridgeWithMocks(t, 1)
+			w := performRequest(t, bridgeMocks.bridge.router, http.MethodGet,
+				fmt.Sprintf("%s%s?%s", BridgeV1Prefix, tt.path, tt.query.Encode()), nil)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Body.String(), tt.expectedError)
+		})
+	}
+}
+
+func FuzzParseUint(f *testing.F) {
+	for _, seed := range []string{"", "0", "1", "4294967295", "4294967296", "18446744073709551616", "-1", "0x10", " 1"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		v32, err := parseUint(input, "key", false, uint32(0))
+		if err != nil {
+			var paramErr *InvalidParamError
+			require.True(t, errors.As(err, &paramErr))
+		} else if input != "" {
+			require.Equal(t, strings.TrimLeft(input, "0"), strings.TrimLeft(fmt.Sprintf("%d", v32), "0"),
+				"only decimal values are accepted")
+		}
+
+		_, err = parseUint(input, "key", true, uint64(0))
+		if input == "" {
+			require.ErrorIs(t, err, ErrMandatoryParam)
+		}
+	})
+}
+
+func FuzzParseAddress(f *testing.F) {
+	for _, seed := range []string{"", "foo", "0x", "0xE34aaF64b29273B7D567FCFc40544c014EEe9970",
+		"0xe34aaf64b29273b7d567fcfc40544c014eee9970", "E34aaF64b29273B7D567FCFc40544c014EEe9970"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		address, err := parseAddress(input, fromAddressParam)
+		if err != nil {
+			var paramErr *InvalidParamError
+			require.True(t, errors.As(err, &paramErr))
+			return
+		}
+		if input == "" {
+			require.Empty(t, address)
+			return
+		}
+		// the result is always the checksummed version of the input
+		require.Equal(t, common.HexToAddress(input).Hex(), address)
+		require.True(t, strings.EqualFold(strings.TrimPrefix(strings.TrimPrefix(input, "0x"), "0X"), address[2:]))
+	})
+}
+
+func FuzzParseUint32Slice(f *testing.F) {
+	f.Add("1,2,3")
+	f.Add("")
+	f.Add("1,,x")
+	f.Fuzz(func(t *testing.T, input string) {
+		values := strings.Split(input, ",")
+		result, err := parseUint32Slice(values, networkIDsParam)
+		if err != nil {
+			var paramErr *InvalidParamError
+			require.True(t, errors.As(err, &paramErr))
+			return
+		}
+		require.Len(t, result, len(values))
+		require.LessOrEqual(t, len(result), MaxNetworkIDsFilter)
+	})
+}
+
+func TestParseEnum(t *testing.T) {
+	_, err := parseEnum("", exportTypeParam, true, "", exportTypeBridges, exportTypeClaims)
+	require.ErrorIs(t, err, ErrMandatoryParam)
+
+	value, err := parseEnum("", exportFormatParam, false, exportFormatNDJSON, exportFormatNDJSON, exportFormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, exportFormatNDJSON, value)
+
+	value, err = parseEnum(exportTypeClaims, exportTypeParam, true, "", exportTypeBridges, exportTypeClaims)
+	require.NoError(t, err)
+	require.Equal(t, exportTypeClaims, value)
+
+	_, err = parseEnum("Claims", exportTypeParam, true, "", exportTypeBridges, exportTypeClaims)
+	var paramErr *InvalidParamError
+	require.ErrorAs(t, err, &paramErr)
+	require.ErrorContains(t, err, "allowed values: bridges, claims")
+}
+
+func TestValidateBlockRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		fromBlock     uint64
+		toBlock       uint64
+		expectedParam string
+	}{
+		{name: "valid", fromBlock: 1, toBlock: 10},
+		{name: "single block", fromBlock: 10, toBlock: 10},
+		{name: "from greater than to", fromBlock: 11, toBlock: 10, expectedParam: fromBlockParam},
+		{name: "to not processed", fromBlock: 1, toBlock: 101, expectedParam: toBlockParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBlockRange(tt.fromBlock, tt.toBlock, 100)
+			if tt.expectedParam == "" {
+				require.NoError(t, err)
+				return
+			}
+			var paramErr *InvalidParamError
+			require.ErrorAs(t, err, &paramErr)
+			require.Equal(t, tt.expectedParam, paramErr.Param)
+		})
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	_, err := parseHash("", "key", true)
+	require.ErrorIs(t, err, ErrMandatoryParam)
+
+	hash, err := parseHash("", "key", false)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, hash)
+
+	expected := common.HexToHash("0x1234")
+	hash, err = parseHash(expected.Hex(), "key", true)
+	require.NoError(t, err)
+	require.Equal(t, expected, hash)
+
+	_, err = parseHash("0x1234", "key", true)
+	require.ErrorContains(t, err, "hash must be 32 bytes long, got 2")
+
+	_, err = parseHash("1234", "key", true)
+	require.ErrorContains(t, err, "invalid key parameter")
+}
@@ -0,0 +1,193 @@
+package bridgeservice
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/agglayer/aggkit/tree"
+	treetypes "github.com/agglayer/aggkit/tree/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	claimProofCheckMainnetExitRoot      = "mainnet_exit_root"
+	claimProofCheckRollupExitRoot       = "rollup_exit_root"
+	claimProofCheckGlobalExitRoot       = "global_exit_root"
+	claimProofCheckTargetGlobalExitRoot = "target_global_exit_root"
+	claimProofCheckL1InfoTreeLeafHash   = "l1_info_tree_leaf_hash"
+)
+
+// VerifyClaimProofHandler verifies the Merkle paths of a claim proof server-side.
+//
+// @Summary Verify claim proof
+// @Description Verifies the Merkle proofs of a claim (local and rollup exit root) against the exit roots
+// @Description of the L1 info tree leaf and the target global exit root, and returns a verdict with the
+// @Description result of every check. An invalid proof is not an error: the verdict is returned with valid=false.
+// @Tags claims
+// @Accept json
+// @Produce json
+// @Param request body types.VerifyClaimProofRequest true "Claim proof to verify"
+// @Success 200 {object} types.ClaimProofVerdict "Verification verdict"
+// @Failure 400 {object} types.ErrorResponse "Bad Request"
+// @Router /verify-claim-proof [post]
+func (b *BridgeService) VerifyClaimProofHandler(c *gin.Context) {
+	b.logger.Debug("VerifyClaimProof request received")
+
+	cnt, merr := b.meter.Int64Counter("verify_claim_proof")
+	if merr != nil {
+		b.logger.Warnf("failed to create verify_claim_proof counter: %s", merr)
+	}
+	cnt.Add(c, 1)
+
+	var req types.VerifyClaimProofRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		b.logger.Warnf("invalid verify claim proof request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request body: %s", err)})
+		return
+	}
+
+	verdict, err := verifyClaimProof(&req)
+	if err != nil {
+		b.logger.Warnf("invalid verify claim proof request: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, verdict)
+}
+
+// verifyClaimProof computes the exit roots from the leaf and the Merkle paths (as the bridge
+// contract does when claiming) and compares them with the ones of the payload
+func verifyClaimProof(req *types.VerifyClaimProofRequest) (*types.ClaimProofVerdict, error) {
+	globalIndex, ok := new(big.Int).SetString(string(req.GlobalIndex), 0)
+	if !ok {
+		return nil, newInvalidParamError(globalIndexParam,
+			fmt.Errorf("%s is not a valid number", req.GlobalIndex))
+	}
+	mainnetFlag, rollupIndex, depositCount, err := bridgesync.DecodeGlobalIndex(globalIndex)
+	if err != nil {
+		return nil, newInvalidParamError(globalIndexParam, err)
+	}
+	leafHash, err := parseHash(string(req.LeafHash), "leaf_hash", true)
+	if err != nil {
+		return nil, err
+	}
+	targetGER, err := parseHash(string(req.GlobalExitRoot), "global_exit_root", false)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := req.ClaimProof
+	proofLocalExitRoot, err := parseProof(proof.ProofLocalExitRoot, "proof_local_exit_root")
+	if err != nil {
+		return nil, err
+	}
+	l1InfoLeaf, err := parseL1InfoTreeLeaf(proof.L1InfoTreeLeaf)
+	if err != nil {
+		return nil, err
+	}
+
+	verdict := &types.ClaimProofVerdict{
+		Valid:        true,
+		MainnetFlag:  mainnetFlag,
+		RollupIndex:  rollupIndex,
+		DepositCount: depositCount,
+	}
+	addCheck := func(name string, expected, computed common.Hash) {
+		valid := expected == computed
+		verdict.Valid = verdict.Valid && valid
+		verdict.Checks = append(verdict.Checks, types.ClaimProofCheck{
+			Name:     name,
+			Valid:    valid,
+			Expected: types.Hash(expected.Hex()),
+			Computed: types.Hash(computed.Hex()),
+		})
+	}
+
+	computedLeaf := &l1infotreesync.L1InfoTreeLeaf{
+		MainnetExitRoot:   l1InfoLeaf.MainnetExitRoot,
+		RollupExitRoot:    l1InfoLeaf.RollupExitRoot,
+		PreviousBlockHash: l1InfoLeaf.PreviousBlockHash,
+		Timestamp:         l1InfoLeaf.Timestamp,
+	}
+	if mainnetFlag {
+		computedLeaf.MainnetExitRoot = tree.CalculateRoot(leafHash, proofLocalExitRoot, depositCount)
+		addCheck(claimProofCheckMainnetExitRoot, l1InfoLeaf.MainnetExitRoot, computedLeaf.MainnetExitRoot)
+	} else {
+		proofRollupExitRoot, err := parseProof(proof.ProofRollupExitRoot, "proof_rollup_exit_root")
+		if err != nil {
+			return nil, err
+		}
+		localExitRoot := tree.CalculateRoot(leafHash, proofLocalExitRoot, depositCount)
+		computedLeaf.RollupExitRoot = tree.CalculateRoot(localExitRoot, proofRollupExitRoot, rollupIndex)
+		addCheck(claimProofCheckRollupExitRoot, l1InfoLeaf.RollupExitRoot, computedLeaf.RollupExitRoot)
+	}
+
+	computedGER := computedLeaf.GetGlobalExitRoot()
+	if l1InfoLeaf.GlobalExitRoot != (common.Hash{}) {
+		addCheck(claimProofCheckGlobalExitRoot, l1InfoLeaf.GlobalExitRoot, computedGER)
+	}
+	if targetGER != (common.Hash{}) {
+		addCheck(claimProofCheckTargetGlobalExitRoot, targetGER, computedGER)
+	}
+	if l1InfoLeaf.Hash != (common.Hash{}) {
+		addCheck(claimProofCheckL1InfoTreeLeafHash, l1InfoLeaf.Hash, computedLeaf.GetHash())
+	}
+
+	return verdict, nil
+}
+
+func parseProof(proof types.Proof, key string) ([treetypes.DefaultHeight]common.Hash, error) {
+	var result [treetypes.DefaultHeight]common.Hash
+	for i, h := range proof {
+		hash, err := parseHash(string(h), fmt.Sprintf("%s[%d]", key, i), true)
+		if err != nil {
+			return result, err
+		}
+		result[i] = hash
+	}
+	return result, nil
+}
+
+// parsedL1InfoTreeLeaf holds the hashes of an L1InfoTreeLeafResponse (the zero hash if empty)
+type parsedL1InfoTreeLeaf struct {
+	MainnetExitRoot   common.Hash
+	RollupExitRoot    common.Hash
+	GlobalExitRoot    common.Hash
+	PreviousBlockHash common.Hash
+	Hash              common.Hash
+	Timestamp         uint64
+}
+
+func parseL1InfoTreeLeaf(leaf types.L1InfoTreeLeafResponse) (*parsedL1InfoTreeLeaf, error) {
+	var (
+		result parsedL1InfoTreeLeaf
+		err    error
+	)
+	result.Timestamp = leaf.Timestamp
+	if result.MainnetExitRoot, err = parseHash(string(leaf.MainnetExitRoot),
+		"l1_info_tree_leaf.mainnet_exit_root", true); err != nil {
+		return nil, err
+	}
+	if result.RollupExitRoot, err = parseHash(string(leaf.RollupExitRoot),
+		"l1_info_tree_leaf.rollup_exit_root", true); err != nil {
+		return nil, err
+	}
+	if result.GlobalExitRoot, err = parseHash(string(leaf.GlobalExitRoot),
+		"l1_info_tree_leaf.global_exit_root", false); err != nil {
+		return nil, err
+	}
+	if result.PreviousBlockHash, err = parseHash(string(leaf.PreviousBlockHash),
+		"l1_info_tree_leaf.previous_block_hash", false); err != nil {
+		return nil, err
+	}
+	if result.Hash, err = parseHash(string(leaf.Hash), "l1_info_tree_leaf.hash", false); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,117 @@
+package bridgesync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/db"
+	dbtypes "github.com/agglayer/aggkit/db/types"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/russross/meddler"
+)
+
+// exitRootSnapshotTableName is the name of the table that stores the exit root after each deposit (archive mode)
+const exitRootSnapshotTableName = "exit_root_snapshot"
+
+var (
+	// ErrArchiveModeDisabled is returned when the exit root snapshots are requested without the archive mode
+	ErrArchiveModeDisabled = errors.New("archive mode is disabled")
+	// ErrInvalidSnapshotDepositCount is returned when a proof is requested against a root previous to the deposit
+	ErrInvalidSnapshotDepositCount = errors.New("the snapshot can't be previous to the deposit")
+)
+
+// ExitRootSnapshot is the local exit tree right after a deposit was added: the index of its leaf
+// (the deposit count) and the exit root at that moment
+type ExitRootSnapshot struct {
+	DepositCount uint32      `meddler:"deposit_count"`
+	BlockNum     uint64      `meddler:"block_num"`
+	BlockPos     uint64      `meddler:"block_pos"`
+	LeafHash     common.Hash `meddler:"leaf_hash,hash"`
+	ExitRoot     common.Hash `meddler:"exit_root,hash"`
+}
+
+// HistoricalProof is the merkle proof of a deposit against the exit root of a past snapshot
+type HistoricalProof struct {
+	Deposit  *ExitRootSnapshot
+	Snapshot *ExitRootSnapshot
+	Proof    types.Proof
+}
+
+// EnableArchiveMode stores, for each deposit synced from now on, the exit root right after it was added
+// to the local exit tree. It must be called before Start
+func (s *BridgeSync) EnableArchiveMode() {
+	s.processor.archiveMode = true
+	s.processor.log.Info("archive mode enabled: the exit root of each deposit is stored")
+}
+
+// GetExitRootSnapshot returns the snapshot of the exit tree after the deposit, db.ErrNotFound if it
+// was synced before enabling the archive mode (or it's not synced yet)
+func (s *BridgeSync) GetExitRootSnapshot(ctx context.Context, depositCount uint32) (*ExitRootSnapshot, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	if !s.processor.archiveMode {
+		return nil, ErrArchiveModeDisabled
+	}
+	return s.processor.getExitRootSnapshot(s.processor.db, depositCount)
+}
+
+// GetHistoricalProof returns the proof of the deposit against the exit root of the snapshot after
+// the deposit atDepositCount (that must be the same deposit or a later one), as it was built at that
+// moment, to validate old claims
+func (s *BridgeSync) GetHistoricalProof(ctx context.Context,
+	depositCount, atDepositCount uint32) (*HistoricalProof, error) {
+	if atDepositCount < depositCount {
+		return nil, fmt.Errorf("%w: deposit %d, snapshot %d",
+			ErrInvalidSnapshotDepositCount, depositCount, atDepositCount)
+	}
+	deposit, err := s.GetExitRootSnapshot(ctx, depositCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the snapshot of the deposit %d: %w", depositCount, err)
+	}
+	snapshot, err := s.GetExitRootSnapshot(ctx, atDepositCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the snapshot of the deposit %d: %w", atDepositCount, err)
+	}
+	proof, err := s.processor.exitTree.GetProof(ctx, depositCount, snapshot.ExitRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the proof of the deposit %d against the exit root %s: %w",
+			depositCount, snapshot.ExitRoot.Hex(), err)
+	}
+	return &HistoricalProof{Deposit: deposit, Snapshot: snapshot, Proof: proof}, nil
+}
+
+// storeExitRootSnapshot stores the exit root after adding the leaf of the bridge to the exit tree
+func (p *processor) storeExitRootSnapshot(tx dbtypes.Txer, bridge *Bridge) error {
+	root, err := p.exitTree.GetLastRoot(tx)
+	if err != nil {
+		return fmt.Errorf("failed to get the exit root after the deposit %d: %w", bridge.DepositCount, err)
+	}
+	if root.Index != bridge.DepositCount {
+		return fmt.Errorf("the last exit root is of the deposit %d instead of %d", root.Index, bridge.DepositCount)
+	}
+	return meddler.Insert(tx, exitRootSnapshotTableName, &ExitRootSnapshot{
+		DepositCount: bridge.DepositCount,
+		BlockNum:     bridge.BlockNum,
+		BlockPos:     bridge.BlockPos,
+		LeafHash:     bridge.Hash(),
+		ExitRoot:     root.Hash,
+	})
+}
+
+func (p *processor) getExitRootSnapshot(tx dbtypes.Querier, depositCount uint32) (*ExitRootSnapshot, error) {
+	snapshot := &ExitRootSnapshot{}
+	err := meddler.QueryRow(tx, snapshot,
+		fmt.Sprintf(`SELECT * FROM %s WHERE deposit_count = $1;`, exitRootSnapshotTableName), depositCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, db.ErrNotFound
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,67 @@
+package bridgesync
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/bridgesync/migrations"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveMode(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTestArchiveMode.sqlite")
+	require.NoError(t, migrations.RunMigrations(dbPath))
+	p, err := newProcessor(dbPath, db.SQLiteConfig{}, "foo", log.WithFields("bridge-syncer", "foo"))
+	require.NoError(t, err)
+	s := &BridgeSync{processor: p}
+	ctx := context.Background()
+
+	_, err = s.GetExitRootSnapshot(ctx, 0)
+	require.ErrorIs(t, err, ErrArchiveModeDisabled)
+	s.EnableArchiveMode()
+
+	for i := uint32(0); i < 3; i++ {
+		blockNum := uint64(i + 1)
+		require.NoError(t, p.ProcessBlock(ctx, sync.Block{
+			Num: blockNum,
+			Events: []interface{}{
+				Event{Bridge: &Bridge{BlockNum: blockNum, BlockPos: 0, LeafType: leafTypeAsset,
+					OriginAddress: common.HexToAddress("0x01"), DepositCount: i}},
+			},
+		}))
+	}
+
+	for i := uint32(0); i < 3; i++ {
+		snapshot, err := s.GetExitRootSnapshot(ctx, i)
+		require.NoError(t, err)
+		root, err := p.exitTree.GetRootByIndex(ctx, i)
+		require.NoError(t, err)
+		require.Equal(t, root.Hash, snapshot.ExitRoot)
+		require.Equal(t, uint64(i+1), snapshot.BlockNum)
+	}
+
+	t.Run("historical proof", func(t *testing.T) {
+		proof, err := s.GetHistoricalProof(ctx, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, proof.Snapshot.ExitRoot, tree.CalculateRoot(proof.Deposit.LeafHash, proof.Proof, 0))
+
+		_, err = s.GetHistoricalProof(ctx, 2, 1)
+		require.ErrorIs(t, err, ErrInvalidSnapshotDepositCount)
+		_, err = s.GetHistoricalProof(ctx, 0, 3)
+		require.ErrorIs(t, err, db.ErrNotFound)
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		require.NoError(t, p.Reorg(ctx, 3))
+		_, err := s.GetExitRootSnapshot(ctx, 2)
+		require.ErrorIs(t, err, db.ErrNotFound)
+		_, err = s.GetExitRootSnapshot(ctx, 1)
+		require.NoError(t, err)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/fep/etrog/polygonzkevmbridgev2"
 	cfgtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/reorgdetector"
 	"github.com/agglayer/aggkit/test/contracts/transparentupgradableproxy"
 	aggkittypes "github.com/agglayer/aggkit/types"
@@ -117,7 +118,7 @@ func TestBridgeCallData(t *testing.T) {
 	require.NoError(t, err)
 	go reorgDetector.Start(ctx) //nolint:errcheck
 
-	bridgeSync, err := NewL1(ctx, dbPathBridgeSyncL1, bridgeProxyAddr, 1, aggkittypes.LatestBlock, reorgDetector, ethClient,
+	bridgeSync, err := NewL1(ctx, dbPathBridgeSyncL1, db.SQLiteConfig{}, bridgeProxyAddr, 1, aggkittypes.LatestBlock, reorgDetector, ethClient,
 		initialBlock, waitForNewBlocksPeriod, retryPeriod, retriesCount, originNetwork, false, false)
 	require.NoError(t, err)
 	go bridgeSync.Start(ctx)
@@ -174,7 +175,7 @@ func TestBridgeCallData(t *testing.T) {
 
 	// wait for bridge event to get indexed
 	for attempt < maxAttempts {
-		bridgeResponse, totalCount, err := bridgeSync.GetBridgesPaged(ctx, page, pageSize, nil, nil, "")
+		bridgeResponse, totalCount, err := bridgeSync.GetBridgesPaged(ctx, page, pageSize, nil, nil, "", nil)
 		require.NoError(t, err)
 
 		if len(bridgeResponse) > 0 {
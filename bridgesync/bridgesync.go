@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/db/compatibility"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/reorgdetector"
 	"github.com/agglayer/aggkit/sync"
 	tree "github.com/agglayer/aggkit/tree/types"
 	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -44,6 +46,9 @@ var (
 
 	// ErrInvalidPageNumber indicates that the page number is invalid
 	ErrInvalidPageNumber = errors.New("page number must be greater than 0")
+
+	// ErrNetworkIDMismatch indicates that the network ID of the bridge contract is not the configured one
+	ErrNetworkIDMismatch = errors.New("network ID of the bridge contract mismatch")
 )
 
 type ReorgDetector interface {
@@ -56,18 +61,22 @@ type BridgeSync struct {
 	processor  *processor
 	driver     *sync.EVMDriver
 	downloader *sync.EVMDownloader
+	syncerID   BridgeSyncerType
 
 	originNetwork    uint32
 	reorgDetector    ReorgDetector
 	blockFinality    aggkittypes.BlockNumberFinality
 	ethClient        aggkittypes.EthClienter
 	bridgeContractV2 *polygonzkevmbridgev2.Polygonzkevmbridgev2
+	bridgeAddr       common.Address
+	syncFullClaims   bool
 }
 
 // NewL1 creates a bridge syncer that synchronizes the mainnet exit tree
 func NewL1(
 	ctx context.Context,
 	dbPath string,
+	storageTuning db.SQLiteConfig,
 	bridge common.Address,
 	syncBlockChunkSize uint64,
 	blockFinalityType aggkittypes.BlockNumberFinality,
@@ -84,6 +93,7 @@ func NewL1(
 	return newBridgeSync(
 		ctx,
 		dbPath,
+		storageTuning,
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -104,6 +114,7 @@ func NewL1(
 func NewL2(
 	ctx context.Context,
 	dbPath string,
+	storageTuning db.SQLiteConfig,
 	bridge common.Address,
 	syncBlockChunkSize uint64,
 	blockFinalityType aggkittypes.BlockNumberFinality,
@@ -120,6 +131,7 @@ func NewL2(
 	return newBridgeSync(
 		ctx,
 		dbPath,
+		storageTuning,
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -139,6 +151,7 @@ func NewL2(
 func newBridgeSync(
 	ctx context.Context,
 	dbPath string,
+	storageTuning db.SQLiteConfig,
 	bridge common.Address,
 	syncBlockChunkSize uint64,
 	blockFinalityType aggkittypes.BlockNumberFinality,
@@ -166,7 +179,10 @@ func newBridgeSync(
 			bridge.String(), err)
 		return nil, err
 	}
-	processor, err := newProcessor(dbPath, "bridge_sync_"+syncerID.String(), logger)
+	if err := checkNetworkID(logger, bridge, bridgeContractV2, originNetwork); err != nil {
+		return nil, err
+	}
+	processor, err := newProcessor(dbPath, storageTuning, "bridge_sync_"+syncerID.String(), logger)
 	if err != nil {
 		return nil, err
 	}
@@ -262,22 +278,26 @@ func newBridgeSync(
 		processor:        processor,
 		driver:           driver,
 		downloader:       downloader,
+		syncerID:         syncerID,
 		originNetwork:    originNetwork,
 		reorgDetector:    rd,
 		blockFinality:    blockFinalityType,
 		ethClient:        ethClient,
 		bridgeContractV2: bridgeContractV2,
+		bridgeAddr:       bridge,
+		syncFullClaims:   syncFullClaims,
 	}, nil
 }
 
 func (s *BridgeSync) GetClaimsPaged(
 	ctx context.Context,
-	page, pageSize uint32, networkIDs []uint32, fromAddress string) ([]*Claim, int, error) {
+	page, pageSize uint32, networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter) ([]*Claim, int, error) {
 	if s.processor.isHalted() {
 		s.processor.log.Error("processor is halted, cannot get claims")
 		return nil, 0, sync.ErrInconsistentState
 	}
-	return s.processor.GetClaimsPaged(ctx, page, pageSize, networkIDs, fromAddress)
+	return s.processor.GetClaimsPaged(ctx, page, pageSize, networkIDs, fromAddress, blockNumFilter)
 }
 
 // Start starts the synchronization process
@@ -286,14 +306,138 @@ func (s *BridgeSync) Start(ctx context.Context) {
 	s.driver.Sync(ctx)
 }
 
+// EnableSequencerFeed subscribes to the new heads of the sequencer feed (websocket URL), so the
+// new blocks are synced as soon as the sequencer produces them instead of waiting for the next poll.
+// It must be called before Start
+func (s *BridgeSync) EnableSequencerFeed(ctx context.Context, url string, reconnectPeriod time.Duration) error {
+	feed, err := sync.NewHeadFeed(s.syncerID.String(), url, reconnectPeriod)
+	if err != nil {
+		return fmt.Errorf("failed to create sequencer feed: %w", err)
+	}
+	s.downloader.SetNewHeadsNotifier(feed.NewHeads())
+	go feed.Start(ctx)
+	s.processor.log.Infof("sequencer feed enabled: %s", url)
+	return nil
+}
+
+// SetBlockTimestampSource sets how the downloader obtains the timestamp of the synced blocks
+func (s *BridgeSync) SetBlockTimestampSource(source sync.BlockTimestampSource, blockInterval time.Duration) error {
+	if err := s.downloader.SetBlockTimestampSource(source, blockInterval); err != nil {
+		return fmt.Errorf("failed to set the block timestamp source: %w", err)
+	}
+	return nil
+}
+
+// EnableBatchHeaderRequests makes the downloader request the block headers of each range in JSON-RPC
+// batch requests
+func (s *BridgeSync) EnableBatchHeaderRequests() error {
+	if err := s.downloader.EnableBatchHeaderRequests(); err != nil {
+		return fmt.Errorf("failed to enable the batch header requests: %w", err)
+	}
+	return nil
+}
+
+// EnableClientSideTopicFiltering makes the downloader filter the topics of the logs after retrieval
+func (s *BridgeSync) EnableClientSideTopicFiltering() error {
+	if err := s.downloader.EnableClientSideTopicFiltering(); err != nil {
+		return fmt.Errorf("failed to enable the client side topic filtering: %w", err)
+	}
+	return nil
+}
+
+// EnableCrossCheck downloads the blocks also from a second RPC provider and compares their events before
+// they are processed, to detect the data corruption of a single provider. It must be called before Start,
+// after the other settings of the downloader
+func (s *BridgeSync) EnableCrossCheck(ctx context.Context, cfg CrossCheckConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid cross check config: %w", err)
+	}
+	ethClient, err := aggkittypes.DialEthClient(ctx, cfg.URL, cfg.Options())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the cross check RPC %s: %w", cfg.URL, err)
+	}
+	bridgeContractV2, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(s.bridgeAddr, ethClient)
+	if err != nil {
+		return err
+	}
+	appender, err := buildAppender(ethClient, s.bridgeAddr, s.syncFullClaims, bridgeContractV2, s.processor.log)
+	if err != nil {
+		return err
+	}
+	if err := s.downloader.EnableCrossCheck(ethClient, appender, cfg.HaltOnDiscrepancy); err != nil {
+		return fmt.Errorf("failed to enable the cross check: %w", err)
+	}
+	s.processor.log.Infof("cross check enabled: %s", cfg.URL)
+	return nil
+}
+
+// EnablePriceOracle starts tracking the value in USD of the bridges and claims of assets, as returned
+// by the price oracle for the time of the event. The events are priced in the background once synced
+func (s *BridgeSync) EnablePriceOracle(ctx context.Context, cfg PriceOracleConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid price oracle config: %w", err)
+	}
+	oracle := NewHTTPPriceOracle(cfg.URL, cfg.RequestTimeout.Duration)
+	go newUSDValueTracker(s.processor, oracle, cfg).Start(ctx)
+	s.processor.log.Infof("price oracle enabled: %s", cfg.URL)
+	return nil
+}
+
+// EnableClaimsAudit compares periodically, in the background, the claims synced in the finalized blocks
+// with the ClaimEvent logs of the bridge contract, reporting the missing, extra and mismatching claims
+func (s *BridgeSync) EnableClaimsAudit(ctx context.Context, cfg ClaimsAuditConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid claims audit config: %w", err)
+	}
+	getFinalizedBlock := func(ctx context.Context) (uint64, error) {
+		finality, err := s.GetFinalityBlocks(ctx)
+		return finality.Finalized, err
+	}
+	auditor, err := newClaimsAuditor(s.processor, s.ethClient, s.bridgeAddr, s.syncerID.String(),
+		getFinalizedBlock, cfg)
+	if err != nil {
+		return err
+	}
+	go auditor.Start(ctx)
+	s.processor.log.Infof("claims audit enabled (check interval: %s)", cfg.CheckInterval)
+	return nil
+}
+
+// GetUSDValues returns the values in USD of the events of the type (USDValueEventBridge or USDValueEventClaim)
+// in the block range. The events that have not been priced yet are not returned
+func (s *BridgeSync) GetUSDValues(ctx context.Context,
+	eventType string, fromBlock, toBlock uint64) ([]*USDValue, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetUSDValues(ctx, eventType, fromBlock, toBlock)
+}
+
+// GetUSDValueStats returns the totals in USD of the bridges and claims with block timestamp in the range
+func (s *BridgeSync) GetUSDValueStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*USDValueStats, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetUSDValueStats(ctx, fromTimestamp, toTimestamp)
+}
+
+// GetClaimGasStats returns the gas used and the fees of the claims with block timestamp in the range
+func (s *BridgeSync) GetClaimGasStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*ClaimGasStats, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetClaimGasStats(ctx, fromTimestamp, toTimestamp)
+}
+
 func (s *BridgeSync) GetBridgesPaged(
 	ctx context.Context,
 	page, pageSize uint32,
-	depositCount *uint64, networkIDs []uint32, fromAddress string) ([]*Bridge, int, error) {
+	depositCount *uint64, networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter) ([]*Bridge, int, error) {
 	if s.processor.isHalted() {
 		return nil, 0, sync.ErrInconsistentState
 	}
-	return s.processor.GetBridgesPaged(ctx, page, pageSize, depositCount, networkIDs, fromAddress)
+	return s.processor.GetBridgesPaged(ctx, page, pageSize, depositCount, networkIDs, fromAddress, blockNumFilter)
 }
 
 func (s *BridgeSync) GetLastProcessedBlock(ctx context.Context) (uint64, error) {
@@ -318,6 +462,51 @@ func (s *BridgeSync) GetClaims(ctx context.Context, fromBlock, toBlock uint64) (
 	return s.processor.GetClaims(ctx, fromBlock, toBlock)
 }
 
+// GetDuplicatedClaims returns up to limit claims of the global indexes claimed more than once up to
+// toBlock and at least once in the blocks fromBlock-toBlock, and the total number of claims found
+func (s *BridgeSync) GetDuplicatedClaims(ctx context.Context,
+	fromBlock, toBlock uint64, limit uint32) ([]*Claim, int, error) {
+	if s.processor.isHalted() {
+		return nil, 0, sync.ErrInconsistentState
+	}
+	return s.processor.GetDuplicatedClaims(ctx, fromBlock, toBlock, limit)
+}
+
+// GetBridgesByDepositCount returns the bridges whose deposit count is between fromDepositCount and
+// toDepositCount (both included)
+func (s *BridgeSync) GetBridgesByDepositCount(ctx context.Context,
+	fromDepositCount, toDepositCount uint32) ([]*Bridge, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetBridgesByDepositCount(ctx, fromDepositCount, toDepositCount)
+}
+
+// GetBridgesByDepositCounts returns the bridges with the given deposit counts
+func (s *BridgeSync) GetBridgesByDepositCounts(ctx context.Context, depositCounts []uint32) ([]*Bridge, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetBridgesByDepositCounts(ctx, depositCounts)
+}
+
+// GetClaimsByGlobalIndex returns the claims of the given global index
+func (s *BridgeSync) GetClaimsByGlobalIndex(ctx context.Context, globalIndex *big.Int) ([]*Claim, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetClaimsByGlobalIndex(ctx, globalIndex)
+}
+
+// GetBridgesAndClaimsByTxHash returns the bridges and the claims emitted by the given transaction
+func (s *BridgeSync) GetBridgesAndClaimsByTxHash(ctx context.Context,
+	txHash common.Hash) ([]*Bridge, []*Claim, error) {
+	if s.processor.isHalted() {
+		return nil, nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetBridgesAndClaimsByTxHash(ctx, txHash)
+}
+
 func (s *BridgeSync) GetBridges(ctx context.Context, fromBlock, toBlock uint64) ([]Bridge, error) {
 	if s.processor.isHalted() {
 		return nil, sync.ErrInconsistentState
@@ -438,6 +627,22 @@ func sanityCheckContract(logger *log.Logger, bridgeAddr common.Address,
 	return nil
 }
 
+// checkNetworkID checks that the network ID of the bridge contract (networkID()) is the configured
+// origin network, so a misconfigured network ID doesn't end up in certificates for the wrong network
+func checkNetworkID(logger *log.Logger, bridgeAddr common.Address,
+	bridgeContractV2 *polygonzkevmbridgev2.Polygonzkevmbridgev2, originNetwork uint32) error {
+	contractNetworkID, err := bridgeContractV2.NetworkID(nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the network ID of the bridge contract %s: %w", bridgeAddr.String(), err)
+	}
+	if contractNetworkID != originNetwork {
+		return fmt.Errorf("%w: the bridge contract %s has network ID %d, but the configured one is %d",
+			ErrNetworkIDMismatch, bridgeAddr.String(), contractNetworkID, originNetwork)
+	}
+	logger.Infof("network ID of the bridge contract %s: %d", bridgeAddr.String(), contractNetworkID)
+	return nil
+}
+
 // GetContractDepositCount returns the last deposit count from the bridge contract
 func (s *BridgeSync) GetContractDepositCount(ctx context.Context) (uint32, error) {
 	if s.processor.isHalted() {
@@ -451,3 +656,21 @@ func (s *BridgeSync) GetContractDepositCount(ctx context.Context) (uint32, error
 
 	return uint32(depositCount.Int64()), nil
 }
+
+// GetContractLocalExitRoot returns the local exit root (getRoot) and the deposit count of the bridge contract
+// at the given block. The calls to old blocks require an archive node
+func (s *BridgeSync) GetContractLocalExitRoot(ctx context.Context, blockNumber uint64) (common.Hash, uint32, error) {
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	root, err := s.bridgeContractV2.GetRoot(opts)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to get the local exit root of the bridge contract at block %d: %w",
+			blockNumber, err)
+	}
+	depositCount, err := s.bridgeContractV2.DepositCount(opts)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to get the deposit count of the bridge contract at block %d: %w",
+			blockNumber, err)
+	}
+
+	return common.Hash(root), uint32(depositCount.Uint64()), nil
+}
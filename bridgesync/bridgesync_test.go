@@ -1,6 +1,7 @@
 package bridgesync
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,12 +10,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
 	mocksbridgesync "github.com/agglayer/aggkit/bridgesync/mocks"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/reorgdetector"
 	"github.com/agglayer/aggkit/sync"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	mocksethclient "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -38,6 +42,7 @@ func TestNewLx(t *testing.T) {
 	)
 
 	mockEthClient := mocksethclient.NewEthClienter(t)
+	expectBridgeNetworkID(t, mockEthClient, originNetwork)
 	mockEthClient.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(
 		common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002a"), nil).Times(2)
 	mockEthClient.EXPECT().
@@ -56,6 +61,7 @@ func TestNewLx(t *testing.T) {
 	l1BridgeSync, err := NewL1(
 		ctx,
 		dbPath,
+		db.SQLiteConfig{},
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -78,6 +84,7 @@ func TestNewLx(t *testing.T) {
 	l2BridgdeSync, err := NewL2(
 		ctx,
 		dbPath,
+		db.SQLiteConfig{},
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -97,6 +104,12 @@ func TestNewLx(t *testing.T) {
 	require.Equal(t, originNetwork, l2BridgdeSync.OriginNetwork())
 	require.Equal(t, blockFinalityType, l2BridgdeSync.BlockFinality())
 
+	err = l2BridgdeSync.EnableSequencerFeed(ctx, "http://localhost:8545", time.Second)
+	require.ErrorContains(t, err, "must be a websocket URL")
+	feedCtx, cancelFeed := context.WithCancel(ctx)
+	cancelFeed()
+	require.NoError(t, l2BridgdeSync.EnableSequencerFeed(feedCtx, "ws://localhost:8546", time.Second))
+
 	// Fails the sanity check of the contract address
 	mockEthClient = mocksethclient.NewEthClienter(t)
 	mockEthClient.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
@@ -104,6 +117,7 @@ func TestNewLx(t *testing.T) {
 	l2BridgdeSyncErr, err := NewL2(
 		ctx,
 		dbPath,
+		db.SQLiteConfig{},
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -120,6 +134,42 @@ func TestNewLx(t *testing.T) {
 	t.Log(err)
 	require.Error(t, err)
 	require.Nil(t, l2BridgdeSyncErr)
+
+	// The network ID of the bridge contract is not the configured one
+	mockEthClient = mocksethclient.NewEthClienter(t)
+	expectBridgeNetworkID(t, mockEthClient, originNetwork+1)
+	mockEthClient.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(
+		common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002a"), nil).Once()
+	l2BridgdeSyncErr, err = NewL2(
+		ctx,
+		dbPath,
+		db.SQLiteConfig{},
+		bridge,
+		syncBlockChunkSize,
+		blockFinalityType,
+		mockReorgDetector,
+		mockEthClient,
+		initialBlock,
+		waitForNewBlocksPeriod,
+		retryAfterErrorPeriod,
+		maxRetryAttemptsAfterError,
+		originNetwork,
+		false,
+		true,
+	)
+	require.ErrorIs(t, err, ErrNetworkIDMismatch)
+	require.Nil(t, l2BridgdeSyncErr)
+}
+
+// expectBridgeNetworkID mocks the networkID() call of the bridge contract
+func expectBridgeNetworkID(t *testing.T, ethClient *mocksethclient.EthClienter, networkID uint32) {
+	t.Helper()
+	bridgeABI, err := polygonzkevmbridgev2.Polygonzkevmbridgev2MetaData.GetAbi()
+	require.NoError(t, err)
+	selector := bridgeABI.Methods["networkID"].ID
+	ethClient.EXPECT().CallContract(mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return bytes.HasPrefix(msg.Data, selector)
+	}), mock.Anything).Return(common.LeftPadBytes(big.NewInt(int64(networkID)).Bytes(), 32), nil).Maybe()
 }
 
 func TestGetLastProcessedBlock(t *testing.T) {
@@ -193,6 +243,7 @@ func TestBridgeSync_GetTokenMappings(t *testing.T) {
 	)
 
 	mockEthClient := mocksethclient.NewEthClienter(t)
+	expectBridgeNetworkID(t, mockEthClient, originNetwork)
 	mockEthClient.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(
 		common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002a"), nil).Once()
 	mockEthClient.EXPECT().
@@ -212,6 +263,7 @@ func TestBridgeSync_GetTokenMappings(t *testing.T) {
 	s, err := NewL2(
 		ctx,
 		dbPath,
+		db.SQLiteConfig{},
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -325,6 +377,7 @@ func TestBridgeSync_GetLegacyTokenMigrations(t *testing.T) {
 	)
 
 	mockEthClient := mocksethclient.NewEthClienter(t)
+	expectBridgeNetworkID(t, mockEthClient, originNetwork)
 	mockEthClient.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(
 		common.FromHex("0x000000000000000000000000000000000000000000000000000000000000002a"), nil).Once()
 	mockEthClient.EXPECT().
@@ -344,6 +397,7 @@ func TestBridgeSync_GetLegacyTokenMigrations(t *testing.T) {
 	s, err := NewL2(
 		ctx,
 		dbPath,
+		db.SQLiteConfig{},
 		bridge,
 		syncBlockChunkSize,
 		blockFinalityType,
@@ -440,7 +494,7 @@ func TestBridgeSync_GetLegacyTokenMigrations(t *testing.T) {
 
 func TestGetBridgePaged(t *testing.T) {
 	s := BridgeSync{processor: &processor{halted: true}}
-	_, _, err := s.GetBridgesPaged(context.Background(), 0, 0, nil, nil, "")
+	_, _, err := s.GetBridgesPaged(context.Background(), 0, 0, nil, nil, "", nil)
 	require.ErrorIs(t, err, sync.ErrInconsistentState)
 }
 
@@ -449,7 +503,7 @@ func TestGetClaimPaged(t *testing.T) {
 		halted: true,
 		log:    log.WithFields("module", "L2BridgeSyncer"),
 	}}
-	_, _, err := s.GetClaimsPaged(context.Background(), 0, 0, nil, "")
+	_, _, err := s.GetClaimsPaged(context.Background(), 0, 0, nil, "", nil)
 	require.ErrorIs(t, err, sync.ErrInconsistentState)
 }
 
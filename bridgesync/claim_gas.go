@@ -0,0 +1,84 @@
+package bridgesync
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/russross/meddler"
+)
+
+// claimTxGas is the gas of the tx of a claim, as stored with the claim
+type claimTxGas struct {
+	TxHash            common.Hash `meddler:"tx_hash,hash"`
+	GasUsed           *uint64     `meddler:"gas_used"`
+	EffectiveGasPrice *big.Int    `meddler:"effective_gas_price,nullbigint"`
+}
+
+// ClaimGasStats are the gas used and the fees paid by the claim txs of a time range. A tx with several
+// claims is counted once, and the claims synced before the gas was tracked are counted as untracked
+type ClaimGasStats struct {
+	Claims          int
+	UntrackedClaims int
+	Transactions    int
+	TotalGasUsed    uint64
+	// TotalFee is the sum of gas used * effective gas price (wei) of the txs with effective gas price
+	TotalFee *big.Int
+	// AverageGasUsed is the average gas used per tx, 0 if there are no txs
+	AverageGasUsed uint64
+	// AverageEffectiveGasPrice is the average price (wei) weighted by the gas used, nil if there are no fees
+	AverageEffectiveGasPrice *big.Int
+	// AverageFeePerClaim is the average fee (wei) per claim of the txs with effective gas price, nil if
+	// there are no fees
+	AverageFeePerClaim *big.Int
+}
+
+// GetClaimGasStats returns the gas used and the fees of the claims with block timestamp in
+// [fromTimestamp..toTimestamp]
+func (p *processor) GetClaimGasStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*ClaimGasStats, error) {
+	claims := []*claimTxGas{}
+	err := meddler.QueryAll(p.db, &claims, fmt.Sprintf(`
+		SELECT tx_hash, gas_used, effective_gas_price FROM %s
+		WHERE block_timestamp >= $1 AND block_timestamp <= $2;
+	`, claimTableName), fromTimestamp, toTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gas of the claims of timestamps [%d..%d]: %w",
+			fromTimestamp, toTimestamp, err)
+	}
+
+	stats := &ClaimGasStats{Claims: len(claims), TotalFee: big.NewInt(0)}
+	txs := make(map[common.Hash]*claimTxGas)
+	claimsByTx := make(map[common.Hash]int)
+	for _, claim := range claims {
+		if claim.GasUsed == nil {
+			stats.UntrackedClaims++
+			continue
+		}
+		txs[claim.TxHash] = claim
+		claimsByTx[claim.TxHash]++
+	}
+
+	gasWithPrice := uint64(0)
+	claimsWithPrice := 0
+	for txHash, tx := range txs {
+		stats.TotalGasUsed += *tx.GasUsed
+		if tx.EffectiveGasPrice == nil {
+			continue
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(*tx.GasUsed), tx.EffectiveGasPrice)
+		stats.TotalFee.Add(stats.TotalFee, fee)
+		gasWithPrice += *tx.GasUsed
+		claimsWithPrice += claimsByTx[txHash]
+	}
+
+	stats.Transactions = len(txs)
+	if stats.Transactions > 0 {
+		stats.AverageGasUsed = stats.TotalGasUsed / uint64(stats.Transactions)
+	}
+	if gasWithPrice > 0 {
+		stats.AverageEffectiveGasPrice = new(big.Int).Div(stats.TotalFee, new(big.Int).SetUint64(gasWithPrice))
+		stats.AverageFeePerClaim = new(big.Int).Div(stats.TotalFee, big.NewInt(int64(claimsWithPrice)))
+	}
+	return stats, nil
+}
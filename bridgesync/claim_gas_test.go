@@ -0,0 +1,80 @@
+package bridgesync
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/bridgesync/migrations"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClaimGasStats(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTestGetClaimGasStats.sqlite")
+	require.NoError(t, migrations.RunMigrations(dbPath))
+	p, err := newProcessor(dbPath, db.SQLiteConfig{}, "foo", log.WithFields("bridge-syncer", "foo"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	gasUsed := func(gas uint64) *uint64 { return &gas }
+	multiClaimTx := common.HexToHash("0x01")
+	singleClaimTx := common.HexToHash("0x02")
+	noPriceTx := common.HexToHash("0x03")
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{
+		Num: 1,
+		Events: []interface{}{
+			// the claims of the same tx share its gas
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 0, BlockTimestamp: 100, TxHash: multiClaimTx,
+				GlobalIndex: big.NewInt(1), Amount: big.NewInt(1),
+				GasUsed: gasUsed(200_000), EffectiveGasPrice: big.NewInt(10)}},
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 1, BlockTimestamp: 100, TxHash: multiClaimTx,
+				GlobalIndex: big.NewInt(2), Amount: big.NewInt(1),
+				GasUsed: gasUsed(200_000), EffectiveGasPrice: big.NewInt(10)}},
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 2, BlockTimestamp: 100, TxHash: singleClaimTx,
+				GlobalIndex: big.NewInt(3), Amount: big.NewInt(1),
+				GasUsed: gasUsed(100_000), EffectiveGasPrice: big.NewInt(40)}},
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 3, BlockTimestamp: 100, TxHash: noPriceTx,
+				GlobalIndex: big.NewInt(4), Amount: big.NewInt(1), GasUsed: gasUsed(60_000)}},
+			// synced before the gas was tracked
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 4, BlockTimestamp: 100, TxHash: common.HexToHash("0x04"),
+				GlobalIndex: big.NewInt(5), Amount: big.NewInt(1)}},
+		},
+	}))
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{
+		Num: 2,
+		Events: []interface{}{
+			Event{Claim: &Claim{BlockNum: 2, BlockPos: 0, BlockTimestamp: 200, TxHash: common.HexToHash("0x05"),
+				GlobalIndex: big.NewInt(6), Amount: big.NewInt(1),
+				GasUsed: gasUsed(1), EffectiveGasPrice: big.NewInt(1)}},
+		},
+	}))
+
+	claims, err := p.GetClaims(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, claims, 5)
+	require.Equal(t, uint64(200_000), *claims[0].GasUsed)
+	require.Equal(t, big.NewInt(10), claims[0].EffectiveGasPrice)
+	require.Nil(t, claims[4].GasUsed)
+	require.Nil(t, claims[4].EffectiveGasPrice)
+
+	stats, err := p.GetClaimGasStats(ctx, 0, 150)
+	require.NoError(t, err)
+	require.Equal(t, 5, stats.Claims)
+	require.Equal(t, 1, stats.UntrackedClaims)
+	require.Equal(t, 3, stats.Transactions)
+	require.Equal(t, uint64(360_000), stats.TotalGasUsed)
+	// 200000 * 10 + 100000 * 40
+	require.Equal(t, big.NewInt(6_000_000), stats.TotalFee)
+	require.Equal(t, uint64(120_000), stats.AverageGasUsed)
+	require.Equal(t, big.NewInt(20), stats.AverageEffectiveGasPrice)
+	require.Equal(t, big.NewInt(2_000_000), stats.AverageFeePerClaim)
+
+	stats, err = p.GetClaimGasStats(ctx, 300, 400)
+	require.NoError(t, err)
+	require.Equal(t, &ClaimGasStats{TotalFee: big.NewInt(0)}, stats)
+}
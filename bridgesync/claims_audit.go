@@ -0,0 +1,282 @@
+package bridgesync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	mutex "sync"
+	"time"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/fep/etrog/polygonzkevmbridge"
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/prometheus"
+	"github.com/agglayer/aggkit/sync"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	claimsAuditDiscrepancies = "bridge_sync_claims_audit_discrepancies_total"
+	claimsAuditSyncerLabel   = "syncer"
+	claimsAuditKindLabel     = "kind"
+
+	// ClaimsAuditMissing is a ClaimEvent of the bridge contract without a synced claim
+	ClaimsAuditMissing = "missing"
+	// ClaimsAuditExtra is a synced claim without a ClaimEvent of the bridge contract
+	ClaimsAuditExtra = "extra"
+	// ClaimsAuditMismatch is a synced claim whose values differ from the ClaimEvent at the same position
+	ClaimsAuditMismatch = "mismatch"
+)
+
+var registerClaimsAuditMetricsOnce mutex.Once
+
+// ClaimsAuditConfig is the config of the periodic audit of the synced claims against the ClaimEvent
+// logs of the bridge contract
+type ClaimsAuditConfig struct {
+	// Enabled runs the audit
+	Enabled bool `mapstructure:"Enabled"`
+	// CheckInterval is the time waited between the audits of the new finalized blocks
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+	// LookbackBlocks is the number of finalized blocks, before the last one, audited on startup
+	LookbackBlocks uint64 `mapstructure:"LookbackBlocks"`
+	// BlockChunkSize is the max number of blocks of the logs requested at once
+	BlockChunkSize uint64 `mapstructure:"BlockChunkSize"`
+}
+
+// Validate checks the config of the claims audit, if it's enabled
+func (c ClaimsAuditConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.CheckInterval.Duration <= 0 {
+		return errors.New("claims audit CheckInterval must be greater than 0")
+	}
+	if c.BlockChunkSize == 0 {
+		return errors.New("claims audit BlockChunkSize must be greater than 0")
+	}
+	return nil
+}
+
+// registerClaimsAuditMetrics registers the metrics of the claims audit, shared by the bridge syncers
+func registerClaimsAuditMetrics() {
+	registerClaimsAuditMetricsOnce.Do(func() {
+		prometheus.RegisterCounterVecs(prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: claimsAuditDiscrepancies,
+				Help: "[BRIDGESYNC] number of synced claims that differ from the ClaimEvent logs, " +
+					"by kind (missing, extra, mismatch)",
+			},
+			Labels: []string{claimsAuditSyncerLabel, claimsAuditKindLabel},
+		})
+	})
+}
+
+// ClaimsAuditDiscrepancy is a claim that differs between the DB and the ClaimEvent logs
+type ClaimsAuditDiscrepancy struct {
+	// Kind is ClaimsAuditMissing, ClaimsAuditExtra or ClaimsAuditMismatch
+	Kind string
+	sync.Discrepancy
+}
+
+func (d ClaimsAuditDiscrepancy) String() string {
+	return fmt.Sprintf("%s claim in block %d: %s, event %s, synced %s",
+		d.Kind, d.BlockNum, d.Field, d.Computed, d.Stored)
+}
+
+// claimsAuditor compares the claims synced in the finalized blocks with the ClaimEvent logs read again
+// from the bridge contract, so the bugs of the appender or the processor (claims lost, duplicated or
+// decoded wrong) are detected before the claims are used
+type claimsAuditor struct {
+	processor         *processor
+	ethClient         aggkittypes.EthClienter
+	bridgeAddr        common.Address
+	contractV1        *polygonzkevmbridge.Polygonzkevmbridge
+	contractV2        *polygonzkevmbridgev2.Polygonzkevmbridgev2
+	getFinalizedBlock func(ctx context.Context) (uint64, error)
+	syncerID          string
+	cfg               ClaimsAuditConfig
+	log               *log.Logger
+
+	// checkedUpToBlock is the last audited block, 0 before the first audit
+	checkedUpToBlock uint64
+}
+
+func newClaimsAuditor(p *processor, ethClient aggkittypes.EthClienter, bridgeAddr common.Address,
+	syncerID string, getFinalizedBlock func(ctx context.Context) (uint64, error),
+	cfg ClaimsAuditConfig) (*claimsAuditor, error) {
+	contractV1, err := polygonzkevmbridge.NewPolygonzkevmbridge(bridgeAddr, ethClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PolygonZkEVMBridge SC binding (bridge addr: %s): %w", bridgeAddr, err)
+	}
+	contractV2, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(bridgeAddr, ethClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PolygonZkEVMBridgeV2 SC binding (bridge addr: %s): %w", bridgeAddr, err)
+	}
+	registerClaimsAuditMetrics()
+	return &claimsAuditor{
+		processor:         p,
+		ethClient:         ethClient,
+		bridgeAddr:        bridgeAddr,
+		contractV1:        contractV1,
+		contractV2:        contractV2,
+		getFinalizedBlock: getFinalizedBlock,
+		syncerID:          syncerID,
+		cfg:               cfg,
+		log:               p.log,
+	}, nil
+}
+
+// Start audits the new finalized blocks every check interval until the context is done
+func (a *claimsAuditor) Start(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.CheckInterval.Duration)
+	defer ticker.Stop()
+	for {
+		if _, err := a.audit(ctx); err != nil {
+			a.log.Warnf("error auditing the synced claims: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit compares the claims of the finalized blocks processed since the last audit (the last
+// LookbackBlocks on the first one) with the ClaimEvent logs, and reports the discrepancies. Only the
+// finalized blocks are audited, so the pending reorgs are not reported
+func (a *claimsAuditor) audit(ctx context.Context) ([]ClaimsAuditDiscrepancy, error) {
+	if a.processor.isHalted() {
+		return nil, nil
+	}
+	finalized, err := a.getFinalizedBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the finalized block: %w", err)
+	}
+	lastProcessed, err := a.processor.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the last processed block: %w", err)
+	}
+	toBlock := min(finalized, lastProcessed)
+	if toBlock <= a.checkedUpToBlock {
+		return nil, nil
+	}
+	fromBlock := a.checkedUpToBlock + 1
+	if a.checkedUpToBlock == 0 {
+		firstProcessed, err := a.processor.getFirstProcessedBlock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the first processed block: %w", err)
+		}
+		fromBlock = firstProcessed
+		if toBlock >= a.cfg.LookbackBlocks {
+			fromBlock = max(fromBlock, toBlock-a.cfg.LookbackBlocks+1)
+		}
+		if fromBlock > 0 {
+			// the blocks before the lookback are not audited
+			a.checkedUpToBlock = fromBlock - 1
+		}
+	}
+
+	discrepancies := []ClaimsAuditDiscrepancy{}
+	for chunkFromBlock := fromBlock; chunkFromBlock <= toBlock; chunkFromBlock += a.cfg.BlockChunkSize {
+		chunkToBlock := min(chunkFromBlock+a.cfg.BlockChunkSize-1, toBlock)
+		found, err := a.auditBlocks(ctx, chunkFromBlock, chunkToBlock)
+		if err != nil {
+			return discrepancies, err
+		}
+		for _, d := range found {
+			a.log.Errorf("claims audit discrepancy: %s", d)
+			if cv, ok := prometheus.CounterVec(claimsAuditDiscrepancies); ok {
+				cv.WithLabelValues(a.syncerID, d.Kind).Inc()
+			}
+		}
+		discrepancies = append(discrepancies, found...)
+		a.checkedUpToBlock = chunkToBlock
+	}
+	return discrepancies, nil
+}
+
+// auditBlocks compares the claims of the blocks [fromBlock, toBlock] of the DB and the ClaimEvent logs
+func (a *claimsAuditor) auditBlocks(ctx context.Context, fromBlock, toBlock uint64) ([]ClaimsAuditDiscrepancy, error) {
+	logs, err := a.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{a.bridgeAddr},
+		Topics:    [][]common.Hash{{claimEventSignature, claimEventSignaturePreEtrog}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the ClaimEvent logs of blocks %d-%d: %w", fromBlock, toBlock, err)
+	}
+	events := make([]Claim, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed || len(l.Topics) == 0 {
+			continue
+		}
+		claim, err := a.parseClaimEvent(l)
+		if err != nil {
+			return nil, err
+		}
+		if claim != nil {
+			events = append(events, *claim)
+		}
+	}
+	stored, err := a.processor.GetClaims(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the synced claims of blocks %d-%d: %w", fromBlock, toBlock, err)
+	}
+
+	discrepancies := []ClaimsAuditDiscrepancy{}
+	for _, d := range compareClaims(events, stored) {
+		kind := ClaimsAuditMismatch
+		switch {
+		case d.Stored == missingValue:
+			kind = ClaimsAuditMissing
+		case d.Computed == missingValue:
+			kind = ClaimsAuditExtra
+		}
+		discrepancies = append(discrepancies, ClaimsAuditDiscrepancy{Kind: kind, Discrepancy: d})
+	}
+	return discrepancies, nil
+}
+
+// parseClaimEvent decodes the log as the claim built by the claim event handlers. It returns nil if
+// the log is not a ClaimEvent
+func (a *claimsAuditor) parseClaimEvent(l ethtypes.Log) (*Claim, error) {
+	claim := &Claim{BlockNum: l.BlockNumber, BlockPos: uint64(l.Index)}
+	switch l.Topics[0] {
+	case claimEventSignature:
+		event, err := a.contractV2.ParseClaimEvent(l)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing Claim event log %+v: %w", l, err)
+		}
+		claim.GlobalIndex = event.GlobalIndex
+		claim.Amount = event.Amount
+	case claimEventSignaturePreEtrog:
+		event, err := a.contractV1.ParseClaimEvent(l)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing Claim event log %+v: %w", l, err)
+		}
+		claim.GlobalIndex = big.NewInt(int64(event.Index))
+		claim.Amount = event.Amount
+	default:
+		return nil, nil
+	}
+	return claim, nil
+}
+
+// getFirstProcessedBlock returns the first processed block, or 0 if there is none
+func (p *processor) getFirstProcessedBlock(ctx context.Context) (uint64, error) {
+	var firstProcessedBlockNum uint64
+	err := p.db.QueryRowContext(ctx, "SELECT num FROM block ORDER BY num ASC LIMIT 1;").Scan(&firstProcessedBlockNum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return firstProcessedBlockNum, err
+}
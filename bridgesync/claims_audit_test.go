@@ -0,0 +1,168 @@
+package bridgesync
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/fep/etrog/polygonzkevmbridge"
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	"github.com/agglayer/aggkit/bridgesync/migrations"
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	mocksethclient "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimsAuditConfigValidate(t *testing.T) {
+	valid := ClaimsAuditConfig{Enabled: true, CheckInterval: types.NewDuration(time.Minute), BlockChunkSize: 100}
+	tests := []struct {
+		name        string
+		cfg         ClaimsAuditConfig
+		expectedErr string
+	}{
+		{name: "disabled", cfg: ClaimsAuditConfig{}},
+		{name: "valid", cfg: valid},
+		{
+			name:        "no check interval",
+			cfg:         ClaimsAuditConfig{Enabled: true, BlockChunkSize: 100},
+			expectedErr: "CheckInterval must be greater than 0",
+		},
+		{
+			name:        "no block chunk size",
+			cfg:         ClaimsAuditConfig{Enabled: true, CheckInterval: valid.CheckInterval},
+			expectedErr: "BlockChunkSize must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+// claimEventLog builds the ClaimEvent log of the bridge contract (the pre-Etrog one if preEtrog)
+func claimEventLog(t *testing.T, blockNum uint64, index uint, globalIndex, amount *big.Int,
+	preEtrog bool) ethtypes.Log {
+	t.Helper()
+	signature := claimEventSignature
+	metaData := polygonzkevmbridgev2.Polygonzkevmbridgev2MetaData
+	var indexArg any = globalIndex
+	if preEtrog {
+		signature = claimEventSignaturePreEtrog
+		metaData = polygonzkevmbridge.PolygonzkevmbridgeMetaData
+		indexArg = uint32(globalIndex.Uint64())
+	}
+	abi, err := metaData.GetAbi()
+	require.NoError(t, err)
+	event, err := abi.EventByID(signature)
+	require.NoError(t, err)
+	data, err := event.Inputs.Pack(indexArg, uint32(0), common.HexToAddress("0x20"),
+		common.HexToAddress("0x30"), amount)
+	require.NoError(t, err)
+	return ethtypes.Log{BlockNumber: blockNum, Index: index, Topics: []common.Hash{signature}, Data: data}
+}
+
+func TestClaimsAuditorAudit(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "bridgesyncTestClaimsAudit.sqlite")
+	require.NoError(t, migrations.RunMigrations(dbPath))
+	p, err := newProcessor(dbPath, db.SQLiteConfig{}, "foo", log.WithFields("bridge-syncer", "foo"))
+	require.NoError(t, err)
+
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{Num: 1, Events: []interface{}{
+		Event{Claim: &Claim{BlockNum: 1, BlockPos: 0, GlobalIndex: big.NewInt(1), Amount: big.NewInt(10)}},
+		Event{Claim: &Claim{BlockNum: 1, BlockPos: 1, GlobalIndex: big.NewInt(2), Amount: big.NewInt(20)}},
+	}}))
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{Num: 3, Events: []interface{}{
+		Event{Claim: &Claim{BlockNum: 3, BlockPos: 0, GlobalIndex: big.NewInt(3), Amount: big.NewInt(30)}},
+	}}))
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{Num: 5}))
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{Num: 6, Events: []interface{}{
+		Event{Claim: &Claim{BlockNum: 6, BlockPos: 2, GlobalIndex: big.NewInt(6), Amount: big.NewInt(60)}},
+	}}))
+
+	bridgeAddr := common.HexToAddress("0xb7")
+	ethClient := mocksethclient.NewEthClienter(t)
+	finalized := uint64(5)
+	cfg := ClaimsAuditConfig{
+		Enabled: true, CheckInterval: types.NewDuration(time.Minute), LookbackBlocks: 100, BlockChunkSize: 10,
+	}
+	auditor, err := newClaimsAuditor(p, ethClient, bridgeAddr, "foo",
+		func(context.Context) (uint64, error) { return finalized, nil }, cfg)
+	require.NoError(t, err)
+
+	removed := claimEventLog(t, 4, 0, big.NewInt(4), big.NewInt(40), false)
+	removed.Removed = true
+	ethClient.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(5),
+		Addresses: []common.Address{bridgeAddr},
+		Topics:    [][]common.Hash{{claimEventSignature, claimEventSignaturePreEtrog}},
+	}).Return([]ethtypes.Log{
+		claimEventLog(t, 1, 0, big.NewInt(1), big.NewInt(10), false),
+		claimEventLog(t, 1, 1, big.NewInt(2), big.NewInt(21), false),
+		claimEventLog(t, 2, 4, big.NewInt(7), big.NewInt(5), true),
+		removed,
+	}, nil).Once()
+
+	// the claims of the block 6 are not audited until it's finalized
+	discrepancies, err := auditor.audit(ctx)
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 3)
+	require.Equal(t, ClaimsAuditMismatch, discrepancies[0].Kind)
+	require.Equal(t, uint64(1), discrepancies[0].BlockNum)
+	require.Equal(t, "globalIndex:2, amount:21", discrepancies[0].Computed)
+	require.Equal(t, "globalIndex:2, amount:20", discrepancies[0].Stored)
+	require.Equal(t, ClaimsAuditMissing, discrepancies[1].Kind)
+	require.Equal(t, uint64(2), discrepancies[1].BlockNum)
+	require.Equal(t, "globalIndex:7, amount:5", discrepancies[1].Computed)
+	require.Equal(t, ClaimsAuditExtra, discrepancies[2].Kind)
+	require.Equal(t, uint64(3), discrepancies[2].BlockNum)
+	require.Equal(t, uint64(5), auditor.checkedUpToBlock)
+
+	// no new finalized blocks
+	discrepancies, err = auditor.audit(ctx)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	finalized = 7
+	ethClient.EXPECT().FilterLogs(mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+		return q.FromBlock.Uint64() == 6 && q.ToBlock.Uint64() == 6
+	})).Return([]ethtypes.Log{claimEventLog(t, 6, 2, big.NewInt(6), big.NewInt(60), false)}, nil).Once()
+	discrepancies, err = auditor.audit(ctx)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+	require.Equal(t, uint64(6), auditor.checkedUpToBlock)
+
+	t.Run("only the lookback blocks are audited on startup", func(t *testing.T) {
+		cfg := cfg
+		cfg.LookbackBlocks = 2
+		cfg.BlockChunkSize = 1
+		auditor, err := newClaimsAuditor(p, ethClient, bridgeAddr, "foo",
+			func(context.Context) (uint64, error) { return 5, nil }, cfg)
+		require.NoError(t, err)
+		for _, blockNum := range []uint64{4, 5} {
+			ethClient.EXPECT().FilterLogs(mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+				return q.FromBlock.Uint64() == blockNum && q.ToBlock.Uint64() == blockNum
+			})).Return(nil, nil).Once()
+		}
+		discrepancies, err := auditor.audit(ctx)
+		require.NoError(t, err)
+		require.Empty(t, discrepancies)
+		require.Equal(t, uint64(5), auditor.checkedUpToBlock)
+	})
+}
@@ -1,13 +1,30 @@
 package bridgesync
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	ethermanconfig "github.com/agglayer/aggkit/etherman/config"
+	"github.com/agglayer/aggkit/sync"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	// SyncModeRPC polls the RPC every WaitForNewBlocksPeriod to detect new blocks
+	SyncModeRPC = "RPC"
+	// SyncModeSequencerFeed subscribes to the new heads of the sequencer feed to detect new blocks
+	// as soon as they are produced. The blocks and events are still read from the RPC (that is also
+	// polled as a fallback), and the reorg detector reconciles them against the RPC/finalized data
+	SyncModeSequencerFeed = "SequencerFeed"
+)
+
 type Config struct {
 	// DBPath path of the DB
 	DBPath string `mapstructure:"DBPath"`
+	// StorageTuning tunes the sqlite DB (journal mode, cache size, storage quota...)
+	StorageTuning db.SQLiteConfig `mapstructure:"StorageTuning"`
 	// BlockFinality indicates the status of the blocks that will be queried in order to sync
 	BlockFinality string `jsonschema:"enum=LatestBlock, enum=SafeBlock, enum=PendingBlock, enum=FinalizedBlock, enum=EarliestBlock" mapstructure:"BlockFinality"` //nolint:lll
 	// InitialBlockNum is the first block that will be queried when starting the synchronization from scratch.
@@ -27,4 +44,67 @@ type Config struct {
 	// RequireStorageContentCompatibility is true it's mandatory that data stored in the database
 	// is compatible with the running environment
 	RequireStorageContentCompatibility bool `mapstructure:"RequireStorageContentCompatibility"`
+	// SyncMode is the way new blocks are detected (only for the L2 syncer). Empty means RPC
+	SyncMode string `jsonschema:"enum=RPC, enum=SequencerFeed" mapstructure:"SyncMode"`
+	// SequencerFeedURL is the websocket URL (ws:// or wss://) of the sequencer, used on SequencerFeed mode
+	SequencerFeedURL string `mapstructure:"SequencerFeedURL"`
+	// SequencerFeedReconnectPeriod is the time waited before reconnecting to the sequencer feed after an error
+	SequencerFeedReconnectPeriod types.Duration `mapstructure:"SequencerFeedReconnectPeriod"`
+	// PriceOracle is the price oracle used to track the value in USD of the bridges and claims of assets
+	PriceOracle PriceOracleConfig `mapstructure:"PriceOracle"`
+	// ArchiveMode stores, for each deposit, the exit root right after it was added to the local exit tree,
+	// to build the proofs against any past root (e.g. to validate old claims)
+	ArchiveMode bool `mapstructure:"ArchiveMode"`
+	// BlockTimestampSource is how the timestamp of the synced blocks (block_timestamp of the events) is
+	// obtained: the header time or the parent header time plus BlockInterval. Empty means Header
+	BlockTimestampSource string `jsonschema:"enum=Header, enum=ParentTimePlusInterval" mapstructure:"BlockTimestampSource"` //nolint:lll
+	// BlockInterval is the time between blocks of the network, used by the ParentTimePlusInterval source
+	BlockInterval types.Duration `mapstructure:"BlockInterval"`
+	// BatchHeaderRequests requests the block headers of each range (the blocks with events and the last one)
+	// in JSON-RPC batch requests instead of one request per block. The headers are requested one by one if
+	// a batch fails, or always if the RPC client doesn't support batch requests
+	BatchHeaderRequests bool `mapstructure:"BatchHeaderRequests"`
+	// ClientSideTopicFiltering requests all the logs of the bridge contract and filters the topics of the
+	// synced events after retrieval, instead of sending them in the FilterLogs query. It's meant for the
+	// RPC providers with buggy topic filtering
+	ClientSideTopicFiltering bool `mapstructure:"ClientSideTopicFiltering"`
+	// Verify runs the syncer in read-only verification mode, to validate the integrity of the DB
+	Verify sync.VerifierConfig `mapstructure:"Verify"`
+	// CrossCheck compares the synced events with the ones of a second RPC provider before processing them
+	CrossCheck CrossCheckConfig `mapstructure:"CrossCheck"`
+	// ClaimsAudit compares periodically the synced claims with the ClaimEvent logs of the bridge contract
+	ClaimsAudit ClaimsAuditConfig `mapstructure:"ClaimsAudit"`
+}
+
+// CrossCheckConfig is the config of the verification of the downloaded blocks against a second RPC provider
+type CrossCheckConfig struct {
+	// URL is the second RPC provider. Empty disables the cross check
+	URL                                string `mapstructure:"URL"`
+	ethermanconfig.RPCConnectionConfig `mapstructure:",squash"`
+	// HaltOnDiscrepancy doesn't process the blocks whose events differ, they are downloaded again from both
+	// providers until they agree. Otherwise the discrepancies are reported and the events of the RPC are processed
+	HaltOnDiscrepancy bool `mapstructure:"HaltOnDiscrepancy"`
+}
+
+// Validate checks the options of the connection to the second provider
+func (c CrossCheckConfig) Validate() error {
+	if c.URL == "" {
+		return errors.New("the URL of the cross check RPC is required")
+	}
+	return c.Options().Validate()
+}
+
+// ValidateSyncMode checks that the SyncMode is supported and that it has the required fields
+func (c Config) ValidateSyncMode() error {
+	switch c.SyncMode {
+	case "", SyncModeRPC:
+		return nil
+	case SyncModeSequencerFeed:
+		if c.SequencerFeedURL == "" {
+			return fmt.Errorf("SequencerFeedURL is mandatory on %s sync mode", SyncModeSequencerFeed)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported sync mode %s", c.SyncMode)
+	}
 }
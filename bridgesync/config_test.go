@@ -0,0 +1,51 @@
+package bridgesync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidateSyncMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{name: "empty mode", cfg: Config{}},
+		{name: "RPC mode", cfg: Config{SyncMode: SyncModeRPC}},
+		{
+			name: "sequencer feed mode",
+			cfg:  Config{SyncMode: SyncModeSequencerFeed, SequencerFeedURL: "ws://localhost:8546"},
+		},
+		{
+			name:        "sequencer feed mode without URL",
+			cfg:         Config{SyncMode: SyncModeSequencerFeed},
+			expectedErr: "SequencerFeedURL is mandatory",
+		},
+		{
+			name:        "unsupported mode",
+			cfg:         Config{SyncMode: "Polling"},
+			expectedErr: "unsupported sync mode Polling",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateSyncMode()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCrossCheckConfigValidate(t *testing.T) {
+	require.ErrorContains(t, CrossCheckConfig{}.Validate(), "the URL of the cross check RPC is required")
+	cfg := CrossCheckConfig{URL: "http://localhost:8545"}
+	require.NoError(t, cfg.Validate())
+	cfg.BearerToken = "token"
+	cfg.BasicAuthUser = "user"
+	require.ErrorContains(t, cfg.Validate(), "BearerToken and BasicAuth can't be used at the same time")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/agglayer/aggkit/sync"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang-collections/collections/stack"
@@ -51,6 +52,9 @@ const (
 	// debugTraceTxEndpoint is the name of the debug method used to trace a transaction.
 	debugTraceTxEndpoint = "debug_traceTransaction"
 
+	// getTxReceiptEndpoint is the name of the method used to get the receipt of a transaction.
+	getTxReceiptEndpoint = "eth_getTransactionReceipt"
+
 	// callTracerType is the name of the call tracer
 	callTracerType = "callTracer"
 
@@ -170,6 +174,9 @@ func buildClaimEventHandler(contract *polygonzkevmbridgev2.Polygonzkevmbridgev2,
 				return err
 			}
 		}
+		if err := claim.setTxReceipt(client, b, l.TxHash); err != nil {
+			return fmt.Errorf("failed to get the receipt of the claim tx (tx hash: %s): %w", l.TxHash, err)
+		}
 
 		b.Events = append(b.Events, Event{Claim: claim})
 		return nil
@@ -201,6 +208,9 @@ func buildClaimEventHandlerPreEtrog(contract *polygonzkevmbridge.Polygonzkevmbri
 				return err
 			}
 		}
+		if err := claim.setTxReceipt(client, b, l.TxHash); err != nil {
+			return fmt.Errorf("failed to get the receipt of the claim tx (tx hash: %s): %w", l.TxHash, err)
+		}
 
 		b.Events = append(b.Events, Event{Claim: claim})
 		return nil
@@ -434,6 +444,39 @@ func (c *Claim) setClaimCalldata(
 	return err
 }
 
+// claimTxReceipt are the fields of the receipt of a claim transaction stored with the claim
+type claimTxReceipt struct {
+	GasUsed           *hexutil.Uint64 `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+}
+
+// setTxReceipt sets the gas used and the effective gas price of the claim transaction. The claims of the
+// same transaction in the block reuse them instead of requesting the receipt again. They are left unset if
+// the node doesn't return them
+func (c *Claim) setTxReceipt(client aggkittypes.RPCClienter, b *sync.EVMBlock, txHash common.Hash) error {
+	for _, e := range b.Events {
+		if event, ok := e.(Event); ok && event.Claim != nil && event.Claim.TxHash == txHash &&
+			event.Claim.GasUsed != nil {
+			c.GasUsed = event.Claim.GasUsed
+			c.EffectiveGasPrice = event.Claim.EffectiveGasPrice
+			return nil
+		}
+	}
+
+	receipt := &claimTxReceipt{}
+	if err := client.Call(receipt, getTxReceiptEndpoint, txHash); err != nil {
+		return err
+	}
+	if receipt.GasUsed != nil {
+		gasUsed := uint64(*receipt.GasUsed)
+		c.GasUsed = &gasUsed
+	}
+	if receipt.EffectiveGasPrice != nil {
+		c.EffectiveGasPrice = receipt.EffectiveGasPrice.ToInt()
+	}
+	return nil
+}
+
 // tryDecodeClaimCalldata attempts to find and decode the claim calldata from the provided input bytes.
 // It checks if the method ID corresponds to either the claim asset or claim message methods.
 // If a match is found, it decodes the calldata using the ABI of the bridge contract and updates the claim object.
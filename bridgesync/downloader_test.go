@@ -1,6 +1,8 @@
 package bridgesync
 
 import (
+	"encoding/json"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -256,6 +258,10 @@ func TestBuildAppender(t *testing.T) {
 				Call(&tt.callFrame, debugTraceTxEndpoint, mock.Anything, mock.Anything).
 				Return(nil).
 				Maybe()
+			ethClient.EXPECT().
+				Call(mock.Anything, getTxReceiptEndpoint, mock.Anything).
+				Return(nil).
+				Maybe()
 
 			bridgeContractV2, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(bridgeAddr, ethClient)
 			require.NoError(t, err)
@@ -441,3 +447,46 @@ func TestSetClaimCalldata(t *testing.T) {
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestSetClaimTxReceipt(t *testing.T) {
+	txHash := common.HexToHash("0x1234")
+
+	t.Run("sets the gas used and the effective gas price of the receipt", func(t *testing.T) {
+		client := mocks.NewRPCClienter(t)
+		client.EXPECT().Call(mock.Anything, getTxReceiptEndpoint, txHash).Run(func(result any, method string, args ...any) {
+			require.NoError(t, json.Unmarshal([]byte(`{"gasUsed":"0x1d4c0","effectiveGasPrice":"0x3b9aca00"}`), result))
+		}).Return(nil).Once()
+
+		block := &sync.EVMBlock{}
+		claim := &Claim{TxHash: txHash}
+		require.NoError(t, claim.setTxReceipt(client, block, txHash))
+		require.NotNil(t, claim.GasUsed)
+		require.Equal(t, uint64(120000), *claim.GasUsed)
+		require.Equal(t, big.NewInt(1000000000), claim.EffectiveGasPrice)
+
+		// the next claim of the same tx reuses the receipt
+		block.Events = append(block.Events, Event{Claim: claim})
+		nextClaim := &Claim{TxHash: txHash}
+		require.NoError(t, nextClaim.setTxReceipt(client, block, txHash))
+		require.Equal(t, claim.GasUsed, nextClaim.GasUsed)
+		require.Equal(t, claim.EffectiveGasPrice, nextClaim.EffectiveGasPrice)
+	})
+
+	t.Run("receipt without the fields", func(t *testing.T) {
+		client := mocks.NewRPCClienter(t)
+		client.EXPECT().Call(mock.Anything, getTxReceiptEndpoint, txHash).Return(nil).Once()
+
+		claim := &Claim{TxHash: txHash}
+		require.NoError(t, claim.setTxReceipt(client, &sync.EVMBlock{}, txHash))
+		require.Nil(t, claim.GasUsed)
+		require.Nil(t, claim.EffectiveGasPrice)
+	})
+
+	t.Run("rpc error", func(t *testing.T) {
+		client := mocks.NewRPCClienter(t)
+		client.EXPECT().Call(mock.Anything, getTxReceiptEndpoint, txHash).Return(errors.New("rpc error")).Once()
+
+		claim := &Claim{TxHash: txHash}
+		require.ErrorContains(t, claim.setTxReceipt(client, &sync.EVMBlock{}, txHash), "rpc error")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/test/helpers"
+	"github.com/agglayer/aggkit/test/reorgsim"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/agglayer/aggkit/types/mocks"
 	"github.com/ethereum/go-ethereum/common"
@@ -113,6 +114,83 @@ func TestBridgeEventE2E(t *testing.T) {
 	require.Equal(t, expectedBridges, actualBridges)
 }
 
+func TestBridgeEventE2EWithReorgs(t *testing.T) {
+	const blockTime = time.Millisecond * 100
+
+	ctx := context.Background()
+	setup := helpers.NewE2EEnvWithEVML2(t, helpers.DefaultEnvironmentConfig()).L1Environment
+	client := setup.SimBackend.Client()
+
+	bridgeAsset := func(destinationNetwork uint32) reorgsim.Step {
+		return reorgsim.Inject(func(t *testing.T) {
+			t.Helper()
+			_, err := setup.BridgeContract.BridgeAsset(setup.Auth, destinationNetwork,
+				common.HexToAddress("f00"), big.NewInt(0), common.Address{}, true, nil)
+			require.NoError(t, err)
+		})
+	}
+
+	// the syncer must have the bridges of the canonical chain, with the blocks where they are included now
+	requireBridges := func(expected int) reorgsim.Step {
+		return reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+			t.Helper()
+			lastProcessedBlock, err := setup.BridgeSync.GetLastProcessedBlock(ctx)
+			require.NoError(t, err)
+			bridges, err := setup.BridgeSync.GetBridges(ctx, 0, lastProcessedBlock)
+			require.NoError(t, err)
+			require.Len(t, bridges, expected)
+			for i, bridge := range bridges {
+				require.Equal(t, uint32(i), bridge.DepositCount)
+				receipt, err := client.TransactionReceipt(ctx, bridge.TxHash)
+				require.NoError(t, err)
+				require.Equal(t, receipt.BlockNumber.Uint64(), bridge.BlockNum, "deposit count %d", bridge.DepositCount)
+			}
+
+			expectedRoot, err := setup.BridgeContract.GetRoot(nil)
+			require.NoError(t, err)
+			root, err := setup.BridgeSync.GetExitRootByIndex(ctx, uint32(expected-1))
+			require.NoError(t, err)
+			require.Equal(t, common.Hash(expectedRoot), root.Hash)
+		})
+	}
+
+	sim := reorgsim.New(t, setup.SimBackend, reorgsim.WithBlockTime(blockTime))
+	sim.Run(
+		bridgeAsset(1),
+		reorgsim.Commit(1),
+		reorgsim.Mark("firstBridge"),
+		bridgeAsset(2),
+		reorgsim.Commit(1),
+		bridgeAsset(3),
+		reorgsim.Commit(1),
+		reorgsim.Synced(setup.BridgeSync),
+		requireBridges(3),
+
+		// the reorged bridges go back to the pending transactions and are included again after the fork, in
+		// other blocks
+		reorgsim.ForkFrom("firstBridge"),
+		reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+			t.Helper()
+			require.Eventually(t, func() bool {
+				pendingTx, err := client.PendingTransactionCount(ctx)
+				require.NoError(t, err)
+				return pendingTx == 2
+			}, time.Second*5, time.Millisecond*100)
+		}),
+		reorgsim.Commit(4),
+		// wait for the reorg to be detected
+		reorgsim.Wait(time.Second),
+
+		// the blocks without events are only processed once they are finalized, so a new bridge is sent to
+		// have the syncer at the head of the new chain
+		bridgeAsset(4),
+		reorgsim.Commit(1),
+		reorgsim.Synced(setup.BridgeSync),
+		requireBridges(4),
+	)
+	require.Len(t, sim.Forks(), 1)
+}
+
 func getFinalizedBlockNumber(t *testing.T, ctx context.Context, client simulated.Client) uint64 {
 	t.Helper()
 	lastBlockFinalityType, err := aggkittypes.FinalizedBlock.ToBlockNum()
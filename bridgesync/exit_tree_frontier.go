@@ -0,0 +1,157 @@
+package bridgesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree/types"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// bridgeBranchSlot is the storage slot of the _branch array (the frontier of the local exit tree) of the
+	// deposit contract of the bridge, after the storage of ReentrancyGuardUpgradeable
+	bridgeBranchSlot int64 = 51
+	// bridgeDepositCountSlot is the storage slot of the depositCount of the deposit contract of the bridge
+	bridgeDepositCountSlot = bridgeBranchSlot + int64(types.DefaultHeight)
+)
+
+// emptyLocalExitRoot is the root of the local exit tree without deposits
+var emptyLocalExitRoot = common.HexToHash("0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757")
+
+var (
+	// ErrLocalExitRootMismatch is returned when the local exit tree read from the bridge contract doesn't have
+	// the expected root
+	ErrLocalExitRootMismatch = errors.New("local exit root mismatch")
+	// ErrStorageNotEmpty is returned when the syncer can't be started at a block because it already synced
+	ErrStorageNotEmpty = errors.New("the storage of the syncer is not empty")
+)
+
+// InitFromLocalExitRoot starts the syncer of an empty storage after blockNum, instead of its initial block:
+// the bridges until blockNum are not synced, and the local exit tree starts from its frontier read from the
+// storage of the bridge contract at blockNum, that must have the given root. The proofs of the deposits
+// until blockNum can't be generated. It requires the state of blockNum in the RPC (e.g. an archive node)
+// and it must be called before Start
+func (s *BridgeSync) InitFromLocalExitRoot(ctx context.Context, blockNum uint64, localExitRoot common.Hash) error {
+	lastProcessedBlock, err := s.processor.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if lastProcessedBlock >= blockNum {
+		return fmt.Errorf("%w: the last processed block %d is not before the block %d",
+			ErrStorageNotEmpty, lastProcessedBlock, blockNum)
+	}
+	depositCount, frontier, err := readExitTreeFrontier(s.ethClient, s.bridgeAddr, blockNum)
+	if err != nil {
+		return err
+	}
+	// the deposit count of the contract checks the storage layout, the root checks the frontier
+	contractDepositCount, err := s.bridgeContractV2.DepositCount(
+		&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNum)})
+	if err != nil {
+		return fmt.Errorf("error getting the deposit count of the bridge contract at block %d: %w", blockNum, err)
+	}
+	if contractDepositCount.Uint64() != uint64(depositCount) {
+		return fmt.Errorf("the deposit count of the bridge contract at block %d is %d, but %d is stored in the slot %d",
+			blockNum, contractDepositCount.Uint64(), depositCount, bridgeDepositCountSlot)
+	}
+	header, err := s.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNum))
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", blockNum, err)
+	}
+	if err := s.processor.initExitTree(ctx, sync.Block{Num: blockNum, Hash: header.Hash()},
+		depositCount, frontier, localExitRoot); err != nil {
+		return err
+	}
+	s.processor.log.Infof("syncer started at block %d with %d deposits in the local exit tree (root %s)",
+		blockNum, depositCount, localExitRoot.Hex())
+	return nil
+}
+
+// readExitTreeFrontier reads the deposit count and the frontier of the local exit tree of the bridge contract
+// from its storage at blockNum
+func readExitTreeFrontier(client aggkittypes.RPCClienter, bridgeAddr common.Address, blockNum uint64) (
+	uint32, [types.DefaultHeight]common.Hash, error) {
+	var frontier [types.DefaultHeight]common.Hash
+	readSlot := func(slot int64) (common.Hash, error) {
+		var value hexutil.Bytes
+		if err := client.Call(&value, "eth_getStorageAt", bridgeAddr,
+			common.BigToHash(big.NewInt(slot)), hexutil.EncodeUint64(blockNum)); err != nil {
+			return common.Hash{}, fmt.Errorf("error reading the slot %d of the bridge contract at block %d: %w",
+				slot, blockNum, err)
+		}
+		return common.BytesToHash(value), nil
+	}
+	value, err := readSlot(bridgeDepositCountSlot)
+	if err != nil {
+		return 0, frontier, err
+	}
+	if !value.Big().IsUint64() || value.Big().Uint64() > uint64(^uint32(0)) {
+		return 0, frontier, fmt.Errorf("invalid deposit count %s in the bridge contract", value.Hex())
+	}
+	depositCount := uint32(value.Big().Uint64())
+	if depositCount == 0 {
+		return 0, frontier, nil
+	}
+	for h := range frontier {
+		if frontier[h], err = readSlot(bridgeBranchSlot + int64(h)); err != nil {
+			return 0, frontier, err
+		}
+	}
+	return depositCount, frontier, nil
+}
+
+// initExitTree stores the block as the last processed one, with the local exit tree started from the frontier.
+// There must be no bridges synced yet
+func (p *processor) initExitTree(ctx context.Context, block sync.Block, depositCount uint32,
+	frontier [types.DefaultHeight]common.Hash, localExitRoot common.Hash) error {
+	tx, err := db.NewTx(ctx, p.db)
+	if err != nil {
+		return err
+	}
+	shouldRollback := true
+	defer func() {
+		if shouldRollback {
+			p.rollbackTransaction(tx)
+		}
+	}()
+
+	var bridges int
+	if err := tx.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s;", bridgeTableName)).Scan(&bridges); err != nil {
+		return err
+	}
+	if bridges > 0 {
+		return fmt.Errorf("%w: %d bridges synced", ErrStorageNotEmpty, bridges)
+	}
+	if _, err := tx.Exec(`INSERT INTO block (num, hash) VALUES ($1, $2)`, block.Num, block.Hash.String()); err != nil {
+		return fmt.Errorf("failed to insert block %d: %w", block.Num, err)
+	}
+	if depositCount == 0 {
+		if localExitRoot != (common.Hash{}) && localExitRoot != emptyLocalExitRoot {
+			return fmt.Errorf("%w: the local exit tree of the bridge contract at block %d is empty, expected the root %s",
+				ErrLocalExitRootMismatch, block.Num, localExitRoot.Hex())
+		}
+	} else {
+		root, err := p.exitTree.InitFromFrontier(tx, block.Num, depositCount, frontier)
+		if err != nil {
+			return fmt.Errorf("error starting the local exit tree from the frontier: %w", err)
+		}
+		if root != localExitRoot {
+			return fmt.Errorf("%w: the local exit tree of the bridge contract at block %d has the root %s, expected %s",
+				ErrLocalExitRootMismatch, block.Num, root.Hex(), localExitRoot.Hex())
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	shouldRollback = false
+	return nil
+}
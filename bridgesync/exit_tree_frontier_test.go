@@ -0,0 +1,148 @@
+package bridgesync
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/test/contracts/transparentupgradableproxy"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+// storageRPCClient serves eth_getStorageAt from the simulated backend
+type storageRPCClient struct {
+	simulated.Client
+}
+
+func (c storageRPCClient) Call(result any, method string, args ...any) error {
+	blockNum, err := hexutil.DecodeBig(args[2].(string))
+	if err != nil {
+		return err
+	}
+	value, err := c.StorageAt(context.Background(), args[0].(common.Address), args[1].(common.Hash), blockNum)
+	if err != nil {
+		return err
+	}
+	*result.(*hexutil.Bytes) = value
+	return nil
+}
+
+func TestInitFromLocalExitRoot(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	// the admin of the proxy can't call the bridge
+	userKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	userAuth, err := bind.NewKeyedTransactorWithChainID(userKey, big.NewInt(1337))
+	require.NoError(t, err)
+	balance, _ := new(big.Int).SetString("100000000000000000000000", 10)
+	backend := simulated.NewBackend(types.GenesisAlloc{auth.From: {Balance: balance}, userAuth.From: {Balance: balance}})
+	client := storageRPCClient{backend.Client()}
+
+	// deploy the bridge behind a proxy, as in the networks
+	bridgeImplAddr, _, _, err := polygonzkevmbridgev2.DeployPolygonzkevmbridgev2(auth, client)
+	require.NoError(t, err)
+	backend.Commit()
+	bridgeABI, err := polygonzkevmbridgev2.Polygonzkevmbridgev2MetaData.GetAbi()
+	require.NoError(t, err)
+	initCalldata, err := bridgeABI.Pack("initialize", uint32(1), common.Address{}, uint32(0),
+		common.Address{}, common.Address{}, []byte{})
+	require.NoError(t, err)
+	bridgeAddr, _, _, err := transparentupgradableproxy.DeployTransparentupgradableproxy(
+		auth, client, bridgeImplAddr, auth.From, initCalldata)
+	require.NoError(t, err)
+	backend.Commit()
+	bridgeContract, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(bridgeAddr, client)
+	require.NoError(t, err)
+
+	bridgeAsset := func(deposits int) uint64 {
+		for i := 0; i < deposits; i++ {
+			userAuth.Value = big.NewInt(int64(i + 1))
+			_, err := bridgeContract.BridgeAsset(userAuth, 0, userAuth.From, userAuth.Value, common.Address{}, false, nil)
+			require.NoError(t, err)
+			backend.Commit()
+		}
+		blockNum, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		return blockNum
+	}
+	contractRoot := func(blockNum uint64) common.Hash {
+		root, err := bridgeContract.GetRoot(&bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNum)})
+		require.NoError(t, err)
+		return root
+	}
+	newBridgeSync := func() *BridgeSync {
+		p, err := newProcessor(path.Join(t.TempDir(), "bridgesync.sqlite"), db.SQLiteConfig{}, "foo", log.GetDefaultLogger())
+		require.NoError(t, err)
+		return &BridgeSync{processor: p, ethClient: client, bridgeContractV2: bridgeContract, bridgeAddr: bridgeAddr}
+	}
+
+	// without deposits the tree stays empty
+	emptyBlock := bridgeAsset(0)
+	s := newBridgeSync()
+	require.NoError(t, s.InitFromLocalExitRoot(ctx, emptyBlock, emptyLocalExitRoot))
+	lastProcessedBlock, err := s.GetLastProcessedBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, emptyBlock, lastProcessedBlock)
+	require.ErrorIs(t, s.InitFromLocalExitRoot(ctx, emptyBlock, emptyLocalExitRoot), ErrStorageNotEmpty)
+
+	settledBlock := bridgeAsset(5)
+	settledLER := contractRoot(settledBlock)
+
+	s = newBridgeSync()
+	require.ErrorIs(t, s.InitFromLocalExitRoot(ctx, settledBlock, common.HexToHash("0x1")), ErrLocalExitRootMismatch)
+	lastProcessedBlock, err = s.GetLastProcessedBlock(ctx)
+	require.NoError(t, err)
+	require.Zero(t, lastProcessedBlock)
+
+	require.NoError(t, s.InitFromLocalExitRoot(ctx, settledBlock, settledLER))
+	root, err := s.processor.exitTree.GetLastRoot(nil)
+	require.NoError(t, err)
+	require.Equal(t, settledLER, root.Hash)
+	require.Equal(t, uint32(4), root.Index)
+
+	// the bridges synced after the settled block give the roots of the contract
+	lastBlock := bridgeAsset(3)
+	it, err := bridgeContract.FilterBridgeEvent(&bind.FilterOpts{Context: ctx, Start: settledBlock + 1})
+	require.NoError(t, err)
+	for it.Next() {
+		e := it.Event
+		require.NoError(t, s.processor.ProcessBlock(ctx, sync.Block{
+			Num:  e.Raw.BlockNumber,
+			Hash: e.Raw.BlockHash,
+			Events: []any{Event{Bridge: &Bridge{
+				BlockNum:           e.Raw.BlockNumber,
+				BlockPos:           uint64(e.Raw.Index),
+				LeafType:           e.LeafType,
+				OriginNetwork:      e.OriginNetwork,
+				OriginAddress:      e.OriginAddress,
+				DestinationNetwork: e.DestinationNetwork,
+				DestinationAddress: e.DestinationAddress,
+				Amount:             e.Amount,
+				Metadata:           e.Metadata,
+				DepositCount:       e.DepositCount,
+			}}},
+		}))
+		root, err := s.GetExitRootByIndex(ctx, e.DepositCount)
+		require.NoError(t, err)
+		require.Equal(t, contractRoot(e.Raw.BlockNumber), root.Hash)
+	}
+	require.NoError(t, it.Error())
+	lastProcessedBlock, err = s.GetLastProcessedBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, lastBlock, lastProcessedBlock)
+}
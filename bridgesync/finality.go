@@ -0,0 +1,111 @@
+package bridgesync
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+)
+
+// FinalityStatus is the finality of the block that contains a bridge or claim event
+type FinalityStatus string
+
+const (
+	// FinalityPending is an event in a block that is neither safe nor finalized (it can be reorged)
+	FinalityPending FinalityStatus = "pending"
+	// FinalitySafe is an event in a safe block that is not finalized yet
+	FinalitySafe FinalityStatus = "safe"
+	// FinalityFinalized is an event in a finalized block
+	FinalityFinalized FinalityStatus = "finalized"
+)
+
+var (
+	safeBlockBigInt      = big.NewInt(int64(aggkittypes.Safe))
+	finalizedBlockBigInt = big.NewInt(int64(aggkittypes.Finalized))
+)
+
+// BlockNumFilter filters the events by block number. The bounds are inclusive and a nil bound
+// is not applied
+type BlockNumFilter struct {
+	FromBlock *uint64
+	ToBlock   *uint64
+}
+
+// FinalityBlocks are the last safe and finalized blocks of a network
+type FinalityBlocks struct {
+	Safe      uint64
+	Finalized uint64
+}
+
+// Status returns the finality status of the given block
+func (f FinalityBlocks) Status(blockNum uint64) FinalityStatus {
+	switch {
+	case blockNum <= f.Finalized:
+		return FinalityFinalized
+	case blockNum <= f.Safe:
+		return FinalitySafe
+	default:
+		return FinalityPending
+	}
+}
+
+// BlockNumFilter returns the block number filter that selects the events with the given status
+func (f FinalityBlocks) BlockNumFilter(status FinalityStatus) *BlockNumFilter {
+	finalized := f.Finalized
+	safe := max(f.Safe, f.Finalized)
+	switch status {
+	case FinalityFinalized:
+		return &BlockNumFilter{ToBlock: &finalized}
+	case FinalitySafe:
+		from := finalized + 1
+		return &BlockNumFilter{FromBlock: &from, ToBlock: &safe}
+	default:
+		from := safe + 1
+		return &BlockNumFilter{FromBlock: &from}
+	}
+}
+
+// GetFinalityBlocks returns the last safe and finalized blocks of the network. The finalized block
+// is the one tracked by the downloader, if it tracks it, otherwise it's queried to the RPC.
+// The networks that don't support the safe block tag use the finalized block as the safe one
+func (s *BridgeSync) GetFinalityBlocks(ctx context.Context) (FinalityBlocks, error) {
+	var (
+		finalized uint64
+		tracked   bool
+	)
+	if s.downloader != nil {
+		finalized, tracked = s.downloader.LastFinalizedBlock()
+	}
+	if !tracked {
+		header, err := s.ethClient.HeaderByNumber(ctx, finalizedBlockBigInt)
+		if err != nil {
+			return FinalityBlocks{}, fmt.Errorf("failed to get the last finalized block: %w", err)
+		}
+		finalized = header.Number.Uint64()
+	}
+
+	safe := finalized
+	header, err := s.ethClient.HeaderByNumber(ctx, safeBlockBigInt)
+	if err != nil {
+		s.processor.log.Debugf("failed to get the last safe block, using the finalized one (%d): %v", finalized, err)
+	} else {
+		safe = max(header.Number.Uint64(), finalized)
+	}
+
+	return FinalityBlocks{Safe: safe, Finalized: finalized}, nil
+}
+
+// GetSyncTargetBlock returns the last block of the chain with the block finality of the syncer,
+// that is the block up to which it syncs
+func (s *BridgeSync) GetSyncTargetBlock(ctx context.Context) (uint64, error) {
+	blockNum, err := s.blockFinality.ToBlockNum()
+	if err != nil {
+		return 0, err
+	}
+	header, err := s.ethClient.HeaderByNumber(ctx, blockNum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the last %s: %w", s.blockFinality.String(), err)
+	}
+	return header.Number.Uint64(), nil
+}
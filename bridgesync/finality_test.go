@@ -0,0 +1,96 @@
+package bridgesync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/agglayer/aggkit/log"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	mocksethclient "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityBlocks(t *testing.T) {
+	t.Parallel()
+
+	f := FinalityBlocks{Safe: 20, Finalized: 10}
+
+	require.Equal(t, FinalityFinalized, f.Status(0))
+	require.Equal(t, FinalityFinalized, f.Status(10))
+	require.Equal(t, FinalitySafe, f.Status(11))
+	require.Equal(t, FinalitySafe, f.Status(20))
+	require.Equal(t, FinalityPending, f.Status(21))
+
+	u64 := func(v uint64) *uint64 { return &v }
+	require.Equal(t, &BlockNumFilter{ToBlock: u64(10)}, f.BlockNumFilter(FinalityFinalized))
+	require.Equal(t, &BlockNumFilter{FromBlock: u64(11), ToBlock: u64(20)}, f.BlockNumFilter(FinalitySafe))
+	require.Equal(t, &BlockNumFilter{FromBlock: u64(21)}, f.BlockNumFilter(FinalityPending))
+}
+
+func TestGetFinalityBlocks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("safe and finalized blocks", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocksethclient.NewEthClienter(t)
+		ethClient.EXPECT().HeaderByNumber(context.Background(), finalizedBlockBigInt).
+			Return(&types.Header{Number: big.NewInt(10)}, nil).Once()
+		ethClient.EXPECT().HeaderByNumber(context.Background(), safeBlockBigInt).
+			Return(&types.Header{Number: big.NewInt(20)}, nil).Once()
+		s := BridgeSync{ethClient: ethClient, processor: &processor{log: log.WithFields("module", "test")}}
+
+		f, err := s.GetFinalityBlocks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, FinalityBlocks{Safe: 20, Finalized: 10}, f)
+	})
+
+	t.Run("safe block not supported", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocksethclient.NewEthClienter(t)
+		ethClient.EXPECT().HeaderByNumber(context.Background(), finalizedBlockBigInt).
+			Return(&types.Header{Number: big.NewInt(10)}, nil).Once()
+		ethClient.EXPECT().HeaderByNumber(context.Background(), safeBlockBigInt).
+			Return(nil, errors.New("safe block not supported")).Once()
+		s := BridgeSync{ethClient: ethClient, processor: &processor{log: log.WithFields("module", "test")}}
+
+		f, err := s.GetFinalityBlocks(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, FinalityBlocks{Safe: 10, Finalized: 10}, f)
+	})
+
+	t.Run("finalized block error", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocksethclient.NewEthClienter(t)
+		ethClient.EXPECT().HeaderByNumber(context.Background(), finalizedBlockBigInt).
+			Return(nil, errors.New("rpc error")).Once()
+		s := BridgeSync{ethClient: ethClient, processor: &processor{log: log.WithFields("module", "test")}}
+
+		_, err := s.GetFinalityBlocks(context.Background())
+		require.ErrorContains(t, err, "failed to get the last finalized block: rpc error")
+	})
+}
+
+func TestGetSyncTargetBlock(t *testing.T) {
+	t.Parallel()
+
+	latestBlockBigInt := big.NewInt(int64(aggkittypes.Latest))
+	ethClient := mocksethclient.NewEthClienter(t)
+	ethClient.EXPECT().HeaderByNumber(context.Background(), latestBlockBigInt).
+		Return(&types.Header{Number: big.NewInt(30)}, nil).Once()
+	ethClient.EXPECT().HeaderByNumber(context.Background(), latestBlockBigInt).
+		Return(nil, errors.New("rpc error")).Once()
+	s := BridgeSync{ethClient: ethClient, blockFinality: aggkittypes.LatestBlock}
+
+	block, err := s.GetSyncTargetBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), block)
+
+	_, err = s.GetSyncTargetBlock(context.Background())
+	require.ErrorContains(t, err, "failed to get the last LatestBlock: rpc error")
+}
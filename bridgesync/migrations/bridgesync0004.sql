@@ -0,0 +1,6 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_claim_global_index;
+
+-- +migrate Up
+-- the claims are looked up by global index (e.g. GET /claims/{global_index})
+CREATE INDEX IF NOT EXISTS idx_claim_global_index ON claim (global_index);
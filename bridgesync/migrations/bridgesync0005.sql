@@ -0,0 +1,21 @@
+-- +migrate Down
+DROP TABLE IF EXISTS usd_value;
+
+-- +migrate Up
+-- value in USD of the bridges and claims of assets, at the time of the event, as returned by the price oracle.
+-- The rows are added incrementally after the events are synced, and removed with their block on reorgs
+CREATE TABLE usd_value (
+    event_type      VARCHAR NOT NULL, -- bridge or claim
+    block_num       INTEGER NOT NULL REFERENCES block (num) ON DELETE CASCADE,
+    block_pos       INTEGER NOT NULL,
+    block_timestamp INTEGER NOT NULL,
+    origin_network  INTEGER NOT NULL,
+    origin_address  VARCHAR NOT NULL,
+    amount          TEXT NOT NULL,
+    price_usd       TEXT,             -- NULL if the oracle has no price for the token
+    value_usd       TEXT,             -- decimal string, NULL if the oracle has no price for the token
+    priced_at       INTEGER NOT NULL, -- unix time when the price was requested
+    PRIMARY KEY (event_type, block_num, block_pos)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usd_value_block_timestamp ON usd_value (block_timestamp);
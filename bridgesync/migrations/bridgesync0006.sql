@@ -0,0 +1,16 @@
+-- +migrate Down
+DROP TABLE IF EXISTS exit_root_snapshot;
+
+-- +migrate Up
+-- snapshot of the local exit tree after each deposit (archive mode): the index of the leaf and the exit root
+-- right after it was added, so the proofs against any past root can be built without replaying the tree.
+-- The rows are removed with their block on reorgs
+CREATE TABLE exit_root_snapshot (
+    deposit_count   INTEGER PRIMARY KEY, -- index of the leaf in the local exit tree
+    block_num       INTEGER NOT NULL REFERENCES block (num) ON DELETE CASCADE,
+    block_pos       INTEGER NOT NULL,
+    leaf_hash       VARCHAR NOT NULL,
+    exit_root       VARCHAR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_exit_root_snapshot_exit_root ON exit_root_snapshot (exit_root);
@@ -0,0 +1,8 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_bridge_tx_hash;
+DROP INDEX IF EXISTS idx_claim_tx_hash;
+
+-- +migrate Up
+-- the bridges and claims are looked up by transaction hash (e.g. GET /tx/{tx_hash})
+CREATE INDEX IF NOT EXISTS idx_bridge_tx_hash ON bridge (tx_hash);
+CREATE INDEX IF NOT EXISTS idx_claim_tx_hash ON claim (tx_hash);
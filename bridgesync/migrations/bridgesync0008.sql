@@ -0,0 +1,9 @@
+-- +migrate Down
+ALTER TABLE claim DROP COLUMN gas_used;
+ALTER TABLE claim DROP COLUMN effective_gas_price;
+
+-- +migrate Up
+-- gas used and effective gas price (wei) of the receipt of the claim transaction. They are NULL for the claims
+-- synced before they were tracked. The claims of the same transaction share them
+ALTER TABLE claim ADD COLUMN gas_used INTEGER;
+ALTER TABLE claim ADD COLUMN effective_gas_price TEXT;
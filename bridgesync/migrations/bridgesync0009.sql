@@ -0,0 +1,16 @@
+-- +migrate Down
+DROP INDEX IF EXISTS idx_bridge_deposit_count;
+DROP INDEX IF EXISTS idx_bridge_destination_network;
+DROP INDEX IF EXISTS idx_bridge_from_address;
+DROP INDEX IF EXISTS idx_claim_origin_network;
+DROP INDEX IF EXISTS idx_claim_from_address;
+
+-- +migrate Up
+-- the bridges are paginated by deposit count (GET /bridges), optionally filtered by destination network
+-- and from address. The from address is compared in upper case, so its index is on the expression
+CREATE INDEX IF NOT EXISTS idx_bridge_deposit_count ON bridge (deposit_count);
+CREATE INDEX IF NOT EXISTS idx_bridge_destination_network ON bridge (destination_network, deposit_count);
+CREATE INDEX IF NOT EXISTS idx_bridge_from_address ON bridge (UPPER(from_address), deposit_count);
+-- the claims are paginated by block (GET /claims), optionally filtered by origin network and from address
+CREATE INDEX IF NOT EXISTS idx_claim_origin_network ON claim (origin_network, block_num, block_pos);
+CREATE INDEX IF NOT EXISTS idx_claim_from_address ON claim (UPPER(from_address), block_num, block_pos);
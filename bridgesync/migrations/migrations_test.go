@@ -260,3 +260,147 @@ func TestMigrations0003(t *testing.T) {
 	require.Equal(t, common.HexToAddress("0x7"), legacyTokenMigration.UpdatedTokenAddress)
 	require.Equal(t, big.NewInt(1000), legacyTokenMigration.Amount)
 }
+
+func TestMigrations0004(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0004.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var indexName string
+	err = db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'claim'
+		AND name = 'idx_claim_global_index'`).Scan(&indexName)
+	require.NoError(t, err)
+	require.Equal(t, "idx_claim_global_index", indexName)
+}
+
+func TestMigrations0005(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0005.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO block (num, hash) VALUES (1, '0x01');
+		INSERT INTO usd_value (event_type, block_num, block_pos, block_timestamp, origin_network,
+			origin_address, amount, price_usd, value_usd, priced_at)
+		VALUES ('bridge', 1, 0, 1700000000, 0, '0x0000000000000000000000000000000000000000',
+			'1000000000000000000', '2500.5', '2500.500000', 1700000100);
+		INSERT INTO usd_value (event_type, block_num, block_pos, block_timestamp, origin_network,
+			origin_address, amount, priced_at)
+		VALUES ('claim', 1, 1, 1700000000, 1, '0x0000000000000000000000000000000000000001', '1', 1700000100);
+	`)
+	require.NoError(t, err)
+
+	// the values are removed with their block on reorgs
+	_, err = db.Exec(`DELETE FROM block WHERE num = 1`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM usd_value`).Scan(&count))
+	require.Equal(t, 0, count)
+}
+
+func TestMigrations0006(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0006.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO block (num, hash) VALUES (1, '0x01');
+		INSERT INTO exit_root_snapshot (deposit_count, block_num, block_pos, leaf_hash, exit_root)
+		VALUES (0, 1, 0, '0x0a', '0x0b');
+		INSERT INTO exit_root_snapshot (deposit_count, block_num, block_pos, leaf_hash, exit_root)
+		VALUES (1, 1, 1, '0x0c', '0x0d');
+	`)
+	require.NoError(t, err)
+
+	// the snapshots are removed with their block on reorgs
+	_, err = db.Exec(`DELETE FROM block WHERE num = 1`)
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM exit_root_snapshot`).Scan(&count))
+	require.Equal(t, 0, count)
+}
+
+func TestMigrations0007(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0007.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for table, index := range map[string]string{"bridge": "idx_bridge_tx_hash", "claim": "idx_claim_tx_hash"} {
+		var indexName string
+		err = db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = $1
+			AND name = $2`, table, index).Scan(&indexName)
+		require.NoError(t, err)
+		require.Equal(t, index, indexName)
+	}
+}
+
+func TestMigrations0008(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0008.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO block (num, hash) VALUES (1, '0x01');
+		INSERT INTO claim (block_num, block_pos, global_index, origin_network, origin_address, destination_address,
+			amount, proof_local_exit_root, proof_rollup_exit_root, mainnet_exit_root, rollup_exit_root,
+			global_exit_root, destination_network, metadata, is_message, gas_used, effective_gas_price)
+		VALUES (1, 0, 0, 0, '0x0000', '0x0000', 0, '', '', '0x000', '0x000', '0x0', 0, NULL, FALSE,
+			120000, '1000000000');
+		INSERT INTO claim (block_num, block_pos, global_index, origin_network, origin_address, destination_address,
+			amount, proof_local_exit_root, proof_rollup_exit_root, mainnet_exit_root, rollup_exit_root,
+			global_exit_root, destination_network, metadata, is_message)
+		VALUES (1, 1, 1, 0, '0x0000', '0x0000', 0, '', '', '0x000', '0x000', '0x0', 0, NULL, FALSE);
+	`)
+	require.NoError(t, err)
+
+	// the gas of the claims synced before the migration is unknown
+	var untracked int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM claim
+		WHERE gas_used IS NULL AND effective_gas_price IS NULL`).Scan(&untracked))
+	require.Equal(t, 1, untracked)
+}
+
+func TestMigrations0009(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTest0009.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	indexes := map[string]string{
+		"idx_bridge_deposit_count":       "bridge",
+		"idx_bridge_destination_network": "bridge",
+		"idx_bridge_from_address":        "bridge",
+		"idx_claim_origin_network":       "claim",
+		"idx_claim_from_address":         "claim",
+	}
+	for index, table := range indexes {
+		var indexName string
+		err = db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = $1
+			AND name = $2`, table, index).Scan(&indexName)
+		require.NoError(t, err)
+		require.Equal(t, index, indexName)
+	}
+}
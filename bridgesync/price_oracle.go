@@ -0,0 +1,151 @@
+package bridgesync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// maxPriceOracleResponseSize is the max size of the body of a price oracle response
+	maxPriceOracleResponseSize = 1 << 16
+)
+
+// ErrPriceNotFound is returned by the price oracle when it has no price for the token at the given time
+var ErrPriceNotFound = errors.New("price not found")
+
+// PriceOracleConfig is the configuration of the price oracle used to annotate the bridges and claims
+// of assets with their value in USD at the time of the event
+type PriceOracleConfig struct {
+	// URL is the endpoint of the price oracle. Empty means the USD values are not tracked
+	URL string `mapstructure:"URL"`
+	// RequestTimeout is the timeout of each request to the price oracle
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
+	// UpdateInterval is the time waited between the runs that price the new bridges and claims
+	UpdateInterval types.Duration `mapstructure:"UpdateInterval"`
+	// BatchSize is the max number of bridges and claims priced on each run
+	BatchSize uint32 `mapstructure:"BatchSize"`
+}
+
+// Validate checks the config of the price oracle, if it's enabled
+func (c PriceOracleConfig) Validate() error {
+	if c.URL == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.URL); err != nil {
+		return fmt.Errorf("invalid price oracle URL %s: %w", c.URL, err)
+	}
+	if c.UpdateInterval.Duration <= 0 {
+		return errors.New("price oracle UpdateInterval must be greater than 0")
+	}
+	if c.BatchSize == 0 {
+		return errors.New("price oracle BatchSize must be greater than 0")
+	}
+	return nil
+}
+
+// TokenPrice is the price in USD of a whole token (not of its smallest unit)
+type TokenPrice struct {
+	// PriceUSD is the price as a decimal string (e.g. "2500.75")
+	PriceUSD string
+	// Decimals are the decimals of the token, used to convert the amount of the event
+	Decimals uint8
+}
+
+// ValueUSD returns the value in USD of the amount (in the smallest unit of the token) as a decimal string
+func (p TokenPrice) ValueUSD(amount *big.Int) (string, error) {
+	price, ok := new(big.Rat).SetString(p.PriceUSD)
+	if !ok {
+		return "", fmt.Errorf("invalid price %q", p.PriceUSD)
+	}
+	value := new(big.Rat).Mul(new(big.Rat).SetInt(amount), price)
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Decimals)), nil) //nolint:mnd
+	value.Quo(value, new(big.Rat).SetInt(unit))
+	return value.FloatString(usdValueDecimals), nil
+}
+
+// PriceOracle returns the price in USD of the tokens
+type PriceOracle interface {
+	// GetPriceUSD returns the price of the token of the origin network at the given unix time.
+	// It returns ErrPriceNotFound if the oracle has no price for it
+	GetPriceUSD(ctx context.Context, originNetwork uint32, token common.Address, timestamp uint64) (*TokenPrice, error)
+}
+
+// HTTPPriceOracle is a PriceOracle that queries an HTTP endpoint:
+// GET <URL>?network_id=<origin network>&token=<origin address>&timestamp=<unix time>
+// that answers {"price_usd": "2500.75", "decimals": 18}, or 404 if it has no price for the token
+type HTTPPriceOracle struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPriceOracle creates a price oracle for the endpoint url
+func NewHTTPPriceOracle(url string, requestTimeout time.Duration) *HTTPPriceOracle {
+	return &HTTPPriceOracle{
+		url:    url,
+		client: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+type priceOracleResponse struct {
+	PriceUSD string `json:"price_usd"`
+	Decimals *uint8 `json:"decimals"`
+}
+
+// GetPriceUSD queries the price of the token to the endpoint
+func (o *HTTPPriceOracle) GetPriceUSD(ctx context.Context,
+	originNetwork uint32, token common.Address, timestamp uint64) (*TokenPrice, error) {
+	reqURL, err := url.Parse(o.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price oracle URL %s: %w", o.url, err)
+	}
+	query := reqURL.Query()
+	query.Set("network_id", strconv.FormatUint(uint64(originNetwork), 10))
+	query.Set("token", token.Hex())
+	query.Set("timestamp", strconv.FormatUint(timestamp, 10))
+	reqURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create price oracle request: %w", err)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request the price of token %s (network %d): %w", token.Hex(), originNetwork, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPriceOracleResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the price oracle response: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrPriceNotFound
+	default:
+		return nil, fmt.Errorf("price oracle returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result priceOracleResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode the price oracle response: %w", err)
+	}
+	if result.Decimals == nil {
+		return nil, errors.New("the price oracle response has no decimals")
+	}
+	if _, ok := new(big.Rat).SetString(result.PriceUSD); !ok {
+		return nil, fmt.Errorf("the price oracle returned an invalid price %q", result.PriceUSD)
+	}
+	return &TokenPrice{PriceUSD: result.PriceUSD, Decimals: *result.Decimals}, nil
+}
@@ -0,0 +1,118 @@
+package bridgesync
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceOracleConfig_Validate(t *testing.T) {
+	valid := PriceOracleConfig{
+		URL:            "http://localhost:8080/price",
+		UpdateInterval: types.NewDuration(time.Minute),
+		BatchSize:      100,
+	}
+	tests := []struct {
+		name        string
+		cfg         PriceOracleConfig
+		expectedErr string
+	}{
+		{name: "disabled", cfg: PriceOracleConfig{}},
+		{name: "valid", cfg: valid},
+		{
+			name:        "invalid URL",
+			cfg:         PriceOracleConfig{URL: "localhost", UpdateInterval: valid.UpdateInterval, BatchSize: 1},
+			expectedErr: "invalid price oracle URL",
+		},
+		{
+			name:        "no update interval",
+			cfg:         PriceOracleConfig{URL: valid.URL, BatchSize: 1},
+			expectedErr: "UpdateInterval must be greater than 0",
+		},
+		{
+			name:        "no batch size",
+			cfg:         PriceOracleConfig{URL: valid.URL, UpdateInterval: valid.UpdateInterval},
+			expectedErr: "BatchSize must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestTokenPrice_ValueUSD(t *testing.T) {
+	ether, ok := new(big.Int).SetString("1500000000000000000", 10)
+	require.True(t, ok)
+
+	value, err := TokenPrice{PriceUSD: "2500.5", Decimals: 18}.ValueUSD(ether)
+	require.NoError(t, err)
+	require.Equal(t, "3750.750000", value)
+
+	value, err = TokenPrice{PriceUSD: "0.999", Decimals: 6}.ValueUSD(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, "0.000001", value)
+
+	_, err = TokenPrice{PriceUSD: "abc", Decimals: 18}.ValueUSD(ether)
+	require.ErrorContains(t, err, `invalid price "abc"`)
+}
+
+func TestHTTPPriceOracle_GetPriceUSD(t *testing.T) {
+	token := common.HexToAddress("0x1234")
+	found := common.HexToAddress("0x01")
+	notFound := common.HexToAddress("0x02")
+	invalid := common.HexToAddress("0x03")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.Equal(t, "key", query.Get("api_key"), "the query of the URL must be kept")
+		switch common.HexToAddress(query.Get("token")) {
+		case token:
+			require.Equal(t, "1", query.Get("network_id"))
+			require.Equal(t, "1700000000", query.Get("timestamp"))
+			_, _ = w.Write([]byte(`{"price_usd":"2500.5","decimals":18}`))
+		case notFound:
+			w.WriteHeader(http.StatusNotFound)
+		case invalid:
+			_, _ = w.Write([]byte(`{"price_usd":"2500.5"}`))
+		case found:
+			_, _ = w.Write([]byte(`{"price_usd":"1","decimals":6}`))
+		default:
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	oracle := NewHTTPPriceOracle(server.URL+"/price?api_key=key", time.Second)
+
+	price, err := oracle.GetPriceUSD(ctx, 1, token, 1700000000)
+	require.NoError(t, err)
+	require.Equal(t, &TokenPrice{PriceUSD: "2500.5", Decimals: 18}, price)
+
+	price, err = oracle.GetPriceUSD(ctx, 0, found, 1)
+	require.NoError(t, err)
+	require.Equal(t, &TokenPrice{PriceUSD: "1", Decimals: 6}, price)
+
+	_, err = oracle.GetPriceUSD(ctx, 0, notFound, 1)
+	require.ErrorIs(t, err, ErrPriceNotFound)
+
+	_, err = oracle.GetPriceUSD(ctx, 0, invalid, 1)
+	require.ErrorContains(t, err, "has no decimals")
+
+	_, err = oracle.GetPriceUSD(ctx, 0, common.HexToAddress("0x04"), 1)
+	require.ErrorContains(t, err, "price oracle returned status 500")
+}
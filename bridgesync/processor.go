@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -125,6 +126,10 @@ type Claim struct {
 	Metadata            []byte         `meddler:"metadata"`
 	IsMessage           bool           `meddler:"is_message"`
 	BlockTimestamp      uint64         `meddler:"block_timestamp"`
+	// GasUsed and EffectiveGasPrice (wei) are the ones of the receipt of the claim tx, shared by all its
+	// claims. They are nil if they are unknown (e.g. the claim was synced before they were tracked)
+	GasUsed           *uint64  `meddler:"gas_used"`
+	EffectiveGasPrice *big.Int `meddler:"effective_gas_price,nullbigint"`
 }
 
 // decodeEtrogCalldata decodes claim calldata for Etrog fork
@@ -350,15 +355,20 @@ type processor struct {
 	mu           mutex.RWMutex
 	halted       bool
 	haltedReason string
+	// archiveMode stores the exit root after each deposit (see EnableArchiveMode)
+	archiveMode bool
 	compatibility.CompatibilityDataStorager[BridgeSyncRuntimeData]
 }
 
-func newProcessor(dbPath string, name string, logger *log.Logger) (*processor, error) {
+func newProcessor(dbPath string, dbCfg db.SQLiteConfig, name string, logger *log.Logger) (*processor, error) {
+	if err := dbCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage tuning: %w", err)
+	}
 	err := migrations.RunMigrations(dbPath)
 	if err != nil {
 		return nil, err
 	}
-	database, err := db.NewSQLiteDB(dbPath)
+	database, err := db.NewSQLiteDBWithConfig(dbPath, dbCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -431,8 +441,147 @@ func (p *processor) GetClaims(ctx context.Context, fromBlock, toBlock uint64) ([
 	return claims, nil
 }
 
+// GetBridgesByDepositCount returns the bridges whose deposit count is between fromDepositCount and
+// toDepositCount (both included), sorted by deposit count
+func (p *processor) GetBridgesByDepositCount(ctx context.Context,
+	fromDepositCount, toDepositCount uint32) ([]*Bridge, error) {
+	tx, err := p.startTransaction(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer p.rollbackTransaction(tx)
+
+	bridges := []*Bridge{}
+	if err := meddler.QueryAll(tx, &bridges, fmt.Sprintf(`
+		SELECT * FROM %s WHERE deposit_count >= $1 AND deposit_count <= $2 ORDER BY deposit_count ASC;
+	`, bridgeTableName), fromDepositCount, toDepositCount); err != nil {
+		return nil, err
+	}
+	return bridges, nil
+}
+
+// GetBridgesByDepositCounts returns the bridges with the given deposit counts, sorted by deposit count.
+// The deposit counts are joined as a json array, so a batch is answered by a single query
+func (p *processor) GetBridgesByDepositCounts(ctx context.Context, depositCounts []uint32) ([]*Bridge, error) {
+	bridges := []*Bridge{}
+	if len(depositCounts) == 0 {
+		return bridges, nil
+	}
+	depositCountsJSON, err := json.Marshal(depositCounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the deposit counts: %w", err)
+	}
+	tx, err := p.startTransaction(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer p.rollbackTransaction(tx)
+
+	if err := meddler.QueryAll(tx, &bridges, fmt.Sprintf(`
+		SELECT DISTINCT b.* FROM %s AS b JOIN json_each($1) AS d ON b.deposit_count = d.value
+		ORDER BY b.deposit_count ASC;
+	`, bridgeTableName), string(depositCountsJSON)); err != nil {
+		return nil, err
+	}
+	return bridges, nil
+}
+
+// GetClaimsByGlobalIndex returns the claims of the given global index, sorted by position. There is
+// at most one claim per global index, unless the claim is duplicated (see GetDuplicatedClaims)
+func (p *processor) GetClaimsByGlobalIndex(ctx context.Context, globalIndex *big.Int) ([]*Claim, error) {
+	tx, err := p.startTransaction(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	defer p.rollbackTransaction(tx)
+
+	claims := []*Claim{}
+	if err := meddler.QueryAll(tx, &claims, fmt.Sprintf(`
+		SELECT * FROM %s WHERE global_index = $1 ORDER BY block_num ASC, block_pos ASC;
+	`, claimTableName), globalIndex.String()); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GetBridgesAndClaimsByTxHash returns the bridges and the claims emitted by the given transaction,
+// sorted by position
+func (p *processor) GetBridgesAndClaimsByTxHash(ctx context.Context,
+	txHash common.Hash) ([]*Bridge, []*Claim, error) {
+	tx, err := p.startTransaction(ctx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.rollbackTransaction(tx)
+
+	bridges := []*Bridge{}
+	if err := meddler.QueryAll(tx, &bridges, fmt.Sprintf(`
+		SELECT * FROM %s WHERE tx_hash = $1 ORDER BY block_num ASC, block_pos ASC;
+	`, bridgeTableName), txHash.Hex()); err != nil {
+		return nil, nil, err
+	}
+	claims := []*Claim{}
+	if err := meddler.QueryAll(tx, &claims, fmt.Sprintf(`
+		SELECT * FROM %s WHERE tx_hash = $1 ORDER BY block_num ASC, block_pos ASC;
+	`, claimTableName), txHash.Hex()); err != nil {
+		return nil, nil, err
+	}
+	return bridges, claims, nil
+}
+
+// GetDuplicatedClaims returns up to limit claims of the global indexes claimed more than once up
+// to toBlock and at least once in the blocks fromBlock-toBlock, sorted by position, and the total
+// number of claims found. Only the claims up to toBlock are counted, so the blocks after toBlock
+// don't change the result. The bridge contract doesn't allow to claim twice the same global index,
+// so any result is an anomaly of the contract or the syncer
+func (p *processor) GetDuplicatedClaims(ctx context.Context,
+	fromBlock, toBlock uint64, limit uint32) ([]*Claim, int, error) {
+	tx, err := p.startTransaction(ctx, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer p.rollbackTransaction(tx)
+
+	// the parameters are bound in the order of their first appearance
+	duplicatedClause := fmt.Sprintf(`
+		FROM %[1]s
+		WHERE global_index IN (
+			SELECT global_index FROM %[1]s
+			WHERE global_index IN (
+				SELECT global_index FROM %[1]s WHERE block_num >= $1 AND block_num <= $2
+			) AND block_num <= $2
+			GROUP BY global_index HAVING COUNT(*) > 1
+		) AND block_num <= $2`, claimTableName)
+
+	count := 0
+	if err := tx.QueryRow("SELECT COUNT(*) "+duplicatedClause+";", fromBlock, toBlock).Scan(&count); err != nil {
+		return nil, 0, err
+	}
+	if count == 0 || limit == 0 {
+		return []*Claim{}, count, nil
+	}
+
+	rows, err := tx.Query("SELECT * "+duplicatedClause+" ORDER BY block_num ASC, block_pos ASC LIMIT $3;",
+		fromBlock, toBlock, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			p.log.Warnf("error closing rows: %v", err)
+		}
+	}()
+
+	claims := []*Claim{}
+	if err = meddler.ScanAll(rows, &claims); err != nil {
+		return nil, 0, err
+	}
+	return claims, count, nil
+}
+
 func (p *processor) GetBridgesPaged(
 	ctx context.Context, pageNumber, pageSize uint32, depositCount *uint64, networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter,
 ) ([]*Bridge, int, error) {
 	tx, err := p.startTransaction(ctx, true)
 	if err != nil {
@@ -440,7 +589,7 @@ func (p *processor) GetBridgesPaged(
 	}
 	defer p.rollbackTransaction(tx)
 
-	whereClause := p.buildBridgesFilterClause(depositCount, networkIDs, fromAddress)
+	whereClause := p.buildBridgesFilterClause(depositCount, networkIDs, fromAddress, blockNumFilter)
 	orderByClause := "deposit_count DESC"
 	bridgesCount, err := p.GetTotalNumberOfRecords(bridgeTableName, whereClause)
 	if err != nil {
@@ -480,9 +629,10 @@ func (p *processor) GetBridgesPaged(
 }
 
 // buildBridgesFilterClause builds the WHERE clause for the bridges table
-// based on the provided depositCount and networkIDs
-func (p *processor) buildBridgesFilterClause(depositCount *uint64, networkIDs []uint32, fromAddress string) string {
-	const clauseCapacity = 3
+// based on the provided depositCount, networkIDs, fromAddress and block number filter
+func (p *processor) buildBridgesFilterClause(depositCount *uint64, networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter) string {
+	const clauseCapacity = 5
 	clauses := make([]string, 0, clauseCapacity)
 	if depositCount != nil {
 		clauses = append(clauses, fmt.Sprintf("deposit_count = %d", *depositCount))
@@ -493,9 +643,11 @@ func (p *processor) buildBridgesFilterClause(depositCount *uint64, networkIDs []
 	}
 
 	if fromAddress != "" && common.IsHexAddress(fromAddress) {
-		clauses = append(clauses, fmt.Sprintf("UPPER(from_address) LIKE '%s'", fromAddress))
+		clauses = append(clauses, buildFromAddressFilter(fromAddress))
 	}
 
+	clauses = append(clauses, buildBlockNumFilter(blockNumFilter)...)
+
 	if len(clauses) > 0 {
 		return " WHERE " + strings.Join(clauses, " AND ")
 	}
@@ -504,6 +656,7 @@ func (p *processor) buildBridgesFilterClause(depositCount *uint64, networkIDs []
 
 func (p *processor) GetClaimsPaged(
 	ctx context.Context, pageNumber, pageSize uint32, networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter,
 ) ([]*Claim, int, error) {
 	tx, err := p.startTransaction(ctx, true)
 	if err != nil {
@@ -511,7 +664,7 @@ func (p *processor) GetClaimsPaged(
 	}
 	defer p.rollbackTransaction(tx)
 
-	whereClause := p.buildClaimsFilterClause(networkIDs, fromAddress)
+	whereClause := p.buildClaimsFilterClause(networkIDs, fromAddress, blockNumFilter)
 	claimsCount, err := p.GetTotalNumberOfRecords(claimTableName, whereClause)
 	if err != nil {
 		return nil, 0, err
@@ -552,18 +705,21 @@ func (p *processor) GetClaimsPaged(
 }
 
 // buildClaimsFilterClause builds the WHERE clause for the claims table
-// based on the provided networkIDs and fromAddress
-func (p *processor) buildClaimsFilterClause(networkIDs []uint32, fromAddress string) string {
-	const clauseCapacity = 2
+// based on the provided networkIDs, fromAddress and block number filter
+func (p *processor) buildClaimsFilterClause(networkIDs []uint32, fromAddress string,
+	blockNumFilter *BlockNumFilter) string {
+	const clauseCapacity = 4
 	clauses := make([]string, 0, clauseCapacity)
 	if len(networkIDs) > 0 {
 		clauses = append(clauses, buildNetworkIDsFilter(networkIDs, "origin_network"))
 	}
 
 	if fromAddress != "" && common.IsHexAddress(fromAddress) {
-		clauses = append(clauses, fmt.Sprintf("UPPER(from_address) LIKE '%s'", fromAddress))
+		clauses = append(clauses, buildFromAddressFilter(fromAddress))
 	}
 
+	clauses = append(clauses, buildBlockNumFilter(blockNumFilter)...)
+
 	if len(clauses) > 0 {
 		return " WHERE " + strings.Join(clauses, " AND ")
 	}
@@ -634,13 +790,7 @@ func (p *processor) queryPaged(tx dbtypes.Querier,
 	offset, pageSize uint32,
 	table, orderByClause, whereClause string,
 ) (*sql.Rows, error) {
-	rows, err := tx.Query(fmt.Sprintf(`
-		SELECT *
-		FROM %s
-		%s
-		ORDER BY %s
-		LIMIT $1 OFFSET $2;
-	`, table, whereClause, orderByClause), pageSize, offset)
+	rows, err := tx.Query(pagedQuerySQL(table, whereClause, orderByClause), pageSize, offset)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, db.ErrNotFound
@@ -769,6 +919,12 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 				p.log.Errorf("failed to insert bridge event at block %d: %v", block.Num, err)
 				return err
 			}
+			if p.archiveMode {
+				if err = p.storeExitRootSnapshot(tx, event.Bridge); err != nil {
+					p.log.Errorf("failed to store the exit root snapshot at block %d: %v", block.Num, err)
+					return err
+				}
+			}
 		}
 
 		if event.Claim != nil {
@@ -818,7 +974,7 @@ func (p *processor) GetTotalNumberOfRecords(tableName, whereClause string) (int,
 	}
 
 	count := 0
-	err := p.db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) AS count FROM %s%s;`, tableName, whereClause)).Scan(&count)
+	err := p.db.QueryRow(countQuerySQL(tableName, whereClause)).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -896,6 +1052,44 @@ func buildNetworkIDsFilter(networkIDs []uint32, networkIDColumn string) string {
 	return fmt.Sprintf("%s IN (%s)", networkIDColumn, strings.Join(placeholders, ", "))
 }
 
+// buildFromAddressFilter builds the SQL filter for the from_address column. The addresses are compared
+// in upper case, as indexed by idx_bridge_from_address and idx_claim_from_address
+func buildFromAddressFilter(fromAddress string) string {
+	return fmt.Sprintf("UPPER(from_address) = '%s'", strings.ToUpper(fromAddress))
+}
+
+// pagedQuerySQL returns the query of a page of the table, with the limit and the offset as parameters
+func pagedQuerySQL(table, whereClause, orderByClause string) string {
+	return fmt.Sprintf(`
+		SELECT *
+		FROM %s
+		%s
+		ORDER BY %s
+		LIMIT $1 OFFSET $2;
+	`, table, whereClause, orderByClause)
+}
+
+// countQuerySQL returns the query of the number of records of the table
+func countQuerySQL(table, whereClause string) string {
+	return fmt.Sprintf(`SELECT COUNT(*) AS count FROM %s%s;`, table, whereClause)
+}
+
+// buildBlockNumFilter builds the SQL filters for the block_num column
+func buildBlockNumFilter(blockNumFilter *BlockNumFilter) []string {
+	if blockNumFilter == nil {
+		return nil
+	}
+
+	var clauses []string
+	if blockNumFilter.FromBlock != nil {
+		clauses = append(clauses, fmt.Sprintf("block_num >= %d", *blockNumFilter.FromBlock))
+	}
+	if blockNumFilter.ToBlock != nil {
+		clauses = append(clauses, fmt.Sprintf("block_num <= %d", *blockNumFilter.ToBlock))
+	}
+	return clauses
+}
+
 func GenerateGlobalIndex(mainnetFlag bool, rollupIndex uint32, localExitRootIndex uint32) *big.Int {
 	var (
 		globalIndexBytes []byte
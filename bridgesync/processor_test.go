@@ -2,6 +2,7 @@ package bridgesync
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"path"
 	"slices"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/fep/etrog/polygonzkevmbridge"
@@ -84,7 +86,7 @@ func TestBigIntString(t *testing.T) {
 func TestProcessor(t *testing.T) {
 	path := path.Join(t.TempDir(), "bridgeSyncerProcessor.db")
 	logger := log.WithFields("module", "bridge-syncer")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 	actions := []processAction{
 		// processed: ~
@@ -807,7 +809,7 @@ func TestInsertAndGetClaim(t *testing.T) {
 	err := migrations.RunMigrations(path)
 	require.NoError(t, err)
 	logger := log.WithFields("bridge-syncer", "foo")
-	p, err := newProcessor(path, "foo", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
 	require.NoError(t, err)
 
 	tx, err := p.db.BeginTx(context.Background(), nil)
@@ -845,6 +847,224 @@ func TestInsertAndGetClaim(t *testing.T) {
 	require.Equal(t, testClaim, &claims[0])
 }
 
+func TestGetDuplicatedClaims(t *testing.T) {
+	path := path.Join(t.TempDir(), "bridgesyncTestGetDuplicatedClaims.sqlite")
+	require.NoError(t, migrations.RunMigrations(path))
+	logger := log.WithFields("bridge-syncer", "foo")
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	newClaim := func(blockNum, blockPos uint64, globalIndex *big.Int) *Claim {
+		return &Claim{
+			BlockNum:    blockNum,
+			BlockPos:    blockPos,
+			GlobalIndex: globalIndex,
+			Amount:      big.NewInt(1),
+		}
+	}
+	duplicatedFirst := newClaim(1, 0, GenerateGlobalIndex(true, 0, 7))
+	unique := newClaim(1, 1, GenerateGlobalIndex(true, 0, 8))
+	duplicatedSecond := newClaim(2, 0, GenerateGlobalIndex(true, 0, 7))
+	duplicatedLater := newClaim(3, 0, GenerateGlobalIndex(true, 0, 8))
+
+	claims, total, err := p.GetDuplicatedClaims(context.Background(), 1, 3, 10)
+	require.NoError(t, err)
+	require.Empty(t, claims)
+	require.Zero(t, total)
+
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	for _, blockNum := range []uint64{1, 2, 3} {
+		_, err = tx.Exec(`INSERT INTO block (num, hash) VALUES ($1, $2)`, blockNum, fmt.Sprintf("0x%x", blockNum))
+		require.NoError(t, err)
+	}
+	for _, claim := range []*Claim{duplicatedFirst, unique, duplicatedSecond, duplicatedLater} {
+		require.NoError(t, meddler.Insert(tx, "claim", claim))
+	}
+	require.NoError(t, tx.Commit())
+
+	testCases := []struct {
+		name           string
+		fromBlock      uint64
+		toBlock        uint64
+		limit          uint32
+		expectedClaims []*Claim
+		expectedTotal  int
+	}{
+		{
+			name:           "the claims after toBlock are not counted",
+			fromBlock:      1,
+			toBlock:        1,
+			limit:          10,
+			expectedClaims: []*Claim{},
+		},
+		{
+			name:           "the claims before fromBlock of a global index claimed in the range",
+			fromBlock:      2,
+			toBlock:        2,
+			limit:          10,
+			expectedClaims: []*Claim{duplicatedFirst, duplicatedSecond},
+			expectedTotal:  2,
+		},
+		{
+			name:           "all the blocks",
+			fromBlock:      1,
+			toBlock:        3,
+			limit:          10,
+			expectedClaims: []*Claim{duplicatedFirst, unique, duplicatedSecond, duplicatedLater},
+			expectedTotal:  4,
+		},
+		{
+			name:           "limited",
+			fromBlock:      1,
+			toBlock:        3,
+			limit:          1,
+			expectedClaims: []*Claim{duplicatedFirst},
+			expectedTotal:  4,
+		},
+		{
+			name:           "only counted",
+			fromBlock:      3,
+			toBlock:        3,
+			limit:          0,
+			expectedClaims: []*Claim{},
+			expectedTotal:  2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, total, err := p.GetDuplicatedClaims(context.Background(), tc.fromBlock, tc.toBlock, tc.limit)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedClaims, claims)
+			require.Equal(t, tc.expectedTotal, total)
+		})
+	}
+}
+
+func TestGetBridgesByDepositCount(t *testing.T) {
+	path := path.Join(t.TempDir(), "bridgesyncTestGetBridgesByDepositCount.sqlite")
+	require.NoError(t, migrations.RunMigrations(path))
+	logger := log.WithFields("bridge-syncer", "foo")
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO block (num, hash) VALUES ($1, $2)`, 1, "0x1")
+	require.NoError(t, err)
+	bridges := make([]*Bridge, 0, 4)
+	for depositCount := uint32(0); depositCount < 4; depositCount++ {
+		bridge := &Bridge{BlockNum: 1, BlockPos: uint64(depositCount), DepositCount: depositCount, Amount: big.NewInt(1)}
+		require.NoError(t, meddler.Insert(tx, "bridge", bridge))
+		bridges = append(bridges, bridge)
+	}
+	require.NoError(t, tx.Commit())
+
+	result, err := p.GetBridgesByDepositCount(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, bridges[1:3], result)
+	result, err = p.GetBridgesByDepositCount(context.Background(), 4, 10)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestGetBridgesByDepositCounts(t *testing.T) {
+	path := path.Join(t.TempDir(), "bridgesyncTestGetBridgesByDepositCounts.sqlite")
+	require.NoError(t, migrations.RunMigrations(path))
+	logger := log.WithFields("bridge-syncer", "foo")
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO block (num, hash) VALUES ($1, $2)`, 1, "0x1")
+	require.NoError(t, err)
+	bridges := make([]*Bridge, 0, 4)
+	for depositCount := uint32(0); depositCount < 4; depositCount++ {
+		bridge := &Bridge{BlockNum: 1, BlockPos: uint64(depositCount), DepositCount: depositCount, Amount: big.NewInt(1)}
+		require.NoError(t, meddler.Insert(tx, "bridge", bridge))
+		bridges = append(bridges, bridge)
+	}
+	require.NoError(t, tx.Commit())
+
+	// the repeated and the missing deposit counts are ignored
+	result, err := p.GetBridgesByDepositCounts(context.Background(), []uint32{3, 1, 7, 1})
+	require.NoError(t, err)
+	require.Equal(t, []*Bridge{bridges[1], bridges[3]}, result)
+	result, err = p.GetBridgesByDepositCounts(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestGetClaimsByGlobalIndex(t *testing.T) {
+	path := path.Join(t.TempDir(), "bridgesyncTestGetClaimsByGlobalIndex.sqlite")
+	require.NoError(t, migrations.RunMigrations(path))
+	logger := log.WithFields("bridge-syncer", "foo")
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	globalIndex := GenerateGlobalIndex(false, 1, 7)
+	first := &Claim{BlockNum: 1, BlockPos: 0, GlobalIndex: globalIndex, Amount: big.NewInt(1)}
+	other := &Claim{BlockNum: 1, BlockPos: 1, GlobalIndex: GenerateGlobalIndex(false, 1, 8), Amount: big.NewInt(1)}
+	second := &Claim{BlockNum: 2, BlockPos: 0, GlobalIndex: globalIndex, Amount: big.NewInt(1)}
+
+	claims, err := p.GetClaimsByGlobalIndex(context.Background(), globalIndex)
+	require.NoError(t, err)
+	require.Empty(t, claims)
+
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	for _, blockNum := range []uint64{1, 2} {
+		_, err = tx.Exec(`INSERT INTO block (num, hash) VALUES ($1, $2)`, blockNum, fmt.Sprintf("0x%x", blockNum))
+		require.NoError(t, err)
+	}
+	for _, claim := range []*Claim{second, other, first} {
+		require.NoError(t, meddler.Insert(tx, "claim", claim))
+	}
+	require.NoError(t, tx.Commit())
+
+	claims, err = p.GetClaimsByGlobalIndex(context.Background(), globalIndex)
+	require.NoError(t, err)
+	require.Equal(t, []*Claim{first, second}, claims)
+}
+
+func TestGetBridgesAndClaimsByTxHash(t *testing.T) {
+	path := path.Join(t.TempDir(), "bridgesyncTestGetBridgesAndClaimsByTxHash.sqlite")
+	require.NoError(t, migrations.RunMigrations(path))
+	logger := log.WithFields("bridge-syncer", "foo")
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	txHash := common.HexToHash("0xabc")
+	bridge := &Bridge{BlockNum: 1, BlockPos: 1, TxHash: txHash, Amount: big.NewInt(1), DepositCount: 0}
+	otherBridge := &Bridge{BlockNum: 1, BlockPos: 2, TxHash: common.HexToHash("0xdef"), Amount: big.NewInt(1),
+		DepositCount: 1}
+	firstClaim := &Claim{BlockNum: 1, BlockPos: 0, TxHash: txHash, GlobalIndex: big.NewInt(1), Amount: big.NewInt(1)}
+	secondClaim := &Claim{BlockNum: 1, BlockPos: 3, TxHash: txHash, GlobalIndex: big.NewInt(2), Amount: big.NewInt(1)}
+
+	bridges, claims, err := p.GetBridgesAndClaimsByTxHash(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Empty(t, bridges)
+	require.Empty(t, claims)
+
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO block (num, hash) VALUES (1, '0x1')`)
+	require.NoError(t, err)
+	for _, b := range []*Bridge{otherBridge, bridge} {
+		require.NoError(t, meddler.Insert(tx, "bridge", b))
+	}
+	for _, claim := range []*Claim{secondClaim, firstClaim} {
+		require.NoError(t, meddler.Insert(tx, "claim", claim))
+	}
+	require.NoError(t, tx.Commit())
+
+	bridges, claims, err = p.GetBridgesAndClaimsByTxHash(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Equal(t, []*Bridge{bridge}, bridges)
+	require.Equal(t, []*Claim{firstClaim, secondClaim}, claims)
+}
+
 func TestGetBridgesPublished(t *testing.T) {
 	t.Parallel()
 
@@ -892,7 +1112,7 @@ func TestGetBridgesPublished(t *testing.T) {
 			path := path.Join(t.TempDir(), fmt.Sprintf("bridgesyncTestGetBridgesPublished_%s.sqlite", tc.name))
 			require.NoError(t, migrations.RunMigrations(path))
 			logger := log.WithFields("bridge-syncer", "foo")
-			p, err := newProcessor(path, "foo", logger)
+			p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
 			require.NoError(t, err)
 
 			tx, err := p.db.BeginTx(context.Background(), nil)
@@ -925,7 +1145,7 @@ func TestGetBridgesPublished(t *testing.T) {
 func TestProcessBlockInvalidIndex(t *testing.T) {
 	path := path.Join(t.TempDir(), "aggsenderTestProcessor.sqlite")
 	logger := log.WithFields("bridge-syncer", "foo")
-	p, err := newProcessor(path, "foo", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "foo", logger)
 	require.NoError(t, err)
 	err = p.ProcessBlock(context.Background(), sync.Block{
 		Num: 0,
@@ -956,7 +1176,7 @@ func TestGetBridgesPaged(t *testing.T) {
 	path := path.Join(t.TempDir(), "bridgesyncGetBridgesPaged.sqlite")
 	require.NoError(t, migrations.RunMigrations(path))
 	logger := log.WithFields("bridge-syncer", "foo")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 
 	tx, err := p.db.BeginTx(context.Background(), nil)
@@ -983,6 +1203,7 @@ func TestGetBridgesPaged(t *testing.T) {
 		depositCount    *uint64
 		networkIDs      []uint32
 		fromAddress     string
+		blockNumFilter  *BlockNumFilter
 		expectedCount   int
 		expectedBridges []*Bridge
 		expectedError   string
@@ -1147,6 +1368,25 @@ func TestGetBridgesPaged(t *testing.T) {
 			},
 			expectedError: "",
 		},
+		{
+			name:            "filter by block range",
+			pageSize:        10,
+			page:            1,
+			blockNumFilter:  &BlockNumFilter{FromBlock: depositCountPtr(3), ToBlock: depositCountPtr(4)},
+			expectedCount:   2,
+			expectedBridges: []*Bridge{bridges[3], bridges[2]},
+			expectedError:   "",
+		},
+		{
+			name:            "filter by block range and destination network",
+			pageSize:        10,
+			page:            1,
+			networkIDs:      []uint32{30},
+			blockNumFilter:  &BlockNumFilter{FromBlock: depositCountPtr(5)},
+			expectedCount:   2,
+			expectedBridges: []*Bridge{bridges[5], bridges[4]},
+			expectedError:   "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1156,7 +1396,8 @@ func TestGetBridgesPaged(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			bridges, count, err := p.GetBridgesPaged(ctx, tc.page, tc.pageSize, tc.depositCount, tc.networkIDs, tc.fromAddress)
+			bridges, count, err := p.GetBridgesPaged(ctx, tc.page, tc.pageSize, tc.depositCount, tc.networkIDs, tc.fromAddress,
+				tc.blockNumFilter)
 
 			if tc.expectedError != "" {
 				require.ErrorContains(t, err, tc.expectedError)
@@ -1195,7 +1436,7 @@ func TestGetClaimsPaged(t *testing.T) {
 	path := path.Join(t.TempDir(), "bridgesyncGetClaimsPaged.sqlite")
 	require.NoError(t, migrations.RunMigrations(path))
 	logger := log.WithFields("module", "bridge-syncer")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 
 	tx, err := p.db.BeginTx(context.Background(), nil)
@@ -1217,6 +1458,7 @@ func TestGetClaimsPaged(t *testing.T) {
 		page           uint32
 		networkIDs     []uint32
 		fromAddress    string
+		blockNumFilter *BlockNumFilter
 		expectedCount  int
 		expectedClaims []*Claim
 		expectedError  string
@@ -1291,6 +1533,15 @@ func TestGetClaimsPaged(t *testing.T) {
 			expectedClaims: []*Claim{},
 			expectedError:  "",
 		},
+		{
+			name:           "filter by block range",
+			pageSize:       20,
+			page:           1,
+			blockNumFilter: &BlockNumFilter{ToBlock: &claims[1].BlockNum},
+			expectedCount:  2,
+			expectedClaims: []*Claim{claims[1], claims[0]},
+			expectedError:  "",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1300,7 +1551,7 @@ func TestGetClaimsPaged(t *testing.T) {
 			t.Parallel()
 
 			ctx := context.Background()
-			claims, count, err := p.GetClaimsPaged(ctx, tc.page, tc.pageSize, tc.networkIDs, tc.fromAddress)
+			claims, count, err := p.GetClaimsPaged(ctx, tc.page, tc.pageSize, tc.networkIDs, tc.fromAddress, tc.blockNumFilter)
 
 			if tc.expectedError != "" {
 				require.ErrorContains(t, err, tc.expectedError)
@@ -1323,7 +1574,7 @@ func TestProcessor_GetTokenMappings(t *testing.T) {
 	require.NoError(t, err)
 
 	logger := log.WithFields("module", "bridge-syncer")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 
 	allTokenMappings := make([]*TokenMapping, 0, tokenMappingsCount)
@@ -1422,7 +1673,7 @@ func TestProcessor_GetLegacyTokenMigrations(t *testing.T) {
 	require.NoError(t, err)
 
 	logger := log.WithFields("module", "bridge-syncer")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 
 	const (
@@ -1970,7 +2221,7 @@ func TestDecodeEtrogCalldata(t *testing.T) {
 func TestQueryBlockRangeOrdering(t *testing.T) {
 	path := path.Join(t.TempDir(), "bridgeSyncerProcessorOrdering.db")
 	logger := log.WithFields("module", "bridge-syncer")
-	p, err := newProcessor(path, "bridge-syncer", logger)
+	p, err := newProcessor(path, db.SQLiteConfig{}, "bridge-syncer", logger)
 	require.NoError(t, err)
 
 	// Create test data with events in different blocks and positions
@@ -2038,6 +2289,140 @@ func TestQueryBlockRangeOrdering(t *testing.T) {
 	require.Equal(t, uint64(0), bridges[3].BlockPos)
 }
 
+// TestQueryPlans checks that the hot queries of the bridge service use the indexes instead of scanning the tables
+func TestQueryPlans(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncQueryPlans.sqlite")
+	require.NoError(t, migrations.RunMigrations(dbPath))
+	p, err := newProcessor(dbPath, db.SQLiteConfig{}, "bridge-syncer", log.WithFields("module", "bridge-syncer"))
+	require.NoError(t, err)
+
+	fromAddress := "0xE34aaF64b29273B7D567FCFc40544c014EEe9970"
+	fromBlock, toBlock := uint64(10), uint64(100)
+	blockRange := &BlockNumFilter{FromBlock: &fromBlock, ToBlock: &toBlock}
+	depositCount := uint64(4)
+	const (
+		bridgesOrder = "deposit_count DESC"
+		claimsOrder  = "block_num DESC, block_pos DESC"
+	)
+
+	// sortedByIndex means that the rows are read in the order of the index, without sorting them. With
+	// several network ids the rows of each one are sorted, but only the filtered ones
+	tests := []struct {
+		name          string
+		query         string
+		expectedIndex string
+		sortedByIndex bool
+	}{
+		{
+			name:          "bridges page",
+			query:         pagedQuerySQL(bridgeTableName, "", bridgesOrder),
+			expectedIndex: "idx_bridge_deposit_count",
+			sortedByIndex: true,
+		},
+		{
+			name:          "bridges by deposit count",
+			query:         pagedQuerySQL(bridgeTableName, p.buildBridgesFilterClause(&depositCount, nil, "", nil), bridgesOrder),
+			expectedIndex: "idx_bridge_deposit_count",
+			sortedByIndex: true,
+		},
+		{
+			name: "bridges by network ids",
+			query: pagedQuerySQL(bridgeTableName,
+				p.buildBridgesFilterClause(nil, []uint32{1, 2}, "", nil), bridgesOrder),
+			expectedIndex: "idx_bridge_destination_network",
+		},
+		{
+			name: "bridges by network ids and from address",
+			query: pagedQuerySQL(bridgeTableName,
+				p.buildBridgesFilterClause(nil, []uint32{1, 2}, fromAddress, blockRange), bridgesOrder),
+			expectedIndex: "idx_bridge_from_address",
+			sortedByIndex: true,
+		},
+		{
+			name:          "bridges count by from address",
+			query:         countQuerySQL(bridgeTableName, p.buildBridgesFilterClause(nil, nil, fromAddress, nil)),
+			expectedIndex: "idx_bridge_from_address",
+			sortedByIndex: true,
+		},
+		{
+			name:          "claims page",
+			query:         pagedQuerySQL(claimTableName, "", claimsOrder),
+			expectedIndex: "sqlite_autoindex_claim_1",
+			sortedByIndex: true,
+		},
+		{
+			name:          "claims by network ids",
+			query:         pagedQuerySQL(claimTableName, p.buildClaimsFilterClause([]uint32{1, 2}, "", nil), claimsOrder),
+			expectedIndex: "idx_claim_origin_network",
+		},
+		{
+			name: "claims by network ids and block range",
+			query: pagedQuerySQL(claimTableName,
+				p.buildClaimsFilterClause([]uint32{1, 2}, "", blockRange), claimsOrder),
+			expectedIndex: "idx_claim_origin_network",
+		},
+		{
+			name: "claims by network ids and from address",
+			query: pagedQuerySQL(claimTableName,
+				p.buildClaimsFilterClause([]uint32{1, 2}, fromAddress, nil), claimsOrder),
+			expectedIndex: "idx_claim_from_address",
+			sortedByIndex: true,
+		},
+		{
+			name:          "claims count by network ids",
+			query:         countQuerySQL(claimTableName, p.buildClaimsFilterClause([]uint32{1, 2}, "", nil)),
+			expectedIndex: "idx_claim_origin_network",
+			sortedByIndex: true,
+		},
+		{
+			name:          "claims by block range",
+			query:         pagedQuerySQL(claimTableName, p.buildClaimsFilterClause(nil, "", blockRange), claimsOrder),
+			expectedIndex: "sqlite_autoindex_claim_1",
+			sortedByIndex: true,
+		},
+		{
+			name:          "claims by global index",
+			query:         "SELECT * FROM claim WHERE global_index = $1 ORDER BY block_num ASC, block_pos ASC;",
+			expectedIndex: "idx_claim_global_index",
+		},
+		{
+			name:          "claims by tx hash",
+			query:         "SELECT * FROM claim WHERE tx_hash = $1 ORDER BY block_num ASC, block_pos ASC;",
+			expectedIndex: "idx_claim_tx_hash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := explainQueryPlan(t, p.db, tt.query)
+			require.Contains(t, plan, "INDEX "+tt.expectedIndex, "query plan: %s", plan)
+			if tt.sortedByIndex {
+				require.NotContains(t, plan, "USE TEMP B-TREE", "query plan: %s", plan)
+			}
+			for _, table := range []string{bridgeTableName, claimTableName} {
+				require.NotRegexp(t, `SCAN `+table+`($|\n)`, plan, "full table scan: %s", plan)
+			}
+		})
+	}
+}
+
+// explainQueryPlan returns the details of the query plan of the query, one step per line
+func explainQueryPlan(t *testing.T, database *sql.DB, query string) string {
+	t.Helper()
+	// the parameters of the queries don't change the plan
+	rows, err := database.Query("EXPLAIN QUERY PLAN "+query, 1, 1)
+	require.NoError(t, err)
+	defer rows.Close()
+	var steps []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+		steps = append(steps, detail)
+	}
+	require.NoError(t, rows.Err())
+	return strings.Join(steps, "\n")
+}
+
 func TestBridgeSyncRuntimeData_IsCompatible(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,280 @@
+package bridgesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/russross/meddler"
+)
+
+const (
+	// usdValueTableName is the name of the table that stores the value in USD of the bridges and claims
+	usdValueTableName = "usd_value"
+	// usdValueDecimals are the decimals of the values in USD
+	usdValueDecimals = 6
+	// leafTypeAsset is the leaf type of the bridges of assets (the messages are not priced)
+	leafTypeAsset = 0
+
+	// USDValueEventBridge is the event type of the USD values of bridges
+	USDValueEventBridge = "bridge"
+	// USDValueEventClaim is the event type of the USD values of claims
+	USDValueEventClaim = "claim"
+)
+
+// selectPendingUSDValuesSQL selects the bridges and claims of assets that have not been priced yet
+var selectPendingUSDValuesSQL = fmt.Sprintf(`
+	SELECT '%[1]s' AS event_type, b.block_num AS block_num, b.block_pos AS block_pos, b.block_timestamp,
+		b.origin_network, b.origin_address, b.amount
+	FROM %[3]s b
+	LEFT JOIN %[5]s v ON v.event_type = '%[1]s' AND v.block_num = b.block_num AND v.block_pos = b.block_pos
+	WHERE v.block_num IS NULL AND b.leaf_type = %[6]d AND b.amount != '0'
+	UNION ALL
+	SELECT '%[2]s', c.block_num, c.block_pos, c.block_timestamp,
+		c.origin_network, c.origin_address, c.amount
+	FROM %[4]s c
+	LEFT JOIN %[5]s v ON v.event_type = '%[2]s' AND v.block_num = c.block_num AND v.block_pos = c.block_pos
+	WHERE v.block_num IS NULL AND NOT c.is_message AND c.amount != '0'
+	ORDER BY block_num ASC, block_pos ASC
+	LIMIT $1;
+`, USDValueEventBridge, USDValueEventClaim, bridgeTableName, claimTableName, usdValueTableName, leafTypeAsset)
+
+// USDValue is the value in USD of a bridge or a claim of an asset at the time of the event
+type USDValue struct {
+	EventType      string         `meddler:"event_type"`
+	BlockNum       uint64         `meddler:"block_num"`
+	BlockPos       uint64         `meddler:"block_pos"`
+	BlockTimestamp uint64         `meddler:"block_timestamp"`
+	OriginNetwork  uint32         `meddler:"origin_network"`
+	OriginAddress  common.Address `meddler:"origin_address"`
+	Amount         *big.Int       `meddler:"amount,bigint"`
+	// PriceUSD is the price of the token, nil if the price oracle has no price for it
+	PriceUSD *string `meddler:"price_usd"`
+	// ValueUSD is the value of the amount, nil if the price oracle has no price for the token
+	ValueUSD *string `meddler:"value_usd"`
+	// PricedAt is the unix time when the price was requested
+	PricedAt uint64 `meddler:"priced_at"`
+}
+
+// TokenUSDValueStats are the totals in USD of a token
+type TokenUSDValueStats struct {
+	OriginNetwork uint32
+	OriginAddress common.Address
+	BridgedUSD    string
+	ClaimedUSD    string
+	Bridges       int
+	Claims        int
+}
+
+// USDValueStats are the totals in USD of the bridges and claims of a time range. The events without
+// price are counted as unpriced, and the ones that have not been priced yet are not included
+type USDValueStats struct {
+	BridgedUSD      string
+	ClaimedUSD      string
+	PricedBridges   int
+	UnpricedBridges int
+	PricedClaims    int
+	UnpricedClaims  int
+	// Tokens are the totals by token, sorted by origin network and address
+	Tokens []*TokenUSDValueStats
+}
+
+// GetUSDValues returns the USD values of the events of the type (bridge or claim) in the block range
+func (p *processor) GetUSDValues(ctx context.Context,
+	eventType string, fromBlock, toBlock uint64) ([]*USDValue, error) {
+	values := []*USDValue{}
+	err := meddler.QueryAll(p.db, &values, fmt.Sprintf(`
+		SELECT * FROM %s
+		WHERE event_type = $1 AND block_num >= $2 AND block_num <= $3
+		ORDER BY block_num ASC, block_pos ASC;
+	`, usdValueTableName), eventType, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the USD values of %s events in blocks [%d..%d]: %w",
+			eventType, fromBlock, toBlock, err)
+	}
+	return values, nil
+}
+
+// GetUSDValueStats returns the totals in USD of the events with block timestamp in [fromTimestamp..toTimestamp]
+func (p *processor) GetUSDValueStats(ctx context.Context, fromTimestamp, toTimestamp uint64) (*USDValueStats, error) {
+	values := []*USDValue{}
+	err := meddler.QueryAll(p.db, &values, fmt.Sprintf(`
+		SELECT * FROM %s
+		WHERE block_timestamp >= $1 AND block_timestamp <= $2;
+	`, usdValueTableName), fromTimestamp, toTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the USD values of timestamps [%d..%d]: %w",
+			fromTimestamp, toTimestamp, err)
+	}
+
+	type tokenKey struct {
+		network uint32
+		address common.Address
+	}
+	type tokenTotals struct {
+		stats            *TokenUSDValueStats
+		bridged, claimed *big.Rat
+	}
+	stats := &USDValueStats{}
+	bridged, claimed := new(big.Rat), new(big.Rat)
+	tokens := map[tokenKey]*tokenTotals{}
+	for _, v := range values {
+		key := tokenKey{network: v.OriginNetwork, address: v.OriginAddress}
+		token, ok := tokens[key]
+		if !ok {
+			token = &tokenTotals{
+				stats:   &TokenUSDValueStats{OriginNetwork: v.OriginNetwork, OriginAddress: v.OriginAddress},
+				bridged: new(big.Rat),
+				claimed: new(big.Rat),
+			}
+			tokens[key] = token
+		}
+
+		var value *big.Rat
+		if v.ValueUSD != nil {
+			if value, ok = new(big.Rat).SetString(*v.ValueUSD); !ok {
+				return nil, fmt.Errorf("invalid USD value %q of %s at block %d, pos %d",
+					*v.ValueUSD, v.EventType, v.BlockNum, v.BlockPos)
+			}
+		}
+		switch v.EventType {
+		case USDValueEventBridge:
+			token.stats.Bridges++
+			if value == nil {
+				stats.UnpricedBridges++
+				continue
+			}
+			stats.PricedBridges++
+			bridged.Add(bridged, value)
+			token.bridged.Add(token.bridged, value)
+		case USDValueEventClaim:
+			token.stats.Claims++
+			if value == nil {
+				stats.UnpricedClaims++
+				continue
+			}
+			stats.PricedClaims++
+			claimed.Add(claimed, value)
+			token.claimed.Add(token.claimed, value)
+		}
+	}
+
+	stats.BridgedUSD = bridged.FloatString(usdValueDecimals)
+	stats.ClaimedUSD = claimed.FloatString(usdValueDecimals)
+	stats.Tokens = make([]*TokenUSDValueStats, 0, len(tokens))
+	for _, token := range tokens {
+		token.stats.BridgedUSD = token.bridged.FloatString(usdValueDecimals)
+		token.stats.ClaimedUSD = token.claimed.FloatString(usdValueDecimals)
+		stats.Tokens = append(stats.Tokens, token.stats)
+	}
+	sort.Slice(stats.Tokens, func(i, j int) bool {
+		if stats.Tokens[i].OriginNetwork != stats.Tokens[j].OriginNetwork {
+			return stats.Tokens[i].OriginNetwork < stats.Tokens[j].OriginNetwork
+		}
+		return stats.Tokens[i].OriginAddress.Cmp(stats.Tokens[j].OriginAddress) < 0
+	})
+	return stats, nil
+}
+
+// usdValueTracker prices with the price oracle the bridges and claims of assets once they are synced,
+// storing their value in USD at the time of the event
+type usdValueTracker struct {
+	processor      *processor
+	oracle         PriceOracle
+	updateInterval time.Duration
+	batchSize      uint32
+	log            *log.Logger
+}
+
+func newUSDValueTracker(p *processor, oracle PriceOracle, cfg PriceOracleConfig) *usdValueTracker {
+	return &usdValueTracker{
+		processor:      p,
+		oracle:         oracle,
+		updateInterval: cfg.UpdateInterval.Duration,
+		batchSize:      cfg.BatchSize,
+		log:            p.log,
+	}
+}
+
+// Start prices the new events every update interval until the context is done
+func (t *usdValueTracker) Start(ctx context.Context) {
+	ticker := time.NewTicker(t.updateInterval)
+	defer ticker.Stop()
+	for {
+		priced, err := t.update(ctx)
+		if err != nil {
+			t.log.Warnf("error updating the USD values: %v", err)
+		} else if priced > 0 {
+			t.log.Debugf("%d bridges and claims priced in USD", priced)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update prices a batch of the events that have not been priced yet and returns the number of stored values.
+// The events that can't be priced because of an error of the oracle are retried on the next update
+func (t *usdValueTracker) update(ctx context.Context) (int, error) {
+	if t.processor.isHalted() {
+		return 0, nil
+	}
+	pending := []*USDValue{}
+	if err := meddler.QueryAll(t.processor.db, &pending, selectPendingUSDValuesSQL, t.batchSize); err != nil {
+		return 0, fmt.Errorf("failed to get the events pending to be priced: %w", err)
+	}
+
+	type priceKey struct {
+		network   uint32
+		token     common.Address
+		timestamp uint64
+	}
+	prices := map[priceKey]*TokenPrice{}
+	stored := 0
+	for _, v := range pending {
+		key := priceKey{network: v.OriginNetwork, token: v.OriginAddress, timestamp: v.BlockTimestamp}
+		price, ok := prices[key]
+		if !ok {
+			var err error
+			price, err = t.oracle.GetPriceUSD(ctx, v.OriginNetwork, v.OriginAddress, v.BlockTimestamp)
+			if err != nil && !errors.Is(err, ErrPriceNotFound) {
+				if ctx.Err() != nil {
+					return stored, ctx.Err()
+				}
+				t.log.Warnf("failed to get the price of token %s (network %d) at %d: %v",
+					v.OriginAddress.Hex(), v.OriginNetwork, v.BlockTimestamp, err)
+				continue
+			}
+			prices[key] = price
+		}
+
+		v.PricedAt = uint64(time.Now().Unix())
+		if price != nil {
+			value, err := price.ValueUSD(v.Amount)
+			if err != nil {
+				return stored, fmt.Errorf("failed to calculate the USD value of %s at block %d, pos %d: %w",
+					v.EventType, v.BlockNum, v.BlockPos, err)
+			}
+			v.PriceUSD = &price.PriceUSD
+			v.ValueUSD = &value
+		}
+		if err := meddler.Insert(t.processor.db, usdValueTableName, v); err != nil {
+			if sqliteErr, ok := db.SQLiteErr(err); ok && sqliteErr.ExtendedCode == db.ForeignKeyConstrain {
+				// the block of the event has been reorged meanwhile
+				continue
+			}
+			return stored, fmt.Errorf("failed to store the USD value of %s at block %d, pos %d: %w",
+				v.EventType, v.BlockNum, v.BlockPos, err)
+		}
+		stored++
+	}
+	return stored, nil
+}
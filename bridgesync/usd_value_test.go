@@ -0,0 +1,148 @@
+package bridgesync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/bridgesync/migrations"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// priceOracleStub returns the prices by token, ErrPriceNotFound for the unknown tokens and
+// the error of the token, if any
+type priceOracleStub struct {
+	prices map[common.Address]*TokenPrice
+	errs   map[common.Address]error
+}
+
+func (o *priceOracleStub) GetPriceUSD(ctx context.Context,
+	originNetwork uint32, token common.Address, timestamp uint64) (*TokenPrice, error) {
+	if err, ok := o.errs[token]; ok {
+		return nil, err
+	}
+	if price, ok := o.prices[token]; ok {
+		return price, nil
+	}
+	return nil, ErrPriceNotFound
+}
+
+func TestUSDValueTracker(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "bridgesyncTestUSDValueTracker.sqlite")
+	require.NoError(t, migrations.RunMigrations(dbPath))
+	p, err := newProcessor(dbPath, db.SQLiteConfig{}, "foo", log.WithFields("bridge-syncer", "foo"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	weth := common.HexToAddress("0x01")
+	usdc := common.HexToAddress("0x02")
+	unknown := common.HexToAddress("0x03")
+	failing := common.HexToAddress("0x04")
+	ether, ok := new(big.Int).SetString("2000000000000000000", 10)
+	require.True(t, ok)
+
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{
+		Num: 1,
+		Events: []interface{}{
+			Event{Bridge: &Bridge{BlockNum: 1, BlockPos: 0, BlockTimestamp: 100, LeafType: leafTypeAsset,
+				OriginAddress: weth, Amount: ether, DepositCount: 0}},
+			// messages and bridges without amount are not priced
+			Event{Bridge: &Bridge{BlockNum: 1, BlockPos: 1, BlockTimestamp: 100, LeafType: 1,
+				OriginAddress: weth, Amount: ether, DepositCount: 1}},
+			Event{Bridge: &Bridge{BlockNum: 1, BlockPos: 2, BlockTimestamp: 100, LeafType: leafTypeAsset,
+				OriginAddress: weth, Amount: big.NewInt(0), DepositCount: 2}},
+			Event{Claim: &Claim{BlockNum: 1, BlockPos: 3, BlockTimestamp: 100, OriginNetwork: 1,
+				OriginAddress: usdc, Amount: big.NewInt(1_500_000), GlobalIndex: big.NewInt(1)}},
+		},
+	}))
+	require.NoError(t, p.ProcessBlock(ctx, sync.Block{
+		Num: 2,
+		Events: []interface{}{
+			Event{Claim: &Claim{BlockNum: 2, BlockPos: 0, BlockTimestamp: 200, OriginAddress: unknown,
+				Amount: big.NewInt(1), GlobalIndex: big.NewInt(2)}},
+			Event{Claim: &Claim{BlockNum: 2, BlockPos: 1, BlockTimestamp: 200, OriginAddress: weth,
+				Amount: ether, GlobalIndex: big.NewInt(3), IsMessage: true}},
+			Event{Bridge: &Bridge{BlockNum: 2, BlockPos: 2, BlockTimestamp: 200, LeafType: leafTypeAsset,
+				OriginAddress: failing, Amount: big.NewInt(1), DepositCount: 3}},
+		},
+	}))
+
+	oracle := &priceOracleStub{
+		prices: map[common.Address]*TokenPrice{
+			weth: {PriceUSD: "2500.5", Decimals: 18},
+			usdc: {PriceUSD: "1", Decimals: 6},
+		},
+		errs: map[common.Address]error{failing: errors.New("rate limited")},
+	}
+	tracker := newUSDValueTracker(p, oracle, PriceOracleConfig{BatchSize: 2})
+
+	// the events are priced in batches, sorted by position
+	stored, err := tracker.update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, stored)
+	stored, err = tracker.update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored, "the event of the failing token is retried")
+
+	bridges, err := p.GetUSDValues(ctx, USDValueEventBridge, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, bridges, 1)
+	require.Equal(t, uint64(1), bridges[0].BlockNum)
+	require.Equal(t, weth, bridges[0].OriginAddress)
+	require.Equal(t, "2500.5", *bridges[0].PriceUSD)
+	require.Equal(t, "5001.000000", *bridges[0].ValueUSD)
+	require.NotZero(t, bridges[0].PricedAt)
+
+	claims, err := p.GetUSDValues(ctx, USDValueEventClaim, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, claims, 2)
+	require.Equal(t, "1.500000", *claims[0].ValueUSD)
+	require.Nil(t, claims[1].PriceUSD, "the tokens without price are stored without value")
+	require.Nil(t, claims[1].ValueUSD)
+
+	// the failing token is priced once the oracle has a price for it
+	delete(oracle.errs, failing)
+	oracle.prices[failing] = &TokenPrice{PriceUSD: "3", Decimals: 0}
+	stored, err = tracker.update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, stored)
+	stored, err = tracker.update(ctx)
+	require.NoError(t, err)
+	require.Zero(t, stored)
+
+	stats, err := p.GetUSDValueStats(ctx, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, "5004.000000", stats.BridgedUSD)
+	require.Equal(t, "1.500000", stats.ClaimedUSD)
+	require.Equal(t, 2, stats.PricedBridges)
+	require.Zero(t, stats.UnpricedBridges)
+	require.Equal(t, 1, stats.PricedClaims)
+	require.Equal(t, 1, stats.UnpricedClaims)
+	require.Equal(t, []*TokenUSDValueStats{
+		{OriginNetwork: 0, OriginAddress: weth, BridgedUSD: "5001.000000", ClaimedUSD: "0.000000", Bridges: 1},
+		{OriginNetwork: 0, OriginAddress: unknown, BridgedUSD: "0.000000", ClaimedUSD: "0.000000", Claims: 1},
+		{OriginNetwork: 0, OriginAddress: failing, BridgedUSD: "3.000000", ClaimedUSD: "0.000000", Bridges: 1},
+		{OriginNetwork: 1, OriginAddress: usdc, BridgedUSD: "0.000000", ClaimedUSD: "1.500000", Claims: 1},
+	}, stats.Tokens)
+
+	stats, err = p.GetUSDValueStats(ctx, 150, 1000)
+	require.NoError(t, err)
+	require.Equal(t, "3.000000", stats.BridgedUSD)
+	require.Equal(t, 1, stats.UnpricedClaims)
+
+	// the values are removed with their block on reorgs
+	require.NoError(t, p.Reorg(ctx, 2))
+	claims, err = p.GetUSDValues(ctx, USDValueEventClaim, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, claims, 1)
+	bridges, err = p.GetUSDValues(ctx, USDValueEventBridge, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, bridges, 1)
+	require.Equal(t, uint64(1), bridges[0].BlockNum)
+}
@@ -0,0 +1,267 @@
+package bridgesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const missingValue = "missing"
+
+// NewVerifier creates a verifier of the DB of a bridge syncer (cfg.DBPath). The chain is reprocessed into
+// a scratch DB, removed on Close, and compared with the DB, which is opened read only. The bridges are
+// compared by deposit count, the claims by block position and the exit roots by deposit count
+func NewVerifier(
+	ctx context.Context,
+	cfg Config,
+	syncerID BridgeSyncerType,
+	ethClient aggkittypes.EthClienter,
+	syncFullClaims bool,
+) (*sync.Verifier, error) {
+	logger := log.WithFields("module", syncerID.String()+"_verifier")
+
+	stored, err := newReadOnlyProcessor(cfg.DBPath, cfg.StorageTuning, logger)
+	if err != nil {
+		return nil, err
+	}
+	bridgeContractV2, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(cfg.BridgeAddr, ethClient)
+	if err != nil {
+		return nil, errors.Join(err, stored.db.Close())
+	}
+	appender, err := buildAppender(ethClient, cfg.BridgeAddr, syncFullClaims, bridgeContractV2, logger)
+	if err != nil {
+		return nil, errors.Join(err, stored.db.Close())
+	}
+	rh := &sync.RetryHandler{
+		MaxRetryAttemptsAfterError: cfg.MaxRetryAttemptsAfterError,
+		RetryAfterErrorPeriod:      cfg.RetryAfterErrorPeriod.Duration,
+	}
+	// there is no reorg detector, the verified blocks should be final
+	downloader, err := sync.NewEVMDownloader(
+		syncerID.String()+"_verifier",
+		ethClient,
+		cfg.SyncBlockChunkSize,
+		aggkittypes.NewBlockNumberFinality(cfg.BlockFinality),
+		cfg.WaitForNewBlocksPeriod.Duration,
+		appender,
+		[]common.Address{cfg.BridgeAddr},
+		rh,
+		aggkittypes.FinalizedBlock,
+	)
+	if err != nil {
+		return nil, errors.Join(err, stored.db.Close())
+	}
+	if cfg.ClientSideTopicFiltering {
+		if err := downloader.EnableClientSideTopicFiltering(); err != nil {
+			return nil, errors.Join(err, stored.db.Close())
+		}
+	}
+
+	scratchDir, err := os.MkdirTemp("", "bridgesync-verify-*")
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create the scratch DB dir: %w", err), stored.db.Close())
+	}
+	closeFn := func() error {
+		return errors.Join(stored.db.Close(), os.RemoveAll(scratchDir))
+	}
+	scratch, err := newProcessor(filepath.Join(scratchDir, "bridgesync.sqlite"), db.SQLiteConfig{},
+		"bridge_sync_"+syncerID.String()+"_verifier", logger)
+	if err != nil {
+		return nil, errors.Join(err, closeFn())
+	}
+	closeFn = func() error {
+		return errors.Join(scratch.db.Close(), stored.db.Close(), os.RemoveAll(scratchDir))
+	}
+	block, err := ethClient.BlockByNumber(ctx, new(big.Int).SetUint64(cfg.InitialBlockNum))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to get initial block %d: %w", cfg.InitialBlockNum, err),
+			closeFn())
+	}
+	if err := scratch.ProcessBlock(ctx, sync.Block{Num: cfg.InitialBlockNum, Hash: block.Hash()}); err != nil {
+		return nil, errors.Join(err, closeFn())
+	}
+
+	return sync.NewVerifier(syncerID.String(), downloader, scratch,
+		&bridgeVerifier{scratch: scratch, stored: stored}, cfg.Verify.MaxDiscrepancies, closeFn), nil
+}
+
+// newReadOnlyProcessor opens an existing DB without running the migrations, so it's never written
+func newReadOnlyProcessor(dbPath string, dbCfg db.SQLiteConfig, logger *log.Logger) (*processor, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to open the DB to verify %s: %w", dbPath, err)
+	}
+	database, err := db.NewSQLiteReaderDB(dbPath, dbCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &processor{
+		db:       database,
+		exitTree: tree.NewAppendOnlyTree(database, ""),
+		log:      logger,
+	}, nil
+}
+
+// bridgeVerifier compares the bridges, claims and exit roots of the scratch processor and the verified DB
+type bridgeVerifier struct {
+	scratch *processor
+	stored  *processor
+}
+
+// LastStoredBlock returns the last processed block of the verified DB
+func (v *bridgeVerifier) LastStoredBlock(ctx context.Context) (uint64, error) {
+	return v.stored.GetLastProcessedBlock(ctx)
+}
+
+// VerifyBlocks compares the bridges, claims and exit roots of the blocks [fromBlock, toBlock]
+func (v *bridgeVerifier) VerifyBlocks(ctx context.Context, fromBlock, toBlock uint64) ([]sync.Discrepancy, error) {
+	computedBridges, err := v.scratch.GetBridges(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed bridges: %w", err)
+	}
+	storedBridges, err := v.stored.GetBridges(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stored bridges: %w", err)
+	}
+	discrepancies := compareBridges(computedBridges, storedBridges)
+
+	computedClaims, err := v.scratch.GetClaims(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed claims: %w", err)
+	}
+	storedClaims, err := v.stored.GetClaims(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stored claims: %w", err)
+	}
+	discrepancies = append(discrepancies, compareClaims(computedClaims, storedClaims)...)
+
+	if len(computedBridges) > 0 {
+		last := computedBridges[len(computedBridges)-1]
+		discrepancy, err := v.compareExitRoots(ctx, last.BlockNum, last.DepositCount)
+		if err != nil {
+			return nil, err
+		}
+		if discrepancy != nil {
+			discrepancies = append(discrepancies, *discrepancy)
+		}
+	}
+	return discrepancies, nil
+}
+
+// compareExitRoots compares the exit root after adding the leaf of the deposit count
+func (v *bridgeVerifier) compareExitRoots(ctx context.Context, blockNum uint64,
+	depositCount uint32) (*sync.Discrepancy, error) {
+	computed, err := v.scratch.exitTree.GetRootByIndex(ctx, depositCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed exit root of deposit count %d: %w", depositCount, err)
+	}
+	storedHash := missingValue
+	stored, err := v.stored.exitTree.GetRootByIndex(ctx, depositCount)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the stored exit root of deposit count %d: %w", depositCount, err)
+	default:
+		storedHash = stored.Hash.Hex()
+	}
+	if storedHash == computed.Hash.Hex() {
+		return nil, nil
+	}
+	return &sync.Discrepancy{
+		BlockNum: blockNum,
+		Field:    fmt.Sprintf("exit root of deposit count %d", depositCount),
+		Computed: computed.Hash.Hex(),
+		Stored:   storedHash,
+	}, nil
+}
+
+// compareBridges compares the bridges by deposit count
+func compareBridges(computed, stored []Bridge) []sync.Discrepancy {
+	storedByDepositCount := make(map[uint32]Bridge, len(stored))
+	for _, b := range stored {
+		storedByDepositCount[b.DepositCount] = b
+	}
+	discrepancies := []sync.Discrepancy{}
+	for _, b := range computed {
+		field := fmt.Sprintf("bridge of deposit count %d", b.DepositCount)
+		s, ok := storedByDepositCount[b.DepositCount]
+		delete(storedByDepositCount, b.DepositCount)
+		switch {
+		case !ok:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: b.BlockNum, Field: field, Computed: b.Hash().Hex(), Stored: missingValue,
+			})
+		case s.BlockNum != b.BlockNum:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: b.BlockNum, Field: field + " block",
+				Computed: fmt.Sprint(b.BlockNum), Stored: fmt.Sprint(s.BlockNum),
+			})
+		case s.Hash() != b.Hash():
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: b.BlockNum, Field: field, Computed: b.Hash().Hex(), Stored: s.Hash().Hex(),
+			})
+		}
+	}
+	for _, s := range stored {
+		if _, ok := storedByDepositCount[s.DepositCount]; ok {
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: s.BlockNum, Field: fmt.Sprintf("bridge of deposit count %d", s.DepositCount),
+				Computed: missingValue, Stored: s.Hash().Hex(),
+			})
+		}
+	}
+	return discrepancies
+}
+
+type claimPosition struct {
+	blockNum uint64
+	blockPos uint64
+}
+
+// compareClaims compares the global index and the amount of the claims by block position
+func compareClaims(computed, stored []Claim) []sync.Discrepancy {
+	storedByPosition := make(map[claimPosition]Claim, len(stored))
+	for _, c := range stored {
+		storedByPosition[claimPosition{c.BlockNum, c.BlockPos}] = c
+	}
+	discrepancies := []sync.Discrepancy{}
+	for _, c := range computed {
+		position := claimPosition{c.BlockNum, c.BlockPos}
+		field := fmt.Sprintf("claim at position %d", c.BlockPos)
+		s, ok := storedByPosition[position]
+		delete(storedByPosition, position)
+		switch {
+		case !ok:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: c.BlockNum, Field: field, Computed: claimSummary(c), Stored: missingValue,
+			})
+		case claimSummary(s) != claimSummary(c):
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: c.BlockNum, Field: field, Computed: claimSummary(c), Stored: claimSummary(s),
+			})
+		}
+	}
+	for _, s := range stored {
+		if _, ok := storedByPosition[claimPosition{s.BlockNum, s.BlockPos}]; ok {
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: s.BlockNum, Field: fmt.Sprintf("claim at position %d", s.BlockPos),
+				Computed: missingValue, Stored: claimSummary(s),
+			})
+		}
+	}
+	return discrepancies
+}
+
+func claimSummary(c Claim) string {
+	return fmt.Sprintf("globalIndex:%s, amount:%s", c.GlobalIndex, c.Amount)
+}
@@ -0,0 +1,85 @@
+package bridgesync
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeVerifier(t *testing.T) {
+	ctx := context.Background()
+	logger := log.WithFields("bridge-syncer", "verifier")
+	scratch, err := newProcessor(path.Join(t.TempDir(), "scratch.sqlite"), db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+	storedPath := path.Join(t.TempDir(), "stored.sqlite")
+	writer, err := newProcessor(storedPath, db.SQLiteConfig{}, "foo", logger)
+	require.NoError(t, err)
+
+	newBlock := func(blockNum uint64, depositCount uint32, amount int64) sync.Block {
+		return sync.Block{
+			Num: blockNum,
+			Events: []interface{}{
+				Event{Bridge: &Bridge{BlockNum: blockNum, BlockPos: 0, LeafType: leafTypeAsset,
+					OriginAddress: common.HexToAddress("0x01"), Amount: big.NewInt(amount), DepositCount: depositCount}},
+				Event{Claim: &Claim{BlockNum: blockNum, BlockPos: 1, GlobalIndex: big.NewInt(int64(blockNum)),
+					Amount: big.NewInt(amount)}},
+			},
+		}
+	}
+	for _, b := range []sync.Block{newBlock(1, 0, 10), newBlock(2, 1, 20)} {
+		require.NoError(t, scratch.ProcessBlock(ctx, b))
+	}
+	// the stored DB has a different amount in the block 2
+	for _, b := range []sync.Block{newBlock(1, 0, 10), newBlock(2, 1, 21)} {
+		require.NoError(t, writer.ProcessBlock(ctx, b))
+	}
+	require.NoError(t, writer.db.Close())
+
+	stored, err := newReadOnlyProcessor(storedPath, db.SQLiteConfig{}, logger)
+	require.NoError(t, err)
+	verifier := &bridgeVerifier{scratch: scratch, stored: stored}
+
+	lastBlock, err := verifier.LastStoredBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lastBlock)
+
+	discrepancies, err := verifier.VerifyBlocks(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Empty(t, discrepancies)
+
+	discrepancies, err = verifier.VerifyBlocks(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 3)
+	require.Equal(t, "bridge of deposit count 1", discrepancies[0].Field)
+	require.Equal(t, "claim at position 1", discrepancies[1].Field)
+	require.Equal(t, "globalIndex:2, amount:21", discrepancies[1].Stored)
+	require.Equal(t, "exit root of deposit count 1", discrepancies[2].Field)
+
+	t.Run("the stored DB isn't written", func(t *testing.T) {
+		require.Error(t, stored.ProcessBlock(ctx, newBlock(3, 2, 30)))
+	})
+
+	t.Run("the DB to verify must exist", func(t *testing.T) {
+		_, err := newReadOnlyProcessor(path.Join(t.TempDir(), "missing.sqlite"), db.SQLiteConfig{}, logger)
+		require.Error(t, err)
+	})
+}
+
+func TestCompareBridgesMissing(t *testing.T) {
+	computed := []Bridge{{BlockNum: 1, DepositCount: 0}, {BlockNum: 2, DepositCount: 1}}
+	stored := []Bridge{{BlockNum: 1, DepositCount: 0}, {BlockNum: 3, DepositCount: 2}}
+
+	discrepancies := compareBridges(computed, stored)
+	require.Len(t, discrepancies, 2)
+	require.Equal(t, missingValue, discrepancies[0].Stored)
+	require.Equal(t, uint64(2), discrepancies[0].BlockNum)
+	require.Equal(t, missingValue, discrepancies[1].Computed)
+	require.Equal(t, uint64(3), discrepancies[1].BlockNum)
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/certvalidation"
+	aggsenderrpc "github.com/agglayer/aggkit/aggsender/rpc"
+	"github.com/agglayer/aggkit/aggsender/types"
+)
+
+// AggsenderClient is a client of the JSON-RPC API of the aggsender
+type AggsenderClient struct {
+	url       string
+	requester *requester
+}
+
+// NewAggsenderClient returns a client of the aggsender RPC served at url (e.g. http://localhost:5576)
+func NewAggsenderClient(url string, opts Options) *AggsenderClient {
+	return &AggsenderClient{
+		url:       url,
+		requester: newRequester(opts),
+	}
+}
+
+// Status returns the status of the aggsender (aggsender_status)
+func (c *AggsenderClient) Status(ctx context.Context) (*types.AggsenderInfo, error) {
+	result := &types.AggsenderInfo{}
+	if err := c.call(ctx, result, "aggsender_status"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCertificateHeaderPerHeight returns the certificate of the given height, or the last sent one
+// if height is nil (aggsender_getCertificateHeaderPerHeight)
+func (c *AggsenderClient) GetCertificateHeaderPerHeight(ctx context.Context,
+	height *uint64) (*types.Certificate, error) {
+	result := &types.Certificate{}
+	if err := c.call(ctx, result, "aggsender_getCertificateHeaderPerHeight", height); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCertificateAnalytics returns the aggregated figures of the certificate of the given height,
+// or of the last sent one if height is nil (aggsender_getCertificateAnalytics)
+func (c *AggsenderClient) GetCertificateAnalytics(ctx context.Context,
+	height *uint64) (*types.CertificateAnalytics, error) {
+	result := &types.CertificateAnalytics{}
+	if err := c.call(ctx, result, "aggsender_getCertificateAnalytics", height); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCertificateAuditTrail returns the decisions taken for the certificate of the given height,
+// or for the last sent one if height is nil (aggsender_getCertificateAuditTrail)
+func (c *AggsenderClient) GetCertificateAuditTrail(ctx context.Context,
+	height *uint64) ([]*types.CertificateAuditEvent, error) {
+	var result []*types.CertificateAuditEvent
+	if err := c.call(ctx, &result, "aggsender_getCertificateAuditTrail", height); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListCertificates returns the summaries of the stored certificates from the given height down,
+// starting from the last sent one if fromHeight is nil (aggsender_listCertificates)
+func (c *AggsenderClient) ListCertificates(ctx context.Context,
+	fromHeight, limit *uint64) ([]aggsenderrpc.CertificateSummary, error) {
+	var result []aggsenderrpc.CertificateSummary
+	if err := c.call(ctx, &result, "aggsender_listCertificates", fromHeight, limit); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCertificateDetails returns the certificate of the given height, or the last sent one if height
+// is nil, with its metadata, agglayer header, bridges and claims (aggsender_getCertificateDetails)
+func (c *AggsenderClient) GetCertificateDetails(ctx context.Context,
+	height *uint64) (*aggsenderrpc.CertificateDetails, error) {
+	result := &aggsenderrpc.CertificateDetails{}
+	if err := c.call(ctx, result, "aggsender_getCertificateDetails", height); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValidateCertificate returns the verdict of the local replica of the agglayer acceptance rules for
+// the stored certificate of the given height, or for the supplied certificate (aggsender_validateCertificate)
+func (c *AggsenderClient) ValidateCertificate(ctx context.Context, height *uint64,
+	certificate *agglayertypes.Certificate) (*certvalidation.Verdict, error) {
+	result := &certvalidation.Verdict{}
+	if err := c.call(ctx, result, "aggsender_validateCertificate", height, certificate); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// call calls the JSON-RPC method and decodes its result into result
+func (c *AggsenderClient) call(ctx context.Context, result interface{}, method string,
+	params ...interface{}) error {
+	status, body, err := c.requester.do(ctx, func(ctx context.Context) (*http.Request, error) {
+		return rpc.BuildJsonHTTPRequest(ctx, c.url, method, params...)
+	})
+	if err != nil {
+		return fmt.Errorf("error calling %s: %w", method, err)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("error calling %s: %w", method, &HTTPError{StatusCode: status, Message: errorMessage(body)})
+	}
+
+	var response rpc.Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("error decoding the response of %s: %w", method, err)
+	}
+	if response.Error != nil {
+		return &RPCError{Method: method, Code: response.Error.Code, Message: response.Error.Message}
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("error decoding the result of %s: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/agglayer/aggkit/aggsender/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newRPCServer returns a JSON-RPC server that checks the method and params of the request
+// and answers with the result or the error
+func newRPCServer(t *testing.T, method, params string, result interface{},
+	rpcErr *rpc.ErrorObject) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req rpc.Request
+		require.NoError(t, json.Unmarshal(body, &req))
+		require.Equal(t, method, req.Method)
+		require.JSONEq(t, params, string(req.Params))
+
+		res := rpc.Response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+		if result != nil {
+			res.Result, err = json.Marshal(result)
+			require.NoError(t, err)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+}
+
+func TestAggsenderClientStatus(t *testing.T) {
+	info := types.AggsenderInfo{AggsenderStatus: types.AggsenderStatus{Running: true}}
+	server := newRPCServer(t, "aggsender_status", "null", info, nil)
+	defer server.Close()
+
+	result, err := NewAggsenderClient(server.URL, DefaultOptions()).Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, info, *result)
+}
+
+func TestAggsenderClientGetCertificateHeaderPerHeight(t *testing.T) {
+	height := uint64(3)
+	cert := types.Certificate{Header: &types.CertificateHeader{Height: height}}
+	server := newRPCServer(t, "aggsender_getCertificateHeaderPerHeight", "[3]", cert, nil)
+	defer server.Close()
+
+	result, err := NewAggsenderClient(server.URL, DefaultOptions()).
+		GetCertificateHeaderPerHeight(context.Background(), &height)
+	require.NoError(t, err)
+	require.Equal(t, height, result.Header.Height)
+}
+
+func TestAggsenderClientListCertificates(t *testing.T) {
+	limit := uint64(1)
+	server := newRPCServer(t, "aggsender_listCertificates", "[null, 1]",
+		[]map[string]interface{}{{"header": map[string]interface{}{"Height": 7}}}, nil)
+	defer server.Close()
+
+	result, err := NewAggsenderClient(server.URL, DefaultOptions()).
+		ListCertificates(context.Background(), nil, &limit)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, uint64(7), result[0].Header.Height)
+}
+
+func TestAggsenderClientRPCError(t *testing.T) {
+	server := newRPCServer(t, "aggsender_getCertificateDetails", "[null]", nil,
+		&rpc.ErrorObject{Code: rpc.NotFoundErrorCode, Message: "certificate not found"})
+	defer server.Close()
+
+	_, err := NewAggsenderClient(server.URL, DefaultOptions()).GetCertificateDetails(context.Background(), nil)
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, rpc.NotFoundErrorCode, rpcErr.Code)
+	require.Equal(t, "certificate not found", rpcErr.Message)
+}
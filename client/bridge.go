@@ -0,0 +1,389 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// bridgeV1Prefix is the url prefix of the bridge service (bridgeservice.BridgeV1Prefix), that isn't
+// imported to keep the dependencies of the client to the types of the API
+const bridgeV1Prefix = "/bridge/v1"
+
+// PageRequest is the pagination of the list endpoints (0 = the default of the bridge service)
+type PageRequest struct {
+	PageNumber uint32
+	PageSize   uint32
+}
+
+// BridgesRequest are the filters of GetBridges
+type BridgesRequest struct {
+	PageRequest
+	NetworkID    uint32
+	DepositCount *uint64
+	FromAddress  string
+	NetworkIDs   []uint32
+	// Finality is the minimum finality of the block of the bridges (pending, safe or finalized)
+	Finality       string
+	DecodeMetadata bool
+}
+
+// ClaimsRequest are the filters of GetClaims
+type ClaimsRequest struct {
+	PageRequest
+	NetworkID        uint32
+	NetworkIDs       []uint32
+	FromAddress      string
+	Finality         string
+	IncludeAllFields bool
+}
+
+// BridgeClient is a client of the REST API of the bridge service
+type BridgeClient struct {
+	baseURL   string
+	requester *requester
+}
+
+// NewBridgeClient returns a client of the bridge service served at url (e.g. http://localhost:5577)
+func NewBridgeClient(url string, opts Options) *BridgeClient {
+	return &BridgeClient{
+		baseURL:   strings.TrimSuffix(url, "/"),
+		requester: newRequester(opts),
+	}
+}
+
+// HealthCheck returns the status and version of the bridge service
+func (c *BridgeClient) HealthCheck(ctx context.Context) (*types.HealthCheckResponse, error) {
+	result := &types.HealthCheckResponse{}
+	if err := c.get(ctx, "/", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Readiness returns whether the bridge syncers are synced. A service that is not ready (503)
+// is not an error, it's returned with Ready set to false
+func (c *BridgeClient) Readiness(ctx context.Context) (*types.ReadinessResponse, error) {
+	result := &types.ReadinessResponse{}
+	if err := c.request(ctx, http.MethodGet, "/ready", nil, nil, result, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBridges returns the bridges of a network
+func (c *BridgeClient) GetBridges(ctx context.Context, req BridgesRequest) (*types.BridgesResult, error) {
+	query := networkQuery(req.NetworkID)
+	addPage(query, req.PageRequest)
+	if req.DepositCount != nil {
+		query.Set("deposit_count", strconv.FormatUint(*req.DepositCount, 10))
+	}
+	addOptional(query, "from_address", req.FromAddress)
+	addNetworkIDs(query, req.NetworkIDs)
+	addOptional(query, "finality", req.Finality)
+	if req.DecodeMetadata {
+		query.Set("decode_metadata", "true")
+	}
+
+	result := &types.BridgesResult{}
+	if err := c.get(ctx, bridgeV1Prefix+"/bridges", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClaims returns the claims of a network
+func (c *BridgeClient) GetClaims(ctx context.Context, req ClaimsRequest) (*types.ClaimsResult, error) {
+	query := networkQuery(req.NetworkID)
+	addPage(query, req.PageRequest)
+	addNetworkIDs(query, req.NetworkIDs)
+	addOptional(query, "from_address", req.FromAddress)
+	addOptional(query, "finality", req.Finality)
+	if req.IncludeAllFields {
+		query.Set("include_all_fields", "true")
+	}
+
+	result := &types.ClaimsResult{}
+	if err := c.get(ctx, bridgeV1Prefix+"/claims", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClaimByGlobalIndex returns the claim of a global index with the status of its bridge
+func (c *BridgeClient) GetClaimByGlobalIndex(ctx context.Context, globalIndex *big.Int,
+	includeAllFields bool) (*types.ClaimLookupResponse, error) {
+	if globalIndex == nil {
+		return nil, fmt.Errorf("global index is nil")
+	}
+	query := url.Values{}
+	if includeAllFields {
+		query.Set("include_all_fields", "true")
+	}
+
+	result := &types.ClaimLookupResponse{}
+	if err := c.get(ctx, bridgeV1Prefix+"/claims/"+globalIndex.String(), query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTxEvents returns the bridges and claims emitted by a transaction on the L1 and the L2
+func (c *BridgeClient) GetTxEvents(ctx context.Context, txHash common.Hash,
+	includeAllFields bool) (*types.TxEventsResponse, error) {
+	query := url.Values{}
+	if includeAllFields {
+		query.Set("include_all_fields", "true")
+	}
+
+	result := &types.TxEventsResponse{}
+	if err := c.get(ctx, bridgeV1Prefix+"/tx/"+txHash.Hex(), query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTokenMappings returns the token mappings of a network
+func (c *BridgeClient) GetTokenMappings(ctx context.Context, networkID uint32, page PageRequest,
+	decodeMetadata bool) (*types.TokenMappingsResult, error) {
+	query := networkQuery(networkID)
+	addPage(query, page)
+	if decodeMetadata {
+		query.Set("decode_metadata", "true")
+	}
+
+	result := &types.TokenMappingsResult{}
+	if err := c.get(ctx, bridgeV1Prefix+"/token-mappings", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLegacyTokenMigrations returns the legacy token migrations of a network
+func (c *BridgeClient) GetLegacyTokenMigrations(ctx context.Context, networkID uint32,
+	page PageRequest) (*types.LegacyTokenMigrationsResult, error) {
+	query := networkQuery(networkID)
+	addPage(query, page)
+
+	result := &types.LegacyTokenMigrationsResult{}
+	if err := c.get(ctx, bridgeV1Prefix+"/legacy-token-migrations", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// L1InfoTreeIndexForBridge returns the first L1 info tree index that includes the bridge
+func (c *BridgeClient) L1InfoTreeIndexForBridge(ctx context.Context, networkID uint32,
+	depositCount uint32) (uint32, error) {
+	query := networkQuery(networkID)
+	query.Set("deposit_count", strconv.FormatUint(uint64(depositCount), 10))
+
+	var result uint32
+	if err := c.get(ctx, bridgeV1Prefix+"/l1-info-tree-index", query, &result); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// InjectedL1InfoLeaf returns the L1 info tree leaf injected in the network for the given index
+func (c *BridgeClient) InjectedL1InfoLeaf(ctx context.Context, networkID uint32,
+	leafIndex uint32) (*types.L1InfoTreeLeafResponse, error) {
+	query := networkQuery(networkID)
+	query.Set("leaf_index", strconv.FormatUint(uint64(leafIndex), 10))
+
+	result := &types.L1InfoTreeLeafResponse{}
+	if err := c.get(ctx, bridgeV1Prefix+"/injected-l1-info-leaf", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ClaimProof returns the merkle proofs needed to claim a bridge
+func (c *BridgeClient) ClaimProof(ctx context.Context, networkID uint32,
+	leafIndex, depositCount uint32) (*types.ClaimProof, error) {
+	query := networkQuery(networkID)
+	query.Set("leaf_index", strconv.FormatUint(uint64(leafIndex), 10))
+	query.Set("deposit_count", strconv.FormatUint(uint64(depositCount), 10))
+
+	result := &types.ClaimProof{}
+	if err := c.get(ctx, bridgeV1Prefix+"/claim-proof", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// VerifyClaimProof checks a claim proof against the local exit root, rollup exit root and
+// L1 info tree (root and leaf) known by the bridge service
+func (c *BridgeClient) VerifyClaimProof(ctx context.Context,
+	req *types.VerifyClaimProofRequest) (*types.ClaimProofVerdict, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the claim proof: %w", err)
+	}
+
+	result := &types.ClaimProofVerdict{}
+	if err := c.request(ctx, http.MethodPost, bridgeV1Prefix+"/verify-claim-proof",
+		nil, body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLastReorgEvent returns the last reorg detected in the network
+func (c *BridgeClient) GetLastReorgEvent(ctx context.Context, networkID uint32) (*bridgesync.LastReorg, error) {
+	result := &bridgesync.LastReorg{}
+	if err := c.get(ctx, bridgeV1Prefix+"/last-reorg-event", networkQuery(networkID),
+		result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSyncStatus returns the sync status of the L1 and L2 bridge syncers
+func (c *BridgeClient) GetSyncStatus(ctx context.Context) (*types.SyncStatus, error) {
+	result := &types.SyncStatus{}
+	if err := c.get(ctx, bridgeV1Prefix+"/sync-status", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNetworks returns the networks supported by the bridge service
+func (c *BridgeClient) GetNetworks(ctx context.Context) (*types.NetworksResponse, error) {
+	result := &types.NetworksResponse{}
+	if err := c.get(ctx, bridgeV1Prefix+"/networks", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetConfig returns the contract addresses, network IDs and finality settings used by the node
+func (c *BridgeClient) GetConfig(ctx context.Context) (*types.ConfigResponse, error) {
+	result := &types.ConfigResponse{}
+	if err := c.get(ctx, bridgeV1Prefix+"/config", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClaimsReconciliation returns the last report of the claims reconciliation job. It is an admin endpoint,
+// the admin token must be sent in the Authorization header (see Options.Headers)
+func (c *BridgeClient) GetClaimsReconciliation(ctx context.Context) (*types.ClaimsReconciliationReport, error) {
+	result := &types.ClaimsReconciliationReport{}
+	if err := c.get(ctx, bridgeV1Prefix+"/admin/claims-reconciliation", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUSDValueStats returns the value in USD bridged and claimed in a network between the timestamps
+// (0 = the defaults of the bridge service: from the beginning and until now)
+func (c *BridgeClient) GetUSDValueStats(ctx context.Context, networkID uint32,
+	fromTimestamp, toTimestamp uint64) (*types.USDValueStats, error) {
+	query := networkQuery(networkID)
+	if fromTimestamp != 0 {
+		query.Set("from_timestamp", strconv.FormatUint(fromTimestamp, 10))
+	}
+	if toTimestamp != 0 {
+		query.Set("to_timestamp", strconv.FormatUint(toTimestamp, 10))
+	}
+
+	result := &types.USDValueStats{}
+	if err := c.get(ctx, bridgeV1Prefix+"/usd-value-stats", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClaimGasStats returns the gas used and the fees of the claims of a network between the timestamps
+// (0 = the defaults of the bridge service: from the beginning and until now)
+func (c *BridgeClient) GetClaimGasStats(ctx context.Context, networkID uint32,
+	fromTimestamp, toTimestamp uint64) (*types.ClaimGasStats, error) {
+	query := networkQuery(networkID)
+	if fromTimestamp != 0 {
+		query.Set("from_timestamp", strconv.FormatUint(fromTimestamp, 10))
+	}
+	if toTimestamp != 0 {
+		query.Set("to_timestamp", strconv.FormatUint(toTimestamp, 10))
+	}
+
+	result := &types.ClaimGasStats{}
+	if err := c.get(ctx, bridgeV1Prefix+"/claim-gas-stats", query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *BridgeClient) get(ctx context.Context, path string, query url.Values, result interface{}) error {
+	return c.request(ctx, http.MethodGet, path, query, nil, result)
+}
+
+// request sends the request and decodes the response into result. The responses with a status code
+// other than 200 or acceptedStatuses are returned as an HTTPError
+func (c *BridgeClient) request(ctx context.Context, method, path string, query url.Values, body []byte,
+	result interface{}, acceptedStatuses ...int) error {
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	status, resBody, err := c.requester.do(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req, nil
+	}, acceptedStatuses...)
+	if err != nil {
+		return fmt.Errorf("error calling %s %s: %w", method, path, err)
+	}
+	if status != http.StatusOK && !slices.Contains(acceptedStatuses, status) {
+		return fmt.Errorf("error calling %s %s: %w", method, path,
+			&HTTPError{StatusCode: status, Message: errorMessage(resBody)})
+	}
+
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return fmt.Errorf("error decoding the response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+func networkQuery(networkID uint32) url.Values {
+	return url.Values{"network_id": []string{strconv.FormatUint(uint64(networkID), 10)}}
+}
+
+func addPage(query url.Values, page PageRequest) {
+	if page.PageNumber != 0 {
+		query.Set("page_number", strconv.FormatUint(uint64(page.PageNumber), 10))
+	}
+	if page.PageSize != 0 {
+		query.Set("page_size", strconv.FormatUint(uint64(page.PageSize), 10))
+	}
+}
+
+func addNetworkIDs(query url.Values, networkIDs []uint32) {
+	for _, networkID := range networkIDs {
+		query.Add("network_ids", strconv.FormatUint(uint64(networkID), 10))
+	}
+}
+
+func addOptional(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/bridgeservice/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newBridgeServer returns a server that checks the method, path and query of the request
+// and answers with the status and body
+func newBridgeServer(t *testing.T, method, path, query string, status int, body string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, method, r.Method)
+		require.Equal(t, path, r.URL.Path)
+		require.Equal(t, query, r.URL.RawQuery)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestBridgeClientGetBridges(t *testing.T) {
+	depositCount := uint64(5)
+	server := newBridgeServer(t, http.MethodGet, "/bridge/v1/bridges",
+		"decode_metadata=true&deposit_count=5&network_id=1&network_ids=0&network_ids=2&page_size=10",
+		http.StatusOK, `{"bridges":[{"deposit_count":5}],"count":1}`)
+	defer server.Close()
+
+	result, err := NewBridgeClient(server.URL+"/", DefaultOptions()).GetBridges(context.Background(),
+		BridgesRequest{
+			PageRequest:    PageRequest{PageSize: 10},
+			NetworkID:      1,
+			DepositCount:   &depositCount,
+			NetworkIDs:     []uint32{0, 2},
+			DecodeMetadata: true,
+		})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Count)
+	require.Len(t, result.Bridges, 1)
+	require.Equal(t, uint32(5), result.Bridges[0].DepositCount)
+}
+
+func TestBridgeClientGetClaimByGlobalIndex(t *testing.T) {
+	server := newBridgeServer(t, http.MethodGet, "/bridge/v1/claims/18446744073709551616", "",
+		http.StatusNotFound, `{"error":"claim not found"}`)
+	defer server.Close()
+
+	globalIndex := new(big.Int).Lsh(big.NewInt(1), 64)
+	_, err := NewBridgeClient(server.URL, DefaultOptions()).
+		GetClaimByGlobalIndex(context.Background(), globalIndex, false)
+	require.True(t, IsNotFound(err))
+	require.ErrorContains(t, err, "claim not found")
+}
+
+func TestBridgeClientL1InfoTreeIndexForBridge(t *testing.T) {
+	server := newBridgeServer(t, http.MethodGet, "/bridge/v1/l1-info-tree-index",
+		"deposit_count=3&network_id=0", http.StatusOK, "12")
+	defer server.Close()
+
+	index, err := NewBridgeClient(server.URL, DefaultOptions()).
+		L1InfoTreeIndexForBridge(context.Background(), 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint32(12), index)
+}
+
+func TestBridgeClientReadiness(t *testing.T) {
+	server := newBridgeServer(t, http.MethodGet, "/ready", "", http.StatusServiceUnavailable,
+		`{"ready":false,"l1":{"ready":true},"l2":{"network_id":1,"blocks_behind":500,"ready":false}}`)
+	defer server.Close()
+
+	// the 503 of a service that is not ready isn't retried
+	opts := Options{MaxRetries: 3, RetryBackoff: time.Hour}
+	result, err := NewBridgeClient(server.URL, opts).Readiness(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Ready)
+	require.Equal(t, &types.NetworkReadiness{NetworkID: 1, BlocksBehind: 500}, result.L2)
+}
+
+func TestBridgeClientVerifyClaimProof(t *testing.T) {
+	server := newBridgeServer(t, http.MethodPost, "/bridge/v1/verify-claim-proof", "",
+		http.StatusBadRequest, `{"error":"invalid leaf"}`)
+	defer server.Close()
+
+	_, err := NewBridgeClient(server.URL, DefaultOptions()).
+		VerifyClaimProof(context.Background(), &types.VerifyClaimProofRequest{})
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	require.Equal(t, "invalid leaf", httpErr.Message)
+}
+
+func TestBridgeClientGetTxEvents(t *testing.T) {
+	txHash := common.HexToHash("0xabc")
+	server := newBridgeServer(t, http.MethodGet, "/bridge/v1/tx/"+txHash.Hex(), "include_all_fields=true",
+		http.StatusOK, `{"tx_hash":"`+txHash.Hex()+`","found":true,"networks":[{"network_id":0,"bridges":[],
+		"claims":[{"block_num":10}]},{"network_id":1,"bridges":[],"claims":[]}]}`)
+	defer server.Close()
+
+	result, err := NewBridgeClient(server.URL, DefaultOptions()).GetTxEvents(context.Background(), txHash, true)
+	require.NoError(t, err)
+	require.True(t, result.Found)
+	require.Len(t, result.Networks, 2)
+	require.Equal(t, uint64(10), result.Networks[0].Claims[0].BlockNum)
+}
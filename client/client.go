@@ -0,0 +1,181 @@
+// Package client is a typed Go client of the aggsender RPC and the bridge service REST API,
+// so the services of the agglayer ecosystem (monitoring, orchestration...) can integrate
+// with aggkit without duplicating the HTTP plumbing.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 10 * time.Second
+	// maxResponseSize is the maximum size of a response body that is read
+	maxResponseSize = 64 * 1024 * 1024
+	// maxErrorMessageLen is the maximum length of a response body that is added to an error
+	maxErrorMessageLen = 512
+)
+
+// Options are the settings of the HTTP requests of the clients
+type Options struct {
+	// HTTPClient is the client used to send the requests (nil = a client with a 30s timeout)
+	HTTPClient *http.Client
+	// MaxRetries is the number of retries of a request that failed with a transient error:
+	// a network error or the status codes 429, 502, 503 and 504 (0 = no retries)
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, it's doubled on each retry (max 10s)
+	RetryBackoff time.Duration
+	// Headers are added to all the requests (e.g. an API key of the gateway in front of aggkit)
+	Headers map[string]string
+}
+
+// DefaultOptions returns the options with a 30s timeout and 3 retries, starting with a 500ms backoff
+func DefaultOptions() Options {
+	return Options{
+		HTTPClient:   &http.Client{Timeout: defaultTimeout},
+		MaxRetries:   defaultMaxRetries,
+		RetryBackoff: defaultRetryBackoff,
+	}
+}
+
+// HTTPError is returned when the server answers with an unexpected status code
+type HTTPError struct {
+	StatusCode int
+	// Message is the error returned by the server, if any
+	Message string
+}
+
+func (e *HTTPError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("unexpected status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Message)
+}
+
+// RPCError is the error returned by a JSON-RPC method
+type RPCError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("error in the response calling %s (code %d): %s", e.Method, e.Code, e.Message)
+}
+
+// requester sends the HTTP requests, retrying them on transient errors
+type requester struct {
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	headers      map[string]string
+}
+
+func newRequester(opts Options) *requester {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &requester{
+		httpClient:   httpClient,
+		maxRetries:   max(opts.MaxRetries, 0),
+		retryBackoff: opts.RetryBackoff,
+		headers:      opts.Headers,
+	}
+}
+
+// do sends the request built by newRequest, rebuilt on each attempt because the body is consumed,
+// and returns the status code and body of the response. The requests that fail with a transient
+// error are retried, unless the status code is one of finalStatuses
+func (r *requester) do(ctx context.Context, newRequest func(ctx context.Context) (*http.Request, error),
+	finalStatuses ...int) (int, []byte, error) {
+	backoff := r.retryBackoff
+	for attempt := 0; ; attempt++ {
+		status, body, err := r.send(ctx, newRequest)
+		if err == nil && !isTransientStatus(status, finalStatuses) {
+			return status, body, nil
+		}
+		if err == nil {
+			err = &HTTPError{StatusCode: status, Message: errorMessage(body)}
+		}
+		if attempt >= r.maxRetries || ctx.Err() != nil {
+			return 0, nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, nil, fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRetryBackoff) //nolint:mnd
+	}
+}
+
+func (r *requester) send(ctx context.Context,
+	newRequest func(ctx context.Context) (*http.Request, error)) (int, []byte, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to build the request: %w", err)
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("request to %s failed: %w", req.URL.Redacted(), err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read the response of %s: %w", req.URL.Redacted(), err)
+	}
+	return res.StatusCode, body, nil
+}
+
+// isTransientStatus returns true if the request failed with a status code that is worth retrying
+func isTransientStatus(status int, finalStatuses []int) bool {
+	if slices.Contains(finalStatuses, status) {
+		return false
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNotFound returns true if the error is a 404 of the bridge service
+func IsNotFound(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
+
+// errorMessage returns the error of a response body of the bridge service ({"error": "..."}),
+// or the body itself if it's not a JSON error
+func errorMessage(body []byte) string {
+	var errResponse struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResponse); err == nil && errResponse.Error != "" {
+		return errResponse.Error
+	}
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorMessageLen {
+		message = message[:maxErrorMessageLen] + "..."
+	}
+	return message
+}
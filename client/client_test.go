@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRequest(url string) func(ctx context.Context) (*http.Request, error) {
+	return func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	}
+}
+
+func TestRequesterRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	sut := newRequester(Options{MaxRetries: 3, RetryBackoff: time.Millisecond,
+		Headers: map[string]string{"X-Api-Key": "key"}})
+	status, body, err := sut.do(context.Background(), newTestRequest(server.URL))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "ok", string(body))
+	require.Equal(t, int32(3), calls.Load())
+
+	t.Run("too many retries", func(t *testing.T) {
+		calls.Store(-10)
+		sut := newRequester(Options{MaxRetries: 1, RetryBackoff: time.Millisecond,
+			Headers: map[string]string{"X-Api-Key": "key"}})
+		_, _, err := sut.do(context.Background(), newTestRequest(server.URL))
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+		require.Equal(t, int32(-8), calls.Load())
+	})
+
+	t.Run("final status", func(t *testing.T) {
+		calls.Store(-10)
+		sut := newRequester(Options{MaxRetries: 3, RetryBackoff: time.Millisecond,
+			Headers: map[string]string{"X-Api-Key": "key"}})
+		status, _, err := sut.do(context.Background(), newTestRequest(server.URL), http.StatusBadGateway)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadGateway, status)
+		require.Equal(t, int32(-9), calls.Load())
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		calls.Store(-10)
+		sut := newRequester(Options{MaxRetries: 3, RetryBackoff: time.Hour,
+			Headers: map[string]string{"X-Api-Key": "key"}})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, _, err := sut.do(ctx, newTestRequest(server.URL))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "unexpected status code 502")
+	})
+}
+
+func TestErrorMessage(t *testing.T) {
+	require.Equal(t, "invalid network id", errorMessage([]byte(`{"error":"invalid network id"}`)))
+	require.Equal(t, "bad gateway", errorMessage([]byte("bad gateway\n")))
+	require.Len(t, errorMessage(make([]byte, maxErrorMessageLen*2)), maxErrorMessageLen+len("..."))
+}
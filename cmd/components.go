@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/agglayer/aggkit/aggoracle"
+	"github.com/agglayer/aggkit/aggsender"
+	aggsendercfg "github.com/agglayer/aggkit/aggsender/config"
+	"github.com/agglayer/aggkit/aggsender/prover"
+	"github.com/agglayer/aggkit/bridgeservice"
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	aggkitcomponents "github.com/agglayer/aggkit/components"
+)
+
+// the built-in components, the downstream builds register theirs with aggkitcomponents.MustRegister
+func init() {
+	for _, registration := range []aggkitcomponents.Registration{
+		{
+			Name:          aggkitcommon.AGGORACLE,
+			ConfigSection: "AggOracle",
+			Dependencies: []aggkitcomponents.Dependency{
+				aggkitcomponents.L1Client, aggkitcomponents.L2Client,
+				aggkitcomponents.ReorgDetectorL1, aggkitcomponents.ReorgDetectorL2,
+				aggkitcomponents.RollupDataQuerier, aggkitcomponents.L1InfoTreeSync,
+			},
+			New: newAggOracleComponent,
+		},
+		{
+			Name:          aggkitcommon.BRIDGE,
+			ConfigSection: "REST",
+			Dependencies: []aggkitcomponents.Dependency{
+				aggkitcomponents.L1Client, aggkitcomponents.L2Client,
+				aggkitcomponents.ReorgDetectorL1, aggkitcomponents.ReorgDetectorL2,
+				aggkitcomponents.RollupDataQuerier, aggkitcomponents.L1InfoTreeSync,
+				aggkitcomponents.BridgeL1Sync, aggkitcomponents.BridgeL2Sync, aggkitcomponents.LastGERSync,
+			},
+			New: newBridgeComponent,
+		},
+		{
+			Name:          aggkitcommon.AGGSENDER,
+			ConfigSection: "AggSender",
+			Dependencies: []aggkitcomponents.Dependency{
+				aggkitcomponents.L1Client, aggkitcomponents.L2Client,
+				aggkitcomponents.ReorgDetectorL1, aggkitcomponents.ReorgDetectorL2,
+				aggkitcomponents.RollupDataQuerier, aggkitcomponents.L1InfoTreeSync, aggkitcomponents.BridgeL2Sync,
+			},
+			New: newAggSenderComponent,
+		},
+		{
+			Name:          aggkitcommon.AGGCHAINPROOFGEN,
+			ConfigSection: "AggchainProofGen",
+			Dependencies: []aggkitcomponents.Dependency{
+				aggkitcomponents.L1Client, aggkitcomponents.L2Client,
+				aggkitcomponents.ReorgDetectorL1, aggkitcomponents.ReorgDetectorL2,
+				aggkitcomponents.RollupDataQuerier, aggkitcomponents.L1InfoTreeSync, aggkitcomponents.BridgeL2Sync,
+			},
+			New: newAggchainProofGenComponent,
+		},
+		{
+			// it only runs the L1 info tree syncer, that is started as a shared service
+			Name: aggkitcommon.L1INFOTREESYNC,
+			Dependencies: []aggkitcomponents.Dependency{
+				aggkitcomponents.L1Client, aggkitcomponents.ReorgDetectorL1, aggkitcomponents.L1InfoTreeSync,
+			},
+			New: func(aggkitcomponents.SectionDecoder) (aggkitcomponents.Component, error) {
+				return &l1InfoTreeSyncComponent{}, nil
+			},
+		},
+	} {
+		aggkitcomponents.MustRegister(registration)
+	}
+}
+
+// stoppedByContext is embedded by the components that stop when the context of the process is done
+type stoppedByContext struct{}
+
+func (stoppedByContext) Stop(context.Context) error { return nil }
+
+type aggOracleComponent struct {
+	stoppedByContext
+	cfg       aggoracle.Config
+	aggOracle *aggoracle.AggOracle
+}
+
+func newAggOracleComponent(decodeSection aggkitcomponents.SectionDecoder) (aggkitcomponents.Component, error) {
+	c := &aggOracleComponent{}
+	return c, decodeSection(&c.cfg)
+}
+
+func (c *aggOracleComponent) Init(ctx context.Context, s *aggkitcomponents.Services) error {
+	c.aggOracle = createAggoracle(ctx, s.RollupDataQuerier, c.cfg, s.Config.Log, s.L1Client, s.L2Client,
+		s.L1InfoTreeSync)
+	return nil
+}
+
+func (c *aggOracleComponent) Start(ctx context.Context) error {
+	go c.aggOracle.Start(ctx)
+	return nil
+}
+
+type bridgeComponent struct {
+	stoppedByContext
+	cfg           aggkitcommon.RESTConfig
+	bridgeService *bridgeservice.BridgeService
+}
+
+func newBridgeComponent(decodeSection aggkitcomponents.SectionDecoder) (aggkitcomponents.Component, error) {
+	c := &bridgeComponent{}
+	return c, decodeSection(&c.cfg)
+}
+
+func (c *bridgeComponent) Init(_ context.Context, s *aggkitcomponents.Services) error {
+	c.bridgeService = createBridgeService(
+		c.cfg,
+		s.Config.BridgeService,
+		s.Config.Common.NetworkID,
+		s.NetworksRegistry,
+		newBridgeServiceNodeConfig(s.Config),
+		s.L1InfoTreeSync,
+		s.LastGERSync,
+		s.BridgeL1Sync,
+		s.BridgeL2Sync,
+	)
+	return nil
+}
+
+func (c *bridgeComponent) Start(ctx context.Context) error {
+	go c.bridgeService.Start(ctx)
+	return nil
+}
+
+type aggSenderComponent struct {
+	stoppedByContext
+	cfg       aggsendercfg.Config
+	aggSender *aggsender.AggSender
+}
+
+func newAggSenderComponent(decodeSection aggkitcomponents.SectionDecoder) (aggkitcomponents.Component, error) {
+	c := &aggSenderComponent{}
+	return c, decodeSection(&c.cfg)
+}
+
+func (c *aggSenderComponent) Init(ctx context.Context, s *aggkitcomponents.Services) error {
+	aggSender, err := createAggSender(ctx, c.cfg, s.L1Client, s.L1InfoTreeSync, s.BridgeL2Sync, s.L2Client,
+		s.RollupDataQuerier)
+	if err != nil {
+		return err
+	}
+	c.aggSender = aggSender
+	return nil
+}
+
+func (c *aggSenderComponent) Start(ctx context.Context) error {
+	go c.aggSender.Start(ctx)
+	return nil
+}
+
+func (c *aggSenderComponent) GetRPCServices() []jRPC.Service {
+	return c.aggSender.GetRPCServices()
+}
+
+type aggchainProofGenComponent struct {
+	stoppedByContext
+	cfg              prover.Config
+	aggchainProofGen *prover.AggchainProofGenerationTool
+}
+
+func newAggchainProofGenComponent(
+	decodeSection aggkitcomponents.SectionDecoder) (aggkitcomponents.Component, error) {
+	c := &aggchainProofGenComponent{}
+	return c, decodeSection(&c.cfg)
+}
+
+func (c *aggchainProofGenComponent) Init(ctx context.Context, s *aggkitcomponents.Services) error {
+	aggchainProofGen, err := createAggchainProofGen(ctx, c.cfg, s.L1Client, s.L2Client, s.L1InfoTreeSync,
+		s.BridgeL2Sync)
+	if err != nil {
+		return err
+	}
+	c.aggchainProofGen = aggchainProofGen
+	return nil
+}
+
+func (c *aggchainProofGenComponent) Start(ctx context.Context) error {
+	return c.aggchainProofGen.StartService(ctx)
+}
+
+func (c *aggchainProofGenComponent) GetRPCServices() []jRPC.Service {
+	return c.aggchainProofGen.GetRPCServices()
+}
+
+type l1InfoTreeSyncComponent struct {
+	stoppedByContext
+}
+
+func (*l1InfoTreeSyncComponent) Init(context.Context, *aggkitcomponents.Services) error { return nil }
+
+func (*l1InfoTreeSyncComponent) Start(context.Context) error { return nil }
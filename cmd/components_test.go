@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	aggkitcomponents "github.com/agglayer/aggkit/components"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinComponentsDependencies(t *testing.T) {
+	l1Syncers := []aggkitcomponents.Dependency{
+		aggkitcomponents.L1Client, aggkitcomponents.ReorgDetectorL1, aggkitcomponents.L1InfoTreeSync,
+	}
+	l2Syncers := append([]aggkitcomponents.Dependency{
+		aggkitcomponents.L2Client, aggkitcomponents.ReorgDetectorL2, aggkitcomponents.RollupDataQuerier,
+	}, l1Syncers...)
+	tests := []struct {
+		component string
+		expected  []aggkitcomponents.Dependency
+	}{
+		{component: aggkitcommon.L1INFOTREESYNC, expected: l1Syncers},
+		{component: aggkitcommon.AGGORACLE, expected: l2Syncers},
+		{component: aggkitcommon.AGGSENDER, expected: append([]aggkitcomponents.Dependency{
+			aggkitcomponents.BridgeL2Sync}, l2Syncers...)},
+		{component: aggkitcommon.AGGCHAINPROOFGEN, expected: append([]aggkitcomponents.Dependency{
+			aggkitcomponents.BridgeL2Sync}, l2Syncers...)},
+		{component: aggkitcommon.BRIDGE, expected: append([]aggkitcomponents.Dependency{
+			aggkitcomponents.BridgeL1Sync, aggkitcomponents.BridgeL2Sync, aggkitcomponents.LastGERSync,
+		}, l2Syncers...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.component, func(t *testing.T) {
+			needed, err := aggkitcomponents.Default().Dependencies([]string{tt.component})
+			require.NoError(t, err)
+			expected := make(map[aggkitcomponents.Dependency]bool, len(tt.expected))
+			for _, dependency := range tt.expected {
+				expected[dependency] = true
+			}
+			require.Equal(t, expected, needed)
+		})
+	}
+}
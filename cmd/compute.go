@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagMainnetExitRoot    = "mainnet-exit-root"
+	flagRollupExitRoot     = "rollup-exit-root"
+	flagMainnetFlag        = "mainnet-flag"
+	flagRollupIndex        = "rollup-index"
+	flagLeafIndex          = "leaf-index"
+	flagGlobalIndex        = "global-index"
+	flagLeafType           = "leaf-type"
+	flagOriginNetwork      = "origin-network"
+	flagOriginAddress      = "origin-address"
+	flagDestinationNetwork = "destination-network"
+	flagDestinationAddress = "destination-address"
+	flagAmount             = "amount"
+	flagMetadata           = "metadata"
+)
+
+// computeCommand returns the command with the conversions of the values of the bridge and the
+// exit trees, computed with the same code as the syncers
+func computeCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "compute",
+		Usage: "Compute GERs, global indexes and bridge leaf hashes from raw inputs",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "ger",
+				Usage:  "Compute the global exit root from the mainnet and rollup exit roots",
+				Action: computeGERCmd,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: flagMainnetExitRoot, Usage: "Mainnet exit root (hex)", Required: true},
+					&cli.StringFlag{Name: flagRollupExitRoot, Usage: "Rollup exit root (hex)", Required: true},
+				},
+			},
+			{
+				Name:   "global-index",
+				Usage:  "Compute the global index of a deposit from its mainnet flag, rollup index and leaf index",
+				Action: computeGlobalIndexCmd,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: flagMainnetFlag, Usage: "The deposit is of the L1 (mainnet) exit tree"},
+					&cli.UintFlag{Name: flagRollupIndex, Usage: "Index of the rollup (ignored for mainnet)"},
+					&cli.UintFlag{Name: flagLeafIndex, Usage: "Index of the leaf in the local exit tree (deposit count)"},
+				},
+			},
+			{
+				Name:   "decode-global-index",
+				Usage:  "Decode a global index into its mainnet flag, rollup index and leaf index",
+				Action: decodeGlobalIndexCmd,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: flagGlobalIndex, Usage: "Global index (decimal or 0x hex)", Required: true},
+				},
+			},
+			{
+				Name:   "leaf-hash",
+				Usage:  "Compute the hash of the leaf of a deposit in the local exit tree",
+				Action: computeLeafHashCmd,
+				Flags: []cli.Flag{
+					&cli.UintFlag{Name: flagLeafType, Usage: "Leaf type: 0 = asset, 1 = message"},
+					&cli.UintFlag{Name: flagOriginNetwork, Usage: "Origin network of the token (or message)"},
+					&cli.StringFlag{Name: flagOriginAddress, Usage: "Origin address of the token (or message sender)",
+						Value: common.Address{}.Hex()},
+					&cli.UintFlag{Name: flagDestinationNetwork, Usage: "Destination network"},
+					&cli.StringFlag{Name: flagDestinationAddress, Usage: "Destination address", Required: true},
+					&cli.StringFlag{Name: flagAmount, Usage: "Amount (decimal or 0x hex)", Value: "0"},
+					&cli.StringFlag{Name: flagMetadata, Usage: "Metadata (0x hex)"},
+				},
+			},
+		},
+	}
+}
+
+func computeGERCmd(cliCtx *cli.Context) error {
+	mer, err := parseHash(flagMainnetExitRoot, cliCtx.String(flagMainnetExitRoot))
+	if err != nil {
+		return err
+	}
+	rer, err := parseHash(flagRollupExitRoot, cliCtx.String(flagRollupExitRoot))
+	if err != nil {
+		return err
+	}
+	leaf := &l1infotreesync.L1InfoTreeLeaf{MainnetExitRoot: mer, RollupExitRoot: rer}
+	fmt.Fprintf(cliCtx.App.Writer, "Global exit root: %s\n", leaf.GetGlobalExitRoot().Hex())
+	return nil
+}
+
+func computeGlobalIndexCmd(cliCtx *cli.Context) error {
+	rollupIndex, err := parseUint32(flagRollupIndex, cliCtx.Uint(flagRollupIndex))
+	if err != nil {
+		return err
+	}
+	leafIndex, err := parseUint32(flagLeafIndex, cliCtx.Uint(flagLeafIndex))
+	if err != nil {
+		return err
+	}
+	mainnetFlag := cliCtx.Bool(flagMainnetFlag)
+	if mainnetFlag && rollupIndex != 0 {
+		return fmt.Errorf("--%s must be 0 with --%s", flagRollupIndex, flagMainnetFlag)
+	}
+	globalIndex := bridgesync.GenerateGlobalIndex(mainnetFlag, rollupIndex, leafIndex)
+	fmt.Fprintf(cliCtx.App.Writer, "Global index: %s (%s)\n", globalIndex.String(), hexutil.EncodeBig(globalIndex))
+	return nil
+}
+
+func decodeGlobalIndexCmd(cliCtx *cli.Context) error {
+	globalIndex, err := parseBigInt(flagGlobalIndex, cliCtx.String(flagGlobalIndex))
+	if err != nil {
+		return err
+	}
+	mainnetFlag, rollupIndex, leafIndex, err := bridgesync.DecodeGlobalIndex(globalIndex)
+	if err != nil {
+		return fmt.Errorf("error decoding the global index %s: %w", globalIndex.String(), err)
+	}
+	// the global index is only valid if encoding its parts gives it back, otherwise it has
+	// unused bits set and the contracts would reject it
+	if encoded := bridgesync.GenerateGlobalIndex(mainnetFlag, rollupIndex, leafIndex); encoded.Cmp(globalIndex) != 0 {
+		return fmt.Errorf("invalid global index %s: it has bits set outside of the mainnet flag, rollup index "+
+			"and leaf index", globalIndex.String())
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "Mainnet flag: %t\nRollup index: %d\nLeaf index: %d\n",
+		mainnetFlag, rollupIndex, leafIndex)
+	return nil
+}
+
+func computeLeafHashCmd(cliCtx *cli.Context) error {
+	leafType := cliCtx.Uint(flagLeafType)
+	if leafType > 1 {
+		return fmt.Errorf("invalid --%s %d: expected 0 (asset) or 1 (message)", flagLeafType, leafType)
+	}
+	originNetwork, err := parseUint32(flagOriginNetwork, cliCtx.Uint(flagOriginNetwork))
+	if err != nil {
+		return err
+	}
+	destinationNetwork, err := parseUint32(flagDestinationNetwork, cliCtx.Uint(flagDestinationNetwork))
+	if err != nil {
+		return err
+	}
+	originAddress, err := parseAddress(flagOriginAddress, cliCtx.String(flagOriginAddress))
+	if err != nil {
+		return err
+	}
+	destinationAddress, err := parseAddress(flagDestinationAddress, cliCtx.String(flagDestinationAddress))
+	if err != nil {
+		return err
+	}
+	amount, err := parseBigInt(flagAmount, cliCtx.String(flagAmount))
+	if err != nil {
+		return err
+	}
+	var metadata []byte
+	if m := cliCtx.String(flagMetadata); m != "" && m != "0x" {
+		if metadata, err = hexutil.Decode(m); err != nil {
+			return fmt.Errorf("invalid --%s %s: %w", flagMetadata, m, err)
+		}
+	}
+
+	bridge := &bridgesync.Bridge{
+		LeafType:           uint8(leafType),
+		OriginNetwork:      originNetwork,
+		OriginAddress:      originAddress,
+		DestinationNetwork: destinationNetwork,
+		DestinationAddress: destinationAddress,
+		Amount:             amount,
+		Metadata:           metadata,
+	}
+	fmt.Fprintf(cliCtx.App.Writer, "Leaf hash: %s\n", bridge.Hash().Hex())
+	return nil
+}
+
+func parseHash(flag, value string) (common.Hash, error) {
+	b, err := hexutil.Decode(value)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid --%s %s: expected a 0x prefixed %d bytes hex", flag, value,
+			common.HashLength)
+	}
+	return common.BytesToHash(b), nil
+}
+
+func parseAddress(flag, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid --%s %s: expected a hex address", flag, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func parseBigInt(flag, value string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(strings.ToLower(value), 0)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid --%s %s: expected a non negative decimal or 0x hex number", flag, value)
+	}
+	return v, nil
+}
+
+func parseUint32(flag string, value uint) (uint32, error) {
+	if uint64(value) > uint64(^uint32(0)) {
+		return 0, fmt.Errorf("invalid --%s %d: it must fit in 32 bits", flag, value)
+	}
+	return uint32(value), nil
+}
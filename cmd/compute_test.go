@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runCompute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	app := cli.NewApp()
+	app.Writer = out
+	app.Commands = []*cli.Command{computeCommand()}
+	err := app.Run(append([]string{"aggkit", "compute"}, args...))
+	return out.String(), err
+}
+
+func TestComputeGER(t *testing.T) {
+	mer := common.HexToHash("0x01")
+	rer := common.HexToHash("0x02")
+	expected := crypto.Keccak256Hash(mer.Bytes(), rer.Bytes())
+
+	out, err := runCompute(t, "ger", "--mainnet-exit-root", mer.Hex(), "--rollup-exit-root", rer.Hex())
+	require.NoError(t, err)
+	require.Equal(t, "Global exit root: "+expected.Hex()+"\n", out)
+
+	_, err = runCompute(t, "ger", "--mainnet-exit-root", "0x01", "--rollup-exit-root", rer.Hex())
+	require.ErrorContains(t, err, "invalid --mainnet-exit-root")
+}
+
+func TestComputeGlobalIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		err      string
+	}{
+		{name: "mainnet", args: []string{"--mainnet-flag", "--leaf-index", "5"},
+			expected: "Global index: 18446744073709551621 (0x10000000000000005)\n"},
+		{name: "rollup", args: []string{"--rollup-index", "1", "--leaf-index", "5"},
+			expected: "Global index: 4294967301 (0x100000005)\n"},
+		{name: "mainnet with rollup index", args: []string{"--mainnet-flag", "--rollup-index", "1"},
+			err: "--rollup-index must be 0 with --mainnet-flag"},
+		{name: "leaf index overflow", args: []string{"--leaf-index", "4294967296"},
+			err: "it must fit in 32 bits"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := runCompute(t, append([]string{"global-index"}, tt.args...)...)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestDecodeGlobalIndex(t *testing.T) {
+	tests := []struct {
+		globalIndex string
+		expected    string
+		err         string
+	}{
+		{globalIndex: "18446744073709551621", expected: "Mainnet flag: true\nRollup index: 0\nLeaf index: 5\n"},
+		{globalIndex: "0x100000005", expected: "Mainnet flag: false\nRollup index: 1\nLeaf index: 5\n"},
+		{globalIndex: "0x30000000000000005", err: "it has bits set outside"},
+		{globalIndex: "abc", err: "invalid --global-index"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.globalIndex, func(t *testing.T) {
+			out, err := runCompute(t, "decode-global-index", "--global-index", tt.globalIndex)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestComputeLeafHash(t *testing.T) {
+	// vectors of tree/testvectors/leaf-vectors.json
+	out, err := runCompute(t, "leaf-hash",
+		"--origin-network", "1",
+		"--origin-address", "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+		"--destination-network", "0",
+		"--destination-address", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"--amount", "0x8ac7230489e80000",
+		"--metadata", "0x12345670")
+	require.NoError(t, err)
+	require.Equal(t, "Leaf hash: 0x315fee1aa202bf4a6bd0fde560c89be90b6e6e2aaf92dc5e8d118209abc3410f\n", out)
+
+	out, err = runCompute(t, "leaf-hash",
+		"--origin-address", "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+		"--destination-network", "1",
+		"--destination-address", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"--amount", "10000000000000000000")
+	require.NoError(t, err)
+	require.Equal(t, "Leaf hash: 0xa4bfa0908dc7b06d98da4309f859023d6947561bc19bc00d77f763dea1a0b9f5\n", out)
+
+	_, err = runCompute(t, "leaf-hash", "--leaf-type", "2", "--destination-address", "0x01")
+	require.ErrorContains(t, err, "invalid --leaf-type")
+	_, err = runCompute(t, "leaf-hash", "--destination-address", "0xzz")
+	require.ErrorContains(t, err, "invalid --destination-address")
+}
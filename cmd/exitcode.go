@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+)
+
+// exitCodeOf returns the exit code of the class of the error (see aggkitcommon.ExitCode). The errors
+// not classified by their ExitError are checked for a corrupted DB
+func exitCodeOf(err error) aggkitcommon.ExitCode {
+	code := aggkitcommon.ExitCodeOf(err)
+	if code == aggkitcommon.ExitCodeUnexpected && db.IsCorruption(err) {
+		return aggkitcommon.ExitCodeDBCorruption
+	}
+	return code
+}
+
+// fatal logs the error and exits with the exit code of its class, instead of the 1 of log.Fatal
+func fatal(err error) {
+	code := exitCodeOf(err)
+	log.Error(fmt.Sprintf("exit code %d (%s): ", int(code), code), err)
+	os.Exit(int(code))
+}
+
+// fatalf is fatal with a formatted error (the error to classify must be wrapped with %w)
+func fatalf(format string, args ...any) {
+	fatal(fmt.Errorf(format, args...))
+}
+
+// configError classifies the error as an invalid configuration
+func configError(err error) error {
+	return aggkitcommon.NewExitError(aggkitcommon.ExitCodeConfig, err)
+}
+
+// rpcError classifies the error as a failure of an RPC node
+func rpcError(err error) error {
+	return aggkitcommon.NewExitError(aggkitcommon.ExitCodeFatalRPC, err)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	sqlite "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	errCorrupt := sqlite.Error{Code: sqlite.ErrCorrupt}
+	require.Equal(t, aggkitcommon.ExitCodeOK, exitCodeOf(nil))
+	require.Equal(t, aggkitcommon.ExitCodeUnexpected, exitCodeOf(errors.New("unittest")))
+	require.Equal(t, aggkitcommon.ExitCodeConfig, exitCodeOf(configError(errors.New("unittest"))))
+	require.Equal(t, aggkitcommon.ExitCodeFatalRPC, exitCodeOf(rpcError(errors.New("unittest"))))
+	require.Equal(t, aggkitcommon.ExitCodeDBCorruption,
+		exitCodeOf(fmt.Errorf("error creating bridgeSyncL1: %w", errCorrupt)))
+	// the classified errors keep their class
+	require.Equal(t, aggkitcommon.ExitCodeConfig, exitCodeOf(configError(errCorrupt)))
+}
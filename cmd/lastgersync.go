@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/etherman"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/agglayer/aggkit/lastgersync"
+	"github.com/agglayer/aggkit/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagFromBlock = "from-block"
+	flagToBlock   = "to-block"
+)
+
+func lastGERSyncCommand(flags []cli.Flag) *cli.Command {
+	return &cli.Command{
+		Name:  "lastgersync",
+		Usage: "Maintenance tools of the lastgersync DB",
+		Subcommands: []*cli.Command{
+			{
+				Name: "backfill",
+				Usage: "Re-scan a block range of L2 for the injected GERs and insert the ones missing in the DB " +
+					"(PP mode). The node must be stopped",
+				Action: lastGERSyncBackfillCmd,
+				Flags: append([]cli.Flag{
+					&cli.Uint64Flag{Name: flagFromBlock, Usage: "First L2 block of the range", Required: true},
+					&cli.Uint64Flag{Name: flagToBlock, Usage: "Last L2 block of the range", Required: true},
+				}, flags...),
+			},
+		},
+	}
+}
+
+func lastGERSyncBackfillCmd(cliCtx *cli.Context) error {
+	cfg, err := config.Load(cliCtx)
+	if err != nil {
+		return configError(err)
+	}
+	log.Init(cfg.Log)
+
+	if cfg.LastGERSync.SyncMode != lastgersync.PP {
+		return fmt.Errorf("the backfill requires the %s sync mode, the configured one is %s",
+			lastgersync.PP, cfg.LastGERSync.SyncMode)
+	}
+	l2Client, err := etherman.NewRPCClient(cfg.Common.L2RPC)
+	if err != nil {
+		return fmt.Errorf("failed to create client for L2 using URL: %s: %w", cfg.Common.L2RPC.URL, err)
+	}
+	l1InfoTreeSync, err := l1infotreesync.NewReadOnly(cfg.L1InfoTreeSync.DBPath, cfg.L1InfoTreeSync.StorageTuning)
+	if err != nil {
+		return fmt.Errorf("failed to open the L1 info tree DB: %w", err)
+	}
+
+	result, err := lastgersync.Backfill(cliCtx.Context, cfg.LastGERSync.DBPath, cfg.LastGERSync.StorageTuning,
+		l2Client, cfg.LastGERSync.GlobalExitRootL2Addr, l1InfoTreeSync,
+		cliCtx.Uint64(flagFromBlock), cliCtx.Uint64(flagToBlock))
+	if err != nil {
+		return errors.Join(err, l1InfoTreeSync.Close())
+	}
+	fmt.Printf("Backfill of L2 blocks [%d, %d]: %s\n", cliCtx.Uint64(flagFromBlock), cliCtx.Uint64(flagToBlock), result)
+	return l1InfoTreeSync.Close()
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/agglayer/aggkit"
 	"github.com/agglayer/aggkit/common"
+	aggkitcomponents "github.com/agglayer/aggkit/components"
 	"github.com/agglayer/aggkit/config"
-	"github.com/agglayer/aggkit/log"
 	"github.com/urfave/cli/v2"
 )
 
@@ -21,7 +23,7 @@ var (
 	configFileFlag = cli.StringSliceFlag{
 		Name:     config.FlagCfg,
 		Aliases:  []string{"c"},
-		Usage:    "Configuration file(s)",
+		Usage:    "Configuration file(s), local paths or http(s)://, s3:// and consul:// URLs",
 		Required: false,
 	}
 	componentsFlag = cli.StringSliceFlag{
@@ -48,18 +50,34 @@ var (
 		Usage:    "Allow that config-files contains deprecated fields",
 		Required: false,
 	}
+	configCacheDirFlag = cli.StringFlag{
+		Name:     config.FlagCfgCacheDir,
+		Usage:    "Dir where the remote config files are cached, used if the remote source is unreachable",
+		Required: false,
+	}
+	configPublicKeyFlag = cli.StringFlag{
+		Name:     config.FlagCfgPublicKey,
+		Usage:    "Hex encoded ed25519 public key that must sign the remote config files (<url>.sig)",
+		Required: false,
+	}
 )
 
 func main() {
 	app := cli.NewApp()
 	app.Name = appName
 	app.Version = aggkit.Version
+	app.Description = common.ExitCodesHelp()
+	// the components are registered by the init functions, including the ones of the downstream builds
+	componentsFlag.Usage = fmt.Sprintf("List of components to run (%s)",
+		strings.Join(aggkitcomponents.Default().Names(), ", "))
 	flags := []cli.Flag{
 		&configFileFlag,
 		&componentsFlag,
 		&saveConfigFlag,
 		&disableDefaultConfigVars,
 		&allowDeprecatedFields,
+		&configCacheDirFlag,
+		&configPublicKeyFlag,
 	}
 	app.Commands = []*cli.Command{
 		{
@@ -72,14 +90,30 @@ func main() {
 			Name:    "run",
 			Aliases: []string{},
 			Usage:   "Run the aggkit client",
-			Action:  start,
-			Flags:   flags,
+			// the exit codes are documented in the help of the command used by the orchestration
+			Description: common.ExitCodesHelp(),
+			Action:      start,
+			Flags:       flags,
 		},
+		migrateCommand([]cli.Flag{
+			&configFileFlag,
+			&disableDefaultConfigVars,
+			&allowDeprecatedFields,
+			&configCacheDirFlag,
+			&configPublicKeyFlag,
+		}),
+		computeCommand(),
+		lastGERSyncCommand([]cli.Flag{
+			&configFileFlag,
+			&disableDefaultConfigVars,
+			&allowDeprecatedFields,
+			&configCacheDirFlag,
+			&configPublicKeyFlag,
+		}),
 	}
 
 	err := app.Run(os.Args)
 	if err != nil {
-		log.Fatal(err)
-		os.Exit(1)
+		fatal(err)
 	}
 }
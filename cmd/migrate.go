@@ -0,0 +1,205 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	aggsendermigrations "github.com/agglayer/aggkit/aggsender/db/migrations"
+	bridgesyncmigrations "github.com/agglayer/aggkit/bridgesync/migrations"
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/db"
+	dbtypes "github.com/agglayer/aggkit/db/types"
+	l1infotreesyncmigrations "github.com/agglayer/aggkit/l1infotreesync/migrations"
+	lastgersyncmigrations "github.com/agglayer/aggkit/lastgersync/migrations"
+	"github.com/agglayer/aggkit/log"
+	reorgdetectormigrations "github.com/agglayer/aggkit/reorgdetector/migrations"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagDB    = "db"
+	flagSteps = "steps"
+)
+
+// migratableDB is a sqlite DB of aggkit that can be migrated with the migrate command
+type migratableDB struct {
+	path       func(cfg *config.Config) string
+	migrations func() []dbtypes.Migration
+}
+
+var migratableDBs = map[string]migratableDB{
+	"aggsender": {
+		path:       func(cfg *config.Config) string { return cfg.AggSender.StoragePath },
+		migrations: func() []dbtypes.Migration { return aggsendermigrations.Migrations },
+	},
+	"bridgel1sync": {
+		path:       func(cfg *config.Config) string { return cfg.BridgeL1Sync.DBPath },
+		migrations: bridgesyncmigrations.GetMigrations,
+	},
+	"bridgel2sync": {
+		path:       func(cfg *config.Config) string { return cfg.BridgeL2Sync.DBPath },
+		migrations: bridgesyncmigrations.GetMigrations,
+	},
+	"l1infotreesync": {
+		path:       func(cfg *config.Config) string { return cfg.L1InfoTreeSync.DBPath },
+		migrations: l1infotreesyncmigrations.GetMigrations,
+	},
+	"lastgersync": {
+		path:       func(cfg *config.Config) string { return cfg.LastGERSync.DBPath },
+		migrations: lastgersyncmigrations.GetMigrations,
+	},
+	"reorgdetectorl1": {
+		path:       func(cfg *config.Config) string { return cfg.ReorgDetectorL1.DBPath },
+		migrations: reorgdetectormigrations.GetMigrations,
+	},
+	"reorgdetectorl2": {
+		path:       func(cfg *config.Config) string { return cfg.ReorgDetectorL2.DBPath },
+		migrations: reorgdetectormigrations.GetMigrations,
+	},
+}
+
+func migratableDBNames() []string {
+	names := make([]string, 0, len(migratableDBs))
+	for name := range migratableDBs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func migrateCommand(flags []cli.Flag) *cli.Command {
+	dbFlag := &cli.StringFlag{
+		Name:     flagDB,
+		Usage:    "DB to migrate: " + strings.Join(migratableDBNames(), ", "),
+		Required: true,
+	}
+	stepsFlag := &cli.IntFlag{
+		Name:  flagSteps,
+		Usage: "Number of migrations to apply (0 = all the pending ones)",
+	}
+	downStepsFlag := &cli.IntFlag{
+		Name:  flagSteps,
+		Usage: "Number of migrations to roll back",
+		Value: 1,
+	}
+	withFlags := func(extra ...cli.Flag) []cli.Flag {
+		return append(append([]cli.Flag{dbFlag}, extra...), flags...)
+	}
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Manage the schema migrations of the aggkit DBs",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "status",
+				Usage:  "Show the schema version and the applied and pending migrations of a DB",
+				Action: migrateStatusCmd,
+				Flags:  withFlags(),
+			},
+			{
+				Name:   "up",
+				Usage:  "Apply the pending migrations of a DB",
+				Action: migrateUpCmd,
+				Flags:  withFlags(stepsFlag),
+			},
+			{
+				Name:   "down",
+				Usage:  "Roll back the last migrations of a DB",
+				Action: migrateDownCmd,
+				Flags:  withFlags(downStepsFlag),
+			},
+		},
+	}
+}
+
+func migrateStatusCmd(cliCtx *cli.Context) error {
+	database, migrations, err := openMigratableDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	status, err := db.GetMigrationsStatus(database, migrations)
+	if err != nil {
+		return err
+	}
+	version, err := db.GetSchemaVersion(database)
+	switch {
+	case err == nil:
+		fmt.Printf("Schema version: %d (last migration: %s, migrated by aggkit %s at %s)\n",
+			version.Version, version.LastMigration, version.AggkitVersion,
+			time.Unix(version.UpdatedAt, 0).UTC().Format(time.RFC3339))
+	case errors.Is(err, db.ErrNotFound):
+		fmt.Println("Schema version: none")
+	default:
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if s.Unknown {
+			appliedAt += " (unknown to aggkit " + cliCtx.App.Version + ")"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", s.ID, appliedAt)
+	}
+	return w.Flush()
+}
+
+func migrateUpCmd(cliCtx *cli.Context) error {
+	return runMigrateCmd(cliCtx, migrate.Up)
+}
+
+func migrateDownCmd(cliCtx *cli.Context) error {
+	if cliCtx.Int(flagSteps) <= 0 {
+		return fmt.Errorf("--%s must be greater than 0 to roll back migrations", flagSteps)
+	}
+	return runMigrateCmd(cliCtx, migrate.Down)
+}
+
+func runMigrateCmd(cliCtx *cli.Context, dir migrate.MigrationDirection) error {
+	steps := cliCtx.Int(flagSteps)
+	if steps < 0 {
+		return fmt.Errorf("--%s can't be negative", flagSteps)
+	}
+	database, migrations, err := openMigratableDB(cliCtx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	return db.MigrateDB(log.GetDefaultLogger(), database, migrations, dir, steps)
+}
+
+func openMigratableDB(cliCtx *cli.Context) (*sql.DB, []dbtypes.Migration, error) {
+	name := cliCtx.String(flagDB)
+	mdb, ok := migratableDBs[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown DB %q, expected one of: %s", name, strings.Join(migratableDBNames(), ", "))
+	}
+	cfg, err := config.Load(cliCtx)
+	if err != nil {
+		return nil, nil, configError(err)
+	}
+	log.Init(cfg.Log)
+
+	dbPath := mdb.path(cfg)
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, nil, fmt.Errorf("DB %s (%s) not found: %w", name, dbPath, err)
+	}
+	database, err := db.NewSQLiteDB(dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening DB %s (%s): %w", name, dbPath, err)
+	}
+	return database, mdb.migrations(), nil
+}
@@ -2,12 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonrollupmanager"
@@ -18,33 +22,41 @@ import (
 	agglayer "github.com/agglayer/aggkit/agglayer/grpc"
 	"github.com/agglayer/aggkit/aggoracle"
 	"github.com/agglayer/aggkit/aggoracle/chaingersender"
+	aggoracletypes "github.com/agglayer/aggkit/aggoracle/types"
 	"github.com/agglayer/aggkit/aggsender"
 	aggsendercfg "github.com/agglayer/aggkit/aggsender/config"
 	"github.com/agglayer/aggkit/aggsender/prover"
+	"github.com/agglayer/aggkit/balancemonitor"
 	"github.com/agglayer/aggkit/bridgeservice"
 	"github.com/agglayer/aggkit/bridgesync"
 	aggkitcommon "github.com/agglayer/aggkit/common"
+	aggkitcomponents "github.com/agglayer/aggkit/components"
 	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/etherman"
 	ethermanconfig "github.com/agglayer/aggkit/etherman/config"
 	"github.com/agglayer/aggkit/healthcheck"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/lastgersync"
 	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/networks"
 	"github.com/agglayer/aggkit/pprof"
 	"github.com/agglayer/aggkit/prometheus"
 	"github.com/agglayer/aggkit/reorgdetector"
+	"github.com/agglayer/aggkit/sync"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
 )
 
+// componentsStopTimeout is the max time waited for the components to stop on the graceful shutdown
+const componentsStopTimeout = 30 * time.Second
+
 func start(cliCtx *cli.Context) error {
 	cfg, err := config.Load(cliCtx)
 	if err != nil {
-		return err
+		return configError(err)
 	}
 
 	log.Init(cfg.Log)
@@ -59,91 +71,89 @@ func start(cliCtx *cli.Context) error {
 	if cfg.Prometheus.Enabled {
 		prometheus.Init()
 	}
+	errorBudget, err := healthcheck.NewErrorBudget(cfg.HealthCheck.ErrorBudget)
+	if err != nil {
+		return configError(fmt.Errorf("invalid HealthCheck config: %w", err))
+	}
+	healthcheck.SetDefaultErrorBudget(errorBudget)
+
+	networksRegistry, err := createNetworksRegistry(cfg)
+	if err != nil {
+		return configError(err)
+	}
 	components := cliCtx.StringSlice(config.FlagComponents)
-	l1Client := runL1ClientIfNeeded(components, cfg.L1NetworkConfig.URL)
-	l2Client := runL2ClientIfNeeded(components, cfg.Common.L2RPC)
-	reorgDetectorL1, errChanL1 := runReorgDetectorL1IfNeeded(cliCtx.Context, components, l1Client, &cfg.ReorgDetectorL1)
+	registry := aggkitcomponents.Default()
+	componentsRunner, err := registry.NewRunner(log.WithFields("module", "components"), cfg, components)
+	if err != nil {
+		return configError(fmt.Errorf("%w (registered components: %v)", err, registry.Names()))
+	}
+	needed, err := registry.Dependencies(components)
+	if err != nil {
+		return configError(err)
+	}
+	l1Client := runL1ClientIfNeeded(needed, cfg.L1NetworkConfig)
+	l2Client := runL2ClientIfNeeded(needed, cfg.Common.L2RPC)
+	if verifyOnly, err := runVerificationsIfNeeded(cliCtx.Context, cfg, l1Client, l2Client); verifyOnly {
+		return err
+	}
+	reorgDetectorL1, errChanL1 := runReorgDetectorL1IfNeeded(cliCtx.Context, needed, l1Client, &cfg.ReorgDetectorL1)
 	go func() {
 		if err := <-errChanL1; err != nil {
-			log.Fatal("Error from ReorgDetectorL1: ", err)
+			fatalf("error from ReorgDetectorL1: %w", err)
 		}
 	}()
 
-	reorgDetectorL2, errChanL2 := runReorgDetectorL2IfNeeded(cliCtx.Context, components, l2Client, &cfg.ReorgDetectorL2)
+	reorgDetectorL2, errChanL2 := runReorgDetectorL2IfNeeded(cliCtx.Context, needed, l2Client, &cfg.ReorgDetectorL2)
 	go func() {
 		if err := <-errChanL2; err != nil {
-			log.Fatal("Error from ReorgDetectorL2: ", err)
+			fatalf("error from ReorgDetectorL2: %w", err)
 		}
 	}()
 
-	rollupDataQuerier, err := createRollupDataQuerier(cfg.L1NetworkConfig, components)
+	rollupDataQuerier, err := createRollupDataQuerier(cfg.L1NetworkConfig, needed)
 	if err != nil {
-		return fmt.Errorf("failed to create etherman client: %w", err)
+		return rpcError(fmt.Errorf("failed to create etherman client: %w", err))
 	}
 
-	l1InfoTreeSync := runL1InfoTreeSyncerIfNeeded(cliCtx.Context, components, *cfg, l1Client, reorgDetectorL1)
-	l1BridgeSync := runBridgeSyncL1IfNeeded(cliCtx.Context, components, cfg.BridgeL1Sync, reorgDetectorL1,
-		l1Client, 0)
-	l2BridgeSync := runBridgeSyncL2IfNeeded(cliCtx.Context, components, cfg.BridgeL2Sync, reorgDetectorL2,
-		l2Client, rollupDataQuerier.RollupID)
+	l1InfoTreeSync := runL1InfoTreeSyncerIfNeeded(cliCtx.Context, needed, *cfg, l1Client, reorgDetectorL1)
+	l1BridgeSync := runBridgeSyncL1IfNeeded(cliCtx.Context, needed, cfg.BridgeL1Sync, reorgDetectorL1,
+		l1Client, networksRegistry.L1().ID)
+	l2BridgeSync := runBridgeSyncL2IfNeeded(cliCtx.Context, needed, cfg.BridgeL2Sync, reorgDetectorL2,
+		l2Client, rollupDataQuerier, fastSyncAggSenderConfig(cfg, components))
+	if err := checkL2NetworkID(cfg.Common.NetworkID, l2BridgeSync); err != nil {
+		fatal(configError(err))
+	}
 	lastGERSync := runLastGERSyncIfNeeded(
-		cliCtx.Context, components, cfg.LastGERSync, reorgDetectorL2, l2Client, l1InfoTreeSync,
+		cliCtx.Context, needed, cfg.LastGERSync, reorgDetectorL2, l2Client, l1InfoTreeSync,
 	)
-	var rpcServices []jRPC.Service
-	for _, component := range components {
-		switch component {
-		case aggkitcommon.AGGORACLE:
-			aggOracle := createAggoracle(rollupDataQuerier, *cfg, l1Client, l2Client, l1InfoTreeSync)
-			go aggOracle.Start(cliCtx.Context)
-
-		case aggkitcommon.BRIDGE:
-			b := createBridgeService(
-				cfg.REST,
-				cfg.Common.NetworkID,
-				l1InfoTreeSync,
-				lastGERSync,
-				l1BridgeSync,
-				l2BridgeSync,
-			)
-
-			go b.Start(cliCtx.Context)
-		case aggkitcommon.AGGSENDER:
-			aggsender, err := createAggSender(
-				cliCtx.Context,
-				cfg.AggSender,
-				l1Client,
-				l1InfoTreeSync,
-				l2BridgeSync,
-				l2Client,
-				rollupDataQuerier,
-			)
-			if err != nil {
-				log.Fatal(err)
-			}
-			rpcServices = append(rpcServices, aggsender.GetRPCServices()...)
-
-			go aggsender.Start(cliCtx.Context)
-		case aggkitcommon.AGGCHAINPROOFGEN:
-			aggchainProofGen, err := createAggchainProofGen(
-				cliCtx.Context,
-				cfg.AggchainProofGen,
-				l1Client,
-				l2Client,
-				l1InfoTreeSync,
-				l2BridgeSync,
-			)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			rpcServices = append(rpcServices, aggchainProofGen.GetRPCServices()...)
-		}
+	startL1InfoTreePruning(cliCtx.Context, cfg.L1InfoTreeSync.Retention, networksRegistry, l1InfoTreeSync,
+		lastGERSync, l1BridgeSync, l2BridgeSync)
+
+	services := &aggkitcomponents.Services{
+		Config:            cfg,
+		NetworksRegistry:  networksRegistry,
+		L1Client:          l1Client,
+		L2Client:          l2Client,
+		ReorgDetectorL1:   reorgDetectorL1,
+		ReorgDetectorL2:   reorgDetectorL2,
+		RollupDataQuerier: rollupDataQuerier,
+		L1InfoTreeSync:    l1InfoTreeSync,
+		BridgeL1Sync:      l1BridgeSync,
+		BridgeL2Sync:      l2BridgeSync,
+		LastGERSync:       lastGERSync,
+	}
+	if err := componentsRunner.Init(cliCtx.Context, services); err != nil {
+		fatal(err)
+	}
+	if err := componentsRunner.Start(cliCtx.Context); err != nil {
+		fatal(err)
 	}
+	rpcServices := componentsRunner.RPCServices()
 	if len(rpcServices) > 0 {
-		rpcServer := createRPC(cfg.RPC, rpcServices)
+		rpcServer := createRPC(cfg.RPC, rpcServices, errorBudget)
 		go func() {
 			if err := rpcServer.Start(); err != nil {
-				log.Fatal(err)
+				fatal(err)
 			}
 		}()
 	}
@@ -157,12 +167,22 @@ func start(cliCtx *cli.Context) error {
 	if cfg.Profiling.ProfilingEnabled {
 		go pprof.StartProfilingHTTPServer(cliCtx.Context, cfg.Profiling)
 	}
+	startProfilingSnapshots(cliCtx.Context, cfg.Profiling.Snapshots)
 
-	waitSignal(nil)
+	waitSignal([]context.CancelFunc{func() { stopComponents(componentsRunner) }})
 
 	return nil
 }
 
+// stopComponents stops the components on the graceful shutdown, waiting at most componentsStopTimeout
+func stopComponents(runner *aggkitcomponents.Runner) {
+	ctx, cancel := context.WithTimeout(context.Background(), componentsStopTimeout)
+	defer cancel()
+	if err := runner.Stop(ctx); err != nil {
+		log.Errorf("error stopping the components: %v", err)
+	}
+}
+
 func createAggchainProofGen(
 	ctx context.Context,
 	cfg prover.Config,
@@ -198,6 +218,10 @@ func createAggSender(
 	rollupDataQuerier *etherman.RollupDataQuerier) (*aggsender.AggSender, error) {
 	logger := log.WithFields("module", aggkitcommon.AGGSENDER)
 
+	if err := startStorageMonitor(ctx, aggkitcommon.AGGSENDER, cfg.StoragePath, cfg.StorageTuning); err != nil {
+		return nil, err
+	}
+
 	if err := cfg.AgglayerClient.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid agglayer client config: %w", err)
 	}
@@ -206,6 +230,7 @@ func createAggSender(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create agglayer grpc client: %w", err)
 	}
+	agglayerClient.SetLatestCertificateCacheTTL(cfg.AgglayerLatestCertificateCacheTTL.Duration)
 
 	blockNotifier, err := aggsender.NewBlockNotifierPolling(l1EthClient,
 		aggsender.ConfigBlockNotifierPolling{
@@ -237,8 +262,10 @@ func createAggSender(
 }
 
 func createAggoracle(
+	ctx context.Context,
 	ethermanClient *etherman.RollupDataQuerier,
-	cfg config.Config,
+	cfg aggoracle.Config,
+	logCfg log.Config,
 	l1Client,
 	l2Client aggkittypes.BaseEthereumClienter,
 	l1InfoTreeSyncer *l1infotreesync.L1InfoTreeSync,
@@ -250,57 +277,70 @@ func createAggoracle(
 	}
 
 	// sanity check for the aggOracle ChainID
-	if cfg.AggOracle.EVMSender.EthTxManager.Etherman.L1ChainID != l2ChainID {
+	if cfg.EVMSender.EthTxManager.Etherman.L1ChainID != l2ChainID {
 		logger.Warnf("Incorrect ChainID in aggOracle provided: %d expected: %d",
-			cfg.AggOracle.EVMSender.EthTxManager.Etherman.L1ChainID,
+			cfg.EVMSender.EthTxManager.Etherman.L1ChainID,
 			l2ChainID,
 		)
 	}
 
 	var sender aggoracle.ChainSender
-	switch cfg.AggOracle.TargetChainType {
+	switch cfg.TargetChainType {
 	case aggoracle.EVMChain:
-		cfg.AggOracle.EVMSender.EthTxManager.Log = ethtxlog.Config{
-			Environment: ethtxlog.LogEnvironment(cfg.Log.Environment),
-			Level:       cfg.Log.Level,
-			Outputs:     cfg.Log.Outputs,
+		relayCfg := cfg.EVMSender.Relay
+		// without a fallback to direct submission, the oracle host doesn't need a funded key
+		var ethTxMan aggoracletypes.EthTxManager
+		if !relayCfg.Enabled || relayCfg.FallbackToDirect {
+			cfg.EVMSender.EthTxManager.Log = ethtxlog.Config{
+				Environment: ethtxlog.LogEnvironment(logCfg.Environment),
+				Level:       logCfg.Level,
+				Outputs:     logCfg.Outputs,
+			}
+			ethTxManager, err := ethtxmanager.New(cfg.EVMSender.EthTxManager)
+			if err != nil {
+				fatal(err)
+			}
+			logger.Infof("AggOracle sender address: %s | GER contract address on L2: %s",
+				ethTxManager.From().Hex(),
+				cfg.EVMSender.GlobalExitRootL2Addr.Hex(),
+			)
+			go ethTxManager.Start()
+			ethTxMan = ethTxManager
+			startBalanceMonitor(ctx, "aggoracle_sender", ethTxManager.From(), l2Client,
+				cfg.EVMSender.BalanceMonitor)
 		}
-		ethTxManager, err := ethtxmanager.New(cfg.AggOracle.EVMSender.EthTxManager)
-		if err != nil {
-			log.Fatal(err)
+		if relayCfg.Enabled {
+			logger.Infof("AggOracle injects the GERs through the relay %s (fallback to direct submission: %t)",
+				relayCfg.URL, relayCfg.FallbackToDirect)
 		}
-		logger.Infof("AggOracle sender address: %s | GER contract address on L2: %s",
-			ethTxManager.From().Hex(),
-			cfg.AggOracle.EVMSender.GlobalExitRootL2Addr.Hex(),
-		)
-		go ethTxManager.Start()
 		sender, err = chaingersender.NewEVMChainGERSender(
 			logger,
-			cfg.AggOracle.EVMSender.GlobalExitRootL2Addr,
+			cfg.EVMSender.GlobalExitRootL2Addr,
 			l2Client,
-			ethTxManager,
-			cfg.AggOracle.EVMSender.GasOffset,
-			cfg.AggOracle.EVMSender.WaitPeriodMonitorTx.Duration,
+			ethTxMan,
+			cfg.EVMSender.GasOffset,
+			cfg.EVMSender.WaitPeriodMonitorTx.Duration,
+			relayCfg,
 		)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 	default:
-		log.Fatalf(
-			"Unsupported chaintype %s. Supported values: %v",
-			cfg.AggOracle.TargetChainType, aggoracle.SupportedChainTypes,
-		)
+		fatal(configError(fmt.Errorf(
+			"unsupported chaintype %s. Supported values: %v",
+			cfg.TargetChainType, aggoracle.SupportedChainTypes,
+		)))
 	}
 	aggOracle, err := aggoracle.New(
 		logger,
 		sender,
 		l1Client,
 		l1InfoTreeSyncer,
-		aggkittypes.NewBlockNumberFinality(cfg.AggOracle.BlockFinality),
-		cfg.AggOracle.WaitPeriodNextGER.Duration,
+		aggkittypes.NewBlockNumberFinality(cfg.BlockFinality),
+		cfg.WaitPeriodNextGER.Duration,
 	)
 	if err != nil {
-		logger.Fatal(err)
+		fatal(err)
 	}
 
 	return aggOracle
@@ -335,6 +375,145 @@ func waitSignal(cancelFuncs []context.CancelFunc) {
 	}
 }
 
+// startStorageMonitor checks the free disk space of the DB and starts alerting when the DB exceeds
+// its storage quota
+func startStorageMonitor(ctx context.Context, name, dbPath string, tuning db.SQLiteConfig) error {
+	monitor := db.NewStorageMonitor(log.WithFields("module", "storage-monitor"), name, dbPath, tuning)
+	if err := monitor.CheckStartup(); err != nil {
+		return err
+	}
+	go monitor.Start(ctx)
+	return nil
+}
+
+// runVerificationsIfNeeded verifies the DBs of the syncers configured in verify-only mode and writes
+// their discrepancy reports. It returns true if any syncer is in verify-only mode, so no component is
+// started, and an error if any verification fails or finds discrepancies
+func runVerificationsIfNeeded(ctx context.Context, cfg *config.Config,
+	l1Client, l2Client aggkittypes.EthClienter) (bool, error) {
+	verifications := []struct {
+		name      string
+		dbPath    string
+		verifyCfg sync.VerifierConfig
+		client    aggkittypes.EthClienter
+		create    func() (*sync.Verifier, error)
+	}{
+		{aggkitcommon.L1INFOTREESYNC, cfg.L1InfoTreeSync.DBPath, cfg.L1InfoTreeSync.Verify, l1Client,
+			func() (*sync.Verifier, error) {
+				return l1infotreesync.NewVerifier(ctx, cfg.L1InfoTreeSync, l1Client, l1infotreesync.FlagNone)
+			}},
+		{"bridge_sync_l1", cfg.BridgeL1Sync.DBPath, cfg.BridgeL1Sync.Verify, l1Client,
+			func() (*sync.Verifier, error) {
+				return bridgesync.NewVerifier(ctx, cfg.BridgeL1Sync, bridgesync.L1BridgeSyncer, l1Client, true)
+			}},
+		{"bridge_sync_l2", cfg.BridgeL2Sync.DBPath, cfg.BridgeL2Sync.Verify, l2Client,
+			func() (*sync.Verifier, error) {
+				return bridgesync.NewVerifier(ctx, cfg.BridgeL2Sync, bridgesync.L2BridgeSyncer, l2Client, true)
+			}},
+	}
+
+	verifyOnly := false
+	failed := []string{}
+	for _, v := range verifications {
+		if !v.verifyCfg.VerifyOnly {
+			continue
+		}
+		verifyOnly = true
+		if v.client == nil {
+			return true, fmt.Errorf("the %s DB can't be verified, its client isn't used by the components", v.name)
+		}
+		report, err := runVerification(ctx, v.create, v.verifyCfg.ReportPathFor(v.dbPath))
+		if err != nil {
+			return true, fmt.Errorf("error verifying the %s DB: %w", v.name, err)
+		}
+		if !report.OK() {
+			failed = append(failed, v.name)
+		}
+	}
+	if len(failed) > 0 {
+		return true, fmt.Errorf("discrepancies found verifying the DBs of %v", failed)
+	}
+	return verifyOnly, nil
+}
+
+// runVerification runs a verifier and writes its report to reportPath
+func runVerification(ctx context.Context, create func() (*sync.Verifier, error),
+	reportPath string) (*sync.VerificationReport, error) {
+	verifier, err := create()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := verifier.Close(); err != nil {
+			log.Warnf("error closing the verifier: %v", err)
+		}
+	}()
+	report, err := verifier.Verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := report.WriteFile(reportPath); err != nil {
+		return nil, err
+	}
+	log.Infof("verification report written to %s: %s", reportPath, report.String())
+	return report, nil
+}
+
+// startProfilingSnapshots starts capturing the profiles of the process when the memory or the goroutines
+// exceed the thresholds
+func startProfilingSnapshots(ctx context.Context, cfg pprof.SnapshotsConfig) {
+	if err := cfg.Validate(); err != nil {
+		fatal(configError(err))
+	}
+	monitor := pprof.NewSnapshotMonitor(log.WithFields("module", "profiling-snapshots"), cfg)
+	if monitor == nil {
+		return
+	}
+	go monitor.Start(ctx)
+}
+
+// startL1InfoTreePruning starts pruning the old leaves of the L1 info tree. The first leaf that includes
+// each unclaimed deposit is kept while the bridge syncers run, and the leaves of the GERs injected on L2
+// while the lastGERSync runs, because the claims of the unclaimed deposits need them
+func startL1InfoTreePruning(ctx context.Context, cfg l1infotreesync.RetentionConfig,
+	networksRegistry *networks.Registry, l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
+	lastGERSync *lastgersync.LastGERSync, l1BridgeSync, l2BridgeSync *bridgesync.BridgeSync) {
+	if l1InfoTreeSync == nil || !cfg.Enabled() {
+		return
+	}
+	var guards []l1infotreesync.RetentionGuard
+	if l1BridgeSync != nil && l2BridgeSync != nil {
+		guards = append(guards, bridgeservice.NewUnclaimedDepositsGuard(networksRegistry,
+			l2BridgeSync.OriginNetwork(), l1InfoTreeSync, l1BridgeSync, l2BridgeSync))
+	}
+	if lastGERSync != nil {
+		guards = append(guards, lastGERSync)
+	}
+	if err := l1InfoTreeSync.StartPruning(ctx, cfg, guards...); err != nil {
+		fatal(configError(err))
+	}
+}
+
+// startBalanceMonitor starts alerting when the balance of the account used for on-chain actions is low
+func startBalanceMonitor(ctx context.Context, name string, address common.Address,
+	client aggkittypes.BaseEthereumClienter, cfg balancemonitor.Config) {
+	if err := cfg.Validate(); err != nil {
+		fatal(configError(err))
+	}
+	rpcClient, ok := client.(aggkittypes.RPCClienter)
+	if !ok {
+		fatal(configError(fmt.Errorf(
+			"the client of the %s account doesn't support the RPC calls required by the BalanceMonitor", name)))
+	}
+	monitor := balancemonitor.NewBalanceMonitor(log.WithFields("module", "balance-monitor"),
+		name, address, rpcClient, cfg)
+	if monitor == nil {
+		return
+	}
+	log.Infof("Starting the balance monitor of the %s account %s: %s", name, address.Hex(), cfg.String())
+	go monitor.Start(ctx)
+}
+
 func newReorgDetector(
 	cfg *reorgdetector.Config,
 	client aggkittypes.BaseEthereumClienter,
@@ -342,40 +521,31 @@ func newReorgDetector(
 ) *reorgdetector.ReorgDetector {
 	rd, err := reorgdetector.New(client, *cfg, network)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 
 	return rd
 }
 
-func isNeeded(casesWhereNeeded, actualCases []string) bool {
-	for _, actualCase := range actualCases {
-		for _, caseWhereNeeded := range casesWhereNeeded {
-			if actualCase == caseWhereNeeded {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
 func runL1InfoTreeSyncerIfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	cfg config.Config,
 	l1Client aggkittypes.BaseEthereumClienter,
 	reorgDetector *reorgdetector.ReorgDetector,
 ) *l1infotreesync.L1InfoTreeSync {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE, aggkitcommon.AGGSENDER,
-		aggkitcommon.BRIDGE, aggkitcommon.L1INFOTREESYNC,
-		aggkitcommon.AGGCHAINPROOFGEN}, components) {
+	if !needed[aggkitcomponents.L1InfoTreeSync] {
 		return nil
 	}
+	if err := startStorageMonitor(ctx, aggkitcommon.L1INFOTREESYNC,
+		cfg.L1InfoTreeSync.DBPath, cfg.L1InfoTreeSync.StorageTuning); err != nil {
+		fatal(err)
+	}
 	l1InfoTreeSync, err := l1infotreesync.New(
 		ctx,
 		cfg.L1InfoTreeSync.DBPath,
+		cfg.L1InfoTreeSync.StorageTuning,
+		cfg.L1InfoTreeSync.InMemoryMirror,
 		cfg.L1InfoTreeSync.GlobalExitRootAddr,
 		cfg.L1InfoTreeSync.RollupManagerAddr,
 		cfg.L1InfoTreeSync.SyncBlockChunkSize,
@@ -391,61 +561,55 @@ func runL1InfoTreeSyncerIfNeeded(
 		cfg.L1InfoTreeSync.RequireStorageContentCompatibility,
 	)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	go l1InfoTreeSync.Start(ctx)
 
 	return l1InfoTreeSync
 }
 
-func runL1ClientIfNeeded(components []string, urlRPCL1 string) aggkittypes.EthClienter {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE,
-		aggkitcommon.AGGSENDER,
-		aggkitcommon.BRIDGE,
-		aggkitcommon.L1INFOTREESYNC,
-		aggkitcommon.AGGCHAINPROOFGEN,
-	}, components) {
+func runL1ClientIfNeeded(needed map[aggkitcomponents.Dependency]bool,
+	cfg config.L1NetworkConfig) aggkittypes.EthClienter {
+	if !needed[aggkitcomponents.L1Client] {
 		return nil
 	}
-	log.Debugf("dialing L1 client at: %s", urlRPCL1)
-	l1Client, err := ethclient.Dial(urlRPCL1)
+	log.Debugf("dialing L1 client at: %s", cfg.URL)
+	l1Client, err := aggkittypes.DialEthClient(context.Background(), cfg.URL, cfg.Options())
 	if err != nil {
-		log.Fatalf("failed to create client for L1 using URL: %s. Err:%v", urlRPCL1, err)
+		fatal(rpcError(fmt.Errorf("failed to create client for L1 using URL: %s. Err: %w", cfg.URL, err)))
 	}
 
-	return aggkittypes.NewDefaultEthClient(l1Client, l1Client.Client())
+	// the header cache wraps the instrumented client, so the cached headers don't consume the rate limit
+	return aggkittypes.NewHeaderCacheEthClient("l1",
+		aggkittypes.NewInstrumentedEthClient("l1", l1Client, cfg.Options().RateLimit), cfg.Options().HeaderCache)
 }
 
-func runL2ClientIfNeeded(components []string, urlRPCL2 ethermanconfig.RPCClientConfig) aggkittypes.EthClienter {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE,
-		aggkitcommon.BRIDGE,
-		aggkitcommon.AGGSENDER,
-		aggkitcommon.AGGCHAINPROOFGEN}, components) {
+func runL2ClientIfNeeded(needed map[aggkitcomponents.Dependency]bool,
+	urlRPCL2 ethermanconfig.RPCClientConfig) aggkittypes.EthClienter {
+	if !needed[aggkitcomponents.L2Client] {
 		return nil
 	}
 	l2Client, err := etherman.NewRPCClient(urlRPCL2)
 	if err != nil {
-		log.Fatalf("failed to create client for L2 using URL: %s. Err:%v", urlRPCL2, err)
+		fatal(rpcError(fmt.Errorf("failed to create client for L2 using URL: %s. Err: %w", urlRPCL2.URL, err)))
 	}
 
-	return l2Client
+	return aggkittypes.NewHeaderCacheEthClient("l2",
+		aggkittypes.NewInstrumentedEthClient("l2", l2Client, urlRPCL2.Options().RateLimit), urlRPCL2.Options().HeaderCache)
 }
 
 func runReorgDetectorL1IfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	l1Client aggkittypes.BaseEthereumClienter,
 	cfg *reorgdetector.Config,
 ) (*reorgdetector.ReorgDetector, chan error) {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE, aggkitcommon.AGGSENDER,
-		aggkitcommon.BRIDGE, aggkitcommon.L1INFOTREESYNC,
-		aggkitcommon.AGGCHAINPROOFGEN},
-		components) {
+	if !needed[aggkitcomponents.ReorgDetectorL1] {
 		return nil, nil
 	}
+	if err := startStorageMonitor(ctx, "reorg_detector_l1", cfg.DBPath, cfg.StorageTuning); err != nil {
+		fatal(err)
+	}
 	rd := newReorgDetector(cfg, l1Client, reorgdetector.L1)
 
 	errChan := make(chan error)
@@ -461,17 +625,16 @@ func runReorgDetectorL1IfNeeded(
 
 func runReorgDetectorL2IfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	l2Client aggkittypes.BaseEthereumClienter,
 	cfg *reorgdetector.Config,
 ) (*reorgdetector.ReorgDetector, chan error) {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE,
-		aggkitcommon.BRIDGE,
-		aggkitcommon.AGGSENDER,
-		aggkitcommon.AGGCHAINPROOFGEN}, components) {
+	if !needed[aggkitcomponents.ReorgDetectorL2] {
 		return nil, nil
 	}
+	if err := startStorageMonitor(ctx, "reorg_detector_l2", cfg.DBPath, cfg.StorageTuning); err != nil {
+		fatal(err)
+	}
 	rd := newReorgDetector(cfg, l2Client, reorgdetector.L2)
 
 	errChan := make(chan error)
@@ -487,18 +650,22 @@ func runReorgDetectorL2IfNeeded(
 
 func runLastGERSyncIfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	cfg lastgersync.Config,
 	reorgDetectorL2 *reorgdetector.ReorgDetector,
 	l2Client aggkittypes.BaseEthereumClienter,
 	l1InfoTreeSync *l1infotreesync.L1InfoTreeSync,
 ) *lastgersync.LastGERSync {
-	if !isNeeded([]string{aggkitcommon.BRIDGE}, components) {
+	if !needed[aggkitcomponents.LastGERSync] {
 		return nil
 	}
+	if err := startStorageMonitor(ctx, "last_ger_sync", cfg.DBPath, cfg.StorageTuning); err != nil {
+		fatalf("error checking the lastGERSync storage: %w", err)
+	}
 	lastGERSync, err := lastgersync.New(
 		ctx,
 		cfg.DBPath,
+		cfg.StorageTuning,
 		reorgDetectorL2,
 		l2Client,
 		cfg.GlobalExitRootL2Addr,
@@ -512,12 +679,12 @@ func runLastGERSyncIfNeeded(
 		cfg.SyncMode,
 	)
 	if err != nil {
-		log.Fatalf("error creating lastGERSync: %s", err)
+		fatalf("error creating lastGERSync: %w", err)
 	}
 
 	go func() {
 		if err := lastGERSync.Start(ctx); err != nil {
-			log.Fatalf("lastGERSync failed: %s", err)
+			fatalf("lastGERSync failed: %w", err)
 		}
 	}()
 
@@ -526,19 +693,23 @@ func runLastGERSyncIfNeeded(
 
 func runBridgeSyncL1IfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	cfg bridgesync.Config,
 	reorgDetectorL1 *reorgdetector.ReorgDetector,
 	l1Client aggkittypes.EthClienter,
 	rollupID uint32,
 ) *bridgesync.BridgeSync {
-	if !isNeeded([]string{aggkitcommon.BRIDGE}, components) {
+	if !needed[aggkitcomponents.BridgeL1Sync] {
 		return nil
 	}
 
+	if err := startStorageMonitor(ctx, "bridge_sync_l1", cfg.DBPath, cfg.StorageTuning); err != nil {
+		fatalf("error checking the bridgeSyncL1 storage: %w", err)
+	}
 	bridgeSyncL1, err := bridgesync.NewL1(
 		ctx,
 		cfg.DBPath,
+		cfg.StorageTuning,
 		cfg.BridgeAddr,
 		cfg.SyncBlockChunkSize,
 		aggkittypes.NewBlockNumberFinality(cfg.BlockFinality),
@@ -553,7 +724,43 @@ func runBridgeSyncL1IfNeeded(
 		cfg.RequireStorageContentCompatibility,
 	)
 	if err != nil {
-		log.Fatalf("error creating bridgeSyncL1: %s", err)
+		fatalf("error creating bridgeSyncL1: %w", err)
+	}
+	if cfg.BlockTimestampSource != "" {
+		if err := bridgeSyncL1.SetBlockTimestampSource(
+			sync.BlockTimestampSource(cfg.BlockTimestampSource), cfg.BlockInterval.Duration); err != nil {
+			fatalf("error setting the block timestamp source on bridgeSyncL1: %w", err)
+		}
+	}
+	if cfg.BatchHeaderRequests {
+		if err := bridgeSyncL1.EnableBatchHeaderRequests(); err != nil {
+			// the headers are requested one by one
+			log.Warnf("error enabling the batch header requests on bridgeSyncL1: %s", err)
+		}
+	}
+	if cfg.ClientSideTopicFiltering {
+		if err := bridgeSyncL1.EnableClientSideTopicFiltering(); err != nil {
+			fatalf("error enabling the client side topic filtering on bridgeSyncL1: %w", err)
+		}
+	}
+	if cfg.CrossCheck.URL != "" {
+		// after the other settings of the downloader, that are also used for the second provider
+		if err := bridgeSyncL1.EnableCrossCheck(ctx, cfg.CrossCheck); err != nil {
+			fatalf("error enabling the cross check on bridgeSyncL1: %w", err)
+		}
+	}
+	if cfg.ArchiveMode {
+		bridgeSyncL1.EnableArchiveMode()
+	}
+	if cfg.PriceOracle.URL != "" {
+		if err := bridgeSyncL1.EnablePriceOracle(ctx, cfg.PriceOracle); err != nil {
+			fatalf("error enabling the price oracle on bridgeSyncL1: %w", err)
+		}
+	}
+	if cfg.ClaimsAudit.Enabled {
+		if err := bridgeSyncL1.EnableClaimsAudit(ctx, cfg.ClaimsAudit); err != nil {
+			fatalf("error enabling the claims audit on bridgeSyncL1: %w", err)
+		}
 	}
 	go bridgeSyncL1.Start(ctx)
 
@@ -562,22 +769,26 @@ func runBridgeSyncL1IfNeeded(
 
 func runBridgeSyncL2IfNeeded(
 	ctx context.Context,
-	components []string,
+	needed map[aggkitcomponents.Dependency]bool,
 	cfg bridgesync.Config,
 	reorgDetectorL2 *reorgdetector.ReorgDetector,
 	l2Client aggkittypes.EthClienter,
-	rollupID uint32,
+	rollupDataQuerier *etherman.RollupDataQuerier,
+	fastSyncCfg *aggsendercfg.Config,
 ) *bridgesync.BridgeSync {
-	if !isNeeded([]string{
-		aggkitcommon.BRIDGE,
-		aggkitcommon.AGGSENDER,
-		aggkitcommon.AGGCHAINPROOFGEN}, components) {
+	if !needed[aggkitcomponents.BridgeL2Sync] {
 		return nil
 	}
+	// the rollup ID is the network ID of the L2 bridge contract
+	rollupID := rollupDataQuerier.RollupID
 
+	if err := startStorageMonitor(ctx, "bridge_sync_l2", cfg.DBPath, cfg.StorageTuning); err != nil {
+		fatalf("error checking the bridgeSyncL2 storage: %w", err)
+	}
 	bridgeSyncL2, err := bridgesync.NewL2(
 		ctx,
 		cfg.DBPath,
+		cfg.StorageTuning,
 		cfg.BridgeAddr,
 		cfg.SyncBlockChunkSize,
 		aggkittypes.NewBlockNumberFinality(cfg.BlockFinality),
@@ -592,16 +803,196 @@ func runBridgeSyncL2IfNeeded(
 		cfg.RequireStorageContentCompatibility,
 	)
 	if err != nil {
-		log.Fatalf("error creating bridgeSyncL2: %s", err)
+		fatalf("error creating bridgeSyncL2: %w", err)
+	}
+	if err := cfg.ValidateSyncMode(); err != nil {
+		fatal(configError(fmt.Errorf("invalid BridgeL2Sync config: %w", err)))
+	}
+	if cfg.SyncMode == bridgesync.SyncModeSequencerFeed {
+		if err := bridgeSyncL2.EnableSequencerFeed(
+			ctx, cfg.SequencerFeedURL, cfg.SequencerFeedReconnectPeriod.Duration); err != nil {
+			fatalf("error enabling the sequencer feed on bridgeSyncL2: %w", err)
+		}
+	}
+	if cfg.BlockTimestampSource != "" {
+		if err := bridgeSyncL2.SetBlockTimestampSource(
+			sync.BlockTimestampSource(cfg.BlockTimestampSource), cfg.BlockInterval.Duration); err != nil {
+			fatalf("error setting the block timestamp source on bridgeSyncL2: %w", err)
+		}
+	}
+	if cfg.BatchHeaderRequests {
+		if err := bridgeSyncL2.EnableBatchHeaderRequests(); err != nil {
+			// the headers are requested one by one
+			log.Warnf("error enabling the batch header requests on bridgeSyncL2: %s", err)
+		}
+	}
+	if cfg.ClientSideTopicFiltering {
+		if err := bridgeSyncL2.EnableClientSideTopicFiltering(); err != nil {
+			fatalf("error enabling the client side topic filtering on bridgeSyncL2: %w", err)
+		}
+	}
+	if cfg.CrossCheck.URL != "" {
+		// after the other settings of the downloader, that are also used for the second provider
+		if err := bridgeSyncL2.EnableCrossCheck(ctx, cfg.CrossCheck); err != nil {
+			fatalf("error enabling the cross check on bridgeSyncL2: %w", err)
+		}
+	}
+	if cfg.ArchiveMode {
+		bridgeSyncL2.EnableArchiveMode()
+	}
+	if cfg.PriceOracle.URL != "" {
+		if err := bridgeSyncL2.EnablePriceOracle(ctx, cfg.PriceOracle); err != nil {
+			fatalf("error enabling the price oracle on bridgeSyncL2: %w", err)
+		}
+	}
+	if cfg.ClaimsAudit.Enabled {
+		if err := bridgeSyncL2.EnableClaimsAudit(ctx, cfg.ClaimsAudit); err != nil {
+			fatalf("error enabling the claims audit on bridgeSyncL2: %w", err)
+		}
+	}
+	if fastSyncCfg != nil {
+		fastSyncBridgeL2(ctx, *fastSyncCfg, bridgeSyncL2)
 	}
 	go bridgeSyncL2.Start(ctx)
 
 	return bridgeSyncL2
 }
 
+// fastSyncAggSenderConfig returns the config of the AggSender if the L2 bridge syncer has to be started at the
+// last block settled in the agglayer: FastSyncFromAgglayer is enabled and the AggSender runs for the first time
+// (no storage), without the bridge service that needs all the deposits. Otherwise it returns nil
+func fastSyncAggSenderConfig(cfg *config.Config, components []string) *aggsendercfg.Config {
+	if !cfg.AggSender.FastSyncFromAgglayer || !slices.Contains(components, aggkitcommon.AGGSENDER) {
+		return nil
+	}
+	if slices.Contains(components, aggkitcommon.BRIDGE) {
+		log.Warnf("FastSyncFromAgglayer is ignored: the %s component needs all the deposits of the L2",
+			aggkitcommon.BRIDGE)
+		return nil
+	}
+	if _, err := os.Stat(cfg.AggSender.StoragePath); !errors.Is(err, fs.ErrNotExist) {
+		// the AggSender continues from its last certificate, the syncer from its last processed block
+		return nil
+	}
+	return &cfg.AggSender
+}
+
+// fastSyncBridgeL2 starts the L2 bridge syncer at the last block settled in the agglayer (see
+// aggsender.FastSyncL2Bridge). If it fails the syncer starts at its initial block
+func fastSyncBridgeL2(ctx context.Context, cfg aggsendercfg.Config, bridgeSyncL2 *bridgesync.BridgeSync) {
+	logger := log.WithFields("module", "fast-sync")
+	if err := cfg.AgglayerClient.Validate(); err != nil {
+		logger.Warnf("fast sync skipped, invalid agglayer client config: %s", err)
+		return
+	}
+	agglayerClient, err := agglayer.NewAgglayerGRPCClient(cfg.AgglayerClient)
+	if err != nil {
+		logger.Warnf("fast sync skipped, failed to create the agglayer grpc client: %s", err)
+		return
+	}
+	if _, err := aggsender.FastSyncL2Bridge(ctx, logger, agglayerClient, bridgeSyncL2); err != nil {
+		if errors.Is(err, bridgesync.ErrStorageNotEmpty) {
+			logger.Infof("fast sync skipped, the L2 bridge syncer continues from its storage: %s", err)
+			return
+		}
+		logger.Warnf("fast sync failed, the L2 bridge syncer starts at its initial block: %s", err)
+	}
+}
+
+// checkL2NetworkID checks that the network ID of the L2 used by the bridge service (Common.NetworkID) is
+// the origin network of the L2 bridge syncer (the rollup ID, checked against the bridge contract), which is
+// the one used by the aggsender in the certificates
+func checkL2NetworkID(networkID uint32, l2BridgeSync *bridgesync.BridgeSync) error {
+	if l2BridgeSync == nil {
+		return nil
+	}
+	if l2BridgeSync.OriginNetwork() != networkID {
+		return fmt.Errorf("%w: Common.NetworkID is %d, but the network ID of the L2 bridge contract is %d",
+			bridgesync.ErrNetworkIDMismatch, networkID, l2BridgeSync.OriginNetwork())
+	}
+	return nil
+}
+
+// createNetworksRegistry creates the registry of the configured networks (or the default one
+// if there are none), completes the L1 and the L2 run by this aggkit with the settings of their
+// syncers and checks that the L2 is one of them
+func createNetworksRegistry(cfg *config.Config) (*networks.Registry, error) {
+	configured := cfg.Networks
+	if len(configured) == 0 {
+		configured = networks.DefaultNetworks(cfg.Common.NetworkID)
+	}
+	synced := syncedNetworks(cfg)
+	networksCfg := make([]networks.NetworkConfig, 0, len(configured))
+	for _, n := range configured {
+		if s, ok := synced[n.ID]; ok {
+			var err error
+			if n, err = n.WithSynced(s); err != nil {
+				return nil, fmt.Errorf("failed to create the networks registry: %w", err)
+			}
+		}
+		networksCfg = append(networksCfg, n)
+	}
+	registry, err := networks.NewRegistry(networksCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the networks registry: %w", err)
+	}
+	if !registry.IsL2(cfg.Common.NetworkID) {
+		return nil, fmt.Errorf("network ID %d (Common.NetworkID) is not a L2 network of the registry",
+			cfg.Common.NetworkID)
+	}
+	return registry, nil
+}
+
+// syncedNetworks returns the endpoints, contracts and finality used by the syncers of the L1 and of the
+// L2 run by this aggkit, indexed by network ID
+func syncedNetworks(cfg *config.Config) map[uint32]networks.NetworkConfig {
+	rpcURLs := func(url string) []string {
+		if url == "" {
+			return nil
+		}
+		return []string{url}
+	}
+	return map[uint32]networks.NetworkConfig{
+		networks.L1NetworkID: {
+			RPCURLs:       rpcURLs(cfg.L1NetworkConfig.URL),
+			BridgeAddr:    cfg.BridgeL1Sync.BridgeAddr,
+			GERAddr:       cfg.L1InfoTreeSync.GlobalExitRootAddr,
+			BlockFinality: aggkittypes.NewBlockNumberFinality(cfg.BridgeL1Sync.BlockFinality),
+		},
+		cfg.Common.NetworkID: {
+			RPCURLs:       rpcURLs(cfg.Common.L2RPC.URL),
+			BridgeAddr:    cfg.BridgeL2Sync.BridgeAddr,
+			GERAddr:       cfg.LastGERSync.GlobalExitRootL2Addr,
+			BlockFinality: aggkittypes.NewBlockNumberFinality(cfg.BridgeL2Sync.BlockFinality),
+		},
+	}
+}
+
+// newBridgeServiceNodeConfig returns the contracts and finality settings of the syncers used by the node
+func newBridgeServiceNodeConfig(cfg *config.Config) bridgeservice.NodeConfig {
+	return bridgeservice.NodeConfig{
+		RollupManagerAddr: cfg.L1InfoTreeSync.RollupManagerAddr,
+		L1: bridgeservice.SyncedNetworkConfig{
+			BridgeAddr:          cfg.BridgeL1Sync.BridgeAddr,
+			GERAddr:             cfg.L1InfoTreeSync.GlobalExitRootAddr,
+			BridgeBlockFinality: cfg.BridgeL1Sync.BlockFinality,
+			GERBlockFinality:    cfg.L1InfoTreeSync.BlockFinality,
+		},
+		L2: bridgeservice.SyncedNetworkConfig{
+			BridgeAddr:          cfg.BridgeL2Sync.BridgeAddr,
+			GERAddr:             cfg.LastGERSync.GlobalExitRootL2Addr,
+			BridgeBlockFinality: cfg.BridgeL2Sync.BlockFinality,
+			GERBlockFinality:    cfg.LastGERSync.BlockFinality,
+		},
+	}
+}
+
 func createBridgeService(
 	cfg aggkitcommon.RESTConfig,
+	serviceCfg bridgeservice.ServiceConfig,
 	l2NetworkID uint32,
+	networksRegistry *networks.Registry,
+	nodeConfig bridgeservice.NodeConfig,
 	l1InfoTree *l1infotreesync.L1InfoTreeSync,
 	injectedGERs *lastgersync.LastGERSync,
 	bridgeL1 *bridgesync.BridgeSync,
@@ -615,6 +1006,20 @@ func createBridgeService(
 		ReadTimeout:  cfg.ReadTimeout.Duration,
 		WriteTimeout: cfg.WriteTimeout.Duration,
 		NetworkID:    l2NetworkID,
+		Networks:     networksRegistry,
+		NodeConfig:   nodeConfig,
+
+		AdminToken:                   serviceCfg.AdminToken,
+		ClaimsReconciliationInterval: serviceCfg.ClaimsReconciliationInterval.Duration,
+		ClaimProofPrecomputeDeposits: cfg.ClaimProofPrecomputeDeposits,
+		ClaimProofPrecomputeInterval: cfg.ClaimProofPrecomputeInterval.Duration,
+		EndpointTimeouts:             make(map[string]time.Duration, len(cfg.EndpointTimeouts)),
+		SlowRequestThreshold:         cfg.SlowRequestThreshold.Duration,
+		ReadinessMaxL1BlocksBehind:   cfg.ReadinessMaxL1BlocksBehind,
+		ReadinessMaxL2BlocksBehind:   cfg.ReadinessMaxL2BlocksBehind,
+	}
+	for endpoint, timeout := range cfg.EndpointTimeouts {
+		bridgeCfg.EndpointTimeouts[endpoint] = timeout.Duration
 	}
 
 	return bridgeservice.New(
@@ -626,10 +1031,18 @@ func createBridgeService(
 	)
 }
 
-func createRPC(cfg jRPC.Config, services []jRPC.Service) *jRPC.Server {
+func createRPC(cfg jRPC.Config, services []jRPC.Service, errorBudget *healthcheck.ErrorBudget) *jRPC.Server {
 	logger := log.WithFields("module", "RPC")
 
-	healthHandler := healthcheck.NewHealthCheckHandler(logger)
+	if strings.HasPrefix(cfg.Host, aggkitcommon.UnixSocketScheme) {
+		fatal(configError(fmt.Errorf("the RPC server doesn't support unix domain sockets (RPC.Host: %s)", cfg.Host)))
+	}
+	// the RPC server builds its listen address as host:port, so the IPv6 literals need the brackets
+	if ip := net.ParseIP(cfg.Host); ip != nil && ip.To4() == nil {
+		cfg.Host = "[" + cfg.Host + "]"
+	}
+
+	healthHandler := healthcheck.NewHealthCheckHandler(logger, errorBudget)
 	logger.Infof("Starting RPC server at %s:%d", cfg.Host, cfg.Port)
 	return jRPC.NewServer(cfg, services,
 		jRPC.WithLogger(logger.GetSugaredLogger()),
@@ -639,10 +1052,10 @@ func createRPC(cfg jRPC.Config, services []jRPC.Service) *jRPC.Server {
 func startPrometheusHTTPServer(c prometheus.Config) {
 	const ten = 10
 	mux := http.NewServeMux()
-	address := fmt.Sprintf("%s:%d", c.Host, c.Port)
-	lis, err := net.Listen("tcp", address)
+	address := aggkitcommon.ListenAddress(c.Host, c.Port)
+	lis, err := aggkitcommon.Listen(address)
 	if err != nil {
-		log.Errorf("failed to create tcp listener for metrics: %v", err)
+		log.Errorf("failed to create listener for metrics: %v", err)
 		return
 	}
 	mux.Handle(prometheus.Endpoint, promhttp.Handler())
@@ -652,7 +1065,7 @@ func startPrometheusHTTPServer(c prometheus.Config) {
 		ReadHeaderTimeout: ten * time.Second,
 		ReadTimeout:       ten * time.Second,
 	}
-	log.Infof("prometheus server listening on port %d", c.Port)
+	log.Infof("prometheus server listening on %s", address)
 	if err := metricsServer.Serve(lis); err != nil {
 		if err == http.ErrServerClosed {
 			log.Warnf("prometheus http server stopped")
@@ -663,23 +1076,19 @@ func startPrometheusHTTPServer(c prometheus.Config) {
 	}
 }
 
-// createRollupDataQuerier initializes and returns the rollup data querier if any of the required components
-// (AGGORACLE, AGGCHAINPROOFGEN, AGGSENDER, BRIDGE) are needed. The client is configured with
-// the provided L1 network configuration and uses default implementations for creating Ethereum
-// clients and rollup manager contracts. Returns (nil, nil) if none of the required components are needed.
-func createRollupDataQuerier(cfg config.L1NetworkConfig, components []string) (*etherman.RollupDataQuerier, error) {
-	if !isNeeded([]string{
-		aggkitcommon.AGGORACLE,
-		aggkitcommon.AGGCHAINPROOFGEN,
-		aggkitcommon.AGGSENDER,
-		aggkitcommon.BRIDGE,
-	}, components) {
+// createRollupDataQuerier initializes and returns the rollup data querier if any of the components
+// to run needs it. The client is configured with the provided L1 network configuration and uses default
+// implementations for creating Ethereum clients and rollup manager contracts. Returns (nil, nil) if none
+// of the components needs it.
+func createRollupDataQuerier(cfg config.L1NetworkConfig,
+	needed map[aggkitcomponents.Dependency]bool) (*etherman.RollupDataQuerier, error) {
+	if !needed[aggkitcomponents.RollupDataQuerier] {
 		return nil, nil
 	}
 
 	return etherman.NewRollupDataQuerier(cfg,
 		func(url string) (aggkittypes.BaseEthereumClienter, error) {
-			return ethclient.Dial(url)
+			return aggkittypes.DialEthClient(context.Background(), url, cfg.Options())
 		},
 		func(rollupAddr common.Address,
 			client aggkittypes.BaseEthereumClienter) (etherman.RollupManagerContract, error) {
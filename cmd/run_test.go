@@ -0,0 +1,138 @@
+package main
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/networks"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFastSyncAggSenderConfig(t *testing.T) {
+	existingStorage := path.Join(t.TempDir(), "aggsender.sqlite")
+	require.NoError(t, os.WriteFile(existingStorage, nil, 0600))
+	freshStorage := path.Join(t.TempDir(), "aggsender.sqlite")
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		storagePath string
+		components  []string
+		expected    bool
+	}{
+		{
+			name:        "fresh aggsender",
+			enabled:     true,
+			storagePath: freshStorage,
+			components:  []string{aggkitcommon.AGGSENDER, aggkitcommon.AGGORACLE},
+			expected:    true,
+		},
+		{
+			name:        "disabled",
+			storagePath: freshStorage,
+			components:  []string{aggkitcommon.AGGSENDER},
+		},
+		{
+			name:        "the aggsender doesn't run",
+			enabled:     true,
+			storagePath: freshStorage,
+			components:  []string{aggkitcommon.AGGORACLE},
+		},
+		{
+			name:        "the bridge service needs all the deposits",
+			enabled:     true,
+			storagePath: freshStorage,
+			components:  []string{aggkitcommon.AGGSENDER, aggkitcommon.BRIDGE},
+		},
+		{
+			name:        "the aggsender already ran",
+			enabled:     true,
+			storagePath: existingStorage,
+			components:  []string{aggkitcommon.AGGSENDER},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.AggSender.FastSyncFromAgglayer = tt.enabled
+			cfg.AggSender.StoragePath = tt.storagePath
+			fastSyncCfg := fastSyncAggSenderConfig(cfg, tt.components)
+			if !tt.expected {
+				require.Nil(t, fastSyncCfg)
+				return
+			}
+			require.Equal(t, &cfg.AggSender, fastSyncCfg)
+		})
+	}
+}
+
+func TestCreateNetworksRegistry(t *testing.T) {
+	newConfig := func(configured ...networks.NetworkConfig) *config.Config {
+		cfg := &config.Config{Networks: configured}
+		cfg.Common.NetworkID = 1
+		cfg.Common.L2RPC.URL = "http://localhost:8123"
+		cfg.L1NetworkConfig.URL = "http://localhost:8545"
+		cfg.BridgeL1Sync.BridgeAddr = common.HexToAddress("0x1")
+		cfg.BridgeL1Sync.BlockFinality = aggkittypes.FinalizedBlock.String()
+		cfg.L1InfoTreeSync.GlobalExitRootAddr = common.HexToAddress("0x2")
+		cfg.BridgeL2Sync.BridgeAddr = common.HexToAddress("0x3")
+		cfg.BridgeL2Sync.BlockFinality = aggkittypes.LatestBlock.String()
+		cfg.LastGERSync.GlobalExitRootL2Addr = common.HexToAddress("0x4")
+		return cfg
+	}
+	syncedL1 := networks.NetworkConfig{
+		ID: networks.L1NetworkID, Name: "L1", Role: networks.RoleL1,
+		RPCURLs:       []string{"http://localhost:8545"},
+		BridgeAddr:    common.HexToAddress("0x1"),
+		GERAddr:       common.HexToAddress("0x2"),
+		BlockFinality: aggkittypes.FinalizedBlock,
+	}
+	syncedL2 := networks.NetworkConfig{
+		ID: 1, Name: "L2", Role: networks.RoleL2,
+		RPCURLs:       []string{"http://localhost:8123"},
+		BridgeAddr:    common.HexToAddress("0x3"),
+		GERAddr:       common.HexToAddress("0x4"),
+		BlockFinality: aggkittypes.LatestBlock,
+	}
+	otherL2 := networks.NetworkConfig{ID: 2, Name: "other", Role: networks.RoleL2,
+		RPCURLs: []string{"http://localhost:9000"}}
+
+	t.Run("default networks are filled with the syncers settings", func(t *testing.T) {
+		registry, err := createNetworksRegistry(newConfig())
+		require.NoError(t, err)
+		require.Equal(t, []networks.NetworkConfig{syncedL1, syncedL2}, registry.List())
+	})
+
+	t.Run("configured networks are completed and the other L2s are kept", func(t *testing.T) {
+		registry, err := createNetworksRegistry(newConfig(
+			networks.NetworkConfig{ID: networks.L1NetworkID, Name: "L1", Role: networks.RoleL1},
+			networks.NetworkConfig{ID: 1, Name: "L2", Role: networks.RoleL2, BridgeAddr: common.HexToAddress("0x3")},
+			otherL2,
+		))
+		require.NoError(t, err)
+		require.Equal(t, []networks.NetworkConfig{syncedL1, syncedL2, otherL2}, registry.List())
+	})
+
+	t.Run("configured value conflicting with the syncers", func(t *testing.T) {
+		_, err := createNetworksRegistry(newConfig(
+			networks.NetworkConfig{ID: networks.L1NetworkID, Name: "L1", Role: networks.RoleL1,
+				GERAddr: common.HexToAddress("0x5")},
+			networks.NetworkConfig{ID: 1, Name: "L2", Role: networks.RoleL2},
+		))
+		require.ErrorIs(t, err, networks.ErrInvalidNetworkConfig)
+		require.ErrorContains(t, err, "GERAddr")
+	})
+
+	t.Run("the L2 of the aggkit is not configured", func(t *testing.T) {
+		_, err := createNetworksRegistry(newConfig(
+			networks.NetworkConfig{ID: networks.L1NetworkID, Name: "L1", Role: networks.RoleL1},
+			otherL2,
+		))
+		require.ErrorContains(t, err, "is not a L2 network of the registry")
+	})
+}
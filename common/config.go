@@ -1,8 +1,6 @@
 package common
 
 import (
-	"fmt"
-
 	"github.com/agglayer/aggkit/config/types"
 	ethermanconfig "github.com/agglayer/aggkit/etherman/config"
 )
@@ -17,7 +15,8 @@ type Config struct {
 
 // RESTConfig contains the configuration settings for the REST service in the Aggkit application.
 type RESTConfig struct {
-	// Host specifies the hostname or IP address on which the REST service will listen.
+	// Host specifies the hostname or IP address (IPv4 or IPv6) on which the REST service will listen.
+	// It can also be a unix domain socket (unix:///path/to/socket), in which case the Port is ignored
 	Host string `mapstructure:"Host"`
 
 	// Port defines the port number on which the REST service will be accessible.
@@ -34,9 +33,35 @@ type RESTConfig struct {
 	// MaxRequestsPerIPAndSecond defines how many requests a single IP can
 	// send within a single second
 	MaxRequestsPerIPAndSecond float64 `mapstructure:"MaxRequestsPerIPAndSecond"`
+
+	// ClaimProofPrecomputeDeposits is the number of most recent unclaimed deposits of the L1 and the L2
+	// whose claim proofs are precomputed and cached, so /claim-proof answers them without walking the
+	// trees (0 = disabled)
+	ClaimProofPrecomputeDeposits uint32 `mapstructure:"ClaimProofPrecomputeDeposits"`
+
+	// ClaimProofPrecomputeInterval is the interval at which the cached claim proofs are refreshed
+	// if a new L1 info tree root has arrived
+	ClaimProofPrecomputeInterval types.Duration `mapstructure:"ClaimProofPrecomputeInterval"`
+
+	// EndpointTimeouts overrides the ReadTimeout of the queries of heavy endpoints. The keys are the
+	// routes relative to /bridge/v1 (e.g. claim-proof)
+	EndpointTimeouts map[string]types.Duration `mapstructure:"EndpointTimeouts"`
+
+	// SlowRequestThreshold is the latency from which a request is logged (with its parameters)
+	// and counted as slow (0 = disabled)
+	SlowRequestThreshold types.Duration `mapstructure:"SlowRequestThreshold"`
+
+	// ReadinessMaxL1BlocksBehind is the maximum number of blocks that the L1 bridge syncer can be behind
+	// the chain for the /ready endpoint to report the bridge service as ready
+	ReadinessMaxL1BlocksBehind uint64 `mapstructure:"ReadinessMaxL1BlocksBehind"`
+
+	// ReadinessMaxL2BlocksBehind is the maximum number of blocks that the L2 bridge syncer can be behind
+	// the chain for the /ready endpoint to report the bridge service as ready
+	ReadinessMaxL2BlocksBehind uint64 `mapstructure:"ReadinessMaxL2BlocksBehind"`
 }
 
-// Address constructs and returns the address as a string in the format "host:port".
+// Address constructs and returns the address as a string in the format "host:port" ("[host]:port" for
+// IPv6 literals), or the unix socket address as is.
 func (c *RESTConfig) Address() string {
-	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+	return ListenAddress(c.Host, c.Port)
 }
@@ -0,0 +1,177 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cronFields = 5
+	// maxCronSearchDays is how far Next looks for the next matching time (a schedule like `0 0 31 2 *`
+	// never matches)
+	maxCronSearchDays = 5 * 366
+	hoursPerDay       = 24
+	minutesPerHour    = 60
+)
+
+var ErrInvalidCronSchedule = errors.New("invalid cron schedule")
+
+// cronField is the range of values of a field of the cron expression
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var (
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12}
+	// the day of week 7 is also Sunday
+	cronDayOfWeek = cronField{name: "day of week", min: 0, max: 7}
+)
+
+// CronSchedule is a cron expression with the standard 5 fields (minute, hour, day of month, month and
+// day of week), evaluated in UTC. Each field accepts `*`, single values, ranges (`1-5`), steps (`*/15`,
+// `0-30/10`) and lists of them (`1,15`). As in cron, if both the day of month and the day of week are
+// restricted, a day matches if any of them matches
+type CronSchedule struct {
+	expr                                            string
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	dayOfMonthRestricted, dayOfWeekRestricted       bool
+}
+
+// ParseCronSchedule parses a 5 fields cron expression, e.g. `0 3 * * 2` (every Tuesday at 03:00 UTC)
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != cronFields {
+		return nil, fmt.Errorf("%w: %q must have %d fields (minute hour day-of-month month day-of-week), got %d",
+			ErrInvalidCronSchedule, expr, cronFields, len(fields))
+	}
+	s := &CronSchedule{
+		expr:                 strings.Join(fields, " "),
+		dayOfMonthRestricted: fields[2] != "*",
+		dayOfWeekRestricted:  fields[4] != "*",
+	}
+	var err error
+	if s.minutes, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if s.daysOfMonth, err = parseCronField(fields[2], cronDayOfMonth); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if s.daysOfWeek, err = parseCronField(fields[4], cronDayOfWeek); err != nil {
+		return nil, err
+	}
+	if s.daysOfWeek&(1<<cronDayOfWeek.max) != 0 {
+		s.daysOfWeek |= 1
+	}
+	return s, nil
+}
+
+// parseCronField returns the bitset of the values of the field
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q of the %s", ErrInvalidCronSchedule, stepPart, field.name)
+			}
+		}
+		from, to := field.min, field.max
+		if rangePart != "*" {
+			fromPart, toPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = parseCronValue(fromPart, field); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if to, err = parseCronValue(toPart, field); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				// a single value, with a step it goes up to the max (e.g. 5/15)
+				to = from
+			}
+			if from > to {
+				return 0, fmt.Errorf("%w: invalid range %q of the %s", ErrInvalidCronSchedule, rangePart, field.name)
+			}
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("%w: invalid %s %q (must be between %d and %d)",
+			ErrInvalidCronSchedule, field.name, value, field.min, field.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time of the schedule after t (in UTC), or the zero time if the schedule
+// doesn't match any time in the next 5 years
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	for day := 0; day < maxCronSearchDays; day++ {
+		if s.matchDay(t) {
+			for hour := t.Hour(); hour < hoursPerDay; hour++ {
+				if s.hours&(1<<hour) == 0 {
+					continue
+				}
+				minute := 0
+				if hour == t.Hour() {
+					minute = t.Minute()
+				}
+				for ; minute < minutesPerHour; minute++ {
+					if s.minutes&(1<<minute) != 0 {
+						return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, time.UTC)
+					}
+				}
+			}
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
+
+// matchDay returns true if the month and the day of t match the schedule
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	if s.months&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.daysOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.daysOfWeek&(1<<int(t.Weekday())) != 0
+	switch {
+	case s.dayOfMonthRestricted && s.dayOfWeekRestricted:
+		return dayOfMonth || dayOfWeek
+	case s.dayOfMonthRestricted:
+		return dayOfMonth
+	case s.dayOfWeekRestricted:
+		return dayOfWeek
+	default:
+		return true
+	}
+}
+
+// String returns the cron expression
+func (s *CronSchedule) String() string {
+	return s.expr
+}
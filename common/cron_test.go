@@ -0,0 +1,65 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := common.ParseCronSchedule(expr)
+		require.ErrorIs(t, err, common.ErrInvalidCronSchedule, expr)
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2025, time.January, 1, 10, 30, 20, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.January, 1, 10, 31, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2025, time.January, 2, 10, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.January, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * 2", time.Date(2025, time.January, 7, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 0", time.Date(2025, time.January, 5, 3, 0, 0, 0, time.UTC)},
+		{"0 3 * * 7", time.Date(2025, time.January, 5, 3, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 22-23 * * 1-5", time.Date(2025, time.January, 1, 22, 0, 0, 0, time.UTC)},
+		{"5,45 9,11 * * *", time.Date(2025, time.January, 1, 11, 5, 0, 0, time.UTC)},
+		// the day of month or the day of week (Friday 3rd)
+		{"0 0 15 * 5", time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := common.ParseCronSchedule(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, schedule.Next(from))
+			require.Equal(t, tt.expr, schedule.String())
+		})
+	}
+
+	// the times are evaluated in UTC
+	schedule, err := common.ParseCronSchedule("0 12 * * *")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+		schedule.Next(time.Date(2025, time.January, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))))
+}
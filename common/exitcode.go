@@ -0,0 +1,108 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExitCode is the exit code of the process, by the class of the failure that stopped it, so the
+// orchestration (e.g. the restart policy of docker or kubernetes) can decide between restarting it
+// and paging a human
+type ExitCode int
+
+const (
+	// ExitCodeOK is a clean exit (e.g. a graceful shutdown)
+	ExitCodeOK ExitCode = 0
+	// ExitCodeUnexpected is any failure not classified
+	ExitCodeUnexpected ExitCode = 1
+	// ExitCodeConfig is an invalid configuration (or flags)
+	ExitCodeConfig ExitCode = 2
+	// ExitCodeDBCorruption is a corrupted DB file
+	ExitCodeDBCorruption ExitCode = 3
+	// ExitCodeIncompatibleStorage is a DB whose contents are from another network or contracts
+	// (RequireStorageContentCompatibility)
+	ExitCodeIncompatibleStorage ExitCode = 4
+	// ExitCodeFatalRPC is an RPC that failed too many times, or a node that couldn't be reached on startup
+	ExitCodeFatalRPC ExitCode = 5
+)
+
+// exitCodeInfo describes an exit code for the help
+type exitCodeInfo struct {
+	code        ExitCode
+	name        string
+	restart     bool
+	description string
+}
+
+var exitCodes = []exitCodeInfo{
+	{ExitCodeOK, "ok", false, "clean exit"},
+	{ExitCodeUnexpected, "unexpected", true, "unclassified failure"},
+	{ExitCodeConfig, "config", false, "invalid configuration, fix it before restarting"},
+	{ExitCodeDBCorruption, "db-corruption", false, "corrupted DB file, restore it from a backup or resync it"},
+	{ExitCodeIncompatibleStorage, "incompatible-storage", false,
+		"the DB contents are from another network or contracts, check the DBPath of the config"},
+	{ExitCodeFatalRPC, "fatal-rpc", true, "an RPC node failed too many times or is not reachable, check the node"},
+}
+
+// String returns the name of the exit code
+func (c ExitCode) String() string {
+	for _, info := range exitCodes {
+		if info.code == c {
+			return info.name
+		}
+	}
+	return fmt.Sprintf("exit-code-%d", int(c))
+}
+
+// ExitCodesHelp returns the description of the exit codes, for the help of the CLI
+func ExitCodesHelp() string {
+	var sb strings.Builder
+	sb.WriteString("Exit codes:\n")
+	for _, info := range exitCodes {
+		action := "restart"
+		if !info.restart {
+			action = "don't restart"
+		}
+		if info.code == ExitCodeOK {
+			action = "-"
+		}
+		fmt.Fprintf(&sb, "  %d  %-21s %-14s %s\n", int(info.code), info.name, action, info.description)
+	}
+	return sb.String()
+}
+
+// ExitError is an error with the exit code of its class
+type ExitError struct {
+	Code ExitCode
+	Err  error
+}
+
+// NewExitError classifies the error with the exit code. It returns nil if err is nil
+func NewExitError(code ExitCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ExitError{Code: code, Err: err}
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCodeOf returns the exit code of the error: the code of the outermost ExitError of its chain,
+// ExitCodeUnexpected if it's not classified, or ExitCodeOK if it's nil
+func ExitCodeOf(err error) ExitCode {
+	if err == nil {
+		return ExitCodeOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitCodeUnexpected
+}
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	errUnittest := errors.New("unittest")
+	tests := []struct {
+		name     string
+		err      error
+		expected ExitCode
+	}{
+		{name: "nil", err: nil, expected: ExitCodeOK},
+		{name: "not classified", err: errUnittest, expected: ExitCodeUnexpected},
+		{name: "classified", err: NewExitError(ExitCodeConfig, errUnittest), expected: ExitCodeConfig},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("starting: %w", NewExitError(ExitCodeFatalRPC, errUnittest)),
+			expected: ExitCodeFatalRPC,
+		},
+		{
+			name:     "the outermost class wins",
+			err:      NewExitError(ExitCodeConfig, NewExitError(ExitCodeFatalRPC, errUnittest)),
+			expected: ExitCodeConfig,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ExitCodeOf(tt.err))
+		})
+	}
+}
+
+func TestExitError(t *testing.T) {
+	errUnittest := errors.New("unittest")
+	require.NoError(t, NewExitError(ExitCodeConfig, nil))
+	err := NewExitError(ExitCodeIncompatibleStorage, errUnittest)
+	require.ErrorIs(t, err, errUnittest)
+	require.Equal(t, errUnittest.Error(), err.Error())
+}
+
+func TestExitCodesHelp(t *testing.T) {
+	help := ExitCodesHelp()
+	for _, code := range []ExitCode{ExitCodeOK, ExitCodeUnexpected, ExitCodeConfig, ExitCodeDBCorruption,
+		ExitCodeIncompatibleStorage, ExitCodeFatalRPC} {
+		require.Contains(t, help, fmt.Sprintf("  %d  %s ", int(code), code.String()))
+	}
+	require.Equal(t, "exit-code-42", ExitCode(42).String())
+}
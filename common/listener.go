@@ -0,0 +1,64 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// UnixSocketScheme is the prefix of the listen addresses of unix domain sockets (e.g. unix:///run/aggkit.sock)
+const UnixSocketScheme = "unix://"
+
+// ListenAddress returns the address to listen on for the host and port of a server config. The host can
+// be a host name, an IPv4 or IPv6 literal (with or without brackets) or a unix domain socket
+// (unix:///path/to/socket), in which case the port is ignored
+func ListenAddress(host string, port int) string {
+	if strings.HasPrefix(host, UnixSocketScheme) {
+		return host
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// Listen creates a listener on an address returned by ListenAddress: a unix domain socket for the
+// unix:// addresses or a TCP listener otherwise. The socket file left by a previous run is removed,
+// and it's removed again when the listener is closed
+func Listen(address string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(address, UnixSocketScheme)
+	if !ok {
+		return net.Listen("tcp", address)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("missing the socket path in the listen address %s", address)
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", path)
+}
+
+// removeStaleSocket removes the socket file of the path if no process is listening on it
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error checking the unix socket %s: %w", path, err)
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return fmt.Errorf("the unix socket path %s already exists and it's not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("the unix socket %s is already in use", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("error removing the stale unix socket %s: %w", path, err)
+	}
+	return nil
+}
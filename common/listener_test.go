@@ -0,0 +1,89 @@
+package common
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		host     string
+		port     int
+		expected string
+	}{
+		{name: "host name", host: "localhost", port: 5577, expected: "localhost:5577"},
+		{name: "IPv4 literal", host: "0.0.0.0", port: 5577, expected: "0.0.0.0:5577"},
+		{name: "IPv6 literal", host: "::1", port: 5577, expected: "[::1]:5577"},
+		{name: "IPv6 literal with brackets", host: "[::]", port: 5577, expected: "[::]:5577"},
+		{name: "empty host", host: "", port: 5577, expected: ":5577"},
+		{name: "unix socket", host: "unix:///run/aggkit/bridge.sock", port: 5577,
+			expected: "unix:///run/aggkit/bridge.sock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, ListenAddress(tt.host, tt.port))
+		})
+	}
+}
+
+func TestListenTCP(t *testing.T) {
+	t.Parallel()
+
+	lis, err := Listen(ListenAddress("127.0.0.1", 0))
+	require.NoError(t, err)
+	defer lis.Close()
+	require.Equal(t, "tcp", lis.Addr().Network())
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "aggkit.sock")
+	address := ListenAddress(UnixSocketScheme+path, 0)
+
+	lis, err := Listen(address)
+	require.NoError(t, err)
+	require.Equal(t, "unix", lis.Addr().Network())
+
+	// the socket is in use, so it's not removed
+	_, err = Listen(address)
+	require.ErrorContains(t, err, "is already in use")
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	require.NoError(t, lis.Close())
+	require.NoFileExists(t, path)
+
+	// a socket file without a listener (e.g. after a crash) is removed
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	require.FileExists(t, path)
+
+	lis, err = Listen(address)
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+}
+
+func TestListenUnixSocketErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Listen(UnixSocketScheme)
+	require.ErrorContains(t, err, "missing the socket path")
+
+	path := filepath.Join(t.TempDir(), "aggkit.sock")
+	require.NoError(t, os.WriteFile(path, []byte("not a socket"), 0o600))
+	_, err = Listen(UnixSocketScheme + path)
+	require.ErrorContains(t, err, "it's not a socket")
+}
@@ -0,0 +1,206 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/agglayer/aggkit/bridgesync"
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/etherman"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/agglayer/aggkit/lastgersync"
+	"github.com/agglayer/aggkit/networks"
+	"github.com/agglayer/aggkit/reorgdetector"
+	aggkittypes "github.com/agglayer/aggkit/types"
+)
+
+// Dependency is a shared service created by the start command for the components that need it
+type Dependency string
+
+const (
+	L1Client          Dependency = "L1Client"
+	L2Client          Dependency = "L2Client"
+	ReorgDetectorL1   Dependency = "ReorgDetectorL1"
+	ReorgDetectorL2   Dependency = "ReorgDetectorL2"
+	RollupDataQuerier Dependency = "RollupDataQuerier"
+	L1InfoTreeSync    Dependency = "L1InfoTreeSync"
+	BridgeL1Sync      Dependency = "BridgeL1Sync"
+	BridgeL2Sync      Dependency = "BridgeL2Sync"
+	LastGERSync       Dependency = "LastGERSync"
+)
+
+// impliedDependencies are the dependencies used to create each dependency
+var impliedDependencies = map[Dependency][]Dependency{
+	ReorgDetectorL1: {L1Client},
+	ReorgDetectorL2: {L2Client},
+	L1InfoTreeSync:  {L1Client, ReorgDetectorL1},
+	BridgeL1Sync:    {L1Client, ReorgDetectorL1},
+	BridgeL2Sync:    {L2Client, ReorgDetectorL2, RollupDataQuerier},
+	LastGERSync:     {L2Client, ReorgDetectorL2, L1InfoTreeSync},
+}
+
+// Services are the shared services passed to the components on Init. The dependencies not needed by
+// any of the components that run are nil
+type Services struct {
+	Config            *config.Config
+	NetworksRegistry  *networks.Registry
+	L1Client          aggkittypes.EthClienter
+	L2Client          aggkittypes.EthClienter
+	ReorgDetectorL1   *reorgdetector.ReorgDetector
+	ReorgDetectorL2   *reorgdetector.ReorgDetector
+	RollupDataQuerier *etherman.RollupDataQuerier
+	L1InfoTreeSync    *l1infotreesync.L1InfoTreeSync
+	BridgeL1Sync      *bridgesync.BridgeSync
+	BridgeL2Sync      *bridgesync.BridgeSync
+	LastGERSync       *lastgersync.LastGERSync
+}
+
+// Component is a component run by the start command. All the components are initialized before any of
+// them is started, and they are stopped in the reverse order on the graceful shutdown
+type Component interface {
+	// Init creates the resources of the component from the shared services
+	Init(ctx context.Context, services *Services) error
+	// Start starts the component, it must not block: the long running tasks run in their goroutines
+	// until ctx is done
+	Start(ctx context.Context) error
+	// Stop stops the component before the process exits
+	Stop(ctx context.Context) error
+}
+
+// HealthChecker is implemented by the components that report their health, it's included in the health
+// endpoint of the RPC server once the component is started
+type HealthChecker interface {
+	// Health returns an error if the component is not healthy
+	Health(ctx context.Context) error
+}
+
+// RPCServicesProvider is implemented by the components that serve RPC services, they are added to the
+// RPC server once the component is initialized
+type RPCServicesProvider interface {
+	GetRPCServices() []jRPC.Service
+}
+
+// SectionDecoder decodes the config section of a component into out, see config.Config.DecodeSection
+type SectionDecoder func(out any) error
+
+// Factory creates a component from its config section
+type Factory func(decodeSection SectionDecoder) (Component, error)
+
+// Registration is a component that can be run by the start command
+type Registration struct {
+	// Name identifies the component in the --components flag
+	Name string
+	// ConfigSection is the config section of the component: a field of the Config (e.g. AggSender)
+	// or a section under [Components]. Empty if the component has no config
+	ConfigSection string
+	// Dependencies are the shared services used by the component
+	Dependencies []Dependency
+	// New creates the component
+	New Factory
+}
+
+var (
+	ErrComponentAlreadyRegistered = errors.New("component already registered")
+	ErrUnknownComponent           = errors.New("unknown component")
+)
+
+// Registry is the set of components that can be run, by name
+type Registry struct {
+	mu            sync.Mutex
+	registrations map[string]Registration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{registrations: make(map[string]Registration)}
+}
+
+// Register adds the component to the registry
+func (r *Registry) Register(registration Registration) error {
+	if registration.Name == "" {
+		return errors.New("the component must have a name")
+	}
+	if registration.New == nil {
+		return fmt.Errorf("the component %s must have a factory", registration.Name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.registrations[registration.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrComponentAlreadyRegistered, registration.Name)
+	}
+	r.registrations[registration.Name] = registration
+	return nil
+}
+
+// Names returns the names of the registered components, sorted
+func (r *Registry) Names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.registrations))
+	for name := range r.registrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// registrationsOf returns the registrations of the components, in the same order
+func (r *Registry) registrationsOf(names []string) ([]Registration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]Registration, 0, len(names))
+	for _, name := range names {
+		registration, ok := r.registrations[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+		}
+		result = append(result, registration)
+	}
+	return result, nil
+}
+
+// Dependencies returns the shared services needed by the components, including the ones used to create them
+func (r *Registry) Dependencies(names []string) (map[Dependency]bool, error) {
+	registrations, err := r.registrationsOf(names)
+	if err != nil {
+		return nil, err
+	}
+	needed := make(map[Dependency]bool)
+	var add func(dependency Dependency)
+	add = func(dependency Dependency) {
+		if needed[dependency] {
+			return
+		}
+		needed[dependency] = true
+		for _, implied := range impliedDependencies[dependency] {
+			add(implied)
+		}
+	}
+	for _, registration := range registrations {
+		for _, dependency := range registration.Dependencies {
+			add(dependency)
+		}
+	}
+	return needed, nil
+}
+
+// defaultRegistry is the registry of the components run by the start command
+var defaultRegistry = NewRegistry()
+
+// Default returns the registry of the components run by the start command
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// MustRegister adds the component to the registry of the start command, it panics if it can't be added.
+// It's meant to be called from the init function of the package of the component, so the downstream
+// builds add their components by importing it
+func MustRegister(registration Registration) {
+	if err := defaultRegistry.Register(registration); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestComponentFactory(component Component) Factory {
+	return func(SectionDecoder) (Component, error) { return component, nil }
+}
+
+func TestRegistryRegister(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Registration{Name: "oracle", New: newTestComponentFactory(nil)}))
+	require.NoError(t, registry.Register(Registration{Name: "indexer", New: newTestComponentFactory(nil)}))
+	require.Equal(t, []string{"indexer", "oracle"}, registry.Names())
+
+	require.ErrorIs(t, registry.Register(Registration{Name: "oracle", New: newTestComponentFactory(nil)}),
+		ErrComponentAlreadyRegistered)
+	require.ErrorContains(t, registry.Register(Registration{New: newTestComponentFactory(nil)}), "must have a name")
+	require.ErrorContains(t, registry.Register(Registration{Name: "foo"}), "must have a factory")
+}
+
+func TestRegistryDependencies(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Registration{
+		Name: "oracle", Dependencies: []Dependency{L1InfoTreeSync}, New: newTestComponentFactory(nil),
+	}))
+	require.NoError(t, registry.Register(Registration{
+		Name: "indexer", Dependencies: []Dependency{LastGERSync}, New: newTestComponentFactory(nil),
+	}))
+	require.NoError(t, registry.Register(Registration{Name: "noop", New: newTestComponentFactory(nil)}))
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected map[Dependency]bool
+	}{
+		{name: "no dependencies", names: []string{"noop"}, expected: map[Dependency]bool{}},
+		{
+			name:     "the dependencies used to create them are included",
+			names:    []string{"oracle"},
+			expected: map[Dependency]bool{L1InfoTreeSync: true, L1Client: true, ReorgDetectorL1: true},
+		},
+		{
+			name:  "the dependencies of all the components",
+			names: []string{"oracle", "indexer"},
+			expected: map[Dependency]bool{
+				L1InfoTreeSync: true, L1Client: true, ReorgDetectorL1: true,
+				LastGERSync: true, L2Client: true, ReorgDetectorL2: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needed, err := registry.Dependencies(tt.names)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, needed)
+		})
+	}
+
+	_, err := registry.Dependencies([]string{"oracle", "foo"})
+	require.ErrorIs(t, err, ErrUnknownComponent)
+}
+
+func TestMustRegister(t *testing.T) {
+	t.Cleanup(func() {
+		defaultRegistry.mu.Lock()
+		defer defaultRegistry.mu.Unlock()
+		delete(defaultRegistry.registrations, "test-component")
+	})
+	registration := Registration{Name: "test-component", New: newTestComponentFactory(nil)}
+	MustRegister(registration)
+	require.Contains(t, Default().Names(), "test-component")
+	require.Panics(t, func() { MustRegister(registration) })
+}
+
+// testComponent records the calls to its lifecycle hooks in calls
+type testComponent struct {
+	name      string
+	calls     *[]string
+	initErr   error
+	startErr  error
+	stopErr   error
+	healthErr error
+}
+
+func (c *testComponent) Init(context.Context, *Services) error {
+	*c.calls = append(*c.calls, "init "+c.name)
+	return c.initErr
+}
+
+func (c *testComponent) Start(context.Context) error {
+	*c.calls = append(*c.calls, "start "+c.name)
+	return c.startErr
+}
+
+func (c *testComponent) Stop(context.Context) error {
+	*c.calls = append(*c.calls, "stop "+c.name)
+	return c.stopErr
+}
+
+func (c *testComponent) Health(context.Context) error {
+	return c.healthErr
+}
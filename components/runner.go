@@ -0,0 +1,106 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/healthcheck"
+	"github.com/agglayer/aggkit/log"
+)
+
+// instance is a component created from its registration
+type instance struct {
+	name      string
+	component Component
+}
+
+// Runner runs the lifecycle (init, start, stop) of the components, in the order they were requested
+type Runner struct {
+	log       *log.Logger
+	instances []instance
+	started   []instance
+}
+
+// NewRunner creates the components from their registrations and config sections. The repeated
+// components are created once
+func (r *Registry) NewRunner(logger *log.Logger, cfg *config.Config, names []string) (*Runner, error) {
+	registrations, err := r.registrationsOf(names)
+	if err != nil {
+		return nil, err
+	}
+	runner := &Runner{log: logger}
+	created := make(map[string]bool, len(registrations))
+	for _, registration := range registrations {
+		if created[registration.Name] {
+			continue
+		}
+		created[registration.Name] = true
+		section := registration.ConfigSection
+		component, err := registration.New(func(out any) error {
+			if section == "" {
+				return fmt.Errorf("the component %s has no config section", registration.Name)
+			}
+			return cfg.DecodeSection(section, out)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error creating the component %s: %w", registration.Name, err)
+		}
+		runner.instances = append(runner.instances, instance{name: registration.Name, component: component})
+	}
+	return runner, nil
+}
+
+// Init initializes all the components, it stops on the first error
+func (r *Runner) Init(ctx context.Context, services *Services) error {
+	for _, i := range r.instances {
+		if err := i.component.Init(ctx, services); err != nil {
+			return fmt.Errorf("error initializing the component %s: %w", i.name, err)
+		}
+	}
+	return nil
+}
+
+// RPCServices returns the RPC services of the initialized components
+func (r *Runner) RPCServices() []jRPC.Service {
+	var services []jRPC.Service
+	for _, i := range r.instances {
+		if provider, ok := i.component.(RPCServicesProvider); ok {
+			services = append(services, provider.GetRPCServices()...)
+		}
+	}
+	return services
+}
+
+// Start starts the components and adds their health checks to the health endpoint, it stops on the
+// first error
+func (r *Runner) Start(ctx context.Context) error {
+	for _, i := range r.instances {
+		r.log.Infof("starting the component %s", i.name)
+		if err := i.component.Start(ctx); err != nil {
+			return fmt.Errorf("error starting the component %s: %w", i.name, err)
+		}
+		r.started = append(r.started, i)
+		if checker, ok := i.component.(HealthChecker); ok {
+			healthcheck.RegisterComponentHealth(i.name, checker.Health)
+		}
+	}
+	return nil
+}
+
+// Stop stops the started components in the reverse order. All of them are stopped, the errors are joined
+func (r *Runner) Stop(ctx context.Context) error {
+	var errs []error
+	for idx := len(r.started) - 1; idx >= 0; idx-- {
+		i := r.started[idx]
+		healthcheck.RegisterComponentHealth(i.name, nil)
+		r.log.Infof("stopping the component %s", i.name)
+		if err := i.component.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error stopping the component %s: %w", i.name, err))
+		}
+	}
+	r.started = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,128 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/agglayer/aggkit/config"
+	"github.com/agglayer/aggkit/healthcheck"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+// rpcTestComponent is a testComponent that serves an RPC service
+type rpcTestComponent struct {
+	testComponent
+}
+
+func (c *rpcTestComponent) GetRPCServices() []jRPC.Service {
+	return []jRPC.Service{{Name: c.name}}
+}
+
+func TestRunner(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	oracle := &rpcTestComponent{testComponent{name: "oracle", calls: &calls}}
+	indexer := &testComponent{name: "indexer", calls: &calls}
+
+	type oracleConfig struct {
+		URL     string `mapstructure:"URL"`
+		Retries int    `mapstructure:"Retries"`
+	}
+	var decodedCfg oracleConfig
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Registration{
+		Name:          "oracle",
+		ConfigSection: "Oracle",
+		New: func(decodeSection SectionDecoder) (Component, error) {
+			decodedCfg = oracleConfig{Retries: 3}
+			return oracle, decodeSection(&decodedCfg)
+		},
+	}))
+	require.NoError(t, registry.Register(Registration{
+		Name: "indexer",
+		New: func(decodeSection SectionDecoder) (Component, error) {
+			require.ErrorContains(t, decodeSection(&oracleConfig{}), "has no config section")
+			return indexer, nil
+		},
+	}))
+	cfg := &config.Config{Components: map[string]map[string]any{"oracle": {"url": "http://localhost:9000"}}}
+
+	_, err := registry.NewRunner(log.WithFields("test", "components"), cfg, []string{"oracle", "foo"})
+	require.ErrorIs(t, err, ErrUnknownComponent)
+
+	runner, err := registry.NewRunner(log.WithFields("test", "components"), cfg,
+		[]string{"oracle", "indexer", "oracle"})
+	require.NoError(t, err)
+	require.Equal(t, oracleConfig{URL: "http://localhost:9000", Retries: 3}, decodedCfg)
+
+	services := &Services{Config: cfg}
+	require.NoError(t, runner.Init(ctx, services))
+	require.Equal(t, []jRPC.Service{{Name: "oracle"}}, runner.RPCServices())
+	require.NoError(t, runner.Start(ctx))
+	require.Equal(t, []string{"init oracle", "init indexer", "start oracle", "start indexer"}, calls)
+
+	// the health of the started components is in the health endpoint
+	serveHealth := func() (int, []healthcheck.ComponentCheck) {
+		rr := httptest.NewRecorder()
+		healthcheck.NewHealthCheckHandler(log.GetDefaultLogger(), nil).
+			ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var response struct {
+			ComponentChecks []healthcheck.ComponentCheck `json:"component_checks"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response.ComponentChecks
+	}
+	indexer.healthErr = errors.New("behind the chain")
+	code, checks := serveHealth()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, []healthcheck.ComponentCheck{
+		{Component: "indexer", Error: "behind the chain"},
+		{Component: "oracle", Healthy: true},
+	}, checks)
+
+	// the components are stopped in the reverse order, all of them even if one fails
+	calls = nil
+	oracle.stopErr = errors.New("foo")
+	err = runner.Stop(ctx)
+	require.ErrorContains(t, err, "error stopping the component oracle: foo")
+	require.Equal(t, []string{"stop indexer", "stop oracle"}, calls)
+	code, checks = serveHealth()
+	require.Equal(t, http.StatusOK, code)
+	require.Empty(t, checks)
+}
+
+func TestRunnerLifecycleErrors(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	first := &testComponent{name: "first", calls: &calls}
+	second := &testComponent{name: "second", calls: &calls}
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(Registration{Name: "first", New: newTestComponentFactory(first)}))
+	require.NoError(t, registry.Register(Registration{Name: "second", New: newTestComponentFactory(second)}))
+	require.NoError(t, registry.Register(Registration{
+		Name: "broken",
+		New:  func(SectionDecoder) (Component, error) { return nil, errors.New("bar") },
+	}))
+
+	_, err := registry.NewRunner(log.WithFields("test", "components"), &config.Config{}, []string{"first", "broken"})
+	require.ErrorContains(t, err, "error creating the component broken: bar")
+
+	runner, err := registry.NewRunner(log.WithFields("test", "components"), &config.Config{},
+		[]string{"first", "second"})
+	require.NoError(t, err)
+	second.initErr = errors.New("foo")
+	require.ErrorContains(t, runner.Init(ctx, &Services{}), "error initializing the component second: foo")
+
+	// only the started components are stopped
+	calls = nil
+	second.startErr = errors.New("foo")
+	require.ErrorContains(t, runner.Start(ctx), "error starting the component second: foo")
+	require.NoError(t, runner.Stop(ctx))
+	require.Equal(t, []string{"start first", "start second", "stop first"}, calls)
+}
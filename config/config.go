@@ -2,21 +2,26 @@ package config
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
 	"github.com/agglayer/aggkit/aggoracle"
 	aggsendercfg "github.com/agglayer/aggkit/aggsender/config"
 	"github.com/agglayer/aggkit/aggsender/prover"
+	"github.com/agglayer/aggkit/bridgeservice"
 	"github.com/agglayer/aggkit/bridgesync"
 	"github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/healthcheck"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/lastgersync"
 	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/networks"
 	"github.com/agglayer/aggkit/pprof"
 	"github.com/agglayer/aggkit/prometheus"
 	"github.com/agglayer/aggkit/reorgdetector"
@@ -196,12 +201,22 @@ type Config struct {
 	// L1NetworkConfig represents the L1 network config and contains RPC URL alongside L1 contract addresses.
 	L1NetworkConfig L1NetworkConfig
 
+	// Networks are the networks supported by the Aggkit (the L1 and the L2s). The L1 and the L2 run by the
+	// Aggkit are completed with the settings of their syncers
+	Networks []networks.NetworkConfig
+
 	// REST contains the configuration settings for the REST service in the Aggkit
 	REST common.RESTConfig
 
+	// BridgeService is the configuration of the jobs and of the admin endpoints of the bridge service
+	BridgeService bridgeservice.ServiceConfig
+
 	// RPC is the config for the RPC server
 	RPC jRPC.Config
 
+	// HealthCheck is the config of the health endpoint of the RPC server
+	HealthCheck healthcheck.Config
+
 	// Configuration of the reorg detector service to be used for the L1
 	ReorgDetectorL1 reorgdetector.Config
 
@@ -235,12 +250,61 @@ type Config struct {
 
 	// Profiling is the configuration of the profiling service
 	Profiling pprof.Config
+
+	// Components are the config sections ([Components.<Section>]) of the components that are not part of
+	// this Config, like the ones registered by the downstream builds. See DecodeSection
+	Components map[string]map[string]any
+}
+
+// DecodeSection decodes the config section of a component into out. A section that is a field of the
+// Config is copied (out must point to its type), any other is decoded from [Components.<section>] over
+// the values of out, that are its defaults
+func (c *Config) DecodeSection(section string, out any) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("the config section %s must be decoded into a pointer, got %T", section, out)
+	}
+	field := reflect.ValueOf(c).Elem().FieldByNameFunc(func(name string) bool {
+		return name != "Components" && strings.EqualFold(name, section)
+	})
+	if field.IsValid() {
+		if target.Elem().Type() != field.Type() {
+			return fmt.Errorf("the config section %s must be decoded into a *%s, got %T", section, field.Type(), out)
+		}
+		target.Elem().Set(field)
+		return nil
+	}
+
+	// the keys of the sections are lowercased by viper
+	values, ok := c.Components[strings.ToLower(section)]
+	if !ok {
+		return nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			mapstructure.TextUnmarshallerHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+		),
+		WeaklyTypedInput: true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(values); err != nil {
+		return fmt.Errorf("failed to decode the config section Components.%s: %w", section, err)
+	}
+	return nil
 }
 
 // Load loads the configuration
 func Load(ctx *cli.Context) (*Config, error) {
 	configFilePath := ctx.StringSlice(FlagCfg)
-	filesData, err := readFiles(configFilePath)
+	remoteOpts, err := newRemoteConfigOptions(ctx.String(FlagCfgCacheDir), ctx.String(FlagCfgPublicKey))
+	if err != nil {
+		return nil, err
+	}
+	filesData, err := readFiles(ctx.Context, configFilePath, remoteOpts)
 	if err != nil {
 		return nil, fmt.Errorf("error reading files:  Err:%w", err)
 	}
@@ -250,14 +314,26 @@ func Load(ctx *cli.Context) (*Config, error) {
 	return LoadFile(filesData, saveConfigPath, defaultConfigVars, allowDeprecatedFields)
 }
 
-func readFiles(files []string) ([]FileData, error) {
+// readFiles reads the config files, fetching the remote ones (URLs) with the remote options
+func readFiles(ctx context.Context, files []string, remoteOpts RemoteConfigOptions) ([]FileData, error) {
 	result := make([]FileData, 0, len(files))
 	for _, file := range files {
-		fileContent, err := readFileToString(file)
+		var (
+			fileContent   string
+			fileExtension string
+			err           error
+		)
+		if isRemoteConfigFile(file) {
+			fileContent, err = remoteOpts.fetch(ctx, file)
+			fileExtension = remoteConfigFileExtension(file)
+			file = redactURL(file)
+		} else {
+			fileContent, err = readFileToString(file)
+			fileExtension = getFileExtension(file)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("error reading file content: %s. Err:%w", file, err)
 		}
-		fileExtension := getFileExtension(file)
 		if fileExtension != ConfigType {
 			fileContent, err = convertFileToToml(fileContent, fileExtension)
 			if err != nil {
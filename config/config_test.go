@@ -7,8 +7,12 @@ import (
 	"testing"
 	"time"
 
+	configtypes "github.com/agglayer/aggkit/config/types"
 	ethermanconfig "github.com/agglayer/aggkit/etherman/config"
+	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	"github.com/agglayer/aggkit/lastgersync"
 	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 )
@@ -37,13 +41,127 @@ func TestLoadDefaultConfig(t *testing.T) {
 	require.Equal(t, cfg.AggSender.OptimisticModeConfig.TrustedSequencerKey, cfg.AggSender.AggsenderPrivateKey)
 	require.Equal(t, cfg.AggSender.OptimisticModeConfig.OpNodeURL, "http://localhost:8080")
 	require.Equal(t, cfg.L1InfoTreeSync.RequireStorageContentCompatibility, true)
+	require.False(t, cfg.L1InfoTreeSync.InMemoryMirror.Enabled)
+	require.Equal(t, uint64(256), cfg.L1InfoTreeSync.InMemoryMirror.MaxMemoryMiB)
+	require.Equal(t, 10*time.Minute, cfg.L1InfoTreeSync.InMemoryMirror.CheckpointInterval.Duration)
 	require.Equal(t, ethermanconfig.RPCClientConfig{Mode: ethermanconfig.RPCModeBasic, URL: "http://localhost:8123"}, cfg.Common.L2RPC)
 	require.Equal(t, cfg.Profiling.ProfilingEnabled, false)
 	require.Equal(t, cfg.Profiling.ProfilingHost, "localhost")
 	require.Equal(t, cfg.Profiling.ProfilingPort, 6060)
+	require.Equal(t, aggkitgrpc.DefaultMaxMsgSize, cfg.AggSender.AggkitProverClient.MaxCallRecvMsgSize)
+	require.Equal(t, aggkitgrpc.DefaultMaxMsgSize, cfg.AggchainProofGen.AggkitProverClient.MaxCallRecvMsgSize)
+	require.Equal(t, 5*time.Minute, cfg.AggSender.AgglayerClient.KeepAlive.Time.Duration)
+	require.NoError(t, cfg.AggSender.AgglayerClient.Validate())
+	require.Equal(t, 10*time.Second, cfg.REST.EndpointTimeouts["claim-proof"].Duration)
+	require.Equal(t, time.Second, cfg.REST.SlowRequestThreshold.Duration)
 	t.Logf("cfg.AggSender.OptimisticModeConfig.TrustedSequencerKey: %+v", cfg.AggSender.OptimisticModeConfig.TrustedSequencerKey)
 }
 
+func TestLoadConfigRPCConnection(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "ut_config")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write([]byte(DefaultMandatoryVars + `
+[Common]
+L2RPC = { Mode = "op", URL = "http://localhost:8123", OpNodeURL = "http://localhost:8080", BearerToken = "token", Timeout = "5s", Headers = { "X-Api-Key" = "key" }, RateLimit = { MaxConcurrentRequests = 8 }, ProxyURL = "http://proxy:3128" }
+
+[L1NetworkConfig]
+URL = "http://localhost:8545"
+BasicAuthUser = "user"
+BasicAuthPassword = "pass"
+	[L1NetworkConfig.RateLimit]
+	RequestsPerSecond = 25.5
+	Burst = 10
+	[L1NetworkConfig.HeaderCache]
+	Size = 500
+	TTL = "12s"
+	[L1NetworkConfig.TLS]
+	CACertFile = "/etc/aggkit/ca.pem"
+
+[AggSender]
+	[AggSender.AgglayerClient]
+	ProxyURL = "http://proxy:3128"
+	UseTLS = true
+		[AggSender.AgglayerClient.TLS]
+		CertFile = "/etc/aggkit/client.pem"
+		KeyFile = "/etc/aggkit/client-key.pem"
+`))
+	require.NoError(t, err)
+	ctx := newCliContextConfigFlag(t, tmpFile.Name())
+	cfg, err := Load(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, ethermanconfig.RPCModeOp, cfg.Common.L2RPC.Mode)
+	require.Equal(t, "token", cfg.Common.L2RPC.BearerToken)
+	require.Equal(t, 5*time.Second, cfg.Common.L2RPC.Timeout.Duration)
+	require.Equal(t, map[string]string{"x-api-key": "key"}, cfg.Common.L2RPC.Headers)
+	opNodeURL, err := cfg.Common.L2RPC.GetString("opnodeurl")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080", opNodeURL)
+	require.NotContains(t, cfg.Common.L2RPC.ExtraParams, "bearertoken")
+	require.NotContains(t, cfg.Common.L2RPC.ExtraParams, "ratelimit")
+	require.Equal(t, ethermanconfig.RPCRateLimitConfig{MaxConcurrentRequests: 8}, cfg.Common.L2RPC.RateLimit)
+
+	require.Equal(t, "http://localhost:8545", cfg.L1NetworkConfig.URL)
+	require.Equal(t, "user", cfg.L1NetworkConfig.BasicAuthUser)
+	require.Equal(t, "pass", cfg.L1NetworkConfig.BasicAuthPassword)
+	require.Equal(t, aggkittypes.RPCRateLimitOptions{RequestsPerSecond: 25.5, Burst: 10},
+		cfg.L1NetworkConfig.Options().RateLimit)
+	require.Equal(t, aggkittypes.RPCHeaderCacheOptions{Size: 500, TTL: 12 * time.Second},
+		cfg.L1NetworkConfig.Options().HeaderCache)
+	require.Equal(t, aggkittypes.RPCHeaderCacheOptions{}, cfg.Common.L2RPC.Options().HeaderCache)
+
+	require.Equal(t, "http://proxy:3128", cfg.Common.L2RPC.Options().ProxyURL)
+	require.NotContains(t, cfg.Common.L2RPC.ExtraParams, "proxyurl")
+	require.Equal(t, configtypes.TLSClientConfig{CACertFile: "/etc/aggkit/ca.pem"}, cfg.L1NetworkConfig.Options().TLS)
+	require.Equal(t, "http://proxy:3128", cfg.AggSender.AgglayerClient.ProxyURL)
+	require.Equal(t, configtypes.TLSClientConfig{CertFile: "/etc/aggkit/client.pem", KeyFile: "/etc/aggkit/client-key.pem"},
+		cfg.AggSender.AgglayerClient.TLS)
+}
+
+func TestLoadConfigNetworks(t *testing.T) {
+	t.Run("default networks", func(t *testing.T) {
+		tmpFile, err := os.CreateTemp("", "ut_config")
+		require.NoError(t, err)
+		defer os.Remove(tmpFile.Name())
+		_, err = tmpFile.Write([]byte(DefaultMandatoryVars))
+		require.NoError(t, err)
+		cfg, err := Load(newCliContextConfigFlag(t, tmpFile.Name()))
+		require.NoError(t, err)
+
+		// the L1 and the L2 run by the aggkit are filled at startup with the settings of the syncers
+		require.Empty(t, cfg.Networks)
+	})
+
+	t.Run("networks replaced by the config file", func(t *testing.T) {
+		tmpFile, err := os.CreateTemp("", "ut_config")
+		require.NoError(t, err)
+		defer os.Remove(tmpFile.Name())
+		_, err = tmpFile.Write([]byte(DefaultMandatoryVars + `
+[[Networks]]
+ID = 0
+Name = "sepolia"
+Role = "L1"
+BlockFinality = "FinalizedBlock"
+
+[[Networks]]
+ID = 1
+Name = "katana"
+Role = "L2"
+RPCURLs = ["http://localhost:8123", "http://localhost:8124"]
+`))
+		require.NoError(t, err)
+		cfg, err := Load(newCliContextConfigFlag(t, tmpFile.Name()))
+		require.NoError(t, err)
+
+		require.Len(t, cfg.Networks, 2)
+		require.Equal(t, "sepolia", cfg.Networks[0].Name)
+		require.Equal(t, aggkittypes.FinalizedBlock, cfg.Networks[0].BlockFinality)
+		require.Equal(t, "katana", cfg.Networks[1].Name)
+		require.Equal(t, []string{"http://localhost:8123", "http://localhost:8124"}, cfg.Networks[1].RPCURLs)
+	})
+}
+
 func TestLoadConfigWithSaveConfigFile(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "ut_config")
 	require.NoError(t, err)
@@ -151,3 +269,65 @@ func TestLoadConfigWithDeprecatedFields(t *testing.T) {
 	require.ErrorContains(t, err, l1NetworkConfigUseRollupAddrHint)
 	require.ErrorContains(t, err, delayBetweenRetriesHint)
 }
+
+func TestLoadConfigTokenPolicy(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "ut_config")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write([]byte(DefaultMandatoryVars + `
+[AggSender.TokenPolicy]
+DeniedTokens = ["0x1111111111111111111111111111111111111111"]
+DeniedOriginNetworks = [7]
+`))
+	require.NoError(t, err)
+	cfg, err := Load(newCliContextConfigFlag(t, tmpFile.Name()))
+	require.NoError(t, err)
+
+	require.Equal(t, []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111")},
+		cfg.AggSender.TokenPolicy.DeniedTokens)
+	require.Equal(t, []uint32{7}, cfg.AggSender.TokenPolicy.DeniedOriginNetworks)
+	require.Empty(t, cfg.AggSender.TokenPolicy.AllowedTokens)
+	require.True(t, cfg.AggSender.TokenPolicy.IsEnabled())
+}
+
+func TestConfigDecodeSection(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "ut_config")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write([]byte(DefaultMandatoryVars + `
+[Components.PriceOracle]
+URL = "http://localhost:9000"
+CheckInterval = "30s"
+Tokens = ["0x1111111111111111111111111111111111111111"]
+`))
+	require.NoError(t, err)
+	cfg, err := Load(newCliContextConfigFlag(t, tmpFile.Name()))
+	require.NoError(t, err)
+
+	type priceOracleConfig struct {
+		URL           string               `mapstructure:"URL"`
+		CheckInterval configtypes.Duration `mapstructure:"CheckInterval"`
+		Tokens        []common.Address     `mapstructure:"Tokens"`
+		MaxRetries    int                  `mapstructure:"MaxRetries"`
+	}
+	oracleCfg := priceOracleConfig{MaxRetries: 3}
+	require.NoError(t, cfg.DecodeSection("PriceOracle", &oracleCfg))
+	require.Equal(t, "http://localhost:9000", oracleCfg.URL)
+	require.Equal(t, 30*time.Second, oracleCfg.CheckInterval.Duration)
+	require.Equal(t, []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111")},
+		oracleCfg.Tokens)
+	// the values not set keep the defaults
+	require.Equal(t, 3, oracleCfg.MaxRetries)
+
+	// a section without config keeps the defaults
+	oracleCfg = priceOracleConfig{MaxRetries: 5}
+	require.NoError(t, cfg.DecodeSection("OtherOracle", &oracleCfg))
+	require.Equal(t, priceOracleConfig{MaxRetries: 5}, oracleCfg)
+
+	// the sections of the Config are copied
+	var lastGERSyncCfg lastgersync.Config
+	require.NoError(t, cfg.DecodeSection("LastGERSync", &lastGERSyncCfg))
+	require.Equal(t, cfg.LastGERSync, lastGERSyncCfg)
+	require.ErrorContains(t, cfg.DecodeSection("LastGERSync", &oracleCfg), "must be decoded into a *lastgersync.Config")
+	require.ErrorContains(t, cfg.DecodeSection("PriceOracle", oracleCfg), "must be decoded into a pointer")
+}
@@ -69,10 +69,28 @@ GlobalExitRootManagerAddr = "{{L1Config.polygonZkEVMGlobalExitRootAddress}}"
 [ReorgDetectorL1]
 DBPath = "{{PathRWData}}/reorgdetectorl1.sqlite"
 FinalizedBlock = "FinalizedBlock"
+	[ReorgDetectorL1.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
+	[ReorgDetectorL1.SubscriptionsServer]
+		Host = ""
+		Port = 0
+		WriteTimeout = "5s"
 
 [ReorgDetectorL2]
 DBPath = "{{PathRWData}}/reorgdetectorl2.sqlite"
 FinalizedBlock = "LatestBlock"
+	[ReorgDetectorL2.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
+	[ReorgDetectorL2.SubscriptionsServer]
+		Host = ""
+		Port = 0
+		WriteTimeout = "5s"
 
 [L1InfoTreeSync]
 DBPath = "{{PathRWData}}/L1InfoTreeSync.sqlite"
@@ -86,6 +104,24 @@ InitialBlock = {{genesisBlockNumber}}
 RetryAfterErrorPeriod = "1s"
 MaxRetryAttemptsAfterError = -1
 RequireStorageContentCompatibility = {{RequireStorageContentCompatibility}}
+	[L1InfoTreeSync.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
+	[L1InfoTreeSync.InMemoryMirror]
+		Enabled = false
+		MaxMemoryMiB = 256
+		CheckpointInterval = "10m"
+	[L1InfoTreeSync.Retention]
+		Months = 0
+		CheckpointInterval = 1000
+		PruneInterval = "1h"
+		MaxLeavesPerRun = 10000
+	[L1InfoTreeSync.Verify]
+		VerifyOnly = false
+		ReportPath = ""
+		MaxDiscrepancies = 100
 
 [AggOracle]
 TargetChainType = "EVM"
@@ -96,6 +132,22 @@ WaitPeriodNextGER = "10s"
 		GlobalExitRootL2 = "{{L2Config.GlobalExitRootAddr}}"
 		GasOffset = 0
 		WaitPeriodMonitorTx = "1s"
+		[AggOracle.EVMSender.Relay]
+			Enabled = false
+			URL = ""
+			Method = "relay_sendTransaction"
+			HTTPHeaders = {}
+			RelayerAddr = "0x0000000000000000000000000000000000000000"
+			RequestTimeout = "10s"
+			ReceiptTimeout = "2m"
+			FallbackToDirect = true
+		[AggOracle.EVMSender.BalanceMonitor]
+			Enabled = false
+			MinBalance = 100000000000000000
+			CheckInterval = "5m"
+			FaucetURL = ""
+			FaucetTimeout = "10s"
+			FaucetCooldown = "1h"
 		[AggOracle.EVMSender.EthTxManager]
 				FrequencyToMonitorTxs = "1s"
 				WaitTxToBeMined = "2s"
@@ -126,12 +178,32 @@ ReadTimeout = "2s"
 WriteTimeout = "2s"
 MaxRequestsPerIPAndSecond = 10
 
+[HealthCheck]
+	[HealthCheck.ErrorBudget]
+		Enabled = false
+		Window = "10m"
+		MinEvents = 20
+		DegradedErrorRate = 0.05
+		UnhealthyErrorRate = 0.25
+
 [REST]
 Host = "0.0.0.0"
 Port = 5577
 ReadTimeout = "2s"
 WriteTimeout = "2s"
 MaxRequestsPerIPAndSecond = 10
+ClaimProofPrecomputeDeposits = 100
+ClaimProofPrecomputeInterval = "5s"
+SlowRequestThreshold = "1s"
+ReadinessMaxL1BlocksBehind = 10
+ReadinessMaxL2BlocksBehind = 100
+	[REST.EndpointTimeouts]
+		claim-proof = "10s"
+		l1-info-tree-index = "10s"
+
+[BridgeService]
+AdminToken = ""
+ClaimsReconciliationInterval = "10m"
 
 [BridgeL1Sync]
 DBPath = "{{PathRWData}}/bridgel1sync.sqlite"
@@ -143,6 +215,33 @@ RetryAfterErrorPeriod = "1s"
 MaxRetryAttemptsAfterError = -1
 WaitForNewBlocksPeriod = "3s"
 RequireStorageContentCompatibility = {{RequireStorageContentCompatibility}}
+ArchiveMode = false
+BlockTimestampSource = "Header"
+BlockInterval = "0s"
+BatchHeaderRequests = false
+ClientSideTopicFiltering = false
+	[BridgeL1Sync.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
+	[BridgeL1Sync.PriceOracle]
+		URL = ""
+		RequestTimeout = "10s"
+		UpdateInterval = "1m"
+		BatchSize = 100
+	[BridgeL1Sync.Verify]
+		VerifyOnly = false
+		ReportPath = ""
+		MaxDiscrepancies = 100
+	[BridgeL1Sync.CrossCheck]
+		URL = ""
+		HaltOnDiscrepancy = false
+	[BridgeL1Sync.ClaimsAudit]
+		Enabled = false
+		CheckInterval = "10m"
+		LookbackBlocks = 10000
+		BlockChunkSize = 1000
 
 [BridgeL2Sync]
 DBPath = "{{PathRWData}}/bridgel2sync.sqlite"
@@ -154,6 +253,36 @@ RetryAfterErrorPeriod = "1s"
 MaxRetryAttemptsAfterError = -1
 WaitForNewBlocksPeriod = "3s"
 RequireStorageContentCompatibility = {{RequireStorageContentCompatibility}}
+SyncMode = "RPC"
+SequencerFeedURL = ""
+SequencerFeedReconnectPeriod = "5s"
+ArchiveMode = false
+BlockTimestampSource = "Header"
+BlockInterval = "0s"
+BatchHeaderRequests = false
+ClientSideTopicFiltering = false
+	[BridgeL2Sync.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
+	[BridgeL2Sync.PriceOracle]
+		URL = ""
+		RequestTimeout = "10s"
+		UpdateInterval = "1m"
+		BatchSize = 100
+	[BridgeL2Sync.Verify]
+		VerifyOnly = false
+		ReportPath = ""
+		MaxDiscrepancies = 100
+	[BridgeL2Sync.CrossCheck]
+		URL = ""
+		HaltOnDiscrepancy = false
+	[BridgeL2Sync.ClaimsAudit]
+		Enabled = false
+		CheckInterval = "10m"
+		LookbackBlocks = 10000
+		BlockChunkSize = 1000
 
 [LastGERSync]
 DBPath = "{{PathRWData}}/lastgersync.sqlite"
@@ -166,6 +295,11 @@ WaitForNewBlocksPeriod = "1s"
 DownloadBufferSize = 100
 RequireStorageContentCompatibility = {{RequireStorageContentCompatibility}}
 SyncMode = "FEP"
+	[LastGERSync.StorageTuning]
+		JournalMode = "WAL"
+		CacheSizeKiB = 0
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
 
 [AggSender]
 StoragePath = "{{PathRWData}}/aggsender.sqlite"
@@ -177,26 +311,62 @@ DelayBetweenRetries = "30s"
 KeepCertificatesHistory = true
 # MaxSize of the certificate to 8Mb
 MaxCertSize = 8388608
+MaxConcurrentClaimProofs = 8
+MaxBlocksPerBridgeQuery = 10000
+L2IdleThreshold = "0s"
+L2HaltAlertThreshold = "0s"
 DryRun = false
 EnableRPC = true
 # PessimisticProof or AggchainProver
 Mode = "PessimisticProof"
 CheckStatusCertificateInterval = "5m"
 RetryCertAfterInError = false
+InErrorWaitRetryDelay = "5m"
+EpochRolloverRecheckInterval = "1m"
+AgglayerMaintenanceBackoff = "30s"
+AgglayerMaintenanceMaxBackoff = "10m"
+MaintenanceWindows = []
+RetryInErrorRequiringIntervention = false
 GlobalExitRootL2 = "{{L2Config.GlobalExitRootAddr}}"
 SovereignRollupAddr = "{{L1Config.polygonZkEVMAddress}}"
 RequireStorageContentCompatibility = {{RequireStorageContentCompatibility}}
 RequireNoFEPBlockGap = false
+InstanceLeaseTTL = "30s"
+CheckAgglayerHeightBeforeSend = false
+CheckLocalExitRootAgainstContract = false
+FastSyncFromAgglayer = false
+AgglayerMirrorInterval = "1m"
+AgglayerMirrorMaxHeadersPerRun = 100
+AgglayerLatestCertificateCacheTTL = "2s"
 RequireOneBridgeInPPCertificate = false
+HeartbeatCertificateInterval = "0s"
 RollupManagerAddr = "{{L1Config.polygonRollupManagerAddress}}"
 RollupCreationBlockL1 = {{rollupCreationBlockNumber}}
 MaxL2BlockNumber = 0
 StopOnFinishedSendingAllCertificates = false
+	[AggSender.SignerReload]
+		CheckInterval = "0s"
+		TTL = "0s"
+		RequireKeyMatchTrustedSequencer = false
+	[AggSender.StorageTuning]
+		BusyTimeout = "5s"
+		Synchronous = "FULL"
+		CacheSizeKiB = 0
+		MaxReadConns = 4
+		JournalMode = "WAL"
+		StorageQuotaMiB = 0
+		MinFreeDiskSpaceMiB = 0
 	[AggSender.AgglayerClient]
 		URL = "{{AggLayerURL}}"
 		MinConnectTimeout = "5s"
 		RequestTimeout = "300s" 
 		UseTLS = false
+		MaxCallRecvMsgSize = 67108864
+		MaxCallSendMsgSize = 67108864
+		[AggSender.AgglayerClient.KeepAlive]
+			Time = "5m"
+			Timeout = "20s"
+			PermitWithoutStream = false
 		[AggSender.AgglayerClient.Retry]
 			InitialBackoff = "1s"
 			MaxBackoff = "10s"
@@ -207,6 +377,12 @@ StopOnFinishedSendingAllCertificates = false
 		MinConnectTimeout = "5s"
 		RequestTimeout = "{{GenerateAggchainProofTimeout}}"
 		UseTLS = false
+		MaxCallRecvMsgSize = 67108864
+		MaxCallSendMsgSize = 67108864
+		[AggSender.AggkitProverClient.KeepAlive]
+			Time = "5m"
+			Timeout = "20s"
+			PermitWithoutStream = false
 	[AggSender.MaxSubmitCertificateRate]
 		NumRequests = 20
 		Interval = "1h"
@@ -217,6 +393,75 @@ StopOnFinishedSendingAllCertificates = false
 		OpNodeURL = "{{OpNodeURL}}"
 		# TODO: For now set it to false, until it gets fixed on the contracts deployment end
 		RequireKeyMatchTrustedSequencer = false
+		[AggSender.OptimisticModeConfig.SignerReload]
+			CheckInterval = "0s"
+			TTL = "0s"
+			RequireKeyMatchTrustedSequencer = false
+		[AggSender.OptimisticModeConfig.Signature]
+			Scheme = "Keccak"
+			DomainName = ""
+			DomainVersion = ""
+			ChainID = 0
+			VerifyingContract = "0x0000000000000000000000000000000000000000"
+			LegacyV = false
+	[AggSender.ArchiverConfig]
+		Enabled = false
+		URL = ""
+		Bucket = ""
+		Region = ""
+		Prefix = ""
+		AccessKeyID = ""
+		SecretAccessKey = ""
+		RequestTimeout = "30s"
+		BufferSize = 100
+		MaxRetries = 5
+		RetryInterval = "10s"
+		# 0 means archived certificates are kept forever
+		RetentionPeriod = "0s"
+		PruneInterval = "1h"
+	[AggSender.EventBusConfig]
+		Enabled = false
+		# NATS or RedisStreams
+		Backend = "NATS"
+		URL = ""
+		Subject = "aggsender.certificates"
+		# 0 means the Redis stream is not trimmed
+		MaxLen = 0
+		BufferSize = 1000
+		RequestTimeout = "10s"
+		RetryInterval = "5s"
+	[AggSender.FeeBudget]
+		Enabled = false
+		# fees in wei
+		BaseFeePerCertificate = 0
+		FeePerExit = 0
+		# 0 means no limit
+		MaxFeePerEpoch = 0
+		MaxFeePerDay = 0
+	[AggSender.ProverSLO]
+		Enabled = false
+		WindowSize = 20
+		Percentile = 95
+		# 0 means no limit
+		MaxProvingTime = "0s"
+		MaxProofSize = 0
+		WebhookURL = ""
+		WebhookTimeout = "10s"
+	[AggSender.TokenPolicy]
+		DeniedTokens = []
+		DeniedOriginNetworks = []
+		AllowedTokens = []
+		AllowedOriginNetworks = []
+	[AggSender.ApprovalHook]
+		Enabled = false
+		URL = ""
+		Timeout = "30s"
+		# FailClosed (the certificate is not sent) or FailOpen (it's sent) when the hook fails
+		FailurePolicy = "FailClosed"
+	[AggSender.DataAvailability]
+		Enabled = false
+		URL = ""
+		Timeout = "30s"
 [Prometheus]
 Enabled = true
 Host = "localhost"
@@ -230,9 +475,37 @@ GlobalExitRootL2 = "{{L2Config.GlobalExitRootAddr}}"
 		MinConnectTimeout = "5s"
 		UseTLS = false
 		RequestTimeout = "{{GenerateAggchainProofTimeout}}"
+		MaxCallRecvMsgSize = 67108864
+		MaxCallSendMsgSize = 67108864
+		[AggchainProofGen.AggkitProverClient.KeepAlive]
+			Time = "5m"
+			Timeout = "20s"
+			PermitWithoutStream = false
+	[AggchainProofGen.Service]
+		MaxQueuedJobs = 10
+		MaxFinishedJobs = 100
+		[AggchainProofGen.Service.GRPC]
+			Host = "0.0.0.0"
+			Port = 0
+			EnableReflection = false
+		[AggchainProofGen.Service.REST]
+			Host = "0.0.0.0"
+			Port = 0
+			ReadTimeout = "2s"
+			WriteTimeout = "2s"
 
 [Profiling]
 ProfilingHost = "localhost"
 ProfilingPort = 6060
 ProfilingEnabled = false
+
+[Profiling.Snapshots]
+Enabled = false
+Dir = "{{PathRWData}}/profiles"
+CheckInterval = "30s"
+HeapThresholdMiB = 0
+GoroutinesThreshold = 0
+CPUProfileDuration = "0s"
+Cooldown = "10m"
+MaxSnapshots = 10
 `
@@ -1,11 +1,16 @@
 package config
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	ethermanconfig "github.com/agglayer/aggkit/etherman/config"
+	"github.com/ethereum/go-ethereum/common"
+)
 
 // L1NetworkConfig represents the configuration of the network used in L1
 type L1NetworkConfig struct {
 	// URL is the URL of the Ethereum node for L1
 	URL string `mapstructure:"URL"`
+	// RPCConnectionConfig are the headers, auth and timeout used to connect to the L1 node
+	ethermanconfig.RPCConnectionConfig `mapstructure:",squash"`
 	// Chain ID of the L1 network
 	ChainID uint64 `json:"chainId"`
 	// RollupAddr Address of the L1 rollup contract
@@ -0,0 +1,443 @@
+package config
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agglayer/aggkit/aggsender/archiver"
+	"github.com/agglayer/aggkit/log"
+)
+
+const (
+	// FlagCfgCacheDir is the flag for the dir where the remote config files are cached
+	FlagCfgCacheDir = "cfg-cache-dir"
+	// FlagCfgPublicKey is the flag for the ed25519 public key that signs the remote config files
+	FlagCfgPublicKey = "cfg-public-key"
+
+	// consulTokenEnvVar is the env var with the ACL token of the consul KV store
+	consulTokenEnvVar = "CONSUL_HTTP_TOKEN"
+	// consulSSLEnvVar is the env var that enables https for the consul KV store
+	consulSSLEnvVar = "CONSUL_HTTP_SSL"
+	// awsAccessKeyIDEnvVar, awsSecretAccessKeyEnvVar and awsSessionTokenEnvVar are the env vars with the
+	// credentials that sign the requests of the s3:// files
+	awsAccessKeyIDEnvVar     = "AWS_ACCESS_KEY_ID"
+	awsSecretAccessKeyEnvVar = "AWS_SECRET_ACCESS_KEY"
+	awsSessionTokenEnvVar    = "AWS_SESSION_TOKEN"
+	// awsRegionEnvVar and awsDefaultRegionEnvVar are the env vars with the region of the s3:// buckets
+	awsRegionEnvVar        = "AWS_REGION"
+	awsDefaultRegionEnvVar = "AWS_DEFAULT_REGION"
+	defaultS3Region        = "us-east-1"
+	// s3PresignedQueryParam is the query param of the presigned s3:// URLs, that are not signed again
+	s3PresignedQueryParam = "X-Amz-Signature"
+	// checksumFragmentPrefix is the prefix of the URL fragment with the sha256 of the remote config file
+	checksumFragmentPrefix = "sha256="
+	// signatureSuffix is appended to the location of a remote config file to get its signature
+	signatureSuffix = ".sig"
+
+	defaultRemoteConfigTimeout = 30 * time.Second
+	maxRemoteConfigSize        = 10 * 1024 * 1024
+)
+
+var (
+	// ErrConfigChecksumMismatch is returned when the sha256 of a remote config file is not the expected one
+	ErrConfigChecksumMismatch = errors.New("config file checksum mismatch")
+	// ErrConfigInvalidSignature is returned when the signature of a remote config file can't be verified
+	ErrConfigInvalidSignature = errors.New("invalid config file signature")
+	// ErrConfigUnverifiedSource is returned when a remote config file is fetched without TLS and it has
+	// neither a checksum nor a signature
+	ErrConfigUnverifiedSource = errors.New("config file fetched without TLS nor verification")
+	// ErrConfigRollback is returned when the version of the signature of a fetched config file is older
+	// than the one of its cached copy
+	ErrConfigRollback = errors.New("config file older than its cached copy")
+)
+
+// RemoteConfigOptions are the options to fetch the config files from a remote source
+// (http(s)://, s3:// or consul://)
+type RemoteConfigOptions struct {
+	// CacheDir is the dir where the verified remote files are cached, to be used if the remote
+	// source is unreachable. Empty disables the cache
+	CacheDir string
+	// PublicKey is the ed25519 key that signs the remote files. If set, each remote file must have
+	// a signature at the same location with the suffix .sig. A signature can be versioned, and a
+	// fetched file whose version is older than the cached copy is rejected
+	PublicKey ed25519.PublicKey
+	// HTTPClient is the client used to fetch the files
+	HTTPClient *http.Client
+}
+
+// newRemoteConfigOptions returns the options of the remote config files set by the flags
+// cacheDir and publicKey (hex encoded)
+func newRemoteConfigOptions(cacheDir, publicKey string) (RemoteConfigOptions, error) {
+	opts := RemoteConfigOptions{
+		CacheDir:   cacheDir,
+		HTTPClient: &http.Client{Timeout: defaultRemoteConfigTimeout},
+	}
+	if publicKey != "" {
+		key, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+		if err != nil {
+			return opts, fmt.Errorf("error decoding the config public key: %w", err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return opts, fmt.Errorf("invalid config public key length %d, expected %d", len(key), ed25519.PublicKeySize)
+		}
+		opts.PublicKey = key
+	}
+	return opts, nil
+}
+
+// isRemoteConfigFile returns true if the config file is a URL of a remote source
+func isRemoteConfigFile(file string) bool {
+	for _, scheme := range []string{"http://", "https://", "s3://", "consul://"} {
+		if strings.HasPrefix(strings.ToLower(file), scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteConfigFileExtension returns the extension of the file in the path of the URL, ignoring the
+// query and the fragment
+func remoteConfigFileExtension(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return getFileExtension(rawURL)
+	}
+	return getFileExtension(u.Path)
+}
+
+// fetch returns the content of the remote config file, verifying its checksum (set as the URL fragment
+// #sha256=<hex>) and its signature (if there is a public key). The files fetched without TLS require
+// one of them. If the file can't be fetched, the cached copy is returned, verified in the same way
+func (o RemoteConfigOptions) fetch(ctx context.Context, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid config URL %s: %w", rawURL, err)
+	}
+	var checksum string
+	if u.Fragment != "" {
+		if !strings.HasPrefix(u.Fragment, checksumFragmentPrefix) {
+			return "", fmt.Errorf("invalid config URL fragment %s, expected #%s<hex>", u.Fragment, checksumFragmentPrefix)
+		}
+		checksum = strings.ToLower(strings.TrimPrefix(u.Fragment, checksumFragmentPrefix))
+		u.Fragment = ""
+	}
+	location := u.String()
+	if checksum == "" && o.PublicKey == nil && !isTLSLocation(u) {
+		return "", fmt.Errorf("%w: %s requires a checksum (#%s<hex>) or a signature (--%s)",
+			ErrConfigUnverifiedSource, redactURL(location), checksumFragmentPrefix, FlagCfgPublicKey)
+	}
+
+	content, signature, fetchErr := o.fetchWithSignature(ctx, location)
+	fromCache := false
+	if fetchErr != nil {
+		var cacheErr error
+		content, signature, cacheErr = o.readCache(location)
+		if cacheErr != nil {
+			return "", fmt.Errorf("error fetching config file %s: %w (no cached copy: %w)",
+				redactURL(location), fetchErr, cacheErr)
+		}
+		log.Warnf("error fetching config file %s, using the cached copy: %v", redactURL(location), fetchErr)
+		fromCache = true
+	}
+
+	version, err := o.verify(content, checksum, signature)
+	if err != nil {
+		return "", fmt.Errorf("error verifying config file %s: %w", redactURL(location), err)
+	}
+	if !fromCache {
+		if err := o.checkRollback(location, version); err != nil {
+			return "", fmt.Errorf("error verifying config file %s: %w", redactURL(location), err)
+		}
+		if err := o.writeCache(location, content, signature); err != nil {
+			log.Warnf("error caching config file %s: %v", redactURL(location), err)
+		}
+	}
+	return string(content), nil
+}
+
+// checkRollback returns an error if the version of the signature of the fetched file is older than the
+// version of its cached copy, so a replayed old file signed by the same key is rejected
+func (o RemoteConfigOptions) checkRollback(location string, version uint64) error {
+	if o.PublicKey == nil {
+		return nil
+	}
+	cachedContent, cachedSignature, err := o.readCache(location)
+	if err != nil {
+		// no cached copy
+		return nil
+	}
+	cachedVersion, err := o.verify(cachedContent, "", cachedSignature)
+	if err != nil {
+		log.Warnf("the cached copy of the config file %s can't be verified, it's replaced: %v",
+			redactURL(location), err)
+		return nil
+	}
+	if version < cachedVersion {
+		return fmt.Errorf("%w: version %d, the cached copy has version %d", ErrConfigRollback, version, cachedVersion)
+	}
+	return nil
+}
+
+// fetchWithSignature returns the content of the file and, if there is a public key, its signature
+func (o RemoteConfigOptions) fetchWithSignature(ctx context.Context, location string) ([]byte, []byte, error) {
+	content, err := o.get(ctx, location)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.PublicKey == nil {
+		return content, nil, nil
+	}
+	signature, err := o.get(ctx, signatureLocation(location))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error fetching the signature: %w", err)
+	}
+	return content, signature, nil
+}
+
+// verify checks the sha256 of the content, if any, and its signature, if there is a public key. It returns
+// the version of the signature (0 if it's not versioned)
+func (o RemoteConfigOptions) verify(content []byte, checksum string, signature []byte) (uint64, error) {
+	if checksum != "" {
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != checksum {
+			return 0, fmt.Errorf("%w: expected %s, got %s", ErrConfigChecksumMismatch, checksum, hex.EncodeToString(sum[:]))
+		}
+	}
+	if o.PublicKey == nil {
+		return 0, nil
+	}
+	version, message, sig, err := decodeSignature(signature, content)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrConfigInvalidSignature, err)
+	}
+	if !ed25519.Verify(o.PublicKey, message, sig) {
+		return 0, ErrConfigInvalidSignature
+	}
+	return version, nil
+}
+
+// get returns the body of the location, translating the s3:// and consul:// URLs to their HTTP APIs
+func (o RemoteConfigOptions) get(ctx context.Context, location string) ([]byte, error) {
+	httpURL, headers, err := toHTTPLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if strings.HasPrefix(strings.ToLower(location), "s3://") && !req.URL.Query().Has(s3PresignedQueryParam) {
+		archiver.SignS3Request(req, nil, s3CredentialsFromEnv(), time.Now())
+	}
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteConfigSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxRemoteConfigSize {
+		return nil, fmt.Errorf("config file exceeds the max size of %d bytes", maxRemoteConfigSize)
+	}
+	return body, nil
+}
+
+// toHTTPLocation returns the HTTP URL and headers to fetch the location:
+//   - s3://bucket/key is fetched from https://bucket.s3.<region>.amazonaws.com/key, signed with the
+//     AWS credentials of the env vars, if any (public, private or presigned objects)
+//   - consul://host:port/key is fetched from the KV store at http(s)://host:port/v1/kv/key?raw, with
+//     the token of CONSUL_HTTP_TOKEN, if any, and https if CONSUL_HTTP_SSL is true
+func toHTTPLocation(location string) (string, map[string]string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", nil, err
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return location, nil, nil
+	case "s3":
+		if u.Host == "" {
+			return "", nil, fmt.Errorf("missing bucket in %s", location)
+		}
+		host := u.Host + ".s3.amazonaws.com"
+		if region := s3Region(); region != defaultS3Region {
+			host = u.Host + ".s3." + region + ".amazonaws.com"
+		}
+		s3URL := url.URL{Scheme: "https", Host: host, Path: u.Path, RawQuery: u.RawQuery}
+		return s3URL.String(), nil, nil
+	case "consul":
+		scheme := "http"
+		if consulUsesTLS() {
+			scheme = "https"
+		}
+		consulURL := url.URL{Scheme: scheme, Host: u.Host, Path: path.Join("/v1/kv", u.Path), RawQuery: "raw"}
+		var headers map[string]string
+		if token := os.Getenv(consulTokenEnvVar); token != "" {
+			headers = map[string]string{"X-Consul-Token": token}
+		}
+		return consulURL.String(), headers, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported config URL scheme %s", u.Scheme)
+	}
+}
+
+// isTLSLocation returns true if the location is fetched with TLS
+func isTLSLocation(u *url.URL) bool {
+	switch strings.ToLower(u.Scheme) {
+	case "https", "s3":
+		return true
+	case "consul":
+		return consulUsesTLS()
+	default:
+		return false
+	}
+}
+
+// consulUsesTLS returns true if the consul KV store is fetched with https (CONSUL_HTTP_SSL)
+func consulUsesTLS() bool {
+	useTLS, err := strconv.ParseBool(os.Getenv(consulSSLEnvVar))
+	return err == nil && useTLS
+}
+
+// s3Region returns the region of the s3:// buckets, set by the AWS env vars
+func s3Region() string {
+	for _, envVar := range []string{awsRegionEnvVar, awsDefaultRegionEnvVar} {
+		if region := os.Getenv(envVar); region != "" {
+			return region
+		}
+	}
+	return defaultS3Region
+}
+
+// s3CredentialsFromEnv returns the AWS credentials of the env vars, that sign the requests of the
+// s3:// files (empty if they are not set, so the requests are not signed)
+func s3CredentialsFromEnv() archiver.S3Credentials {
+	return archiver.S3Credentials{
+		Region:          s3Region(),
+		AccessKeyID:     os.Getenv(awsAccessKeyIDEnvVar),
+		SecretAccessKey: os.Getenv(awsSecretAccessKeyEnvVar),
+		SessionToken:    os.Getenv(awsSessionTokenEnvVar),
+	}
+}
+
+// signatureLocation returns the location of the signature of the file: the path with the suffix .sig
+func signatureLocation(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location + signatureSuffix
+	}
+	u.Path += signatureSuffix
+	u.RawPath = ""
+	return u.String()
+}
+
+// decodeSignature decodes the signature file of the content: the ed25519 signature of the content, hex or
+// base64 encoded, or "<version> <signature>" with the signature of "<version>\n<content>", where the version
+// is a number that must grow with each new file (e.g. the unix timestamp of the signature). It returns the
+// version (0 if it's not versioned), the signed message and the signature
+func decodeSignature(signature, content []byte) (uint64, []byte, []byte, error) {
+	fields := strings.Fields(string(signature))
+	var (
+		version uint64
+		message = content
+	)
+	switch len(fields) {
+	case 1:
+	case 2: //nolint:mnd
+		var err error
+		if version, err = strconv.ParseUint(fields[0], 10, 64); err != nil {
+			return 0, nil, nil, fmt.Errorf("invalid signature version %s: %w", fields[0], err)
+		}
+		message = append([]byte(fields[0]+"\n"), content...)
+		fields = fields[1:]
+	default:
+		return 0, nil, nil, errors.New("the signature file must be <signature> or <version> <signature>")
+	}
+	encoded := fields[0]
+	if sig, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x")); err == nil && len(sig) == ed25519.SignatureSize {
+		return version, message, sig, nil
+	}
+	if sig, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(sig) == ed25519.SignatureSize {
+		return version, message, sig, nil
+	}
+	return 0, nil, nil, fmt.Errorf("the signature must be %d bytes, hex or base64 encoded", ed25519.SignatureSize)
+}
+
+// cachePath returns the path of the cached copy of the location, named by the hash of the location
+func (o RemoteConfigOptions) cachePath(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return filepath.Join(o.CacheDir, hex.EncodeToString(sum[:8])+"-"+path.Base(strings.Split(location, "?")[0]))
+}
+
+func (o RemoteConfigOptions) writeCache(location string, content, signature []byte) error {
+	if o.CacheDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(o.CacheDir, 0700); err != nil { //nolint:mnd
+		return err
+	}
+	cachePath := o.cachePath(location)
+	if signature != nil {
+		if err := os.WriteFile(cachePath+signatureSuffix, signature, DefaultCreationFilePermissions); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(cachePath, content, DefaultCreationFilePermissions)
+}
+
+func (o RemoteConfigOptions) readCache(location string) ([]byte, []byte, error) {
+	if o.CacheDir == "" {
+		return nil, nil, errors.New("cache is disabled")
+	}
+	cachePath := o.cachePath(location)
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if o.PublicKey == nil {
+		return content, nil, nil
+	}
+	signature, err := os.ReadFile(cachePath + signatureSuffix)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, signature, nil
+}
+
+// redactURL removes the credentials and the query (e.g. the signature of a presigned URL) from the URL
+// to log it
+func redactURL(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const remoteConfigContent = `
+[Log]
+Level = "debug"
+`
+
+func newRemoteConfigServer(t *testing.T, files map[string]string) (*httptest.Server, *atomic.Bool) {
+	t.Helper()
+	down := &atomic.Bool{}
+	srv := httptest.NewServer(remoteConfigHandler(files, down))
+	t.Cleanup(srv.Close)
+	return srv, down
+}
+
+func remoteConfigHandler(files map[string]string, down *atomic.Bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if down.Load() || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRemoteConfigOptions_Fetch(t *testing.T) {
+	ctx := context.Background()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte(remoteConfigContent))
+	checksum := hex.EncodeToString(sum[:])
+	srv, down := newRemoteConfigServer(t, map[string]string{
+		"/node.toml":         remoteConfigContent,
+		"/node.toml.sig":     hex.EncodeToString(ed25519.Sign(priv, []byte(remoteConfigContent))),
+		"/b64.toml":          remoteConfigContent,
+		"/b64.toml.sig":      base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(remoteConfigContent))),
+		"/unsigned.toml":     remoteConfigContent,
+		"/wrong.toml":        remoteConfigContent,
+		"/wrong.toml.sig":    hex.EncodeToString(ed25519.Sign(priv, []byte("other content"))),
+		"/v1/kv/aggkit/node": remoteConfigContent,
+	})
+
+	t.Run("without verification", func(t *testing.T) {
+		// the files fetched without TLS require a checksum or a signature
+		_, err := RemoteConfigOptions{}.fetch(ctx, srv.URL+"/unsigned.toml")
+		require.ErrorIs(t, err, ErrConfigUnverifiedSource)
+
+		tlsSrv := httptest.NewTLSServer(remoteConfigHandler(map[string]string{"/unsigned.toml": remoteConfigContent}, down))
+		defer tlsSrv.Close()
+		content, err := RemoteConfigOptions{HTTPClient: tlsSrv.Client()}.fetch(ctx, tlsSrv.URL+"/unsigned.toml")
+		require.NoError(t, err)
+		require.Equal(t, remoteConfigContent, content)
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		_, err := RemoteConfigOptions{}.fetch(ctx, srv.URL+"/node.toml#sha256="+checksum)
+		require.NoError(t, err)
+		_, err = RemoteConfigOptions{}.fetch(ctx, srv.URL+"/node.toml#sha256=00")
+		require.ErrorIs(t, err, ErrConfigChecksumMismatch)
+		_, err = RemoteConfigOptions{}.fetch(ctx, srv.URL+"/node.toml#md5=00")
+		require.ErrorContains(t, err, "invalid config URL fragment")
+	})
+
+	t.Run("signature", func(t *testing.T) {
+		opts := RemoteConfigOptions{PublicKey: pub}
+		_, err := opts.fetch(ctx, srv.URL+"/node.toml")
+		require.NoError(t, err)
+		_, err = opts.fetch(ctx, srv.URL+"/b64.toml")
+		require.NoError(t, err)
+		_, err = opts.fetch(ctx, srv.URL+"/wrong.toml")
+		require.ErrorIs(t, err, ErrConfigInvalidSignature)
+		_, err = opts.fetch(ctx, srv.URL+"/unsigned.toml")
+		require.ErrorContains(t, err, "error fetching the signature")
+	})
+
+	t.Run("consul", func(t *testing.T) {
+		_, err := RemoteConfigOptions{}.fetch(ctx, "consul://"+srv.Listener.Addr().String()+"/aggkit/node")
+		require.ErrorIs(t, err, ErrConfigUnverifiedSource)
+		content, err := RemoteConfigOptions{}.fetch(ctx,
+			"consul://"+srv.Listener.Addr().String()+"/aggkit/node#sha256="+checksum)
+		require.NoError(t, err)
+		require.Equal(t, remoteConfigContent, content)
+	})
+
+	t.Run("consul with https", func(t *testing.T) {
+		t.Setenv(consulSSLEnvVar, "true")
+		tlsSrv := httptest.NewTLSServer(remoteConfigHandler(map[string]string{"/v1/kv/aggkit/node": remoteConfigContent}, down))
+		defer tlsSrv.Close()
+		content, err := RemoteConfigOptions{HTTPClient: tlsSrv.Client()}.fetch(ctx,
+			"consul://"+tlsSrv.Listener.Addr().String()+"/aggkit/node")
+		require.NoError(t, err)
+		require.Equal(t, remoteConfigContent, content)
+	})
+
+	t.Run("s3 requests signed with the AWS credentials", func(t *testing.T) {
+		t.Setenv(awsAccessKeyIDEnvVar, "AKIDEXAMPLE")
+		t.Setenv(awsSecretAccessKeyEnvVar, "secret")
+		t.Setenv(awsSessionTokenEnvVar, "session")
+		t.Setenv(awsRegionEnvVar, "eu-west-1")
+		var requests []*http.Request
+		client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(remoteConfigContent))}, nil
+		})}
+		opts := RemoteConfigOptions{HTTPClient: client}
+		content, err := opts.fetch(ctx, "s3://bucket/aggkit.toml")
+		require.NoError(t, err)
+		require.Equal(t, remoteConfigContent, content)
+		_, err = opts.fetch(ctx, "s3://bucket/aggkit.toml?X-Amz-Signature=abc")
+		require.NoError(t, err)
+
+		require.Len(t, requests, 2)
+		require.Equal(t, "https://bucket.s3.eu-west-1.amazonaws.com/aggkit.toml", requests[0].URL.String())
+		require.True(t, strings.HasPrefix(requests[0].Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), requests[0].Header.Get("Authorization"))
+		require.Equal(t, "session", requests[0].Header.Get("X-Amz-Security-Token"))
+		// the presigned URLs are not signed again
+		require.Empty(t, requests[1].Header.Get("Authorization"))
+	})
+
+	t.Run("versioned signature", func(t *testing.T) {
+		signVersion := func(version, content string) string {
+			return version + " " + hex.EncodeToString(ed25519.Sign(priv, []byte(version+"\n"+content)))
+		}
+		files := map[string]string{"/versioned.toml": remoteConfigContent}
+		versionedSrv, _ := newRemoteConfigServer(t, files)
+		opts := RemoteConfigOptions{CacheDir: t.TempDir(), PublicKey: pub}
+
+		files["/versioned.toml.sig"] = signVersion("2", remoteConfigContent)
+		_, err := opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.NoError(t, err)
+		// the version is signed
+		files["/versioned.toml.sig"] = "3 " + hex.EncodeToString(ed25519.Sign(priv, []byte(remoteConfigContent)))
+		_, err = opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.ErrorIs(t, err, ErrConfigInvalidSignature)
+		// an older file (or an unversioned one) replayed by the source is rejected
+		files["/versioned.toml.sig"] = signVersion("1", remoteConfigContent)
+		_, err = opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.ErrorIs(t, err, ErrConfigRollback)
+		files["/versioned.toml.sig"] = hex.EncodeToString(ed25519.Sign(priv, []byte(remoteConfigContent)))
+		_, err = opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.ErrorIs(t, err, ErrConfigRollback)
+		// a newer one replaces the cached copy
+		files["/versioned.toml.sig"] = signVersion("3", remoteConfigContent)
+		_, err = opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.NoError(t, err)
+		files["/versioned.toml.sig"] = signVersion("2", remoteConfigContent)
+		_, err = opts.fetch(ctx, versionedSrv.URL+"/versioned.toml")
+		require.ErrorIs(t, err, ErrConfigRollback)
+	})
+
+	t.Run("cached copy if the source is unreachable", func(t *testing.T) {
+		opts := RemoteConfigOptions{CacheDir: t.TempDir(), PublicKey: pub}
+		_, err := opts.fetch(ctx, srv.URL+"/node.toml")
+		require.NoError(t, err)
+
+		down.Store(true)
+		defer down.Store(false)
+		content, err := opts.fetch(ctx, srv.URL+"/node.toml#sha256="+checksum)
+		require.NoError(t, err)
+		require.Equal(t, remoteConfigContent, content)
+		_, err = opts.fetch(ctx, srv.URL+"/node.toml#sha256=00")
+		require.ErrorIs(t, err, ErrConfigChecksumMismatch)
+		_, err = opts.fetch(ctx, srv.URL+"/unsigned.toml")
+		require.ErrorContains(t, err, "no cached copy")
+	})
+}
+
+func TestNewRemoteConfigOptions(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	opts, err := newRemoteConfigOptions("/tmp/cache", "0x"+hex.EncodeToString(pub))
+	require.NoError(t, err)
+	require.Equal(t, ed25519.PublicKey(pub), opts.PublicKey)
+	require.Equal(t, "/tmp/cache", opts.CacheDir)
+
+	opts, err = newRemoteConfigOptions("", "")
+	require.NoError(t, err)
+	require.Nil(t, opts.PublicKey)
+
+	_, err = newRemoteConfigOptions("", "zz")
+	require.ErrorContains(t, err, "error decoding the config public key")
+	_, err = newRemoteConfigOptions("", "0102")
+	require.ErrorContains(t, err, "invalid config public key length")
+}
+
+func TestToHTTPLocation(t *testing.T) {
+	t.Setenv(consulTokenEnvVar, "secret")
+	t.Setenv(consulSSLEnvVar, "")
+	t.Setenv(awsRegionEnvVar, "")
+	t.Setenv(awsDefaultRegionEnvVar, "")
+	tests := []struct {
+		location string
+		env      map[string]string
+		expected string
+		headers  map[string]string
+		err      string
+	}{
+		{location: "https://host/aggkit.toml?a=b", expected: "https://host/aggkit.toml?a=b"},
+		{location: "s3://bucket/dir/aggkit.toml", expected: "https://bucket.s3.amazonaws.com/dir/aggkit.toml"},
+		{location: "consul://localhost:8500/aggkit/node1", expected: "http://localhost:8500/v1/kv/aggkit/node1?raw",
+			headers: map[string]string{"X-Consul-Token": "secret"}},
+		{location: "s3://bucket/aggkit.toml", env: map[string]string{awsDefaultRegionEnvVar: "eu-west-1"},
+			expected: "https://bucket.s3.eu-west-1.amazonaws.com/aggkit.toml"},
+		{location: "consul://localhost:8501/aggkit/node1", env: map[string]string{consulSSLEnvVar: "true"},
+			expected: "https://localhost:8501/v1/kv/aggkit/node1?raw", headers: map[string]string{"X-Consul-Token": "secret"}},
+		{location: "s3:///aggkit.toml", err: "missing bucket"},
+		{location: "ftp://host/aggkit.toml", err: "unsupported config URL scheme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			httpURL, headers, err := toHTTPLocation(tt.location)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, httpURL)
+			require.Equal(t, tt.headers, headers)
+		})
+	}
+}
+
+func TestLoadRemoteConfigFile(t *testing.T) {
+	srv, _ := newRemoteConfigServer(t, map[string]string{
+		"/aggkit.toml": DefaultMandatoryVars,
+	})
+	require.True(t, isRemoteConfigFile(srv.URL+"/aggkit.toml"))
+	require.False(t, isRemoteConfigFile("/etc/aggkit.toml"))
+	require.Equal(t, "toml", remoteConfigFileExtension(srv.URL+"/aggkit.toml?token=abc#sha256=00"))
+
+	sum := sha256.Sum256([]byte(DefaultMandatoryVars))
+	ctx := newCliContextConfigFlag(t, srv.URL+"/aggkit.toml#sha256="+hex.EncodeToString(sum[:]))
+	cfg, err := Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+}
@@ -84,8 +84,9 @@ func (s *CompatibilityCheck[T]) Check(ctx context.Context, tx types.Querier) err
 	// Compare data
 	if err = runtimeData.IsCompatible(storageData); err != nil {
 		if s.RequireStorageContentCompatibility {
-			return fmt.Errorf("compatibilityCheck: data on DB is [%s] != runtime [%s]. Err: %w",
-				storageData.String(), runtimeData.String(), err)
+			return common.NewExitError(common.ExitCodeIncompatibleStorage,
+				fmt.Errorf("compatibilityCheck: data on DB is [%s] != runtime [%s]. Err: %w",
+					storageData.String(), runtimeData.String(), err))
 		} else {
 			s.Logger.Warnf("compatibilityCheck: data on DB is [%s] != runtime [%s]. Err: %w",
 				storageData.String(), runtimeData.String(), err)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/db/compatibility/mocks"
 	"github.com/stretchr/testify/require"
 )
@@ -65,6 +66,7 @@ func TestCheckCompatibilityData(t *testing.T) {
 		storageMock.EXPECT().GetCompatibilityData(ctx, nil).Return(true, testBindData{a: 2}, nil).Once()
 		err := sut.Check(ctx, nil)
 		require.Error(t, err)
+		require.Equal(t, common.ExitCodeIncompatibleStorage, common.ExitCodeOf(err))
 	})
 	t.Run("data on store incompatible with runtime, RequireStorageContentCompatibility=false", func(t *testing.T) {
 		// No data stored (false,....)
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"math/big"
@@ -17,6 +18,7 @@ import (
 func init() {
 	meddler.Default = meddler.SQLite
 	meddler.Register("bigint", BigIntMeddler{})
+	meddler.Register("nullbigint", NullBigIntMeddler{})
 	meddler.Register("merkleproof", MerkleProofMeddler{})
 	meddler.Register("hash", HashMeddler{})
 	meddler.Register("address", AddressMeddler{})
@@ -102,6 +104,48 @@ func (b BigIntMeddler) PreWrite(fieldPtr interface{}) (saveValue interface{}, er
 	return field.String(), nil
 }
 
+// NullBigIntMeddler encodes or decodes a nullable field value to or from string: a nil *big.Int is NULL
+type NullBigIntMeddler struct{}
+
+// PreRead is called before a Scan operation for fields that have the NullBigIntMeddler
+func (b NullBigIntMeddler) PreRead(fieldAddr interface{}) (scanTarget interface{}, err error) {
+	return new(sql.NullString), nil
+}
+
+// PostRead is called after a Scan operation for fields that have the NullBigIntMeddler
+func (b NullBigIntMeddler) PostRead(fieldPtr, scanTarget interface{}) error {
+	raw, ok := scanTarget.(*sql.NullString)
+	if !ok {
+		return errors.New("scanTarget is not *sql.NullString")
+	}
+	field, ok := fieldPtr.(**big.Int)
+	if !ok {
+		return errors.New("fieldPtr is not *big.Int")
+	}
+	if !raw.Valid {
+		*field = nil
+		return nil
+	}
+	decimal := 10
+	*field, ok = new(big.Int).SetString(raw.String, decimal)
+	if !ok {
+		return fmt.Errorf("big.Int.SetString failed on \"%v\"", raw.String)
+	}
+	return nil
+}
+
+// PreWrite is called before an Insert or Update operation for fields that have the NullBigIntMeddler
+func (b NullBigIntMeddler) PreWrite(fieldPtr interface{}) (saveValue interface{}, err error) {
+	field, ok := fieldPtr.(*big.Int)
+	if !ok {
+		return nil, errors.New("fieldPtr is not *big.Int")
+	}
+	if field == nil {
+		return nil, nil
+	}
+	return field.String(), nil
+}
+
 // MerkleProofMeddler encodes or decodes the field value to or from string
 type MerkleProofMeddler struct{}
 
@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -134,3 +135,27 @@ func createExampleDB(t *testing.T) *sql.DB {
 	require.NoError(t, err, "failed to insert data")
 	return db
 }
+
+func TestNullBigIntMeddler(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE example (id INTEGER PRIMARY KEY, value TEXT);`)
+	require.NoError(t, err)
+
+	type example struct {
+		ID    int64    `meddler:"id,pk"`
+		Value *big.Int `meddler:"value,nullbigint"`
+	}
+	require.NoError(t, meddler.Insert(db, "example", &example{Value: big.NewInt(1234567890)}))
+	require.NoError(t, meddler.Insert(db, "example", &example{}))
+
+	var rows []*example
+	require.NoError(t, meddler.QueryAll(db, &rows, "SELECT * FROM example ORDER BY id;"))
+	require.Len(t, rows, 2)
+	require.Equal(t, big.NewInt(1234567890), rows[0].Value)
+	require.Nil(t, rows[1].Value)
+
+	var isNull bool
+	require.NoError(t, db.QueryRow("SELECT value IS NULL FROM example WHERE id = $1;", rows[1].ID).Scan(&isNull))
+	require.True(t, isNull)
+}
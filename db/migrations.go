@@ -2,9 +2,12 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/agglayer/aggkit"
 	"github.com/agglayer/aggkit/db/migrations"
 	"github.com/agglayer/aggkit/db/types"
 	"github.com/agglayer/aggkit/log"
@@ -16,8 +19,38 @@ const (
 	UpDownSeparator   = "-- +migrate Up"
 	dbPrefixReplacer  = "/*dbprefix*/"
 	NoLimitMigrations = 0 // indicate that there is no limit on the number of migrations to run
+
+	migrationsDialect      = "sqlite3"
+	schemaVersionTableName = "schema_version"
 )
 
+// ErrUnknownSchemaVersion is returned when the DB has migrations applied that are unknown to this
+// version of aggkit, i.e. it has been migrated by a newer version. Running on it could corrupt the
+// data, so the DB must be migrated down with the newer version (aggkit migrate down) or restored
+var ErrUnknownSchemaVersion = errors.New("the DB schema version is newer than the supported by this version")
+
+// SchemaVersion is the version of the schema of a DB, recorded in the schema_version table every
+// time the migrations are run
+type SchemaVersion struct {
+	// Version is the number of migrations applied
+	Version int `meddler:"version"`
+	// LastMigration is the ID of the last migration applied
+	LastMigration string `meddler:"last_migration"`
+	// AggkitVersion is the version of aggkit that ran the migrations
+	AggkitVersion string `meddler:"aggkit_version"`
+	// UpdatedAt is the time (unix seconds) the migrations were run
+	UpdatedAt int64 `meddler:"updated_at"`
+}
+
+// MigrationStatus is the status of a migration in a DB
+type MigrationStatus struct {
+	ID string
+	// AppliedAt is nil if the migration is pending
+	AppliedAt *time.Time
+	// Unknown is true if the migration is applied in the DB but it's unknown to this version
+	Unknown bool
+}
+
 // RunMigrations will execute pending migrations if needed to keep
 // the database updated with the latest changes in either direction,
 // up or down.
@@ -41,14 +74,100 @@ func RunMigrationsDBExtended(logger *log.Logger,
 	migrationsParam []types.Migration,
 	dir migrate.MigrationDirection,
 	maxMigrations int) error {
-	migs := &migrate.MemoryMigrationSource{Migrations: []*migrate.Migration{}}
 	fullmigrations := migrationsParam
 	// In case of partial execution we ignore the base migrations
-	if maxMigrations == NoLimitMigrations {
+	ignoreUnknown := maxMigrations != NoLimitMigrations
+	if !ignoreUnknown {
 		fullmigrations = append(fullmigrations, migrations.GetBaseMigrations()...)
-	} else {
-		migrate.SetIgnoreUnknown(true)
 	}
+	return runMigrations(logger, db, fullmigrations, dir, maxMigrations, ignoreUnknown)
+}
+
+// MigrateDB runs at most maxMigrations migrations (0 for no limit) in the given direction, including
+// the base migrations. It refuses to run if the DB has unknown migrations (see ErrUnknownSchemaVersion)
+func MigrateDB(logger *log.Logger, db *sql.DB, migrationsParam []types.Migration,
+	dir migrate.MigrationDirection, maxMigrations int) error {
+	fullmigrations := append(migrationsParam, migrations.GetBaseMigrations()...) //nolint:gocritic
+	return runMigrations(logger, db, fullmigrations, dir, maxMigrations, false)
+}
+
+// runMigrations applies the planned migrations atomically: all of them and the new schema version
+// are committed in a single transaction, so a failure leaves the DB as it was
+func runMigrations(logger *log.Logger,
+	db *sql.DB,
+	fullmigrations []types.Migration,
+	dir migrate.MigrationDirection,
+	maxMigrations int,
+	ignoreUnknown bool) error {
+	migs := newMigrationSource(fullmigrations)
+
+	var listMigrations strings.Builder
+	for _, m := range migs.Migrations {
+		listMigrations.WriteString(m.Id + ", ")
+	}
+
+	if !ignoreUnknown {
+		if err := checkUnknownMigrations(db, migs); err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf("running migrations: (max %d/%d) migrations: %s", maxMigrations,
+		len(migs.Migrations),
+		listMigrations.String())
+	migSet := migrate.MigrationSet{IgnoreUnknown: ignoreUnknown}
+	planned, dbMap, err := migSet.PlanMigration(db, migrationsDialect, migs, dir, maxMigrations)
+	if err != nil {
+		return fmt.Errorf("error planning migration (max %d/%d) migrations: %s . Err: %w",
+			maxMigrations, len(migs.Migrations), listMigrations.String(), err)
+	}
+
+	tx, err := dbMap.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting the migrations tx: %w", err)
+	}
+	shouldRollback := true
+	defer func() {
+		if shouldRollback {
+			if errRllbck := tx.Rollback(); errRllbck != nil {
+				logger.Errorf("error rolling back the migrations tx: %v", errRllbck)
+			}
+		}
+	}()
+
+	for _, m := range planned {
+		for _, stmt := range m.Queries {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("error executing migration %s (max %d/%d) migrations: %s . Err: %w",
+					m.Id, maxMigrations, len(migs.Migrations), listMigrations.String(), err)
+			}
+		}
+		record := &migrate.MigrationRecord{Id: m.Id, AppliedAt: time.Now()}
+		switch dir {
+		case migrate.Up:
+			err = tx.Insert(record)
+		case migrate.Down:
+			_, err = tx.Delete(record)
+		}
+		if err != nil {
+			return fmt.Errorf("error recording migration %s: %w", m.Id, err)
+		}
+	}
+
+	if err := updateSchemaVersion(tx, migs); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing the migrations tx: %w", err)
+	}
+	shouldRollback = false
+
+	logger.Infof("successfully ran %d migrations from migrations: %s", len(planned), listMigrations.String())
+	return nil
+}
+
+func newMigrationSource(fullmigrations []types.Migration) *migrate.MemoryMigrationSource {
+	migs := &migrate.MemoryMigrationSource{Migrations: []*migrate.Migration{}}
 	for _, m := range fullmigrations {
 		prefixed := strings.ReplaceAll(m.SQL, dbPrefixReplacer, m.Prefix)
 		splitted := strings.Split(prefixed, UpDownSeparator)
@@ -58,22 +177,139 @@ func RunMigrationsDBExtended(logger *log.Logger,
 			Down: []string{splitted[0]},
 		})
 	}
+	return migs
+}
 
-	var listMigrations strings.Builder
+// checkUnknownMigrations returns ErrUnknownSchemaVersion if the DB has migrations applied that are
+// not in the given source
+func checkUnknownMigrations(db *sql.DB, migs *migrate.MemoryMigrationSource) error {
+	records, err := migrate.MigrationSet{}.GetMigrationRecords(db, migrationsDialect)
+	if err != nil {
+		return fmt.Errorf("error getting the migrations applied: %w", err)
+	}
+	known := make(map[string]struct{}, len(migs.Migrations))
 	for _, m := range migs.Migrations {
-		listMigrations.WriteString(m.Id + ", ")
+		known[m.Id] = struct{}{}
+	}
+	var unknown []string
+	for _, record := range records {
+		if _, ok := known[record.Id]; !ok {
+			unknown = append(unknown, record.Id)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w (aggkit %s): unknown migrations applied: %s. Migrate the DB down with the version "+
+			"of aggkit that applied them (aggkit migrate down) or restore a backup",
+			ErrUnknownSchemaVersion, aggkit.Version, strings.Join(unknown, ", "))
 	}
+	return nil
+}
 
-	logger.Debugf("running migrations: (max %d/%d) migrations: %s", maxMigrations,
-		len(migs.Migrations),
-		listMigrations.String())
-	nMigrations, err := migrate.ExecMax(db, "sqlite3", migs, dir, maxMigrations)
+// updateSchemaVersion records the current schema version of the DB
+func updateSchemaVersion(tx types.Querier, migs *migrate.MemoryMigrationSource) error {
+	if _, err := tx.Exec(`CREATE TABLE IF NOT EXISTS ` + schemaVersionTableName + ` (
+		id             INTEGER PRIMARY KEY CHECK (id = 1),
+		version        INTEGER NOT NULL,
+		last_migration VARCHAR NOT NULL,
+		aggkit_version VARCHAR NOT NULL,
+		updated_at     INTEGER NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("error creating the %s table: %w", schemaVersionTableName, err)
+	}
 
+	applied, err := appliedMigrations(tx)
 	if err != nil {
-		return fmt.Errorf("error executing migration (max %d/%d) migrations: %s . Err: %w",
-			maxMigrations, len(migs.Migrations), listMigrations.String(), err)
+		return err
+	}
+	sorted, err := migs.FindMigrations()
+	if err != nil {
+		return fmt.Errorf("error sorting the migrations: %w", err)
+	}
+	version := SchemaVersion{
+		AggkitVersion: aggkit.Version,
+		UpdatedAt:     time.Now().Unix(),
+	}
+	for _, m := range sorted {
+		if _, ok := applied[m.Id]; ok {
+			version.Version++
+			version.LastMigration = m.Id
+		}
 	}
 
-	logger.Infof("successfully ran %d migrations from migrations: %s", nMigrations, listMigrations.String())
+	if _, err := tx.Exec(`INSERT INTO `+schemaVersionTableName+
+		` (id, version, last_migration, aggkit_version, updated_at) VALUES (1, $1, $2, $3, $4)
+		ON CONFLICT(id) DO UPDATE SET version = excluded.version, last_migration = excluded.last_migration,
+		aggkit_version = excluded.aggkit_version, updated_at = excluded.updated_at`,
+		version.Version, version.LastMigration, version.AggkitVersion, version.UpdatedAt); err != nil {
+		return fmt.Errorf("error updating the %s table: %w", schemaVersionTableName, err)
+	}
 	return nil
 }
+
+func appliedMigrations(tx types.Querier) (map[string]struct{}, error) {
+	rows, err := tx.Query(`SELECT id FROM gorp_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the migrations applied: %w", err)
+	}
+	defer rows.Close()
+	applied := make(map[string]struct{})
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error scanning the migrations applied: %w", err)
+		}
+		applied[id] = struct{}{}
+	}
+	return applied, rows.Err()
+}
+
+// GetSchemaVersion returns the schema version of the DB, or ErrNotFound if the migrations have
+// never been run on it
+func GetSchemaVersion(db types.Querier) (*SchemaVersion, error) {
+	var version SchemaVersion
+	err := db.QueryRow(`SELECT version, last_migration, aggkit_version, updated_at FROM `+
+		schemaVersionTableName+` WHERE id = 1`).
+		Scan(&version.Version, &version.LastMigration, &version.AggkitVersion, &version.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "no such table") {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting the schema version: %w", err)
+	}
+	return &version, nil
+}
+
+// GetMigrationsStatus returns the status of the given migrations (and the base migrations) in the DB,
+// in the order they are applied, followed by the unknown migrations applied in the DB
+func GetMigrationsStatus(db *sql.DB, migrationsParam []types.Migration) ([]MigrationStatus, error) {
+	migs := newMigrationSource(append(migrationsParam, migrations.GetBaseMigrations()...)) //nolint:gocritic
+	sorted, err := migs.FindMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("error sorting the migrations: %w", err)
+	}
+	records, err := migrate.MigrationSet{}.GetMigrationRecords(db, migrationsDialect)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the migrations applied: %w", err)
+	}
+	applied := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+
+	status := make([]MigrationStatus, 0, len(sorted))
+	for _, m := range sorted {
+		s := MigrationStatus{ID: m.Id}
+		if appliedAt, ok := applied[m.Id]; ok {
+			s.AppliedAt = &appliedAt
+			delete(applied, m.Id)
+		}
+		status = append(status, s)
+	}
+	for _, record := range records {
+		if _, ok := applied[record.Id]; ok {
+			appliedAt := record.AppliedAt
+			status = append(status, MigrationStatus{ID: record.Id, AppliedAt: &appliedAt, Unknown: true})
+		}
+	}
+	return status, nil
+}
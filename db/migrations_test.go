@@ -0,0 +1,130 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/agglayer/aggkit/db/types"
+	"github.com/agglayer/aggkit/log"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = []types.Migration{
+	{
+		ID: "test0001",
+		SQL: `-- +migrate Down
+		DROP TABLE IF EXISTS foo;
+		-- +migrate Up
+		CREATE TABLE foo (id INTEGER PRIMARY KEY);`,
+	},
+	{
+		ID: "test0002",
+		SQL: `-- +migrate Down
+		DROP TABLE IF EXISTS bar;
+		-- +migrate Up
+		CREATE TABLE bar (id INTEGER PRIMARY KEY);`,
+	},
+}
+
+func tableExists(t *testing.T, database types.Querier, table string) bool {
+	t.Helper()
+	var count int
+	err := database.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1`, table).
+		Scan(&count)
+	require.NoError(t, err)
+	return count > 0
+}
+
+func TestRunMigrationsSchemaVersion(t *testing.T) {
+	database, err := NewSQLiteDB(filepath.Join(t.TempDir(), "migrations.sqlite"))
+	require.NoError(t, err)
+	logger := log.WithFields("test", "migrations")
+
+	_, err = GetSchemaVersion(database)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, RunMigrationsDB(logger, database, testMigrations))
+	require.True(t, tableExists(t, database, "foo"))
+	require.True(t, tableExists(t, database, "bar"))
+
+	version, err := GetSchemaVersion(database)
+	require.NoError(t, err)
+	// the test migrations and the base migrations
+	require.Equal(t, len(testMigrations)+1, version.Version)
+	require.Equal(t, "test0002", version.LastMigration)
+
+	status, err := GetMigrationsStatus(database, testMigrations)
+	require.NoError(t, err)
+	require.Len(t, status, len(testMigrations)+1)
+	for _, s := range status {
+		require.NotNil(t, s.AppliedAt, s.ID)
+		require.False(t, s.Unknown, s.ID)
+	}
+
+	// running them again is a no-op
+	require.NoError(t, RunMigrationsDB(logger, database, testMigrations))
+
+	require.NoError(t, MigrateDB(logger, database, testMigrations, migrate.Down, 1))
+	require.False(t, tableExists(t, database, "bar"))
+	version, err = GetSchemaVersion(database)
+	require.NoError(t, err)
+	require.Equal(t, len(testMigrations), version.Version)
+	require.Equal(t, "test0001", version.LastMigration)
+}
+
+func TestRunMigrationsAtomic(t *testing.T) {
+	database, err := NewSQLiteDB(filepath.Join(t.TempDir(), "migrations.sqlite"))
+	require.NoError(t, err)
+	logger := log.WithFields("test", "migrations")
+
+	failing := append(testMigrations, types.Migration{ //nolint:gocritic
+		ID: "test0003",
+		SQL: `-- +migrate Down
+		DROP TABLE IF EXISTS baz;
+		-- +migrate Up
+		CREATE TABLE baz (id INTEGER PRIMARY KEY);
+		INSERT INTO not_existing_table (id) VALUES (1);`,
+	})
+	require.ErrorContains(t, RunMigrationsDB(logger, database, failing), "test0003")
+
+	// none of the migrations has been applied
+	require.False(t, tableExists(t, database, "foo"))
+	require.False(t, tableExists(t, database, "baz"))
+	_, err = GetSchemaVersion(database)
+	require.ErrorIs(t, err, ErrNotFound)
+	status, err := GetMigrationsStatus(database, failing)
+	require.NoError(t, err)
+	for _, s := range status {
+		require.Nil(t, s.AppliedAt, s.ID)
+	}
+}
+
+func TestRunMigrationsUnknownSchemaVersion(t *testing.T) {
+	database, err := NewSQLiteDB(filepath.Join(t.TempDir(), "migrations.sqlite"))
+	require.NoError(t, err)
+	logger := log.WithFields("test", "migrations")
+
+	// the DB is migrated by a newer version with an extra migration
+	newer := append(testMigrations, types.Migration{ //nolint:gocritic
+		ID: "test0003",
+		SQL: `-- +migrate Down
+		DROP TABLE IF EXISTS baz;
+		-- +migrate Up
+		CREATE TABLE baz (id INTEGER PRIMARY KEY);`,
+	})
+	require.NoError(t, RunMigrationsDB(logger, database, newer))
+
+	err = RunMigrationsDB(logger, database, testMigrations)
+	require.ErrorIs(t, err, ErrUnknownSchemaVersion)
+	require.ErrorContains(t, err, "test0003")
+
+	status, err := GetMigrationsStatus(database, testMigrations)
+	require.NoError(t, err)
+	require.Equal(t, "test0003", status[len(status)-1].ID)
+	require.True(t, status[len(status)-1].Unknown)
+
+	// once migrated down with the newer version, it can be run again
+	require.NoError(t, MigrateDB(logger, database, newer, migrate.Down, 1))
+	require.NoError(t, RunMigrationsDB(logger, database, testMigrations))
+}
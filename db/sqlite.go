@@ -4,13 +4,17 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/agglayer/aggkit/config/types"
+	sqlite "github.com/mattn/go-sqlite3"
 )
 
 const (
-	UniqueConstrain = 1555
+	UniqueConstrain     = 1555
+	ForeignKeyConstrain = 787
 )
 
 var (
@@ -24,9 +28,124 @@ func NewSQLiteDB(dbPath string) (*sql.DB, error) {
 	return sql.Open("sqlite3", fmt.Sprintf("file:%s?_txlock=exclusive&_foreign_keys=on&_journal_mode=WAL", dbPath))
 }
 
+// NewSQLiteDBWithConfig creates a new SQLite DB (as NewSQLiteDB) with the given tuning. The reads and
+// writes share the same pool, so MaxReadConns is not used
+func NewSQLiteDBWithConfig(dbPath string, cfg SQLiteConfig) (*sql.DB, error) {
+	params := sqliteParams(cfg)
+	params.Set("_txlock", "exclusive")
+	return sql.Open("sqlite3", fmt.Sprintf("file:%s?%s", dbPath, params.Encode()))
+}
+
+// SQLiteConfig tunes the connections and the storage checks of a SQLite DB
+type SQLiteConfig struct {
+	// BusyTimeout is the time a connection waits for a lock before failing with SQLITE_BUSY (0 = no wait)
+	BusyTimeout types.Duration `mapstructure:"BusyTimeout"`
+	// Synchronous is the synchronous pragma (OFF, NORMAL, FULL or EXTRA). NORMAL is safe in WAL mode
+	// and avoids a fsync per commit. Empty keeps the SQLite default (FULL)
+	Synchronous string `mapstructure:"Synchronous"`
+	// CacheSizeKiB is the page cache size of each connection in KiB (0 keeps the SQLite default)
+	CacheSizeKiB int `mapstructure:"CacheSizeKiB"`
+	// MaxReadConns is the maximum number of connections of the reader pool (0 = unlimited)
+	MaxReadConns int `mapstructure:"MaxReadConns"`
+	// JournalMode is the journal_mode pragma (WAL, DELETE, TRUNCATE or PERSIST). Empty means WAL
+	JournalMode string `mapstructure:"JournalMode"`
+	// StorageQuotaMiB is a soft limit of the size of the DB files in MiB. When it's exceeded an alert
+	// is logged and reported by the metrics, but the DB keeps working (0 = disabled)
+	StorageQuotaMiB uint64 `mapstructure:"StorageQuotaMiB"`
+	// MinFreeDiskSpaceMiB is the free space in MiB required at startup in the disk of the DB (0 = not checked)
+	MinFreeDiskSpaceMiB uint64 `mapstructure:"MinFreeDiskSpaceMiB"`
+}
+
+// Validate checks the config values
+func (c SQLiteConfig) Validate() error {
+	switch strings.ToUpper(c.Synchronous) {
+	case "", "OFF", "NORMAL", "FULL", "EXTRA":
+	default:
+		return fmt.Errorf("invalid sqlite synchronous mode %q, valid values: OFF, NORMAL, FULL, EXTRA", c.Synchronous)
+	}
+	switch strings.ToUpper(c.JournalMode) {
+	case "", "WAL", "DELETE", "TRUNCATE", "PERSIST":
+	default:
+		return fmt.Errorf("invalid sqlite journal mode %q, valid values: WAL, DELETE, TRUNCATE, PERSIST", c.JournalMode)
+	}
+	if c.BusyTimeout.Duration < 0 {
+		return fmt.Errorf("invalid sqlite busy timeout %s", c.BusyTimeout)
+	}
+	if c.CacheSizeKiB < 0 {
+		return fmt.Errorf("invalid sqlite cache size %d", c.CacheSizeKiB)
+	}
+	if c.MaxReadConns < 0 {
+		return fmt.Errorf("invalid sqlite max read connections %d", c.MaxReadConns)
+	}
+	return nil
+}
+
+// NewSQLiteWriterDB creates a SQLite DB for writing with the given tuning. It only has one connection,
+// so the writers are queued in the pool instead of competing for the SQLite write lock
+func NewSQLiteWriterDB(dbPath string, cfg SQLiteConfig) (*sql.DB, error) {
+	params := sqliteParams(cfg)
+	params.Set("_txlock", "immediate")
+	database, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?%s", dbPath, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	database.SetMaxOpenConns(1)
+	return database, nil
+}
+
+// NewSQLiteReaderDB creates a SQLite DB for reading with the given tuning. In WAL mode the readers
+// are not blocked by the writer, so long write transactions don't delay the queries
+func NewSQLiteReaderDB(dbPath string, cfg SQLiteConfig) (*sql.DB, error) {
+	params := sqliteParams(cfg)
+	params.Set("_txlock", "deferred")
+	params.Set("_query_only", "true")
+	database, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?%s", dbPath, params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxReadConns > 0 {
+		database.SetMaxOpenConns(cfg.MaxReadConns)
+	}
+	return database, nil
+}
+
+func sqliteParams(cfg SQLiteConfig) url.Values {
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_journal_mode", "WAL")
+	if cfg.JournalMode != "" {
+		params.Set("_journal_mode", strings.ToUpper(cfg.JournalMode))
+	}
+	if cfg.BusyTimeout.Duration > 0 {
+		params.Set("_busy_timeout", fmt.Sprintf("%d", cfg.BusyTimeout.Milliseconds()))
+	}
+	if cfg.Synchronous != "" {
+		params.Set("_synchronous", strings.ToUpper(cfg.Synchronous))
+	}
+	if cfg.CacheSizeKiB > 0 {
+		// a negative cache_size is the size in KiB instead of pages
+		params.Set("_cache_size", fmt.Sprintf("-%d", cfg.CacheSizeKiB))
+	}
+	return params
+}
+
 func ReturnErrNotFound(err error) error {
 	if errors.Is(err, sql.ErrNoRows) {
 		return ErrNotFound
 	}
 	return err
 }
+
+// IsCorruption returns true if the error is caused by a corrupted DB file (or a file that is not a DB),
+// that can't be fixed by retrying. The errors that lost their type (e.g. the ones of the migrations) are
+// detected by their message
+func IsCorruption(err error) bool {
+	if err == nil {
+		return false
+	}
+	if sqliteErr, ok := SQLiteErr(err); ok {
+		return sqliteErr.Code == sqlite.ErrCorrupt || sqliteErr.Code == sqlite.ErrNotADB
+	}
+	msg := err.Error()
+	return strings.Contains(msg, sqlite.ErrCorrupt.Error()) || strings.Contains(msg, sqlite.ErrNotADB.Error())
+}
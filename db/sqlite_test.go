@@ -1,11 +1,17 @@
 package db
 
 import (
+	"fmt"
+	"os"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
+	configtypes "github.com/agglayer/aggkit/config/types"
 	"github.com/agglayer/aggkit/db/types"
 	"github.com/agglayer/aggkit/log"
+	sqlite "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -41,3 +47,134 @@ func TestSqlite(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "value", value)
 }
+
+func TestSQLiteConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         SQLiteConfig
+		expectedErr string
+	}{
+		{
+			name: "empty config",
+		},
+		{
+			name: "valid config",
+			cfg: SQLiteConfig{
+				BusyTimeout:  configtypes.NewDuration(time.Second),
+				Synchronous:  "normal",
+				CacheSizeKiB: 2048,
+				MaxReadConns: 4,
+				JournalMode:  "delete",
+			},
+		},
+		{
+			name:        "invalid synchronous",
+			cfg:         SQLiteConfig{Synchronous: "SOMETIMES"},
+			expectedErr: "invalid sqlite synchronous mode",
+		},
+		{
+			name:        "invalid journal mode",
+			cfg:         SQLiteConfig{JournalMode: "MEMORY"},
+			expectedErr: "invalid sqlite journal mode",
+		},
+		{
+			name:        "negative busy timeout",
+			cfg:         SQLiteConfig{BusyTimeout: configtypes.NewDuration(-time.Second)},
+			expectedErr: "invalid sqlite busy timeout",
+		},
+		{
+			name:        "negative cache size",
+			cfg:         SQLiteConfig{CacheSizeKiB: -1},
+			expectedErr: "invalid sqlite cache size",
+		},
+		{
+			name:        "negative max read connections",
+			cfg:         SQLiteConfig{MaxReadConns: -1},
+			expectedErr: "invalid sqlite max read connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestSQLiteReaderWriterDB(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "readerwriter.sqlite")
+	cfg := SQLiteConfig{
+		BusyTimeout:  configtypes.NewDuration(time.Second),
+		Synchronous:  "NORMAL",
+		CacheSizeKiB: 1024,
+	}
+	writer, err := NewSQLiteWriterDB(dbPath, cfg)
+	require.NoError(t, err)
+	_, err = writer.Exec("CREATE TABLE test (id INTEGER PRIMARY KEY);")
+	require.NoError(t, err)
+
+	reader, err := NewSQLiteReaderDB(dbPath, cfg)
+	require.NoError(t, err)
+
+	var synchronous int
+	require.NoError(t, reader.QueryRow("PRAGMA synchronous;").Scan(&synchronous))
+	require.Equal(t, 1, synchronous) // NORMAL
+
+	// the reader can't write
+	_, err = reader.Exec("INSERT INTO test (id) VALUES (1);")
+	require.Error(t, err)
+
+	// the reader is not blocked by an open write transaction
+	tx, err := writer.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec("INSERT INTO test (id) VALUES (1);")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, reader.QueryRow("SELECT COUNT(*) FROM test;").Scan(&count))
+	require.Equal(t, 0, count)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, reader.QueryRow("SELECT COUNT(*) FROM test;").Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestNewSQLiteDBWithConfig(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "withconfig.sqlite")
+	db, err := NewSQLiteDBWithConfig(dbPath, SQLiteConfig{JournalMode: "truncate", CacheSizeKiB: 1024})
+	require.NoError(t, err)
+	defer db.Close()
+
+	var journalMode string
+	require.NoError(t, db.QueryRow("PRAGMA journal_mode;").Scan(&journalMode))
+	require.Equal(t, "truncate", journalMode)
+	var cacheSize int
+	require.NoError(t, db.QueryRow("PRAGMA cache_size;").Scan(&cacheSize))
+	require.Equal(t, -1024, cacheSize)
+
+	defaultDB, err := NewSQLiteDBWithConfig(path.Join(t.TempDir(), "default.sqlite"), SQLiteConfig{})
+	require.NoError(t, err)
+	defer defaultDB.Close()
+	require.NoError(t, defaultDB.QueryRow("PRAGMA journal_mode;").Scan(&journalMode))
+	require.Equal(t, "wal", journalMode)
+}
+
+func TestIsCorruption(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "corrupted.sqlite")
+	require.NoError(t, os.WriteFile(dbPath, []byte(strings.Repeat("not a sqlite db", 100)), 0o600))
+	db, err := NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`SELECT 1 FROM sqlite_master;`)
+	require.Error(t, err)
+	require.True(t, IsCorruption(err))
+	// the wrapped errors are detected by their message too
+	require.True(t, IsCorruption(fmt.Errorf("migration failed: %s", err.Error())))
+
+	require.False(t, IsCorruption(nil))
+	require.False(t, IsCorruption(ErrNotFound))
+	require.False(t, IsCorruption(sqlite.Error{Code: sqlite.ErrConstraint}))
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/prometheus"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	bytesPerMiB = 1024 * 1024
+	// storageCheckInterval is the interval at which the size of the DB is checked against the quota
+	storageCheckInterval = time.Minute
+
+	storageMetricsDBLabel     = "db"
+	storageQuotaExceededTotal = "db_storage_quota_exceeded_total"
+)
+
+var (
+	ErrNotEnoughDiskSpace = errors.New("not enough free disk space")
+
+	registerStorageMetricsOnce sync.Once
+)
+
+// StorageMonitor checks the free disk space at startup and alerts when the DB files exceed the
+// soft storage quota, so a full disk is noticed before sqlite fails with opaque errors
+type StorageMonitor struct {
+	name   string
+	dbPath string
+	cfg    SQLiteConfig
+	logger aggkitcommon.Logger
+}
+
+// NewStorageMonitor creates a StorageMonitor of the DB with the given name (used as metrics label)
+func NewStorageMonitor(logger aggkitcommon.Logger, name, dbPath string, cfg SQLiteConfig) *StorageMonitor {
+	registerStorageMetrics()
+	return &StorageMonitor{
+		name:   name,
+		dbPath: dbPath,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// CheckStartup returns an error if the free disk space of the DB is lower than MinFreeDiskSpaceMiB,
+// and checks the storage quota
+func (m *StorageMonitor) CheckStartup() error {
+	if m.cfg.MinFreeDiskSpaceMiB > 0 {
+		free, err := FreeDiskSpace(m.dbPath)
+		if err != nil {
+			return fmt.Errorf("failed to get the free disk space of the %s DB (%s): %w", m.name, m.dbPath, err)
+		}
+		if free < m.cfg.MinFreeDiskSpaceMiB*bytesPerMiB {
+			return fmt.Errorf("%w for the %s DB (%s): %d MiB free, %d MiB required (MinFreeDiskSpaceMiB)",
+				ErrNotEnoughDiskSpace, m.name, m.dbPath, free/bytesPerMiB, m.cfg.MinFreeDiskSpaceMiB)
+		}
+	}
+	m.checkQuota()
+	return nil
+}
+
+// Start checks periodically the storage quota until the context is done. It does nothing if the
+// quota is disabled
+func (m *StorageMonitor) Start(ctx context.Context) {
+	if m.cfg.StorageQuotaMiB == 0 {
+		return
+	}
+	ticker := time.NewTicker(storageCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkQuota()
+		}
+	}
+}
+
+// checkQuota alerts if the size of the DB files exceeds the storage quota. It returns true if exceeded
+func (m *StorageMonitor) checkQuota() bool {
+	if m.cfg.StorageQuotaMiB == 0 {
+		return false
+	}
+	size, err := DBFilesSize(m.dbPath)
+	if err != nil {
+		m.logger.Warnf("failed to get the size of the %s DB (%s): %v", m.name, m.dbPath, err)
+		return false
+	}
+	if size <= m.cfg.StorageQuotaMiB*bytesPerMiB {
+		return false
+	}
+	prometheus.CounterVecInc(storageQuotaExceededTotal, m.name)
+	m.logger.Warnf("the %s DB (%s) exceeds its storage quota: %d MiB used, quota %d MiB (StorageQuotaMiB)",
+		m.name, m.dbPath, size/bytesPerMiB, m.cfg.StorageQuotaMiB)
+	return true
+}
+
+// DBFilesSize returns the size in bytes of the sqlite DB files (the DB and its WAL and shared memory
+// files). The files that don't exist are not counted
+func DBFilesSize(dbPath string) (uint64, error) {
+	var size uint64
+	for _, file := range []string{dbPath, dbPath + "-wal", dbPath + "-shm"} {
+		info, err := os.Stat(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return 0, err
+		}
+		size += uint64(info.Size())
+	}
+	return size, nil
+}
+
+// FreeDiskSpace returns the free space in bytes (available to unprivileged users) of the disk that
+// contains the DB
+func FreeDiskSpace(dbPath string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(filepath.Dir(dbPath), &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:gosec,unconvert
+}
+
+// registerStorageMetrics registers the storage metrics. All the DBs share the same metrics, so they
+// are registered only once and labelled by DB name
+func registerStorageMetrics() {
+	registerStorageMetricsOnce.Do(func() {
+		prometheus.RegisterCounterVecs(
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: storageQuotaExceededTotal,
+					Help: "[DB] number of checks in which the DB files exceeded the storage quota",
+				},
+				Labels: []string{storageMetricsDBLabel},
+			},
+		)
+	})
+}
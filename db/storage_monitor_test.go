@@ -0,0 +1,49 @@
+package db
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageMonitorCheckStartup(t *testing.T) {
+	logger := log.WithFields("test", "storage-monitor")
+	dbPath := path.Join(t.TempDir(), "monitor.sqlite")
+
+	sut := NewStorageMonitor(logger, "test", dbPath, SQLiteConfig{MinFreeDiskSpaceMiB: 1})
+	require.NoError(t, sut.CheckStartup())
+
+	sut = NewStorageMonitor(logger, "test", dbPath, SQLiteConfig{MinFreeDiskSpaceMiB: 1 << 40})
+	require.ErrorIs(t, sut.CheckStartup(), ErrNotEnoughDiskSpace)
+
+	sut = NewStorageMonitor(logger, "test", path.Join(dbPath, "missing", "monitor.sqlite"),
+		SQLiteConfig{MinFreeDiskSpaceMiB: 1})
+	require.ErrorContains(t, sut.CheckStartup(), "failed to get the free disk space")
+}
+
+func TestStorageMonitorCheckQuota(t *testing.T) {
+	logger := log.WithFields("test", "storage-monitor")
+	dbPath := path.Join(t.TempDir(), "monitor.sqlite")
+
+	// disabled quota
+	sut := NewStorageMonitor(logger, "test", dbPath, SQLiteConfig{})
+	require.False(t, sut.checkQuota())
+
+	// the DB files don't exist yet
+	sut = NewStorageMonitor(logger, "test", dbPath, SQLiteConfig{StorageQuotaMiB: 1})
+	require.False(t, sut.checkQuota())
+
+	require.NoError(t, os.WriteFile(dbPath, make([]byte, bytesPerMiB/2), 0o600))
+	require.False(t, sut.checkQuota())
+
+	// the WAL file is part of the DB size
+	require.NoError(t, os.WriteFile(dbPath+"-wal", make([]byte, bytesPerMiB), 0o600))
+	require.True(t, sut.checkQuota())
+
+	size, err := DBFilesSize(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, uint64(bytesPerMiB+bytesPerMiB/2), size)
+}
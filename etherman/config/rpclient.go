@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+
+	configtypes "github.com/agglayer/aggkit/config/types"
+	aggkittypes "github.com/agglayer/aggkit/types"
 )
 
 type RPCMode string
@@ -11,10 +14,77 @@ var (
 	RPCModeOp    RPCMode = "op"
 )
 
+// RPCConnectionConfig is the configuration of the auth and transport of a RPC endpoint
+type RPCConnectionConfig struct {
+	// Headers are static headers added to every request (e.g. { "x-api-key" = "..." })
+	Headers map[string]string `jsonschema:"omitempty" mapstructure:"Headers"`
+	// BasicAuthUser is the user for HTTP basic authentication
+	BasicAuthUser string `jsonschema:"omitempty" mapstructure:"BasicAuthUser"`
+	// BasicAuthPassword is the password for HTTP basic authentication
+	BasicAuthPassword string `jsonschema:"omitempty" mapstructure:"BasicAuthPassword"`
+	// BearerToken is sent as "Authorization: Bearer <token>"
+	BearerToken string `jsonschema:"omitempty" mapstructure:"BearerToken"`
+	// Timeout is the timeout of each request to the endpoint, 0 means no timeout
+	Timeout configtypes.Duration `jsonschema:"omitempty" mapstructure:"Timeout"`
+	// RateLimit limits the requests sent to the endpoint, to stay inside the quotas of the provider
+	RateLimit RPCRateLimitConfig `jsonschema:"omitempty" mapstructure:"RateLimit"`
+	// HeaderCache caches the block headers shared by all the syncers connected to the endpoint
+	HeaderCache RPCHeaderCacheConfig `jsonschema:"omitempty" mapstructure:"HeaderCache"`
+	// ProxyURL is the HTTP(S) proxy used to connect to the endpoint (e.g. "http://proxy:3128").
+	// If it's empty, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used
+	ProxyURL string `jsonschema:"omitempty" mapstructure:"ProxyURL"`
+	// TLS are the CAs trusted to verify the endpoint and the client certificate for mutual TLS
+	TLS configtypes.TLSClientConfig `jsonschema:"omitempty" mapstructure:"TLS"`
+}
+
+// RPCHeaderCacheConfig is the configuration of the cache of the block headers requested to a RPC
+// endpoint. The zero value disables the cache
+type RPCHeaderCacheConfig struct {
+	// Size is the maximum number of headers cached, 0 disables the cache
+	Size int `jsonschema:"omitempty" mapstructure:"Size"`
+	// TTL is the time a header requested by number is cached, 0 means that the headers are only
+	// cached by hash
+	TTL configtypes.Duration `jsonschema:"omitempty" mapstructure:"TTL"`
+}
+
+// RPCRateLimitConfig is the configuration of the limits of the requests sent to a RPC endpoint.
+// The zero value doesn't limit the requests
+type RPCRateLimitConfig struct {
+	// RequestsPerSecond is the maximum rate of requests, 0 means no rate limit
+	RequestsPerSecond float64 `jsonschema:"omitempty" mapstructure:"RequestsPerSecond"`
+	// Burst is the maximum number of requests sent at once when the rate limit allows it (default: 1)
+	Burst int `jsonschema:"omitempty" mapstructure:"Burst"`
+	// MaxConcurrentRequests is the maximum number of in-flight requests, 0 means no limit
+	MaxConcurrentRequests int `jsonschema:"omitempty" mapstructure:"MaxConcurrentRequests"`
+}
+
+// Options returns the RPC client options for this endpoint
+func (c RPCConnectionConfig) Options() aggkittypes.RPCClientOptions {
+	return aggkittypes.RPCClientOptions{
+		Headers:           c.Headers,
+		BasicAuthUser:     c.BasicAuthUser,
+		BasicAuthPassword: c.BasicAuthPassword,
+		BearerToken:       c.BearerToken,
+		Timeout:           c.Timeout.Duration,
+		RateLimit: aggkittypes.RPCRateLimitOptions{
+			RequestsPerSecond:     c.RateLimit.RequestsPerSecond,
+			Burst:                 c.RateLimit.Burst,
+			MaxConcurrentRequests: c.RateLimit.MaxConcurrentRequests,
+		},
+		HeaderCache: aggkittypes.RPCHeaderCacheOptions{
+			Size: c.HeaderCache.Size,
+			TTL:  c.HeaderCache.TTL.Duration,
+		},
+		ProxyURL: c.ProxyURL,
+		TLS:      c.TLS,
+	}
+}
+
 type RPCClientConfig struct {
-	URL         string         `mapstructure:"URL"`
-	Mode        RPCMode        `jsonschema:"enum=basic, enum=op" mapstructure:"Mode"`
-	ExtraParams map[string]any `jsonschema:"omitempty" mapstructure:",remain"`
+	URL                 string  `mapstructure:"URL"`
+	Mode                RPCMode `jsonschema:"enum=basic, enum=op" mapstructure:"Mode"`
+	RPCConnectionConfig `mapstructure:",squash"`
+	ExtraParams         map[string]any `jsonschema:"omitempty" mapstructure:",remain"`
 }
 
 func (c RPCClientConfig) GetString(key string) (string, error) {
@@ -11,7 +11,6 @@ import (
 	"github.com/agglayer/aggkit/opnode"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -40,13 +39,13 @@ func NewRPCClientModeOp(cfg ethermanconfig.RPCClientConfig) (aggkittypes.EthClie
 	if err != nil {
 		return nil, fmt.Errorf("field %s not found in extra params (%+v). Err: %w", ExtraParamFieldName, cfg, err)
 	}
+	ctx := context.Background()
 	log.Debugf("Creating OPNode RPC client with URL %s %s:%s", cfg.URL, ExtraParamFieldName, opNodeURL)
-	basicClient, err := ethclient.Dial(cfg.URL)
+	ethClient, err := aggkittypes.DialEthClient(ctx, cfg.URL, cfg.Options())
 	if err != nil {
 		return nil, fmt.Errorf("fails to create RPC client. Err: %w", err)
 	}
 	opNodeClient := opnode.NewOpNodeClient(opNodeURL)
-	ethClient := aggkittypes.NewDefaultEthClient(basicClient, basicClient.Client())
 	return NewRPCOpNodeDecorator(ethClient, opNodeClient), nil
 }
 
@@ -68,3 +67,12 @@ func (f *RPCOpNodeDecorator) HeaderByNumber(ctx context.Context, number *big.Int
 	}
 	return f.EthClienter.HeaderByNumber(ctx, number)
 }
+
+// BatchCallContext forwards the batch request to the decorated client
+func (f *RPCOpNodeDecorator) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	batchCaller, ok := f.EthClienter.(aggkittypes.RPCBatchCaller)
+	if !ok {
+		return aggkittypes.ErrBatchCallNotSupported
+	}
+	return batchCaller.BatchCallContext(ctx, b)
+}
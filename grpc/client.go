@@ -1,10 +1,10 @@
 package grpc
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/status"
 )
 
@@ -26,6 +27,14 @@ const (
 	defaultMaxBackoff        = 10 * time.Second
 	defaultBackoffMultiplier = 2.0
 
+	// DefaultMaxMsgSize is the default maximum size of the messages sent and received by the client.
+	// The gRPC default (4MiB) is too small for the SP1 proofs, that can be several MiB
+	DefaultMaxMsgSize = 64 * 1024 * 1024
+	// defaultKeepAliveTime is aligned with the minimum ping interval allowed by default by
+	// the gRPC servers (5m), pinging more often makes the server close the connection (too_many_pings)
+	defaultKeepAliveTime    = 5 * time.Minute
+	defaultKeepAliveTimeout = 20 * time.Second
+
 	noneStr = "none"
 )
 
@@ -46,6 +55,77 @@ type ClientConfig struct {
 
 	// Retry represents the retry configuration
 	Retry *RetryConfig `mapstructure:"Retry"`
+
+	// MaxCallRecvMsgSize is the maximum size in bytes of a received message (0 = DefaultMaxMsgSize)
+	MaxCallRecvMsgSize int `mapstructure:"MaxCallRecvMsgSize"`
+
+	// MaxCallSendMsgSize is the maximum size in bytes of a sent message (0 = DefaultMaxMsgSize)
+	MaxCallSendMsgSize int `mapstructure:"MaxCallSendMsgSize"`
+
+	// KeepAlive is the HTTP/2 keepalive configuration (nil = no keepalive pings)
+	KeepAlive *KeepAliveConfig `mapstructure:"KeepAlive"`
+
+	// InitialWindowSize is the HTTP/2 flow control window of each stream in bytes.
+	// 0 uses the gRPC dynamic window (BDP estimation). Values lower than 64KiB are ignored by gRPC
+	InitialWindowSize int32 `mapstructure:"InitialWindowSize"`
+
+	// InitialConnWindowSize is the HTTP/2 flow control window of the connection in bytes.
+	// 0 uses the gRPC dynamic window (BDP estimation). Values lower than 64KiB are ignored by gRPC
+	InitialConnWindowSize int32 `mapstructure:"InitialConnWindowSize"`
+
+	// MethodTimeouts are the deadlines (including the retries) of specific methods or services,
+	// that override RequestTimeout if they are shorter
+	MethodTimeouts []MethodTimeout `mapstructure:"MethodTimeouts"`
+
+	// TLS are the CAs trusted to verify the server and the client certificate for mutual TLS.
+	// It requires UseTLS
+	TLS types.TLSClientConfig `mapstructure:"TLS"`
+
+	// ProxyURL is the HTTP(S) proxy used to connect to the server (e.g. "http://proxy:3128").
+	// If it's empty, the HTTPS_PROXY and NO_PROXY environment variables are used
+	ProxyURL string `mapstructure:"ProxyURL"`
+}
+
+// KeepAliveConfig is the HTTP/2 keepalive configuration of the client connection
+type KeepAliveConfig struct {
+	// Time is the period without activity after which the client pings the server
+	Time types.Duration `mapstructure:"Time"`
+
+	// Timeout is the time waited for the ping ack before closing the connection
+	Timeout types.Duration `mapstructure:"Timeout"`
+
+	// PermitWithoutStream allows to send pings when there are no active calls
+	PermitWithoutStream bool `mapstructure:"PermitWithoutStream"`
+}
+
+func (k *KeepAliveConfig) String() string {
+	if k == nil {
+		return noneStr
+	}
+
+	return fmt.Sprintf("Time=%s, Timeout=%s, PermitWithoutStream=%t",
+		k.Time.String(), k.Timeout.String(), k.PermitWithoutStream)
+}
+
+// Validate checks if the gRPC keepalive configuration is valid
+func (k *KeepAliveConfig) Validate() error {
+	if k.Time.Duration <= 0 {
+		return fmt.Errorf("KeepAlive.Time must be greater than zero")
+	}
+
+	if k.Timeout.Duration <= 0 {
+		return fmt.Errorf("KeepAlive.Timeout must be greater than zero")
+	}
+
+	return nil
+}
+
+// MethodTimeout is the deadline of the calls to a gRPC method (or to all the methods of a service)
+type MethodTimeout struct {
+	Method `mapstructure:",squash"`
+
+	// Timeout is the deadline of the calls, including the retries
+	Timeout types.Duration `mapstructure:"Timeout"`
 }
 
 // DefaultConfig returns a default configuration for the gRPC client
@@ -59,8 +139,14 @@ func DefaultConfig() *ClientConfig {
 			MaxBackoff:        types.NewDuration(defaultMaxBackoff),
 			BackoffMultiplier: defaultBackoffMultiplier,
 		},
-		RequestTimeout: types.NewDuration(defaultTimeout),
-		UseTLS:         false,
+		RequestTimeout:     types.NewDuration(defaultTimeout),
+		UseTLS:             false,
+		MaxCallRecvMsgSize: DefaultMaxMsgSize,
+		MaxCallSendMsgSize: DefaultMaxMsgSize,
+		KeepAlive: &KeepAliveConfig{
+			Time:    types.NewDuration(defaultKeepAliveTime),
+			Timeout: types.NewDuration(defaultKeepAliveTimeout),
+		},
 	}
 }
 
@@ -72,9 +158,11 @@ func (c *ClientConfig) String() string {
 
 	return fmt.Sprintf("GRPC Client Config: "+
 		"URL=%s, MinConnectTimeout=%s, "+
-		"RequestTimeout=%s, UseTLS=%t, Retry=%s",
+		"RequestTimeout=%s, UseTLS=%t, Retry=%s, "+
+		"MaxCallRecvMsgSize=%d, MaxCallSendMsgSize=%d, KeepAlive=%s",
 		c.URL, c.MinConnectTimeout.String(),
-		c.RequestTimeout.Duration, c.UseTLS, c.Retry.String())
+		c.RequestTimeout.Duration, c.UseTLS, c.Retry.String(),
+		c.maxCallRecvMsgSize(), c.maxCallSendMsgSize(), c.KeepAlive.String())
 }
 
 // Validate checks if the gRPC client configuration is valid.
@@ -102,9 +190,61 @@ func (c *ClientConfig) Validate() error {
 		}
 	}
 
+	if c.MaxCallRecvMsgSize < 0 || c.MaxCallSendMsgSize < 0 {
+		return fmt.Errorf("MaxCallRecvMsgSize and MaxCallSendMsgSize can't be negative")
+	}
+
+	if c.InitialWindowSize < 0 || c.InitialConnWindowSize < 0 {
+		return fmt.Errorf("InitialWindowSize and InitialConnWindowSize can't be negative")
+	}
+
+	if c.KeepAlive != nil {
+		if err := c.KeepAlive.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if !c.TLS.IsEmpty() && !c.UseTLS {
+		return fmt.Errorf("TLS is set but UseTLS is false")
+	}
+
+	if err := c.TLS.Validate(); err != nil {
+		return err
+	}
+
+	if c.ProxyURL != "" {
+		if _, err := types.ParseProxyURL(c.ProxyURL); err != nil {
+			return err
+		}
+	}
+
+	for _, mt := range c.MethodTimeouts {
+		if mt.ServiceName == "" {
+			return fmt.Errorf("MethodTimeouts: the service name of method %q can't be empty", mt.MethodName)
+		}
+		if mt.Timeout.Duration <= 0 {
+			return fmt.Errorf("MethodTimeouts: the timeout of %s/%s must be greater than zero",
+				mt.ServiceName, mt.MethodName)
+		}
+	}
+
 	return nil
 }
 
+func (c *ClientConfig) maxCallRecvMsgSize() int {
+	if c.MaxCallRecvMsgSize == 0 {
+		return DefaultMaxMsgSize
+	}
+	return c.MaxCallRecvMsgSize
+}
+
+func (c *ClientConfig) maxCallSendMsgSize() int {
+	if c.MaxCallSendMsgSize == 0 {
+		return DefaultMaxMsgSize
+	}
+	return c.MaxCallSendMsgSize
+}
+
 // validateRequestTimeout ensures that the configured request timeout is long enough
 // to accommodate all retry attempts, including exponential backoff delays and an
 // estimated execution time per call. This prevents premature request termination
@@ -232,9 +372,29 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithConnectParams(connectParams),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(cfg.maxCallRecvMsgSize()),
+			grpc.MaxCallSendMsgSize(cfg.maxCallSendMsgSize()),
+		),
+	}
+
+	if cfg.KeepAlive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepAlive.Time.Duration,
+			Timeout:             cfg.KeepAlive.Timeout.Duration,
+			PermitWithoutStream: cfg.KeepAlive.PermitWithoutStream,
+		}))
+	}
+
+	if cfg.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(cfg.InitialWindowSize))
+	}
+
+	if cfg.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(cfg.InitialConnWindowSize))
 	}
 
-	serviceCfgJSON, err := createServiceConfig(retryCfg)
+	serviceCfgJSON, err := createServiceConfig(retryCfg, cfg.MethodTimeouts)
 	if err != nil {
 		return nil, err
 	}
@@ -244,14 +404,29 @@ func NewClient(cfg *ClientConfig) (*Client, error) {
 	}
 
 	if cfg.UseTLS {
-		creds := credentials.NewTLS(&tls.Config{InsecureSkipVerify: false, MinVersion: tls.VersionTLS12})
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+		tlsCfg, err := cfg.TLS.Load()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
 	// trim the http:// and https:// prefixes from the URL because the go-grpc client expects it without it
 	serverAddr := trimGRPCAddress(cfg.URL)
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := types.ParseProxyURL(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithContextDialer(newProxyDialer(proxyURL).DialContext))
+		// the host name is resolved by the proxy, that may be the only one able to resolve it
+		if !strings.Contains(serverAddr, "://") {
+			serverAddr = "passthrough:///" + serverAddr
+		}
+	}
 	conn, err := grpc.NewClient(serverAddr, opts...)
 	if err != nil {
 		return nil, err
@@ -266,35 +441,56 @@ func trimGRPCAddress(address string) string {
 	return serverAddr
 }
 
-func createServiceConfig(cfg *RetryConfig) (string, error) {
-	if cfg == nil {
+// createServiceConfig returns the JSON service config with the retry policy and the method timeouts.
+// gRPC applies the config of the most specific name to each call (without merging), so every
+// method config carries both its retry policy and its timeout
+func createServiceConfig(cfg *RetryConfig, methodTimeouts []MethodTimeout) (string, error) {
+	if cfg == nil && len(methodTimeouts) == 0 {
 		return "", nil
 	}
 
-	methodCfg := make([]MethodConfig, 0, len(cfg.Excluded))
-	methodCfg = append(methodCfg, MethodConfig{
-		Name: []MethodName{{}}, // Empty name matches all methods
-		RetryPolicy: &RetryPolicy{
+	var retryPolicy *RetryPolicy
+	if cfg != nil {
+		retryPolicy = &RetryPolicy{
 			MaxAttempts:       cfg.MaxAttempts,
-			InitialBackoff:    cfg.InitialBackoff.String(),
-			MaxBackoff:        cfg.MaxBackoff.String(),
+			InitialBackoff:    serviceConfigDuration(cfg.InitialBackoff.Duration),
+			MaxBackoff:        serviceConfigDuration(cfg.MaxBackoff.Duration),
 			BackoffMultiplier: cfg.BackoffMultiplier,
 			RetryableStatusCodes: []string{
 				grpcCodeCanonicalString(codes.Unavailable),
 				grpcCodeCanonicalString(codes.Aborted),
 				grpcCodeCanonicalString(codes.ResourceExhausted),
 			},
-		},
-	})
+		}
+	}
 
-	for _, excluded := range cfg.Excluded {
+	methodCfg := make([]MethodConfig, 0, 1+len(methodTimeouts))
+	index := make(map[MethodName]int)
+	if retryPolicy != nil {
 		methodCfg = append(methodCfg, MethodConfig{
-			Name: []MethodName{
-				{
-					Service: excluded.ServiceName,
-					Method:  excluded.MethodName,
-				},
-			},
+			Name:        []MethodName{{}}, // Empty name matches all methods
+			RetryPolicy: retryPolicy,
+		})
+
+		for _, excluded := range cfg.Excluded {
+			name := MethodName{Service: excluded.ServiceName, Method: excluded.MethodName}
+			index[name] = len(methodCfg)
+			methodCfg = append(methodCfg, MethodConfig{Name: []MethodName{name}})
+		}
+	}
+
+	for _, mt := range methodTimeouts {
+		name := MethodName{Service: mt.ServiceName, Method: mt.MethodName}
+		timeout := serviceConfigDuration(mt.Timeout.Duration)
+		if i, exists := index[name]; exists {
+			methodCfg[i].Timeout = timeout
+			continue
+		}
+		index[name] = len(methodCfg)
+		methodCfg = append(methodCfg, MethodConfig{
+			Name:        []MethodName{name},
+			RetryPolicy: retryPolicy,
+			Timeout:     timeout,
 		})
 	}
 
@@ -308,6 +504,12 @@ func createServiceConfig(cfg *RetryConfig) (string, error) {
 	return string(serviceCfgJSON), nil
 }
 
+// serviceConfigDuration formats the duration as required by the gRPC service config
+// (seconds with the 's' suffix, e.g. 0.1s), time.Duration.String() (e.g. 100ms) is not accepted
+func serviceConfigDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
 // Conn returns the gRPC connection
 func (c *Client) Conn() *grpc.ClientConn {
 	return c.conn
@@ -257,6 +257,83 @@ func TestClientConfig_Validate(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "negative max message size",
+			cfg: &ClientConfig{
+				URL:                "localhost:1234",
+				MinConnectTimeout:  types.Duration{Duration: 1 * time.Second},
+				RequestTimeout:     types.Duration{Duration: 5 * time.Second},
+				MaxCallRecvMsgSize: -1,
+			},
+			wantErr: "MaxCallRecvMsgSize and MaxCallSendMsgSize can't be negative",
+		},
+		{
+			name: "keepalive without timeout",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				RequestTimeout:    types.Duration{Duration: 5 * time.Second},
+				KeepAlive:         &KeepAliveConfig{Time: types.NewDuration(time.Minute)},
+			},
+			wantErr: "KeepAlive.Timeout must be greater than zero",
+		},
+		{
+			name: "method timeout without service",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				RequestTimeout:    types.Duration{Duration: 5 * time.Second},
+				MethodTimeouts: []MethodTimeout{
+					{Method: Method{MethodName: "Foo"}, Timeout: types.NewDuration(time.Second)},
+				},
+			},
+			wantErr: `MethodTimeouts: the service name of method "Foo" can't be empty`,
+		},
+		{
+			name: "method timeout not positive",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				RequestTimeout:    types.Duration{Duration: 5 * time.Second},
+				MethodTimeouts: []MethodTimeout{
+					{Method: Method{ServiceName: "some.Service", MethodName: "Foo"}},
+				},
+			},
+			wantErr: "MethodTimeouts: the timeout of some.Service/Foo must be greater than zero",
+		},
+		{
+			name: "TLS without UseTLS",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				TLS:               types.TLSClientConfig{CACertFile: "/etc/ssl/ca.pem"},
+			},
+			wantErr: "TLS is set but UseTLS is false",
+		},
+		{
+			name: "TLS client certificate without key",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				UseTLS:            true,
+				TLS:               types.TLSClientConfig{CertFile: "/etc/ssl/client.pem"},
+			},
+			wantErr: "TLS: CertFile and KeyFile must be set together",
+		},
+		{
+			name: "invalid proxy URL",
+			cfg: &ClientConfig{
+				URL:               "localhost:1234",
+				MinConnectTimeout: types.Duration{Duration: 1 * time.Second},
+				ProxyURL:          "socks5://proxy:1080",
+			},
+			wantErr: "invalid proxy URL",
+		},
+		{
+			name:    "default config",
+			cfg:     func() *ClientConfig { c := DefaultConfig(); c.URL = "localhost:1234"; return c }(),
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -285,7 +362,7 @@ func TestGenerateServiceConfig(t *testing.T) {
 		cfg := defaultRetry
 		cfg.Excluded = nil
 
-		sc, err := createServiceConfig(&cfg)
+		sc, err := createServiceConfig(&cfg, nil)
 		require.NoError(t, err)
 		require.Contains(t, sc, `"name":[{}]`)
 		require.Contains(t, sc, `"retryPolicy"`)
@@ -295,7 +372,7 @@ func TestGenerateServiceConfig(t *testing.T) {
 		cfg := defaultRetry
 		cfg.Excluded = []Method{{ServiceName: "some.Service", MethodName: "Foo"}}
 
-		sc, err := createServiceConfig(&cfg)
+		sc, err := createServiceConfig(&cfg, nil)
 		require.NoError(t, err)
 		require.Contains(t, sc, `"name":[{"service":"some.Service","method":"Foo"}]`)
 		require.Contains(t, sc, `"name":[{}]`) // default retry for others
@@ -305,11 +382,58 @@ func TestGenerateServiceConfig(t *testing.T) {
 		cfg := defaultRetry
 		cfg.Excluded = []Method{{ServiceName: "some.Service", MethodName: ""}}
 
-		sc, err := createServiceConfig(&cfg)
+		sc, err := createServiceConfig(&cfg, nil)
 		require.NoError(t, err)
 		require.Contains(t, sc, `"name":[{"service":"some.Service"}]`)
 		require.Contains(t, sc, `"name":[{}]`) // default retry for all others
 	})
+
+	t.Run("method timeouts", func(t *testing.T) {
+		cfg := defaultRetry
+		cfg.Excluded = []Method{{ServiceName: "some.Service", MethodName: "Foo"}}
+		timeouts := []MethodTimeout{
+			{Method: cfg.Excluded[0], Timeout: types.NewDuration(1500 * time.Millisecond)},
+			{Method: Method{ServiceName: "some.Service", MethodName: "Bar"}, Timeout: types.NewDuration(time.Minute)},
+		}
+
+		sc, err := createServiceConfig(&cfg, timeouts)
+		require.NoError(t, err)
+		// the excluded method gets the timeout but not the retry policy
+		require.Contains(t, sc, `{"name":[{"service":"some.Service","method":"Foo"}],"timeout":"1.5s"}`)
+		require.Contains(t, sc, `"name":[{"service":"some.Service","method":"Bar"}],"retryPolicy":{`)
+		require.Contains(t, sc, `"timeout":"60s"`)
+		require.Contains(t, sc, `"initialBackoff":"0.1s"`)
+	})
+
+	t.Run("method timeouts without retry", func(t *testing.T) {
+		sc, err := createServiceConfig(nil, []MethodTimeout{
+			{Method: Method{ServiceName: "some.Service"}, Timeout: types.NewDuration(30 * time.Second)},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"methodConfig":[{"name":[{"service":"some.Service"}],"timeout":"30s"}]}`, sc)
+	})
+
+	t.Run("no retry nor timeouts", func(t *testing.T) {
+		sc, err := createServiceConfig(nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, sc)
+	})
+}
+
+func TestNewClientServiceConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.URL = "localhost:1234"
+	cfg.InitialWindowSize = 1024 * 1024
+	cfg.InitialConnWindowSize = 1024 * 1024
+	cfg.Retry.Excluded = []Method{{ServiceName: "some.Service", MethodName: "Foo"}}
+	cfg.MethodTimeouts = []MethodTimeout{
+		{Method: Method{ServiceName: "some.Service", MethodName: "Foo"}, Timeout: types.NewDuration(time.Second)},
+	}
+
+	// grpc.NewClient parses the service config, so it fails if any duration is not valid
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	require.NoError(t, client.Conn().Close())
 }
 
 func TestGRPCError_Is(t *testing.T) {
@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// proxyDialer connects to the gRPC server through a HTTP(S) proxy, using a CONNECT tunnel.
+// gRPC only takes the proxy from the environment, so it's needed for the proxy of the config
+type proxyDialer struct {
+	proxyURL *url.URL
+	dialer   net.Dialer
+}
+
+func newProxyDialer(proxyURL *url.URL) *proxyDialer {
+	return &proxyDialer{proxyURL: proxyURL}
+}
+
+// DialContext opens the tunnel to addr, it has the signature required by grpc.WithContextDialer
+func (p *proxyDialer) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	proxyAddr := p.proxyURL.Host
+	if p.proxyURL.Port() == "" {
+		port := "80"
+		if p.proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(p.proxyURL.Hostname(), port)
+	}
+
+	conn, err := p.dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the proxy %s: %w", proxyAddr, err)
+	}
+	if p.proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: p.proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	tunnel, err := p.connect(conn, addr)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	return tunnel, nil
+}
+
+func (p *proxyDialer) connect(conn net.Conn, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Header: make(http.Header),
+		Host:   addr,
+	}
+	if user := p.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to send the CONNECT request to the proxy: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CONNECT response of the proxy: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the proxy refused the CONNECT to %s: %s", addr, resp.Status)
+	}
+
+	if reader.Buffered() > 0 {
+		// the server already sent data through the tunnel, it has to be read before the conn
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a net.Conn whose first bytes have already been read into a buffer
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeProxy accepts one CONNECT request, answers with the given status and, if it's 200,
+// echoes the data of the tunnel
+func startFakeProxy(t *testing.T, status int) (string, <-chan *http.Request) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- req
+		resp := &http.Response{StatusCode: status, ProtoMajor: 1, ProtoMinor: 1}
+		if err := resp.Write(conn); err != nil || status != http.StatusOK {
+			return
+		}
+		_, _ = io.Copy(conn, conn)
+	}()
+
+	return listener.Addr().String(), requests
+}
+
+func TestProxyDialer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("tunnel established", func(t *testing.T) {
+		proxyAddr, requests := startFakeProxy(t, http.StatusOK)
+		proxyURL, err := url.Parse("http://user:pass@" + proxyAddr)
+		require.NoError(t, err)
+
+		conn, err := newProxyDialer(proxyURL).DialContext(ctx, "agglayer.example.com:443")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		req := <-requests
+		require.Equal(t, http.MethodConnect, req.Method)
+		require.Equal(t, "agglayer.example.com:443", req.Host)
+		require.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")),
+			req.Header.Get("Proxy-Authorization"))
+
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		require.Equal(t, "ping", string(buf))
+	})
+
+	t.Run("tunnel refused", func(t *testing.T) {
+		proxyAddr, requests := startFakeProxy(t, http.StatusForbidden)
+		proxyURL, err := url.Parse("http://" + proxyAddr)
+		require.NoError(t, err)
+
+		_, err = newProxyDialer(proxyURL).DialContext(ctx, "agglayer.example.com:443")
+		require.ErrorContains(t, err, "the proxy refused the CONNECT to agglayer.example.com:443")
+		require.Empty(t, (<-requests).Header.Get("Proxy-Authorization"))
+	})
+
+	t.Run("proxy unreachable", func(t *testing.T) {
+		proxyURL, err := url.Parse("http://127.0.0.1:1")
+		require.NoError(t, err)
+
+		_, err = newProxyDialer(proxyURL).DialContext(ctx, "agglayer.example.com:443")
+		require.ErrorContains(t, err, "failed to connect to the proxy")
+	})
+}
+
+func TestNewClientWithProxy(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.URL = "http://agglayer.example.com:443"
+	cfg.ProxyURL = "http://proxy.example.com:3128"
+
+	client, err := NewClient(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "passthrough:///agglayer.example.com:443", client.Conn().Target())
+	require.NoError(t, client.Conn().Close())
+}
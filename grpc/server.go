@@ -2,16 +2,17 @@ package grpc
 
 import (
 	"context"
-	"fmt"
 	"net"
 
+	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 type ServerConfig struct {
-	// Host is the address to bind the gRPC server
+	// Host is the address to bind the gRPC server (a host name, an IPv4 or IPv6 literal,
+	// or a unix domain socket unix:///path/to/socket, in which case the Port is ignored)
 	Host string `mapstructure:"Host"`
 	// Port is the port to bind the gRPC server
 	Port int `mapstructure:"Port"`
@@ -35,10 +36,9 @@ type Server struct {
 // If enableReflection is true, the server will register the reflection service for introspection.
 // Returns a pointer to the Server and an error if the listener cannot be created.
 func NewServer(cfg ServerConfig, opts ...grpc.ServerOption) (*Server, error) {
-	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
-	serverAddr := trimGRPCAddress(addr)
+	serverAddr := aggkitcommon.ListenAddress(trimGRPCAddress(cfg.Host), cfg.Port)
 
-	listener, err := net.Listen("tcp", serverAddr)
+	listener, err := aggkitcommon.Listen(serverAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -12,7 +12,9 @@ type MethodConfig struct {
 	// List of service/method pairs this config applies to.
 	Name []MethodName `json:"name"`
 	// Optional retry policy to apply to the specified methods.
-	RetryPolicy *RetryPolicy `json:"retryPolicy"`
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// Optional deadline of the calls (e.g., "30s"), including the retries.
+	Timeout string `json:"timeout,omitempty"`
 }
 
 // MethodName identifies a gRPC method or service that the retry policy should apply to.
@@ -0,0 +1,57 @@
+package healthcheck
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ComponentCheck is the health of a component reported in the health response
+type ComponentCheck struct {
+	Component string `json:"component"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ComponentHealthChecker returns an error if the component is not healthy
+type ComponentHealthChecker func(ctx context.Context) error
+
+var (
+	componentCheckersMu sync.Mutex
+	componentCheckers   = make(map[string]ComponentHealthChecker)
+)
+
+// RegisterComponentHealth adds the health check of the component to the health response, replacing the
+// checker previously registered for it. A nil checker removes it
+func RegisterComponentHealth(component string, checker ComponentHealthChecker) {
+	componentCheckersMu.Lock()
+	defer componentCheckersMu.Unlock()
+	if checker == nil {
+		delete(componentCheckers, component)
+		return
+	}
+	componentCheckers[component] = checker
+}
+
+// componentChecks runs the health check of each component, sorted by component
+func componentChecks(ctx context.Context) []ComponentCheck {
+	componentCheckersMu.Lock()
+	checkers := make(map[string]ComponentHealthChecker, len(componentCheckers))
+	for component, checker := range componentCheckers {
+		checkers[component] = checker
+	}
+	componentCheckersMu.Unlock()
+
+	// the checks run without the lock, so a slow one doesn't block the registrations
+	result := make([]ComponentCheck, 0, len(checkers))
+	for component, checker := range checkers {
+		check := ComponentCheck{Component: component, Healthy: true}
+		if err := checker(ctx); err != nil {
+			check.Healthy = false
+			check.Error = err.Error()
+		}
+		result = append(result, check)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Component < result[j].Component })
+	return result
+}
@@ -0,0 +1,47 @@
+package healthcheck
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
+// Config is the configuration of the health endpoint
+type Config struct {
+	// ErrorBudget reports the error rates of the components in the health endpoint
+	ErrorBudget ErrorBudgetConfig `mapstructure:"ErrorBudget"`
+}
+
+// ErrorBudgetConfig tracks the error rate of each component (RPC failures, processor retries, prover
+// failures) over a sliding window. A component is degraded when its error rate reaches DegradedErrorRate,
+// and unhealthy when it reaches UnhealthyErrorRate
+type ErrorBudgetConfig struct {
+	// Enabled enables the error budget of the components in the health endpoint
+	Enabled bool `mapstructure:"Enabled"`
+	// Window is the sliding window of the error rates
+	Window types.Duration `mapstructure:"Window"`
+	// MinEvents is the minimum number of events of a component in the window to evaluate its error rate,
+	// the components with less events are healthy
+	MinEvents uint64 `mapstructure:"MinEvents"`
+	// DegradedErrorRate is the error rate (0-1) from which a component is degraded
+	DegradedErrorRate float64 `mapstructure:"DegradedErrorRate"`
+	// UnhealthyErrorRate is the error rate (0-1) from which a component is unhealthy, and the health
+	// endpoint answers with 503
+	UnhealthyErrorRate float64 `mapstructure:"UnhealthyErrorRate"`
+}
+
+// Validate checks that the window is set and the thresholds are valid error rates
+func (c ErrorBudgetConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Window.Duration <= 0 {
+		return errors.New("ErrorBudget.Window must be greater than 0")
+	}
+	if c.DegradedErrorRate <= 0 || c.DegradedErrorRate > c.UnhealthyErrorRate || c.UnhealthyErrorRate > 1 {
+		return fmt.Errorf("invalid ErrorBudget thresholds (DegradedErrorRate: %v, UnhealthyErrorRate: %v), "+
+			"they must be 0 < DegradedErrorRate <= UnhealthyErrorRate <= 1", c.DegradedErrorRate, c.UnhealthyErrorRate)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// windowBuckets is the number of buckets of the sliding window, the window moves forward one bucket at a time
+const windowBuckets = 10
+
+// Status is the health status of a component or of the whole aggkit
+type Status string
+
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// severity returns the order of the statuses, from the best to the worst
+func (s Status) severity() int {
+	switch s {
+	case StatusDegraded:
+		return 1
+	case StatusUnhealthy:
+		return 2 //nolint:mnd
+	default:
+		return 0
+	}
+}
+
+// ComponentErrorBudget is the error rate of a component over the window
+type ComponentErrorBudget struct {
+	Component string  `json:"component"`
+	Status    Status  `json:"status"`
+	Events    uint64  `json:"events"`
+	Failures  uint64  `json:"failures"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// ErrorBudgetReport is the error budget of all the components that reported events in the window
+type ErrorBudgetReport struct {
+	Status     Status                 `json:"status"`
+	Window     string                 `json:"window"`
+	Components []ComponentErrorBudget `json:"components"`
+}
+
+// bucket counts the events of a component in a slot of the window
+type bucket struct {
+	// slot is the index of the slot since the epoch (time / bucket width)
+	slot      int64
+	successes uint64
+	failures  uint64
+}
+
+// ErrorBudget tracks the successes and failures of each component over a sliding window, to report
+// the partial degradations before a total failure
+type ErrorBudget struct {
+	cfg         ErrorBudgetConfig
+	bucketWidth time.Duration
+	now         func() time.Time
+
+	mu         sync.Mutex
+	components map[string]*[windowBuckets]bucket
+}
+
+// NewErrorBudget creates the error budget of the components, it returns nil if it's disabled
+func NewErrorBudget(cfg ErrorBudgetConfig) (*ErrorBudget, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &ErrorBudget{
+		cfg:         cfg,
+		bucketWidth: max(cfg.Window.Duration/windowBuckets, time.Nanosecond),
+		now:         time.Now,
+		components:  make(map[string]*[windowBuckets]bucket),
+	}, nil
+}
+
+// Record counts an event of the component: a failure if err is not nil (the cancellations are ignored)
+func (b *ErrorBudget) Record(component string, err error) {
+	if b == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	slot := b.now().UnixNano() / int64(b.bucketWidth)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	buckets, ok := b.components[component]
+	if !ok {
+		buckets = &[windowBuckets]bucket{}
+		b.components[component] = buckets
+	}
+	current := &buckets[slot%windowBuckets]
+	if current.slot != slot {
+		*current = bucket{slot: slot}
+	}
+	if err != nil {
+		current.failures++
+	} else {
+		current.successes++
+	}
+}
+
+// Report returns the error rate and the status of each component, and the worst status of them
+func (b *ErrorBudget) Report() ErrorBudgetReport {
+	report := ErrorBudgetReport{
+		Status:     StatusHealthy,
+		Window:     b.cfg.Window.String(),
+		Components: []ComponentErrorBudget{},
+	}
+	firstSlot := b.now().UnixNano()/int64(b.bucketWidth) - windowBuckets + 1
+
+	b.mu.Lock()
+	for component, buckets := range b.components {
+		budget := ComponentErrorBudget{Component: component, Status: StatusHealthy}
+		for _, bucket := range buckets {
+			if bucket.slot >= firstSlot {
+				budget.Events += bucket.successes + bucket.failures
+				budget.Failures += bucket.failures
+			}
+		}
+		if budget.Events == 0 {
+			continue
+		}
+		budget.ErrorRate = float64(budget.Failures) / float64(budget.Events)
+		budget.Status = b.status(budget)
+		if budget.Status.severity() > report.Status.severity() {
+			report.Status = budget.Status
+		}
+		report.Components = append(report.Components, budget)
+	}
+	b.mu.Unlock()
+
+	sort.Slice(report.Components, func(i, j int) bool {
+		return report.Components[i].Component < report.Components[j].Component
+	})
+	return report
+}
+
+// status returns the status of the component for its error rate
+func (b *ErrorBudget) status(budget ComponentErrorBudget) Status {
+	switch {
+	case budget.Events < b.cfg.MinEvents:
+		return StatusHealthy
+	case budget.ErrorRate >= b.cfg.UnhealthyErrorRate:
+		return StatusUnhealthy
+	case budget.ErrorRate >= b.cfg.DegradedErrorRate:
+		return StatusDegraded
+	default:
+		return StatusHealthy
+	}
+}
+
+// defaultErrorBudget is the error budget where the components report their events, nil until it's set
+var defaultErrorBudget atomic.Pointer[ErrorBudget]
+
+// SetDefaultErrorBudget sets the error budget where the components report their events
+func SetDefaultErrorBudget(b *ErrorBudget) {
+	defaultErrorBudget.Store(b)
+}
+
+// RecordResult counts an event of the component in the default error budget: a failure if err is not nil.
+// It does nothing if there is no default error budget
+func RecordResult(component string, err error) {
+	defaultErrorBudget.Load().Record(component, err)
+}
@@ -0,0 +1,122 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestErrorBudget(t *testing.T, now *time.Time) *ErrorBudget {
+	t.Helper()
+
+	b, err := NewErrorBudget(ErrorBudgetConfig{
+		Enabled:            true,
+		Window:             types.NewDuration(10 * time.Minute),
+		MinEvents:          4,
+		DegradedErrorRate:  0.25,
+		UnhealthyErrorRate: 0.5,
+	})
+	require.NoError(t, err)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestErrorBudgetConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ErrorBudgetConfig
+		expectedErr string
+	}{
+		{name: "disabled", cfg: ErrorBudgetConfig{}},
+		{name: "valid", cfg: ErrorBudgetConfig{Enabled: true, Window: types.NewDuration(time.Minute),
+			DegradedErrorRate: 0.1, UnhealthyErrorRate: 0.5}},
+		{name: "no window", cfg: ErrorBudgetConfig{Enabled: true, DegradedErrorRate: 0.1, UnhealthyErrorRate: 0.5},
+			expectedErr: "Window"},
+		{name: "degraded over unhealthy", cfg: ErrorBudgetConfig{Enabled: true, Window: types.NewDuration(time.Minute),
+			DegradedErrorRate: 0.6, UnhealthyErrorRate: 0.5}, expectedErr: "invalid ErrorBudget thresholds"},
+		{name: "unhealthy over 1", cfg: ErrorBudgetConfig{Enabled: true, Window: types.NewDuration(time.Minute),
+			DegradedErrorRate: 0.1, UnhealthyErrorRate: 1.5}, expectedErr: "invalid ErrorBudget thresholds"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestErrorBudget(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	b := newTestErrorBudget(t, &now)
+	errFoo := errors.New("foo")
+
+	// rpc_l1: 1 failure out of 4 events, degraded
+	b.Record("rpc_l1", nil)
+	b.Record("rpc_l1", nil)
+	b.Record("rpc_l1", nil)
+	b.Record("rpc_l1", errFoo)
+	// aggchain_prover: too few events to be evaluated
+	b.Record("aggchain_prover", errFoo)
+	// the cancellations are ignored
+	b.Record("processor_l1InfoTreeSyncer", context.Canceled)
+
+	report := b.Report()
+	require.Equal(t, StatusDegraded, report.Status)
+	require.Equal(t, "10m0s", report.Window)
+	require.Equal(t, []ComponentErrorBudget{
+		{Component: "aggchain_prover", Status: StatusHealthy, Events: 1, Failures: 1, ErrorRate: 1},
+		{Component: "rpc_l1", Status: StatusDegraded, Events: 4, Failures: 1, ErrorRate: 0.25},
+	}, report.Components)
+
+	// the prover fails again later in the window, it's unhealthy
+	now = now.Add(5 * time.Minute)
+	b.Record("aggchain_prover", errFoo)
+	b.Record("aggchain_prover", errFoo)
+	b.Record("aggchain_prover", nil)
+	report = b.Report()
+	require.Equal(t, StatusUnhealthy, report.Status)
+	require.Equal(t, ComponentErrorBudget{Component: "aggchain_prover", Status: StatusUnhealthy,
+		Events: 4, Failures: 3, ErrorRate: 0.75}, report.Components[0])
+
+	// the first events leave the window
+	now = now.Add(6 * time.Minute)
+	report = b.Report()
+	require.Equal(t, StatusHealthy, report.Status)
+	require.Equal(t, []ComponentErrorBudget{
+		{Component: "aggchain_prover", Status: StatusHealthy, Events: 3, Failures: 2, ErrorRate: 2.0 / 3},
+	}, report.Components)
+
+	// all the events leave the window
+	now = now.Add(10 * time.Minute)
+	report = b.Report()
+	require.Equal(t, StatusHealthy, report.Status)
+	require.Empty(t, report.Components)
+}
+
+func TestRecordResult(t *testing.T) {
+	// it does nothing without a default error budget
+	RecordResult("rpc_l1", errors.New("foo"))
+
+	now := time.Unix(1_760_000_000, 0)
+	b := newTestErrorBudget(t, &now)
+	SetDefaultErrorBudget(b)
+	t.Cleanup(func() { SetDefaultErrorBudget(nil) })
+
+	RecordResult("rpc_l1", errors.New("foo"))
+	require.Equal(t, []ComponentErrorBudget{
+		{Component: "rpc_l1", Status: StatusHealthy, Events: 1, Failures: 1, ErrorRate: 1},
+	}, b.Report().Components)
+
+	disabled, err := NewErrorBudget(ErrorBudgetConfig{})
+	require.NoError(t, err)
+	require.Nil(t, disabled)
+}
@@ -1,7 +1,9 @@
 package healthcheck
 
 import (
+	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/agglayer/aggkit/log"
 )
@@ -9,22 +11,73 @@ import (
 // HealthCheckHandler encapsulates logic that serves the HTTP request for health checks
 type HealthCheckHandler struct {
 	logger *log.Logger
+	// errorBudget is nil if the error budget of the components is not reported
+	errorBudget *ErrorBudget
 }
 
 var _ http.Handler = (*HealthCheckHandler)(nil)
 
-// NewHealthCheckHandler creates a new healthcheck http handler
-func NewHealthCheckHandler(logger *log.Logger) *HealthCheckHandler {
-	return &HealthCheckHandler{logger: logger}
+// healthResponse is the health response with the error budget and the maintenance windows of the components,
+// the last stop of the syncers and the health checks of the components
+type healthResponse struct {
+	IsHealthy bool   `json:"is_healthy"`
+	Status    Status `json:"status"`
+	// ErrorBudget is nil if the error budget is not reported
+	ErrorBudget        *ErrorBudgetReport  `json:"error_budget,omitempty"`
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows,omitempty"`
+	SyncerStops        []SyncerStop        `json:"syncer_stops,omitempty"`
+	ComponentChecks    []ComponentCheck    `json:"component_checks,omitempty"`
+}
+
+// NewHealthCheckHandler creates a new healthcheck http handler. If errorBudget is not nil, the health
+// includes the error rates of the components, and it's unhealthy (503) if any of them is unhealthy.
+// The registered maintenance windows (see RegisterMaintenanceWindows) and the last stop of each syncer
+// (see RecordSyncerStop) are always included. The health checks of the components (see
+// RegisterComponentHealth) are also included, and it's unhealthy if any of them fails
+func NewHealthCheckHandler(logger *log.Logger, errorBudget *ErrorBudget) *HealthCheckHandler {
+	return &HealthCheckHandler{logger: logger, errorBudget: errorBudget}
 }
 
 // HealthHandler is a health check handler
 func (h *HealthCheckHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
+	windows := maintenanceWindows(time.Now())
+	stops := lastSyncerStops()
+	checks := componentChecks(r.Context())
+	if h.errorBudget == nil && len(windows) == 0 && len(stops) == 0 && len(checks) == 0 {
+		w.WriteHeader(http.StatusOK)
+		response := `{"is_healthy": true}`
+		if _, err := w.Write([]byte(response)); err != nil {
+			h.logger.Errorf("failed to write health indicator: %v", err)
+		}
+		return
+	}
 
-	response := `{"is_healthy": true}`
-	if _, err := w.Write([]byte(response)); err != nil {
+	response := healthResponse{
+		IsHealthy:          true,
+		Status:             StatusHealthy,
+		MaintenanceWindows: windows,
+		SyncerStops:        stops,
+		ComponentChecks:    checks,
+	}
+	if h.errorBudget != nil {
+		report := h.errorBudget.Report()
+		response.IsHealthy = report.Status != StatusUnhealthy
+		response.Status = report.Status
+		response.ErrorBudget = &report
+	}
+	for _, check := range checks {
+		if !check.Healthy {
+			response.IsHealthy = false
+			response.Status = StatusUnhealthy
+		}
+	}
+	status := http.StatusOK
+	if !response.IsHealthy {
+		status = http.StatusServiceUnavailable
+	}
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
 		h.logger.Errorf("failed to write health indicator: %v", err)
 	}
 }
@@ -1,16 +1,20 @@
 package healthcheck
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/agglayer/aggkit/log"
 	"github.com/stretchr/testify/require"
 )
 
 func TestHealthCheckHandler_ServeHTTP_Healthy(t *testing.T) {
-	handler := NewHealthCheckHandler(log.GetDefaultLogger())
+	handler := NewHealthCheckHandler(log.GetDefaultLogger(), nil)
 
 	// Create a request and record the response
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
@@ -23,3 +27,126 @@ func TestHealthCheckHandler_ServeHTTP_Healthy(t *testing.T) {
 	require.Equal(t, http.StatusOK, rr.Code)
 	require.Contains(t, rr.Body.String(), `{"is_healthy": true}`)
 }
+
+func TestHealthCheckHandler_ServeHTTP_ErrorBudget(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	budget := newTestErrorBudget(t, &now)
+	handler := NewHealthCheckHandler(log.GetDefaultLogger(), budget)
+
+	serve := func() (int, healthResponse) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+		require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		var response healthResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response
+	}
+
+	code, response := serve()
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, response.IsHealthy)
+	require.Equal(t, StatusHealthy, response.Status)
+
+	// degraded is still healthy
+	for _, err := range []error{nil, nil, nil, errors.New("foo")} {
+		budget.Record("rpc_l2", err)
+	}
+	code, response = serve()
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, response.IsHealthy)
+	require.Equal(t, StatusDegraded, response.Status)
+	require.Len(t, response.ErrorBudget.Components, 1)
+
+	for range 4 {
+		budget.Record("rpc_l2", errors.New("foo"))
+	}
+	code, response = serve()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, response.IsHealthy)
+	require.Equal(t, StatusUnhealthy, response.Status)
+	require.Equal(t, StatusUnhealthy, response.ErrorBudget.Components[0].Status)
+}
+
+func TestHealthCheckHandler_ServeHTTP_MaintenanceWindows(t *testing.T) {
+	start := time.Unix(1_760_000_000, 0).UTC()
+	RegisterMaintenanceWindows("aggsender", func(now time.Time) *MaintenanceWindow {
+		return &MaintenanceWindow{Schedule: "0 3 * * 2", Active: true, Start: start, End: start.Add(time.Hour)}
+	})
+	t.Cleanup(func() { RegisterMaintenanceWindows("aggsender", nil) })
+	handler := NewHealthCheckHandler(log.GetDefaultLogger(), nil)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+	// a maintenance window doesn't degrade the health
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response healthResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.True(t, response.IsHealthy)
+	require.Equal(t, StatusHealthy, response.Status)
+	require.Nil(t, response.ErrorBudget)
+	require.Equal(t, []MaintenanceWindow{{
+		Component: "aggsender", Schedule: "0 3 * * 2", Active: true, Start: start, End: start.Add(time.Hour),
+	}}, response.MaintenanceWindows)
+}
+
+func TestHealthCheckHandler_ServeHTTP_SyncerStops(t *testing.T) {
+	t.Cleanup(func() {
+		syncerStopsMu.Lock()
+		defer syncerStopsMu.Unlock()
+		clear(syncerStops)
+	})
+	RecordSyncerStop("L2BridgeSyncer", "reorg", "the sync restarts from the reorged block 5")
+	RecordSyncerStop("L1InfoTreeSync", "reorg", "the sync restarts from the reorged block 7")
+	RecordSyncerStop("L1InfoTreeSync", "inconsistent_state", "block 9")
+	handler := NewHealthCheckHandler(log.GetDefaultLogger(), nil)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var response healthResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.True(t, response.IsHealthy)
+	require.Len(t, response.SyncerStops, 2)
+	// the last stop of each syncer, sorted by syncer
+	require.Equal(t, "L1InfoTreeSync", response.SyncerStops[0].Syncer)
+	require.Equal(t, "inconsistent_state", response.SyncerStops[0].Reason)
+	require.Equal(t, "block 9", response.SyncerStops[0].Detail)
+	require.Equal(t, uint64(2), response.SyncerStops[0].Count)
+	require.False(t, response.SyncerStops[0].Time.IsZero())
+	require.Equal(t, "L2BridgeSyncer", response.SyncerStops[1].Syncer)
+	require.Equal(t, uint64(1), response.SyncerStops[1].Count)
+}
+
+func TestHealthCheckHandler_ServeHTTP_ComponentChecks(t *testing.T) {
+	var oracleErr error
+	RegisterComponentHealth("oracle", func(context.Context) error { return oracleErr })
+	RegisterComponentHealth("aggsender", func(context.Context) error { return nil })
+	t.Cleanup(func() {
+		RegisterComponentHealth("oracle", nil)
+		RegisterComponentHealth("aggsender", nil)
+	})
+	handler := NewHealthCheckHandler(log.GetDefaultLogger(), nil)
+
+	serve := func() (int, healthResponse) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var response healthResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		return rr.Code, response
+	}
+
+	code, response := serve()
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, response.IsHealthy)
+	require.Equal(t, []ComponentCheck{
+		{Component: "aggsender", Healthy: true},
+		{Component: "oracle", Healthy: true},
+	}, response.ComponentChecks)
+
+	oracleErr = errors.New("price feed unreachable")
+	code, response = serve()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, response.IsHealthy)
+	require.Equal(t, StatusUnhealthy, response.Status)
+	require.Equal(t, ComponentCheck{Component: "oracle", Error: "price feed unreachable"}, response.ComponentChecks[1])
+}
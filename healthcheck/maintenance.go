@@ -0,0 +1,53 @@
+package healthcheck
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaintenanceWindow is a scheduled maintenance window of a component, during which it pauses part of its
+// work on purpose. It's reported in the health response without degrading the status
+type MaintenanceWindow struct {
+	Component string    `json:"component"`
+	Schedule  string    `json:"schedule"`
+	Active    bool      `json:"active"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// MaintenanceWindowReporter returns the active maintenance window of a component, or the next one
+// (nil if there is none)
+type MaintenanceWindowReporter func(now time.Time) *MaintenanceWindow
+
+var (
+	maintenanceReportersMu sync.Mutex
+	maintenanceReporters   = make(map[string]MaintenanceWindowReporter)
+)
+
+// RegisterMaintenanceWindows adds the maintenance windows of the component to the health response,
+// replacing the reporter previously registered for it. A nil reporter removes it
+func RegisterMaintenanceWindows(component string, reporter MaintenanceWindowReporter) {
+	maintenanceReportersMu.Lock()
+	defer maintenanceReportersMu.Unlock()
+	if reporter == nil {
+		delete(maintenanceReporters, component)
+		return
+	}
+	maintenanceReporters[component] = reporter
+}
+
+// maintenanceWindows returns the current or next maintenance window of each component, sorted by component
+func maintenanceWindows(now time.Time) []MaintenanceWindow {
+	maintenanceReportersMu.Lock()
+	defer maintenanceReportersMu.Unlock()
+	result := make([]MaintenanceWindow, 0, len(maintenanceReporters))
+	for component, reporter := range maintenanceReporters {
+		if window := reporter(now); window != nil {
+			window.Component = component
+			result = append(result, *window)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Component < result[j].Component })
+	return result
+}
@@ -0,0 +1,47 @@
+package healthcheck
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SyncerStop is the last stop or restart of the sync of a syncer (e.g. after a reorg), and the number
+// of them since aggkit started
+type SyncerStop struct {
+	Syncer string    `json:"syncer"`
+	Reason string    `json:"reason"`
+	Detail string    `json:"detail,omitempty"`
+	Time   time.Time `json:"time"`
+	Count  uint64    `json:"count"`
+}
+
+var (
+	syncerStopsMu sync.Mutex
+	syncerStops   = make(map[string]SyncerStop)
+)
+
+// RecordSyncerStop reports a stop or restart of the sync of the syncer, with its reason, in the health response
+func RecordSyncerStop(syncer, reason, detail string) {
+	syncerStopsMu.Lock()
+	defer syncerStopsMu.Unlock()
+	syncerStops[syncer] = SyncerStop{
+		Syncer: syncer,
+		Reason: reason,
+		Detail: detail,
+		Time:   time.Now().UTC(),
+		Count:  syncerStops[syncer].Count + 1,
+	}
+}
+
+// lastSyncerStops returns the last stop of each syncer, sorted by syncer
+func lastSyncerStops() []SyncerStop {
+	syncerStopsMu.Lock()
+	defer syncerStopsMu.Unlock()
+	result := make([]SyncerStop, 0, len(syncerStops))
+	for _, stop := range syncerStops {
+		result = append(result, stop)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Syncer < result[j].Syncer })
+	return result
+}
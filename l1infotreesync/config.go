@@ -2,14 +2,22 @@ package l1infotreesync
 
 import (
 	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/sync"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type Config struct {
-	DBPath             string         `mapstructure:"DBPath"`
-	GlobalExitRootAddr common.Address `mapstructure:"GlobalExitRootAddr"`
-	RollupManagerAddr  common.Address `mapstructure:"RollupManagerAddr"`
-	SyncBlockChunkSize uint64         `mapstructure:"SyncBlockChunkSize"`
+	DBPath string `mapstructure:"DBPath"`
+	// StorageTuning tunes the sqlite DB (journal mode, cache size, storage quota...)
+	StorageTuning db.SQLiteConfig `mapstructure:"StorageTuning"`
+	// InMemoryMirror keeps a copy of the L1 info tree in memory for the hot reads
+	InMemoryMirror MirrorConfig `mapstructure:"InMemoryMirror"`
+	// Retention prunes the leaves older than N months, keeping the checkpoints and the leaves still required
+	Retention          RetentionConfig `mapstructure:"Retention"`
+	GlobalExitRootAddr common.Address  `mapstructure:"GlobalExitRootAddr"`
+	RollupManagerAddr  common.Address  `mapstructure:"RollupManagerAddr"`
+	SyncBlockChunkSize uint64          `mapstructure:"SyncBlockChunkSize"`
 	// BlockFinality indicates the status of the blocks that will be queried in order to sync
 	BlockFinality              string         `jsonschema:"enum=LatestBlock, enum=SafeBlock, enum=PendingBlock, enum=FinalizedBlock, enum=EarliestBlock" mapstructure:"BlockFinality"` //nolint:lll
 	URLRPCL1                   string         `mapstructure:"URLRPCL1"`
@@ -20,4 +28,6 @@ type Config struct {
 	// RequireStorageContentCompatibility is true it's mandatory that data stored in the database
 	// is compatible with the running environment
 	RequireStorageContentCompatibility bool `mapstructure:"RequireStorageContentCompatibility"`
+	// Verify runs the syncer in read-only verification mode, to validate the integrity of the DB
+	Verify sync.VerifierConfig `mapstructure:"Verify"`
 }
@@ -11,11 +11,13 @@ import (
 
 	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmglobalexitrootv2"
 	cfgtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	mocks_l1infotreesync "github.com/agglayer/aggkit/l1infotreesync/mocks"
 	"github.com/agglayer/aggkit/reorgdetector"
 	"github.com/agglayer/aggkit/test/contracts/verifybatchesmock"
 	"github.com/agglayer/aggkit/test/helpers"
+	"github.com/agglayer/aggkit/test/reorgsim"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -70,7 +72,7 @@ func TestE2E(t *testing.T) {
 	rdm.On("AddBlockToTrack", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	client, auth, gerAddr, verifyAddr, gerSc, _ := newSimulatedClient(t)
-	syncer, err := l1infotreesync.New(ctx, dbPath, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rdm, client.Client(), time.Millisecond, 0, 100*time.Millisecond, 25,
+	syncer, err := l1infotreesync.New(ctx, dbPath, db.SQLiteConfig{}, l1infotreesync.MirrorConfig{}, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rdm, client.Client(), time.Millisecond, 0, 100*time.Millisecond, 25,
 		l1infotreesync.FlagAllowWrongContractsAddrs, aggkittypes.SafeBlock, true)
 	require.NoError(t, err)
 
@@ -116,7 +118,7 @@ func TestWithReorgs(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, rd.Start(ctx))
 
-	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 25,
+	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, db.SQLiteConfig{}, l1infotreesync.MirrorConfig{}, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 25,
 		l1infotreesync.FlagAllowWrongContractsAddrs, aggkittypes.SafeBlock, true)
 	require.NoError(t, err)
 	go syncer.Start(ctx)
@@ -236,7 +238,7 @@ func TestStressAndReorgs(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, rd.Start(ctx))
 
-	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 100,
+	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, db.SQLiteConfig{}, l1infotreesync.MirrorConfig{}, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 100,
 		l1infotreesync.FlagAllowWrongContractsAddrs, aggkittypes.SafeBlock, true)
 	require.NoError(t, err)
 	go syncer.Start(ctx)
@@ -287,6 +289,185 @@ func TestStressAndReorgs(t *testing.T) {
 	require.Equal(t, common.Hash(expectedL1InfoRoot), lastRoot.Hash)
 }
 
+// TestWithReorgsScenario runs the reorgs of TestWithReorgs as a reorgsim scenario
+func TestWithReorgsScenario(t *testing.T) {
+	ctx := context.Background()
+	dbPathSyncer := path.Join(t.TempDir(), "l1infotreesyncTestWithReorgsScenario_sync.sqlite")
+	dbPathReorg := path.Join(t.TempDir(), "l1infotreesyncTestWithReorgsScenario_reorg.sqlite")
+
+	client, auth, gerAddr, verifyAddr, gerSc, verifySC := newSimulatedClient(t)
+
+	rd, err := reorgdetector.New(client.Client(), reorgdetector.Config{DBPath: dbPathReorg, CheckReorgsInterval: cfgtypes.NewDuration(time.Millisecond * 30)}, reorgdetector.L1)
+	require.NoError(t, err)
+	require.NoError(t, rd.Start(ctx))
+
+	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, db.SQLiteConfig{}, l1infotreesync.MirrorConfig{}, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 25,
+		l1infotreesync.FlagAllowWrongContractsAddrs, aggkittypes.SafeBlock, true)
+	require.NoError(t, err)
+	go syncer.Start(ctx)
+
+	updateL1InfoTreeAndRollupExitTree := func(i int, rollupID uint32) reorgsim.Step {
+		return reorgsim.Inject(func(t *testing.T) {
+			t.Helper()
+			// Update L1 Info Tree
+			_, err := gerSc.UpdateExitRoot(auth, common.HexToHash(strconv.Itoa(i)))
+			require.NoError(t, err)
+
+			// Update L1 Info Tree + Rollup Exit Tree
+			newLocalExitRoot := common.HexToHash(strconv.Itoa(i) + "ffff" + strconv.Itoa(1))
+			_, err = verifySC.VerifyBatchesTrustedAggregator(auth, rollupID, 0, newLocalExitRoot, common.Hash{}, true)
+			require.NoError(t, err)
+
+			// Update Rollup Exit Tree
+			newLocalExitRoot = common.HexToHash(strconv.Itoa(i) + "ffff" + strconv.Itoa(2))
+			_, err = verifySC.VerifyBatchesTrustedAggregator(auth, rollupID, 0, newLocalExitRoot, common.Hash{}, false)
+			require.NoError(t, err)
+		})
+	}
+
+	requireRollupExitRoot := reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+		t.Helper()
+		expectedRollupExitRoot, err := verifySC.GetRollupExitRoot(&bind.CallOpts{Pending: false})
+		require.NoError(t, err)
+		actualRollupExitRoot, err := syncer.GetLastRollupExitRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash(expectedRollupExitRoot), actualRollupExitRoot.Hash)
+	})
+
+	requireL1InfoTreeRoot := reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+		t.Helper()
+		expectedL1InfoRoot, err := gerSc.GetRoot(&bind.CallOpts{Pending: false})
+		require.NoError(t, err)
+		expectedGER, err := gerSc.GetLastGlobalExitRoot(&bind.CallOpts{Pending: false})
+		require.NoError(t, err)
+		actualL1InfoRoot, err := syncer.GetLastL1InfoTreeRoot(ctx)
+		require.NoError(t, err)
+		info, err := syncer.GetInfoByIndex(ctx, actualL1InfoRoot.Index)
+		require.NoError(t, err)
+
+		require.Equal(t, common.Hash(expectedL1InfoRoot), actualL1InfoRoot.Hash)
+		require.Equal(t, common.Hash(expectedGER), info.GlobalExitRoot, fmt.Sprintf("%+v", info))
+	})
+
+	sim := reorgsim.New(t, client, reorgsim.WithBlockTime(time.Millisecond*500))
+	sim.Run(
+		// Commit block 6
+		reorgsim.Commit(1),
+		reorgsim.Mark("block6"),
+		// Commit block 7
+		reorgsim.Commit(1),
+		// create some events and update the trees, and commit block 8 that contains them
+		updateL1InfoTreeAndRollupExitTree(1, 1),
+		reorgsim.Commit(1),
+		reorgsim.Wait(time.Second*5),
+		reorgsim.Synced(syncer),
+		requireRollupExitRoot,
+		requireL1InfoTreeRoot,
+
+		// Forking from block 6
+		// Note: reorged trx will be added to pending transactions
+		// and will be committed when the forked block is committed
+		reorgsim.ForkFrom("block6"),
+		reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+			t.Helper()
+			// the reorged transactions go back to the pool asynchronously
+			require.Eventually(t, func() bool {
+				pendingTx, err := client.Client().PendingTransactionCount(ctx)
+				require.NoError(t, err)
+				return pendingTx == 3
+			}, time.Second*5, time.Millisecond*100)
+		}),
+		// Commit blocks 7 to 11 after the fork
+		reorgsim.Commit(5),
+		reorgsim.Synced(syncer),
+		requireRollupExitRoot,
+
+		// Forking from block 6 again
+		reorgsim.ForkFrom("block6"),
+		reorgsim.Wait(time.Millisecond*500),
+		// Commit block 7
+		reorgsim.Commit(1),
+		// create some events and update the trees
+		updateL1InfoTreeAndRollupExitTree(2, 1),
+		reorgsim.Commit(1),
+		reorgsim.Synced(syncer),
+		requireRollupExitRoot,
+	)
+}
+
+// TestStressAndReorgsSchedule runs the reorgs of TestStressAndReorgs as a reorgsim fork schedule
+func TestStressAndReorgsSchedule(t *testing.T) {
+	const (
+		totalIterations       = 3
+		blocksInIteration     = 140
+		reorgEveryXIterations = 70
+		reorgSizeInBlocks     = 2
+		maxRollupID           = 31
+		extraBlocksToMine     = 10
+	)
+
+	ctx := context.Background()
+	dbPathSyncer := path.Join(t.TempDir(), "l1infotreesyncTestStressAndReorgsSchedule_sync.sqlite")
+	dbPathReorg := path.Join(t.TempDir(), "l1infotreesyncTestStressAndReorgsSchedule_reorg.sqlite")
+
+	client, auth, gerAddr, verifyAddr, gerSc, verifySC := newSimulatedClient(t)
+
+	rd, err := reorgdetector.New(client.Client(), reorgdetector.Config{DBPath: dbPathReorg, CheckReorgsInterval: cfgtypes.NewDuration(time.Millisecond * 100)}, reorgdetector.L1)
+	require.NoError(t, err)
+	require.NoError(t, rd.Start(ctx))
+
+	syncer, err := l1infotreesync.New(ctx, dbPathSyncer, db.SQLiteConfig{}, l1infotreesync.MirrorConfig{}, gerAddr, verifyAddr, 10, aggkittypes.LatestBlock, rd, client.Client(), time.Millisecond, 0, time.Second, 100,
+		l1infotreesync.FlagAllowWrongContractsAddrs, aggkittypes.SafeBlock, true)
+	require.NoError(t, err)
+	go syncer.Start(ctx)
+
+	updateL1InfoTreeAndRollupExitTree := func(i, j int, rollupID uint32) {
+		// Update L1 Info Tree
+		_, err := gerSc.UpdateExitRoot(auth, common.HexToHash(strconv.Itoa(i)))
+		require.NoError(t, err)
+
+		// Update L1 Info Tree + Rollup Exit Tree
+		newLocalExitRoot := common.HexToHash(strconv.Itoa(i) + "ffff" + strconv.Itoa(j))
+		_, err = verifySC.VerifyBatches(auth, rollupID, 0, newLocalExitRoot, common.Hash{}, true)
+		require.NoError(t, err)
+
+		// Update Rollup Exit Tree
+		newLocalExitRoot = common.HexToHash(strconv.Itoa(i) + "fffa" + strconv.Itoa(j))
+		_, err = verifySC.VerifyBatches(auth, rollupID, 0, newLocalExitRoot, common.Hash{}, false)
+		require.NoError(t, err)
+	}
+
+	sim := reorgsim.New(t, client, reorgsim.WithBlockTime(time.Millisecond*10))
+	for i := 1; i <= totalIterations; i++ {
+		sim.RunSchedule(reorgsim.Schedule{
+			Blocks:    blocksInIteration,
+			ForkEvery: reorgEveryXIterations,
+			ForkDepth: reorgSizeInBlocks,
+		}, func(t *testing.T, j int) {
+			t.Helper()
+			updateL1InfoTreeAndRollupExitTree(i, j, uint32(j%maxRollupID)+1)
+		})
+	}
+
+	sim.Commit(extraBlocksToMine + 1)
+
+	sim.RequireSynced(syncer)
+
+	// Assert L1 Info tree root
+	expectedL1InfoRoot, err := gerSc.GetRoot(&bind.CallOpts{Pending: false})
+	require.NoError(t, err)
+	expectedGER, err := gerSc.GetLastGlobalExitRoot(&bind.CallOpts{Pending: false})
+	require.NoError(t, err)
+	lastRoot, err := syncer.GetLastL1InfoTreeRoot(ctx)
+	require.NoError(t, err)
+	info, err := syncer.GetInfoByIndex(ctx, lastRoot.Index)
+	require.NoError(t, err, fmt.Sprintf("index: %d", lastRoot.Index))
+
+	t.Logf("expectedL1InfoRoot: %s", common.Hash(expectedL1InfoRoot).String())
+	require.Equal(t, common.Hash(expectedGER), info.GlobalExitRoot, fmt.Sprintf("%+v", info))
+	require.Equal(t, common.Hash(expectedL1InfoRoot), lastRoot.Hash)
+}
+
 func waitForSyncerToCatchUp(ctx context.Context, t *testing.T, syncer *l1infotreesync.L1InfoTreeSync, client *simulated.Backend) {
 	t.Helper()
 	for {
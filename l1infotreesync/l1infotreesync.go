@@ -9,6 +9,7 @@ import (
 
 	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/db/compatibility"
+	"github.com/agglayer/aggkit/networks"
 	"github.com/agglayer/aggkit/sync"
 	"github.com/agglayer/aggkit/tree"
 	"github.com/agglayer/aggkit/tree/types"
@@ -42,6 +43,8 @@ type L1InfoTreeSync struct {
 func New(
 	ctx context.Context,
 	dbPath string,
+	storageTuning db.SQLiteConfig,
+	mirrorCfg MirrorConfig,
 	globalExitRoot, rollupManager common.Address,
 	syncBlockChunkSize uint64,
 	blockFinalityType aggkittypes.BlockNumberFinality,
@@ -55,10 +58,15 @@ func New(
 	finalizedBlockType aggkittypes.BlockNumberFinality,
 	requireStorageContentCompatibility bool,
 ) (*L1InfoTreeSync, error) {
-	processor, err := newProcessor(dbPath)
+	processor, err := newProcessor(dbPath, storageTuning)
 	if err != nil {
 		return nil, err
 	}
+	if mirrorCfg.Enabled {
+		if err := processor.enableMirror(mirrorCfg); err != nil {
+			return nil, err
+		}
+	}
 	// TODO: get the initialBlock from L1 to simplify config
 	lastProcessedBlock, err := processor.GetLastProcessedBlock(ctx)
 	if err != nil {
@@ -117,8 +125,25 @@ func New(
 	}, nil
 }
 
+// NewReadOnly opens an existing L1 info tree DB to query it, without running the migrations nor syncing.
+// It's meant for the tools that need the synced L1 info tree while the node is stopped, Start must not be
+// called on it
+func NewReadOnly(dbPath string, storageTuning db.SQLiteConfig) (*L1InfoTreeSync, error) {
+	processor, err := newReadOnlyProcessor(dbPath, storageTuning)
+	if err != nil {
+		return nil, err
+	}
+	return &L1InfoTreeSync{processor: processor}, nil
+}
+
+// Close closes the DB of a read-only L1 info tree
+func (s *L1InfoTreeSync) Close() error {
+	return s.processor.db.Close()
+}
+
 // Start starts the synchronization process
 func (s *L1InfoTreeSync) Start(ctx context.Context) {
+	go s.processor.mirror.start(ctx)
 	s.driver.Sync(ctx)
 }
 
@@ -139,7 +164,7 @@ func (s *L1InfoTreeSync) GetRollupExitTreeMerkleProof(
 	if s.processor.isHalted() {
 		return types.Proof{}, sync.ErrInconsistentState
 	}
-	if networkID == 0 {
+	if networkID == networks.L1NetworkID {
 		return tree.EmptyProof, nil
 	}
 
@@ -174,12 +199,20 @@ func (s *L1InfoTreeSync) GetInfoByIndex(ctx context.Context, index uint32) (*L1I
 	return s.processor.GetInfoByIndex(ctx, index)
 }
 
+// GetInfoBeforeIndex returns the last leaf of the L1 info tree before the index, skipping the pruned ones
+func (s *L1InfoTreeSync) GetInfoBeforeIndex(ctx context.Context, index uint32) (*L1InfoTreeLeaf, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetInfoBeforeIndex(ctx, index)
+}
+
 // GetL1InfoTreeRootByIndex returns the root of the L1 info tree at the moment the leaf with the given index was added
 func (s *L1InfoTreeSync) GetL1InfoTreeRootByIndex(ctx context.Context, index uint32) (types.Root, error) {
 	if s.processor.isHalted() {
 		return types.Root{}, sync.ErrInconsistentState
 	}
-	return s.processor.l1InfoTree.GetRootByIndex(ctx, index)
+	return s.processor.GetL1InfoTreeRootByIndex(ctx, index)
 }
 
 // GetLastRollupExitRoot return the last rollup exit root processed
@@ -212,7 +245,7 @@ func (s *L1InfoTreeSync) GetLocalExitRoot(
 	if s.processor.isHalted() {
 		return common.Hash{}, sync.ErrInconsistentState
 	}
-	if networkID == 0 {
+	if networkID == networks.L1NetworkID {
 		return common.Hash{}, errors.New("network 0 is not a rollup, and it's not part of the rollup exit tree")
 	}
 
@@ -268,6 +301,16 @@ func (s *L1InfoTreeSync) GetFirstInfoAfterBlock(blockNum uint64) (*L1InfoTreeLea
 	return s.processor.GetFirstInfoAfterBlock(blockNum)
 }
 
+// GetGERsInsertedInBlockRange returns the L1 Info tree leafs (GERs) inserted in the L1 blocks
+// [fromBlock, toBlock], ordered by index
+func (s *L1InfoTreeSync) GetGERsInsertedInBlockRange(
+	ctx context.Context, fromBlock, toBlock uint64) ([]*L1InfoTreeLeaf, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetGERsInsertedInBlockRange(ctx, fromBlock, toBlock)
+}
+
 func (s *L1InfoTreeSync) GetInfoByGlobalExitRoot(ger common.Hash) (*L1InfoTreeLeaf, error) {
 	if s.processor.isHalted() {
 		return nil, sync.ErrInconsistentState
@@ -282,7 +325,18 @@ func (s *L1InfoTreeSync) GetL1InfoTreeMerkleProofFromIndexToRoot(
 	if s.processor.isHalted() {
 		return types.Proof{}, sync.ErrInconsistentState
 	}
-	return s.processor.l1InfoTree.GetProof(ctx, index, root)
+	return s.processor.GetL1InfoTreeMerkleProofFromIndexToRoot(ctx, index, root)
+}
+
+// GetL1InfoTreeMerkleProofsFromIndexesToRoot creates the merkle proofs of a batch of leaves of the L1 Info tree
+// to the same root, in the order of the indexes. It's faster than a call per index for many leaves
+func (s *L1InfoTreeSync) GetL1InfoTreeMerkleProofsFromIndexesToRoot(
+	ctx context.Context, indexes []uint32, root common.Hash,
+) ([]types.Proof, error) {
+	if s.processor.isHalted() {
+		return nil, sync.ErrInconsistentState
+	}
+	return s.processor.GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, indexes, root)
 }
 
 // GetInitL1InfoRootMap returns the initial L1 info root map, nil if no root map has been set
@@ -196,3 +196,14 @@ func TestGetL1InfoTreeMerkleProofFromIndexToRoot(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, errors.Is(err, sync.ErrInconsistentState))
 }
+
+func TestGetL1InfoTreeMerkleProofsFromIndexesToRoot(t *testing.T) {
+	s := L1InfoTreeSync{
+		processor: &processor{
+			halted: true,
+		},
+	}
+	_, err := s.GetL1InfoTreeMerkleProofsFromIndexesToRoot(context.Background(), []uint32{0, 1}, common.Hash{})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, sync.ErrInconsistentState))
+}
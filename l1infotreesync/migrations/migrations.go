@@ -22,7 +22,8 @@ var mig002 string
 //go:embed l1infotreesync0003.sql
 var mig003 string
 
-func RunMigrations(dbPath string) error {
+// GetMigrations returns the migrations of the l1infotreesync DB
+func GetMigrations() []types.Migration {
 	migrations := []types.Migration{
 		{
 			ID:  "l1infotreesync0001",
@@ -49,5 +50,9 @@ func RunMigrations(dbPath string) error {
 			Prefix: L1InfoTreePrefix,
 		})
 	}
-	return db.RunMigrations(dbPath, migrations)
+	return migrations
+}
+
+func RunMigrations(dbPath string) error {
+	return db.RunMigrations(dbPath, GetMigrations())
 }
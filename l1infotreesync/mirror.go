@@ -0,0 +1,320 @@
+package l1infotreesync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	mutex "sync"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/tree"
+	treeTypes "github.com/agglayer/aggkit/tree/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/russross/meddler"
+)
+
+const (
+	bytesPerMiB = 1024 * 1024
+	// mirrorBytesPerLeaf is the estimated memory used by the mirror for each leaf: the leaf, its root
+	// (and the entry of the roots by hash) and the nodes of the tree
+	mirrorBytesPerLeaf = 400
+)
+
+var errMirrorMiss = errors.New("l1infotreesync: not found in the in-memory mirror")
+
+// MirrorConfig is the configuration of the in-memory mirror of the L1 info tree
+type MirrorConfig struct {
+	// Enabled keeps the leaves and roots of the L1 info tree in memory, so GetInfoByIndex and the
+	// merkle proofs are served without querying the DB
+	Enabled bool `mapstructure:"Enabled"`
+	// MaxMemoryMiB is the memory cap of the mirror. If the tree grows over it, the mirror is
+	// dropped and the queries go to the DB. 0 means no cap
+	MaxMemoryMiB uint64 `mapstructure:"MaxMemoryMiB"`
+	// CheckpointInterval is the interval at which the mirror is checked against the DB (last root).
+	// If they differ, the mirror is rebuilt from the DB. 0 disables the check
+	CheckpointInterval types.Duration `mapstructure:"CheckpointInterval"`
+}
+
+// maxLeaves returns the max number of leaves that fit in the memory cap (0 means no cap)
+func (c MirrorConfig) maxLeaves() uint64 {
+	return c.MaxMemoryMiB * bytesPerMiB / mirrorBytesPerLeaf
+}
+
+// l1InfoTreeMirror is an in-memory copy of the leaves and roots of the L1 info tree. It's updated by
+// the processor while committing the changes to the DB (holding the lock), so the reads never see
+// a state different from the DB. The reads that the mirror can't answer fall back to the DB
+type l1InfoTreeMirror struct {
+	db         *sql.DB
+	l1InfoTree *tree.AppendOnlyTree
+	cfg        MirrorConfig
+	log        *log.Logger
+	zeroHashes []common.Hash
+
+	mu     mutex.RWMutex
+	active bool
+	leaves []L1InfoTreeLeaf
+	// nodes[h][k] is the hash of the complete subtree of height h that contains the leaves
+	// [k*2^h, (k+1)*2^h). nodes[0] are the hashes of the leaves
+	nodes       [treeTypes.DefaultHeight][]common.Hash
+	roots       []treeTypes.Root
+	rootsByHash map[common.Hash]uint32
+}
+
+func newL1InfoTreeMirror(database *sql.DB, l1InfoTree *tree.AppendOnlyTree,
+	cfg MirrorConfig, logger *log.Logger) (*l1InfoTreeMirror, error) {
+	zeroHashes := make([]common.Hash, treeTypes.DefaultHeight+1)
+	for h := 1; h <= int(treeTypes.DefaultHeight); h++ {
+		zeroHashes[h] = crypto.Keccak256Hash(zeroHashes[h-1][:], zeroHashes[h-1][:])
+	}
+	m := &l1InfoTreeMirror{
+		db:         database,
+		l1InfoTree: l1InfoTree,
+		cfg:        cfg,
+		log:        logger,
+		zeroHashes: zeroHashes,
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.loadLocked(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// loadLocked rebuilds the mirror from the leaves stored in the DB and checks that the last root
+// matches the one of the DB
+func (m *l1InfoTreeMirror) loadLocked() error {
+	m.reset()
+	var leaves []*L1InfoTreeLeaf
+	if err := meddler.QueryAll(m.db, &leaves, `SELECT * FROM l1info_leaf ORDER BY position ASC;`); err != nil {
+		return fmt.Errorf("failed to load the L1 info tree leaves: %w", err)
+	}
+	if maxLeaves := m.cfg.maxLeaves(); maxLeaves > 0 && uint64(len(leaves)) > maxLeaves {
+		m.log.Warnf("the L1 info tree (%d leaves) doesn't fit in the in-memory mirror (MaxMemoryMiB: %d), "+
+			"it's disabled", len(leaves), m.cfg.MaxMemoryMiB)
+		return nil
+	}
+	m.active = true
+	if !m.addLeavesLocked(leaves) {
+		m.reset()
+		return fmt.Errorf("the L1 info tree leaves stored in the DB are not consecutive")
+	}
+	if err := m.checkLocked(); err != nil {
+		m.reset()
+		return err
+	}
+	m.log.Infof("in-memory mirror of the L1 info tree loaded with %d leaves", len(m.leaves))
+	return nil
+}
+
+func (m *l1InfoTreeMirror) reset() {
+	m.active = false
+	m.leaves = nil
+	m.nodes = [treeTypes.DefaultHeight][]common.Hash{}
+	m.roots = nil
+	m.rootsByHash = make(map[common.Hash]uint32)
+}
+
+// checkLocked returns an error if the last root of the mirror doesn't match the one of the DB
+func (m *l1InfoTreeMirror) checkLocked() error {
+	if !m.active {
+		return nil
+	}
+	dbRoot, err := m.l1InfoTree.GetLastRoot(m.db)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return fmt.Errorf("failed to get the last L1 info tree root: %w", err)
+	}
+	var mirrorRoot treeTypes.Root
+	if len(m.roots) > 0 {
+		mirrorRoot = m.roots[len(m.roots)-1]
+	}
+	if mirrorRoot.Hash != dbRoot.Hash || mirrorRoot.Index != dbRoot.Index {
+		return fmt.Errorf("the last root of the L1 info tree in memory (%s, index %d) doesn't match the DB (%s, index %d)",
+			mirrorRoot.Hash.Hex(), mirrorRoot.Index, dbRoot.Hash.Hex(), dbRoot.Index)
+	}
+	return nil
+}
+
+// start checks the mirror against the DB every CheckpointInterval until the context is done
+func (m *l1InfoTreeMirror) start(ctx context.Context) {
+	if m == nil || m.cfg.CheckpointInterval.Duration == 0 {
+		return
+	}
+	ticker := time.NewTicker(m.cfg.CheckpointInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkpoint()
+		}
+	}
+}
+
+// checkpoint checks the mirror against the DB and rebuilds it if they differ
+func (m *l1InfoTreeMirror) checkpoint() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.checkLocked()
+	if err == nil {
+		return
+	}
+	m.log.Warnf("in-memory mirror of the L1 info tree out of sync, rebuilding it: %v", err)
+	if err := m.loadLocked(); err != nil {
+		m.log.Errorf("failed to rebuild the in-memory mirror of the L1 info tree, it's disabled: %v", err)
+	}
+}
+
+// commit runs commitFn (the commit of the DB transaction that added the leaves) and adds the
+// leaves to the mirror if it succeeds. The reads wait meanwhile, so they see both changes or none
+func (m *l1InfoTreeMirror) commit(commitFn func() error, leaves []*L1InfoTreeLeaf) error {
+	if m == nil {
+		return commitFn()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := commitFn(); err != nil {
+		return err
+	}
+	if !m.active || len(leaves) == 0 {
+		return nil
+	}
+	if maxLeaves := m.cfg.maxLeaves(); maxLeaves > 0 && uint64(len(m.leaves)+len(leaves)) > maxLeaves {
+		m.log.Warnf("the L1 info tree (%d leaves) exceeds the memory cap of the in-memory mirror "+
+			"(MaxMemoryMiB: %d), it's disabled", len(m.leaves)+len(leaves), m.cfg.MaxMemoryMiB)
+		m.reset()
+		return nil
+	}
+	if !m.addLeavesLocked(leaves) {
+		m.log.Warnf("unexpected L1 info tree leaf index %d (mirror has %d leaves), rebuilding the in-memory mirror",
+			leaves[0].L1InfoTreeIndex, len(m.leaves))
+		if err := m.loadLocked(); err != nil {
+			m.log.Errorf("failed to rebuild the in-memory mirror of the L1 info tree, it's disabled: %v", err)
+		}
+	}
+	return nil
+}
+
+// commitReorg runs commitFn (the commit of the DB transaction of the reorg) and removes the
+// reorged leaves from the mirror if it succeeds
+func (m *l1InfoTreeMirror) commitReorg(commitFn func() error, firstReorgedBlock uint64) error {
+	if m == nil {
+		return commitFn()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := commitFn(); err != nil {
+		return err
+	}
+	if !m.active {
+		return nil
+	}
+	count := len(m.leaves)
+	for count > 0 && m.leaves[count-1].BlockNumber >= firstReorgedBlock {
+		count--
+	}
+	for _, root := range m.roots[count:] {
+		delete(m.rootsByHash, root.Hash)
+	}
+	m.leaves = m.leaves[:count]
+	m.roots = m.roots[:count]
+	for h := range m.nodes {
+		m.nodes[h] = m.nodes[h][:min(len(m.nodes[h]), count>>h)]
+	}
+	return nil
+}
+
+// addLeavesLocked appends the leaves to the mirror. It returns false if they are not the next ones
+func (m *l1InfoTreeMirror) addLeavesLocked(leaves []*L1InfoTreeLeaf) bool {
+	for _, leaf := range leaves {
+		index := uint32(len(m.leaves))
+		if leaf.L1InfoTreeIndex != index {
+			return false
+		}
+		m.leaves = append(m.leaves, *leaf)
+		m.nodes[0] = append(m.nodes[0], leaf.Hash)
+		// the subtrees completed by the new leaf
+		for h := 1; h < int(treeTypes.DefaultHeight) && (index+1)%(1<<h) == 0; h++ {
+			k := len(m.nodes[h])
+			m.nodes[h] = append(m.nodes[h], crypto.Keccak256Hash(m.nodes[h-1][2*k][:], m.nodes[h-1][2*k+1][:]))
+		}
+		root := treeTypes.Root{
+			Hash:          m.subtreeHash(treeTypes.DefaultHeight, 0, index+1),
+			Index:         index,
+			BlockNum:      leaf.BlockNumber,
+			BlockPosition: leaf.BlockPosition,
+		}
+		m.roots = append(m.roots, root)
+		m.rootsByHash[root.Hash] = index
+	}
+	return true
+}
+
+// subtreeHash returns the hash of the subtree of height h with index k in the tree with count leaves
+func (m *l1InfoTreeMirror) subtreeHash(h uint8, k uint64, count uint32) common.Hash {
+	first := k << h
+	switch {
+	case first >= uint64(count):
+		return m.zeroHashes[h]
+	case h < treeTypes.DefaultHeight && first+(1<<h) <= uint64(count):
+		return m.nodes[h][k]
+	default:
+		return crypto.Keccak256Hash(m.subtreeHash(h-1, 2*k, count).Bytes(), m.subtreeHash(h-1, 2*k+1, count).Bytes())
+	}
+}
+
+// getInfoByIndex returns the leaf with the given index, or errMirrorMiss if it's not in the mirror
+func (m *l1InfoTreeMirror) getInfoByIndex(index uint32) (*L1InfoTreeLeaf, error) {
+	if m == nil {
+		return nil, errMirrorMiss
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.active || int(index) >= len(m.leaves) {
+		return nil, errMirrorMiss
+	}
+	leaf := m.leaves[index]
+	return &leaf, nil
+}
+
+// getRootByIndex returns the root of the tree after adding the leaf with the given index, or
+// errMirrorMiss if it's not in the mirror
+func (m *l1InfoTreeMirror) getRootByIndex(index uint32) (treeTypes.Root, error) {
+	if m == nil {
+		return treeTypes.Root{}, errMirrorMiss
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.active || int(index) >= len(m.roots) {
+		return treeTypes.Root{}, errMirrorMiss
+	}
+	return m.roots[index], nil
+}
+
+// getProof returns the merkle proof of the leaf with the given index in the tree with the given root,
+// or errMirrorMiss if the root is not in the mirror or the leaf is not part of it
+func (m *l1InfoTreeMirror) getProof(index uint32, root common.Hash) (treeTypes.Proof, error) {
+	if m == nil {
+		return treeTypes.Proof{}, errMirrorMiss
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.active {
+		return treeTypes.Proof{}, errMirrorMiss
+	}
+	rootIndex, ok := m.rootsByHash[root]
+	if !ok || index > rootIndex {
+		return treeTypes.Proof{}, errMirrorMiss
+	}
+	var proof treeTypes.Proof
+	for h := uint8(0); h < treeTypes.DefaultHeight; h++ {
+		proof[h] = m.subtreeHash(h, uint64(index>>h)^1, rootIndex+1)
+	}
+	return proof, nil
+}
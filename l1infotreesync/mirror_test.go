@@ -0,0 +1,157 @@
+package l1infotreesync
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree"
+	treeTypes "github.com/agglayer/aggkit/tree/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// processMirrorTestBlocks processes numBlocks blocks (from fromBlock) with leavesPerBlock L1 info tree leaves each
+func processMirrorTestBlocks(t *testing.T, p *processor, fromBlock, numBlocks uint64, leavesPerBlock int) {
+	t.Helper()
+	for blockNum := fromBlock; blockNum < fromBlock+numBlocks; blockNum++ {
+		events := make([]interface{}, 0, leavesPerBlock)
+		for i := 0; i < leavesPerBlock; i++ {
+			events = append(events, Event{UpdateL1InfoTree: &UpdateL1InfoTree{
+				BlockPosition:   uint64(i),
+				MainnetExitRoot: common.BigToHash(new(big.Int).SetUint64(blockNum<<32 + uint64(i))),
+				RollupExitRoot:  common.HexToHash("5ca1e"),
+				ParentHash:      common.HexToHash("1010101"),
+				Timestamp:       blockNum,
+			}})
+		}
+		require.NoError(t, p.ProcessBlock(context.Background(), sync.Block{Num: blockNum, Events: events}))
+	}
+}
+
+// requireMirrorMatchesDB checks that the reads served by the mirror are the same as the DB ones
+func requireMirrorMatchesDB(t *testing.T, p *processor) {
+	t.Helper()
+	ctx := context.Background()
+	lastRoot, err := p.l1InfoTree.GetLastRoot(p.db)
+	require.NoError(t, err)
+	for index := uint32(0); index <= lastRoot.Index; index++ {
+		info, err := p.mirror.getInfoByIndex(index)
+		require.NoError(t, err)
+		dbInfo, err := p.getInfoByIndexWithTx(p.db, index)
+		require.NoError(t, err)
+		require.Equal(t, dbInfo, info)
+
+		root, err := p.mirror.getRootByIndex(index)
+		require.NoError(t, err)
+		dbRoot, err := p.l1InfoTree.GetRootByIndex(ctx, index)
+		require.NoError(t, err)
+		require.Equal(t, dbRoot, root)
+
+		for _, proofIndex := range []uint32{0, index / 2, index} {
+			proof, err := p.mirror.getProof(proofIndex, root.Hash)
+			require.NoError(t, err)
+			dbProof, err := p.l1InfoTree.GetProof(ctx, proofIndex, root.Hash)
+			require.NoError(t, err)
+			require.Equal(t, dbProof, proof, "index %d, root index %d", proofIndex, index)
+			leaf, err := p.mirror.getInfoByIndex(proofIndex)
+			require.NoError(t, err)
+			require.Equal(t, root.Hash, tree.CalculateRoot(leaf.Hash, proof, proofIndex))
+		}
+	}
+	_, err = p.mirror.getInfoByIndex(lastRoot.Index + 1)
+	require.ErrorIs(t, err, errMirrorMiss)
+}
+
+func TestL1InfoTreeMirror(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestL1InfoTreeMirror.sqlite")
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	// some leaves before enabling the mirror, so they are loaded from the DB
+	processMirrorTestBlocks(t, p, 1, 3, 2)
+	require.NoError(t, p.enableMirror(MirrorConfig{Enabled: true}))
+	processMirrorTestBlocks(t, p, 4, 7, 3)
+	requireMirrorMatchesDB(t, p)
+
+	// a proof of a leaf that is not part of the root is not served by the mirror
+	root, err := p.mirror.getRootByIndex(2)
+	require.NoError(t, err)
+	_, err = p.mirror.getProof(3, root.Hash)
+	require.ErrorIs(t, err, errMirrorMiss)
+	_, err = p.mirror.getProof(0, common.HexToHash("0x1234"))
+	require.ErrorIs(t, err, errMirrorMiss)
+
+	// the reorged leaves are removed from the mirror
+	require.NoError(t, p.Reorg(ctx, 6))
+	requireMirrorMatchesDB(t, p)
+	removedRoot := root
+	root, err = p.mirror.getRootByIndex(11)
+	require.NoError(t, err)
+	_, err = p.mirror.getRootByIndex(12)
+	require.ErrorIs(t, err, errMirrorMiss)
+	require.NotEqual(t, removedRoot, root)
+
+	// new leaves after the reorg
+	processMirrorTestBlocks(t, p, 6, 4, 1)
+	requireMirrorMatchesDB(t, p)
+
+	// the processor reads use the mirror
+	info, err := p.GetInfoByIndex(ctx, 3)
+	require.NoError(t, err)
+	mirrorInfo, err := p.mirror.getInfoByIndex(3)
+	require.NoError(t, err)
+	require.Equal(t, mirrorInfo, info)
+	proof, root, err := p.GetL1InfoTreeMerkleProof(ctx, 5)
+	require.NoError(t, err)
+	dbProof, err := p.l1InfoTree.GetProof(ctx, 5, root.Hash)
+	require.NoError(t, err)
+	require.Equal(t, dbProof, proof)
+}
+
+func TestL1InfoTreeMirrorMemoryCap(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestL1InfoTreeMirrorMemoryCap.sqlite")
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	cfg := MirrorConfig{Enabled: true, MaxMemoryMiB: 1}
+	require.NoError(t, p.enableMirror(cfg))
+
+	maxLeaves := int(cfg.maxLeaves())
+	processMirrorTestBlocks(t, p, 1, 1, maxLeaves)
+	require.True(t, p.mirror.active)
+	_, err = p.mirror.getInfoByIndex(uint32(maxLeaves - 1))
+	require.NoError(t, err)
+
+	// exceeding the cap, the mirror is dropped and the reads go to the DB
+	processMirrorTestBlocks(t, p, 2, 1, 1)
+	require.False(t, p.mirror.active)
+	_, err = p.mirror.getInfoByIndex(0)
+	require.ErrorIs(t, err, errMirrorMiss)
+	info, err := p.GetInfoByIndex(ctx, uint32(maxLeaves))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), info.BlockNumber)
+}
+
+func TestL1InfoTreeMirrorCheckpoint(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestL1InfoTreeMirrorCheckpoint.sqlite")
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	require.NoError(t, p.enableMirror(MirrorConfig{Enabled: true}))
+	processMirrorTestBlocks(t, p, 1, 2, 2)
+
+	// the mirror is out of sync with the DB (e.g. a leaf missed)
+	p.mirror.mu.Lock()
+	p.mirror.leaves = p.mirror.leaves[:3]
+	p.mirror.roots = p.mirror.roots[:3]
+	p.mirror.nodes = [treeTypes.DefaultHeight][]common.Hash{}
+	p.mirror.mu.Unlock()
+	require.Error(t, p.mirror.checkLocked())
+
+	p.mirror.checkpoint()
+	require.NoError(t, p.mirror.checkLocked())
+	requireMirrorMatchesDB(t, p)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"runtime"
 	mutex "sync"
 
 	aggkitcommon "github.com/agglayer/aggkit/common"
@@ -21,6 +22,7 @@ import (
 	"github.com/iden3/go-iden3-crypto/keccak256"
 	"github.com/russross/meddler"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -32,10 +34,12 @@ type processor struct {
 	db             *sql.DB
 	l1InfoTree     *tree.AppendOnlyTree
 	rollupExitTree *tree.UpdatableTree
-	mu             mutex.RWMutex
-	halted         bool
-	haltedReason   string
-	log            *log.Logger
+	// mirror is the optional in-memory copy of the L1 info tree (nil if disabled)
+	mirror       *l1InfoTreeMirror
+	mu           mutex.RWMutex
+	halted       bool
+	haltedReason string
+	log          *log.Logger
 	compatibility.CompatibilityDataStorager[sync.RuntimeData]
 }
 
@@ -143,12 +147,15 @@ func (l *L1InfoTreeLeaf) GetGlobalExitRoot() common.Hash {
 	return gerBytes
 }
 
-func newProcessor(dbPath string) (*processor, error) {
+func newProcessor(dbPath string, dbCfg db.SQLiteConfig) (*processor, error) {
+	if err := dbCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage tuning: %w", err)
+	}
 	err := migrations.RunMigrations(dbPath)
 	if err != nil {
 		return nil, err
 	}
-	database, err := db.NewSQLiteDB(dbPath)
+	database, err := db.NewSQLiteDBWithConfig(dbPath, dbCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -164,19 +171,73 @@ func newProcessor(dbPath string) (*processor, error) {
 	}, nil
 }
 
+// enableMirror loads the in-memory mirror of the L1 info tree, used by the reads from now on
+func (p *processor) enableMirror(cfg MirrorConfig) error {
+	mirror, err := newL1InfoTreeMirror(p.db, p.l1InfoTree, cfg, p.log)
+	if err != nil {
+		return fmt.Errorf("failed to load the in-memory mirror of the L1 info tree: %w", err)
+	}
+	p.mirror = mirror
+	return nil
+}
+
 // GetL1InfoTreeMerkleProof creates a merkle proof for the L1 Info tree
 func (p *processor) GetL1InfoTreeMerkleProof(
 	ctx context.Context, index uint32,
 ) (treeTypes.Proof, treeTypes.Root, error) {
-	root, err := p.l1InfoTree.GetRootByIndex(ctx, index)
+	root, err := p.GetL1InfoTreeRootByIndex(ctx, index)
 	if err != nil {
 		return treeTypes.Proof{}, treeTypes.Root{}, err
 	}
 
-	proof, err := p.l1InfoTree.GetProof(ctx, root.Index, root.Hash)
+	proof, err := p.GetL1InfoTreeMerkleProofFromIndexToRoot(ctx, root.Index, root.Hash)
 	return proof, root, err
 }
 
+// GetL1InfoTreeMerkleProofFromIndexToRoot creates a merkle proof of the leaf with the given index
+// in the L1 Info tree with the given root
+func (p *processor) GetL1InfoTreeMerkleProofFromIndexToRoot(
+	ctx context.Context, index uint32, root common.Hash,
+) (treeTypes.Proof, error) {
+	if proof, err := p.mirror.getProof(index, root); err == nil {
+		return proof, nil
+	}
+	return p.l1InfoTree.GetProof(ctx, index, root)
+}
+
+// GetL1InfoTreeMerkleProofsFromIndexesToRoot creates the merkle proofs of the leaves with the given indexes
+// in the L1 Info tree with the given root. The proofs are computed in parallel and returned in the order
+// of the indexes
+func (p *processor) GetL1InfoTreeMerkleProofsFromIndexesToRoot(
+	ctx context.Context, indexes []uint32, root common.Hash,
+) ([]treeTypes.Proof, error) {
+	proofs := make([]treeTypes.Proof, len(indexes))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(runtime.GOMAXPROCS(0))
+	for i, index := range indexes {
+		group.Go(func() error {
+			proof, err := p.GetL1InfoTreeMerkleProofFromIndexToRoot(groupCtx, index, root)
+			if err != nil {
+				return fmt.Errorf("error getting the merkle proof of index %d to root %s: %w", index, root.String(), err)
+			}
+			proofs[i] = proof
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return proofs, nil
+}
+
+// GetL1InfoTreeRootByIndex returns the root of the L1 info tree at the moment the leaf with the given index was added
+func (p *processor) GetL1InfoTreeRootByIndex(ctx context.Context, index uint32) (treeTypes.Root, error) {
+	if root, err := p.mirror.getRootByIndex(index); err == nil {
+		return root, nil
+	}
+	return p.l1InfoTree.GetRootByIndex(ctx, index)
+}
+
 // GetLatestInfoUntilBlock returns the most recent L1InfoTreeLeaf that occurred before or at blockNum.
 // If the blockNum has not been processed yet the error ErrBlockNotProcessed will be returned
 func (p *processor) GetLatestInfoUntilBlock(ctx context.Context, blockNum uint64) (*L1InfoTreeLeaf, error) {
@@ -216,11 +277,65 @@ func (p *processor) GetLatestInfoUntilBlock(ctx context.Context, blockNum uint64
 	return info, nil
 }
 
+// GetGERsInsertedInBlockRange returns the L1InfoTreeLeafs (GERs) inserted in the blocks [fromBlock, toBlock],
+// ordered by index. The query is resolved by the primary key (block_num, block_pos), so the whole set is
+// obtained at once instead of one leaf at a time.
+// If the toBlock has not been processed yet the error ErrBlockNotProcessed will be returned
+func (p *processor) GetGERsInsertedInBlockRange(
+	ctx context.Context, fromBlock, toBlock uint64) ([]*L1InfoTreeLeaf, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range: fromBlock %d is greater than toBlock %d", fromBlock, toBlock)
+	}
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			p.log.Warnf("error rolling back tx: %v", err)
+		}
+	}()
+
+	lpb, err := p.getLastProcessedBlockWithTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	if lpb < toBlock {
+		return nil, ErrBlockNotProcessed
+	}
+
+	var infos []*L1InfoTreeLeaf
+	err = meddler.QueryAll(
+		tx, &infos,
+		`SELECT * FROM l1info_leaf WHERE block_num >= $1 AND block_num <= $2 ORDER BY block_num ASC, block_pos ASC;`,
+		fromBlock, toBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
 // GetInfoByIndex returns the value of a leaf (not the hash) of the L1 info tree
 func (p *processor) GetInfoByIndex(ctx context.Context, index uint32) (*L1InfoTreeLeaf, error) {
+	if info, err := p.mirror.getInfoByIndex(index); err == nil {
+		return info, nil
+	}
 	return p.getInfoByIndexWithTx(p.db, index)
 }
 
+// GetInfoBeforeIndex returns the last leaf stored before the index, skipping the pruned ones
+func (p *processor) GetInfoBeforeIndex(ctx context.Context, index uint32) (*L1InfoTreeLeaf, error) {
+	info := &L1InfoTreeLeaf{}
+	err := meddler.QueryRow(p.db, info, `
+		SELECT * FROM l1info_leaf
+		WHERE position < $1
+		ORDER BY position DESC
+		LIMIT 1;
+	`, index)
+	return info, db.ReturnErrNotFound(err)
+}
+
 func (p *processor) getInfoByIndexWithTx(tx dbtypes.DBer, index uint32) (*L1InfoTreeLeaf, error) {
 	info := &L1InfoTreeLeaf{}
 	return info, meddler.QueryRow(
@@ -301,7 +416,7 @@ func (p *processor) Reorg(ctx context.Context, firstReorgedBlock uint64) error {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := p.mirror.commitReorg(tx.Commit, firstReorgedBlock); err != nil {
 		return err
 	}
 
@@ -342,6 +457,7 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 	var (
 		initialL1InfoIndex uint32
 		l1InfoLeavesAdded  uint32
+		leaves             []*L1InfoTreeLeaf
 	)
 	lastIndex, err := p.getLastIndex(tx)
 
@@ -384,6 +500,7 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 				return fmt.Errorf("AddLeaf(%s). err: %w", info.String(), err)
 			}
 			p.log.Infof("inserted L1InfoTreeLeaf %s", info.String())
+			leaves = append(leaves, info)
 			l1InfoLeavesAdded++
 		}
 		if event.UpdateL1InfoTreeV2 != nil {
@@ -435,7 +552,7 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
+	if err := p.mirror.commit(tx.Commit, leaves); err != nil {
 		return fmt.Errorf("err: %w", err)
 	}
 	shouldRollback = false
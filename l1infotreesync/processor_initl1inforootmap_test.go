@@ -5,6 +5,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/sync"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -12,7 +13,7 @@ import (
 
 func TestInitL1InfoRootMap(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestInitL1InfoRootMap.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.TODO()
 	event := InitL1InfoRootMap{
@@ -39,7 +40,7 @@ func TestInitL1InfoRootMap(t *testing.T) {
 
 func TestInitL1InfoRootMapDontAllow2Rows(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestInitL1InfoRootMapDontAllow2Rows.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.TODO()
 	block := sync.Block{
@@ -60,7 +61,7 @@ func TestInitL1InfoRootMapDontAllow2Rows(t *testing.T) {
 
 func TestGetInitL1InfoRootMap(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetInitL1InfoRootMap.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	info, err := sut.GetInitL1InfoRootMap(nil)
 	require.NoError(t, err, "should return no error if no row is present, because it returns data=nil")
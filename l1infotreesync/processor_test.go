@@ -2,6 +2,7 @@ package l1infotreesync
 
 import (
 	"database/sql"
+	"math/big"
 	"path"
 	"testing"
 
@@ -15,7 +16,7 @@ import (
 
 func TestGetInfo(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetInfo.sqlite")
-	p, err := newProcessor(dbPath)
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.Background()
 
@@ -114,7 +115,7 @@ func TestGetInfo(t *testing.T) {
 
 func TestGetLatestInfoUntilBlockIfNotFoundReturnsErrNotFound(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetLatestInfoUntilBlockIfNotFoundReturnsErrNotFound.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.Background()
 	// Fake block 1
@@ -138,7 +139,7 @@ func Test_processor_GetL1InfoTreeMerkleProof(t *testing.T) {
 			getProcessor: func(t *testing.T) *processor {
 				t.Helper()
 
-				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_GetL1InfoTreeMerkleProof_1.sqlite"))
+				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_GetL1InfoTreeMerkleProof_1.sqlite"), db.SQLiteConfig{})
 				require.NoError(t, err)
 
 				return p
@@ -151,7 +152,7 @@ func Test_processor_GetL1InfoTreeMerkleProof(t *testing.T) {
 			getProcessor: func(t *testing.T) *processor {
 				t.Helper()
 
-				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_GetL1InfoTreeMerkleProof_2.sqlite"))
+				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_GetL1InfoTreeMerkleProof_2.sqlite"), db.SQLiteConfig{})
 				require.NoError(t, err)
 
 				info := &UpdateL1InfoTree{
@@ -200,6 +201,29 @@ func Test_processor_GetL1InfoTreeMerkleProof(t *testing.T) {
 	}
 }
 
+func Test_processor_GetL1InfoTreeMerkleProofsFromIndexesToRoot(t *testing.T) {
+	ctx := context.Background()
+	p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_GetL1InfoTreeMerkleProofs.sqlite"), db.SQLiteConfig{})
+	require.NoError(t, err)
+	processMirrorTestBlocks(t, p, 1, 5, 4)
+	root, err := p.GetL1InfoTreeRootByIndex(ctx, 15)
+	require.NoError(t, err)
+
+	indexes := []uint32{15, 0, 7, 7, 3}
+	proofs, err := p.GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, indexes, root.Hash)
+	require.NoError(t, err)
+	require.Len(t, proofs, len(indexes))
+	for i, index := range indexes {
+		proof, err := p.GetL1InfoTreeMerkleProofFromIndexToRoot(ctx, index, root.Hash)
+		require.NoError(t, err)
+		require.Equal(t, proof, proofs[i], "index %d", index)
+	}
+
+	proofs, err = p.GetL1InfoTreeMerkleProofsFromIndexesToRoot(ctx, nil, root.Hash)
+	require.NoError(t, err)
+	require.Empty(t, proofs)
+}
+
 func Test_processor_Reorg(t *testing.T) {
 	t.Parallel()
 
@@ -214,7 +238,7 @@ func Test_processor_Reorg(t *testing.T) {
 			getProcessor: func(t *testing.T) *processor {
 				t.Helper()
 
-				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_Reorg_1.sqlite"))
+				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_Reorg_1.sqlite"), db.SQLiteConfig{})
 				require.NoError(t, err)
 				return p
 			},
@@ -226,7 +250,7 @@ func Test_processor_Reorg(t *testing.T) {
 			getProcessor: func(t *testing.T) *processor {
 				t.Helper()
 
-				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_Reorg_2.sqlite"))
+				p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTest_processor_Reorg_2.sqlite"), db.SQLiteConfig{})
 				require.NoError(t, err)
 
 				info := &UpdateL1InfoTree{
@@ -267,7 +291,7 @@ func Test_processor_Reorg(t *testing.T) {
 }
 
 func TestProcessBlockUpdateL1InfoTreeV2DontMatchTree(t *testing.T) {
-	sut, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTestProcessBlockUpdateL1InfoTreeV2DontMatchTree.sqlite"))
+	sut, err := newProcessor(path.Join(t.TempDir(), "l1infotreesyncTestProcessBlockUpdateL1InfoTreeV2DontMatchTree.sqlite"), db.SQLiteConfig{})
 	require.NoError(t, err)
 	block := sync.Block{
 		Num: 10,
@@ -291,7 +315,7 @@ func TestProcessBlockUpdateL1InfoTreeV2DontMatchTree(t *testing.T) {
 
 func TestGetProcessedBlockUntil(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetProcessedBlockUntil.sqlite")
-	p, err := newProcessor(dbPath)
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.Background()
 
@@ -344,3 +368,50 @@ func TestGetProcessedBlockUntil(t *testing.T) {
 	require.Equal(t, uint64(4), blockNum)
 	require.Equal(t, common.Hash{}, blockHash)
 }
+
+func TestGetGERsInsertedInBlockRange(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetGERsInsertedInBlockRange.sqlite")
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// blocks 1 and 3 insert 2 GERs each, block 2 has no GERs
+	for _, blockNum := range []uint64{1, 2, 3} {
+		block := sync.Block{Num: blockNum}
+		if blockNum != 2 {
+			for i := range 2 {
+				block.Events = append(block.Events, Event{UpdateL1InfoTree: &UpdateL1InfoTree{
+					BlockPosition:   uint64(i),
+					MainnetExitRoot: common.BigToHash(big.NewInt(int64(blockNum*10) + int64(i))),
+					RollupExitRoot:  common.HexToHash("5ca1e"),
+					ParentHash:      common.HexToHash("1010101"),
+					Timestamp:       420,
+				}})
+			}
+		}
+		require.NoError(t, p.ProcessBlock(ctx, block))
+	}
+
+	infos, err := p.GetGERsInsertedInBlockRange(ctx, 1, 3)
+	require.NoError(t, err)
+	require.Len(t, infos, 4)
+	for i, info := range infos {
+		require.Equal(t, uint32(i), info.L1InfoTreeIndex)
+		require.Equal(t, info.GetGlobalExitRoot(), info.GlobalExitRoot)
+	}
+
+	infos, err = p.GetGERsInsertedInBlockRange(ctx, 2, 3)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, uint64(3), infos[0].BlockNumber)
+
+	infos, err = p.GetGERsInsertedInBlockRange(ctx, 2, 2)
+	require.NoError(t, err)
+	require.Empty(t, infos)
+
+	_, err = p.GetGERsInsertedInBlockRange(ctx, 3, 4)
+	require.ErrorIs(t, err, ErrBlockNotProcessed)
+
+	_, err = p.GetGERsInsertedInBlockRange(ctx, 3, 1)
+	require.ErrorContains(t, err, "invalid block range")
+}
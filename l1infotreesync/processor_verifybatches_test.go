@@ -13,7 +13,7 @@ import (
 
 func TestProcessVerifyBatchesNil(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestProcessVerifyBatchesNil.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	err = sut.processVerifyBatches(nil, 1, nil)
 	require.Error(t, err)
@@ -21,7 +21,7 @@ func TestProcessVerifyBatchesNil(t *testing.T) {
 
 func TestProcessVerifyBatchesOK(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestProcessVerifyBatchesOK.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	event := VerifyBatches{
 		BlockPosition:  1,
@@ -43,7 +43,7 @@ func TestProcessVerifyBatchesOK(t *testing.T) {
 
 func TestProcessVerifyBatchesSkip0000(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestProcessVerifyBatchesSkip0000.sqlite")
-	sut, err := newProcessor(dbPath)
+	sut, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	event := VerifyBatches{
 		BlockPosition:  1,
@@ -63,7 +63,7 @@ func TestProcessVerifyBatchesSkip0000(t *testing.T) {
 
 func TestGetVerifiedBatches(t *testing.T) {
 	dbPath := path.Join(t.TempDir(), "l1infotreesyncTestGetVerifiedBatches.sqlite")
-	p, err := newProcessor(dbPath)
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
 	require.NoError(t, err)
 	ctx := context.Background()
 
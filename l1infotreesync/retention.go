@@ -0,0 +1,213 @@
+package l1infotreesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/sync"
+)
+
+// RetentionConfig is the configuration of the pruning of the old leaves of the L1 info tree
+type RetentionConfig struct {
+	// Months is the age, by the L1 timestamp, from which the leaves are pruned. 0 disables the pruning
+	Months uint32 `mapstructure:"Months"`
+	// CheckpointInterval keeps the leaves (and their roots) whose index is a multiple of it, so
+	// there are periodic checkpoints of the old roots of the tree
+	CheckpointInterval uint32 `mapstructure:"CheckpointInterval"`
+	// PruneInterval is the interval of the pruning
+	PruneInterval types.Duration `mapstructure:"PruneInterval"`
+	// MaxLeavesPerRun is the max number of leaves checked in each pruning, to bound its transaction
+	MaxLeavesPerRun uint32 `mapstructure:"MaxLeavesPerRun"`
+}
+
+// Enabled returns true if the old leaves are pruned
+func (c RetentionConfig) Enabled() bool {
+	return c.Months > 0
+}
+
+// Validate checks that the configuration is correct
+func (c RetentionConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.CheckpointInterval == 0 {
+		return errors.New("L1InfoTreeSync.Retention.CheckpointInterval must be greater than 0")
+	}
+	if c.PruneInterval.Duration <= 0 {
+		return errors.New("L1InfoTreeSync.Retention.PruneInterval must be greater than 0")
+	}
+	if c.MaxLeavesPerRun == 0 {
+		return errors.New("L1InfoTreeSync.Retention.MaxLeavesPerRun must be greater than 0")
+	}
+	return nil
+}
+
+func (c RetentionConfig) String() string {
+	return fmt.Sprintf("Months: %d, CheckpointInterval: %d, PruneInterval: %s, MaxLeavesPerRun: %d",
+		c.Months, c.CheckpointInterval, c.PruneInterval, c.MaxLeavesPerRun)
+}
+
+// RetentionGuard returns the leaves of the L1 info tree that must not be pruned, because they are
+// still needed by another component (e.g. the leaves of the GERs injected on L2, or the first leaf
+// that includes each deposit not claimed yet)
+type RetentionGuard interface {
+	RequiredL1InfoTreeIndexes(ctx context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error)
+}
+
+// pruner prunes periodically the old leaves of the L1 info tree
+type pruner struct {
+	processor *processor
+	cfg       RetentionConfig
+	guards    []RetentionGuard
+	// nextIndex is the first index checked in the next pruning, so the kept leaves are not checked again
+	nextIndex uint32
+	timeNowFn func() time.Time
+}
+
+// StartPruning prunes periodically the leaves older than the retention, until the context is done.
+// The leaves required by the guards, the checkpoints and the last leaf are kept. It requires at least
+// a guard, otherwise the leaves used by the claims of the unclaimed deposits would be pruned
+func (s *L1InfoTreeSync) StartPruning(ctx context.Context, cfg RetentionConfig, guards ...RetentionGuard) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if !cfg.Enabled() {
+		return nil
+	}
+	if len(guards) == 0 {
+		return errors.New("the L1 info tree can't be pruned without the leaves required by the claims: " +
+			"L1InfoTreeSync.Retention requires the bridge syncers or the LastGERSync")
+	}
+	if s.processor.mirror != nil {
+		return errors.New("the L1 info tree can't be pruned with the InMemoryMirror enabled (it loads all the leaves)")
+	}
+	p := &pruner{processor: s.processor, cfg: cfg, guards: guards, timeNowFn: time.Now}
+	go p.start(ctx)
+	return nil
+}
+
+func (p *pruner) start(ctx context.Context) {
+	p.processor.log.Infof("pruning the L1 info tree leaves older than the retention (%s)", p.cfg.String())
+	ticker := time.NewTicker(p.cfg.PruneInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := p.prune(ctx)
+			if err != nil {
+				p.processor.log.Errorf("error pruning the L1 info tree leaves: %v", err)
+				continue
+			}
+			if pruned > 0 {
+				p.processor.log.Infof("pruned %d leaves of the L1 info tree older than %d months",
+					pruned, p.cfg.Months)
+			}
+		}
+	}
+}
+
+// prune deletes the leaves (and their roots) older than the retention, except the checkpoints, the
+// last leaf and the leaves required by the guards. The nodes of the tree shared with the kept roots
+// are kept, so the proofs to them can still be generated. It returns the number of pruned leaves
+func (p *pruner) prune(ctx context.Context) (int, error) {
+	if p.processor.isHalted() {
+		return 0, sync.ErrInconsistentState
+	}
+	cutoff := p.timeNowFn().AddDate(0, -int(p.cfg.Months), 0).Unix()
+	var candidates []uint32
+	rows, err := p.processor.db.QueryContext(ctx, `
+		SELECT position FROM l1info_leaf
+		WHERE position >= $1 AND timestamp < $2 AND position % $3 != 0
+			AND position < (SELECT MAX(position) FROM l1info_leaf)
+		ORDER BY position ASC LIMIT $4;`,
+		p.nextIndex, cutoff, p.cfg.CheckpointInterval, p.cfg.MaxLeavesPerRun)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the leaves to prune: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var index uint32
+		if err := rows.Scan(&index); err != nil {
+			return 0, fmt.Errorf("error getting the leaves to prune: %w", err)
+		}
+		candidates = append(candidates, index)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error getting the leaves to prune: %w", err)
+	}
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	first, last := candidates[0], candidates[len(candidates)-1]
+	required := make(map[uint32]struct{})
+	for _, guard := range p.guards {
+		indexes, err := guard.RequiredL1InfoTreeIndexes(ctx, first, last)
+		if err != nil {
+			return 0, fmt.Errorf("error getting the required leaves %d-%d: %w", first, last, err)
+		}
+		for index := range indexes {
+			required[index] = struct{}{}
+		}
+	}
+	pruned := make([]uint32, 0, len(candidates))
+	for _, index := range candidates {
+		if _, ok := required[index]; !ok {
+			pruned = append(pruned, index)
+		}
+	}
+	if err := p.processor.pruneLeaves(ctx, pruned); err != nil {
+		return 0, err
+	}
+	p.nextIndex = last + 1
+	return len(pruned), nil
+}
+
+// pruneLeaves deletes the leaves with the given indexes, their roots and the nodes of the tree only
+// reachable from those roots
+func (p *processor) pruneLeaves(ctx context.Context, indexes []uint32) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+	tx, err := db.NewTx(ctx, p.db)
+	if err != nil {
+		return err
+	}
+	shouldRollback := true
+	defer func() {
+		if shouldRollback {
+			if errRllbck := tx.Rollback(); errRllbck != nil {
+				p.log.Errorf("error while rolling back tx %v", errRllbck)
+			}
+		}
+	}()
+
+	placeholders := make([]string, len(indexes))
+	args := make([]any, len(indexes))
+	for i, index := range indexes {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = index
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM l1info_leaf WHERE position IN (%s);`,
+		strings.Join(placeholders, ", ")), args...); err != nil {
+		return fmt.Errorf("error deleting the leaves %d-%d: %w", indexes[0], indexes[len(indexes)-1], err)
+	}
+	deletedNodes, err := p.l1InfoTree.PruneRoots(tx, indexes)
+	if err != nil {
+		return fmt.Errorf("error pruning the roots %d-%d: %w", indexes[0], indexes[len(indexes)-1], err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	shouldRollback = false
+	p.log.Debugf("pruned the leaves %d-%d of the L1 info tree (%d leaves, %d nodes)",
+		indexes[0], indexes[len(indexes)-1], len(indexes), deletedNodes)
+	return nil
+}
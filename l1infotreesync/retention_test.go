@@ -0,0 +1,180 @@
+package l1infotreesync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/tree"
+	"github.com/stretchr/testify/require"
+)
+
+type retentionGuardFn func(ctx context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error)
+
+func (f retentionGuardFn) RequiredL1InfoTreeIndexes(
+	ctx context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error) {
+	return f(ctx, fromIndex, toIndex)
+}
+
+func TestRetentionConfigValidate(t *testing.T) {
+	valid := RetentionConfig{
+		Months:             6,
+		CheckpointInterval: 1000,
+		PruneInterval:      types.NewDuration(time.Hour),
+		MaxLeavesPerRun:    10000,
+	}
+	tests := []struct {
+		name   string
+		modify func(c *RetentionConfig)
+		errMsg string
+	}{
+		{name: "valid", modify: func(c *RetentionConfig) {}},
+		{name: "disabled", modify: func(c *RetentionConfig) { *c = RetentionConfig{} }},
+		{
+			name:   "no checkpoints",
+			modify: func(c *RetentionConfig) { c.CheckpointInterval = 0 },
+			errMsg: "CheckpointInterval must be greater than 0",
+		},
+		{
+			name:   "no interval",
+			modify: func(c *RetentionConfig) { c.PruneInterval = types.NewDuration(0) },
+			errMsg: "PruneInterval must be greater than 0",
+		},
+		{
+			name:   "no batch",
+			modify: func(c *RetentionConfig) { c.MaxLeavesPerRun = 0 },
+			errMsg: "MaxLeavesPerRun must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestStartPruningWithMirror(t *testing.T) {
+	p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesync_TestStartPruningWithMirror.sqlite"), db.SQLiteConfig{})
+	require.NoError(t, err)
+	require.NoError(t, p.enableMirror(MirrorConfig{Enabled: true}))
+	s := &L1InfoTreeSync{processor: p}
+	cfg := RetentionConfig{
+		Months:             1,
+		CheckpointInterval: 10,
+		PruneInterval:      types.NewDuration(time.Hour),
+		MaxLeavesPerRun:    10,
+	}
+	require.ErrorContains(t, s.StartPruning(context.Background(), cfg), "without the leaves required by the claims")
+	guard := retentionGuardFn(func(context.Context, uint32, uint32) (map[uint32]struct{}, error) { return nil, nil })
+	require.ErrorContains(t, s.StartPruning(context.Background(), cfg, guard), "InMemoryMirror")
+	// disabled
+	require.NoError(t, s.StartPruning(context.Background(), RetentionConfig{}))
+}
+
+func TestPrunerPrune(t *testing.T) {
+	ctx := context.Background()
+	p, err := newProcessor(path.Join(t.TempDir(), "l1infotreesync_TestPrunerPrune.sqlite"), db.SQLiteConfig{})
+	require.NoError(t, err)
+	// 30 blocks with 2 leaves each: the leaves 2*(n-1) and 2*n-1 have the timestamp n
+	processMirrorTestBlocks(t, p, 1, 30, 2)
+	leaves := make([]*L1InfoTreeLeaf, 0, 60)
+	for index := uint32(0); index < 60; index++ {
+		leaf, err := p.GetInfoByIndex(ctx, index)
+		require.NoError(t, err)
+		leaves = append(leaves, leaf)
+	}
+
+	guardErr := errors.New("guard failed")
+	var failGuard bool
+	guard := retentionGuardFn(func(_ context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error) {
+		if failGuard {
+			return nil, guardErr
+		}
+		required := map[uint32]struct{}{}
+		for _, index := range []uint32{7, 33} {
+			if index >= fromIndex && index <= toIndex {
+				required[index] = struct{}{}
+			}
+		}
+		return required, nil
+	})
+	sut := &pruner{
+		processor: p,
+		cfg:       RetentionConfig{Months: 1, CheckpointInterval: 10, MaxLeavesPerRun: 20},
+		guards:    []RetentionGuard{guard},
+		// the leaves with a timestamp lower than 21 (the indexes 0-39) are older than the retention
+		timeNowFn: func() time.Time { return time.Unix(21, 0).AddDate(0, 1, 0) },
+	}
+
+	failGuard = true
+	_, err = sut.prune(ctx)
+	require.ErrorIs(t, err, guardErr)
+	failGuard = false
+
+	// the candidates are checked in batches of MaxLeavesPerRun
+	pruned, err := sut.prune(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 20-1, pruned) // 1-22 without the checkpoints 10 and 20, and the required 7
+	pruned, err = sut.prune(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 16-1, pruned) // 23-39 without the checkpoint 30, and the required 33
+	pruned, err = sut.prune(ctx)
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+
+	kept := map[uint32]bool{0: true, 7: true, 10: true, 20: true, 30: true, 33: true}
+	lastRoot, err := p.l1InfoTree.GetLastRoot(p.db)
+	require.NoError(t, err)
+	for index := uint32(0); index < 60; index++ {
+		if index < 40 && !kept[index] {
+			_, err := p.GetInfoByIndex(ctx, index)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+			_, err = p.GetL1InfoTreeRootByIndex(ctx, index)
+			require.ErrorIs(t, err, db.ErrNotFound)
+			continue
+		}
+		leaf, err := p.GetInfoByIndex(ctx, index)
+		require.NoError(t, err)
+		require.Equal(t, leaves[index], leaf)
+		// the proofs of all the leaves to the kept roots can still be generated
+		for _, root := range []uint32{index, 59} {
+			rootToProve, err := p.GetL1InfoTreeRootByIndex(ctx, root)
+			require.NoError(t, err)
+			for leafIndex := uint32(0); leafIndex <= root; leafIndex += 3 {
+				proof, err := p.GetL1InfoTreeMerkleProofFromIndexToRoot(ctx, leafIndex, rootToProve.Hash)
+				require.NoError(t, err)
+				require.Equal(t, rootToProve.Hash, tree.CalculateRoot(leaves[leafIndex].Hash, proof, leafIndex))
+			}
+		}
+	}
+	require.Equal(t, uint32(59), lastRoot.Index)
+	// the leaf before an index skips the pruned ones
+	previous, err := p.GetInfoBeforeIndex(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, leaves[7], previous)
+	_, err = p.GetInfoBeforeIndex(ctx, 0)
+	require.ErrorIs(t, err, db.ErrNotFound)
+
+	// the tree keeps growing after the pruning
+	processMirrorTestBlocks(t, p, 31, 1, 2)
+	newRoot, err := p.l1InfoTree.GetLastRoot(p.db)
+	require.NoError(t, err)
+	require.Equal(t, uint32(61), newRoot.Index)
+	newLeaf, err := p.GetInfoByIndex(ctx, 61)
+	require.NoError(t, err)
+	proof, err := p.GetL1InfoTreeMerkleProofFromIndexToRoot(ctx, 61, newRoot.Hash)
+	require.NoError(t, err)
+	require.Equal(t, newRoot.Hash, tree.CalculateRoot(newLeaf.Hash, proof, 61))
+}
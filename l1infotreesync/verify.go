@@ -0,0 +1,252 @@
+package l1infotreesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/l1infotreesync/migrations"
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/sync"
+	"github.com/agglayer/aggkit/tree"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const missingValue = "missing"
+
+// NewVerifier creates a verifier of the DB of the L1 info tree syncer (cfg.DBPath). The chain is reprocessed
+// into a scratch DB, removed on Close, and compared with the DB, which is opened read only. The leaves and
+// the roots of the L1 info tree are compared by index, and the rollup exit roots by hash
+func NewVerifier(
+	ctx context.Context,
+	cfg Config,
+	l1Client aggkittypes.BaseEthereumClienter,
+	flags CreationFlags,
+) (*sync.Verifier, error) {
+	stored, err := newReadOnlyProcessor(cfg.DBPath, cfg.StorageTuning)
+	if err != nil {
+		return nil, err
+	}
+	appender, err := buildAppender(l1Client, cfg.GlobalExitRootAddr, cfg.RollupManagerAddr, flags)
+	if err != nil {
+		return nil, errors.Join(err, stored.db.Close())
+	}
+	rh := &sync.RetryHandler{
+		RetryAfterErrorPeriod:      cfg.RetryAfterErrorPeriod.Duration,
+		MaxRetryAttemptsAfterError: cfg.MaxRetryAttemptsAfterError,
+	}
+	// there is no reorg detector, the verified blocks should be final
+	downloader, err := sync.NewEVMDownloader(
+		"l1infotreesync_verifier",
+		l1Client,
+		cfg.SyncBlockChunkSize,
+		aggkittypes.NewBlockNumberFinality(cfg.BlockFinality),
+		cfg.WaitForNewBlocksPeriod.Duration,
+		appender,
+		[]common.Address{cfg.GlobalExitRootAddr, cfg.RollupManagerAddr},
+		rh,
+		aggkittypes.FinalizedBlock,
+	)
+	if err != nil {
+		return nil, errors.Join(err, stored.db.Close())
+	}
+
+	scratchDir, err := os.MkdirTemp("", "l1infotreesync-verify-*")
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create the scratch DB dir: %w", err), stored.db.Close())
+	}
+	closeFn := func() error {
+		return errors.Join(stored.db.Close(), os.RemoveAll(scratchDir))
+	}
+	scratch, err := newProcessor(filepath.Join(scratchDir, "l1infotreesync.sqlite"), db.SQLiteConfig{})
+	if err != nil {
+		return nil, errors.Join(err, closeFn())
+	}
+	closeFn = func() error {
+		return errors.Join(scratch.db.Close(), stored.db.Close(), os.RemoveAll(scratchDir))
+	}
+	if cfg.InitialBlock > 0 {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(cfg.InitialBlock-1))
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to get initial block %d: %w", cfg.InitialBlock-1, err),
+				closeFn())
+		}
+		if err := scratch.ProcessBlock(ctx, sync.Block{Num: cfg.InitialBlock - 1, Hash: block.Hash()}); err != nil {
+			return nil, errors.Join(err, closeFn())
+		}
+	}
+
+	return sync.NewVerifier("l1infotreesync", downloader, scratch,
+		&l1InfoTreeVerifier{scratch: scratch, stored: stored}, cfg.Verify.MaxDiscrepancies, closeFn), nil
+}
+
+// newReadOnlyProcessor opens an existing DB without running the migrations, so it's never written
+func newReadOnlyProcessor(dbPath string, dbCfg db.SQLiteConfig) (*processor, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to open the DB to verify %s: %w", dbPath, err)
+	}
+	database, err := db.NewSQLiteReaderDB(dbPath, dbCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &processor{
+		db:             database,
+		l1InfoTree:     tree.NewAppendOnlyTree(database, migrations.L1InfoTreePrefix),
+		rollupExitTree: tree.NewUpdatableTree(database, migrations.RollupExitTreePrefix),
+		log:            log.WithFields("processor", "l1infotreesync_verifier"),
+	}, nil
+}
+
+// l1InfoTreeVerifier compares the L1 info trees and the rollup exit trees of the scratch processor and the
+// verified DB
+type l1InfoTreeVerifier struct {
+	scratch *processor
+	stored  *processor
+}
+
+// LastStoredBlock returns the last processed block of the verified DB
+func (v *l1InfoTreeVerifier) LastStoredBlock(ctx context.Context) (uint64, error) {
+	return v.stored.GetLastProcessedBlock(ctx)
+}
+
+// VerifyBlocks compares the L1 info tree leaves, the L1 info root and the rollup exit root of the blocks
+// [fromBlock, toBlock]
+func (v *l1InfoTreeVerifier) VerifyBlocks(ctx context.Context,
+	fromBlock, toBlock uint64) ([]sync.Discrepancy, error) {
+	computedLeaves, err := v.scratch.GetGERsInsertedInBlockRange(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed L1 info tree leaves: %w", err)
+	}
+	storedLeaves, err := v.stored.GetGERsInsertedInBlockRange(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stored L1 info tree leaves: %w", err)
+	}
+	discrepancies := compareLeaves(computedLeaves, storedLeaves)
+
+	if len(computedLeaves) > 0 {
+		last := computedLeaves[len(computedLeaves)-1]
+		discrepancy, err := v.compareL1InfoRoots(ctx, last.BlockNumber, last.L1InfoTreeIndex)
+		if err != nil {
+			return nil, err
+		}
+		if discrepancy != nil {
+			discrepancies = append(discrepancies, *discrepancy)
+		}
+	}
+
+	discrepancy, err := v.compareRollupExitRoots(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if discrepancy != nil {
+		discrepancies = append(discrepancies, *discrepancy)
+	}
+	return discrepancies, nil
+}
+
+// compareL1InfoRoots compares the L1 info root after adding the leaf of the index
+func (v *l1InfoTreeVerifier) compareL1InfoRoots(ctx context.Context, blockNum uint64,
+	index uint32) (*sync.Discrepancy, error) {
+	computed, err := v.scratch.l1InfoTree.GetRootByIndex(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed L1 info root of index %d: %w", index, err)
+	}
+	storedHash := missingValue
+	stored, err := v.stored.l1InfoTree.GetRootByIndex(ctx, index)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the stored L1 info root of index %d: %w", index, err)
+	default:
+		storedHash = stored.Hash.Hex()
+	}
+	if storedHash == computed.Hash.Hex() {
+		return nil, nil
+	}
+	return &sync.Discrepancy{
+		BlockNum: blockNum,
+		Field:    fmt.Sprintf("L1 info root of index %d", index),
+		Computed: computed.Hash.Hex(),
+		Stored:   storedHash,
+	}, nil
+}
+
+// compareRollupExitRoots checks that the last rollup exit root computed, if it was computed in the blocks
+// [fromBlock, toBlock], is stored for the same block
+func (v *l1InfoTreeVerifier) compareRollupExitRoots(ctx context.Context,
+	fromBlock, toBlock uint64) (*sync.Discrepancy, error) {
+	computed, err := v.scratch.rollupExitTree.GetLastRoot(v.scratch.db)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reprocessed rollup exit root: %w", err)
+	}
+	if computed.BlockNum < fromBlock || computed.BlockNum > toBlock {
+		return nil, nil
+	}
+	stored, err := v.stored.rollupExitTree.GetRootByHash(ctx, computed.Hash)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		return &sync.Discrepancy{
+			BlockNum: computed.BlockNum,
+			Field:    "rollup exit root",
+			Computed: computed.Hash.Hex(),
+			Stored:   missingValue,
+		}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the stored rollup exit root %s: %w", computed.Hash.Hex(), err)
+	case stored.BlockNum != computed.BlockNum:
+		return &sync.Discrepancy{
+			BlockNum: computed.BlockNum,
+			Field:    fmt.Sprintf("block of the rollup exit root %s", computed.Hash.Hex()),
+			Computed: fmt.Sprint(computed.BlockNum),
+			Stored:   fmt.Sprint(stored.BlockNum),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// compareLeaves compares the L1 info tree leaves by index
+func compareLeaves(computed, stored []*L1InfoTreeLeaf) []sync.Discrepancy {
+	storedByIndex := make(map[uint32]*L1InfoTreeLeaf, len(stored))
+	for _, l := range stored {
+		storedByIndex[l.L1InfoTreeIndex] = l
+	}
+	discrepancies := []sync.Discrepancy{}
+	for _, l := range computed {
+		field := fmt.Sprintf("L1 info tree leaf of index %d", l.L1InfoTreeIndex)
+		s, ok := storedByIndex[l.L1InfoTreeIndex]
+		delete(storedByIndex, l.L1InfoTreeIndex)
+		switch {
+		case !ok:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: l.BlockNumber, Field: field, Computed: l.Hash.Hex(), Stored: missingValue,
+			})
+		case s.BlockNumber != l.BlockNumber:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: l.BlockNumber, Field: field + " block",
+				Computed: fmt.Sprint(l.BlockNumber), Stored: fmt.Sprint(s.BlockNumber),
+			})
+		case s.Hash != l.Hash:
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: l.BlockNumber, Field: field, Computed: l.Hash.Hex(), Stored: s.Hash.Hex(),
+			})
+		}
+	}
+	for _, s := range stored {
+		if _, ok := storedByIndex[s.L1InfoTreeIndex]; ok {
+			discrepancies = append(discrepancies, sync.Discrepancy{
+				BlockNum: s.BlockNumber, Field: fmt.Sprintf("L1 info tree leaf of index %d", s.L1InfoTreeIndex),
+				Computed: missingValue, Stored: s.Hash.Hex(),
+			})
+		}
+	}
+	return discrepancies
+}
@@ -0,0 +1,155 @@
+package lastgersync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/globalexitrootmanagerl2sovereignchain"
+	"github.com/agglayer/aggkit/db"
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// backfillRangeSize is the maximum number of blocks scanned in a single logs request of the backfill
+const backfillRangeSize = 1000
+
+// BackfillResult is the summary of a backfill
+type BackfillResult struct {
+	// Found is the number of injected GER events found in the range
+	Found int
+	// Inserted is the number of missing GERs inserted
+	Inserted int
+	// AlreadySynced is the number of GERs that were already stored
+	AlreadySynced int
+	// Removed is the number of GERs not inserted because they're removed in a later synced block
+	Removed int
+	// NotSynced is the number of GERs not inserted because their block is not synced yet (or it's reorged),
+	// the syncer processes them when it reaches the block
+	NotSynced int
+}
+
+// String returns a string representation of the result
+func (r BackfillResult) String() string {
+	return fmt.Sprintf("found: %d, inserted: %d, already synced: %d, removed: %d, not synced: %d",
+		r.Found, r.Inserted, r.AlreadySynced, r.Removed, r.NotSynced)
+}
+
+// Backfill re-scans the blocks [fromBlock, toBlock] of L2 for the injected GER events (UpdateHashChainValue)
+// and inserts the ones missing in the lastgersync DB (PP mode). Only the blocks already synced, with the same
+// hash, are filled. It's meant to be run while the node is stopped
+func Backfill(
+	ctx context.Context,
+	dbPath string,
+	storageTuning db.SQLiteConfig,
+	l2Client aggkittypes.BaseEthereumClienter,
+	l2GERManagerAddr common.Address,
+	l1InfoTreeSync L1InfoTreeQuerier,
+	fromBlock, toBlock uint64,
+) (BackfillResult, error) {
+	if fromBlock > toBlock {
+		return BackfillResult{}, fmt.Errorf("invalid block range [%d, %d]", fromBlock, toBlock)
+	}
+	l2GERManager, err := globalexitrootmanagerl2sovereignchain.NewGlobalexitrootmanagerl2sovereignchain(
+		l2GERManagerAddr, l2Client)
+	if err != nil {
+		return BackfillResult{}, fmt.Errorf("failed to initialize L2 GER manager contract: %w", err)
+	}
+	p, err := newProcessor(dbPath, storageTuning)
+	if err != nil {
+		return BackfillResult{}, fmt.Errorf("failed to create processor: %w", err)
+	}
+	defer p.database.Close()
+
+	var result BackfillResult
+	for from := fromBlock; from <= toBlock; from += backfillRangeSize {
+		to := min(from+backfillRangeSize-1, toBlock)
+		logs, err := l2Client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{l2GERManagerAddr},
+			Topics:    [][]common.Hash{{insertGEREventSignature}},
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to get the logs of blocks [%d, %d]: %w", from, to, err)
+		}
+		for _, l := range logs {
+			event, err := l2GERManager.ParseUpdateHashChainValue(l)
+			if err != nil {
+				return result, fmt.Errorf("error parsing UpdateHashChainValue event log %+v: %w", l, err)
+			}
+			result.Found++
+			if err := p.backfillGER(ctx, l, event.NewGlobalExitRoot, l1InfoTreeSync, &result); err != nil {
+				return result, err
+			}
+		}
+		if to == toBlock {
+			// avoid the overflow of from when toBlock is the max uint64
+			break
+		}
+	}
+	p.log.Infof("backfilled blocks [%d, %d]: %s", fromBlock, toBlock, result)
+	return result, nil
+}
+
+// backfillGER inserts the GER injected by the log if its block is synced and it's not stored nor removed later
+func (p *processor) backfillGER(ctx context.Context, l types.Log, ger common.Hash,
+	l1InfoTreeSync L1InfoTreeQuerier, result *BackfillResult) error {
+	var hash sql.NullString
+	err := p.database.QueryRowContext(ctx, `SELECT hash FROM block WHERE num = $1;`, l.BlockNumber).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		result.NotSynced++
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get block %d: %w", l.BlockNumber, err)
+	}
+	if hash.Valid && common.HexToHash(hash.String) != l.BlockHash {
+		p.log.Warnf("block %d is stored with hash %s but the GER %s was injected in block %s, skipping it",
+			l.BlockNumber, hash.String, ger.Hex(), l.BlockHash.Hex())
+		result.NotSynced++
+		return nil
+	}
+
+	var count int
+	err = p.database.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM imported_global_exit_root WHERE block_num = $1 AND global_exit_root = $2;`,
+		l.BlockNumber, ger.Hex()).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to check the GER %s of block %d: %w", ger.Hex(), l.BlockNumber, err)
+	}
+	if count > 0 {
+		result.AlreadySynced++
+		return nil
+	}
+	err = p.database.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM removed_global_exit_root WHERE global_exit_root = $1 AND block_num >= $2;`,
+		ger.Hex(), l.BlockNumber).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to check the removals of the GER %s: %w", ger.Hex(), err)
+	}
+	if count > 0 {
+		result.Removed++
+		return nil
+	}
+
+	leaf, err := l1InfoTreeSync.GetInfoByGlobalExitRoot(ger)
+	if err != nil {
+		return fmt.Errorf("failed to fetch l1 info tree for global exit root %s: %w", ger.Hex(), err)
+	}
+	if err := p.handleGERInsertion(p.database, &GEREvent{
+		BlockNum:        l.BlockNumber,
+		GlobalExitRoot:  ger,
+		L1InfoTreeIndex: leaf.L1InfoTreeIndex,
+	}); err != nil {
+		return err
+	}
+	p.log.Infof("backfilled GER %s (l1 info tree index %d) of block %d",
+		ger.Hex(), leaf.L1InfoTreeIndex, l.BlockNumber)
+	result.Inserted++
+	return nil
+}
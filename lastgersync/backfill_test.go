@@ -0,0 +1,114 @@
+package lastgersync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path"
+	"testing"
+
+	"github.com/agglayer/aggkit/db"
+	"github.com/agglayer/aggkit/l1infotreesync"
+	"github.com/agglayer/aggkit/sync"
+	treetypes "github.com/agglayer/aggkit/tree/types"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// l1InfoTreeQuerierStub returns the leaves of the GERs of the map
+type l1InfoTreeQuerierStub map[common.Hash]uint32
+
+func (l1InfoTreeQuerierStub) GetLastL1InfoTreeRoot(ctx context.Context) (treetypes.Root, error) {
+	return treetypes.Root{}, errors.New("not implemented")
+}
+
+func (l1InfoTreeQuerierStub) GetInfoByIndex(ctx context.Context, index uint32) (*l1infotreesync.L1InfoTreeLeaf, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s l1InfoTreeQuerierStub) GetInfoByGlobalExitRoot(ger common.Hash) (*l1infotreesync.L1InfoTreeLeaf, error) {
+	index, ok := s[ger]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return &l1infotreesync.L1InfoTreeLeaf{GlobalExitRoot: ger, L1InfoTreeIndex: index}, nil
+}
+
+// insertGERLog returns the UpdateHashChainValue log of the GER injected in the block
+func insertGERLog(blockNum uint64, blockHash, ger common.Hash) types.Log {
+	return types.Log{
+		BlockNumber: blockNum,
+		BlockHash:   blockHash,
+		Topics:      []common.Hash{insertGEREventSignature, ger, common.HexToHash("0xc4a1")},
+	}
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	dbPath := path.Join(t.TempDir(), "lastgersync_TestBackfill.sqlite")
+	gerAddr := common.HexToAddress("0x1234")
+	hash := func(blockNum uint64) common.Hash { return common.BigToHash(new(big.Int).SetUint64(blockNum + 100)) }
+
+	p, err := newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	blocks := []sync.Block{
+		// the GER of block 1 was missed
+		{Num: 1, Hash: hash(1)},
+		{Num: 2, Hash: hash(2), Events: []any{
+			&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x2"), L1InfoTreeIndex: 2}},
+		}},
+		{Num: 3, Hash: hash(3), Events: []any{
+			&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x3"), L1InfoTreeIndex: 3}},
+		}},
+		{Num: 4, Hash: hash(4), Events: []any{
+			&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x3"), IsRemove: true}},
+		}},
+		{Num: 5, Hash: hash(5)},
+	}
+	for _, b := range blocks {
+		require.NoError(t, p.ProcessBlock(ctx, b))
+	}
+	require.NoError(t, p.database.Close())
+
+	clientMock := aggkittypesmocks.NewBaseEthereumClienter(t)
+	clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(10),
+		Addresses: []common.Address{gerAddr},
+		Topics:    [][]common.Hash{{insertGEREventSignature}},
+	}).Return([]types.Log{
+		insertGERLog(1, hash(1), common.HexToHash("0x1")),
+		insertGERLog(2, hash(2), common.HexToHash("0x2")),
+		insertGERLog(3, hash(3), common.HexToHash("0x3")),
+		// block 5 is stored with another hash
+		insertGERLog(5, common.HexToHash("0xdead"), common.HexToHash("0x5")),
+		// block 10 is not synced yet
+		insertGERLog(10, hash(10), common.HexToHash("0x10")),
+	}, nil).Once()
+	l1InfoTree := l1InfoTreeQuerierStub{common.HexToHash("0x1"): 1}
+
+	result, err := Backfill(ctx, dbPath, db.SQLiteConfig{}, clientMock, gerAddr, l1InfoTree, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, BackfillResult{Found: 5, Inserted: 1, AlreadySynced: 1, Removed: 1, NotSynced: 2}, result)
+
+	p, err = newProcessor(dbPath, db.SQLiteConfig{})
+	require.NoError(t, err)
+	ger, err := p.GetFirstGERAfterL1InfoTreeIndex(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, GlobalExitRootInfo{GlobalExitRoot: common.HexToHash("0x1"), L1InfoTreeIndex: 1}, ger)
+
+	// the backfill is idempotent
+	clientMock.EXPECT().FilterLogs(mock.Anything, mock.Anything).Return([]types.Log{
+		insertGERLog(1, hash(1), common.HexToHash("0x1")),
+	}, nil).Once()
+	result, err = Backfill(ctx, dbPath, db.SQLiteConfig{}, clientMock, gerAddr, l1InfoTree, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, BackfillResult{Found: 1, AlreadySynced: 1}, result)
+
+	_, err = Backfill(ctx, dbPath, db.SQLiteConfig{}, clientMock, gerAddr, l1InfoTree, 10, 1)
+	require.ErrorContains(t, err, "invalid block range")
+}
@@ -2,12 +2,15 @@ package lastgersync
 
 import (
 	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type Config struct {
 	// DBPath path of the DB
 	DBPath string `mapstructure:"DBPath"`
+	// StorageTuning tunes the sqlite DB (journal mode, cache size, storage quota...)
+	StorageTuning db.SQLiteConfig `mapstructure:"StorageTuning"`
 	// BlockFinality indicates the status of the blocks that will be queried in order to sync
 	BlockFinality string `jsonschema:"enum=LatestBlock, enum=SafeBlock, enum=PendingBlock, enum=FinalizedBlock, enum=EarliestBlock" mapstructure:"BlockFinality"` //nolint:lll
 	// InitialBlockNum is the first block that will be queried when starting the synchronization from scratch.
@@ -9,8 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/lastgersync"
 	"github.com/agglayer/aggkit/test/helpers"
+	"github.com/agglayer/aggkit/test/reorgsim"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -36,6 +38,7 @@ func TestLastGERSyncE2E(t *testing.T) {
 	syncer, err := lastgersync.New(
 		ctx,
 		dbPathSyncer,
+		db.SQLiteConfig{},
 		setup.L2Environment.ReorgDetector,
 		setup.L2Environment.SimBackend.Client(),
 		setup.L2Environment.GERAddr,
@@ -63,6 +66,81 @@ func TestLastGERSyncE2E(t *testing.T) {
 	}
 }
 
+func TestLastGERSyncE2EWithReorgs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	setup := helpers.NewE2EEnvWithEVML2(t, helpers.DefaultEnvironmentConfig())
+	dbPathSyncer := path.Join(t.TempDir(), "lastGERSyncTestE2EWithReorgs.sqlite")
+
+	syncer, err := lastgersync.New(
+		ctx,
+		dbPathSyncer,
+		db.SQLiteConfig{},
+		setup.L2Environment.ReorgDetector,
+		setup.L2Environment.SimBackend.Client(),
+		setup.L2Environment.GERAddr,
+		setup.InfoTreeSync,
+		retryAfterErrorPeriod,
+		maxRetryAttemptsAfterError,
+		aggkittypes.LatestBlock,
+		waitForNewBlocksPeriod,
+		syncBlockChunkSize,
+		true,
+		lastgersync.FEP,
+	)
+	require.NoError(t, err)
+
+	go func() {
+		if err := syncer.Start(ctx); err != nil {
+			log.Fatalf("lastGERSync failed: %s", err)
+		}
+	}()
+
+	// the GERs are updated on the L1, and the aggoracle injects them on the L2 (one block per injection)
+	updateGER := func(i int) reorgsim.Step {
+		return reorgsim.Inject(func(t *testing.T) {
+			t.Helper()
+			updateGlobalExitRoot(t, setup, i)
+			time.Sleep(syncDelay)
+		})
+	}
+	requireGER := func(i int) reorgsim.Step {
+		return reorgsim.Check(func(t *testing.T, _ *reorgsim.Simulator) {
+			t.Helper()
+			testGERSyncer(t, ctx, setup, syncer, i)
+		})
+	}
+
+	sim := reorgsim.New(t, setup.L2Environment.SimBackend, reorgsim.WithBlockTime(syncDelay))
+	sim.Run(
+		updateGER(0),
+		requireGER(0),
+		reorgsim.Mark("firstGER"),
+		updateGER(1),
+		requireGER(1),
+		reorgsim.Mark("secondGER"),
+		updateGER(2),
+		requireGER(2),
+
+		// the injections of the reorged blocks go back to the pending transactions, and are included again
+		// in the new chain, that is longer than the reorged one
+		reorgsim.ForkFrom("firstGER"),
+		reorgsim.Commit(3),
+		// wait for the reorg to be detected
+		reorgsim.Wait(time.Second),
+		reorgsim.Check(func(t *testing.T, s *reorgsim.Simulator) {
+			t.Helper()
+			s.RequireReorged(s.Marked("secondGER"))
+		}),
+		requireGER(2),
+
+		// the syncer keeps syncing the new chain
+		updateGER(3),
+		requireGER(3),
+	)
+	require.Len(t, sim.Forks(), 1)
+}
+
 func TestLastGERSync_GERRemoval(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -72,6 +150,7 @@ func TestLastGERSync_GERRemoval(t *testing.T) {
 	syncer, err := lastgersync.New(
 		ctx,
 		dbPathSyncer,
+		db.SQLiteConfig{},
 		setup.L2Environment.ReorgDetector,
 		setup.L2Environment.SimBackend.Client(),
 		setup.L2Environment.GERAddr,
@@ -15,6 +15,9 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// downloadPPRangeSize is the maximum number of blocks scanned in a single logs request
+const downloadPPRangeSize = 100
+
 var (
 	// event UpdateHashChainValue(bytes32 indexed newGlobalExitRoot, bytes32 indexed newHashChainValue);
 	insertGEREventSignature = crypto.Keccak256Hash([]byte("UpdateHashChainValue(bytes32,bytes32)"))
@@ -78,6 +81,9 @@ func (d *downloaderPP) RuntimeData(ctx context.Context) (sync.RuntimeData, error
 	}, nil
 }
 
+// Download scans every block from fromBlock in ranges of downloadPPRangeSize blocks, so no block is skipped
+// when several blocks are produced between two polls. The last block of each range is reported even if it
+// has no events, so the synced block moves forward
 func (d *downloaderPP) Download(ctx context.Context, fromBlock uint64, downloadedCh chan sync.EVMBlock) {
 	for {
 		select {
@@ -90,9 +96,31 @@ func (d *downloaderPP) Download(ctx context.Context, fromBlock uint64, downloade
 		}
 
 		// Wait for new blocks before processing
-		fromBlock = d.WaitForNewBlocks(ctx, fromBlock)
-		for _, block := range d.GetEventsByBlockRange(ctx, fromBlock, fromBlock) {
-			downloadedCh <- *block
+		lastBlockSeen := fromBlock
+		if fromBlock > 0 {
+			lastBlockSeen = fromBlock - 1
+		}
+		lastBlock := d.WaitForNewBlocks(ctx, lastBlockSeen)
+		if ctx.Err() != nil {
+			continue
+		}
+		for fromBlock <= lastBlock && ctx.Err() == nil {
+			toBlock := min(fromBlock+downloadPPRangeSize-1, lastBlock)
+			blocks := d.GetEventsByBlockRange(ctx, fromBlock, toBlock)
+			if ctx.Err() != nil {
+				break
+			}
+			for _, block := range blocks {
+				downloadedCh <- *block
+			}
+			if blocks.Len() == 0 || blocks[blocks.Len()-1].Num < toBlock {
+				header, canceled := d.GetBlockHeader(ctx, toBlock)
+				if canceled {
+					break
+				}
+				downloadedCh <- sync.EVMBlock{EVMBlockHeader: header}
+			}
+			fromBlock = toBlock + 1
 		}
 	}
 }
@@ -110,14 +138,13 @@ func (d *downloaderPP) buildAppender(
 			return fmt.Errorf("error parsing UpdateRemovalHashChainValue event log %+v: %w", l, err)
 		}
 
-		b.Events = []any{
-			&Event{
-				GEREvent: &GEREvent{
-					BlockNum:       b.Num,
-					GlobalExitRoot: removeGEREvent.RemovedGlobalExitRoot,
-					IsRemove:       true,
-				},
-			}}
+		b.Events = append(b.Events, &Event{
+			GEREvent: &GEREvent{
+				BlockNum:       b.Num,
+				GlobalExitRoot: removeGEREvent.RemovedGlobalExitRoot,
+				IsRemove:       true,
+			},
+		})
 		return nil
 	}
 
@@ -133,15 +160,14 @@ func (d *downloaderPP) buildAppender(
 				insertGEREvent.NewGlobalExitRoot, err)
 		}
 
-		b.Events = []any{
-			&Event{
-				GEREvent: &GEREvent{
-					BlockNum:        b.Num,
-					GlobalExitRoot:  insertGEREvent.NewGlobalExitRoot,
-					L1InfoTreeIndex: l1InfoTreeLeaf.L1InfoTreeIndex,
-					IsRemove:        false,
-				},
-			}}
+		b.Events = append(b.Events, &Event{
+			GEREvent: &GEREvent{
+				BlockNum:        b.Num,
+				GlobalExitRoot:  insertGEREvent.NewGlobalExitRoot,
+				L1InfoTreeIndex: l1InfoTreeLeaf.L1InfoTreeIndex,
+				IsRemove:        false,
+			},
+		})
 		return nil
 	}
 
@@ -0,0 +1,58 @@
+package lastgersync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/sync"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloaderPPDownload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gerAddr := common.HexToAddress("0x1234")
+	finality := big.NewInt(-2)
+	header := func(blockNum int64) *types.Header { return &types.Header{Number: big.NewInt(blockNum)} }
+
+	clientMock := aggkittypesmocks.NewBaseEthereumClienter(t)
+	d, err := newDownloaderPP(clientMock, gerAddr,
+		l1InfoTreeQuerierStub{common.HexToHash("0x1"): 1, common.HexToHash("0x2"): 2}, nil,
+		&sync.RetryHandler{MaxRetryAttemptsAfterError: 1, RetryAfterErrorPeriod: time.Millisecond},
+		finality, time.Millisecond)
+	require.NoError(t, err)
+
+	// several blocks are produced between two polls, and a block injects two GERs
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, finality).Return(header(5), nil).Once()
+	clientMock.EXPECT().FilterLogs(mock.Anything, mock.Anything).Return([]types.Log{
+		insertGERLog(3, header(3).Hash(), common.HexToHash("0x1")),
+		insertGERLog(3, header(3).Hash(), common.HexToHash("0x2")),
+	}, nil).Once()
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(3)).Return(header(3), nil).Once()
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(5)).Return(header(5), nil).Once()
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, finality).Return(header(5), nil).Maybe()
+
+	downloadedCh := make(chan sync.EVMBlock, 10)
+	go d.Download(ctx, 1, downloadedCh)
+
+	block := <-downloadedCh
+	require.Equal(t, uint64(3), block.Num)
+	require.Equal(t, []any{
+		&Event{GEREvent: &GEREvent{BlockNum: 3, GlobalExitRoot: common.HexToHash("0x1"), L1InfoTreeIndex: 1}},
+		&Event{GEREvent: &GEREvent{BlockNum: 3, GlobalExitRoot: common.HexToHash("0x2"), L1InfoTreeIndex: 2}},
+	}, block.Events)
+	// the last block of the range is reported without events
+	block = <-downloadedCh
+	require.Equal(t, uint64(5), block.Num)
+	require.Empty(t, block.Events)
+
+	cancel()
+	_, ok := <-downloadedCh
+	require.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/db/compatibility"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/sync"
@@ -41,6 +42,7 @@ type LastGERSync struct {
 func New(
 	ctx context.Context,
 	dbPath string,
+	storageTuning db.SQLiteConfig,
 	rdL2 sync.ReorgDetector,
 	l2Client aggkittypes.BaseEthereumClienter,
 	l2GERManagerAddr common.Address,
@@ -53,7 +55,7 @@ func New(
 	requireStorageContentCompatibility bool,
 	syncMode SyncMode,
 ) (*LastGERSync, error) {
-	processor, err := newProcessor(dbPath)
+	processor, err := newProcessor(dbPath, storageTuning)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create processor: %w", err)
 	}
@@ -123,3 +125,12 @@ func (s *LastGERSync) GetFirstGERAfterL1InfoTreeIndex(
 func (s *LastGERSync) GetLastProcessedBlock(ctx context.Context) (uint64, error) {
 	return s.processor.GetLastProcessedBlock(ctx)
 }
+
+// RequiredL1InfoTreeIndexes returns the L1 info tree indexes, between fromIndex and toIndex, that must be
+// kept by the pruning of the L1 info tree: the ones of the GERs injected into the chain, whose claims need
+// their leaves, and the ones not synced yet
+func (s *LastGERSync) RequiredL1InfoTreeIndexes(
+	ctx context.Context, fromIndex, toIndex uint32,
+) (map[uint32]struct{}, error) {
+	return s.processor.RequiredL1InfoTreeIndexes(ctx, fromIndex, toIndex)
+}
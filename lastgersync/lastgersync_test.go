@@ -13,7 +13,7 @@ import (
 
 func TestGetLastProcessedBlock(t *testing.T) {
 	testDir := path.Join(t.TempDir(), "lastgersync_TestGetLastProcessedBlock.sqlite")
-	processor, err := newProcessor(testDir)
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
 	require.NoError(t, err)
 
 	block := sync.Block{
@@ -40,7 +40,7 @@ func TestGetLastProcessedBlock(t *testing.T) {
 
 func TestGetFirstGERAfterL1InfoTreeIndex(t *testing.T) {
 	testDir := path.Join(t.TempDir(), "lastgersync_TestGetFirstGERAfterL1InfoTreeIndex.sqlite")
-	processor, err := newProcessor(testDir)
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
 	require.NoError(t, err)
 
 	ctx := context.TODO()
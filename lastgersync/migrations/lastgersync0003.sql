@@ -0,0 +1,33 @@
+-- +migrate Down
+DROP TABLE IF EXISTS removed_global_exit_root;
+CREATE TABLE imported_global_exit_root_old (
+	block_num           INTEGER PRIMARY KEY REFERENCES block(num) ON DELETE CASCADE,
+	global_exit_root    VARCHAR NOT NULL,
+	l1_info_tree_index  INTEGER NOT NULL
+);
+INSERT OR IGNORE INTO imported_global_exit_root_old (block_num, global_exit_root, l1_info_tree_index)
+	SELECT block_num, global_exit_root, l1_info_tree_index FROM imported_global_exit_root;
+DROP TABLE imported_global_exit_root;
+ALTER TABLE imported_global_exit_root_old RENAME TO imported_global_exit_root;
+
+-- +migrate Up
+-- a block can inject several GERs
+CREATE TABLE imported_global_exit_root_new (
+	block_num           INTEGER NOT NULL REFERENCES block(num) ON DELETE CASCADE,
+	global_exit_root    VARCHAR NOT NULL,
+	l1_info_tree_index  INTEGER NOT NULL,
+	PRIMARY KEY (block_num, global_exit_root)
+);
+INSERT INTO imported_global_exit_root_new (block_num, global_exit_root, l1_info_tree_index)
+	SELECT block_num, global_exit_root, l1_info_tree_index FROM imported_global_exit_root;
+DROP TABLE imported_global_exit_root;
+ALTER TABLE imported_global_exit_root_new RENAME TO imported_global_exit_root;
+
+-- the GERs removed in each block, restored if the block is reorged
+CREATE TABLE removed_global_exit_root (
+	block_num           INTEGER NOT NULL REFERENCES block(num) ON DELETE CASCADE,
+	global_exit_root    VARCHAR NOT NULL,
+	l1_info_tree_index  INTEGER NOT NULL,
+	inserted_block_num  INTEGER NOT NULL
+);
+CREATE INDEX idx_removed_global_exit_root_block_num ON removed_global_exit_root (block_num);
@@ -13,7 +13,11 @@ var mig001 string
 //go:embed lastgersync0002.sql
 var mig002 string
 
-func RunMigrations(dbPath string) error {
+//go:embed lastgersync0003.sql
+var mig003 string
+
+// GetMigrations returns the migrations of the lastgersync DB
+func GetMigrations() []types.Migration {
 	migrations := []types.Migration{
 		{
 			ID:  "lastgersync0001",
@@ -23,6 +27,14 @@ func RunMigrations(dbPath string) error {
 			ID:  "lastgersync0002",
 			SQL: mig002,
 		},
+		{
+			ID:  "lastgersync0003",
+			SQL: mig003,
+		},
 	}
-	return db.RunMigrations(dbPath, migrations)
+	return migrations
+}
+
+func RunMigrations(dbPath string) error {
+	return db.RunMigrations(dbPath, GetMigrations())
 }
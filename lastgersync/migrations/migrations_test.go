@@ -65,10 +65,38 @@ func TestMigration0001(t *testing.T) {
 	require.Equal(t, uint32(2), importedGER.L1InfoTreeIndex)
 }
 
+func TestMigration0003(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "lastgersyncTest0003.sqlite")
+
+	err := RunMigrations(dbPath)
+	require.NoError(t, err)
+	db, err := db.NewSQLiteDB(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// a block can inject several GERs
+	_, err = db.Exec(`
+		INSERT INTO block (num) VALUES (1), (2);
+		INSERT INTO imported_global_exit_root (block_num, global_exit_root, l1_info_tree_index)
+			VALUES (1, '0x1', 1), (1, '0x2', 2);
+		INSERT INTO removed_global_exit_root (block_num, global_exit_root, l1_info_tree_index, inserted_block_num)
+			VALUES (2, '0x3', 3, 1);
+	`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO imported_global_exit_root VALUES (1, '0x1', 1);`)
+	require.Error(t, err)
+
+	_, err = db.Exec(`DELETE FROM block WHERE num = 2;`)
+	require.NoError(t, err)
+	var removed int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM removed_global_exit_root;`).Scan(&removed))
+	require.Zero(t, removed)
+}
+
 func TestMigrations_UpDown(t *testing.T) {
 	dbPath := filepath.Join(t.TempDir(), "test.db")
 
-	const totalMigrations = 3
+	const totalMigrations = 4
 
 	migs := []types.Migration{
 		{
@@ -79,6 +107,10 @@ func TestMigrations_UpDown(t *testing.T) {
 			ID:  "lastgersync0002",
 			SQL: readFile(t, "lastgersync0002.sql"),
 		},
+		{
+			ID:  "lastgersync0003",
+			SQL: readFile(t, "lastgersync0003.sql"),
+		},
 	}
 
 	// Apply migrations Up
@@ -90,7 +122,7 @@ func TestMigrations_UpDown(t *testing.T) {
 	defer conn.Close()
 
 	// Check that tables exist after Up
-	tables := []string{"block", "imported_global_exit_root"}
+	tables := []string{"block", "imported_global_exit_root", "removed_global_exit_root"}
 	for _, table := range tables {
 		exists := checkTableExists(t, conn, table)
 		require.True(t, exists, "table %s should exist after up migration", table)
@@ -18,6 +18,16 @@ import (
 
 const (
 	deleteGERSql = "DELETE FROM imported_global_exit_root WHERE global_exit_root = $1;"
+	// saveRemovedGERSql keeps the GER entries removed in a block, so they're restored if the block is reorged
+	saveRemovedGERSql = `INSERT INTO removed_global_exit_root
+		(block_num, global_exit_root, l1_info_tree_index, inserted_block_num)
+		SELECT $1, global_exit_root, l1_info_tree_index, block_num
+		FROM imported_global_exit_root WHERE global_exit_root = $2;`
+	// restoreRemovedGERsSql restores the GER entries inserted before the reorged blocks and removed in them
+	restoreRemovedGERsSql = `INSERT OR IGNORE INTO imported_global_exit_root
+		(block_num, global_exit_root, l1_info_tree_index)
+		SELECT inserted_block_num, global_exit_root, l1_info_tree_index
+		FROM removed_global_exit_root WHERE block_num >= $1 AND inserted_block_num < $1;`
 )
 
 type BlockNum struct {
@@ -48,12 +58,15 @@ type processor struct {
 	compatibility.CompatibilityDataStorager[sync.RuntimeData]
 }
 
-func newProcessor(dbPath string) (*processor, error) {
+func newProcessor(dbPath string, dbCfg db.SQLiteConfig) (*processor, error) {
+	if err := dbCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage tuning: %w", err)
+	}
 	err := migrations.RunMigrations(dbPath)
 	if err != nil {
 		return nil, err
 	}
-	database, err := db.NewSQLiteDB(dbPath)
+	database, err := db.NewSQLiteDBWithConfig(dbPath, dbCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -107,7 +120,9 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 			}
 
 		case event.GEREvent != nil:
-			if err := p.handleGEREvent(tx, event.GEREvent); err != nil {
+			gerEvent := *event.GEREvent
+			gerEvent.BlockNum = block.Num
+			if err := p.handleGEREvent(tx, &gerEvent); err != nil {
 				return err
 			}
 		}
@@ -123,7 +138,7 @@ func (p *processor) ProcessBlock(ctx context.Context, block sync.Block) error {
 }
 
 // handleGERInsertion inserts the given global exit root entry to `imported_global_exit_root`
-func (*processor) handleGERInsertion(tx dbtypes.Txer, gerInfo *GEREvent) error {
+func (*processor) handleGERInsertion(tx dbtypes.Querier, gerInfo *GEREvent) error {
 	gerInfoWithBlockNum := &gerInfoWithBlockNum{
 		GlobalExitRoot:  gerInfo.GlobalExitRoot,
 		L1InfoTreeIndex: gerInfo.L1InfoTreeIndex,
@@ -137,9 +152,13 @@ func (*processor) handleGERInsertion(tx dbtypes.Txer, gerInfo *GEREvent) error {
 	return nil
 }
 
-// handleGEREvent either inserts or removes the global exit root entry from `imported_global_exit_root` table,
+// handleGEREvent either inserts or removes the global exit root entry from `imported_global_exit_root` table.
+// The removed entries are kept in `removed_global_exit_root` with the block of the removal
 func (p *processor) handleGEREvent(tx dbtypes.Txer, event *GEREvent) error {
 	if event.IsRemove {
+		if _, err := tx.Exec(saveRemovedGERSql, event.BlockNum, event.GlobalExitRoot.Hex()); err != nil {
+			return fmt.Errorf("failed to save removed global exit root %s: %w", event.GlobalExitRoot.Hex(), err)
+		}
 		_, err := tx.Exec(deleteGERSql, event.GlobalExitRoot.Hex())
 		if err != nil {
 			return fmt.Errorf("failed to remove global exit root %s: %w", event.GlobalExitRoot.Hex(), err)
@@ -183,12 +202,39 @@ func (p *processor) getLatestL1InfoTreeIndex() (uint32, error) {
 	return latestGERInfo.L1InfoTreeIndex, nil
 }
 
-// Reorg removes all blocks and associated data starting from a specific block number from lastgersync database
+// Reorg removes all blocks and associated data starting from a specific block number from lastgersync database.
+// The GERs inserted before the reorged blocks and removed in them are restored
 func (p *processor) Reorg(ctx context.Context, firstReorgedBlock uint64) error {
-	_, err := p.database.ExecContext(ctx, `DELETE FROM block WHERE num >= $1;`, firstReorgedBlock)
+	tx, err := db.NewTx(ctx, p.database)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	shouldRollback := true
+	defer func() {
+		if shouldRollback {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				p.log.Errorf("error while rolling back tx %v", errRollback)
+			}
+		}
+	}()
+
+	restored, err := tx.Exec(restoreRemovedGERsSql, firstReorgedBlock)
+	if err != nil {
+		return fmt.Errorf("error restoring the GERs removed in the reorged blocks: %w", err)
+	}
+	deleted, err := tx.Exec(`DELETE FROM block WHERE num >= $1;`, firstReorgedBlock)
 	if err != nil {
 		return fmt.Errorf("error processing reorg: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing reorg: %w", err)
+	}
+	shouldRollback = false
+
+	restoredGERs, _ := restored.RowsAffected()
+	deletedBlocks, _ := deleted.RowsAffected()
+	p.log.Infof("reorged from block %d: %d blocks deleted, %d removed GERs restored",
+		firstReorgedBlock, deletedBlocks, restoredGERs)
 	return nil
 }
 
@@ -212,3 +258,41 @@ func (p *processor) GetFirstGERAfterL1InfoTreeIndex(
 
 	return e, nil
 }
+
+// RequiredL1InfoTreeIndexes returns the L1 info tree indexes, between fromIndex and toIndex, of the GERs
+// injected into the chain, and the ones after the latest injected GER (not synced yet)
+func (p *processor) RequiredL1InfoTreeIndexes(
+	ctx context.Context, fromIndex, toIndex uint32) (map[uint32]struct{}, error) {
+	latestIndex, err := p.getLatestL1InfoTreeIndex()
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get the latest L1 info tree index: %w", err)
+	}
+	required := make(map[uint32]struct{})
+	firstNotSynced := fromIndex
+	if err == nil && latestIndex >= fromIndex {
+		firstNotSynced = latestIndex + 1
+	}
+	for index := firstNotSynced; index <= toIndex; index++ {
+		required[index] = struct{}{}
+	}
+
+	rows, err := p.database.QueryContext(ctx, `
+		SELECT DISTINCT l1_info_tree_index FROM imported_global_exit_root
+		WHERE l1_info_tree_index >= $1 AND l1_info_tree_index <= $2;
+	`, fromIndex, toIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the L1 info tree indexes %d-%d: %w", fromIndex, toIndex, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var index uint32
+		if err := rows.Scan(&index); err != nil {
+			return nil, fmt.Errorf("failed to get the L1 info tree indexes %d-%d: %w", fromIndex, toIndex, err)
+		}
+		required[index] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get the L1 info tree indexes %d-%d: %w", fromIndex, toIndex, err)
+	}
+	return required, nil
+}
@@ -15,7 +15,7 @@ import (
 func Test_getLatestL1InfoTreeIndex(t *testing.T) {
 	t.Parallel()
 	testDir := path.Join(t.TempDir(), "lastgersync_Test_getLatestL1InfoTreeIndex.sqlite")
-	processor, err := newProcessor(testDir)
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
 	require.NoError(t, err)
 
 	block := sync.Block{
@@ -138,6 +138,29 @@ func TestProcessBlock(t *testing.T) {
 			},
 			expectedIndex: l1InfoTreeIndex + 1,
 		},
+		{
+			name: "Insert several GERs in the same block",
+			blocks: []sync.Block{
+				{
+					Num: 7,
+					Events: []any{
+						&Event{
+							GEREvent: &GEREvent{
+								GlobalExitRoot:  common.HexToHash("0x1234"),
+								L1InfoTreeIndex: l1InfoTreeIndex,
+							},
+						},
+						&Event{
+							GEREvent: &GEREvent{
+								GlobalExitRoot:  common.HexToHash("0x5678"),
+								L1InfoTreeIndex: l1InfoTreeIndex + 1,
+							},
+						},
+					},
+				},
+			},
+			expectedIndex: l1InfoTreeIndex + 1,
+		},
 	}
 
 	ctx := context.Background()
@@ -148,7 +171,7 @@ func TestProcessBlock(t *testing.T) {
 			t.Parallel()
 
 			testDir := path.Join(t.TempDir(), fmt.Sprintf("lastgersync_Test_ProcessBlock_%s.sqlite", tt.name))
-			p, err := newProcessor(testDir)
+			p, err := newProcessor(testDir, db.SQLiteConfig{})
 			require.NoError(t, err)
 
 			for _, b := range tt.blocks {
@@ -169,7 +192,7 @@ func TestProcessBlock(t *testing.T) {
 
 func TestReorg(t *testing.T) {
 	testDir := path.Join(t.TempDir(), "lastgersync_TestReorg.sqlite")
-	processor, err := newProcessor(testDir)
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
 	require.NoError(t, err)
 
 	block1 := sync.Block{
@@ -210,3 +233,90 @@ func TestReorg(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint32(2), index)
 }
+
+func TestReorgRestoresRemovedGERs(t *testing.T) {
+	testDir := path.Join(t.TempDir(), "lastgersync_TestReorgRestoresRemovedGERs.sqlite")
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	blocks := []sync.Block{
+		{
+			Num: 1,
+			Events: []any{
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x1"), L1InfoTreeIndex: 1}},
+			},
+		},
+		{
+			Num: 2,
+			Events: []any{
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x2"), L1InfoTreeIndex: 2}},
+			},
+		},
+		{
+			// removes a GER inserted before the reorg and a GER inserted in the reorged blocks
+			Num: 3,
+			Events: []any{
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x1"), IsRemove: true}},
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x2"), IsRemove: true}},
+			},
+		},
+	}
+	for _, b := range blocks {
+		require.NoError(t, processor.ProcessBlock(ctx, b))
+	}
+	_, err = processor.GetFirstGERAfterL1InfoTreeIndex(ctx, 0)
+	require.ErrorIs(t, err, db.ErrNotFound)
+
+	require.NoError(t, processor.Reorg(ctx, 2))
+
+	ger, err := processor.GetFirstGERAfterL1InfoTreeIndex(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, GlobalExitRootInfo{GlobalExitRoot: common.HexToHash("0x1"), L1InfoTreeIndex: 1}, ger)
+	_, err = processor.GetFirstGERAfterL1InfoTreeIndex(ctx, 2)
+	require.ErrorIs(t, err, db.ErrNotFound)
+
+	var removed int
+	require.NoError(t, processor.database.QueryRow(`SELECT COUNT(*) FROM removed_global_exit_root;`).Scan(&removed))
+	require.Zero(t, removed)
+}
+
+func TestRequiredL1InfoTreeIndexes(t *testing.T) {
+	testDir := path.Join(t.TempDir(), "lastgersync_TestRequiredL1InfoTreeIndexes.sqlite")
+	processor, err := newProcessor(testDir, db.SQLiteConfig{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// no GER injected yet, all the indexes are required
+	required, err := processor.RequiredL1InfoTreeIndexes(ctx, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]struct{}{2: {}, 3: {}, 4: {}}, required)
+
+	blocks := []sync.Block{
+		{
+			Num: 1,
+			Events: []any{
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x3"), L1InfoTreeIndex: 3}},
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0x7"), L1InfoTreeIndex: 7}},
+			},
+		},
+		{
+			Num: 2,
+			Events: []any{
+				&Event{GEREvent: &GEREvent{GlobalExitRoot: common.HexToHash("0xa"), L1InfoTreeIndex: 10}},
+			},
+		},
+	}
+	for _, b := range blocks {
+		require.NoError(t, processor.ProcessBlock(ctx, b))
+	}
+
+	required, err = processor.RequiredL1InfoTreeIndexes(ctx, 0, 8)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]struct{}{3: {}, 7: {}}, required)
+
+	// the indexes after the latest injected GER are not synced yet
+	required, err = processor.RequiredL1InfoTreeIndexes(ctx, 8, 12)
+	require.NoError(t, err)
+	require.Equal(t, map[uint32]struct{}{10: {}, 11: {}, 12: {}}, required)
+}
@@ -0,0 +1,106 @@
+package networks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Role is the role of a network in the agglayer
+type Role string
+
+const (
+	// RoleL1 is the settlement layer (there is only one and its network ID is always 0)
+	RoleL1 Role = "L1"
+	// RoleL2 is a chain attached to the agglayer
+	RoleL2 Role = "L2"
+
+	// L1NetworkID is the network ID of the L1 (mainnet)
+	L1NetworkID uint32 = 0
+)
+
+var ErrInvalidNetworkConfig = errors.New("invalid network config")
+
+// NetworkConfig is the configuration of a network
+type NetworkConfig struct {
+	// ID is the network ID (0 for the L1, the rollup ID for the L2s)
+	ID uint32 `mapstructure:"ID"`
+	// Name is a human readable name of the network
+	Name string `mapstructure:"Name"`
+	// Role is the role of the network: L1 or L2
+	Role Role `mapstructure:"Role"`
+	// RPCURLs are the RPC endpoints of the network
+	RPCURLs []string `mapstructure:"RPCURLs"`
+	// BridgeAddr is the address of the bridge contract deployed on the network
+	BridgeAddr common.Address `mapstructure:"BridgeAddr"`
+	// GERAddr is the address of the global exit root contract deployed on the network
+	GERAddr common.Address `mapstructure:"GERAddr"`
+	// BlockFinality is the finality of the blocks of the network that are considered safe to sync
+	BlockFinality aggkittypes.BlockNumberFinality `mapstructure:"BlockFinality"`
+}
+
+// Validate checks the name, role and block finality of the network
+func (n NetworkConfig) Validate() error {
+	if n.Name == "" {
+		return fmt.Errorf("%w: network %d has no name", ErrInvalidNetworkConfig, n.ID)
+	}
+	switch n.Role {
+	case RoleL1:
+		if n.ID != L1NetworkID {
+			return fmt.Errorf("%w: L1 network %s must have network ID %d, got %d",
+				ErrInvalidNetworkConfig, n.Name, L1NetworkID, n.ID)
+		}
+	case RoleL2:
+		if n.ID == L1NetworkID {
+			return fmt.Errorf("%w: L2 network %s can't use the L1 network ID %d",
+				ErrInvalidNetworkConfig, n.Name, L1NetworkID)
+		}
+	default:
+		return fmt.Errorf("%w: network %s has an invalid role %q (must be %s or %s)",
+			ErrInvalidNetworkConfig, n.Name, n.Role, RoleL1, RoleL2)
+	}
+	if n.BlockFinality.String() != "" {
+		if _, err := n.BlockFinality.ToBlockNum(); err != nil {
+			return fmt.Errorf("%w: network %s: %w", ErrInvalidNetworkConfig, n.Name, err)
+		}
+	}
+	return nil
+}
+
+// WithSynced fills the RPC endpoints, the addresses and the block finality left empty with the ones of synced,
+// which are taken from the sections of the syncers of the network. A configured value that differs from the
+// synced one is rejected, so the registry never reports a setting the syncers don't use
+func (n NetworkConfig) WithSynced(synced NetworkConfig) (NetworkConfig, error) {
+	if len(n.RPCURLs) == 0 {
+		n.RPCURLs = synced.RPCURLs
+	} else if len(synced.RPCURLs) > 0 && !slices.Contains(n.RPCURLs, synced.RPCURLs[0]) {
+		return n, fmt.Errorf("%w: network %s: RPCURLs %v don't include the synced RPC URL %s",
+			ErrInvalidNetworkConfig, n.Name, n.RPCURLs, synced.RPCURLs[0])
+	}
+	var err error
+	if n.BridgeAddr, err = withSyncedValue(n, "BridgeAddr", n.BridgeAddr, synced.BridgeAddr); err != nil {
+		return n, err
+	}
+	if n.GERAddr, err = withSyncedValue(n, "GERAddr", n.GERAddr, synced.GERAddr); err != nil {
+		return n, err
+	}
+	if n.BlockFinality, err = withSyncedValue(n, "BlockFinality", n.BlockFinality, synced.BlockFinality); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func withSyncedValue[T comparable](n NetworkConfig, field string, configured, synced T) (T, error) {
+	var zero T
+	if configured == zero {
+		return synced, nil
+	}
+	if synced != zero && configured != synced {
+		return configured, fmt.Errorf("%w: network %s: %s is %v, but the syncers use %v",
+			ErrInvalidNetworkConfig, n.Name, field, configured, synced)
+	}
+	return configured, nil
+}
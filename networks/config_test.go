@@ -0,0 +1,95 @@
+package networks
+
+import (
+	"testing"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConfigWithSynced(t *testing.T) {
+	t.Parallel()
+
+	synced := NetworkConfig{
+		RPCURLs:       []string{"http://localhost:8545"},
+		BridgeAddr:    common.HexToAddress("0x1"),
+		GERAddr:       common.HexToAddress("0x2"),
+		BlockFinality: aggkittypes.FinalizedBlock,
+	}
+	network := NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1}
+
+	tests := []struct {
+		name        string
+		configured  NetworkConfig
+		expected    NetworkConfig
+		expectedErr string
+	}{
+		{
+			name:       "empty values are filled",
+			configured: network,
+			expected: NetworkConfig{
+				ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				RPCURLs:       synced.RPCURLs,
+				BridgeAddr:    synced.BridgeAddr,
+				GERAddr:       synced.GERAddr,
+				BlockFinality: synced.BlockFinality,
+			},
+		},
+		{
+			name: "matching values are kept",
+			configured: NetworkConfig{
+				ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				RPCURLs:       []string{"http://localhost:8546", "http://localhost:8545"},
+				BridgeAddr:    synced.BridgeAddr,
+				BlockFinality: synced.BlockFinality,
+			},
+			expected: NetworkConfig{
+				ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				RPCURLs:       []string{"http://localhost:8546", "http://localhost:8545"},
+				BridgeAddr:    synced.BridgeAddr,
+				GERAddr:       synced.GERAddr,
+				BlockFinality: synced.BlockFinality,
+			},
+		},
+		{
+			name: "RPC URLs without the synced one",
+			configured: NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				RPCURLs: []string{"http://localhost:8546"}},
+			expectedErr: "don't include the synced RPC URL http://localhost:8545",
+		},
+		{
+			name: "conflicting bridge address",
+			configured: NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				BridgeAddr: common.HexToAddress("0x3")},
+			expectedErr: "BridgeAddr is 0x0000000000000000000000000000000000000003, " +
+				"but the syncers use 0x0000000000000000000000000000000000000001",
+		},
+		{
+			name: "conflicting GER address",
+			configured: NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				GERAddr: common.HexToAddress("0x3")},
+			expectedErr: "GERAddr is 0x0000000000000000000000000000000000000003",
+		},
+		{
+			name: "conflicting block finality",
+			configured: NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1,
+				BlockFinality: aggkittypes.LatestBlock},
+			expectedErr: "BlockFinality is LatestBlock, but the syncers use FinalizedBlock",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tt.configured.WithSynced(synced)
+			if tt.expectedErr != "" {
+				require.ErrorIs(t, err, ErrInvalidNetworkConfig)
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package networks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnknownNetwork = errors.New("unknown network")
+
+// Registry holds the networks supported by the aggkit, so the bridge service and the retention guard
+// of the L1 info tree look them up by ID instead of comparing network IDs on their own. The entries of
+// the L1 and of the L2 run by the aggkit are filled with the settings of their syncers (see WithSynced)
+type Registry struct {
+	networks []NetworkConfig
+	byID     map[uint32]NetworkConfig
+}
+
+// NewRegistry validates the networks and creates a Registry. There must be exactly one L1
+// network and the IDs and names must be unique
+func NewRegistry(networks []NetworkConfig) (*Registry, error) {
+	r := &Registry{
+		networks: make([]NetworkConfig, 0, len(networks)),
+		byID:     make(map[uint32]NetworkConfig, len(networks)),
+	}
+	names := make(map[string]struct{}, len(networks))
+	l1Networks := 0
+	for _, n := range networks {
+		if err := n.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := r.byID[n.ID]; exists {
+			return nil, fmt.Errorf("%w: duplicated network ID %d", ErrInvalidNetworkConfig, n.ID)
+		}
+		if _, exists := names[n.Name]; exists {
+			return nil, fmt.Errorf("%w: duplicated network name %s", ErrInvalidNetworkConfig, n.Name)
+		}
+		if n.Role == RoleL1 {
+			l1Networks++
+		}
+		names[n.Name] = struct{}{}
+		r.byID[n.ID] = n
+		r.networks = append(r.networks, n)
+	}
+	if l1Networks != 1 {
+		return nil, fmt.Errorf("%w: there must be exactly one L1 network, got %d", ErrInvalidNetworkConfig, l1Networks)
+	}
+	sort.Slice(r.networks, func(i, j int) bool { return r.networks[i].ID < r.networks[j].ID })
+	return r, nil
+}
+
+// DefaultNetworks returns the L1 and the given L2 network, with no endpoints nor addresses.
+// They are used when no networks are configured
+func DefaultNetworks(l2NetworkID uint32) []NetworkConfig {
+	return []NetworkConfig{
+		{ID: L1NetworkID, Name: "L1", Role: RoleL1},
+		{ID: l2NetworkID, Name: "L2", Role: RoleL2},
+	}
+}
+
+// NewDefaultRegistry creates a Registry with the DefaultNetworks
+func NewDefaultRegistry(l2NetworkID uint32) (*Registry, error) {
+	return NewRegistry(DefaultNetworks(l2NetworkID))
+}
+
+// Get returns the network with the given ID
+func (r *Registry) Get(networkID uint32) (NetworkConfig, error) {
+	n, ok := r.byID[networkID]
+	if !ok {
+		return NetworkConfig{}, fmt.Errorf("%w: %d", ErrUnknownNetwork, networkID)
+	}
+	return n, nil
+}
+
+// List returns all the networks sorted by ID
+func (r *Registry) List() []NetworkConfig {
+	result := make([]NetworkConfig, len(r.networks))
+	copy(result, r.networks)
+	return result
+}
+
+// L1 returns the L1 network
+func (r *Registry) L1() NetworkConfig {
+	// NewRegistry ensures there is always a L1 network (and it's the first one)
+	return r.networks[0]
+}
+
+// L2s returns the L2 networks sorted by ID
+func (r *Registry) L2s() []NetworkConfig {
+	result := make([]NetworkConfig, len(r.networks)-1)
+	copy(result, r.networks[1:])
+	return result
+}
+
+// IsL1 returns true if the network ID is the L1 one
+func (r *Registry) IsL1(networkID uint32) bool {
+	n, ok := r.byID[networkID]
+	return ok && n.Role == RoleL1
+}
+
+// IsL2 returns true if the network ID is one of the L2s
+func (r *Registry) IsL2(networkID uint32) bool {
+	n, ok := r.byID[networkID]
+	return ok && n.Role == RoleL2
+}
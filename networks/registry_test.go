@@ -0,0 +1,119 @@
+package networks
+
+import (
+	"testing"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRegistry(t *testing.T) {
+	t.Parallel()
+
+	l1 := NetworkConfig{ID: L1NetworkID, Name: "sepolia", Role: RoleL1, BlockFinality: aggkittypes.FinalizedBlock}
+	l2 := NetworkConfig{ID: 1, Name: "katana", Role: RoleL2, BlockFinality: aggkittypes.LatestBlock}
+	otherL2 := NetworkConfig{ID: 2, Name: "other", Role: RoleL2}
+
+	tests := []struct {
+		name        string
+		networks    []NetworkConfig
+		expectedErr string
+	}{
+		{
+			name:     "L1 and two L2s",
+			networks: []NetworkConfig{otherL2, l2, l1},
+		},
+		{
+			name:        "no L1",
+			networks:    []NetworkConfig{l2},
+			expectedErr: "there must be exactly one L1 network, got 0",
+		},
+		{
+			name:        "two L1s",
+			networks:    []NetworkConfig{l1, {ID: L1NetworkID, Name: "mainnet", Role: RoleL1}},
+			expectedErr: "duplicated network ID 0",
+		},
+		{
+			name:        "duplicated L2 id",
+			networks:    []NetworkConfig{l1, l2, {ID: l2.ID, Name: "other", Role: RoleL2}},
+			expectedErr: "duplicated network ID 1",
+		},
+		{
+			name:        "duplicated name",
+			networks:    []NetworkConfig{l1, l2, {ID: 2, Name: l2.Name, Role: RoleL2}},
+			expectedErr: "duplicated network name katana",
+		},
+		{
+			name:        "L1 with wrong id",
+			networks:    []NetworkConfig{{ID: 1, Name: "sepolia", Role: RoleL1}},
+			expectedErr: "must have network ID 0",
+		},
+		{
+			name:        "L2 with the L1 id",
+			networks:    []NetworkConfig{{ID: L1NetworkID, Name: "katana", Role: RoleL2}},
+			expectedErr: "can't use the L1 network ID",
+		},
+		{
+			name:        "invalid role",
+			networks:    []NetworkConfig{l1, {ID: 1, Name: "katana", Role: "L3"}},
+			expectedErr: `invalid role "L3"`,
+		},
+		{
+			name:        "no name",
+			networks:    []NetworkConfig{l1, {ID: 1, Role: RoleL2}},
+			expectedErr: "network 1 has no name",
+		},
+		{
+			name: "invalid finality",
+			networks: []NetworkConfig{l1,
+				{ID: 1, Name: "katana", Role: RoleL2, BlockFinality: aggkittypes.NewBlockNumberFinality("Wrong")}},
+			expectedErr: "invalid finality keyword",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := NewRegistry(tt.networks)
+			if tt.expectedErr != "" {
+				require.ErrorIs(t, err, ErrInvalidNetworkConfig)
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []NetworkConfig{l1, l2, otherL2}, r.List())
+		})
+	}
+}
+
+func TestRegistryLookups(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewDefaultRegistry(3)
+	require.NoError(t, err)
+
+	require.Equal(t, L1NetworkID, r.L1().ID)
+	require.Equal(t, RoleL1, r.L1().Role)
+	require.Len(t, r.L2s(), 1)
+	require.Equal(t, uint32(3), r.L2s()[0].ID)
+
+	require.True(t, r.IsL1(0))
+	require.False(t, r.IsL1(3))
+	require.True(t, r.IsL2(3))
+	require.False(t, r.IsL2(0))
+	require.False(t, r.IsL2(4))
+
+	n, err := r.Get(3)
+	require.NoError(t, err)
+	require.Equal(t, "L2", n.Name)
+
+	_, err = r.Get(4)
+	require.ErrorIs(t, err, ErrUnknownNetwork)
+
+	// the returned slices are copies
+	r.List()[0].Name = "changed"
+	r.L2s()[0].Name = "changed"
+	require.Equal(t, "L1", r.L1().Name)
+	require.Equal(t, "L2", r.L2s()[0].Name)
+}
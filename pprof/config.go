@@ -1,12 +1,63 @@
 package pprof
 
+import (
+	"errors"
+
+	"github.com/agglayer/aggkit/config/types"
+)
+
 // Config represents the configuration settings for the profiling server.
 // It includes options to specify the host, port, and whether profiling is enabled.
 type Config struct {
-	// ProfilingHost is the address to bind the profiling server
+	// ProfilingHost is the address to bind the profiling server (a host name, an IPv4 or IPv6 literal,
+	// or a unix domain socket unix:///path/to/socket, in which case the ProfilingPort is ignored)
 	ProfilingHost string `mapstructure:"ProfilingHost"`
 	// ProfilingPort is the port to bind the profiling server
 	ProfilingPort int `mapstructure:"ProfilingPort"`
 	// ProfilingEnabled is the flag to enable/disable the profiling server
 	ProfilingEnabled bool `mapstructure:"ProfilingEnabled"`
+	// Snapshots captures profiles to disk when the memory or the goroutines exceed the thresholds
+	Snapshots SnapshotsConfig `mapstructure:"Snapshots"`
+}
+
+// SnapshotsConfig captures the heap and goroutine profiles (and optionally a CPU profile) of the process to
+// disk when its heap or its number of goroutines exceed the thresholds, so the leaks can be diagnosed after
+// the fact without running pprof at the right moment
+type SnapshotsConfig struct {
+	// Enabled enables the checks of the thresholds
+	Enabled bool `mapstructure:"Enabled"`
+	// Dir is the directory where the snapshots are written, one subdirectory per snapshot
+	Dir string `mapstructure:"Dir"`
+	// CheckInterval is the interval of the checks of the thresholds
+	CheckInterval types.Duration `mapstructure:"CheckInterval"`
+	// HeapThresholdMiB is the size of the heap (allocated objects) from which a snapshot is captured (0 = not checked)
+	HeapThresholdMiB uint64 `mapstructure:"HeapThresholdMiB"`
+	// GoroutinesThreshold is the number of goroutines from which a snapshot is captured (0 = not checked)
+	GoroutinesThreshold uint64 `mapstructure:"GoroutinesThreshold"`
+	// CPUProfileDuration is the duration of the CPU profile included in the snapshots (0 = no CPU profile)
+	CPUProfileDuration types.Duration `mapstructure:"CPUProfileDuration"`
+	// Cooldown is the minimum time between two snapshots, so a sustained breach doesn't fill the disk
+	Cooldown types.Duration `mapstructure:"Cooldown"`
+	// MaxSnapshots is the number of snapshots kept in Dir, the oldest ones are removed
+	MaxSnapshots int `mapstructure:"MaxSnapshots"`
+}
+
+// Validate checks that the directory, the interval, a threshold and the rotation are set if the checks are enabled
+func (c SnapshotsConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Dir == "" {
+		return errors.New("Profiling.Snapshots.Dir must be set")
+	}
+	if c.CheckInterval.Duration <= 0 {
+		return errors.New("Profiling.Snapshots.CheckInterval must be greater than 0")
+	}
+	if c.HeapThresholdMiB == 0 && c.GoroutinesThreshold == 0 {
+		return errors.New("Profiling.Snapshots requires HeapThresholdMiB or GoroutinesThreshold")
+	}
+	if c.MaxSnapshots <= 0 {
+		return errors.New("Profiling.Snapshots.MaxSnapshots must be greater than 0")
+	}
+	return nil
 }
@@ -2,12 +2,11 @@ package pprof
 
 import (
 	"context"
-	"fmt"
-	"net"
 	"net/http"
 	"net/http/pprof"
 	"time"
 
+	aggkitcommon "github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/prometheus"
 )
@@ -24,18 +23,18 @@ const serverShutdownTimeout = 5 * time.Second
 //   - c (Config): The configuration object containing the profiling host and port.
 //
 // Behavior:
-//   - Logs an error and returns if the TCP listener cannot be created.
-//   - Logs the port on which the profiling server is listening.
+//   - Logs an error and returns if the listener (TCP or unix domain socket) cannot be created.
+//   - Logs the address on which the profiling server is listening.
 //   - Handles server closure gracefully, logging a warning if the server is stopped
 //     or an error if the connection is closed unexpectedly.
 //   - Supports graceful shutdown using context cancellation or timeout.
 func StartProfilingHTTPServer(ctx context.Context, c Config) {
 	const two = 2
 	mux := http.NewServeMux()
-	address := fmt.Sprintf("%s:%d", c.ProfilingHost, c.ProfilingPort)
-	lis, err := net.Listen("tcp", address)
+	address := aggkitcommon.ListenAddress(c.ProfilingHost, c.ProfilingPort)
+	lis, err := aggkitcommon.Listen(address)
 	if err != nil {
-		log.Errorf("failed to create tcp listener for profiling: %v", err)
+		log.Errorf("failed to create listener for profiling: %v", err)
 		return
 	}
 	mux.HandleFunc(prometheus.ProfilingIndexEndpoint, pprof.Index)
@@ -62,7 +61,7 @@ func StartProfilingHTTPServer(ctx context.Context, c Config) {
 		}
 	}()
 
-	log.Infof("profiling server listening on port %d", c.ProfilingPort)
+	log.Infof("profiling server listening on %s", address)
 	if err := profilingServer.Serve(lis); err != nil {
 		if err == http.ErrServerClosed {
 			log.Warnf("http server for profiling stopped")
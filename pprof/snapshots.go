@@ -0,0 +1,233 @@
+package pprof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/prometheus"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	bytesPerMiB = 1024 * 1024
+
+	snapshotTimeFormat   = "20060102T150405Z"
+	heapProfileFile      = "heap.pb.gz"
+	goroutineProfileFile = "goroutine.pb.gz"
+	cpuProfileFile       = "cpu.pb.gz"
+
+	snapshotReasonHeap       = "heap"
+	snapshotReasonGoroutines = "goroutines"
+
+	snapshotsMetricsReasonLabel = "reason"
+	snapshotsTotal              = "profiling_snapshots_total"
+)
+
+var registerSnapshotsMetricsOnce sync.Once
+
+// processStats are the values of the process compared with the thresholds
+type processStats struct {
+	heapBytes  uint64
+	goroutines uint64
+}
+
+// SnapshotMonitor checks periodically the heap and the goroutines of the process and, when they exceed
+// the thresholds, captures their profiles to disk (keeping the last MaxSnapshots) and alerts
+type SnapshotMonitor struct {
+	cfg    SnapshotsConfig
+	logger aggkitcommon.Logger
+
+	lastSnapshot time.Time
+	timeNowFn    func() time.Time
+	readStatsFn  func() processStats
+}
+
+// NewSnapshotMonitor creates a SnapshotMonitor. It returns nil if the snapshots are disabled
+func NewSnapshotMonitor(logger aggkitcommon.Logger, cfg SnapshotsConfig) *SnapshotMonitor {
+	if !cfg.Enabled {
+		return nil
+	}
+	registerSnapshotsMetrics()
+	return &SnapshotMonitor{
+		cfg:         cfg,
+		logger:      logger,
+		timeNowFn:   time.Now,
+		readStatsFn: readProcessStats,
+	}
+}
+
+// Start checks periodically the thresholds until the context is done
+func (m *SnapshotMonitor) Start(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.logger.Infof("profiling snapshots enabled: heap threshold %d MiB, goroutines threshold %d, dir %s",
+		m.cfg.HeapThresholdMiB, m.cfg.GoroutinesThreshold, m.cfg.Dir)
+	ticker := time.NewTicker(m.cfg.CheckInterval.Duration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := m.check(ctx); err != nil {
+				m.logger.Errorf("failed to capture the profiling snapshot: %v", err)
+			}
+		}
+	}
+}
+
+// check captures a snapshot if a threshold is exceeded and the cooldown since the last one has passed.
+// It returns the directory of the snapshot, or an empty string if none was captured
+func (m *SnapshotMonitor) check(ctx context.Context) (string, error) {
+	stats := m.readStatsFn()
+	var reasons, breaches []string
+	if m.cfg.HeapThresholdMiB > 0 && stats.heapBytes > m.cfg.HeapThresholdMiB*bytesPerMiB {
+		reasons = append(reasons, snapshotReasonHeap)
+		breaches = append(breaches, fmt.Sprintf("heap %d MiB > %d MiB (HeapThresholdMiB)",
+			stats.heapBytes/bytesPerMiB, m.cfg.HeapThresholdMiB))
+	}
+	if m.cfg.GoroutinesThreshold > 0 && stats.goroutines > m.cfg.GoroutinesThreshold {
+		reasons = append(reasons, snapshotReasonGoroutines)
+		breaches = append(breaches, fmt.Sprintf("%d goroutines > %d (GoroutinesThreshold)",
+			stats.goroutines, m.cfg.GoroutinesThreshold))
+	}
+	if len(reasons) == 0 {
+		return "", nil
+	}
+	now := m.timeNowFn()
+	if !m.lastSnapshot.IsZero() && now.Sub(m.lastSnapshot) < m.cfg.Cooldown.Duration {
+		m.logger.Debugf("thresholds exceeded (%s), snapshot skipped: last one captured at %s (Cooldown %s)",
+			strings.Join(breaches, ", "), m.lastSnapshot.UTC().Format(time.RFC3339), m.cfg.Cooldown)
+		return "", nil
+	}
+	m.lastSnapshot = now
+
+	for _, reason := range reasons {
+		prometheus.CounterVecInc(snapshotsTotal, reason)
+	}
+	dir := filepath.Join(m.cfg.Dir, now.UTC().Format(snapshotTimeFormat)+"-"+strings.Join(reasons, "-"))
+	if err := m.capture(ctx, dir); err != nil {
+		return "", fmt.Errorf("failed to capture the profiles in %s: %w", dir, err)
+	}
+	m.logger.Warnf("thresholds exceeded: %s. Profiles captured in %s", strings.Join(breaches, ", "), dir)
+	if err := m.rotate(); err != nil {
+		return dir, fmt.Errorf("failed to remove the oldest snapshots of %s: %w", m.cfg.Dir, err)
+	}
+	return dir, nil
+}
+
+// capture writes the heap and goroutine profiles, and the CPU profile if CPUProfileDuration is set, to dir
+func (m *SnapshotMonitor) capture(ctx context.Context, dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := writeProfile(filepath.Join(dir, heapProfileFile), "heap"); err != nil {
+		return err
+	}
+	if err := writeProfile(filepath.Join(dir, goroutineProfileFile), "goroutine"); err != nil {
+		return err
+	}
+	if m.cfg.CPUProfileDuration.Duration > 0 {
+		return writeCPUProfile(ctx, filepath.Join(dir, cpuProfileFile), m.cfg.CPUProfileDuration.Duration)
+	}
+	return nil
+}
+
+// rotate removes the oldest snapshots of Dir beyond MaxSnapshots. The names of the snapshots start with
+// their time, so they are sorted from the oldest
+func (m *SnapshotMonitor) rotate() error {
+	entries, err := os.ReadDir(m.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	snapshots := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		snapshotTime, _, _ := strings.Cut(entry.Name(), "-")
+		if _, err := time.Parse(snapshotTimeFormat, snapshotTime); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, entry.Name())
+	}
+	sort.Strings(snapshots)
+	for len(snapshots) > m.cfg.MaxSnapshots {
+		if err := os.RemoveAll(filepath.Join(m.cfg.Dir, snapshots[0])); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+func writeProfile(path, name string) error {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return fmt.Errorf("unknown profile %s", name)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := profile.WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write the %s profile: %w", name, err)
+	}
+	return f.Close()
+}
+
+// writeCPUProfile profiles the CPU for the duration, or until the context is done. It fails if a CPU
+// profile is already running (e.g. requested to the profiling server)
+func writeCPUProfile(ctx context.Context, path string, duration time.Duration) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		return errors.Join(fmt.Errorf("failed to start the CPU profile: %w", err), os.Remove(path))
+	}
+	timer := time.NewTimer(duration)
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	timer.Stop()
+	pprof.StopCPUProfile()
+	return f.Close()
+}
+
+func readProcessStats() processStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	return processStats{
+		heapBytes:  memStats.HeapAlloc,
+		goroutines: uint64(runtime.NumGoroutine()), //nolint:gosec
+	}
+}
+
+func registerSnapshotsMetrics() {
+	registerSnapshotsMetricsOnce.Do(func() {
+		prometheus.RegisterCounterVecs(
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: snapshotsTotal,
+					Help: "[PROFILING] number of profiling snapshots captured because a threshold was exceeded",
+				},
+				Labels: []string{snapshotsMetricsReasonLabel},
+			},
+		)
+	})
+}
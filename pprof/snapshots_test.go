@@ -0,0 +1,140 @@
+package pprof
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotsConfigValidate(t *testing.T) {
+	valid := SnapshotsConfig{
+		Enabled:          true,
+		Dir:              "/tmp/profiles",
+		CheckInterval:    types.NewDuration(time.Second),
+		HeapThresholdMiB: 1024,
+		MaxSnapshots:     1,
+	}
+	tests := []struct {
+		name   string
+		modify func(c *SnapshotsConfig)
+		errMsg string
+	}{
+		{name: "valid", modify: func(c *SnapshotsConfig) {}},
+		{name: "disabled", modify: func(c *SnapshotsConfig) { *c = SnapshotsConfig{} }},
+		{name: "no dir", modify: func(c *SnapshotsConfig) { c.Dir = "" }, errMsg: "Dir must be set"},
+		{
+			name:   "no interval",
+			modify: func(c *SnapshotsConfig) { c.CheckInterval = types.NewDuration(0) },
+			errMsg: "CheckInterval must be greater than 0",
+		},
+		{
+			name:   "no threshold",
+			modify: func(c *SnapshotsConfig) { c.HeapThresholdMiB = 0 },
+			errMsg: "requires HeapThresholdMiB or GoroutinesThreshold",
+		},
+		{
+			name:   "no rotation",
+			modify: func(c *SnapshotsConfig) { c.MaxSnapshots = 0 },
+			errMsg: "MaxSnapshots must be greater than 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestNewSnapshotMonitorDisabled(t *testing.T) {
+	sut := NewSnapshotMonitor(log.WithFields("test", "snapshots"), SnapshotsConfig{})
+	require.Nil(t, sut)
+	// a nil monitor does nothing
+	sut.Start(context.Background())
+}
+
+func TestSnapshotMonitorCheck(t *testing.T) {
+	dir := t.TempDir()
+	sut := NewSnapshotMonitor(log.WithFields("test", "snapshots"), SnapshotsConfig{
+		Enabled:             true,
+		Dir:                 dir,
+		CheckInterval:       types.NewDuration(time.Second),
+		HeapThresholdMiB:    100,
+		GoroutinesThreshold: 1000,
+		Cooldown:            types.NewDuration(time.Minute),
+		MaxSnapshots:        2,
+	})
+	require.NotNil(t, sut)
+	now := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	sut.timeNowFn = func() time.Time { return now }
+	stats := processStats{heapBytes: 100 * bytesPerMiB, goroutines: 1000}
+	sut.readStatsFn = func() processStats { return stats }
+	ctx := context.Background()
+
+	// the thresholds are not exceeded
+	snapshot, err := sut.check(ctx)
+	require.NoError(t, err)
+	require.Empty(t, snapshot)
+
+	stats.heapBytes++
+	snapshot, err = sut.check(ctx)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "20261014T093000Z-heap"), snapshot)
+	require.FileExists(t, filepath.Join(snapshot, heapProfileFile))
+	require.FileExists(t, filepath.Join(snapshot, goroutineProfileFile))
+	require.NoFileExists(t, filepath.Join(snapshot, cpuProfileFile))
+
+	// the cooldown has not passed
+	now = now.Add(time.Minute - time.Second)
+	stats.goroutines++
+	snapshot, err = sut.check(ctx)
+	require.NoError(t, err)
+	require.Empty(t, snapshot)
+
+	now = now.Add(time.Second)
+	snapshot, err = sut.check(ctx)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "20261014T093100Z-heap-goroutines"), snapshot)
+
+	// only the last MaxSnapshots are kept, the other files of the dir are not removed
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "other"), os.ModePerm))
+	now = now.Add(time.Minute)
+	snapshot, err = sut.check(ctx)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	require.Equal(t, []string{"20261014T093100Z-heap-goroutines", filepath.Base(snapshot), "notes.txt", "other"}, names)
+}
+
+func TestSnapshotMonitorCPUProfile(t *testing.T) {
+	sut := NewSnapshotMonitor(log.WithFields("test", "snapshots"), SnapshotsConfig{
+		Enabled:             true,
+		Dir:                 t.TempDir(),
+		CheckInterval:       types.NewDuration(time.Second),
+		GoroutinesThreshold: 1,
+		CPUProfileDuration:  types.NewDuration(10 * time.Millisecond),
+		MaxSnapshots:        1,
+	})
+	sut.readStatsFn = func() processStats { return processStats{goroutines: 2} }
+
+	snapshot, err := sut.check(context.Background())
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(snapshot, cpuProfileFile))
+}
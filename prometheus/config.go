@@ -4,7 +4,8 @@ package prometheus
 type Config struct {
 	// Enabled is the flag to enable/disable the metrics server
 	Enabled bool `mapstructure:"Enabled"`
-	// Host is the address to bind the metrics server
+	// Host is the address to bind the metrics server (a host name, an IPv4 or IPv6 literal,
+	// or a unix domain socket unix:///path/to/socket, in which case the Port is ignored)
 	Host string `mapstructure:"Host"`
 	// Port is the port to bind the metrics server
 	Port int `mapstructure:"Port"`
@@ -3,7 +3,9 @@ package reorgdetector
 import (
 	"time"
 
+	"github.com/agglayer/aggkit/common"
 	"github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/db"
 	aggkittypes "github.com/agglayer/aggkit/types"
 )
 
@@ -15,12 +17,38 @@ const (
 type Config struct {
 	// DBPath is the path to the database
 	DBPath string `mapstructure:"DBPath"`
+	// StorageTuning tunes the sqlite DB (journal mode, cache size, storage quota...)
+	StorageTuning db.SQLiteConfig `mapstructure:"StorageTuning"`
 
 	// CheckReorgsInterval is the interval to check for reorgs in tracked blocks
 	CheckReorgsInterval types.Duration `mapstructure:"CheckReorgsInterval"`
 	// FinalizedBlockType indicates the status of the blocks that will be queried in order to sync
 	// if finalizedBlock == "LatestBlock" then it's disabled and we assume the network has no chances of reorgs
 	FinalizedBlock aggkittypes.BlockNumberFinality `jsonschema:"enum=LatestBlock, enum=SafeBlock, enum=PendingBlock, enum=FinalizedBlock, enum=EarliestBlock" mapstructure:"FinalizedBlock"` //nolint:lll
+	// SubscriptionsServer exposes the detected reorgs over a websocket, so external processes
+	// (e.g. a bridge API replica or a prover) are notified of them
+	SubscriptionsServer SubscriptionsServerConfig `mapstructure:"SubscriptionsServer"`
+}
+
+// SubscriptionsServerConfig is the configuration of the websocket server of the reorg subscriptions
+type SubscriptionsServerConfig struct {
+	// Host is the hostname or IP address on which the server listens. It can also be a unix domain
+	// socket (unix:///path/to/socket), in which case the Port is ignored. Empty means disabled
+	Host string `mapstructure:"Host"`
+	// Port is the port of the server
+	Port int `mapstructure:"Port"`
+	// WriteTimeout is the timeout to write a notification to a consumer. The slow consumers are disconnected
+	WriteTimeout types.Duration `mapstructure:"WriteTimeout"`
+}
+
+// IsEnabled returns true if the subscriptions server is configured
+func (c SubscriptionsServerConfig) IsEnabled() bool {
+	return c.Host != ""
+}
+
+// Address returns the listen address of the server
+func (c SubscriptionsServerConfig) Address() string {
+	return common.ListenAddress(c.Host, c.Port)
 }
 
 // GetCheckReorgsInterval returns the interval to check for reorgs in tracked blocks
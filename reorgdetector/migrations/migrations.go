@@ -13,7 +13,8 @@ var mig001 string
 //go:embed reorgdetector0002.sql
 var mig002 string
 
-func RunMigrations(dbPath string) error {
+// GetMigrations returns the migrations of the reorgdetector DB
+func GetMigrations() []types.Migration {
 	migrations := []types.Migration{
 		{
 			ID:  "reorgdetector0001",
@@ -24,5 +25,9 @@ func RunMigrations(dbPath string) error {
 			SQL: mig002,
 		},
 	}
-	return db.RunMigrations(dbPath, migrations)
+	return migrations
+}
+
+func RunMigrations(dbPath string) error {
+	return db.RunMigrations(dbPath, GetMigrations())
 }
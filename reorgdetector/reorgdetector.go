@@ -42,16 +42,25 @@ type ReorgDetector struct {
 	subscriptionsLock sync.RWMutex
 	subscriptions     map[string]*Subscription
 
+	watchersLock  sync.RWMutex
+	watchers      map[uint64]chan ReorgNotification
+	nextWatcherID uint64
+
+	subscriptionsServerCfg SubscriptionsServerConfig
+
 	log *log.Logger
 }
 
 func New(client aggkittypes.BaseEthereumClienter, cfg Config, network Network) (*ReorgDetector, error) {
 	log := log.WithFields("reorg-detector", network.String())
+	if err := cfg.StorageTuning.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid storage tuning: %w", err)
+	}
 	err := migrations.RunMigrations(cfg.DBPath)
 	if err != nil {
 		return nil, err
 	}
-	db, err := db.NewSQLiteDB(cfg.DBPath)
+	db, err := db.NewSQLiteDBWithConfig(cfg.DBPath, cfg.StorageTuning)
 	if err != nil {
 		return nil, err
 	}
@@ -66,15 +75,17 @@ func New(client aggkittypes.BaseEthereumClienter, cfg Config, network Network) (
 	}
 
 	return &ReorgDetector{
-		client:               client,
-		db:                   db,
-		checkReorgInterval:   cfg.GetCheckReorgsInterval(),
-		finalizedBlockType:   cfg.FinalizedBlock,
-		finalizedBlockNumber: finalizedBlockNumber,
-		network:              network,
-		trackedBlocks:        make(map[string]*headersList),
-		subscriptions:        make(map[string]*Subscription),
-		log:                  log,
+		client:                 client,
+		db:                     db,
+		checkReorgInterval:     cfg.GetCheckReorgsInterval(),
+		finalizedBlockType:     cfg.FinalizedBlock,
+		finalizedBlockNumber:   finalizedBlockNumber,
+		network:                network,
+		trackedBlocks:          make(map[string]*headersList),
+		subscriptions:          make(map[string]*Subscription),
+		watchers:               make(map[uint64]chan ReorgNotification),
+		subscriptionsServerCfg: cfg.SubscriptionsServer,
+		log:                    log,
 	}, nil
 }
 
@@ -89,6 +100,13 @@ func (rd *ReorgDetector) Start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to load tracked headers: %w", err)
 	}
 
+	// Expose the reorgs to the external consumers
+	if rd.subscriptionsServerCfg.IsEnabled() {
+		if err = newSubscriptionsServer(rd, rd.subscriptionsServerCfg).start(ctx); err != nil {
+			return fmt.Errorf("failed to start the reorg subscriptions server: %w", err)
+		}
+	}
+
 	// Continuously check reorgs in tracked by subscribers blocks
 	go func() {
 		ticker := time.NewTicker(rd.checkReorgInterval)
@@ -167,6 +185,20 @@ func (rd *ReorgDetector) detectReorgInTrackedList(ctx context.Context) error {
 		}
 		errGroup errgroup.Group
 	)
+	// getCurrentHeader gets the actual header from the network or from the cache
+	getCurrentHeader := func(num uint64) (*types.Header, error) {
+		headersCacheLock.Lock()
+		defer headersCacheLock.Unlock()
+		if currentHeader, ok := headersCache[num]; ok && currentHeader != nil {
+			return currentHeader, nil
+		}
+		currentHeader, err := rd.client.HeaderByNumber(ctx, new(big.Int).SetUint64(num))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the header %d: %w", num, err)
+		}
+		headersCache[num] = currentHeader
+		return currentHeader, nil
+	}
 
 	subscriberIDs := rd.getSubscriberIDs()
 	startTime := time.Now()
@@ -187,19 +219,11 @@ func (rd *ReorgDetector) detectReorgInTrackedList(ctx context.Context) error {
 
 		errGroup.Go(func() error {
 			headers := hdrs.getSorted()
-			for _, hdr := range headers {
-				// Get the actual header from the network or from the cache
-				var err error
-				headersCacheLock.Lock()
-				currentHeader, ok := headersCache[hdr.Num]
-				if !ok || currentHeader == nil {
-					if currentHeader, err = rd.client.HeaderByNumber(ctx, new(big.Int).SetUint64(hdr.Num)); err != nil {
-						headersCacheLock.Unlock()
-						return fmt.Errorf("failed to get the header %d: %w", hdr.Num, err)
-					}
-					headersCache[hdr.Num] = currentHeader
+			for i, hdr := range headers {
+				currentHeader, err := getCurrentHeader(hdr.Num)
+				if err != nil {
+					return err
 				}
-				headersCacheLock.Unlock()
 
 				// Check if the block hash matches with the actual block hash
 				if hdr.Hash == currentHeader.Hash() {
@@ -238,6 +262,11 @@ func (rd *ReorgDetector) detectReorgInTrackedList(ctx context.Context) error {
 				}
 				// Remove the reorged block and all the following blocks from memory
 				hdrs.removeRange(event.FromBlock, event.ToBlock)
+				// Notify the external watchers once the subscriber has processed the reorg
+				if rd.hasWatchers() {
+					rd.broadcastReorg(newReorgNotification(rd.network, event,
+						canonicalBlocks(headers[i:], getCurrentHeader, rd.log)))
+				}
 
 				break
 			}
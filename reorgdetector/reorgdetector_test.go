@@ -10,6 +10,7 @@ import (
 	"time"
 
 	cfgtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/agglayer/aggkit/test/reorgsim"
 	aggkittypes "github.com/agglayer/aggkit/types"
 	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
 	common "github.com/ethereum/go-ethereum/common"
@@ -106,6 +107,70 @@ func Test_ReorgDetector(t *testing.T) {
 	require.Equal(t, header2.Hash(), actualHeader2.Hash)
 }
 
+func Test_ReorgDetectorConsecutiveReorgs(t *testing.T) {
+	const subID = "test"
+
+	ctx := context.Background()
+	clientL1 := simulated.NewBackend(nil, simulated.WithBlockGasLimit(10000000))
+	reorgDetector, err := New(clientL1.Client(),
+		Config{
+			DBPath:              path.Join(t.TempDir(), "reorgdetectorTest_ReorgDetectorConsecutiveReorgs.sqlite"),
+			CheckReorgsInterval: cfgtypes.NewDuration(time.Millisecond * 100),
+			FinalizedBlock:      aggkittypes.FinalizedBlock,
+		}, L1)
+	require.NoError(t, err)
+	require.NoError(t, reorgDetector.Start(ctx))
+	reorgSub, err := reorgDetector.Subscribe(subID)
+	require.NoError(t, err)
+
+	sim := reorgsim.New(t, clientL1)
+	trackBlocks := reorgsim.Check(func(t *testing.T, s *reorgsim.Simulator) {
+		t.Helper()
+		for blockNum := uint64(1); blockNum <= s.Head().Number.Uint64(); blockNum++ {
+			header := s.HeaderByNumber(blockNum)
+			require.NoError(t, reorgDetector.AddBlockToTrack(ctx, subID, blockNum, header.Hash()))
+		}
+	})
+	requireTrackedBlocks := func(expected int) reorgsim.Step {
+		return reorgsim.Check(func(t *testing.T, s *reorgsim.Simulator) {
+			t.Helper()
+			// just wait a little for completion
+			time.Sleep(time.Second / 5)
+			reorgDetector.trackedBlocksLock.Lock()
+			defer reorgDetector.trackedBlocksLock.Unlock()
+			require.Equal(t, expected, reorgDetector.trackedBlocks[subID].len())
+		})
+	}
+
+	sim.Run(
+		reorgsim.Commit(2),
+		reorgsim.Mark("block2"),
+		reorgsim.Commit(3),
+		reorgsim.Mark("block5"),
+		trackBlocks,
+		// the new canonical chain is longer than the previous one so the reorg is visible to the detector
+		reorgsim.ForkFrom("block2"),
+		reorgsim.Commit(4),
+		reorgsim.Check(func(t *testing.T, s *reorgsim.Simulator) {
+			t.Helper()
+			s.RequireReorged(s.Marked("block5"))
+			reorgsim.RequireFirstReorgedBlock(t, reorgSub.ReorgedBlock, reorgSub.ReorgProcessed, 3, 5*time.Second)
+		}),
+		requireTrackedBlocks(2),
+		// the blocks of the new chain are tracked, and reorged again
+		trackBlocks,
+		requireTrackedBlocks(6),
+		reorgsim.ForkBack(2),
+		reorgsim.Commit(3),
+		reorgsim.Check(func(t *testing.T, s *reorgsim.Simulator) {
+			t.Helper()
+			reorgsim.RequireFirstReorgedBlock(t, reorgSub.ReorgedBlock, reorgSub.ReorgProcessed, 5, 5*time.Second)
+		}),
+		requireTrackedBlocks(4),
+	)
+	require.Len(t, sim.Forks(), 2)
+}
+
 func TestGetTrackedBlocks(t *testing.T) {
 	clientL1 := simulated.NewBackend(nil, simulated.WithBlockGasLimit(10000000))
 	testDir := path.Join(t.TempDir(), "reorgdetector_TestGetTrackedBlocks.sqlite")
@@ -0,0 +1,109 @@
+package reorgdetector
+
+import (
+	"github.com/agglayer/aggkit/log"
+	common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// watcherBufferSize is the number of notifications buffered for each watcher.
+// A watcher that doesn't keep up is closed, so it never blocks the detection of reorgs
+const watcherBufferSize = 16
+
+// BlockHash is the number and hash of a block
+type BlockHash struct {
+	Num  uint64      `json:"num"`
+	Hash common.Hash `json:"hash"`
+}
+
+// ReorgNotification is the notification of a reorg sent to the watchers (the external consumers)
+type ReorgNotification struct {
+	Network Network `json:"network"`
+	// SubscriberID is the syncer whose tracked blocks have been reorged
+	SubscriberID string `json:"subscriber_id"`
+	DetectedAt   int64  `json:"detected_at"`
+	// FirstReorgedBlock is the first tracked block whose hash has changed
+	FirstReorgedBlock uint64 `json:"first_reorged_block"`
+	// ToBlock is the last tracked block removed by the reorg
+	ToBlock     uint64      `json:"to_block"`
+	ReorgedHash common.Hash `json:"reorged_hash"`
+	// CanonicalBlocks are the new canonical hashes of the tracked blocks from FirstReorgedBlock
+	CanonicalBlocks []BlockHash `json:"canonical_blocks"`
+}
+
+func newReorgNotification(network Network, event ReorgEvent, canonical []BlockHash) ReorgNotification {
+	return ReorgNotification{
+		Network:           network,
+		SubscriberID:      event.SubscriberID,
+		DetectedAt:        event.DetectedAt,
+		FirstReorgedBlock: event.FromBlock,
+		ToBlock:           event.ToBlock,
+		ReorgedHash:       event.TrackedHash,
+		CanonicalBlocks:   canonical,
+	}
+}
+
+// canonicalBlocks returns the current hashes of the reorged headers. If a header can't be queried,
+// the hashes of the previous ones are returned
+func canonicalBlocks(reorged []header, getCurrentHeader func(uint64) (*types.Header, error),
+	logger *log.Logger) []BlockHash {
+	canonical := make([]BlockHash, 0, len(reorged))
+	for _, hdr := range reorged {
+		currentHeader, err := getCurrentHeader(hdr.Num)
+		if err != nil {
+			logger.Warnf("error getting the canonical hash of the reorged block %d: %v", hdr.Num, err)
+			break
+		}
+		canonical = append(canonical, BlockHash{Num: hdr.Num, Hash: currentHeader.Hash()})
+	}
+	return canonical
+}
+
+// Watch returns a channel where all the reorgs detected (for any subscriber) are notified, and the
+// function to stop watching. The channel is closed if the watcher doesn't keep up with the notifications
+func (rd *ReorgDetector) Watch() (<-chan ReorgNotification, func()) {
+	rd.watchersLock.Lock()
+	defer rd.watchersLock.Unlock()
+
+	id := rd.nextWatcherID
+	rd.nextWatcherID++
+	ch := make(chan ReorgNotification, watcherBufferSize)
+	rd.watchers[id] = ch
+
+	return ch, func() { rd.removeWatcher(id) }
+}
+
+// removeWatcher removes and closes the channel of a watcher, if it's still watching
+func (rd *ReorgDetector) removeWatcher(id uint64) {
+	rd.watchersLock.Lock()
+	defer rd.watchersLock.Unlock()
+
+	if ch, ok := rd.watchers[id]; ok {
+		delete(rd.watchers, id)
+		close(ch)
+	}
+}
+
+// hasWatchers returns true if there is any watcher
+func (rd *ReorgDetector) hasWatchers() bool {
+	rd.watchersLock.RLock()
+	defer rd.watchersLock.RUnlock()
+
+	return len(rd.watchers) > 0
+}
+
+// broadcastReorg notifies the reorg to all the watchers without blocking
+func (rd *ReorgDetector) broadcastReorg(notification ReorgNotification) {
+	rd.watchersLock.Lock()
+	defer rd.watchersLock.Unlock()
+
+	for id, ch := range rd.watchers {
+		select {
+		case ch <- notification:
+		default:
+			rd.log.Warnf("reorg watcher %d doesn't keep up with the notifications, closing it", id)
+			delete(rd.watchers, id)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package reorgdetector
+
+import (
+	"context"
+	"math/big"
+	"path"
+	"testing"
+	"time"
+
+	cfgtypes "github.com/agglayer/aggkit/config/types"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	common "github.com/ethereum/go-ethereum/common"
+	types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReorgDetector(t *testing.T, client *aggkittypesmocks.BaseEthereumClienter) *ReorgDetector {
+	t.Helper()
+
+	testDir := path.Join(t.TempDir(), "reorgdetectorTestWatch.sqlite")
+	reorgDetector, err := New(client, Config{DBPath: testDir, CheckReorgsInterval: cfgtypes.NewDuration(time.Millisecond * 100)}, L1)
+	require.NoError(t, err)
+	return reorgDetector
+}
+
+func TestWatchReorgNotification(t *testing.T) {
+	ctx := context.Background()
+	syncerID := "test-syncer"
+	trackedBlock9 := &types.Header{Number: big.NewInt(9)}
+	trackedBlock10 := &types.Header{Number: big.NewInt(10)}
+	reorgedBlock9 := &types.Header{Number: big.NewInt(9), Extra: []byte("reorged")}
+	reorgedBlock10 := &types.Header{Number: big.NewInt(10), Extra: []byte("reorged")}
+
+	client := aggkittypesmocks.NewBaseEthereumClienter(t)
+	client.EXPECT().HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber))).
+		Return(&types.Header{Number: big.NewInt(5)}, nil)
+	client.EXPECT().HeaderByNumber(ctx, big.NewInt(9)).Return(reorgedBlock9, nil).Once()
+	client.EXPECT().HeaderByNumber(ctx, big.NewInt(10)).Return(reorgedBlock10, nil).Once()
+	reorgDetector := newTestReorgDetector(t, client)
+
+	subscription, err := reorgDetector.Subscribe(syncerID)
+	require.NoError(t, err)
+	go func() {
+		<-subscription.ReorgedBlock
+		subscription.ReorgProcessed <- true
+	}()
+	require.NoError(t, reorgDetector.AddBlockToTrack(ctx, syncerID, 9, trackedBlock9.Hash()))
+	require.NoError(t, reorgDetector.AddBlockToTrack(ctx, syncerID, 10, trackedBlock10.Hash()))
+
+	notifications, stop := reorgDetector.Watch()
+	defer stop()
+	require.NoError(t, reorgDetector.detectReorgInTrackedList(ctx))
+
+	select {
+	case notification := <-notifications:
+		require.Equal(t, L1, notification.Network)
+		require.Equal(t, syncerID, notification.SubscriberID)
+		require.Equal(t, uint64(9), notification.FirstReorgedBlock)
+		require.Equal(t, uint64(10), notification.ToBlock)
+		require.Equal(t, trackedBlock9.Hash(), notification.ReorgedHash)
+		require.Equal(t, []BlockHash{
+			{Num: 9, Hash: reorgedBlock9.Hash()},
+			{Num: 10, Hash: reorgedBlock10.Hash()},
+		}, notification.CanonicalBlocks)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the reorg notification")
+	}
+}
+
+func TestWatchStop(t *testing.T) {
+	reorgDetector := newTestReorgDetector(t, aggkittypesmocks.NewBaseEthereumClienter(t))
+
+	notifications, stop := reorgDetector.Watch()
+	require.True(t, reorgDetector.hasWatchers())
+	stop()
+	stop() // stopping twice is harmless
+	require.False(t, reorgDetector.hasWatchers())
+	_, ok := <-notifications
+	require.False(t, ok)
+}
+
+func TestWatchSlowWatcherIsClosed(t *testing.T) {
+	reorgDetector := newTestReorgDetector(t, aggkittypesmocks.NewBaseEthereumClienter(t))
+
+	slow, stopSlow := reorgDetector.Watch()
+	defer stopSlow()
+	for i := 0; i <= watcherBufferSize; i++ {
+		reorgDetector.broadcastReorg(ReorgNotification{FirstReorgedBlock: uint64(i)})
+	}
+	require.False(t, reorgDetector.hasWatchers())
+
+	received := 0
+	for range slow {
+		received++
+	}
+	require.Equal(t, watcherBufferSize, received)
+}
+
+func TestCanonicalBlocksStopsOnError(t *testing.T) {
+	reorged := []header{newHeader(1, common.Hash{}), newHeader(2, common.Hash{})}
+	header1 := &types.Header{Number: big.NewInt(1)}
+	getCurrentHeader := func(num uint64) (*types.Header, error) {
+		if num == 1 {
+			return header1, nil
+		}
+		return nil, context.DeadlineExceeded
+	}
+
+	reorgDetector := newTestReorgDetector(t, aggkittypesmocks.NewBaseEthereumClienter(t))
+	require.Equal(t, []BlockHash{{Num: 1, Hash: header1.Hash()}},
+		canonicalBlocks(reorged, getCurrentHeader, reorgDetector.log))
+}
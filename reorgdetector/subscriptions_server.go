@@ -0,0 +1,140 @@
+package reorgdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/log"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// SubscriptionsPath is the path of the websocket endpoint of the reorg subscriptions
+	SubscriptionsPath = "/reorgs"
+	// SubscriberIDParam is the query parameter to receive only the reorgs of a subscriber (syncer)
+	SubscriberIDParam = "subscriber_id"
+
+	defaultSubscriptionsWriteTimeout = 5 * time.Second
+	subscriptionsReadHeaderTimeout   = 5 * time.Second
+)
+
+// subscriptionsServer notifies the reorgs detected to the external consumers connected to its
+// websocket endpoint. Each notification is a JSON ReorgNotification. A consumer that doesn't keep
+// up is disconnected with the close code 1013 (try again later) and it must resync on reconnection
+type subscriptionsServer struct {
+	rd           *ReorgDetector
+	address      string
+	writeTimeout time.Duration
+	upgrader     websocket.Upgrader
+	log          *log.Logger
+}
+
+func newSubscriptionsServer(rd *ReorgDetector, cfg SubscriptionsServerConfig) *subscriptionsServer {
+	writeTimeout := cfg.WriteTimeout.Duration
+	if writeTimeout == 0 {
+		writeTimeout = defaultSubscriptionsWriteTimeout
+	}
+	return &subscriptionsServer{
+		rd:           rd,
+		address:      cfg.Address(),
+		writeTimeout: writeTimeout,
+		log:          rd.log,
+	}
+}
+
+// start listens on the address of the server and serves the subscriptions until the context is done
+func (s *subscriptionsServer) start(ctx context.Context) error {
+	lis, err := common.Listen(s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+	srv := &http.Server{
+		Handler:           s.handler(ctx),
+		ReadHeaderTimeout: subscriptionsReadHeaderTimeout,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := srv.Close(); err != nil {
+			s.log.Errorf("reorg subscriptions server shutdown error: %v", err)
+		}
+	}()
+	go func() {
+		s.log.Infof("reorg subscriptions server listening on %s%s", s.address, SubscriptionsPath)
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.Errorf("reorg subscriptions server error: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (s *subscriptionsServer) handler(ctx context.Context) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(SubscriptionsPath, func(w http.ResponseWriter, r *http.Request) {
+		s.serveSubscription(ctx, w, r)
+	})
+	return mux
+}
+
+// serveSubscription upgrades the request to a websocket and writes the reorg notifications to it
+// until the consumer disconnects or the context is done
+func (s *subscriptionsServer) serveSubscription(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	subscriberID := r.URL.Query().Get(SubscriberIDParam)
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Warnf("error upgrading the reorg subscription of %s: %v", r.RemoteAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	notifications, stop := s.rd.Watch()
+	defer stop()
+	s.log.Infof("reorg consumer %s subscribed (subscriber filter: %q)", r.RemoteAddr, subscriberID)
+	defer s.log.Infof("reorg consumer %s unsubscribed", r.RemoteAddr)
+
+	// the consumers don't send messages, the reads only detect the disconnection
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.writeClose(conn, websocket.CloseGoingAway, "shutting down")
+			return
+		case <-disconnected:
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				s.writeClose(conn, websocket.CloseTryAgainLater, "too slow consuming the reorg notifications")
+				return
+			}
+			if subscriberID != "" && notification.SubscriberID != subscriberID {
+				continue
+			}
+			if err := conn.SetWriteDeadline(time.Now().Add(s.writeTimeout)); err != nil {
+				return
+			}
+			if err := conn.WriteJSON(notification); err != nil {
+				s.log.Warnf("error notifying the reorg to the consumer %s: %v", r.RemoteAddr, err)
+				return
+			}
+		}
+	}
+}
+
+func (s *subscriptionsServer) writeClose(conn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(s.writeTimeout)); err != nil {
+		s.log.Debugf("error closing the reorg subscription: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package reorgdetector
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func dialTestSubscription(t *testing.T, srv *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + SubscriptionsPath + query
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestSubscriptionsServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reorgDetector := newTestReorgDetector(t, aggkittypesmocks.NewBaseEthereumClienter(t))
+	server := newSubscriptionsServer(reorgDetector, SubscriptionsServerConfig{Host: "127.0.0.1"})
+	srv := httptest.NewServer(server.handler(ctx))
+	defer srv.Close()
+
+	all := dialTestSubscription(t, srv, "")
+	filtered := dialTestSubscription(t, srv, "?"+SubscriberIDParam+"=l1InfoTreeSyncer")
+	require.Eventually(t, func() bool {
+		reorgDetector.watchersLock.RLock()
+		defer reorgDetector.watchersLock.RUnlock()
+		return len(reorgDetector.watchers) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	reorgDetector.broadcastReorg(ReorgNotification{Network: L1, SubscriberID: "L1BridgeSyncer", FirstReorgedBlock: 10})
+	reorgDetector.broadcastReorg(ReorgNotification{Network: L1, SubscriberID: "l1InfoTreeSyncer", FirstReorgedBlock: 20,
+		CanonicalBlocks: []BlockHash{{Num: 20}}})
+
+	var notification ReorgNotification
+	require.NoError(t, all.ReadJSON(&notification))
+	require.Equal(t, "L1BridgeSyncer", notification.SubscriberID)
+	require.Equal(t, uint64(10), notification.FirstReorgedBlock)
+	require.NoError(t, all.ReadJSON(&notification))
+	require.Equal(t, "l1InfoTreeSyncer", notification.SubscriberID)
+
+	require.NoError(t, filtered.ReadJSON(&notification))
+	require.Equal(t, "l1InfoTreeSyncer", notification.SubscriberID)
+	require.Equal(t, uint64(20), notification.FirstReorgedBlock)
+	require.Equal(t, []BlockHash{{Num: 20}}, notification.CanonicalBlocks)
+
+	// the consumers are disconnected on shutdown
+	cancel()
+	_, _, err := all.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func TestSubscriptionsServerConfig(t *testing.T) {
+	require.False(t, SubscriptionsServerConfig{}.IsEnabled())
+	require.True(t, SubscriptionsServerConfig{Host: "unix:///tmp/reorgs.sock"}.IsEnabled())
+	require.Equal(t, "127.0.0.1:5580", SubscriptionsServerConfig{Host: "127.0.0.1", Port: 5580}.Address())
+}
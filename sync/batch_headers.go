@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxHeadersBatchSize is the maximum number of headers requested in a single batch request, the
+// providers limit the size of the batches
+const maxHeadersBatchSize = 100
+
+// EnableBatchHeaderRequests requests the headers of the blocks with events of each range, and the header
+// of the last block of the range (reported if it has no events), in JSON-RPC batch requests instead of one
+// request per block. If a batch request fails, the headers are requested one by one, and the batches are
+// disabled if the RPC client doesn't support them. It requires an eth client that supports batch requests
+func (d *EVMDownloader) EnableBatchHeaderRequests() error {
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	if !ok {
+		return errors.New("the downloader doesn't support batch header requests")
+	}
+	if _, ok := impl.ethClient.(aggkittypes.RPCBatchCaller); !ok {
+		return fmt.Errorf("batch header requests: %w", aggkittypes.ErrBatchCallNotSupported)
+	}
+	impl.batchHeaders.Store(true)
+	d.log.Infof("batch header requests enabled (max batch size: %d)", maxHeadersBatchSize)
+	return nil
+}
+
+// prefetchHeaders requests the headers of the blocks in batch requests. The headers are kept until
+// the next prefetch, and getHeader requests one by one the ones that are not prefetched
+func (d *EVMDownloaderImplementation) prefetchHeaders(ctx context.Context, blockNums []uint64) {
+	d.prefetchedMu.Lock()
+	defer d.prefetchedMu.Unlock()
+	d.prefetched = nil
+	if !d.batchHeaders.Load() || len(blockNums) <= 1 {
+		return
+	}
+	batchCaller, ok := d.ethClient.(aggkittypes.RPCBatchCaller)
+	if !ok {
+		return
+	}
+	d.prefetched = make(map[uint64]EVMBlockHeader, len(blockNums))
+	for chunk := range slices.Chunk(blockNums, maxHeadersBatchSize) {
+		headers := make([]*types.Header, len(chunk))
+		batch := make([]rpc.BatchElem, len(chunk))
+		for i, blockNum := range chunk {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []any{hexutil.EncodeUint64(blockNum), false},
+				Result: &headers[i],
+			}
+		}
+		err := batchCaller.BatchCallContext(ctx, batch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			headerBatchFallback(d.syncerID)
+			if errors.Is(err, aggkittypes.ErrBatchCallNotSupported) {
+				d.log.Warnf("the eth client doesn't support batch requests, the headers are requested one by one")
+				d.batchHeaders.Store(false)
+				return
+			}
+			d.log.Warnf("error requesting the headers of blocks %d-%d in a batch, requesting them one by one: %v",
+				chunk[0], chunk[len(chunk)-1], err)
+			return
+		}
+		batched := 0
+		for i, blockNum := range chunk {
+			// the headers not found or with errors are requested one by one
+			if batch[i].Error != nil || headers[i] == nil {
+				continue
+			}
+			d.prefetched[blockNum] = EVMBlockHeader{
+				Num:        headers[i].Number.Uint64(),
+				Hash:       headers[i].Hash(),
+				ParentHash: headers[i].ParentHash,
+				Timestamp:  headers[i].Time,
+			}
+			batched++
+		}
+		headersBatched(d.syncerID, batched)
+	}
+}
+
+// takePrefetchedHeader returns and forgets the prefetched header of the block, if any
+func (d *EVMDownloaderImplementation) takePrefetchedHeader(blockNum uint64) (EVMBlockHeader, bool) {
+	d.prefetchedMu.Lock()
+	defer d.prefetchedMu.Unlock()
+	header, ok := d.prefetched[blockNum]
+	if ok {
+		delete(d.prefetched, blockNum)
+	}
+	return header, ok
+}
+
+// headerBlockNums returns the distinct block numbers of the logs and the last block of the range
+func headerBlockNums(logs []types.Log, toBlock uint64) []uint64 {
+	blockNums := make([]uint64, 0, len(logs)+1)
+	for _, l := range logs {
+		if len(blockNums) == 0 || blockNums[len(blockNums)-1] != l.BlockNumber {
+			blockNums = append(blockNums, l.BlockNumber)
+		}
+	}
+	if len(blockNums) == 0 || blockNums[len(blockNums)-1] < toBlock {
+		blockNums = append(blockNums, toBlock)
+	}
+	return blockNums
+}
@@ -0,0 +1,156 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	aggkittypes "github.com/agglayer/aggkit/types"
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// batchEthClient is an eth client mock that supports RPC batch requests
+type batchEthClient struct {
+	*aggkittypesmocks.BaseEthereumClienter
+	batchFn func(ctx context.Context, b []rpc.BatchElem) error
+}
+
+func (c *batchEthClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.batchFn(ctx, b)
+}
+
+// newBatchHeadersTestDownloader returns a downloader with the batch header requests enabled, whose batch
+// requests are answered by batchFn
+func newBatchHeadersTestDownloader(t *testing.T,
+	batchFn func(ctx context.Context, b []rpc.BatchElem) error) (*EVMDownloader, *aggkittypesmocks.BaseEthereumClienter) {
+	t.Helper()
+
+	rh := &RetryHandler{MaxRetryAttemptsAfterError: 5, RetryAfterErrorPeriod: time.Millisecond}
+	clientMock := aggkittypesmocks.NewBaseEthereumClienter(t)
+	client := &batchEthClient{BaseEthereumClienter: clientMock, batchFn: batchFn}
+	d, err := NewEVMDownloader("test", client, syncBlockChunck, aggkittypes.LatestBlock, time.Millisecond,
+		buildAppender(), []common.Address{contractAddr}, rh, aggkittypes.FinalizedBlock)
+	require.NoError(t, err)
+	require.NoError(t, d.EnableBatchHeaderRequests())
+	return d, clientMock
+}
+
+// headerOf returns the header of the block of the logs generated by generateEvent
+func headerOf(blockNum int64) *types.Header {
+	return &types.Header{Number: big.NewInt(blockNum), ParentHash: common.HexToHash("foo")}
+}
+
+func TestEnableBatchHeaderRequests(t *testing.T) {
+	d, _ := NewTestDownloader(t, time.Millisecond)
+	require.ErrorIs(t, d.EnableBatchHeaderRequests(), aggkittypes.ErrBatchCallNotSupported)
+
+	d, _ = newBatchHeadersTestDownloader(t, nil)
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	require.True(t, ok)
+	require.True(t, impl.batchHeaders.Load())
+}
+
+func TestGetEventsByBlockRangeBatchHeaders(t *testing.T) {
+	ctx := context.Background()
+	log3, _ := generateEvent(3)
+	log4, _ := generateEvent(4)
+	query := ethereum.FilterQuery{
+		Topics:    [][]common.Hash{{eventSignature}},
+		FromBlock: big.NewInt(3),
+		Addresses: []common.Address{contractAddr},
+		ToBlock:   big.NewInt(6),
+	}
+
+	t.Run("the headers are requested in a batch", func(t *testing.T) {
+		batchCalls := 0
+		d, clientMock := newBatchHeadersTestDownloader(t, func(ctx context.Context, b []rpc.BatchElem) error {
+			batchCalls++
+			require.Len(t, b, 3)
+			for i, blockNum := range []uint64{3, 4, 6} {
+				require.Equal(t, "eth_getBlockByNumber", b[i].Method)
+				require.Equal(t, []any{hexutil.EncodeUint64(blockNum), false}, b[i].Args)
+				result, ok := b[i].Result.(**types.Header)
+				require.True(t, ok)
+				if blockNum == 4 {
+					// not found, it's requested one by one
+					continue
+				}
+				*result = headerOf(int64(blockNum))
+			}
+			return nil
+		})
+		clientMock.EXPECT().FilterLogs(mock.Anything, query).Return([]types.Log{*log3, *log4}, nil).Once()
+		clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(4)).Return(headerOf(4), nil).Once()
+
+		blocks := d.GetEventsByBlockRange(ctx, 3, 6)
+		require.Equal(t, 1, batchCalls)
+		require.Len(t, blocks, 2)
+		require.Equal(t, log3.BlockHash, blocks[0].Hash)
+		require.Equal(t, log4.BlockHash, blocks[1].Hash)
+
+		// the empty last block of the range is reported with the batched header
+		header, canceled := d.GetBlockHeader(ctx, 6)
+		require.False(t, canceled)
+		require.Equal(t, headerOf(6).Hash(), header.Hash)
+	})
+
+	t.Run("fallback to one by one requests if the batch fails", func(t *testing.T) {
+		d, clientMock := newBatchHeadersTestDownloader(t, func(ctx context.Context, b []rpc.BatchElem) error {
+			return errors.New("foo")
+		})
+		clientMock.EXPECT().FilterLogs(mock.Anything, query).Return([]types.Log{*log3, *log4}, nil).Once()
+		clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(3)).Return(headerOf(3), nil).Once()
+		clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(4)).Return(headerOf(4), nil).Once()
+
+		blocks := d.GetEventsByBlockRange(ctx, 3, 6)
+		require.Len(t, blocks, 2)
+		impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+		require.True(t, ok)
+		require.True(t, impl.batchHeaders.Load())
+	})
+
+	t.Run("the batches are disabled if they are not supported", func(t *testing.T) {
+		batchCalls := 0
+		d, clientMock := newBatchHeadersTestDownloader(t, func(ctx context.Context, b []rpc.BatchElem) error {
+			batchCalls++
+			return aggkittypes.ErrBatchCallNotSupported
+		})
+		clientMock.EXPECT().FilterLogs(mock.Anything, query).Return([]types.Log{*log3, *log4}, nil).Twice()
+		clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(3)).Return(headerOf(3), nil).Twice()
+		clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(4)).Return(headerOf(4), nil).Twice()
+
+		require.Len(t, d.GetEventsByBlockRange(ctx, 3, 6), 2)
+		require.Len(t, d.GetEventsByBlockRange(ctx, 3, 6), 2)
+		require.Equal(t, 1, batchCalls)
+	})
+}
+
+func TestHeaderBlockNums(t *testing.T) {
+	tests := []struct {
+		name     string
+		logs     []types.Log
+		toBlock  uint64
+		expected []uint64
+	}{
+		{name: "no logs", toBlock: 10, expected: []uint64{10}},
+		{name: "several logs per block", logs: []types.Log{{BlockNumber: 3}, {BlockNumber: 3}, {BlockNumber: 5}},
+			toBlock: 10, expected: []uint64{3, 5, 10}},
+		{name: "logs in the last block", logs: []types.Log{{BlockNumber: 3}, {BlockNumber: 10}},
+			toBlock: 10, expected: []uint64{3, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, headerBlockNums(tt.logs, tt.toBlock))
+		})
+	}
+}
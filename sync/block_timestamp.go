@@ -0,0 +1,112 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BlockTimestampSource is the strategy used by the downloader to fill the timestamp of the blocks
+type BlockTimestampSource string
+
+const (
+	// BlockTimestampSourceHeader uses the time of the block header (default)
+	BlockTimestampSourceHeader BlockTimestampSource = "Header"
+	// BlockTimestampSourceParentTimePlusInterval uses the time of the parent header plus the block interval,
+	// for the L2s whose headers have the time of the previous block. The header time of the parent is used
+	// (not its computed timestamp), so the timestamp doesn't depend on the ranges the blocks are synced in
+	BlockTimestampSourceParentTimePlusInterval BlockTimestampSource = "ParentTimePlusInterval"
+)
+
+// Validate checks that the source is known and that the block interval is set if the source needs it
+func (s BlockTimestampSource) Validate(blockInterval time.Duration) error {
+	switch s {
+	case "", BlockTimestampSourceHeader:
+		return nil
+	case BlockTimestampSourceParentTimePlusInterval:
+		if blockInterval <= 0 {
+			return fmt.Errorf("block timestamp source %s requires a block interval greater than 0", s)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid block timestamp source %q, valid values are: %s, %s", s,
+			BlockTimestampSourceHeader, BlockTimestampSourceParentTimePlusInterval)
+	}
+}
+
+// SetBlockTimestampSource sets the strategy used to fill the timestamp of the downloaded blocks
+func (d *EVMDownloader) SetBlockTimestampSource(source BlockTimestampSource, blockInterval time.Duration) error {
+	if err := source.Validate(blockInterval); err != nil {
+		return err
+	}
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	if !ok {
+		return fmt.Errorf("the downloader doesn't support the block timestamp source %s", source)
+	}
+	impl.timestampSource = source
+	impl.blockInterval = blockInterval
+	d.log.Infof("block timestamp source: %s (block interval: %s)", source, blockInterval)
+	return nil
+}
+
+// fillTimestamps sets the timestamp of the headers following the block timestamp source.
+// It returns true if the context is canceled
+func (d *EVMDownloaderImplementation) fillTimestamps(ctx context.Context, headers []*EVMBlockHeader) bool {
+	if len(headers) == 0 {
+		return false
+	}
+	switch d.timestampSource {
+	case BlockTimestampSourceParentTimePlusInterval:
+		return d.fillTimestampsFromParent(ctx, headers)
+	default:
+		return false
+	}
+}
+
+// fillTimestampsFromParent sets the timestamp of each header to the time of its parent plus the block interval
+func (d *EVMDownloaderImplementation) fillTimestampsFromParent(ctx context.Context, headers []*EVMBlockHeader) bool {
+	interval := uint64(d.blockInterval.Seconds())
+	// the parent of a header may be the previous header of the range, whose time is already known
+	headerTimes := make(map[common.Hash]uint64, len(headers))
+	for _, header := range headers {
+		headerTimes[header.Hash] = header.Timestamp
+	}
+	for _, header := range headers {
+		if header.Num == 0 {
+			continue
+		}
+		parentTime, ok := headerTimes[header.ParentHash]
+		if !ok {
+			var canceled bool
+			parentTime, canceled = d.getParentTime(ctx, header)
+			if canceled {
+				return true
+			}
+		}
+		header.Timestamp = parentTime + interval
+	}
+	return false
+}
+
+// getParentTime returns the header time of the parent of the header, retrying until it succeeds.
+// It returns true if the context is canceled
+func (d *EVMDownloaderImplementation) getParentTime(ctx context.Context, header *EVMBlockHeader) (uint64, bool) {
+	attempts := 0
+	for {
+		start := time.Now()
+		parent, err := d.ethClient.HeaderByHash(ctx, header.ParentHash)
+		headerByNumberDone(d.syncerID, start)
+		if err == nil {
+			return parent.Time, false
+		}
+		if ctx.Err() != nil {
+			return 0, true
+		}
+		attempts++
+		rpcRetry(d.syncerID)
+		d.log.Errorf("error getting the parent header %s of block %d, err: %v", header.ParentHash, header.Num, err)
+		d.rh.HandleRPC("getParentTime", attempts)
+	}
+}
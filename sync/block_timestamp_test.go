@@ -0,0 +1,96 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTimestampSourceValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		source        BlockTimestampSource
+		blockInterval time.Duration
+		expectedErr   string
+	}{
+		{name: "empty", source: ""},
+		{name: "header", source: BlockTimestampSourceHeader},
+		{name: "block body is not supported anymore", source: "BlockBody",
+			expectedErr: "invalid block timestamp source \"BlockBody\""},
+		{name: "parent time plus interval", source: BlockTimestampSourceParentTimePlusInterval,
+			blockInterval: time.Second},
+		{name: "parent time without interval", source: BlockTimestampSourceParentTimePlusInterval,
+			expectedErr: "requires a block interval greater than 0"},
+		{name: "unknown source", source: "Foo", expectedErr: "invalid block timestamp source \"Foo\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source.Validate(tt.blockInterval)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSetBlockTimestampSource(t *testing.T) {
+	d, _ := NewTestDownloader(t, time.Millisecond)
+	err := d.SetBlockTimestampSource(BlockTimestampSourceParentTimePlusInterval, 0)
+	require.ErrorContains(t, err, "requires a block interval")
+
+	require.NoError(t, d.SetBlockTimestampSource(BlockTimestampSourceParentTimePlusInterval, 2*time.Second))
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	require.True(t, ok)
+	require.Equal(t, BlockTimestampSourceParentTimePlusInterval, impl.timestampSource)
+	require.Equal(t, 2*time.Second, impl.blockInterval)
+}
+
+func TestGetBlockHeaderParentTimePlusInterval(t *testing.T) {
+	ctx := context.Background()
+	d, clientMock := NewTestDownloader(t, time.Millisecond)
+	require.NoError(t, d.SetBlockTimestampSource(BlockTimestampSourceParentTimePlusInterval, 2*time.Second))
+
+	parentHash := common.HexToHash("parent")
+	header := &types.Header{Number: big.NewInt(5), ParentHash: parentHash}
+	clientMock.EXPECT().HeaderByNumber(ctx, big.NewInt(5)).Return(header, nil).Once()
+	clientMock.EXPECT().HeaderByHash(ctx, parentHash).Return(nil, errors.New("foo")).Once()
+	clientMock.EXPECT().HeaderByHash(ctx, parentHash).Return(&types.Header{Time: 100}, nil).Once()
+
+	actual, canceled := d.GetBlockHeader(ctx, 5)
+	require.False(t, canceled)
+	require.Equal(t, EVMBlockHeader{Num: 5, Hash: header.Hash(), ParentHash: parentHash, Timestamp: 102}, actual)
+}
+
+func TestGetEventsByBlockRangeParentTimePlusInterval(t *testing.T) {
+	ctx := context.Background()
+	d, clientMock := NewTestDownloader(t, time.Millisecond)
+	require.NoError(t, d.SetBlockTimestampSource(BlockTimestampSourceParentTimePlusInterval, time.Second))
+
+	header3 := &types.Header{Number: big.NewInt(3), ParentHash: common.HexToHash("foo"), Time: 50}
+	header4 := &types.Header{Number: big.NewInt(4), ParentHash: header3.Hash(), Time: 0}
+	logs := []types.Log{
+		{Address: contractAddr, BlockNumber: 3, BlockHash: header3.Hash(), Topics: []common.Hash{eventSignature, {}}},
+		{Address: contractAddr, BlockNumber: 4, BlockHash: header4.Hash(), Topics: []common.Hash{eventSignature, {}}},
+	}
+	clientMock.EXPECT().FilterLogs(mock.Anything, mock.Anything).Return(logs, nil).Once()
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(3)).Return(header3, nil).Once()
+	clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(4)).Return(header4, nil).Once()
+	// the parent of the block 4 is in the range, so only the parent of the block 3 is queried
+	clientMock.EXPECT().HeaderByHash(mock.Anything, common.HexToHash("foo")).
+		Return(&types.Header{Time: 40}, nil).Once()
+
+	blocks := d.GetEventsByBlockRange(ctx, 3, 4)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(41), blocks[0].Timestamp)
+	require.Equal(t, uint64(51), blocks[1].Timestamp)
+}
@@ -2,12 +2,22 @@ package sync
 
 import (
 	"log"
+	"os"
 	"sync"
 	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
 )
 
 var LogFatalf = log.Fatalf
 
+// ExitWithCodef logs the message and exits with the exit code of the class of the failure. It can be
+// overridden by the tests, as LogFatalf
+var ExitWithCodef = func(code aggkitcommon.ExitCode, format string, args ...any) {
+	log.Printf(format, args...)
+	os.Exit(int(code))
+}
+
 type RetryHandler struct {
 	RetryAfterErrorPeriod      time.Duration
 	MaxRetryAttemptsAfterError int
@@ -21,8 +31,20 @@ type RetryHandler struct {
 func (h *RetryHandler) Handle(funcName string, attempts int) {
 	if h.MaxRetryAttemptsAfterError > -1 && attempts >= h.MaxRetryAttemptsAfterError {
 		LogFatalf(
-			"%s failed too many times (%d)",
-			funcName, h.MaxRetryAttemptsAfterError,
+			"%s failed too many times (%d), stopping (reason: %s)",
+			funcName, h.MaxRetryAttemptsAfterError, StopReasonFatal,
+		)
+	}
+	time.Sleep(h.RetryAfterErrorPeriod)
+}
+
+// HandleRPC is Handle for the RPC calls: if they reach the max retry attempts, the process exits with
+// the ExitCodeFatalRPC exit code
+func (h *RetryHandler) HandleRPC(funcName string, attempts int) {
+	if h.MaxRetryAttemptsAfterError > -1 && attempts >= h.MaxRetryAttemptsAfterError {
+		ExitWithCodef(aggkitcommon.ExitCodeFatalRPC,
+			"%s failed too many times (%d), stopping (reason: %s)",
+			funcName, h.MaxRetryAttemptsAfterError, StopReasonFatal,
 		)
 	}
 	time.Sleep(h.RetryAfterErrorPeriod)
@@ -0,0 +1,25 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryHandlerHandleRPC(t *testing.T) {
+	exitWithCodef := ExitWithCodef
+	defer func() { ExitWithCodef = exitWithCodef }()
+	ExitWithCodef = func(code aggkitcommon.ExitCode, format string, args ...any) {
+		panic(code)
+	}
+	rh := &RetryHandler{MaxRetryAttemptsAfterError: 2, RetryAfterErrorPeriod: time.Millisecond}
+
+	require.NotPanics(t, func() { rh.HandleRPC("getLogs", 1) })
+	require.PanicsWithValue(t, aggkitcommon.ExitCodeFatalRPC, func() { rh.HandleRPC("getLogs", 2) })
+
+	// no max retry attempts
+	rh.MaxRetryAttemptsAfterError = -1
+	require.NotPanics(t, func() { rh.HandleRPC("getLogs", 100) })
+}
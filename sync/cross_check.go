@@ -0,0 +1,190 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	aggkittypes "github.com/agglayer/aggkit/types"
+)
+
+// crossCheck compares the blocks downloaded from the RPC with the ones downloaded from a second RPC provider
+type crossCheck struct {
+	secondary *EVMDownloaderImplementation
+	// haltOnDiscrepancy downloads the range again from both providers until they agree, instead of
+	// reporting the blocks of the main RPC
+	haltOnDiscrepancy bool
+	waitPeriod        time.Duration
+	log               *log.Logger
+}
+
+// CrossCheckDiscrepancy is a difference between the blocks downloaded from the RPC and from the second provider
+type CrossCheckDiscrepancy struct {
+	BlockNum uint64
+	// Field is the value that differs, e.g. the hash of the block or an event
+	Field     string
+	Primary   string
+	Secondary string
+}
+
+func (d CrossCheckDiscrepancy) String() string {
+	return fmt.Sprintf("block %d: %s primary %s, secondary %s", d.BlockNum, d.Field, d.Primary, d.Secondary)
+}
+
+// EnableCrossCheck downloads each block range also from a second RPC provider (ethClient, with the appender
+// built on it) and compares the blocks and events before reporting them to the driver. The discrepancies are
+// logged and counted. If haltOnDiscrepancy is true, the range is downloaded again from both providers until
+// they agree, so the differing events are never processed. The settings of the downloader (finality, timestamp
+// source, topic filtering...) are copied to the second provider, so it must be called after setting them
+func (d *EVMDownloader) EnableCrossCheck(ethClient aggkittypes.BaseEthereumClienter, appender LogAppenderMap,
+	haltOnDiscrepancy bool) error {
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	if !ok {
+		return errors.New("the downloader doesn't support the cross check")
+	}
+	secondary := NewEVMDownloaderImplementation(d.syncerID+"_cross_check", ethClient, impl.blockFinality,
+		impl.waitForNewBlocksPeriod, appender, impl.addressesToQuery, impl.rh, impl.finalizedBlockType)
+	secondary.timestampSource = impl.timestampSource
+	secondary.blockInterval = impl.blockInterval
+	secondary.clientSideTopicFiltering = impl.clientSideTopicFiltering
+	d.crossCheck = &crossCheck{
+		secondary:         secondary,
+		haltOnDiscrepancy: haltOnDiscrepancy,
+		waitPeriod:        impl.waitForNewBlocksPeriod,
+		log:               d.log,
+	}
+	d.log.Infof("cross check against a second RPC provider enabled (halt on discrepancy: %t)", haltOnDiscrepancy)
+	return nil
+}
+
+// getEventsByBlockRange returns the events of the range, compared with the second provider if the
+// cross check is enabled
+func (d *EVMDownloader) getEventsByBlockRange(ctx context.Context, fromBlock, toBlock uint64) EVMBlocks {
+	blocks := d.GetEventsByBlockRange(ctx, fromBlock, toBlock)
+	if d.crossCheck == nil {
+		return blocks
+	}
+	for {
+		if !d.crossCheck.waitForBlock(ctx, toBlock) {
+			return nil
+		}
+		secondaryBlocks := d.crossCheck.secondary.GetEventsByBlockRange(ctx, fromBlock, toBlock)
+		if ctx.Err() != nil {
+			return nil
+		}
+		discrepancies := CompareEVMBlocks(blocks, secondaryBlocks)
+		if len(discrepancies) == 0 {
+			return blocks
+		}
+		crossCheckDiscrepanciesFound(d.syncerID, len(discrepancies))
+		for _, discrepancy := range discrepancies {
+			d.log.Errorf("cross check discrepancy in blocks %d-%d: %s", fromBlock, toBlock, discrepancy)
+		}
+		if !d.crossCheck.haltOnDiscrepancy {
+			return blocks
+		}
+		d.log.Warnf("the blocks %d-%d differ between the RPC providers, downloading them again in %s",
+			fromBlock, toBlock, d.crossCheck.waitPeriod)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(d.crossCheck.waitPeriod):
+		}
+		blocks = d.GetEventsByBlockRange(ctx, fromBlock, toBlock)
+	}
+}
+
+// waitForBlock waits until the block is available on the second provider, so a provider that lags
+// behind is not reported as a discrepancy. It returns false if the context is canceled
+func (c *crossCheck) waitForBlock(ctx context.Context, blockNum uint64) bool {
+	for {
+		start := time.Now()
+		header, err := c.secondary.ethClient.HeaderByNumber(ctx, c.secondary.blockFinality)
+		headerByNumberDone(c.secondary.syncerID, start)
+		switch {
+		case ctx.Err() != nil:
+			return false
+		case err != nil:
+			rpcRetry(c.secondary.syncerID)
+			c.log.Errorf("error getting the last block of the cross check provider: %v", err)
+		case header.Number.Uint64() >= blockNum:
+			return true
+		default:
+			c.log.Debugf("the cross check provider is at block %d, waiting for block %d",
+				header.Number.Uint64(), blockNum)
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(c.waitPeriod):
+		}
+	}
+}
+
+// CompareEVMBlocks compares the hashes and the events (JSON encoded) of the blocks downloaded from two providers
+func CompareEVMBlocks(primary, secondary EVMBlocks) []CrossCheckDiscrepancy {
+	secondaryByNum := make(map[uint64]*EVMBlock, len(secondary))
+	for _, b := range secondary {
+		secondaryByNum[b.Num] = b
+	}
+	discrepancies := []CrossCheckDiscrepancy{}
+	for _, b := range primary {
+		s, ok := secondaryByNum[b.Num]
+		delete(secondaryByNum, b.Num)
+		switch {
+		case !ok:
+			discrepancies = append(discrepancies, CrossCheckDiscrepancy{
+				BlockNum: b.Num, Field: "events",
+				Primary: fmt.Sprintf("%d events", len(b.Events)), Secondary: "missing block",
+			})
+		case b.Hash != s.Hash:
+			discrepancies = append(discrepancies, CrossCheckDiscrepancy{
+				BlockNum: b.Num, Field: "hash", Primary: b.Hash.Hex(), Secondary: s.Hash.Hex(),
+			})
+		default:
+			discrepancies = append(discrepancies, compareEvents(b, s)...)
+		}
+	}
+	for _, s := range secondary {
+		if _, ok := secondaryByNum[s.Num]; ok {
+			discrepancies = append(discrepancies, CrossCheckDiscrepancy{
+				BlockNum: s.Num, Field: "events",
+				Primary: "missing block", Secondary: fmt.Sprintf("%d events", len(s.Events)),
+			})
+		}
+	}
+	return discrepancies
+}
+
+// compareEvents compares the events of the same block by position
+func compareEvents(primary, secondary *EVMBlock) []CrossCheckDiscrepancy {
+	if len(primary.Events) != len(secondary.Events) {
+		return []CrossCheckDiscrepancy{{
+			BlockNum: primary.Num, Field: "number of events",
+			Primary: fmt.Sprint(len(primary.Events)), Secondary: fmt.Sprint(len(secondary.Events)),
+		}}
+	}
+	var discrepancies []CrossCheckDiscrepancy
+	for i := range primary.Events {
+		p, s := encodeEvent(primary.Events[i]), encodeEvent(secondary.Events[i])
+		if !bytes.Equal(p, s) {
+			discrepancies = append(discrepancies, CrossCheckDiscrepancy{
+				BlockNum: primary.Num, Field: fmt.Sprintf("event %d", i), Primary: string(p), Secondary: string(s),
+			})
+		}
+	}
+	return discrepancies
+}
+
+// encodeEvent returns the JSON encoding of the event, that compares the values instead of the pointers
+func encodeEvent(event interface{}) []byte {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return []byte(fmt.Sprintf("%+v", event))
+	}
+	return encoded
+}
@@ -0,0 +1,137 @@
+package sync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	aggkittypesmocks "github.com/agglayer/aggkit/types/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareEVMBlocks(t *testing.T) {
+	block := func(num uint64, hash string, events ...interface{}) *EVMBlock {
+		return &EVMBlock{EVMBlockHeader: EVMBlockHeader{Num: num, Hash: common.HexToHash(hash)}, Events: events}
+	}
+	type event struct {
+		Amount *big.Int
+	}
+	tests := []struct {
+		name      string
+		primary   EVMBlocks
+		secondary EVMBlocks
+		expected  []CrossCheckDiscrepancy
+	}{
+		{
+			name:      "equal blocks",
+			primary:   EVMBlocks{block(1, "0x1", &event{big.NewInt(5)})},
+			secondary: EVMBlocks{block(1, "0x1", &event{big.NewInt(5)})},
+			expected:  []CrossCheckDiscrepancy{},
+		},
+		{
+			name:      "different hash",
+			primary:   EVMBlocks{block(1, "0x1")},
+			secondary: EVMBlocks{block(1, "0x2")},
+			expected: []CrossCheckDiscrepancy{{
+				BlockNum: 1, Field: "hash", Primary: common.HexToHash("0x1").Hex(), Secondary: common.HexToHash("0x2").Hex(),
+			}},
+		},
+		{
+			name:      "missing blocks",
+			primary:   EVMBlocks{block(1, "0x1", &event{big.NewInt(5)})},
+			secondary: EVMBlocks{block(2, "0x2")},
+			expected: []CrossCheckDiscrepancy{
+				{BlockNum: 1, Field: "events", Primary: "1 events", Secondary: "missing block"},
+				{BlockNum: 2, Field: "events", Primary: "missing block", Secondary: "0 events"},
+			},
+		},
+		{
+			name:      "different number of events",
+			primary:   EVMBlocks{block(1, "0x1", &event{big.NewInt(5)})},
+			secondary: EVMBlocks{block(1, "0x1")},
+			expected:  []CrossCheckDiscrepancy{{BlockNum: 1, Field: "number of events", Primary: "1", Secondary: "0"}},
+		},
+		{
+			name:      "different event",
+			primary:   EVMBlocks{block(1, "0x1", &event{big.NewInt(5)})},
+			secondary: EVMBlocks{block(1, "0x1", &event{big.NewInt(6)})},
+			expected: []CrossCheckDiscrepancy{
+				{BlockNum: 1, Field: "event 0", Primary: `{"Amount":5}`, Secondary: `{"Amount":6}`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, CompareEVMBlocks(tt.primary, tt.secondary))
+		})
+	}
+}
+
+func TestGetEventsByBlockRangeCrossCheck(t *testing.T) {
+	ctx := context.Background()
+	l, event := generateEvent(5)
+	header := &types.Header{Number: big.NewInt(5), ParentHash: common.HexToHash("foo")}
+	expected := EVMBlocks{{
+		EVMBlockHeader: EVMBlockHeader{Num: 5, Hash: l.BlockHash, ParentHash: header.ParentHash},
+		Events:         []interface{}{event},
+	}}
+	corrupted := EVMBlocks{{
+		EVMBlockHeader: EVMBlockHeader{Num: 5, Hash: l.BlockHash, ParentHash: header.ParentHash},
+		Events:         []interface{}{testEvent(common.HexToHash("0xbad"))},
+	}}
+
+	newDownloaders := func(t *testing.T, haltOnDiscrepancy bool) (*EVMDownloader, *EVMDownloaderMock) {
+		t.Helper()
+		d, _ := NewTestDownloader(t, 0)
+		secondaryClient := aggkittypesmocks.NewBaseEthereumClienter(t)
+		require.NoError(t, d.EnableCrossCheck(secondaryClient, buildAppender(), haltOnDiscrepancy))
+		primary := NewEVMDownloaderMock(t)
+		d.EVMDownloaderInterface = primary
+		// the second provider lags behind the first time
+		secondaryClient.EXPECT().HeaderByNumber(mock.Anything, mock.Anything).Return(
+			&types.Header{Number: big.NewInt(4)}, nil).Once().Maybe()
+		secondaryClient.EXPECT().HeaderByNumber(mock.Anything, mock.Anything).RunAndReturn(
+			func(_ context.Context, n *big.Int) (*types.Header, error) {
+				if n.Sign() < 0 {
+					return &types.Header{Number: big.NewInt(10)}, nil
+				}
+				return header, nil
+			}).Maybe()
+		secondaryClient.EXPECT().FilterLogs(mock.Anything, mock.Anything).Return([]types.Log{*l}, nil).Maybe()
+		return d, primary
+	}
+
+	t.Run("the blocks match", func(t *testing.T) {
+		d, primary := newDownloaders(t, true)
+		primary.EXPECT().GetEventsByBlockRange(ctx, uint64(1), uint64(5)).Return(expected).Once()
+		require.Equal(t, expected, d.getEventsByBlockRange(ctx, 1, 5))
+	})
+
+	t.Run("the discrepancies are reported", func(t *testing.T) {
+		d, primary := newDownloaders(t, false)
+		primary.EXPECT().GetEventsByBlockRange(ctx, uint64(1), uint64(5)).Return(corrupted).Once()
+		require.Equal(t, corrupted, d.getEventsByBlockRange(ctx, 1, 5))
+	})
+
+	t.Run("the range is downloaded again until both providers agree", func(t *testing.T) {
+		d, primary := newDownloaders(t, true)
+		primary.EXPECT().GetEventsByBlockRange(ctx, uint64(1), uint64(5)).Return(corrupted).Once()
+		primary.EXPECT().GetEventsByBlockRange(ctx, uint64(1), uint64(5)).Return(expected).Once()
+		require.Equal(t, expected, d.getEventsByBlockRange(ctx, 1, 5))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		d, primary := newDownloaders(t, true)
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		primary.EXPECT().GetEventsByBlockRange(canceledCtx, uint64(1), uint64(5)).Return(nil).Once()
+		require.Nil(t, d.getEventsByBlockRange(canceledCtx, 1, 5))
+	})
+
+	d, _ := NewTestDownloader(t, 0)
+	d.EVMDownloaderInterface = NewEVMDownloaderMock(t)
+	require.Error(t, d.EnableCrossCheck(aggkittypesmocks.NewBaseEthereumClienter(t), buildAppender(), false))
+}
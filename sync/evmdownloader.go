@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"slices"
+	mutex "sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/agglayer/aggkit/log"
@@ -28,6 +30,7 @@ type EVMDownloaderInterface interface {
 	WaitForNewBlocks(ctx context.Context, lastBlockSeen uint64) (newLastBlock uint64)
 	GetEventsByBlockRange(ctx context.Context, fromBlock, toBlock uint64) EVMBlocks
 	GetLogs(ctx context.Context, fromBlock, toBlock uint64) []types.Log
+	GetLogsByBlockHash(ctx context.Context, blockHash common.Hash) ([]types.Log, error)
 	GetBlockHeader(ctx context.Context, blockNum uint64) (EVMBlockHeader, bool)
 	GetLastFinalizedBlock(ctx context.Context) (*types.Header, error)
 	ChainID(ctx context.Context) (uint64, error)
@@ -35,22 +38,28 @@ type EVMDownloaderInterface interface {
 
 type LogAppenderMap map[common.Hash]func(b *EVMBlock, l types.Log) error
 
-// GetTopics returns the EVM event topics that are being queried
+// GetTopics returns the EVM event topics that are being queried, sorted so the queries are deterministic
 func (m LogAppenderMap) GetTopics() []common.Hash {
 	topics := make([]common.Hash, 0, len(m))
 	for topic := range m {
 		topics = append(topics, topic)
 	}
+	slices.SortFunc(topics, func(a, b common.Hash) int { return a.Cmp(b) })
 	return topics
 }
 
 type EVMDownloader struct {
 	syncBlockChunkSize uint64
 	EVMDownloaderInterface
+	syncerID                   string
 	log                        *log.Logger
 	finalizedBlockType         aggkittypes.BlockNumberFinality
 	stopDownloaderOnIterationN int
 	addressesToQuery           []common.Address
+	// lastFinalizedBlock is the last finalized block seen by Download (0 = none yet)
+	lastFinalizedBlock atomic.Uint64
+	// crossCheck compares the downloaded blocks with a second RPC provider (see EnableCrossCheck), nil disables it
+	crossCheck *crossCheck
 }
 
 func NewEVMDownloader(
@@ -90,6 +99,7 @@ func NewEVMDownloader(
 
 	return &EVMDownloader{
 		syncBlockChunkSize: syncBlockChunkSize,
+		syncerID:           syncerID,
 		log:                logger,
 		finalizedBlockType: fbtEthermanType,
 		addressesToQuery:   addressesToQuery,
@@ -111,6 +121,24 @@ func (d *EVMDownloader) setStopDownloaderOnIterationN(iteration int) {
 	d.stopDownloaderOnIterationN = iteration
 }
 
+// SetNewHeadsNotifier sets a channel that notifies new blocks (e.g. HeadFeed.NewHeads), so
+// WaitForNewBlocks doesn't need to wait for the next poll to detect them
+func (d *EVMDownloader) SetNewHeadsNotifier(newHeads <-chan uint64) {
+	if impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation); ok {
+		impl.newHeads = newHeads
+	}
+}
+
+// LastFinalizedBlock returns the last finalized block seen while downloading. It returns false
+// if the finalized block type is not FinalizedBlock or no block has been downloaded yet
+func (d *EVMDownloader) LastFinalizedBlock() (uint64, bool) {
+	if !d.finalizedBlockType.IsFinalized() {
+		return 0, false
+	}
+	lastFinalizedBlock := d.lastFinalizedBlock.Load()
+	return lastFinalizedBlock, lastFinalizedBlock != 0
+}
+
 // RuntimeData returns the runtime data: chainID + addresses to query
 func (d *EVMDownloader) RuntimeData(ctx context.Context) (RuntimeData, error) {
 	chainID, err := d.ChainID(ctx)
@@ -131,7 +159,7 @@ func (d *EVMDownloader) Download(ctx context.Context, fromBlock uint64, download
 	for {
 		select {
 		case <-ctx.Done():
-			d.log.Info("closing evm downloader channel")
+			d.log.Infof("closing evm downloader channel (reason: %s)", StopReasonOf(ctx))
 			close(downloadedCh)
 			return
 		default:
@@ -158,6 +186,7 @@ func (d *EVMDownloader) Download(ctx context.Context, fromBlock uint64, download
 		}
 		// lastFinalizedBlock can't be > lastBlock
 		lastFinalizedBlockNumber := min(lastBlock, lastFinalizedBlock.Number.Uint64())
+		d.lastFinalizedBlock.Store(lastFinalizedBlockNumber)
 
 		requestToBlock := toBlock
 		if toBlock >= lastBlock {
@@ -166,7 +195,7 @@ func (d *EVMDownloader) Download(ctx context.Context, fromBlock uint64, download
 		}
 		d.log.Debugf("getting events from blocks [%d to  %d] toBlock: %d. lastFinalizedBlock: %d lastBlock: %d",
 			fromBlock, requestToBlock, toBlock, lastFinalizedBlockNumber, lastBlock)
-		blocks := d.GetEventsByBlockRange(ctx, fromBlock, requestToBlock)
+		blocks := d.getEventsByBlockRange(ctx, fromBlock, requestToBlock)
 		d.log.Debugf("result events from blocks [%d to  %d] -> len(blocks)=%d",
 			fromBlock, requestToBlock, len(blocks))
 		if requestToBlock <= lastFinalizedBlockNumber {
@@ -206,6 +235,7 @@ func (d *EVMDownloader) Download(ctx context.Context, fromBlock uint64, download
 }
 
 func (d *EVMDownloader) reportBlocks(downloadedCh chan EVMBlock, blocks EVMBlocks, lastFinalizedBlock uint64) {
+	defer blocksEmitted(d.syncerID, len(blocks))
 	for _, block := range blocks {
 		d.log.Debugf("sending block %d to the driver (with events)", block.Num)
 		block.IsFinalizedBlock = d.finalizedBlockType.IsFinalized() && block.Num <= lastFinalizedBlock
@@ -226,18 +256,35 @@ func (d *EVMDownloader) reportEmptyBlock(ctx context.Context, downloadedCh chan
 		IsFinalizedBlock: d.finalizedBlockType.IsFinalized() && header.Num <= lastFinalizedBlock,
 		EVMBlockHeader:   header,
 	}
+	emptyBlockEmitted(d.syncerID)
 }
 
 type EVMDownloaderImplementation struct {
+	syncerID               string
 	ethClient              aggkittypes.BaseEthereumClienter
 	blockFinality          *big.Int
 	waitForNewBlocksPeriod time.Duration
 	appender               LogAppenderMap
 	topicsToQuery          []common.Hash
-	addressesToQuery       []common.Address
-	rh                     *RetryHandler
-	log                    *log.Logger
-	finalizedBlockType     *big.Int
+	// clientSideTopicFiltering requests all the logs of the addresses and filters the topics after
+	// retrieval, instead of sending the topics in the FilterLogs query (see EnableClientSideTopicFiltering)
+	clientSideTopicFiltering bool
+	addressesToQuery         []common.Address
+	rh                       *RetryHandler
+	log                      *log.Logger
+	finalizedBlockType       *big.Int
+	// newHeads is an optional notifier of new blocks, the RPC is queried on each notification
+	// besides the regular polling. A nil channel disables it
+	newHeads <-chan uint64
+	// timestampSource is the strategy used to fill the timestamp of the blocks (empty = header time)
+	timestampSource BlockTimestampSource
+	// blockInterval is the time between blocks used by BlockTimestampSourceParentTimePlusInterval
+	blockInterval time.Duration
+	// batchHeaders requests the headers of each range in batch requests (see EnableBatchHeaderRequests)
+	batchHeaders atomic.Bool
+	// prefetched are the headers of the current range obtained in batch requests
+	prefetched   map[uint64]EVMBlockHeader
+	prefetchedMu mutex.Mutex
 }
 
 func NewEVMDownloaderImplementation(
@@ -251,12 +298,14 @@ func NewEVMDownloaderImplementation(
 	finalizedBlockType *big.Int,
 ) *EVMDownloaderImplementation {
 	logger := log.WithFields("syncer", syncerID)
+	registerMetrics()
 	var topics []common.Hash
 	if appender != nil {
 		topics = appender.GetTopics()
 	}
 
 	return &EVMDownloaderImplementation{
+		syncerID:               syncerID,
 		ethClient:              ethClient,
 		blockFinality:          blockFinality,
 		waitForNewBlocksPeriod: waitForNewBlocksPeriod,
@@ -283,6 +332,7 @@ func (d *EVMDownloaderImplementation) ChainID(ctx context.Context) (uint64, erro
 }
 
 func (d *EVMDownloaderImplementation) GetLastFinalizedBlock(ctx context.Context) (*types.Header, error) {
+	defer headerByNumberDone(d.syncerID, time.Now())
 	// if the finalized block type is nil, it means that the reorgs are not happening on the network
 	if d.finalizedBlockType == nil {
 		return d.ethClient.HeaderByNumber(ctx, d.blockFinality)
@@ -299,29 +349,41 @@ func (d *EVMDownloaderImplementation) WaitForNewBlocks(
 	for {
 		select {
 		case <-ctx.Done():
-			d.log.Info("context cancelled")
+			d.log.Infof("context cancelled while waiting for new blocks (reason: %s)", StopReasonOf(ctx))
 			return latestSyncedBlock
 		case <-ticker.C:
-			header, err := d.ethClient.HeaderByNumber(ctx, d.blockFinality)
-			if err != nil {
-				if ctx.Err() == nil {
-					attempts++
-					d.log.Error("error getting last block num from eth client: ", err)
-					d.rh.Handle("WaitForNewBlocks", attempts)
-				} else {
-					d.log.Warn("context has been canceled while trying to get header by number")
-				}
-				continue
-			}
-			if header.Number.Uint64() > latestSyncedBlock {
-				return header.Number.Uint64()
+		case <-d.newHeads:
+		}
+		start := time.Now()
+		header, err := d.ethClient.HeaderByNumber(ctx, d.blockFinality)
+		headerByNumberDone(d.syncerID, start)
+		if err != nil {
+			if ctx.Err() == nil {
+				attempts++
+				rpcRetry(d.syncerID)
+				d.log.Error("error getting last block num from eth client: ", err)
+				d.rh.HandleRPC("WaitForNewBlocks", attempts)
+			} else {
+				d.log.Warn("context has been canceled while trying to get header by number")
 			}
+			continue
+		}
+		if header.Number.Uint64() > latestSyncedBlock {
+			return header.Number.Uint64()
 		}
 	}
 }
 
 func (d *EVMDownloaderImplementation) GetEventsByBlockRange(ctx context.Context, fromBlock, toBlock uint64) EVMBlocks {
-	return d.getEventsByBlockRangeWithRetry(ctx, fromBlock, toBlock, 0)
+	blocks := d.getEventsByBlockRangeWithRetry(ctx, fromBlock, toBlock, 0)
+	headers := make([]*EVMBlockHeader, len(blocks))
+	for i, b := range blocks {
+		headers[i] = &b.EVMBlockHeader
+	}
+	if d.fillTimestamps(ctx, headers) {
+		return nil
+	}
+	return blocks
 }
 
 func (d *EVMDownloaderImplementation) getEventsByBlockRangeWithRetry(
@@ -333,11 +395,12 @@ func (d *EVMDownloaderImplementation) getEventsByBlockRangeWithRetry(
 		return nil
 	default:
 		logs := d.GetLogs(ctx, fromBlock, toBlock)
+		d.prefetchHeaders(ctx, headerBlockNums(logs, toBlock))
 		blocks := make(EVMBlocks, 0, len(logs))
 		var latestBlock *EVMBlock
 		for _, l := range logs {
 			if latestBlock == nil || latestBlock.Num < l.BlockNumber {
-				b, canceled := d.GetBlockHeader(ctx, l.BlockNumber)
+				b, canceled := d.getHeader(ctx, l.BlockNumber)
 				if canceled {
 					return nil
 				}
@@ -345,7 +408,7 @@ func (d *EVMDownloaderImplementation) getEventsByBlockRangeWithRetry(
 				if b.Hash != l.BlockHash {
 					d.log.Infof(
 						"there has been a block hash change between the event query and the block query "+
-							"for block %d: %s vs %s. Retrying attempt %d/%d.",
+							"for block %d: %s vs %s. Querying the events of the block by hash, attempt %d/%d.",
 						l.BlockNumber, b.Hash, l.BlockHash, retryCount, MaxRetryCountBlockHashMismatch,
 					)
 					if retryCount >= MaxRetryCountBlockHashMismatch {
@@ -356,8 +419,10 @@ func (d *EVMDownloaderImplementation) getEventsByBlockRangeWithRetry(
 						)
 						return nil
 					}
-					// Retry the operation with an incremented retry count.
-					return d.getEventsByBlockRangeWithRetry(ctx, fromBlock, toBlock, retryCount+1)
+					blockHashMismatchRetry(d.syncerID)
+					// The events of the block are queried by the hash of the header, so they are consistent
+					// with it. The following blocks of the range may have changed too, so they are queried again
+					return d.getEventsAfterBlockHashMismatch(ctx, blocks, b, toBlock, retryCount+1)
 				}
 				latestBlock = &EVMBlock{
 					EVMBlockHeader: EVMBlockHeader{
@@ -371,44 +436,147 @@ func (d *EVMDownloaderImplementation) getEventsByBlockRangeWithRetry(
 				blocks = append(blocks, latestBlock)
 			}
 
-			appenderFn := d.appender[l.Topics[0]]
-			for {
-				attempts := 0
-				err := appenderFn(latestBlock, l)
-				if err != nil {
-					attempts++
-					d.log.Error("error trying to append log: ", err)
-					d.rh.Handle("appendLogs", attempts)
-					continue
-				}
-				break
-			}
+			d.appendLog(latestBlock, l)
 		}
 
 		return blocks
 	}
 }
 
+// getEventsAfterBlockHashMismatch returns the blocks already processed, the block of the header with
+// the events queried by its hash and the blocks of the rest of the range (up to toBlock)
+func (d *EVMDownloaderImplementation) getEventsAfterBlockHashMismatch(
+	ctx context.Context,
+	blocks EVMBlocks, header EVMBlockHeader, toBlock uint64, retryCount int,
+) EVMBlocks {
+	logs, err := d.GetLogsByBlockHash(ctx, header.Hash)
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil || len(logs) == 0 {
+		// The header may have been reorged out too: the node returns an error or no logs for an unknown
+		// hash. Unless the header is still the canonical one, the range is queried again from the block
+		current, canceled := d.getHeader(ctx, header.Num)
+		if canceled {
+			return nil
+		}
+		if err != nil || current.Hash != header.Hash {
+			d.log.Infof("the events of the block %d can't be queried by the hash %s (canonical hash: %s, err: %v), "+
+				"querying the range again", header.Num, header.Hash, current.Hash, err)
+			nextBlocks := d.getEventsByBlockRangeWithRetry(ctx, header.Num, toBlock, retryCount)
+			if nextBlocks == nil {
+				return nil
+			}
+			return append(blocks, nextBlocks...)
+		}
+	}
+	if len(logs) > 0 {
+		block := &EVMBlock{
+			EVMBlockHeader: header,
+			Events:         []interface{}{},
+		}
+		for _, l := range logs {
+			d.appendLog(block, l)
+		}
+		blocks = append(blocks, block)
+	}
+	if header.Num >= toBlock {
+		return blocks
+	}
+	nextBlocks := d.getEventsByBlockRangeWithRetry(ctx, header.Num+1, toBlock, retryCount)
+	if nextBlocks == nil {
+		return nil
+	}
+	return append(blocks, nextBlocks...)
+}
+
+// appendLog appends the event of the log to the block, retrying until it succeeds
+func (d *EVMDownloaderImplementation) appendLog(b *EVMBlock, l types.Log) {
+	appenderFn := d.appender[l.Topics[0]]
+	for {
+		attempts := 0
+		err := appenderFn(b, l)
+		if err != nil {
+			attempts++
+			d.log.Error("error trying to append log: ", err)
+			d.rh.Handle("appendLogs", attempts)
+			continue
+		}
+		break
+	}
+}
+
 func filterQueryToString(query ethereum.FilterQuery) string {
+	if query.BlockHash != nil {
+		return fmt.Sprintf("BlockHash: %s, Addresses: %s, Topics: %s",
+			query.BlockHash.Hex(), query.Addresses, query.Topics)
+	}
 	return fmt.Sprintf("FromBlock: %s, ToBlock: %s, Addresses: %s, Topics: %s",
 		query.FromBlock.String(), query.ToBlock.String(), query.Addresses, query.Topics)
 }
 
+// EnableClientSideTopicFiltering requests all the logs of the queried addresses and filters the topics
+// after retrieval. By default the topics of the appenders are sent in the FilterLogs query, this is an
+// escape hatch for the providers with buggy topic filtering
+func (d *EVMDownloader) EnableClientSideTopicFiltering() error {
+	impl, ok := d.EVMDownloaderInterface.(*EVMDownloaderImplementation)
+	if !ok {
+		return errors.New("the downloader doesn't support client side topic filtering")
+	}
+	impl.clientSideTopicFiltering = true
+	d.log.Infof("client side topic filtering enabled")
+	return nil
+}
+
+// queryTopics returns the topics of the FilterLogs queries: any of the appenders' topics as the first
+// topic of the logs, or nil (any topic) if the topics are filtered client side
+func (d *EVMDownloaderImplementation) queryTopics() [][]common.Hash {
+	if d.clientSideTopicFiltering || len(d.topicsToQuery) == 0 {
+		return nil
+	}
+	return [][]common.Hash{d.topicsToQuery}
+}
+
 func (d *EVMDownloaderImplementation) GetLogs(ctx context.Context, fromBlock, toBlock uint64) []types.Log {
+	return d.filterLogs(ctx, ethereum.FilterQuery{
+		Addresses: d.addressesToQuery,
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Topics:    d.queryTopics(),
+	})
+}
+
+// GetLogsByBlockHash returns the logs of the block with the given hash (EIP-234). Unlike a block range
+// query, the logs are guaranteed to belong to that block even if there is a reorg meanwhile. It's not
+// retried: the block may have been reorged out, so the error (or no logs) is returned to the caller
+func (d *EVMDownloaderImplementation) GetLogsByBlockHash(ctx context.Context,
+	blockHash common.Hash) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		Addresses: d.addressesToQuery,
+		BlockHash: &blockHash,
+		Topics:    d.queryTopics(),
+	}
+	start := time.Now()
+	logs, err := d.ethClient.FilterLogs(ctx, query)
+	filterLogsDone(d.syncerID, start)
+	if err != nil {
+		rpcRetry(d.syncerID)
+		return nil, fmt.Errorf("error calling FilterLogs to eth client: filter: %s: %w", filterQueryToString(query), err)
+	}
+	return d.trackedLogs(logs), nil
+}
+
+func (d *EVMDownloaderImplementation) filterLogs(ctx context.Context, query ethereum.FilterQuery) []types.Log {
 	var (
 		attempts       = 0
 		unfilteredLogs []types.Log
 		err            error
 	)
 
-	query := ethereum.FilterQuery{
-		Addresses: d.addressesToQuery,
-		FromBlock: new(big.Int).SetUint64(fromBlock),
-		ToBlock:   new(big.Int).SetUint64(toBlock),
-	}
-
 	for {
+		start := time.Now()
 		unfilteredLogs, err = d.ethClient.FilterLogs(ctx, query)
+		filterLogsDone(d.syncerID, start)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				// context is canceled, we don't want to fatal on max attempts in this case
@@ -416,33 +584,60 @@ func (d *EVMDownloaderImplementation) GetLogs(ctx context.Context, fromBlock, to
 			}
 
 			attempts++
+			rpcRetry(d.syncerID)
 			d.log.Errorf("error calling FilterLogs to eth client: filter: %s err:%w ",
 				filterQueryToString(query),
 				err,
 			)
-			d.rh.Handle("getLogs", attempts)
+			d.rh.HandleRPC("getLogs", attempts)
 			continue
 		}
 		break
 	}
 
+	return d.trackedLogs(unfilteredLogs)
+}
+
+// trackedLogs returns the logs that are not removed and have the topic of an appender
+func (d *EVMDownloaderImplementation) trackedLogs(unfilteredLogs []types.Log) []types.Log {
 	logs := make([]types.Log, 0, len(unfilteredLogs))
 	for _, l := range unfilteredLogs {
 		if l.Removed {
 			d.log.Warnf("log removed: %+v", l)
 			continue
 		}
-		if slices.Contains(d.topicsToQuery, l.Topics[0]) {
+		// the topics are checked even if they are in the query, the providers may return other logs
+		if len(l.Topics) > 0 && slices.Contains(d.topicsToQuery, l.Topics[0]) {
 			logs = append(logs, l)
 		}
 	}
 	return logs
 }
 
+// GetBlockHeader returns the header of the block, with the timestamp of the block timestamp source.
+// It returns true if the context is canceled
 func (d *EVMDownloaderImplementation) GetBlockHeader(ctx context.Context, blockNum uint64) (EVMBlockHeader, bool) {
+	header, canceled := d.getHeader(ctx, blockNum)
+	if canceled {
+		return EVMBlockHeader{}, true
+	}
+	if d.fillTimestamps(ctx, []*EVMBlockHeader{&header}) {
+		return EVMBlockHeader{}, true
+	}
+	return header, false
+}
+
+// getHeader returns the header of the block with the header time (the prefetched one, if any),
+// retrying until it succeeds
+func (d *EVMDownloaderImplementation) getHeader(ctx context.Context, blockNum uint64) (EVMBlockHeader, bool) {
+	if header, ok := d.takePrefetchedHeader(blockNum); ok {
+		return header, false
+	}
 	attempts := 0
 	for {
+		start := time.Now()
 		header, err := d.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNum))
+		headerByNumberDone(d.syncerID, start)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
 				// context is canceled, we don't want to fatal on max attempts in this case
@@ -452,6 +647,7 @@ func (d *EVMDownloaderImplementation) GetBlockHeader(ctx context.Context, blockN
 				// block num can temporary disappear from the execution client due to a reorg,
 				// in this case, we want to wait and not panic
 				log.Warnf("block %d not found on the ethereum client: %v", blockNum, err)
+				rpcRetry(d.syncerID)
 				if d.rh.RetryAfterErrorPeriod != 0 {
 					time.Sleep(d.rh.RetryAfterErrorPeriod)
 				} else {
@@ -461,8 +657,9 @@ func (d *EVMDownloaderImplementation) GetBlockHeader(ctx context.Context, blockN
 			}
 
 			attempts++
+			rpcRetry(d.syncerID)
 			d.log.Errorf("error getting block header for block %d, err: %v", blockNum, err)
-			d.rh.Handle("getBlockHeader", attempts)
+			d.rh.HandleRPC("getBlockHeader", attempts)
 			continue
 		}
 		return EVMBlockHeader{
@@ -205,26 +205,39 @@ func TestGetEventsByBlockRange(t *testing.T) {
 	}
 	testCases = append(testCases, case5)
 
-	// case 6: block hash mismatch with max retries exceeded
+	// case 6: block hash mismatch, the events of the block are queried by the hash of the header
 	logC6, _ := generateEvent(15)
 	logsC6 := []types.Log{*logC6}
+	headerC6 := &types.Header{
+		Number:     big.NewInt(15),
+		ParentHash: common.HexToHash("bar"), // Different parent hash to create different block hash
+	}
+	logC6ByHash, updateC6ByHash := generateEvent(15)
+	logC6ByHash.BlockHash = headerC6.Hash()
+	blocksC6 := EVMBlocks{
+		{
+			EVMBlockHeader: EVMBlockHeader{
+				Num:        15,
+				Hash:       headerC6.Hash(),
+				ParentHash: common.HexToHash("bar"),
+			},
+			Events: []interface{}{updateC6ByHash},
+		},
+	}
 	case6 := testCase{
-		description:    "case 6: block hash mismatch with max retries exceeded",
+		description:    "case 6: block hash mismatch, events queried by block hash",
 		inputLogs:      logsC6,
 		fromBlock:      15,
 		toBlock:        15,
-		expectedBlocks: nil,
+		expectedBlocks: blocksC6,
 		setupMocks: func(clientMock *aggkittypesmocks.BaseEthereumClienter) {
-			// Return a different hash than the log's block hash for all retry attempts
-			// This will trigger the retry logic and eventually exceed max retries
-			for i := 0; i < MaxRetryCountBlockHashMismatch+1; i++ {
-				clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(15)).
-					Return(&types.Header{
-						Number:     big.NewInt(15),
-						ParentHash: common.HexToHash("bar"), // Different parent hash to create different block hash
-						// The hash will be different from logC6.BlockHash, causing mismatch
-					}, nil).Once()
-			}
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(15)).Return(headerC6, nil).Once()
+			blockHash := headerC6.Hash()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				BlockHash: &blockHash,
+			}).Return([]types.Log{*logC6ByHash}, nil).Once()
 		},
 	}
 	testCases = append(testCases, case6)
@@ -285,12 +298,212 @@ func TestGetEventsByBlockRange(t *testing.T) {
 	}
 	testCases = append(testCases, case8)
 
+	// case 9: block hash mismatch in the middle of the range, the block is queried by hash
+	// (it has no events anymore) and the rest of the range is queried again
+	logC9_1, updateC9_1 := generateEvent(30)
+	logC9_2, _ := generateEvent(31)
+	logC9_3, updateC9_3 := generateEvent(32)
+	headerC9 := &types.Header{
+		Number:     big.NewInt(31),
+		ParentHash: common.HexToHash("bar"),
+	}
+	blocksC9 := EVMBlocks{
+		{
+			EVMBlockHeader: EVMBlockHeader{
+				Num:        30,
+				Hash:       logC9_1.BlockHash,
+				ParentHash: common.HexToHash("foo"),
+			},
+			Events: []interface{}{updateC9_1},
+		},
+		{
+			EVMBlockHeader: EVMBlockHeader{
+				Num:        32,
+				Hash:       logC9_3.BlockHash,
+				ParentHash: common.HexToHash("foo"),
+			},
+			Events: []interface{}{updateC9_3},
+		},
+	}
+	case9 := testCase{
+		description:    "case 9: block hash mismatch in the middle of the range",
+		inputLogs:      []types.Log{*logC9_1, *logC9_2},
+		fromBlock:      30,
+		toBlock:        32,
+		expectedBlocks: blocksC9,
+		setupMocks: func(clientMock *aggkittypesmocks.BaseEthereumClienter) {
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(30)).
+				Return(&types.Header{
+					Number:     big.NewInt(30),
+					ParentHash: common.HexToHash("foo"),
+				}, nil).Once()
+			// the header is read again because there are no logs by hash, it's still the canonical one
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(31)).Return(headerC9, nil).Twice()
+			blockHash := headerC9.Hash()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				BlockHash: &blockHash,
+			}).Return([]types.Log{}, nil).Once()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				FromBlock: big.NewInt(32),
+				ToBlock:   big.NewInt(32),
+			}).Return([]types.Log{*logC9_3}, nil).Once()
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(32)).
+				Return(&types.Header{
+					Number:     big.NewInt(32),
+					ParentHash: common.HexToHash("foo"),
+				}, nil).Once()
+		},
+	}
+	testCases = append(testCases, case9)
+
+	// case 10: block hash mismatch on every block, max retries exceeded
+	fromBlockC10 := uint64(40)
+	toBlockC10 := fromBlockC10 + MaxRetryCountBlockHashMismatch + 1
+	logsC10 := make([]types.Log, 0, toBlockC10-fromBlockC10+1)
+	for blockNum := fromBlockC10; blockNum <= toBlockC10; blockNum++ {
+		l, _ := generateEvent(uint32(blockNum))
+		logsC10 = append(logsC10, *l)
+	}
+	case10 := testCase{
+		description:    "case 10: block hash mismatch on every block, max retries exceeded",
+		inputLogs:      logsC10,
+		fromBlock:      fromBlockC10,
+		toBlock:        toBlockC10,
+		expectedBlocks: nil,
+		setupMocks: func(clientMock *aggkittypesmocks.BaseEthereumClienter) {
+			for i := 0; i <= MaxRetryCountBlockHashMismatch; i++ {
+				blockNum := fromBlockC10 + uint64(i)
+				header := &types.Header{
+					Number:     new(big.Int).SetUint64(blockNum),
+					ParentHash: common.HexToHash("bar"),
+				}
+				if i == MaxRetryCountBlockHashMismatch {
+					clientMock.EXPECT().HeaderByNumber(mock.Anything, header.Number).Return(header, nil).Once()
+					break
+				}
+				clientMock.EXPECT().HeaderByNumber(mock.Anything, header.Number).Return(header, nil).Twice()
+				blockHash := header.Hash()
+				clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+					Topics:    [][]common.Hash{{eventSignature}},
+					Addresses: []common.Address{contractAddr},
+					BlockHash: &blockHash,
+				}).Return([]types.Log{}, nil).Once()
+				clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+					Topics:    [][]common.Hash{{eventSignature}},
+					Addresses: []common.Address{contractAddr},
+					FromBlock: new(big.Int).SetUint64(blockNum + 1),
+					ToBlock:   new(big.Int).SetUint64(toBlockC10),
+				}).Return(logsC10[i+1:], nil).Once()
+			}
+		},
+	}
+	testCases = append(testCases, case10)
+
+	// case 11: block hash mismatch and the header is reorged out too: the logs can't be queried by its
+	// hash, so the range is queried again from the block
+	logC11, _ := generateEvent(60)
+	staleHeaderC11 := &types.Header{
+		Number:     big.NewInt(60),
+		ParentHash: common.HexToHash("bar"),
+	}
+	canonicalHeaderC11 := &types.Header{
+		Number:     big.NewInt(60),
+		ParentHash: common.HexToHash("0xdead"),
+	}
+	logC11Canonical, updateC11Canonical := generateEvent(60)
+	logC11Canonical.BlockHash = canonicalHeaderC11.Hash()
+	blocksC11 := EVMBlocks{
+		{
+			EVMBlockHeader: EVMBlockHeader{
+				Num:        60,
+				Hash:       canonicalHeaderC11.Hash(),
+				ParentHash: common.HexToHash("0xdead"),
+			},
+			Events: []interface{}{updateC11Canonical},
+		},
+	}
+	case11 := testCase{
+		description:    "case 11: block hash mismatch, the header is reorged out before querying by hash",
+		inputLogs:      []types.Log{*logC11},
+		fromBlock:      59,
+		toBlock:        60,
+		expectedBlocks: blocksC11,
+		setupMocks: func(clientMock *aggkittypesmocks.BaseEthereumClienter) {
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(60)).Return(staleHeaderC11, nil).Once()
+			staleHash := staleHeaderC11.Hash()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				BlockHash: &staleHash,
+			}).Return(nil, errors.New("unknown block")).Once()
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(60)).Return(canonicalHeaderC11, nil).Twice()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				FromBlock: big.NewInt(60),
+				ToBlock:   big.NewInt(60),
+			}).Return([]types.Log{*logC11Canonical}, nil).Once()
+		},
+	}
+	testCases = append(testCases, case11)
+
+	// case 12: block hash mismatch, the node returns no logs for the stale hash instead of an error
+	logC12, _ := generateEvent(70)
+	staleHeaderC12 := &types.Header{
+		Number:     big.NewInt(70),
+		ParentHash: common.HexToHash("bar"),
+	}
+	canonicalHeaderC12 := &types.Header{
+		Number:     big.NewInt(70),
+		ParentHash: common.HexToHash("0xdead"),
+	}
+	logC12Canonical, updateC12Canonical := generateEvent(70)
+	logC12Canonical.BlockHash = canonicalHeaderC12.Hash()
+	case12 := testCase{
+		description: "case 12: block hash mismatch, no logs for the stale hash",
+		inputLogs:   []types.Log{*logC12},
+		fromBlock:   69,
+		toBlock:     70,
+		expectedBlocks: EVMBlocks{
+			{
+				EVMBlockHeader: EVMBlockHeader{
+					Num:        70,
+					Hash:       canonicalHeaderC12.Hash(),
+					ParentHash: common.HexToHash("0xdead"),
+				},
+				Events: []interface{}{updateC12Canonical},
+			},
+		},
+		setupMocks: func(clientMock *aggkittypesmocks.BaseEthereumClienter) {
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(70)).Return(staleHeaderC12, nil).Once()
+			staleHash := staleHeaderC12.Hash()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				BlockHash: &staleHash,
+			}).Return([]types.Log{}, nil).Once()
+			clientMock.EXPECT().HeaderByNumber(mock.Anything, big.NewInt(70)).Return(canonicalHeaderC12, nil).Twice()
+			clientMock.EXPECT().FilterLogs(mock.Anything, ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
+				Addresses: []common.Address{contractAddr},
+				FromBlock: big.NewInt(70),
+				ToBlock:   big.NewInt(70),
+			}).Return([]types.Log{*logC12Canonical}, nil).Once()
+		},
+	}
+	testCases = append(testCases, case12)
+
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("test_case_%d_%s", i, tc.description), func(t *testing.T) {
 			// Reset mock for each test case
 			clientMock.ExpectedCalls = nil
 
 			query := ethereum.FilterQuery{
+				Topics:    [][]common.Hash{{eventSignature}},
 				FromBlock: new(big.Int).SetUint64(tc.fromBlock),
 				Addresses: []common.Address{contractAddr},
 				ToBlock:   new(big.Int).SetUint64(tc.toBlock),
@@ -385,6 +598,33 @@ func TestWaitForNewBlocks(t *testing.T) {
 	assert.Equal(t, expectedBlock, actualBlock)
 }
 
+func TestWaitForNewBlocksWithNewHeadsNotifier(t *testing.T) {
+	ctx := context.Background()
+	clientMock := aggkittypesmocks.NewBaseEthereumClienter(t)
+	// the polling period is long enough to ensure that the new head notification wakes up the wait
+	d, err := NewEVMDownloader("test",
+		clientMock, syncBlockChunck, aggkittypes.LatestBlock, time.Hour,
+		buildAppender(), []common.Address{contractAddr}, &RetryHandler{MaxRetryAttemptsAfterError: 5},
+		aggkittypes.FinalizedBlock,
+	)
+	require.NoError(t, err)
+	newHeads := make(chan uint64, 1)
+	d.SetNewHeadsNotifier(newHeads)
+
+	clientMock.EXPECT().HeaderByNumber(ctx, mock.Anything).Return(&types.Header{Number: big.NewInt(6)}, nil).Once()
+	newHeads <- 6
+	done := make(chan uint64)
+	go func() {
+		done <- d.WaitForNewBlocks(ctx, 5)
+	}()
+	select {
+	case actualBlock := <-done:
+		require.Equal(t, uint64(6), actualBlock)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "WaitForNewBlocks didn't return after the new head notification")
+	}
+}
+
 func TestGetBlockHeader(t *testing.T) {
 	ctx := context.Background()
 	d, clientMock := NewTestDownloader(t, time.Millisecond)
@@ -446,6 +686,13 @@ func TestFilterQueryToString(t *testing.T) {
 		Topics:    [][]common.Hash{{common.HexToHash("0x1234"), common.HexToHash("0x5678")}},
 	}
 	assert.Equal(t, "FromBlock: 1000, ToBlock: 1100, Addresses: [0x000000000000000000000000000000000000f000 0x000000000000000000000000000000000000ABcD], Topics: [[0x0000000000000000000000000000000000000000000000000000000000001234 0x0000000000000000000000000000000000000000000000000000000000005678]]", filterQueryToString(query))
+
+	blockHash := common.HexToHash("0xabcd")
+	query = ethereum.FilterQuery{
+		BlockHash: &blockHash,
+		Addresses: []common.Address{addr1},
+	}
+	assert.Equal(t, "BlockHash: 0x000000000000000000000000000000000000000000000000000000000000abcd, Addresses: [0x000000000000000000000000000000000000f000], Topics: []", filterQueryToString(query))
 }
 
 func TestGetLogs(t *testing.T) {
@@ -466,6 +713,79 @@ func TestGetLogs(t *testing.T) {
 	require.Equal(t, []types.Log{}, logs)
 }
 
+func TestGetLogsByBlockHash(t *testing.T) {
+	mockEthClient := aggkittypesmocks.NewBaseEthereumClienter(t)
+	sut := EVMDownloaderImplementation{
+		ethClient:        mockEthClient,
+		addressesToQuery: []common.Address{contractAddr},
+		topicsToQuery:    []common.Hash{eventSignature},
+		log:              log.WithFields("test", "EVMDownloaderImplementation"),
+		rh: &RetryHandler{
+			RetryAfterErrorPeriod:      time.Millisecond,
+			MaxRetryAttemptsAfterError: 5,
+		},
+	}
+	ctx := context.TODO()
+	l, _ := generateEvent(1)
+	removed, _ := generateEvent(1)
+	removed.Removed = true
+	query := ethereum.FilterQuery{
+		Topics:    [][]common.Hash{{eventSignature}},
+		Addresses: []common.Address{contractAddr},
+		BlockHash: &l.BlockHash,
+	}
+	// the errors are not retried, the block may have been reorged out
+	mockEthClient.EXPECT().FilterLogs(ctx, query).Return(nil, errors.New("unknown block")).Once()
+	_, err := sut.GetLogsByBlockHash(ctx, l.BlockHash)
+	require.ErrorContains(t, err, "unknown block")
+
+	mockEthClient.EXPECT().FilterLogs(ctx, query).Return([]types.Log{*l, *removed}, nil).Once()
+	logs, err := sut.GetLogsByBlockHash(ctx, l.BlockHash)
+	require.NoError(t, err)
+	require.Equal(t, []types.Log{*l}, logs)
+}
+
+func TestGetLogsClientSideTopicFiltering(t *testing.T) {
+	ctx := context.TODO()
+	d, clientMock := NewTestDownloader(t, time.Millisecond)
+	tracked, _ := generateEvent(1)
+	untracked, _ := generateEvent(1)
+	untracked.Topics[0] = common.HexToHash("0x1234")
+	anonymous := &types.Log{Address: contractAddr, BlockNumber: 1}
+
+	// the topics are sent in the query by default
+	clientMock.EXPECT().FilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{contractAddr},
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(2),
+		Topics:    [][]common.Hash{{eventSignature}},
+	}).Return([]types.Log{*tracked}, nil).Once()
+	require.Equal(t, []types.Log{*tracked}, d.GetLogs(ctx, 1, 2))
+
+	// all the logs are requested and filtered after retrieval
+	require.NoError(t, d.EnableClientSideTopicFiltering())
+	clientMock.EXPECT().FilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{contractAddr},
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(2),
+	}).Return([]types.Log{*tracked, *untracked, *anonymous}, nil).Once()
+	require.Equal(t, []types.Log{*tracked}, d.GetLogs(ctx, 1, 2))
+
+	d.EVMDownloaderInterface = NewEVMDownloaderMock(t)
+	require.Error(t, d.EnableClientSideTopicFiltering())
+}
+
+func TestLogAppenderMapGetTopics(t *testing.T) {
+	appender := LogAppenderMap{
+		common.HexToHash("0x03"): nil,
+		common.HexToHash("0x01"): nil,
+		common.HexToHash("0x02"): nil,
+	}
+	require.Equal(t, []common.Hash{
+		common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03"),
+	}, appender.GetTopics())
+}
+
 func TestDownloadBeforeFinalized(t *testing.T) {
 	steps := []evmTestStep{
 		{finalizedBlock: 33, fromBlock: 1, toBlock: 11, waitForNewBlocks: true, waitForNewBlocksRequest: 0, waitForNewBlockReply: 35, getBlockHeader: &EVMBlockHeader{Num: 11}},
@@ -501,6 +821,31 @@ func TestCaseAskLastBlockIfFinalitySameAsTargetBlock(t *testing.T) {
 	runSteps(t, 99, steps)
 }
 
+func TestLastFinalizedBlock(t *testing.T) {
+	mockEthDownloader := NewEVMDownloaderMock(t)
+	downloader, _ := NewTestDownloader(t, time.Millisecond)
+	downloader.EVMDownloaderInterface = mockEthDownloader
+	downloader.setStopDownloaderOnIterationN(1)
+
+	_, ok := downloader.LastFinalizedBlock()
+	require.False(t, ok)
+
+	mockEthDownloader.EXPECT().WaitForNewBlocks(mock.Anything, uint64(0)).Return(uint64(35)).Once()
+	mockEthDownloader.EXPECT().GetLastFinalizedBlock(mock.Anything).Return(&types.Header{Number: big.NewInt(33)}, nil).Once()
+	mockEthDownloader.EXPECT().GetEventsByBlockRange(mock.Anything, uint64(1), uint64(11)).Return(nil).Once()
+	mockEthDownloader.EXPECT().GetBlockHeader(mock.Anything, uint64(11)).Return(EVMBlockHeader{Num: 11}, false).Once()
+	downloader.Download(context.Background(), 1, make(chan EVMBlock, 10))
+
+	lastFinalizedBlock, ok := downloader.LastFinalizedBlock()
+	require.True(t, ok)
+	require.Equal(t, uint64(33), lastFinalizedBlock)
+
+	// the blocks are not tracked as finalized if the finalized block type is not FinalizedBlock
+	downloader.finalizedBlockType = aggkittypes.LatestBlock
+	_, ok = downloader.LastFinalizedBlock()
+	require.False(t, ok)
+}
+
 func buildAppender() LogAppenderMap {
 	appender := make(LogAppenderMap)
 	appender[eventSignature] = func(b *EVMBlock, l types.Log) error {
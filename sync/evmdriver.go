@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/db/compatibility"
+	"github.com/agglayer/aggkit/healthcheck"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/reorgdetector"
 	aggkittypes "github.com/agglayer/aggkit/types"
@@ -93,6 +95,7 @@ func NewEVMDriver(
 	compatibilityChecker compatibility.CompatibilityChecker,
 ) (*EVMDriver, error) {
 	logger := log.WithFields("syncer", reorgDetectorID)
+	registerMetrics()
 	reorgSub, err := reorgDetector.Subscribe(reorgDetectorID)
 	if err != nil {
 		return nil, err
@@ -120,6 +123,7 @@ reset:
 	)
 	for {
 		if err = d.compatibilityChecker.Check(ctx, nil); err != nil {
+			d.exitIfUnrecoverable("CompatibilityChecker", err)
 			attempts++
 			d.log.Error("error checking compatibility data between downloader (runtime) and processor (db): ", err)
 			d.rh.Handle("CompatibilityChecker", attempts)
@@ -130,6 +134,7 @@ reset:
 	for {
 		lastProcessedBlock, err = d.processor.GetLastProcessedBlock(ctx)
 		if err != nil {
+			d.exitIfUnrecoverable("Sync", err)
 			attempts++
 			d.log.Error("error getting last processed block: ", err)
 			d.rh.Handle("Sync", attempts)
@@ -137,8 +142,9 @@ reset:
 		}
 		break
 	}
-	cancellableCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	// the cause of the cancellation of the downloader is the reason of the stop (see StopReasonOf)
+	cancellableCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
 
 	d.log.Infof("Starting sync... lastProcessedBlock %d", lastProcessedBlock)
 	// start downloading
@@ -148,8 +154,8 @@ reset:
 	for {
 		select {
 		case <-ctx.Done():
-			d.log.Info("sync stopped due to context done")
-			cancel()
+			d.recordStop(StopReasonShutdown, fmt.Sprintf("context done: %v", context.Cause(ctx)))
+			cancel(context.Cause(ctx))
 			return
 		case b, ok := <-downloadCh:
 			if ok {
@@ -166,7 +172,7 @@ reset:
 	}
 }
 
-func (d *EVMDriver) handleNewBlock(ctx context.Context, cancel context.CancelFunc, b EVMBlock) {
+func (d *EVMDriver) handleNewBlock(ctx context.Context, cancel context.CancelCauseFunc, b EVMBlock) {
 	attempts := 0
 	succeed := false
 	for {
@@ -178,6 +184,11 @@ func (d *EVMDriver) handleNewBlock(ctx context.Context, cancel context.CancelFun
 		default:
 			if !b.IsFinalizedBlock {
 				err := d.reorgDetector.AddBlockToTrack(ctx, d.reorgDetectorID, b.Num, b.Hash)
+				if err != nil && ctx.Err() != nil {
+					// not a failure of the reorg detector, the error is caused by the cancellation
+					d.log.Warnf("block %d not added to tracker, the context is canceled: %v", b.Num, err)
+					return
+				}
 				if err != nil {
 					attempts++
 					d.log.Errorf("error adding block %d to tracker: %v", b.Num, err)
@@ -208,12 +219,21 @@ func (d *EVMDriver) handleNewBlock(ctx context.Context, cancel context.CancelFun
 				Hash:   b.Hash,
 			}
 			err := d.processor.ProcessBlock(ctx, blockToProcess)
+			if err != nil && ctx.Err() != nil {
+				// not a failure of the processor, the error is caused by the cancellation
+				d.log.Warnf("block %d not processed, the context is canceled: %v", b.Num, err)
+				return
+			}
+			healthcheck.RecordResult(d.healthComponent(), err)
 			if err != nil {
 				if errors.Is(err, ErrInconsistentState) {
-					d.log.Warn("state got inconsistent after processing this block. Stopping downloader until there is a reorg")
-					cancel()
+					d.recordStop(StopReasonInconsistentState, fmt.Sprintf(
+						"the state got inconsistent processing block %d, the downloader is stopped until there is a reorg",
+						b.Num))
+					cancel(ErrInconsistentState)
 					return
 				}
+				d.exitIfUnrecoverable("handleNewBlock", err)
 				attempts++
 				d.log.Errorf("error processing events for block %d, err: %v", b.Num, err)
 				d.rh.Handle("handleNewBlock", attempts)
@@ -227,15 +247,30 @@ func (d *EVMDriver) handleNewBlock(ctx context.Context, cancel context.CancelFun
 	}
 }
 
-func (d *EVMDriver) handleReorg(ctx context.Context, cancel context.CancelFunc, firstReorgedBlock uint64) {
+func (d *EVMDriver) handleReorg(ctx context.Context, cancel context.CancelCauseFunc, firstReorgedBlock uint64) {
 	// stop downloader
-	cancel()
+	cancel(ErrReorgRestart)
+	d.recordStop(StopReasonReorg, fmt.Sprintf("the sync restarts from the reorged block %d", firstReorgedBlock))
+
+	// the processor is reorged before the ack: the reorg detector stops tracking the reorged blocks, so
+	// the reorged data must not be kept in the DB afterwards
+	d.reorgProcessor(ctx, firstReorgedBlock)
+	d.reorgSub.ReorgProcessed <- true
+}
 
-	// handle reorg
+// reorgProcessor reorgs the processor, retrying until it succeeds
+func (d *EVMDriver) reorgProcessor(ctx context.Context, firstReorgedBlock uint64) {
 	attempts := 0
 	for {
 		err := d.processor.Reorg(ctx, firstReorgedBlock)
+		if err != nil && ctx.Err() != nil {
+			// the sync is stopping, the reorg is not retried
+			d.log.Warnf("reorg from block %d not processed, the context is canceled: %v", firstReorgedBlock, err)
+			return
+		}
+		healthcheck.RecordResult(d.healthComponent(), err)
 		if err != nil {
+			d.exitIfUnrecoverable("handleReorg", err)
 			attempts++
 			d.log.Errorf(
 				"error processing reorg, last valid Block %d, err: %v",
@@ -246,5 +281,24 @@ func (d *EVMDriver) handleReorg(ctx context.Context, cancel context.CancelFunc,
 		}
 		break
 	}
-	d.reorgSub.ReorgProcessed <- true
+}
+
+// healthComponent is the component of the processor in the error budget of the health endpoint
+func (d *EVMDriver) healthComponent() string {
+	return "processor_" + d.reorgDetectorID
+}
+
+// exitIfUnrecoverable exits the process if the error can't be fixed by retrying: the DB is corrupted or its
+// contents are incompatible with the runtime. The exit code is the one of the class of the failure
+func (d *EVMDriver) exitIfUnrecoverable(funcName string, err error) {
+	code := aggkitcommon.ExitCodeOf(err)
+	if db.IsCorruption(err) {
+		code = aggkitcommon.ExitCodeDBCorruption
+	}
+	if code != aggkitcommon.ExitCodeDBCorruption && code != aggkitcommon.ExitCodeIncompatibleStorage {
+		return
+	}
+	d.recordStop(StopReasonFatal, fmt.Sprintf("%s failed (%s): %v", funcName, code, err))
+	ExitWithCodef(code, "%s failed, stopping (reason: %s, exit code: %d %s): %v",
+		funcName, StopReasonFatal, int(code), code, err)
 }
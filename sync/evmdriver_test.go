@@ -7,10 +7,12 @@ import (
 	"testing"
 	"time"
 
+	aggkitcommon "github.com/agglayer/aggkit/common"
 	compmocks "github.com/agglayer/aggkit/db/compatibility/mocks"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/reorgdetector"
 	"github.com/ethereum/go-ethereum/common"
+	sqlite "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -197,12 +199,28 @@ func TestHandleNewBlock(t *testing.T) {
 		Return(nil)
 	pm.On("ProcessBlock", ctx, Block{Num: b4.Num, Events: b4.Events, Hash: b4.Hash}).
 		Return(ErrInconsistentState)
-	cancelIsCalled := false
-	cancel := func() {
-		cancelIsCalled = true
+	var cancelCause error
+	cancel := func(cause error) {
+		cancelCause = cause
 	}
 	driver.handleNewBlock(ctx, cancel, b4)
-	require.True(t, cancelIsCalled)
+	require.ErrorIs(t, cancelCause, ErrInconsistentState)
+
+	// the error caused by the cancellation is not retried
+	b5 := EVMBlock{
+		EVMBlockHeader: EVMBlockHeader{
+			Num:  5,
+			Hash: common.HexToHash("f00"),
+		},
+	}
+	canceledCtx, cancelCtx := context.WithCancel(ctx)
+	rdm.
+		On("AddBlockToTrack", canceledCtx, reorgDetectorID, b5.Num, b5.Hash).
+		Return(nil)
+	pm.On("ProcessBlock", canceledCtx, Block{Num: b5.Num, Events: b5.Events, Hash: b5.Hash}).
+		Run(func(mock.Arguments) { cancelCtx() }).
+		Return(context.Canceled).Once()
+	driver.handleNewBlock(canceledCtx, nil, b5)
 }
 
 func TestHandleReorg(t *testing.T) {
@@ -223,15 +241,17 @@ func TestHandleReorg(t *testing.T) {
 	ctx := context.Background()
 
 	// happy path
-	_, cancel := context.WithCancel(ctx)
+	downloaderCtx, cancel := context.WithCancelCause(ctx)
 	firstReorgedBlock := uint64(5)
 	pm.On("Reorg", ctx, firstReorgedBlock).Return(nil)
 	go driver.handleReorg(ctx, cancel, firstReorgedBlock)
 	done := <-reorgProcessed
 	require.True(t, done)
+	// the downloader is stopped because of the reorg
+	require.Equal(t, StopReasonReorg, StopReasonOf(downloaderCtx))
 
 	// processor fails 2 times
-	_, cancel = context.WithCancel(ctx)
+	_, cancel = context.WithCancelCause(ctx)
 	firstReorgedBlock = uint64(7)
 	pm.On("Reorg", ctx, firstReorgedBlock).Return(errors.New("foo")).Once()
 	pm.On("Reorg", ctx, firstReorgedBlock).Return(errors.New("foo")).Once()
@@ -241,6 +261,74 @@ func TestHandleReorg(t *testing.T) {
 	require.True(t, done)
 }
 
+// memoryProcessor is a processor that keeps the processed blocks in memory, shared by the drivers of a test
+type memoryProcessor struct {
+	mu     sync.Mutex
+	blocks []uint64
+}
+
+func (p *memoryProcessor) GetLastProcessedBlock(context.Context) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.blocks) == 0 {
+		return 0, nil
+	}
+	return p.blocks[len(p.blocks)-1], nil
+}
+
+func (p *memoryProcessor) ProcessBlock(_ context.Context, block Block) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.blocks = append(p.blocks, block.Num)
+	return nil
+}
+
+func (p *memoryProcessor) Reorg(_ context.Context, firstReorgedBlock uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, num := range p.blocks {
+		if num >= firstReorgedBlock {
+			p.blocks = p.blocks[:i]
+			break
+		}
+	}
+	return nil
+}
+
+func TestHandleReorgRestartBeforeRedownload(t *testing.T) {
+	ctx := context.Background()
+	rh := &RetryHandler{MaxRetryAttemptsAfterError: 5, RetryAfterErrorPeriod: time.Millisecond}
+	processor := &memoryProcessor{blocks: []uint64{1, 2, 3, 4, 5}}
+	newTestDriver := func(dm *DownloaderMock) *EVMDriver {
+		rdm := NewReorgDetectorMock(t)
+		rdm.On("Subscribe", reorgDetectorID).Return(&reorgdetector.Subscription{
+			ReorgedBlock:   make(chan uint64),
+			ReorgProcessed: make(chan bool, 1),
+		}, nil)
+		compatibilityCheckerMock := compmocks.NewCompatibilityChecker(t)
+		compatibilityCheckerMock.EXPECT().Check(mock.Anything, nil).Return(nil).Maybe()
+		driver, err := NewEVMDriver(rdm, processor, dm, reorgDetectorID, 10, rh, compatibilityCheckerMock)
+		require.NoError(t, err)
+		return driver
+	}
+
+	// the reorg is acked, and the driver is stopped before downloading again the reorged blocks
+	driver := newTestDriver(NewDownloaderMock(t))
+	_, cancel := context.WithCancelCause(ctx)
+	driver.handleReorg(ctx, cancel, 3)
+	require.True(t, <-driver.reorgSub.ReorgProcessed)
+	lastProcessedBlock, err := processor.GetLastProcessedBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lastProcessedBlock)
+
+	// on restart the reorged blocks are not in the processor, and they are downloaded again
+	dm := NewDownloaderMock(t)
+	restartedCtx, stop := context.WithCancel(ctx)
+	dm.On("Download", mock.Anything, uint64(3), mock.Anything).Run(func(mock.Arguments) { stop() }).Return()
+	newTestDriver(dm).Sync(restartedCtx)
+	require.Equal(t, []uint64{1, 2}, processor.blocks)
+}
+
 func TestCheckCompatibility(t *testing.T) {
 	reorgDetectorMock := NewReorgDetectorMock(t)
 	processorMock := NewProcessorMock(t)
@@ -275,4 +363,37 @@ func TestCheckCompatibility(t *testing.T) {
 			driver.Sync(context.Background())
 		}, "should stop because GetLastProcessedBlock failed")
 	})
+	newDriver := func(t *testing.T) (*EVMDriver, *compmocks.CompatibilityChecker, *ProcessorMock) {
+		t.Helper()
+		reorgDetectorMock := NewReorgDetectorMock(t)
+		processorMock := NewProcessorMock(t)
+		compatibilityCheckerMock := compmocks.NewCompatibilityChecker(t)
+		reorgDetectorMock.EXPECT().Subscribe(reorgDetectorID).Return(&reorgdetector.Subscription{}, nil)
+		driver, err := NewEVMDriver(reorgDetectorMock, processorMock, NewDownloaderMock(t), reorgDetectorID, 10,
+			retryHandler, compatibilityCheckerMock)
+		require.NoError(t, err)
+		return driver, compatibilityCheckerMock, processorMock
+	}
+	exitWithCodef := ExitWithCodef
+	defer func() { ExitWithCodef = exitWithCodef }()
+	ExitWithCodef = func(code aggkitcommon.ExitCode, format string, args ...any) {
+		panic(code)
+	}
+	t.Run("incompatible storage exits without retrying", func(t *testing.T) {
+		driver, compatibilityCheckerMock, _ := newDriver(t)
+		compatibilityCheckerMock.EXPECT().Check(context.Background(), nil).Return(
+			aggkitcommon.NewExitError(aggkitcommon.ExitCodeIncompatibleStorage, errUnittest)).Once()
+		require.PanicsWithValue(t, aggkitcommon.ExitCodeIncompatibleStorage, func() {
+			driver.Sync(context.Background())
+		})
+	})
+	t.Run("corrupted DB exits without retrying", func(t *testing.T) {
+		driver, compatibilityCheckerMock, processorMock := newDriver(t)
+		compatibilityCheckerMock.EXPECT().Check(context.Background(), nil).Return(nil).Once()
+		processorMock.EXPECT().GetLastProcessedBlock(context.Background()).
+			Return(uint64(0), sqlite.Error{Code: sqlite.ErrCorrupt}).Once()
+		require.PanicsWithValue(t, aggkitcommon.ExitCodeDBCorruption, func() {
+			driver.Sync(context.Background())
+		})
+	})
 }
@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// DefaultHeadFeedReconnectPeriod is the time waited before reconnecting to the feed after an error
+const DefaultHeadFeedReconnectPeriod = time.Second * 5
+
+// HeadSubscriber is a client that notifies the new blocks (e.g. the WS endpoint of the sequencer)
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	Close()
+}
+
+// HeadSubscriberDialer connects to the feed
+type HeadSubscriberDialer func(ctx context.Context) (HeadSubscriber, error)
+
+// HeadFeed subscribes to the new heads of a real-time feed (e.g. the sequencer WS subscription)
+// and notifies the block number of each new head. The notifications are just a hint to
+// query the RPC without waiting for the next poll: the blocks, logs and finality are
+// always read (and reconciled) from the RPC
+type HeadFeed struct {
+	syncerID        string
+	log             *log.Logger
+	dial            HeadSubscriberDialer
+	reconnectPeriod time.Duration
+	newHeadsCh      chan uint64
+}
+
+// NewHeadFeed creates a HeadFeed that connects to the given websocket URL
+func NewHeadFeed(syncerID, url string, reconnectPeriod time.Duration) (*HeadFeed, error) {
+	if !strings.HasPrefix(url, "ws://") && !strings.HasPrefix(url, "wss://") {
+		return nil, fmt.Errorf("head feed URL %q must be a websocket URL (ws:// or wss://)", url)
+	}
+	dial := func(ctx context.Context) (HeadSubscriber, error) {
+		return ethclient.DialContext(ctx, url)
+	}
+	return newHeadFeed(syncerID, dial, reconnectPeriod), nil
+}
+
+func newHeadFeed(syncerID string, dial HeadSubscriberDialer, reconnectPeriod time.Duration) *HeadFeed {
+	if reconnectPeriod == 0 {
+		reconnectPeriod = DefaultHeadFeedReconnectPeriod
+	}
+	registerMetrics()
+	return &HeadFeed{
+		syncerID:        syncerID,
+		log:             log.WithFields("syncer", syncerID, "module", "headfeed"),
+		dial:            dial,
+		reconnectPeriod: reconnectPeriod,
+		// only the last head matters, so the channel holds one notification
+		newHeadsCh: make(chan uint64, 1),
+	}
+}
+
+// NewHeads returns the channel where the block numbers of the new heads are notified
+func (f *HeadFeed) NewHeads() <-chan uint64 {
+	return f.newHeadsCh
+}
+
+// Start consumes the feed until the context is cancelled, reconnecting after any error
+func (f *HeadFeed) Start(ctx context.Context) {
+	for {
+		err := f.consume(ctx)
+		if ctx.Err() != nil {
+			f.log.Info("context cancelled, stopping head feed")
+			return
+		}
+		headFeedReconnection(f.syncerID)
+		f.log.Warnf("head feed disconnected, reconnecting in %s. Err: %v", f.reconnectPeriod, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.reconnectPeriod):
+		}
+	}
+}
+
+func (f *HeadFeed) consume(ctx context.Context) error {
+	client, err := f.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("error connecting to the feed: %w", err)
+	}
+	defer client.Close()
+	headers := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return fmt.Errorf("error subscribing to new heads: %w", err)
+	}
+	defer sub.Unsubscribe()
+	f.log.Info("subscribed to new heads")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return fmt.Errorf("subscription error: %w", err)
+		case header := <-headers:
+			if header == nil || header.Number == nil {
+				continue
+			}
+			headFeedHeadReceived(f.syncerID)
+			f.notify(header.Number.Uint64())
+		}
+	}
+}
+
+// notify replaces the pending notification (if any) by the new head, so the consumer
+// never blocks the feed and always gets the latest block number
+func (f *HeadFeed) notify(blockNum uint64) {
+	for {
+		select {
+		case f.newHeadsCh <- blockNum:
+			return
+		default:
+		}
+		select {
+		case <-f.newHeadsCh:
+		default:
+		}
+	}
+}
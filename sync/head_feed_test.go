@@ -0,0 +1,113 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testSubscription struct {
+	errCh chan error
+}
+
+func (s *testSubscription) Unsubscribe() {}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+type testHeadSubscriber struct {
+	headers chan chan<- *types.Header
+	sub     *testSubscription
+	err     error
+	closed  atomic.Int32
+}
+
+func (s *testHeadSubscriber) Close() {
+	s.closed.Add(1)
+}
+
+func (s *testHeadSubscriber) SubscribeNewHead(_ context.Context,
+	ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.headers <- ch
+	return s.sub, nil
+}
+
+func TestNewHeadFeed(t *testing.T) {
+	_, err := NewHeadFeed("test", "http://localhost:8545", 0)
+	require.ErrorContains(t, err, "must be a websocket URL")
+
+	feed, err := NewHeadFeed("test", "ws://localhost:8546", 0)
+	require.NoError(t, err)
+	require.Equal(t, DefaultHeadFeedReconnectPeriod, feed.reconnectPeriod)
+}
+
+func TestHeadFeedNotifiesNewHeads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscriber := &testHeadSubscriber{
+		headers: make(chan chan<- *types.Header, 2),
+		sub:     &testSubscription{errCh: make(chan error, 1)},
+	}
+	dials := 0
+	feed := newHeadFeed("test", func(ctx context.Context) (HeadSubscriber, error) {
+		dials++
+		if dials == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return subscriber, nil
+	}, time.Millisecond)
+	go feed.Start(ctx)
+
+	// the first dial fails, so the feed reconnects
+	headersCh := <-subscriber.headers
+	headersCh <- &types.Header{Number: big.NewInt(10)}
+	require.Equal(t, uint64(10), waitNewHead(t, feed))
+
+	// a pending notification is replaced by the latest head
+	headersCh <- &types.Header{Number: big.NewInt(11)}
+	headersCh <- &types.Header{Number: big.NewInt(12)}
+	require.Eventually(t, func() bool { return len(feed.newHeadsCh) == 1 }, time.Second, time.Millisecond)
+	headersCh <- &types.Header{Number: big.NewInt(13)}
+	require.Equal(t, uint64(13), waitLatestHead(t, feed, 13))
+
+	// a subscription error makes the feed resubscribe
+	subscriber.sub.errCh <- errors.New("connection lost")
+	headersCh = <-subscriber.headers
+	headersCh <- &types.Header{Number: big.NewInt(14)}
+	require.Equal(t, uint64(14), waitLatestHead(t, feed, 14))
+	// the client of the lost subscription is closed before reconnecting
+	require.Equal(t, int32(1), subscriber.closed.Load())
+}
+
+func waitNewHead(t *testing.T, feed *HeadFeed) uint64 {
+	t.Helper()
+	select {
+	case blockNum := <-feed.NewHeads():
+		return blockNum
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no new head notified")
+		return 0
+	}
+}
+
+// waitLatestHead consumes the notifications until the expected block is notified
+func waitLatestHead(t *testing.T, feed *HeadFeed, expected uint64) uint64 {
+	t.Helper()
+	for {
+		if blockNum := waitNewHead(t, feed); blockNum >= expected {
+			return blockNum
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/prometheus"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsPrefix                = "sync_downloader_"
+	metricsSyncerLabel           = "syncer"
+	filterLogsDuration           = metricsPrefix + "filter_logs_duration_seconds"
+	headerByNumberDuration       = metricsPrefix + "header_by_number_duration_seconds"
+	numberOfRPCRetries           = metricsPrefix + "rpc_retries_total"
+	numberOfBlockHashMismatches  = metricsPrefix + "block_hash_mismatch_retries_total"
+	numberOfBlocksEmitted        = metricsPrefix + "blocks_emitted_total"
+	numberOfBlocksEmittedNoEvent = metricsPrefix + "empty_blocks_emitted_total"
+	numberOfHeadFeedHeads        = metricsPrefix + "head_feed_heads_total"
+	numberOfHeadFeedReconnects   = metricsPrefix + "head_feed_reconnections_total"
+	numberOfBatchedHeaders       = metricsPrefix + "batched_headers_total"
+	numberOfBatchFallbacks       = metricsPrefix + "header_batch_fallbacks_total"
+	numberOfCrossCheckMismatches = metricsPrefix + "cross_check_discrepancies_total"
+	numberOfSyncStops            = "sync_driver_stops_total"
+	metricsReasonLabel           = "reason"
+)
+
+var registerMetricsOnce sync.Once
+
+// registerMetrics registers the downloader metrics. All the syncers share the same
+// metrics, so they are registered only once and labelled by syncer ID
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.RegisterHistogramVecs(
+			prometheus.HistogramVecOpts{
+				HistogramOpts: prometheusClient.HistogramOpts{
+					Name:    filterLogsDuration,
+					Help:    "[SYNC] latency of the eth_getLogs (FilterLogs) calls in seconds",
+					Buckets: prometheusClient.DefBuckets,
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.HistogramVecOpts{
+				HistogramOpts: prometheusClient.HistogramOpts{
+					Name:    headerByNumberDuration,
+					Help:    "[SYNC] latency of the eth_getBlockByNumber (HeaderByNumber) calls in seconds",
+					Buckets: prometheusClient.DefBuckets,
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+		)
+		prometheus.RegisterCounterVecs(
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfRPCRetries,
+					Help: "[SYNC] number of retries of failed RPC calls",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfBlockHashMismatches,
+					Help: "[SYNC] number of retries due to a block hash mismatch between logs and headers",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfBlocksEmitted,
+					Help: "[SYNC] number of blocks with events emitted to the driver",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfBlocksEmittedNoEvent,
+					Help: "[SYNC] number of blocks without events emitted to the driver",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfHeadFeedHeads,
+					Help: "[SYNC] number of new heads received from the head feed",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfHeadFeedReconnects,
+					Help: "[SYNC] number of reconnections to the head feed",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfBatchedHeaders,
+					Help: "[SYNC] number of block headers obtained in JSON-RPC batch requests",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfBatchFallbacks,
+					Help: "[SYNC] number of failed header batch requests whose headers are requested one by one",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfCrossCheckMismatches,
+					Help: "[SYNC] number of discrepancies between the blocks downloaded from the RPC and the second provider",
+				},
+				Labels: []string{metricsSyncerLabel},
+			},
+			prometheus.CounterVecOpts{
+				CounterOpts: prometheusClient.CounterOpts{
+					Name: numberOfSyncStops,
+					Help: "[SYNC] number of stops and restarts of the sync, by reason (shutdown, reorg, inconsistent_state)",
+				},
+				Labels: []string{metricsSyncerLabel, metricsReasonLabel},
+			},
+		)
+		log.Info("Registered prometheus sync downloader metrics")
+	})
+}
+
+// filterLogsDone observes the latency of a FilterLogs call
+func filterLogsDone(syncerID string, start time.Time) {
+	prometheus.HistogramVecObserve(filterLogsDuration, syncerID, time.Since(start).Seconds())
+}
+
+// headerByNumberDone observes the latency of a HeaderByNumber call
+func headerByNumberDone(syncerID string, start time.Time) {
+	prometheus.HistogramVecObserve(headerByNumberDuration, syncerID, time.Since(start).Seconds())
+}
+
+// rpcRetry increments the number of retries of failed RPC calls
+func rpcRetry(syncerID string) {
+	prometheus.CounterVecInc(numberOfRPCRetries, syncerID)
+}
+
+// blockHashMismatchRetry increments the number of retries due to a block hash mismatch
+func blockHashMismatchRetry(syncerID string) {
+	prometheus.CounterVecInc(numberOfBlockHashMismatches, syncerID)
+}
+
+// blocksEmitted adds the number of blocks with events emitted to the driver
+func blocksEmitted(syncerID string, n int) {
+	prometheus.CounterVecAdd(numberOfBlocksEmitted, syncerID, float64(n))
+}
+
+// emptyBlockEmitted increments the number of blocks without events emitted to the driver
+func emptyBlockEmitted(syncerID string) {
+	prometheus.CounterVecInc(numberOfBlocksEmittedNoEvent, syncerID)
+}
+
+// headFeedHeadReceived increments the number of new heads received from the head feed
+func headFeedHeadReceived(syncerID string) {
+	prometheus.CounterVecInc(numberOfHeadFeedHeads, syncerID)
+}
+
+// headFeedReconnection increments the number of reconnections to the head feed
+func headFeedReconnection(syncerID string) {
+	prometheus.CounterVecInc(numberOfHeadFeedReconnects, syncerID)
+}
+
+// headersBatched adds the number of headers obtained in batch requests
+func headersBatched(syncerID string, n int) {
+	prometheus.CounterVecAdd(numberOfBatchedHeaders, syncerID, float64(n))
+}
+
+// headerBatchFallback increments the number of failed header batch requests
+func headerBatchFallback(syncerID string) {
+	prometheus.CounterVecInc(numberOfBatchFallbacks, syncerID)
+}
+
+// crossCheckDiscrepanciesFound adds the number of discrepancies found by the cross check
+func crossCheckDiscrepanciesFound(syncerID string, n int) {
+	prometheus.CounterVecAdd(numberOfCrossCheckMismatches, syncerID, float64(n))
+}
+
+// syncStopped increments the number of stops and restarts of the sync with the reason
+func syncStopped(syncerID string, reason StopReason) {
+	if cv, ok := prometheus.CounterVec(numberOfSyncStops); ok {
+		cv.WithLabelValues(syncerID, string(reason)).Inc()
+	}
+}
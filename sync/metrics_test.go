@@ -0,0 +1,47 @@
+package sync
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloaderMetrics(t *testing.T) {
+	prometheus.Init()
+	registerMetricsOnce = sync.Once{}
+	registerMetrics()
+
+	const syncerID = "TestDownloaderMetrics"
+
+	rpcRetry(syncerID)
+	rpcRetry(syncerID)
+	blockHashMismatchRetry(syncerID)
+	blocksEmitted(syncerID, 3)
+	emptyBlockEmitted(syncerID)
+	headFeedHeadReceived(syncerID)
+	headFeedReconnection(syncerID)
+	filterLogsDone(syncerID, time.Now())
+	headerByNumberDone(syncerID, time.Now())
+
+	counterValue := func(name string) float64 {
+		cv, ok := prometheus.CounterVec(name)
+		require.True(t, ok, name)
+		return testutil.ToFloat64(cv.WithLabelValues(syncerID))
+	}
+	require.Equal(t, float64(2), counterValue(numberOfRPCRetries))
+	require.Equal(t, float64(1), counterValue(numberOfBlockHashMismatches))
+	require.Equal(t, float64(3), counterValue(numberOfBlocksEmitted))
+	require.Equal(t, float64(1), counterValue(numberOfBlocksEmittedNoEvent))
+	require.Equal(t, float64(1), counterValue(numberOfHeadFeedHeads))
+	require.Equal(t, float64(1), counterValue(numberOfHeadFeedReconnects))
+
+	for _, name := range []string{filterLogsDuration, headerByNumberDuration} {
+		hv, ok := prometheus.HistogramVec(name)
+		require.True(t, ok, name)
+		require.Equal(t, 1, testutil.CollectAndCount(hv, name))
+	}
+}
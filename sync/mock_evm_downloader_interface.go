@@ -5,8 +5,11 @@ package sync
 import (
 	context "context"
 
-	types "github.com/ethereum/go-ethereum/core/types"
+	common "github.com/ethereum/go-ethereum/common"
+
 	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/ethereum/go-ethereum/core/types"
 )
 
 // EVMDownloaderMock is an autogenerated mock type for the EVMDownloaderInterface type
@@ -293,6 +296,65 @@ func (_c *EVMDownloaderMock_GetLogs_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetLogsByBlockHash provides a mock function with given fields: ctx, blockHash
+func (_m *EVMDownloaderMock) GetLogsByBlockHash(ctx context.Context, blockHash common.Hash) ([]types.Log, error) {
+	ret := _m.Called(ctx, blockHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLogsByBlockHash")
+	}
+
+	var r0 []types.Log
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) ([]types.Log, error)); ok {
+		return rf(ctx, blockHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) []types.Log); ok {
+		r0 = rf(ctx, blockHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Log)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, blockHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EVMDownloaderMock_GetLogsByBlockHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLogsByBlockHash'
+type EVMDownloaderMock_GetLogsByBlockHash_Call struct {
+	*mock.Call
+}
+
+// GetLogsByBlockHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockHash common.Hash
+func (_e *EVMDownloaderMock_Expecter) GetLogsByBlockHash(ctx interface{}, blockHash interface{}) *EVMDownloaderMock_GetLogsByBlockHash_Call {
+	return &EVMDownloaderMock_GetLogsByBlockHash_Call{Call: _e.mock.On("GetLogsByBlockHash", ctx, blockHash)}
+}
+
+func (_c *EVMDownloaderMock_GetLogsByBlockHash_Call) Run(run func(ctx context.Context, blockHash common.Hash)) *EVMDownloaderMock_GetLogsByBlockHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *EVMDownloaderMock_GetLogsByBlockHash_Call) Return(_a0 []types.Log, _a1 error) *EVMDownloaderMock_GetLogsByBlockHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EVMDownloaderMock_GetLogsByBlockHash_Call) RunAndReturn(run func(context.Context, common.Hash) ([]types.Log, error)) *EVMDownloaderMock_GetLogsByBlockHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitForNewBlocks provides a mock function with given fields: ctx, lastBlockSeen
 func (_m *EVMDownloaderMock) WaitForNewBlocks(ctx context.Context, lastBlockSeen uint64) uint64 {
 	ret := _m.Called(ctx, lastBlockSeen)
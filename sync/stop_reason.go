@@ -0,0 +1,46 @@
+package sync
+
+import (
+	"context"
+	"errors"
+
+	"github.com/agglayer/aggkit/healthcheck"
+)
+
+// StopReason is why the sync of a syncer stopped or restarted
+type StopReason string
+
+const (
+	// StopReasonShutdown means that the context of the syncer is done (aggkit is shutting down)
+	StopReasonShutdown StopReason = "shutdown"
+	// StopReasonReorg means that the sync restarts from the first reorged block
+	StopReasonReorg StopReason = "reorg"
+	// StopReasonInconsistentState means that the downloader is stopped until there is a reorg
+	StopReasonInconsistentState StopReason = "inconsistent_state"
+	// StopReasonFatal means that an operation failed too many times and the process exits
+	StopReasonFatal StopReason = "fatal"
+)
+
+// ErrReorgRestart is the cause of the cancellation of the downloader when the sync restarts after a reorg
+var ErrReorgRestart = errors.New("the sync restarts after a reorg")
+
+// StopReasonOf returns why the context has been canceled, from its cause (see context.WithCancelCause):
+// ErrReorgRestart, ErrInconsistentState, or a shutdown otherwise
+func StopReasonOf(ctx context.Context) StopReason {
+	cause := context.Cause(ctx)
+	switch {
+	case errors.Is(cause, ErrReorgRestart):
+		return StopReasonReorg
+	case errors.Is(cause, ErrInconsistentState):
+		return StopReasonInconsistentState
+	default:
+		return StopReasonShutdown
+	}
+}
+
+// recordStop reports the stop or restart of the sync in the logs, the metrics and the health endpoint
+func (d *EVMDriver) recordStop(reason StopReason, detail string) {
+	d.log.Infof("sync stopped (reason: %s): %s", reason, detail)
+	syncStopped(d.reorgDetectorID, reason)
+	healthcheck.RecordSyncerStop(d.reorgDetectorID, string(reason), detail)
+}
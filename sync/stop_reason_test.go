@@ -0,0 +1,29 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopReasonOf(t *testing.T) {
+	for cause, expected := range map[error]StopReason{
+		ErrReorgRestart:      StopReasonReorg,
+		ErrInconsistentState: StopReasonInconsistentState,
+		errors.Join(errors.New("foo"), ErrReorgRestart): StopReasonReorg,
+		context.Canceled: StopReasonShutdown,
+	} {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(cause)
+		require.Equal(t, expected, StopReasonOf(ctx), cause.Error())
+	}
+
+	// the parent is canceled
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	cancelParent()
+	require.Equal(t, StopReasonShutdown, StopReasonOf(ctx))
+}
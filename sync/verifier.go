@@ -0,0 +1,230 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	aggkitcommon "github.com/agglayer/aggkit/common"
+	"github.com/agglayer/aggkit/log"
+)
+
+const (
+	verifierDownloadBufferSize = 1000
+	verifyReportSuffix         = ".verify-report.json"
+)
+
+// ErrDownloaderStopped is returned if the downloader stops before the verification ends
+var ErrDownloaderStopped = errors.New("the downloader stopped before the end of the verification")
+
+// VerifierConfig is the config of the read-only verification mode of a syncer
+type VerifierConfig struct {
+	// VerifyOnly reprocesses the chain and compares the result with the DB of the syncer, without writing
+	// it, instead of running the syncer. The process exits after writing the report
+	VerifyOnly bool `mapstructure:"VerifyOnly"`
+	// ReportPath is the path of the JSON discrepancy report. Empty means the DB path plus .verify-report.json
+	ReportPath string `mapstructure:"ReportPath"`
+	// MaxDiscrepancies stops the verification after finding this number of discrepancies. 0 means no limit
+	MaxDiscrepancies int `mapstructure:"MaxDiscrepancies"`
+}
+
+// ReportPathFor returns the path of the report of the verification of the given DB
+func (c VerifierConfig) ReportPathFor(dbPath string) string {
+	if c.ReportPath != "" {
+		return c.ReportPath
+	}
+	return dbPath + verifyReportSuffix
+}
+
+// Discrepancy is a difference between the state computed reprocessing the chain and the state of the
+// verified DB
+type Discrepancy struct {
+	BlockNum uint64 `json:"block_num"`
+	// Field is the value that differs, e.g. the exit root of a deposit count
+	Field string `json:"field"`
+	// Computed is the value computed reprocessing the chain
+	Computed string `json:"computed"`
+	// Stored is the value of the verified DB
+	Stored string `json:"stored"`
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("block %d: %s computed %s, stored %s", d.BlockNum, d.Field, d.Computed, d.Stored)
+}
+
+// VerificationReport is the result of the verification of the DB of a syncer
+type VerificationReport struct {
+	Syncer    string `json:"syncer"`
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+	// VerifiedBlocks is the number of blocks with events reprocessed and compared with the DB
+	VerifiedBlocks uint64        `json:"verified_blocks"`
+	Discrepancies  []Discrepancy `json:"discrepancies"`
+	// Truncated is true if the verification stopped after reaching the maximum number of discrepancies
+	Truncated  bool      `json:"truncated"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// OK returns true if no discrepancy has been found
+func (r *VerificationReport) OK() bool {
+	return len(r.Discrepancies) == 0
+}
+
+func (r *VerificationReport) String() string {
+	return fmt.Sprintf("VerificationReport{syncer:%s, blocks:%d-%d, verifiedBlocks:%d, discrepancies:%d, "+
+		"truncated:%t, elapsed:%s}", r.Syncer, r.FromBlock, r.ToBlock, r.VerifiedBlocks, len(r.Discrepancies),
+		r.Truncated, r.FinishedAt.Sub(r.StartedAt))
+}
+
+// WriteFile writes the report as JSON to the given path
+func (r *VerificationReport) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding the verification report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), data, 0600); err != nil { //nolint:mnd
+		return fmt.Errorf("error writing the verification report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// BlockVerifier compares the state computed by the scratch processor with the state of the verified DB
+type BlockVerifier interface {
+	// LastStoredBlock returns the last processed block of the verified DB
+	LastStoredBlock(ctx context.Context) (uint64, error)
+	// VerifyBlocks compares the state of the blocks [fromBlock, toBlock], already reprocessed
+	VerifyBlocks(ctx context.Context, fromBlock, toBlock uint64) ([]Discrepancy, error)
+}
+
+// Verifier reprocesses the chain with a scratch processor (that writes to a scratch DB) and compares
+// the result with the DB of a syncer, which is never written. It's used to validate the integrity of
+// the DB after a crash or a snapshot restore. The reorgs are not handled, so the DB should be verified
+// with a finalized block finality
+type Verifier struct {
+	name             string
+	downloader       Downloader
+	processor        processorInterface
+	blockVerifier    BlockVerifier
+	maxDiscrepancies int
+	log              aggkitcommon.Logger
+	// closeFn releases the scratch processor
+	closeFn func() error
+}
+
+// NewVerifier creates a Verifier. The verification stops after maxDiscrepancies discrepancies
+// (0 means no limit). closeFn, if not nil, is called on Close to release the scratch processor
+func NewVerifier(
+	name string,
+	downloader Downloader,
+	scratchProcessor processorInterface,
+	blockVerifier BlockVerifier,
+	maxDiscrepancies int,
+	closeFn func() error,
+) *Verifier {
+	return &Verifier{
+		name:             name,
+		downloader:       downloader,
+		processor:        scratchProcessor,
+		blockVerifier:    blockVerifier,
+		maxDiscrepancies: maxDiscrepancies,
+		log:              log.WithFields("verifier", name),
+		closeFn:          closeFn,
+	}
+}
+
+// Verify reprocesses the blocks from the last processed block of the scratch processor up to the last
+// processed block of the verified DB, and compares them with the DB
+func (v *Verifier) Verify(ctx context.Context) (*VerificationReport, error) {
+	toBlock, err := v.blockVerifier.LastStoredBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the last processed block of the verified DB: %w", err)
+	}
+	lastProcessedBlock, err := v.processor.GetLastProcessedBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the last processed block of the scratch DB: %w", err)
+	}
+	report := &VerificationReport{
+		Syncer:        v.name,
+		FromBlock:     lastProcessedBlock + 1,
+		ToBlock:       toBlock,
+		Discrepancies: []Discrepancy{},
+		StartedAt:     time.Now().UTC(),
+	}
+	if toBlock <= lastProcessedBlock {
+		return v.finish(report), nil
+	}
+	v.log.Infof("verifying the blocks %d-%d of the DB", report.FromBlock, toBlock)
+
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	downloadCh := make(chan EVMBlock, verifierDownloadBufferSize)
+	go v.downloader.Download(downloadCtx, report.FromBlock, downloadCh)
+
+	nextBlock := report.FromBlock
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("verification canceled at block %d: %w", nextBlock, ctx.Err())
+		case b, ok := <-downloadCh:
+			if !ok {
+				return nil, ErrDownloaderStopped
+			}
+			block := Block{Num: b.Num, Events: b.Events, Hash: b.Hash}
+			if err := v.processor.ProcessBlock(ctx, block); err != nil {
+				return nil, fmt.Errorf("error reprocessing block %d: %w", b.Num, err)
+			}
+			// a block beyond toBlock means that the verified DB has no events in the blocks pending to verify
+			lastBlock := min(b.Num, toBlock)
+			if b.Num <= toBlock && len(b.Events) > 0 {
+				report.VerifiedBlocks++
+			}
+			if err := v.verifyBlocks(ctx, report, nextBlock, lastBlock); err != nil {
+				return nil, err
+			}
+			nextBlock = b.Num + 1
+			if b.Num >= toBlock || report.Truncated {
+				return v.finish(report), nil
+			}
+		}
+	}
+}
+
+// verifyBlocks compares the blocks [fromBlock, toBlock] and adds the discrepancies to the report
+func (v *Verifier) verifyBlocks(ctx context.Context, report *VerificationReport, fromBlock, toBlock uint64) error {
+	discrepancies, err := v.blockVerifier.VerifyBlocks(ctx, fromBlock, toBlock)
+	if err != nil {
+		return fmt.Errorf("error verifying the blocks %d-%d: %w", fromBlock, toBlock, err)
+	}
+	for _, discrepancy := range discrepancies {
+		v.log.Warnf("discrepancy found: %s", discrepancy.String())
+		report.Discrepancies = append(report.Discrepancies, discrepancy)
+		if v.maxDiscrepancies > 0 && len(report.Discrepancies) >= v.maxDiscrepancies {
+			report.Truncated = true
+			return nil
+		}
+	}
+	return nil
+}
+
+func (v *Verifier) finish(report *VerificationReport) *VerificationReport {
+	report.FinishedAt = time.Now().UTC()
+	if report.OK() {
+		v.log.Infof("verification finished without discrepancies: %s", report.String())
+	} else {
+		v.log.Warnf("verification finished with discrepancies: %s", report.String())
+	}
+	return report
+}
+
+// Close releases the scratch processor
+func (v *Verifier) Close() error {
+	if v.closeFn == nil {
+		return nil
+	}
+	return v.closeFn()
+}
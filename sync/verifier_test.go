@@ -0,0 +1,154 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDownload sends the blocks to the download channel and then waits for the context to be done
+func mockDownload(dm *DownloaderMock, blocks ...EVMBlock) {
+	dm.EXPECT().Download(mock.Anything, mock.Anything, mock.Anything).Run(
+		func(ctx context.Context, fromBlock uint64, downloadedCh chan EVMBlock) {
+			for _, b := range blocks {
+				downloadedCh <- b
+			}
+			<-ctx.Done()
+		})
+}
+
+func newTestEVMBlock(num uint64, events ...interface{}) EVMBlock {
+	return EVMBlock{
+		EVMBlockHeader: EVMBlockHeader{Num: num, Hash: common.BigToHash(new(big.Int).SetUint64(num))},
+		Events:         events,
+	}
+}
+
+func TestVerifierVerify(t *testing.T) {
+	ctx := context.Background()
+	discrepancy := Discrepancy{BlockNum: 7, Field: "exit root", Computed: "0x01", Stored: "0x02"}
+
+	t.Run("nothing to verify", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(10, nil)
+
+		report, err := NewVerifier("test", NewDownloaderMock(t), pm, bv, 0, nil).Verify(ctx)
+		require.NoError(t, err)
+		require.True(t, report.OK())
+		require.Equal(t, uint64(0), report.VerifiedBlocks)
+	})
+
+	t.Run("no discrepancies", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		dm := NewDownloaderMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(4, nil)
+		mockDownload(dm, newTestEVMBlock(5, "event"), newTestEVMBlock(8), newTestEVMBlock(10, "event"))
+		pm.EXPECT().ProcessBlock(ctx, mock.Anything).Return(nil).Times(3)
+		bv.EXPECT().VerifyBlocks(ctx, uint64(5), uint64(5)).Return(nil, nil)
+		bv.EXPECT().VerifyBlocks(ctx, uint64(6), uint64(8)).Return(nil, nil)
+		bv.EXPECT().VerifyBlocks(ctx, uint64(9), uint64(10)).Return(nil, nil)
+
+		report, err := NewVerifier("test", dm, pm, bv, 0, nil).Verify(ctx)
+		require.NoError(t, err)
+		require.True(t, report.OK())
+		require.Equal(t, uint64(5), report.FromBlock)
+		require.Equal(t, uint64(10), report.ToBlock)
+		require.Equal(t, uint64(2), report.VerifiedBlocks)
+	})
+
+	t.Run("the last stored blocks have no events", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		dm := NewDownloaderMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(4, nil)
+		mockDownload(dm, newTestEVMBlock(7, "event"), newTestEVMBlock(15, "event"))
+		pm.EXPECT().ProcessBlock(ctx, mock.Anything).Return(nil).Times(2)
+		bv.EXPECT().VerifyBlocks(ctx, uint64(5), uint64(7)).Return(nil, nil)
+		bv.EXPECT().VerifyBlocks(ctx, uint64(8), uint64(10)).Return([]Discrepancy{discrepancy}, nil)
+
+		report, err := NewVerifier("test", dm, pm, bv, 0, nil).Verify(ctx)
+		require.NoError(t, err)
+		require.False(t, report.OK())
+		require.Equal(t, []Discrepancy{discrepancy}, report.Discrepancies)
+		require.Equal(t, uint64(1), report.VerifiedBlocks)
+	})
+
+	t.Run("stops after the max discrepancies", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		dm := NewDownloaderMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(4, nil)
+		mockDownload(dm, newTestEVMBlock(5, "event"), newTestEVMBlock(10, "event"))
+		pm.EXPECT().ProcessBlock(ctx, mock.Anything).Return(nil).Once()
+		bv.EXPECT().VerifyBlocks(ctx, uint64(5), uint64(5)).Return([]Discrepancy{discrepancy, discrepancy}, nil)
+
+		report, err := NewVerifier("test", dm, pm, bv, 1, nil).Verify(ctx)
+		require.NoError(t, err)
+		require.True(t, report.Truncated)
+		require.Len(t, report.Discrepancies, 1)
+	})
+
+	t.Run("error reprocessing a block", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		dm := NewDownloaderMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(4, nil)
+		mockDownload(dm, newTestEVMBlock(5, "event"))
+		pm.EXPECT().ProcessBlock(ctx, mock.Anything).Return(errUnittest)
+
+		_, err := NewVerifier("test", dm, pm, bv, 0, nil).Verify(ctx)
+		require.ErrorIs(t, err, errUnittest)
+	})
+
+	t.Run("the downloader stops", func(t *testing.T) {
+		pm := NewProcessorMock(t)
+		dm := NewDownloaderMock(t)
+		bv := NewBlockVerifierMock(t)
+		bv.EXPECT().LastStoredBlock(ctx).Return(10, nil)
+		pm.EXPECT().GetLastProcessedBlock(ctx).Return(4, nil)
+		dm.EXPECT().Download(mock.Anything, mock.Anything, mock.Anything).Run(
+			func(ctx context.Context, fromBlock uint64, downloadedCh chan EVMBlock) {
+				close(downloadedCh)
+			})
+
+		_, err := NewVerifier("test", dm, pm, bv, 0, nil).Verify(ctx)
+		require.ErrorIs(t, err, ErrDownloaderStopped)
+	})
+}
+
+func TestVerificationReportWriteFile(t *testing.T) {
+	report := &VerificationReport{
+		Syncer:        "test",
+		FromBlock:     1,
+		ToBlock:       10,
+		Discrepancies: []Discrepancy{{BlockNum: 3, Field: "bridge", Computed: "0x01", Stored: "missing"}},
+	}
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var decoded VerificationReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, report.Discrepancies, decoded.Discrepancies)
+	require.False(t, decoded.OK())
+}
+
+func TestVerifierConfigReportPathFor(t *testing.T) {
+	require.Equal(t, "/data/bridge.sqlite.verify-report.json", VerifierConfig{}.ReportPathFor("/data/bridge.sqlite"))
+	require.Equal(t, "/tmp/report.json", VerifierConfig{ReportPath: "/tmp/report.json"}.ReportPathFor("/data/db"))
+}
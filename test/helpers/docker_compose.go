@@ -0,0 +1,155 @@
+package helpers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// dockerComposeUpTimeout is the max time to pull the images and start the containers
+	dockerComposeUpTimeout = 10 * time.Minute
+	// dockerComposeDownTimeout is the max time to remove the containers of a test
+	dockerComposeDownTimeout = 2 * time.Minute
+	// dockerComposeLogsTail is the number of lines of each service dumped if the test fails
+	dockerComposeLogsTail = "200"
+)
+
+// DockerComposeConfig is the docker compose project started by a test
+type DockerComposeConfig struct {
+	// Files are the paths of the docker compose files, the later ones override the previous ones
+	Files []string
+	// Project is the name of the project (docker compose -p). It must be unique per test
+	Project string
+	// Profiles are the profiles of the services to start (e.g. the services of a PP or a FEP network)
+	Profiles []string
+	// Env are the variables used to interpolate the docker compose file (e.g. the images)
+	Env map[string]string
+}
+
+// DockerCompose is a docker compose project started by StartDockerCompose. The containers are
+// removed at the end of the test, and their logs are dumped if the test has failed
+type DockerCompose struct {
+	cfg DockerComposeConfig
+}
+
+// StartDockerCompose starts the services of the docker compose file and waits until they are
+// healthy. The test is skipped if docker compose is not available
+func StartDockerCompose(t *testing.T, cfg DockerComposeConfig) *DockerCompose {
+	t.Helper()
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker is not installed, skipping test")
+	}
+	dc := &DockerCompose{cfg: cfg}
+	ctx, cancel := context.WithTimeout(context.Background(), dockerComposeUpTimeout)
+	defer cancel()
+	if _, err := dc.run(ctx, "version"); err != nil {
+		t.Skipf("docker compose is not available, skipping test: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("logs of docker compose project %s:\n%s", cfg.Project, dc.Logs())
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), dockerComposeDownTimeout)
+		defer cancel()
+		if _, err := dc.run(ctx, "down", "--volumes", "--remove-orphans"); err != nil {
+			t.Logf("error removing docker compose project %s: %v", cfg.Project, err)
+		}
+	})
+
+	_, err := dc.run(ctx, "up", "--detach", "--wait")
+	require.NoError(t, err, "error starting docker compose project %s", cfg.Project)
+	return dc
+}
+
+// ServiceAddress returns the host address (host:port) where the port of the service is published
+func (dc *DockerCompose) ServiceAddress(t *testing.T, service string, port uint16) string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	out, err := dc.run(ctx, "port", service, fmt.Sprintf("%d", port))
+	require.NoError(t, err, "error getting the published port %d of service %s", port, service)
+	addr, err := parseDockerComposePort(out)
+	require.NoError(t, err, "service %s, port %d", service, port)
+	return addr
+}
+
+// Logs returns the last lines of the logs of all the services
+func (dc *DockerCompose) Logs() string {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	out, err := dc.run(ctx, "logs", "--no-color", "--tail", dockerComposeLogsTail)
+	if err != nil {
+		return fmt.Sprintf("error getting logs: %v", err)
+	}
+	return out
+}
+
+func (dc *DockerCompose) run(ctx context.Context, args ...string) (string, error) {
+	cmdArgs := []string{"compose", "--project-name", dc.cfg.Project}
+	for _, file := range dc.cfg.Files {
+		cmdArgs = append(cmdArgs, "--file", file)
+	}
+	for _, profile := range dc.cfg.Profiles {
+		cmdArgs = append(cmdArgs, "--profile", profile)
+	}
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.CommandContext(ctx, "docker", cmdArgs...)
+	cmd.Env = os.Environ()
+	keys := make([]string, 0, len(dc.cfg.Env))
+	for key := range dc.cfg.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cmd.Env = append(cmd.Env, key+"="+dc.cfg.Env[key])
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// parseDockerComposePort parses the output of `docker compose port` (e.g. 0.0.0.0:32768).
+// The unspecified addresses are replaced by localhost
+func parseDockerComposePort(out string) (string, error) {
+	line := strings.TrimSpace(strings.Split(strings.TrimSpace(out), "\n")[0])
+	host, port, err := net.SplitHostPort(line)
+	if err != nil {
+		return "", fmt.Errorf("unexpected output of docker compose port %q: %w", out, err)
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// WaitFor calls check until it returns nil, failing the test if it's not ready before the timeout
+func WaitFor(t *testing.T, what string, timeout, period time.Duration, check func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			require.NoError(t, err, "timeout (%s) waiting for %s", timeout, what)
+		}
+		time.Sleep(period)
+	}
+}
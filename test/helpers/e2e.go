@@ -14,6 +14,7 @@ import (
 	"github.com/agglayer/aggkit/aggoracle/chaingersender"
 	"github.com/agglayer/aggkit/bridgesync"
 	cfgTypes "github.com/agglayer/aggkit/config/types"
+	aggkitdb "github.com/agglayer/aggkit/db"
 	"github.com/agglayer/aggkit/l1infotreesync"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/reorgdetector"
@@ -121,7 +122,7 @@ func L1Setup(t *testing.T, cfg *EnvironmentConfig) *L1Environment {
 		FinalizedBlock:      aggkittypes.FinalizedBlock,
 	}, reorgdetector.L1)
 	require.NoError(t, err)
-	go rdL1.Start(ctx) //nolint:errcheck
+	require.NoError(t, rdL1.Start(ctx))
 
 	const (
 		l1InfoTreeSyncerRetries   = 3
@@ -131,7 +132,7 @@ func L1Setup(t *testing.T, cfg *EnvironmentConfig) *L1Environment {
 	// L1 info tree sync
 	dbPathL1InfoTreeSync := path.Join(t.TempDir(), "L1InfoTreeSync.sqlite")
 	l1InfoTreeSync, err := l1infotreesync.New(
-		ctx, dbPathL1InfoTreeSync,
+		ctx, dbPathL1InfoTreeSync, aggkitdb.SQLiteConfig{}, l1infotreesync.MirrorConfig{},
 		gerL1Addr, common.Address{},
 		syncBlockChunkSize, aggkittypes.LatestBlock,
 		rdL1, l1Client.Client(),
@@ -146,7 +147,7 @@ func L1Setup(t *testing.T, cfg *EnvironmentConfig) *L1Environment {
 
 	const (
 		waitForNewBlocksPeriod = time.Millisecond * 10
-		originNetwork          = 1
+		originNetwork          = 0
 		initialBlock           = 0
 		retryPeriod            = time.Millisecond * 30
 		retriesCount           = 10
@@ -156,7 +157,7 @@ func L1Setup(t *testing.T, cfg *EnvironmentConfig) *L1Environment {
 	testClient := NewTestClient(l1Client.Client(), WithRPCClienter(cfg.L1RPCClient))
 	dbPathBridgeSyncL1 := path.Join(t.TempDir(), "BridgeSyncL1.sqlite")
 	bridgeL1Sync, err := bridgesync.NewL1(
-		ctx, dbPathBridgeSyncL1, bridgeL1Addr,
+		ctx, dbPathBridgeSyncL1, aggkitdb.SQLiteConfig{}, bridgeL1Addr,
 		syncBlockChunkSize, aggkittypes.LatestBlock, rdL1, testClient,
 		initialBlock, waitForNewBlocksPeriod, retryPeriod,
 		retriesCount, originNetwork, false, true)
@@ -191,7 +192,7 @@ func L2Setup(t *testing.T, cfg *EnvironmentConfig) *L2Environment {
 	const gerCheckFrequency = time.Millisecond * 50
 	sender, err := chaingersender.NewEVMChainGERSender(
 		log.GetDefaultLogger(), gerL2Addr, l2Client.Client(),
-		ethTxManagerMock, 0, gerCheckFrequency,
+		ethTxManagerMock, 0, gerCheckFrequency, chaingersender.RelayConfig{},
 	)
 	require.NoError(t, err)
 	ctx := context.Background()
@@ -206,7 +207,7 @@ func L2Setup(t *testing.T, cfg *EnvironmentConfig) *L2Environment {
 		reorgdetector.L2,
 	)
 	require.NoError(t, err)
-	go rdL2.Start(ctx) //nolint:errcheck
+	require.NoError(t, rdL2.Start(ctx))
 
 	// Bridge sync
 	dbPathL2BridgeSync := path.Join(t.TempDir(), "BridgeSyncL2.sqlite")
@@ -214,14 +215,14 @@ func L2Setup(t *testing.T, cfg *EnvironmentConfig) *L2Environment {
 
 	const (
 		waitForNewBlocksPeriod = 10 * time.Millisecond
-		originNetwork          = 1
+		originNetwork          = rollupID
 		initialBlock           = 0
 		retryPeriod            = 50 * time.Millisecond
 		retriesCount           = 100
 	)
 
 	bridgeL2Sync, err := bridgesync.NewL2(
-		ctx, dbPathL2BridgeSync, bridgeL2Addr, syncBlockChunkSize,
+		ctx, dbPathL2BridgeSync, aggkitdb.SQLiteConfig{}, bridgeL2Addr, syncBlockChunkSize,
 		aggkittypes.LatestBlock, rdL2, testClient,
 		initialBlock, waitForNewBlocksPeriod, retryPeriod,
 		retriesCount, originNetwork, false, true)
@@ -0,0 +1,178 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/cdk-contracts-tooling/contracts/pp/l2-sovereign-chain/polygonzkevmbridgev2"
+	agglayergrpc "github.com/agglayer/aggkit/agglayer/grpc"
+	agglayertypes "github.com/agglayer/aggkit/agglayer/types"
+	"github.com/agglayer/aggkit/aggsender/rpcclient"
+	"github.com/agglayer/aggkit/aggsender/types"
+	configtypes "github.com/agglayer/aggkit/config/types"
+	aggkitgrpc "github.com/agglayer/aggkit/grpc"
+	"github.com/agglayer/aggkit/test/helpers"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// envDirVar is the folder with the state of the chains, the configs and env.json
+	envDirVar = "AGGKIT_IT_ENV_DIR"
+
+	aggsenderRPCPort = 5576
+	agglayerGRPCPort = 4443
+	l2RPCPort        = 8545
+
+	defaultCertificateTimeout = 15 * time.Minute
+	pollPeriod                = 2 * time.Second
+)
+
+// testEnvironment is the env.json of the environment folder, with the data of the deployed
+// contracts that the tests need
+type testEnvironment struct {
+	dir string
+	// L2BridgeAddress is the address of the bridge contract on L2
+	L2BridgeAddress common.Address `json:"l2_bridge_address"`
+	// L2FundedPrivateKey is the hex private key of an account with funds on L2, used to bridge
+	L2FundedPrivateKey string `json:"l2_funded_private_key"`
+	// CertificateTimeout is the max time to wait for the certificate to be settled (default 15m)
+	CertificateTimeout configtypes.Duration `json:"certificate_timeout"`
+}
+
+func loadTestEnvironment(t *testing.T) testEnvironment {
+	t.Helper()
+	dir := os.Getenv(envDirVar)
+	if dir == "" {
+		t.Skipf("%s is not set, skipping integration test", envDirVar)
+	}
+	dir, err := filepath.Abs(dir)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "env.json"))
+	require.NoError(t, err)
+	env := testEnvironment{dir: dir}
+	require.NoError(t, json.Unmarshal(data, &env))
+	if env.CertificateTimeout.Duration == 0 {
+		env.CertificateTimeout = configtypes.NewDuration(defaultCertificateTimeout)
+	}
+	return env
+}
+
+// composeFiles returns the docker compose file of the tests and the override of the environment, if any
+func (e testEnvironment) composeFiles() []string {
+	files := []string{"docker-compose.yml"}
+	override := filepath.Join(e.dir, "docker-compose.override.yml")
+	if _, err := os.Stat(override); err == nil {
+		files = append(files, override)
+	}
+	return files
+}
+
+func TestAggsenderPPCertificateRound(t *testing.T) {
+	runCertificateRound(t, "pp", func(t *testing.T, cert *types.Certificate) {
+		t.Helper()
+		require.Nil(t, cert.AggchainProof, "a PP certificate must not have an aggchain proof")
+	})
+}
+
+func TestAggsenderFEPCertificateRound(t *testing.T) {
+	runCertificateRound(t, "fep", func(t *testing.T, cert *types.Certificate) {
+		t.Helper()
+		require.NotNil(t, cert.AggchainProof, "a FEP certificate must have an aggchain proof")
+	})
+}
+
+// runCertificateRound starts the network of the profile, bridges an asset on L2 and waits until
+// the certificate that includes it is settled on the agglayer
+func runCertificateRound(t *testing.T, profile string, checkCertificate func(*testing.T, *types.Certificate)) {
+	t.Helper()
+	env := loadTestEnvironment(t)
+	ctx := context.Background()
+
+	compose := helpers.StartDockerCompose(t, helpers.DockerComposeConfig{
+		Files:    env.composeFiles(),
+		Project:  "aggkit-it-" + profile,
+		Profiles: []string{profile},
+		Env:      map[string]string{envDirVar: env.dir},
+	})
+	aggsender := rpcclient.NewClient("http://" + compose.ServiceAddress(t, "aggkit-"+profile, aggsenderRPCPort))
+	helpers.WaitFor(t, "aggsender RPC", 2*time.Minute, pollPeriod, func() error {
+		_, err := aggsender.GetStatus()
+		return err
+	})
+	agglayer, err := agglayergrpc.NewAgglayerGRPCClient(&aggkitgrpc.ClientConfig{
+		URL:               compose.ServiceAddress(t, "agglayer", agglayerGRPCPort),
+		MinConnectTimeout: configtypes.NewDuration(5 * time.Second),
+		RequestTimeout:    configtypes.NewDuration(30 * time.Second),
+	})
+	require.NoError(t, err)
+
+	bridgeBlock := bridgeAssetOnL2(ctx, t, env, "http://"+compose.ServiceAddress(t, "l2", l2RPCPort))
+	t.Logf("asset bridged on L2 block %d", bridgeBlock)
+
+	var cert *types.Certificate
+	helpers.WaitFor(t, "certificate settled", env.CertificateTimeout.Duration, pollPeriod, func() error {
+		cert, err = aggsender.GetCertificateHeaderPerHeight(nil)
+		if err != nil {
+			return err
+		}
+		header := cert.Header
+		if header == nil || header.ToBlock < bridgeBlock {
+			return errors.New("the last certificate doesn't include the bridge yet")
+		}
+		if header.Status == agglayertypes.InError {
+			// the aggsender retries it, but it's an error of the protocol that the test must report
+			return fmt.Errorf("certificate %s is InError", header.ID())
+		}
+		if !header.Status.IsSettled() {
+			return fmt.Errorf("certificate %s is %s", header.ID(), header.Status.String())
+		}
+		return nil
+	})
+	checkCertificate(t, cert)
+
+	agglayerHeader, err := agglayer.GetCertificateHeader(ctx, cert.Header.CertificateID)
+	require.NoError(t, err)
+	require.Equal(t, agglayertypes.Settled, agglayerHeader.Status)
+	require.Equal(t, cert.Header.Height, agglayerHeader.Height)
+	require.Equal(t, cert.Header.NewLocalExitRoot, agglayerHeader.NewLocalExitRoot)
+	require.NotNil(t, agglayerHeader.SettlementTxHash)
+}
+
+// bridgeAssetOnL2 bridges 1 wei of the gas token to L1 and returns the block of the bridge
+func bridgeAssetOnL2(ctx context.Context, t *testing.T, env testEnvironment, l2URL string) uint64 {
+	t.Helper()
+	client, err := ethclient.DialContext(ctx, l2URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(env.L2FundedPrivateKey, "0x"))
+	require.NoError(t, err)
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	require.NoError(t, err)
+	auth.Value = big.NewInt(1)
+
+	bridge, err := polygonzkevmbridgev2.NewPolygonzkevmbridgev2(env.L2BridgeAddress, client)
+	require.NoError(t, err)
+	tx, err := bridge.BridgeAsset(auth, 0, auth.From, auth.Value, common.Address{}, true, nil)
+	require.NoError(t, err)
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.Status, "bridge tx %s failed", tx.Hash().Hex())
+	return receipt.BlockNumber.Uint64()
+}
@@ -0,0 +1,231 @@
+// Package reorgsim is a test harness to simulate chain reorgs on a simulated backend. A test describes
+// the chain as a scenario of steps (commit blocks, inject events, mark and fork blocks) or as a
+// deterministic fork schedule, and it asserts that the syncers under test follow the canonical chain
+package reorgsim
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	defaultSyncTimeout      = 30 * time.Second
+	defaultSyncPollInterval = 10 * time.Millisecond
+	// defaultSyncStableTime is the time the head must not change to consider a processor synced
+	defaultSyncStableTime = 500 * time.Millisecond
+)
+
+// Processorer is a syncer (or processor) whose progress is checked by the assertions
+type Processorer interface {
+	GetLastProcessedBlock(ctx context.Context) (uint64, error)
+}
+
+// Fork is a fork applied to the chain
+type Fork struct {
+	// FromBlock is the last block kept of the previous chain
+	FromBlock uint64
+	// FromHash is the hash of FromBlock
+	FromHash common.Hash
+	// ReorgedHead is the head of the chain before the fork
+	ReorgedHead uint64
+}
+
+// String returns a string representation of the fork
+func (f Fork) String() string {
+	return fmt.Sprintf("fork{from:%d (%s), reorgedHead:%d}", f.FromBlock, f.FromHash.Hex(), f.ReorgedHead)
+}
+
+// Simulator drives a simulated backend through blocks, events and forks
+type Simulator struct {
+	t         *testing.T
+	backend   *simulated.Backend
+	blockTime time.Duration
+	// marks are the named blocks that the chain can be forked from
+	marks map[string]*types.Header
+	forks []Fork
+}
+
+// Option configures a Simulator
+type Option func(*Simulator)
+
+// WithBlockTime sets the time to wait after committing each block (default: 0), so the syncers
+// under test can see the intermediate blocks
+func WithBlockTime(blockTime time.Duration) Option {
+	return func(s *Simulator) {
+		s.blockTime = blockTime
+	}
+}
+
+// New returns a Simulator of the given simulated backend
+func New(t *testing.T, backend *simulated.Backend, opts ...Option) *Simulator {
+	t.Helper()
+	s := &Simulator{
+		t:       t,
+		backend: backend,
+		marks:   make(map[string]*types.Header),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Backend returns the simulated backend
+func (s *Simulator) Backend() *simulated.Backend {
+	return s.backend
+}
+
+// Head returns the header of the last block of the chain
+func (s *Simulator) Head() *types.Header {
+	s.t.Helper()
+	header, err := s.backend.Client().HeaderByNumber(context.Background(), nil)
+	require.NoError(s.t, err)
+	return header
+}
+
+// HeaderByNumber returns the header of the canonical block with the given number
+func (s *Simulator) HeaderByNumber(blockNum uint64) *types.Header {
+	s.t.Helper()
+	header, err := s.backend.Client().HeaderByNumber(context.Background(), new(big.Int).SetUint64(blockNum))
+	require.NoError(s.t, err)
+	return header
+}
+
+// Commit commits numBlocks blocks (waiting the block time after each one) and returns their hashes
+func (s *Simulator) Commit(numBlocks int) []common.Hash {
+	s.t.Helper()
+	hashes := make([]common.Hash, 0, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		hashes = append(hashes, s.backend.Commit())
+		time.Sleep(s.blockTime)
+	}
+	return hashes
+}
+
+// Mark names the current head, so the chain can be forked from it later
+func (s *Simulator) Mark(name string) *types.Header {
+	s.t.Helper()
+	header := s.Head()
+	s.marks[name] = header
+	return header
+}
+
+// Marked returns the header of the block marked with the given name
+func (s *Simulator) Marked(name string) *types.Header {
+	s.t.Helper()
+	header, ok := s.marks[name]
+	require.True(s.t, ok, "unknown mark %q", name)
+	return header
+}
+
+// ForkFrom forks the chain from the block marked with the given name: the blocks after it are
+// reorged. The transactions of the reorged blocks go back to the pending ones
+func (s *Simulator) ForkFrom(name string) Fork {
+	s.t.Helper()
+	return s.fork(s.Marked(name))
+}
+
+// ForkBack forks the chain depth blocks before the head
+func (s *Simulator) ForkBack(depth uint64) Fork {
+	s.t.Helper()
+	head := s.Head()
+	require.LessOrEqual(s.t, depth, head.Number.Uint64(), "can't fork %d blocks back from block %d",
+		depth, head.Number.Uint64())
+	return s.fork(s.HeaderByNumber(head.Number.Uint64() - depth))
+}
+
+func (s *Simulator) fork(from *types.Header) Fork {
+	s.t.Helper()
+	fork := Fork{
+		FromBlock:   from.Number.Uint64(),
+		FromHash:    from.Hash(),
+		ReorgedHead: s.Head().Number.Uint64(),
+	}
+	log.Debugf("reorgsim: forking the chain: %s", fork.String())
+	require.NoError(s.t, s.backend.Fork(from.Hash()))
+	s.forks = append(s.forks, fork)
+	return fork
+}
+
+// Forks returns the forks applied to the chain, in order
+func (s *Simulator) Forks() []Fork {
+	return s.forks
+}
+
+// RequireCanonical asserts that the block is part of the canonical chain
+func (s *Simulator) RequireCanonical(header *types.Header) {
+	s.t.Helper()
+	canonical := s.HeaderByNumber(header.Number.Uint64())
+	require.Equal(s.t, header.Hash(), canonical.Hash(), "block %d is not canonical", header.Number.Uint64())
+}
+
+// RequireReorged asserts that the block is not part of the canonical chain anymore
+func (s *Simulator) RequireReorged(header *types.Header) {
+	s.t.Helper()
+	canonical, err := s.backend.Client().HeaderByNumber(context.Background(), header.Number)
+	if err != nil {
+		// the new chain is shorter than the block
+		return
+	}
+	require.NotEqual(s.t, header.Hash(), canonical.Hash(), "block %d is still canonical", header.Number.Uint64())
+}
+
+// RequireSynced waits until all the processors have processed the head of the chain, and the head
+// doesn't change for a while. It fails after a timeout
+func (s *Simulator) RequireSynced(processors ...Processorer) {
+	s.t.Helper()
+	ctx := context.Background()
+	deadline := time.Now().Add(defaultSyncTimeout)
+	for {
+		head := s.Head().Number.Uint64()
+		for _, processor := range processors {
+			s.waitProcessor(ctx, processor, head, deadline)
+		}
+		time.Sleep(defaultSyncStableTime)
+		if s.Head().Number.Uint64() == head {
+			return
+		}
+	}
+}
+
+func (s *Simulator) waitProcessor(ctx context.Context, processor Processorer, targetBlock uint64,
+	deadline time.Time) {
+	s.t.Helper()
+	for {
+		lastProcessed, err := processor.GetLastProcessedBlock(ctx)
+		require.NoError(s.t, err)
+		if lastProcessed >= targetBlock {
+			return
+		}
+		if time.Now().After(deadline) {
+			require.FailNow(s.t, fmt.Sprintf("processor not synced. Last processed block: %d, target block: %d",
+				lastProcessed, targetBlock))
+		}
+		time.Sleep(defaultSyncPollInterval)
+	}
+}
+
+// RequireFirstReorgedBlock waits for a reorg notification and asserts its first reorged block. The
+// notification is acknowledged on processed (if it's not nil), as the reorg detector subscribers do
+func RequireFirstReorgedBlock(t *testing.T, reorgedBlock <-chan uint64, processed chan<- bool,
+	expected uint64, timeout time.Duration) {
+	t.Helper()
+	select {
+	case firstReorgedBlock := <-reorgedBlock:
+		if processed != nil {
+			processed <- true
+		}
+		require.Equal(t, expected, firstReorgedBlock)
+	case <-time.After(timeout):
+		require.FailNow(t, fmt.Sprintf("timeout waiting for the reorg of block %d", expected))
+	}
+}
@@ -0,0 +1,71 @@
+package reorgsim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/stretchr/testify/require"
+)
+
+// headProcessor is a processor that is always synced with the chain
+type headProcessor struct {
+	sim *Simulator
+}
+
+func (p *headProcessor) GetLastProcessedBlock(ctx context.Context) (uint64, error) {
+	return p.sim.Head().Number.Uint64(), nil
+}
+
+func TestScenario(t *testing.T) {
+	sim := New(t, simulated.NewBackend(nil, simulated.WithBlockGasLimit(10000000)))
+	injected := 0
+
+	sim.Run(
+		Commit(2),
+		Mark("block2"),
+		Commit(3),
+		Mark("block5"),
+		Check(func(t *testing.T, s *Simulator) {
+			t.Helper()
+			require.Equal(t, uint64(5), s.Head().Number.Uint64())
+		}),
+		ForkFrom("block2"),
+		Inject(func(t *testing.T) {
+			t.Helper()
+			injected++
+		}),
+		Commit(4),
+		Synced(&headProcessor{sim: sim}),
+		Check(func(t *testing.T, s *Simulator) {
+			t.Helper()
+			require.Equal(t, uint64(6), s.Head().Number.Uint64())
+			s.RequireCanonical(s.Marked("block2"))
+			s.RequireReorged(s.Marked("block5"))
+		}),
+	)
+	require.Equal(t, 1, injected)
+	require.Equal(t, []Fork{{
+		FromBlock:   2,
+		FromHash:    sim.Marked("block2").Hash(),
+		ReorgedHead: 5,
+	}}, sim.Forks())
+}
+
+func TestRunSchedule(t *testing.T) {
+	sim := New(t, simulated.NewBackend(nil, simulated.WithBlockGasLimit(10000000)))
+	injected := []int{}
+
+	sim.RunSchedule(Schedule{Blocks: 10, ForkEvery: 4, ForkDepth: 2}, func(t *testing.T, block int) {
+		t.Helper()
+		injected = append(injected, block)
+	})
+
+	require.Equal(t, []int{1, 2, 3, 5, 6, 7, 9, 10}, injected)
+	// blocks 1-4, fork back to 2, blocks 3-6, fork back to 4, blocks 5-6
+	require.Equal(t, []Fork{
+		{FromBlock: 2, FromHash: sim.Forks()[0].FromHash, ReorgedHead: 4},
+		{FromBlock: 4, FromHash: sim.Forks()[1].FromHash, ReorgedHead: 6},
+	}, sim.Forks())
+	require.Equal(t, uint64(6), sim.Head().Number.Uint64())
+}
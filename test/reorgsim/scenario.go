@@ -0,0 +1,103 @@
+package reorgsim
+
+import (
+	"testing"
+	"time"
+)
+
+// Step is a step of a scenario
+type Step func(s *Simulator)
+
+// Run runs the steps of a scenario in order
+func (s *Simulator) Run(steps ...Step) {
+	s.t.Helper()
+	for _, step := range steps {
+		step(s)
+	}
+}
+
+// Commit commits numBlocks blocks
+func Commit(numBlocks int) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		s.Commit(numBlocks)
+	}
+}
+
+// Mark names the current head, so the chain can be forked from it (see ForkFrom)
+func Mark(name string) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		s.Mark(name)
+	}
+}
+
+// ForkFrom forks the chain from the block marked with the given name
+func ForkFrom(name string) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		s.ForkFrom(name)
+	}
+}
+
+// ForkBack forks the chain depth blocks before the head
+func ForkBack(depth uint64) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		s.ForkBack(depth)
+	}
+}
+
+// Inject sends transactions (e.g. calls to the contracts that emit the events to sync). They are
+// included in the next committed block
+func Inject(fn func(t *testing.T)) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		fn(s.t)
+	}
+}
+
+// Wait waits the given duration
+func Wait(d time.Duration) Step {
+	return func(s *Simulator) {
+		time.Sleep(d)
+	}
+}
+
+// Synced waits until the processors have processed the head of the chain (see RequireSynced)
+func Synced(processors ...Processorer) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		s.RequireSynced(processors...)
+	}
+}
+
+// Check runs the assertions of the test
+func Check(fn func(t *testing.T, s *Simulator)) Step {
+	return func(s *Simulator) {
+		s.t.Helper()
+		fn(s.t, s)
+	}
+}
+
+// Schedule is a deterministic fork schedule: Blocks blocks are committed, and every ForkEvery blocks
+// the chain is forked ForkDepth blocks back instead of injecting events
+type Schedule struct {
+	Blocks    int
+	ForkEvery int
+	ForkDepth uint64
+}
+
+// RunSchedule commits the blocks of the schedule. After committing each block, if it's not the turn of
+// a fork, inject is called with the index of the block in the schedule (starting at 1)
+func (s *Simulator) RunSchedule(schedule Schedule, inject func(t *testing.T, block int)) {
+	s.t.Helper()
+	for block := 1; block <= schedule.Blocks; block++ {
+		s.Commit(1)
+		if schedule.ForkEvery > 0 && block%schedule.ForkEvery == 0 {
+			s.ForkBack(schedule.ForkDepth)
+		} else if inject != nil {
+			inject(s.t, block)
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math/bits"
 
 	"github.com/agglayer/aggkit/db"
 	dbtypes "github.com/agglayer/aggkit/db/types"
 	"github.com/agglayer/aggkit/log"
 	"github.com/agglayer/aggkit/tree/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/russross/meddler"
 )
 
 var (
@@ -105,6 +107,11 @@ func (t *AppendOnlyTree) initCache(tx dbtypes.Txer) error {
 	// It starts in height-1 because 0 is the level of the leafs
 	for h := int(types.DefaultHeight - 1); h >= 0; h-- {
 		currentNode, err := t.getRHTNode(tx, currentNodeHash)
+		if errors.Is(err, db.ErrNotFound) && isCompleteSubtree(index, h) {
+			// the tree was started from a frontier (see InitFromFrontier), the nodes of this complete
+			// subtree are unknown but the next leaves don't use them
+			break
+		}
 		if err != nil {
 			return fmt.Errorf(
 				"error getting node %s from the RHT at height %d with root %s: %w",
@@ -130,3 +137,131 @@ func (t *AppendOnlyTree) initCache(tx dbtypes.Txer) error {
 	t.lastLeftCache = siblings
 	return nil
 }
+
+// isCompleteSubtree returns true if the leaf of the given index is the last one of its subtree of the given
+// height (its children are at height h)
+func isCompleteSubtree(index int64, h int) bool {
+	mask := int64(1)<<(h+1) - 1
+	return index&mask == mask
+}
+
+// InitFromFrontier starts an empty tree with leafCount leaves, from its frontier: the last left node of
+// each height, as stored in the branch of the deposit contract. The leaves before leafCount are unknown,
+// so only the proofs of the leaves added after it are complete. It returns the root of the tree
+func (t *AppendOnlyTree) InitFromFrontier(tx dbtypes.Txer, blockNum uint64, leafCount uint32,
+	frontier [types.DefaultHeight]common.Hash) (common.Hash, error) {
+	if leafCount == 0 {
+		return common.Hash{}, errors.New("the frontier of an empty tree has no leaves to init")
+	}
+	if _, err := t.getLastRootWithTx(tx); err == nil {
+		return common.Hash{}, errors.New("the tree is not empty")
+	} else if !errors.Is(err, db.ErrNotFound) {
+		return common.Hash{}, err
+	}
+	lastIndex := leafCount - 1
+	// the frontier at the height of the first 0 bit of the last index is its complete subtree, the
+	// nodes below it are unknown
+	subtreeHeight := uint8(bits.TrailingZeros32(leafCount))
+	currentChildHash := frontier[subtreeHeight]
+	newNodes := []types.TreeNode{}
+	for h := subtreeHeight; h < types.DefaultHeight; h++ {
+		var parent types.TreeNode
+		if lastIndex&(1<<h) > 0 {
+			parent = newTreeNode(frontier[h], currentChildHash)
+		} else {
+			parent = newTreeNode(currentChildHash, t.zeroHashes[h])
+		}
+		currentChildHash = parent.Hash
+		newNodes = append(newNodes, parent)
+	}
+
+	if err := t.storeRoot(tx, types.Root{
+		Hash:     currentChildHash,
+		Index:    lastIndex,
+		BlockNum: blockNum,
+	}); err != nil {
+		return common.Hash{}, err
+	}
+	if err := t.storeNodes(tx, newNodes); err != nil {
+		return common.Hash{}, err
+	}
+	// the frontier below the complete subtree is not used by the next leaf, that is added to the left
+	t.lastIndex = int64(lastIndex)
+	t.lastLeftCache = frontier
+	tx.AddRollbackCallback(func() {
+		log.Debugf("resetting the cache due to rollback")
+		t.lastIndex = -2
+	})
+	return currentChildHash, nil
+}
+
+// PruneRoots deletes the roots of the given indexes and the nodes only reachable from them. The nodes of
+// the path of the leaf of a root whose subtree was not complete when the leaf was added are unique to that
+// root (the later leaves change them). The complete subtrees are shared with the later roots and are kept,
+// so the proofs of any index to the remaining roots can still be generated. It returns the number of
+// deleted nodes
+func (t *AppendOnlyTree) PruneRoots(tx dbtypes.Txer, indexes []uint32) (int64, error) {
+	var deletedNodes int64
+	for _, index := range indexes {
+		var root types.Root
+		err := meddler.QueryRow(tx, &root,
+			fmt.Sprintf(`SELECT * FROM %s WHERE position = $1;`, t.rootTable), index)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return deletedNodes, fmt.Errorf("error getting the root of index %d: %w", index, err)
+		}
+		nodes, err := t.uniquePathNodes(tx, root)
+		if err != nil {
+			return deletedNodes, err
+		}
+		for _, node := range nodes {
+			res, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE hash = $1;`, t.rhtTable), node.String())
+			if err != nil {
+				return deletedNodes, fmt.Errorf("error deleting the node %s of the root of index %d: %w",
+					node.Hex(), index, err)
+			}
+			deleted, err := res.RowsAffected()
+			if err != nil {
+				return deletedNodes, err
+			}
+			deletedNodes += deleted
+		}
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE position = $1;`, t.rootTable), index); err != nil {
+			return deletedNodes, fmt.Errorf("error deleting the root of index %d: %w", index, err)
+		}
+	}
+	return deletedNodes, nil
+}
+
+// uniquePathNodes returns the nodes, from the root to the leaf, whose subtree was not complete when the
+// leaf of the root was added. From the first complete subtree down, all the nodes are complete
+func (t *AppendOnlyTree) uniquePathNodes(tx dbtypes.Querier, root types.Root) ([]common.Hash, error) {
+	var nodes []common.Hash
+	currentNodeHash := root.Hash
+	index := uint64(root.Index)
+	for h := int(types.DefaultHeight - 1); h >= 0; h-- {
+		// the subtree of height h+1 that contains the leaf is complete if the leaf is its last one
+		lastLeafMask := uint64(1)<<(h+1) - 1
+		if index&lastLeafMask == lastLeafMask {
+			break
+		}
+		node, err := t.getRHTNode(tx, currentNodeHash)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				// already pruned
+				break
+			}
+			return nil, fmt.Errorf("error getting the node %s at height %d of the root %s: %w",
+				currentNodeHash.Hex(), h, root.Hash.Hex(), err)
+		}
+		nodes = append(nodes, currentNodeHash)
+		if index&(1<<h) > 0 {
+			currentNodeHash = node.Right
+		} else {
+			currentNodeHash = node.Left
+		}
+	}
+	return nodes, nil
+}
@@ -198,6 +198,89 @@ func TestMTGetProof(t *testing.T) {
 	}
 }
 
+func TestAppendOnlyTreePruneRoots(t *testing.T) {
+	ctx := context.Background()
+	const numLeaves = 40
+	leafHash := func(i int) common.Hash { return common.HexToHash(fmt.Sprintf("0x%x", i+1)) }
+	addLeaves := func(tre *AppendOnlyTree, treeDB *sql.DB, from, to int) {
+		tx, err := db.NewTx(ctx, treeDB)
+		require.NoError(t, err)
+		for i := from; i < to; i++ {
+			require.NoError(t, tre.AddLeaf(tx, uint64(i), 0, types.Leaf{Index: uint32(i), Hash: leafHash(i)}))
+		}
+		require.NoError(t, tx.Commit())
+	}
+	countNodes := func(treeDB *sql.DB) int {
+		var count int
+		require.NoError(t, treeDB.QueryRow(`SELECT COUNT(*) FROM rht;`).Scan(&count))
+		return count
+	}
+
+	treeDB := createTreeDBForTest(t)
+	tre := NewAppendOnlyTree(treeDB, "")
+	addLeaves(tre, treeDB, 0, numLeaves)
+	roots := make([]types.Root, numLeaves)
+	for i := range roots {
+		root, err := tre.GetRootByIndex(ctx, uint32(i))
+		require.NoError(t, err)
+		roots[i] = root
+	}
+
+	// the checkpoints 10 and 20 (and the last root) are kept
+	kept := map[uint32]bool{10: true, 20: true, numLeaves - 1: true}
+	var pruned []uint32
+	for i := uint32(0); i < numLeaves; i++ {
+		if !kept[i] {
+			pruned = append(pruned, i)
+		}
+	}
+	nodesBefore := countNodes(treeDB)
+	tx, err := db.NewTx(ctx, treeDB)
+	require.NoError(t, err)
+	deletedNodes, err := tre.PruneRoots(tx, pruned)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.Positive(t, deletedNodes)
+	require.Equal(t, nodesBefore-int(deletedNodes), countNodes(treeDB))
+
+	// pruning again does nothing
+	tx, err = db.NewTx(ctx, treeDB)
+	require.NoError(t, err)
+	deletedNodes, err = tre.PruneRoots(tx, pruned)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.Zero(t, deletedNodes)
+
+	for _, index := range pruned {
+		_, err := tre.GetRootByIndex(ctx, index)
+		require.ErrorIs(t, err, db.ErrNotFound)
+	}
+	// the proofs of all the leaves to the kept roots are still valid
+	for index := range kept {
+		for leaf := uint32(0); leaf <= index; leaf++ {
+			proof, err := tre.GetProof(ctx, leaf, roots[index].Hash)
+			require.NoError(t, err)
+			require.Equal(t, roots[index].Hash, CalculateRoot(leafHash(int(leaf)), proof, leaf))
+		}
+	}
+
+	// the new leaves are added on top of the last root, as in a tree that has not been pruned
+	addLeaves(tre, treeDB, numLeaves, numLeaves+5)
+	expectedDB := createTreeDBForTest(t)
+	expected := NewAppendOnlyTree(expectedDB, "")
+	addLeaves(expected, expectedDB, 0, numLeaves+5)
+	lastRoot, err := tre.GetLastRoot(nil)
+	require.NoError(t, err)
+	expectedRoot, err := expected.GetLastRoot(nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, lastRoot)
+	for leaf := uint32(0); leaf < numLeaves+5; leaf++ {
+		proof, err := tre.GetProof(ctx, leaf, lastRoot.Hash)
+		require.NoError(t, err)
+		require.Equal(t, lastRoot.Hash, CalculateRoot(leafHash(int(leaf)), proof, leaf))
+	}
+}
+
 func createTreeDBForTest(t *testing.T) *sql.DB {
 	t.Helper()
 	dbPath := path.Join(t.TempDir(), "tree_createTreeDBForTest.sqlite")
@@ -207,3 +290,65 @@ func createTreeDBForTest(t *testing.T) *sql.DB {
 	require.NoError(t, err)
 	return treeDB
 }
+
+func TestAppendOnlyTreeInitFromFrontier(t *testing.T) {
+	ctx := context.Background()
+	leafHash := func(i int) common.Hash { return common.HexToHash(fmt.Sprintf("0x%x", i+1)) }
+	addLeaves := func(tre *AppendOnlyTree, treeDB *sql.DB, from, to int) {
+		tx, err := db.NewTx(ctx, treeDB)
+		require.NoError(t, err)
+		for i := from; i < to; i++ {
+			require.NoError(t, tre.AddLeaf(tx, uint64(i), 0, types.Leaf{Index: uint32(i), Hash: leafHash(i)}))
+		}
+		require.NoError(t, tx.Commit())
+	}
+
+	for _, leafCount := range []int{1, 2, 7, 8, 12, 33} {
+		t.Run(fmt.Sprintf("%d leaves", leafCount), func(t *testing.T) {
+			expectedDB := createTreeDBForTest(t)
+			expected := NewAppendOnlyTree(expectedDB, "")
+			addLeaves(expected, expectedDB, 0, leafCount)
+			// the cache of the tree is the frontier of the deposit contract
+			frontier := expected.lastLeftCache
+			expectedRoot, err := expected.GetLastRoot(nil)
+			require.NoError(t, err)
+
+			treeDB := createTreeDBForTest(t)
+			tre := NewAppendOnlyTree(treeDB, "")
+			tx, err := db.NewTx(ctx, treeDB)
+			require.NoError(t, err)
+			root, err := tre.InitFromFrontier(tx, uint64(leafCount-1), uint32(leafCount), frontier)
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+			require.Equal(t, expectedRoot.Hash, root)
+
+			tx, err = db.NewTx(ctx, treeDB)
+			require.NoError(t, err)
+			_, err = tre.InitFromFrontier(tx, uint64(leafCount-1), uint32(leafCount), frontier)
+			require.ErrorContains(t, err, "the tree is not empty")
+			require.NoError(t, tx.Rollback())
+
+			// the next leaves give the same roots and proofs, also after rebuilding the cache from the storage
+			addLeaves(tre, treeDB, leafCount, leafCount+3)
+			tre = NewAppendOnlyTree(treeDB, "")
+			addLeaves(tre, treeDB, leafCount+3, leafCount+6)
+			addLeaves(expected, expectedDB, leafCount, leafCount+6)
+			for i := leafCount; i < leafCount+6; i++ {
+				expectedRoot, err := expected.GetRootByIndex(ctx, uint32(i))
+				require.NoError(t, err)
+				root, err := tre.GetRootByIndex(ctx, uint32(i))
+				require.NoError(t, err)
+				require.Equal(t, expectedRoot.Hash, root.Hash)
+				proof, err := tre.GetProof(ctx, uint32(i), root.Hash)
+				require.NoError(t, err)
+				require.Equal(t, root.Hash, CalculateRoot(leafHash(i), proof, uint32(i)))
+			}
+		})
+	}
+
+	tx, err := db.NewTx(ctx, createTreeDBForTest(t))
+	require.NoError(t, err)
+	_, err = NewAppendOnlyTree(nil, "").InitFromFrontier(tx, 0, 0, [types.DefaultHeight]common.Hash{})
+	require.ErrorContains(t, err, "no leaves")
+	require.NoError(t, tx.Rollback())
+}
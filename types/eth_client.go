@@ -1,10 +1,17 @@
 package types
 
 import (
+	"context"
+	"errors"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// ErrBatchCallNotSupported is returned when the RPC client can't send batch requests
+var ErrBatchCallNotSupported = errors.New("the RPC client doesn't support batch calls")
+
 var _ EthClienter = (*DefaultEthClient)(nil)
 
 // DefaultEthClient is the default implementation of EthClienter.
@@ -49,3 +56,19 @@ type NoopRPCClient struct{}
 func (c *NoopRPCClient) Call(result any, method string, args ...any) error {
 	return nil
 }
+
+// RPCBatchCaller defines an interface for sending several RPC calls in a single request.
+type RPCBatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+var _ RPCBatchCaller = (*DefaultEthClient)(nil)
+
+// BatchCallContext sends the calls in a single batch request if the RPC client supports it.
+func (c *DefaultEthClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	batchCaller, ok := c.RPCClienter.(RPCBatchCaller)
+	if !ok {
+		return ErrBatchCallNotSupported
+	}
+	return batchCaller.BatchCallContext(ctx, b)
+}
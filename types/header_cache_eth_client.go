@@ -0,0 +1,130 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	_ EthClienter    = (*HeaderCacheEthClient)(nil)
+	_ RPCBatchCaller = (*HeaderCacheEthClient)(nil)
+)
+
+// cachedHeader is a header cached by number, that expires because the block can be reorged
+type cachedHeader struct {
+	header   *gethtypes.Header
+	cachedAt time.Time
+}
+
+// HeaderCacheEthClient is a decorator of an EthClienter that caches the block headers, so all the
+// syncers and the reorg detector sharing the same client don't request the same headers again and
+// again. The concurrent requests of the same header are also sent only once.
+// The headers by hash never change, so they are kept until they are evicted. The headers by number
+// can change because of a reorg, so they expire after the TTL. The headers by tag (latest, safe,
+// finalized...) are never cached
+type HeaderCacheEthClient struct {
+	EthClienter
+	network  string
+	ttl      time.Duration
+	byNumber *lru.Cache[uint64, cachedHeader]
+	byHash   *lru.Cache[common.Hash, *gethtypes.Header]
+	inFlight singleflight.Group
+}
+
+// NewHeaderCacheEthClient decorates the client of the given network with a header cache. It returns
+// the client itself if the cache is disabled
+func NewHeaderCacheEthClient(network string, client EthClienter, opts RPCHeaderCacheOptions) EthClienter {
+	if opts.Size <= 0 {
+		return client
+	}
+	registerRPCMetrics()
+	return &HeaderCacheEthClient{
+		EthClienter: client,
+		network:     network,
+		ttl:         opts.TTL,
+		byNumber:    lru.NewCache[uint64, cachedHeader](opts.Size),
+		byHash:      lru.NewCache[common.Hash, *gethtypes.Header](opts.Size),
+	}
+}
+
+// HeaderByNumber returns the header from the cache if it has not expired, otherwise it's requested
+func (c *HeaderCacheEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error) {
+	if number == nil || !number.IsUint64() || c.ttl <= 0 {
+		header, err := c.EthClienter.HeaderByNumber(ctx, number)
+		if err == nil {
+			c.byHash.Add(header.Hash(), gethtypes.CopyHeader(header))
+		}
+		return header, err
+	}
+	num := number.Uint64()
+	if cached, ok := c.byNumber.Get(num); ok && time.Since(cached.cachedAt) < c.ttl {
+		rpcHeaderCacheRequest(c.network, true)
+		return gethtypes.CopyHeader(cached.header), nil
+	}
+	rpcHeaderCacheRequest(c.network, false)
+	header, err := c.fetch(fmt.Sprintf("number:%d", num), func() (*gethtypes.Header, error) {
+		header, err := c.EthClienter.HeaderByNumber(ctx, number)
+		if err == nil {
+			c.byNumber.Add(num, cachedHeader{header: header, cachedAt: time.Now()})
+		}
+		return header, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gethtypes.CopyHeader(header), nil
+}
+
+// HeaderByHash returns the header from the cache, otherwise it's requested
+func (c *HeaderCacheEthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*gethtypes.Header, error) {
+	if header, ok := c.byHash.Get(hash); ok {
+		rpcHeaderCacheRequest(c.network, true)
+		return gethtypes.CopyHeader(header), nil
+	}
+	rpcHeaderCacheRequest(c.network, false)
+	header, err := c.fetch("hash:"+hash.Hex(), func() (*gethtypes.Header, error) {
+		return c.EthClienter.HeaderByHash(ctx, hash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gethtypes.CopyHeader(header), nil
+}
+
+// fetch requests the header once for all the concurrent callers and caches it by hash
+func (c *HeaderCacheEthClient) fetch(key string,
+	request func() (*gethtypes.Header, error)) (*gethtypes.Header, error) {
+	result, err, _ := c.inFlight.Do(key, func() (any, error) {
+		header, err := request()
+		if err != nil {
+			return nil, err
+		}
+		c.byHash.Add(header.Hash(), header)
+		return header, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	header, ok := result.(*gethtypes.Header)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T of the cached header", result)
+	}
+	return header, nil
+}
+
+// BatchCallContext forwards the batch request to the decorated client, the results are not cached
+func (c *HeaderCacheEthClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	batchCaller, ok := c.EthClienter.(RPCBatchCaller)
+	if !ok {
+		return ErrBatchCallNotSupported
+	}
+	return batchCaller.BatchCallContext(ctx, b)
+}
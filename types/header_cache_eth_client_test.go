@@ -0,0 +1,150 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHeaderClient returns a header with the requested number, and counts the requests
+type fakeHeaderClient struct {
+	EthClienter
+	calls   atomic.Int32
+	delay   time.Duration
+	err     error
+	headers sync.Map // common.Hash -> *types.Header
+}
+
+func (f *fakeHeaderClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	f.calls.Add(1)
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if number == nil || number.Sign() < 0 {
+		number = big.NewInt(1000)
+	}
+	header := &types.Header{Number: new(big.Int).Set(number)}
+	f.headers.Store(header.Hash(), header)
+	return header, nil
+}
+
+func (f *fakeHeaderClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	f.calls.Add(1)
+	if header, ok := f.headers.Load(hash); ok {
+		return header.(*types.Header), nil //nolint:forcetypeassert
+	}
+	return nil, errors.New("not found")
+}
+
+func TestHeaderCacheEthClientDisabled(t *testing.T) {
+	fake := &fakeHeaderClient{}
+	require.Equal(t, fake, NewHeaderCacheEthClient("l1", fake, RPCHeaderCacheOptions{}))
+}
+
+func TestHeaderCacheEthClientByNumber(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeHeaderClient{}
+	client := NewHeaderCacheEthClient("l1", fake, RPCHeaderCacheOptions{Size: 10, TTL: 50 * time.Millisecond})
+
+	header, err := client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), header.Number.Uint64())
+	// the returned header is a copy, so the caller can't modify the cached one
+	header.Number.SetUint64(6)
+
+	header, err = client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), header.Number.Uint64())
+	require.Equal(t, int32(1), fake.calls.Load())
+
+	// the header requested by number is also cached by hash
+	_, err = client.HeaderByHash(ctx, header.Hash())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), fake.calls.Load())
+
+	// the tags are never cached
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	_, err = client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	require.NoError(t, err)
+	require.Equal(t, int32(3), fake.calls.Load())
+
+	// the header by number expires after the TTL
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, int32(4), fake.calls.Load())
+}
+
+func TestHeaderCacheEthClientOnlyByHash(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeHeaderClient{}
+	client := NewHeaderCacheEthClient("l1", fake, RPCHeaderCacheOptions{Size: 10})
+
+	header, err := client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	_, err = client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), fake.calls.Load())
+
+	for range 3 {
+		cached, err := client.HeaderByHash(ctx, header.Hash())
+		require.NoError(t, err)
+		require.Equal(t, header.Hash(), cached.Hash())
+	}
+	require.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestHeaderCacheEthClientErrors(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeHeaderClient{err: errors.New("rpc error")}
+	client := NewHeaderCacheEthClient("l1", fake, RPCHeaderCacheOptions{Size: 10, TTL: time.Minute})
+
+	_, err := client.HeaderByNumber(ctx, big.NewInt(5))
+	require.ErrorContains(t, err, "rpc error")
+	_, err = client.HeaderByHash(ctx, common.HexToHash("0x1"))
+	require.ErrorContains(t, err, "not found")
+
+	// the errors are not cached
+	fake.err = nil
+	_, err = client.HeaderByNumber(ctx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, int32(3), fake.calls.Load())
+}
+
+func TestHeaderCacheEthClientConcurrentRequests(t *testing.T) {
+	const numRequests = 5
+	fake := &fakeHeaderClient{delay: 50 * time.Millisecond}
+	client := NewHeaderCacheEthClient("l1", fake, RPCHeaderCacheOptions{Size: 10, TTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for range numRequests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			header, err := client.HeaderByNumber(context.Background(), big.NewInt(7))
+			require.NoError(t, err)
+			require.Equal(t, uint64(7), header.Number.Uint64())
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), fake.calls.Load())
+}
+
+func TestRPCHeaderCacheOptionsValidate(t *testing.T) {
+	require.NoError(t, RPCHeaderCacheOptions{}.Validate())
+	require.NoError(t, RPCHeaderCacheOptions{Size: 100, TTL: time.Second}.Validate())
+	require.ErrorContains(t, RPCHeaderCacheOptions{Size: -1}.Validate(), "Size")
+	require.ErrorContains(t, RPCHeaderCacheOptions{TTL: -time.Second}.Validate(), "TTL")
+	require.ErrorContains(t, RPCClientOptions{HeaderCache: RPCHeaderCacheOptions{Size: -1}}.Validate(), "Size")
+}
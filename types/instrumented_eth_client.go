@@ -0,0 +1,274 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/agglayer/aggkit/healthcheck"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// codeOK is the error code label of the successful requests
+	codeOK = "ok"
+	// codeTimeout is the error code label of the requests cancelled or timed out
+	codeTimeout = "timeout"
+	// codeUnknown is the error code label of the errors without a JSON-RPC or HTTP code
+	codeUnknown = "error"
+)
+
+var (
+	_ EthClienter    = (*InstrumentedEthClient)(nil)
+	_ RPCBatchCaller = (*InstrumentedEthClient)(nil)
+)
+
+// InstrumentedEthClient is a decorator of an EthClienter that limits the rate and the number of
+// in-flight requests sent to the endpoint, and exports the metrics of the requests (method, latency
+// and error code) labelled by network. It keeps aggkit inside the quotas of the RPC providers,
+// e.g. during the initial sync, instead of getting banned with 429 responses
+type InstrumentedEthClient struct {
+	EthClienter
+	network string
+	// limiter is nil if the rate is not limited
+	limiter *rate.Limiter
+	// inFlight is a semaphore of the in-flight requests, nil if the concurrency is not limited
+	inFlight chan struct{}
+}
+
+// NewInstrumentedEthClient decorates the client of the given network with the rate limit options
+func NewInstrumentedEthClient(network string, client EthClienter, opts RPCRateLimitOptions) *InstrumentedEthClient {
+	registerRPCMetrics()
+	c := &InstrumentedEthClient{
+		EthClienter: client,
+		network:     network,
+	}
+	if opts.RequestsPerSecond > 0 {
+		burst := opts.Burst
+		if burst <= 0 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(opts.RequestsPerSecond), burst)
+	}
+	if opts.MaxConcurrentRequests > 0 {
+		c.inFlight = make(chan struct{}, opts.MaxConcurrentRequests)
+	}
+	return c
+}
+
+// acquire waits until the request can be sent. The returned func must be called when the request is done
+func (c *InstrumentedEthClient) acquire(ctx context.Context) (func(), error) {
+	if c.limiter == nil && c.inFlight == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	if c.inFlight != nil {
+		select {
+		case c.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if c.inFlight != nil {
+			<-c.inFlight
+		}
+	}
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	rpcRequestThrottled(c.network, time.Since(start))
+	return release, nil
+}
+
+// instrumented sends the request with the given call, applying the limits and exporting its metrics
+func instrumented[T any](ctx context.Context, c *InstrumentedEthClient, method string,
+	call func() (T, error)) (T, error) {
+	var zero T
+	release, err := c.acquire(ctx)
+	if err != nil {
+		rpcRequestDone(c.network, method, errorCode(err), 0)
+		return zero, fmt.Errorf("rpc %s (%s): failed waiting for the rate limit: %w", method, c.network, err)
+	}
+	defer release()
+
+	start := time.Now()
+	result, err := call()
+	rpcRequestDone(c.network, method, errorCode(err), time.Since(start))
+	// the blocks or receipts not found are not failures of the endpoint
+	if !errors.Is(err, ethereum.NotFound) {
+		healthcheck.RecordResult("rpc_"+c.network, err)
+	}
+	return result, err
+}
+
+// errorCode returns the label of the error: the JSON-RPC error code, the HTTP status code (e.g. 429)
+// or if it timed out
+func errorCode(err error) string {
+	if err == nil {
+		return codeOK
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return "http_" + strconv.Itoa(httpErr.StatusCode)
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return strconv.Itoa(rpcErr.ErrorCode())
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return codeTimeout
+	}
+	return codeUnknown
+}
+
+// Call sends a generic RPC call. As it doesn't get a context, it can wait forever for the rate limit
+func (c *InstrumentedEthClient) Call(result any, method string, args ...any) error {
+	_, err := instrumented(context.Background(), c, method, func() (struct{}, error) {
+		return struct{}{}, c.EthClienter.Call(result, method, args...)
+	})
+	return err
+}
+
+func (c *InstrumentedEthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	return instrumented(ctx, c, "eth_getLogs", func() ([]gethtypes.Log, error) {
+		return c.EthClienter.FilterLogs(ctx, q)
+	})
+}
+
+func (c *InstrumentedEthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery,
+	ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	return instrumented(ctx, c, "eth_subscribe_logs", func() (ethereum.Subscription, error) {
+		return c.EthClienter.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+func (c *InstrumentedEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return instrumented(ctx, c, "eth_blockNumber", func() (uint64, error) {
+		return c.EthClienter.BlockNumber(ctx)
+	})
+}
+
+func (c *InstrumentedEthClient) BlockByHash(ctx context.Context, hash common.Hash) (*gethtypes.Block, error) {
+	return instrumented(ctx, c, "eth_getBlockByHash", func() (*gethtypes.Block, error) {
+		return c.EthClienter.BlockByHash(ctx, hash)
+	})
+}
+
+func (c *InstrumentedEthClient) BlockByNumber(ctx context.Context, number *big.Int) (*gethtypes.Block, error) {
+	return instrumented(ctx, c, "eth_getBlockByNumber", func() (*gethtypes.Block, error) {
+		return c.EthClienter.BlockByNumber(ctx, number)
+	})
+}
+
+func (c *InstrumentedEthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*gethtypes.Header, error) {
+	return instrumented(ctx, c, "eth_getBlockByHash", func() (*gethtypes.Header, error) {
+		return c.EthClienter.HeaderByHash(ctx, hash)
+	})
+}
+
+func (c *InstrumentedEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error) {
+	return instrumented(ctx, c, "eth_getBlockByNumber", func() (*gethtypes.Header, error) {
+		return c.EthClienter.HeaderByNumber(ctx, number)
+	})
+}
+
+func (c *InstrumentedEthClient) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return instrumented(ctx, c, "eth_getBlockTransactionCountByHash", func() (uint, error) {
+		return c.EthClienter.TransactionCount(ctx, blockHash)
+	})
+}
+
+func (c *InstrumentedEthClient) TransactionInBlock(ctx context.Context, blockHash common.Hash,
+	index uint) (*gethtypes.Transaction, error) {
+	return instrumented(ctx, c, "eth_getTransactionByBlockHashAndIndex", func() (*gethtypes.Transaction, error) {
+		return c.EthClienter.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+func (c *InstrumentedEthClient) SubscribeNewHead(ctx context.Context,
+	ch chan<- *gethtypes.Header) (ethereum.Subscription, error) {
+	return instrumented(ctx, c, "eth_subscribe_newHeads", func() (ethereum.Subscription, error) {
+		return c.EthClienter.SubscribeNewHead(ctx, ch)
+	})
+}
+
+func (c *InstrumentedEthClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return instrumented(ctx, c, "eth_chainId", func() (*big.Int, error) {
+		return c.EthClienter.ChainID(ctx)
+	})
+}
+
+func (c *InstrumentedEthClient) CodeAt(ctx context.Context, contract common.Address,
+	blockNumber *big.Int) ([]byte, error) {
+	return instrumented(ctx, c, "eth_getCode", func() ([]byte, error) {
+		return c.EthClienter.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+func (c *InstrumentedEthClient) CallContract(ctx context.Context, call ethereum.CallMsg,
+	blockNumber *big.Int) ([]byte, error) {
+	return instrumented(ctx, c, "eth_call", func() ([]byte, error) {
+		return c.EthClienter.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (c *InstrumentedEthClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return instrumented(ctx, c, "eth_getCode", func() ([]byte, error) {
+		return c.EthClienter.PendingCodeAt(ctx, account)
+	})
+}
+
+func (c *InstrumentedEthClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return instrumented(ctx, c, "eth_getTransactionCount", func() (uint64, error) {
+		return c.EthClienter.PendingNonceAt(ctx, account)
+	})
+}
+
+func (c *InstrumentedEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return instrumented(ctx, c, "eth_gasPrice", func() (*big.Int, error) {
+		return c.EthClienter.SuggestGasPrice(ctx)
+	})
+}
+
+func (c *InstrumentedEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return instrumented(ctx, c, "eth_maxPriorityFeePerGas", func() (*big.Int, error) {
+		return c.EthClienter.SuggestGasTipCap(ctx)
+	})
+}
+
+func (c *InstrumentedEthClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return instrumented(ctx, c, "eth_estimateGas", func() (uint64, error) {
+		return c.EthClienter.EstimateGas(ctx, call)
+	})
+}
+
+func (c *InstrumentedEthClient) SendTransaction(ctx context.Context, tx *gethtypes.Transaction) error {
+	_, err := instrumented(ctx, c, "eth_sendRawTransaction", func() (struct{}, error) {
+		return struct{}{}, c.EthClienter.SendTransaction(ctx, tx)
+	})
+	return err
+}
+
+// BatchCallContext sends the batch request to the decorated client, if it supports it. The whole batch
+// counts as a single request for the limits and the metrics (method "batch")
+func (c *InstrumentedEthClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	batchCaller, ok := c.EthClienter.(RPCBatchCaller)
+	if !ok {
+		return ErrBatchCallNotSupported
+	}
+	_, err := instrumented(ctx, c, "batch", func() (struct{}, error) {
+		return struct{}{}, batchCaller.BatchCallContext(ctx, b)
+	})
+	return err
+}
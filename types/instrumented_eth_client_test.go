@@ -0,0 +1,167 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEthClient implements the methods of EthClienter used by the tests
+type fakeEthClient struct {
+	EthClienter
+	blockNumberFn func(ctx context.Context) (uint64, error)
+	calls         atomic.Int32
+}
+
+func (f *fakeEthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	f.calls.Add(1)
+	return f.blockNumberFn(ctx)
+}
+
+func (f *fakeEthClient) Call(result any, method string, args ...any) error {
+	f.calls.Add(1)
+	return nil
+}
+
+type fakeRPCError struct {
+	code int
+}
+
+func (e fakeRPCError) Error() string  { return fmt.Sprintf("rpc error %d", e.code) }
+func (e fakeRPCError) ErrorCode() int { return e.code }
+
+func TestInstrumentedEthClientNoLimits(t *testing.T) {
+	fake := &fakeEthClient{blockNumberFn: func(ctx context.Context) (uint64, error) {
+		return 123, nil
+	}}
+	client := NewInstrumentedEthClient("l1", fake, RPCRateLimitOptions{})
+
+	blockNum, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(123), blockNum)
+	require.NoError(t, client.Call(nil, "eth_getTransactionReceipt"))
+	require.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestInstrumentedEthClientMaxConcurrentRequests(t *testing.T) {
+	const (
+		maxConcurrent = 2
+		numRequests   = 6
+	)
+	var inFlight, maxInFlight atomic.Int32
+	fake := &fakeEthClient{blockNumberFn: func(ctx context.Context) (uint64, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	}}
+	client := NewInstrumentedEthClient("l2", fake, RPCRateLimitOptions{MaxConcurrentRequests: maxConcurrent})
+
+	var wg sync.WaitGroup
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.BlockNumber(context.Background())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(numRequests), fake.calls.Load())
+	require.Equal(t, int32(maxConcurrent), maxInFlight.Load())
+}
+
+func TestInstrumentedEthClientRateLimit(t *testing.T) {
+	fake := &fakeEthClient{blockNumberFn: func(ctx context.Context) (uint64, error) {
+		return 1, nil
+	}}
+	client := NewInstrumentedEthClient("l1", fake, RPCRateLimitOptions{RequestsPerSecond: 20})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.BlockNumber(context.Background())
+		require.NoError(t, err)
+	}
+	// the first request is sent at once (burst 1), the next ones every 50ms
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+
+	t.Run("context cancelled while waiting for the rate limit", func(t *testing.T) {
+		client := NewInstrumentedEthClient("l1", fake, RPCRateLimitOptions{RequestsPerSecond: 0.1})
+		_, err := client.BlockNumber(context.Background())
+		require.NoError(t, err)
+
+		calls := fake.calls.Load()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = client.BlockNumber(ctx)
+		require.ErrorContains(t, err, "failed waiting for the rate limit")
+		require.Equal(t, calls, fake.calls.Load())
+	})
+}
+
+func TestRPCRateLimitOptionsValidate(t *testing.T) {
+	require.NoError(t, RPCRateLimitOptions{}.Validate())
+	require.NoError(t, RPCRateLimitOptions{RequestsPerSecond: 10, Burst: 5, MaxConcurrentRequests: 4}.Validate())
+	require.ErrorContains(t, RPCRateLimitOptions{RequestsPerSecond: -1}.Validate(), "RequestsPerSecond")
+	require.ErrorContains(t, RPCRateLimitOptions{Burst: -1}.Validate(), "Burst")
+	require.ErrorContains(t, RPCRateLimitOptions{MaxConcurrentRequests: -1}.Validate(), "MaxConcurrentRequests")
+	require.ErrorContains(t, RPCClientOptions{RateLimit: RPCRateLimitOptions{Burst: -1}}.Validate(), "Burst")
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "no error", err: nil, expected: codeOK},
+		{
+			name:     "too many requests",
+			err:      fmt.Errorf("wrapped: %w", rpc.HTTPError{StatusCode: http.StatusTooManyRequests}),
+			expected: "http_429",
+		},
+		{name: "JSON-RPC error", err: fakeRPCError{code: -32005}, expected: "-32005"},
+		{name: "timeout", err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), expected: codeTimeout},
+		{name: "other error", err: errors.New("connection refused"), expected: codeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, errorCode(tt.err))
+		})
+	}
+}
+
+// fakeBatchEthClient is a fakeEthClient that supports batch requests
+type fakeBatchEthClient struct {
+	*fakeEthClient
+}
+
+func (f *fakeBatchEthClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	f.calls.Add(1)
+	return nil
+}
+
+func TestInstrumentedEthClientBatchCallContext(t *testing.T) {
+	client := NewInstrumentedEthClient("l1", &fakeEthClient{}, RPCRateLimitOptions{})
+	require.ErrorIs(t, client.BatchCallContext(context.Background(), nil), ErrBatchCallNotSupported)
+
+	fake := &fakeBatchEthClient{fakeEthClient: &fakeEthClient{}}
+	client = NewInstrumentedEthClient("l1", fake, RPCRateLimitOptions{MaxConcurrentRequests: 1})
+	require.NoError(t, client.BatchCallContext(context.Background(), []rpc.BatchElem{{Method: "eth_chainId"}}))
+	require.Equal(t, int32(1), fake.calls.Load())
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agglayer/aggkit/log"
+	"github.com/agglayer/aggkit/prometheus"
+	prometheusClient "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	rpcMetricsPrefix       = "rpc_client_"
+	rpcRequests            = rpcMetricsPrefix + "requests_total"
+	rpcRequestDuration     = rpcMetricsPrefix + "request_duration_seconds"
+	rpcRateLimitWait       = rpcMetricsPrefix + "rate_limit_wait_seconds"
+	rpcHeaderCacheRequests = rpcMetricsPrefix + "header_cache_requests_total"
+	rpcMetricsNetworkLabel = "network"
+	rpcMetricsMethodLabel  = "method"
+	rpcMetricsCodeLabel    = "code"
+	rpcMetricsResultLabel  = "result"
+)
+
+var registerRPCMetricsOnce sync.Once
+
+// registerRPCMetrics registers the RPC client metrics. All the clients share the same metrics, so
+// they are registered only once and labelled by network
+func registerRPCMetrics() {
+	registerRPCMetricsOnce.Do(func() {
+		prometheus.RegisterCounterVecs(prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: rpcRequests,
+				Help: "[RPC] number of requests sent to the RPC endpoints, by method and error code",
+			},
+			Labels: []string{rpcMetricsNetworkLabel, rpcMetricsMethodLabel, rpcMetricsCodeLabel},
+		}, prometheus.CounterVecOpts{
+			CounterOpts: prometheusClient.CounterOpts{
+				Name: rpcHeaderCacheRequests,
+				Help: "[RPC] number of block headers requested to the header cache, by result (hit or miss)",
+			},
+			Labels: []string{rpcMetricsNetworkLabel, rpcMetricsResultLabel},
+		})
+		prometheus.RegisterHistogramVecs(
+			prometheus.HistogramVecOpts{
+				HistogramOpts: prometheusClient.HistogramOpts{
+					Name:    rpcRequestDuration,
+					Help:    "[RPC] latency of the requests sent to the RPC endpoints in seconds",
+					Buckets: prometheusClient.DefBuckets,
+				},
+				Labels: []string{rpcMetricsNetworkLabel, rpcMetricsMethodLabel},
+			},
+			prometheus.HistogramVecOpts{
+				HistogramOpts: prometheusClient.HistogramOpts{
+					Name:    rpcRateLimitWait,
+					Help:    "[RPC] time waiting for the rate and concurrency limits of the RPC endpoints in seconds",
+					Buckets: prometheusClient.ExponentialBuckets(0.001, 4, 8), //nolint:mnd
+				},
+				Labels: []string{rpcMetricsNetworkLabel},
+			},
+		)
+		log.Info("Registered prometheus RPC client metrics")
+	})
+}
+
+// rpcRequestDone counts the request and observes its latency (if it was sent)
+func rpcRequestDone(network, method, code string, latency time.Duration) {
+	if cv, ok := prometheus.CounterVec(rpcRequests); ok {
+		cv.WithLabelValues(network, method, code).Inc()
+	}
+	if latency == 0 {
+		return
+	}
+	if hv, ok := prometheus.HistogramVec(rpcRequestDuration); ok {
+		hv.WithLabelValues(network, method).Observe(latency.Seconds())
+	}
+}
+
+// rpcRequestThrottled observes the time a request waited for the limits
+func rpcRequestThrottled(network string, wait time.Duration) {
+	prometheus.HistogramVecObserve(rpcRateLimitWait, network, wait.Seconds())
+}
+
+// rpcHeaderCacheRequest counts a request of a header to the header cache
+func rpcHeaderCacheRequest(network string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	if cv, ok := prometheus.CounterVec(rpcHeaderCacheRequests); ok {
+		cv.WithLabelValues(network, result).Inc()
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	rpc "github.com/ethereum/go-ethereum/rpc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RPCBatchCaller is an autogenerated mock type for the RPCBatchCaller type
+type RPCBatchCaller struct {
+	mock.Mock
+}
+
+type RPCBatchCaller_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RPCBatchCaller) EXPECT() *RPCBatchCaller_Expecter {
+	return &RPCBatchCaller_Expecter{mock: &_m.Mock}
+}
+
+// BatchCallContext provides a mock function with given fields: ctx, b
+func (_m *RPCBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	ret := _m.Called(ctx, b)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchCallContext")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []rpc.BatchElem) error); ok {
+		r0 = rf(ctx, b)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RPCBatchCaller_BatchCallContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchCallContext'
+type RPCBatchCaller_BatchCallContext_Call struct {
+	*mock.Call
+}
+
+// BatchCallContext is a helper method to define mock.On call
+//   - ctx context.Context
+//   - b []rpc.BatchElem
+func (_e *RPCBatchCaller_Expecter) BatchCallContext(ctx interface{}, b interface{}) *RPCBatchCaller_BatchCallContext_Call {
+	return &RPCBatchCaller_BatchCallContext_Call{Call: _e.mock.On("BatchCallContext", ctx, b)}
+}
+
+func (_c *RPCBatchCaller_BatchCallContext_Call) Run(run func(ctx context.Context, b []rpc.BatchElem)) *RPCBatchCaller_BatchCallContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]rpc.BatchElem))
+	})
+	return _c
+}
+
+func (_c *RPCBatchCaller_BatchCallContext_Call) Return(_a0 error) *RPCBatchCaller_BatchCallContext_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RPCBatchCaller_BatchCallContext_Call) RunAndReturn(run func(context.Context, []rpc.BatchElem) error) *RPCBatchCaller_BatchCallContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRPCBatchCaller creates a new instance of RPCBatchCaller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRPCBatchCaller(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RPCBatchCaller {
+	mock := &RPCBatchCaller{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
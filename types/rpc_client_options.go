@@ -0,0 +1,191 @@
+package types
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// RPCClientOptions are the transport options used to connect to a RPC endpoint
+type RPCClientOptions struct {
+	// Headers are static headers added to every request (e.g. API keys)
+	Headers map[string]string
+	// BasicAuthUser and BasicAuthPassword are the credentials for HTTP basic authentication
+	BasicAuthUser     string
+	BasicAuthPassword string
+	// BearerToken is sent as "Authorization: Bearer <token>"
+	BearerToken string
+	// Timeout is the timeout of each HTTP request, 0 means no timeout
+	Timeout time.Duration
+	// RateLimit limits the requests sent to the endpoint (see InstrumentedEthClient)
+	RateLimit RPCRateLimitOptions
+	// HeaderCache caches the block headers requested to the endpoint (see HeaderCacheEthClient)
+	HeaderCache RPCHeaderCacheOptions
+	// ProxyURL is the HTTP(S) proxy used to connect to the endpoint. If it's empty, the proxy is
+	// taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+	ProxyURL string
+	// TLS are the CAs and the client certificate of the https and wss endpoints
+	TLS configtypes.TLSClientConfig
+}
+
+// RPCHeaderCacheOptions are the options of the cache of the block headers shared by all the syncers
+// connected to the same RPC endpoint. The zero value disables the cache
+type RPCHeaderCacheOptions struct {
+	// Size is the maximum number of headers cached by number and by hash, 0 disables the cache
+	Size int
+	// TTL is the time a header requested by number is cached, because the block can be reorged.
+	// 0 means that the headers are only cached by hash
+	TTL time.Duration
+}
+
+// Validate checks that the header cache options are consistent
+func (o RPCHeaderCacheOptions) Validate() error {
+	if o.Size < 0 {
+		return fmt.Errorf("rpc header cache: Size can't be negative (%d)", o.Size)
+	}
+	if o.TTL < 0 {
+		return fmt.Errorf("rpc header cache: TTL can't be negative (%s)", o.TTL.String())
+	}
+	return nil
+}
+
+// RPCRateLimitOptions are the limits of the requests sent to a RPC endpoint, to stay inside the
+// quotas of the provider. The zero value doesn't limit the requests
+type RPCRateLimitOptions struct {
+	// RequestsPerSecond is the maximum rate of requests, 0 means no rate limit
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests sent at once when the rate limit allows it. If it's 0
+	// and RequestsPerSecond is set, it's 1
+	Burst int
+	// MaxConcurrentRequests is the maximum number of in-flight requests, 0 means no limit
+	MaxConcurrentRequests int
+}
+
+// Validate checks that the rate limit options are consistent
+func (o RPCRateLimitOptions) Validate() error {
+	if o.RequestsPerSecond < 0 {
+		return fmt.Errorf("rpc rate limit: RequestsPerSecond can't be negative (%f)", o.RequestsPerSecond)
+	}
+	if o.Burst < 0 {
+		return fmt.Errorf("rpc rate limit: Burst can't be negative (%d)", o.Burst)
+	}
+	if o.MaxConcurrentRequests < 0 {
+		return fmt.Errorf("rpc rate limit: MaxConcurrentRequests can't be negative (%d)", o.MaxConcurrentRequests)
+	}
+	return nil
+}
+
+// Validate checks that the options are consistent
+func (o RPCClientOptions) Validate() error {
+	if o.BearerToken != "" && (o.BasicAuthUser != "" || o.BasicAuthPassword != "") {
+		return fmt.Errorf("rpc client options: BearerToken and BasicAuth can't be used at the same time")
+	}
+	if o.BasicAuthUser == "" && o.BasicAuthPassword != "" {
+		return fmt.Errorf("rpc client options: BasicAuthPassword is set but BasicAuthUser is empty")
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("rpc client options: Timeout can't be negative (%s)", o.Timeout.String())
+	}
+	if o.ProxyURL != "" {
+		if _, err := configtypes.ParseProxyURL(o.ProxyURL); err != nil {
+			return fmt.Errorf("rpc client options: %w", err)
+		}
+	}
+	if err := o.TLS.Validate(); err != nil {
+		return fmt.Errorf("rpc client options: %w", err)
+	}
+	if err := o.RateLimit.Validate(); err != nil {
+		return err
+	}
+	return o.HeaderCache.Validate()
+}
+
+// ClientOptions returns the go-ethereum rpc client options. It fails if the TLS files can't be loaded
+func (o RPCClientOptions) ClientOptions() ([]rpc.ClientOption, error) {
+	var opts []rpc.ClientOption
+	if len(o.Headers) > 0 {
+		headers := make(http.Header, len(o.Headers))
+		for k, v := range o.Headers {
+			headers.Set(k, v)
+		}
+		opts = append(opts, rpc.WithHeaders(headers))
+	}
+	if authorization := o.authorizationHeader(); authorization != "" {
+		opts = append(opts, rpc.WithHTTPAuth(func(h http.Header) error {
+			h.Set("Authorization", authorization)
+			return nil
+		}))
+	}
+	if o.ProxyURL == "" && o.TLS.IsEmpty() {
+		if o.Timeout > 0 {
+			opts = append(opts, rpc.WithHTTPClient(&http.Client{Timeout: o.Timeout}))
+		}
+		return opts, nil
+	}
+
+	proxy, err := configtypes.ProxyFunc(o.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	var tlsConfig *tls.Config
+	if !o.TLS.IsEmpty() {
+		if tlsConfig, err = o.TLS.Load(); err != nil {
+			return nil, err
+		}
+	}
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("rpc client options: unexpected default HTTP transport %T", http.DefaultTransport)
+	}
+	transport = transport.Clone()
+	transport.Proxy = proxy
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	opts = append(opts,
+		rpc.WithHTTPClient(&http.Client{Timeout: o.Timeout, Transport: transport}),
+		rpc.WithWebsocketDialer(websocket.Dialer{
+			Proxy:            proxy,
+			TLSClientConfig:  tlsConfig,
+			HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+		}),
+	)
+	return opts, nil
+}
+
+func (o RPCClientOptions) authorizationHeader() string {
+	switch {
+	case o.BearerToken != "":
+		return "Bearer " + o.BearerToken
+	case o.BasicAuthUser != "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(o.BasicAuthUser + ":" + o.BasicAuthPassword))
+		return "Basic " + credentials
+	default:
+		return ""
+	}
+}
+
+// DialEthClient connects to the RPC endpoint using the given options
+func DialEthClient(ctx context.Context, url string, opts RPCClientOptions) (*DefaultEthClient, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	clientOpts, err := opts.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	rpcClient, err := rpc.DialOptions(ctx, url, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	ethClient := ethclient.NewClient(rpcClient)
+	return NewDefaultEthClient(ethClient, rpcClient), nil
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configtypes "github.com/agglayer/aggkit/config/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRPCClientOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          RPCClientOptions
+		expectedError string
+	}{
+		{name: "empty", opts: RPCClientOptions{}},
+		{name: "bearer", opts: RPCClientOptions{BearerToken: "token"}},
+		{name: "basic auth", opts: RPCClientOptions{BasicAuthUser: "user", BasicAuthPassword: "pass"}},
+		{
+			name:          "bearer and basic auth",
+			opts:          RPCClientOptions{BearerToken: "token", BasicAuthUser: "user"},
+			expectedError: "can't be used at the same time",
+		},
+		{
+			name:          "password without user",
+			opts:          RPCClientOptions{BasicAuthPassword: "pass"},
+			expectedError: "BasicAuthUser is empty",
+		},
+		{
+			name:          "negative timeout",
+			opts:          RPCClientOptions{Timeout: -time.Second},
+			expectedError: "Timeout can't be negative",
+		},
+		{
+			name: "proxy and TLS",
+			opts: RPCClientOptions{ProxyURL: "http://proxy:3128", TLS: configtypes.TLSClientConfig{CACertFile: "ca.pem"}},
+		},
+		{
+			name:          "invalid proxy URL",
+			opts:          RPCClientOptions{ProxyURL: "socks5://proxy:1080"},
+			expectedError: "invalid proxy URL",
+		},
+		{
+			name:          "TLS client certificate without key",
+			opts:          RPCClientOptions{TLS: configtypes.TLSClientConfig{CertFile: "client.pem"}},
+			expectedError: "CertFile and KeyFile must be set together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDialEthClientSendsHeaders(t *testing.T) {
+	tests := []struct {
+		name                  string
+		opts                  RPCClientOptions
+		expectedAuthorization string
+	}{
+		{
+			name:                  "bearer token",
+			opts:                  RPCClientOptions{Headers: map[string]string{"x-api-key": "key"}, BearerToken: "token"},
+			expectedAuthorization: "Bearer token",
+		},
+		{
+			name: "basic auth",
+			opts: RPCClientOptions{
+				Headers:           map[string]string{"x-api-key": "key"},
+				BasicAuthUser:     "user",
+				BasicAuthPassword: "pass",
+				Timeout:           time.Second,
+			},
+			expectedAuthorization: "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedHeaders http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedHeaders = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+			}))
+			defer server.Close()
+
+			client, err := DialEthClient(context.Background(), server.URL, tt.opts)
+			require.NoError(t, err)
+			blockNumber, err := client.BlockNumber(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, uint64(16), blockNumber)
+			require.Equal(t, "key", receivedHeaders.Get("X-Api-Key"))
+			require.Equal(t, tt.expectedAuthorization, receivedHeaders.Get("Authorization"))
+		})
+	}
+}
+
+func TestDialEthClientProxyAndTLS(t *testing.T) {
+	respond := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}
+
+	t.Run("proxy", func(t *testing.T) {
+		var proxiedHost string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the requests sent to a HTTP proxy have the absolute URL of the endpoint
+			proxiedHost = r.URL.Host
+			respond(w)
+		}))
+		defer proxy.Close()
+
+		client, err := DialEthClient(context.Background(), "http://rpc.example.com:8545",
+			RPCClientOptions{ProxyURL: proxy.URL})
+		require.NoError(t, err)
+		blockNumber, err := client.BlockNumber(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(16), blockNumber)
+		require.Equal(t, "rpc.example.com:8545", proxiedHost)
+	})
+
+	t.Run("custom CA bundle", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respond(w)
+		}))
+		defer server.Close()
+		caFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caFile,
+			pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+		// without the CA the certificate of the server is not trusted
+		client, err := DialEthClient(context.Background(), server.URL, RPCClientOptions{Timeout: time.Second})
+		require.NoError(t, err)
+		_, err = client.BlockNumber(context.Background())
+		require.ErrorContains(t, err, "certificate")
+
+		client, err = DialEthClient(context.Background(), server.URL,
+			RPCClientOptions{TLS: configtypes.TLSClientConfig{CACertFile: caFile}})
+		require.NoError(t, err)
+		blockNumber, err := client.BlockNumber(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(16), blockNumber)
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		_, err := DialEthClient(context.Background(), "https://rpc.example.com",
+			RPCClientOptions{TLS: configtypes.TLSClientConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}})
+		require.ErrorContains(t, err, "failed to read CACertFile")
+	})
+}
+
+func TestDialEthClientInvalidOptions(t *testing.T) {
+	_, err := DialEthClient(context.Background(), "http://localhost:1234",
+		RPCClientOptions{BearerToken: "token", BasicAuthUser: "user"})
+	require.Error(t, err)
+}